@@ -1,6 +1,8 @@
 package aggregation
 
 import (
+	"sort"
+
 	"github.com/lindb/lindb/aggregation/fields"
 	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/pkg/collections"
@@ -131,14 +133,76 @@ func (e *expression) eval(parentFunc *stmt.CallExpr, expr stmt.Expr) []collectio
 
 // funcCall calls the function
 func (e *expression) funcCall(expr *stmt.CallExpr) []collections.FloatArray {
+	if expr.FuncType == function.Histogram || expr.FuncType == function.Quantile {
+		return e.quantileCall(expr)
+	}
+	parentFunc := expr
+	if expr.FuncType.IsTransform() {
+		// field param of transform function uses default values, like rate(f)
+		parentFunc = nil
+	}
 	var params []collections.FloatArray
 	for _, param := range expr.Params {
-		paramValues := e.eval(expr, param)
+		paramValues := e.eval(parentFunc, param)
 		if len(paramValues) == 0 {
 			return nil
 		}
 		params = append(params, paramValues...)
 	}
+	var result collections.FloatArray
+	if expr.FuncType.IsTransform() {
+		result = function.TransformCall(expr.FuncType, e.interval, params...)
+	} else {
+		result = function.FuncCall(expr.FuncType, params...)
+	}
+	if result == nil {
+		return nil
+	}
+	return []collections.FloatArray{result}
+}
+
+// quantileCall calculates the quantile of histogram field, like quantile(latency, 0.99),
+// finds all bucket fields of histogram field, then passes quantile/upper bounds/bucket values to function.
+func (e *expression) quantileCall(expr *stmt.CallExpr) []collections.FloatArray {
+	if len(expr.Params) != 2 {
+		return nil
+	}
+	fieldExpr, ok := expr.Params[0].(*stmt.FieldExpr)
+	if !ok {
+		return nil
+	}
+	quantile := e.eval(expr, expr.Params[1])
+	if len(quantile) != 1 {
+		return nil
+	}
+	type bucket struct {
+		upperBound float64
+		values     collections.FloatArray
+	}
+	var buckets []bucket
+	for fieldName, fieldValues := range e.fieldStore {
+		histogramName, upperBound, ok := field.ParseHistogramBucketName(fieldName)
+		if !ok || histogramName != fieldExpr.Name {
+			continue
+		}
+		values := fieldValues.GetValues(expr.FuncType)
+		if len(values) == 0 {
+			continue
+		}
+		buckets = append(buckets, bucket{upperBound: upperBound, values: values[0]})
+	}
+	if len(buckets) == 0 {
+		return nil
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].upperBound < buckets[j].upperBound
+	})
+	upperBounds := collections.NewFloatArray(len(buckets))
+	params := []collections.FloatArray{quantile[0], upperBounds}
+	for idx, b := range buckets {
+		upperBounds.SetValue(idx, b.upperBound)
+		params = append(params, b.values)
+	}
 	result := function.FuncCall(expr.FuncType, params...)
 	if result == nil {
 		return nil
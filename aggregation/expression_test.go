@@ -295,6 +295,89 @@ func TestExpression_FuncCall_Sum(t *testing.T) {
 	assert.Equal(t, 0, len(resultSet))
 }
 
+func TestExpression_FuncCall_Quantile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bucket1 := mockTimeSeries(ctrl, familyTime, "latency__bucket_1", field.HistogramField, field.Sum)
+	bucket2 := mockTimeSeries(ctrl, familyTime, "latency__bucket_+Inf", field.HistogramField, field.Sum)
+	other := mockTimeSeries(ctrl, familyTime, "f1", field.SumField, field.Sum)
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+
+	q, _ := sql.Parse("select quantile(latency, 0.25) as p25, histogram(f1, 0.5), quantile(latency) from cpu")
+	query := q.(*stmt.Query)
+	expression := NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + timeutil.OneHour*2,
+	}, timeutil.OneMinute, query.SelectItems)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(bucket2),
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(other),
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(bucket1),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet := expression.ResultSet()
+	// histogram(f1, 0.5) and quantile(latency) returns nil
+	assert.Equal(t, 1, len(resultSet))
+
+	value := resultSet["p25"]
+	assert.Equal(t, 1, value.Size())
+	assert.Equal(t, 0.5, value.GetValue(50-10))
+
+	// param isn't field
+	expression = NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + timeutil.OneHour*2,
+	}, timeutil.OneMinute, []stmt.Expr{&stmt.SelectItem{Expr: &stmt.CallExpr{
+		FuncType: function.Quantile,
+		Params:   []stmt.Expr{&stmt.NumberLiteral{Val: 1}, &stmt.NumberLiteral{Val: 1}},
+	}}})
+	series1 := mockTimeSeries(ctrl, familyTime, "f2", field.SumField, field.Sum)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(series1),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet = expression.ResultSet()
+	assert.Equal(t, 0, len(resultSet))
+}
+
+func TestExpression_FuncCall_Transform(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	series1 := mockTimeSeries(ctrl, familyTime, "f1", field.SumField, field.Sum)
+	timeSeries := series.NewMockGroupedIterator(ctrl)
+
+	q, _ := sql.Parse("select abs(f1), top(sum(f1), 1), moving_average(f1, 0), abs(f2) from cpu")
+	query := q.(*stmt.Query)
+	expression := NewExpression(timeutil.TimeRange{
+		Start: now,
+		End:   now + timeutil.OneHour*2,
+	}, timeutil.OneMinute, query.SelectItems)
+	gomock.InOrder(
+		timeSeries.EXPECT().HasNext().Return(true),
+		timeSeries.EXPECT().Next().Return(series1),
+		timeSeries.EXPECT().HasNext().Return(false),
+	)
+	expression.Eval(timeSeries)
+	resultSet := expression.ResultSet()
+	// moving_average(f1, 0) and abs(f2) returns nil
+	assert.Equal(t, 2, len(resultSet))
+
+	value := resultSet["abs(f1)"]
+	assert.Equal(t, 1, value.Size())
+	assert.Equal(t, 50.0, value.GetValue(50-10))
+	value = resultSet["top(sum(f1),1.00)"]
+	assert.Equal(t, 1, value.Size())
+	assert.Equal(t, 50.0, value.GetValue(50-10))
+}
+
 func TestExpression_NotSupport_Expr(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,38 @@
+package aggregation
+
+import (
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// FillValues fills the missing points of values based on the fill policy of query,
+// 1. no fill/fill(null), keeps the missing points absent
+// 2. fill(previous), fills the missing points with the previous value, the leading missing points keep absent
+// 3. fill(number), fills all missing points with the given number
+func FillValues(values collections.FloatArray, fill stmt.FillPolicy, fillValue float64) {
+	if values == nil || values.IsEmpty() {
+		return
+	}
+	capacity := values.Capacity()
+	switch fill {
+	case stmt.PreviousFill:
+		hasPrevious := false
+		previous := 0.0
+		for i := 0; i < capacity; i++ {
+			if values.HasValue(i) {
+				hasPrevious = true
+				previous = values.GetValue(i)
+				continue
+			}
+			if hasPrevious {
+				values.SetValue(i, previous)
+			}
+		}
+	case stmt.NumberFill:
+		for i := 0; i < capacity; i++ {
+			if !values.HasValue(i) {
+				values.SetValue(i, fillValue)
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package aggregation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/collections"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestFillValues(t *testing.T) {
+	// nil/empty values
+	FillValues(nil, stmt.NumberFill, 1)
+	values := collections.NewFloatArray(5)
+	FillValues(values, stmt.NumberFill, 1)
+	assert.True(t, values.IsEmpty())
+
+	newValues := func() collections.FloatArray {
+		values := collections.NewFloatArray(5)
+		values.SetValue(1, 10)
+		values.SetValue(3, 30)
+		return values
+	}
+	// no fill
+	values = newValues()
+	FillValues(values, stmt.NoFill, 1)
+	assert.Equal(t, 2, values.Size())
+	// null fill
+	values = newValues()
+	FillValues(values, stmt.NullFill, 1)
+	assert.Equal(t, 2, values.Size())
+	// previous fill
+	values = newValues()
+	FillValues(values, stmt.PreviousFill, 1)
+	assert.False(t, values.HasValue(0))
+	assert.Equal(t, 10.0, values.GetValue(2))
+	assert.Equal(t, 30.0, values.GetValue(4))
+	assert.Equal(t, 4, values.Size())
+	// number fill
+	values = newValues()
+	FillValues(values, stmt.NumberFill, 1)
+	assert.Equal(t, 1.0, values.GetValue(0))
+	assert.Equal(t, 10.0, values.GetValue(1))
+	assert.Equal(t, 1.0, values.GetValue(2))
+	assert.Equal(t, 30.0, values.GetValue(3))
+	assert.Equal(t, 1.0, values.GetValue(4))
+	assert.Equal(t, 5, values.Size())
+}
@@ -27,6 +27,12 @@ func FuncCall(funcType FuncType, params ...collections.FloatArray) collections.F
 			}
 		}
 		return result
+	case Histogram, Quantile:
+		// params: 0=>quantile, 1=>upper bounds of buckets, 2...=>bucket values(order by upper bound)
+		if len(params) < 3 {
+			return nil
+		}
+		return quantileCall(params[0], params[1], params[2:])
 	default:
 		return nil
 	}
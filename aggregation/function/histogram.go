@@ -0,0 +1,81 @@
+package function
+
+import (
+	"math"
+
+	"github.com/lindb/lindb/pkg/collections"
+)
+
+// quantileCall calculates the φ-quantile(0 ≤ φ ≤ 1) from the buckets of histogram,
+// upper bounds of buckets must be in ascending order and the last one is +Inf,
+// bucket values are the count of each bucket(not cumulative).
+// the quantile is calculated by linear interpolation within the bucket which the quantile falls into.
+func quantileCall(quantile, upperBounds collections.FloatArray, buckets []collections.FloatArray) collections.FloatArray {
+	if quantile == nil || upperBounds == nil || upperBounds.Size() != len(buckets) {
+		return nil
+	}
+	q := quantile.GetValue(0)
+	if q < 0 || q > 1 || math.IsNaN(q) {
+		return nil
+	}
+	bounds := make([]float64, len(buckets))
+	for idx := range bounds {
+		bounds[idx] = upperBounds.GetValue(idx)
+	}
+	capacity := buckets[0].Capacity()
+	result := collections.NewFloatArray(capacity)
+	counts := make([]float64, len(buckets))
+	for pos := 0; pos < capacity; pos++ {
+		hasValue := false
+		for idx, bucket := range buckets {
+			counts[idx] = 0
+			if bucket != nil && bucket.HasValue(pos) {
+				counts[idx] = bucket.GetValue(pos)
+				hasValue = true
+			}
+		}
+		if !hasValue {
+			continue
+		}
+		if value, ok := bucketQuantile(q, bounds, counts); ok {
+			result.SetValue(pos, value)
+		}
+	}
+	return result
+}
+
+// bucketQuantile calculates the quantile from one time slot's buckets, returns false if histogram is empty.
+func bucketQuantile(q float64, upperBounds, counts []float64) (float64, bool) {
+	total := 0.0
+	for _, count := range counts {
+		total += count
+	}
+	if total <= 0 {
+		return 0, false
+	}
+	rank := q * total
+	cumulative := 0.0
+	for idx, count := range counts {
+		if cumulative+count < rank || count <= 0 {
+			cumulative += count
+			continue
+		}
+		upperBound := upperBounds[idx]
+		switch {
+		case idx == len(counts)-1:
+			// quantile falls into +Inf bucket, returns the upper bound of the last explicit bucket
+			if idx == 0 {
+				return 0, false
+			}
+			return upperBounds[idx-1], true
+		case idx == 0 && upperBound <= 0:
+			return upperBound, true
+		}
+		lowerBound := 0.0
+		if idx > 0 {
+			lowerBound = upperBounds[idx-1]
+		}
+		return lowerBound + (upperBound-lowerBound)*(rank-cumulative)/count, true
+	}
+	return 0, false
+}
@@ -0,0 +1,64 @@
+package function
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/collections"
+)
+
+func TestFuncCall_Quantile(t *testing.T) {
+	quantile := collections.NewFloatArray(10)
+	quantile.SetValue(0, 0.5)
+	upperBounds := collections.NewFloatArray(3)
+	upperBounds.SetValue(0, 1)
+	upperBounds.SetValue(1, 2)
+	upperBounds.SetValue(2, math.Inf(1))
+	bucket1 := collections.NewFloatArray(10)
+	bucket2 := collections.NewFloatArray(10)
+	bucket3 := collections.NewFloatArray(10)
+	// slot 0: [3, 1, 0], quantile falls into first bucket
+	bucket1.SetValue(0, 3)
+	bucket2.SetValue(0, 1)
+	bucket3.SetValue(0, 0)
+	// slot 1: [1, 2, 1], quantile falls into second bucket
+	bucket1.SetValue(1, 1)
+	bucket2.SetValue(1, 2)
+	bucket3.SetValue(1, 1)
+	// slot 2: [0, 0, 4], quantile falls into +Inf bucket
+	bucket3.SetValue(2, 4)
+	// slot 3: empty histogram
+	bucket1.SetValue(3, 0)
+
+	result := FuncCall(Quantile, quantile, upperBounds, bucket1, bucket2, bucket3)
+	assert.InDelta(t, 2.0/3.0, result.GetValue(0), 0.00001)
+	assert.InDelta(t, 1.5, result.GetValue(1), 0.00001)
+	assert.Equal(t, 2.0, result.GetValue(2))
+	assert.False(t, result.HasValue(3))
+	assert.False(t, result.HasValue(4))
+	assert.Equal(t, result.Size(), FuncCall(Histogram, quantile, upperBounds, bucket1, bucket2, bucket3).Size())
+
+	// case: params not enough
+	assert.Nil(t, FuncCall(Quantile, quantile, upperBounds))
+	// case: bounds not match buckets
+	assert.Nil(t, FuncCall(Quantile, quantile, upperBounds, bucket1))
+	// case: invalid quantile
+	quantile.SetValue(0, 1.5)
+	assert.Nil(t, FuncCall(Quantile, quantile, upperBounds, bucket1, bucket2, bucket3))
+}
+
+func TestBucketQuantile(t *testing.T) {
+	// only +Inf bucket
+	_, ok := bucketQuantile(0.5, []float64{math.Inf(1)}, []float64{10})
+	assert.False(t, ok)
+	// negative upper bound of first bucket
+	value, ok := bucketQuantile(0.5, []float64{-1, math.Inf(1)}, []float64{10, 0})
+	assert.True(t, ok)
+	assert.Equal(t, -1.0, value)
+	// skip empty bucket for 0-quantile
+	value, ok = bucketQuantile(0, []float64{1, 2, math.Inf(1)}, []float64{0, 2, 0})
+	assert.True(t, ok)
+	assert.Equal(t, 1.0, value)
+}
@@ -0,0 +1,131 @@
+package function
+
+import (
+	"math"
+
+	"github.com/lindb/lindb/pkg/collections"
+)
+
+// TransformCall calls the transform function by function type and params,
+// params: 0=>values of series, 1=>argument of function(like window size of moving average),
+// interval is the interval(ms) of time slot, which is used by the functions based on time.
+func TransformCall(funcType FuncType, interval int64, params ...collections.FloatArray) collections.FloatArray {
+	if len(params) == 0 || params[0] == nil || interval <= 0 {
+		return nil
+	}
+	values := params[0]
+	switch funcType {
+	case Rate:
+		return rateCall(values, interval, true, true)
+	case IRate:
+		return rateCall(values, interval, true, false)
+	case Derivative:
+		return rateCall(values, interval, false, true)
+	case Abs:
+		return mapCall(values, math.Abs)
+	case Log:
+		return mapCall(values, math.Log)
+	}
+	// the functions below need argument
+	if len(params) < 2 || params[1] == nil || !params[1].HasValue(0) {
+		return nil
+	}
+	arg := params[1].GetValue(0)
+	switch funcType {
+	case MovingAverage:
+		return movingAverageCall(values, int(arg))
+	case TimeShift:
+		// argument is seconds
+		return timeShiftCall(values, int64(arg*1000)/interval)
+	case Top, Bottom:
+		// keeps the values of series, the series are selected across the result set by broker
+		if arg <= 0 {
+			return nil
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// rateCall calculates the per-second change between each point and its previous point,
+// counter reset(value decreases) is handled if counter is true, the increase is the value after reset.
+// if spanGaps is false, the point without value in previous time slot has no result(irate).
+func rateCall(values collections.FloatArray, interval int64, counter, spanGaps bool) collections.FloatArray {
+	result := collections.NewFloatArray(values.Capacity())
+	prevIdx := -1
+	prevValue := 0.0
+	it := values.Iterator()
+	for it.HasNext() {
+		idx, value := it.Next()
+		if prevIdx >= 0 && (spanGaps || idx-prevIdx == 1) {
+			delta := value - prevValue
+			if counter && delta < 0 {
+				delta = value
+			}
+			seconds := float64(int64(idx-prevIdx)*interval) / 1000
+			result.SetValue(idx, delta/seconds)
+		}
+		prevIdx = idx
+		prevValue = value
+	}
+	return result
+}
+
+// mapCall applies the function on each point, drops the point whose result is NaN or Inf
+func mapCall(values collections.FloatArray, fn func(float64) float64) collections.FloatArray {
+	result := collections.NewFloatArray(values.Capacity())
+	it := values.Iterator()
+	for it.HasNext() {
+		idx, value := it.Next()
+		value = fn(value)
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		result.SetValue(idx, value)
+	}
+	return result
+}
+
+// movingAverageCall calculates the average of points within the window which ends at each point,
+// window is the number of time slots.
+func movingAverageCall(values collections.FloatArray, window int) collections.FloatArray {
+	if window <= 0 {
+		return nil
+	}
+	result := collections.NewFloatArray(values.Capacity())
+	var (
+		slots []int
+		sum   float64
+	)
+	it := values.Iterator()
+	for it.HasNext() {
+		idx, value := it.Next()
+		// remove the points out of window
+		for len(slots) > 0 && slots[0] <= idx-window {
+			sum -= values.GetValue(slots[0])
+			slots = slots[1:]
+		}
+		slots = append(slots, idx)
+		sum += value
+		result.SetValue(idx, sum/float64(len(slots)))
+	}
+	return result
+}
+
+// timeShiftCall moves the points forward by the number of time slots(backward if negative),
+// the points out of time range are dropped.
+func timeShiftCall(values collections.FloatArray, slots int64) collections.FloatArray {
+	capacity := values.Capacity()
+	result := collections.NewFloatArray(capacity)
+	it := values.Iterator()
+	for it.HasNext() {
+		idx, value := it.Next()
+		pos := int64(idx) + slots
+		if pos < 0 || pos >= int64(capacity) {
+			continue
+		}
+		result.SetValue(int(pos), value)
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package function
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/collections"
+)
+
+// newValues creates float array, NaN means no value
+func newValues(values ...float64) collections.FloatArray {
+	array := collections.NewFloatArray(len(values))
+	for idx, value := range values {
+		if !math.IsNaN(value) {
+			array.SetValue(idx, value)
+		}
+	}
+	return array
+}
+
+// newArg creates the argument of function
+func newArg(value float64) collections.FloatArray {
+	array := collections.NewFloatArray(1)
+	array.SetValue(0, value)
+	return array
+}
+
+// toMap converts float array to map of slot => value
+func toMap(array collections.FloatArray) map[int]float64 {
+	result := make(map[int]float64)
+	it := array.Iterator()
+	for it.HasNext() {
+		idx, value := it.Next()
+		result[idx] = value
+	}
+	return result
+}
+
+func TestTransformCall_bad_params(t *testing.T) {
+	assert.Nil(t, TransformCall(Rate, 10000))
+	assert.Nil(t, TransformCall(Rate, 10000, nil))
+	assert.Nil(t, TransformCall(Rate, 0, newValues(1)))
+	assert.Nil(t, TransformCall(MovingAverage, 10000, newValues(1)))
+	assert.Nil(t, TransformCall(MovingAverage, 10000, newValues(1), collections.NewFloatArray(1)))
+	assert.Nil(t, TransformCall(MovingAverage, 10000, newValues(1), newArg(0)))
+	assert.Nil(t, TransformCall(Top, 10000, newValues(1), newArg(-1)))
+	assert.Nil(t, TransformCall(Sum, 10000, newValues(1), newArg(1)))
+}
+
+func TestTransformCall_Rate(t *testing.T) {
+	nan := math.NaN()
+	// counter reset at slot 4, gap at slot 2
+	values := newValues(10, 30, nan, 70, 5, 25)
+	assert.Equal(t, map[int]float64{1: 2, 3: 2, 4: 0.5, 5: 2}, toMap(TransformCall(Rate, 10000, values)))
+	// irate doesn't span gaps
+	assert.Equal(t, map[int]float64{1: 2, 4: 0.5, 5: 2}, toMap(TransformCall(IRate, 10000, values)))
+	// derivative keeps negative change
+	assert.Equal(t, map[int]float64{1: 2, 3: 2, 4: -6.5, 5: 2}, toMap(TransformCall(Derivative, 10000, values)))
+}
+
+func TestTransformCall_Math(t *testing.T) {
+	values := newValues(-2, 0, 1)
+	assert.Equal(t, map[int]float64{0: 2, 1: 0, 2: 1}, toMap(TransformCall(Abs, 10000, values)))
+	// log of non-positive value is dropped
+	assert.Equal(t, map[int]float64{2: 0}, toMap(TransformCall(Log, 10000, values)))
+}
+
+func TestTransformCall_MovingAverage(t *testing.T) {
+	nan := math.NaN()
+	values := newValues(1, 3, nan, 5, 7)
+	assert.Equal(t, map[int]float64{0: 1, 1: 2, 3: 5, 4: 6}, toMap(TransformCall(MovingAverage, 10000, values, newArg(2))))
+	assert.Equal(t, map[int]float64{0: 1, 1: 2, 3: 4, 4: 6}, toMap(TransformCall(MovingAverage, 10000, values, newArg(3))))
+}
+
+func TestTransformCall_TimeShift(t *testing.T) {
+	values := newValues(1, 2, 3)
+	assert.Equal(t, map[int]float64{1: 1, 2: 2}, toMap(TransformCall(TimeShift, 10000, values, newArg(10))))
+	assert.Equal(t, map[int]float64{0: 3}, toMap(TransformCall(TimeShift, 10000, values, newArg(-20))))
+}
+
+func TestTransformCall_Selector(t *testing.T) {
+	values := newValues(3, 1, 4, 1, 5)
+	assert.Equal(t, toMap(values), toMap(TransformCall(Top, 10000, values, newArg(2))))
+	assert.Equal(t, toMap(values), toMap(TransformCall(Bottom, 10000, values, newArg(2))))
+}
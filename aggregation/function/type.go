@@ -12,6 +12,16 @@ const (
 	Replace
 	Histogram
 	Stddev
+	Quantile
+	Rate
+	IRate
+	Derivative
+	MovingAverage
+	Abs
+	Log
+	TimeShift
+	Top
+	Bottom
 
 	Unknown
 )
@@ -35,7 +45,44 @@ func (t FuncType) String() string {
 		return "histogram"
 	case Stddev:
 		return "stddev"
+	case Quantile:
+		return "quantile"
+	case Rate:
+		return "rate"
+	case IRate:
+		return "irate"
+	case Derivative:
+		return "derivative"
+	case MovingAverage:
+		return "moving_average"
+	case Abs:
+		return "abs"
+	case Log:
+		return "log"
+	case TimeShift:
+		return "time_shift"
+	case Top:
+		return "top"
+	case Bottom:
+		return "bottom"
 	default:
 		return "unknown"
 	}
 }
+
+// IsTransform returns if the function transforms the values of its first param(like rate, abs),
+// the field param of transform function is evaluated with field's default aggregation.
+func (t FuncType) IsTransform() bool {
+	switch t {
+	case Rate, IRate, Derivative, MovingAverage, Abs, Log, TimeShift, Top, Bottom:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsSelector returns if the function selects the time series across the result set(like top, bottom),
+// instead of the points of one time series.
+func (t FuncType) IsSelector() bool {
+	return t == Top || t == Bottom
+}
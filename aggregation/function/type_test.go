@@ -15,5 +15,28 @@ func TestFuncTypeString(t *testing.T) {
 	assert.Equal(t, "replace", Replace.String())
 	assert.Equal(t, "histogram", Histogram.String())
 	assert.Equal(t, "stddev", Stddev.String())
+	assert.Equal(t, "quantile", Quantile.String())
+	assert.Equal(t, "rate", Rate.String())
+	assert.Equal(t, "irate", IRate.String())
+	assert.Equal(t, "derivative", Derivative.String())
+	assert.Equal(t, "moving_average", MovingAverage.String())
+	assert.Equal(t, "abs", Abs.String())
+	assert.Equal(t, "log", Log.String())
+	assert.Equal(t, "time_shift", TimeShift.String())
+	assert.Equal(t, "top", Top.String())
+	assert.Equal(t, "bottom", Bottom.String())
 	assert.Equal(t, "unknown", Unknown.String())
 }
+
+func TestFuncType_IsTransform(t *testing.T) {
+	assert.True(t, Rate.IsTransform())
+	assert.True(t, Bottom.IsTransform())
+	assert.False(t, Sum.IsTransform())
+	assert.False(t, Quantile.IsTransform())
+}
+
+func TestFuncType_IsSelector(t *testing.T) {
+	assert.True(t, Top.IsSelector())
+	assert.True(t, Bottom.IsSelector())
+	assert.False(t, Rate.IsSelector())
+}
@@ -3,6 +3,7 @@ package aggregation
 import (
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
 )
 
 //go:generate mockgen -source=./group_agg.go -destination=./group_agg_mock.go -package=aggregation
@@ -53,6 +54,11 @@ func (ga *groupingAggregator) Aggregate(it series.GroupedIterator) {
 				break
 			}
 		}
+		if sAgg == nil {
+			// histogram field is stored as bucket fields, creates aggregator for bucket field lazily
+			sAgg = ga.getHistogramBucketAggregator(tags, fieldName)
+			seriesAgg = ga.aggregates[tags]
+		}
 		if sAgg == nil {
 			continue
 		}
@@ -98,3 +104,20 @@ func (ga *groupingAggregator) getAggregator(tags string) (agg FieldAggregates) {
 	}
 	return
 }
+
+// getHistogramBucketAggregator creates the aggregator for histogram bucket field if query the histogram field,
+// returns nil if field isn't a bucket field of query's histogram field.
+func (ga *groupingAggregator) getHistogramBucketAggregator(tags string, fieldName field.Name) SeriesAggregator {
+	histogramName, _, ok := field.ParseHistogramBucketName(fieldName)
+	if !ok {
+		return nil
+	}
+	for _, aggSpec := range ga.aggSpecs {
+		if string(aggSpec.FieldName()) == histogramName {
+			sAgg := NewSeriesAggregator(ga.interval, 1, ga.timeRange, false, NewAggregatorSpec(fieldName))
+			ga.aggregates[tags] = append(ga.aggregates[tags], sAgg)
+			return sAgg
+		}
+	}
+	return nil
+}
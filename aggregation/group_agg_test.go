@@ -1,5 +1,62 @@
 package aggregation
 
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestGroupingAggregator_Aggregate_HistogramBucket(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	gIt := series.NewMockGroupedIterator(ctrl)
+	sIt := series.NewMockIterator(ctrl)
+
+	agg := NewGroupingAggregator(
+		timeutil.Interval(timeutil.OneSecond),
+		timeutil.TimeRange{
+			Start: now,
+			End:   now + 3*timeutil.OneHour,
+		},
+		AggregatorSpecs{NewAggregatorSpec("latency")})
+	gomock.InOrder(
+		gIt.EXPECT().Tags().Return("1.1.1.1"),
+		// bucket of query histogram field
+		gIt.EXPECT().HasNext().Return(true),
+		gIt.EXPECT().Next().Return(sIt),
+		sIt.EXPECT().FieldName().Return(field.Name("latency__bucket_0.5")),
+		sIt.EXPECT().FieldType().Return(field.HistogramField),
+		sIt.EXPECT().HasNext().Return(false),
+		// same bucket found
+		gIt.EXPECT().HasNext().Return(true),
+		gIt.EXPECT().Next().Return(sIt),
+		sIt.EXPECT().FieldName().Return(field.Name("latency__bucket_0.5")),
+		sIt.EXPECT().FieldType().Return(field.HistogramField),
+		sIt.EXPECT().HasNext().Return(false),
+		// bucket of other histogram field
+		gIt.EXPECT().HasNext().Return(true),
+		gIt.EXPECT().Next().Return(sIt),
+		sIt.EXPECT().FieldName().Return(field.Name("size__bucket_0.5")),
+		sIt.EXPECT().FieldType().Return(field.HistogramField),
+		// not bucket field
+		gIt.EXPECT().HasNext().Return(true),
+		gIt.EXPECT().Next().Return(sIt),
+		sIt.EXPECT().FieldName().Return(field.Name("f")),
+		sIt.EXPECT().FieldType().Return(field.SumField),
+		gIt.EXPECT().HasNext().Return(false),
+	)
+	agg.Aggregate(gIt)
+	fieldAgg := agg.(*groupingAggregator).aggregates["1.1.1.1"]
+	assert.Len(t, fieldAgg, 2)
+	assert.Equal(t, field.Name("latency__bucket_0.5"), fieldAgg[1].FieldName())
+	assert.Equal(t, field.HistogramField, fieldAgg[1].GetFieldType())
+}
+
 //TODO need impl
 //func TestGroupByAggregator_Aggregate(t *testing.T) {
 //	ctrl := gomock.NewController(t)
@@ -52,6 +52,25 @@ func (d *DatabaseAPI) Save(w http.ResponseWriter, r *http.Request) {
 	api.NoContent(w)
 }
 
+// Delete deletes the database config by the name,
+// master will drop the database from related storage cluster after config deleted.
+func (d *DatabaseAPI) Delete(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("name", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if _, err := d.databaseService.Get(databaseName); err != nil {
+		api.NotFound(w)
+		return
+	}
+	if err := d.databaseService.Delete(databaseName); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
 // List returns all database configs
 func (d *DatabaseAPI) List(w http.ResponseWriter, r *http.Request) {
 	dbs, err := d.databaseService.List()
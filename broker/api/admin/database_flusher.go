@@ -1,7 +1,6 @@
 package admin
 
 import (
-	"fmt"
 	"net/http"
 
 	"github.com/lindb/lindb/broker/api"
@@ -10,7 +9,6 @@ import (
 )
 
 var adminLogger = logger.GetLogger("broker", "adminAPI")
-var httpGet = http.Get
 
 // DatabaseFlusherAPI represents the memory database flush by manual
 type DatabaseFlusherAPI struct {
@@ -34,32 +32,15 @@ func (df *DatabaseFlusherAPI) SubmitFlushTask(w http.ResponseWriter, r *http.Req
 		api.Error(w, err)
 		return
 	}
-	if df.master.IsMaster() {
-		// if current node is master, submits the flush task
-		if err := df.master.FlushDatabase(cluster, databaseName); err != nil {
-			api.Error(w, err)
-			return
-		}
-	} else {
+	if !df.master.IsMaster() {
 		// if current node is not master, need forward to master node
-		masterNode := df.master.GetMaster().Node
-		resp, err := httpGet(fmt.Sprintf("http://%s:%d"+r.RequestURI, masterNode.IP, masterNode.Port))
-		if resp != nil {
-			if resp.Body != nil {
-				if err := resp.Body.Close(); err != nil {
-					adminLogger.Error("close http response body", logger.Error(err))
-				}
-			}
-
-			if resp.StatusCode != http.StatusOK {
-				api.Error(w, fmt.Errorf("master handle error after forward"))
-				return
-			}
-		}
-		if err != nil {
-			api.Error(w, err)
-			return
-		}
+		forwardToMaster(w, r, df.master)
+		return
+	}
+	// if current node is master, submits the flush task
+	if err := df.master.FlushDatabase(cluster, databaseName); err != nil {
+		api.Error(w, err)
+		return
 	}
 	api.OK(w, "success")
 }
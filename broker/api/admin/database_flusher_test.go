@@ -1,15 +1,19 @@
 package admin
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/coordinator"
 	"github.com/lindb/lindb/mock"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
 )
 
 type mockIOReader struct {
@@ -66,18 +70,15 @@ func TestNewDatabaseFlusherAPI(t *testing.T) {
 	})
 
 	defer func() {
-		httpGet = http.Get
+		httpDo = http.DefaultClient.Do
 	}()
 
 	// forward master
-	master.EXPECT().IsMaster().Return(false)
+	master.EXPECT().IsMaster().Return(false).AnyTimes()
 	master.EXPECT().GetMaster().Return(&models.Master{
-		Node: models.Node{
-			IP:   "127.0.0.1",
-			Port: 12345,
-		},
-	})
-	httpGet = func(url string) (resp *http.Response, err error) {
+		Node: models.Node{IP: "127.0.0.1", Port: 12345},
+	}).AnyTimes()
+	httpDo = func(req *http.Request) (*http.Response, error) {
 		return nil, fmt.Errorf("err")
 	}
 	mock.DoRequest(t, &mock.HTTPHandler{
@@ -86,59 +87,24 @@ func TestNewDatabaseFlusherAPI(t *testing.T) {
 		HandlerFunc:    flushAPI.SubmitFlushTask,
 		ExpectHTTPCode: http.StatusInternalServerError,
 	})
-
-	httpGet = func(url string) (resp *http.Response, err error) {
-		return nil, nil
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusInternalServerError}, nil
 	}
-	master.EXPECT().IsMaster().Return(false)
-	master.EXPECT().GetMaster().Return(&models.Master{
-		Node: models.Node{
-			IP:   "127.0.0.1",
-			Port: 12345,
-		},
-	})
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodGet,
-		URL:            "/database/flush/ok?cluster=test&db=test",
-		HandlerFunc:    flushAPI.SubmitFlushTask,
-		ExpectHTTPCode: http.StatusOK,
-	})
-	httpGet = func(url string) (resp *http.Response, err error) {
-		return &http.Response{
-			StatusCode: http.StatusInternalServerError,
-		}, nil
-	}
-	master.EXPECT().IsMaster().Return(false)
-	master.EXPECT().GetMaster().Return(&models.Master{
-		Node: models.Node{
-			IP:   "127.0.0.1",
-			Port: 12346,
-		},
-	})
-	mock.DoRequest(t, &mock.HTTPHandler{
-		Method:         http.MethodGet,
-		URL:            "/database/flush/ok?cluster=test&db=test",
+		URL:            "/database/flush?cluster=test&db=test",
 		HandlerFunc:    flushAPI.SubmitFlushTask,
 		ExpectHTTPCode: http.StatusInternalServerError,
 	})
-
-	httpGet = func(url string) (resp *http.Response, err error) {
-		return &http.Response{
-			StatusCode: http.StatusOK,
-			Body:       &mockIOReader{},
-		}, nil
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, "http://127.0.0.1:12345/database/flush?cluster=test&db=test", req.URL.String())
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(encoding.JSONMarshal("success")))}, nil
 	}
-	master.EXPECT().IsMaster().Return(false)
-	master.EXPECT().GetMaster().Return(&models.Master{
-		Node: models.Node{
-			IP:   "127.0.0.1",
-			Port: 12346,
-		},
-	})
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodGet,
-		URL:            "/database/flush/ok?cluster=test&db=test",
+		URL:            "/database/flush?cluster=test&db=test",
 		HandlerFunc:    flushAPI.SubmitFlushTask,
 		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: "success",
 	})
 }
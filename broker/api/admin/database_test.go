@@ -26,7 +26,12 @@ func TestDatabaseAPI(t *testing.T) {
 		Cluster:       "test",
 		NumOfShard:    12,
 		ReplicaFactor: 3,
-		Option:        option.DatabaseOption{Interval: "10s"},
+		Option: option.DatabaseOption{
+			Interval:  "10s",
+			TTL:       "7d",
+			Rollup:    []string{"5m"},
+			RollupTTL: []string{"30d"},
+		},
 	}
 
 	// get request error
@@ -99,3 +104,44 @@ func TestDatabaseAPI(t *testing.T) {
 		ExpectResponse: []*models.Database{&db},
 	})
 }
+
+func TestDatabaseAPI_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	databaseService := service.NewMockDatabaseService(ctrl)
+
+	api := NewDatabaseAPI(databaseService)
+	// no database name
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/database",
+		HandlerFunc:    api.Delete,
+		ExpectHTTPCode: 500,
+	})
+	// database not exist
+	databaseService.EXPECT().Get("test").Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/database?name=test",
+		HandlerFunc:    api.Delete,
+		ExpectHTTPCode: 404,
+	})
+	databaseService.EXPECT().Get("test").Return(&models.Database{Name: "test"}, nil).AnyTimes()
+	// delete err
+	databaseService.EXPECT().Delete("test").Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/database?name=test",
+		HandlerFunc:    api.Delete,
+		ExpectHTTPCode: 500,
+	})
+	// delete success
+	databaseService.EXPECT().Delete("test").Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/database?name=test",
+		HandlerFunc:    api.Delete,
+		ExpectHTTPCode: 204,
+	})
+}
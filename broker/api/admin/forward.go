@@ -0,0 +1,59 @@
+package admin
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+// for testing
+var (
+	httpDo = http.DefaultClient.Do
+)
+
+// forwardToMaster forwards the request to master node if current node isn't master,
+// copies the method, headers(such as authorization) and body of request, then responds the response of master.
+func forwardToMaster(w http.ResponseWriter, r *http.Request, master coordinator.Master) {
+	masterNode := master.GetMaster().Node
+	var body io.Reader
+	switch {
+	case len(r.PostForm) > 0:
+		// body is consumed when parsing the params of form, forwards the parsed form
+		body = strings.NewReader(r.PostForm.Encode())
+	case r.Body != nil:
+		body = r.Body
+	}
+	req, err := http.NewRequest(r.Method, fmt.Sprintf("http://%s:%d%s", masterNode.IP, masterNode.Port, r.RequestURI), body)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	req.Header = r.Header.Clone()
+	resp, err := httpDo(req)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	var content []byte
+	if resp.Body != nil {
+		content, err = ioutil.ReadAll(resp.Body)
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			adminLogger.Error("close http response body", logger.Error(closeErr))
+		}
+		if err != nil {
+			api.Error(w, err)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(resp.StatusCode)
+	if len(content) > 0 {
+		_, _ = w.Write(content)
+	}
+}
@@ -0,0 +1,112 @@
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/models"
+)
+
+func TestForwardToMaster(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: models.Node{IP: "127.0.0.1", Port: 12345},
+	}).AnyTimes()
+	newRequest := func(method, target string, body string) *http.Request {
+		r := httptest.NewRequest(method, target, strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer token")
+		return r
+	}
+
+	// forward err
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("err")
+	}
+	w := httptest.NewRecorder()
+	forwardToMaster(w, newRequest(http.MethodGet, "/database/flush?db=test", ""), master)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// read response err
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: &mockIOReader{}}, nil
+	}
+	w = httptest.NewRecorder()
+	forwardToMaster(w, newRequest(http.MethodGet, "/database/flush?db=test", ""), master)
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+
+	// master responds err status
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusUnauthorized}, nil
+	}
+	w = httptest.NewRecorder()
+	forwardToMaster(w, newRequest(http.MethodGet, "/database/flush?db=test", ""), master)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	// forward method, headers and body
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		assert.Equal(t, "http://127.0.0.1:12345/storage/cluster/node/decommission?cluster=test", req.URL.String())
+		assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+		body, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, `{"node":"1.1.1.1:2080"}`, string(body))
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader([]byte(`"success"`)))}, nil
+	}
+	w = httptest.NewRecorder()
+	forwardToMaster(w, newRequest(http.MethodPost, "/storage/cluster/node/decommission?cluster=test", `{"node":"1.1.1.1:2080"}`), master)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"success"`, w.Body.String())
+	assert.Equal(t, "application/json; charset=utf-8", w.Header().Get("Content-Type"))
+
+	// forward parsed form
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		body, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "cluster=test", string(body))
+		assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+		return &http.Response{StatusCode: http.StatusOK, Body: &mockIOReaderCloseErr{data: []byte(`"success"`)}}, nil
+	}
+	r := newRequest(http.MethodPost, "/database/flush", url.Values{"cluster": []string{"test"}}.Encode())
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.NoError(t, r.ParseForm())
+	w = httptest.NewRecorder()
+	forwardToMaster(w, r, master)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, `"success"`, w.Body.String())
+}
+
+// mockIOReaderCloseErr reads the data successfully, but returns err when closing
+type mockIOReaderCloseErr struct {
+	data []byte
+	pos  int
+}
+
+func (m *mockIOReaderCloseErr) Close() error {
+	return fmt.Errorf("err")
+}
+
+func (m *mockIOReaderCloseErr) Read(p []byte) (n int, err error) {
+	if m.pos >= len(m.data) {
+		return 0, io.EOF
+	}
+	n = copy(p, m.data[m.pos:])
+	m.pos += n
+	return n, nil
+}
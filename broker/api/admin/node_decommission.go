@@ -0,0 +1,50 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/models"
+)
+
+// NodeDecommissionAPI represents the storage node decommission by manual
+type NodeDecommissionAPI struct {
+	master coordinator.Master
+}
+
+// NewNodeDecommissionAPI creates node decommission api
+func NewNodeDecommissionAPI(master coordinator.Master) *NodeDecommissionAPI {
+	return &NodeDecommissionAPI{master: master}
+}
+
+// Decommission submits the job which moves all the replicas of storage node to other active nodes,
+// like: /storage/cluster/node/decommission?cluster=test&node=1.1.1.1:2080
+func (nd *NodeDecommissionAPI) Decommission(w http.ResponseWriter, r *http.Request) {
+	cluster, err := api.GetParamsFromRequest("cluster", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	nodeIndicator, err := api.GetParamsFromRequest("node", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	node, err := models.ParseNode(nodeIndicator)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if !nd.master.IsMaster() {
+		// if current node is not master, need forward to master node
+		forwardToMaster(w, r, nd.master)
+		return
+	}
+	// if current node is master, moves the replicas of node
+	if err := nd.master.DecommissionNode(cluster, *node); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, "success")
+}
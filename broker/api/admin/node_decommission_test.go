@@ -0,0 +1,104 @@
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+func TestNodeDecommissionAPI_Decommission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	decommissionAPI := NewNodeDecommissionAPI(master)
+	decommissionURL := "/storage/cluster/node/decommission?cluster=test&node=1.1.1.1:2080"
+	node := models.Node{IP: "1.1.1.1", Port: 2080}
+
+	// no cluster name
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/storage/cluster/node/decommission",
+		HandlerFunc:    decommissionAPI.Decommission,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// no node
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/storage/cluster/node/decommission?cluster=test",
+		HandlerFunc:    decommissionAPI.Decommission,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// parse node err
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/storage/cluster/node/decommission?cluster=test&node=1.1.1.1",
+		HandlerFunc:    decommissionAPI.Decommission,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// decommission err
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().DecommissionNode("test", node).Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            decommissionURL,
+		HandlerFunc:    decommissionAPI.Decommission,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// decommission ok
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().DecommissionNode("test", node).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            decommissionURL,
+		HandlerFunc:    decommissionAPI.Decommission,
+		ExpectHTTPCode: http.StatusOK,
+	})
+
+	// forward master
+	master.EXPECT().IsMaster().Return(false).AnyTimes()
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: models.Node{IP: "127.0.0.1", Port: 12345},
+	}).AnyTimes()
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("err")
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            decommissionURL,
+		HandlerFunc:    decommissionAPI.Decommission,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusInternalServerError}, nil
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            decommissionURL,
+		HandlerFunc:    decommissionAPI.Decommission,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodPost, req.Method)
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(encoding.JSONMarshal("success")))}, nil
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            decommissionURL,
+		HandlerFunc:    decommissionAPI.Decommission,
+		ExpectHTTPCode: http.StatusOK,
+	})
+}
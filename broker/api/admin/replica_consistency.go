@@ -0,0 +1,39 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/coordinator"
+)
+
+// ReplicaConsistencyAPI represents the query of data consistency between the replicas of storage cluster
+type ReplicaConsistencyAPI struct {
+	master coordinator.Master
+}
+
+// NewReplicaConsistencyAPI creates replica consistency api
+func NewReplicaConsistencyAPI(master coordinator.Master) *ReplicaConsistencyAPI {
+	return &ReplicaConsistencyAPI{master: master}
+}
+
+// GetDivergences returns the divergent data families between replicas found by master in latest checking,
+// like: /storage/cluster/consistency?cluster=test
+func (rc *ReplicaConsistencyAPI) GetDivergences(w http.ResponseWriter, r *http.Request) {
+	cluster, err := api.GetParamsFromRequest("cluster", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if !rc.master.IsMaster() {
+		// if current node is not master, need forward to master node
+		forwardToMaster(w, r, rc.master)
+		return
+	}
+	divergences, err := rc.master.GetReplicaDivergences(cluster)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, divergences)
+}
@@ -0,0 +1,98 @@
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+func TestReplicaConsistencyAPI_GetDivergences(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	consistencyAPI := NewReplicaConsistencyAPI(master)
+	consistencyURL := "/storage/cluster/consistency?cluster=test"
+	divergences := []models.ReplicaDivergence{{Database: "db", ShardID: 1, FamilyTime: 10, Source: "1.1.1.1:2080"}}
+
+	// no cluster name
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/storage/cluster/consistency",
+		HandlerFunc:    consistencyAPI.GetDivergences,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// get divergences err
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().GetReplicaDivergences("test").Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            consistencyURL,
+		HandlerFunc:    consistencyAPI.GetDivergences,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// get divergences ok
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().GetReplicaDivergences("test").Return(divergences, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            consistencyURL,
+		HandlerFunc:    consistencyAPI.GetDivergences,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: divergences,
+	})
+
+	// forward master
+	master.EXPECT().IsMaster().Return(false).AnyTimes()
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: models.Node{IP: "127.0.0.1", Port: 12345},
+	}).AnyTimes()
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("err")
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            consistencyURL,
+		HandlerFunc:    consistencyAPI.GetDivergences,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// master handle err
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       ioutil.NopCloser(bytes.NewReader(nil)),
+		}, nil
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            consistencyURL,
+		HandlerFunc:    consistencyAPI.GetDivergences,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// forward ok
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       ioutil.NopCloser(bytes.NewReader(encoding.JSONMarshal(divergences))),
+		}, nil
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            consistencyURL,
+		HandlerFunc:    consistencyAPI.GetDivergences,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: divergences,
+	})
+}
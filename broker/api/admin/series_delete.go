@@ -0,0 +1,57 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// SeriesDeleteAPI represents the series delete by delete statement
+type SeriesDeleteAPI struct {
+	master coordinator.Master
+}
+
+// NewSeriesDeleteAPI creates series delete api
+func NewSeriesDeleteAPI(master coordinator.Master) *SeriesDeleteAPI {
+	return &SeriesDeleteAPI{master: master}
+}
+
+// DeleteSeries submits the task which tombstones the series of metric matched delete statement,
+// like: delete from cpu where host='1.1.1.1', or drops the metric by drop metric statement, like: drop metric cpu
+func (sd *SeriesDeleteAPI) DeleteSeries(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	deleteSQL, err := api.GetParamsFromRequest("sql", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	statement, err := sql.Parse(deleteSQL)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	deleteStmt, ok := statement.(*stmt.Delete)
+	if !ok {
+		api.Error(w, fmt.Errorf("not delete statement"))
+		return
+	}
+	if !sd.master.IsMaster() {
+		// if current node is not master, need forward to master node
+		forwardToMaster(w, r, sd.master)
+		return
+	}
+	// if current node is master, submits the delete series task
+	if err := sd.master.DeleteSeries(databaseName, deleteStmt); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, "success")
+}
@@ -0,0 +1,116 @@
+package admin
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/coordinator"
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+func TestSeriesDeleteAPI_DeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		httpDo = http.DefaultClient.Do
+		ctrl.Finish()
+	}()
+
+	master := coordinator.NewMockMaster(ctrl)
+	deleteAPI := NewSeriesDeleteAPI(master)
+	deleteURL := "/series?db=test&sql=" + url.QueryEscape("delete from cpu where host='1.1.1.1'")
+
+	// no database name
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/series",
+		HandlerFunc:    deleteAPI.DeleteSeries,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// no sql
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/series?db=test",
+		HandlerFunc:    deleteAPI.DeleteSeries,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// parse sql err
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/series?db=test&sql=" + url.QueryEscape("delete cpu"),
+		HandlerFunc:    deleteAPI.DeleteSeries,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// not delete statement
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/series?db=test&sql=" + url.QueryEscape("select f from cpu"),
+		HandlerFunc:    deleteAPI.DeleteSeries,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// submit err
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().DeleteSeries("test", gomock.Any()).Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            deleteURL,
+		HandlerFunc:    deleteAPI.DeleteSeries,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// submit ok
+	master.EXPECT().IsMaster().Return(true)
+	master.EXPECT().DeleteSeries("test", gomock.Any()).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            deleteURL,
+		HandlerFunc:    deleteAPI.DeleteSeries,
+		ExpectHTTPCode: http.StatusOK,
+	})
+
+	// forward master
+	master.EXPECT().IsMaster().Return(false).AnyTimes()
+	master.EXPECT().GetMaster().Return(&models.Master{
+		Node: models.Node{IP: "127.0.0.1", Port: 12345},
+	}).AnyTimes()
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return nil, fmt.Errorf("err")
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            deleteURL,
+		HandlerFunc:    deleteAPI.DeleteSeries,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusInternalServerError}, nil
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            deleteURL,
+		HandlerFunc:    deleteAPI.DeleteSeries,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	httpDo = func(req *http.Request) (*http.Response, error) {
+		assert.Equal(t, http.MethodDelete, req.Method)
+		assert.Equal(t, "Bearer token", req.Header.Get("Authorization"))
+		body, err := ioutil.ReadAll(req.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, "body", string(body))
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader(encoding.JSONMarshal("success")))}, nil
+	}
+	r := httptest.NewRequest(http.MethodDelete, deleteURL, strings.NewReader("body"))
+	r.Header.Set("Authorization", "Bearer token")
+	w := httptest.NewRecorder()
+	deleteAPI.DeleteSeries(w, r)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,173 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/service"
+)
+
+// UserAPI represents user/role/api key admin rest api
+type UserAPI struct {
+	userService service.UserService
+}
+
+// NewUserAPI creates user api instance
+func NewUserAPI(userService service.UserService) *UserAPI {
+	return &UserAPI{
+		userService: userService,
+	}
+}
+
+// SaveUser creates the user if not exist, otherwise updates the user,
+// the password of exist user is kept if password is empty.
+func (u *UserAPI) SaveUser(w http.ResponseWriter, r *http.Request) {
+	user := &models.User{}
+	if err := api.GetJSONBodyFromRequest(r, user); err != nil {
+		api.Error(w, err)
+		return
+	}
+	// super user only can be configured by the admin user of config
+	user.SuperUser = false
+	if err := u.userService.SaveUser(user); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
+// GetUser gets the user by name without password
+func (u *UserAPI) GetUser(w http.ResponseWriter, r *http.Request) {
+	name, err := api.GetParamsFromRequest("name", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	user, err := u.userService.GetUser(name)
+	if err != nil {
+		api.NotFound(w)
+		return
+	}
+	user.PasswordHash = ""
+	api.OK(w, user)
+}
+
+// DeleteUser deletes the user by name
+func (u *UserAPI) DeleteUser(w http.ResponseWriter, r *http.Request) {
+	name, err := api.GetParamsFromRequest("name", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := u.userService.DeleteUser(name); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
+// ListUsers returns all users without password
+func (u *UserAPI) ListUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := u.userService.ListUsers()
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	for _, user := range users {
+		user.PasswordHash = ""
+	}
+	api.OK(w, users)
+}
+
+// SaveRole creates the role if not exist, otherwise updates the role
+func (u *UserAPI) SaveRole(w http.ResponseWriter, r *http.Request) {
+	role := &models.Role{}
+	if err := api.GetJSONBodyFromRequest(r, role); err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := u.userService.SaveRole(role); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
+// GetRole gets the role by name
+func (u *UserAPI) GetRole(w http.ResponseWriter, r *http.Request) {
+	name, err := api.GetParamsFromRequest("name", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	role, err := u.userService.GetRole(name)
+	if err != nil {
+		api.NotFound(w)
+		return
+	}
+	api.OK(w, role)
+}
+
+// DeleteRole deletes the role by name
+func (u *UserAPI) DeleteRole(w http.ResponseWriter, r *http.Request) {
+	name, err := api.GetParamsFromRequest("name", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := u.userService.DeleteRole(name); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
+// ListRoles returns all roles
+func (u *UserAPI) ListRoles(w http.ResponseWriter, r *http.Request) {
+	roles, err := u.userService.ListRoles()
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, roles)
+}
+
+// CreateAPIKey creates the api key for user, the plaintext key only responses once
+func (u *UserAPI) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	userName, err := api.GetParamsFromRequest("user", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	apiKey, err := u.userService.CreateAPIKey(userName)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, apiKey)
+}
+
+// DeleteAPIKey deletes the api key by id
+func (u *UserAPI) DeleteAPIKey(w http.ResponseWriter, r *http.Request) {
+	id, err := api.GetParamsFromRequest("id", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if err := u.userService.DeleteAPIKey(id); err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.NoContent(w)
+}
+
+// ListAPIKeys returns all api keys without plaintext key
+func (u *UserAPI) ListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	apiKeys, err := u.userService.ListAPIKeys()
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, apiKeys)
+}
@@ -0,0 +1,277 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/service"
+)
+
+func TestUserAPI_User(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userService := service.NewMockUserService(ctrl)
+	api := NewUserAPI(userService)
+
+	// get request error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/user",
+		RequestBody:    []byte{1, 3, 4},
+		HandlerFunc:    api.SaveUser,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// save success, super user cannot be saved
+	userService.EXPECT().SaveUser(&models.User{Name: "test", Password: "123"}).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/user",
+		RequestBody:    models.User{Name: "test", Password: "123", SuperUser: true},
+		HandlerFunc:    api.SaveUser,
+		ExpectHTTPCode: http.StatusNoContent,
+	})
+	// save err
+	userService.EXPECT().SaveUser(gomock.Any()).Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/user",
+		RequestBody:    models.User{Name: "test"},
+		HandlerFunc:    api.SaveUser,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+
+	// get user
+	userService.EXPECT().GetUser("test").Return(&models.User{Name: "test", PasswordHash: "hash"}, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/user?name=test",
+		HandlerFunc:    api.GetUser,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: models.User{Name: "test"},
+	})
+	userService.EXPECT().GetUser("test").Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/user?name=test",
+		HandlerFunc:    api.GetUser,
+		ExpectHTTPCode: http.StatusNotFound,
+	})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/user",
+		HandlerFunc:    api.GetUser,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+
+	// delete user
+	userService.EXPECT().DeleteUser("test").Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/user?name=test",
+		HandlerFunc:    api.DeleteUser,
+		ExpectHTTPCode: http.StatusNoContent,
+	})
+	userService.EXPECT().DeleteUser("test").Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/user?name=test",
+		HandlerFunc:    api.DeleteUser,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/user",
+		HandlerFunc:    api.DeleteUser,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+
+	// list users
+	userService.EXPECT().ListUsers().Return([]*models.User{{Name: "test", PasswordHash: "hash"}}, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/user/list",
+		HandlerFunc:    api.ListUsers,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: []models.User{{Name: "test"}},
+	})
+	userService.EXPECT().ListUsers().Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/user/list",
+		HandlerFunc:    api.ListUsers,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+}
+
+func TestUserAPI_Role(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userService := service.NewMockUserService(ctrl)
+	api := NewUserAPI(userService)
+
+	role := models.Role{Name: "dev", Privileges: []models.Privilege{{Database: "db", Permission: models.ReadPermission}}}
+	// save role
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/role",
+		RequestBody:    []byte{1, 3, 4},
+		HandlerFunc:    api.SaveRole,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	userService.EXPECT().SaveRole(&role).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/role",
+		RequestBody:    role,
+		HandlerFunc:    api.SaveRole,
+		ExpectHTTPCode: http.StatusNoContent,
+	})
+	userService.EXPECT().SaveRole(gomock.Any()).Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/role",
+		RequestBody:    role,
+		HandlerFunc:    api.SaveRole,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+
+	// get role
+	userService.EXPECT().GetRole("dev").Return(&role, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/role?name=dev",
+		HandlerFunc:    api.GetRole,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: role,
+	})
+	userService.EXPECT().GetRole("dev").Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/role?name=dev",
+		HandlerFunc:    api.GetRole,
+		ExpectHTTPCode: http.StatusNotFound,
+	})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/role",
+		HandlerFunc:    api.GetRole,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+
+	// delete role
+	userService.EXPECT().DeleteRole("dev").Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/role?name=dev",
+		HandlerFunc:    api.DeleteRole,
+		ExpectHTTPCode: http.StatusNoContent,
+	})
+	userService.EXPECT().DeleteRole("dev").Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/role?name=dev",
+		HandlerFunc:    api.DeleteRole,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/role",
+		HandlerFunc:    api.DeleteRole,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+
+	// list roles
+	userService.EXPECT().ListRoles().Return([]*models.Role{&role}, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/role/list",
+		HandlerFunc:    api.ListRoles,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: []models.Role{role},
+	})
+	userService.EXPECT().ListRoles().Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/role/list",
+		HandlerFunc:    api.ListRoles,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+}
+
+func TestUserAPI_APIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	userService := service.NewMockUserService(ctrl)
+	api := NewUserAPI(userService)
+
+	apiKey := models.APIKey{ID: "id", UserName: "test", Key: "key"}
+	// create api key
+	userService.EXPECT().CreateAPIKey("test").Return(&apiKey, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/apikey?user=test",
+		HandlerFunc:    api.CreateAPIKey,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: apiKey,
+	})
+	userService.EXPECT().CreateAPIKey("test").Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/apikey?user=test",
+		HandlerFunc:    api.CreateAPIKey,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/apikey",
+		HandlerFunc:    api.CreateAPIKey,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+
+	// delete api key
+	userService.EXPECT().DeleteAPIKey("id").Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/apikey?id=id",
+		HandlerFunc:    api.DeleteAPIKey,
+		ExpectHTTPCode: http.StatusNoContent,
+	})
+	userService.EXPECT().DeleteAPIKey("id").Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/apikey?id=id",
+		HandlerFunc:    api.DeleteAPIKey,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodDelete,
+		URL:            "/apikey",
+		HandlerFunc:    api.DeleteAPIKey,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+
+	// list api keys
+	userService.EXPECT().ListAPIKeys().Return([]*models.APIKey{{ID: "id", UserName: "test"}}, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/apikey/list",
+		HandlerFunc:    api.ListAPIKeys,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: []models.APIKey{{ID: "id", UserName: "test"}},
+	})
+	userService.EXPECT().ListAPIKeys().Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/apikey/list",
+		HandlerFunc:    api.ListAPIKeys,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+}
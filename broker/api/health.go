@@ -0,0 +1,9 @@
+package api
+
+import "net/http"
+
+// HealthCheck responses ok if broker's http server is alive,
+// it is not protected by authentication, so no any internal state responses.
+func HealthCheck(w http.ResponseWriter, r *http.Request) {
+	OK(w, "ok")
+}
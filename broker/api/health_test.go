@@ -0,0 +1,17 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthCheck(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "/health", nil)
+	resp := httptest.NewRecorder()
+	HealthCheck(resp, req)
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, `"ok"`, resp.Body.String())
+}
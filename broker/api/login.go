@@ -6,21 +6,24 @@ import (
 	"github.com/lindb/lindb/broker/middleware"
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/service"
 )
 
 var log = logger.GetLogger("broker", "api")
 
 // LoginAPI represents login param
 type LoginAPI struct {
-	user config.User
-	auth middleware.Authentication
+	user        config.User
+	auth        middleware.Authentication
+	userService service.UserService
 }
 
 // NewLoginAPI creates login api instance
-func NewLoginAPI(user config.User, auth middleware.Authentication) *LoginAPI {
+func NewLoginAPI(user config.User, auth middleware.Authentication, userService service.UserService) *LoginAPI {
 	return &LoginAPI{
-		user: user,
-		auth: auth,
+		user:        user,
+		auth:        auth,
+		userService: userService,
 	}
 }
 
@@ -48,19 +51,21 @@ func (l *LoginAPI) Login(w http.ResponseWriter, r *http.Request) {
 		OK(w, "")
 		return
 	}
-	// user name is error
-	if l.user.UserName != user.UserName {
-		log.Error("username is invalid")
+	// admin user of config
+	if l.user.UserName == user.UserName {
+		// password is error
+		if l.user.Password != user.Password {
+			log.Error("password is invalid")
+			OK(w, "")
+			return
+		}
+	} else if _, err := l.userService.Authenticate(user.UserName, user.Password); err != nil {
+		// user name or password is error
+		log.Error("authenticate user failure", logger.String("user", user.UserName), logger.Error(err))
 		OK(w, "")
 		return
 	}
-	// password is error
-	if l.user.Password != user.Password {
-		log.Error("password is invalid")
-		OK(w, "")
-		return
-	}
-	token, err := l.auth.CreateToken(user)
+	token, err := l.auth.CreateToken(user.UserName)
 	if err != nil {
 		OK(w, "")
 		return
@@ -68,8 +73,15 @@ func (l *LoginAPI) Login(w http.ResponseWriter, r *http.Request) {
 	OK(w, token)
 }
 
-// Check responses use msg
+// Check responses the authenticated user of request
 // this method use for test
 func (l *LoginAPI) Check(w http.ResponseWriter, r *http.Request) {
-	OK(w, l.user)
+	user := middleware.UserFromContext(r.Context())
+	if user == nil {
+		NotFound(w)
+		return
+	}
+	result := *user
+	result.PasswordHash = ""
+	OK(w, &result)
 }
@@ -3,15 +3,19 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
-	jwt "github.com/dgrijalva/jwt-go"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/broker/middleware"
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/service"
 )
 
 var tokenStr = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJ1c2VybmFtZSI6ImFkbWluIiwicGFzc3dvc" +
@@ -22,9 +26,10 @@ func TestLogin(t *testing.T) {
 	defer ctrl.Finish()
 
 	auth := middleware.NewMockAuthentication(ctrl)
+	userService := service.NewMockUserService(ctrl)
 
 	user := config.User{UserName: "admin", Password: "admin123"}
-	api := NewLoginAPI(user, auth)
+	api := NewLoginAPI(user, auth, userService)
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodPost,
 		URL:            "/user",
@@ -66,6 +71,7 @@ func TestLogin(t *testing.T) {
 	})
 
 	//user failure error user name
+	userService.EXPECT().Authenticate("123", "admin123").Return(nil, service.ErrUserNameOrPasswordInvalid)
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodPost,
 		URL:            "/user",
@@ -75,6 +81,18 @@ func TestLogin(t *testing.T) {
 		ExpectResponse: "",
 	})
 
+	// login by the user of state's repo
+	userService.EXPECT().Authenticate("dev", "dev123").Return(&models.User{Name: "dev"}, nil)
+	auth.EXPECT().CreateToken("dev").Return(tokenStr, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/user",
+		RequestBody:    config.User{UserName: "dev", Password: "dev123"},
+		HandlerFunc:    api.Login,
+		ExpectHTTPCode: 200,
+		ExpectResponse: tokenStr,
+	})
+
 	//user failure error password
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodPost,
@@ -105,31 +123,26 @@ func TestLogin(t *testing.T) {
 		ExpectResponse: "",
 	})
 
+	// not authenticated
 	mock.DoRequest(t, &mock.HTTPHandler{
 		Method:         http.MethodPost,
 		URL:            "/check",
 		HandlerFunc:    api.Check,
-		ExpectHTTPCode: 200,
-		ExpectResponse: user,
+		ExpectHTTPCode: 404,
 	})
 }
 
-func Test_JWT(t *testing.T) {
-	user := config.User{UserName: "admin", Password: "admin123"}
-	claims := middleware.CustomClaims{
-		UserName: user.UserName,
-		Password: user.Password,
-	}
-	cid := middleware.Md5Encrypt(user)
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
-	tokenString, _ := token.SignedString([]byte(cid))
-
-	assert.Equal(t, tokenStr, tokenString)
-
-	mapClaims := middleware.CustomClaims{}
-	_, _ = jwt.ParseWithClaims(tokenString, &mapClaims, func(token *jwt.Token) (i interface{}, e error) {
-		return cid, nil
-	})
-	assert.Equal(t, user.Password, mapClaims.Password)
-	assert.Equal(t, user.UserName, mapClaims.UserName)
+func TestLoginAPI_Check(t *testing.T) {
+	api := NewLoginAPI(config.User{}, nil, nil)
+	auth := middleware.NewAuthentication(config.User{UserName: "admin", Password: "admin123",
+		TokenExpire: ltoml.Duration(time.Minute)}, nil)
+	token, err := auth.CreateToken("admin")
+	assert.NoError(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, "/check/1", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	auth.Validate(http.HandlerFunc(api.Check)).ServeHTTP(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, `{"name":"admin","superUser":true}`, rr.Body.String())
 }
@@ -2,16 +2,27 @@ package query
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/series"
 )
 
+// errInvalidCursor represents the cursor of pagination is invalid
+var errInvalidCursor = errors.New("invalid cursor")
+
 // MetricAPI represents the metric query api
 type MetricAPI struct {
 	replicaStateMachine  replica.StatusStateMachine
@@ -19,10 +30,11 @@ type MetricAPI struct {
 	databaseStateMachine database.DBStateMachine
 	executorFactory      parallel.ExecutorFactory
 	jobManager           parallel.JobManager
+	timeout              time.Duration
 }
 
 // NewMetricAPI creates the metric query api
-func NewMetricAPI(replicaStateMachine replica.StatusStateMachine,
+func NewMetricAPI(cfg config.Query, replicaStateMachine replica.StatusStateMachine,
 	nodeStateMachine broker.NodeStateMachine, databaseStateMachine database.DBStateMachine,
 	executorFactory parallel.ExecutorFactory, jobManager parallel.JobManager) *MetricAPI {
 	return &MetricAPI{
@@ -31,10 +43,15 @@ func NewMetricAPI(replicaStateMachine replica.StatusStateMachine,
 		databaseStateMachine: databaseStateMachine,
 		executorFactory:      executorFactory,
 		jobManager:           jobManager,
+		timeout:              cfg.Timeout.Duration(),
 	}
 }
 
-// Search searches the metric data based on database and sql.
+// Search searches the metric data based on database and sql,
+// 1) stream=true, writes each time series as ndjson line when the result arrives
+// 2) limit/cursor, returns the time series of page, the cursor of next page is in result set
+// 3) replicaPolicy, chooses the replica of shards by given policy instead of the policy of database
+// the query is canceled when timeout or client disconnects.
 func (m *MetricAPI) Search(w http.ResponseWriter, r *http.Request) {
 	db, err := api.GetParamsFromRequest("db", r, "", true)
 	if err != nil {
@@ -46,22 +63,47 @@ func (m *MetricAPI) Search(w http.ResponseWriter, r *http.Request) {
 		api.Error(w, err)
 		return
 	}
-	//FIXME add timeout cfg
-	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	stream, _ := api.GetParamsFromRequest("stream", r, "false", false)
+	limitStr, _ := api.GetParamsFromRequest("limit", r, "0", false)
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	cursor, _ := api.GetParamsFromRequest("cursor", r, "", false)
+	offset, err := decodeCursor(cursor)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	replicaPolicy, _ := api.GetParamsFromRequest("replicaPolicy", r, "", false)
+	ctx, cancel := context.WithTimeout(r.Context(), m.timeout)
 	defer cancel()
+	if replicaPolicy != "" {
+		policy, err := option.ParseReplicaSelectPolicy(replicaPolicy)
+		if err != nil {
+			api.Error(w, err)
+			return
+		}
+		ctx = replica.WithSelectPolicy(ctx, policy)
+	}
 
 	exec := m.executorFactory.NewBrokerExecutor(ctx, db, sql,
 		m.replicaStateMachine, m.nodeStateMachine, m.databaseStateMachine,
 		m.jobManager)
 	exec.Execute()
 
-	brokerExecutor := exec.(parallel.BrokerExecutor)
-	exeCtx := brokerExecutor.ExecuteContext()
-
-	//FIXME timeout logic use select
-	resultCh := exeCtx.ResultCh()
-	for result := range resultCh {
-		exeCtx.Emit(result)
+	exeCtx := exec.ExecuteContext()
+	if stream == "true" {
+		m.stream(ctx, w, exeCtx)
+		return
+	}
+	if err := waitResult(ctx, exeCtx, func(event *series.TimeSeriesEvent) error {
+		exeCtx.Emit(event)
+		return nil
+	}); err != nil {
+		api.Error(w, err)
+		return
 	}
 
 	resultSet, err := exeCtx.ResultSet()
@@ -69,5 +111,91 @@ func (m *MetricAPI) Search(w http.ResponseWriter, r *http.Request) {
 		api.Error(w, err)
 		return
 	}
+	if limit > 0 && resultSet.Paginate(offset, limit) {
+		resultSet.NextCursor = encodeCursor(offset + limit)
+	}
 	api.OK(w, resultSet)
 }
+
+// streamError represents the error line of streaming result
+type streamError struct {
+	Error string `json:"error"`
+}
+
+// stream writes the time series as ndjson line when the result arrives,
+// if query fail, writes the error line as the last line.
+func (m *MetricAPI) stream(ctx context.Context, w http.ResponseWriter, exeCtx parallel.BrokerExecuteContext) {
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher, _ := w.(http.Flusher)
+	encoder := json.NewEncoder(w)
+
+	err := waitResult(ctx, exeCtx, func(event *series.TimeSeriesEvent) error {
+		seriesList, err := exeCtx.Series(event)
+		if err != nil {
+			return err
+		}
+		for _, s := range seriesList {
+			if err := encoder.Encode(models.NewStreamSeries(s)); err != nil {
+				return err
+			}
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+		return nil
+	})
+	if err == nil {
+		// check if execute fail before query job submitted
+		_, err = exeCtx.ResultSet()
+	}
+	if err != nil {
+		_ = encoder.Encode(&streamError{Error: err.Error()})
+	}
+}
+
+// waitResult receives the time series event until the result chan closed,
+// returns err if handle event fail or query timeout/canceled/killed.
+func waitResult(ctx context.Context, exeCtx parallel.BrokerExecuteContext,
+	handle func(event *series.TimeSeriesEvent) error,
+) error {
+	resultCh := exeCtx.ResultCh()
+	for {
+		select {
+		case event, ok := <-resultCh:
+			if !ok {
+				return nil
+			}
+			if event != nil && event.Err != nil {
+				// query fail or killed
+				return event.Err
+			}
+			if err := handle(event); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// encodeCursor encodes the offset of series as cursor
+func encodeCursor(offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(offset)))
+}
+
+// decodeCursor decodes the offset of series from cursor, returns 0 if cursor is empty
+func decodeCursor(cursor string) (int, error) {
+	if cursor == "" {
+		return 0, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, errInvalidCursor
+	}
+	offset, err := strconv.Atoi(string(data))
+	if err != nil || offset < 0 {
+		return 0, errInvalidCursor
+	}
+	return offset, nil
+}
@@ -1,16 +1,25 @@
 package query
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/coordinator/database"
+	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/mock"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/series"
 )
 
@@ -28,7 +37,7 @@ func TestMetricAPI_Search(t *testing.T) {
 		gomock.Any(), gomock.Any(),
 		gomock.Any(), gomock.Any()).Return(brokerExecutor)
 
-	api := NewMetricAPI(nil, nil, nil, executorFactory, nil)
+	api := NewMetricAPI(*config.NewDefaultQuery(), nil, nil, nil, executorFactory, nil)
 
 	ch := make(chan *series.TimeSeriesEvent)
 
@@ -54,7 +63,7 @@ func TestNewMetricAPI_Search_Err(t *testing.T) {
 	defer ctrl.Finish()
 
 	executorFactory := parallel.NewMockExecutorFactory(ctrl)
-	api := NewMetricAPI(nil, nil, nil, executorFactory, nil)
+	api := NewMetricAPI(*config.NewDefaultQuery(), nil, nil, nil, executorFactory, nil)
 
 	// param error
 	mock.DoRequest(t, &mock.HTTPHandler{
@@ -96,3 +105,215 @@ func TestNewMetricAPI_Search_Err(t *testing.T) {
 		ExpectHTTPCode: 500,
 	})
 }
+
+func TestMetricAPI_Search_Param_Err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	api := NewMetricAPI(*config.NewDefaultQuery(), nil, nil, nil, nil, nil)
+	// limit error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/metric?db=test&sql=select+f+from+cpu&limit=a",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+	// cursor error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/metric?db=test&sql=select+f+from+cpu&limit=1&cursor=@@",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+	// replica policy error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/metric?db=test&sql=select+f+from+cpu&replicaPolicy=unknown",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+}
+
+func TestMetricAPI_Search_ReplicaPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
+	executeCtx := parallel.NewMockBrokerExecuteContext(ctrl)
+	executorFactory.EXPECT().NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _, _ string, _ replica.StatusStateMachine,
+			_ broker.NodeStateMachine, _ database.DBStateMachine, _ parallel.JobManager) parallel.BrokerExecutor {
+			policy, ok := replica.SelectPolicyFromContext(ctx)
+			assert.True(t, ok)
+			assert.Equal(t, option.MostCaughtUp, policy)
+			return brokerExecutor
+		})
+	brokerExecutor.EXPECT().Execute()
+	brokerExecutor.EXPECT().ExecuteContext().Return(executeCtx)
+	ch := make(chan *series.TimeSeriesEvent)
+	close(ch)
+	executeCtx.EXPECT().ResultCh().Return(ch)
+	executeCtx.EXPECT().ResultSet().Return(models.NewResultSet(), nil)
+
+	api := NewMetricAPI(*config.NewDefaultQuery(), nil, nil, nil, executorFactory, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/metric?db=test&sql=select+f+from+cpu&replicaPolicy=most-caught-up",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 200,
+	})
+}
+
+func TestMetricAPI_Search_Paginate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
+	executeCtx := parallel.NewMockBrokerExecuteContext(ctrl)
+	executorFactory.EXPECT().NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(brokerExecutor)
+	brokerExecutor.EXPECT().Execute()
+	brokerExecutor.EXPECT().ExecuteContext().Return(executeCtx)
+	ch := make(chan *series.TimeSeriesEvent)
+	close(ch)
+	executeCtx.EXPECT().ResultCh().Return(ch)
+	rs := models.NewResultSet()
+	for _, host := range []string{"1", "2", "3"} {
+		rs.AddSeries(models.NewSeries(map[string]string{"host": host}))
+	}
+	executeCtx.EXPECT().ResultSet().Return(rs, nil)
+
+	api := NewMetricAPI(*config.NewDefaultQuery(), nil, nil, nil, executorFactory, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/metric?db=test&sql=select+f+from+cpu+group+by+host&limit=1&cursor=" + encodeCursor(1),
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 200,
+		ExpectResponse: &models.ResultSet{
+			Series:     []*models.Series{{Tags: map[string]string{"host": "2"}}},
+			NextCursor: encodeCursor(2),
+		},
+	})
+}
+
+func TestMetricAPI_Search_Stream(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
+	executeCtx := parallel.NewMockBrokerExecuteContext(ctrl)
+	executorFactory.EXPECT().NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(brokerExecutor).AnyTimes()
+	brokerExecutor.EXPECT().Execute().AnyTimes()
+	brokerExecutor.EXPECT().ExecuteContext().Return(executeCtx).AnyTimes()
+	api := NewMetricAPI(*config.NewDefaultQuery(), nil, nil, nil, executorFactory, nil)
+
+	// case 1: stream series
+	ch := make(chan *series.TimeSeriesEvent, 1)
+	ch <- &series.TimeSeriesEvent{}
+	close(ch)
+	executeCtx.EXPECT().ResultCh().Return(ch)
+	s := models.NewSeries(map[string]string{"host": "1"})
+	points := models.NewPoints()
+	points.AddPoint(20, 2)
+	points.AddPoint(10, 1)
+	s.AddField("f", points)
+	executeCtx.EXPECT().Series(gomock.Any()).Return([]*models.Series{s}, nil)
+	executeCtx.EXPECT().ResultSet().Return(nil, nil)
+	rr := doStreamRequest(api)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/x-ndjson", rr.Header().Get("Content-Type"))
+	assert.Equal(t, `{"tags":{"host":"1"},"fields":{"f":[{"timestamp":10,"value":1},{"timestamp":20,"value":2}]}}`+"\n",
+		rr.Body.String())
+
+	// case 2: stream err
+	ch = make(chan *series.TimeSeriesEvent, 1)
+	ch <- &series.TimeSeriesEvent{}
+	close(ch)
+	executeCtx.EXPECT().ResultCh().Return(ch)
+	executeCtx.EXPECT().Series(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	rr = doStreamRequest(api)
+	assert.Equal(t, `{"error":"err"}`+"\n", rr.Body.String())
+
+	// case 3: execute err
+	ch = make(chan *series.TimeSeriesEvent)
+	close(ch)
+	executeCtx.EXPECT().ResultCh().Return(ch)
+	executeCtx.EXPECT().ResultSet().Return(nil, fmt.Errorf("err"))
+	rr = doStreamRequest(api)
+	assert.Equal(t, `{"error":"err"}`+"\n", rr.Body.String())
+}
+
+func TestMetricAPI_Search_Timeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
+	executeCtx := parallel.NewMockBrokerExecuteContext(ctrl)
+	executorFactory.EXPECT().NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(brokerExecutor)
+	brokerExecutor.EXPECT().Execute()
+	brokerExecutor.EXPECT().ExecuteContext().Return(executeCtx)
+	// result chan never closed
+	executeCtx.EXPECT().ResultCh().Return(make(chan *series.TimeSeriesEvent))
+
+	cfg := config.NewDefaultQuery()
+	cfg.Timeout = ltoml.Duration(10 * time.Millisecond)
+	api := NewMetricAPI(*cfg, nil, nil, nil, executorFactory, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/metric?db=test&sql=select+f+from+cpu",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+}
+
+func TestMetricAPI_Search_Killed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
+	executeCtx := parallel.NewMockBrokerExecuteContext(ctrl)
+	executorFactory.EXPECT().NewBrokerExecutor(gomock.Any(), gomock.Any(), gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(brokerExecutor)
+	brokerExecutor.EXPECT().Execute()
+	brokerExecutor.EXPECT().ExecuteContext().Return(executeCtx)
+	// result chan isn't closed after query killed
+	ch := make(chan *series.TimeSeriesEvent, 1)
+	ch <- &series.TimeSeriesEvent{Err: fmt.Errorf("killed")}
+	executeCtx.EXPECT().ResultCh().Return(ch)
+
+	api := NewMetricAPI(*config.NewDefaultQuery(), nil, nil, nil, executorFactory, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/metric?db=test&sql=select+f+from+cpu",
+		HandlerFunc:    api.Search,
+		ExpectHTTPCode: 500,
+	})
+}
+
+func TestCursor(t *testing.T) {
+	offset, err := decodeCursor("")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, offset)
+	offset, err = decodeCursor(encodeCursor(100))
+	assert.NoError(t, err)
+	assert.Equal(t, 100, offset)
+	_, err = decodeCursor(encodeCursor(-1))
+	assert.Equal(t, errInvalidCursor, err)
+	_, err = decodeCursor("@@")
+	assert.Equal(t, errInvalidCursor, err)
+}
+
+func doStreamRequest(api *MetricAPI) *httptest.ResponseRecorder {
+	req, _ := http.NewRequest(http.MethodGet, "/query/metric?db=test&sql=select+f+from+cpu&stream=true", nil)
+	rr := httptest.NewRecorder()
+	api.Search(rr, req)
+	return rr
+}
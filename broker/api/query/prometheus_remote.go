@@ -0,0 +1,239 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/broker"
+	"github.com/lindb/lindb/coordinator/database"
+	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/protocol"
+	"github.com/lindb/lindb/rpc/proto/prompb"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// for testing
+var (
+	readAllFunc = ioutil.ReadAll
+)
+
+// errMetricNameMatcherNotFound represents the remote read query hasn't the equal matcher of metric name
+var errMetricNameMatcherNotFound = errors.New("equal matcher of metric name(__name__) not found")
+
+// PrometheusRemoteRead represents support prometheus remote read protocol
+type PrometheusRemoteRead struct {
+	replicaStateMachine  replica.StatusStateMachine
+	nodeStateMachine     broker.NodeStateMachine
+	databaseStateMachine database.DBStateMachine
+	executorFactory      parallel.ExecutorFactory
+	jobManager           parallel.JobManager
+	timeout              time.Duration
+}
+
+// NewPrometheusRemoteRead creates prometheus remote read
+func NewPrometheusRemoteRead(cfg config.Query, replicaStateMachine replica.StatusStateMachine,
+	nodeStateMachine broker.NodeStateMachine, databaseStateMachine database.DBStateMachine,
+	executorFactory parallel.ExecutorFactory, jobManager parallel.JobManager) *PrometheusRemoteRead {
+	return &PrometheusRemoteRead{
+		replicaStateMachine:  replicaStateMachine,
+		nodeStateMachine:     nodeStateMachine,
+		databaseStateMachine: databaseStateMachine,
+		executorFactory:      executorFactory,
+		jobManager:           jobManager,
+		timeout:              cfg.Timeout.Duration(),
+	}
+}
+
+// Read parses snappy compressed prometheus remote read request, translates the label matchers of
+// each query into tag filter of query statement, then responses the samples of time series.
+func (m *PrometheusRemoteRead) Read(w http.ResponseWriter, r *http.Request) {
+	db, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	namespace, _ := api.GetParamsFromRequest("ns", r, constants.DefaultNamespace, false)
+	s, err := readAllFunc(r.Body)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	req, err := protocol.PromRemoteReadParse(s)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), m.timeout)
+	defer cancel()
+
+	resp := &prompb.ReadResponse{}
+	for _, q := range req.Queries {
+		result, err := m.query(ctx, db, namespace, q)
+		if err != nil {
+			api.Error(w, err)
+			return
+		}
+		resp.Results = append(resp.Results, result)
+	}
+	data, err := protocol.PromRemoteReadEncode(resp)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	w.Header().Set("Content-Encoding", "snappy")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(data)
+}
+
+// query executes one query of remote read request
+func (m *PrometheusRemoteRead) query(ctx context.Context, db, namespace string,
+	q *prompb.Query) (*prompb.QueryResult, error) {
+	metricName, condition, err := buildPromCondition(q.Matchers)
+	if err != nil {
+		return nil, err
+	}
+	// group by all tag keys of metric for returning the raw time series
+	tagKeys, err := m.getTagKeys(ctx, db, namespace, metricName)
+	if err != nil {
+		return nil, err
+	}
+	query := &stmt.Query{
+		Namespace:   namespace,
+		MetricName:  metricName,
+		SelectItems: []stmt.Expr{&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: protocol.PromRemoteValueField}}},
+		FieldNames:  []string{protocol.PromRemoteValueField},
+		Condition:   condition,
+		TimeRange:   timeutil.TimeRange{Start: q.StartTimestampMs, End: q.EndTimestampMs},
+		GroupBy:     tagKeys,
+	}
+	exec := m.executorFactory.NewQueryBrokerExecutor(ctx, db, query,
+		m.replicaStateMachine, m.nodeStateMachine, m.databaseStateMachine,
+		m.jobManager)
+	exec.Execute()
+
+	exeCtx := exec.ExecuteContext()
+	if err := waitResult(ctx, exeCtx, func(event *series.TimeSeriesEvent) error {
+		exeCtx.Emit(event)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	resultSet, err := exeCtx.ResultSet()
+	if err != nil {
+		return nil, err
+	}
+	return buildPromQueryResult(metricName, resultSet), nil
+}
+
+// getTagKeys returns all tag keys of metric
+func (m *PrometheusRemoteRead) getTagKeys(ctx context.Context, db, namespace, metricName string) ([]string, error) {
+	exec := m.executorFactory.NewMetadataBrokerExecutor(ctx, db, &stmt.Metadata{
+		Namespace:  namespace,
+		MetricName: metricName,
+		Type:       stmt.TagKey,
+		Limit:      constants.MaxSuggestions,
+	}, m.replicaStateMachine, m.nodeStateMachine, m.jobManager)
+	values, err := exec.Execute()
+	if err != nil {
+		return nil, err
+	}
+	tagKeys := make(map[string]struct{})
+	var result []string
+	for _, value := range values {
+		if _, ok := tagKeys[value]; ok {
+			continue
+		}
+		tagKeys[value] = struct{}{}
+		result = append(result, value)
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+// buildPromCondition returns the metric name and the tag filter condition by label matchers,
+// the equal matcher of __name__ is required, other matchers are combined by and operator.
+func buildPromCondition(matchers []*prompb.LabelMatcher) (metricName string, condition stmt.Expr, err error) {
+	for _, matcher := range matchers {
+		if matcher.Name == protocol.PromMetricNameLabel {
+			if matcher.Type != prompb.LabelMatcher_EQ {
+				return "", nil, fmt.Errorf("not support matcher type: %s for metric name", matcher.Type)
+			}
+			metricName = matcher.Value
+			continue
+		}
+		var expr stmt.Expr
+		switch matcher.Type {
+		case prompb.LabelMatcher_EQ:
+			expr = &stmt.EqualsExpr{Key: matcher.Name, Value: matcher.Value}
+		case prompb.LabelMatcher_NEQ:
+			expr = &stmt.NotExpr{Expr: &stmt.EqualsExpr{Key: matcher.Name, Value: matcher.Value}}
+		case prompb.LabelMatcher_RE:
+			expr = &stmt.RegexExpr{Key: matcher.Name, Regexp: anchorRegexp(matcher.Value)}
+		case prompb.LabelMatcher_NRE:
+			expr = &stmt.NotExpr{Expr: &stmt.RegexExpr{Key: matcher.Name, Regexp: anchorRegexp(matcher.Value)}}
+		default:
+			return "", nil, fmt.Errorf("not support matcher type: %s", matcher.Type)
+		}
+		if condition == nil {
+			condition = expr
+		} else {
+			condition = &stmt.BinaryExpr{Left: condition, Operator: stmt.AND, Right: expr}
+		}
+	}
+	if metricName == "" {
+		return "", nil, errMetricNameMatcherNotFound
+	}
+	return metricName, condition, nil
+}
+
+// anchorRegexp anchors the regexp, because the regexp of prometheus matcher is fully anchored
+func anchorRegexp(regexp string) string {
+	return "^(?:" + regexp + ")$"
+}
+
+// buildPromQueryResult builds the time series of prometheus by query result set
+func buildPromQueryResult(metricName string, resultSet *models.ResultSet) *prompb.QueryResult {
+	result := &prompb.QueryResult{}
+	if resultSet == nil {
+		return result
+	}
+	for _, series := range resultSet.Series {
+		points := series.Fields[protocol.PromRemoteValueField]
+		if len(points) == 0 {
+			continue
+		}
+		labels := []*prompb.Label{{Name: protocol.PromMetricNameLabel, Value: metricName}}
+		for key, value := range series.Tags {
+			// empty label value means the label is absent in prometheus
+			if value == "" {
+				continue
+			}
+			labels = append(labels, &prompb.Label{Name: key, Value: value})
+		}
+		sort.Slice(labels, func(i, j int) bool {
+			return labels[i].Name < labels[j].Name
+		})
+		samples := make([]*prompb.Sample, 0, len(points))
+		for timestamp, value := range points {
+			samples = append(samples, &prompb.Sample{Timestamp: timestamp, Value: value})
+		}
+		sort.Slice(samples, func(i, j int) bool {
+			return samples[i].Timestamp < samples[j].Timestamp
+		})
+		result.Timeseries = append(result.Timeseries, &prompb.TimeSeries{Labels: labels, Samples: samples})
+	}
+	return result
+}
@@ -0,0 +1,192 @@
+package query
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/rpc/proto/prompb"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestPrometheusRemoteRead_Read(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		readAllFunc = ioutil.ReadAll
+		ctrl.Finish()
+	}()
+
+	executorFactory := parallel.NewMockExecutorFactory(ctrl)
+	metadataExecutor := parallel.NewMockMetadataExecutor(ctrl)
+	brokerExecutor := parallel.NewMockBrokerExecutor(ctrl)
+	executeCtx := parallel.NewMockBrokerExecuteContext(ctrl)
+	api := NewPrometheusRemoteRead(*config.NewDefaultQuery(), nil, nil, nil, executorFactory, nil)
+
+	// case 1: param error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/read",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+	// case 2: read request body err
+	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
+		return nil, fmt.Errorf("err")
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+	// case 3: parse request err
+	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
+		return []byte("bad data"), nil
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+	// case 4: metric name not found
+	setReadRequest(t, &prompb.ReadRequest{Queries: []*prompb.Query{{}}})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+	setReadRequest(t, &prompb.ReadRequest{Queries: []*prompb.Query{{
+		StartTimestampMs: 10,
+		EndTimestampMs:   20,
+		Matchers: []*prompb.LabelMatcher{
+			{Type: prompb.LabelMatcher_EQ, Name: "__name__", Value: "cpu"},
+			{Type: prompb.LabelMatcher_RE, Name: "host", Value: "1.*"},
+		},
+	}}})
+	// case 5: get tag keys err
+	executorFactory.EXPECT().NewMetadataBrokerExecutor(gomock.Any(), "test", gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any()).Return(metadataExecutor).AnyTimes()
+	metadataExecutor.EXPECT().Execute().Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+	// case 6: query err
+	metadataExecutor.EXPECT().Execute().Return([]string{"host", "zone", "host"}, nil).AnyTimes()
+	executorFactory.EXPECT().NewQueryBrokerExecutor(gomock.Any(), "test", gomock.Any(),
+		gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_, _, query interface{}, _, _, _, _ interface{}) parallel.BrokerExecutor {
+			q := query.(*stmt.Query)
+			assert.Equal(t, "cpu", q.MetricName)
+			assert.Equal(t, []string{"host", "zone"}, q.GroupBy)
+			assert.Equal(t, &stmt.RegexExpr{Key: "host", Regexp: "^(?:1.*)$"}, q.Condition)
+			return brokerExecutor
+		}).AnyTimes()
+	brokerExecutor.EXPECT().Execute().AnyTimes()
+	brokerExecutor.EXPECT().ExecuteContext().Return(executeCtx).AnyTimes()
+	ch := make(chan *series.TimeSeriesEvent)
+	close(ch)
+	executeCtx.EXPECT().ResultCh().Return(ch).AnyTimes()
+	executeCtx.EXPECT().ResultSet().Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 500,
+	})
+	// case 7: query success
+	executeCtx.EXPECT().ResultSet().Return(&models.ResultSet{}, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/read?db=test",
+		HandlerFunc:    api.Read,
+		ExpectHTTPCode: 200,
+	})
+}
+
+func TestBuildPromCondition(t *testing.T) {
+	_, _, err := buildPromCondition([]*prompb.LabelMatcher{
+		{Type: prompb.LabelMatcher_RE, Name: "__name__", Value: "cpu.*"},
+	})
+	assert.Error(t, err)
+	_, _, err = buildPromCondition([]*prompb.LabelMatcher{
+		{Type: prompb.LabelMatcher_EQ, Name: "__name__", Value: "cpu"},
+		{Type: prompb.LabelMatcher_Type(10), Name: "host", Value: "1.1.1.1"},
+	})
+	assert.Error(t, err)
+
+	metricName, condition, err := buildPromCondition([]*prompb.LabelMatcher{
+		{Type: prompb.LabelMatcher_EQ, Name: "__name__", Value: "cpu"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "cpu", metricName)
+	assert.Nil(t, condition)
+
+	_, condition, err = buildPromCondition([]*prompb.LabelMatcher{
+		{Type: prompb.LabelMatcher_EQ, Name: "host", Value: "1.1.1.1"},
+		{Type: prompb.LabelMatcher_EQ, Name: "__name__", Value: "cpu"},
+		{Type: prompb.LabelMatcher_NEQ, Name: "zone", Value: "sh"},
+		{Type: prompb.LabelMatcher_RE, Name: "ip", Value: "1.*"},
+		{Type: prompb.LabelMatcher_NRE, Name: "app", Value: "lind.*"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.BinaryExpr{
+		Left: &stmt.BinaryExpr{
+			Left: &stmt.BinaryExpr{
+				Left:     &stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"},
+				Operator: stmt.AND,
+				Right:    &stmt.NotExpr{Expr: &stmt.EqualsExpr{Key: "zone", Value: "sh"}},
+			},
+			Operator: stmt.AND,
+			Right:    &stmt.RegexExpr{Key: "ip", Regexp: "^(?:1.*)$"},
+		},
+		Operator: stmt.AND,
+		Right:    &stmt.NotExpr{Expr: &stmt.RegexExpr{Key: "app", Regexp: "^(?:lind.*)$"}},
+	}, condition)
+}
+
+func TestBuildPromQueryResult(t *testing.T) {
+	assert.Empty(t, buildPromQueryResult("cpu", nil).Timeseries)
+
+	result := buildPromQueryResult("cpu", &models.ResultSet{Series: []*models.Series{
+		{
+			Tags:   map[string]string{"zone": "sh", "host": "1.1.1.1", "app": ""},
+			Fields: map[string]map[int64]float64{"value": {20: 2, 10: 1}},
+		},
+		{
+			Tags:   map[string]string{"host": "1.1.1.2"},
+			Fields: map[string]map[int64]float64{"f": {10: 1}},
+		},
+	}})
+	assert.Equal(t, []*prompb.TimeSeries{{
+		Labels: []*prompb.Label{
+			{Name: "__name__", Value: "cpu"},
+			{Name: "host", Value: "1.1.1.1"},
+			{Name: "zone", Value: "sh"},
+		},
+		Samples: []*prompb.Sample{{Value: 1, Timestamp: 10}, {Value: 2, Timestamp: 20}},
+	}}, result.Timeseries)
+}
+
+func setReadRequest(t *testing.T, req *prompb.ReadRequest) {
+	data, err := req.Marshal()
+	assert.NoError(t, err)
+	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
+		return snappy.Encode(nil, data), nil
+	}
+}
@@ -0,0 +1,52 @@
+package query
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+var errUnknownQueryJobStmt = errors.New("unknown query job statement, only support show queries/kill query")
+
+// QueryJobAPI represents the api of running query jobs,
+// shows the running queries and kills the running query by LinQL.
+type QueryJobAPI struct {
+	jobManager parallel.JobManager
+}
+
+// NewQueryJobAPI creates the query job api instance
+func NewQueryJobAPI(jobManager parallel.JobManager) *QueryJobAPI {
+	return &QueryJobAPI{
+		jobManager: jobManager,
+	}
+}
+
+// Handle handles show queries/kill query <id> statement
+func (q *QueryJobAPI) Handle(w http.ResponseWriter, r *http.Request) {
+	ql, err := api.GetParamsFromRequest("sql", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	statement, err := sql.Parse(ql)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	switch s := statement.(type) {
+	case *stmt.ShowQueries:
+		api.OK(w, q.jobManager.ListJobs())
+	case *stmt.KillQuery:
+		if err := q.jobManager.KillJob(s.JobID); err != nil {
+			api.Error(w, err)
+			return
+		}
+		api.NoContent(w)
+	default:
+		api.Error(w, errUnknownQueryJobStmt)
+	}
+}
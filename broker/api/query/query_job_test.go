@@ -0,0 +1,69 @@
+package query
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/parallel"
+)
+
+func TestQueryJobAPI_Handle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobManager := parallel.NewMockJobManager(ctrl)
+	api := NewQueryJobAPI(jobManager)
+
+	// case 1: sql not input
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/job",
+		HandlerFunc:    api.Handle,
+		ExpectHTTPCode: 500,
+	})
+	// case 2: parse sql err
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/job?sql=show+query",
+		HandlerFunc:    api.Handle,
+		ExpectHTTPCode: 500,
+	})
+	// case 3: unknown statement
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/job?sql=show+databases",
+		HandlerFunc:    api.Handle,
+		ExpectHTTPCode: 500,
+	})
+	// case 4: show queries
+	jobs := []*models.QueryJob{{JobID: 1, Database: "db", SQL: "select f from cpu", StorageNodes: []string{"1.1.1.1:9000"}}}
+	jobManager.EXPECT().ListJobs().Return(jobs)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/query/job?sql=show+queries",
+		HandlerFunc:    api.Handle,
+		ExpectHTTPCode: 200,
+		ExpectResponse: jobs,
+	})
+	// case 5: kill query
+	jobManager.EXPECT().KillJob(int64(1)).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/query/job?sql=kill+query+1",
+		HandlerFunc:    api.Handle,
+		ExpectHTTPCode: 204,
+	})
+	// case 6: kill query fail
+	jobManager.EXPECT().KillJob(int64(2)).Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/query/job?sql=kill+query+2",
+		HandlerFunc:    api.Handle,
+		ExpectHTTPCode: 500,
+	})
+}
@@ -24,12 +24,16 @@ func GetParamsFromRequest(paramsName string, r *http.Request, defaultValue strin
 	method := r.Method
 	//get value from different object according to different request methods
 	switch method {
-	// the parameter value need to be parsed from the form when the request method is POST
+	// the parameter value need to be parsed from the form(or url) when the request method is POST
 	case http.MethodPost:
 		if err := r.ParseForm(); err != nil {
 			return "", err
 		}
 		values := r.PostForm[paramsName]
+		if len(values) == 0 {
+			// the parameter value may be also in the url, such as the body is not form data
+			values = r.URL.Query()[paramsName]
+		}
 		if len(values) > 0 {
 			value = values[0]
 		}
@@ -51,3 +55,18 @@ func GetParamsFromRequest(paramsName string, r *http.Request, defaultValue strin
 	}
 	return "", fmt.Errorf("please input %s", paramsName)
 }
+
+// GetParamsFromQuery gets the parameter value from the url query only,
+// which doesn't parse the form, so that the request body isn't consumed(such as the body of write api).
+func GetParamsFromQuery(paramsName string, r *http.Request, defaultValue string, required bool) (string, error) {
+	if len(paramsName) == 0 {
+		return "", fmt.Errorf("the params name must not be null")
+	}
+	if value := r.URL.Query().Get(paramsName); len(value) > 0 {
+		return value, nil
+	}
+	if !required {
+		return defaultValue, nil
+	}
+	return "", fmt.Errorf("please input %s", paramsName)
+}
@@ -3,8 +3,10 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -44,6 +46,11 @@ func TestGetJSONBodyFromRequest(t *testing.T) {
 	value, err = GetParamsFromRequest("key", req2, "defaultValue", true)
 	assert.NoError(t, err)
 	assert.Equal(t, "value", value)
+
+	req2, _ = http.NewRequest("POST", "/database?key=value", bytes.NewReader(nil))
+	value, err = GetParamsFromRequest("key", req2, "defaultValue", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
 }
 
 func TestGetParamsFromRequest(t *testing.T) {
@@ -54,3 +61,23 @@ func TestGetParamsFromRequest(t *testing.T) {
 	_ = GetJSONBodyFromRequest(req, newDatabase)
 	assert.Equal(t, newDatabase.Name, database.Name)
 }
+
+func TestGetParamsFromQuery(t *testing.T) {
+	_, err := GetParamsFromQuery("", &http.Request{URL: &url.URL{}}, "defaultValue", false)
+	assert.Error(t, err)
+
+	req, _ := http.NewRequest(http.MethodPost, "/write?key=value", strings.NewReader("key=form"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	value, err := GetParamsFromQuery("key", req, "defaultValue", true)
+	assert.NoError(t, err)
+	assert.Equal(t, "value", value)
+	// body isn't consumed
+	body, _ := ioutil.ReadAll(req.Body)
+	assert.Equal(t, "key=form", string(body))
+
+	_, err = GetParamsFromQuery("test", req, "defaultValue", true)
+	assert.Error(t, err)
+	value, err = GetParamsFromQuery("test", req, "defaultValue", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "defaultValue", value)
+}
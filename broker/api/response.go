@@ -27,6 +27,19 @@ func Error(w http.ResponseWriter, err error) {
 	response(w, http.StatusInternalServerError, b)
 }
 
+// Forbidden responses error message and set the http status code 403
+func Forbidden(w http.ResponseWriter, err error) {
+	b, _ := json.Marshal(err.Error())
+	response(w, http.StatusForbidden, b)
+}
+
+// TooManyRequests responses error message and set the http status code 429,
+// client should retry later
+func TooManyRequests(w http.ResponseWriter, err error) {
+	b, _ := json.Marshal(err.Error())
+	response(w, http.StatusTooManyRequests, b)
+}
+
 // response responses json body for http restful api
 func response(w http.ResponseWriter, httpCode int, content []byte) {
 	w.Header().Set("Content-Type", "application/json; charset=utf-8")
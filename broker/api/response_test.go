@@ -36,3 +36,17 @@ func TestError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, resp.Code)
 	assert.Equal(t, `"err"`, resp.Body.String())
 }
+
+func TestTooManyRequests(t *testing.T) {
+	resp := httptest.NewRecorder()
+	TooManyRequests(resp, fmt.Errorf("err"))
+	assert.Equal(t, http.StatusTooManyRequests, resp.Code)
+	assert.Equal(t, `"err"`, resp.Body.String())
+}
+
+func TestForbidden(t *testing.T) {
+	resp := httptest.NewRecorder()
+	Forbidden(resp, fmt.Errorf("err"))
+	assert.Equal(t, http.StatusForbidden, resp.Code)
+	assert.Equal(t, `"err"`, resp.Body.String())
+}
@@ -22,6 +22,7 @@ type route struct {
 
 type middlewareHandler struct {
 	regexp     *regexp.Regexp
+	exclude    *regexp.Regexp
 	middleware mux.MiddlewareFunc
 }
 
@@ -34,6 +35,11 @@ func AddMiddleware(middleware mux.MiddlewareFunc, regexp *regexp.Regexp) {
 	middlewareHandlers = append(middlewareHandlers, middlewareHandler{middleware: middleware, regexp: regexp})
 }
 
+// AddMiddlewareExclude adds middleware func base on url path pattern, except the url path matching exclude pattern
+func AddMiddlewareExclude(middleware mux.MiddlewareFunc, regexp, exclude *regexp.Regexp) {
+	middlewareHandlers = append(middlewareHandlers, middlewareHandler{middleware: middleware, regexp: regexp, exclude: exclude})
+}
+
 // AddRoute adds http route handle func for urp pattern
 func AddRoute(name, method, pattern string, handler http.HandlerFunc) {
 	routes = append(routes, route{name: name, method: method, pattern: pattern, handler: handler})
@@ -45,14 +51,10 @@ func NewRouter() *mux.Router {
 	router := mux.NewRouter().StrictSlash(true)
 	for _, route := range routes {
 		mds := getMiddleware(route.pattern)
-		var handler http.Handler
-		// this route.pattern set middleware
-		if len(mds) > 0 {
-			for _, md := range mds {
-				handler = md.Middleware(route.handler)
-			}
-		} else {
-			handler = route.handler
+		var handler http.Handler = route.handler
+		// this route.pattern set middleware, the first added middleware is the outermost one
+		for i := len(mds) - 1; i >= 0; i-- {
+			handler = mds[i].Middleware(handler)
 		}
 		router.
 			Methods([]string{route.method, http.MethodOptions}...).
@@ -100,6 +102,9 @@ func redirectToConsole(w http.ResponseWriter, r *http.Request) {
 func getMiddleware(pattern string) []mux.MiddlewareFunc {
 	var ms []mux.MiddlewareFunc
 	for _, middlewareHandler := range middlewareHandlers {
+		if middlewareHandler.exclude != nil && middlewareHandler.exclude.MatchString(pattern) {
+			continue
+		}
 		if middlewareHandler.regexp.MatchString(pattern) {
 			ms = append(ms, middlewareHandler.middleware)
 		}
@@ -24,6 +24,21 @@ func TestAddMiddleware(t *testing.T) {
 	assert.Equal(t, 1, len(middleware))
 }
 
+func TestAddMiddlewareExclude(t *testing.T) {
+	defer func() {
+		middlewareHandlers = nil
+	}()
+	middlewareHandlers = nil
+	AddMiddlewareExclude(func(next http.Handler) http.Handler {
+		return nil
+	}, regexp.MustCompile("^/"), regexp.MustCompile("^/(login|health)$"))
+
+	assert.Len(t, getMiddleware("/database"), 1)
+	assert.Len(t, getMiddleware("/storage/cluster"), 1)
+	assert.Empty(t, getMiddleware("/login"))
+	assert.Empty(t, getMiddleware("/health"))
+}
+
 func TestAddRoute(t *testing.T) {
 	AddRoute("test", http.MethodGet, "/test", func(writer http.ResponseWriter, request *http.Request) {})
 	assert.Equal(t, 1, len(routes))
@@ -93,3 +108,24 @@ func TestRouteHandle(t *testing.T) {
 	resp = httptest.NewRecorder()
 	r.ServeHTTP(resp, req)
 }
+
+func TestNewRouter_MiddlewareChain(t *testing.T) {
+	var orders []string
+	reg, _ := regexp.Compile("^/chain$")
+	for _, name := range []string{"first", "second"} {
+		name := name
+		AddMiddleware(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				orders = append(orders, name)
+				next.ServeHTTP(w, r)
+			})
+		}, reg)
+	}
+	AddRoute("chain", http.MethodGet, "/chain", func(writer http.ResponseWriter, request *http.Request) {
+		orders = append(orders, "handler")
+	})
+	r := NewRouter()
+	req, _ := http.NewRequest(http.MethodGet, "/chain", nil)
+	r.ServeHTTP(httptest.NewRecorder(), req)
+	assert.Equal(t, []string{"first", "second", "handler"}, orders)
+}
@@ -0,0 +1,53 @@
+package write
+
+import (
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/protocol"
+	"github.com/lindb/lindb/replication"
+)
+
+// InfluxWrite represents support influxdb line protocol
+type InfluxWrite struct {
+	cm replication.ChannelManager
+}
+
+// NewInfluxWrite creates influxdb line protocol write
+func NewInfluxWrite(cm replication.ChannelManager) *InfluxWrite {
+	return &InfluxWrite{
+		cm: cm,
+	}
+}
+
+// Write parses influxdb line protocol then writes data into wal,
+// the valid lines are written even if some lines cannot be parsed, and the error reports the failed lines.
+func (m *InfluxWrite) Write(w http.ResponseWriter, r *http.Request) {
+	// params are read from url query only, parsing form consumes the body with form content type
+	databaseName, err := api.GetParamsFromQuery("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	namespace, _ := api.GetParamsFromQuery("ns", r, constants.DefaultNamespace, false)
+	precision, _ := api.GetParamsFromQuery("precision", r, "", false)
+	s, err := readAllFunc(r.Body)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+
+	metricList, parseErr := protocol.InfluxParse(s, namespace, precision)
+	if metricList != nil && len(metricList.Metrics) > 0 {
+		if err := m.cm.Write(databaseName, metricList); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	if parseErr != nil {
+		api.Error(w, parseErr)
+		return
+	}
+	api.OK(w, "success")
+}
@@ -0,0 +1,108 @@
+package write
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/replication"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+func TestInfluxWrite_Write(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		readAllFunc = ioutil.ReadAll
+		ctrl.Finish()
+	}()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	api := NewInfluxWrite(cm)
+	// case 1: param error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPut,
+		URL:            "/metric/influx",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+	// case 2: read request body err
+	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
+		return nil, fmt.Errorf("err")
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPut,
+		URL:            "/metric/influx?db=dal",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+	// case 3: write wal err
+	input := `cpu.load,host=test load_SUM=7 1577000000
+memory,host=test used_SUM=100 1577000000
+`
+	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
+		return []byte(input), nil
+	}
+	cm.EXPECT().Write(gomock.Any(), gomock.Any()).Return(errors.New("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPut,
+		URL:            "/metric/influx?db=dal&precision=s",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+	// case 4: write wal success
+	cm.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/metric/influx?db=dal&precision=s",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 200,
+	})
+	// case 5: invalid precision
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPut,
+		URL:            "/metric/influx?db=dal&precision=d",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+	// case 6: partial lines failure, valid lines are written
+	input = `cpu.load,host=test load_SUM=7 1577000000
+,host=test used_SUM=100 1577000000
+`
+	cm.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPut,
+		URL:            "/metric/influx?db=dal",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+}
+
+func TestInfluxWrite_Write_FormContentType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	api := NewInfluxWrite(cm)
+	cm.EXPECT().Write("dal", gomock.Any()).DoAndReturn(func(databaseName string, metricList *pb.MetricList) error {
+		assert.Len(t, metricList.Metrics, 1)
+		assert.Equal(t, "ns", metricList.Metrics[0].Namespace)
+		assert.Equal(t, int64(1577000000000), metricList.Metrics[0].Timestamp)
+		return nil
+	})
+	// body is read as line protocol even if the content type is form
+	req, _ := http.NewRequest(http.MethodPost, "/metric/influx?db=dal&ns=ns&precision=s",
+		strings.NewReader("cpu.load,host=test load_SUM=7 1577000000\n"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr := httptest.NewRecorder()
+	api.Write(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
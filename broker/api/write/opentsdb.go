@@ -0,0 +1,55 @@
+package write
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/protocol"
+	"github.com/lindb/lindb/replication"
+)
+
+// OpenTSDBWrite represents support opentsdb http api(/api/put)
+type OpenTSDBWrite struct {
+	cm  replication.ChannelManager
+	cfg config.OpenTSDB
+}
+
+// NewOpenTSDBWrite creates opentsdb http api write
+func NewOpenTSDBWrite(cm replication.ChannelManager, cfg config.OpenTSDB) *OpenTSDBWrite {
+	return &OpenTSDBWrite{
+		cm:  cm,
+		cfg: cfg,
+	}
+}
+
+// Write parses the data points of opentsdb json then writes data into wal, uses the database/namespace of config
+// if not set in request, the valid data points are written even if some data points cannot be parsed.
+func (m *OpenTSDBWrite) Write(w http.ResponseWriter, r *http.Request) {
+	databaseName, _ := api.GetParamsFromRequest("db", r, m.cfg.Database, false)
+	if databaseName == "" {
+		api.Error(w, fmt.Errorf("database is required, sets db param or database of opentsdb config"))
+		return
+	}
+	namespace, _ := api.GetParamsFromRequest("ns", r, m.cfg.Namespace, false)
+	s, err := readAllFunc(r.Body)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+
+	metricList, parseErr := protocol.OpenTSDBParseJSON(s, namespace)
+	if metricList != nil && len(metricList.Metrics) > 0 {
+		if err := m.cm.Write(databaseName, metricList); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	if parseErr != nil {
+		api.Error(w, parseErr)
+		return
+	}
+	// opentsdb responses 204 if all data points are stored successfully
+	w.WriteHeader(http.StatusNoContent)
+}
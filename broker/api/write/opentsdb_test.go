@@ -0,0 +1,74 @@
+package write
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/replication"
+)
+
+func TestOpenTSDBWrite_Write(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		readAllFunc = ioutil.ReadAll
+		ctrl.Finish()
+	}()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	api := NewOpenTSDBWrite(cm, config.OpenTSDB{})
+	// case 1: database not set
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/put",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+	// case 2: read request body err
+	api = NewOpenTSDBWrite(cm, config.OpenTSDB{Database: "dal", Namespace: "ns"})
+	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
+		return nil, fmt.Errorf("err")
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/put",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+	// case 3: write wal err
+	input := `[{"metric":"sys.cpu","timestamp":1577000000,"value":18,"tags":{"host":"web01"}}]`
+	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
+		return []byte(input), nil
+	}
+	cm.EXPECT().Write("dal", gomock.Any()).Return(errors.New("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/put",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+	// case 4: write wal success, database of request
+	cm.EXPECT().Write("test", gomock.Any()).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/put?db=test",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 204,
+	})
+	// case 5: partial data points failure, valid data points are written
+	input = `[{"metric":"sys.cpu","timestamp":1577000000,"value":18},{"metric":"","timestamp":1577000000,"value":1}]`
+	cm.EXPECT().Write("dal", gomock.Any()).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/put",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+}
@@ -3,6 +3,7 @@ package write
 import (
 	"io/ioutil"
 	"net/http"
+	"sync"
 
 	"github.com/lindb/lindb/broker/api"
 	"github.com/lindb/lindb/constants"
@@ -17,7 +18,8 @@ var (
 
 // PrometheusWrite represents support prometheus text protocol
 type PrometheusWrite struct {
-	cm replication.ChannelManager
+	cm      replication.ChannelManager
+	parsers sync.Map // database => *protocol.PromParser, keeps the last cumulative values of series
 }
 
 // NewPrometheusWrite creates prometheus write
@@ -27,6 +29,15 @@ func NewPrometheusWrite(cm replication.ChannelManager) *PrometheusWrite {
 	}
 }
 
+// getParser returns the prometheus parser of database
+func (m *PrometheusWrite) getParser(databaseName string) *protocol.PromParser {
+	parser, ok := m.parsers.Load(databaseName)
+	if !ok {
+		parser, _ = m.parsers.LoadOrStore(databaseName, protocol.NewPromParser())
+	}
+	return parser.(*protocol.PromParser)
+}
+
 // Write parses prometheus text protocol then writes data into wal
 func (m *PrometheusWrite) Write(w http.ResponseWriter, r *http.Request) {
 	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
@@ -41,14 +52,14 @@ func (m *PrometheusWrite) Write(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	metricList, err := protocol.PromParse(s)
+	metricList, err := m.getParser(databaseName).Parse(s)
 	if err != nil {
 		api.Error(w, err)
 		return
 	}
 
 	if err := m.cm.Write(databaseName, metricList); err != nil {
-		api.Error(w, err)
+		writeError(w, err)
 		return
 	}
 	api.OK(w, "success")
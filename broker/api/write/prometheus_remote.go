@@ -0,0 +1,50 @@
+package write
+
+import (
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/protocol"
+	"github.com/lindb/lindb/replication"
+)
+
+// PrometheusRemoteWrite represents support prometheus remote write protocol
+type PrometheusRemoteWrite struct {
+	cm replication.ChannelManager
+}
+
+// NewPrometheusRemoteWrite creates prometheus remote write
+func NewPrometheusRemoteWrite(cm replication.ChannelManager) *PrometheusRemoteWrite {
+	return &PrometheusRemoteWrite{
+		cm: cm,
+	}
+}
+
+// Write parses snappy compressed prometheus remote write request then writes data into wal
+func (m *PrometheusRemoteWrite) Write(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	namespace, _ := api.GetParamsFromRequest("ns", r, constants.DefaultNamespace, false)
+	s, err := readAllFunc(r.Body)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+
+	metricList, err := protocol.PromRemoteWriteParse(s, namespace)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if len(metricList.Metrics) > 0 {
+		if err := m.cm.Write(databaseName, metricList); err != nil {
+			writeError(w, err)
+			return
+		}
+	}
+	api.NoContent(w)
+}
@@ -0,0 +1,89 @@
+package write
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/golang/snappy"
+
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/replication"
+	"github.com/lindb/lindb/rpc/proto/prompb"
+)
+
+func TestPrometheusRemoteWrite_Write(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		readAllFunc = ioutil.ReadAll
+		ctrl.Finish()
+	}()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	api := NewPrometheusRemoteWrite(cm)
+	// case 1: param error
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/write",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+	// case 2: read request body err
+	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
+		return nil, fmt.Errorf("err")
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/write?db=dal",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+	// case 3: parse request err
+	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
+		return []byte("bad data"), nil
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/write?db=dal",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+	// case 4: write wal err
+	req := &prompb.WriteRequest{Timeseries: []*prompb.TimeSeries{{
+		Labels:  []*prompb.Label{{Name: "__name__", Value: "cpu"}, {Name: "host", Value: "1.1.1.1"}},
+		Samples: []*prompb.Sample{{Value: 1, Timestamp: 10}},
+	}}}
+	data, _ := req.Marshal()
+	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
+		return snappy.Encode(nil, data), nil
+	}
+	cm.EXPECT().Write("dal", gomock.Any()).Return(errors.New("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/write?db=dal&ns=ns",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 500,
+	})
+	// case 5: write wal success
+	cm.EXPECT().Write("dal", gomock.Any()).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/write?db=dal",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 204,
+	})
+	// case 6: empty request
+	readAllFunc = func(r io.Reader) (bytes []byte, err error) {
+		return snappy.Encode(nil, nil), nil
+	}
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/api/v1/prom/write?db=dal",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 204,
+	})
+}
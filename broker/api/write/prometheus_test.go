@@ -57,6 +57,14 @@ go_gc_duration_seconds_sum 90
 		HandlerFunc:    api.Write,
 		ExpectHTTPCode: 500,
 	})
+	// case 4: replication queue is full
+	cm.EXPECT().Write(gomock.Any(), gomock.Any()).Return(replication.ErrQueueFull)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPut,
+		URL:            "/metric/prometheus?db=dal&cluster=dal&c=1",
+		HandlerFunc:    api.Write,
+		ExpectHTTPCode: 429,
+	})
 	// case 4: write wal success
 	cm.EXPECT().Write(gomock.Any(), gomock.Any()).Return(nil)
 	mock.DoRequest(t, &mock.HTTPHandler{
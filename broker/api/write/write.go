@@ -0,0 +1,19 @@
+package write
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/replication"
+)
+
+// writeError responses the error of writing data into replication channel,
+// responses 429 if the replication queue is full, so that client can retry later.
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, replication.ErrQueueFull) {
+		api.TooManyRequests(w, err)
+		return
+	}
+	api.Error(w, err)
+}
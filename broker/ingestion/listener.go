@@ -0,0 +1,289 @@
+package ingestion
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/atomic"
+
+	"github.com/lindb/lindb/monitoring"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/replication"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+//go:generate mockgen -source=./listener.go -destination=./listener_mock.go -package=ingestion
+
+// for testing
+var (
+	listenFunc       = net.Listen
+	listenPacketFunc = net.ListenPacket
+)
+
+var log = logger.GetLogger("broker", "Ingestion")
+
+var (
+	receivedMetrics = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ingestion_received_metrics",
+			Help: "Number of metrics received by plaintext protocol listener.",
+		},
+		[]string{"protocol"},
+	)
+	parseFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ingestion_parse_failures",
+			Help: "Number of lines which cannot be parsed by plaintext protocol listener.",
+		},
+		[]string{"protocol"},
+	)
+	writeFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ingestion_write_failures",
+			Help: "Number of metrics which cannot be written into replication channel by plaintext protocol listener.",
+		},
+		[]string{"protocol"},
+	)
+)
+
+func init() {
+	monitoring.BrokerRegistry.MustRegister(receivedMetrics, parseFailures, writeFailures)
+}
+
+const (
+	defaultBatchSize = 1000
+	maxPacketSize    = 64 * 1024
+)
+
+// LineParser parses a line of plaintext protocol to metric, returns nil if the line is ignored
+type LineParser func(line string) (*pb.Metric, error)
+
+// Listener represents the listener of plaintext line protocol(like graphite, opentsdb telnet),
+// which parses the received lines to metrics, then writes them into replication channel.
+type Listener interface {
+	io.Closer
+	// Start starts listening, handles the connections(tcp) or packets(udp) in background
+	Start() error
+}
+
+// listenerOption represents the common options of tcp/udp listener
+type listenerOption struct {
+	protocol  string // name of protocol, like graphite-tcp
+	addr      string
+	database  string
+	batchSize int
+	parser    LineParser
+	cm        replication.ChannelManager
+}
+
+// batchWriter buffers the metrics parsed from lines, writes them into replication channel in batch
+type batchWriter struct {
+	option  *listenerOption
+	metrics []*pb.Metric
+}
+
+// newBatchWriter creates the batch writer of connection/packet
+func newBatchWriter(option *listenerOption) *batchWriter {
+	return &batchWriter{option: option}
+}
+
+// parse parses the line to metric, flushes metrics if reach the batch size
+func (w *batchWriter) parse(line string) {
+	metric, err := w.option.parser(strings.TrimSpace(line))
+	if err != nil {
+		parseFailures.WithLabelValues(w.option.protocol).Inc()
+		log.Debug("parse line error", logger.String("protocol", w.option.protocol), logger.Error(err))
+		return
+	}
+	if metric == nil {
+		return
+	}
+	w.metrics = append(w.metrics, metric)
+	if len(w.metrics) >= w.option.batchSize {
+		w.flush()
+	}
+}
+
+// flush writes the buffered metrics into replication channel
+func (w *batchWriter) flush() {
+	if len(w.metrics) == 0 {
+		return
+	}
+	metrics := w.metrics
+	// replication channel holds the metrics, cannot reuse the buffer
+	w.metrics = nil
+	if err := w.option.cm.Write(w.option.database, &pb.MetricList{Metrics: metrics}); err != nil {
+		writeFailures.WithLabelValues(w.option.protocol).Add(float64(len(metrics)))
+		log.Error("write metrics error", logger.String("protocol", w.option.protocol),
+			logger.String("db", w.option.database), logger.Error(err))
+		return
+	}
+	receivedMetrics.WithLabelValues(w.option.protocol).Add(float64(len(metrics)))
+}
+
+// tcpListener implements Listener, reads the lines of each connection
+type tcpListener struct {
+	option   *listenerOption
+	listener net.Listener
+	conns    map[net.Conn]struct{}
+	closed   atomic.Bool
+	wg       sync.WaitGroup
+	mutex    sync.Mutex
+}
+
+// newTCPListener creates the tcp listener of plaintext line protocol
+func newTCPListener(option *listenerOption) Listener {
+	return &tcpListener{
+		option: option,
+		conns:  make(map[net.Conn]struct{}),
+	}
+}
+
+// Start starts listening, handles the connections in background
+func (l *tcpListener) Start() error {
+	listener, err := listenFunc("tcp", l.option.addr)
+	if err != nil {
+		return err
+	}
+	l.listener = listener
+	l.wg.Add(1)
+	go l.accept()
+	log.Info("tcp listener started", logger.String("protocol", l.option.protocol), logger.String("addr", l.option.addr))
+	return nil
+}
+
+// accept accepts the connections until listener closed
+func (l *tcpListener) accept() {
+	defer l.wg.Done()
+	for {
+		conn, err := l.listener.Accept()
+		if err != nil {
+			if l.closed.Load() {
+				return
+			}
+			log.Warn("accept connection error", logger.String("protocol", l.option.protocol), logger.Error(err))
+			continue
+		}
+		l.mutex.Lock()
+		if l.closed.Load() {
+			l.mutex.Unlock()
+			_ = conn.Close()
+			return
+		}
+		l.conns[conn] = struct{}{}
+		l.wg.Add(1)
+		l.mutex.Unlock()
+
+		go l.handle(conn)
+	}
+}
+
+// handle reads the lines of connection, flushes the metrics if no more data buffered
+func (l *tcpListener) handle(conn net.Conn) {
+	defer func() {
+		_ = conn.Close()
+		l.mutex.Lock()
+		delete(l.conns, conn)
+		l.mutex.Unlock()
+		l.wg.Done()
+	}()
+	reader := bufio.NewReader(conn)
+	writer := newBatchWriter(l.option)
+	for {
+		line, err := reader.ReadString('\n')
+		if len(line) > 0 {
+			writer.parse(line)
+		}
+		if err != nil || reader.Buffered() == 0 {
+			writer.flush()
+		}
+		if err != nil {
+			if err != io.EOF && !l.closed.Load() {
+				log.Warn("read connection error", logger.String("protocol", l.option.protocol), logger.Error(err))
+			}
+			return
+		}
+	}
+}
+
+// Close stops listening, closes all connections
+func (l *tcpListener) Close() error {
+	if !l.closed.CAS(false, true) {
+		return nil
+	}
+	var err error
+	if l.listener != nil {
+		err = l.listener.Close()
+	}
+	l.mutex.Lock()
+	for conn := range l.conns {
+		_ = conn.Close()
+	}
+	l.mutex.Unlock()
+	l.wg.Wait()
+	return err
+}
+
+// udpListener implements Listener, reads the lines of each packet
+type udpListener struct {
+	option *listenerOption
+	conn   net.PacketConn
+	closed atomic.Bool
+	wg     sync.WaitGroup
+}
+
+// newUDPListener creates the udp listener of plaintext line protocol
+func newUDPListener(option *listenerOption) Listener {
+	return &udpListener{option: option}
+}
+
+// Start starts listening, handles the packets in background
+func (l *udpListener) Start() error {
+	conn, err := listenPacketFunc("udp", l.option.addr)
+	if err != nil {
+		return err
+	}
+	l.conn = conn
+	l.wg.Add(1)
+	go l.read()
+	log.Info("udp listener started", logger.String("protocol", l.option.protocol), logger.String("addr", l.option.addr))
+	return nil
+}
+
+// read reads the packets until listener closed, the metrics of each packet are written in batch
+func (l *udpListener) read() {
+	defer l.wg.Done()
+	buf := make([]byte, maxPacketSize)
+	for {
+		n, _, err := l.conn.ReadFrom(buf)
+		if err != nil {
+			if l.closed.Load() {
+				return
+			}
+			log.Warn("read packet error", logger.String("protocol", l.option.protocol), logger.Error(err))
+			continue
+		}
+		writer := newBatchWriter(l.option)
+		for _, line := range strings.Split(string(buf[:n]), "\n") {
+			writer.parse(line)
+		}
+		writer.flush()
+	}
+}
+
+// Close stops listening
+func (l *udpListener) Close() error {
+	if !l.closed.CAS(false, true) {
+		return nil
+	}
+	var err error
+	if l.conn != nil {
+		err = l.conn.Close()
+	}
+	l.wg.Wait()
+	return err
+}
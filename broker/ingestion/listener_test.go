@@ -0,0 +1,178 @@
+package ingestion
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/replication"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+func newTestOption(cm replication.ChannelManager, batchSize int) *listenerOption {
+	return &listenerOption{
+		protocol:  "test",
+		addr:      "127.0.0.1:0",
+		database:  "db",
+		batchSize: batchSize,
+		parser: func(line string) (*pb.Metric, error) {
+			switch line {
+			case "":
+				return nil, nil
+			case "bad":
+				return nil, fmt.Errorf("err")
+			default:
+				return &pb.Metric{Name: line}, nil
+			}
+		},
+		cm: cm,
+	}
+}
+
+// captureWrites records the metric names written into replication channel
+func captureWrites(cm *replication.MockChannelManager, err error) chan []string {
+	ch := make(chan []string, 10)
+	cm.EXPECT().Write("db", gomock.Any()).DoAndReturn(func(db string, metricList *pb.MetricList) error {
+		var names []string
+		for _, m := range metricList.Metrics {
+			names = append(names, m.Name)
+		}
+		ch <- names
+		return err
+	}).AnyTimes()
+	return ch
+}
+
+func receive(t *testing.T, ch chan []string) []string {
+	select {
+	case names := <-ch:
+		return names
+	case <-time.After(5 * time.Second):
+		t.Fatal("wait write metrics timeout")
+		return nil
+	}
+}
+
+func TestTCPListener(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	ch := captureWrites(cm, nil)
+	l := newTCPListener(newTestOption(cm, 2))
+	assert.NoError(t, l.Start())
+
+	conn, err := net.Dial("tcp", l.(*tcpListener).listener.Addr().String())
+	assert.NoError(t, err)
+	_, err = conn.Write([]byte("m1\nbad\n\nm2\nm3\n"))
+	assert.NoError(t, err)
+	// flush if reach batch size
+	assert.Equal(t, []string{"m1", "m2"}, receive(t, ch))
+	// flush if no more data
+	assert.Equal(t, []string{"m3"}, receive(t, ch))
+	// flush the line without new line when connection closed
+	_, err = conn.Write([]byte("m4"))
+	assert.NoError(t, err)
+	assert.NoError(t, conn.Close())
+	assert.Equal(t, []string{"m4"}, receive(t, ch))
+
+	// close listener with active connection
+	conn, err = net.Dial("tcp", l.(*tcpListener).listener.Addr().String())
+	assert.NoError(t, err)
+	_, err = conn.Write([]byte("m5\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"m5"}, receive(t, ch))
+	assert.NoError(t, l.Close())
+	// close again
+	assert.NoError(t, l.Close())
+	_ = conn.Close()
+}
+
+func TestTCPListener_Start_err(t *testing.T) {
+	defer func() {
+		listenFunc = net.Listen
+	}()
+	listenFunc = func(network, address string) (net.Listener, error) {
+		return nil, fmt.Errorf("err")
+	}
+	l := newTCPListener(newTestOption(nil, 2))
+	assert.Error(t, l.Start())
+	assert.NoError(t, l.Close())
+}
+
+func TestUDPListener(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := replication.NewMockChannelManager(ctrl)
+	ch := captureWrites(cm, fmt.Errorf("err"))
+	l := newUDPListener(newTestOption(cm, 10))
+	assert.NoError(t, l.Start())
+
+	conn, err := net.Dial("udp", l.(*udpListener).conn.LocalAddr().String())
+	assert.NoError(t, err)
+	_, err = conn.Write([]byte("m1\nbad\nm2"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"m1", "m2"}, receive(t, ch))
+	assert.NoError(t, conn.Close())
+
+	assert.NoError(t, l.Close())
+	// close again
+	assert.NoError(t, l.Close())
+}
+
+func TestUDPListener_Start_err(t *testing.T) {
+	defer func() {
+		listenPacketFunc = net.ListenPacket
+	}()
+	listenPacketFunc = func(network, address string) (net.PacketConn, error) {
+		return nil, fmt.Errorf("err")
+	}
+	l := newUDPListener(newTestOption(nil, 2))
+	assert.Error(t, l.Start())
+	assert.NoError(t, l.Close())
+}
+
+func TestNewGraphiteListeners(t *testing.T) {
+	// case 1: disabled
+	listeners, err := NewGraphiteListeners(config.Graphite{}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, listeners)
+	// case 2: database is empty
+	_, err = NewGraphiteListeners(config.Graphite{TCPPort: 2003}, nil)
+	assert.Error(t, err)
+	// case 3: bad template
+	_, err = NewGraphiteListeners(config.Graphite{TCPPort: 2003, Database: "db", Templates: []string{"host.field"}}, nil)
+	assert.Error(t, err)
+	// case 4: tcp and udp listeners
+	listeners, err = NewGraphiteListeners(config.Graphite{TCPPort: 2003, UDPPort: 2003, Database: "db"}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, listeners, 2)
+	assert.Equal(t, defaultBatchSize, listeners[0].(*tcpListener).option.batchSize)
+	assert.Equal(t, ":2003", listeners[1].(*udpListener).option.addr)
+}
+
+func TestNewOpenTSDBListeners(t *testing.T) {
+	// case 1: disabled
+	listeners, err := NewOpenTSDBListeners(config.OpenTSDB{}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, listeners)
+	// case 2: database is empty
+	_, err = NewOpenTSDBListeners(config.OpenTSDB{TCPPort: 4242}, nil)
+	assert.Error(t, err)
+	// case 3: telnet listener
+	listeners, err = NewOpenTSDBListeners(config.OpenTSDB{TCPPort: 4242, Database: "db", Namespace: "ns", BatchSize: 10}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, listeners, 1)
+	option := listeners[0].(*tcpListener).option
+	assert.Equal(t, 10, option.batchSize)
+	metric, err := option.parser("put cpu 1500000000 1 host=a")
+	assert.NoError(t, err)
+	assert.Equal(t, "ns", metric.Namespace)
+	assert.Equal(t, "cpu", metric.Name)
+}
@@ -0,0 +1,75 @@
+package ingestion
+
+import (
+	"fmt"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/protocol"
+	"github.com/lindb/lindb/replication"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+// NewGraphiteListeners creates the graphite tcp/udp listeners which are enabled(port > 0) in config
+func NewGraphiteListeners(cfg config.Graphite, cm replication.ChannelManager) ([]Listener, error) {
+	if cfg.TCPPort == 0 && cfg.UDPPort == 0 {
+		return nil, nil
+	}
+	if cfg.Database == "" {
+		return nil, fmt.Errorf("database of graphite listener is empty")
+	}
+	parser, err := protocol.NewGraphiteParser(cfg.Namespace, cfg.Templates)
+	if err != nil {
+		return nil, err
+	}
+	var listeners []Listener
+	if cfg.TCPPort > 0 {
+		listeners = append(listeners, newTCPListener(&listenerOption{
+			protocol:  "graphite-tcp",
+			addr:      fmt.Sprintf(":%d", cfg.TCPPort),
+			database:  cfg.Database,
+			batchSize: batchSize(cfg.BatchSize),
+			parser:    parser.ParseLine,
+			cm:        cm,
+		}))
+	}
+	if cfg.UDPPort > 0 {
+		listeners = append(listeners, newUDPListener(&listenerOption{
+			protocol:  "graphite-udp",
+			addr:      fmt.Sprintf(":%d", cfg.UDPPort),
+			database:  cfg.Database,
+			batchSize: batchSize(cfg.BatchSize),
+			parser:    parser.ParseLine,
+			cm:        cm,
+		}))
+	}
+	return listeners, nil
+}
+
+// NewOpenTSDBListeners creates the opentsdb telnet listener if it's enabled(port > 0) in config
+func NewOpenTSDBListeners(cfg config.OpenTSDB, cm replication.ChannelManager) ([]Listener, error) {
+	if cfg.TCPPort == 0 {
+		return nil, nil
+	}
+	if cfg.Database == "" {
+		return nil, fmt.Errorf("database of opentsdb listener is empty")
+	}
+	namespace := cfg.Namespace
+	return []Listener{newTCPListener(&listenerOption{
+		protocol:  "opentsdb-telnet",
+		addr:      fmt.Sprintf(":%d", cfg.TCPPort),
+		database:  cfg.Database,
+		batchSize: batchSize(cfg.BatchSize),
+		parser: func(line string) (*pb.Metric, error) {
+			return protocol.OpenTSDBParseLine(line, namespace)
+		},
+		cm: cm,
+	})}, nil
+}
+
+// batchSize returns the batch size of config, uses default value if not set
+func batchSize(size int) int {
+	if size <= 0 {
+		return defaultBatchSize
+	}
+	return size
+}
@@ -1,96 +1,253 @@
 package middleware
 
 import (
+	"bytes"
+	"context"
 	/* #nosec */
 	"crypto/md5"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
 	"net/http"
-
-	"github.com/lindb/lindb/config"
+	"strings"
+	"time"
 
 	jwt "github.com/dgrijalva/jwt-go"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/service"
 )
 
 //go:generate mockgen -source=./authentication.go -destination=./authentication_mock.go -package=middleware
 
+// userKey represents the key of authenticated user in the context of request
+type userKey struct{}
+
+var (
+	errTokenInvalid = errors.New("authorization token invalid")
+	errTokenExpired = errors.New("authorization token expired")
+)
+
 type Authentication interface {
-	// CreateLToken returns the authentication token
-	CreateToken(user config.User) (string, error)
-	// Validate validates the token
+	// CreateToken returns the authentication token of user, the token expires after the expire time of config
+	CreateToken(userName string) (string, error)
+	// Validate validates the token or the api key of request,
+	// then puts the authenticated user into the context of request.
 	Validate(next http.Handler) http.Handler
+	// Authorize creates middleware which checks if the authenticated user has the permission of database,
+	// the database of request is returned by database func.
+	Authorize(permission models.Permission, database DatabaseFunc) func(next http.Handler) http.Handler
 }
 
-// userAuthentication represents user authentication using jwt
+// DatabaseFunc returns the database name of request for authorization,
+// empty database means the permission of all databases is required.
+type DatabaseFunc func(r *http.Request) string
+
+// userAuthentication represents user authentication using jwt and api key,
+// the admin user of config is the super user, other users are stored in the state's repo.
 type userAuthentication struct {
-	user config.User
+	user        config.User
+	userService service.UserService
 }
 
 // CustomClaims represents jwt custom claims param
-// need username and password and some standard claims
+// need username and some standard claims(expire time)
 type CustomClaims struct {
 	jwt.StandardClaims
 	UserName string `json:"username"`
-	Password string `json:"password"`
 }
 
-// Valid rewrites jwt.Claims valid method return nil
-func (*CustomClaims) Valid() error {
+// Valid validates the expire time of claims, the token without expire time is invalid
+func (c *CustomClaims) Valid() error {
+	if c.ExpiresAt == 0 {
+		return errTokenInvalid
+	}
+	if !c.VerifyExpiresAt(time.Now().Unix(), true) {
+		return errTokenExpired
+	}
 	return nil
 }
 
 // NewAuthentication creates authentication api instance
-func NewAuthentication(user config.User) Authentication {
+func NewAuthentication(user config.User, userService service.UserService) Authentication {
 	return &userAuthentication{
-		user: user,
+		user:        user,
+		userService: userService,
 	}
 }
 
-// Validate creates middleware for user permissions validation by request header Authorization
-// if not authorization throw error
-// else perform the next action
+// Validate creates middleware for user authentication by request header Authorization,
+// the value of header is login token or api key(with optional Bearer prefix),
+// if not authorization throw error, else perform the next action with the authenticated user.
 func (u *userAuthentication) Validate(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		token := r.Header.Get("Authorization")
-		if len(token) > 0 {
-			claims := parseToken(token, u.user)
-			if claims.UserName == u.user.UserName && claims.Password == u.user.Password {
-				next.ServeHTTP(w, r)
-				return
-			}
+		token := strings.TrimSpace(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		if len(token) == 0 {
+			writeError(w, http.StatusUnauthorized, errTokenInvalid)
+			return
+		}
+		user, err := u.authenticate(token)
+		if err != nil {
+			writeError(w, http.StatusUnauthorized, err)
+			return
 		}
-		err := errors.New("authorization token invalid")
-		w.Header().Set("Content-Type", "application/json; charset=utf-8")
-		w.WriteHeader(http.StatusUnauthorized)
-		b, _ := json.Marshal(err.Error())
-		_, _ = w.Write(b)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), userKey{}, user)))
 	})
 }
 
-// ParseToken returns jwt claims by token
-// get secret key use Md5Encrypt method with username and password
-// then jwt parse token by secret key
-func parseToken(tokenString string, user config.User) *CustomClaims {
+// Authorize creates middleware which checks the permission of the authenticated user for the database of request,
+// must be used after Validate middleware.
+func (u *userAuthentication) Authorize(permission models.Permission, database DatabaseFunc) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := UserFromContext(r.Context())
+			if user == nil {
+				writeError(w, http.StatusUnauthorized, errTokenInvalid)
+				return
+			}
+			db := database(r)
+			ok, err := u.userService.Authorize(user, db, permission)
+			if err != nil {
+				writeError(w, http.StatusInternalServerError, err)
+				return
+			}
+			if !ok {
+				if db == "" {
+					db = models.AllDatabases
+				}
+				writeError(w, http.StatusForbidden,
+					fmt.Errorf("user: %s hasn't %s permission of database: %s", user.Name, permission, db))
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// authenticate returns the user by login token or api key
+func (u *userAuthentication) authenticate(token string) (*models.User, error) {
+	// jwt token consists of three parts separated by dot, otherwise it's api key
+	if strings.Count(token, ".") != 2 {
+		return u.userService.AuthenticateAPIKey(token)
+	}
+	claims, err := parseToken(token, u.user)
+	if err != nil {
+		return nil, err
+	}
+	if claims.UserName == u.user.UserName {
+		return &models.User{Name: u.user.UserName, SuperUser: true}, nil
+	}
+	return u.userService.GetUser(claims.UserName)
+}
+
+// parseToken returns jwt claims by token
+// get secret key use Md5Encrypt method with username and password of admin user,
+// then jwt parse token by secret key, returns err if token is invalid or expired.
+func parseToken(tokenString string, user config.User) (*CustomClaims, error) {
 	claims := CustomClaims{}
 	cid := Md5Encrypt(user)
-	_, _ = jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
-		return cid, nil
+	_, err := jwt.ParseWithClaims(tokenString, &claims, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, errTokenInvalid
+		}
+		return []byte(cid), nil
 	})
-	return &claims
+	if err != nil {
+		var validationErr *jwt.ValidationError
+		if errors.As(err, &validationErr) && validationErr.Inner == errTokenExpired {
+			return nil, errTokenExpired
+		}
+		return nil, errTokenInvalid
+	}
+	return &claims, nil
 }
 
-// CreateLToken returns token use jwt with custom claims
-func (u *userAuthentication) CreateToken(user config.User) (string, error) {
+// CreateToken returns token use jwt with custom claims
+func (u *userAuthentication) CreateToken(userName string) (string, error) {
 	claims := CustomClaims{
-		UserName: user.UserName,
-		Password: user.Password,
+		StandardClaims: jwt.StandardClaims{
+			ExpiresAt: time.Now().Add(u.user.TokenExpire.Duration()).Unix(),
+		},
+		UserName: userName,
 	}
-	cid := Md5Encrypt(user)
+	cid := Md5Encrypt(u.user)
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &claims)
 	return token.SignedString([]byte(cid))
 }
 
+// UserFromContext returns the authenticated user from the context of request, returns nil if not authenticated
+func UserFromContext(ctx context.Context) *models.User {
+	user, ok := ctx.Value(userKey{}).(*models.User)
+	if !ok {
+		return nil
+	}
+	return user
+}
+
+// DatabaseFromParam returns the database name by the params of request, the first non-empty param is used
+func DatabaseFromParam(params ...string) DatabaseFunc {
+	return func(r *http.Request) string {
+		// parse form data of post request, also includes the url params
+		_ = r.ParseForm()
+		for _, param := range params {
+			if value := r.Form.Get(param); value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+}
+
+// DatabaseFromJSONBody returns the database name by the field of json body,
+// the body is restored for the next handler.
+func DatabaseFromJSONBody(field string) DatabaseFunc {
+	return func(r *http.Request) string {
+		if r.Body == nil || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			return ""
+		}
+		body, err := ioutil.ReadAll(r.Body)
+		_ = r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+		if err != nil {
+			return ""
+		}
+		fields := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(body, &fields); err != nil {
+			return ""
+		}
+		var database string
+		_ = json.Unmarshal(fields[field], &database)
+		return database
+	}
+}
+
+// FirstDatabase returns the first non-empty database name of database funcs
+func FirstDatabase(databases ...DatabaseFunc) DatabaseFunc {
+	return func(r *http.Request) string {
+		for _, database := range databases {
+			if db := database(r); db != "" {
+				return db
+			}
+		}
+		return ""
+	}
+}
+
+// AllDatabases requires the permission of all databases, e.g. user/role management
+func AllDatabases(_ *http.Request) string {
+	return ""
+}
+
+// writeError writes the error message with http code
+func writeError(w http.ResponseWriter, httpCode int, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(httpCode)
+	b, _ := json.Marshal(err.Error())
+	_, _ = w.Write(b)
+}
+
 // Md5Encrypt returns secret key use Mk5 encryption with username and password
 func Md5Encrypt(user config.User) string {
 	/* #nosec */
@@ -1,63 +1,176 @@
 package middleware
 
 import (
+	"bytes"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
-	"github.com/lindb/lindb/config"
-
+	jwt "github.com/dgrijalva/jwt-go"
+	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/service"
 )
 
-var tokenStr = "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.eyJ1c2VybmFtZSI6ImFkbWluIiwicGF" +
-	"zc3dvcmQiOiJhZG1pbjEyMyJ9.YbNGN0V-U5Y3xOIGNXcgbQkK2VV30UDDEZV19FN62hk"
+var adminUser = config.User{UserName: "admin", Password: "admin123", TokenExpire: ltoml.Duration(time.Minute)}
 
-func Test_ParseToken(t *testing.T) {
-	user := config.User{UserName: "admin", Password: "admin123"}
-	claim := parseToken(tokenStr, user)
-	assert.Equal(t, user.UserName, claim.UserName)
-	assert.Equal(t, user.Password, claim.Password)
-}
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = io.WriteString(w, "ok")
+})
 
 func Test_CreateToken(t *testing.T) {
-	user := config.User{UserName: "admin", Password: "admin123"}
-	u := NewAuthentication(user)
-	token, err := u.CreateToken(user)
-	assert.Equal(t, true, err == nil)
-	assert.Equal(t, tokenStr, token)
+	u := NewAuthentication(adminUser, nil)
+	token, err := u.CreateToken("admin")
+	assert.NoError(t, err)
+	claims, err := parseToken(token, adminUser)
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", claims.UserName)
+	assert.True(t, claims.ExpiresAt > time.Now().Unix())
+
+	// signed by other secret
+	_, err = parseToken(token, config.User{UserName: "admin", Password: "other"})
+	assert.Equal(t, errTokenInvalid, err)
+	// token expired
+	u = NewAuthentication(config.User{UserName: "admin", Password: "admin123", TokenExpire: ltoml.Duration(-time.Minute)}, nil)
+	token, err = u.CreateToken("admin")
+	assert.NoError(t, err)
+	_, err = parseToken(token, adminUser)
+	assert.Equal(t, errTokenExpired, err)
+	// token without expire time
+	token, _ = jwt.NewWithClaims(jwt.SigningMethodHS256, &CustomClaims{UserName: "admin"}).SignedString([]byte(Md5Encrypt(adminUser)))
+	_, err = parseToken(token, adminUser)
+	assert.Equal(t, errTokenInvalid, err)
+	// wrong signing method
+	token, _ = jwt.NewWithClaims(jwt.SigningMethodNone, &CustomClaims{UserName: "admin"}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+	_, err = parseToken(token, adminUser)
+	assert.Equal(t, errTokenInvalid, err)
 }
 
 func TestUserAuthentication_Validate(t *testing.T) {
-	req, err := http.NewRequest("GET", "/health-check", nil)
-	if err != nil {
-		t.Fatal(err)
-	}
-	req.Header.Set("Authorization", "Bearer abc123")
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	rr := httptest.NewRecorder()
-	user := config.User{UserName: "admin", Password: "admin123"}
-	auth := NewAuthentication(user)
+	userService := service.NewMockUserService(ctrl)
+	auth := NewAuthentication(adminUser, userService)
+	var user *models.User
+	handler := auth.Validate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user = UserFromContext(r.Context())
+		okHandler.ServeHTTP(w, r)
+	}))
+
+	// case 1: no token
+	rr := doRequest(handler, "", "")
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	// case 2: invalid token
+	rr = doRequest(handler, "", "Bearer abc.123.456")
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	// case 3: admin user of config
+	token, _ := auth.CreateToken("admin")
+	rr = doRequest(handler, "", "Bearer "+token)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "ok", rr.Body.String())
+	assert.Equal(t, &models.User{Name: "admin", SuperUser: true}, user)
+	// case 4: user of state's repo
+	token, _ = auth.CreateToken("dev")
+	userService.EXPECT().GetUser("dev").Return(&models.User{Name: "dev"}, nil)
+	rr = doRequest(handler, "", token)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, &models.User{Name: "dev"}, user)
+	userService.EXPECT().GetUser("dev").Return(nil, fmt.Errorf("err"))
+	rr = doRequest(handler, "", token)
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	// case 5: api key
+	userService.EXPECT().AuthenticateAPIKey("key").Return(&models.User{Name: "agent"}, nil)
+	rr = doRequest(handler, "", "Bearer key")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, &models.User{Name: "agent"}, user)
+	userService.EXPECT().AuthenticateAPIKey("key").Return(nil, service.ErrAPIKeyInvalid)
+	rr = doRequest(handler, "", "key")
+	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+}
 
-	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-		w.Header().Set("Content-Type", "application/json")
-		_, _ = io.WriteString(w, "ok")
-	})
-	authHandler := auth.Validate(handler)
+func TestUserAuthentication_Authorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
 
-	authHandler.ServeHTTP(rr, req)
+	userService := service.NewMockUserService(ctrl)
+	auth := NewAuthentication(adminUser, userService)
+	handler := auth.Validate(auth.Authorize(models.WritePermission, DatabaseFromParam("db"))(okHandler))
+	userService.EXPECT().AuthenticateAPIKey("key").Return(&models.User{Name: "agent"}, nil).AnyTimes()
 
+	// case 1: not authenticated
+	rr := httptest.NewRecorder()
+	auth.Authorize(models.WritePermission, AllDatabases)(okHandler).
+		ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metric/influx", nil))
 	assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	// case 2: has permission
+	userService.EXPECT().Authorize(gomock.Any(), "db", models.WritePermission).Return(true, nil)
+	rr = doRequest(handler, "/metric/influx?db=db", "key")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	// case 3: no permission
+	userService.EXPECT().Authorize(gomock.Any(), "db", models.WritePermission).Return(false, nil)
+	rr = doRequest(handler, "/metric/influx?db=db", "key")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Equal(t, `"user: agent hasn't write permission of database: db"`, rr.Body.String())
+	userService.EXPECT().Authorize(gomock.Any(), "", models.WritePermission).Return(false, nil)
+	rr = doRequest(handler, "/metric/influx", "key")
+	assert.Equal(t, http.StatusForbidden, rr.Code)
+	assert.Equal(t, `"user: agent hasn't write permission of database: *"`, rr.Body.String())
+	// case 4: authorize err
+	userService.EXPECT().Authorize(gomock.Any(), "db", models.WritePermission).Return(false, fmt.Errorf("err"))
+	rr = doRequest(handler, "/metric/influx?db=db", "key")
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+}
 
-	req, err = http.NewRequest("GET", "/health-check", nil)
-	assert.NoError(t, err)
-	req.Header.Set("Authorization", tokenStr)
-	rr = httptest.NewRecorder()
+func TestDatabaseFunc(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/database?name=db1&db=db2", nil)
+	assert.Equal(t, "db1", DatabaseFromParam("name", "db")(req))
+	assert.Equal(t, "db2", DatabaseFromParam("db")(req))
+	assert.Equal(t, "", DatabaseFromParam("other")(req))
+	assert.Equal(t, "", AllDatabases(req))
 
-	authHandler.ServeHTTP(rr, req)
+	// form data
+	req = httptest.NewRequest(http.MethodPost, "/database", strings.NewReader("db=db3"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	assert.Equal(t, "db3", DatabaseFromParam("db")(req))
 
-	assert.Equal(t, http.StatusOK, rr.Code)
-	assert.Equal(t, "ok", rr.Body.String())
+	// json body
+	database := FirstDatabase(DatabaseFromParam("name"), DatabaseFromJSONBody("name"))
+	body := `{"name":"db4","numOfShard":1}`
+	req = httptest.NewRequest(http.MethodPost, "/database", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	assert.Equal(t, "db4", database(req))
+	// body restored
+	data, _ := ioutil.ReadAll(req.Body)
+	assert.Equal(t, body, string(data))
+	// not json content
+	req = httptest.NewRequest(http.MethodPost, "/database", bytes.NewReader([]byte(body)))
+	assert.Equal(t, "", database(req))
+	// invalid json
+	req = httptest.NewRequest(http.MethodPost, "/database", strings.NewReader("abc"))
+	req.Header.Set("Content-Type", "application/json")
+	assert.Equal(t, "", database(req))
+}
+
+func doRequest(handler http.Handler, url, token string) *httptest.ResponseRecorder {
+	if url == "" {
+		url = "/check/1"
+	}
+	req := httptest.NewRequest(http.MethodGet, url, nil)
+	if token != "" {
+		req.Header.Set("Authorization", token)
+	}
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	return rr
 }
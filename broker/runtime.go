@@ -20,6 +20,7 @@ import (
 	queryAPI "github.com/lindb/lindb/broker/api/query"
 	stateAPI "github.com/lindb/lindb/broker/api/state"
 	"github.com/lindb/lindb/broker/api/write"
+	"github.com/lindb/lindb/broker/ingestion"
 	"github.com/lindb/lindb/broker/middleware"
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
@@ -52,6 +53,7 @@ type srv struct {
 	storageStateService   service.StorageStateService
 	shardAssignService    service.ShardAssignService
 	databaseService       service.DatabaseService
+	userService           service.UserService
 	replicatorStateReport replication.ReplicatorStateReport
 	channelManager        replication.ChannelManager
 	taskManager           parallel.TaskManager
@@ -69,14 +71,23 @@ type apiHandler struct {
 	storageClusterAPI  *admin.StorageClusterAPI
 	databaseAPI        *admin.DatabaseAPI
 	databaseFlusherAPI *admin.DatabaseFlusherAPI
+	seriesDeleteAPI    *admin.SeriesDeleteAPI
+	decommissionAPI    *admin.NodeDecommissionAPI
+	consistencyAPI     *admin.ReplicaConsistencyAPI
+	userAPI            *admin.UserAPI
 	loginAPI           *api.LoginAPI
 	storageStateAPI    *stateAPI.StorageAPI
 	brokerStateAPI     *stateAPI.BrokerAPI
 	masterAPI          *masterAPI.MasterAPI
 	metricAPI          *queryAPI.MetricAPI
 	metadataAPI        *queryAPI.MetadataAPI
+	queryJobAPI        *queryAPI.QueryJobAPI
 	writeAPI           *writeAPI.WriteAPI
 	prometheusWriter   *write.PrometheusWrite
+	promRemoteWriter   *write.PrometheusRemoteWrite
+	promRemoteReader   *queryAPI.PrometheusRemoteRead
+	influxWriter       *write.InfluxWrite
+	openTSDBWriter     *write.OpenTSDBWrite
 }
 
 type rpcHandler struct {
@@ -105,6 +116,7 @@ type runtime struct {
 
 	grpcServer rpc.GRPCServer
 	rpcHandler *rpcHandler
+	listeners  []ingestion.Listener
 
 	middleware *middlewareHandler
 
@@ -137,6 +149,10 @@ func (r *runtime) Name() string {
 
 // Run runs broker server based on config file
 func (r *runtime) Run() error {
+	if err := r.config.BrokerBase.ReplicationChannel.Validate(); err != nil {
+		r.state = server.Failed
+		return err
+	}
 	ip, err := getHostIP()
 	if err != nil {
 		r.state = server.Failed
@@ -176,6 +192,7 @@ func (r *runtime) Run() error {
 		ShardAssignSRV:    r.srv.shardAssignService,
 		DiscoveryFactory:  discoveryFactory,
 		TaskClientFactory: r.factory.taskClient,
+		Zone:              r.config.BrokerBase.Labels[models.LabelZone],
 	})
 
 	// finally start all state machine
@@ -196,6 +213,10 @@ func (r *runtime) Run() error {
 		StorageStateService: r.srv.storageStateService,
 		ShardAssignService:  r.srv.shardAssignService,
 		BrokerSM:            r.stateMachines,
+		Rebalance:           r.config.BrokerBase.Rebalance,
+		Sharding:            r.config.BrokerBase.Sharding,
+		Consistency:         r.config.BrokerBase.Consistency,
+		ClientStreamFactory: rpc.NewClientStreamFactory(r.node),
 	}
 	r.master = coordinator.NewMaster(masterCfg)
 
@@ -206,7 +227,7 @@ func (r *runtime) Run() error {
 
 	// register broker node info
 	//TODO TTL default value???
-	r.registry = discovery.NewRegistry(r.repo, constants.ActiveNodesPath, 1)
+	r.registry = discovery.NewRegistry(r.repo, constants.ActiveNodesPath, 1, r.config.BrokerBase.Labels)
 	if err := r.registry.Register(r.node); err != nil {
 		return fmt.Errorf("register storage node error:%s", err)
 	}
@@ -215,6 +236,12 @@ func (r *runtime) Run() error {
 	// start http server
 	r.startHTTPServer()
 
+	// start graphite/opentsdb listeners
+	if err := r.startListeners(); err != nil {
+		r.state = server.Failed
+		return err
+	}
+
 	// start stat monitoring
 	r.monitoring()
 
@@ -236,6 +263,12 @@ func (r *runtime) Stop() error {
 		r.pusher.Stop()
 	}
 
+	for _, listener := range r.listeners {
+		if err := listener.Close(); err != nil {
+			r.log.Error("close ingestion listener error", logger.Error(err))
+		}
+	}
+
 	if r.httpServer != nil {
 		r.log.Info("starting shutdown http server")
 		if err := r.httpServer.Shutdown(r.ctx); err != nil {
@@ -302,6 +335,25 @@ func (r *runtime) startHTTPServer() {
 	}()
 }
 
+// startListeners starts the graphite/opentsdb listeners which are enabled in config
+func (r *runtime) startListeners() error {
+	graphiteListeners, err := ingestion.NewGraphiteListeners(r.config.BrokerBase.Graphite, r.srv.channelManager)
+	if err != nil {
+		return fmt.Errorf("create graphite listener error:%s", err)
+	}
+	openTSDBListeners, err := ingestion.NewOpenTSDBListeners(r.config.BrokerBase.OpenTSDB, r.srv.channelManager)
+	if err != nil {
+		return fmt.Errorf("create opentsdb listener error:%s", err)
+	}
+	for _, listener := range append(graphiteListeners, openTSDBListeners...) {
+		if err := listener.Start(); err != nil {
+			return fmt.Errorf("start ingestion listener error:%s", err)
+		}
+		r.listeners = append(r.listeners, listener)
+	}
+	return nil
+}
+
 // startStateRepo starts state repository
 func (r *runtime) startStateRepo() error {
 	repo, err := r.repoFactory.CreateRepo(r.config.BrokerBase.Coordinator)
@@ -331,6 +383,7 @@ func (r *runtime) buildServiceDependency() {
 	srv := srv{
 		storageClusterService: service.NewStorageClusterService(r.repo),
 		databaseService:       service.NewDatabaseService(r.repo),
+		userService:           service.NewCachedUserService(r.ctx, r.repo),
 		storageStateService:   service.NewStorageStateService(r.repo),
 		shardAssignService:    service.NewShardAssignService(r.repo),
 		replicatorStateReport: replicatorStateReport,
@@ -347,30 +400,59 @@ func (r *runtime) buildAPIDependency() {
 		storageClusterAPI:  admin.NewStorageClusterAPI(r.srv.storageClusterService),
 		databaseAPI:        admin.NewDatabaseAPI(r.srv.databaseService),
 		databaseFlusherAPI: admin.NewDatabaseFlusherAPI(r.master),
-		loginAPI:           api.NewLoginAPI(r.config.BrokerBase.User, r.middleware.authentication),
+		seriesDeleteAPI:    admin.NewSeriesDeleteAPI(r.master),
+		decommissionAPI:    admin.NewNodeDecommissionAPI(r.master),
+		consistencyAPI:     admin.NewReplicaConsistencyAPI(r.master),
+		userAPI:            admin.NewUserAPI(r.srv.userService),
+		loginAPI:           api.NewLoginAPI(r.config.BrokerBase.User, r.middleware.authentication, r.srv.userService),
 		storageStateAPI:    stateAPI.NewStorageAPI(r.ctx, r.repo, r.stateMachines.StorageSM, r.srv.shardAssignService, r.srv.databaseService),
 		brokerStateAPI:     stateAPI.NewBrokerAPI(r.ctx, r.repo, r.stateMachines.NodeSM),
 		masterAPI:          masterAPI.NewMasterAPI(r.master),
-		metricAPI: queryAPI.NewMetricAPI(r.stateMachines.ReplicaStatusSM,
+		metricAPI: queryAPI.NewMetricAPI(r.config.BrokerBase.Query, r.stateMachines.ReplicaStatusSM,
 			r.stateMachines.NodeSM, r.stateMachines.DatabaseSM, query.NewExecutorFactory(), r.srv.jobManager),
 		metadataAPI: queryAPI.NewMetadataAPI(r.srv.databaseService, r.stateMachines.ReplicaStatusSM,
 			r.stateMachines.NodeSM, query.NewExecutorFactory(), r.srv.jobManager),
+		queryJobAPI:      queryAPI.NewQueryJobAPI(r.srv.jobManager),
 		writeAPI:         writeAPI.NewWriteAPI(r.srv.channelManager),
 		prometheusWriter: write.NewPrometheusWrite(r.srv.channelManager),
+		influxWriter:     write.NewInfluxWrite(r.srv.channelManager),
+		promRemoteWriter: write.NewPrometheusRemoteWrite(r.srv.channelManager),
+		openTSDBWriter:   write.NewOpenTSDBWrite(r.srv.channelManager, r.config.BrokerBase.OpenTSDB),
+		promRemoteReader: queryAPI.NewPrometheusRemoteRead(r.config.BrokerBase.Query, r.stateMachines.ReplicaStatusSM,
+			r.stateMachines.NodeSM, r.stateMachines.DatabaseSM, query.NewExecutorFactory(), r.srv.jobManager),
 	}
 
 	api.AddRoute("Login", http.MethodPost, "/login", handlers.loginAPI.Login)
+	api.AddRoute("HealthCheck", http.MethodGet, "/health", api.HealthCheck)
 	api.AddRoute("Check", http.MethodGet, "/check/1", handlers.loginAPI.Check)
 
 	api.AddRoute("SaveStorageCluster", http.MethodPost, "/storage/cluster", handlers.storageClusterAPI.Create)
 	api.AddRoute("GetStorageCluster", http.MethodGet, "/storage/cluster", handlers.storageClusterAPI.GetByName)
 	api.AddRoute("DeleteStorageCluster", http.MethodDelete, "/storage/cluster", handlers.storageClusterAPI.DeleteByName)
 	api.AddRoute("ListStorageClusters", http.MethodGet, "/storage/cluster/list", handlers.storageClusterAPI.List)
+	api.AddRoute("DecommissionStorageNode", http.MethodPost, "/storage/cluster/node/decommission",
+		handlers.decommissionAPI.Decommission)
+	api.AddRoute("GetReplicaDivergences", http.MethodGet, "/storage/cluster/consistency",
+		handlers.consistencyAPI.GetDivergences)
 
 	api.AddRoute("CreateOrUpdateDatabase", http.MethodPost, "/database", handlers.databaseAPI.Save)
 	api.AddRoute("GetDatabase", http.MethodGet, "/database", handlers.databaseAPI.GetByName)
+	api.AddRoute("DropDatabase", http.MethodDelete, "/database", handlers.databaseAPI.Delete)
 	api.AddRoute("ListDatabase", http.MethodGet, "/database/list", handlers.databaseAPI.List)
 	api.AddRoute("FLushDatabase", http.MethodGet, "/database/flush", handlers.databaseFlusherAPI.SubmitFlushTask)
+	api.AddRoute("DeleteSeries", http.MethodDelete, "/series", handlers.seriesDeleteAPI.DeleteSeries)
+
+	api.AddRoute("SaveUser", http.MethodPost, "/user", handlers.userAPI.SaveUser)
+	api.AddRoute("GetUser", http.MethodGet, "/user", handlers.userAPI.GetUser)
+	api.AddRoute("DeleteUser", http.MethodDelete, "/user", handlers.userAPI.DeleteUser)
+	api.AddRoute("ListUsers", http.MethodGet, "/user/list", handlers.userAPI.ListUsers)
+	api.AddRoute("SaveRole", http.MethodPost, "/role", handlers.userAPI.SaveRole)
+	api.AddRoute("GetRole", http.MethodGet, "/role", handlers.userAPI.GetRole)
+	api.AddRoute("DeleteRole", http.MethodDelete, "/role", handlers.userAPI.DeleteRole)
+	api.AddRoute("ListRoles", http.MethodGet, "/role/list", handlers.userAPI.ListRoles)
+	api.AddRoute("CreateAPIKey", http.MethodPost, "/apikey", handlers.userAPI.CreateAPIKey)
+	api.AddRoute("DeleteAPIKey", http.MethodDelete, "/apikey", handlers.userAPI.DeleteAPIKey)
+	api.AddRoute("ListAPIKeys", http.MethodGet, "/apikey/list", handlers.userAPI.ListAPIKeys)
 
 	api.AddRoute("ListStorageClusterNodesState", http.MethodGet, "/storage/cluster/state", handlers.storageStateAPI.GetStorageClusterState)
 	api.AddRoute("ListStorageClusterState", http.MethodGet, "/storage/cluster/state/list", handlers.storageStateAPI.ListStorageClusterState)
@@ -380,24 +462,59 @@ func (r *runtime) buildAPIDependency() {
 
 	api.AddRoute("QueryMetric", http.MethodGet, "/query/metric", handlers.metricAPI.Search)
 	api.AddRoute("QueryMetadata", http.MethodGet, "/query/metadata", handlers.metadataAPI.Handle)
+	api.AddRoute("QueryJob", http.MethodGet, "/query/job", handlers.queryJobAPI.Handle)
+	api.AddRoute("QueryJobPost", http.MethodPost, "/query/job", handlers.queryJobAPI.Handle)
 
 	api.AddRoute("WriteSumMetric", http.MethodPut, "/metric/sum", handlers.writeAPI.Sum)
 	api.AddRoute("PrometheusWriter", http.MethodPut, "/metric/prometheus", handlers.prometheusWriter.Write)
+	api.AddRoute("InfluxWriter", http.MethodPut, "/metric/influx", handlers.influxWriter.Write)
+	api.AddRoute("InfluxWriterPost", http.MethodPost, "/metric/influx", handlers.influxWriter.Write)
+	api.AddRoute("PrometheusRemoteWriter", http.MethodPost, "/api/v1/prom/write", handlers.promRemoteWriter.Write)
+	api.AddRoute("PrometheusRemoteReader", http.MethodPost, "/api/v1/prom/read", handlers.promRemoteReader.Read)
+	api.AddRoute("OpenTSDBWriter", http.MethodPost, "/api/put", handlers.openTSDBWriter.Write)
 }
 
 // buildMiddlewareDependency builds middleware dependency
 // pattern support regexp matching
 func (r *runtime) buildMiddlewareDependency() {
+	auth := middleware.NewAuthentication(r.config.BrokerBase.User, r.srv.userService)
 	r.middleware = &middlewareHandler{
-		authentication: middleware.NewAuthentication(r.config.BrokerBase.User),
+		authentication: auth,
 	}
 	httpAPI, err := regexp.Compile("/*")
 	if err == nil {
 		api.AddMiddleware(middleware.AccessLogMiddleware, httpAPI)
 	}
-	validate, err := regexp.Compile("/check/*")
+	// all apis are protected by default, except login and health check
+	validate, err := regexp.Compile("^/")
 	if err == nil {
-		api.AddMiddleware(r.middleware.authentication.Validate, validate)
+		api.AddMiddlewareExclude(auth.Validate, validate, regexp.MustCompile("^/(login|health)$"))
+	}
+	// authorization must be added after validation, the middleware is applied by the adding order
+	database := middleware.FirstDatabase(middleware.DatabaseFromParam("name", "db"), middleware.DatabaseFromJSONBody("name"))
+	// opentsdb writes into the database of config if no db param
+	openTSDBDatabase := middleware.FirstDatabase(middleware.DatabaseFromParam("db"), func(_ *http.Request) string {
+		return r.config.BrokerBase.OpenTSDB.Database
+	})
+	authorizations := []struct {
+		pattern    string
+		permission models.Permission
+		database   middleware.DatabaseFunc
+	}{
+		{pattern: "^/query/(metric|metadata)$", permission: models.ReadPermission, database: middleware.DatabaseFromParam("db")},
+		{pattern: "^/api/v1/prom/read$", permission: models.ReadPermission, database: middleware.DatabaseFromParam("db")},
+		{pattern: "^/metric/", permission: models.WritePermission, database: middleware.DatabaseFromParam("db")},
+		{pattern: "^/api/v1/prom/write$", permission: models.WritePermission, database: middleware.DatabaseFromParam("db")},
+		{pattern: "^/api/put$", permission: models.WritePermission, database: openTSDBDatabase},
+		{pattern: "^/(database|series)(/|$)", permission: models.AdminPermission, database: database},
+		{pattern: "^/(query/job|user|role|apikey)(/|$)", permission: models.AdminPermission, database: middleware.AllDatabases},
+		{pattern: "^/(storage|broker|cluster)(/|$)", permission: models.AdminPermission, database: middleware.AllDatabases},
+	}
+	for _, authorization := range authorizations {
+		pattern, err := regexp.Compile(authorization.pattern)
+		if err == nil {
+			api.AddMiddleware(auth.Authorize(authorization.permission, authorization.database), pattern)
+		}
 	}
 }
 
@@ -51,7 +51,6 @@ var cfg = config.Broker{
 			DataSizeLimit:      128,
 			RemoveTaskInterval: ltoml.Duration(time.Minute),
 			CheckFlushInterval: ltoml.Duration(time.Second),
-			FlushInterval:      ltoml.Duration(time.Second * 5),
 			BufferSize:         128,
 		},
 	}}
@@ -98,6 +97,15 @@ func (ts *testBrokerRuntimeSuite) TestBrokerRun_GetHost_Err(c *check.C) {
 	}
 }
 
+func (ts *testBrokerRuntimeSuite) TestBrokerRun_OverflowPolicy_Err(c *check.C) {
+	brokerCfg := cfg
+	brokerCfg.BrokerBase.ReplicationChannel.OverflowPolicy = "unknown"
+	broker := NewBrokerRuntime("test-version", brokerCfg)
+	err := broker.Run()
+	c.Assert(err, check.NotNil)
+	c.Assert(server.Failed, check.Equals, broker.State())
+}
+
 func (ts *testBrokerRuntimeSuite) TestBroker_Run_Err(c *check.C) {
 	ctrl := gomock.NewController(ts.t)
 	defer ctrl.Finish()
@@ -0,0 +1,282 @@
+package lind
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
+	kvversion "github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/queue"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+	"github.com/lindb/lindb/tsdb/wal"
+)
+
+var (
+	inspectPath     string
+	inspectVerify   string
+	inspectMetricID uint32
+	inspectLimit    int
+	inspectWALType  string
+)
+
+// newToolCmd returns a new tool-cmd, which inspects the files of storage offline.
+func newToolCmd() *cobra.Command {
+	toolCmd := &cobra.Command{
+		Use:   "tool",
+		Short: "Inspect the data files of storage offline, the storage node should be stopped",
+	}
+	sstCmd.Flags().StringVar(&inspectPath, "path", "", "path of sst file")
+	sstCmd.Flags().StringVar(&inspectVerify, "verify", "footer", "checksum verify mode(off/footer/full)")
+	_ = sstCmd.MarkFlagRequired("path")
+
+	metricDataCmd.Flags().StringVar(&inspectPath, "path", "", "path of sst file in metric data family")
+	metricDataCmd.Flags().Uint32Var(&inspectMetricID, "metric", 0, "only decodes the block of metric id if not 0")
+	metricDataCmd.Flags().IntVar(&inspectLimit, "limit", 100, "max num. of series decoded in each block, 0 means no limit")
+	_ = metricDataCmd.MarkFlagRequired("path")
+
+	manifestCmd.Flags().StringVar(&inspectPath, "path", "", "path of kv store")
+	_ = manifestCmd.MarkFlagRequired("path")
+
+	walCmd.Flags().StringVar(&inspectPath, "path", "", "path of wal directory")
+	walCmd.Flags().StringVar(&inspectWALType, "type", "meta", "type of wal(meta/series/data)")
+	_ = walCmd.MarkFlagRequired("path")
+
+	queueCmd.Flags().StringVar(&inspectPath, "path", "", "path of replication queue directory")
+	_ = queueCmd.MarkFlagRequired("path")
+
+	toolCmd.AddCommand(
+		sstCmd,
+		metricDataCmd,
+		manifestCmd,
+		walCmd,
+		queueCmd,
+	)
+	return toolCmd
+}
+
+// sstCmd dumps the footer and keys of sst file
+var sstCmd = &cobra.Command{
+	Use:   "sst",
+	Short: "dump the footer, keys and value sizes of sst file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		verifyMode, err := table.ParseVerifyMode(inspectVerify)
+		if err != nil {
+			return err
+		}
+		info, reader, err := table.Inspect(inspectPath, verifyMode)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = reader.Close()
+		}()
+		out := cmd.OutOrStdout()
+		printSSTInfo(out, info)
+		it := reader.Iterator()
+		for it.HasNext() {
+			key := it.Key()
+			value, err := it.Value()
+			if err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(out, "key: %d, size: %d\n", key, len(value))
+		}
+		return nil
+	},
+}
+
+// metricDataCmd decodes the metric blocks of sst file in metric data family
+var metricDataCmd = &cobra.Command{
+	Use:   "metric-data",
+	Short: "decode the metric blocks(series ids, fields, time slots and values) of sst file in metric data family",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info, reader, err := table.Inspect(inspectPath, table.VerifyFooter)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = reader.Close()
+		}()
+		out := cmd.OutOrStdout()
+		printSSTInfo(out, info)
+		it := reader.Iterator()
+		for it.HasNext() {
+			metricID := it.Key()
+			value, err := it.Value()
+			if err != nil {
+				return err
+			}
+			if inspectMetricID != 0 && inspectMetricID != metricID {
+				continue
+			}
+			if err := printMetricBlock(out, metricID, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+// manifestCmd prints the edit logs of current manifest and current version of each family in kv store
+var manifestCmd = &cobra.Command{
+	Use:   "manifest",
+	Short: "print the edit logs of current manifest and current version of each family in kv store",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		manifest, err := kv.ReadStoreManifest(inspectPath)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		familyName := func(familyID kvversion.FamilyID) string {
+			if familyID == kvversion.StoreFamilyID {
+				return "store"
+			}
+			if option, ok := manifest.Families[familyID]; ok {
+				return option.Name
+			}
+			return "unknown"
+		}
+		_, _ = fmt.Fprintf(out, "store: %s, manifest: %s, levels: %d\n",
+			manifest.Path, manifest.Manifest.FileName, manifest.Option.Levels)
+		_, _ = fmt.Fprintf(out, "edit logs:\n")
+		for idx, editLog := range manifest.Manifest.EditLogs {
+			_, _ = fmt.Fprintf(out, "  [%d] family: %s(%d), logs: %s\n",
+				idx, familyName(editLog.FamilyID()), editLog.FamilyID(), editLog)
+		}
+		_, _ = fmt.Fprintf(out, "next file number: %d\n", manifest.NextFileNumber)
+		familyIDs := make([]int, 0, len(manifest.Versions))
+		for familyID := range manifest.Versions {
+			familyIDs = append(familyIDs, familyID.Int())
+		}
+		sort.Ints(familyIDs)
+		for _, id := range familyIDs {
+			familyID := kvversion.FamilyID(id)
+			v := manifest.Versions[familyID]
+			_, _ = fmt.Fprintf(out, "family: %s(%d)\n", familyName(familyID), familyID)
+			for level := 0; level < manifest.Option.Levels; level++ {
+				for _, file := range v.GetFiles(level) {
+					_, _ = fmt.Fprintf(out, "  level: %d, file: %s\n", level, file)
+				}
+			}
+			for fileNumber, interval := range v.GetRollupFiles() {
+				_, _ = fmt.Fprintf(out, "  rollup file: %d, interval: %d\n", fileNumber, interval.Int64())
+			}
+			for source, fileNumbers := range v.GetReferenceFiles() {
+				_, _ = fmt.Fprintf(out, "  reference files: %v, source store: %s, source family id: %d\n",
+					fileNumbers, source.Store, source.FamilyID)
+			}
+		}
+		return nil
+	},
+}
+
+// walCmd lists the entries of metric meta/series/data wal
+var walCmd = &cobra.Command{
+	Use:   "wal",
+	Short: "list the entries of metric meta/series/data wal, includes the entries which are not committed",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		count := 0
+		var err error
+		switch strings.ToLower(inspectWALType) {
+		case "meta":
+			err = wal.ReadMetricMetaWAL(inspectPath,
+				func(namespace, metricName string, metricID uint32) error {
+					count++
+					_, _ = fmt.Fprintf(out, "metric: namespace=%s, name=%s, id=%d\n", namespace, metricName, metricID)
+					return nil
+				}, func(metricID uint32, fID field.ID, fieldName field.Name, fType field.Type) error {
+					count++
+					_, _ = fmt.Fprintf(out, "field: metric=%d, id=%d, name=%s, type=%s\n", metricID, fID, fieldName, fType)
+					return nil
+				}, func(metricID uint32, tagKeyID uint32, tagKey string) error {
+					count++
+					_, _ = fmt.Fprintf(out, "tag key: metric=%d, id=%d, key=%s\n", metricID, tagKeyID, tagKey)
+					return nil
+				})
+		case "series":
+			err = wal.ReadSeriesWAL(inspectPath, func(metricID uint32, tagsHash uint64, seriesID uint32) error {
+				count++
+				_, _ = fmt.Fprintf(out, "series: metric=%d, tagsHash=%d, id=%d\n", metricID, tagsHash, seriesID)
+				return nil
+			})
+		case "data":
+			err = wal.ReadDataWAL(inspectPath, func(replicaPeer string, seq int64, metricList []byte) error {
+				count++
+				_, _ = fmt.Fprintf(out, "data: replica=%s, seq=%d, size=%d\n", replicaPeer, seq, len(metricList))
+				return nil
+			})
+		default:
+			return fmt.Errorf("unknown wal type: %s", inspectWALType)
+		}
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintf(out, "total entries: %d\n", count)
+		return nil
+	},
+}
+
+// queueCmd shows the head/tail/ack sequences of replication queue
+var queueCmd = &cobra.Command{
+	Use:   "queue",
+	Short: "show the head/tail/ack sequences of replication queue and its fan outs",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info, err := queue.ReadInfo(inspectPath)
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		_, _ = fmt.Fprintf(out, "queue: head=%d, tail=%d, expireDataPage=%d, expireIndexPage=%d\n",
+			info.HeadSeq, info.TailSeq, info.ExpireDataPage, info.ExpireIndexPage)
+		_, _ = fmt.Fprintf(out, "data pages: %v\nindex pages: %v\n", info.DataPages, info.IndexPages)
+		for _, fanOut := range info.FanOuts {
+			_, _ = fmt.Fprintf(out, "fan out: %s, head(next consume)=%d, ack=%d, pending=%d\n",
+				fanOut.Name, fanOut.HeadSeq, fanOut.TailSeq, info.HeadSeq-fanOut.HeadSeq)
+		}
+		return nil
+	},
+}
+
+// printSSTInfo prints the footer and index info of sst file
+func printSSTInfo(out io.Writer, info *table.FileInfo) {
+	_, _ = fmt.Fprintf(out, "file: %s\n", info.Path)
+	_, _ = fmt.Fprintf(out, "size: %d, version: %d, compression: %s\n", info.Size, info.Version, info.Compression)
+	_, _ = fmt.Fprintf(out, "posOfOffset: %d, posOfKeys: %d, indexChecksum: %d\n",
+		info.PosOfOffset, info.PosOfKeys, info.IndexChecksum)
+	_, _ = fmt.Fprintf(out, "keys: %d, minKey: %d, maxKey: %d\n", info.NumOfKeys, info.MinKey, info.MaxKey)
+}
+
+// printMetricBlock decodes the metric block, then prints the series data
+func printMetricBlock(out io.Writer, metricID uint32, block []byte) error {
+	reader, err := metricsdata.NewReader(inspectPath, block)
+	if err != nil {
+		return fmt.Errorf("decode block of metric[%d] error:%s", metricID, err)
+	}
+	start, end := reader.GetTimeRange()
+	_, _ = fmt.Fprintf(out, "metric: %d, slots: [%d,%d], series: %d, fields: %v\n",
+		metricID, start, end, reader.GetSeriesIDs().GetCardinality(), reader.GetFields())
+	count := 0
+	return metricsdata.ScanSeries(reader, func(series *metricsdata.SeriesData) bool {
+		if inspectLimit > 0 && count >= inspectLimit {
+			_, _ = fmt.Fprintf(out, "  ...\n")
+			return false
+		}
+		count++
+		_, _ = fmt.Fprintf(out, "  series: %d\n", series.SeriesID)
+		for _, f := range series.Fields {
+			points := make([]string, len(f.Slots))
+			for idx, slot := range f.Slots {
+				points[idx] = fmt.Sprintf("%d=%v", slot, f.Values[idx])
+			}
+			_, _ = fmt.Fprintf(out, "    field: %d(%s), points: [%s]\n", f.Field.ID, f.Field.Type, strings.Join(points, ","))
+		}
+		return true
+	})
+}
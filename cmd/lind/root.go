@@ -34,5 +34,6 @@ func init() {
 		newStorageCmd(),
 		newBrokerCmd(),
 		newStandaloneCmd(),
+		newToolCmd(),
 	)
 }
@@ -2,8 +2,15 @@ package lind
 
 import (
 	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strings"
 
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/storage"
@@ -37,10 +44,146 @@ func newStorageCmd() *cobra.Command {
 		runStorageCmd,
 		initializeStorageConfigCmd,
 		databaseCmd,
+		scrubCmd,
+		backupCmd,
+		restoreCmd,
 	)
 	return storageCmd
 }
 
+var (
+	scrubDatabase string
+	scrubRepair   bool
+)
+
+// scrubCmd verifies all checksums of the database's data files, the storage node must be stopped when scrubbing.
+var scrubCmd = &cobra.Command{
+	Use:   "scrub",
+	Short: "verify the checksums of database's data files, and quarantine the corrupted files if repair",
+	RunE:  scrubStorage,
+}
+
+func init() {
+	scrubCmd.Flags().StringVar(&cfg, "config", "",
+		fmt.Sprintf("storage config file path, default is %s", defaultStorageCfgFile))
+	scrubCmd.Flags().StringVar(&scrubDatabase, "db", "", "database name which need scrub")
+	scrubCmd.Flags().BoolVar(&scrubRepair, "repair", false,
+		"remove the corrupted files from version, then move them into quarantine directory")
+	_ = scrubCmd.MarkFlagRequired("db")
+}
+
+func scrubStorage(cmd *cobra.Command, args []string) error {
+	storageCfg := config.Storage{}
+	if err := ltoml.LoadConfig(cfg, defaultStorageCfgFile, &storageCfg); err != nil {
+		return fmt.Errorf("decode config file error: %s", err)
+	}
+	dbPath := filepath.Join(storageCfg.StorageBase.TSDB.Dir, scrubDatabase)
+	if !fileutil.Exist(dbPath) {
+		return fmt.Errorf("database[%s] not exist in path: %s", scrubDatabase, dbPath)
+	}
+	results, err := kv.ScrubStores(dbPath, scrubRepair)
+	if err != nil {
+		return err
+	}
+	out := cmd.OutOrStdout()
+	files, corrupted := 0, 0
+	for _, result := range results {
+		files += result.Files
+		corrupted += len(result.Corrupted)
+		for _, file := range result.Corrupted {
+			_, _ = fmt.Fprintf(out, "corrupted: store=%s, family=%s, level=%d, file=%d, error=%s\n",
+				result.Path, file.Family, file.Level, file.FileNumber, file.Err)
+		}
+	}
+	_, _ = fmt.Fprintf(out, "scrub database[%s] completed, stores: %d, files: %d, corrupted: %d\n",
+		scrubDatabase, len(results), files, corrupted)
+	switch {
+	case corrupted == 0:
+		return nil
+	case scrubRepair:
+		_, _ = fmt.Fprintf(out, "corrupted files are quarantined, replicate data from other replicas if need\n")
+		return nil
+	default:
+		return fmt.Errorf("found %d corrupted files, use --repair to quarantine them", corrupted)
+	}
+}
+
+var (
+	backupDatabase      string
+	backupPath          string
+	restoreShardMapping string
+)
+
+// backupCmd backups the database of running storage node into the path of storage node
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "backup the database of running storage node into target path",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := url.Values{}
+		params.Set("db", backupDatabase)
+		params.Set("path", backupPath)
+		return requestStorageAdmin(cmd, "/database/backup", params)
+	},
+}
+
+// restoreCmd restores the backup as a new database of running storage node,
+// shards can be restored as other shard ids with shard mapping when shard assignment is changed.
+var restoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "restore the backup as a new database of running storage node",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		params := url.Values{}
+		params.Set("path", backupPath)
+		params.Set("db", backupDatabase)
+		params.Set("shardMapping", restoreShardMapping)
+		return requestStorageAdmin(cmd, "/database/restore", params)
+	},
+}
+
+func init() {
+	backupCmd.Flags().StringVar(&cfg, "config", "",
+		fmt.Sprintf("storage config file path, default is %s", defaultStorageCfgFile))
+	backupCmd.Flags().StringVar(&backupDatabase, "db", "", "database name which need backup")
+	backupCmd.Flags().StringVar(&backupPath, "path", "", "target path of backup under backup dir of storage config, which must not exist")
+	_ = backupCmd.MarkFlagRequired("db")
+	_ = backupCmd.MarkFlagRequired("path")
+
+	restoreCmd.Flags().StringVar(&cfg, "config", "",
+		fmt.Sprintf("storage config file path, default is %s", defaultStorageCfgFile))
+	restoreCmd.Flags().StringVar(&backupPath, "path", "", "path of backup under backup dir of storage config")
+	restoreCmd.Flags().StringVar(&backupDatabase, "db", "",
+		"database name which restored as, default is the database name of backup")
+	restoreCmd.Flags().StringVar(&restoreShardMapping, "shard-mapping", "",
+		"restore shards as other shard ids, e.g. 1:3,2:4 means restoring shard 1 as 3 and shard 2 as 4")
+	_ = restoreCmd.MarkFlagRequired("path")
+}
+
+// requestStorageAdmin posts the admin request to the http server of local storage node, then prints the response
+func requestStorageAdmin(cmd *cobra.Command, api string, params url.Values) error {
+	storageCfg := config.Storage{}
+	if err := ltoml.LoadConfig(cfg, defaultStorageCfgFile, &storageCfg); err != nil {
+		return fmt.Errorf("decode config file error: %s", err)
+	}
+	// http server of storage node listens on grpc port + 1
+	address := fmt.Sprintf("http://localhost:%d%s", storageCfg.StorageBase.GRPC.Port+1, api)
+	resp, err := http.Post(address, "application/x-www-form-urlencoded", strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request storage node error, code: %d, message: %s", resp.StatusCode, body)
+	}
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "%s\n", body)
+	return nil
+}
+
 var initializeStorageConfigCmd = &cobra.Command{
 	Use:   "init-config",
 	Short: "create a new default storage-config",
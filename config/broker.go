@@ -1,8 +1,11 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/lindb/lindb/pkg/ltoml"
@@ -25,17 +28,22 @@ func (h *HTTP) TOML() string {
 
 // User represents user model
 type User struct {
-	UserName string `toml:"username" json:"username"`
-	Password string `toml:"password" json:"password"`
+	UserName    string         `toml:"username" json:"username"`
+	Password    string         `toml:"password" json:"password"`
+	TokenExpire ltoml.Duration `toml:"token-expire" json:"-"`
 }
 
 func (u *User) TOML() string {
 	return fmt.Sprintf(`
-    ## admin user setting
+    ## admin user setting, the admin user is the super user which has all permissions,
+    ## other users/roles are managed by the api of broker.
     username = "%s"
-    password = "%s"`,
+    password = "%s"
+    ## the expire time of login token
+    token-expire = "%s"`,
 		u.UserName,
-		u.Password)
+		u.Password,
+		u.TokenExpire.String())
 }
 
 type TCP struct {
@@ -48,15 +56,88 @@ func (t *TCP) TOML() string {
 		t.Port)
 }
 
+// Graphite represents the graphite plaintext protocol(path value timestamp) listeners of broker
+type Graphite struct {
+	TCPPort   uint16   `toml:"tcp-port"`
+	UDPPort   uint16   `toml:"udp-port"`
+	Database  string   `toml:"database"`
+	Namespace string   `toml:"namespace"`
+	Templates []string `toml:"templates"`
+	BatchSize int      `toml:"batch-size"`
+}
+
+func (g *Graphite) TOML() string {
+	templates, _ := json.Marshal(g.Templates)
+	return fmt.Sprintf(`
+    ## which port graphite tcp/udp listener is listening on, 0 means disabled
+    tcp-port = %d
+    udp-port = %d
+    ## database/namespace which graphite metrics are written into
+    database = "%s"
+    namespace = "%s"
+    ## templates map the dotted path to metric name, field and tags, format: [filter] template [tag1=v1,tag2=v2],
+    ## the first template which filter matches path is used, e.g. "servers.* .host.measurement.field region=us"
+    templates = %s
+    ## max num. of metrics written in one batch
+    batch-size = %d`,
+		g.TCPPort,
+		g.UDPPort,
+		g.Database,
+		g.Namespace,
+		templates,
+		g.BatchSize,
+	)
+}
+
+// OpenTSDB represents the opentsdb telnet protocol listener and http api(/api/put) of broker
+type OpenTSDB struct {
+	TCPPort   uint16 `toml:"tcp-port"`
+	Database  string `toml:"database"`
+	Namespace string `toml:"namespace"`
+	BatchSize int    `toml:"batch-size"`
+}
+
+func (o *OpenTSDB) TOML() string {
+	return fmt.Sprintf(`
+    ## which port opentsdb telnet listener is listening on, 0 means disabled
+    tcp-port = %d
+    ## database/namespace which opentsdb metrics are written into,
+    ## the http api(/api/put) uses the database/namespace if not set in request(db/ns params)
+    database = "%s"
+    namespace = "%s"
+    ## max num. of metrics written in one batch
+    batch-size = %d`,
+		o.TCPPort,
+		o.Database,
+		o.Namespace,
+		o.BatchSize,
+	)
+}
+
+// OverflowPolicy represents how to handle the write when the pending data of database exceeds the quota
+type OverflowPolicy string
+
+const (
+	// OverflowBlock blocks the write until the pending data is under quota, rejects the write if timeout
+	OverflowBlock OverflowPolicy = "block"
+	// OverflowReject rejects the write directly
+	OverflowReject OverflowPolicy = "reject"
+	// OverflowDropOldest drops the oldest pending data which isn't replicated to all replicas, then accepts the write
+	OverflowDropOldest OverflowPolicy = "drop-oldest"
+)
+
 // ReplicationChannel represents config for data replication in broker.
 type ReplicationChannel struct {
-	Dir                string         `toml:"dir"`
-	DataSizeLimit      int64          `toml:"data-size-limit"`
-	RemoveTaskInterval ltoml.Duration `toml:"remove-task-interval"`
-	ReportInterval     ltoml.Duration `toml:"report-interval"` // replicator state report interval
-	CheckFlushInterval ltoml.Duration `toml:"check-flush-interval"`
-	FlushInterval      ltoml.Duration `toml:"flush-interval"`
-	BufferSize         int            `toml:"buffer-size"`
+	Dir                   string           `toml:"dir"`
+	DataSizeLimit         int64            `toml:"data-size-limit"`
+	RemoveTaskInterval    ltoml.Duration   `toml:"remove-task-interval"`
+	ReportInterval        ltoml.Duration   `toml:"report-interval"` // replicator state report interval
+	CheckFlushInterval    ltoml.Duration   `toml:"check-flush-interval"`
+	BufferSize            int              `toml:"buffer-size"`
+	MaxPendingPerDatabase int64            `toml:"max-pending-per-database"`
+	DatabaseMaxPending    map[string]int64 `toml:"database-max-pending"` // database => max pending, overrides the default quota
+	OverflowPolicy        OverflowPolicy   `toml:"overflow-policy"`
+	BlockTimeout          ltoml.Duration   `toml:"block-timeout"`
 }
 
 func (rc *ReplicationChannel) GetDataSizeLimit() int64 {
@@ -73,6 +154,46 @@ func (rc *ReplicationChannel) BufferSizeInBytes() int {
 	return rc.BufferSize
 }
 
+// GetMaxPending returns the max num. of pending msg in the replication queues of database, 0 means no limit
+func (rc *ReplicationChannel) GetMaxPending(database string) int64 {
+	if maxPending, ok := rc.DatabaseMaxPending[database]; ok {
+		return maxPending
+	}
+	return rc.MaxPendingPerDatabase
+}
+
+// GetOverflowPolicy returns the overflow policy, returns block if not set
+func (rc *ReplicationChannel) GetOverflowPolicy() OverflowPolicy {
+	if rc.OverflowPolicy == "" {
+		return OverflowBlock
+	}
+	return rc.OverflowPolicy
+}
+
+// Validate checks if the overflow policy is valid
+func (rc *ReplicationChannel) Validate() error {
+	switch rc.GetOverflowPolicy() {
+	case OverflowBlock, OverflowReject, OverflowDropOldest:
+		return nil
+	default:
+		return fmt.Errorf("unknown overflow policy of replication channel: %s", rc.OverflowPolicy)
+	}
+}
+
+// databaseMaxPendingTOML returns the inline table of database quotas, sorted by database name
+func (rc *ReplicationChannel) databaseMaxPendingTOML() string {
+	databases := make([]string, 0, len(rc.DatabaseMaxPending))
+	for database := range rc.DatabaseMaxPending {
+		databases = append(databases, database)
+	}
+	sort.Strings(databases)
+	items := make([]string, 0, len(databases))
+	for _, database := range databases {
+		items = append(items, fmt.Sprintf("%q = %d", database, rc.DatabaseMaxPending[database]))
+	}
+	return "{" + strings.Join(items, ", ") + "}"
+}
+
 func (rc *ReplicationChannel) TOML() string {
 	return fmt.Sprintf(`
     ## WAL mmaped log directory
@@ -88,21 +209,106 @@ func (rc *ReplicationChannel) TOML() string {
     ## replicator state report interval
     report-interval = "%s"
 
-    ## interval for how often buffer will be checked if it's available to flush
+    ## interval for how often the replication queue will be synced, the write rejected by full queue
+    ## is accepted again after syncing if the replicated data is truncated
     check-flush-interval = "%s"
 
-    ## interval for how often data will be flushed if data not exceeds the buffer-size
-    flush-interval = "%s"
+    ## max num. of metrics in a msg of replication queue, the metrics of a write are appended
+    ## into the queue before responding
+    buffer-size = %d
+
+    ## max num. of pending msg which isn't replicated to all replicas in the queues of each database,
+    ## the write is handled by overflow-policy if exceeds, 0 means no limit
+    max-pending-per-database = %d
+
+    ## max num. of pending msg of the databases which have different quota, e.g. {"db1" = 1000}
+    database-max-pending = %s
+
+    ## how to handle the write if the pending msg of database exceeds the quota:
+    ## block: waits for the pending msg replicated until block-timeout, then rejects the write
+    ## reject: rejects the write with http status 429(too many requests)
+    ## drop-oldest: drops the oldest pending msg, the storage nodes lose the dropped data
+    overflow-policy = "%s"
 
-    ## will flush if this size of data in kegabytes get buffered
-    buffer-size = %d`,
+    ## max waiting time of the write if overflow-policy is block
+    block-timeout = "%s"`,
 		rc.Dir,
 		rc.DataSizeLimit,
 		rc.RemoveTaskInterval.String(),
 		rc.ReportInterval.String(),
 		rc.CheckFlushInterval.String(),
-		rc.FlushInterval.String(),
 		rc.BufferSize,
+		rc.MaxPendingPerDatabase,
+		rc.databaseMaxPendingTOML(),
+		rc.OverflowPolicy,
+		rc.BlockTimeout.String(),
+	)
+}
+
+// Rebalance represents the config of moving the replicas of storage node which is removed from cluster
+type Rebalance struct {
+	OfflineGracePeriod ltoml.Duration `toml:"offline-grace-period"`
+	CheckInterval      ltoml.Duration `toml:"check-interval"`
+	PhaseTimeout       ltoml.Duration `toml:"phase-timeout"`
+	MaxPending         int64          `toml:"max-pending"`
+}
+
+func (r *Rebalance) TOML() string {
+	return fmt.Sprintf(`
+    ## the replicas of storage node are moved to other nodes if the node is offline longer than grace period,
+    ## 0 means the replicas are only moved by decommission api
+    offline-grace-period = "%s"
+    ## interval for how often the progress of moving replicas is checked
+    check-interval = "%s"
+    ## max waiting time of each phase(create learner, transfer shards, catch up data) when moving replicas
+    phase-timeout = "%s"
+    ## the new replica is promoted after the num. of pending msg in all brokers <= max-pending
+    max-pending = %d`,
+		r.OfflineGracePeriod.String(),
+		r.CheckInterval.String(),
+		r.PhaseTimeout.String(),
+		r.MaxPending,
+	)
+}
+
+// Sharding represents the config of changing the num. of shard of database
+type Sharding struct {
+	SwitchDelay ltoml.Duration `toml:"switch-delay"`
+}
+
+func (s *Sharding) TOML() string {
+	return fmt.Sprintf(`
+    ## delay before the new num. of shard takes effect when shards increased,
+    ## leaves time for brokers/storage nodes to receive the new shard assignment
+    switch-delay = "%s"`,
+		s.SwitchDelay.String(),
+	)
+}
+
+// Consistency represents the config of checking the data consistency between the replicas of shard
+type Consistency struct {
+	CheckInterval ltoml.Duration `toml:"check-interval"`
+	TimeRange     ltoml.Duration `toml:"time-range"`
+	SettleDelay   ltoml.Duration `toml:"settle-delay"`
+	AutoRepair    bool           `toml:"auto-repair"`
+}
+
+func (c *Consistency) TOML() string {
+	return fmt.Sprintf(`
+    ## interval for how often the digests of data families in all replicas are compared, 0 means disabled
+    check-interval = "%s"
+    ## time range of data families which are checked in each round
+    time-range = "%s"
+    ## only the data families which end before now-settle-delay are checked, because recent data may be in flight
+    settle-delay = "%s"
+    ## repairs the lagging replica from the replica which includes the data of all others automatically,
+    ## repair copies the data files of source replica, which is refused unless the metric/series/field ids
+    ## in the files are same in both replicas, such as the replica which is built by shard transfer
+    auto-repair = %v`,
+		c.CheckInterval.String(),
+		c.TimeRange.String(),
+		c.SettleDelay.String(),
+		c.AutoRepair,
 	)
 }
 
@@ -114,6 +320,12 @@ type BrokerBase struct {
 	User               User               `toml:"user"`
 	GRPC               GRPC               `toml:"grpc"`
 	ReplicationChannel ReplicationChannel `toml:"replication_channel"`
+	Graphite           Graphite           `toml:"graphite"`
+	OpenTSDB           OpenTSDB           `toml:"opentsdb"`
+	Rebalance          Rebalance          `toml:"rebalance"`
+	Sharding           Sharding           `toml:"sharding"`
+	Consistency        Consistency        `toml:"consistency"`
+	Labels             Labels             `toml:"labels"`
 }
 
 func (bb *BrokerBase) TOML() string {
@@ -129,13 +341,31 @@ func (bb *BrokerBase) TOML() string {
 
   [broker.grpc]%s
 
-  [broker.replication_channel]%s`,
+  [broker.replication_channel]%s
+
+  [broker.graphite]%s
+
+  [broker.opentsdb]%s
+
+  [broker.rebalance]%s
+
+  [broker.sharding]%s
+
+  [broker.consistency]%s
+
+  [broker.labels]%s`,
 		bb.Coordinator.TOML(),
 		bb.Query.TOML(),
 		bb.HTTP.TOML(),
 		bb.User.TOML(),
 		bb.GRPC.TOML(),
 		bb.ReplicationChannel.TOML(),
+		bb.Graphite.TOML(),
+		bb.OpenTSDB.TOML(),
+		bb.Rebalance.TOML(),
+		bb.Sharding.TOML(),
+		bb.Consistency.TOML(),
+		bb.Labels.TOML(),
 	)
 }
 
@@ -153,18 +383,46 @@ func NewDefaultBrokerBase() *BrokerBase {
 			DialTimeout: ltoml.Duration(time.Second * 5),
 		},
 		User: User{
-			UserName: "admin",
-			Password: "admin123",
+			UserName:    "admin",
+			Password:    "admin123",
+			TokenExpire: ltoml.Duration(24 * time.Hour),
 		},
 		ReplicationChannel: ReplicationChannel{
 			Dir:                filepath.Join(defaultParentDir, "broker/replication"),
 			DataSizeLimit:      512,
 			RemoveTaskInterval: ltoml.Duration(time.Minute),
 			CheckFlushInterval: ltoml.Duration(time.Second),
-			FlushInterval:      ltoml.Duration(5 * time.Second),
 			BufferSize:         128,
+
+			MaxPendingPerDatabase: 100000,
+			DatabaseMaxPending:    map[string]int64{},
+			OverflowPolicy:        OverflowBlock,
+			BlockTimeout:          ltoml.Duration(5 * time.Second),
 		},
 		Query: *NewDefaultQuery(),
+		Graphite: Graphite{
+			Templates: []string{},
+			BatchSize: 1000,
+		},
+		OpenTSDB: OpenTSDB{
+			BatchSize: 1000,
+		},
+		Rebalance: Rebalance{
+			OfflineGracePeriod: ltoml.Duration(10 * time.Minute),
+			CheckInterval:      ltoml.Duration(time.Second),
+			PhaseTimeout:       ltoml.Duration(time.Hour),
+			MaxPending:         100,
+		},
+		Sharding: Sharding{
+			SwitchDelay: ltoml.Duration(time.Minute),
+		},
+		Consistency: Consistency{
+			CheckInterval: ltoml.Duration(time.Hour),
+			TimeRange:     ltoml.Duration(24 * time.Hour),
+			SettleDelay:   ltoml.Duration(time.Hour),
+			AutoRepair:    false,
+		},
+		Labels: Labels{},
 	}
 }
 
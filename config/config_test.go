@@ -63,3 +63,26 @@ func Test_ReplicationChannel_SegmentFileSizeInBytes(t *testing.T) {
 	rc.DataSizeLimit = 10000
 	assert.Equal(t, int64(1024*1024*1024), rc.GetDataSizeLimit())
 }
+
+func TestLabels_TOML(t *testing.T) {
+	labels := Labels{"zone": "zone-a", "idc": "sh"}
+	assert.Contains(t, labels.TOML(), "\n    idc = \"sh\"\n    zone = \"zone-a\"")
+}
+
+func Test_ReplicationChannel_Quota(t *testing.T) {
+	rc := ReplicationChannel{
+		MaxPendingPerDatabase: 100,
+		DatabaseMaxPending:    map[string]int64{"db2": 20, "db1": 10},
+	}
+	assert.Equal(t, int64(10), rc.GetMaxPending("db1"))
+	assert.Equal(t, int64(100), rc.GetMaxPending("db3"))
+	assert.Equal(t, `{"db1" = 10, "db2" = 20}`, rc.databaseMaxPendingTOML())
+
+	assert.Equal(t, OverflowBlock, rc.GetOverflowPolicy())
+	assert.NoError(t, rc.Validate())
+	rc.OverflowPolicy = OverflowDropOldest
+	assert.Equal(t, OverflowDropOldest, rc.GetOverflowPolicy())
+	assert.NoError(t, rc.Validate())
+	rc.OverflowPolicy = "unknown"
+	assert.Error(t, rc.Validate())
+}
@@ -3,6 +3,8 @@ package config
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/lindb/lindb/pkg/ltoml"
@@ -70,7 +72,7 @@ func (q *Query) TOML() string {
     ## idle worker will be canceled in this duration
 	idle-timeout = "%s"
 
-    ## maximum timeout threshold for the task performed
+    ## maximum timeout threshold for the task performed(also the timeout of query api in broker)
     timeout = "%s"`,
 		q.MaxWorkers,
 		q.IdleTimeout,
@@ -85,3 +87,22 @@ func NewDefaultQuery() *Query {
 		Timeout:     ltoml.Duration(30 * time.Second),
 	}
 }
+
+// Labels represents the labels of node(like zone), which are registered with node info
+type Labels map[string]string
+
+func (l Labels) TOML() string {
+	keys := make([]string, 0, len(l))
+	for key := range l {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	sb.WriteString(`
+    ## labels of node, e.g. zone = "zone-a",
+    ## broker chooses the replica in same zone first if replica select policy is prefer-local-zone`)
+	for _, key := range keys {
+		sb.WriteString(fmt.Sprintf("\n    %s = \"%s\"", key, l[key]))
+	}
+	return sb.String()
+}
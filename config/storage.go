@@ -10,14 +10,44 @@ import (
 
 // TSDB represents the tsdb configuration
 type TSDB struct {
-	Dir string `toml:"dir"`
+	Dir                string `toml:"dir"`
+	BackupDir          string `toml:"backup-dir"`
+	ChecksumVerify     string `toml:"checksum-verify"`
+	Compression        string `toml:"compression"`
+	CompactConcurrency int    `toml:"compact-concurrency"`
+	CompactRateLimit   int    `toml:"compact-rate-limit"`
+	CacheMaxFiles      int    `toml:"cache-max-files"`
+	CacheMaxSize       int    `toml:"cache-max-size"`
 }
 
 func (t *TSDB) TOML() string {
 	return fmt.Sprintf(`
     ## where the tsdb data is stored
-    dir = "%s"`,
+    dir = "%s"
+    ## root path of database backups, the paths of backup/restore admin api are resolved under it
+    backup-dir = "%s"
+    ## checksum verification mode when opening sst file
+    ## off: not verify, footer: verify index blocks, full: verify all data blocks
+    checksum-verify = "%s"
+    ## compression type of values in sst file(none/snappy/zstd),
+    ## old files are rewritten by compaction job after changing compression type
+    compression = "%s"
+    ## max num. of compaction jobs running concurrently in all kv stores
+    compact-concurrency = %d
+    ## max bytes written by all compaction jobs per second(MB/s), 0 means no limit
+    compact-rate-limit = %d
+    ## max num. of opened sst files in the reader cache shared by all kv stores, 0 means no limit
+    cache-max-files = %d
+    ## max total size of opened sst files in the reader cache shared by all kv stores(MB), 0 means no limit
+    cache-max-size = %d`,
 		t.Dir,
+		t.BackupDir,
+		t.ChecksumVerify,
+		t.Compression,
+		t.CompactConcurrency,
+		t.CompactRateLimit,
+		t.CacheMaxFiles,
+		t.CacheMaxSize,
 	)
 }
 
@@ -27,6 +57,7 @@ type StorageBase struct {
 	GRPC        GRPC      `toml:"grpc"`
 	TSDB        TSDB      `toml:"tsdb"`
 	Query       Query     `toml:"query"`
+	Labels      Labels    `toml:"labels"`
 }
 
 // TOML returns StorageBase's toml config string
@@ -40,11 +71,14 @@ func (s *StorageBase) TOML() string {
   [storage.grpc]%s
 
   [storage.tsdb]%s
+
+  [storage.labels]%s
 `,
 		s.Coordinator.TOML(),
 		s.Query.TOML(),
 		s.GRPC.TOML(),
 		s.TSDB.TOML(),
+		s.Labels.TOML(),
 	)
 }
 
@@ -66,8 +100,16 @@ func NewDefaultStorageBase() *StorageBase {
 			Port: 2891,
 			TTL:  ltoml.Duration(time.Second)},
 		TSDB: TSDB{
-			Dir: filepath.Join(defaultParentDir, "storage/data")},
-		Query: *NewDefaultQuery(),
+			Dir:                filepath.Join(defaultParentDir, "storage/data"),
+			BackupDir:          filepath.Join(defaultParentDir, "storage/backup"),
+			ChecksumVerify:     "footer",
+			Compression:        "none",
+			CompactConcurrency: 2,
+			CompactRateLimit:   0,
+			CacheMaxFiles:      512,
+			CacheMaxSize:       8192},
+		Query:  *NewDefaultQuery(),
+		Labels: Labels{},
 	}
 }
 
@@ -15,7 +15,11 @@ const (
 )
 
 // defines storage level constants will be used in storage
-//const ()
+const (
+	// RestoredDatabasePath represents the databases restored from backup in storage node,
+	// master registers the database config and shard assignment when discovering them.
+	RestoredDatabasePath = "/database/restored"
+)
 
 // defines broker level constants will be used in broker
 const (
@@ -34,6 +38,12 @@ const (
 	ReplicaStatePath = "/state/replica"
 	// StorageClusterStatPath represents storage cluster's node monitoring stat
 	StorageClusterStatPath = "/state/storage/stat/cluster"
+	// UserPath represents the user of broker for authentication
+	UserPath = "/auth/user"
+	// RolePath represents the role which defines the permissions of databases
+	RolePath = "/auth/role"
+	// APIKeyPath represents the api key of user for write agents
+	APIKeyPath = "/auth/apikey"
 )
 
 // defines all task kinds
@@ -42,6 +52,12 @@ const (
 	CreateShard task.Kind = "create-shard"
 	// FlushDatabase represents task kind which is flush memory database for storage node
 	FlushDatabase task.Kind = "flush-database"
+	// DropDatabase represents task kind which is drop database for storage node
+	DropDatabase task.Kind = "drop-database"
+	// DeleteSeries represents task kind which is delete series of metric for storage node
+	DeleteSeries task.Kind = "delete-series"
+	// TransferShard represents task kind which is pull shards from source node to build new replica for storage node
+	TransferShard task.Kind = "transfer-shard"
 )
 
 // GetStorageClusterConfigPath returns path which storing config of storage cluster
@@ -59,6 +75,11 @@ func GetStorageClusterStatPath(name string) string {
 	return fmt.Sprintf("%s/%s", StorageClusterStatPath, name)
 }
 
+// GetRestoredDatabasePath returns path which storing the restored database of storage node
+func GetRestoredDatabasePath(name string) string {
+	return fmt.Sprintf("%s/%s", RestoredDatabasePath, name)
+}
+
 // GetDatabaseConfigPath returns path which storing config of database
 func GetDatabaseConfigPath(name string) string {
 	return fmt.Sprintf("%s/%s", DatabaseConfigPath, name)
@@ -69,6 +90,21 @@ func GetDatabaseAssignPath(name string) string {
 	return fmt.Sprintf("%s/%s", DatabaseAssignPath, name)
 }
 
+// GetUserPath returns path which storing user
+func GetUserPath(name string) string {
+	return fmt.Sprintf("%s/%s", UserPath, name)
+}
+
+// GetRolePath returns path which storing role
+func GetRolePath(name string) string {
+	return fmt.Sprintf("%s/%s", RolePath, name)
+}
+
+// GetAPIKeyPath returns path which storing api key by key id
+func GetAPIKeyPath(id string) string {
+	return fmt.Sprintf("%s/%s", APIKeyPath, id)
+}
+
 // GetNodePath returns node register path
 func GetNodePath(prefix, node string) string {
 	return fmt.Sprintf("%s/%s", prefix, node)
@@ -10,6 +10,10 @@ func TestGetDatabaseAssignPath(t *testing.T) {
 	assert.Equal(t, DatabaseAssignPath+"/name", GetDatabaseAssignPath("name"))
 }
 
+func TestGetRestoredDatabasePath(t *testing.T) {
+	assert.Equal(t, RestoredDatabasePath+"/name", GetRestoredDatabasePath("name"))
+}
+
 func TestGetDatabaseConfigPath(t *testing.T) {
 	assert.Equal(t, DatabaseConfigPath+"/name", GetDatabaseConfigPath("name"))
 }
@@ -37,3 +41,9 @@ func TestGetReplicaStatePath(t *testing.T) {
 func TestGetNodeMonitoringStatPath(t *testing.T) {
 	assert.Equal(t, StateNodesPath+"/1.1.1.1:port", GetNodeMonitoringStatPath("1.1.1.1:port"))
 }
+
+func TestGetAuthPath(t *testing.T) {
+	assert.Equal(t, UserPath+"/name", GetUserPath("name"))
+	assert.Equal(t, RolePath+"/name", GetRolePath("name"))
+	assert.Equal(t, APIKeyPath+"/id", GetAPIKeyPath("id"))
+}
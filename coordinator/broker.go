@@ -41,7 +41,7 @@ func (s *BrokerStateMachines) Start() (err error) {
 	if err != nil {
 		return err
 	}
-	s.ReplicaStatusSM, err = s.factory.CreateReplicaStatusStateMachine()
+	s.ReplicaStatusSM, err = s.factory.CreateReplicaStatusStateMachine(storageNodeZones(s.StorageSM))
 	if err != nil {
 		return err
 	}
@@ -80,3 +80,18 @@ func (s *BrokerStateMachines) Stop() {
 		}
 	}
 }
+
+// storageNodeZones returns the zones of active storage nodes in all storage clusters
+func storageNodeZones(storageSM broker.StorageStateMachine) replica.NodeZones {
+	return func() map[string]string {
+		zones := make(map[string]string)
+		for _, storageState := range storageSM.List() {
+			for nodeID, node := range storageState.ActiveNodes {
+				if zone := node.Zone(); zone != "" {
+					zones[nodeID] = zone
+				}
+			}
+		}
+		return zones
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/models"
 )
 
 func TestBrokerStateMachines(t *testing.T) {
@@ -39,11 +40,11 @@ func TestBrokerStateMachines(t *testing.T) {
 	assert.Error(t, err)
 
 	factory.EXPECT().CreateStorageStateMachine().Return(storageStateSM, nil).AnyTimes()
-	factory.EXPECT().CreateReplicaStatusStateMachine().Return(nil, fmt.Errorf("err"))
+	factory.EXPECT().CreateReplicaStatusStateMachine(gomock.Any()).Return(nil, fmt.Errorf("err"))
 	err = brokerSMs.Start()
 	assert.Error(t, err)
 
-	factory.EXPECT().CreateReplicaStatusStateMachine().Return(replicaSM, nil).AnyTimes()
+	factory.EXPECT().CreateReplicaStatusStateMachine(gomock.Any()).Return(replicaSM, nil).AnyTimes()
 	factory.EXPECT().CreateDatabaseStateMachine().Return(nil, fmt.Errorf("err"))
 	err = brokerSMs.Start()
 	assert.Error(t, err)
@@ -59,3 +60,19 @@ func TestBrokerStateMachines(t *testing.T) {
 	dbSM.EXPECT().Close().Return(fmt.Errorf("err"))
 	brokerSMs.Stop()
 }
+
+func TestStorageNodeZones(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageStateSM := broker.NewMockStorageStateMachine(ctrl)
+	storageState := models.NewStorageState()
+	storageState.AddActiveNode(&models.ActiveNode{
+		Node:   models.Node{IP: "1.1.1.1", Port: 2080},
+		Labels: map[string]string{models.LabelZone: "zone-a"},
+	})
+	storageState.AddActiveNode(&models.ActiveNode{Node: models.Node{IP: "1.1.1.2", Port: 2080}})
+	storageStateSM.EXPECT().List().Return([]*models.StorageState{storageState})
+	zones := storageNodeZones(storageStateSM)()
+	assert.Equal(t, map[string]string{"1.1.1.1:2080": "zone-a"}, zones)
+}
@@ -5,14 +5,22 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
 	"sync"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/discovery"
 	"github.com/lindb/lindb/coordinator/storage"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// for testing
+var (
+	nowFunc = timeutil.Now
 )
 
 //go:generate mockgen -source=./admin_state_machine.go -destination=./admin_state_machine_mock.go -package=database
@@ -32,6 +40,7 @@ type AdminStateMachine interface {
 type adminStateMachine struct {
 	storageCluster storage.ClusterStateMachine
 	discovery      discovery.Discovery
+	sharding       config.Sharding
 
 	mutex  sync.RWMutex
 	ctx    context.Context
@@ -42,11 +51,12 @@ type adminStateMachine struct {
 
 // NewAdminStateMachine creates admin state machine instance
 func NewAdminStateMachine(ctx context.Context, discoveryFactory discovery.Factory,
-	storageCluster storage.ClusterStateMachine) (AdminStateMachine, error) {
+	storageCluster storage.ClusterStateMachine, sharding config.Sharding) (AdminStateMachine, error) {
 	c, cancel := context.WithCancel(ctx)
 	// new admin state machine instance
 	stateMachine := &adminStateMachine{
 		storageCluster: storageCluster,
+		sharding:       sharding,
 		ctx:            c,
 		cancel:         cancel,
 		log:            logger.GetLogger("coordinator", "AdminStateMachine"),
@@ -101,8 +111,24 @@ func (sm *adminStateMachine) OnCreate(key string, resource []byte) {
 	}
 }
 
+// OnDelete drops database from related storage cluster when receive database delete event,
+// submits drop database coordinator task, then deletes shard assignment.
 func (sm *adminStateMachine) OnDelete(key string) {
-	//TODO impl delete database???
+	_, databaseName := filepath.Split(key)
+	if len(databaseName) == 0 {
+		return
+	}
+
+	sm.mutex.Lock()
+	defer sm.mutex.Unlock()
+
+	// database config already deleted, so find the storage cluster which has the shard assignment of it
+	for _, cluster := range sm.storageCluster.GetAllCluster() {
+		if err := cluster.DropDatabase(databaseName); err != nil {
+			sm.log.Error("drop database error",
+				logger.String("db", databaseName), logger.Error(err))
+		}
+	}
 }
 
 // Close closes admin state machine, stops watch change event
@@ -151,8 +177,7 @@ func (sm *adminStateMachine) createShardAssignment(databaseName string,
 func (sm *adminStateMachine) modifyShardAssignment(databaseName string, shardAssign *models.ShardAssignment,
 	cluster storage.Cluster, cfg *models.Database) error {
 	if len(shardAssign.Shards) > cfg.NumOfShard { //reduce shardAssign's shards
-		//TODO implement the reduce shards, is needed?
-		panic("not implemented")
+		return fmt.Errorf("cannot reduce num. of shard from %d to %d", len(shardAssign.Shards), cfg.NumOfShard)
 	} else if len(shardAssign.Shards) < cfg.NumOfShard { //add shardAssign's shards
 		activeNodes := cluster.GetActiveNodes()
 		if len(activeNodes) == 0 {
@@ -169,6 +194,12 @@ func (sm *adminStateMachine) modifyShardAssignment(databaseName string, shardAss
 			nodeIDs = append(nodeIDs, idx)
 		}
 
+		// record new shard epoch before adding shards, the data after switch time will be routed by new num. of shard,
+		// the data before switch time still be routed by old num. of shard.
+		// the new num. of shard takes effect after switch delay, leaves time for brokers/storage nodes
+		// to receive the new shard assignment.
+		switchTime := nowFunc() + sm.sharding.SwitchDelay.Duration().Milliseconds()
+		shardAssign.AddEpoch(cfg.NumOfShard, switchTime)
 		// generate shard assignment based on node ids and config
 		err := ModifyShardAssignment(nodeIDs, cfg, shardAssign, -1, len(shardAssign.Shards))
 		if err != nil {
@@ -5,14 +5,19 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/discovery"
 	"github.com/lindb/lindb/coordinator/storage"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 func TestAdminStateMachine(t *testing.T) {
@@ -24,13 +29,14 @@ func TestAdminStateMachine(t *testing.T) {
 	factory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1)
 
 	discovery1.EXPECT().Discovery().Return(fmt.Errorf("err"))
-	_, err := NewAdminStateMachine(context.TODO(), factory, nil)
+	_, err := NewAdminStateMachine(context.TODO(), factory, nil, config.Sharding{})
 	assert.NotNil(t, err)
 
 	storageCluster := storage.NewMockClusterStateMachine(ctrl)
 	factory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1)
 	discovery1.EXPECT().Discovery().Return(nil)
-	stateMachine, err := NewAdminStateMachine(context.TODO(), factory, storageCluster)
+	stateMachine, err := NewAdminStateMachine(context.TODO(), factory, storageCluster,
+		config.Sharding{SwitchDelay: ltoml.Duration(time.Minute)})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -79,7 +85,12 @@ func TestAdminStateMachine(t *testing.T) {
 	cluster.EXPECT().GetActiveNodes().Return(prepareStorageCluster())
 	stateMachine.OnCreate("/data/db1", data)
 
-	stateMachine.OnDelete("mock")
+	stateMachine.OnDelete("/data/")
+	storageCluster.EXPECT().GetAllCluster().Return([]storage.Cluster{cluster}).Times(2)
+	cluster.EXPECT().DropDatabase("db1").Return(fmt.Errorf("err"))
+	stateMachine.OnDelete("/data/db1")
+	cluster.EXPECT().DropDatabase("db1").Return(nil)
+	stateMachine.OnDelete("/data/db1")
 	discovery1.EXPECT().Close()
 	_ = stateMachine.Close()
 }
@@ -93,3 +104,37 @@ func prepareStorageCluster() []*models.ActiveNode {
 		{Node: models.Node{IP: "127.0.0.5", Port: 2080}},
 	}
 }
+
+func TestAdminStateMachine_modifyShardAssignment(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		nowFunc = timeutil.Now
+		ctrl.Finish()
+	}()
+	nowFunc = func() int64 { return 1000 }
+
+	cluster := storage.NewMockCluster(ctrl)
+	sm := &adminStateMachine{
+		sharding: config.Sharding{SwitchDelay: ltoml.Duration(time.Minute)},
+		log:      logger.GetLogger("coordinator", "AdminStateMachine"),
+	}
+	cfg := &models.Database{Name: "db1", NumOfShard: 2, ReplicaFactor: 1}
+	shardAssign := models.NewShardAssignment("db1")
+	assert.NoError(t, ModifyShardAssignment([]int{0, 1}, cfg, shardAssign, -1, 0))
+
+	// cannot reduce shards
+	err := sm.modifyShardAssignment("db1", shardAssign, cluster, &models.Database{Name: "db1", NumOfShard: 1})
+	assert.Error(t, err)
+
+	// add shards, record new epoch
+	cfg = &models.Database{Name: "db1", NumOfShard: 4, ReplicaFactor: 1}
+	cluster.EXPECT().GetActiveNodes().Return(prepareStorageCluster())
+	cluster.EXPECT().SaveShardAssign("db1", gomock.Any(), gomock.Any()).Return(nil)
+	err = sm.modifyShardAssignment("db1", shardAssign, cluster, cfg)
+	assert.NoError(t, err)
+	assert.Len(t, shardAssign.Shards, 4)
+	assert.Equal(t, models.ShardEpochs{
+		{NumOfShard: 2},
+		{NumOfShard: 4, StartTime: 1000 + time.Minute.Milliseconds()},
+	}, shardAssign.Epochs)
+}
@@ -23,6 +23,8 @@ type DBStateMachine interface {
 
 	// GetDatabaseCfg returns the database config by name
 	GetDatabaseCfg(databaseName string) (models.Database, bool)
+	// GetShardEpochs returns the shard epochs of database by name
+	GetShardEpochs(databaseName string) (models.ShardEpochs, bool)
 
 	// Close closes database config state machine, stops watch change event
 	io.Closer
@@ -30,12 +32,14 @@ type DBStateMachine interface {
 
 // dbStateMachine implements DBStateMachine
 type dbStateMachine struct {
-	discovery discovery.Discovery
+	discovery      discovery.Discovery
+	epochDiscovery discovery.Discovery
 
-	databases map[string]models.Database
-	mutex     sync.RWMutex
-	ctx       context.Context
-	cancel    context.CancelFunc
+	databases   map[string]models.Database
+	shardEpochs map[string]models.ShardEpochs
+	mutex       sync.RWMutex
+	ctx         context.Context
+	cancel      context.CancelFunc
 
 	log *logger.Logger
 }
@@ -45,16 +49,24 @@ func NewDBStateMachine(ctx context.Context, discoveryFactory discovery.Factory)
 	c, cancel := context.WithCancel(ctx)
 	// new admin state machine instance
 	stateMachine := &dbStateMachine{
-		ctx:       c,
-		cancel:    cancel,
-		databases: make(map[string]models.Database),
-		log:       logger.GetLogger("coordinator", "DBStateMachine"),
+		ctx:         c,
+		cancel:      cancel,
+		databases:   make(map[string]models.Database),
+		shardEpochs: make(map[string]models.ShardEpochs),
+		log:         logger.GetLogger("coordinator", "DBStateMachine"),
 	}
 	// new database config discovery
 	stateMachine.discovery = discoveryFactory.CreateDiscovery(constants.DatabaseConfigPath, stateMachine)
 	if err := stateMachine.discovery.Discovery(); err != nil {
 		return nil, fmt.Errorf("discovery database config error:%s", err)
 	}
+	// new database's shard assign discovery for shard epochs
+	stateMachine.epochDiscovery = discoveryFactory.CreateDiscovery(constants.DatabaseAssignPath,
+		&shardEpochListener{sm: stateMachine})
+	if err := stateMachine.epochDiscovery.Discovery(); err != nil {
+		stateMachine.discovery.Close()
+		return nil, fmt.Errorf("discovery database shard assign error:%s", err)
+	}
 	return stateMachine, nil
 }
 
@@ -97,9 +109,53 @@ func (sm *dbStateMachine) GetDatabaseCfg(databaseName string) (models.Database,
 	return database, ok
 }
 
+// GetShardEpochs returns the shard epochs of database by name
+func (sm *dbStateMachine) GetShardEpochs(databaseName string) (models.ShardEpochs, bool) {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+
+	epochs, ok := sm.shardEpochs[databaseName]
+	return epochs, ok
+}
+
 // Close closes database config state machine, stops watch change event
 func (sm *dbStateMachine) Close() error {
 	sm.discovery.Close()
+	sm.epochDiscovery.Close()
 	sm.cancel()
 	return nil
 }
+
+// shardEpochListener listens shard assignment change event, maintains the shard epochs of database
+type shardEpochListener struct {
+	sm *dbStateMachine
+}
+
+// OnCreate updates the shard epochs of database when shard assignment creation/modification
+func (l *shardEpochListener) OnCreate(key string, resource []byte) {
+	shardAssign := &models.ShardAssignment{}
+	if err := json.Unmarshal(resource, shardAssign); err != nil {
+		l.sm.log.Error("discovery shard assign create but unmarshal error",
+			logger.String("data", string(resource)), logger.Error(err))
+		return
+	}
+	if len(shardAssign.Name) == 0 {
+		l.sm.log.Error("database name cannot be empty", logger.String("data", string(resource)))
+		return
+	}
+
+	l.sm.mutex.Lock()
+	defer l.sm.mutex.Unlock()
+
+	l.sm.shardEpochs[shardAssign.Name] = shardAssign.GetEpochs()
+}
+
+// OnDelete removes the shard epochs of database when shard assignment deletion
+func (l *shardEpochListener) OnDelete(key string) {
+	_, databaseName := filepath.Split(key)
+
+	l.sm.mutex.Lock()
+	defer l.sm.mutex.Unlock()
+
+	delete(l.sm.shardEpochs, databaseName)
+}
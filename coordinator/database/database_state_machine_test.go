@@ -9,6 +9,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/discovery"
 	"github.com/lindb/lindb/models"
 )
@@ -24,9 +25,19 @@ func TestNewDBStateMachine(t *testing.T) {
 	_, err := NewDBStateMachine(context.TODO(), factory)
 	assert.Error(t, err)
 
-	// normal case
-	factory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1)
+	// shard assign discovery failure
+	discovery2 := discovery.NewMockDiscovery(ctrl)
+	factory.EXPECT().CreateDiscovery(constants.DatabaseConfigPath, gomock.Any()).Return(discovery1)
+	factory.EXPECT().CreateDiscovery(constants.DatabaseAssignPath, gomock.Any()).Return(discovery2)
 	discovery1.EXPECT().Discovery().Return(nil)
+	discovery2.EXPECT().Discovery().Return(fmt.Errorf("err"))
+	discovery1.EXPECT().Close()
+	_, err = NewDBStateMachine(context.TODO(), factory)
+	assert.Error(t, err)
+
+	// normal case
+	factory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1).Times(2)
+	discovery1.EXPECT().Discovery().Return(nil).Times(2)
 	stateMachine, err := NewDBStateMachine(context.TODO(), factory)
 	assert.NoError(t, err)
 	assert.NotNil(t, stateMachine)
@@ -39,8 +50,8 @@ func TestDBStateMachine_listen(t *testing.T) {
 	factory := discovery.NewMockFactory(ctrl)
 	discovery1 := discovery.NewMockDiscovery(ctrl)
 	// normal case
-	factory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1)
-	discovery1.EXPECT().Discovery().Return(nil)
+	factory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1).Times(2)
+	discovery1.EXPECT().Discovery().Return(nil).Times(2)
 	stateMachine, err := NewDBStateMachine(context.TODO(), factory)
 	assert.NoError(t, err)
 
@@ -65,6 +76,53 @@ func TestDBStateMachine_listen(t *testing.T) {
 	_, ok = stateMachine.GetDatabaseCfg("test")
 	assert.False(t, ok)
 
-	discovery1.EXPECT().Close()
+	discovery1.EXPECT().Close().Times(2)
 	_ = stateMachine.Close()
 }
+
+func TestDBStateMachine_shardEpochs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	factory := discovery.NewMockFactory(ctrl)
+	discovery1 := discovery.NewMockDiscovery(ctrl)
+	var listener discovery.Listener
+	factory.EXPECT().CreateDiscovery(constants.DatabaseConfigPath, gomock.Any()).Return(discovery1)
+	factory.EXPECT().CreateDiscovery(constants.DatabaseAssignPath, gomock.Any()).
+		DoAndReturn(func(_ string, l discovery.Listener) discovery.Discovery {
+			listener = l
+			return discovery1
+		})
+	discovery1.EXPECT().Discovery().Return(nil).Times(2)
+	stateMachine, err := NewDBStateMachine(context.TODO(), factory)
+	assert.NoError(t, err)
+
+	shardAssign := models.NewShardAssignment("test")
+	shardAssign.AddReplica(0, 1)
+	data, _ := json.Marshal(shardAssign)
+	listener.OnCreate("/database/assign/test", data)
+	epochs, ok := stateMachine.GetShardEpochs("test")
+	assert.True(t, ok)
+	assert.Equal(t, models.ShardEpochs{{NumOfShard: 1}}, epochs)
+
+	shardAssign.AddEpoch(2, 100)
+	shardAssign.AddReplica(1, 1)
+	data, _ = json.Marshal(shardAssign)
+	listener.OnCreate("/database/assign/test", data)
+	epochs, _ = stateMachine.GetShardEpochs("test")
+	assert.Equal(t, models.ShardEpochs{{NumOfShard: 1}, {NumOfShard: 2, StartTime: 100}}, epochs)
+
+	// unmarshal failure
+	listener.OnCreate("/database/assign/test2", []byte{1, 1})
+	_, ok = stateMachine.GetShardEpochs("test2")
+	assert.False(t, ok)
+	// database name empty
+	data, _ = json.Marshal(&models.ShardAssignment{})
+	listener.OnCreate("/database/assign/test2", data)
+	_, ok = stateMachine.GetShardEpochs("test2")
+	assert.False(t, ok)
+
+	listener.OnDelete("/database/assign/test")
+	_, ok = stateMachine.GetShardEpochs("test")
+	assert.False(t, ok)
+}
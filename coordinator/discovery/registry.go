@@ -28,6 +28,7 @@ type Registry interface {
 type registry struct {
 	prefix string
 	ttl    time.Duration
+	labels map[string]string
 	repo   state.Repository
 
 	ctx    context.Context
@@ -36,16 +37,18 @@ type registry struct {
 	log *logger.Logger
 }
 
-// NewRegistry returns a new registry with prefix and ttl
+// NewRegistry returns a new registry with prefix and ttl, the labels of node are registered with node info
 func NewRegistry(
 	repo state.Repository,
 	prefix string,
 	ttl time.Duration,
+	labels map[string]string,
 ) Registry {
 	ctx, cancel := context.WithCancel(context.Background())
 	return &registry{
 		prefix: prefix,
 		ttl:    ttl,
+		labels: labels,
 		repo:   repo,
 		ctx:    ctx,
 		cancel: cancel,
@@ -80,7 +83,7 @@ func (r *registry) register(path string, node models.Node) {
 		if r.ctx.Err() != nil {
 			return
 		}
-		nodeBytes, _ := json.Marshal(&models.ActiveNode{OnlineTime: timeutil.Now(), Node: node})
+		nodeBytes, _ := json.Marshal(&models.ActiveNode{OnlineTime: timeutil.Now(), Node: node, Labels: r.labels})
 
 		closed, err := r.repo.Heartbeat(r.ctx, path, nodeBytes, int64(r.ttl.Seconds()))
 		if err != nil {
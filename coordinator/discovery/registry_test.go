@@ -1,6 +1,7 @@
 package discovery
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/state"
 )
 
@@ -20,14 +22,19 @@ func TestRegistry(t *testing.T) {
 
 	repo := state.NewMockRepository(ctrl)
 
-	registry1 := NewRegistry(repo, testRegistryPath, 100)
+	registry1 := NewRegistry(repo, testRegistryPath, 100, map[string]string{models.LabelZone: "zone-a"})
 
 	closedCh := make(chan state.Closed)
 
 	node := models.Node{IP: "127.0.0.1", Port: 2080, HTTPPort: 9002}
 	gomock.InOrder(
 		repo.EXPECT().Heartbeat(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-			Return(nil, fmt.Errorf("err")),
+			DoAndReturn(func(ctx context.Context, key string, value []byte, ttl int64) (<-chan state.Closed, error) {
+				activeNode := &models.ActiveNode{}
+				assert.NoError(t, encoding.JSONUnmarshal(value, activeNode))
+				assert.Equal(t, "zone-a", activeNode.Zone())
+				return nil, fmt.Errorf("err")
+			}),
 		repo.EXPECT().Heartbeat(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 			Return(closedCh, nil),
 	)
@@ -49,14 +56,14 @@ func TestRegistry(t *testing.T) {
 	err = registry1.Close()
 	assert.NoError(t, err)
 
-	registry1 = NewRegistry(repo, testRegistryPath, 100)
+	registry1 = NewRegistry(repo, testRegistryPath, 100, nil)
 	err = registry1.Close()
 	assert.NoError(t, err)
 
 	r := registry1.(*registry)
 	r.register("/data/pant", node)
 
-	registry1 = NewRegistry(repo, testRegistryPath, 100)
+	registry1 = NewRegistry(repo, testRegistryPath, 100, nil)
 	r = registry1.(*registry)
 
 	// cancel ctx in timer
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/lindb/lindb/config"
 	coCtx "github.com/lindb/lindb/coordinator/context"
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/discovery"
@@ -15,7 +16,9 @@ import (
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 //go:generate mockgen -source=./master.go -destination=./master_mock.go -package=coordinator
@@ -46,6 +49,15 @@ type MasterCfg struct {
 
 	// broker state machine
 	BrokerSM *BrokerStateMachines
+
+	// config of moving the replicas of removed storage node
+	Rebalance config.Rebalance
+	// config of changing the num. of shard of database
+	Sharding config.Sharding
+	// config of checking the data consistency between replicas
+	Consistency config.Consistency
+	// rpc client factory for digesting/repairing the replicas of storage node
+	ClientStreamFactory rpc.ClientStreamFactory
 }
 
 // Master represents all metadata/state controller, only has one active master in broker cluster.
@@ -62,6 +74,12 @@ type Master interface {
 	Stop()
 	// FlushDatabase submits the coordinator task for flushing memory database by cluster and database name
 	FlushDatabase(cluster string, databaseName string) error
+	// DeleteSeries submits the coordinator task for deleting series of metric by database name and delete statement
+	DeleteSeries(databaseName string, deleteStmt *stmt.Delete) error
+	// DecommissionNode moves all the replicas of the storage node to other active nodes by cluster name
+	DecommissionNode(cluster string, node models.Node) error
+	// GetReplicaDivergences returns the divergent data families between replicas by cluster name
+	GetReplicaDivergences(cluster string) ([]models.ReplicaDivergence, error)
 }
 
 // master implements master interface
@@ -109,12 +127,14 @@ func (m *master) OnFailOver() error {
 
 	stateMachine.StorageCluster, err = storage.NewClusterStateMachine(m.ctx, m.cfg.Repo,
 		m.cfg.ControllerFactory, m.cfg.DiscoveryFactory, m.cfg.ClusterFactory, m.cfg.RepoFactory,
-		m.cfg.StorageStateService, m.cfg.ShardAssignService)
+		m.cfg.StorageStateService, m.cfg.ShardAssignService, m.cfg.BrokerSM.ReplicaStatusSM, m.cfg.Rebalance,
+		m.cfg.Consistency, m.cfg.ClientStreamFactory)
 	if err != nil {
 		return fmt.Errorf("start storage cluster state machine errer:%s", err)
 	}
 
-	stateMachine.DatabaseAdmin, err = database.NewAdminStateMachine(m.ctx, m.cfg.DiscoveryFactory, stateMachine.StorageCluster)
+	stateMachine.DatabaseAdmin, err = database.NewAdminStateMachine(m.ctx, m.cfg.DiscoveryFactory, stateMachine.StorageCluster,
+		m.cfg.Sharding)
 	if err != nil {
 		return fmt.Errorf("start database admin state machine error:%s", err)
 	}
@@ -172,3 +192,48 @@ func (m *master) FlushDatabase(cluster string, databaseName string) error {
 	}
 	return nil
 }
+
+// DeleteSeries submits the coordinator task for deleting series of metric by database name and delete statement,
+// only the storage cluster which has the shard assignment of database will submit the task.
+func (m *master) DeleteSeries(databaseName string, deleteStmt *stmt.Delete) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		for _, cluster := range m.masterCtx.StateMachine.StorageCluster.GetAllCluster() {
+			if err := cluster.DeleteSeries(databaseName, deleteStmt); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// DecommissionNode moves all the replicas of the storage node to other active nodes by cluster name,
+// the replicas are moved in background.
+func (m *master) DecommissionNode(cluster string, node models.Node) error {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		cluster := m.masterCtx.StateMachine.StorageCluster.GetCluster(cluster)
+		if cluster == nil {
+			return errNoCluster
+		}
+		return cluster.DecommissionNode(node)
+	}
+	return nil
+}
+
+// GetReplicaDivergences returns the divergent data families between replicas by cluster name,
+// returns empty if current node isn't master.
+func (m *master) GetReplicaDivergences(cluster string) ([]models.ReplicaDivergence, error) {
+	if m.IsMaster() {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		cluster := m.masterCtx.StateMachine.StorageCluster.GetCluster(cluster)
+		if cluster == nil {
+			return nil, errNoCluster
+		}
+		return cluster.GetReplicaDivergences(), nil
+	}
+	return nil, nil
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestMaster(t *testing.T) {
@@ -170,6 +171,11 @@ func TestMaster_FlushDatabase(t *testing.T) {
 	})
 	err := master1.FlushDatabase("test", "test")
 	assert.NoError(t, err)
+	err = master1.DecommissionNode("test", node1)
+	assert.NoError(t, err)
+	divergences, err := master1.GetReplicaDivergences("test")
+	assert.NoError(t, err)
+	assert.Empty(t, divergences)
 
 	master1.Start()
 	data := encoding.JSONMarshal(&models.Master{Node: node1})
@@ -194,6 +200,35 @@ func TestMaster_FlushDatabase(t *testing.T) {
 	cluster1.EXPECT().FlushDatabase(gomock.Any()).Return(nil)
 	err = master1.FlushDatabase("test", "test")
 	assert.NoError(t, err)
+
+	// delete series
+	deleteStmt := &stmt.Delete{MetricName: "cpu"}
+	clusterSM.EXPECT().GetAllCluster().Return([]storage.Cluster{cluster1}).Times(2)
+	cluster1.EXPECT().DeleteSeries("test", deleteStmt).Return(fmt.Errorf("err"))
+	err = master1.DeleteSeries("test", deleteStmt)
+	assert.Error(t, err)
+	cluster1.EXPECT().DeleteSeries("test", deleteStmt).Return(nil)
+	err = master1.DeleteSeries("test", deleteStmt)
+	assert.NoError(t, err)
+
+	// decommission node
+	clusterSM.EXPECT().GetCluster("test").Return(nil)
+	err = master1.DecommissionNode("test", node1)
+	assert.Equal(t, errNoCluster, err)
+	clusterSM.EXPECT().GetCluster("test").Return(cluster1)
+	cluster1.EXPECT().DecommissionNode(node1).Return(nil)
+	err = master1.DecommissionNode("test", node1)
+	assert.NoError(t, err)
+
+	// replica divergences
+	clusterSM.EXPECT().GetCluster("test").Return(nil)
+	_, err = master1.GetReplicaDivergences("test")
+	assert.Equal(t, errNoCluster, err)
+	clusterSM.EXPECT().GetCluster("test").Return(cluster1)
+	cluster1.EXPECT().GetReplicaDivergences().Return([]models.ReplicaDivergence{{Database: "test"}})
+	divergences, err = master1.GetReplicaDivergences("test")
+	assert.NoError(t, err)
+	assert.Len(t, divergences, 1)
 }
 
 func sendEvent(eventCh chan *state.Event, event *state.Event) {
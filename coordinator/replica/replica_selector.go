@@ -0,0 +1,100 @@
+package replica
+
+import (
+	"context"
+	"sort"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/option"
+)
+
+// selectPolicyKey is the context key of replica select policy
+type selectPolicyKey struct{}
+
+// WithSelectPolicy returns a copy of parent context carrying the replica select policy of query
+func WithSelectPolicy(ctx context.Context, policy option.ReplicaSelectPolicy) context.Context {
+	return context.WithValue(ctx, selectPolicyKey{}, policy)
+}
+
+// SelectPolicyFromContext returns the replica select policy of query from context, returns false if not set
+func SelectPolicyFromContext(ctx context.Context) (option.ReplicaSelectPolicy, bool) {
+	policy, ok := ctx.Value(selectPolicyKey{}).(option.ReplicaSelectPolicy)
+	return policy, ok
+}
+
+// NodeZones returns the zones of active storage nodes, node's indicator => zone
+type NodeZones func() map[string]string
+
+// replicaCandidate represents the queryable replica of shard, the state is aggregated from all brokers
+type replicaCandidate struct {
+	node    string
+	pending int64 // num. of pending msg in all brokers
+	lag     int64 // num. of msg behind the most caught-up replica by ack index in all brokers
+	zone    string
+}
+
+// newReplicaCandidates aggregates the replica states of shard under each broker into candidates,
+// the ack index of different brokers cannot be compared, so the lag is calculated under each broker then summed.
+func newReplicaCandidates(brokers map[string][]models.ReplicaState) []*replicaCandidate {
+	candidates := make(map[string]*replicaCandidate)
+	for _, replicas := range brokers {
+		var maxAckIndex int64
+		for _, replica := range replicas {
+			if replica.AckIndex > maxAckIndex {
+				maxAckIndex = replica.AckIndex
+			}
+		}
+		for _, replica := range replicas {
+			node := replica.Target.Indicator()
+			candidate, ok := candidates[node]
+			if !ok {
+				candidate = &replicaCandidate{node: node}
+				candidates[node] = candidate
+			}
+			candidate.pending += replica.Pending
+			candidate.lag += maxAckIndex - replica.AckIndex
+		}
+	}
+	result := make([]*replicaCandidate, 0, len(candidates))
+	for _, candidate := range candidates {
+		result = append(result, candidate)
+	}
+	// sorts by node first, makes the result stable if candidates have same priority
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].node < result[j].node
+	})
+	return result
+}
+
+// sortCandidates sorts the candidates of shard by select policy, the first one is chosen for querying
+func sortCandidates(candidates []*replicaCandidate, policy option.ReplicaSelectPolicy, localZone string, seq uint64) {
+	switch policy {
+	case option.MostCaughtUp:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			if candidates[i].lag != candidates[j].lag {
+				return candidates[i].lag < candidates[j].lag
+			}
+			return candidates[i].pending < candidates[j].pending
+		})
+	case option.PreferLocalZone:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			localI := localZone != "" && candidates[i].zone == localZone
+			localJ := localZone != "" && candidates[j].zone == localZone
+			if localI != localJ {
+				return localI
+			}
+			return candidates[i].pending < candidates[j].pending
+		})
+	case option.RoundRobin:
+		// rotates the candidates sorted by node
+		offset := int(seq % uint64(len(candidates)))
+		rotated := make([]*replicaCandidate, 0, len(candidates))
+		rotated = append(rotated, candidates[offset:]...)
+		rotated = append(rotated, candidates[:offset]...)
+		copy(candidates, rotated)
+	default:
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].pending < candidates[j].pending
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package replica
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/option"
+)
+
+func TestSelectPolicyContext(t *testing.T) {
+	_, ok := SelectPolicyFromContext(context.TODO())
+	assert.False(t, ok)
+
+	policy, ok := SelectPolicyFromContext(WithSelectPolicy(context.TODO(), option.RoundRobin))
+	assert.True(t, ok)
+	assert.Equal(t, option.RoundRobin, policy)
+}
+
+func TestNewReplicaCandidates(t *testing.T) {
+	node1 := models.Node{IP: "1.1.1.1", Port: 2090}
+	node2 := models.Node{IP: "1.1.1.2", Port: 2090}
+	candidates := newReplicaCandidates(map[string][]models.ReplicaState{
+		"broker-1": {
+			{Target: node2, Pending: 10, AckIndex: 100},
+			{Target: node1, Pending: 5, AckIndex: 80},
+		},
+		"broker-2": {
+			{Target: node1, Pending: 5, AckIndex: 10},
+			{Target: node2, Pending: 10, AckIndex: 5},
+		},
+	})
+	assert.Equal(t, []*replicaCandidate{
+		{node: "1.1.1.1:2090", pending: 10, lag: 20},
+		{node: "1.1.1.2:2090", pending: 20, lag: 5},
+	}, candidates)
+}
+
+func TestSortCandidates(t *testing.T) {
+	newCandidates := func() []*replicaCandidate {
+		return []*replicaCandidate{
+			{node: "a", pending: 30, lag: 0, zone: "zone-b"},
+			{node: "b", pending: 10, lag: 20, zone: "zone-b"},
+			{node: "c", pending: 20, lag: 0, zone: "zone-a"},
+		}
+	}
+	nodes := func(candidates []*replicaCandidate) []string {
+		var result []string
+		for _, candidate := range candidates {
+			result = append(result, candidate.node)
+		}
+		return result
+	}
+
+	candidates := newCandidates()
+	sortCandidates(candidates, option.LeastPending, "", 0)
+	assert.Equal(t, []string{"b", "c", "a"}, nodes(candidates))
+
+	candidates = newCandidates()
+	sortCandidates(candidates, option.MostCaughtUp, "", 0)
+	assert.Equal(t, []string{"c", "a", "b"}, nodes(candidates))
+
+	candidates = newCandidates()
+	sortCandidates(candidates, option.PreferLocalZone, "zone-a", 0)
+	assert.Equal(t, []string{"c", "b", "a"}, nodes(candidates))
+	// local zone unknown, same as least pending
+	candidates = newCandidates()
+	sortCandidates(candidates, option.PreferLocalZone, "", 0)
+	assert.Equal(t, []string{"b", "c", "a"}, nodes(candidates))
+
+	candidates = newCandidates()
+	sortCandidates(candidates, option.RoundRobin, "", 4)
+	assert.Equal(t, []string{"b", "c", "a"}, nodes(candidates))
+	candidates = newCandidates()
+	sortCandidates(candidates, option.RoundRobin, "", 3)
+	assert.Equal(t, []string{"a", "b", "c"}, nodes(candidates))
+}
@@ -89,9 +89,16 @@ func (sm *replicatorStateMachine) OnCreate(key string, resource []byte) {
 func (sm *replicatorStateMachine) OnDelete(key string) {
 	_, dbName := filepath.Split(key)
 	sm.mutex.Lock()
-	//FIXME: need remove replicator and channel when database delete?
+	defer sm.mutex.Unlock()
+
 	delete(sm.shardAssigns, dbName)
-	sm.mutex.Unlock()
+	// stop replicators and remove replication channel for deletion database
+	if err := sm.cm.DropDatabase(dbName); err != nil {
+		sm.log.Error("drop database replication channel error",
+			logger.String("db", dbName), logger.Error(err))
+	}
+	// final sync new replicator state
+	sm.cm.SyncReplicatorState()
 }
 
 // Close closes the state machine
@@ -110,6 +117,8 @@ func (sm *replicatorStateMachine) buildShardAssign(shardAssign *models.ShardAssi
 	for shardID := range shards {
 		sm.createReplicaChannel(numOfShard, shardID, shardAssign)
 	}
+	// sync shard epochs after all shard channels created, then data can be routed to new shards
+	sm.cm.SyncShardEpochs(shardAssign.Name, shardAssign.GetEpochs())
 }
 
 // createReplicaChannel creates wal replica channel for spec database's shard
@@ -125,21 +134,29 @@ func (sm *replicatorStateMachine) createReplicaChannel(numOfShard, shardID int,
 	sm.startReplicator(ch, shardID, shardAssign)
 }
 
-// startReplicator starts wal replicator for spec database's shard
+// startReplicator starts wal replicator for spec database's shard, includes the learners which are catching up data,
+// then stops the replicators whose target is removed from shard assignment(replica moved by rebalancing).
 func (sm *replicatorStateMachine) startReplicator(ch replication.Channel, shardID int, shardAssign *models.ShardAssignment) {
 	replica := shardAssign.Shards[shardID]
 	db := shardAssign.Name
 
-	for _, replicaID := range replica.Replicas {
-		target := shardAssign.Nodes[replicaID]
-		if target != nil {
-			_, err := ch.GetOrCreateReplicator(*target)
-			if err != nil {
-				sm.log.Error("start replicator", logger.Error(err))
-				continue
+	createReplicators := func(replicaIDs []int) (targets []models.Node) {
+		for _, replicaID := range replicaIDs {
+			target := shardAssign.Nodes[replicaID]
+			if target != nil {
+				_, err := ch.GetOrCreateReplicator(*target)
+				if err != nil {
+					sm.log.Error("start replicator", logger.Error(err))
+					continue
+				}
+				sm.log.Info("create replicator successfully", logger.String("db", db),
+					logger.Any("shardID", shardID), logger.String("target", target.Indicator()))
+				targets = append(targets, *target)
 			}
-			sm.log.Info("create replicator successfully", logger.String("db", db),
-				logger.Any("shardID", shardID), logger.String("target", target.Indicator()))
 		}
+		return targets
 	}
+	replicas := createReplicators(replica.Replicas)
+	learners := createReplicators(replica.Learners)
+	ch.SyncTargets(replicas, learners)
 }
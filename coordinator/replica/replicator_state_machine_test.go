@@ -34,6 +34,7 @@ func TestReplicatorStateMachine(t *testing.T) {
 	shardAssign := models.NewShardAssignment("test")
 	shardAssign.Nodes[1] = &models.Node{IP: "1.1.1.1", Port: 9000}
 	shardAssign.AddReplica(1, 1)
+	cm.EXPECT().SyncShardEpochs("test", models.ShardEpochs{{NumOfShard: 1}}).Times(3)
 
 	shardAssignSRV.EXPECT().List().Return(nil, nil)
 	discovery1.EXPECT().Discovery().Return(fmt.Errorf("err"))
@@ -64,18 +65,23 @@ func TestReplicatorStateMachine(t *testing.T) {
 	ch := replication.NewMockChannel(ctrl)
 	cm.EXPECT().CreateChannel(gomock.Any(), gomock.Any(), gomock.Any()).Return(ch, nil)
 	ch.EXPECT().GetOrCreateReplicator(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	ch.EXPECT().SyncTargets(nil, nil)
 	sm.OnCreate("/test/path", data)
 
 	cm.EXPECT().CreateChannel(gomock.Any(), gomock.Any(), gomock.Any()).Return(ch, nil)
 	ch.EXPECT().GetOrCreateReplicator(gomock.Any()).Return(nil, nil)
+	ch.EXPECT().SyncTargets([]models.Node{{IP: "1.1.1.1", Port: 9000}}, nil)
 	sm.OnCreate("/test/path", data)
 
 	s := sm.(*replicatorStateMachine)
 	assert.Equal(t, 1, len(s.shardAssigns))
 	assert.NotNil(t, s.shardAssigns["test"])
 
+	cm.EXPECT().DropDatabase("test").Return(fmt.Errorf("err"))
 	sm.OnDelete("/shard/test")
 	assert.Equal(t, 0, len(s.shardAssigns))
+	cm.EXPECT().DropDatabase("test").Return(nil)
+	sm.OnDelete("/shard/test")
 
 	discovery1.EXPECT().Close()
 	err = sm.Close()
@@ -5,13 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
-	"sort"
 	"sync"
 
+	"go.uber.org/atomic"
+
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/discovery"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/option"
 )
 
 //go:generate mockgen -source=./status_state_machine.go -destination=./status_state_machine_mock.go -package=replica
@@ -21,11 +23,13 @@ import (
 type StatusStateMachine interface {
 	discovery.Listener
 	// GetQueryableReplicas returns the queryable replicas，
-	// and chooses the fastest replica if the shard has multi-replica.
-	// returns storage node => shard id list
-	GetQueryableReplicas(database string) map[string][]int32
+	// and chooses one replica by select policy if the shard has multi-replica,
+	// the other replicas are returned as candidates for retrying, returns nil if no queryable replica.
+	GetQueryableReplicas(database string, policy option.ReplicaSelectPolicy) *models.QueryableReplicas
 	// GetReplicas returns the replica state list under this broker by broker's indicator
 	GetReplicas(broker string) models.BrokerReplicaState
+	// GetAllReplicas returns the replica state list of all brokers, broker's indicator => replica state list
+	GetAllReplicas() map[string]models.BrokerReplicaState
 	// Close closes state machine, stops watch change event
 	Close() error
 }
@@ -38,6 +42,10 @@ type statusStateMachine struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
+	localZone string    // zone of current broker
+	nodeZones NodeZones // zones of storage nodes
+	seq       atomic.Uint64
+
 	mutex sync.RWMutex
 	// brokers: broker node => replica list under this broker
 	brokers map[string]models.BrokerReplicaState
@@ -45,14 +53,18 @@ type statusStateMachine struct {
 	log *logger.Logger
 }
 
-// NewStatusStateMachine creates a replica's status state machine
-func NewStatusStateMachine(ctx context.Context, factory discovery.Factory) (StatusStateMachine, error) {
+// NewStatusStateMachine creates a replica's status state machine,
+// the local zone and zones of storage nodes are used by prefer-local-zone select policy.
+func NewStatusStateMachine(ctx context.Context, factory discovery.Factory,
+	localZone string, nodeZones NodeZones) (StatusStateMachine, error) {
 	c, cancel := context.WithCancel(ctx)
 	sm := &statusStateMachine{
-		ctx:     c,
-		cancel:  cancel,
-		brokers: make(map[string]models.BrokerReplicaState),
-		log:     logger.GetLogger("coordinator", "ReplicaStatusStateMachine"),
+		ctx:       c,
+		cancel:    cancel,
+		localZone: localZone,
+		nodeZones: nodeZones,
+		brokers:   make(map[string]models.BrokerReplicaState),
+		log:       logger.GetLogger("coordinator", "ReplicaStatusStateMachine"),
 	}
 	repo := factory.GetRepo()
 	replicaStatusList, err := repo.List(c, constants.ReplicaStatePath)
@@ -72,19 +84,24 @@ func NewStatusStateMachine(ctx context.Context, factory discovery.Factory) (Stat
 	return sm, nil
 }
 
-// GetQueryableReplicas returns the queryable replicas
-// returns storage node => shard id list
-func (sm *statusStateMachine) GetQueryableReplicas(database string) map[string][]int32 {
-	// 1. find shards by given database's name
-	shards := make(map[string][]models.ReplicaState)
+// GetQueryableReplicas returns the queryable replicas chosen by select policy
+func (sm *statusStateMachine) GetQueryableReplicas(database string,
+	policy option.ReplicaSelectPolicy) *models.QueryableReplicas {
+	// 1. find shards by given database's name, shard id => broker => replica list
+	shards := make(map[int32]map[string][]models.ReplicaState)
 	sm.mutex.RLock()
-	for _, brokerReplicaState := range sm.brokers {
+	for broker, brokerReplicaState := range sm.brokers {
 		for _, replica := range brokerReplicaState.Replicas {
-			if replica.Database != database {
+			// the data of learner is catching up, cannot be queried
+			if replica.Database != database || replica.Learner {
 				continue
 			}
-			shardID := replica.ShardIndicator()
-			shards[shardID] = append(shards[shardID], replica)
+			brokers, ok := shards[replica.ShardID]
+			if !ok {
+				brokers = make(map[string][]models.ReplicaState)
+				shards[replica.ShardID] = brokers
+			}
+			brokers[broker] = append(brokers[broker], replica)
 		}
 	}
 	sm.mutex.RUnlock()
@@ -93,20 +110,30 @@ func (sm *statusStateMachine) GetQueryableReplicas(database string) map[string][
 		return nil
 	}
 
-	result := make(map[string][]int32)
-	for _, replicas := range shards {
-		replicaList := replicas
-		if len(replicaList) > 1 {
-			// has multi-replica, chooses the fastest
-			// sort replicas based pending msg
-			sort.Slice(replicaList, func(i, j int) bool {
-				return replicaList[i].Pending < replicaList[j].Pending
-			})
+	var zones map[string]string
+	if policy == option.PreferLocalZone && sm.nodeZones != nil {
+		zones = sm.nodeZones()
+	}
+	seq := sm.seq.Inc()
+	result := &models.QueryableReplicas{
+		Policy:     string(policy),
+		Nodes:      make(map[string][]int32),
+		Candidates: make(map[int32][]string),
+	}
+	// 2. choose one replica for each shard, others are candidates
+	for shardID, brokers := range shards {
+		candidates := newReplicaCandidates(brokers)
+		for _, candidate := range candidates {
+			candidate.zone = zones[candidate.node]
+		}
+		// shard id as offset, spreads the shards of one query to different replicas for round-robin
+		sortCandidates(candidates, policy, sm.localZone, seq+uint64(shardID))
+		nodeID := candidates[0].node
+		result.Nodes[nodeID] = append(result.Nodes[nodeID], shardID)
+		for _, candidate := range candidates[1:] {
+			result.Candidates[shardID] = append(result.Candidates[shardID], candidate.node)
 		}
-		nodeID := replicaList[0].Target.Indicator()
-		result[nodeID] = append(result[nodeID], replicaList[0].ShardID)
 	}
-
 	return result
 }
 
@@ -117,6 +144,17 @@ func (sm *statusStateMachine) GetReplicas(broker string) models.BrokerReplicaSta
 	return sm.brokers[broker]
 }
 
+// GetAllReplicas returns the replica state list of all brokers, broker's indicator => replica state list
+func (sm *statusStateMachine) GetAllReplicas() map[string]models.BrokerReplicaState {
+	sm.mutex.RLock()
+	defer sm.mutex.RUnlock()
+	result := make(map[string]models.BrokerReplicaState, len(sm.brokers))
+	for broker, state := range sm.brokers {
+		result[broker] = state
+	}
+	return result
+}
+
 // Close closes state machine, stops watch change event
 func (sm *statusStateMachine) Close() error {
 	sm.discovery.Close()
@@ -12,6 +12,7 @@ import (
 
 	"github.com/lindb/lindb/coordinator/discovery"
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/state"
 )
 
@@ -25,18 +26,22 @@ func TestStatusStateMachine(t *testing.T) {
 	discovery1 := discovery.NewMockDiscovery(ctrl)
 	factory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1).AnyTimes()
 
+	nodeZones := func() map[string]string {
+		return map[string]string{"1.1.1.2:2090": "zone-a", "1.1.1.3:2090": "zone-b"}
+	}
+
 	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
-	_, err := NewStatusStateMachine(context.TODO(), factory)
+	_, err := NewStatusStateMachine(context.TODO(), factory, "zone-a", nodeZones)
 	assert.NotNil(t, err)
 
 	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil)
 	discovery1.EXPECT().Discovery().Return(fmt.Errorf("err"))
-	_, err = NewStatusStateMachine(context.TODO(), factory)
+	_, err = NewStatusStateMachine(context.TODO(), factory, "zone-a", nodeZones)
 	assert.NotNil(t, err)
 
 	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return([]state.KeyValue{{Key: "key", Value: []byte{1, 2, 3}}}, nil)
 	discovery1.EXPECT().Discovery().Return(nil)
-	sm, err := NewStatusStateMachine(context.TODO(), factory)
+	sm, err := NewStatusStateMachine(context.TODO(), factory, "zone-a", nodeZones)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -52,6 +57,7 @@ func TestStatusStateMachine(t *testing.T) {
 	data, _ := json.Marshal(&brokerReplicaState)
 	sm.OnCreate("/data/1.1.1.1:9000", data)
 	assert.Equal(t, brokerReplicaState, sm.GetReplicas("1.1.1.1:9000"))
+	assert.Equal(t, map[string]models.BrokerReplicaState{"1.1.1.1:9000": brokerReplicaState}, sm.GetAllReplicas())
 
 	sm.OnDelete("/data/1.1.1.1:9000")
 	assert.Equal(t, 0, len(sm.GetReplicas("1.1.1.1:9000").Replicas))
@@ -106,27 +112,55 @@ func TestStatusStateMachine(t *testing.T) {
 			ReplicaIndex: 90,
 			ShardID:      2,
 		},
+		{
+			// learner isn't queryable
+			Database: "test_db",
+			Target:   models.Node{IP: "1.1.1.4", Port: 2090},
+			ShardID:  2,
+			Learner:  true,
+		},
 	}
 	data, _ = json.Marshal(models.BrokerReplicaState{Replicas: replicaStatus})
 	sm.OnCreate("/broker/2.1.1.2:2080", data)
 
-	r := sm.GetQueryableReplicas("test_db")
-	assert.Equal(t, 1, len(r))
-	shards := r["1.1.1.3:2090"]
-	sort.Slice(shards, func(i, j int) bool {
-		return shards[i] < shards[j]
-	})
-	assert.Equal(t, []int32{1, 2}, shards)
-
-	r = sm.GetQueryableReplicas("test_db_2")
-	assert.Equal(t, 1, len(r))
-	shards = r["1.1.1.2:2090"]
-	sort.Slice(shards, func(i, j int) bool {
-		return shards[i] < shards[j]
-	})
-	assert.Equal(t, []int32{1, 2}, shards)
-
-	r = sm.GetQueryableReplicas("test_db_not_exist")
+	sortShards := func(r *models.QueryableReplicas) {
+		for _, shards := range r.Nodes {
+			sort.Slice(shards, func(i, j int) bool {
+				return shards[i] < shards[j]
+			})
+		}
+	}
+
+	// least pending
+	r := sm.GetQueryableReplicas("test_db", option.LeastPending)
+	sortShards(r)
+	assert.Equal(t, string(option.LeastPending), r.Policy)
+	assert.Equal(t, map[string][]int32{"1.1.1.3:2090": {1, 2}}, r.Nodes)
+	assert.Equal(t, map[int32][]string{1: {"1.1.1.2:2090"}, 2: {"1.1.1.2:2090"}}, r.Candidates)
+
+	// prefer local zone
+	r = sm.GetQueryableReplicas("test_db", option.PreferLocalZone)
+	sortShards(r)
+	assert.Equal(t, map[string][]int32{"1.1.1.2:2090": {1, 2}}, r.Nodes)
+	assert.Equal(t, map[int32][]string{1: {"1.1.1.3:2090"}, 2: {"1.1.1.3:2090"}}, r.Candidates)
+
+	// most caught up, same ack index, then least pending
+	r = sm.GetQueryableReplicas("test_db", option.MostCaughtUp)
+	sortShards(r)
+	assert.Equal(t, map[string][]int32{"1.1.1.3:2090": {1, 2}}, r.Nodes)
+
+	// round robin, each shard has 2 replicas
+	r = sm.GetQueryableReplicas("test_db", option.RoundRobin)
+	assert.Len(t, r.Candidates, 2)
+	assert.Len(t, r.Candidates[1], 1)
+	assert.NotEqual(t, r.Candidates[1][0], r.Candidates[2][0])
+
+	r = sm.GetQueryableReplicas("test_db_2", option.LeastPending)
+	sortShards(r)
+	assert.Equal(t, map[string][]int32{"1.1.1.2:2090": {1, 2}}, r.Nodes)
+	assert.Empty(t, r.Candidates)
+
+	r = sm.GetQueryableReplicas("test_db_not_exist", option.LeastPending)
 	assert.Nil(t, r)
 
 	discovery1.EXPECT().Close()
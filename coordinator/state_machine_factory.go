@@ -23,6 +23,7 @@ type StateMachineCfg struct {
 	ShardAssignSRV    service.ShardAssignService
 	ChannelManager    replication.ChannelManager
 	TaskClientFactory rpc.TaskClientFactory // rpc task stream create factory
+	Zone              string                // zone of current node, used for choosing the replica in same zone
 }
 
 // StateMachineFactory represents the state machine create factory
@@ -31,8 +32,9 @@ type StateMachineFactory interface {
 	CreateNodeStateMachine() (broker.NodeStateMachine, error)
 	// CreateStorageStateMachine creates the storage state machine
 	CreateStorageStateMachine() (broker.StorageStateMachine, error)
-	// CreateReplicaStatusStateMachine creates the shard replica status state machine
-	CreateReplicaStatusStateMachine() (replica.StatusStateMachine, error)
+	// CreateReplicaStatusStateMachine creates the shard replica status state machine,
+	// node zones returns the zones of storage nodes for choosing the replica in same zone.
+	CreateReplicaStatusStateMachine(nodeZones replica.NodeZones) (replica.StatusStateMachine, error)
 	// CreateReplicatorStateMachine creates the shard replicator state machine
 	CreateReplicatorStateMachine() (replica.ReplicatorStateMachine, error)
 	// CreateDatabaseStateMachine creates the database state machine
@@ -60,8 +62,10 @@ func (s *stateMachineFactory) CreateStorageStateMachine() (broker.StorageStateMa
 }
 
 // CreateReplicaStatusStateMachine creates the shard replica status state machine, if fail returns err
-func (s *stateMachineFactory) CreateReplicaStatusStateMachine() (replica.StatusStateMachine, error) {
-	return replica.NewStatusStateMachine(s.cfg.Ctx, s.cfg.DiscoveryFactory)
+func (s *stateMachineFactory) CreateReplicaStatusStateMachine(
+	nodeZones replica.NodeZones,
+) (replica.StatusStateMachine, error) {
+	return replica.NewStatusStateMachine(s.cfg.Ctx, s.cfg.DiscoveryFactory, s.cfg.Zone, nodeZones)
 }
 
 // CreateReplicatorStateMachine creates the shard replicator state machine
@@ -61,11 +61,11 @@ func TestStateMachineFactory_Create(t *testing.T) {
 	// test replica status state machine
 	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil).MaxTimes(2)
 	discovery1.EXPECT().Discovery().Return(fmt.Errorf("err"))
-	replicaStatusSM, err := factory.CreateReplicaStatusStateMachine()
+	replicaStatusSM, err := factory.CreateReplicaStatusStateMachine(nil)
 	assert.NotNil(t, err)
 	assert.Nil(t, replicaStatusSM)
 	discovery1.EXPECT().Discovery().Return(nil)
-	replicaStatusSM, err = factory.CreateReplicaStatusStateMachine()
+	replicaStatusSM, err = factory.CreateReplicaStatusStateMachine(nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, replicaStatusSM)
 
@@ -80,7 +80,7 @@ func TestStateMachineFactory_Create(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, replicatorSM)
 
-	discovery1.EXPECT().Discovery().Return(nil)
+	discovery1.EXPECT().Discovery().Return(nil).Times(2)
 	dbSM, err := factory.CreateDatabaseStateMachine()
 	assert.NoError(t, err)
 	assert.NotNil(t, dbSM)
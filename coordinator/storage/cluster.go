@@ -9,13 +9,16 @@ import (
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/discovery"
+	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/coordinator/task"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 //go:generate mockgen -source=./cluster.go -destination=./cluster_mock.go -package=storage
@@ -32,6 +35,11 @@ type clusterCfg struct {
 	controllerFactory   task.ControllerFactory
 	factory             discovery.Factory
 	shardAssignService  service.ShardAssignService
+	databaseService     service.DatabaseService
+	replicaStatus       replica.StatusStateMachine
+	rebalance           config.Rebalance
+	consistency         config.Consistency
+	fct                 rpc.ClientStreamFactory
 }
 
 // clean cleans the resource for cfg
@@ -61,6 +69,9 @@ func NewClusterFactory() ClusterFactory {
 // 1) discovery active node list in cluster
 // 2) save shard assignment
 // 3) generate coordinator task
+// 4) move the replicas of the node removed from cluster
+// 5) check the data consistency between the replicas of shard
+// 6) register the databases restored by storage nodes
 type Cluster interface {
 	discovery.Listener
 
@@ -76,6 +87,11 @@ type Cluster interface {
 	// FLushDatabase submits the coordinator task for flushing memory database by name
 	FlushDatabase(databaseName string) error
 
+	// DropDatabase submits the coordinator task for dropping database by name, then deletes the shard assignment
+	DropDatabase(databaseName string) error
+	// DeleteSeries submits the coordinator task for deleting series of metric by delete statement
+	DeleteSeries(databaseName string, deleteStmt *stmt.Delete) error
+
 	// SaveShardAssign saves shard assignment
 	SaveShardAssign(
 		databaseName string,
@@ -90,6 +106,12 @@ type Cluster interface {
 		params []task.ControllerTaskParam,
 	) error
 
+	// DecommissionNode moves all the replicas of the node to other active nodes in background
+	DecommissionNode(node models.Node) error
+
+	// GetReplicaDivergences returns the divergent data families between replicas found in latest checking
+	GetReplicaDivergences() []models.ReplicaDivergence
+
 	// GetRepo returns current storage cluster's state repo
 	GetRepo() state.Repository
 
@@ -99,9 +121,12 @@ type Cluster interface {
 
 // cluster implements cluster controller, master will maintain multi storage cluster
 type cluster struct {
-	cfg            clusterCfg
-	discovery      discovery.Discovery
-	taskController task.Controller
+	cfg              clusterCfg
+	discovery        discovery.Discovery
+	restoreDiscovery discovery.Discovery
+	taskController   task.Controller
+	rebalancer       Rebalancer
+	checker          ConsistencyChecker
 
 	clusterState *models.StorageState
 
@@ -122,6 +147,9 @@ func (f *clusterFactory) newCluster(cfg clusterCfg) (Cluster, error) {
 	for _, node := range nodeList {
 		_ = cluster.addNode(node.Value)
 	}
+	cluster.taskController = cfg.controllerFactory.CreateController(cfg.ctx, cfg.repo)
+	cluster.rebalancer = newRebalancer(cfg, cluster.taskController, cluster.GetActiveNodes)
+	cluster.checker = newConsistencyChecker(cfg, cluster.GetActiveNodes)
 	// set cluster name
 	cluster.clusterState.Name = cfg.cfg.Name
 	// saving new cluster state
@@ -132,28 +160,40 @@ func (f *clusterFactory) newCluster(cfg clusterCfg) (Cluster, error) {
 	if err := cluster.discovery.Discovery(); err != nil {
 		return cluster, fmt.Errorf("discovery active storage nodes error:%s", err)
 	}
-	cluster.taskController = cfg.controllerFactory.CreateController(cfg.ctx, cfg.repo)
+	// new restored database discovery
+	cluster.restoreDiscovery = cfg.factory.CreateDiscovery(constants.RestoredDatabasePath,
+		&restoredDatabaseListener{cluster: cluster})
+	if err := cluster.restoreDiscovery.Discovery(); err != nil {
+		return cluster, fmt.Errorf("discovery restored databases error:%s", err)
+	}
 
 	log.Info("init storage cluster success", logger.String("cluster", cluster.clusterState.Name))
 	return cluster, nil
 }
 
-// OnCreate adds node into active node list when node online
+// OnCreate adds node into active node list when node online,
+// cancels moving the replicas of node if node is online within grace period
 func (c *cluster) OnCreate(key string, resource []byte) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
-	if c.addNode(resource) {
+	if node := c.addNode(resource); node != nil {
+		c.rebalancer.OnNodeOnline(node.Node)
 		c.saveClusterState()
 	}
 }
 
-// OnDelete remove node from active node list when node offline
+// OnDelete remove node from active node list when node offline,
+// the replicas of node will be moved if node is offline longer than grace period
 func (c *cluster) OnDelete(key string) {
 	_, name := filepath.Split(key)
 	c.mutex.Lock()
+	node, ok := c.clusterState.ActiveNodes[name]
 	c.clusterState.RemoveActiveNode(name)
 	c.mutex.Unlock()
 
+	if ok {
+		c.rebalancer.OnNodeOffline(node.Node)
+	}
 	c.saveClusterState()
 }
 
@@ -209,6 +249,65 @@ func (c *cluster) FlushDatabase(databaseName string) error {
 	return nil
 }
 
+// DropDatabase submits the coordinator task for dropping database by name, then deletes the shard assignment.
+// the task will be sent to all nodes which hold the replicas of database,
+// storage node will execute it when it is online even if it is offline now.
+func (c *cluster) DropDatabase(databaseName string) error {
+	shardAssign, err := c.GetShardAssign(databaseName)
+	if err != nil {
+		if err == state.ErrNotExist {
+			return nil
+		}
+		return err
+	}
+	var params []task.ControllerTaskParam
+	taskParam := &models.DatabaseDropTask{DatabaseName: databaseName}
+	for _, node := range shardAssign.Nodes {
+		params = append(params, task.ControllerTaskParam{
+			NodeID: node.Indicator(),
+			Params: taskParam,
+		})
+	}
+	// create drop database coordinator tasks
+	if err := c.SubmitTask(constants.DropDatabase, databaseName, params); err != nil {
+		return err
+	}
+	return c.cfg.shardAssignService.Delete(databaseName)
+}
+
+// DeleteSeries submits the coordinator task for deleting series of metric by delete statement,
+// the task will be sent to all nodes which hold the replicas of database.
+func (c *cluster) DeleteSeries(databaseName string, deleteStmt *stmt.Delete) error {
+	shardAssign, err := c.GetShardAssign(databaseName)
+	if err != nil {
+		if err == state.ErrNotExist {
+			return nil
+		}
+		return err
+	}
+	var tasks = make(map[int]*models.SeriesDeleteTask)
+	for ID, shard := range shardAssign.Shards {
+		for _, replicaID := range shard.Replicas {
+			taskParam, ok := tasks[replicaID]
+			if !ok {
+				taskParam = &models.SeriesDeleteTask{DatabaseName: databaseName, Statement: deleteStmt}
+				tasks[replicaID] = taskParam
+			}
+			taskParam.ShardIDs = append(taskParam.ShardIDs, int32(ID))
+		}
+	}
+	var params []task.ControllerTaskParam
+	for nodeID, taskParam := range tasks {
+		node := shardAssign.Nodes[nodeID]
+		params = append(params, task.ControllerTaskParam{
+			NodeID: node.Indicator(),
+			Params: taskParam,
+		})
+	}
+	// create delete series coordinator tasks
+	return c.SubmitTask(constants.DeleteSeries, databaseName, params)
+}
+
 // GetShardAssign returns shard assignment by database name, return not exist err if it not exist
 func (c *cluster) GetShardAssign(databaseName string) (*models.ShardAssignment, error) {
 	return c.cfg.shardAssignService.Get(databaseName)
@@ -251,6 +350,16 @@ func (c *cluster) SaveShardAssign(
 	return nil
 }
 
+// DecommissionNode moves all the replicas of the node to other active nodes in background
+func (c *cluster) DecommissionNode(node models.Node) error {
+	return c.rebalancer.Decommission(node)
+}
+
+// GetReplicaDivergences returns the divergent data families between replicas found in latest checking
+func (c *cluster) GetReplicaDivergences() []models.ReplicaDivergence {
+	return c.checker.GetDivergences()
+}
+
 // SubmitTask submits coordinator task based on kind and params into related storage cluster,
 // storage node will execute task if it care this task kind
 func (c *cluster) SubmitTask(kind task.Kind, name string, params []task.ControllerTaskParam) error {
@@ -260,6 +369,12 @@ func (c *cluster) SubmitTask(kind task.Kind, name string, params []task.Controll
 // Close stops watch, and cleanups cluster's metadata
 func (c *cluster) Close() {
 	log.Info("close storage cluster state machine", logger.String("cluster", c.cfg.cfg.Name))
+	if c.rebalancer != nil {
+		c.rebalancer.Close()
+	}
+	if c.checker != nil {
+		c.checker.Close()
+	}
 	if c.taskController != nil {
 		// need close task controller of current storage cluster
 		if err := c.taskController.Close(); err != nil {
@@ -269,21 +384,24 @@ func (c *cluster) Close() {
 	if c.discovery != nil {
 		c.discovery.Close()
 	}
+	if c.restoreDiscovery != nil {
+		c.restoreDiscovery.Close()
+	}
 
 	(&c.cfg).clean()
 }
 
-// addNode adds node into active node list
-func (c *cluster) addNode(resource []byte) bool {
+// addNode adds node into active node list, returns nil if unmarshal fail
+func (c *cluster) addNode(resource []byte) *models.ActiveNode {
 	node := &models.ActiveNode{}
 	if err := encoding.JSONUnmarshal(resource, node); err != nil {
 		log.Error("discovery new storage node but unmarshal error",
 			logger.String("data", string(resource)), logger.Error(err))
-		return false
+		return nil
 	}
 
 	c.clusterState.AddActiveNode(node)
-	return true
+	return node
 }
 
 // saveClusterState saves a new storage cluster snapshot into state repo.
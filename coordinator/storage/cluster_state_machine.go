@@ -11,10 +11,12 @@ import (
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/discovery"
+	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/coordinator/task"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/service"
 )
 
@@ -46,6 +48,10 @@ type clusterStateMachine struct {
 	discoveryFactory    discovery.Factory
 	repoFactory         state.RepositoryFactory
 	controllerFactory   task.ControllerFactory
+	replicaStatus       replica.StatusStateMachine
+	rebalance           config.Rebalance
+	consistency         config.Consistency
+	fct                 rpc.ClientStreamFactory
 
 	clusters map[string]Cluster
 
@@ -65,7 +71,11 @@ func NewClusterStateMachine(
 	clusterFactory ClusterFactory,
 	repoFactory state.RepositoryFactory,
 	storageStateService service.StorageStateService,
-	shardAssignService service.ShardAssignService) (ClusterStateMachine, error) {
+	shardAssignService service.ShardAssignService,
+	replicaStatus replica.StatusStateMachine,
+	rebalance config.Rebalance,
+	consistency config.Consistency,
+	fct rpc.ClientStreamFactory) (ClusterStateMachine, error) {
 	log := logger.GetLogger("coordinator", "StorageClusterStateMachine")
 	c, cancel := context.WithCancel(ctx)
 	stateMachine := &clusterStateMachine{
@@ -78,6 +88,10 @@ func NewClusterStateMachine(
 		storageStateService: storageStateService,
 		controllerFactory:   controllerFactory,
 		shardAssignService:  shardAssignService,
+		replicaStatus:       replicaStatus,
+		rebalance:           rebalance,
+		consistency:         consistency,
+		fct:                 fct,
 		clusters:            make(map[string]Cluster),
 		interval:            30 * time.Second, //TODO add config ?
 		log:                 log,
@@ -223,6 +237,11 @@ func (c *clusterStateMachine) addCluster(resource []byte) {
 		controllerFactory:   c.controllerFactory,
 		factory:             discovery.NewFactory(repo),
 		shardAssignService:  c.shardAssignService,
+		databaseService:     service.NewDatabaseService(c.repo),
+		replicaStatus:       c.replicaStatus,
+		rebalance:           c.rebalance,
+		consistency:         c.consistency,
+		fct:                 c.fct,
 	}
 	cluster, err := c.clusterFactory.newCluster(clusterCfg)
 	if err != nil {
@@ -9,6 +9,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/coordinator/discovery"
 	"github.com/lindb/lindb/coordinator/task"
 	"github.com/lindb/lindb/models"
@@ -37,7 +38,7 @@ func TestClusterStateMachine_New(t *testing.T) {
 	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
 	_, err := NewClusterStateMachine(context.TODO(), repo,
 		controllerFactory, discoverFactory, clusterFactory, repoFactory,
-		storageService, shardAssignService)
+		storageService, shardAssignService, nil, config.Rebalance{}, config.Consistency{}, nil)
 
 	assert.NotNil(t, err)
 
@@ -46,7 +47,7 @@ func TestClusterStateMachine_New(t *testing.T) {
 	discovery1.EXPECT().Discovery().Return(fmt.Errorf("err"))
 	_, err = NewClusterStateMachine(context.TODO(), repo,
 		controllerFactory, discoverFactory, clusterFactory, repoFactory,
-		storageService, shardAssignService)
+		storageService, shardAssignService, nil, config.Rebalance{}, config.Consistency{}, nil)
 	assert.NotNil(t, err)
 
 	// normal case
@@ -73,7 +74,7 @@ func TestClusterStateMachine_New(t *testing.T) {
 
 	stateMachine, err := NewClusterStateMachine(context.TODO(), repo,
 		controllerFactory, discoverFactory, clusterFactory, repoFactory,
-		storageService, shardAssignService)
+		storageService, shardAssignService, nil, config.Rebalance{}, config.Consistency{}, nil)
 
 	assert.Nil(t, err)
 	assert.NotNil(t, stateMachine)
@@ -116,7 +117,7 @@ func TestClusterStateMachine_collect(t *testing.T) {
 	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil)
 	sm, err := NewClusterStateMachine(context.TODO(), repo,
 		controllerFactory, discoverFactory, clusterFactory, repoFactory,
-		storageService, shardAssignService)
+		storageService, shardAssignService, nil, config.Rebalance{}, config.Consistency{}, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, sm)
 	sm1 := sm.(*clusterStateMachine)
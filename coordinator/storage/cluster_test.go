@@ -1,10 +1,12 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -16,6 +18,7 @@ import (
 	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/state"
 	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestStorageCluster(t *testing.T) {
@@ -31,6 +34,7 @@ func TestStorageCluster(t *testing.T) {
 	}
 	discoveryFactory := discovery.NewMockFactory(ctrl)
 	discovery1 := discovery.NewMockDiscovery(ctrl)
+	mockRestoreDiscovery(ctrl, discoveryFactory)
 	discoveryFactory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1).AnyTimes()
 
 	storageService := service.NewMockStorageStateService(ctrl)
@@ -42,6 +46,7 @@ func TestStorageCluster(t *testing.T) {
 	controllerFactory.EXPECT().CreateController(gomock.Any(), gomock.Any()).Return(controller).AnyTimes()
 	shardAssignService := service.NewMockShardAssignService(ctrl)
 	cfg := clusterCfg{
+		ctx:                 context.TODO(),
 		storageStateService: storageService,
 		cfg:                 storage,
 		repo:                repo,
@@ -126,6 +131,29 @@ func TestStorageCluster(t *testing.T) {
 
 	assert.Equal(t, repo, cluster.GetRepo())
 
+	// rebalance replicas of node
+	rebalancer := NewMockRebalancer(ctrl)
+	setRebalancer(cluster, rebalancer)
+	node := models.Node{IP: "1.1.1.5", Port: 4000}
+	storageService.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil).Times(3)
+	rebalancer.EXPECT().OnNodeOnline(node)
+	cluster.OnCreate("/active/nodes/1.1.1.5:4000", encoding.JSONMarshal(&models.ActiveNode{Node: node}))
+	rebalancer.EXPECT().OnNodeOffline(node)
+	cluster.OnDelete("/active/nodes/1.1.1.5:4000")
+	// node not exist
+	cluster.OnDelete("/active/nodes/1.1.1.5:4000")
+	rebalancer.EXPECT().Decommission(node).Return(fmt.Errorf("err"))
+	assert.Error(t, cluster.DecommissionNode(node))
+
+	// replica divergences
+	checker := NewMockConsistencyChecker(ctrl)
+	setConsistencyChecker(cluster, checker)
+	divergences := []models.ReplicaDivergence{{Database: "test", ShardID: 1}}
+	checker.EXPECT().GetDivergences().Return(divergences)
+	assert.Equal(t, divergences, cluster.GetReplicaDivergences())
+
+	rebalancer.EXPECT().Close()
+	checker.EXPECT().Close()
 	discovery1.EXPECT().Close()
 	repo.EXPECT().Close().Return(fmt.Errorf("err"))
 	cluster.Close()
@@ -141,6 +169,7 @@ func TestCluster_CollectStat(t *testing.T) {
 	}
 	discoveryFactory := discovery.NewMockFactory(ctrl)
 	discovery1 := discovery.NewMockDiscovery(ctrl)
+	mockRestoreDiscovery(ctrl, discoveryFactory)
 	discoveryFactory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1).AnyTimes()
 
 	storageService := service.NewMockStorageStateService(ctrl)
@@ -153,6 +182,7 @@ func TestCluster_CollectStat(t *testing.T) {
 	controllerFactory := task.NewMockControllerFactory(ctrl)
 	controllerFactory.EXPECT().CreateController(gomock.Any(), gomock.Any()).Return(controller).AnyTimes()
 	cfg := clusterCfg{
+		ctx:                 context.TODO(),
 		storageStateService: storageService,
 		cfg:                 storage,
 		repo:                repo,
@@ -202,6 +232,7 @@ func TestCluster_FlushDatabase(t *testing.T) {
 	}
 	discoveryFactory := discovery.NewMockFactory(ctrl)
 	discovery1 := discovery.NewMockDiscovery(ctrl)
+	mockRestoreDiscovery(ctrl, discoveryFactory)
 	discoveryFactory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1).AnyTimes()
 
 	storageService := service.NewMockStorageStateService(ctrl)
@@ -214,6 +245,7 @@ func TestCluster_FlushDatabase(t *testing.T) {
 	controllerFactory := task.NewMockControllerFactory(ctrl)
 	controllerFactory.EXPECT().CreateController(gomock.Any(), gomock.Any()).Return(controller).AnyTimes()
 	cfg := clusterCfg{
+		ctx:                 context.TODO(),
 		storageStateService: storageService,
 		cfg:                 storage,
 		repo:                repo,
@@ -239,3 +271,163 @@ func TestCluster_FlushDatabase(t *testing.T) {
 	err = cluster1.FlushDatabase("test")
 	assert.Error(t, err)
 }
+
+func TestCluster_DropDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	factory := NewClusterFactory()
+	storage := config.StorageCluster{
+		Config: config.RepoState{Namespace: "storage"},
+	}
+	discoveryFactory := discovery.NewMockFactory(ctrl)
+	discovery1 := discovery.NewMockDiscovery(ctrl)
+	mockRestoreDiscovery(ctrl, discoveryFactory)
+	discoveryFactory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1).AnyTimes()
+
+	storageService := service.NewMockStorageStateService(ctrl)
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	repo := state.NewMockRepository(ctrl)
+	discovery1.EXPECT().Discovery().Return(nil)
+
+	storageService.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	controller := task.NewMockController(ctrl)
+	controllerFactory := task.NewMockControllerFactory(ctrl)
+	controllerFactory.EXPECT().CreateController(gomock.Any(), gomock.Any()).Return(controller).AnyTimes()
+	cfg := clusterCfg{
+		ctx:                 context.TODO(),
+		storageStateService: storageService,
+		shardAssignService:  shardAssignService,
+		cfg:                 storage,
+		repo:                repo,
+		factory:             discoveryFactory,
+		controllerFactory:   controllerFactory,
+	}
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil)
+	cluster1, err := factory.newCluster(cfg)
+	assert.Nil(t, err)
+	assert.NotNil(t, cluster1)
+
+	// case 1: shard assign not exist
+	shardAssignService.EXPECT().Get("test").Return(nil, state.ErrNotExist)
+	err = cluster1.DropDatabase("test")
+	assert.NoError(t, err)
+	// case 2: get shard assign err
+	shardAssignService.EXPECT().Get("test").Return(nil, fmt.Errorf("err"))
+	err = cluster1.DropDatabase("test")
+	assert.Error(t, err)
+
+	shardAssign := models.NewShardAssignment("test")
+	shardAssign.Nodes[1] = &models.Node{IP: "1.1.1.1", Port: 9000}
+	shardAssign.Nodes[2] = &models.Node{IP: "1.1.1.2", Port: 9000}
+	shardAssignService.EXPECT().Get("test").Return(shardAssign, nil).AnyTimes()
+	// case 3: submit task err
+	controller.EXPECT().Submit(constants.DropDatabase, "test", gomock.Any()).Return(fmt.Errorf("err"))
+	err = cluster1.DropDatabase("test")
+	assert.Error(t, err)
+	// case 4: drop database successfully
+	controller.EXPECT().Submit(constants.DropDatabase, "test", gomock.Any()).
+		DoAndReturn(func(_ task.Kind, _ string, params []task.ControllerTaskParam) error {
+			assert.Len(t, params, 2)
+			return nil
+		})
+	shardAssignService.EXPECT().Delete("test").Return(nil)
+	err = cluster1.DropDatabase("test")
+	assert.NoError(t, err)
+}
+
+func TestCluster_DeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	factory := NewClusterFactory()
+	storage := config.StorageCluster{
+		Config: config.RepoState{Namespace: "storage"},
+	}
+	discoveryFactory := discovery.NewMockFactory(ctrl)
+	discovery1 := discovery.NewMockDiscovery(ctrl)
+	mockRestoreDiscovery(ctrl, discoveryFactory)
+	discoveryFactory.EXPECT().CreateDiscovery(gomock.Any(), gomock.Any()).Return(discovery1).AnyTimes()
+
+	storageService := service.NewMockStorageStateService(ctrl)
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	repo := state.NewMockRepository(ctrl)
+	discovery1.EXPECT().Discovery().Return(nil)
+
+	storageService.EXPECT().Save(gomock.Any(), gomock.Any()).Return(nil)
+	controller := task.NewMockController(ctrl)
+	controllerFactory := task.NewMockControllerFactory(ctrl)
+	controllerFactory.EXPECT().CreateController(gomock.Any(), gomock.Any()).Return(controller).AnyTimes()
+	cfg := clusterCfg{
+		ctx:                 context.TODO(),
+		storageStateService: storageService,
+		shardAssignService:  shardAssignService,
+		cfg:                 storage,
+		repo:                repo,
+		factory:             discoveryFactory,
+		controllerFactory:   controllerFactory,
+	}
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(nil, nil)
+	cluster1, err := factory.newCluster(cfg)
+	assert.Nil(t, err)
+	assert.NotNil(t, cluster1)
+
+	deleteStmt := &stmt.Delete{MetricName: "cpu"}
+	// case 1: shard assign not exist
+	shardAssignService.EXPECT().Get("test").Return(nil, state.ErrNotExist)
+	err = cluster1.DeleteSeries("test", deleteStmt)
+	assert.NoError(t, err)
+	// case 2: get shard assign err
+	shardAssignService.EXPECT().Get("test").Return(nil, fmt.Errorf("err"))
+	err = cluster1.DeleteSeries("test", deleteStmt)
+	assert.Error(t, err)
+
+	shardAssign := models.NewShardAssignment("test")
+	shardAssign.Nodes[1] = &models.Node{IP: "1.1.1.1", Port: 9000}
+	shardAssign.Nodes[2] = &models.Node{IP: "1.1.1.2", Port: 9000}
+	shardAssign.AddReplica(1, 1)
+	shardAssign.AddReplica(1, 2)
+	shardAssign.AddReplica(2, 2)
+	shardAssignService.EXPECT().Get("test").Return(shardAssign, nil).AnyTimes()
+	// case 3: submit task err
+	controller.EXPECT().Submit(constants.DeleteSeries, "test", gomock.Any()).Return(fmt.Errorf("err"))
+	err = cluster1.DeleteSeries("test", deleteStmt)
+	assert.Error(t, err)
+	// case 4: delete series successfully
+	controller.EXPECT().Submit(constants.DeleteSeries, "test", gomock.Any()).
+		DoAndReturn(func(_ task.Kind, _ string, params []task.ControllerTaskParam) error {
+			assert.Len(t, params, 2)
+			for _, param := range params {
+				taskParam := param.Params.(*models.SeriesDeleteTask)
+				if param.NodeID == shardAssign.Nodes[1].Indicator() {
+					assert.Equal(t, []int32{1}, taskParam.ShardIDs)
+				} else {
+					assert.Len(t, taskParam.ShardIDs, 2)
+				}
+				assert.Equal(t, deleteStmt, taskParam.Statement)
+			}
+			return nil
+		})
+	err = cluster1.DeleteSeries("test", deleteStmt)
+	assert.NoError(t, err)
+}
+
+// setRebalancer replaces the rebalancer of cluster for testing
+func setRebalancer(c Cluster, rebalancer Rebalancer) {
+	impl := c.(*cluster)
+	impl.rebalancer.Close()
+	impl.rebalancer = rebalancer
+}
+
+func setConsistencyChecker(c Cluster, checker ConsistencyChecker) {
+	impl := c.(*cluster)
+	impl.checker.Close()
+	impl.checker = checker
+}
+
+// mockRestoreDiscovery mocks the discovery of restored databases
+func mockRestoreDiscovery(ctrl *gomock.Controller, discoveryFactory *discovery.MockFactory) {
+	restoreDiscovery := discovery.NewMockDiscovery(ctrl)
+	restoreDiscovery.EXPECT().Discovery().Return(nil).AnyTimes()
+	restoreDiscovery.EXPECT().Close().AnyTimes()
+	discoveryFactory.EXPECT().CreateDiscovery(constants.RestoredDatabasePath, gomock.Any()).
+		Return(restoreDiscovery).AnyTimes()
+}
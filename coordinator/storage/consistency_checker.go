@@ -0,0 +1,368 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/monitoring"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/rpc"
+	"github.com/lindb/lindb/rpc/proto/transfer"
+	"github.com/lindb/lindb/service"
+)
+
+//go:generate mockgen -source=./consistency_checker.go -destination=./consistency_checker_mock.go -package=storage
+
+var (
+	checkedFamilies = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "consistency_checked_families",
+			Help: "Number of data families compared between the replicas of shard.",
+		},
+		[]string{"db"},
+	)
+	divergentFamilies = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "consistency_divergent_families",
+			Help: "Number of data families which are different between the replicas of shard in latest checking.",
+		},
+		[]string{"db"},
+	)
+	repairedFamilies = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "consistency_repaired_families",
+			Help: "Number of data families repaired from source replica.",
+		},
+		[]string{"db"},
+	)
+	repairFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "consistency_repair_failures",
+			Help: "Number of data families which cannot be repaired from source replica.",
+		},
+		[]string{"db"},
+	)
+)
+
+func init() {
+	monitoring.BrokerRegistry.MustRegister(checkedFamilies, divergentFamilies, repairedFamilies, repairFailures)
+}
+
+// ConsistencyChecker represents the checker which compares the digests of settled data families
+// between the replicas of each shard periodically, and repairs the lagging replica from the replica
+// which includes the data of all others if auto repair enabled.
+// NOTICE: the id of metric/series is allocated by each node, the digests are same only if the replicas
+// receive the data in same order, which is guaranteed by the replication from brokers.
+type ConsistencyChecker interface {
+	// GetDivergences returns the divergent data families found in latest checking
+	GetDivergences() []models.ReplicaDivergence
+	// Close stops the checker
+	Close()
+}
+
+// repairTask represents repairing the data families of shard in lagging replica from source replica
+type repairTask struct {
+	lagging     models.Node
+	source      models.Node
+	familyTimes []int64
+	divergences []*models.ReplicaDivergence
+}
+
+// consistencyChecker implements ConsistencyChecker interface
+type consistencyChecker struct {
+	ctx                context.Context
+	cancel             context.CancelFunc
+	cfg                config.Consistency
+	shardAssignService service.ShardAssignService
+	fct                rpc.ClientStreamFactory
+	activeNodes        func() []*models.ActiveNode
+
+	divergences []models.ReplicaDivergence
+	mutex       sync.RWMutex
+
+	logger *logger.Logger
+}
+
+// newConsistencyChecker creates the consistency checker of storage cluster,
+// starts the goroutine which checks periodically if check interval > 0.
+func newConsistencyChecker(cfg clusterCfg, activeNodes func() []*models.ActiveNode) ConsistencyChecker {
+	ctx, cancel := context.WithCancel(cfg.ctx)
+	c := &consistencyChecker{
+		ctx:                ctx,
+		cancel:             cancel,
+		cfg:                cfg.consistency,
+		shardAssignService: cfg.shardAssignService,
+		fct:                cfg.fct,
+		activeNodes:        activeNodes,
+		logger:             logger.GetLogger("coordinator", "ConsistencyChecker"),
+	}
+	if c.cfg.CheckInterval.Duration() > 0 && c.fct != nil {
+		go c.run()
+	}
+	return c
+}
+
+// GetDivergences returns the divergent data families found in latest checking
+func (c *consistencyChecker) GetDivergences() []models.ReplicaDivergence {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	return append([]models.ReplicaDivergence(nil), c.divergences...)
+}
+
+// Close stops the checker
+func (c *consistencyChecker) Close() {
+	c.cancel()
+}
+
+// run checks the consistency of all shards periodically
+func (c *consistencyChecker) run() {
+	ticker := time.NewTicker(c.cfg.CheckInterval.Duration())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			c.check()
+		}
+	}
+}
+
+// check compares the digests of settled data families in all shards which all replicas are active
+func (c *consistencyChecker) check() {
+	shardAssigns, err := c.shardAssignService.List()
+	if err != nil {
+		c.logger.Error("list shard assignments error when checking consistency", logger.Error(err))
+		return
+	}
+	sort.Slice(shardAssigns, func(i, j int) bool {
+		return shardAssigns[i].Name < shardAssigns[j].Name
+	})
+	activeNodes := make(map[string]struct{})
+	for _, activeNode := range c.activeNodes() {
+		activeNodes[activeNode.Node.Indicator()] = struct{}{}
+	}
+	now := timeutil.Now()
+	end := now - c.cfg.SettleDelay.Duration().Milliseconds()
+	timeRange := timeutil.TimeRange{Start: end - c.cfg.TimeRange.Duration().Milliseconds(), End: end}
+
+	var divergences []models.ReplicaDivergence
+	for _, shardAssign := range shardAssigns {
+		var shardIDs []int
+		for shardID := range shardAssign.Shards {
+			shardIDs = append(shardIDs, shardID)
+		}
+		sort.Ints(shardIDs)
+		var result []*models.ReplicaDivergence
+		for _, shardID := range shardIDs {
+			nodes, ok := replicaNodes(shardAssign, shardID, activeNodes)
+			if !ok {
+				continue
+			}
+			result = append(result, c.checkShard(shardAssign.Name, int32(shardID), nodes, timeRange, now)...)
+		}
+		if c.cfg.AutoRepair {
+			c.repair(shardAssign.Name, result)
+		}
+		divergentFamilies.WithLabelValues(shardAssign.Name).Set(float64(len(result)))
+		for _, divergence := range result {
+			divergences = append(divergences, *divergence)
+		}
+	}
+
+	c.mutex.Lock()
+	c.divergences = divergences
+	c.mutex.Unlock()
+}
+
+// replicaNodes returns the replica nodes of shard, returns false if the shard has only one replica
+// or any replica is not active(the data of inactive replica is lagging certainly).
+func replicaNodes(shardAssign *models.ShardAssignment, shardID int,
+	activeNodes map[string]struct{}) (map[string]models.Node, bool) {
+	replica := shardAssign.Shards[shardID]
+	if replica == nil || len(replica.Replicas) < 2 {
+		return nil, false
+	}
+	nodes := make(map[string]models.Node)
+	for _, id := range replica.Replicas {
+		node, ok := shardAssign.Nodes[id]
+		if !ok {
+			return nil, false
+		}
+		if _, ok := activeNodes[node.Indicator()]; !ok {
+			return nil, false
+		}
+		nodes[node.Indicator()] = *node
+	}
+	return nodes, true
+}
+
+// checkShard compares the digests of data families between the replicas of shard, returns the divergent families
+func (c *consistencyChecker) checkShard(databaseName string, shardID int32, nodes map[string]models.Node,
+	timeRange timeutil.TimeRange, checkTime int64) []*models.ReplicaDivergence {
+	// family time => node => digest
+	familyDigests := make(map[int64]map[string]*models.FamilyDigest)
+	var indicators []string
+	for indicator := range nodes {
+		indicators = append(indicators, indicator)
+	}
+	sort.Strings(indicators)
+	for _, indicator := range indicators {
+		digests, err := c.digest(databaseName, shardID, nodes[indicator], timeRange)
+		if err != nil {
+			c.logger.Warn("get digests of shard error, skip checking", logger.String("db", databaseName),
+				logger.Int32("shardID", shardID), logger.String("node", indicator), logger.Error(err))
+			return nil
+		}
+		for _, digest := range digests {
+			if _, ok := familyDigests[digest.FamilyTime]; !ok {
+				familyDigests[digest.FamilyTime] = make(map[string]*models.FamilyDigest)
+			}
+			familyDigests[digest.FamilyTime][indicator] = digest
+		}
+	}
+	var familyTimes []int64
+	for familyTime, digests := range familyDigests {
+		// family not exist in replica
+		for _, indicator := range indicators {
+			if _, ok := digests[indicator]; !ok {
+				digests[indicator] = nil
+			}
+		}
+		familyTimes = append(familyTimes, familyTime)
+	}
+	sort.Slice(familyTimes, func(i, j int) bool {
+		return familyTimes[i] < familyTimes[j]
+	})
+	checkedFamilies.WithLabelValues(databaseName).Add(float64(len(familyTimes)))
+
+	var result []*models.ReplicaDivergence
+	for _, familyTime := range familyTimes {
+		divergence := models.NewReplicaDivergence(databaseName, shardID, familyTime, familyDigests[familyTime])
+		if divergence == nil {
+			continue
+		}
+		divergence.CheckTime = checkTime
+		if divergence.Source == "" {
+			divergence.Error = "cannot find the replica which includes the data of all others"
+		}
+		c.logger.Warn("found divergent data family between replicas", logger.String("db", databaseName),
+			logger.Int32("shardID", shardID), logger.Int64("family", familyTime),
+			logger.String("source", divergence.Source), logger.Any("lagging", divergence.Lagging))
+		result = append(result, divergence)
+	}
+	return result
+}
+
+// digest returns the digests of data families in shard replica within the time range
+func (c *consistencyChecker) digest(databaseName string, shardID int32, node models.Node,
+	timeRange timeutil.TimeRange) ([]*models.FamilyDigest, error) {
+	cli, err := c.fct.CreateTransferServiceClient(node)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := cli.Digest(rpc.CreateOutgoingContextWithNode(c.ctx, c.fct.LogicNode()), &transfer.DigestRequest{
+		Database:  databaseName,
+		ShardID:   shardID,
+		StartTime: timeRange.Start,
+		EndTime:   timeRange.End,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var digests []*models.FamilyDigest
+	if err := encoding.JSONUnmarshal(resp.Payload, &digests); err != nil {
+		return nil, err
+	}
+	return digests, nil
+}
+
+// repair repairs the divergent families of database, the families of same shard which have same
+// lagging/source replicas are repaired in one request.
+// NOTICE: the data files of source replica are copied into lagging replica, the ids in data files are generated
+// in source replica, so lagging replica refuses the repair unless all ids are mapped to same metadata in both replicas.
+func (c *consistencyChecker) repair(databaseName string, divergences []*models.ReplicaDivergence) {
+	var (
+		keys  []string
+		tasks = make(map[string]*repairTask)
+	)
+	for _, divergence := range divergences {
+		if divergence.Source == "" {
+			continue
+		}
+		source, err := models.ParseNode(divergence.Source)
+		if err != nil {
+			divergence.Error = err.Error()
+			continue
+		}
+		for _, lagging := range divergence.Lagging {
+			key := fmt.Sprintf("%d/%s/%s", divergence.ShardID, lagging, divergence.Source)
+			task, ok := tasks[key]
+			if !ok {
+				laggingNode, err := models.ParseNode(lagging)
+				if err != nil {
+					divergence.Error = err.Error()
+					continue
+				}
+				task = &repairTask{lagging: *laggingNode, source: *source}
+				tasks[key] = task
+				keys = append(keys, key)
+			}
+			task.familyTimes = append(task.familyTimes, divergence.FamilyTime)
+			task.divergences = append(task.divergences, divergence)
+		}
+	}
+	for _, key := range keys {
+		task := tasks[key]
+		shardID := task.divergences[0].ShardID
+		err := c.repairShard(databaseName, shardID, task)
+		for _, divergence := range task.divergences {
+			if err != nil {
+				divergence.Error = err.Error()
+				continue
+			}
+			if divergence.Error == "" {
+				divergence.Repaired = true
+			}
+		}
+		if err != nil {
+			repairFailures.WithLabelValues(databaseName).Add(float64(len(task.familyTimes)))
+			c.logger.Error("repair data families of shard error", logger.String("db", databaseName),
+				logger.Int32("shardID", shardID), logger.Any("families", task.familyTimes),
+				logger.String("lagging", task.lagging.Indicator()), logger.String("source", task.source.Indicator()),
+				logger.Error(err))
+			continue
+		}
+		repairedFamilies.WithLabelValues(databaseName).Add(float64(len(task.familyTimes)))
+		c.logger.Info("repair data families of shard successfully", logger.String("db", databaseName),
+			logger.Int32("shardID", shardID), logger.Any("families", task.familyTimes),
+			logger.String("lagging", task.lagging.Indicator()), logger.String("source", task.source.Indicator()))
+	}
+}
+
+// repairShard requests the lagging replica to pull the data families from source replica
+func (c *consistencyChecker) repairShard(databaseName string, shardID int32, task *repairTask) error {
+	cli, err := c.fct.CreateTransferServiceClient(task.lagging)
+	if err != nil {
+		return err
+	}
+	_, err = cli.Repair(rpc.CreateOutgoingContextWithNode(c.ctx, c.fct.LogicNode()), &transfer.RepairRequest{
+		Database:    databaseName,
+		ShardID:     shardID,
+		SourceIP:    task.source.IP,
+		SourcePort:  uint32(task.source.Port),
+		FamilyTimes: task.familyTimes,
+	})
+	return err
+}
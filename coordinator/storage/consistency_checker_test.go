@@ -0,0 +1,205 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/rpc"
+	transfermock "github.com/lindb/lindb/rpc/pbmock/transfer"
+	"github.com/lindb/lindb/rpc/proto/transfer"
+	"github.com/lindb/lindb/service"
+)
+
+func TestConsistencyChecker_Run(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	fct := rpc.NewMockClientStreamFactory(ctrl)
+	// case 1: checking disabled
+	c := newConsistencyChecker(clusterCfg{
+		ctx:                context.TODO(),
+		shardAssignService: shardAssignService,
+		fct:                fct,
+	}, nil)
+	c.Close()
+	// case 2: check periodically
+	c = newConsistencyChecker(clusterCfg{
+		ctx:                context.TODO(),
+		shardAssignService: shardAssignService,
+		fct:                fct,
+		consistency:        config.Consistency{CheckInterval: ltoml.Duration(10 * time.Millisecond)},
+	}, nil)
+	shardAssignService.EXPECT().List().Return(nil, fmt.Errorf("err")).MinTimes(1)
+	time.Sleep(50 * time.Millisecond)
+	c.Close()
+	time.Sleep(20 * time.Millisecond)
+	assert.Empty(t, c.GetDivergences())
+}
+
+func TestConsistencyChecker_Check(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	fct := rpc.NewMockClientStreamFactory(ctrl)
+	fct.EXPECT().LogicNode().Return(nodeE).AnyTimes()
+	cliA := transfermock.NewMockTransferServiceClient(ctrl)
+	cliB := transfermock.NewMockTransferServiceClient(ctrl)
+	fct.EXPECT().CreateTransferServiceClient(nodeA).Return(cliA, nil).AnyTimes()
+	fct.EXPECT().CreateTransferServiceClient(nodeB).Return(cliB, nil).AnyTimes()
+	// node C is inactive, shard 1 isn't checked
+	activeNodes := []*models.ActiveNode{{Node: nodeA}, {Node: nodeB}}
+	c := newConsistencyChecker(clusterCfg{
+		ctx:                context.TODO(),
+		shardAssignService: shardAssignService,
+		fct:                fct,
+		consistency: config.Consistency{
+			TimeRange:   ltoml.Duration(time.Hour),
+			SettleDelay: ltoml.Duration(time.Hour),
+			AutoRepair:  true,
+		},
+	}, func() []*models.ActiveNode { return activeNodes }).(*consistencyChecker)
+	defer c.Close()
+
+	digestResp := func(digests ...*models.FamilyDigest) *transfer.DigestResponse {
+		return &transfer.DigestResponse{Payload: encoding.JSONMarshal(digests)}
+	}
+	mockDigest := func(cli *transfermock.MockTransferServiceClient, responses map[int32]*transfer.DigestResponse) {
+		cli.EXPECT().Digest(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, req *transfer.DigestRequest, opts ...grpc.CallOption) (*transfer.DigestResponse, error) {
+				assert.Equal(t, time.Hour.Milliseconds(), req.EndTime-req.StartTime)
+				resp, ok := responses[req.ShardID]
+				if !ok {
+					return nil, fmt.Errorf("err")
+				}
+				return resp, nil
+			}).AnyTimes()
+	}
+
+	// case 1: list shard assignments err
+	shardAssignService.EXPECT().List().Return(nil, fmt.Errorf("err"))
+	c.check()
+	assert.Empty(t, c.GetDivergences())
+	// case 2: compare digests and repair lagging replicas
+	// shard 0: family 10 is same, family 20 lagging in B, family 30 missing in A
+	// shard 2: digest of A err, skip checking
+	mockDigest(cliA, map[int32]*transfer.DigestResponse{
+		0: digestResp(
+			&models.FamilyDigest{FamilyTime: 10, NumOfSeries: 1, NumOfPoints: 10, DataHash: 10},
+			&models.FamilyDigest{FamilyTime: 20, NumOfSeries: 1, NumOfPoints: 5, DataHash: 5,
+				Slots: []models.SlotDigest{{Slot: 1, NumOfPoints: 3, DataHash: 3}, {Slot: 2, NumOfPoints: 2, DataHash: 2}}},
+		),
+	})
+	mockDigest(cliB, map[int32]*transfer.DigestResponse{
+		0: digestResp(
+			&models.FamilyDigest{FamilyTime: 10, NumOfSeries: 1, NumOfPoints: 10, DataHash: 10},
+			&models.FamilyDigest{FamilyTime: 20, NumOfSeries: 1, NumOfPoints: 3, DataHash: 3,
+				Slots: []models.SlotDigest{{Slot: 1, NumOfPoints: 3, DataHash: 3}}},
+			&models.FamilyDigest{FamilyTime: 30, NumOfSeries: 1, NumOfPoints: 3, DataHash: 3,
+				Slots: []models.SlotDigest{{Slot: 1, NumOfPoints: 3, DataHash: 3}}},
+		),
+		2: digestResp(),
+	})
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{newTestShardAssign()}, nil)
+	cliB.EXPECT().Repair(gomock.Any(), &transfer.RepairRequest{
+		Database: "db", ShardID: 0, SourceIP: nodeA.IP, SourcePort: uint32(nodeA.Port), FamilyTimes: []int64{20},
+	}).Return(&transfer.RepairResponse{}, nil)
+	cliA.EXPECT().Repair(gomock.Any(), &transfer.RepairRequest{
+		Database: "db", ShardID: 0, SourceIP: nodeB.IP, SourcePort: uint32(nodeB.Port), FamilyTimes: []int64{30},
+	}).Return(nil, fmt.Errorf("err"))
+	c.check()
+	divergences := c.GetDivergences()
+	assert.Len(t, divergences, 2)
+	assert.Equal(t, int64(20), divergences[0].FamilyTime)
+	assert.Equal(t, nodeA.Indicator(), divergences[0].Source)
+	assert.Equal(t, []string{nodeB.Indicator()}, divergences[0].Lagging)
+	assert.True(t, divergences[0].Repaired)
+	assert.Equal(t, int64(30), divergences[1].FamilyTime)
+	assert.Equal(t, nodeB.Indicator(), divergences[1].Source)
+	assert.Nil(t, divergences[1].Digests[nodeA.Indicator()])
+	assert.False(t, divergences[1].Repaired)
+	assert.Equal(t, "err", divergences[1].Error)
+}
+
+func TestConsistencyChecker_Check_Fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	fct := rpc.NewMockClientStreamFactory(ctrl)
+	fct.EXPECT().LogicNode().Return(nodeE).AnyTimes()
+	cliA := transfermock.NewMockTransferServiceClient(ctrl)
+	cliB := transfermock.NewMockTransferServiceClient(ctrl)
+	activeNodes := []*models.ActiveNode{{Node: nodeA}, {Node: nodeB}, {Node: nodeC}}
+	c := newConsistencyChecker(clusterCfg{
+		ctx:                context.TODO(),
+		shardAssignService: shardAssignService,
+		fct:                fct,
+		consistency:        config.Consistency{AutoRepair: true},
+	}, func() []*models.ActiveNode { return activeNodes }).(*consistencyChecker)
+	defer c.Close()
+
+	shardAssign := models.NewShardAssignment("db")
+	shardAssign.Nodes[0] = &models.Node{IP: nodeA.IP, Port: nodeA.Port}
+	shardAssign.Nodes[1] = &models.Node{IP: nodeB.IP, Port: nodeB.Port}
+	shardAssign.AddReplica(0, 0)
+	shardAssign.AddReplica(0, 1)
+	// shard 1 has one replica, shard 2 has the replica not in nodes
+	shardAssign.AddReplica(1, 0)
+	shardAssign.AddReplica(2, 0)
+	shardAssign.AddReplica(2, 3)
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{shardAssign}, nil).AnyTimes()
+
+	// case 1: create client err
+	fct.EXPECT().CreateTransferServiceClient(nodeA).Return(nil, fmt.Errorf("err"))
+	c.check()
+	assert.Empty(t, c.GetDivergences())
+	fct.EXPECT().CreateTransferServiceClient(nodeA).Return(cliA, nil).AnyTimes()
+	fct.EXPECT().CreateTransferServiceClient(nodeB).Return(cliB, nil).AnyTimes()
+	// case 2: unmarshal digests err
+	cliA.EXPECT().Digest(gomock.Any(), gomock.Any()).Return(&transfer.DigestResponse{Payload: []byte("err")}, nil)
+	c.check()
+	assert.Empty(t, c.GetDivergences())
+	// case 3: cannot find source replica, data of both replicas are different
+	cliA.EXPECT().Digest(gomock.Any(), gomock.Any()).Return(&transfer.DigestResponse{Payload: encoding.JSONMarshal(
+		[]*models.FamilyDigest{{FamilyTime: 10, NumOfPoints: 1, DataHash: 1,
+			Slots: []models.SlotDigest{{Slot: 1, NumOfPoints: 1, DataHash: 1}}}})}, nil)
+	cliB.EXPECT().Digest(gomock.Any(), gomock.Any()).Return(&transfer.DigestResponse{Payload: encoding.JSONMarshal(
+		[]*models.FamilyDigest{{FamilyTime: 10, NumOfPoints: 1, DataHash: 2,
+			Slots: []models.SlotDigest{{Slot: 1, NumOfPoints: 1, DataHash: 2}}}})}, nil)
+	c.check()
+	divergences := c.GetDivergences()
+	assert.Len(t, divergences, 1)
+	assert.Empty(t, divergences[0].Source)
+	assert.NotEmpty(t, divergences[0].Error)
+	assert.False(t, divergences[0].Repaired)
+	// case 4: create client err when repairing
+	cliA.EXPECT().Digest(gomock.Any(), gomock.Any()).Return(&transfer.DigestResponse{Payload: encoding.JSONMarshal(
+		[]*models.FamilyDigest{{FamilyTime: 10, NumOfPoints: 2, DataHash: 3,
+			Slots: []models.SlotDigest{{Slot: 1, NumOfPoints: 1, DataHash: 1}, {Slot: 2, NumOfPoints: 1, DataHash: 2}}}})}, nil)
+	cliB.EXPECT().Digest(gomock.Any(), gomock.Any()).Return(&transfer.DigestResponse{Payload: encoding.JSONMarshal(
+		[]*models.FamilyDigest{{FamilyTime: 10, NumOfPoints: 1, DataHash: 1,
+			Slots: []models.SlotDigest{{Slot: 1, NumOfPoints: 1, DataHash: 1}}}})}, nil)
+	c.fct = rpc.NewMockClientStreamFactory(ctrl)
+	mockFct := c.fct.(*rpc.MockClientStreamFactory)
+	mockFct.EXPECT().LogicNode().Return(nodeE).AnyTimes()
+	mockFct.EXPECT().CreateTransferServiceClient(nodeA).Return(cliA, nil)
+	mockFct.EXPECT().CreateTransferServiceClient(nodeB).Return(cliB, nil)
+	mockFct.EXPECT().CreateTransferServiceClient(nodeB).Return(nil, fmt.Errorf("err"))
+	c.check()
+	divergences = c.GetDivergences()
+	assert.Len(t, divergences, 1)
+	assert.Equal(t, nodeA.Indicator(), divergences[0].Source)
+	assert.Equal(t, "err", divergences[0].Error)
+}
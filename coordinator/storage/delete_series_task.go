@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+//go:generate mockgen -source=./delete_series_task.go -destination=./delete_series_task_mock.go -package=storage
+
+// SeriesDeleter represents the series deleter, which tombstones the series of metric matched delete statement.
+// NOTICE: implementation needs tag filtering/series searching of query module.
+type SeriesDeleter interface {
+	// DeleteSeries deletes the series of metric in spec shards based on delete statement
+	DeleteSeries(databaseName string, shardIDs []int32, deleteStmt *stmt.Delete) error
+}
+
+// seriesDeleteProcessor represents delete series, tombstones the series of metric in storage node
+type seriesDeleteProcessor struct {
+	seriesDeleter SeriesDeleter
+}
+
+// newSeriesDeleteProcessor returns delete series processor instance
+func newSeriesDeleteProcessor(seriesDeleter SeriesDeleter) task.Processor {
+	return &seriesDeleteProcessor{
+		seriesDeleter: seriesDeleter,
+	}
+}
+
+func (p *seriesDeleteProcessor) Kind() task.Kind             { return constants.DeleteSeries }
+func (p *seriesDeleteProcessor) RetryCount() int             { return 3 }
+func (p *seriesDeleteProcessor) RetryBackOff() time.Duration { return time.Second }
+func (p *seriesDeleteProcessor) Concurrency() int            { return 1 }
+
+// Process deletes the series of metric which matches the delete statement
+func (p *seriesDeleteProcessor) Process(ctx context.Context, task task.Task) error {
+	param := models.SeriesDeleteTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	if err := p.seriesDeleter.DeleteSeries(param.DatabaseName, param.ShardIDs, param.Statement); err != nil {
+		return err
+	}
+	logger.GetLogger("coordinator", "StorageDeleteSeriesProcessor").
+		Info("process delete series task",
+			logger.String("params", string(task.Params)))
+	return nil
+}
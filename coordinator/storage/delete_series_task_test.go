@@ -0,0 +1,43 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestSeriesDeleteProcessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	seriesDeleter := NewMockSeriesDeleter(ctrl)
+	processor := newSeriesDeleteProcessor(seriesDeleter)
+	assert.Equal(t, 1, processor.Concurrency())
+	assert.Equal(t, time.Second, processor.RetryBackOff())
+	assert.Equal(t, 3, processor.RetryCount())
+	assert.Equal(t, constants.DeleteSeries, processor.Kind())
+
+	err := processor.Process(context.TODO(), task.Task{Params: []byte{1, 1, 1}})
+	assert.NotNil(t, err)
+	param := models.SeriesDeleteTask{
+		DatabaseName: "test",
+		ShardIDs:     []int32{1, 2},
+		Statement:    &stmt.Delete{Namespace: "ns", MetricName: "cpu"},
+	}
+	seriesDeleter.EXPECT().DeleteSeries("test", []int32{1, 2}, param.Statement).Return(fmt.Errorf("err"))
+	err = processor.Process(context.TODO(), task.Task{Params: encoding.JSONMarshal(&param)})
+	assert.Error(t, err)
+	seriesDeleter.EXPECT().DeleteSeries("test", []int32{1, 2}, param.Statement).Return(nil)
+	err = processor.Process(context.TODO(), task.Task{Params: encoding.JSONMarshal(&param)})
+	assert.NoError(t, err)
+}
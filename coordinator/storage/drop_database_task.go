@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/service"
+)
+
+// databaseDropProcessor represents drop database, removes all data of database in storage node
+type databaseDropProcessor struct {
+	storageService service.StorageService
+}
+
+// newDatabaseDropProcessor returns drop database processor instance
+func newDatabaseDropProcessor(storageService service.StorageService) task.Processor {
+	return &databaseDropProcessor{
+		storageService: storageService,
+	}
+}
+
+func (p *databaseDropProcessor) Kind() task.Kind             { return constants.DropDatabase }
+func (p *databaseDropProcessor) RetryCount() int             { return 3 }
+func (p *databaseDropProcessor) RetryBackOff() time.Duration { return time.Second }
+func (p *databaseDropProcessor) Concurrency() int            { return 1 }
+
+// Process drops the database, removes all shards/segments/metadata of it
+func (p *databaseDropProcessor) Process(ctx context.Context, task task.Task) error {
+	param := models.DatabaseDropTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	if err := p.storageService.DropDatabase(param.DatabaseName); err != nil {
+		return err
+	}
+	logger.GetLogger("coordinator", "StorageDropDBProcessor").
+		Info("process drop database task",
+			logger.String("params", string(task.Params)))
+	return nil
+}
@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/service"
+)
+
+func TestDatabaseDropProcessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageService := service.NewMockStorageService(ctrl)
+	processor := newDatabaseDropProcessor(storageService)
+	assert.Equal(t, 1, processor.Concurrency())
+	assert.Equal(t, time.Second, processor.RetryBackOff())
+	assert.Equal(t, 3, processor.RetryCount())
+	assert.Equal(t, constants.DropDatabase, processor.Kind())
+
+	err := processor.Process(context.TODO(), task.Task{Params: []byte{1, 1, 1}})
+	assert.NotNil(t, err)
+	param := models.DatabaseDropTask{DatabaseName: "test"}
+	storageService.EXPECT().DropDatabase("test").Return(fmt.Errorf("err"))
+	err = processor.Process(context.TODO(), task.Task{Params: encoding.JSONMarshal(&param)})
+	assert.Error(t, err)
+	storageService.EXPECT().DropDatabase("test").Return(nil)
+	err = processor.Process(context.TODO(), task.Task{Params: encoding.JSONMarshal(&param)})
+	assert.NoError(t, err)
+}
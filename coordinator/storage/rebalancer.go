@@ -0,0 +1,550 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/service"
+)
+
+//go:generate mockgen -source=./rebalancer.go -destination=./rebalancer_mock.go -package=storage
+
+// maxPendingNodes is the max num. of nodes waiting for moving replicas
+const maxPendingNodes = 16
+
+// Rebalancer represents the controller which moves the replicas of the node removed from storage cluster.
+// The replicas are moved when node is offline longer than grace period or node is decommissioned,
+// for each database which has replicas on the removed node:
+// 1) adds a new node as learner of the shards, brokers start to replicate data to the learner
+// 2) submits transfer shard task, the learner pulls the files of shards from one alive replica
+// 3) waits the learner catching up data from the replication queue of brokers
+// 4) promotes the learner as replica instead of the removed node
+// the shards without common alive replica are moved to different nodes one move after another.
+// the learner is removed and the imported database is dropped if moving fail,
+// the moving is planned again when the node is removed next time.
+type Rebalancer interface {
+	// OnNodeOffline starts the timer of grace period, moves the replicas of node after grace period
+	OnNodeOffline(node models.Node)
+	// OnNodeOnline cancels the timer of grace period when node is online again
+	OnNodeOnline(node models.Node)
+	// Decommission moves the replicas of node to other active nodes in background
+	Decommission(node models.Node) error
+	// Close stops the rebalancer, cancels the moving in progress
+	Close()
+}
+
+// replicaMove represents moving the replicas of shards from the removed node to the target node
+type replicaMove struct {
+	database string
+	shardIDs []int
+	fromID   int         // id of removed node in shard assignment
+	targetID int         // id of target node in shard assignment
+	source   models.Node // source node which the shards are pulled from
+	target   models.Node
+}
+
+// rebalancer implements Rebalancer interface
+type rebalancer struct {
+	ctx                context.Context
+	cancel             context.CancelFunc
+	cfg                config.Rebalance
+	shardAssignService service.ShardAssignService
+	replicaStatus      replica.StatusStateMachine
+	taskController     task.Controller
+	activeNodes        func() []*models.ActiveNode
+
+	timers map[string]*time.Timer
+	nodes  chan models.Node
+	mutex  sync.Mutex
+
+	logger *logger.Logger
+}
+
+// newRebalancer creates the rebalancer of storage cluster, starts the goroutine which moves replicas
+func newRebalancer(cfg clusterCfg, taskController task.Controller, activeNodes func() []*models.ActiveNode) Rebalancer {
+	ctx, cancel := context.WithCancel(cfg.ctx)
+	r := &rebalancer{
+		ctx:                ctx,
+		cancel:             cancel,
+		cfg:                cfg.rebalance,
+		shardAssignService: cfg.shardAssignService,
+		replicaStatus:      cfg.replicaStatus,
+		taskController:     taskController,
+		activeNodes:        activeNodes,
+		timers:             make(map[string]*time.Timer),
+		nodes:              make(chan models.Node, maxPendingNodes),
+		logger:             logger.GetLogger("coordinator", "Rebalancer"),
+	}
+	go r.run()
+	return r
+}
+
+// OnNodeOffline starts the timer of grace period, moves the replicas of node after grace period
+func (r *rebalancer) OnNodeOffline(node models.Node) {
+	gracePeriod := r.cfg.OfflineGracePeriod.Duration()
+	if gracePeriod <= 0 {
+		return
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	key := node.Indicator()
+	if _, ok := r.timers[key]; ok {
+		return
+	}
+	r.timers[key] = time.AfterFunc(gracePeriod, func() {
+		r.mutex.Lock()
+		delete(r.timers, key)
+		r.mutex.Unlock()
+
+		if err := r.submit(node); err != nil {
+			r.logger.Error("submit offline node for moving replicas error",
+				logger.String("node", key), logger.Error(err))
+		}
+	})
+	r.logger.Info("storage node offline, replicas will be moved after grace period",
+		logger.String("node", key), logger.String("gracePeriod", gracePeriod.String()))
+}
+
+// OnNodeOnline cancels the timer of grace period when node is online again
+func (r *rebalancer) OnNodeOnline(node models.Node) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.stopTimer(node.Indicator())
+}
+
+// Decommission moves the replicas of node to other active nodes in background
+func (r *rebalancer) Decommission(node models.Node) error {
+	r.mutex.Lock()
+	r.stopTimer(node.Indicator())
+	r.mutex.Unlock()
+
+	return r.submit(node)
+}
+
+// Close stops the rebalancer, cancels the moving in progress
+func (r *rebalancer) Close() {
+	r.cancel()
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	for key := range r.timers {
+		r.stopTimer(key)
+	}
+}
+
+// stopTimer stops the timer of grace period if exist, must hold the lock
+func (r *rebalancer) stopTimer(key string) {
+	timer, ok := r.timers[key]
+	if ok {
+		timer.Stop()
+		delete(r.timers, key)
+	}
+}
+
+// submit puts the node into waiting queue for moving replicas
+func (r *rebalancer) submit(node models.Node) error {
+	select {
+	case <-r.ctx.Done():
+		return fmt.Errorf("rebalancer is closed")
+	default:
+	}
+	select {
+	case r.nodes <- node:
+		return nil
+	default:
+		return fmt.Errorf("too many nodes waiting for moving replicas")
+	}
+}
+
+// run moves the replicas of submitted nodes one by one
+func (r *rebalancer) run() {
+	for {
+		select {
+		case <-r.ctx.Done():
+			return
+		case node := <-r.nodes:
+			r.moveReplicas(node)
+		}
+	}
+}
+
+// moveReplicas moves the replicas of all databases which have replicas on the removed node
+func (r *rebalancer) moveReplicas(node models.Node) {
+	shardAssigns, err := r.shardAssignService.List()
+	if err != nil {
+		r.logger.Error("list shard assignments error when moving replicas",
+			logger.String("node", node.Indicator()), logger.Error(err))
+		return
+	}
+	sort.Slice(shardAssigns, func(i, j int) bool {
+		return shardAssigns[i].Name < shardAssigns[j].Name
+	})
+	// load: node => num. of shards
+	load := make(map[string]int)
+	for _, shardAssign := range shardAssigns {
+		for _, replica := range shardAssign.Shards {
+			for _, ids := range [][]int{replica.Replicas, replica.Learners} {
+				for _, id := range ids {
+					if n, ok := shardAssign.Nodes[id]; ok {
+						load[n.Indicator()]++
+					}
+				}
+			}
+		}
+	}
+	var activeNodes []models.Node
+	for _, activeNode := range r.activeNodes() {
+		activeNodes = append(activeNodes, activeNode.Node)
+	}
+	for _, shardAssign := range shardAssigns {
+		shardAssign = r.removeStaleLearners(shardAssign)
+		// moves the replicas of database one move after another, until all replicas are moved or moving fail
+		for shardAssign != nil {
+			shardAssign = r.moveDatabaseReplicas(shardAssign, node, activeNodes, load)
+		}
+	}
+}
+
+// moveDatabaseReplicas plans and executes one move of the replicas of database on removed node,
+// returns the latest shard assignment if the move is successful, else returns nil.
+func (r *rebalancer) moveDatabaseReplicas(shardAssign *models.ShardAssignment, node models.Node,
+	activeNodes []models.Node, load map[string]int,
+) *models.ShardAssignment {
+	newShardAssign, move, err := planReplicaMove(shardAssign, node, activeNodes, load)
+	if err != nil {
+		r.logger.Error("plan moving replicas error", logger.String("db", shardAssign.Name),
+			logger.String("node", node.Indicator()), logger.Error(err))
+		return nil
+	}
+	if move == nil {
+		return nil
+	}
+	if err := r.move(newShardAssign, move); err != nil {
+		r.logger.Error("move replicas error", logger.String("db", move.database),
+			logger.Any("shardIDs", move.shardIDs), logger.String("from", node.Indicator()),
+			logger.String("target", move.target.Indicator()), logger.Error(err))
+		return nil
+	}
+	load[move.target.Indicator()] += len(move.shardIDs)
+	r.logger.Info("move replicas successfully", logger.String("db", move.database),
+		logger.Any("shardIDs", move.shardIDs), logger.String("from", node.Indicator()),
+		logger.String("target", move.target.Indicator()))
+	current, err := r.shardAssignService.Get(move.database)
+	if err != nil {
+		r.logger.Error("get shard assignment error after moving replicas", logger.String("db", move.database),
+			logger.Error(err))
+		return nil
+	}
+	return current
+}
+
+// removeStaleLearners removes the learners left by the moving interrupted(like master fail over),
+// the moving is executed one by one, so all learners are stale before moving replicas.
+func (r *rebalancer) removeStaleLearners(shardAssign *models.ShardAssignment) *models.ShardAssignment {
+	result := shardAssign.Clone()
+	changed := false
+	for shardID, replica := range result.Shards {
+		for _, learnerID := range replica.Learners {
+			changed = true
+			result.RemoveLearner(shardID, learnerID)
+			if len(result.ShardsOfNode(learnerID)) == 0 {
+				delete(result.Nodes, learnerID)
+			}
+		}
+	}
+	if !changed {
+		return shardAssign
+	}
+	if err := r.shardAssignService.Save(result.Name, result); err != nil {
+		r.logger.Error("remove stale learners error", logger.String("db", result.Name), logger.Error(err))
+		return shardAssign
+	}
+	r.logger.Info("remove stale learners successfully", logger.String("db", result.Name))
+	return result
+}
+
+// move moves the replicas of shards to the target node,
+// the shard assignment is switched after the target node catching up data,
+// removes the learner then drops the database imported partially in target node if fail.
+func (r *rebalancer) move(shardAssign *models.ShardAssignment, move *replicaMove) error {
+	// 1. adds target node as learner, brokers create the replicators for learner
+	if err := r.shardAssignService.Save(move.database, shardAssign); err != nil {
+		return err
+	}
+	if err := r.promote(move); err != nil {
+		// brokers remove the replicators of learner after learner removed
+		if rollbackErr := r.removeLearner(move); rollbackErr != nil {
+			r.logger.Error("remove learner error when moving replicas fail", logger.String("db", move.database),
+				logger.String("target", move.target.Indicator()), logger.Error(rollbackErr))
+			return err
+		}
+		// target node doesn't hold any other replica of database, so the whole database is dropped
+		if dropErr := r.dropTargetDatabase(move); dropErr != nil {
+			r.logger.Error("drop database of target node error when moving replicas fail",
+				logger.String("db", move.database), logger.String("target", move.target.Indicator()),
+				logger.Error(dropErr))
+		}
+		return err
+	}
+	return nil
+}
+
+// promote waits the learner pulling shards and catching up data, then promotes the learner as replica
+func (r *rebalancer) promote(move *replicaMove) error {
+	if err := r.waitFor("create learner", func() (bool, error) {
+		return r.isLearnerReady(move, 0, false), nil
+	}); err != nil {
+		return err
+	}
+	// 2. target node pulls the files of shards from source node
+	var shardIDs []int32
+	for _, shardID := range move.shardIDs {
+		shardIDs = append(shardIDs, int32(shardID))
+	}
+	taskName := fmt.Sprintf("%s-%s-%s", move.database, move.target.Indicator(), move.source.Indicator())
+	if err := r.taskController.Submit(constants.TransferShard, taskName, []task.ControllerTaskParam{{
+		NodeID: move.target.Indicator(),
+		Params: &models.ShardTransferTask{DatabaseName: move.database, ShardIDs: shardIDs, Source: move.source},
+	}}); err != nil {
+		return err
+	}
+	if err := r.waitFor("transfer shards", func() (bool, error) {
+		st, err := r.taskController.GetState(constants.TransferShard, taskName)
+		if err != nil {
+			return false, err
+		}
+		if st == task.StateDoneErr {
+			return false, fmt.Errorf("transfer shards task[%s] fail", taskName)
+		}
+		return st == task.StateDoneOK, nil
+	}); err != nil {
+		return err
+	}
+	// 3. target node catches up data from the replication queue of brokers
+	if err := r.waitFor("catch up data", func() (bool, error) {
+		return r.isLearnerReady(move, r.cfg.MaxPending, true), nil
+	}); err != nil {
+		return err
+	}
+	// 4. promotes the learner, brokers remove the replicators of removed node
+	current, err := r.shardAssignService.Get(move.database)
+	if err != nil {
+		return err
+	}
+	for _, shardID := range move.shardIDs {
+		current.PromoteLearner(shardID, move.targetID, move.fromID)
+	}
+	if len(current.ShardsOfNode(move.fromID)) == 0 {
+		delete(current.Nodes, move.fromID)
+	}
+	return r.shardAssignService.Save(move.database, current)
+}
+
+// removeLearner removes the target node from learners of the moving shards
+func (r *rebalancer) removeLearner(move *replicaMove) error {
+	current, err := r.shardAssignService.Get(move.database)
+	if err != nil {
+		return err
+	}
+	for _, shardID := range move.shardIDs {
+		current.RemoveLearner(shardID, move.targetID)
+	}
+	if len(current.ShardsOfNode(move.targetID)) == 0 {
+		delete(current.Nodes, move.targetID)
+	}
+	return r.shardAssignService.Save(move.database, current)
+}
+
+// dropTargetDatabase submits the task which drops the database imported in target node
+func (r *rebalancer) dropTargetDatabase(move *replicaMove) error {
+	taskName := fmt.Sprintf("%s-%s", move.database, move.target.Indicator())
+	return r.taskController.Submit(constants.DropDatabase, taskName, []task.ControllerTaskParam{{
+		NodeID: move.target.Indicator(),
+		Params: &models.DatabaseDropTask{DatabaseName: move.database},
+	}})
+}
+
+// waitFor checks the condition periodically until condition is satisfied, returns err if timeout
+func (r *rebalancer) waitFor(phase string, condition func() (bool, error)) error {
+	ticker := time.NewTicker(r.cfg.CheckInterval.Duration())
+	defer ticker.Stop()
+	timeout := time.NewTimer(r.cfg.PhaseTimeout.Duration())
+	defer timeout.Stop()
+	for {
+		ok, err := condition()
+		if err != nil {
+			return err
+		}
+		if ok {
+			return nil
+		}
+		select {
+		case <-r.ctx.Done():
+			return fmt.Errorf("rebalancer is closed when waiting for %s", phase)
+		case <-timeout.C:
+			return fmt.Errorf("timeout when waiting for %s", phase)
+		case <-ticker.C:
+		}
+	}
+}
+
+// isLearnerReady checks if all brokers which have the replication channel of shard replicate data to target node,
+// also checks if the num. of pending msg <= max pending when checkPending is true.
+func (r *rebalancer) isLearnerReady(move *replicaMove, maxPending int64, checkPending bool) bool {
+	shards := make(map[int32]struct{})
+	for _, shardID := range move.shardIDs {
+		shards[int32(shardID)] = struct{}{}
+	}
+	for _, brokerReplicas := range r.replicaStatus.GetAllReplicas() {
+		// shard id => if target node is replicated
+		replicated := make(map[int32]bool)
+		for _, state := range brokerReplicas.Replicas {
+			if _, ok := shards[state.ShardID]; !ok || state.Database != move.database {
+				continue
+			}
+			if state.Target != move.target {
+				if _, ok := replicated[state.ShardID]; !ok {
+					replicated[state.ShardID] = false
+				}
+				continue
+			}
+			if checkPending && state.Pending > maxPending {
+				return false
+			}
+			replicated[state.ShardID] = true
+		}
+		for _, ok := range replicated {
+			if !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// planReplicaMove plans moving the replicas of removed node to other active node, returns the shard assignment
+// which adds target node as learner, returns nil if the database hasn't replica on removed node.
+// NOTICE: the id of metric/tag metadata is allocated by each storage node, so the files of database cannot be
+// merged, the target node cannot hold any replica of database, picks the node which has the least shards,
+// and all shards of a move are pulled from one source node, the other shards are left to next move.
+func planReplicaMove(shardAssign *models.ShardAssignment, from models.Node,
+	activeNodes []models.Node, load map[string]int,
+) (*models.ShardAssignment, *replicaMove, error) {
+	var nodeIDs []int
+	for id := range shardAssign.Nodes {
+		nodeIDs = append(nodeIDs, id)
+	}
+	sort.Ints(nodeIDs)
+	fromID := -1
+	held := make(map[models.Node]struct{})
+	for _, id := range nodeIDs {
+		node := *shardAssign.Nodes[id]
+		held[node] = struct{}{}
+		if node == from {
+			fromID = id
+		}
+	}
+	if fromID < 0 {
+		return nil, nil, nil
+	}
+	var shardIDs []int
+	for shardID, replica := range shardAssign.Shards {
+		if containsNodeID(replica.Replicas, fromID) {
+			shardIDs = append(shardIDs, shardID)
+		}
+	}
+	if len(shardIDs) == 0 {
+		return nil, nil, nil
+	}
+	sort.Ints(shardIDs)
+
+	active := make(map[models.Node]struct{})
+	var candidates []models.Node
+	for _, node := range activeNodes {
+		active[node] = struct{}{}
+		if _, ok := held[node]; !ok {
+			candidates = append(candidates, node)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil, fmt.Errorf("no available node for moving replicas of database[%s]", shardAssign.Name)
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		li, lj := load[candidates[i].Indicator()], load[candidates[j].Indicator()]
+		if li != lj {
+			return li < lj
+		}
+		return candidates[i].Indicator() < candidates[j].Indicator()
+	})
+	target := candidates[0]
+
+	// pulls the shards from removed node if it is alive(decommission),
+	// else from the alive replica which holds the most of shards, only the shards held by source are moved.
+	sourceID := -1
+	if _, ok := active[from]; ok {
+		sourceID = fromID
+	} else {
+		most := 0
+		for _, id := range nodeIDs {
+			if _, ok := active[*shardAssign.Nodes[id]]; !ok || id == fromID {
+				continue
+			}
+			count := 0
+			for _, shardID := range shardIDs {
+				if containsNodeID(shardAssign.Shards[shardID].Replicas, id) {
+					count++
+				}
+			}
+			if count > most {
+				most = count
+				sourceID = id
+			}
+		}
+		if sourceID < 0 {
+			return nil, nil, fmt.Errorf("no alive replica holds shards%v of database[%s]", shardIDs, shardAssign.Name)
+		}
+		var sourceShardIDs []int
+		for _, shardID := range shardIDs {
+			if containsNodeID(shardAssign.Shards[shardID].Replicas, sourceID) {
+				sourceShardIDs = append(sourceShardIDs, shardID)
+			}
+		}
+		shardIDs = sourceShardIDs
+	}
+
+	targetID := nodeIDs[len(nodeIDs)-1] + 1
+	result := shardAssign.Clone()
+	result.Nodes[targetID] = &target
+	for _, shardID := range shardIDs {
+		result.AddLearner(shardID, targetID)
+	}
+	return result, &replicaMove{
+		database: shardAssign.Name,
+		shardIDs: shardIDs,
+		fromID:   fromID,
+		targetID: targetID,
+		source:   *shardAssign.Nodes[sourceID],
+		target:   target,
+	}, nil
+}
+
+// containsNodeID checks if the node id list contains the spec id
+func containsNodeID(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
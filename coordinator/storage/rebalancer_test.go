@@ -0,0 +1,418 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/service"
+)
+
+var (
+	nodeA = models.Node{IP: "1.1.1.1", Port: 2080}
+	nodeB = models.Node{IP: "1.1.1.2", Port: 2080}
+	nodeC = models.Node{IP: "1.1.1.3", Port: 2080}
+	nodeD = models.Node{IP: "1.1.1.4", Port: 2080}
+	nodeE = models.Node{IP: "1.1.1.5", Port: 2080}
+)
+
+// newTestShardAssign returns the shard assignment: shard 0 => [A,B], shard 1 => [B,C], shard 2 => [B,A]
+func newTestShardAssign() *models.ShardAssignment {
+	shardAssign := models.NewShardAssignment("db")
+	shardAssign.Nodes[0] = &models.Node{IP: nodeA.IP, Port: nodeA.Port}
+	shardAssign.Nodes[1] = &models.Node{IP: nodeB.IP, Port: nodeB.Port}
+	shardAssign.Nodes[2] = &models.Node{IP: nodeC.IP, Port: nodeC.Port}
+	shardAssign.AddReplica(0, 0)
+	shardAssign.AddReplica(0, 1)
+	shardAssign.AddReplica(1, 1)
+	shardAssign.AddReplica(1, 2)
+	shardAssign.AddReplica(2, 1)
+	shardAssign.AddReplica(2, 0)
+	return shardAssign
+}
+
+func TestPlanReplicaMove(t *testing.T) {
+	shardAssign := newTestShardAssign()
+	// case 1: node not in shard assignment
+	result, move, err := planReplicaMove(shardAssign, nodeD, []models.Node{nodeB, nodeC, nodeD}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Nil(t, move)
+	// case 2: node hasn't replica
+	emptyAssign := newTestShardAssign()
+	emptyAssign.Nodes[3] = &models.Node{IP: nodeD.IP, Port: nodeD.Port}
+	result, move, err = planReplicaMove(emptyAssign, nodeD, []models.Node{nodeA, nodeB, nodeC, nodeE}, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, result)
+	assert.Nil(t, move)
+	// case 3: no available node
+	_, _, err = planReplicaMove(shardAssign, nodeA, []models.Node{nodeB, nodeC}, nil)
+	assert.Error(t, err)
+	// case 4: removed node is offline, no alive replica holds the shard
+	_, _, err = planReplicaMove(shardAssign, nodeA, []models.Node{nodeC, nodeD}, nil)
+	assert.Error(t, err)
+	// case 5: removed node is offline, picks the node which has the least shards
+	result, move, err = planReplicaMove(shardAssign, nodeA, []models.Node{nodeB, nodeC, nodeD, nodeE},
+		map[string]int{nodeD.Indicator(): 5, nodeE.Indicator(): 1})
+	assert.NoError(t, err)
+	assert.Equal(t, &replicaMove{
+		database: "db",
+		shardIDs: []int{0, 2},
+		fromID:   0,
+		targetID: 3,
+		source:   nodeB,
+		target:   nodeE,
+	}, move)
+	assert.Equal(t, nodeE, *result.Nodes[3])
+	assert.Equal(t, []int{3}, result.Shards[0].Learners)
+	assert.Empty(t, result.Shards[1].Learners)
+	assert.Equal(t, []int{3}, result.Shards[2].Learners)
+	// original shard assignment isn't modified
+	assert.Len(t, shardAssign.Nodes, 3)
+	assert.Empty(t, shardAssign.Shards[0].Learners)
+	// case 6: removed node is alive(decommission)
+	_, move, err = planReplicaMove(shardAssign, nodeA, []models.Node{nodeA, nodeB, nodeC, nodeD, nodeE}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 2}, move.shardIDs)
+	assert.Equal(t, nodeA, move.source)
+	assert.Equal(t, nodeD, move.target)
+	// case 7: moves the shards on the removed node only
+	_, move, err = planReplicaMove(shardAssign, nodeC, []models.Node{nodeA, nodeB, nodeD}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, move.shardIDs)
+	assert.Equal(t, nodeB, move.source)
+	// case 8: removed node is offline, no alive replica holds all shards, moves the shards held by one source
+	multiSource := newTestShardAssign()
+	multiSource.Shards[2].Replicas = []int{2, 0}
+	result, move, err = planReplicaMove(multiSource, nodeA, []models.Node{nodeB, nodeC, nodeD, nodeE}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0}, move.shardIDs)
+	assert.Equal(t, nodeB, move.source)
+	assert.Equal(t, nodeD, move.target)
+	assert.Empty(t, result.Shards[2].Learners)
+	// case 9: the other shards are moved to other node in next move
+	result.PromoteLearner(0, move.targetID, move.fromID)
+	_, move, err = planReplicaMove(result, nodeA, []models.Node{nodeB, nodeC, nodeD, nodeE}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2}, move.shardIDs)
+	assert.Equal(t, nodeC, move.source)
+	assert.Equal(t, nodeE, move.target)
+}
+
+func TestRebalancer_Timer(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	r := newRebalancer(clusterCfg{
+		ctx:                context.TODO(),
+		shardAssignService: shardAssignService,
+		rebalance:          config.Rebalance{OfflineGracePeriod: ltoml.Duration(50 * time.Millisecond)},
+	}, nil, func() []*models.ActiveNode { return nil })
+
+	moved := make(chan struct{}, 1)
+	shardAssignService.EXPECT().List().DoAndReturn(func() ([]*models.ShardAssignment, error) {
+		moved <- struct{}{}
+		return nil, fmt.Errorf("err")
+	}).AnyTimes()
+
+	// case 1: node online within grace period
+	r.OnNodeOffline(nodeA)
+	r.OnNodeOffline(nodeA)
+	r.OnNodeOnline(nodeA)
+	time.Sleep(100 * time.Millisecond)
+	assert.Len(t, moved, 0)
+	// case 2: node offline longer than grace period
+	r.OnNodeOffline(nodeA)
+	select {
+	case <-moved:
+	case <-time.After(time.Second):
+		t.Fatal("replicas of offline node not moved")
+	}
+	// case 3: decommission node
+	r.OnNodeOffline(nodeB)
+	assert.NoError(t, r.Decommission(nodeB))
+	select {
+	case <-moved:
+	case <-time.After(time.Second):
+		t.Fatal("replicas of decommissioned node not moved")
+	}
+	// case 4: closed
+	r.OnNodeOffline(nodeC)
+	r.Close()
+	assert.Error(t, r.Decommission(nodeC))
+
+	// case 5: grace period disabled
+	r = newRebalancer(clusterCfg{ctx: context.TODO()}, nil, nil)
+	r.OnNodeOffline(nodeA)
+	assert.Empty(t, r.(*rebalancer).timers)
+	r.Close()
+	// case 6: too many nodes waiting
+	r = &rebalancer{ctx: context.TODO(), nodes: make(chan models.Node)}
+	assert.Error(t, r.Decommission(nodeA))
+}
+
+func TestRebalancer_MoveReplicas(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	replicaStatus := replica.NewMockStatusStateMachine(ctrl)
+	taskController := task.NewMockController(ctrl)
+	activeNodes := []*models.ActiveNode{{Node: nodeB}, {Node: nodeC}, {Node: nodeD}}
+	r := newRebalancer(clusterCfg{
+		ctx:                context.TODO(),
+		shardAssignService: shardAssignService,
+		replicaStatus:      replicaStatus,
+		rebalance: config.Rebalance{
+			CheckInterval: ltoml.Duration(time.Millisecond),
+			PhaseTimeout:  ltoml.Duration(100 * time.Millisecond),
+			MaxPending:    10,
+		},
+	}, taskController, func() []*models.ActiveNode { return activeNodes }).(*rebalancer)
+	defer r.Close()
+
+	taskName := "db-" + nodeD.Indicator() + "-" + nodeB.Indicator()
+	replicas := func(learnerPending int64, learnerShards ...int32) map[string]models.BrokerReplicaState {
+		states := []models.ReplicaState{
+			{Database: "db", ShardID: 0, Target: nodeB},
+			{Database: "db", ShardID: 2, Target: nodeB},
+			{Database: "other", ShardID: 0, Target: nodeB},
+		}
+		for _, shardID := range learnerShards {
+			states = append(states, models.ReplicaState{
+				Database: "db", ShardID: shardID, Target: nodeD, Pending: learnerPending, Learner: true,
+			})
+		}
+		return map[string]models.BrokerReplicaState{"broker": {Replicas: states}}
+	}
+
+	// case 1: list shard assignments err
+	shardAssignService.EXPECT().List().Return(nil, fmt.Errorf("err"))
+	r.moveReplicas(nodeA)
+	// case 2: plan err
+	noSource := newTestShardAssign()
+	noSource.Shards[0].Replicas = []int{0}
+	noSource.Shards[2].Replicas = []int{0}
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{noSource}, nil)
+	r.moveReplicas(nodeA)
+	// case 3: removes stale learners
+	staleLearner := newTestShardAssign()
+	staleLearner.Nodes[3] = &models.Node{IP: nodeE.IP, Port: nodeE.Port}
+	staleLearner.AddLearner(1, 3)
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{staleLearner}, nil).Times(2)
+	shardAssignService.EXPECT().Save("db", gomock.Any()).DoAndReturn(
+		func(databaseName string, shardAssign *models.ShardAssignment) error {
+			assert.Empty(t, shardAssign.Shards[1].Learners)
+			assert.Len(t, shardAssign.Nodes, 3)
+			return nil
+		})
+	r.moveReplicas(nodeD)
+	shardAssignService.EXPECT().Save("db", gomock.Any()).Return(fmt.Errorf("err"))
+	r.moveReplicas(nodeD)
+	// case 4: save learners err
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{newTestShardAssign()}, nil)
+	shardAssignService.EXPECT().Save("db", gomock.Any()).Return(fmt.Errorf("err"))
+	r.moveReplicas(nodeA)
+	// case 5: learners not created before timeout, get shard assignment err when removing learner
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{newTestShardAssign()}, nil)
+	shardAssignService.EXPECT().Save("db", gomock.Any()).Return(nil)
+	shardAssignService.EXPECT().Get("db").Return(nil, fmt.Errorf("err"))
+	states := replicas(0, 0)
+	replicaStatus.EXPECT().GetAllReplicas().DoAndReturn(func() map[string]models.BrokerReplicaState {
+		return states
+	}).AnyTimes()
+	r.moveReplicas(nodeA)
+	states = replicas(100, 0, 2)
+	// case 6: submit transfer task err, removes learner then drops database of target node
+	var learnerAssign *models.ShardAssignment
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{newTestShardAssign()}, nil)
+	shardAssignService.EXPECT().Save("db", gomock.Any()).DoAndReturn(
+		func(databaseName string, shardAssign *models.ShardAssignment) error {
+			learnerAssign = shardAssign.Clone()
+			return nil
+		})
+	shardAssignService.EXPECT().Get("db").DoAndReturn(func(databaseName string) (*models.ShardAssignment, error) {
+		return learnerAssign, nil
+	})
+	shardAssignService.EXPECT().Save("db", gomock.Any()).DoAndReturn(
+		func(databaseName string, shardAssign *models.ShardAssignment) error {
+			assert.Empty(t, shardAssign.Shards[0].Learners)
+			assert.Empty(t, shardAssign.Shards[2].Learners)
+			_, ok := shardAssign.Nodes[3]
+			assert.False(t, ok)
+			return nil
+		})
+	taskController.EXPECT().Submit(constants.TransferShard, taskName, []task.ControllerTaskParam{{
+		NodeID: nodeD.Indicator(),
+		Params: &models.ShardTransferTask{DatabaseName: "db", ShardIDs: []int32{0, 2}, Source: nodeB},
+	}}).Return(fmt.Errorf("err"))
+	taskController.EXPECT().Submit(constants.DropDatabase, "db-"+nodeD.Indicator(), []task.ControllerTaskParam{{
+		NodeID: nodeD.Indicator(),
+		Params: &models.DatabaseDropTask{DatabaseName: "db"},
+	}}).Return(fmt.Errorf("err"))
+	r.moveReplicas(nodeA)
+	// case 7: get task state err
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{newTestShardAssign()}, nil)
+	shardAssignService.EXPECT().Save("db", gomock.Any()).Return(nil)
+	shardAssignService.EXPECT().Get("db").Return(nil, fmt.Errorf("err"))
+	taskController.EXPECT().Submit(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	taskController.EXPECT().GetState(constants.TransferShard, taskName).Return(task.StateCreated, fmt.Errorf("err"))
+	r.moveReplicas(nodeA)
+	// case 8: transfer task fail
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{newTestShardAssign()}, nil)
+	shardAssignService.EXPECT().Save("db", gomock.Any()).Return(nil)
+	shardAssignService.EXPECT().Get("db").Return(nil, fmt.Errorf("err"))
+	taskController.EXPECT().Submit(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	taskController.EXPECT().GetState(constants.TransferShard, taskName).Return(task.StateDoneErr, nil)
+	r.moveReplicas(nodeA)
+	// case 9: learner not catch up before timeout
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{newTestShardAssign()}, nil)
+	shardAssignService.EXPECT().Save("db", gomock.Any()).Return(nil)
+	shardAssignService.EXPECT().Get("db").Return(nil, fmt.Errorf("err"))
+	taskController.EXPECT().Submit(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	gomock.InOrder(
+		taskController.EXPECT().GetState(constants.TransferShard, taskName).Return(task.StateRunning, nil),
+		taskController.EXPECT().GetState(constants.TransferShard, taskName).Return(task.StateDoneOK, nil),
+	)
+	r.moveReplicas(nodeA)
+}
+
+func TestRebalancer_Promote(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	replicaStatus := replica.NewMockStatusStateMachine(ctrl)
+	taskController := task.NewMockController(ctrl)
+	activeNodes := []*models.ActiveNode{{Node: nodeA}, {Node: nodeB}, {Node: nodeC}, {Node: nodeD}}
+	r := newRebalancer(clusterCfg{
+		ctx:                context.TODO(),
+		shardAssignService: shardAssignService,
+		replicaStatus:      replicaStatus,
+		rebalance: config.Rebalance{
+			CheckInterval: ltoml.Duration(time.Millisecond),
+			PhaseTimeout:  ltoml.Duration(time.Second),
+			MaxPending:    10,
+		},
+	}, taskController, func() []*models.ActiveNode { return activeNodes }).(*rebalancer)
+	defer r.Close()
+
+	replicaStatus.EXPECT().GetAllReplicas().Return(map[string]models.BrokerReplicaState{
+		"broker": {Replicas: []models.ReplicaState{
+			{Database: "db", ShardID: 0, Target: nodeA},
+			{Database: "db", ShardID: 0, Target: nodeD, Pending: 1, Learner: true},
+		}},
+	}).AnyTimes()
+	taskController.EXPECT().Submit(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	taskController.EXPECT().GetState(gomock.Any(), gomock.Any()).Return(task.StateDoneOK, nil).AnyTimes()
+	var learnerAssign *models.ShardAssignment
+	saveLearner := func(databaseName string, shardAssign *models.ShardAssignment) error {
+		learnerAssign = shardAssign.Clone()
+		return nil
+	}
+
+	// case 1: get shard assignment err before promoting
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{newTestShardAssign()}, nil)
+	shardAssignService.EXPECT().Save("db", gomock.Any()).DoAndReturn(saveLearner)
+	shardAssignService.EXPECT().Get("db").Return(nil, fmt.Errorf("err")).Times(2)
+	r.moveReplicas(nodeA)
+	// case 2: promote learner, removed node is decommissioned
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{newTestShardAssign()}, nil)
+	shardAssignService.EXPECT().Save("db", gomock.Any()).DoAndReturn(saveLearner)
+	shardAssignService.EXPECT().Get("db").DoAndReturn(func(databaseName string) (*models.ShardAssignment, error) {
+		return learnerAssign, nil
+	})
+	shardAssignService.EXPECT().Save("db", gomock.Any()).DoAndReturn(
+		func(databaseName string, shardAssign *models.ShardAssignment) error {
+			assert.Equal(t, []int{3, 1}, shardAssign.Shards[0].Replicas)
+			assert.Equal(t, []int{1, 2}, shardAssign.Shards[1].Replicas)
+			assert.Equal(t, []int{1, 3}, shardAssign.Shards[2].Replicas)
+			assert.Empty(t, shardAssign.Shards[0].Learners)
+			assert.Empty(t, shardAssign.Shards[2].Learners)
+			_, ok := shardAssign.Nodes[0]
+			assert.False(t, ok)
+			assert.Equal(t, nodeD, *shardAssign.Nodes[3])
+			learnerAssign = shardAssign.Clone()
+			return nil
+		})
+	shardAssignService.EXPECT().Get("db").DoAndReturn(func(databaseName string) (*models.ShardAssignment, error) {
+		return learnerAssign, nil
+	})
+	r.moveReplicas(nodeA)
+	// case 3: get shard assignment err after moving replicas
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{newTestShardAssign()}, nil)
+	shardAssignService.EXPECT().Save("db", gomock.Any()).DoAndReturn(saveLearner).Times(2)
+	gomock.InOrder(
+		shardAssignService.EXPECT().Get("db").DoAndReturn(func(databaseName string) (*models.ShardAssignment, error) {
+			return learnerAssign, nil
+		}),
+		shardAssignService.EXPECT().Get("db").Return(nil, fmt.Errorf("err")),
+	)
+	r.moveReplicas(nodeA)
+}
+
+func TestRebalancer_MoveReplicas_MultiMoves(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	replicaStatus := replica.NewMockStatusStateMachine(ctrl)
+	taskController := task.NewMockController(ctrl)
+	activeNodes := []*models.ActiveNode{{Node: nodeB}, {Node: nodeC}, {Node: nodeD}, {Node: nodeE}}
+	r := newRebalancer(clusterCfg{
+		ctx:                context.TODO(),
+		shardAssignService: shardAssignService,
+		replicaStatus:      replicaStatus,
+		rebalance: config.Rebalance{
+			CheckInterval: ltoml.Duration(time.Millisecond),
+			PhaseTimeout:  ltoml.Duration(time.Second),
+			MaxPending:    10,
+		},
+	}, taskController, func() []*models.ActiveNode { return activeNodes }).(*rebalancer)
+	defer r.Close()
+
+	// shard 0 => [A,B], shard 1 => [B,C], shard 2 => [C,A], removed node A is offline
+	shardAssign := newTestShardAssign()
+	shardAssign.Shards[2].Replicas = []int{2, 0}
+	replicaStatus.EXPECT().GetAllReplicas().Return(map[string]models.BrokerReplicaState{}).AnyTimes()
+	taskController.EXPECT().GetState(gomock.Any(), gomock.Any()).Return(task.StateDoneOK, nil).AnyTimes()
+	var current *models.ShardAssignment
+	shardAssignService.EXPECT().List().Return([]*models.ShardAssignment{shardAssign}, nil)
+	shardAssignService.EXPECT().Save("db", gomock.Any()).DoAndReturn(
+		func(databaseName string, shardAssign *models.ShardAssignment) error {
+			current = shardAssign.Clone()
+			return nil
+		}).Times(4)
+	shardAssignService.EXPECT().Get("db").DoAndReturn(func(databaseName string) (*models.ShardAssignment, error) {
+		return current.Clone(), nil
+	}).Times(4)
+	// each move pulls the shards from one source node
+	gomock.InOrder(
+		taskController.EXPECT().Submit(constants.TransferShard, "db-"+nodeD.Indicator()+"-"+nodeB.Indicator(),
+			[]task.ControllerTaskParam{{
+				NodeID: nodeD.Indicator(),
+				Params: &models.ShardTransferTask{DatabaseName: "db", ShardIDs: []int32{0}, Source: nodeB},
+			}}).Return(nil),
+		taskController.EXPECT().Submit(constants.TransferShard, "db-"+nodeE.Indicator()+"-"+nodeC.Indicator(),
+			[]task.ControllerTaskParam{{
+				NodeID: nodeE.Indicator(),
+				Params: &models.ShardTransferTask{DatabaseName: "db", ShardIDs: []int32{2}, Source: nodeC},
+			}}).Return(nil),
+	)
+	r.moveReplicas(nodeA)
+	assert.Equal(t, []int{3, 1}, current.Shards[0].Replicas)
+	assert.Equal(t, []int{2, 4}, current.Shards[2].Replicas)
+	_, ok := current.Nodes[0]
+	assert.False(t, ok)
+}
@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/state"
+)
+
+// restoredDatabaseListener registers the databases restored by storage nodes when discovering them
+type restoredDatabaseListener struct {
+	cluster *cluster
+}
+
+// OnCreate registers the restored database, then deletes it from state repo of storage cluster
+func (l *restoredDatabaseListener) OnCreate(key string, resource []byte) {
+	restored := &models.RestoredDatabase{}
+	if err := encoding.JSONUnmarshal(resource, restored); err != nil {
+		log.Error("discovery restored database but unmarshal error",
+			logger.String("data", string(resource)), logger.Error(err))
+		return
+	}
+	if err := l.cluster.registerRestoredDatabase(restored); err != nil {
+		log.Error("register restored database error",
+			logger.String("cluster", l.cluster.cfg.cfg.Name),
+			logger.String("db", restored.Name), logger.Error(err))
+		return
+	}
+	if err := l.cluster.cfg.repo.Delete(l.cluster.cfg.ctx, key); err != nil {
+		log.Warn("delete registered restored database error",
+			logger.String("key", key), logger.Error(err))
+	}
+	log.Info("register restored database successfully",
+		logger.String("cluster", l.cluster.cfg.cfg.Name), logger.String("db", restored.Name),
+		logger.String("node", restored.Node.Indicator()))
+}
+
+// OnDelete does nothing, restored database is deleted after registered
+func (l *restoredDatabaseListener) OnDelete(key string) {}
+
+// registerRestoredDatabase saves the shard assignment which assigns all shards to the node restoring database,
+// then saves the database config, so that the restored database is writable and queryable.
+// the shard ids of restored database must be 0~(num. of shard-1), because data is routed by num. of shard.
+func (c *cluster) registerRestoredDatabase(restored *models.RestoredDatabase) error {
+	if len(restored.Name) == 0 || len(restored.ShardIDs) == 0 {
+		return fmt.Errorf("database name or shards of restored database is empty")
+	}
+	shardIDs := make([]int, len(restored.ShardIDs))
+	for idx, shardID := range restored.ShardIDs {
+		shardIDs[idx] = int(shardID)
+	}
+	sort.Ints(shardIDs)
+	for idx, shardID := range shardIDs {
+		if idx != shardID {
+			return fmt.Errorf("shard ids of restored database must be 0~%d, but got %v", len(shardIDs)-1, shardIDs)
+		}
+	}
+	if _, err := c.cfg.databaseService.Get(restored.Name); err != state.ErrNotExist {
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("database[%s] already exists", restored.Name)
+	}
+	if _, err := c.cfg.shardAssignService.Get(restored.Name); err != state.ErrNotExist {
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("shard assignment of database[%s] already exists", restored.Name)
+	}
+	shardAssign := models.NewShardAssignment(restored.Name)
+	node := restored.Node
+	shardAssign.Nodes[0] = &node
+	for _, shardID := range shardIDs {
+		shardAssign.AddReplica(shardID, 0)
+	}
+	if err := c.cfg.shardAssignService.Save(restored.Name, shardAssign); err != nil {
+		return err
+	}
+	// save shard assignment before database config,
+	// so admin state machine doesn't create new shard assignment when discovering database config
+	return c.cfg.databaseService.Save(&models.Database{
+		Name:          restored.Name,
+		Cluster:       c.cfg.cfg.Name,
+		NumOfShard:    len(shardIDs),
+		ReplicaFactor: 1,
+		Option:        restored.Option,
+	})
+}
@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/service"
+)
+
+func TestRestoredDatabaseListener(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	shardAssignService := service.NewMockShardAssignService(ctrl)
+	databaseService := service.NewMockDatabaseService(ctrl)
+	c := &cluster{cfg: clusterCfg{
+		ctx:                context.TODO(),
+		cfg:                config.StorageCluster{Name: "test"},
+		repo:               repo,
+		shardAssignService: shardAssignService,
+		databaseService:    databaseService,
+	}}
+	listener := &restoredDatabaseListener{cluster: c}
+	key := constants.GetRestoredDatabasePath("db")
+	node := models.Node{IP: "1.1.1.1", Port: 2891}
+	restored := &models.RestoredDatabase{
+		Name:     "db",
+		ShardIDs: []int32{1, 0},
+		Option:   option.DatabaseOption{Interval: "10s"},
+		Node:     node,
+	}
+	// case 1: unmarshal err
+	listener.OnCreate(key, []byte{1, 2, 3})
+	// case 2: empty database name
+	listener.OnCreate(key, encoding.JSONMarshal(&models.RestoredDatabase{ShardIDs: []int32{0}}))
+	// case 3: shard ids not continuous
+	listener.OnCreate(key, encoding.JSONMarshal(&models.RestoredDatabase{Name: "db", ShardIDs: []int32{0, 2}}))
+	// case 4: get database config err
+	databaseService.EXPECT().Get("db").Return(nil, fmt.Errorf("err"))
+	listener.OnCreate(key, encoding.JSONMarshal(restored))
+	// case 5: database config exists
+	databaseService.EXPECT().Get("db").Return(&models.Database{Name: "db"}, nil)
+	listener.OnCreate(key, encoding.JSONMarshal(restored))
+	// case 6: get shard assignment err
+	databaseService.EXPECT().Get("db").Return(nil, state.ErrNotExist).AnyTimes()
+	shardAssignService.EXPECT().Get("db").Return(nil, fmt.Errorf("err"))
+	listener.OnCreate(key, encoding.JSONMarshal(restored))
+	// case 7: shard assignment exists
+	shardAssignService.EXPECT().Get("db").Return(models.NewShardAssignment("db"), nil)
+	listener.OnCreate(key, encoding.JSONMarshal(restored))
+	// case 8: save shard assignment err
+	shardAssignService.EXPECT().Get("db").Return(nil, state.ErrNotExist).AnyTimes()
+	shardAssignService.EXPECT().Save("db", gomock.Any()).Return(fmt.Errorf("err"))
+	listener.OnCreate(key, encoding.JSONMarshal(restored))
+	// case 9: save database config err
+	shardAssignService.EXPECT().Save("db", gomock.Any()).Return(nil)
+	databaseService.EXPECT().Save(gomock.Any()).Return(fmt.Errorf("err"))
+	listener.OnCreate(key, encoding.JSONMarshal(restored))
+	// case 10: register successfully, all shards are assigned to the restoring node
+	shardAssign := models.NewShardAssignment("db")
+	shardAssign.Nodes[0] = &node
+	shardAssign.AddReplica(0, 0)
+	shardAssign.AddReplica(1, 0)
+	shardAssignService.EXPECT().Save("db", shardAssign).Return(nil).Times(2)
+	databaseService.EXPECT().Save(&models.Database{
+		Name:          "db",
+		Cluster:       "test",
+		NumOfShard:    2,
+		ReplicaFactor: 1,
+		Option:        option.DatabaseOption{Interval: "10s"},
+	}).Return(nil).Times(2)
+	repo.EXPECT().Delete(gomock.Any(), key).Return(fmt.Errorf("err"))
+	listener.OnCreate(key, encoding.JSONMarshal(restored))
+	repo.EXPECT().Delete(gomock.Any(), key).Return(nil)
+	listener.OnCreate(key, encoding.JSONMarshal(restored))
+
+	listener.OnDelete(key)
+}
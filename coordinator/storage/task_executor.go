@@ -25,12 +25,17 @@ type TaskExecutor struct {
 func NewTaskExecutor(ctx context.Context,
 	node *models.Node,
 	repo state.Repository,
-	storageService service.StorageService) *TaskExecutor {
+	storageService service.StorageService,
+	seriesDeleter SeriesDeleter,
+	shardImporter ShardImporter) *TaskExecutor {
 	executor := task.NewExecutor(ctx, node, repo)
 
 	// register task processor
 	executor.Register(newCreateShardProcessor(storageService))
 	executor.Register(newDatabaseFlushProcessor(storageService))
+	executor.Register(newDatabaseDropProcessor(storageService))
+	executor.Register(newSeriesDeleteProcessor(seriesDeleter))
+	executor.Register(newShardTransferProcessor(shardImporter))
 	return &TaskExecutor{
 		ctx:            ctx,
 		repo:           repo,
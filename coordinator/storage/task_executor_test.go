@@ -19,7 +19,7 @@ func TestTaskExecutor(t *testing.T) {
 
 	storageService := service.NewMockStorageService(ctrl)
 	repo := state.NewMockRepository(ctrl)
-	exec := NewTaskExecutor(context.TODO(), &models.Node{IP: "1.1.1.1", Port: 5000}, repo, storageService)
+	exec := NewTaskExecutor(context.TODO(), &models.Node{IP: "1.1.1.1", Port: 5000}, repo, storageService, nil, nil)
 	assert.NotNil(t, exec)
 
 	repo.EXPECT().WatchPrefix(gomock.Any(), gomock.Any(), true).Return(nil)
@@ -0,0 +1,53 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+//go:generate mockgen -source=./transfer_shard_task.go -destination=./transfer_shard_task_mock.go -package=storage
+
+// ShardImporter represents the shard importer, which pulls the shards of database from source node,
+// then restores them as new replica in current node.
+type ShardImporter interface {
+	// Import pulls the shards of database from source node, then restores them in current node
+	Import(ctx context.Context, source models.Node, databaseName string, shardIDs []int32) error
+}
+
+// shardTransferProcessor represents transfer shard, builds new replica of shards in storage node when rebalancing
+type shardTransferProcessor struct {
+	importer ShardImporter
+}
+
+// newShardTransferProcessor returns transfer shard processor instance
+func newShardTransferProcessor(importer ShardImporter) task.Processor {
+	return &shardTransferProcessor{
+		importer: importer,
+	}
+}
+
+func (p *shardTransferProcessor) Kind() task.Kind             { return constants.TransferShard }
+func (p *shardTransferProcessor) RetryCount() int             { return 3 }
+func (p *shardTransferProcessor) RetryBackOff() time.Duration { return 5 * time.Second }
+func (p *shardTransferProcessor) Concurrency() int            { return 1 }
+
+// Process pulls the shards from source node, then restores them as new replica
+func (p *shardTransferProcessor) Process(ctx context.Context, task task.Task) error {
+	param := models.ShardTransferTask{}
+	if err := encoding.JSONUnmarshal(task.Params, &param); err != nil {
+		return err
+	}
+	if err := p.importer.Import(ctx, param.Source, param.DatabaseName, param.ShardIDs); err != nil {
+		return err
+	}
+	logger.GetLogger("coordinator", "StorageShardTransferProcessor").
+		Info("process transfer shard task",
+			logger.String("params", string(task.Params)))
+	return nil
+}
@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/task"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+func TestShardTransferProcessor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	importer := NewMockShardImporter(ctrl)
+	processor := newShardTransferProcessor(importer)
+	assert.Equal(t, 1, processor.Concurrency())
+	assert.Equal(t, 5*time.Second, processor.RetryBackOff())
+	assert.Equal(t, 3, processor.RetryCount())
+	assert.Equal(t, constants.TransferShard, processor.Kind())
+
+	err := processor.Process(context.TODO(), task.Task{Params: []byte{1, 1, 1}})
+	assert.NotNil(t, err)
+	param := models.ShardTransferTask{
+		DatabaseName: "test",
+		ShardIDs:     []int32{1, 2},
+		Source:       models.Node{IP: "1.1.1.1", Port: 2080},
+	}
+	importer.EXPECT().Import(gomock.Any(), param.Source, "test", []int32{1, 2}).Return(fmt.Errorf("err"))
+	err = processor.Process(context.TODO(), task.Task{Params: encoding.JSONMarshal(&param)})
+	assert.Error(t, err)
+	importer.EXPECT().Import(gomock.Any(), param.Source, "test", []int32{1, 2}).Return(nil)
+	err = processor.Process(context.TODO(), task.Task{Params: encoding.JSONMarshal(&param)})
+	assert.NoError(t, err)
+}
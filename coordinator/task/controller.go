@@ -67,6 +67,8 @@ type ControllerTaskParam struct {
 type Controller interface {
 	// Submit submits a task with params
 	Submit(kind Kind, name string, params []ControllerTaskParam) error
+	// GetState returns the state of grouped tasks submitted by kind and name
+	GetState(kind Kind, name string) (State, error)
 	// Close closes controller, then releases the resource
 	Close() error
 	// taskKey returns the key of task
@@ -131,6 +133,20 @@ func (c *controller) Submit(kind Kind, name string, params []ControllerTaskParam
 	return nil
 }
 
+// GetState returns the state of grouped tasks submitted by kind and name,
+// StateDoneOK/StateDoneErr is returned after all tasks are done.
+func (c *controller) GetState(kind Kind, name string) (State, error) {
+	data, err := c.repo.Get(c.ctx, c.statusKey(kind, name))
+	if err != nil {
+		return StateCreated, err
+	}
+	tasks := groupedTasks{}
+	if err := encoding.JSONUnmarshal(data, &tasks); err != nil {
+		return StateCreated, err
+	}
+	return tasks.State, nil
+}
+
 // Close shutdowns task controller
 func (c *controller) Close() error {
 	if atomic.CompareAndSwapInt32(&c.closed, 0, 1) {
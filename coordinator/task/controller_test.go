@@ -69,6 +69,36 @@ func TestController_Submit(t *testing.T) {
 	assert.Equal(t, ErrControllerClosed, err)
 }
 
+func TestController_GetState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	factory := NewControllerFactory()
+	repo := state.NewMockRepository(ctrl)
+	repo.EXPECT().WatchPrefix(gomock.Any(), gomock.Any(), true).Return(nil)
+	controller := factory.CreateController(context.TODO(), repo)
+	defer func() {
+		_ = controller.Close()
+	}()
+
+	// case 1: get status err
+	repo.EXPECT().Get(gomock.Any(), controller.statusKey("k", "name")).Return(nil, fmt.Errorf("err"))
+	_, err := controller.GetState("k", "name")
+	assert.Error(t, err)
+	// case 2: unmarshal err
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return([]byte{1, 2, 3}, nil)
+	_, err = controller.GetState("k", "name")
+	assert.Error(t, err)
+	// case 3: get state
+	repo.EXPECT().Get(gomock.Any(), gomock.Any()).Return(encoding.JSONMarshal(&groupedTasks{
+		State: StateDoneOK,
+		Tasks: []Task{{Kind: "k", Name: "name"}},
+	}), nil)
+	st, err := controller.GetState("k", "name")
+	assert.NoError(t, err)
+	assert.Equal(t, StateDoneOK, st)
+}
+
 func TestController_run(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
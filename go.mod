@@ -17,6 +17,7 @@ require (
 	github.com/golang/snappy v0.0.1
 	github.com/gorilla/mux v1.7.4
 	github.com/json-iterator/go v1.1.7
+	github.com/klauspost/compress v1.10.10
 	github.com/lindb/roaring v0.0.0-00010101000000-000000000000
 	github.com/m3db/prometheus_client_golang v0.8.1 // indirect
 	github.com/m3db/prometheus_client_model v0.1.0 // indirect
@@ -35,7 +36,9 @@ require (
 	go.etcd.io/etcd v0.5.0-alpha.5.0.20200320040136-0eee733220fc
 	go.uber.org/atomic v1.6.0
 	go.uber.org/zap v1.14.1
+	golang.org/x/crypto v0.0.0-20191002192127-34f69633bfdc
 	golang.org/x/sys v0.0.0-20200202164722-d101bd2416d5
+	golang.org/x/time v0.0.0-20180412165947-fbb02b2291d2
 	golang.org/x/tools v0.0.0-20191029190741-b9c20aec41a5
 	google.golang.org/grpc v1.26.0
 	gopkg.in/check.v1 v1.0.0-20180628173108-788fd7840127
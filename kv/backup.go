@@ -0,0 +1,31 @@
+package kv
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+// Backup links or copies the files of current versions into target path, then writes store info and manifest file,
+// so that target path can be opened as a kv store which includes the data of backup time.
+func (s *store) Backup(targetPath string) error {
+	// hold read lock for keeping families of store info and version set consistent
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+
+	if err := mkDirFunc(targetPath); err != nil {
+		return fmt.Errorf("create backup path error:%s", err)
+	}
+	if err := s.versions.Backup(targetPath); err != nil {
+		return fmt.Errorf("backup store version set error:%s", err)
+	}
+	infoPath := filepath.Join(targetPath, version.Options)
+	if err := encodeTomlFunc(infoPath, s.storeInfo); err != nil {
+		return fmt.Errorf("write store info to file[%s] error:%s", infoPath, err)
+	}
+	kvLogger.Info("backup store successfully",
+		logger.String("store", s.option.Path), logger.String("target", targetPath))
+	return nil
+}
@@ -0,0 +1,91 @@
+package kv
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/ltoml"
+)
+
+func TestStore_Backup(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+	storePath := filepath.Join(testKVPath, "store")
+	backupPath := filepath.Join(testKVPath, "backup")
+	kv, err := NewStore("test_kv", DefaultStoreOption(storePath))
+	assert.NoError(t, err)
+	f, err := kv.CreateFamily("f", FamilyOption{
+		CompactThreshold: 10,
+		Merger:           mergerStr,
+	})
+	assert.NoError(t, err)
+	flusher := f.NewFlusher()
+	_ = flusher.Add(1, []byte("test"))
+	assert.NoError(t, flusher.Commit())
+
+	assert.NoError(t, kv.Backup(backupPath))
+	// data written after backup is not included in backup
+	flusher = f.NewFlusher()
+	_ = flusher.Add(2, []byte("test2"))
+	assert.NoError(t, flusher.Commit())
+	assert.NoError(t, kv.Close())
+
+	// open backup as a new store
+	backup, err := NewStore("backup_kv", DefaultStoreOption(backupPath))
+	assert.NoError(t, err)
+	defer func() {
+		_ = backup.Close()
+	}()
+	f = backup.GetFamily("f")
+	assert.NotNil(t, f)
+	snapshot := f.GetSnapshot()
+	defer snapshot.Close()
+	readers, err := snapshot.FindReaders(1)
+	assert.NoError(t, err)
+	assert.Len(t, readers, 1)
+	value, err := readers[0].Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test"), value)
+	readers, err = snapshot.FindReaders(2)
+	assert.NoError(t, err)
+	assert.Empty(t, readers)
+}
+
+func TestStore_Backup_Fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		mkDirFunc = fileutil.MkDir
+		encodeTomlFunc = ltoml.EncodeToml
+		_ = fileutil.RemoveDir(testKVPath)
+		ctrl.Finish()
+	}()
+	versions := version.NewMockStoreVersionSet(ctrl)
+	s := &store{
+		option:    DefaultStoreOption(testKVPath),
+		storeInfo: newStoreInfo(DefaultStoreOption(testKVPath)),
+		versions:  versions,
+	}
+	backupPath := filepath.Join(testKVPath, "backup")
+	// case 1: mkdir err
+	mkDirFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, s.Backup(backupPath))
+	mkDirFunc = fileutil.MkDir
+	// case 2: backup version set err
+	versions.EXPECT().Backup(backupPath).Return(fmt.Errorf("err"))
+	assert.Error(t, s.Backup(backupPath))
+	// case 3: write store info err
+	versions.EXPECT().Backup(backupPath).Return(nil)
+	encodeTomlFunc = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, s.Backup(backupPath))
+}
@@ -38,7 +38,8 @@ func newCompactJob(family Family, state *compactionState, rollup Rollup) Compact
 func (c *compactJob) Run() error {
 	compaction := c.state.compaction
 	switch {
-	case compaction.IsTrivialMove():
+	case c.rollup == nil && compaction.IsTrivialMove() && !c.needRewrite():
+
 		c.moveCompaction()
 	default:
 		if err := c.mergeCompaction(); err != nil {
@@ -48,6 +49,21 @@ func (c *compactJob) Run() error {
 	return nil
 }
 
+// needRewrite checks if the input file of trivial move need to be rewritten by family's compression type,
+// or the file may contain the deleted data which need purge.
+func (c *compactJob) needRewrite() bool {
+	fileMeta := c.state.compaction.GetLevelFiles()[0]
+	if tombstone := c.family.getTombstone(); tombstone != nil && fileMeta.MayContainKeys(tombstone.Keys()) {
+		return true
+	}
+	reader, err := c.state.snapshot.GetReader(fileMeta.GetFileNumber())
+	if err != nil {
+		// if get reader fail, do merge compaction, then return err when merging
+		return true
+	}
+	return reader.Compression() != c.family.getCompression()
+}
+
 // moveCompaction moves low level file to  up level, just does metadata change
 func (c *compactJob) moveCompaction() {
 	compaction := c.state.compaction
@@ -85,17 +101,18 @@ func (c *compactJob) doMerge() error {
 	if err != nil {
 		return err
 	}
-	merger := c.merger()
-	if c.rollup != nil {
-		merger.Init(map[string]interface{}{RollupContext: c.rollup})
-	}
+	merger := c.newMerger()
 
 	var needMerge [][]byte
 	var previousKey uint32
 	start := true
 	for it.HasNext() {
 		key := it.Key()
-		value := it.Value()
+		value, err := it.Value()
+		if err != nil {
+			c.quarantineCorruptedFiles(key, err)
+			return err
+		}
 		switch {
 		case start || key == previousKey:
 			// if start or same keys, append to need merge slice
@@ -107,6 +124,7 @@ func (c *compactJob) doMerge() error {
 			// 1. if new key != previous key do merge logic based on user define
 			mergedValue, err := merger.Merge(previousKey, needMerge)
 			if err != nil {
+				c.quarantineCorruptedFiles(previousKey, err)
 				return err
 			}
 			// 2. add new k/v pair into new store build
@@ -127,6 +145,7 @@ func (c *compactJob) doMerge() error {
 	if len(needMerge) > 0 {
 		mergedValue, err := merger.Merge(previousKey, needMerge)
 		if err != nil {
+			c.quarantineCorruptedFiles(previousKey, err)
 			return err
 		}
 		if err := c.add(previousKey, mergedValue); err != nil {
@@ -142,19 +161,82 @@ func (c *compactJob) doMerge() error {
 	return nil
 }
 
+// newMerger creates the merger of family, then initializes it with rollup/tombstone context
+func (c *compactJob) newMerger() Merger {
+	merger := c.merger()
+	params := make(map[string]interface{})
+	if c.rollup != nil {
+		params[RollupContext] = c.rollup
+	}
+	if tombstone := c.family.getTombstone(); tombstone != nil {
+		params[TombstoneContext] = tombstone
+	}
+	if len(params) > 0 {
+		merger.Init(params)
+	}
+	return merger
+}
+
+// quarantineCorruptedFiles finds the input files which the value of key is corrupted if compaction fails with
+// corrupted data, then moves them into quarantine directory, so that next compaction job skips these files
+// and removes them from family version.
+func (c *compactJob) quarantineCorruptedFiles(key uint32, err error) {
+	if !table.IsCorrupted(err) {
+		return
+	}
+	for _, files := range c.state.compaction.GetInputs() {
+		for _, fileMeta := range files {
+			fileNumber := fileMeta.GetFileNumber()
+			reader, err := c.state.snapshot.GetReader(fileNumber)
+			if err != nil {
+				continue
+			}
+			value, err := reader.Get(key)
+			if err == nil {
+				// the data blocks in value are verified when merging
+				_, err = c.newMerger().Merge(key, [][]byte{value})
+			}
+			if !table.IsCorrupted(err) {
+				continue
+			}
+			if err := c.state.snapshot.Quarantine(fileNumber); err != nil {
+				kvLogger.Error("quarantine corrupted file error when do compact job",
+					logger.String("family", c.family.familyInfo()),
+					logger.Int64("file", fileNumber.Int64()), logger.Error(err))
+				continue
+			}
+			kvLogger.Warn("quarantine corrupted file when do compact job",
+				logger.String("family", c.family.familyInfo()),
+				logger.Int64("file", fileNumber.Int64()), logger.Uint32("key", key))
+		}
+	}
+}
+
 // installCompactionResults installs compactions results.
 // 1. mark input files is deletion which compaction job picked.
 // 2. add output files to up level.
 // 3. commit edit log for manifest.
+// for rollup job, input files are the files of source family, so just adds output files into level0,
+// and marks output files need rollup if target family has next rollup relation.
 func (c *compactJob) installCompactionResults() {
-	// marks compaction input files for deletion
-	c.state.compaction.MarkInputDeletes()
+	compaction := c.state.compaction
+	if c.rollup == nil {
+		// marks compaction input files for deletion
+		compaction.MarkInputDeletes()
+	}
 	// adds compaction outputs
-	level := c.state.compaction.GetLevel()
+	level := compaction.GetLevel()
 	for _, output := range c.state.outputs {
-		c.state.compaction.AddFile(level+1, output)
+		compaction.AddFile(level+1, output)
+	}
+	if c.rollup != nil {
+		if interval, ok := c.family.getRollupInterval(); ok {
+			for _, output := range c.state.outputs {
+				compaction.GetEditLog().Add(version.CreateNewRollupFile(output.GetFileNumber(), interval))
+			}
+		}
 	}
-	c.family.commitEditLog(c.state.compaction.GetEditLog())
+	c.family.commitEditLog(compaction.GetEditLog())
 }
 
 // add adds new k/v pair into new store build,
@@ -170,6 +252,10 @@ func (c *compactJob) add(key uint32, value []byte) error {
 			return err
 		}
 	}
+	// wait rate limiter of compaction scheduler before writing
+	if c.state.scheduler != nil {
+		c.state.scheduler.waitN(len(value))
+	}
 	// add key/value into store builder
 	if err := c.state.builder.Add(key, value); err != nil {
 		return err
@@ -191,9 +277,17 @@ func (c *compactJob) makeInputIterator() (table.Iterator, error) {
 		if len(files) > 0 {
 			for _, fileMeta := range files {
 				reader, err := c.state.snapshot.GetReader(fileMeta.GetFileNumber())
+				if table.IsCorrupted(err) {
+					// corrupted file is quarantined, skip it, then it's removed from version after compaction
+					kvLogger.Warn("skip corrupted file when do compact job",
+						logger.String("family", c.family.familyInfo()),
+						logger.Int64("file", fileMeta.GetFileNumber().Int64()), logger.Error(err))
+					continue
+				}
 				if err != nil {
 					return nil, err
 				}
+				c.state.bytesRead += int64(fileMeta.GetFileSize())
 				its = append(its, reader.Iterator())
 			}
 		}
@@ -238,6 +332,7 @@ func (c *compactJob) finishCompactionOutputFile() (err error) {
 	}
 	fileMeta := version.NewFileMeta(builder.FileNumber(), builder.MinKey(), builder.MaxKey(), builder.Size())
 	c.state.addOutputFile(fileMeta)
+	c.state.bytesWritten += int64(fileMeta.GetFileSize())
 	return err
 }
 
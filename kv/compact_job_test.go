@@ -6,10 +6,13 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 type mockAppendMerger struct {
@@ -41,6 +44,10 @@ func TestCompactJob_move_compact(t *testing.T) {
 		return merge
 	})
 	family.EXPECT().familyInfo().Return("family").AnyTimes()
+	family.EXPECT().getCompression().Return(table.NoCompression).AnyTimes()
+	reader := table.NewMockReader(ctrl)
+	reader.EXPECT().Compression().Return(table.NoCompression)
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader, nil)
 	f1 := version.NewFileMeta(1, 1, 100, 100)
 	compaction := version.NewCompaction(1, 0, []*version.FileMeta{f1}, nil)
 	state := newCompactionState(1000, snapshot, compaction)
@@ -57,6 +64,84 @@ func TestCompactJob_move_compact(t *testing.T) {
 	assert.Equal(t, version.CreateNewFile(1, f1), logs[1])
 }
 
+func TestCompactJob_rewrite_compact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	snapshot := version.NewMockSnapshot(ctrl)
+	family := generateMockFamily(ctrl, newMockAppendMerger)
+	family.EXPECT().familyInfo().Return("family").AnyTimes()
+	family.EXPECT().getCompression().Return(table.SnappyCompression).AnyTimes()
+	f1 := version.NewFileMeta(1, 1, 100, 100)
+	// case 1: get reader err, do merge compaction
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(nil, fmt.Errorf("err")).Times(2)
+	compaction := version.NewCompaction(1, 0, []*version.FileMeta{f1}, nil)
+	compact := newCompactJob(family, newCompactionState(1000, snapshot, compaction), nil)
+	assert.Error(t, compact.Run())
+	// case 2: compression of input file not match, rewrite file by merge compaction
+	reader := table.NewMockReader(ctrl)
+	reader.EXPECT().Compression().Return(table.NoCompression)
+	reader.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{1: {1, 2, 3}}))
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader, nil).Times(2)
+	builder := table.NewMockBuilder(ctrl)
+	gomock.InOrder(
+		family.EXPECT().newTableBuilder().Return(builder, nil),
+		family.EXPECT().addPendingOutput(table.FileNumber(10)),
+		builder.EXPECT().Add(uint32(1), []byte{1, 2, 3}).Return(nil),
+		builder.EXPECT().Count().Return(uint64(1)),
+		builder.EXPECT().Close().Return(nil),
+		family.EXPECT().removePendingOutput(table.FileNumber(10)),
+	)
+	builder.EXPECT().Size().Return(int32(10)).AnyTimes()
+	builder.EXPECT().FileNumber().Return(table.FileNumber(10)).AnyTimes()
+	builder.EXPECT().MinKey().Return(uint32(1)).AnyTimes()
+	builder.EXPECT().MaxKey().Return(uint32(1)).AnyTimes()
+	compaction = version.NewCompaction(1, 0, []*version.FileMeta{f1}, nil)
+	compact = newCompactJob(family, newCompactionState(1000, snapshot, compaction), nil)
+	assert.NoError(t, compact.Run())
+	logs := compaction.GetEditLog().GetLogs()
+	assert.Equal(t, 2, len(logs))
+	assert.Equal(t, version.NewDeleteFile(0, 1), logs[0])
+	assert.Equal(t, version.CreateNewFile(1, version.NewFileMeta(10, 1, 1, 10)), logs[1])
+}
+
+func TestCompactJob_purge_compact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	snapshot := version.NewMockSnapshot(ctrl)
+	merger := NewMockMerger(ctrl)
+	tombstone := NewMockTombstone(ctrl)
+	family := NewMockFamily(ctrl)
+	family.EXPECT().getNewMerger().Return(func() Merger { return merger }).AnyTimes()
+	family.EXPECT().getTombstone().Return(tombstone).AnyTimes()
+	family.EXPECT().commitEditLog(gomock.Any()).Return(true).AnyTimes()
+	family.EXPECT().familyInfo().Return("family").AnyTimes()
+	family.EXPECT().getCompression().Return(table.NoCompression).AnyTimes()
+	tombstone.EXPECT().Keys().Return(roaring.BitmapOf(1)).AnyTimes()
+	// case 1: file not contains deleted keys, just move file
+	reader := table.NewMockReader(ctrl)
+	reader.EXPECT().Compression().Return(table.NoCompression)
+	snapshot.EXPECT().GetReader(table.FileNumber(2)).Return(reader, nil)
+	f2 := version.NewFileMeta(2, 10, 100, 100)
+	compaction := version.NewCompaction(1, 0, []*version.FileMeta{f2}, nil)
+	compact := newCompactJob(family, newCompactionState(1000, snapshot, compaction), nil)
+	assert.NoError(t, compact.Run())
+	assert.Equal(t, version.CreateNewFile(1, f2), compaction.GetEditLog().GetLogs()[1])
+	// case 2: file may contain deleted keys, purge deleted data by merge compaction
+	reader.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{1: {1, 2, 3}}))
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader, nil)
+	merger.EXPECT().Init(map[string]interface{}{TombstoneContext: tombstone})
+	merger.EXPECT().Merge(uint32(1), [][]byte{{1, 2, 3}}).Return(nil, nil)
+	f1 := version.NewFileMeta(1, 1, 100, 100)
+	compaction = version.NewCompaction(1, 0, []*version.FileMeta{f1}, nil)
+	compact = newCompactJob(family, newCompactionState(1000, snapshot, compaction), nil)
+	assert.NoError(t, compact.Run())
+	logs := compaction.GetEditLog().GetLogs()
+	assert.Equal(t, 1, len(logs))
+	assert.Equal(t, version.NewDeleteFile(0, 1), logs[0])
+}
+
 func TestCompactJob_merge_compact_get_read_fail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -79,6 +164,31 @@ func TestCompactJob_merge_compact_get_read_fail(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestCompactJob_merge_compact_skip_corrupted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	snapshot := version.NewMockSnapshot(ctrl)
+	snapshot.EXPECT().GetReader(gomock.Any()).Return(nil, table.ErrChecksumMismatch).Times(2)
+	merge := NewMockMerger(ctrl)
+	family := generateMockFamily(ctrl, func() Merger {
+		return merge
+	})
+	family.EXPECT().familyInfo().Return("family").AnyTimes()
+	f1 := version.NewFileMeta(1, 1, 10, 100)
+	f4 := version.NewFileMeta(4, 30, 100, 100)
+	compaction := version.NewCompaction(1, 0, []*version.FileMeta{f1}, []*version.FileMeta{f4})
+	state := newCompactionState(1000, snapshot, compaction)
+	compactJob := newCompactJob(family, state, nil)
+	err := compactJob.Run()
+	assert.NoError(t, err)
+	// corrupted files are removed from version
+	logs := compaction.GetEditLog().GetLogs()
+	assert.Equal(t, 2, len(logs))
+	assert.Equal(t, version.NewDeleteFile(0, 1), logs[0])
+	assert.Equal(t, version.NewDeleteFile(1, 4), logs[1])
+}
+
 func TestCompactJob_merge_compact_merge_fail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -119,6 +229,68 @@ func TestCompactJob_merge_compact_merge_fail(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestCompactJob_merge_compact_quarantine_corrupted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	snapshot := version.NewMockSnapshot(ctrl)
+	reader1 := table.NewMockReader(ctrl)
+	reader4 := table.NewMockReader(ctrl)
+	merge := NewMockMerger(ctrl)
+	family := generateMockFamily(ctrl, func() Merger {
+		return merge
+	})
+	family.EXPECT().familyInfo().Return("family").AnyTimes()
+	f1 := version.NewFileMeta(1, 1, 10, 100)
+	f4 := version.NewFileMeta(4, 30, 100, 100)
+	compaction := version.NewCompaction(1, 0, []*version.FileMeta{f1}, []*version.FileMeta{f4})
+	state := newCompactionState(1000, snapshot, compaction)
+
+	// case 1: read corrupted value, quarantine the file which value is corrupted
+	it := table.NewMockIterator(ctrl)
+	gomock.InOrder(
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().Key().Return(uint32(1)),
+		it.EXPECT().Value().Return(nil, table.ErrChecksumMismatch),
+		it.EXPECT().HasNext().Return(false),
+	)
+	reader1.EXPECT().Iterator().Return(it)
+	reader4.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{}))
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader1, nil).Times(2)
+	snapshot.EXPECT().GetReader(table.FileNumber(4)).Return(reader4, nil).Times(2)
+	reader1.EXPECT().Get(uint32(1)).Return(nil, table.ErrChecksumMismatch)
+	reader4.EXPECT().Get(uint32(1)).Return(nil, constants.ErrNotFound)
+	snapshot.EXPECT().Quarantine(table.FileNumber(1)).Return(nil)
+	err := newCompactJob(family, state, nil).Run()
+	assert.True(t, table.IsCorrupted(err))
+
+	// case 2: merge corrupted data block, quarantine the file which data block is corrupted
+	reader1.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{1: []byte("value1")}))
+	reader4.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{1: []byte("value4")}))
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader1, nil).Times(2)
+	snapshot.EXPECT().GetReader(table.FileNumber(4)).Return(reader4, nil).Times(2)
+	reader1.EXPECT().Get(uint32(1)).Return([]byte("value1"), nil)
+	reader4.EXPECT().Get(uint32(1)).Return([]byte("value4"), nil)
+	gomock.InOrder(
+		merge.EXPECT().Merge(uint32(1), gomock.Any()).Return(nil, constants.ErrDataFileCorruption),
+		merge.EXPECT().Merge(uint32(1), [][]byte{[]byte("value1")}).Return([]byte("value1"), nil),
+		merge.EXPECT().Merge(uint32(1), [][]byte{[]byte("value4")}).Return(nil, constants.ErrDataFileCorruption),
+	)
+	snapshot.EXPECT().Quarantine(table.FileNumber(4)).Return(fmt.Errorf("err"))
+	err = newCompactJob(family, state, nil).Run()
+	assert.True(t, table.IsCorrupted(err))
+
+	// case 3: merge fail, but not corrupted
+	reader1.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{1: []byte("value1")}))
+	reader4.EXPECT().Iterator().Return(generateIterator(ctrl, map[uint32][]byte{}))
+	snapshot.EXPECT().GetReader(table.FileNumber(1)).Return(reader1, nil)
+	snapshot.EXPECT().GetReader(table.FileNumber(4)).Return(reader4, nil)
+	merge.EXPECT().Merge(uint32(1), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	err = newCompactJob(family, state, nil).Run()
+	assert.Error(t, err)
+	assert.False(t, table.IsCorrupted(err))
+}
+
 func TestCompactJob_merge_doMerge_fail(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -405,6 +577,7 @@ func TestCompactJob_merge_compact(t *testing.T) {
 	compaction := version.NewCompaction(1, 0, []*version.FileMeta{f1, f2}, []*version.FileMeta{f3, f4})
 	state := newCompactionState(10000000, snapshot, compaction)
 	compactJob := newCompactJob(family, state, NewMockRollup(ctrl))
+	family.EXPECT().getRollupInterval().Return(timeutil.Interval(100), true)
 	builder := table.NewMockBuilder(ctrl)
 	gomock.InOrder(
 		family.EXPECT().newTableBuilder().Return(builder, nil),
@@ -435,19 +608,18 @@ func TestCompactJob_merge_compact(t *testing.T) {
 	newFile := version.NewFileMeta(table.FileNumber(5), uint32(1), uint32(100), int32(10))
 	assert.Equal(t, 1, len(state.outputs))
 	assert.Equal(t, *newFile, *(state.outputs[0]))
+	// rollup job doesn't delete source files, marks output files need next rollup
 	editLog := state.compaction.GetEditLog()
 	logs := editLog.GetLogs()
-	assert.Equal(t, 5, len(logs))
-	assert.Equal(t, version.NewDeleteFile(0, 1), logs[0])
-	assert.Equal(t, version.NewDeleteFile(0, 2), logs[1])
-	assert.Equal(t, version.NewDeleteFile(1, 3), logs[2])
-	assert.Equal(t, version.NewDeleteFile(1, 4), logs[3])
-	assert.Equal(t, version.CreateNewFile(1, newFile), logs[4])
+	assert.Equal(t, 2, len(logs))
+	assert.Equal(t, version.CreateNewFile(1, newFile), logs[0])
+	assert.Equal(t, version.CreateNewRollupFile(5, 100), logs[1])
 }
 
 func generateMockFamily(ctrl *gomock.Controller, merger NewMerger) *MockFamily {
 	family := NewMockFamily(ctrl)
 	family.EXPECT().getNewMerger().Return(merger).AnyTimes()
+	family.EXPECT().getTombstone().Return(nil).AnyTimes()
 	family.EXPECT().Name().Return("test-family").AnyTimes()
 	family.EXPECT().commitEditLog(gomock.Any()).Return(true).AnyTimes()
 	return family
@@ -467,7 +639,7 @@ func generateIterator(ctrl *gomock.Controller, values map[uint32][]byte) table.I
 		calls = append(calls,
 			it1.EXPECT().HasNext().Return(true),
 			it1.EXPECT().Key().Return(key),
-			it1.EXPECT().Value().Return(values[key]))
+			it1.EXPECT().Value().Return(values[key], nil))
 	}
 	calls = append(calls, it1.EXPECT().HasNext().Return(false))
 
@@ -0,0 +1,334 @@
+package kv
+
+import (
+	"container/heap"
+	"context"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+
+	"github.com/lindb/lindb/monitoring"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+//go:generate mockgen -source ./compact_scheduler.go -destination=./compact_scheduler_mock.go -package kv
+
+var (
+	compactBytesRead = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kv_compaction_bytes_read",
+			Help: "Bytes of sst files read by compaction job.",
+		},
+		[]string{"family"},
+	)
+	compactBytesWritten = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kv_compaction_bytes_written",
+			Help: "Bytes of sst files written by compaction job.",
+		},
+		[]string{"family"},
+	)
+	compactDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "kv_compaction_duration",
+			Help:    "Compaction job duration(ms).",
+			Buckets: monitoring.DefaultHistogramBuckets,
+		},
+		[]string{"family"},
+	)
+	compactFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kv_compaction_failures",
+			Help: "Number of failed compaction jobs.",
+		},
+		[]string{"family"},
+	)
+	compactPendingJobs = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "kv_compaction_pending_jobs",
+			Help: "Number of compaction jobs waiting in compaction scheduler.",
+		},
+	)
+	compactRunningJobs = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "kv_compaction_running_jobs",
+			Help: "Number of compaction jobs running in compaction scheduler.",
+		},
+	)
+)
+
+func init() {
+	monitoring.StorageRegistry.MustRegister(compactBytesRead, compactBytesWritten, compactDuration, compactFailures)
+	monitoring.StorageRegistry.MustRegister(compactPendingJobs, compactRunningJobs)
+}
+
+// deleteCompactionMetrics removes the compaction statistics of family after family closed/dropped
+func deleteCompactionMetrics(familyPath string) {
+	compactBytesRead.DeleteLabelValues(familyPath)
+	compactBytesWritten.DeleteLabelValues(familyPath)
+	compactDuration.DeleteLabelValues(familyPath)
+	compactFailures.DeleteLabelValues(familyPath)
+}
+
+// fullCompactPriority is the priority of full compaction job which is triggered manually
+const fullCompactPriority = int(^uint(0) >> 1)
+
+// defaultCompactScheduler is the compaction scheduler shared by all kv stores
+var defaultCompactScheduler = newCompactScheduler(CompactSchedulerOption{Concurrency: defaultCompactConcurrency})
+
+// GetCompactScheduler returns the compaction scheduler shared by all kv stores
+func GetCompactScheduler() CompactScheduler {
+	return defaultCompactScheduler
+}
+
+// CompactSchedulerOption defines config items of compaction scheduler
+type CompactSchedulerOption struct {
+	Concurrency int // max num. of compaction jobs running concurrently
+	RateLimit   int // max bytes written by all compaction jobs per second(MB/s), 0 means no limit
+}
+
+// CompactSchedulerState represents the current state of compaction scheduler
+type CompactSchedulerState struct {
+	Paused      bool `json:"paused"`
+	Concurrency int  `json:"concurrency"`
+	RateLimit   int  `json:"rateLimit"`
+	Pending     int  `json:"pending"`
+	Running     int  `json:"running"`
+}
+
+// CompactScheduler schedules the compaction jobs of all kv stores,
+// limits the concurrency and the written bytes rate of compaction jobs,
+// the waiting job of family which has more level0 files runs first.
+type CompactScheduler interface {
+	// SetOption changes the concurrency and rate limit of compaction jobs
+	SetOption(option CompactSchedulerOption)
+	// Pause stops dispatching the waiting compaction jobs, the running jobs aren't interrupted
+	Pause()
+	// Resume resumes dispatching the waiting compaction jobs
+	Resume()
+	// State returns the current state of compaction scheduler
+	State() CompactSchedulerState
+
+	// submit adds the compaction job of family into waiting queue,
+	// if family has job waiting, raises its priority, if family has job running, runs it again after completed.
+	submit(family Family, priority int)
+	// cancel removes the waiting compaction job of family
+	cancel(family Family)
+	// waitN blocks until compaction job can write n bytes based on rate limit
+	waitN(n int)
+}
+
+// compactTask represents the compaction job of family in waiting queue
+type compactTask struct {
+	family   Family
+	priority int
+	index    int  // index of waiting queue, -1 means running
+	again    bool // runs again after running job completed
+}
+
+// compactQueue implements heap.Interface, the task with highest priority is on top
+type compactQueue []*compactTask
+
+func (q compactQueue) Len() int           { return len(q) }
+func (q compactQueue) Less(i, j int) bool { return q[i].priority > q[j].priority }
+func (q compactQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+
+func (q *compactQueue) Push(x interface{}) {
+	task := x.(*compactTask)
+	task.index = len(*q)
+	*q = append(*q, task)
+}
+
+func (q *compactQueue) Pop() interface{} {
+	old := *q
+	n := len(old)
+	task := old[n-1]
+	old[n-1] = nil
+	task.index = -1
+	*q = old[:n-1]
+	return task
+}
+
+// compactScheduler implements CompactScheduler interface
+type compactScheduler struct {
+	option  CompactSchedulerOption
+	limiter *rate.Limiter
+	paused  bool
+	running int
+	queue   compactQueue
+	tasks   map[Family]*compactTask // family => waiting/running task
+
+	mutex sync.Mutex
+}
+
+// newCompactScheduler creates a compaction scheduler
+func newCompactScheduler(option CompactSchedulerOption) CompactScheduler {
+	option, limiter := normalizeCompactSchedulerOption(option)
+	return &compactScheduler{
+		option:  option,
+		limiter: limiter,
+		tasks:   make(map[Family]*compactTask),
+	}
+}
+
+// SetOption changes the concurrency and rate limit of compaction jobs
+func (s *compactScheduler) SetOption(option CompactSchedulerOption) {
+	option, limiter := normalizeCompactSchedulerOption(option)
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.option = option
+	s.limiter = limiter
+	kvLogger.Info("set option of compaction scheduler",
+		logger.Any("concurrency", option.Concurrency), logger.Any("rateLimit", option.RateLimit))
+	s.dispatch()
+}
+
+// Pause stops dispatching the waiting compaction jobs, the running jobs aren't interrupted
+func (s *compactScheduler) Pause() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.paused = true
+	kvLogger.Info("pause compaction scheduler")
+}
+
+// Resume resumes dispatching the waiting compaction jobs
+func (s *compactScheduler) Resume() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.paused = false
+	kvLogger.Info("resume compaction scheduler")
+	s.dispatch()
+}
+
+// State returns the current state of compaction scheduler
+func (s *compactScheduler) State() CompactSchedulerState {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return CompactSchedulerState{
+		Paused:      s.paused,
+		Concurrency: s.option.Concurrency,
+		RateLimit:   s.option.RateLimit,
+		Pending:     s.queue.Len(),
+		Running:     s.running,
+	}
+}
+
+// submit adds the compaction job of family into waiting queue,
+// if family has job waiting, raises its priority, if family has job running, runs it again after completed.
+func (s *compactScheduler) submit(family Family, priority int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if task, ok := s.tasks[family]; ok {
+		if priority > task.priority {
+			task.priority = priority
+			if task.index >= 0 {
+				heap.Fix(&s.queue, task.index)
+			}
+		}
+		if task.index < 0 {
+			task.again = true
+		}
+		return
+	}
+	task := &compactTask{family: family, priority: priority}
+	s.tasks[family] = task
+	heap.Push(&s.queue, task)
+	s.dispatch()
+}
+
+// cancel removes the waiting compaction job of family
+func (s *compactScheduler) cancel(family Family) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	task, ok := s.tasks[family]
+	if !ok {
+		return
+	}
+	if task.index >= 0 {
+		heap.Remove(&s.queue, task.index)
+		delete(s.tasks, family)
+	} else {
+		task.again = false
+	}
+	compactPendingJobs.Set(float64(s.queue.Len()))
+}
+
+// waitN blocks until compaction job can write n bytes based on rate limit
+func (s *compactScheduler) waitN(n int) {
+	s.mutex.Lock()
+	limiter := s.limiter
+	s.mutex.Unlock()
+
+	if limiter == nil {
+		return
+	}
+	burst := limiter.Burst()
+	for n > 0 {
+		size := n
+		if size > burst {
+			size = burst
+		}
+		if err := limiter.WaitN(context.Background(), size); err != nil {
+			kvLogger.Warn("wait compaction rate limiter error", logger.Error(err))
+			return
+		}
+		n -= size
+	}
+}
+
+// normalizeCompactSchedulerOption uses default concurrency if not set, then creates rate limiter if need
+func normalizeCompactSchedulerOption(option CompactSchedulerOption) (CompactSchedulerOption, *rate.Limiter) {
+	if option.Concurrency <= 0 {
+		option.Concurrency = defaultCompactConcurrency
+	}
+	if option.RateLimit <= 0 {
+		option.RateLimit = 0
+		return option, nil
+	}
+	bytesPerSecond := option.RateLimit * 1024 * 1024
+	return option, rate.NewLimiter(rate.Limit(bytesPerSecond), bytesPerSecond)
+}
+
+// dispatch runs the waiting jobs with highest priority if not paused and not reach the concurrency limit,
+// must be invoked with lock.
+func (s *compactScheduler) dispatch() {
+	for !s.paused && s.running < s.option.Concurrency && s.queue.Len() > 0 {
+		task := heap.Pop(&s.queue).(*compactTask)
+		s.running++
+		go s.run(task)
+	}
+	compactPendingJobs.Set(float64(s.queue.Len()))
+	compactRunningJobs.Set(float64(s.running))
+}
+
+// run runs the compaction job of family, then dispatches next waiting jobs
+func (s *compactScheduler) run(task *compactTask) {
+	defer func() {
+		s.mutex.Lock()
+		defer s.mutex.Unlock()
+
+		s.running--
+		if task.again {
+			// family submits job again when running, puts it back into waiting queue
+			task.again = false
+			heap.Push(&s.queue, task)
+		} else {
+			delete(s.tasks, task.family)
+		}
+		s.dispatch()
+	}()
+
+	task.family.doCompaction()
+}
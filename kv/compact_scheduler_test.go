@@ -0,0 +1,127 @@
+package kv
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompactScheduler_submit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := newCompactScheduler(CompactSchedulerOption{Concurrency: 1})
+	var order []string
+	var mutex sync.Mutex
+	newFamily := func(name string) Family {
+		f := NewMockFamily(ctrl)
+		f.EXPECT().doCompaction().Do(func() {
+			mutex.Lock()
+			order = append(order, name)
+			mutex.Unlock()
+		}).AnyTimes()
+		return f
+	}
+	f1 := newFamily("f1")
+	f2 := newFamily("f2")
+	f3 := newFamily("f3")
+	f4 := newFamily("f4")
+	// case 1: paused, jobs are waiting
+	s.Pause()
+	s.submit(f1, 1)
+	s.submit(f2, 5)
+	s.submit(f3, 3)
+	s.submit(f4, 4)
+	// raise priority of waiting job
+	s.submit(f1, 10)
+	// ignore lower priority
+	s.submit(f2, 2)
+	// remove waiting job
+	s.cancel(f4)
+	s.cancel(f4)
+	state := s.State()
+	assert.True(t, state.Paused)
+	assert.Equal(t, 3, state.Pending)
+	assert.Equal(t, 0, state.Running)
+	// case 2: resume, runs jobs by priority
+	s.Resume()
+	waitCompactScheduler(s)
+	mutex.Lock()
+	assert.Equal(t, []string{"f1", "f2", "f3"}, order)
+	mutex.Unlock()
+}
+
+func TestCompactScheduler_submit_running(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := newCompactScheduler(CompactSchedulerOption{Concurrency: 2})
+	f := NewMockFamily(ctrl)
+	started := make(chan struct{})
+	done := make(chan struct{})
+	// case 1: submit again when running, runs job again after completed
+	gomock.InOrder(
+		f.EXPECT().doCompaction().Do(func() {
+			started <- struct{}{}
+			<-done
+		}),
+		f.EXPECT().doCompaction(),
+	)
+	s.submit(f, 1)
+	<-started
+	s.submit(f, 2)
+	assert.Equal(t, 1, s.State().Running)
+	close(done)
+	waitCompactScheduler(s)
+
+	// case 2: cancel running job, not run again
+	started2 := make(chan struct{})
+	done2 := make(chan struct{})
+	f.EXPECT().doCompaction().Do(func() {
+		started2 <- struct{}{}
+		<-done2
+	})
+	s.submit(f, 1)
+	<-started2
+	s.submit(f, 2)
+	s.cancel(f)
+	close(done2)
+	waitCompactScheduler(s)
+}
+
+func TestCompactScheduler_SetOption(t *testing.T) {
+	s := newCompactScheduler(CompactSchedulerOption{})
+	state := s.State()
+	assert.Equal(t, defaultCompactConcurrency, state.Concurrency)
+	assert.Equal(t, 0, state.RateLimit)
+	// no limit
+	s.waitN(1024 * 1024 * 1024)
+
+	s.SetOption(CompactSchedulerOption{Concurrency: 4, RateLimit: 1})
+	state = s.State()
+	assert.Equal(t, 4, state.Concurrency)
+	assert.Equal(t, 1, state.RateLimit)
+	// take all burst
+	s.waitN(1024 * 1024)
+	now := time.Now()
+	s.waitN(512 * 1024)
+	assert.True(t, time.Since(now) > 400*time.Millisecond)
+
+	s.SetOption(CompactSchedulerOption{Concurrency: 1, RateLimit: -1})
+	assert.Equal(t, 0, s.State().RateLimit)
+	assert.Equal(t, defaultCompactScheduler, GetCompactScheduler())
+}
+
+// waitCompactScheduler waits all jobs of compaction scheduler completed
+func waitCompactScheduler(s CompactScheduler) {
+	for i := 0; i < 100; i++ {
+		state := s.State()
+		if state.Pending == 0 && state.Running == 0 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
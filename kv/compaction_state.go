@@ -13,6 +13,10 @@ type compactionState struct {
 	snapshot          version.Snapshot
 	currentFileNumber table.FileNumber
 	maxFileSize       int32
+	scheduler         CompactScheduler // limits the written bytes rate of compaction job if not nil
+
+	bytesRead    int64 // bytes of input files
+	bytesWritten int64 // bytes of output files
 }
 
 // newCompactionState creates a compaction state
@@ -1,12 +1,19 @@
 package kv
 
-import "github.com/lindb/lindb/pkg/logger"
+import (
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
 
 const dummy = ""
 const RollupContext = "RollupContext"
+const TombstoneContext = "TombstoneContext"
 const defaultMaxFileSize = int32(256 * 1024 * 1024)
 const defaultCompactThreshold = 4
 const defaultRollupThreshold = 3
+const defaultRollupTimeThreshold = 5 * timeutil.OneMinute
+const defaultCompactConcurrency = 2
 
 var defaultCompactCheckInterval = 60
+var defaultRollupCheckInterval = 60
 var kvLogger = logger.GetLogger("kv", "store")
@@ -5,12 +5,15 @@ import (
 	"path/filepath"
 	"sync"
 
+	"github.com/lindb/roaring"
+	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 //go:generate mockgen -source ./family.go -destination=./family_mock.go -package kv
@@ -31,8 +34,16 @@ type Family interface {
 	NewFlusher() Flusher
 	// GetSnapshot returns current version's snapshot
 	GetSnapshot() version.Snapshot
+	// BackupFiles links or copies the active files of family into target path
+	BackupFiles(targetPath string) error
+	// ReplaceFiles replaces all active files of family with the sst files under source path
+	ReplaceFiles(sourcePath string) error
+	// HasPendingRollup returns if family has files which are not rolled up into target family yet
+	HasPendingRollup() bool
 	// familyInfo return family info
 	familyInfo() string
+	// storeName returns the name of store which family belongs to
+	storeName() string
 
 	// getFamilyVersion returns the family version
 	getFamilyVersion() version.FamilyVersion
@@ -42,16 +53,41 @@ type Family interface {
 	newTableBuilder() (table.Builder, error)
 	// needCompat returns level0 files if need do compact job
 	needCompat() bool
-	// compact does compaction job
+	// compact submits compaction job into compaction scheduler if hasn't compaction job waiting/running
 	compact()
+	// fullCompact submits full compaction job which compacts all level0 files regardless of compact threshold
+	fullCompact()
+	// purge submits purge compaction job which rewrites the files may contain the keys,
+	// the deleted data of keys is purged by merger with the tombstone of store
+	purge(keys *roaring.Bitmap)
+	// isPurging returns if family has pending or running purge compaction job of the keys
+	isPurging(keys *roaring.Bitmap) bool
+	// doCompaction does the submitted rollup/compaction job, invoked by compaction scheduler
+	doCompaction()
 	// getNewMerger returns new merger function, merger need implement Merger interface
 	getNewMerger() NewMerger
 	// addPendingOutput add a file which current writing file number
 	addPendingOutput(fileNumber table.FileNumber)
 	// removePendingOutput removes pending output file after compact or flush
 	removePendingOutput(fileNumber table.FileNumber)
+	// needRollup returns if need rollup source family data
+	needRollup() bool
+	// rollup submits rollup job into compaction scheduler if hasn't rollup job waiting/running
+	rollup()
 	// doRollupWork does rollup job, merge source family data to target family
 	doRollupWork(sourceFamily Family, rollup Rollup, sourceFiles []table.FileNumber) (err error)
+	// getRollupInterval returns the target interval of rollup, if store has rollup relation registered
+	getRollupInterval() (timeutil.Interval, bool)
+	// getCompression returns the compression type of new sst files
+	getCompression() table.CompressionType
+	// setCompression sets the compression type of new sst files, old files are rewritten when compaction
+	setCompression(compression table.CompressionType)
+	// getTombstone returns the tombstone of store, returns nil if not registered
+	getTombstone() Tombstone
+	// scrub verifies all checksums of active files in family, returns num. of verified files and corrupted files
+	scrub(repair bool) (files int, corrupted []CorruptedFile, err error)
+	// removeCorruptedFiles removes the corrupted files from family version, then moves them into quarantine directory
+	removeCorruptedFiles(corrupted []CorruptedFile) error
 
 	// deleteObsoleteFiles deletes obsolete files
 	deleteObsoleteFiles()
@@ -66,12 +102,26 @@ type family struct {
 	merger        NewMerger
 	familyVersion version.FamilyVersion
 	maxFileSize   int32
+	compression   atomic.Uint32 // compression type of new sst files
 
 	pendingOutputs    sync.Map
 	newCompactJobFunc func(family Family, state *compactionState, rollup Rollup) CompactJob
-
-	rolluping  atomic.Bool
-	compacting atomic.Bool
+	scheduler         CompactScheduler
+
+	rolluping      atomic.Bool // has rollup job waiting/running
+	compacting     atomic.Bool // has compaction job waiting/running
+	compactPending atomic.Bool // compaction job is submitted, but not started
+	fullCompaction atomic.Bool // need compact all level0 files
+	lastRollupTime atomic.Int64
+
+	purgeLock   sync.Mutex
+	purgeKeys   *roaring.Bitmap // pending keys which need purge deleted data
+	purgingKeys *roaring.Bitmap // keys of running purge compaction job
+
+	compactBytesRead    prometheus.Counter
+	compactBytesWritten prometheus.Counter
+	compactDuration     prometheus.Observer
+	compactFailures     prometheus.Counter
 }
 
 // newFamily creates new family or open existed family.
@@ -89,6 +139,14 @@ func newFamily(store Store, option FamilyOption) (Family, error) {
 	if !ok {
 		return nil, fmt.Errorf("merger of option not impelement Merger interface, merger is [%s]", option.Merger)
 	}
+	compressionName := option.Compression
+	if len(compressionName) == 0 {
+		compressionName = defaultCompression
+	}
+	compression, err := table.ParseCompressionType(compressionName)
+	if err != nil {
+		return nil, err
+	}
 	maxFileSize := defaultMaxFileSize
 	if option.MaxFileSize > 0 {
 		maxFileSize = option.MaxFileSize
@@ -102,8 +160,16 @@ func newFamily(store Store, option FamilyOption) (Family, error) {
 		merger:            merger,
 		maxFileSize:       maxFileSize,
 		newCompactJobFunc: newCompactJobFunc,
+		scheduler:         defaultCompactScheduler,
 		familyVersion:     store.createFamilyVersion(name, version.FamilyID(option.ID)),
+
+		compactBytesRead:    compactBytesRead.WithLabelValues(familyPath),
+		compactBytesWritten: compactBytesWritten.WithLabelValues(familyPath),
+		compactDuration:     compactDuration.WithLabelValues(familyPath),
+		compactFailures:     compactFailures.WithLabelValues(familyPath),
 	}
+	f.lastRollupTime.Store(timeutil.Now())
+	f.compression.Store(uint32(compression))
 
 	kvLogger.Info("new family success", logger.String("family", f.familyInfo()))
 	return f, nil
@@ -134,11 +200,26 @@ func (f *family) familyInfo() string {
 	return f.familyPath
 }
 
+// storeName returns the name of store which family belongs to
+func (f *family) storeName() string {
+	return f.store.Name()
+}
+
 // newTableBuilder creates table builder instance for storing kv data.
 func (f *family) newTableBuilder() (table.Builder, error) {
 	fileNumber := f.store.nextFileNumber()
 	fileName := filepath.Join(f.familyPath, version.Table(fileNumber))
-	return table.NewStoreBuilder(fileNumber, fileName)
+	return table.NewStoreBuilder(fileNumber, fileName, f.getCompression())
+}
+
+// getCompression returns the compression type of new sst files
+func (f *family) getCompression() table.CompressionType {
+	return table.CompressionType(f.compression.Load())
+}
+
+// setCompression sets the compression type of new sst files, old files are rewritten when compaction
+func (f *family) setCompression(compression table.CompressionType) {
+	f.compression.Store(uint32(compression))
 }
 
 // commitEditLog persists edit logs into manifest file.
@@ -161,6 +242,9 @@ func (f *family) needCompat() bool {
 	if f.compacting.Load() {
 		return false
 	}
+	if f.fullCompaction.Load() || f.hasPurgeKeys() {
+		return true
+	}
 
 	snapshot := f.GetSnapshot()
 	defer snapshot.Close()
@@ -178,22 +262,111 @@ func (f *family) needCompat() bool {
 	return false
 }
 
-// compact does compact job if hasn't compact job running
+// compact submits compaction job into compaction scheduler if hasn't compaction job waiting/running,
+// the job of family which has more level0 files runs first.
 func (f *family) compact() {
 	if f.compacting.CAS(false, true) {
-		go func() {
-			defer f.compacting.Store(false)
+		snapshot := f.GetSnapshot()
+		numberOfFiles := snapshot.GetCurrent().NumberOfFilesInLevel(0)
+		snapshot.Close()
 
-			if err := f.backgroundCompactionJob(); err != nil {
-				kvLogger.Error("do compact job error",
-					logger.String("family", f.familyInfo()), logger.Error(err), logger.Stack())
-			}
-		}()
+		f.compactPending.Store(true)
+		f.scheduler.submit(f, numberOfFiles)
+	}
+}
+
+// fullCompact submits full compaction job which compacts all level0 files regardless of compact threshold,
+// the full compaction job runs before other waiting jobs.
+func (f *family) fullCompact() {
+	f.fullCompaction.Store(true)
+	f.compacting.Store(true)
+	f.compactPending.Store(true)
+	f.scheduler.submit(f, fullCompactPriority)
+}
+
+// purge submits purge compaction job which rewrites the files may contain the keys,
+// the deleted data of keys is purged by merger with the tombstone of store.
+func (f *family) purge(keys *roaring.Bitmap) {
+	if keys == nil || keys.IsEmpty() {
+		return
 	}
+	f.purgeLock.Lock()
+	if f.purgeKeys == nil {
+		f.purgeKeys = roaring.New()
+	}
+	f.purgeKeys.Or(keys)
+	f.purgeLock.Unlock()
+
+	f.compacting.Store(true)
+	f.compactPending.Store(true)
+	f.scheduler.submit(f, fullCompactPriority)
 }
 
-// backgroundCompactionJob runs compact job in background goroutine
-func (f *family) backgroundCompactionJob() error {
+// hasPurgeKeys returns if has pending keys which need purge deleted data
+func (f *family) hasPurgeKeys() bool {
+	f.purgeLock.Lock()
+	defer f.purgeLock.Unlock()
+	return f.purgeKeys != nil
+}
+
+// takePurgeKeys takes the pending purge keys as the keys of running purge job, returns nil if no pending keys
+func (f *family) takePurgeKeys() *roaring.Bitmap {
+	f.purgeLock.Lock()
+	defer f.purgeLock.Unlock()
+	keys := f.purgeKeys
+	f.purgeKeys = nil
+	f.purgingKeys = keys
+	return keys
+}
+
+// completePurge completes the running purge job, keeps the purge keys if fail, then retries purge when next compaction
+func (f *family) completePurge(success bool) {
+	f.purgeLock.Lock()
+	defer f.purgeLock.Unlock()
+	if !success && f.purgingKeys != nil {
+		if f.purgeKeys == nil {
+			f.purgeKeys = f.purgingKeys
+		} else {
+			f.purgeKeys.Or(f.purgingKeys)
+		}
+	}
+	f.purgingKeys = nil
+}
+
+// isPurging returns if family has pending or running purge compaction job of the keys
+func (f *family) isPurging(keys *roaring.Bitmap) bool {
+	f.purgeLock.Lock()
+	defer f.purgeLock.Unlock()
+	return (f.purgeKeys != nil && f.purgeKeys.Intersects(keys)) ||
+		(f.purgingKeys != nil && f.purgingKeys.Intersects(keys))
+}
+
+// getTombstone returns the tombstone of store, returns nil if not registered
+func (f *family) getTombstone() Tombstone {
+	return f.store.getTombstone()
+}
+
+// doCompaction does the submitted rollup/compaction job, invoked by compaction scheduler,
+// so that rollup job shares the concurrency/rate limit of compaction jobs.
+func (f *family) doCompaction() {
+	if f.rolluping.Load() {
+		f.doRollup()
+	}
+	// family may be marked compacting without job submitted(e.g. replacing files), skip compaction
+	if !f.compactPending.Swap(false) {
+		return
+	}
+	defer f.compacting.Store(false)
+
+	if err := f.backgroundCompactionJob(); err != nil {
+		f.compactFailures.Inc()
+		kvLogger.Error("do compact job error",
+			logger.String("family", f.familyInfo()), logger.Error(err), logger.Stack())
+	}
+}
+
+// backgroundCompactionJob runs compact job, compacts all level0 files if need full compaction
+func (f *family) backgroundCompactionJob() (err error) {
 	snapshot := f.GetSnapshot()
 	defer func() {
 		snapshot.Close()
@@ -201,16 +374,36 @@ func (f *family) backgroundCompactionJob() error {
 		f.deleteObsoleteFiles()
 	}()
 
-	compaction := snapshot.GetCurrent().PickL0Compaction(f.option.CompactThreshold)
+	threshold := f.option.CompactThreshold
+	if f.fullCompaction.Swap(false) {
+		threshold = 1
+	}
+	var compaction *version.Compaction
+	purgeKeys := f.takePurgeKeys()
+	if purgeKeys != nil {
+		defer func() {
+			// keep the purge keys if fail, retry purge when next compaction
+			f.completePurge(err == nil)
+		}()
+		// purge compaction also compacts all level0 files
+		compaction = snapshot.GetCurrent().PickPurgeCompaction(purgeKeys)
+	} else {
+		compaction = snapshot.GetCurrent().PickL0Compaction(threshold)
+	}
 	if compaction == nil {
 		// no compaction job need to do
 		return nil
 	}
+	startTime := timeutil.Now()
 	compactionState := newCompactionState(f.maxFileSize, snapshot, compaction)
+	compactionState.scheduler = f.scheduler
 	compactJob := f.newCompactJobFunc(f, compactionState, nil)
-	if err := compactJob.Run(); err != nil {
+	if err = compactJob.Run(); err != nil {
 		return err
 	}
+	f.compactBytesRead.Add(float64(compactionState.bytesRead))
+	f.compactBytesWritten.Add(float64(compactionState.bytesWritten))
+	f.compactDuration.Observe(float64(timeutil.Now() - startTime))
 	return nil
 }
 
@@ -242,6 +435,11 @@ func (f *family) getNewMerger() NewMerger {
 	return f.merger
 }
 
+// getRollupInterval returns the target interval of rollup, if store has rollup relation registered
+func (f *family) getRollupInterval() (timeutil.Interval, bool) {
+	return f.store.getRollupInterval()
+}
+
 // deleteObsoleteFiles deletes obsolete files
 func (f *family) deleteObsoleteFiles() {
 	sstFiles, err := listDirFunc(f.familyPath)
@@ -0,0 +1,102 @@
+package kv
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+// for testing
+var (
+	linkOrCopyFunc   = fileutil.LinkOrCopy
+	inspectTableFunc = table.Inspect
+)
+
+// BackupFiles links or copies the active files of family into target path,
+// which is used to send the files of family to other replica for repairing.
+func (f *family) BackupFiles(targetPath string) error {
+	snapshot := f.GetSnapshot()
+	defer snapshot.Close()
+
+	if err := mkDirFunc(targetPath); err != nil {
+		return fmt.Errorf("create backup path error:%s", err)
+	}
+	for _, file := range snapshot.GetCurrent().GetAllFiles() {
+		fileName := version.Table(file.GetFileNumber())
+		if err := linkOrCopyFunc(filepath.Join(f.familyPath, fileName), filepath.Join(targetPath, fileName)); err != nil {
+			return fmt.Errorf("backup file[%s] of family[%s] error:%s", fileName, f.familyInfo(), err)
+		}
+	}
+	return nil
+}
+
+// ReplaceFiles replaces all active files of family with the sst files under source path,
+// the source files are verified, then linked or copied into family as new level0 files.
+// the replaced files aren't rolled up any more, the new files are not marked as rollup files,
+// because only the families which are not written any more are replaced when repairing replica.
+func (f *family) ReplaceFiles(sourcePath string) (err error) {
+	// prevent compaction job running when replacing files
+	if !f.compacting.CAS(false, true) {
+		return fmt.Errorf("family[%s] is compacting", f.familyInfo())
+	}
+	var newFiles []table.FileNumber
+	defer func() {
+		for _, fileNumber := range newFiles {
+			f.removePendingOutput(fileNumber)
+		}
+		f.compacting.Store(false)
+		// clean up the replaced files, or the new files if replace failure
+		f.deleteObsoleteFiles()
+	}()
+
+	fileNames, err := listDirFunc(sourcePath)
+	if err != nil {
+		return err
+	}
+	editLog := version.NewEditLog(f.ID())
+	for _, fileName := range fileNames {
+		desc := version.ParseFileName(fileName)
+		if desc == nil || desc.FileType != version.TypeTable {
+			continue
+		}
+		fileNumber := f.store.nextFileNumber()
+		f.addPendingOutput(fileNumber)
+		newFiles = append(newFiles, fileNumber)
+
+		target := filepath.Join(f.familyPath, version.Table(fileNumber))
+		if err := linkOrCopyFunc(filepath.Join(sourcePath, fileName), target); err != nil {
+			return fmt.Errorf("link file[%s] into family[%s] error:%s", fileName, f.familyInfo(), err)
+		}
+		info, reader, err := inspectTableFunc(target, table.VerifyFull)
+		if err != nil {
+			return fmt.Errorf("verify file[%s] of family[%s] error:%s", fileName, f.familyInfo(), err)
+		}
+		_ = reader.Close()
+		editLog.Add(version.CreateNewFile(0, version.NewFileMeta(fileNumber, info.MinKey, info.MaxKey, int32(info.Size))))
+	}
+
+	snapshot := f.GetSnapshot()
+	v := snapshot.GetCurrent()
+	replaced := 0
+	for level := 0; level < f.store.Option().Levels; level++ {
+		for _, file := range v.GetFiles(level) {
+			editLog.Add(version.NewDeleteFile(int32(level), file.GetFileNumber()))
+			replaced++
+		}
+	}
+	for fileNumber := range v.GetRollupFiles() {
+		editLog.Add(version.CreateDeleteRollupFile(fileNumber))
+	}
+	snapshot.Close()
+
+	if !f.commitEditLog(editLog) {
+		return fmt.Errorf("replace files of family[%s] error", f.familyInfo())
+	}
+	kvLogger.Info("replace files of family successfully",
+		logger.String("family", f.familyInfo()), logger.Any("new", len(newFiles)), logger.Any("replaced", replaced))
+	return nil
+}
@@ -0,0 +1,119 @@
+package kv
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+func TestFamily_ReplaceFiles(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+	backupPath := filepath.Join(testKVPath, "backup")
+	source, err := NewStore("source_kv", DefaultStoreOption(filepath.Join(testKVPath, "source")))
+	assert.NoError(t, err)
+	sourceFamily, err := source.CreateFamily("f", FamilyOption{CompactThreshold: 10, Merger: mergerStr})
+	assert.NoError(t, err)
+	for i := uint32(1); i <= 2; i++ {
+		flusher := sourceFamily.NewFlusher()
+		_ = flusher.Add(i, []byte(fmt.Sprintf("source%d", i)))
+		assert.NoError(t, flusher.Commit())
+	}
+	assert.NoError(t, sourceFamily.BackupFiles(backupPath))
+	assert.NoError(t, source.Close())
+	// not sst file is ignored
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(backupPath, "LOCK"), []byte("lock"), 0644))
+
+	target, err := NewStore("target_kv", DefaultStoreOption(filepath.Join(testKVPath, "target")))
+	assert.NoError(t, err)
+	defer func() {
+		_ = target.Close()
+	}()
+	targetFamily, err := target.CreateFamily("f", FamilyOption{CompactThreshold: 10, Merger: mergerStr})
+	assert.NoError(t, err)
+	flusher := targetFamily.NewFlusher()
+	_ = flusher.Add(3, []byte("target3"))
+	assert.NoError(t, flusher.Commit())
+
+	assert.NoError(t, targetFamily.ReplaceFiles(backupPath))
+	snapshot := targetFamily.GetSnapshot()
+	defer snapshot.Close()
+	assert.Len(t, snapshot.GetCurrent().GetAllFiles(), 2)
+	for i := uint32(1); i <= 2; i++ {
+		readers, err := snapshot.FindReaders(i)
+		assert.NoError(t, err)
+		assert.Len(t, readers, 1)
+		value, err := readers[0].Get(i)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte(fmt.Sprintf("source%d", i)), value)
+	}
+	readers, err := snapshot.FindReaders(3)
+	assert.NoError(t, err)
+	assert.Empty(t, readers)
+}
+
+func TestFamily_ReplaceFiles_Fail(t *testing.T) {
+	defer func() {
+		linkOrCopyFunc = fileutil.LinkOrCopy
+		inspectTableFunc = table.Inspect
+		listDirFunc = fileutil.ListDir
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+	backupPath := filepath.Join(testKVPath, "backup")
+	kv, err := NewStore("test_kv", DefaultStoreOption(filepath.Join(testKVPath, "store")))
+	assert.NoError(t, err)
+	defer func() {
+		_ = kv.Close()
+	}()
+	f, err := kv.CreateFamily("f", FamilyOption{CompactThreshold: 10, Merger: mergerStr})
+	assert.NoError(t, err)
+	flusher := f.NewFlusher()
+	_ = flusher.Add(1, []byte("test"))
+	assert.NoError(t, flusher.Commit())
+
+	// case 1: backup files err
+	linkOrCopyFunc = func(source, target string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, f.BackupFiles(backupPath))
+	linkOrCopyFunc = fileutil.LinkOrCopy
+	assert.NoError(t, f.BackupFiles(backupPath))
+	// case 2: family is compacting
+	f1 := f.(*family)
+	f1.compacting.Store(true)
+	assert.Error(t, f.ReplaceFiles(backupPath))
+	f1.compacting.Store(false)
+	// case 3: list dir err
+	listDirFunc = func(path string) ([]string, error) {
+		return nil, fmt.Errorf("err")
+	}
+	assert.Error(t, f.ReplaceFiles(backupPath))
+	listDirFunc = fileutil.ListDir
+	// case 4: link file err
+	linkOrCopyFunc = func(source, target string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, f.ReplaceFiles(backupPath))
+	linkOrCopyFunc = fileutil.LinkOrCopy
+	// case 5: verify file err
+	inspectTableFunc = func(path string, verifyMode table.VerifyMode) (*table.FileInfo, table.Reader, error) {
+		return nil, nil, fmt.Errorf("err")
+	}
+	assert.Error(t, f.ReplaceFiles(backupPath))
+	assert.False(t, f1.compacting.Load())
+
+	// files of family are not changed, new files are removed
+	snapshot := f.GetSnapshot()
+	defer snapshot.Close()
+	assert.Len(t, snapshot.GetCurrent().GetAllFiles(), 1)
+	files, err := fileutil.ListDir(f1.familyPath)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+}
@@ -1,6 +1,8 @@
 package kv
 
 import (
+	"sort"
+
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
 	"github.com/lindb/lindb/pkg/logger"
@@ -17,10 +19,14 @@ type Rollup interface {
 	IntervalRatio() uint16
 	// CalcSlot calculates the target slot based on source timestamp
 	CalcSlot(timestamp int64) uint16
-	// GetTargetFamily returns the target family based on source family name
-	GetTargetFamily(sourceFamilyName string) Family
+	// TargetFamily returns the target family of source family
+	TargetFamily() Family
 }
 
+// NewRollupFunc creates the rollup relation of source family by family name,
+// which is registered into source store with target interval.
+type NewRollupFunc func(sourceFamilyName string) (Rollup, error)
+
 // needRollup returns if need rollup source family data
 func (f *family) needRollup() bool {
 	if f.rolluping.Load() {
@@ -42,58 +48,92 @@ func (f *family) needRollup() bool {
 			logger.Any("numOfFiles", rollupFilesLen), logger.Any("threshold", f.option.RollupThreshold))
 		return true
 	}
-	//FIXME need add time threshold????
+	// family which doesn't accept new data never reaches the threshold of num. of files,
+	// so need rollup those files after time threshold
+	timeThreshold := int64(f.option.RollupTimeThreshold) * timeutil.OneSecond
+	if timeThreshold <= 0 {
+		timeThreshold = defaultRollupTimeThreshold
+	}
+	if timeutil.Now()-f.lastRollupTime.Load() >= timeThreshold {
+		kvLogger.Info("need to rollup level0 files after time threshold", logger.String("family", f.familyInfo()),
+			logger.Any("numOfFiles", rollupFilesLen), logger.Int64("lastRollupTime", f.lastRollupTime.Load()))
+		return true
+	}
 	return false
 }
 
-// rollup does rollup in source family, need trigger target family does rollup compact job
+// HasPendingRollup returns if family has files which are not rolled up into target family yet
+func (f *family) HasPendingRollup() bool {
+	return len(f.familyVersion.GetLiveRollupFiles()) > 0
+}
+
+// rollup submits rollup job into compaction scheduler if hasn't rollup job waiting/running,
+// the job of family which has more rollup files runs first.
 func (f *family) rollup() {
-	// if has background rollup job running, return it.
 	if f.rolluping.CAS(false, true) {
-		defer func() {
-			// clean up unused files, maybe some file not used
-			f.deleteObsoleteFiles()
-			f.rolluping.Store(false)
-		}()
-
-		rollupFiles := f.familyVersion.GetLiveRollupFiles()
-		if len(rollupFiles) == 0 {
-			return
-		}
-		var interval timeutil.Interval
-		var sourceFiles []table.FileNumber
-		for file, i := range rollupFiles {
-			// only allow ont target rollup interval
+		f.scheduler.submit(f, len(f.familyVersion.GetLiveRollupFiles()))
+	}
+}
+
+// doRollup does rollup in source family, need trigger target family does rollup compact job,
+// invoked by compaction scheduler.
+func (f *family) doRollup() {
+	defer func() {
+		// clean up unused files, maybe some file not used
+		f.deleteObsoleteFiles()
+		f.rolluping.Store(false)
+	}()
+
+	rollupFiles := f.familyVersion.GetLiveRollupFiles()
+	if len(rollupFiles) == 0 {
+		return
+	}
+	var interval timeutil.Interval
+	var sourceFiles []table.FileNumber
+	for file, i := range rollupFiles {
+		// only allow one target rollup interval
+		if interval == 0 {
 			interval = i
+		}
+		if interval == i {
 			sourceFiles = append(sourceFiles, file)
 		}
+	}
 
-		// do rollup job in target family
-		rollup, ok := f.store.getRollup(interval)
-		if !ok {
-			kvLogger.Warn("skip rollup because cannot get target rollup",
-				logger.String("family", f.familyInfo()),
-				logger.Int64("interval", interval.Int64()))
-			return
-		}
-		editLog := version.NewEditLog(f.ID())
-		targetFamily := rollup.GetTargetFamily(f.name)
-
-		if err := targetFamily.doRollupWork(f, rollup, sourceFiles); err != nil {
-			kvLogger.Error("do rollup work fail",
-				logger.String("family", f.familyInfo()),
-				logger.Int64("interval", interval.Int64()),
-				logger.Any("files", sourceFiles))
-			return
-		}
+	// do rollup job in target family
+	newRollup, ok := f.store.getRollup(interval)
+	if !ok {
+		kvLogger.Warn("skip rollup because cannot get target rollup",
+			logger.String("family", f.familyInfo()),
+			logger.Int64("interval", interval.Int64()))
+		return
+	}
+	rollup, err := newRollup(f.name)
+	if err != nil {
+		kvLogger.Error("create rollup relation fail",
+			logger.String("family", f.familyInfo()),
+			logger.Int64("interval", interval.Int64()), logger.Error(err))
+		return
+	}
+	editLog := version.NewEditLog(f.ID())
+	targetFamily := rollup.TargetFamily()
 
-		// after rollup job successfully, need add delete rollup file edit log
-		for _, file := range sourceFiles {
-			editLog.Add(version.CreateDeleteRollupFile(file))
-		}
+	if err := targetFamily.doRollupWork(f, rollup, sourceFiles); err != nil {
+		kvLogger.Error("do rollup work fail",
+			logger.String("family", f.familyInfo()),
+			logger.Int64("interval", interval.Int64()),
+			logger.Any("files", sourceFiles), logger.Error(err))
+		return
+	}
+
+	// after rollup job successfully, need add delete rollup file edit log
+	for _, file := range sourceFiles {
+		editLog.Add(version.CreateDeleteRollupFile(file))
+	}
 
-		// finally need commit edit log
-		f.commitEditLog(editLog)
+	// finally need commit edit log
+	if f.commitEditLog(editLog) {
+		f.lastRollupTime.Store(timeutil.Now())
 	}
 }
 
@@ -109,8 +149,10 @@ func (f *family) doRollupWork(sourceFamily Family, rollup Rollup, sourceFiles []
 	for _, file := range sourceFiles {
 		targetFiles[file] = struct{}{}
 	}
+	// family id is only unique in store, multi source stores may roll up into same target family
+	referenceKey := version.ReferenceKey{Store: sourceFamily.storeName(), FamilyID: sourceFamily.ID()}
 	referenceFiles := f.familyVersion.GetLiveReferenceFiles()
-	files, ok := referenceFiles[sourceFamily.ID()]
+	files, ok := referenceFiles[referenceKey]
 	if ok {
 		for _, file := range files {
 			_, exist := targetFiles[file]
@@ -133,9 +175,21 @@ func (f *family) doRollupWork(sourceFamily Family, rollup Rollup, sourceFiles []
 	defer func() {
 		snapshot.Close()
 	}()
-	compaction := version.NewCompaction(f.ID(), -1, nil, nil)
+	// source files maybe not alive in source family version(compacted),
+	// but still can be read by file number, because rollup files are kept until rollup job completed.
+	var inputs []*version.FileMeta
+	for file := range targetFiles {
+		inputs = append(inputs, version.NewFileMeta(file, 0, 0, 0))
+	}
+	sort.Slice(inputs, func(i, j int) bool { return inputs[i].GetFileNumber() < inputs[j].GetFileNumber() })
+	compaction := version.NewCompaction(f.ID(), -1, inputs, nil)
+	// add reference files, make sure source files only rollup once
+	for _, input := range inputs {
+		compaction.GetEditLog().Add(version.CreateNewReferenceFile(referenceKey, input.GetFileNumber()))
+	}
 
 	compactionState := newCompactionState(f.maxFileSize, snapshot, compaction)
+	compactionState.scheduler = f.scheduler
 	compactJob := newCompactJobFunc(f, compactionState, rollup)
 	if err := compactJob.Run(); err != nil {
 		return err
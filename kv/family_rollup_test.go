@@ -44,6 +44,18 @@ func TestFamily_needRollup(t *testing.T) {
 	// case 4: need rollup
 	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10, 11: 10, 12: 10, 13: 10})
 	assert.True(t, f2.needRollup())
+	// case 5: rollup files < threshold, but after time threshold
+	f2.lastRollupTime.Store(timeutil.Now() - defaultRollupTimeThreshold)
+	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10})
+	assert.True(t, f2.needRollup())
+	f2.option.RollupTimeThreshold = 60 * 60
+	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10})
+	assert.False(t, f2.needRollup())
+	// case 6: pending rollup files
+	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10})
+	assert.True(t, f2.HasPendingRollup())
+	fv.EXPECT().GetLiveRollupFiles().Return(nil)
+	assert.False(t, f2.HasPendingRollup())
 }
 
 func TestFamily_rollup(t *testing.T) {
@@ -65,30 +77,47 @@ func TestFamily_rollup(t *testing.T) {
 	f, err := newFamily(store, FamilyOption{Merger: "mockMerger"})
 	assert.NoError(t, err)
 	f2 := f.(*family)
-	// case 1: rollup doing
-	f2.rolluping.Store(true)
+	scheduler := NewMockCompactScheduler(ctrl)
+	f2.scheduler = scheduler
+	// case 1: submit rollup job with num. of rollup files as priority
+	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10, 11: 10})
+	scheduler.EXPECT().submit(f, 2)
 	f2.rollup()
-	// case 2: get rollup files nil
-	f2.rolluping.Store(false)
-	fv.EXPECT().GetLiveRollupFiles().Return(nil).MaxTimes(2)
+	assert.True(t, f2.rolluping.Load())
+	// case 2: rollup job waiting/running
 	f2.rollup()
-	// case 3: rollup relation not found
+	assert.False(t, f2.needRollup())
+	// case 3: get rollup files nil
+	fv.EXPECT().GetLiveRollupFiles().Return(nil).MaxTimes(2)
+	f2.doCompaction()
+	assert.False(t, f2.rolluping.Load())
+	// case 4: rollup relation not found
 	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
 	store.EXPECT().getRollup(timeutil.Interval(10)).Return(nil, false)
-	f2.rollup()
-	// case 4: do rollup err
+	f2.doRollup()
+	// case 5: create rollup relation err
+	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
+	store.EXPECT().getRollup(timeutil.Interval(10)).Return(func(sourceFamilyName string) (Rollup, error) {
+		return nil, fmt.Errorf("err")
+	}, true)
+	f2.doRollup()
+	// case 6: do rollup err
 	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
 	rollup := NewMockRollup(ctrl)
 	tf := NewMockFamily(ctrl)
-	rollup.EXPECT().GetTargetFamily(gomock.Any()).Return(tf).AnyTimes()
-	store.EXPECT().getRollup(timeutil.Interval(10)).Return(rollup, true).AnyTimes()
+	rollup.EXPECT().TargetFamily().Return(tf).AnyTimes()
+	store.EXPECT().getRollup(timeutil.Interval(10)).Return(func(sourceFamilyName string) (Rollup, error) {
+		return rollup, nil
+	}, true).AnyTimes()
 	tf.EXPECT().doRollupWork(f2, rollup, []table.FileNumber{10}).Return(fmt.Errorf("err"))
-	f2.rollup()
-	// case 5: rollup success
+	f2.doRollup()
+	// case 7: rollup success, only rollup the files of one target interval
+	f2.lastRollupTime.Store(0)
 	fv.EXPECT().GetLiveRollupFiles().Return(map[table.FileNumber]timeutil.Interval{10: 10}).MaxTimes(2)
 	tf.EXPECT().doRollupWork(f2, rollup, []table.FileNumber{10}).Return(nil)
 	store.EXPECT().commitFamilyEditLog(gomock.Any(), gomock.Any()).Return(nil)
-	f2.rollup()
+	f2.doRollup()
+	assert.True(t, f2.lastRollupTime.Load() > 0)
 }
 
 func TestFamily_doRollupWork(t *testing.T) {
@@ -109,24 +138,36 @@ func TestFamily_doRollupWork(t *testing.T) {
 	err = f2.doRollupWork(nil, nil, nil)
 	assert.NoError(t, err)
 	// case 2: source files already rollup
-	fv.EXPECT().GetLiveReferenceFiles().Return(map[version.FamilyID][]table.FileNumber{10: {10, 20, 30}})
+	referenceKey := version.ReferenceKey{Store: "20220101", FamilyID: 10}
+	fv.EXPECT().GetLiveReferenceFiles().Return(map[version.ReferenceKey][]table.FileNumber{referenceKey: {10, 20, 30}})
 	sf := NewMockFamily(ctrl)
 	sf.EXPECT().ID().Return(version.FamilyID(10)).AnyTimes()
+	sf.EXPECT().storeName().Return("20220101").AnyTimes()
 	sf.EXPECT().familyInfo().Return("family").AnyTimes()
 	err = f2.doRollupWork(sf, nil, []table.FileNumber{10, 20, 30})
 	assert.NoError(t, err)
 	// case 3: rollup source files
-	fv.EXPECT().GetLiveReferenceFiles().Return(map[version.FamilyID][]table.FileNumber{10: {10, 30}}).AnyTimes()
+	fv.EXPECT().GetLiveReferenceFiles().Return(map[version.ReferenceKey][]table.FileNumber{
+		referenceKey: {10, 30},
+		// same family id of other source store
+		{Store: "20220102", FamilyID: 10}: {20},
+	}).AnyTimes()
 	snapshot := version.NewMockSnapshot(ctrl)
 	snapshot.EXPECT().Close().AnyTimes()
 	sf.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
-	err = f2.doRollupWork(sf, nil, []table.FileNumber{10, 20, 30})
-	assert.NoError(t, err)
-	// case 4: rollup job err
 	compactJob := NewMockCompactJob(ctrl)
 	newCompactJobFunc = func(family Family, state *compactionState, rollup Rollup) CompactJob {
+		// only rollup the files which not rollup before
+		inputs := state.compaction.GetInputs()[0]
+		assert.Len(t, inputs, 1)
+		assert.Equal(t, table.FileNumber(20), inputs[0].GetFileNumber())
+		assert.Equal(t, []version.Log{version.CreateNewReferenceFile(referenceKey, 20)}, state.compaction.GetEditLog().GetLogs())
 		return compactJob
 	}
+	compactJob.EXPECT().Run().Return(nil)
+	err = f2.doRollupWork(sf, nil, []table.FileNumber{10, 20, 30})
+	assert.NoError(t, err)
+	// case 4: rollup job err
 	compactJob.EXPECT().Run().Return(fmt.Errorf("err"))
 	err = f2.doRollupWork(sf, nil, []table.FileNumber{10, 20, 30})
 	assert.Error(t, err)
@@ -3,9 +3,9 @@ package kv
 import (
 	"fmt"
 	"testing"
-	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/kv/table"
@@ -39,7 +39,11 @@ func TestFamily_New(t *testing.T) {
 	f, err = newFamily(store, FamilyOption{Merger: "mockMerger_not_exist"})
 	assert.Error(t, err)
 	assert.Nil(t, f)
-	// case 3: create family success
+	// case 3: create family err, compression not exist
+	f, err = newFamily(store, FamilyOption{Merger: "mockMerger", Compression: "lz4"})
+	assert.Error(t, err)
+	assert.Nil(t, f)
+	// case 4: create family success
 	vs := version.NewMockFamilyVersion(ctrl)
 	store.EXPECT().createFamilyVersion(gomock.Any(), gomock.Any()).Return(vs)
 	f, err = newFamily(store, FamilyOption{Merger: "mockMerger", ID: 10, Name: "f", MaxFileSize: 10})
@@ -52,6 +56,50 @@ func TestFamily_New(t *testing.T) {
 	assert.NotNil(t, f.NewFlusher())
 	assert.NotNil(t, f.getFamilyVersion())
 	assert.NotNil(t, f.getNewMerger())
+	assert.Equal(t, table.NoCompression, f.getCompression())
+	f.setCompression(table.ZstdCompression)
+	assert.Equal(t, table.ZstdCompression, f.getCompression())
+}
+
+func TestFamily_Compression_Write_Read(t *testing.T) {
+	option := DefaultStoreOption(testKVPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+
+	kv, err := NewStore("test_kv", option)
+	assert.NoError(t, err)
+	f, err := kv.CreateFamily("f", FamilyOption{Merger: "mockMerger", Compression: "snappy"})
+	assert.NoError(t, err)
+	flusher := f.NewFlusher()
+	_ = flusher.Add(1, []byte("test"))
+	assert.NoError(t, flusher.Commit())
+	// change compression type of family
+	f, err = kv.CreateFamily("f", FamilyOption{Merger: "mockMerger", Compression: "zstd"})
+	assert.NoError(t, err)
+	flusher = f.NewFlusher()
+	_ = flusher.Add(10, []byte("test10"))
+	assert.NoError(t, flusher.Commit())
+
+	snapshot := f.GetSnapshot()
+	readers, _ := snapshot.FindReaders(1)
+	assert.Len(t, readers, 1)
+	assert.Equal(t, table.SnappyCompression, readers[0].Compression())
+	value, _ := readers[0].Get(1)
+	assert.Equal(t, []byte("test"), value)
+	readers, _ = snapshot.FindReaders(10)
+	assert.Len(t, readers, 1)
+	assert.Equal(t, table.ZstdCompression, readers[0].Compression())
+	value, _ = readers[0].Get(10)
+	assert.Equal(t, []byte("test10"), value)
+	snapshot.Close()
+	assert.NoError(t, kv.Close())
+
+	// reopen store, family keeps the compression type
+	kv, err = NewStore("test_kv", option)
+	assert.NoError(t, err)
+	assert.Equal(t, table.ZstdCompression, kv.GetFamily("f").getCompression())
+	assert.NoError(t, kv.Close())
 }
 
 func TestFamily_Data_Write_Read(t *testing.T) {
@@ -157,26 +205,119 @@ func TestFamily_compact(t *testing.T) {
 	snapshot.EXPECT().GetCurrent().Return(v).AnyTimes()
 	fv.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
 	store.EXPECT().createFamilyVersion(gomock.Any(), gomock.Any()).Return(fv)
-	f, err := newFamily(store, FamilyOption{Merger: "mockMerger"})
+	f, err := newFamily(store, FamilyOption{Merger: "mockMerger", CompactThreshold: 4})
 	assert.NoError(t, err)
 	fv.EXPECT().GetAllActiveFiles().Return(nil).AnyTimes()
 	fv.EXPECT().GetLiveRollupFiles().Return(nil).AnyTimes()
-	// case 1: run compact job err
-	v.EXPECT().PickL0Compaction(gomock.Any()).
-		Return(version.NewCompaction(1, 0, nil, nil))
-	compactJob := NewMockCompactJob(ctrl)
+	scheduler := NewMockCompactScheduler(ctrl)
 	f1 := f.(*family)
+	f1.scheduler = scheduler
+	compactJob := NewMockCompactJob(ctrl)
 	f1.newCompactJobFunc = func(family Family, state *compactionState, rollup Rollup) CompactJob {
+		assert.Equal(t, scheduler, state.scheduler)
 		return compactJob
 	}
-	compactJob.EXPECT().Run().Return(fmt.Errorf("err"))
+	// case 1: submit job with num. of level0 files as priority
+	v.EXPECT().NumberOfFilesInLevel(0).Return(5)
+	scheduler.EXPECT().submit(f, 5)
 	f.compact()
-	time.Sleep(200 * time.Millisecond)
-	// case 2: pick nil compaction
-	v.EXPECT().PickL0Compaction(gomock.Any()).Return(nil)
+	assert.True(t, f1.compacting.Load())
+	// case 2: has job waiting/running
 	f.compact()
+	assert.False(t, f.needCompat())
+	// case 3: run compact job err
+	v.EXPECT().PickL0Compaction(4).Return(version.NewCompaction(1, 0, nil, nil))
+	compactJob.EXPECT().Run().Return(fmt.Errorf("err"))
+	f.doCompaction()
+	assert.False(t, f1.compacting.Load())
+	// case 4: pick nil compaction
+	f1.compactPending.Store(true)
+	v.EXPECT().PickL0Compaction(4).Return(nil)
+	f.doCompaction()
+	// case 5: marked compacting without job submitted, skip compaction
+	f1.compacting.Store(true)
+	f.doCompaction()
+	assert.True(t, f1.compacting.Load())
+	f1.compacting.Store(false)
+	// case 6: full compaction compacts all level0 files
+	scheduler.EXPECT().submit(f, fullCompactPriority)
+	f.fullCompact()
+	assert.True(t, f1.compacting.Load())
+	f1.compacting.Store(false)
+	assert.True(t, f.needCompat())
+	v.EXPECT().PickL0Compaction(1).Return(version.NewCompaction(1, 0, nil, nil))
+	compactJob.EXPECT().Run().Return(nil)
+	f.doCompaction()
+	assert.False(t, f1.fullCompaction.Load())
+	assert.False(t, f1.compacting.Load())
+}
 
-	time.Sleep(200 * time.Millisecond)
+func TestFamily_purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testKVPath)
+		ctrl.Finish()
+	}()
+	store := NewMockStore(ctrl)
+	store.EXPECT().Option().Return(DefaultStoreOption(testKVPath)).AnyTimes()
+	fv := version.NewMockFamilyVersion(ctrl)
+	snapshot := version.NewMockSnapshot(ctrl)
+	v := version.NewMockVersion(ctrl)
+	snapshot.EXPECT().Close().AnyTimes()
+	snapshot.EXPECT().GetCurrent().Return(v).AnyTimes()
+	fv.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
+	store.EXPECT().createFamilyVersion(gomock.Any(), gomock.Any()).Return(fv)
+	f, err := newFamily(store, FamilyOption{Merger: "mockMerger", CompactThreshold: 4})
+	assert.NoError(t, err)
+	fv.EXPECT().GetAllActiveFiles().Return(nil).AnyTimes()
+	fv.EXPECT().GetLiveRollupFiles().Return(nil).AnyTimes()
+	scheduler := NewMockCompactScheduler(ctrl)
+	f1 := f.(*family)
+	f1.scheduler = scheduler
+	compactJob := NewMockCompactJob(ctrl)
+	f1.newCompactJobFunc = func(family Family, state *compactionState, rollup Rollup) CompactJob {
+		return compactJob
+	}
+	// case 1: empty keys
+	f.purge(nil)
+	f.purge(roaring.New())
+	assert.False(t, f1.compacting.Load())
+	// case 2: submit purge job
+	scheduler.EXPECT().submit(f, fullCompactPriority).Times(2)
+	f.purge(roaring.BitmapOf(1))
+	f.purge(roaring.BitmapOf(2))
+	assert.True(t, f1.compacting.Load())
+	f1.compacting.Store(false)
+	assert.True(t, f.needCompat())
+	assert.True(t, f.isPurging(roaring.BitmapOf(1, 3)))
+	assert.False(t, f.isPurging(roaring.BitmapOf(3)))
+	// case 3: purge job fail, keep purge keys for next compaction
+	v.EXPECT().PickPurgeCompaction(roaring.BitmapOf(1, 2)).Return(version.NewCompaction(1, 0, nil, nil))
+	compactJob.EXPECT().Run().DoAndReturn(func() error {
+		// purge job is running
+		assert.False(t, f1.hasPurgeKeys())
+		assert.True(t, f.isPurging(roaring.BitmapOf(2)))
+		return fmt.Errorf("err")
+	})
+	f.doCompaction()
+	assert.True(t, f1.hasPurgeKeys())
+	assert.True(t, f.isPurging(roaring.BitmapOf(2)))
+	// case 4: purge job success
+	f1.compactPending.Store(true)
+	v.EXPECT().PickPurgeCompaction(roaring.BitmapOf(1, 2)).Return(version.NewCompaction(1, 0, nil, nil))
+	compactJob.EXPECT().Run().Return(nil)
+	f.doCompaction()
+	assert.False(t, f1.hasPurgeKeys())
+	assert.False(t, f.isPurging(roaring.BitmapOf(1, 2)))
+	// case 5: no files need purge
+	scheduler.EXPECT().submit(f, fullCompactPriority)
+	f.purge(roaring.BitmapOf(3))
+	v.EXPECT().PickPurgeCompaction(roaring.BitmapOf(3)).Return(nil)
+	f.doCompaction()
+	assert.False(t, f.isPurging(roaring.BitmapOf(3)))
+	// case 6: get tombstone from store
+	store.EXPECT().getTombstone().Return(nil)
+	assert.Nil(t, f.getTombstone())
 }
 
 func TestFamily_compact_background(t *testing.T) {
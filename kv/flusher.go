@@ -66,6 +66,10 @@ func (sf *storeFlusher) Commit() (err error) {
 
 		fileMeta := version.NewFileMeta(builder.FileNumber(), builder.MinKey(), builder.MaxKey(), builder.Size())
 		sf.editLog.Add(version.CreateNewFile(0, fileMeta))
+		// mark the new file need rollup, if store has rollup relation
+		if interval, ok := sf.family.getRollupInterval(); ok {
+			sf.editLog.Add(version.CreateNewRollupFile(fileMeta.GetFileNumber(), interval))
+		}
 	}
 
 	if flag := sf.family.commitEditLog(sf.editLog); !flag {
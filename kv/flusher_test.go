@@ -9,6 +9,7 @@ import (
 
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 func TestFlusher_Add(t *testing.T) {
@@ -97,6 +98,7 @@ func TestStoreFlusher_Commit(t *testing.T) {
 		builder.EXPECT().MinKey().Return(uint32(1)),
 		builder.EXPECT().MaxKey().Return(uint32(10)),
 		builder.EXPECT().Size().Return(int32(100)),
+		family.EXPECT().getRollupInterval().Return(timeutil.Interval(0), false),
 		family.EXPECT().commitEditLog(gomock.Any()).Return(false),
 		builder.EXPECT().FileNumber().Return(table.FileNumber(10)),
 		family.EXPECT().removePendingOutput(table.FileNumber(10)),
@@ -114,7 +116,14 @@ func TestStoreFlusher_Commit(t *testing.T) {
 		builder.EXPECT().MinKey().Return(uint32(1)),
 		builder.EXPECT().MaxKey().Return(uint32(10)),
 		builder.EXPECT().Size().Return(int32(100)),
-		family.EXPECT().commitEditLog(gomock.Any()).Return(true),
+		family.EXPECT().getRollupInterval().Return(timeutil.Interval(10), true),
+		family.EXPECT().commitEditLog(gomock.Any()).DoAndReturn(func(editLog version.EditLog) bool {
+			// new file need rollup
+			logs := editLog.GetLogs()
+			assert.Len(t, logs, 2)
+			assert.Equal(t, version.CreateNewRollupFile(10, 10), logs[1])
+			return true
+		}),
 		builder.EXPECT().FileNumber().Return(table.FileNumber(10)),
 		family.EXPECT().removePendingOutput(table.FileNumber(10)),
 	)
@@ -0,0 +1,48 @@
+package kv
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/kv/version"
+)
+
+// for testing
+var (
+	readManifestFunc = version.ReadManifest
+)
+
+// StoreManifest represents the families and current manifest of kv store, used by offline inspection tool
+type StoreManifest struct {
+	Path           string                               // path of kv store
+	Option         StoreOption                          // option of kv store
+	Families       map[version.FamilyID]FamilyOption    // family id => family option
+	Manifest       *version.Manifest                    // edit logs of current manifest file
+	Versions       map[version.FamilyID]version.Version // current version of each family after replaying edit logs
+	NextFileNumber table.FileNumber                     // next file number after replaying edit logs
+}
+
+// ReadStoreManifest reads the store info and current manifest of kv store without opening the store,
+// then replays the edit logs for building current version of each family.
+func ReadStoreManifest(storePath string) (*StoreManifest, error) {
+	info := &storeInfo{}
+	if err := decodeTomlFunc(filepath.Join(storePath, version.Options), info); err != nil {
+		return nil, fmt.Errorf("load store info error:%s", err)
+	}
+	manifest, err := readManifestFunc(storePath)
+	if err != nil {
+		return nil, err
+	}
+	result := &StoreManifest{
+		Path:     storePath,
+		Option:   info.StoreOption,
+		Families: make(map[version.FamilyID]FamilyOption),
+		Manifest: manifest,
+	}
+	for _, familyOption := range info.Families {
+		result.Families[version.FamilyID(familyOption.ID)] = familyOption
+	}
+	result.Versions, result.NextFileNumber = manifest.Replay(info.StoreOption.Levels)
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package kv
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+func TestReadStoreManifest(t *testing.T) {
+	defer func() {
+		readManifestFunc = version.ReadManifest
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+	storePath := filepath.Join(testKVPath, "store")
+	kv, err := NewStore("test_kv", DefaultStoreOption(storePath))
+	assert.NoError(t, err)
+	f, err := kv.CreateFamily("f", FamilyOption{
+		CompactThreshold: 10,
+		Merger:           mergerStr,
+	})
+	assert.NoError(t, err)
+	flusher := f.NewFlusher()
+	_ = flusher.Add(1, []byte("test"))
+	_ = flusher.Add(10, []byte("test10"))
+	assert.NoError(t, flusher.Commit())
+	assert.NoError(t, kv.Close())
+
+	// case 1: read manifest success
+	manifest, err := ReadStoreManifest(storePath)
+	assert.NoError(t, err)
+	assert.Equal(t, storePath, manifest.Path)
+	assert.Equal(t, DefaultStoreOption(storePath).Levels, manifest.Option.Levels)
+	assert.Len(t, manifest.Families, 1)
+	assert.Equal(t, "f", manifest.Families[f.ID()].Name)
+	assert.NotEmpty(t, manifest.Manifest.EditLogs)
+	assert.Len(t, manifest.Versions[f.ID()].GetFiles(0), 1)
+	assert.True(t, manifest.NextFileNumber > 0)
+	// case 2: read manifest err
+	readManifestFunc = func(storePath string) (*version.Manifest, error) {
+		return nil, fmt.Errorf("err")
+	}
+	_, err = ReadStoreManifest(storePath)
+	assert.Error(t, err)
+	// case 3: store not exist
+	_, err = ReadStoreManifest(filepath.Join(testKVPath, "not_exist"))
+	assert.Error(t, err)
+}
@@ -1,5 +1,9 @@
 package kv
 
+import (
+	"github.com/lindb/roaring"
+)
+
 //go:generate mockgen -source ./merger.go -destination=./merger_mock.go -package kv
 
 // MergerType represents the merger type
@@ -20,6 +24,13 @@ func RegisterMerger(name MergerType, merger NewMerger) {
 	mergers[name] = merger
 }
 
+// Tombstone represents the deleted data of store, the deleted data is purged by merger when compaction,
+// merger gets the tombstone from the params of Init by TombstoneContext.
+type Tombstone interface {
+	// Keys returns the keys which have deleted data
+	Keys() *roaring.Bitmap
+}
+
 // Merger represents merger values of same key when do compaction job(compact/rollup etc.)
 type Merger interface {
 	// Init initializes merger params or context, before does merge operation
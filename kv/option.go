@@ -1,13 +1,55 @@
 package kv
 
+import (
+	"github.com/lindb/lindb/kv/table"
+)
+
+// defaultChecksumVerify is the checksum verification mode of default store option
+var defaultChecksumVerify = table.VerifyFooter.String()
+
+// SetDefaultChecksumVerify sets the checksum verification mode(off/footer/full) of default store option
+func SetDefaultChecksumVerify(mode string) error {
+	verifyMode, err := table.ParseVerifyMode(mode)
+	if err != nil {
+		return err
+	}
+	defaultChecksumVerify = verifyMode.String()
+	return nil
+}
+
+// defaultCompression is the compression type of family if not set in family option
+var defaultCompression = table.NoCompression.String()
+
+// SetDefaultCompression sets the compression type(none/snappy/zstd) of family if not set in family option,
+// the old files of exist family are rewritten by compaction job after changing compression type.
+func SetDefaultCompression(name string) error {
+	compression, err := table.ParseCompressionType(name)
+	if err != nil {
+		return err
+	}
+	defaultCompression = compression.String()
+	return nil
+}
+
+// SetTableCacheOption sets the max num. of opened sst files and max total size(bytes) of opened sst files
+// in the reader cache shared by all stores, 0 means unlimited.
+func SetTableCacheOption(maxFiles int, maxSize int64) {
+	table.SetCacheOption(table.CacheOption{
+		MaxFiles: maxFiles,
+		MaxSize:  maxSize,
+	})
+}
+
 // FamilyOption defines config items for family level
 type FamilyOption struct {
-	ID               int    `toml:"id"`
-	Name             string `toml:"name"`
-	CompactThreshold int    `toml:"compactThreshold"` // level 0 compact threshold
-	RollupThreshold  int    `toml:"rollupThreshold"`  // level 0 rollup threshold
-	Merger           string `toml:"merger"`           // merger which need implement Merger interface
-	MaxFileSize      int32  `toml:"maxFileSize"`      // max file size
+	ID                  int    `toml:"id"`
+	Name                string `toml:"name"`
+	CompactThreshold    int    `toml:"compactThreshold"`    // level 0 compact threshold
+	RollupThreshold     int    `toml:"rollupThreshold"`     // level 0 rollup threshold
+	RollupTimeThreshold int    `toml:"rollupTimeThreshold"` // level 0 rollup time threshold(number of seconds)
+	Merger              string `toml:"merger"`              // merger which need implement Merger interface
+	MaxFileSize         int32  `toml:"maxFileSize"`         // max file size
+	Compression         string `toml:"compression"`         // compression type of sst file(none/snappy/zstd), use default if not set
 }
 
 // StoreOption defines config item for store level
@@ -16,13 +58,15 @@ type StoreOption struct {
 	Levels               int    `toml:"levels"`               // num. of levels
 	CompactCheckInterval int    `toml:"compactCheckInterval"` // compact job check interval(number of seconds)
 	RollupCheckInterval  int    `toml:"rollupCheckInterval"`  // rollup job check interval(number of seconds)
+	ChecksumVerify       string `toml:"checksumVerify"`       // checksum verification mode of sst file(off/footer/full)
 }
 
 // DefaultStoreOption builds default store option
 func DefaultStoreOption(path string) StoreOption {
 	return StoreOption{
-		Path:   path,
-		Levels: 2,
+		Path:           path,
+		Levels:         2,
+		ChecksumVerify: defaultChecksumVerify,
 	}
 }
 
@@ -0,0 +1,207 @@
+package kv
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+// for testing
+var (
+	verifyTableFunc       = table.Verify
+	newStoreFunc          = NewStore
+	readStoreManifestFunc = ReadStoreManifest
+)
+
+// CorruptedFile represents the sst file which fails checksum verification
+type CorruptedFile struct {
+	Family     string
+	Level      int
+	FileNumber table.FileNumber
+	Err        error
+}
+
+// ScrubResult represents the result of scrubbing kv store
+type ScrubResult struct {
+	Path      string          // path of kv store
+	Files     int             // num. of verified files
+	Corrupted []CorruptedFile // corrupted files
+	Repaired  bool            // if corrupted files are removed from version and quarantined
+}
+
+// Scrub verifies all checksums of the sst files in store,
+// if repair, removes the corrupted files from family version, then moves them into quarantine directory.
+func (s *store) Scrub(repair bool) (*ScrubResult, error) {
+	result := &ScrubResult{Path: s.option.Path, Repaired: repair}
+	for _, familyName := range s.ListFamilyNames() {
+		f := s.GetFamily(familyName)
+		if f == nil {
+			continue
+		}
+		files, corrupted, err := f.scrub(repair)
+		if err != nil {
+			return nil, err
+		}
+		result.Files += files
+		result.Corrupted = append(result.Corrupted, corrupted...)
+	}
+	return result, nil
+}
+
+// removeCorruptedFiles removes the corrupted files from family versions, then moves them into quarantine directory
+func (s *store) removeCorruptedFiles(corrupted []CorruptedFile) error {
+	families := make(map[string][]CorruptedFile)
+	for _, file := range corrupted {
+		families[file.Family] = append(families[file.Family], file)
+	}
+	for familyName, files := range families {
+		f := s.GetFamily(familyName)
+		if f == nil {
+			return fmt.Errorf("family[%s] not exist in store[%s]", familyName, s.option.Path)
+		}
+		if err := f.removeCorruptedFiles(files); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// quarantineFamilyFile moves the corrupted family file into quarantine directory
+func (s *store) quarantineFamilyFile(name string, fileNumber table.FileNumber) error {
+	return s.cache.Quarantine(name, version.Table(fileNumber))
+}
+
+// scrub verifies all checksums of active files in family, returns num. of verified files and corrupted files,
+// if repair, removes the corrupted files from family version, then moves them into quarantine directory.
+func (f *family) scrub(repair bool) (files int, corrupted []CorruptedFile, err error) {
+	snapshot := f.GetSnapshot()
+	defer snapshot.Close()
+
+	files, corrupted = verifyFamilyFiles(f.name, f.familyPath, snapshot.GetCurrent(), f.store.Option().Levels)
+	if !repair || len(corrupted) == 0 {
+		return files, corrupted, nil
+	}
+	return files, corrupted, f.removeCorruptedFiles(corrupted)
+}
+
+// removeCorruptedFiles removes the corrupted files from family version, then moves them into quarantine directory
+func (f *family) removeCorruptedFiles(corrupted []CorruptedFile) error {
+	editLog := version.NewEditLog(f.ID())
+	for _, file := range corrupted {
+		editLog.Add(version.NewDeleteFile(int32(file.Level), file.FileNumber))
+		// missing file cannot be quarantined, just removes it from family version
+		if !fileutil.Exist(filepath.Join(f.familyPath, version.Table(file.FileNumber))) {
+			continue
+		}
+		if err := f.store.quarantineFamilyFile(f.name, file.FileNumber); err != nil {
+			return fmt.Errorf("quarantine file[%d] of family[%s] error:%w", file.FileNumber, f.name, err)
+		}
+	}
+	if !f.commitEditLog(editLog) {
+		return fmt.Errorf("remove corrupted files from family[%s] version error", f.name)
+	}
+	return nil
+}
+
+// verifyFamilyFiles verifies all checksums of the files in family version, returns num. of verified files and corrupted files.
+func verifyFamilyFiles(familyName, familyPath string, v version.Version, numOfLevels int) (files int, corrupted []CorruptedFile) {
+	for level := 0; level < numOfLevels; level++ {
+		for _, fileMeta := range v.GetFiles(level) {
+			files++
+			fileNumber := fileMeta.GetFileNumber()
+			if err := verifyTableFunc(filepath.Join(familyPath, version.Table(fileNumber))); err != nil {
+				kvLogger.Warn("found corrupted file when scrub family",
+					logger.String("family", familyPath),
+					logger.Int64("file", fileNumber.Int64()), logger.Error(err))
+				corrupted = append(corrupted, CorruptedFile{
+					Family:     familyName,
+					Level:      level,
+					FileNumber: fileNumber,
+					Err:        err,
+				})
+			}
+		}
+	}
+	return files, corrupted
+}
+
+// ScrubStores finds all kv stores under the root path, then verifies all checksums of the sst files in each store.
+// NOTICE: the stores cannot be opened by other process(storage server) when scrubbing.
+func ScrubStores(rootPath string, repair bool) ([]*ScrubResult, error) {
+	var storePaths []string
+	err := filepath.Walk(rootPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+		// kv store must have CURRENT file, OPTIONS file is also used by tsdb database
+		if fileutil.Exist(filepath.Join(path, version.Current)) {
+			storePaths = append(storePaths, path)
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var results []*ScrubResult
+	for _, storePath := range storePaths {
+		result, err := scrubStore(storePath, repair)
+		if err != nil {
+			return results, fmt.Errorf("scrub store[%s] error:%w", storePath, err)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// scrubStore reads the manifest of kv store without opening it, then verifies the files of current versions read-only,
+// opens the store only if repair and found corrupted files, then removes them from versions and quarantines them.
+func scrubStore(storePath string, repair bool) (result *ScrubResult, err error) {
+	manifest, err := readStoreManifestFunc(storePath)
+	if err != nil {
+		return nil, err
+	}
+	familyIDs := make([]int, 0, len(manifest.Families))
+	for familyID := range manifest.Families {
+		familyIDs = append(familyIDs, familyID.Int())
+	}
+	sort.Ints(familyIDs)
+
+	result = &ScrubResult{Path: storePath, Repaired: repair}
+	for _, id := range familyIDs {
+		familyID := version.FamilyID(id)
+		v, ok := manifest.Versions[familyID]
+		if !ok {
+			continue
+		}
+		familyName := manifest.Families[familyID].Name
+		files, corrupted := verifyFamilyFiles(familyName, filepath.Join(storePath, familyName), v, manifest.Option.Levels)
+		result.Files += files
+		result.Corrupted = append(result.Corrupted, corrupted...)
+	}
+	if !repair || len(result.Corrupted) == 0 {
+		return result, nil
+	}
+	s, err := newStoreFunc(filepath.Base(storePath), DefaultStoreOption(storePath))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := s.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+	if err := s.removeCorruptedFiles(result.Corrupted); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -0,0 +1,190 @@
+package kv
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+func TestScrubStores(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+	// store in sub directory, root directory has OPTIONS file of other component(e.g. tsdb database)
+	storePath := filepath.Join(testKVPath, "store")
+	kv, err := NewStore("test_kv", DefaultStoreOption(storePath))
+	assert.NoError(t, err)
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(testKVPath, version.Options), []byte("option"), 0644))
+	f, err := kv.CreateFamily("f", FamilyOption{
+		CompactThreshold: 10,
+		Merger:           mergerStr,
+	})
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		flusher := f.NewFlusher()
+		_ = flusher.Add(1, []byte("test"))
+		_ = flusher.Add(10, []byte("test10"))
+		assert.NoError(t, flusher.Commit())
+	}
+	snapshot := f.GetSnapshot()
+	corruptedFile := snapshot.GetCurrent().GetFiles(0)[0].GetFileNumber()
+	snapshot.Close()
+	assert.NoError(t, kv.Close())
+
+	// case 1: all files are valid
+	results, err := ScrubStores(testKVPath, false)
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, storePath, results[0].Path)
+	assert.Equal(t, 2, results[0].Files)
+	assert.Empty(t, results[0].Corrupted)
+
+	// corrupt the first value of file
+	filePath := filepath.Join(storePath, "f", version.Table(corruptedFile))
+	data, err := ioutil.ReadFile(filePath)
+	assert.NoError(t, err)
+	data[1] ^= 0xff
+	assert.NoError(t, ioutil.WriteFile(filePath, data, 0644))
+
+	// case 2: report corrupted file
+	results, err = ScrubStores(testKVPath, false)
+	assert.NoError(t, err)
+	assert.Len(t, results[0].Corrupted, 1)
+	assert.Equal(t, "f", results[0].Corrupted[0].Family)
+	assert.Equal(t, corruptedFile, results[0].Corrupted[0].FileNumber)
+	assert.True(t, table.IsCorrupted(results[0].Corrupted[0].Err))
+	assert.False(t, results[0].Repaired)
+	assert.True(t, fileutil.Exist(filePath))
+
+	// case 3: repair corrupted file
+	results, err = ScrubStores(testKVPath, true)
+	assert.NoError(t, err)
+	assert.Len(t, results[0].Corrupted, 1)
+	assert.True(t, results[0].Repaired)
+	assert.False(t, fileutil.Exist(filePath))
+	assert.True(t, fileutil.Exist(filepath.Join(storePath, table.QuarantineDir, "f", version.Table(corruptedFile))))
+
+	// case 4: corrupted file removed from version
+	results, err = ScrubStores(testKVPath, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, results[0].Files)
+	assert.Empty(t, results[0].Corrupted)
+}
+
+func TestScrubStores_Fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newStoreFunc = NewStore
+		verifyTableFunc = table.Verify
+		readStoreManifestFunc = ReadStoreManifest
+		_ = fileutil.RemoveDir(testKVPath)
+		ctrl.Finish()
+	}()
+	// case 1: walk path err
+	results, err := ScrubStores(filepath.Join(testKVPath, "not_exist"), false)
+	assert.Error(t, err)
+	assert.Nil(t, results)
+
+	kv, err := NewStore("test_kv", DefaultStoreOption(testKVPath))
+	assert.NoError(t, err)
+	f, err := kv.CreateFamily("f", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+	flusher := f.NewFlusher()
+	_ = flusher.Add(1, []byte("test"))
+	assert.NoError(t, flusher.Commit())
+	assert.NoError(t, kv.Close())
+	// case 2: read manifest err
+	readStoreManifestFunc = func(storePath string) (*StoreManifest, error) {
+		return nil, fmt.Errorf("err")
+	}
+	results, err = ScrubStores(testKVPath, false)
+	assert.Error(t, err)
+	assert.Empty(t, results)
+	readStoreManifestFunc = ReadStoreManifest
+	// case 3: store isn't opened when verifying
+	newStoreFunc = func(name string, option StoreOption) (Store, error) {
+		assert.Fail(t, "store cannot be opened when verifying")
+		return nil, fmt.Errorf("err")
+	}
+	verifyTableFunc = func(path string) error {
+		return table.ErrChecksumMismatch
+	}
+	results, err = ScrubStores(testKVPath, false)
+	assert.NoError(t, err)
+	assert.Len(t, results[0].Corrupted, 1)
+	// case 4: open store err when repairing
+	newStoreFunc = func(name string, option StoreOption) (Store, error) {
+		return nil, fmt.Errorf("err")
+	}
+	results, err = ScrubStores(testKVPath, true)
+	assert.Error(t, err)
+	assert.Empty(t, results)
+	// case 5: close store err
+	mockStore := NewMockStore(ctrl)
+	newStoreFunc = func(name string, option StoreOption) (Store, error) {
+		return mockStore, nil
+	}
+	mockStore.EXPECT().removeCorruptedFiles(gomock.Len(1)).Return(nil)
+	mockStore.EXPECT().Close().Return(fmt.Errorf("err"))
+	results, err = ScrubStores(testKVPath, true)
+	assert.Error(t, err)
+	assert.Empty(t, results)
+	// case 6: remove corrupted files err
+	mockStore.EXPECT().removeCorruptedFiles(gomock.Any()).Return(fmt.Errorf("err"))
+	mockStore.EXPECT().Close().Return(nil)
+	results, err = ScrubStores(testKVPath, true)
+	assert.Error(t, err)
+	assert.Empty(t, results)
+	// case 7: family not exist
+	newStoreFunc = NewStore
+	kv, err = NewStore("test_kv", DefaultStoreOption(testKVPath))
+	assert.NoError(t, err)
+	err = kv.(*store).removeCorruptedFiles([]CorruptedFile{{Family: "not_exist"}})
+	assert.Error(t, err)
+	assert.NoError(t, kv.Close())
+}
+
+func TestFamily_scrub_repair_fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		verifyTableFunc = table.Verify
+		_ = fileutil.RemoveDir(testKVPath)
+		ctrl.Finish()
+	}()
+	kv, err := NewStore("test_kv", DefaultStoreOption(testKVPath))
+	assert.NoError(t, err)
+	defer func() {
+		_ = kv.Close()
+	}()
+	f, err := kv.CreateFamily("f", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+	flusher := f.NewFlusher()
+	_ = flusher.Add(1, []byte("test"))
+	assert.NoError(t, flusher.Commit())
+
+	verifyTableFunc = func(path string) error {
+		return table.ErrChecksumMismatch
+	}
+	// case 1: quarantine file err
+	store := NewMockStore(ctrl)
+	store.EXPECT().Option().Return(kv.Option()).AnyTimes()
+	store.EXPECT().quarantineFamilyFile(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	f1 := f.(*family)
+	f1.store = store
+	_, corrupted, err := f1.scrub(true)
+	assert.Error(t, err)
+	assert.Len(t, corrupted, 1)
+	// case 2: commit edit log err
+	store.EXPECT().quarantineFamilyFile(gomock.Any(), gomock.Any()).Return(nil)
+	store.EXPECT().commitFamilyEditLog(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	_, _, err = f1.scrub(true)
+	assert.Error(t, err)
+}
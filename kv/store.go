@@ -9,6 +9,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/lindb/roaring"
+
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
 	"github.com/lindb/lindb/pkg/fileutil"
@@ -35,16 +37,37 @@ var (
 // Store is kv store, supporting column family, but is different from other LSM implementation.
 // Current implementation doesn't contain memory table write logic.
 type Store interface {
+	// Name returns the store's name
+	Name() string
 	// CreateFamily create/load column family.
 	CreateFamily(familyName string, option FamilyOption) (Family, error)
 	// GetFamily gets family based on name, return nil if not exist.
 	GetFamily(familyName string) Family
 	// ListFamilyNames returns the all family's name
 	ListFamilyNames() []string
+	// DropFamily drops the family by name, removes family's option, version and data files.
+	DropFamily(familyName string) error
 	// Option returns the store configuration options
 	Option() StoreOption
-	// RegisterRollup registers the rollup source/target relation
-	RegisterRollup(interval timeutil.Interval, rollup Rollup)
+	// RegisterRollup registers the rollup source/target relation,
+	// the level0 files of families are rolled up to the target interval after flushing.
+	RegisterRollup(interval timeutil.Interval, newRollup NewRollupFunc)
+	// RegisterTombstone registers the tombstone of store, the deleted data is purged when compaction.
+	RegisterTombstone(tombstone Tombstone)
+	// Purge submits purge compaction job of all families, which rewrites all level0 files and
+	// the files including the keys, so that the deleted data of keys is purged.
+	Purge(keys *roaring.Bitmap)
+	// IsPurging returns if any family has pending or running purge compaction job of the keys.
+	IsPurging(keys *roaring.Bitmap) bool
+	// Scrub verifies all checksums of the sst files in store,
+	// if repair, removes the corrupted files from family version, then moves them into quarantine directory.
+	Scrub(repair bool) (*ScrubResult, error)
+	// Backup links or copies the files of current versions into target path, then writes store info and manifest file,
+	// so that target path can be opened as a kv store which includes the data of backup time.
+	Backup(targetPath string) error
+	// Compact triggers full compaction of family by name in background, which compacts all level0 files
+	// regardless of compact threshold, triggers all families if family name is empty.
+	Compact(familyName string)
 	// Close closes store, then release some resource
 	Close() error
 
@@ -57,8 +80,16 @@ type Store interface {
 	commitFamilyEditLog(name string, editLog version.EditLog) error
 	// evictFamilyFile evicts family file reader from cache
 	evictFamilyFile(name string, fileNumber table.FileNumber)
+	// quarantineFamilyFile moves the corrupted family file into quarantine directory
+	quarantineFamilyFile(name string, fileNumber table.FileNumber) error
+	// removeCorruptedFiles removes the corrupted files from family versions, then moves them into quarantine directory
+	removeCorruptedFiles(corrupted []CorruptedFile) error
 	// getRollup returns the rollup relation by interval
-	getRollup(interval timeutil.Interval) (Rollup, bool)
+	getRollup(interval timeutil.Interval) (NewRollupFunc, bool)
+	// getRollupInterval returns the smallest target interval of rollup relations
+	getRollupInterval() (timeutil.Interval, bool)
+	// getTombstone returns the tombstone of store, returns nil if not registered
+	getTombstone() Tombstone
 }
 
 // store implements Store interface
@@ -74,10 +105,12 @@ type store struct {
 	// RWMutex for accessing family
 	rwMutex sync.RWMutex
 
-	storeInfo *storeInfo
-	cache     table.Cache
+	storeInfo        *storeInfo
+	cache            table.Cache
+	compactScheduler CompactScheduler
 
-	rollupRelations map[timeutil.Interval]Rollup // save target kv store for rollup job
+	rollupRelations map[timeutil.Interval]NewRollupFunc // save target kv store for rollup job
+	tombstone       Tombstone                           // deleted data which is purged when compaction
 
 	ctx    context.Context
 	cancel context.CancelFunc
@@ -85,6 +118,10 @@ type store struct {
 
 // NewStore new store instance, need recover data if store existent
 func NewStore(name string, option StoreOption) (s Store, err error) {
+	verifyMode, err := table.ParseVerifyMode(option.ChecksumVerify)
+	if err != nil {
+		return nil, err
+	}
 	var info *storeInfo
 	var isCreate bool
 	if fileutil.Exist(option.Path) {
@@ -110,13 +147,14 @@ func NewStore(name string, option StoreOption) (s Store, err error) {
 
 	ctx, cancel := context.WithCancel(context.Background())
 	store1 := &store{
-		name:      name,
-		option:    option,
-		lock:      lock,
-		families:  make(map[string]Family),
-		storeInfo: info,
-		ctx:       ctx,
-		cancel:    cancel,
+		name:             name,
+		option:           option,
+		lock:             lock,
+		families:         make(map[string]Family),
+		storeInfo:        info,
+		compactScheduler: defaultCompactScheduler,
+		ctx:              ctx,
+		cancel:           cancel,
 	}
 
 	defer func() {
@@ -133,8 +171,8 @@ func NewStore(name string, option StoreOption) (s Store, err error) {
 		store1.deleteFamilyObsoleteFiles()
 	}()
 
-	// build store reader cache
-	store1.cache = table.NewCache(store1.option.Path)
+	// build store reader cache, which is bounded by the limit shared by all stores
+	store1.cache = table.NewCache(store1.option.Path, verifyMode)
 	// init version set
 	store1.versions = newVersionSetFunc(store1.option.Path, store1.cache, store1.option.Levels)
 
@@ -162,8 +200,9 @@ func NewStore(name string, option StoreOption) (s Store, err error) {
 		return nil, fmt.Errorf("recover store version set error:%s", err)
 	}
 
-	// schedule compact job
+	// schedule compact/rollup job
 	store1.scheduleCompactJob()
+	store1.scheduleRollupJob()
 	return store1, nil
 }
 
@@ -173,6 +212,10 @@ func (s *store) CreateFamily(familyName string, option FamilyOption) (family Fam
 	family, ok := s.families[familyName]
 	s.rwMutex.RUnlock()
 	if ok {
+		// change compression type of exist family if set in option
+		if err := s.updateCompression(family, option.Compression); err != nil {
+			return nil, err
+		}
 		// return exist family
 		return family, nil
 	}
@@ -204,6 +247,39 @@ func (s *store) CreateFamily(familyName string, option FamilyOption) (family Fam
 	return family, nil
 }
 
+// updateCompression updates the compression type of exist family, then saves it into store info,
+// new sst files use new compression type, old files are rewritten by compaction job.
+func (s *store) updateCompression(family Family, compressionName string) error {
+	if len(compressionName) == 0 {
+		// keep compression type of family if not set
+		return nil
+	}
+	compression, err := table.ParseCompressionType(compressionName)
+	if err != nil {
+		return err
+	}
+	if compression == family.getCompression() {
+		return nil
+	}
+	s.rwMutex.Lock()
+	defer s.rwMutex.Unlock()
+
+	option := s.storeInfo.Families[family.Name()]
+	oldCompression := option.Compression
+	option.Compression = compression.String()
+	s.storeInfo.Families[family.Name()] = option
+	if err := s.dumpStoreInfo(); err != nil {
+		// if dump store info error rollback family option
+		option.Compression = oldCompression
+		s.storeInfo.Families[family.Name()] = option
+		return err
+	}
+	family.setCompression(compression)
+	kvLogger.Info("change compression type of family",
+		logger.String("family", family.familyInfo()), logger.String("compression", compression.String()))
+	return nil
+}
+
 // GetFamily gets family based on name, return nil if not exist.
 func (s *store) GetFamily(familyName string) Family {
 	s.rwMutex.RLock()
@@ -212,6 +288,11 @@ func (s *store) GetFamily(familyName string) Family {
 	return family
 }
 
+// Name returns the store's name
+func (s *store) Name() string {
+	return s.name
+}
+
 // ListFamilyNames returns the all family's name
 func (s *store) ListFamilyNames() []string {
 	var result []string
@@ -223,18 +304,61 @@ func (s *store) ListFamilyNames() []string {
 	return result
 }
 
+// DropFamily drops the family by name, removes family's option, version and data files.
+func (s *store) DropFamily(familyName string) error {
+	s.rwMutex.Lock()
+	defer s.rwMutex.Unlock()
+
+	family, ok := s.families[familyName]
+	familyOption, hasOption := s.storeInfo.Families[familyName]
+	if !ok && !hasOption {
+		return nil
+	}
+	if ok {
+		// evict all file readers of family from cache
+		snapshot := family.GetSnapshot()
+		for _, file := range snapshot.GetCurrent().GetAllFiles() {
+			s.evictFamilyFile(familyName, file.GetFileNumber())
+		}
+		snapshot.Close()
+		// remove waiting compaction job and statistics of family
+		s.compactScheduler.cancel(family)
+		deleteCompactionMetrics(family.familyInfo())
+		// 1. delete family version first, make sure manifest file not includes family's edit logs
+		if err := s.versions.DeleteFamilyVersion(familyName); err != nil {
+			return err
+		}
+		delete(s.families, familyName)
+	}
+	if hasOption {
+		// 2. remove family option from store info
+		delete(s.storeInfo.Families, familyName)
+		if err := s.dumpStoreInfo(); err != nil {
+			s.storeInfo.Families[familyName] = familyOption
+			return err
+		}
+	}
+	// 3. remove family's data files
+	if err := removeDirFunc(filepath.Join(s.option.Path, familyName)); err != nil {
+		return fmt.Errorf("remove family path error:%s", err)
+	}
+	kvLogger.Info("drop family success",
+		logger.String("store", s.option.Path), logger.String("family", familyName))
+	return nil
+}
+
 // Option returns the store configuration options
 func (s *store) Option() StoreOption {
 	return s.option
 }
 
 // RegisterRollup registers the rollup source/target relation
-func (s *store) RegisterRollup(interval timeutil.Interval, rollup Rollup) {
+func (s *store) RegisterRollup(interval timeutil.Interval, newRollup NewRollupFunc) {
 	s.rwMutex.Lock()
 	defer s.rwMutex.Unlock()
 
 	if s.rollupRelations == nil {
-		s.rollupRelations = make(map[timeutil.Interval]Rollup)
+		s.rollupRelations = make(map[timeutil.Interval]NewRollupFunc)
 	}
 	_, ok := s.rollupRelations[interval]
 	if ok {
@@ -243,12 +367,54 @@ func (s *store) RegisterRollup(interval timeutil.Interval, rollup Rollup) {
 			logger.Any("interval", interval))
 		return
 	}
-	s.rollupRelations[interval] = rollup
+	s.rollupRelations[interval] = newRollup
+}
+
+// RegisterTombstone registers the tombstone of store, the deleted data is purged when compaction.
+func (s *store) RegisterTombstone(tombstone Tombstone) {
+	s.rwMutex.Lock()
+	defer s.rwMutex.Unlock()
+
+	s.tombstone = tombstone
+}
+
+// getTombstone returns the tombstone of store, returns nil if not registered
+func (s *store) getTombstone() Tombstone {
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+
+	return s.tombstone
+}
+
+// Purge submits purge compaction job of all families, which rewrites all level0 files and
+// the files including the keys, so that the deleted data of keys is purged.
+func (s *store) Purge(keys *roaring.Bitmap) {
+	if keys == nil || keys.IsEmpty() {
+		return
+	}
+	for _, family := range s.getFamilies() {
+		family.purge(keys)
+	}
+}
+
+// IsPurging returns if any family has pending or running purge compaction job of the keys.
+func (s *store) IsPurging(keys *roaring.Bitmap) bool {
+	for _, family := range s.getFamilies() {
+		if family.isPurging(keys) {
+			return true
+		}
+	}
+	return false
 }
 
 // Close closes store, then release some resource
 func (s *store) Close() error {
 	//FIXME stone1100 need if has background job doing(family compact/flush etc.)
+	for _, family := range s.getFamilies() {
+		// remove waiting compaction job and statistics of family
+		s.compactScheduler.cancel(family)
+		deleteCompactionMetrics(family.familyInfo())
+	}
 	if err := s.cache.Close(); err != nil {
 		kvLogger.Error("close store cache error", logger.String("store", s.option.Path), logger.Error(err))
 	}
@@ -266,9 +432,26 @@ func (s *store) evictFamilyFile(name string, fileNumber table.FileNumber) {
 }
 
 // getRollup returns the rollup relation by interval
-func (s *store) getRollup(interval timeutil.Interval) (Rollup, bool) {
-	rollup, ok := s.rollupRelations[interval]
-	return rollup, ok
+func (s *store) getRollup(interval timeutil.Interval) (NewRollupFunc, bool) {
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+
+	newRollup, ok := s.rollupRelations[interval]
+	return newRollup, ok
+}
+
+// getRollupInterval returns the smallest target interval of rollup relations
+func (s *store) getRollupInterval() (timeutil.Interval, bool) {
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+
+	var result timeutil.Interval
+	for interval := range s.rollupRelations {
+		if result == 0 || interval < result {
+			result = interval
+		}
+	}
+	return result, result > 0
 }
 
 // createFamilyVersion creates family version using family name and family id,
@@ -317,23 +500,66 @@ func (s *store) scheduleCompactJob() {
 	}()
 }
 
+// scheduleRollupJob schedules a rollup background job, if has rollup relation registered
+func (s *store) scheduleRollupJob() {
+	interval := defaultRollupCheckInterval
+	if s.option.RollupCheckInterval > 0 {
+		interval = s.option.RollupCheckInterval
+	}
+	ticker := time.NewTicker(time.Duration(interval) * time.Second)
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				s.rollup()
+			case <-s.ctx.Done():
+				ticker.Stop()
+				return
+			}
+		}
+	}()
+}
+
 // compact checks if family need do compact, if need, does compaction job
 func (s *store) compact() {
-	s.rwMutex.RLock()
-	families := make([]Family, len(s.families))
-	i := 0
-	for _, family := range s.families {
-		families[i] = family
-		i++
-	}
-	s.rwMutex.RUnlock()
-	for _, family := range families {
+	for _, family := range s.getFamilies() {
 		if family.needCompat() {
 			family.compact()
 		}
 	}
 }
 
+// Compact triggers full compaction of family by name in background, which compacts all level0 files
+// regardless of compact threshold, triggers all families if family name is empty.
+func (s *store) Compact(familyName string) {
+	for _, family := range s.getFamilies() {
+		if familyName == "" || family.Name() == familyName {
+			family.fullCompact()
+		}
+	}
+}
+
+// rollup checks if family need do rollup, if need, submits rollup job into compaction scheduler
+func (s *store) rollup() {
+	for _, family := range s.getFamilies() {
+		if family.needRollup() {
+			family.rollup()
+		}
+	}
+}
+
+// getFamilies returns all families of store
+func (s *store) getFamilies() []Family {
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+
+	families := make([]Family, 0, len(s.families))
+	for _, family := range s.families {
+		families = append(families, family)
+	}
+	return families
+}
+
 // deleteFamilyObsoleteFiles deletes the all families obsolete files when init kv store
 func (s *store) deleteFamilyObsoleteFiles() {
 	for _, family := range s.families {
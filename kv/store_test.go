@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/kv/table"
@@ -16,6 +17,7 @@ import (
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/lockers"
 	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 var testKVPath = "./test_data"
@@ -123,6 +125,43 @@ func TestStore_New(t *testing.T) {
 	assert.Nil(t, kv)
 }
 
+func TestStore_ChecksumVerify(t *testing.T) {
+	defer func() {
+		_ = SetDefaultChecksumVerify("footer")
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+	assert.Equal(t, "footer", DefaultStoreOption(testKVPath).ChecksumVerify)
+	assert.Error(t, SetDefaultChecksumVerify("unknown"))
+	assert.NoError(t, SetDefaultChecksumVerify("full"))
+	assert.Equal(t, "full", DefaultStoreOption(testKVPath).ChecksumVerify)
+
+	option := DefaultStoreOption(testKVPath)
+	option.ChecksumVerify = "unknown"
+	kv, err := NewStore("test_kv", option)
+	assert.Error(t, err)
+	assert.Nil(t, kv)
+}
+
+func TestStore_DefaultCompression(t *testing.T) {
+	defer func() {
+		_ = SetDefaultCompression("none")
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+	assert.Error(t, SetDefaultCompression("unknown"))
+	assert.NoError(t, SetDefaultCompression("snappy"))
+
+	kv, err := NewStore("test_kv", DefaultStoreOption(testKVPath))
+	assert.NoError(t, err)
+	// use default compression if not set in family option
+	f1, err := kv.CreateFamily("f1", FamilyOption{Merger: "mockMerger"})
+	assert.NoError(t, err)
+	assert.Equal(t, table.SnappyCompression, f1.getCompression())
+	f2, err := kv.CreateFamily("f2", FamilyOption{Merger: "mockMerger", Compression: "zstd"})
+	assert.NoError(t, err)
+	assert.Equal(t, table.ZstdCompression, f2.getCompression())
+	assert.NoError(t, kv.Close())
+}
+
 func TestStore_CreateFamily(t *testing.T) {
 	option := DefaultStoreOption(testKVPath)
 	defer func() {
@@ -169,6 +208,69 @@ func TestStore_CreateFamily(t *testing.T) {
 	names := kv.ListFamilyNames()
 	assert.Len(t, names, 1)
 	assert.Equal(t, "f", names[0])
+	// case 8: change compression type of exist family err
+	f2, err = kv.CreateFamily("f", FamilyOption{Merger: mergerStr, Compression: "lz4"})
+	assert.Error(t, err)
+	assert.Nil(t, f2)
+	encodeTomlFunc = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	f2, err = kv.CreateFamily("f", FamilyOption{Merger: mergerStr, Compression: "snappy"})
+	assert.Error(t, err)
+	assert.Nil(t, f2)
+	assert.Equal(t, table.NoCompression, f1.getCompression())
+	assert.Equal(t, "", kv.(*store).storeInfo.Families["f"].Compression)
+	// case 9: change compression type of exist family
+	encodeTomlFunc = ltoml.EncodeToml
+	f2, err = kv.CreateFamily("f", FamilyOption{Merger: mergerStr, Compression: "snappy"})
+	assert.NoError(t, err)
+	assert.Equal(t, f1, f2)
+	assert.Equal(t, table.SnappyCompression, f1.getCompression())
+	assert.Equal(t, "snappy", kv.(*store).storeInfo.Families["f"].Compression)
+}
+
+func TestStore_DropFamily(t *testing.T) {
+	option := DefaultStoreOption(testKVPath)
+	defer func() {
+		encodeTomlFunc = ltoml.EncodeToml
+		removeDirFunc = fileutil.RemoveDir
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+
+	kv, err := NewStore("test_kv", option)
+	assert.NoError(t, err, "cannot create kv store")
+	_, err = kv.CreateFamily("f", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+	_, err = kv.CreateFamily("f2", FamilyOption{Merger: mergerStr})
+	assert.NoError(t, err)
+
+	// case 1: drop not exist family
+	assert.NoError(t, kv.DropFamily("f3"))
+	// case 2: dump store info err
+	encodeTomlFunc = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, kv.DropFamily("f2"))
+	encodeTomlFunc = ltoml.EncodeToml
+	// case 3: remove family path err
+	removeDirFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, kv.DropFamily("f2"))
+	assert.Nil(t, kv.GetFamily("f2"))
+	removeDirFunc = fileutil.RemoveDir
+	// case 4: drop family
+	assert.NoError(t, kv.DropFamily("f"))
+	assert.Nil(t, kv.GetFamily("f"))
+	assert.False(t, fileutil.Exist(filepath.Join(testKVPath, "f")))
+	assert.Empty(t, kv.ListFamilyNames())
+	assert.NoError(t, kv.Close())
+
+	// reopen store without dropped families
+	kv, err = NewStore("test_kv", option)
+	assert.NoError(t, err)
+	assert.Empty(t, kv.ListFamilyNames())
+	assert.NoError(t, kv.Close())
 }
 
 func TestStore_deleteObsoleteFiles(t *testing.T) {
@@ -203,7 +305,10 @@ func TestStore_deleteObsoleteFiles(t *testing.T) {
 func TestStore_Compact(t *testing.T) {
 	option := DefaultStoreOption(testKVPath)
 	option.CompactCheckInterval = 1
+	// reader cache only keeps one file
+	SetTableCacheOption(1, 1024)
 	defer func() {
+		SetTableCacheOption(512, 8*1024*1024*1024)
 		_ = fileutil.RemoveDir(testKVPath)
 	}()
 
@@ -239,6 +344,42 @@ func TestStore_Compact(t *testing.T) {
 	snapshot.Close()
 }
 
+func TestStore_FullCompact(t *testing.T) {
+	option := DefaultStoreOption(testKVPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+
+	kv, err := NewStore("test_kv", option)
+	assert.NoError(t, err)
+	defer func() {
+		_ = kv.Close()
+	}()
+	f1, err := kv.CreateFamily("f", FamilyOption{
+		CompactThreshold: 10,
+		Merger:           mergerStr,
+	})
+	assert.NoError(t, err)
+	for i := 0; i < 2; i++ {
+		flusher := f1.NewFlusher()
+		_ = flusher.Add(1, []byte("test"))
+		assert.NoError(t, flusher.Commit())
+	}
+	// ignore not exist family
+	kv.Compact("not_exist")
+	kv.Compact("f")
+	waitCompactScheduler(defaultCompactScheduler)
+
+	snapshot := f1.GetSnapshot()
+	assert.Equal(t, 0, snapshot.GetCurrent().NumberOfFilesInLevel(0))
+	readers, err := snapshot.FindReaders(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(readers))
+	value, _ := readers[0].Get(1)
+	assert.Equal(t, []byte("testtest"), value)
+	snapshot.Close()
+}
+
 func TestStore_Close(t *testing.T) {
 	option := DefaultStoreOption(testKVPath)
 	option.CompactCheckInterval = 1
@@ -272,10 +413,82 @@ func TestStore_RegisterRollup(t *testing.T) {
 
 	kv, err := NewStore("test_kv", option)
 	assert.NoError(t, err)
+	_, ok := kv.getRollupInterval()
+	assert.False(t, ok)
 	rollup := NewMockRollup(ctrl)
-	kv.RegisterRollup(10, rollup)
-	kv.RegisterRollup(10, rollup) // reject
-	rollup2, ok := kv.getRollup(10)
+	newRollup := func(sourceFamilyName string) (Rollup, error) {
+		return rollup, nil
+	}
+	kv.RegisterRollup(100, newRollup)
+	kv.RegisterRollup(10, newRollup)
+	kv.RegisterRollup(10, func(sourceFamilyName string) (Rollup, error) {
+		return nil, fmt.Errorf("err")
+	}) // reject
+	newRollup2, ok := kv.getRollup(10)
 	assert.True(t, ok)
+	rollup2, err := newRollup2("family")
+	assert.NoError(t, err)
 	assert.Equal(t, rollup, rollup2)
+	interval, ok := kv.getRollupInterval()
+	assert.True(t, ok)
+	assert.Equal(t, timeutil.Interval(10), interval)
+}
+
+func TestStore_Purge(t *testing.T) {
+	option := DefaultStoreOption(testKVPath)
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testKVPath)
+		ctrl.Finish()
+	}()
+
+	kv, err := NewStore("test_kv", option)
+	assert.NoError(t, err)
+	kv1 := kv.(*store)
+	assert.Nil(t, kv1.getTombstone())
+	tombstone := NewMockTombstone(ctrl)
+	kv.RegisterTombstone(tombstone)
+	assert.Equal(t, tombstone, kv1.getTombstone())
+
+	family := NewMockFamily(ctrl)
+	kv1.families["f"] = family
+	// empty keys, not purge
+	kv.Purge(nil)
+	kv.Purge(roaring.New())
+	family.EXPECT().purge(roaring.BitmapOf(1, 2))
+	kv.Purge(roaring.BitmapOf(1, 2))
+	// check if purging
+	family.EXPECT().isPurging(roaring.BitmapOf(1)).Return(true)
+	assert.True(t, kv.IsPurging(roaring.BitmapOf(1)))
+	family.EXPECT().isPurging(roaring.BitmapOf(1)).Return(false)
+	assert.False(t, kv.IsPurging(roaring.BitmapOf(1)))
+	delete(kv1.families, "f")
+	err = kv.Close()
+	assert.NoError(t, err)
+}
+
+func TestStore_rollup(t *testing.T) {
+	option := DefaultStoreOption(testKVPath)
+	option.RollupCheckInterval = 1
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testKVPath)
+		ctrl.Finish()
+	}()
+
+	kv, err := NewStore("test_kv", option)
+	assert.NoError(t, err)
+	kv1 := kv.(*store)
+	family := NewMockFamily(ctrl)
+	kv1.families["f"] = family
+	family.EXPECT().needRollup().Return(false)
+	kv1.rollup()
+	family.EXPECT().needRollup().Return(true)
+	family.EXPECT().rollup()
+	kv1.rollup()
+	delete(kv1.families, "f")
+	// wait schedule rollup job
+	time.Sleep(time.Second + 100*time.Millisecond)
+	err = kv.Close()
+	assert.NoError(t, err)
 }
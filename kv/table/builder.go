@@ -3,6 +3,7 @@ package table
 import (
 	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 
 	"github.com/lindb/roaring"
 
@@ -41,6 +42,8 @@ type Builder interface {
 	Size() int32
 	// Count returns the number of k/v pairs contained in the store
 	Count() uint64
+	// Compression returns the compression type of values in the store
+	Compression() CompressionType
 	// Abandon abandons current store build for some reason
 	Abandon() error
 	// Close closes sst file write buffer
@@ -49,10 +52,11 @@ type Builder interface {
 
 // storeBuilder builds store file
 type storeBuilder struct {
-	fileNumber FileNumber
-	fileName   string
-	writer     bufioutil.BufioWriter
-	offset     *encoding.FixedOffsetEncoder
+	fileNumber  FileNumber
+	fileName    string
+	writer      bufioutil.BufioWriter
+	offset      *encoding.FixedOffsetEncoder
+	compression CompressionType
 
 	// see paper of roaring bitmap: https://arxiv.org/pdf/1603.06549.pdf
 	keys   *roaring.Bitmap
@@ -62,19 +66,21 @@ type storeBuilder struct {
 	first bool
 }
 
-// NewStoreBuilder creates store builder instance for building store file
-func NewStoreBuilder(fileNumber FileNumber, fileName string) (Builder, error) {
+// NewStoreBuilder creates store builder instance for building store file,
+// values are compressed by compression type, which is recorded in the footer of store file.
+func NewStoreBuilder(fileNumber FileNumber, fileName string, compression CompressionType) (Builder, error) {
 	writer, err := newBufioWriterFunc(fileName)
 	if err != nil {
 		return nil, fmt.Errorf("create file write for store builder error:%s", err)
 	}
 	return &storeBuilder{
-		fileNumber: fileNumber,
-		fileName:   fileName,
-		keys:       roaring.New(),
-		writer:     writer,
-		first:      true,
-		offset:     encoding.NewFixedOffsetEncoder(),
+		fileNumber:  fileNumber,
+		fileName:    fileName,
+		keys:        roaring.New(),
+		writer:      writer,
+		first:       true,
+		offset:      encoding.NewFixedOffsetEncoder(),
+		compression: compression,
 	}, nil
 }
 
@@ -94,7 +100,7 @@ func (b *storeBuilder) Add(key uint32, value []byte) error {
 
 	// get write offset
 	offset := b.writer.Size()
-	if _, err := b.writer.Write(value); err != nil {
+	if _, err := b.writer.Write(withChecksum(b.compression.compress(value))); err != nil {
 		return fmt.Errorf("write data into store file error:%s", err)
 	}
 	// add offset into offset buffer
@@ -132,6 +138,11 @@ func (b *storeBuilder) Count() uint64 {
 	return b.keys.GetCardinality()
 }
 
+// Compression returns the compression type of values in the store
+func (b *storeBuilder) Compression() CompressionType {
+	return b.compression
+}
+
 // Abandon abandons current store build for some reason, for example compaction job fail or memory store dump error
 func (b *storeBuilder) Abandon() error {
 	return b.writer.Close()
@@ -158,12 +169,15 @@ func (b *storeBuilder) Close() error {
 		return err
 	}
 
-	// for file footer for offsets/keys index, length=1+4+4+8
-	var buf [17]byte
+	// for file footer for offsets/keys index, length=4+4+4+1+1+8
+	var buf [22]byte
 	binary.LittleEndian.PutUint32(buf[:4], uint32(posOfOffset))
 	binary.LittleEndian.PutUint32(buf[4:8], uint32(posOfKeys))
-	buf[8] = version0
-	binary.LittleEndian.PutUint64(buf[9:], magicNumberOffsetFile)
+	// checksum of index blocks
+	binary.LittleEndian.PutUint32(buf[8:12], crc32.Update(crc32.ChecksumIEEE(offset), crc32.IEEETable, keys))
+	buf[12] = byte(b.compression)
+	buf[13] = version2
+	binary.LittleEndian.PutUint64(buf[14:], magicNumberOffsetFile)
 	if _, err = b.writer.Write(buf[:]); err != nil {
 		return err
 	}
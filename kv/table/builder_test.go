@@ -33,7 +33,7 @@ func TestStoreBuilder_magicNumber(t *testing.T) {
 
 func TestStoreBuilder_BuildStore(t *testing.T) {
 	_ = fileutil.MkDirIfNotExist(testKVPath)
-	var builder, err = NewStoreBuilder(10, testKVPath+"/000010.sst")
+	var builder, err = NewStoreBuilder(10, testKVPath+"/000010.sst", NoCompression)
 	defer func() {
 		_ = os.RemoveAll(testKVPath)
 		_ = builder.Close()
@@ -71,7 +71,7 @@ func TestStoreBuilder_Build_Err(t *testing.T) {
 	newBufioWriterFunc = func(fileName string) (bufioutil.BufioWriter, error) {
 		return writer, nil
 	}
-	builder, err := NewStoreBuilder(10, testKVPath+"/000200.sst")
+	builder, err := NewStoreBuilder(10, testKVPath+"/000200.sst", NoCompression)
 	assert.NoError(t, err)
 	writer.EXPECT().Size().Return(int64(10)).AnyTimes()
 
@@ -83,7 +83,7 @@ func TestStoreBuilder_Build_Err(t *testing.T) {
 	err = builder.Close()
 	assert.Equal(t, ErrEmptyKeys, err)
 	// case 3: close write offset err
-	writer.EXPECT().Write([]byte{1, 2, 3}).Return(10, nil)
+	writer.EXPECT().Write(withChecksum([]byte{1, 2, 3})).Return(10, nil)
 	writer.EXPECT().Write(gomock.Any()).Return(0, fmt.Errorf("err"))
 	err = builder.Add(10, []byte{1, 2, 3})
 	assert.NoError(t, err)
@@ -111,7 +111,7 @@ func TestStoreBuilder_Build_Err(t *testing.T) {
 	newBufioWriterFunc = func(fileName string) (bufioutil.BufioWriter, error) {
 		return nil, fmt.Errorf("err")
 	}
-	builder, err = NewStoreBuilder(10, testKVPath+"/000200.sst")
+	builder, err = NewStoreBuilder(10, testKVPath+"/000200.sst", NoCompression)
 	assert.Error(t, err)
 	assert.Nil(t, builder)
 }
@@ -121,7 +121,7 @@ func TestStoreBuilder_Abandon(t *testing.T) {
 	defer func() {
 		_ = os.RemoveAll(testKVPath)
 	}()
-	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst")
+	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst", NoCompression)
 	assert.NoError(t, err)
 	_ = builder.Add(1, []byte("test"))
 	err = builder.Abandon()
@@ -1,92 +1,403 @@
 package table
 
 import (
+	"container/list"
+	"fmt"
+	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lindb/lindb/monitoring"
+	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 )
 
-//FIXME store100 using lru cache?????
-
 //go:generate mockgen -source ./cache.go -destination=./cache_mock.go -package table
 
 // for test
 var (
 	newMMapStoreReaderFunc = newMMapStoreReader
+	renameFunc             = os.Rename
+	mkDirIfNotExistFunc    = fileutil.MkDirIfNotExist
+	closeWaitTimeout       = 30 * time.Second
+)
+
+var (
+	cacheHits = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kv_table_cache_hits",
+			Help: "Number of hits of table reader cache.",
+		},
+	)
+	cacheMisses = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kv_table_cache_misses",
+			Help: "Number of misses of table reader cache.",
+		},
+	)
+	cacheEvictions = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kv_table_cache_evictions",
+			Help: "Number of table readers evicted by table reader cache.",
+		},
+	)
+	quarantinedFiles = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "kv_table_quarantined_files",
+			Help: "Number of corrupted sst files moved into quarantine directory.",
+		},
+	)
 )
 
+func init() {
+	monitoring.StorageRegistry.MustRegister(cacheHits, cacheMisses, cacheEvictions, quarantinedFiles)
+}
+
+// QuarantineDir represents the directory of corrupted files under store path
+const QuarantineDir = "quarantine"
+
+const (
+	defaultCacheMaxFiles = 512
+	defaultCacheMaxSize  = int64(8 * 1024 * 1024 * 1024)
+)
+
+// defaultLRU is the lru list shared by the reader caches of all stores
+var defaultLRU = newSharedLRU(CacheOption{MaxFiles: defaultCacheMaxFiles, MaxSize: defaultCacheMaxSize})
+
+// SetCacheOption sets the limit of the lru list shared by the reader caches of all stores,
+// evicts the readers which are not referenced if the cache is full after changing.
+func SetCacheOption(option CacheOption) {
+	defaultLRU.setOption(option)
+}
+
+// CacheOption represents the options of table reader cache
+type CacheOption struct {
+	MaxFiles int   // max num. of opened files of all stores, 0 means unlimited
+	MaxSize  int64 // max total length of opened files of all stores, 0 means unlimited
+}
+
 // Cache caches table readers
 type Cache interface {
 	// GetReader returns store reader from cache, create new reader if not exist.
+	// the reader is retained by cache, so must release it after reading.
+	// if file is corrupted, moves it into quarantine directory, then returns ErrChecksumMismatch.
 	GetReader(family string, fileName string) (Reader, error)
+	// Release releases the reader retained by GetReader, the reader may be closed if it's evicted.
+	Release(reader Reader)
+	// Quarantine moves the corrupted file into quarantine directory, the file cannot be read after quarantined.
+	Quarantine(family string, fileName string) error
 	// Evict evicts file reader from cache
 	Evict(family string, fileName string)
-	// Close cleans cache data after closing reader resource firstly
+	// Close evicts all readers, waits the referenced readers released, then closes them,
+	// so that the files can be removed safely after closing.
 	Close() error
 }
 
-// Cache caches table readers based on map
-type mapCache struct {
-	storePath string
-	readers   map[string]Reader
-	mutex     sync.Mutex
+// cacheEntry represents the cached reader with reference count
+type cacheEntry struct {
+	cache    *lruCache // cache of store which opens the reader
+	filePath string
+	reader   Reader
+	size     int64
+	refs     int
+	element  *list.Element // element of lru list, nil if entry removed from cache
+}
+
+// openingCall represents the reader being opened, the concurrent getting of same file waits it done.
+type openingCall struct {
+	done chan struct{} // closed after opening done
+	err  error
 }
 
-// NewCache creates cache for store readers
-func NewCache(storePath string) Cache {
-	return &mapCache{
-		storePath: storePath,
-		readers:   make(map[string]Reader),
+// sharedLRU represents the lru list of readers shared by the reader caches of all stores,
+// which is bounded by num. of files and total file size, so that the opened files of all stores are limited.
+type sharedLRU struct {
+	option CacheOption
+	lru    *list.List // the front is the most recently used
+	size   int64      // total file size of entries in lru list
+	mutex  sync.Mutex
+}
+
+// newSharedLRU creates the lru list shared by the reader caches of stores
+func newSharedLRU(option CacheOption) *sharedLRU {
+	return &sharedLRU{
+		option: option,
+		lru:    list.New(),
 	}
 }
 
-// Evict evicts file reader from cache
-func (c *mapCache) Evict(family string, fileName string) {
-	filePath := filepath.Join(family, fileName)
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
-	reader, ok := c.readers[filePath]
-	if ok {
-		if err := reader.Close(); err != nil {
-			tableLogger.Error("close store reader error",
-				logger.String("path", c.storePath),
-				logger.String("file", filePath), logger.Error(err))
+// setOption sets the limit of lru list, then evicts the readers if full
+func (l *sharedLRU) setOption(option CacheOption) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.option = option
+	l.evict()
+}
+
+// evict evicts the least recently used readers which are not referenced until lru isn't full, must hold lock.
+func (l *sharedLRU) evict() {
+	element := l.lru.Back()
+	for element != nil && l.isFull() {
+		prev := element.Prev()
+		entry := element.Value.(*cacheEntry)
+		if entry.refs == 0 {
+			entry.cache.removeEntry(entry)
+			cacheEvictions.Inc()
 		}
-		delete(c.readers, filePath)
+		element = prev
+	}
+}
+
+// isFull checks if num. of files or total file size exceeds the limit, must hold lock.
+func (l *sharedLRU) isFull() bool {
+	return (l.option.MaxFiles > 0 && l.lru.Len() > l.option.MaxFiles) ||
+		(l.option.MaxSize > 0 && l.size > l.option.MaxSize)
+}
+
+// lruCache caches table readers of store based on the shared lru list,
+// the reader is closed only if no one references it.
+type lruCache struct {
+	storePath   string
+	verifyMode  VerifyMode
+	shared      *sharedLRU
+	entries     map[string]*cacheEntry // entries in lru list
+	readers     map[Reader]*cacheEntry // all opened readers, include the readers removed from lru list but referenced
+	quarantined map[string]struct{}
+	opening     map[string]*openingCall // files being opened without holding lock
+	closed      bool
+	drained     chan struct{} // closed after all referenced readers released when closing
+}
+
+// NewCache creates the cache for store readers, which is bounded by the lru list shared by all stores,
+// verifies the checksums by verify mode when opening file.
+func NewCache(storePath string, verifyMode VerifyMode) Cache {
+	return newCache(storePath, verifyMode, defaultLRU)
+}
+
+// newCache creates the cache for store readers based on the shared lru list
+func newCache(storePath string, verifyMode VerifyMode, shared *sharedLRU) Cache {
+	return &lruCache{
+		storePath:   storePath,
+		verifyMode:  verifyMode,
+		shared:      shared,
+		entries:     make(map[string]*cacheEntry),
+		readers:     make(map[Reader]*cacheEntry),
+		quarantined: make(map[string]struct{}),
+		opening:     make(map[string]*openingCall),
 	}
 }
 
-// GetReader returns store reader from cache, create new reader if not exist
-func (c *mapCache) GetReader(family string, fileName string) (Reader, error) {
+// Evict evicts file reader from cache
+func (c *lruCache) Evict(family string, fileName string) {
 	filePath := filepath.Join(family, fileName)
-	c.mutex.Lock()
-	defer c.mutex.Unlock()
+	c.shared.mutex.Lock()
+	defer c.shared.mutex.Unlock()
+
+	if entry, ok := c.entries[filePath]; ok {
+		c.removeEntry(entry)
+	}
+}
 
-	// find from cache
-	reader, ok := c.readers[filePath]
-	if ok {
-		return reader, nil
+// GetReader returns store reader from cache, create new reader if not exist.
+// the file is opened and verified without holding the lock shared by all stores,
+// the concurrent getting of same file waits the opening done.
+func (c *lruCache) GetReader(family string, fileName string) (Reader, error) {
+	filePath := filepath.Join(family, fileName)
+	c.shared.mutex.Lock()
+	for {
+		if c.closed {
+			c.shared.mutex.Unlock()
+			return nil, fmt.Errorf("reader cache of store[%s] is closed", c.storePath)
+		}
+		// find from cache
+		if entry, ok := c.entries[filePath]; ok {
+			cacheHits.Inc()
+			entry.refs++
+			c.shared.lru.MoveToFront(entry.element)
+			c.shared.mutex.Unlock()
+			return entry.reader, nil
+		}
+		if _, ok := c.quarantined[filePath]; ok {
+			c.shared.mutex.Unlock()
+			return nil, fmt.Errorf("%w: file[%s] is quarantined", ErrChecksumMismatch, filePath)
+		}
+		call, ok := c.opening[filePath]
+		if !ok {
+			break
+		}
+		// wait the opening of other goroutine done, then check again
+		c.shared.mutex.Unlock()
+		<-call.done
+		if call.err != nil {
+			return nil, call.err
+		}
+		c.shared.mutex.Lock()
 	}
+	cacheMisses.Inc()
+	call := &openingCall{done: make(chan struct{})}
+	c.opening[filePath] = call
+	c.shared.mutex.Unlock()
 
-	// create new reader
+	// create new reader without holding lock
 	path := filepath.Join(c.storePath, filePath)
-	newReader, err := newMMapStoreReaderFunc(path)
+	newReader, err := newMMapStoreReaderFunc(path, c.verifyMode)
+
+	c.shared.mutex.Lock()
+	defer c.shared.mutex.Unlock()
+
+	delete(c.opening, filePath)
+	defer func() {
+		call.err = err
+		close(call.done)
+	}()
+
 	if err != nil {
+		if IsCorrupted(err) {
+			if e := c.quarantine(filePath); e != nil {
+				tableLogger.Error("quarantine corrupted file error",
+					logger.String("path", c.storePath),
+					logger.String("file", filePath), logger.Error(e))
+			}
+		}
 		return nil, err
 	}
-	c.readers[filePath] = newReader
-	return newReader, nil
-}
-
-// Close closes reader resource and cleans cache data.
-func (c *mapCache) Close() error {
-	for k, v := range c.readers {
-		if err := v.Close(); err != nil {
+	// cache closed or file quarantined when opening
+	if c.closed {
+		err = fmt.Errorf("reader cache of store[%s] is closed", c.storePath)
+	} else if _, ok := c.quarantined[filePath]; ok {
+		err = fmt.Errorf("%w: file[%s] is quarantined", ErrChecksumMismatch, filePath)
+	}
+	if err != nil {
+		if e := newReader.Close(); e != nil {
 			tableLogger.Error("close store reader error",
 				logger.String("path", c.storePath),
-				logger.String("file", k), logger.Error(err))
+				logger.String("file", filePath), logger.Error(e))
 		}
+		return nil, err
+	}
+	entry := &cacheEntry{
+		cache:    c,
+		filePath: filePath,
+		reader:   newReader,
+		size:     newReader.Size(),
+		refs:     1,
+	}
+	entry.element = c.shared.lru.PushFront(entry)
+	c.shared.size += entry.size
+	c.entries[filePath] = entry
+	c.readers[newReader] = entry
+	c.shared.evict()
+	return newReader, nil
+}
+
+// Release releases the reader retained by GetReader, the reader may be closed if it's evicted.
+func (c *lruCache) Release(reader Reader) {
+	c.shared.mutex.Lock()
+	defer c.shared.mutex.Unlock()
+
+	entry, ok := c.readers[reader]
+	if !ok || entry.refs <= 0 {
+		return
+	}
+	entry.refs--
+	if entry.refs > 0 {
+		return
+	}
+	if entry.element == nil {
+		// entry removed from cache, close it after all references released
+		c.closeEntry(entry)
+		return
+	}
+	c.shared.evict()
+}
+
+// Quarantine moves the corrupted file into quarantine directory, the file cannot be read after quarantined.
+func (c *lruCache) Quarantine(family string, fileName string) error {
+	filePath := filepath.Join(family, fileName)
+	c.shared.mutex.Lock()
+	defer c.shared.mutex.Unlock()
+
+	if entry, ok := c.entries[filePath]; ok {
+		c.removeEntry(entry)
+	}
+	return c.quarantine(filePath)
+}
+
+// quarantine moves the file into quarantine directory, then marks it quarantined, must hold lock.
+func (c *lruCache) quarantine(filePath string) error {
+	if _, ok := c.quarantined[filePath]; ok {
+		return nil
+	}
+	target := filepath.Join(c.storePath, QuarantineDir, filePath)
+	if err := mkDirIfNotExistFunc(filepath.Dir(target)); err != nil {
+		return err
 	}
+	if err := renameFunc(filepath.Join(c.storePath, filePath), target); err != nil {
+		return err
+	}
+	c.quarantined[filePath] = struct{}{}
+	quarantinedFiles.Inc()
+	tableLogger.Warn("quarantine corrupted file",
+		logger.String("path", c.storePath), logger.String("file", filePath))
 	return nil
 }
+
+// removeEntry removes entry from cache, closes the reader if no one references it, must hold lock.
+func (c *lruCache) removeEntry(entry *cacheEntry) {
+	delete(c.entries, entry.filePath)
+	if entry.element != nil {
+		c.shared.lru.Remove(entry.element)
+		c.shared.size -= entry.size
+		entry.element = nil
+	}
+	if entry.refs == 0 {
+		c.closeEntry(entry)
+	}
+}
+
+// closeEntry closes the reader of entry, notifies closing if all readers closed, must hold lock.
+func (c *lruCache) closeEntry(entry *cacheEntry) {
+	delete(c.readers, entry.reader)
+	if err := entry.reader.Close(); err != nil {
+		tableLogger.Error("close store reader error",
+			logger.String("path", c.storePath),
+			logger.String("file", entry.filePath), logger.Error(err))
+	}
+	if c.drained != nil && len(c.readers) == 0 {
+		close(c.drained)
+		c.drained = nil
+	}
+}
+
+// Close removes all readers of store from shared lru list, closes the readers which aren't referenced,
+// then waits the referenced readers released by the reading in flight, which are closed after released,
+// so that the mapped files aren't unmapped when reading. returns err if waiting timeout.
+func (c *lruCache) Close() error {
+	c.shared.mutex.Lock()
+	c.closed = true
+	for _, entry := range c.entries {
+		c.removeEntry(entry)
+	}
+	var drained chan struct{}
+	if len(c.readers) > 0 {
+		drained = make(chan struct{})
+		c.drained = drained
+	}
+	c.shared.mutex.Unlock()
+
+	if drained == nil {
+		return nil
+	}
+	timer := time.NewTimer(closeWaitTimeout)
+	defer timer.Stop()
+	select {
+	case <-drained:
+		return nil
+	case <-timer.C:
+		return fmt.Errorf("wait readers of store[%s] released timeout, they are closed after released", c.storePath)
+	}
+}
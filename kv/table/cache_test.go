@@ -2,7 +2,11 @@ package table
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -10,7 +14,7 @@ import (
 	"github.com/lindb/lindb/pkg/fileutil"
 )
 
-func TestMapCache_GetReader(t *testing.T) {
+func TestLRUCache_GetReader(t *testing.T) {
 	_ = fileutil.MkDirIfNotExist(testKVPath)
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -18,9 +22,9 @@ func TestMapCache_GetReader(t *testing.T) {
 		_ = fileutil.RemoveDir(testKVPath)
 		ctrl.Finish()
 	}()
-	cache := NewCache(testKVPath)
+	cache := NewCache(testKVPath, VerifyFooter)
 	// case 1: get reader err
-	newMMapStoreReaderFunc = func(path string) (r Reader, err error) {
+	newMMapStoreReaderFunc = func(path string, verifyMode VerifyMode) (r Reader, err error) {
 		return nil, fmt.Errorf("err")
 	}
 	r, err := cache.GetReader("f", "100000.sst")
@@ -28,7 +32,8 @@ func TestMapCache_GetReader(t *testing.T) {
 	assert.Nil(t, r)
 	// case 2: get reader success
 	mockReader := NewMockReader(ctrl)
-	newMMapStoreReaderFunc = func(path string) (reader Reader, err error) {
+	mockReader.EXPECT().Size().Return(int64(10)).AnyTimes()
+	newMMapStoreReaderFunc = func(path string, verifyMode VerifyMode) (reader Reader, err error) {
 		return mockReader, nil
 	}
 	r, err = cache.GetReader("f", "100000.sst")
@@ -38,6 +43,10 @@ func TestMapCache_GetReader(t *testing.T) {
 	r, err = cache.GetReader("f", "100000.sst")
 	assert.NoError(t, err)
 	assert.Equal(t, mockReader, r)
+	cache.Release(r)
+	cache.Release(r)
+	// release not exist reader
+	cache.Release(NewMockReader(ctrl))
 	// case 4: evict not exist
 	cache.Evict("f", "200000.sst")
 	cache.Evict("f1", "100000.sst")
@@ -45,9 +54,293 @@ func TestMapCache_GetReader(t *testing.T) {
 	mockReader.EXPECT().Close().Return(fmt.Errorf("err"))
 	cache.Evict("f", "100000.sst")
 	// case 6: close err
-	mockReader.EXPECT().Close().Return(fmt.Errorf("err")).MaxTimes(2)
-	_, _ = cache.GetReader("f", "100000.sst")
-	_, _ = cache.GetReader("f", "200000.sst")
+	mockReader2 := NewMockReader(ctrl)
+	mockReader2.EXPECT().Size().Return(int64(10)).AnyTimes()
+	newMMapStoreReaderFunc = func(path string, verifyMode VerifyMode) (reader Reader, err error) {
+		return mockReader2, nil
+	}
+	mockReader2.EXPECT().Close().Return(fmt.Errorf("err"))
+	r, _ = cache.GetReader("f", "200000.sst")
+	cache.Release(r)
 	err = cache.Close()
 	assert.NoError(t, err)
+	// case 7: get reader after closed
+	r, err = cache.GetReader("f", "200000.sst")
+	assert.Error(t, err)
+	assert.Nil(t, r)
+}
+
+func TestLRUCache_GetReader_opening(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newMMapStoreReaderFunc = newMMapStoreReader
+		renameFunc = os.Rename
+		mkDirIfNotExistFunc = fileutil.MkDirIfNotExist
+		ctrl.Finish()
+	}()
+	renameFunc = func(oldpath, newpath string) error {
+		return nil
+	}
+	mkDirIfNotExistFunc = func(path string) error {
+		return nil
+	}
+	shared := newSharedLRU(CacheOption{})
+	cache := newCache(testKVPath, VerifyFooter, shared)
+	cache2 := newCache(testKVPath, VerifyFooter, shared)
+
+	mockReader := NewMockReader(ctrl)
+	mockReader.EXPECT().Size().Return(int64(10)).AnyTimes()
+	opening := make(chan struct{})
+	open := make(chan error)
+	opened := 0
+	newMMapStoreReaderFunc = func(path string, verifyMode VerifyMode) (reader Reader, err error) {
+		opened++
+		opening <- struct{}{}
+		if err := <-open; err != nil {
+			return nil, err
+		}
+		return mockReader, nil
+	}
+	getReader := func(c Cache) chan Reader {
+		ch := make(chan Reader, 1)
+		go func() {
+			r, _ := c.GetReader("f", "100000.sst")
+			ch <- r
+		}()
+		return ch
+	}
+	// case 1: concurrent getting waits the opening, file opened once
+	ch1 := getReader(cache)
+	<-opening
+	ch2 := getReader(cache)
+	// shared lock isn't held when opening
+	mockReader2 := NewMockReader(ctrl)
+	mockReader2.EXPECT().Size().Return(int64(10)).AnyTimes()
+	cache2.(*lruCache).entries["f/100000.sst"] = &cacheEntry{reader: mockReader2, element: shared.lru.PushFront(nil)}
+	r, err := cache2.GetReader("f", "100000.sst")
+	assert.NoError(t, err)
+	assert.Equal(t, mockReader2, r)
+	time.Sleep(50 * time.Millisecond)
+	open <- nil
+	assert.Equal(t, mockReader, <-ch1)
+	assert.Equal(t, mockReader, <-ch2)
+	assert.Equal(t, 1, opened)
+	assert.Equal(t, 2, cache.(*lruCache).entries["f/100000.sst"].refs)
+	// case 2: waiting getting returns err of opening
+	mockReader.EXPECT().Close().Return(nil)
+	cache.Release(mockReader)
+	cache.Release(mockReader)
+	cache.Evict("f", "100000.sst")
+	ch1 = getReader(cache)
+	<-opening
+	ch2 = getReader(cache)
+	time.Sleep(50 * time.Millisecond)
+	open <- fmt.Errorf("err")
+	assert.Nil(t, <-ch1)
+	assert.Nil(t, <-ch2)
+	assert.Equal(t, 2, opened)
+	// case 3: file quarantined when opening
+	ch1 = getReader(cache)
+	<-opening
+	assert.NoError(t, cache.Quarantine("f", "100000.sst"))
+	mockReader.EXPECT().Close().Return(fmt.Errorf("err"))
+	open <- nil
+	assert.Nil(t, <-ch1)
+	// case 4: cache closed when opening
+	cache3 := newCache(testKVPath, VerifyFooter, shared)
+	ch1 = getReader(cache3)
+	<-opening
+	assert.NoError(t, cache3.Close())
+	mockReader.EXPECT().Close().Return(nil)
+	open <- nil
+	assert.Nil(t, <-ch1)
+	assert.Empty(t, cache3.(*lruCache).readers)
+}
+
+func TestLRUCache_Close(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newMMapStoreReaderFunc = newMMapStoreReader
+		closeWaitTimeout = 30 * time.Second
+		ctrl.Finish()
+	}()
+	readers := make(map[string]*MockReader)
+	newMMapStoreReaderFunc = func(path string, verifyMode VerifyMode) (reader Reader, err error) {
+		r := NewMockReader(ctrl)
+		r.EXPECT().Size().Return(int64(10)).AnyTimes()
+		readers[filepath.Base(path)] = r
+		return r, nil
+	}
+	// case 1: referenced reader is closed after released, close waits it
+	cache := newCache(testKVPath, VerifyFooter, newSharedLRU(CacheOption{}))
+	r1, _ := cache.GetReader("f", "1.sst")
+	r2, _ := cache.GetReader("f", "2.sst")
+	cache.Release(r2)
+	readers["2.sst"].EXPECT().Close().Return(nil)
+	closed := make(chan error)
+	go func() {
+		closed <- cache.Close()
+	}()
+	time.Sleep(10 * time.Millisecond)
+	select {
+	case <-closed:
+		assert.Fail(t, "close before reader released")
+	default:
+	}
+	readers["1.sst"].EXPECT().Close().Return(nil)
+	cache.Release(r1)
+	assert.NoError(t, <-closed)
+	// case 2: wait timeout, referenced reader isn't closed until released
+	closeWaitTimeout = 10 * time.Millisecond
+	cache = newCache(testKVPath, VerifyFooter, newSharedLRU(CacheOption{}))
+	r1, _ = cache.GetReader("f", "1.sst")
+	assert.Error(t, cache.Close())
+	readers["1.sst"].EXPECT().Close().Return(nil)
+	cache.Release(r1)
+}
+
+func TestLRUCache_Evict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newMMapStoreReaderFunc = newMMapStoreReader
+		ctrl.Finish()
+	}()
+	readers := make(map[string]*MockReader)
+	newMMapStoreReaderFunc = func(path string, verifyMode VerifyMode) (reader Reader, err error) {
+		r := NewMockReader(ctrl)
+		r.EXPECT().Size().Return(int64(10)).AnyTimes()
+		readers[filepath.Base(path)] = r
+		return r, nil
+	}
+	// case 1: evict by num. of files
+	cache := newCache(testKVPath, VerifyFooter, newSharedLRU(CacheOption{MaxFiles: 2}))
+	r1, _ := cache.GetReader("f", "1.sst")
+	r2, _ := cache.GetReader("f", "2.sst")
+	cache.Release(r1)
+	cache.Release(r2)
+	// 1.sst is the least recently used
+	readers["1.sst"].EXPECT().Close().Return(nil)
+	r3, _ := cache.GetReader("f", "3.sst")
+	cache.Release(r3)
+	// 2.sst is used recently
+	r2, _ = cache.GetReader("f", "2.sst")
+	readers["3.sst"].EXPECT().Close().Return(nil)
+	_, _ = cache.GetReader("f", "4.sst")
+	// all readers are referenced, cannot evict
+	_, _ = cache.GetReader("f", "5.sst")
+	// evict after releasing
+	readers["2.sst"].EXPECT().Close().Return(nil)
+	cache.Release(r2)
+
+	// case 2: evict by total file size
+	cache = newCache(testKVPath, VerifyFooter, newSharedLRU(CacheOption{MaxSize: 15}))
+	r1, _ = cache.GetReader("f", "1.sst")
+	cache.Release(r1)
+	readers["1.sst"].EXPECT().Close().Return(nil)
+	r2, _ = cache.GetReader("f", "2.sst")
+	cache.Release(r2)
+
+	// case 3: reader is closed after released if evicted when referenced
+	readers["2.sst"].EXPECT().Close().Return(nil)
+	r3, _ = cache.GetReader("f", "3.sst")
+	cache.Evict("f", "3.sst")
+	readers["3.sst"].EXPECT().Close().Return(nil)
+	cache.Release(r3)
+	// released reader not exist
+	cache.Release(r3)
+}
+
+func TestLRUCache_Quarantine(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(filepath.Join(testKVPath, "f"))
+	defer func() {
+		renameFunc = os.Rename
+		mkDirIfNotExistFunc = fileutil.MkDirIfNotExist
+		_ = fileutil.RemoveDir(testKVPath)
+	}()
+	fileName := filepath.Join(testKVPath, "f", "000010.sst")
+	data := buildTestFile(t, fileName)
+	cache := NewCache(testKVPath, VerifyFull)
+	r, err := cache.GetReader("f", "000010.sst")
+	assert.NoError(t, err)
+	assert.NotNil(t, r)
+	cache.Release(r)
+	// case 1: quarantine exist reader
+	assert.NoError(t, cache.Quarantine("f", "000010.sst"))
+	assert.False(t, fileutil.Exist(fileName))
+	assert.True(t, fileutil.Exist(filepath.Join(testKVPath, QuarantineDir, "f", "000010.sst")))
+	r, err = cache.GetReader("f", "000010.sst")
+	assert.True(t, IsCorrupted(err))
+	assert.Nil(t, r)
+	// quarantine again
+	assert.NoError(t, cache.Quarantine("f", "000010.sst"))
+	// case 2: corrupted file quarantined when get reader
+	data[2] = 'o'
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(testKVPath, "f", "000011.sst"), data, 0644))
+	r, err = cache.GetReader("f", "000011.sst")
+	assert.True(t, IsCorrupted(err))
+	assert.Nil(t, r)
+	assert.True(t, fileutil.Exist(filepath.Join(testKVPath, QuarantineDir, "f", "000011.sst")))
+	// case 3: mkdir err
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(testKVPath, "f", "000012.sst"), data, 0644))
+	mkDirIfNotExistFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	r, err = cache.GetReader("f", "000012.sst")
+	assert.True(t, IsCorrupted(err))
+	assert.Nil(t, r)
+	// case 4: rename err
+	mkDirIfNotExistFunc = fileutil.MkDirIfNotExist
+	renameFunc = func(oldpath, newpath string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, cache.Quarantine("f", "000012.sst"))
+	assert.NoError(t, cache.Close())
+}
+
+func TestLRUCache_SharedLRU(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newMMapStoreReaderFunc = newMMapStoreReader
+		closeWaitTimeout = 30 * time.Second
+		ctrl.Finish()
+	}()
+	readers := make(map[string]*MockReader)
+	newMMapStoreReaderFunc = func(path string, verifyMode VerifyMode) (reader Reader, err error) {
+		r := NewMockReader(ctrl)
+		r.EXPECT().Size().Return(int64(10)).AnyTimes()
+		readers[path] = r
+		return r, nil
+	}
+	shared := newSharedLRU(CacheOption{MaxFiles: 2})
+	cache1 := newCache(filepath.Join(testKVPath, "s1"), VerifyFooter, shared)
+	cache2 := newCache(filepath.Join(testKVPath, "s2"), VerifyFooter, shared)
+	r1, _ := cache1.GetReader("f", "1.sst")
+	cache1.Release(r1)
+	r2, _ := cache2.GetReader("f", "1.sst")
+	cache2.Release(r2)
+	// case 1: reader of other store is evicted if num. of files of all stores exceeds the limit
+	readers[filepath.Join(testKVPath, "s1", "f", "1.sst")].EXPECT().Close().Return(nil)
+	r3, _ := cache2.GetReader("f", "2.sst")
+	assert.Equal(t, 2, shared.lru.Len())
+	// case 2: evict after changing limit
+	readers[filepath.Join(testKVPath, "s2", "f", "1.sst")].EXPECT().Close().Return(nil)
+	shared.setOption(CacheOption{MaxSize: 10})
+	assert.Equal(t, int64(10), shared.size)
+	// case 3: close store cache, removes readers from shared lru list
+	closeWaitTimeout = time.Millisecond
+	assert.Error(t, cache2.Close())
+	assert.Equal(t, 0, shared.lru.Len())
+	assert.Equal(t, int64(0), shared.size)
+	// referenced reader is closed after released
+	readers[filepath.Join(testKVPath, "s2", "f", "2.sst")].EXPECT().Close().Return(nil)
+	cache2.Release(r3)
+	// released reader not exist after closing
+	cache2.Release(r3)
+	assert.NoError(t, cache1.Close())
+}
+
+func TestSetCacheOption(t *testing.T) {
+	defer SetCacheOption(CacheOption{MaxFiles: defaultCacheMaxFiles, MaxSize: defaultCacheMaxSize})
+	SetCacheOption(CacheOption{MaxFiles: 10})
+	assert.Equal(t, CacheOption{MaxFiles: 10}, defaultLRU.option)
 }
@@ -0,0 +1,111 @@
+package table
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/monitoring"
+)
+
+// ErrChecksumMismatch represents the data of sst file is corrupted
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+var (
+	checksumFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "kv_table_checksum_failures",
+			Help: "Number of checksum verification failures of sst file.",
+		},
+		[]string{"type"},
+	)
+	footerChecksumFailures = checksumFailures.WithLabelValues("footer")
+	valueChecksumFailures  = checksumFailures.WithLabelValues("value")
+	// BlockChecksumFailures counts the checksum failures of data blocks in value, which are verified by value readers
+	BlockChecksumFailures = checksumFailures.WithLabelValues("block")
+)
+
+func init() {
+	monitoring.StorageRegistry.MustRegister(checksumFailures)
+}
+
+// VerifyMode represents the checksum verification mode when opening sst file
+type VerifyMode uint8
+
+// Defines all checksum verification modes
+const (
+	// VerifyOff doesn't verify any checksum
+	VerifyOff VerifyMode = iota
+	// VerifyFooter verifies the checksum of index blocks(keys/offsets) on open, and value checksum on read
+	VerifyFooter
+	// VerifyFull verifies all checksums of index blocks and values on open
+	VerifyFull
+)
+
+// String returns the string value of verify mode
+func (m VerifyMode) String() string {
+	switch m {
+	case VerifyOff:
+		return "off"
+	case VerifyFooter:
+		return "footer"
+	case VerifyFull:
+		return "full"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseVerifyMode returns the verify mode by name(off/footer/full), empty name means footer mode.
+func ParseVerifyMode(name string) (VerifyMode, error) {
+	switch strings.ToLower(name) {
+	case "off":
+		return VerifyOff, nil
+	case "", "footer":
+		return VerifyFooter, nil
+	case "full":
+		return VerifyFull, nil
+	default:
+		return VerifyFooter, fmt.Errorf("unknown checksum verify mode: %s", name)
+	}
+}
+
+// Verify verifies all checksums of the sst file, returns ErrChecksumMismatch if file is corrupted
+func Verify(path string) error {
+	reader, err := newMMapStoreReaderFunc(path, VerifyFull)
+	if err != nil {
+		return err
+	}
+	return reader.Close()
+}
+
+// IsCorrupted checks if the error is caused by corrupted data,
+// includes the corrupted data block(constants.ErrDataFileCorruption) found by value readers.
+func IsCorrupted(err error) bool {
+	return errors.Is(err, ErrChecksumMismatch) || errors.Is(err, constants.ErrDataFileCorruption)
+}
+
+// withChecksum returns a new buffer which contains value and crc32 checksum of value
+func withChecksum(value []byte) []byte {
+	buf := make([]byte, len(value)+valueChecksumSize)
+	copy(buf, value)
+	binary.LittleEndian.PutUint32(buf[len(value):], crc32.ChecksumIEEE(value))
+	return buf
+}
+
+// verifyChecksum verifies the crc32 checksum of value, then returns the value without checksum
+func verifyChecksum(buf []byte) ([]byte, error) {
+	if len(buf) < valueChecksumSize {
+		return nil, ErrChecksumMismatch
+	}
+	value := buf[:len(buf)-valueChecksumSize]
+	if crc32.ChecksumIEEE(value) != binary.LittleEndian.Uint32(buf[len(value):]) {
+		return nil, ErrChecksumMismatch
+	}
+	return value, nil
+}
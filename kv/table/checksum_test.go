@@ -0,0 +1,117 @@
+package table
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+func TestVerifyMode_String(t *testing.T) {
+	assert.Equal(t, "off", VerifyOff.String())
+	assert.Equal(t, "footer", VerifyFooter.String())
+	assert.Equal(t, "full", VerifyFull.String())
+	assert.Equal(t, "unknown", VerifyMode(100).String())
+}
+
+func TestParseVerifyMode(t *testing.T) {
+	cases := []struct {
+		name    string
+		mode    VerifyMode
+		wantErr bool
+	}{
+		{name: "", mode: VerifyFooter},
+		{name: "off", mode: VerifyOff},
+		{name: "Footer", mode: VerifyFooter},
+		{name: "full", mode: VerifyFull},
+		{name: "xxx", mode: VerifyFooter, wantErr: true},
+	}
+	for _, c := range cases {
+		mode, err := ParseVerifyMode(c.name)
+		assert.Equal(t, c.wantErr, err != nil)
+		assert.Equal(t, c.mode, mode)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	value := []byte{1, 2, 3}
+	buf := withChecksum(value)
+	assert.Len(t, buf, 7)
+	v, err := verifyChecksum(buf)
+	assert.NoError(t, err)
+	assert.Equal(t, value, v)
+	buf[0] = 10
+	_, err = verifyChecksum(buf)
+	assert.True(t, IsCorrupted(err))
+	_, err = verifyChecksum([]byte{1, 2})
+	assert.True(t, IsCorrupted(err))
+}
+
+// buildTestFile builds sst file with 2 values, returns the file content
+func buildTestFile(t *testing.T, fileName string) []byte {
+	builder, err := NewStoreBuilder(10, fileName, NoCompression)
+	assert.NoError(t, err)
+	_ = builder.Add(1, []byte("test"))
+	_ = builder.Add(10, []byte("test10"))
+	assert.NoError(t, builder.Close())
+	data, err := ioutil.ReadFile(fileName)
+	assert.NoError(t, err)
+	return data
+}
+
+func TestVerify(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testKVPath)
+	defer func() {
+		_ = os.RemoveAll(testKVPath)
+	}()
+	fileName := filepath.Join(testKVPath, "000010.sst")
+	data := buildTestFile(t, fileName)
+	// case 1: verify success
+	assert.NoError(t, Verify(fileName))
+	// case 2: file not exist
+	assert.Error(t, Verify(filepath.Join(testKVPath, "000011.sst")))
+
+	// case 3: value corrupted, "test" => "tost"
+	data[2] = 'o'
+	assert.NoError(t, ioutil.WriteFile(fileName, data, 0644))
+	err := Verify(fileName)
+	assert.True(t, IsCorrupted(err))
+	// footer mode only verifies index blocks on open, value checksum verified on read
+	reader, err := newMMapStoreReader(fileName, VerifyFooter)
+	assert.NoError(t, err)
+	value, err := reader.Get(1)
+	assert.True(t, IsCorrupted(err))
+	assert.Nil(t, value)
+	value, err = reader.Get(10)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test10"), value)
+	_ = reader.Close()
+	// verify off mode, skip checksum
+	reader, err = newMMapStoreReader(fileName, VerifyOff)
+	assert.NoError(t, err)
+	value, err = reader.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("tost"), value)
+	_ = reader.Close()
+
+	// case 4: index blocks corrupted
+	data = buildTestFile(t, fileName)
+	data[len(data)-sstFileFooterSizeV2-2]++
+	assert.NoError(t, ioutil.WriteFile(fileName, data, 0644))
+	_, err = newMMapStoreReader(fileName, VerifyFooter)
+	assert.True(t, IsCorrupted(err))
+	// verify off mode, skip checksum
+	reader, err = newMMapStoreReader(fileName, VerifyOff)
+	if err == nil {
+		_ = reader.Close()
+	}
+	assert.False(t, IsCorrupted(err))
+	// corrupted data block found by value reader
+	assert.True(t, IsCorrupted(fmt.Errorf("%w: read metric block", constants.ErrDataFileCorruption)))
+}
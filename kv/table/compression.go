@@ -0,0 +1,80 @@
+package table
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressionType represents the compression codec of values in sst file
+type CompressionType uint8
+
+// Defines all compression types of sst file
+const (
+	NoCompression CompressionType = iota
+	SnappyCompression
+	ZstdCompression
+)
+
+var (
+	// zstd encoder/decoder are safe for concurrent use with EncodeAll/DecodeAll
+	zstdEncoder, _ = zstd.NewWriter(nil)
+	zstdDecoder, _ = zstd.NewReader(nil)
+)
+
+// String returns the string value of compression type
+func (c CompressionType) String() string {
+	switch c {
+	case NoCompression:
+		return "none"
+	case SnappyCompression:
+		return "snappy"
+	case ZstdCompression:
+		return "zstd"
+	default:
+		return "unknown"
+	}
+}
+
+// ParseCompressionType returns the compression type by name(none/snappy/zstd),
+// empty name means no compression.
+func ParseCompressionType(name string) (CompressionType, error) {
+	switch strings.ToLower(name) {
+	case "", "none":
+		return NoCompression, nil
+	case "snappy":
+		return SnappyCompression, nil
+	case "zstd":
+		return ZstdCompression, nil
+	default:
+		return NoCompression, fmt.Errorf("unknown compression type: %s", name)
+	}
+}
+
+// compress compresses the value by compression type
+func (c CompressionType) compress(value []byte) []byte {
+	switch c {
+	case SnappyCompression:
+		return snappy.Encode(nil, value)
+	case ZstdCompression:
+		return zstdEncoder.EncodeAll(value, nil)
+	default:
+		return value
+	}
+}
+
+// decompress decompresses the value by compression type
+func (c CompressionType) decompress(value []byte) ([]byte, error) {
+	switch c {
+	case NoCompression:
+		return value, nil
+	case SnappyCompression:
+		return snappy.Decode(nil, value)
+	case ZstdCompression:
+		return zstdDecoder.DecodeAll(value, nil)
+	default:
+		return nil, fmt.Errorf("unknown compression type: %d", c)
+	}
+}
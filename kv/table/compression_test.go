@@ -0,0 +1,45 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressionType_String(t *testing.T) {
+	assert.Equal(t, "none", NoCompression.String())
+	assert.Equal(t, "snappy", SnappyCompression.String())
+	assert.Equal(t, "zstd", ZstdCompression.String())
+	assert.Equal(t, "unknown", CompressionType(100).String())
+}
+
+func TestParseCompressionType(t *testing.T) {
+	cases := []struct {
+		name        string
+		compression CompressionType
+		wantErr     bool
+	}{
+		{name: "", compression: NoCompression},
+		{name: "none", compression: NoCompression},
+		{name: "Snappy", compression: SnappyCompression},
+		{name: "zstd", compression: ZstdCompression},
+		{name: "lz4", compression: NoCompression, wantErr: true},
+	}
+	for _, c := range cases {
+		compression, err := ParseCompressionType(c.name)
+		assert.Equal(t, c.wantErr, err != nil)
+		assert.Equal(t, c.compression, compression)
+	}
+}
+
+func TestCompressionType_compress(t *testing.T) {
+	value := []byte("test-compression-test-compression-test-compression")
+	for _, compression := range []CompressionType{NoCompression, SnappyCompression, ZstdCompression} {
+		compressed := compression.compress(value)
+		decompressed, err := compression.decompress(compressed)
+		assert.NoError(t, err)
+		assert.Equal(t, value, decompressed)
+	}
+	_, err := CompressionType(100).decompress(value)
+	assert.Error(t, err)
+}
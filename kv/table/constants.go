@@ -13,14 +13,24 @@ var (
 const (
 	// magic-number in the footer of sst file
 	magicNumberOffsetFile uint64 = 0x69632d656d656c65
-	// current file layout version
+	// file layout version without compression
 	version0 = 0
+	// file layout version, records compression type of values in footer
+	version1 = 1
+	// current file layout version, records crc32 checksum of each value and index blocks
+	version2 = 2
 
 	sstFileFooterSize = 1 + // entry length wrote by bufioutil
 		4 + // posOfOffset(4)
 		4 + // posOfKeys(4)
 		1 + // version(1)
 		8 // magicNumber(8)
+	sstFileFooterSizeV1 = sstFileFooterSize +
+		1 // compression(1)
+	sstFileFooterSizeV2 = sstFileFooterSizeV1 +
+		4 // crc32 checksum of index blocks(4)
+	// crc32 checksum after each value
+	valueChecksumSize = 4
 	// footer-size, offset(1), keys(1)
 	sstFileMinLength = sstFileFooterSize + 2
 )
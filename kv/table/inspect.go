@@ -0,0 +1,54 @@
+package table
+
+import "fmt"
+
+// FileInfo represents the footer and index info of sst file, used by offline inspection tool
+type FileInfo struct {
+	Path          string          // path of sst file
+	Size          int64           // length of sst file
+	Version       uint8           // file layout version
+	Compression   CompressionType // compression type of values
+	PosOfOffset   int             // position of offsets block
+	PosOfKeys     int             // position of keys block
+	IndexChecksum uint32          // crc32 checksum of index blocks, only for file layout version2
+	NumOfKeys     int             // num. of keys
+	MinKey        uint32          // min key, 0 if no keys
+	MaxKey        uint32          // max key, 0 if no keys
+}
+
+// String returns the string value of file info
+func (f *FileInfo) String() string {
+	return fmt.Sprintf("path:%s,size:%d,version:%d,compression:%s,posOfOffset:%d,posOfKeys:%d,"+
+		"indexChecksum:%d,keys:%d,minKey:%d,maxKey:%d",
+		f.Path, f.Size, f.Version, f.Compression, f.PosOfOffset, f.PosOfKeys,
+		f.IndexChecksum, f.NumOfKeys, f.MinKey, f.MaxKey)
+}
+
+// Inspect opens the sst file with verify mode, returns the file info and the reader for dumping k/v pairs,
+// the reader must be closed by invoker.
+func Inspect(path string, verifyMode VerifyMode) (*FileInfo, Reader, error) {
+	reader, err := newMMapStoreReaderFunc(path, verifyMode)
+	if err != nil {
+		return nil, nil, err
+	}
+	r, ok := reader.(*storeMMapReader)
+	if !ok {
+		_ = reader.Close()
+		return nil, nil, fmt.Errorf("unsupported reader of sstfile:%s", path)
+	}
+	info := &FileInfo{
+		Path:          r.path,
+		Size:          r.Size(),
+		Version:       r.fileVersion,
+		Compression:   r.compression,
+		PosOfOffset:   r.posOfOffset,
+		PosOfKeys:     r.posOfKeys,
+		IndexChecksum: r.indexChecksum,
+		NumOfKeys:     int(r.keys.GetCardinality()),
+	}
+	if !r.keys.IsEmpty() {
+		info.MinKey = r.keys.Minimum()
+		info.MaxKey = r.keys.Maximum()
+	}
+	return info, reader, nil
+}
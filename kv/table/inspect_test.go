@@ -0,0 +1,56 @@
+package table
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+func TestInspect(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	_ = fileutil.MkDirIfNotExist(testKVPath)
+	defer func() {
+		newMMapStoreReaderFunc = newMMapStoreReader
+		_ = os.RemoveAll(testKVPath)
+		ctrl.Finish()
+	}()
+	fileName := filepath.Join(testKVPath, "000010.sst")
+	_ = buildTestFile(t, fileName)
+	// case 1: inspect success
+	info, reader, err := Inspect(fileName, VerifyFull)
+	assert.NoError(t, err)
+	assert.Equal(t, fileName, info.Path)
+	assert.Equal(t, reader.Size(), info.Size)
+	assert.Equal(t, uint8(version2), info.Version)
+	assert.Equal(t, NoCompression, info.Compression)
+	assert.Equal(t, 2, info.NumOfKeys)
+	assert.Equal(t, uint32(1), info.MinKey)
+	assert.Equal(t, uint32(10), info.MaxKey)
+	assert.True(t, info.PosOfKeys > info.PosOfOffset)
+	assert.NotZero(t, info.IndexChecksum)
+	assert.NotEmpty(t, info.String())
+	it := reader.Iterator()
+	assert.True(t, it.HasNext())
+	assert.Equal(t, uint32(1), it.Key())
+	value, err := it.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test"), value)
+	assert.NoError(t, reader.Close())
+	// case 2: file not exist
+	_, _, err = Inspect(filepath.Join(testKVPath, "000011.sst"), VerifyFooter)
+	assert.Error(t, err)
+	// case 3: unsupported reader
+	mockReader := NewMockReader(ctrl)
+	mockReader.EXPECT().Close().Return(fmt.Errorf("err"))
+	newMMapStoreReaderFunc = func(path string, verifyMode VerifyMode) (r Reader, err error) {
+		return mockReader, nil
+	}
+	_, _, err = Inspect(fileName, VerifyFooter)
+	assert.Error(t, err)
+}
@@ -13,8 +13,9 @@ type Iterator interface {
 	HasNext() bool
 	// Key returns the key of the current key/value pair
 	Key() uint32
-	// Value returns the value of the current key/value pair
-	Value() []byte
+	// Value returns the value of the current key/value pair,
+	// returns error if value is corrupted or cannot be decompressed.
+	Value() ([]byte, error)
 }
 
 /////////////
@@ -29,6 +30,7 @@ type mergedIterator struct {
 
 	curKey   uint32
 	curValue []byte
+	curErr   error
 }
 
 // NewMergedIterator create merged iterator for multi iterators
@@ -45,10 +47,13 @@ func (m *mergedIterator) initQueue() {
 	i := 0
 	for _, it := range m.its {
 		if it.HasNext() {
+			key := it.Key()
+			value, err := it.Value()
 			m.pq = append(m.pq, &item{
 				it:    it,
-				key:   it.Key(),
-				value: it.Value(),
+				key:   key,
+				value: value,
+				err:   err,
 				index: i,
 			})
 			i++
@@ -69,12 +74,13 @@ func (m *mergedIterator) HasNext() bool {
 		item := val.(*item)
 		m.curKey = item.key
 		m.curValue = item.value
+		m.curErr = item.err
 
 		// if it has value, push back queue and adjust priority
 		it := item.it
 		if it.HasNext() {
 			item.key = it.Key()
-			item.value = it.Value()
+			item.value, item.err = it.Value()
 			m.pq.Push(item)
 			m.pq.update(item)
 		}
@@ -89,8 +95,8 @@ func (m *mergedIterator) Key() uint32 {
 }
 
 // Value returns the value of the current key/value pair
-func (m *mergedIterator) Value() []byte {
-	return m.curValue
+func (m *mergedIterator) Value() ([]byte, error) {
+	return m.curValue, m.curErr
 }
 
 // item represents an item under priority queue, using key as priority.
@@ -99,6 +105,7 @@ type item struct {
 
 	key   uint32
 	value []byte
+	err   error
 
 	index int
 }
@@ -36,7 +36,9 @@ func TestMergedIterator(t *testing.T) {
 	i := 0
 	for mergedIt.HasNext() {
 		assert.Equal(t, keys[i], mergedIt.Key())
-		assert.Equal(t, expects[keys[i]], mergedIt.Value())
+		value, err := mergedIt.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, expects[keys[i]], value)
 		i++
 	}
 	assert.Equal(t, len(keys), i)
@@ -54,7 +56,9 @@ func TestMergedIterator(t *testing.T) {
 	i = 0
 	for mergedIt.HasNext() {
 		assert.Equal(t, keys[i], mergedIt.Key())
-		assert.Equal(t, expects[keys[i]], mergedIt.Value())
+		value, err := mergedIt.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, expects[keys[i]], value)
 		i++
 	}
 	assert.Equal(t, len(keys), i)
@@ -88,7 +92,9 @@ func TestMergedIterator(t *testing.T) {
 	i = 0
 	for mergedIt.HasNext() {
 		assert.Equal(t, keys[i], mergedIt.Key())
-		assert.Equal(t, expects[keys[i]], mergedIt.Value())
+		value, err := mergedIt.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, expects[keys[i]], value)
 		i++
 	}
 	assert.Equal(t, len(keys), i)
@@ -130,12 +136,34 @@ func TestMergedIterator_complex(t *testing.T) {
 	i := 0
 	for mergedIt.HasNext() {
 		assert.Equal(t, keys[i], mergedIt.Key())
-		assert.Equal(t, expects[keys[i]], mergedIt.Value())
+		value, err := mergedIt.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, expects[keys[i]], value)
 		i++
 	}
 	assert.Equal(t, len(keys), i)
 }
 
+func TestMergedIterator_Value_Err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	it1 := NewMockIterator(ctrl)
+	gomock.InOrder(
+		it1.EXPECT().HasNext().Return(true),
+		it1.EXPECT().Key().Return(uint32(10)),
+		it1.EXPECT().Value().Return(nil, ErrChecksumMismatch),
+		it1.EXPECT().HasNext().Return(false),
+	)
+	mergedIt := NewMergedIterator([]Iterator{it1})
+	assert.True(t, mergedIt.HasNext())
+	assert.Equal(t, uint32(10), mergedIt.Key())
+	value, err := mergedIt.Value()
+	assert.Equal(t, ErrChecksumMismatch, err)
+	assert.Nil(t, value)
+	assert.False(t, mergedIt.HasNext())
+}
+
 func generateIterator(ctrl *gomock.Controller, values map[uint32][]byte) *MockIterator {
 	it1 := NewMockIterator(ctrl)
 	var keys []uint32
@@ -150,7 +178,7 @@ func generateIterator(ctrl *gomock.Controller, values map[uint32][]byte) *MockIt
 		calls = append(calls,
 			it1.EXPECT().HasNext().Return(true),
 			it1.EXPECT().Key().Return(key),
-			it1.EXPECT().Value().Return(values[key]))
+			it1.EXPECT().Value().Return(values[key], nil))
 	}
 	calls = append(calls, it1.EXPECT().HasNext().Return(false))
 
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"hash/crc32"
 
 	"github.com/lindb/roaring"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
@@ -27,10 +29,15 @@ var (
 type Reader interface {
 	// Path returns the file path
 	Path() string
-	// Get returns value for giving key, if key not exist, return nil, false
-	Get(key uint32) ([]byte, bool)
+	// Get returns value for giving key, if key not exist, return constants.ErrNotFound,
+	// returns error if value is corrupted or cannot be decompressed.
+	Get(key uint32) ([]byte, error)
 	// Iterator iterates over a store's key/value pairs in key order.
 	Iterator() Iterator
+	// Compression returns the compression type of values in store file
+	Compression() CompressionType
+	// Size returns the length of store file
+	Size() int64
 	// Close closes reader, release related resources
 	Close() error
 }
@@ -42,10 +49,20 @@ type storeMMapReader struct {
 	len     int                          // length of the file
 	keys    *roaring.Bitmap              // bitmap of keys
 	offsets *encoding.FixedOffsetDecoder // offset of values
+
+	compression   CompressionType // compression type of values
+	verifyMode    VerifyMode      // checksum verification mode
+	valueChecksum bool            // if each value has crc32 checksum(file layout version2)
+
+	fileVersion   uint8  // file layout version
+	posOfOffset   int    // position of offsets block
+	posOfKeys     int    // position of keys block
+	indexChecksum uint32 // crc32 checksum of index blocks(file layout version2)
 }
 
-// newMMapStoreReader creates mmap store file reader
-func newMMapStoreReader(path string) (r Reader, err error) {
+// newMMapStoreReader creates mmap store file reader, verifies the checksums by verify mode,
+// returns ErrChecksumMismatch if file is corrupted.
+func newMMapStoreReader(path string, verifyMode VerifyMode) (r Reader, err error) {
 	data, err := mapFunc(path)
 	defer func() {
 		if err != nil && len(data) > 0 {
@@ -64,35 +81,69 @@ func newMMapStoreReader(path string) (r Reader, err error) {
 		return
 	}
 	reader := &storeMMapReader{
-		path: path,
-		data: data,
-		len:  len(data),
-		keys: roaring.New(),
+		path:       path,
+		data:       data,
+		len:        len(data),
+		keys:       roaring.New(),
+		verifyMode: verifyMode,
 	}
 
-	if err := reader.initialize(); err != nil {
+	if err = reader.initialize(); err != nil {
 		return nil, err
 	}
+	if verifyMode == VerifyFull {
+		if err = reader.verifyValues(); err != nil {
+			return nil, err
+		}
+	}
 
 	return reader, nil
 }
 
 // initialize initializes store reader, reads index block(keys,offset etc.), then caches it
 func (r *storeMMapReader) initialize() error {
-	buf := r.readBytes(r.len - sstFileFooterSize)
-	if (len(buf)) != sstFileFooterSize-1 {
+	// version is before magic-number in footer for all file layout versions
+	fileVersion := r.data[r.len-9]
+	footerSize := sstFileFooterSize
+	switch fileVersion {
+	case version1:
+		footerSize = sstFileFooterSizeV1
+	case version2:
+		footerSize = sstFileFooterSizeV2
+	}
+	buf := r.readBytes(r.len - footerSize)
+	if (len(buf)) != footerSize-1 {
 		return fmt.Errorf("read sstfile:%s footer error", r.path)
 	}
 	// validate magic-number
-	if uint64Func(buf[9:]) != magicNumberOffsetFile {
+	if uint64Func(buf[footerSize-9:]) != magicNumberOffsetFile {
 		return fmt.Errorf("verify magic-number of sstfile:%s failure", r.path)
 	}
-	posOfOffset := int(binary.LittleEndian.Uint32(buf[:4]))
-	posOfKeys := int(binary.LittleEndian.Uint32(buf[4:8]))
-	if err := encoding.BitmapUnmarshal(r.keys, r.readBytes(posOfKeys)); err != nil {
+	switch fileVersion {
+	case version1:
+		r.compression = CompressionType(buf[8])
+	case version2:
+		r.compression = CompressionType(buf[12])
+		r.valueChecksum = true
+	}
+	r.fileVersion = fileVersion
+	r.posOfOffset = int(binary.LittleEndian.Uint32(buf[:4]))
+	r.posOfKeys = int(binary.LittleEndian.Uint32(buf[4:8]))
+	keys := r.readBytes(r.posOfKeys)
+	offset := r.readBytes(r.posOfOffset)
+	if fileVersion == version2 {
+		r.indexChecksum = binary.LittleEndian.Uint32(buf[8:12])
+	}
+	if fileVersion == version2 && r.verifyMode != VerifyOff {
+		// verify checksum of index blocks
+		if crc32.Update(crc32.ChecksumIEEE(offset), crc32.IEEETable, keys) != r.indexChecksum {
+			footerChecksumFailures.Inc()
+			return fmt.Errorf("%w: verify index blocks of sstfile:%s failure", ErrChecksumMismatch, r.path)
+		}
+	}
+	if err := encoding.BitmapUnmarshal(r.keys, keys); err != nil {
 		return fmt.Errorf("unmarshal keys data from file[%s] error:%s", r.path, err)
 	}
-	offset := r.readBytes(posOfOffset)
 	r.offsets = encoding.NewFixedOffsetDecoder(offset)
 
 	if r.offsets.Size() != int(r.keys.GetCardinality()) {
@@ -106,15 +157,20 @@ func (r *storeMMapReader) Path() string {
 	return r.path
 }
 
-// Get return value for key, if not exist return nil,false
-func (r *storeMMapReader) Get(key uint32) ([]byte, bool) {
+// Get return value for key, if not exist return constants.ErrNotFound,
+// returns error if value is corrupted or cannot be decompressed.
+func (r *storeMMapReader) Get(key uint32) ([]byte, error) {
 	if !r.keys.Contains(key) {
-		return nil, false
+		return nil, constants.ErrNotFound
 	}
 	// bitmap data's index from 1, so idx= get index - 1
 	idx := r.keys.Rank(key)
 	offset, _ := r.offsets.Get(int(idx) - 1)
-	return r.readBytes(offset), true
+	value, err := r.readValue(offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read value[key:%d] of sstfile:%s failure", err, key, r.path)
+	}
+	return value, nil
 }
 
 // Iterator iterates over a store's key/value pairs in key order.
@@ -122,6 +178,16 @@ func (r *storeMMapReader) Iterator() Iterator {
 	return newMMapIterator(r)
 }
 
+// Compression returns the compression type of values in store file
+func (r *storeMMapReader) Compression() CompressionType {
+	return r.compression
+}
+
+// Size returns the length of store file
+func (r *storeMMapReader) Size() int64 {
+	return int64(r.len)
+}
+
 // close store reader, release resource
 func (r *storeMMapReader) Close() error {
 	return fileutil.Unmap(r.data)
@@ -142,6 +208,42 @@ func (r *storeMMapReader) readBytes(offset int) []byte {
 	return r.data[start:end]
 }
 
+// verifyValues verifies the checksums of all values in store file
+func (r *storeMMapReader) verifyValues() error {
+	if !r.valueChecksum {
+		return nil
+	}
+	for idx := 0; idx < r.offsets.Size(); idx++ {
+		offset, _ := r.offsets.Get(idx)
+		if _, err := verifyChecksum(r.readBytes(offset)); err != nil {
+			valueChecksumFailures.Inc()
+			return fmt.Errorf("%w: verify value[index:%d] of sstfile:%s failure", err, idx, r.path)
+		}
+	}
+	return nil
+}
+
+// readValue reads value from buffer, verifies checksum by verify mode, then decompresses it by compression type
+func (r *storeMMapReader) readValue(offset int) ([]byte, error) {
+	value := r.readBytes(offset)
+	if r.valueChecksum {
+		if r.verifyMode == VerifyOff {
+			// skip checksum
+			if len(value) < valueChecksumSize {
+				return nil, ErrChecksumMismatch
+			}
+			value = value[:len(value)-valueChecksumSize]
+		} else {
+			var err error
+			if value, err = verifyChecksum(value); err != nil {
+				valueChecksumFailures.Inc()
+				return nil, err
+			}
+		}
+	}
+	return r.compression.decompress(value)
+}
+
 // storeMMapIterator iterates k/v pair using mmap store reader
 type storeMMapIterator struct {
 	reader *storeMMapReader
@@ -170,9 +272,14 @@ func (it *storeMMapIterator) Key() uint32 {
 	return key
 }
 
-// Value returns the value of the current key/value pair
-func (it *storeMMapIterator) Value() []byte {
+// Value returns the value of the current key/value pair,
+// returns error if value is corrupted or cannot be decompressed.
+func (it *storeMMapIterator) Value() ([]byte, error) {
 	offset, _ := it.reader.offsets.Get(it.idx)
 	it.idx++
-	return it.reader.readBytes(offset)
+	value, err := it.reader.readValue(offset)
+	if err != nil {
+		return nil, fmt.Errorf("%w: read value[index:%d] of sstfile:%s failure", err, it.idx-1, it.reader.path)
+	}
+	return value, nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/fileutil"
 )
@@ -25,7 +26,7 @@ func TestReader_Fail(t *testing.T) {
 	mapFunc = func(path string) (bytes []byte, err error) {
 		return nil, fmt.Errorf("err")
 	}
-	reader, err := newMMapStoreReader(testKVPath + "/000010.sst")
+	reader, err := newMMapStoreReader(testKVPath+"/000010.sst", VerifyFooter)
 	assert.Error(t, err)
 	assert.Nil(t, reader)
 	// case 2: footer length err
@@ -35,14 +36,14 @@ func TestReader_Fail(t *testing.T) {
 	unmapFunc = func(data []byte) error {
 		return fmt.Errorf("err")
 	}
-	reader, err = newMMapStoreReader(testKVPath + "/000010.sst")
+	reader, err = newMMapStoreReader(testKVPath+"/000010.sst", VerifyFooter)
 	assert.Error(t, err)
 	assert.Nil(t, reader)
 	// case 3: init err
 	mapFunc = func(path string) (bytes []byte, err error) {
 		return []byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 0, 1, 2, 3, 4, 5}, nil
 	}
-	reader, err = newMMapStoreReader(testKVPath + "/000010.sst")
+	reader, err = newMMapStoreReader(testKVPath+"/000010.sst", VerifyFooter)
 	assert.Error(t, err)
 	assert.Nil(t, reader)
 }
@@ -55,7 +56,7 @@ func TestStoreMMapReader_readBytes_Err(t *testing.T) {
 		encoding.BitmapUnmarshal = bitmapUnmarshal
 		_ = os.RemoveAll(testKVPath)
 	}()
-	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst")
+	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst", NoCompression)
 	assert.NoError(t, err)
 
 	_ = builder.Add(1, []byte("test"))
@@ -67,14 +68,14 @@ func TestStoreMMapReader_readBytes_Err(t *testing.T) {
 	uvarintFunc = func(r io.ByteReader) (u uint64, err error) {
 		return 0, fmt.Errorf("err")
 	}
-	r, err := newMMapStoreReader(testKVPath + "/000010.sst")
+	r, err := newMMapStoreReader(testKVPath+"/000010.sst", VerifyFooter)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 	// case 2: length > end
 	uvarintFunc = func(r io.ByteReader) (u uint64, err error) {
 		return 1000000, nil
 	}
-	r, err = newMMapStoreReader(testKVPath + "/000010.sst")
+	r, err = newMMapStoreReader(testKVPath+"/000010.sst", VerifyFooter)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 	// case 3: read magic number err
@@ -82,7 +83,7 @@ func TestStoreMMapReader_readBytes_Err(t *testing.T) {
 	uint64Func = func(b []byte) uint64 {
 		return 0
 	}
-	r, err = newMMapStoreReader(testKVPath + "/000010.sst")
+	r, err = newMMapStoreReader(testKVPath+"/000010.sst", VerifyFooter)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 	// case 4: unmarshal keys err
@@ -90,7 +91,7 @@ func TestStoreMMapReader_readBytes_Err(t *testing.T) {
 	encoding.BitmapUnmarshal = func(bitmap *roaring.Bitmap, data []byte) error {
 		return fmt.Errorf("err")
 	}
-	r, err = newMMapStoreReader(testKVPath + "/000010.sst")
+	r, err = newMMapStoreReader(testKVPath+"/000010.sst", VerifyFooter)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 	// case 5: offset's size != key's size
@@ -98,7 +99,7 @@ func TestStoreMMapReader_readBytes_Err(t *testing.T) {
 		bitmap.AddRange(1, 1000)
 		return nil
 	}
-	r, err = newMMapStoreReader(testKVPath + "/000010.sst")
+	r, err = newMMapStoreReader(testKVPath+"/000010.sst", VerifyFooter)
 	assert.Error(t, err)
 	assert.Nil(t, r)
 }
@@ -109,7 +110,7 @@ func TestReader(t *testing.T) {
 		_ = os.RemoveAll(testKVPath)
 	}()
 
-	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst")
+	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst", NoCompression)
 	assert.NoError(t, err)
 
 	_ = builder.Add(1, []byte("test"))
@@ -118,7 +119,7 @@ func TestReader(t *testing.T) {
 	err = builder.Close()
 	assert.Nil(t, err)
 
-	cache := NewCache(testKVPath)
+	cache := NewCache(testKVPath, VerifyFooter)
 
 	reader, err := cache.GetReader("", "000010.sst")
 	assert.NoError(t, err)
@@ -133,8 +134,8 @@ func TestReader(t *testing.T) {
 	defer func() {
 		_ = reader.Close()
 	}()
-	value, ok := reader.Get(100)
-	assert.False(t, ok)
+	value, err := reader.Get(100)
+	assert.Equal(t, constants.ErrNotFound, err)
 	assert.Nil(t, value)
 
 	value, _ = reader.Get(1)
@@ -142,7 +143,8 @@ func TestReader(t *testing.T) {
 	value, _ = reader.Get(10)
 	assert.Equal(t, []byte("test10"), value)
 	cache.Evict("", "000100.sst")
-	_ = reader.Close()
+	cache.Release(reader)
+	cache.Release(reader)
 	cache.Evict("", "000010.sst")
 	_ = cache.Close()
 }
@@ -152,7 +154,7 @@ func TestStoreIterator(t *testing.T) {
 	defer func() {
 		_ = os.RemoveAll(testKVPath)
 	}()
-	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst")
+	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst", NoCompression)
 	assert.NoError(t, err)
 
 	_ = builder.Add(1, []byte("test"))
@@ -161,7 +163,7 @@ func TestStoreIterator(t *testing.T) {
 	err = builder.Close()
 	assert.Nil(t, err)
 
-	cache := NewCache(testKVPath)
+	cache := NewCache(testKVPath, VerifyFooter)
 	reader, err := cache.GetReader("", "000010.sst")
 	assert.NoError(t, err)
 
@@ -171,11 +173,107 @@ func TestStoreIterator(t *testing.T) {
 	it := reader.Iterator()
 	assert.True(t, it.HasNext())
 	assert.Equal(t, uint32(1), it.Key())
-	assert.Equal(t, []byte("test"), it.Value())
+	value, err := it.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test"), value)
 
 	assert.True(t, it.HasNext())
 	assert.Equal(t, uint32(10), it.Key())
-	assert.Equal(t, []byte("test10"), it.Value())
+	value, err = it.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test10"), value)
 
 	assert.False(t, it.HasNext())
 }
+
+func TestReader_Compression(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testKVPath)
+	defer func() {
+		_ = os.RemoveAll(testKVPath)
+	}()
+	for _, compression := range []CompressionType{NoCompression, SnappyCompression, ZstdCompression} {
+		fileName := testKVPath + "/000010.sst"
+		builder, err := NewStoreBuilder(10, fileName, compression)
+		assert.NoError(t, err)
+		assert.Equal(t, compression, builder.Compression())
+		_ = builder.Add(1, []byte("test"))
+		_ = builder.Add(10, []byte("test10"))
+		assert.NoError(t, builder.Close())
+
+		reader, err := newMMapStoreReader(fileName, VerifyFooter)
+		assert.NoError(t, err)
+		assert.Equal(t, compression, reader.Compression())
+		value, err := reader.Get(10)
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("test10"), value)
+		it := reader.Iterator()
+		assert.True(t, it.HasNext())
+		assert.Equal(t, uint32(1), it.Key())
+		value, err = it.Value()
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("test"), value)
+		_ = reader.Close()
+		_ = os.Remove(fileName)
+	}
+}
+
+func TestReader_Decompress_Err(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testKVPath)
+	defer func() {
+		_ = os.RemoveAll(testKVPath)
+	}()
+	builder, err := NewStoreBuilder(10, testKVPath+"/000010.sst", NoCompression)
+	assert.NoError(t, err)
+	_ = builder.Add(1, []byte("test"))
+	assert.NoError(t, builder.Close())
+	reader, err := newMMapStoreReader(testKVPath+"/000010.sst", VerifyFooter)
+	assert.NoError(t, err)
+	defer func() {
+		_ = reader.Close()
+	}()
+	// mock value compressed by snappy, but data is invalid
+	reader.(*storeMMapReader).compression = SnappyCompression
+	value, err := reader.Get(1)
+	assert.Error(t, err)
+	assert.Nil(t, value)
+	it := reader.Iterator()
+	assert.True(t, it.HasNext())
+	assert.Equal(t, uint32(1), it.Key())
+	value, err = it.Value()
+	assert.Error(t, err)
+	assert.Nil(t, value)
+}
+
+func TestReader_Version0(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testKVPath)
+	defer func() {
+		_ = os.RemoveAll(testKVPath)
+	}()
+	// write sst file with file layout version0(without compression in footer)
+	fileName := testKVPath + "/000010.sst"
+	writer, err := newBufioWriterFunc(fileName)
+	assert.NoError(t, err)
+	_, _ = writer.Write([]byte("test"))
+	offset := encoding.NewFixedOffsetEncoder()
+	offset.Add(0)
+	posOfOffset := writer.Size()
+	_, _ = writer.Write(offset.MarshalBinary())
+	keys, _ := encoding.BitmapMarshal(roaring.BitmapOf(1))
+	posOfKeys := writer.Size()
+	_, _ = writer.Write(keys)
+	var buf [17]byte
+	binary.LittleEndian.PutUint32(buf[:4], uint32(posOfOffset))
+	binary.LittleEndian.PutUint32(buf[4:8], uint32(posOfKeys))
+	buf[8] = version0
+	binary.LittleEndian.PutUint64(buf[9:], magicNumberOffsetFile)
+	_, _ = writer.Write(buf[:])
+	assert.NoError(t, writer.Close())
+
+	reader, err := newMMapStoreReader(fileName, VerifyFooter)
+	assert.NoError(t, err)
+	assert.Equal(t, NoCompression, reader.Compression())
+	value, err := reader.Get(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("test"), value)
+	_ = reader.Close()
+}
@@ -33,7 +33,7 @@ type FamilyVersion interface {
 	// GetLiveRollupFiles returns all need rollup files
 	GetLiveRollupFiles() map[table.FileNumber]timeutil.Interval
 	// GetLiveReferenceFiles returns all rollup reference files
-	GetLiveReferenceFiles() map[FamilyID][]table.FileNumber
+	GetLiveReferenceFiles() map[ReferenceKey][]table.FileNumber
 	// removeVersion removes version from active versions
 	removeVersion(v Version)
 	// appendVersion swaps family's current version, then releases previous version
@@ -114,7 +114,7 @@ func (fv *familyVersion) GetLiveRollupFiles() map[table.FileNumber]timeutil.Inte
 }
 
 // GetLiveReferenceFiles returns all rollup reference files
-func (fv *familyVersion) GetLiveReferenceFiles() map[FamilyID][]table.FileNumber {
+func (fv *familyVersion) GetLiveReferenceFiles() map[ReferenceKey][]table.FileNumber {
 	fv.mutex.RLock()
 	defer fv.mutex.RUnlock()
 	return fv.current.GetReferenceFiles()
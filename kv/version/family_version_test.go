@@ -81,6 +81,7 @@ func TestFamilyVersion(t *testing.T) {
 	// test append new version then remove old version
 	version1 = snapshot2.GetCurrent()
 	version2 = version1.Clone()
+	cache.EXPECT().Release(reader).Times(3)
 	snapshot2.Close()
 
 	familyVersion1.appendVersion(version2)
@@ -3,6 +3,8 @@ package version
 import (
 	"fmt"
 
+	"github.com/lindb/roaring"
+
 	"github.com/lindb/lindb/kv/table"
 )
 
@@ -44,6 +46,18 @@ func (f *FileMeta) GetFileSize() int32 {
 	return f.fileSize
 }
 
+// MayContainKeys returns if any key of keys is in the key range of sst file
+func (f *FileMeta) MayContainKeys(keys *roaring.Bitmap) bool {
+	if keys == nil || keys.IsEmpty() {
+		return false
+	}
+	count := keys.Rank(f.maxKey)
+	if f.minKey > 0 {
+		count -= keys.Rank(f.minKey - 1)
+	}
+	return count > 0
+}
+
 // String returns the string value of file meta
 func (f *FileMeta) String() string {
 	return fmt.Sprintf("{fileNumber:%d,min:%d,max:%d,size:%d}", f.fileNumber, f.minKey, f.maxKey, f.fileSize)
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/kv/table"
@@ -20,3 +21,16 @@ func TestFileMeta(t *testing.T) {
 		f.fileNumber, f.minKey, f.maxKey, f.fileSize),
 		f.String())
 }
+
+func TestFileMeta_MayContainKeys(t *testing.T) {
+	f := NewFileMeta(10, 2, 40, 1024)
+	assert.False(t, f.MayContainKeys(nil))
+	assert.False(t, f.MayContainKeys(roaring.New()))
+	assert.False(t, f.MayContainKeys(roaring.BitmapOf(1, 41, 100)))
+	assert.True(t, f.MayContainKeys(roaring.BitmapOf(1, 2)))
+	assert.True(t, f.MayContainKeys(roaring.BitmapOf(40, 100)))
+	assert.True(t, f.MayContainKeys(roaring.BitmapOf(20)))
+	f = NewFileMeta(10, 0, 10, 1024)
+	assert.True(t, f.MayContainKeys(roaring.BitmapOf(0)))
+	assert.False(t, f.MayContainKeys(roaring.BitmapOf(11)))
+}
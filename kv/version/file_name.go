@@ -14,6 +14,7 @@ const TmpSuffix = "tmp"
 const Lock = "LOCK"
 const Options = "OPTIONS"
 const ManifestPrefix = "MANIFEST-"
+const Current = "CURRENT"
 
 // FileType represents a file type.
 type FileType int
@@ -35,7 +36,7 @@ type FileDesc struct {
 
 // current returns current file name for saving manifest file name
 func current() string {
-	return "CURRENT"
+	return Current
 }
 
 // Table returns the sst's file name
@@ -0,0 +1,68 @@
+package version
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+// Manifest represents the edit logs of current manifest file in kv store, used by offline inspection tool
+type Manifest struct {
+	FileName string    // current manifest file name
+	EditLogs []EditLog // all edit logs in manifest file
+}
+
+// ReadManifest reads all edit logs of current manifest file under store path without opening the store
+func ReadManifest(storePath string) (*Manifest, error) {
+	v, err := readFileFunc(filepath.Join(storePath, current()))
+	if err != nil {
+		return nil, fmt.Errorf("read current file error:%s", err)
+	}
+	manifest := &Manifest{FileName: string(v)}
+	manifestPath := filepath.Join(storePath, manifest.FileName)
+	reader, err := newBufferReaderFunc(manifestPath)
+	if err != nil {
+		return nil, fmt.Errorf("create manifest reader error:%s", err)
+	}
+	defer func() {
+		if e := reader.Close(); e != nil {
+			versionLogger.Error("close manifest reader error",
+				logger.String("manifest", manifestPath), logger.Error(e))
+		}
+	}()
+	for reader.Next() {
+		record, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("read edit log from manifest file error:%s", err)
+		}
+		editLog := newEmptyEditLogFunc()
+		if err := editLog.unmarshal(record); err != nil {
+			return nil, fmt.Errorf("unmarshal edit log data from manifest file error:%s", err)
+		}
+		manifest.EditLogs = append(manifest.EditLogs, editLog)
+	}
+	return manifest, nil
+}
+
+// Replay replays the edit logs of manifest, returns the current version of each family and next file number.
+func (m *Manifest) Replay(numOfLevels int) (versions map[FamilyID]Version, nextFileNumber table.FileNumber) {
+	vs := NewStoreVersionSet("", nil, numOfLevels).(*storeVersionSet)
+	versions = make(map[FamilyID]Version)
+	for _, editLog := range m.EditLogs {
+		familyID := editLog.FamilyID()
+		if familyID == StoreFamilyID {
+			editLog.applyVersionSet(vs)
+			continue
+		}
+		v, ok := versions[familyID]
+		if !ok {
+			v = vs.CreateFamilyVersion(strconv.Itoa(familyID.Int()), familyID).GetSnapshot().GetCurrent()
+			versions[familyID] = v
+		}
+		editLog.apply(v)
+	}
+	return versions, table.FileNumber(vs.nextFileNumber.Load())
+}
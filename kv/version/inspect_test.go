@@ -0,0 +1,90 @@
+package version
+
+import (
+	"fmt"
+	"io/ioutil"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/bufioutil"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestReadManifest(t *testing.T) {
+	initVersionSetTestData()
+	ctrl := gomock.NewController(t)
+	defer func() {
+		readFileFunc = ioutil.ReadFile
+		newBufferReaderFunc = bufioutil.NewBufioReader
+		newEmptyEditLogFunc = newEmptyEditLog
+		destroyVersionTestData()
+		ctrl.Finish()
+	}()
+	cache := table.NewMockCache(ctrl)
+	vs := NewStoreVersionSet(vsTestPath, cache, 2)
+	vs.CreateFamilyVersion("f", FamilyID(1))
+	vs.CreateFamilyVersion("f2", FamilyID(2))
+	assert.NoError(t, vs.Recover())
+	editLog := NewEditLog(1)
+	editLog.Add(CreateNewFile(0, NewFileMeta(12, 1, 100, 2014)))
+	editLog.Add(CreateNewFile(1, NewFileMeta(13, 1, 100, 2014)))
+	editLog.Add(CreateNewRollupFile(13, timeutil.Interval(10*1000)))
+	assert.NoError(t, vs.CommitFamilyEditLog("f", editLog))
+	editLog = NewEditLog(1)
+	editLog.Add(NewDeleteFile(0, 12))
+	assert.NoError(t, vs.CommitFamilyEditLog("f", editLog))
+	editLog = NewEditLog(2)
+	editLog.Add(CreateNewReferenceFile(ReferenceKey{Store: "20220101", FamilyID: 1}, 13))
+	assert.NoError(t, vs.CommitFamilyEditLog("f2", editLog))
+	nextFileNumber := vs.NextFileNumber()
+	assert.NoError(t, vs.Destroy())
+
+	// case 1: read manifest success
+	manifest, err := ReadManifest(vsTestPath)
+	assert.NoError(t, err)
+	assert.Equal(t, ManifestFileName(1), manifest.FileName)
+	assert.True(t, len(manifest.EditLogs) >= 3)
+	versions, fileNumber := manifest.Replay(2)
+	assert.Len(t, versions, 2)
+	assert.Empty(t, versions[1].GetFiles(0))
+	assert.Len(t, versions[1].GetFiles(1), 1)
+	assert.Equal(t, table.FileNumber(13), versions[1].GetFiles(1)[0].GetFileNumber())
+	assert.Equal(t, map[table.FileNumber]timeutil.Interval{13: timeutil.Interval(10 * 1000)}, versions[1].GetRollupFiles())
+	assert.Equal(t, map[ReferenceKey][]table.FileNumber{{Store: "20220101", FamilyID: 1}: {13}}, versions[2].GetReferenceFiles())
+	assert.True(t, fileNumber <= nextFileNumber)
+	// case 2: read current err
+	readFileFunc = func(filename string) ([]byte, error) {
+		return nil, fmt.Errorf("err")
+	}
+	_, err = ReadManifest(vsTestPath)
+	assert.Error(t, err)
+	readFileFunc = ioutil.ReadFile
+	// case 3: new reader err
+	newBufferReaderFunc = func(fileName string) (bufioutil.BufioReader, error) {
+		return nil, fmt.Errorf("err")
+	}
+	_, err = ReadManifest(vsTestPath)
+	assert.Error(t, err)
+	// case 4: read record err
+	reader := bufioutil.NewMockBufioReader(ctrl)
+	newBufferReaderFunc = func(fileName string) (bufioutil.BufioReader, error) {
+		return reader, nil
+	}
+	reader.EXPECT().Next().Return(true).AnyTimes()
+	reader.EXPECT().Close().Return(fmt.Errorf("err")).AnyTimes()
+	reader.EXPECT().Read().Return(nil, fmt.Errorf("err"))
+	_, err = ReadManifest(vsTestPath)
+	assert.Error(t, err)
+	// case 5: unmarshal edit log err
+	reader.EXPECT().Read().Return([]byte{1, 2, 3}, nil)
+	editLog2 := NewMockEditLog(ctrl)
+	newEmptyEditLogFunc = func() EditLog {
+		return editLog2
+	}
+	editLog2.EXPECT().unmarshal(gomock.Any()).Return(fmt.Errorf("err"))
+	_, err = ReadManifest(vsTestPath)
+	assert.Error(t, err)
+}
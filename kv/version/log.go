@@ -308,15 +308,17 @@ func (d *deleteRollupFile) apply(version Version) {
 
 // newReferenceFile represent version edit log for new reference file for rollup job
 type newReferenceFile struct {
+	store      string           // source store name
 	familyID   FamilyID         // source family id
 	fileNumber table.FileNumber // source file number
 }
 
 // CreateNewReferenceFile creates a new reference file
-func CreateNewReferenceFile(familyID FamilyID, fileNumber table.FileNumber) Log {
+func CreateNewReferenceFile(key ReferenceKey, fileNumber table.FileNumber) Log {
 	return &newReferenceFile{
 		fileNumber: fileNumber,
-		familyID:   familyID,
+		familyID:   key.FamilyID,
+		store:      key.Store,
 	}
 }
 
@@ -325,6 +327,8 @@ func (n *newReferenceFile) Encode() ([]byte, error) {
 	writer := stream.NewBufferWriter(nil)
 	writer.PutVarint64(n.fileNumber.Int64())
 	writer.PutVarint32(n.familyID.Int32())
+	writer.PutUvarint32(uint32(len(n.store)))
+	writer.PutBytes([]byte(n.store))
 	return writer.Bytes()
 }
 
@@ -333,30 +337,36 @@ func (n *newReferenceFile) Decode(v []byte) error {
 	reader := stream.NewReader(v)
 	n.fileNumber = table.FileNumber(reader.ReadVarint64())
 	n.familyID = FamilyID(reader.ReadVarint32())
+	if !reader.Empty() {
+		// old edit log hasn't source store name
+		n.store = string(reader.ReadSlice(int(reader.ReadUvarint32())))
+	}
 	return reader.Error()
 }
 
 // String returns string value of add reference file log
 func (n *newReferenceFile) String() string {
-	return fmt.Sprintf("addRefFile:{familyID:%d,fileNumber:%d}", n.familyID, n.fileNumber)
+	return fmt.Sprintf("addRefFile:{store:%s,familyID:%d,fileNumber:%d}", n.store, n.familyID, n.fileNumber)
 }
 
 // apply applies new reference file edit log to version
 func (n *newReferenceFile) apply(version Version) {
-	version.AddReferenceFile(n.familyID, n.fileNumber)
+	version.AddReferenceFile(ReferenceKey{Store: n.store, FamilyID: n.familyID}, n.fileNumber)
 }
 
 // deleteReferenceFile represent version edit log for remove reference file for rollup job
 type deleteReferenceFile struct {
+	store      string           // source store name
 	familyID   FamilyID         // source family id
 	fileNumber table.FileNumber // source file number
 }
 
 // CreateDeleteReferenceFile creates a delete reference file
-func CreateDeleteReferenceFile(familyID FamilyID, fileNumber table.FileNumber) Log {
+func CreateDeleteReferenceFile(key ReferenceKey, fileNumber table.FileNumber) Log {
 	return &deleteReferenceFile{
 		fileNumber: fileNumber,
-		familyID:   familyID,
+		familyID:   key.FamilyID,
+		store:      key.Store,
 	}
 }
 
@@ -365,6 +375,8 @@ func (n *deleteReferenceFile) Encode() ([]byte, error) {
 	writer := stream.NewBufferWriter(nil)
 	writer.PutVarint64(n.fileNumber.Int64())
 	writer.PutVarint32(n.familyID.Int32())
+	writer.PutUvarint32(uint32(len(n.store)))
+	writer.PutBytes([]byte(n.store))
 	return writer.Bytes()
 }
 
@@ -373,15 +385,19 @@ func (n *deleteReferenceFile) Decode(v []byte) error {
 	reader := stream.NewReader(v)
 	n.fileNumber = table.FileNumber(reader.ReadVarint64())
 	n.familyID = FamilyID(reader.ReadVarint32())
+	if !reader.Empty() {
+		// old edit log hasn't source store name
+		n.store = string(reader.ReadSlice(int(reader.ReadUvarint32())))
+	}
 	return reader.Error()
 }
 
 // String returns string value of delete reference file log
 func (n *deleteReferenceFile) String() string {
-	return fmt.Sprintf("deleteRefFile:{familyID:%d,fileNumber:%d}", n.familyID, n.fileNumber)
+	return fmt.Sprintf("deleteRefFile:{store:%s,familyID:%d,fileNumber:%d}", n.store, n.familyID, n.fileNumber)
 }
 
 // apply applies remove reference file edit log to version
 func (n *deleteReferenceFile) apply(version Version) {
-	version.DeleteReferenceFile(n.familyID, n.fileNumber)
+	version.DeleteReferenceFile(ReferenceKey{Store: n.store, FamilyID: n.familyID}, n.fileNumber)
 }
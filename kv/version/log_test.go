@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/stream"
 	"github.com/lindb/lindb/pkg/timeutil"
 )
 
@@ -122,7 +123,7 @@ func TestDeleteRollupFile(t *testing.T) {
 func TestNewReferenceFile(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	referenceFile := CreateNewReferenceFile(FamilyID(10), 12)
+	referenceFile := CreateNewReferenceFile(ReferenceKey{Store: "20220101", FamilyID: 10}, 12)
 	bytes, err := referenceFile.Encode()
 	assert.NoError(t, err)
 
@@ -134,14 +135,23 @@ func TestNewReferenceFile(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, referenceFile, referenceFile2)
 	version := NewMockVersion(ctrl)
-	version.EXPECT().AddReferenceFile(FamilyID(10), table.FileNumber(12))
+	version.EXPECT().AddReferenceFile(ReferenceKey{Store: "20220101", FamilyID: 10}, table.FileNumber(12))
 	referenceFile2.apply(version)
+	// decode old edit log without source store name
+	writer := stream.NewBufferWriter(nil)
+	writer.PutVarint64(12)
+	writer.PutVarint32(10)
+	bytes, _ = writer.Bytes()
+	referenceFile3 := &newReferenceFile{}
+	err = referenceFile3.Decode(bytes)
+	assert.NoError(t, err)
+	assert.Equal(t, &newReferenceFile{familyID: 10, fileNumber: 12}, referenceFile3)
 }
 
 func TestDeleteReferenceFile(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
-	referenceFile := CreateDeleteReferenceFile(FamilyID(10), 12)
+	referenceFile := CreateDeleteReferenceFile(ReferenceKey{Store: "20220101", FamilyID: 10}, 12)
 	bytes, err := referenceFile.Encode()
 	assert.NoError(t, err)
 
@@ -153,6 +163,6 @@ func TestDeleteReferenceFile(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, referenceFile, referenceFile2)
 	version := NewMockVersion(ctrl)
-	version.EXPECT().DeleteReferenceFile(FamilyID(10), table.FileNumber(12))
+	version.EXPECT().DeleteReferenceFile(ReferenceKey{Store: "20220101", FamilyID: 10}, table.FileNumber(12))
 	referenceFile2.apply(version)
 }
@@ -5,21 +5,28 @@ import (
 	"github.com/lindb/lindb/pkg/timeutil"
 )
 
+// ReferenceKey represents the source family of rollup reference files,
+// family id is only unique in store, so need store name to identify the source family.
+type ReferenceKey struct {
+	Store    string   // source store name
+	FamilyID FamilyID // source family id
+}
+
 // rollup represents the rollup metadata for rollup job.
 // source <=> target family reference
 type rollup struct {
 	// file number -> target interval type for raw family
 	rollupFiles map[table.FileNumber]timeutil.Interval // source family
-	// family id -> file number for source family,
+	// source store/family -> file number for source family,
 	// reference to raw family file number, reference add after rollup successfully
-	referenceFiles map[FamilyID][]table.FileNumber // target family
+	referenceFiles map[ReferenceKey][]table.FileNumber // target family
 }
 
 // newRollup creates the rollup job metadata
 func newRollup() *rollup {
 	return &rollup{
 		rollupFiles:    make(map[table.FileNumber]timeutil.Interval),
-		referenceFiles: make(map[FamilyID][]table.FileNumber),
+		referenceFiles: make(map[ReferenceKey][]table.FileNumber),
 	}
 }
 
@@ -43,10 +50,10 @@ func (r *rollup) getRollupFiles() map[table.FileNumber]timeutil.Interval {
 }
 
 // addReferenceFile adds rollup reference file under target family
-func (r *rollup) addReferenceFile(familyID FamilyID, fileNumber table.FileNumber) {
-	files, ok := r.referenceFiles[familyID]
+func (r *rollup) addReferenceFile(key ReferenceKey, fileNumber table.FileNumber) {
+	files, ok := r.referenceFiles[key]
 	if !ok {
-		r.referenceFiles[familyID] = []table.FileNumber{fileNumber}
+		r.referenceFiles[key] = []table.FileNumber{fileNumber}
 		return
 	}
 	for _, file := range files {
@@ -55,12 +62,12 @@ func (r *rollup) addReferenceFile(familyID FamilyID, fileNumber table.FileNumber
 		}
 	}
 	files = append(files, fileNumber)
-	r.referenceFiles[familyID] = files
+	r.referenceFiles[key] = files
 }
 
 // removeReferenceFile removes rollup reference file under target family
-func (r *rollup) removeReferenceFile(familyID FamilyID, fileNumber table.FileNumber) {
-	files, ok := r.referenceFiles[familyID]
+func (r *rollup) removeReferenceFile(key ReferenceKey, fileNumber table.FileNumber) {
+	files, ok := r.referenceFiles[key]
 	if !ok {
 		return
 	}
@@ -72,15 +79,15 @@ func (r *rollup) removeReferenceFile(familyID FamilyID, fileNumber table.FileNum
 	}
 	if len(newFiles) == 0 {
 		// if source files is empty, remove family reference
-		delete(r.referenceFiles, familyID)
+		delete(r.referenceFiles, key)
 		return
 	}
-	r.referenceFiles[familyID] = newFiles
+	r.referenceFiles[key] = newFiles
 }
 
 // getReferenceFiles returns the reference files under target family
-func (r *rollup) getReferenceFiles() map[FamilyID][]table.FileNumber {
-	result := make(map[FamilyID][]table.FileNumber)
+func (r *rollup) getReferenceFiles() map[ReferenceKey][]table.FileNumber {
+	result := make(map[ReferenceKey][]table.FileNumber)
 	for k, v := range r.referenceFiles {
 		d := make([]table.FileNumber, len(v))
 		copy(d, v)
@@ -24,20 +24,21 @@ func TestRollup_RollupFiles(t *testing.T) {
 
 func TestRollup_Reference(t *testing.T) {
 	rollup := newRollup()
-	rollup.addReferenceFile(10, 100)
-	rollup.addReferenceFile(10, 100)
+	key := ReferenceKey{Store: "20220101", FamilyID: 10}
+	rollup.addReferenceFile(key, 100)
+	rollup.addReferenceFile(key, 100)
 	result := rollup.getReferenceFiles()
-	assert.Equal(t, map[FamilyID][]table.FileNumber{10: {100}}, result)
-	rollup.addReferenceFile(10, 200)
+	assert.Equal(t, map[ReferenceKey][]table.FileNumber{key: {100}}, result)
+	rollup.addReferenceFile(key, 200)
 	result = rollup.getReferenceFiles()
-	assert.Equal(t, map[FamilyID][]table.FileNumber{10: {100, 200}}, result)
-	rollup.removeReferenceFile(100, 100)
+	assert.Equal(t, map[ReferenceKey][]table.FileNumber{key: {100, 200}}, result)
+	rollup.removeReferenceFile(ReferenceKey{Store: "20220102", FamilyID: 10}, 100)
 	result = rollup.getReferenceFiles()
-	assert.Equal(t, map[FamilyID][]table.FileNumber{10: {100, 200}}, result)
-	rollup.removeReferenceFile(10, 200)
+	assert.Equal(t, map[ReferenceKey][]table.FileNumber{key: {100, 200}}, result)
+	rollup.removeReferenceFile(key, 200)
 	result = rollup.getReferenceFiles()
-	assert.Equal(t, map[FamilyID][]table.FileNumber{10: {100}}, result)
-	rollup.removeReferenceFile(10, 100)
+	assert.Equal(t, map[ReferenceKey][]table.FileNumber{key: {100}}, result)
+	rollup.removeReferenceFile(key, 100)
 	result = rollup.getReferenceFiles()
 	assert.Empty(t, result)
 }
@@ -1,22 +1,27 @@
 package version
 
 import (
+	"sync"
+
 	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/pkg/logger"
 )
 
 //go:generate mockgen -source ./snapshot.go -destination=./snapshot_mock.go -package version
 
 // Snapshot represents a current family version for reading data.
-// NOTICE: current version will retain like ref count, so snapshot must close.
+// NOTICE: current version and file readers will retain like ref count, so snapshot must close.
 type Snapshot interface {
 	// GetCurrent returns current mutable version
 	GetCurrent() Version
-	// FindReaders finds all files include key
+	// FindReaders finds all files include key, the corrupted files are skipped
 	FindReaders(key uint32) ([]table.Reader, error)
 	// GetReader returns file reader
 	GetReader(fileNumber table.FileNumber) (table.Reader, error)
+	// Quarantine moves the corrupted file into quarantine directory, the file is skipped when reading
+	Quarantine(fileNumber table.FileNumber) error
 	// Close releases related resources
 	Close()
 }
@@ -27,6 +32,8 @@ type snapshot struct {
 	cache      table.Cache
 
 	version Version
+	readers []table.Reader // readers retained from cache, released when snapshot closed
+	mutex   sync.Mutex
 	closed  atomic.Bool
 }
 
@@ -45,7 +52,7 @@ func (s *snapshot) GetCurrent() Version {
 	return s.version
 }
 
-// FindReaders finds all files include key
+// FindReaders finds all files include key, the corrupted files are skipped
 func (s *snapshot) FindReaders(key uint32) ([]table.Reader, error) {
 	// find files related given key
 	//FIXME stone1100, need add lock for find files or clone version when new snapshot
@@ -54,10 +61,18 @@ func (s *snapshot) FindReaders(key uint32) ([]table.Reader, error) {
 	for _, fileMeta := range files {
 		// get store reader from cache
 		reader, err := s.cache.GetReader(s.familyName, Table(fileMeta.GetFileNumber()))
+		if table.IsCorrupted(err) {
+			// corrupted file is quarantined, skip it for reading other files
+			versionLogger.Warn("skip corrupted file when find readers",
+				logger.String("family", s.familyName),
+				logger.Int64("file", fileMeta.GetFileNumber().Int64()), logger.Error(err))
+			continue
+		}
 		if err != nil {
 			return nil, err
 		}
 		if reader != nil {
+			s.retain(reader)
 			readers = append(readers, reader)
 		}
 	}
@@ -66,13 +81,39 @@ func (s *snapshot) FindReaders(key uint32) ([]table.Reader, error) {
 
 // GetReader returns the file reader
 func (s *snapshot) GetReader(fileNumber table.FileNumber) (table.Reader, error) {
-	return s.cache.GetReader(s.familyName, Table(fileNumber))
+	reader, err := s.cache.GetReader(s.familyName, Table(fileNumber))
+	if err != nil {
+		return nil, err
+	}
+	if reader != nil {
+		s.retain(reader)
+	}
+	return reader, nil
+}
+
+// Quarantine moves the corrupted file into quarantine directory, the file is skipped when reading
+func (s *snapshot) Quarantine(fileNumber table.FileNumber) error {
+	return s.cache.Quarantine(s.familyName, Table(fileNumber))
 }
 
 // Close releases related resources
 func (s *snapshot) Close() {
 	// atomic set closed status, make sure only release once
 	if s.closed.CAS(false, true) {
+		s.mutex.Lock()
+		for _, reader := range s.readers {
+			s.cache.Release(reader)
+		}
+		s.readers = nil
+		s.mutex.Unlock()
+
 		s.version.Release()
 	}
 }
+
+// retain records the reader retained from cache, then releases it when snapshot closed
+func (s *snapshot) retain(reader table.Reader) {
+	s.mutex.Lock()
+	s.readers = append(s.readers, reader)
+	s.mutex.Unlock()
+}
@@ -50,7 +50,17 @@ func TestSnapshot_FindReaders(t *testing.T) {
 	readers, err = snapshot.FindReaders(uint32(80))
 	assert.Error(t, err)
 	assert.Nil(t, readers)
-	// case 7: close snapshot
+	// case 7: skip corrupted file
+	cache.EXPECT().GetReader("test", Table(table.FileNumber(10))).
+		Return(nil, fmt.Errorf("%w: corrupted", table.ErrChecksumMismatch))
+	readers, err = snapshot.FindReaders(uint32(80))
+	assert.NoError(t, err)
+	assert.Empty(t, readers)
+	// case 8: quarantine corrupted file
+	cache.EXPECT().Quarantine("test", Table(table.FileNumber(10))).Return(nil)
+	assert.NoError(t, snapshot.Quarantine(table.FileNumber(10)))
+	// case 9: close snapshot, release retained readers
+	cache.EXPECT().Release(gomock.Any()).Times(2)
 	v.EXPECT().Release()
 	snapshot.Close()
 	snapshot.Close() // test version release only once
@@ -1,6 +1,7 @@
 package version
 
 import (
+	"github.com/lindb/roaring"
 	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/kv/table"
@@ -43,19 +44,22 @@ type Version interface {
 	// PickL0Compaction picks level0 compaction context,
 	// if hasn't congruent compaction return nil.
 	PickL0Compaction(compactThreshold int) *Compaction
+	// PickPurgeCompaction picks purge compaction context which includes all level0 files and
+	// the level1 files which overlap level0 files or may contain the keys, returns nil if no files picked.
+	PickPurgeCompaction(keys *roaring.Bitmap) *Compaction
 
 	// AddRollupFile adds need rollup file and target interval
 	AddRollupFile(fileNumber table.FileNumber, interval timeutil.Interval)
 	// DeleteRollupFile removes rollup file after rollup job complete successfully
 	DeleteRollupFile(fileNumber table.FileNumber)
 	// AddReferenceFile adds rollup reference file under target family
-	AddReferenceFile(familyID FamilyID, fileNumber table.FileNumber)
+	AddReferenceFile(key ReferenceKey, fileNumber table.FileNumber)
 	// DeleteReferenceFile removes rollup reference file under target family
-	DeleteReferenceFile(familyID FamilyID, fileNumber table.FileNumber)
+	DeleteReferenceFile(key ReferenceKey, fileNumber table.FileNumber)
 	// GetRollupFiles returns all need rollup files
 	GetRollupFiles() map[table.FileNumber]timeutil.Interval
 	// GetReferenceFiles returns the reference files under target family
-	GetReferenceFiles() map[FamilyID][]table.FileNumber
+	GetReferenceFiles() map[ReferenceKey][]table.FileNumber
 }
 
 // version is snapshot for current storage metadata includes levels/sst files
@@ -171,6 +175,32 @@ func (v *version) PickL0Compaction(compactThreshold int) *Compaction {
 	return NewCompaction(v.fv.GetID(), 0, levelInputs, levelUpInputs)
 }
 
+// PickPurgeCompaction picks purge compaction context which includes all level0 files and
+// the level1 files which overlap level0 files or may contain the keys, returns nil if no files picked.
+func (v *version) PickPurgeCompaction(keys *roaring.Bitmap) *Compaction {
+	levelInputs := v.GetFiles(0)
+	levelUpInputMap := make(map[table.FileNumber]*FileMeta)
+	for _, lowInput := range levelInputs {
+		upInputs := v.getOverlappingInputs(1, lowInput.GetMinKey(), lowInput.GetMaxKey())
+		for _, upInput := range upInputs {
+			levelUpInputMap[upInput.GetFileNumber()] = upInput
+		}
+	}
+	for _, upInput := range v.GetFiles(1) {
+		if upInput.MayContainKeys(keys) {
+			levelUpInputMap[upInput.GetFileNumber()] = upInput
+		}
+	}
+	if len(levelInputs) == 0 && len(levelUpInputMap) == 0 {
+		return nil
+	}
+	var levelUpInputs []*FileMeta
+	for _, upInput := range levelUpInputMap {
+		levelUpInputs = append(levelUpInputs, upInput)
+	}
+	return NewCompaction(v.fv.GetID(), 0, levelInputs, levelUpInputs)
+}
+
 // FindFiles finds all files include key from each level
 func (v *version) FindFiles(key uint32) []*FileMeta {
 	var files []*FileMeta
@@ -244,13 +274,13 @@ func (v *version) DeleteRollupFile(fileNumber table.FileNumber) {
 }
 
 // AddReferenceFile adds rollup reference file under target family
-func (v *version) AddReferenceFile(familyID FamilyID, fileNumber table.FileNumber) {
-	v.rollup.addReferenceFile(familyID, fileNumber)
+func (v *version) AddReferenceFile(key ReferenceKey, fileNumber table.FileNumber) {
+	v.rollup.addReferenceFile(key, fileNumber)
 }
 
 // DeleteReferenceFile removes rollup reference file under target family
-func (v *version) DeleteReferenceFile(familyID FamilyID, fileNumber table.FileNumber) {
-	v.rollup.removeReferenceFile(familyID, fileNumber)
+func (v *version) DeleteReferenceFile(key ReferenceKey, fileNumber table.FileNumber) {
+	v.rollup.removeReferenceFile(key, fileNumber)
 }
 
 // GetRollupFiles returns all need rollup files
@@ -259,7 +289,7 @@ func (v *version) GetRollupFiles() map[table.FileNumber]timeutil.Interval {
 }
 
 // GetReferenceFiles returns the reference files under target family
-func (v *version) GetReferenceFiles() map[FamilyID][]table.FileNumber {
+func (v *version) GetReferenceFiles() map[ReferenceKey][]table.FileNumber {
 	return v.rollup.getReferenceFiles()
 }
 
@@ -25,6 +25,9 @@ var (
 	newBufferReaderFunc = bufioutil.NewBufioReader
 	newBufferWriterFunc = bufioutil.NewBufioWriter
 	newEmptyEditLogFunc = newEmptyEditLog
+	mkDirFunc           = fileutil.MkDirIfNotExist
+	linkOrCopyFunc      = fileutil.LinkOrCopy
+	removeFileFunc      = fileutil.RemoveFile
 )
 
 // StoreVersionSet maintains all metadata for kv store
@@ -44,6 +47,12 @@ type StoreVersionSet interface {
 	CreateFamilyVersion(family string, familyID FamilyID) FamilyVersion
 	// GetFamilyVersion returns family version if exist, else return nil
 	GetFamilyVersion(family string) FamilyVersion
+	// DeleteFamilyVersion deletes family version by family name,
+	// then rolls a new manifest file without the edit logs of deleted family, removes the old manifest file.
+	DeleteFamilyVersion(family string) error
+	// Backup pins the current versions of all families, links or copies the files of pinned versions into target path,
+	// then writes a new manifest file of pinned versions, so that target path can be opened as a kv store.
+	Backup(targetPath string) error
 
 	// newVersionID generates new version id
 	newVersionID() int64
@@ -181,6 +190,121 @@ func (vs *storeVersionSet) GetFamilyVersion(family string) FamilyVersion {
 	return nil
 }
 
+// DeleteFamilyVersion deletes family version by family name,
+// then rolls a new manifest file without the edit logs of deleted family,
+// the old manifest file is removed after current file switched to new manifest file.
+func (vs *storeVersionSet) DeleteFamilyVersion(family string) error {
+	vs.mutex.Lock()
+	defer vs.mutex.Unlock()
+
+	familyVersion, ok := vs.familyVersions[family]
+	if !ok {
+		return nil
+	}
+	oldManifestFile, err := vs.readManifestFileName()
+	if err != nil {
+		return err
+	}
+	delete(vs.familyVersions, family)
+	delete(vs.familyIDs, familyVersion.GetID())
+
+	// old manifest file includes the edit logs of deleted family,
+	// must roll new manifest file, else recover fail when reopen store.
+	oldManifest := vs.manifest
+	oldManifestFileNumber := vs.manifestFileNumber.Load()
+	vs.manifest = nil
+	vs.manifestFileNumber.Store(int64(vs.NextFileNumber()))
+	if err := vs.initJournal(); err != nil {
+		// current file isn't switched if init journal fail, removes the new manifest file written partially
+		vs.removeManifest(ManifestFileName(table.FileNumber(vs.manifestFileNumber.Load())))
+		vs.manifest = oldManifest
+		vs.manifestFileNumber.Store(oldManifestFileNumber)
+		vs.familyVersions[family] = familyVersion
+		vs.familyIDs[familyVersion.GetID()] = family
+		return err
+	}
+	if oldManifest != nil {
+		if err := oldManifest.Close(); err != nil {
+			versionLogger.Warn("close old manifest file error",
+				logger.String("path", vs.storePath), logger.Error(err))
+		}
+	}
+	vs.removeManifest(oldManifestFile)
+	versionLogger.Info("delete family version and roll new manifest file",
+		logger.String("path", vs.storePath), logger.String("family", family))
+	return nil
+}
+
+// removeManifest removes the manifest file which isn't current manifest file
+func (vs *storeVersionSet) removeManifest(manifestFileName string) {
+	manifestPath := vs.getManifestFilePath(manifestFileName)
+	if err := removeFileFunc(manifestPath); err != nil {
+		versionLogger.Warn("remove manifest file error",
+			logger.String("path", manifestPath), logger.Error(err))
+	}
+}
+
+// Backup pins the current versions of all families, links or copies the files of pinned versions into target path,
+// then writes a new manifest file of pinned versions, so that target path can be opened as a kv store.
+func (vs *storeVersionSet) Backup(targetPath string) error {
+	// pin current versions, the files of pinned versions cannot be deleted
+	vs.mutex.RLock()
+	familyNames := make(map[FamilyID]string, len(vs.familyIDs))
+	snapshots := make(map[FamilyID]Snapshot, len(vs.familyIDs))
+	for id, name := range vs.familyIDs {
+		familyNames[id] = name
+		snapshots[id] = vs.familyVersions[name].GetSnapshot()
+	}
+	nextFileNumber := table.FileNumber(vs.nextFileNumber.Load())
+	manifestFileName := ManifestFileName(table.FileNumber(vs.manifestFileNumber.Load()))
+	vs.mutex.RUnlock()
+
+	defer func() {
+		for _, snapshot := range snapshots {
+			snapshot.Close()
+		}
+	}()
+
+	var editLogs []EditLog
+	for familyID, snapshot := range snapshots {
+		familyName := familyNames[familyID]
+		familyPath := filepath.Join(targetPath, familyName)
+		if err := mkDirFunc(familyPath); err != nil {
+			return err
+		}
+		v := snapshot.GetCurrent()
+		for _, file := range v.GetAllFiles() {
+			fileName := Table(file.GetFileNumber())
+			if err := linkOrCopyFunc(filepath.Join(vs.storePath, familyName, fileName), filepath.Join(familyPath, fileName)); err != nil {
+				return fmt.Errorf("backup file[%s] of family[%s] error:%s", fileName, familyName, err)
+			}
+		}
+		editLogs = append(editLogs, newFamilySnapshotEditLog(familyID, v))
+	}
+	storeEditLog := NewEditLog(StoreFamilyID)
+	storeEditLog.Add(NewNextFileNumber(nextFileNumber))
+	editLogs = append(editLogs, storeEditLog)
+
+	// write manifest file of pinned versions, then set manifest file name into current file
+	writer, err := newBufferWriterFunc(filepath.Join(targetPath, manifestFileName))
+	if err != nil {
+		return err
+	}
+	if err := vs.persistEditLogs(writer, editLogs); err != nil {
+		_ = writer.Close()
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	if err := writeFileFunc(filepath.Join(targetPath, current()), []byte(manifestFileName), 0666); err != nil {
+		return fmt.Errorf("write manifest file name into current file error:%s", err)
+	}
+	versionLogger.Info("backup version set successfully",
+		logger.String("path", vs.storePath), logger.String("target", targetPath))
+	return nil
+}
+
 // Recover recover version set if exist, recover been invoked when kv store init.
 // Initialize if version file not exists, else recover old data then init journal writer.
 func (vs *storeVersionSet) Recover() error {
@@ -359,11 +483,16 @@ func (vs *storeVersionSet) createSnapshot() (editLogs []EditLog) {
 
 // createFamilySnapshot creates snapshot of edit log for family level
 func (vs *storeVersionSet) createFamilySnapshot(familyID FamilyID, familyVersion FamilyVersion) EditLog {
-	editLog := NewEditLog(familyID)
 	// save current version all active files
 	snapshot := familyVersion.GetSnapshot()
 	defer snapshot.Close()
-	levels := snapshot.GetCurrent().Levels()
+	return newFamilySnapshotEditLog(familyID, snapshot.GetCurrent())
+}
+
+// newFamilySnapshotEditLog creates edit log which includes all files of the version
+func newFamilySnapshotEditLog(familyID FamilyID, v Version) EditLog {
+	editLog := NewEditLog(familyID)
+	levels := v.Levels()
 	for numOfLevel, level := range levels {
 		files := level.getFiles()
 		for _, file := range files {
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -289,3 +291,170 @@ func destroyVersionTestData() {
 		fmt.Println("delete test path error")
 	}
 }
+
+func TestStoreVersionSet_DeleteFamilyVersion(t *testing.T) {
+	initVersionSetTestData()
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newBufferWriterFunc = bufioutil.NewBufioWriter
+		renameFunc = os.Rename
+		readFileFunc = ioutil.ReadFile
+		removeFileFunc = fileutil.RemoveFile
+		destroyVersionTestData()
+		ctrl.Finish()
+	}()
+	cache := table.NewMockCache(ctrl)
+
+	vs := NewStoreVersionSet(vsTestPath, cache, 2)
+	vs.CreateFamilyVersion("f", FamilyID(1))
+	vs.CreateFamilyVersion("f2", FamilyID(2))
+	err := vs.Recover()
+	assert.NoError(t, err)
+	for _, familyID := range []FamilyID{1, 2} {
+		editLog := NewEditLog(familyID)
+		editLog.Add(CreateNewFile(1, NewFileMeta(12, 1, 100, 2014)))
+		err = vs.CommitFamilyEditLog(map[FamilyID]string{1: "f", 2: "f2"}[familyID], editLog)
+		assert.NoError(t, err)
+	}
+	// case 1: delete not exist family
+	assert.NoError(t, vs.DeleteFamilyVersion("f3"))
+	// case 2: read current manifest file err
+	readFileFunc = func(filename string) ([]byte, error) {
+		return nil, fmt.Errorf("err")
+	}
+	assert.Error(t, vs.DeleteFamilyVersion("f2"))
+	assert.NotNil(t, vs.GetFamilyVersion("f2"))
+	readFileFunc = ioutil.ReadFile
+	// case 3: roll manifest err
+	manifestFileNumber := vs.ManifestFileNumber()
+	newBufferWriterFunc = func(fileName string) (bufioutil.BufioWriter, error) {
+		return nil, fmt.Errorf("err")
+	}
+	assert.Error(t, vs.DeleteFamilyVersion("f2"))
+	assert.Equal(t, manifestFileNumber, vs.ManifestFileNumber())
+	assert.NotNil(t, vs.GetFamilyVersion("f2"))
+	newBufferWriterFunc = bufioutil.NewBufioWriter
+	// case 4: switch current file err, removes new manifest file
+	manifestFiles := listManifestFiles(t)
+	assert.Len(t, manifestFiles, 1)
+	renameFunc = func(oldpath, newpath string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, vs.DeleteFamilyVersion("f2"))
+	assert.Equal(t, manifestFileNumber, vs.ManifestFileNumber())
+	assert.Equal(t, manifestFiles, listManifestFiles(t))
+	renameFunc = os.Rename
+	// case 5: delete family, removes old manifest file
+	assert.NoError(t, vs.DeleteFamilyVersion("f"))
+	assert.Nil(t, vs.GetFamilyVersion("f"))
+	assert.NotEqual(t, manifestFileNumber, vs.ManifestFileNumber())
+	newManifestFiles := listManifestFiles(t)
+	assert.Len(t, newManifestFiles, 1)
+	assert.NotEqual(t, manifestFiles, newManifestFiles)
+	// case 6: remove old manifest file err
+	removeFileFunc = func(file string) error {
+		return fmt.Errorf("err")
+	}
+	vs.CreateFamilyVersion("f3", FamilyID(3))
+	assert.NoError(t, vs.DeleteFamilyVersion("f3"))
+	_ = vs.Destroy()
+
+	// recover without deleted family
+	vs = NewStoreVersionSet(vsTestPath, cache, 2)
+	vs.CreateFamilyVersion("f2", FamilyID(2))
+	assert.NoError(t, vs.Recover())
+	snapshot := vs.GetFamilyVersion("f2").GetSnapshot()
+	assert.Len(t, snapshot.GetCurrent().GetAllFiles(), 1)
+	snapshot.Close()
+	_ = vs.Destroy()
+}
+
+func TestStoreVersionSet_Backup(t *testing.T) {
+	initVersionSetTestData()
+	ctrl := gomock.NewController(t)
+	defer func() {
+		mkDirFunc = fileutil.MkDirIfNotExist
+		linkOrCopyFunc = fileutil.LinkOrCopy
+		newBufferWriterFunc = bufioutil.NewBufioWriter
+		writeFileFunc = ioutil.WriteFile
+		destroyVersionTestData()
+		ctrl.Finish()
+	}()
+	cache := table.NewMockCache(ctrl)
+	vs := NewStoreVersionSet(vsTestPath, cache, 2)
+	familyID := FamilyID(1)
+	vs.CreateFamilyVersion("f", familyID)
+	assert.NoError(t, vs.Recover())
+	editLog := NewEditLog(familyID)
+	editLog.Add(CreateNewFile(1, NewFileMeta(12, 1, 100, 2014)))
+	assert.NoError(t, vs.CommitFamilyEditLog("f", editLog))
+	assert.NoError(t, fileutil.MkDirIfNotExist(filepath.Join(vsTestPath, "f")))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(vsTestPath, "f", Table(12)), []byte("data"), 0644))
+	defer func() {
+		_ = vs.Destroy()
+	}()
+
+	backupPath := filepath.Join(vsTestPath, "backup")
+	// case 1: mkdir err
+	mkDirFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, vs.Backup(backupPath))
+	mkDirFunc = fileutil.MkDirIfNotExist
+	// case 2: link file err
+	linkOrCopyFunc = func(source, target string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, vs.Backup(backupPath))
+	linkOrCopyFunc = fileutil.LinkOrCopy
+	// case 3: new manifest writer err
+	newBufferWriterFunc = func(fileName string) (bufioutil.BufioWriter, error) {
+		return nil, fmt.Errorf("err")
+	}
+	assert.Error(t, vs.Backup(backupPath))
+	// case 4: write manifest err
+	writer := bufioutil.NewMockBufioWriter(ctrl)
+	newBufferWriterFunc = func(fileName string) (bufioutil.BufioWriter, error) {
+		return writer, nil
+	}
+	writer.EXPECT().Write(gomock.Any()).Return(0, fmt.Errorf("err"))
+	writer.EXPECT().Close().Return(nil)
+	assert.Error(t, vs.Backup(backupPath))
+	// case 5: close manifest err
+	writer.EXPECT().Write(gomock.Any()).Return(10, nil).AnyTimes()
+	writer.EXPECT().Sync().Return(nil).AnyTimes()
+	writer.EXPECT().Close().Return(fmt.Errorf("err"))
+	assert.Error(t, vs.Backup(backupPath))
+	newBufferWriterFunc = bufioutil.NewBufioWriter
+	// case 6: write current file err
+	writeFileFunc = func(filename string, data []byte, perm os.FileMode) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, vs.Backup(backupPath))
+	writeFileFunc = ioutil.WriteFile
+	// case 7: backup successfully, recover from backup path
+	assert.NoError(t, vs.Backup(backupPath))
+	assert.True(t, fileutil.Exist(filepath.Join(backupPath, "f", Table(12))))
+	backup := NewStoreVersionSet(backupPath, cache, 2)
+	backup.CreateFamilyVersion("f", familyID)
+	assert.NoError(t, backup.Recover())
+	snapshot := backup.GetFamilyVersion("f").GetSnapshot()
+	files := snapshot.GetCurrent().GetAllFiles()
+	assert.Len(t, files, 1)
+	assert.Equal(t, table.FileNumber(12), files[0].GetFileNumber())
+	snapshot.Close()
+	assert.NoError(t, backup.Destroy())
+}
+
+// listManifestFiles returns the manifest files under test path
+func listManifestFiles(t *testing.T) []string {
+	files, err := fileutil.ListDir(vsTestPath)
+	assert.NoError(t, err)
+	var result []string
+	for _, file := range files {
+		if strings.HasPrefix(file, ManifestPrefix) {
+			result = append(result, file)
+		}
+	}
+	return result
+}
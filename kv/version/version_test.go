@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/kv/table"
@@ -162,6 +163,39 @@ func TestVersion_PickL0Compaction(t *testing.T) {
 	assert.Equal(t, 3, len(compaction.levelUpInputs))
 }
 
+func TestVersion_PickPurgeCompaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	fv := NewMockFamilyVersion(ctrl)
+	vs := NewMockStoreVersionSet(ctrl)
+	fv.EXPECT().GetVersionSet().Return(vs).AnyTimes()
+	fv.EXPECT().GetID().Return(FamilyID(1)).AnyTimes()
+	vs.EXPECT().numberOfLevels().Return(2).AnyTimes()
+	v := newVersion(1, fv)
+	// no files
+	assert.Nil(t, v.PickPurgeCompaction(roaring.BitmapOf(1)))
+
+	f3 := FileMeta{fileNumber: 3, minKey: 1, maxKey: 5}
+	f4 := FileMeta{fileNumber: 4, minKey: 100, maxKey: 200}
+	f5 := FileMeta{fileNumber: 5, minKey: 400, maxKey: 500}
+	v.AddFiles(1, []*FileMeta{&f3, &f4, &f5})
+	// no files contain keys
+	assert.Nil(t, v.PickPurgeCompaction(roaring.BitmapOf(50, 300)))
+	// pick level1 files which contain keys
+	compaction := v.PickPurgeCompaction(roaring.BitmapOf(3, 450))
+	assert.NotNil(t, compaction)
+	assert.Empty(t, compaction.levelInputs)
+	assert.Len(t, compaction.levelUpInputs, 2)
+	// pick all level0 files and overlapping level1 files
+	f1 := FileMeta{fileNumber: 1, minKey: 10, maxKey: 100}
+	v.AddFiles(0, []*FileMeta{&f1})
+	compaction = v.PickPurgeCompaction(roaring.BitmapOf(3))
+	assert.Equal(t, []*FileMeta{&f1}, compaction.levelInputs)
+	assert.Len(t, compaction.levelUpInputs, 2)
+	assert.False(t, compaction.IsTrivialMove())
+}
+
 func TestVersion_RollupJob(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -175,8 +209,9 @@ func TestVersion_RollupJob(t *testing.T) {
 	v.AddRollupFile(10, 3)
 	v.DeleteRollupFile(10)
 	assert.Empty(t, v.GetRollupFiles())
-	v.AddReferenceFile(10, 100)
-	v.AddReferenceFile(10, 10)
-	v.DeleteReferenceFile(10, 10)
-	assert.Equal(t, map[FamilyID][]table.FileNumber{10: {100}}, v.GetReferenceFiles())
+	key := ReferenceKey{Store: "20220101", FamilyID: 10}
+	v.AddReferenceFile(key, 100)
+	v.AddReferenceFile(key, 10)
+	v.DeleteReferenceFile(key, 10)
+	assert.Equal(t, map[ReferenceKey][]table.FileNumber{key: {100}}, v.GetReferenceFiles())
 }
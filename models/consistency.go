@@ -0,0 +1,124 @@
+package models
+
+import "sort"
+
+// FamilyDigest represents the digest of data family in shard replica, used for checking replica consistency.
+// the hashes are the sum of each series/point's hash, so that they are independent of sst file layout.
+type FamilyDigest struct {
+	FamilyTime  int64        `json:"familyTime"`  // start time of family
+	NumOfFiles  int          `json:"numOfFiles"`  // num. of sst files
+	NumOfSeries uint64       `json:"numOfSeries"` // num. of series of all metrics
+	SeriesHash  uint64       `json:"seriesHash"`  // hash of series of all metrics
+	NumOfPoints uint64       `json:"numOfPoints"` // num. of points of all fields
+	DataHash    uint64       `json:"dataHash"`    // hash of field data
+	Slots       []SlotDigest `json:"slots"`       // digests of the time slots which have points, sort by slot
+}
+
+// SlotDigest represents the digest of the points in one time slot of data family
+type SlotDigest struct {
+	Slot        uint16 `json:"slot"`
+	NumOfPoints uint64 `json:"numOfPoints"`
+	DataHash    uint64 `json:"dataHash"`
+}
+
+// Equal returns if the data of families is same
+func (d *FamilyDigest) Equal(other *FamilyDigest) bool {
+	if d == nil || other == nil {
+		return d == other
+	}
+	return d.NumOfSeries == other.NumOfSeries && d.SeriesHash == other.SeriesHash &&
+		d.NumOfPoints == other.NumOfPoints && d.DataHash == other.DataHash
+}
+
+// Contains returns if the family includes all data of other family, compares the digests slot by slot,
+// each time slot of other family must be empty or same as the slot of this family,
+// because the lagging replica misses the data of the time slots when it is unavailable.
+// the family with different data in same time slot cannot be determined which one is right.
+func (d *FamilyDigest) Contains(other *FamilyDigest) bool {
+	if d.Equal(other) || other == nil {
+		return true
+	}
+	if d == nil || (other.NumOfPoints > 0 && len(other.Slots) == 0) {
+		return false
+	}
+	slots := make(map[uint16]SlotDigest, len(d.Slots))
+	for _, slot := range d.Slots {
+		slots[slot.Slot] = slot
+	}
+	for _, otherSlot := range other.Slots {
+		if otherSlot.NumOfPoints == 0 {
+			continue
+		}
+		if slots[otherSlot.Slot] != otherSlot {
+			return false
+		}
+	}
+	return true
+}
+
+// ReplicaDivergence represents the data family which is different between the replicas of shard
+type ReplicaDivergence struct {
+	Database   string                   `json:"database"`
+	ShardID    int32                    `json:"shardID"`
+	FamilyTime int64                    `json:"familyTime"`
+	Digests    map[string]*FamilyDigest `json:"digests"` // node => digest, nil if family not exist in node
+	Source     string                   `json:"source"`  // replica which includes the data of all replicas
+	Lagging    []string                 `json:"lagging"` // replicas which need to repair from source
+	Repaired   bool                     `json:"repaired"`
+	Error      string                   `json:"error,omitempty"` // error message of repair
+	CheckTime  int64                    `json:"checkTime"`
+}
+
+// NewReplicaDivergence compares the digests of family in each replica, returns nil if all replicas are same,
+// else picks the replica which includes the data of all others as source, the others are lagging replicas.
+func NewReplicaDivergence(database string, shardID int32, familyTime int64,
+	digests map[string]*FamilyDigest) *ReplicaDivergence {
+	if len(digests) < 2 {
+		return nil
+	}
+	var nodes []string
+	for node := range digests {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	consistent := true
+	for _, node := range nodes[1:] {
+		if !digests[node].Equal(digests[nodes[0]]) {
+			consistent = false
+			break
+		}
+	}
+	if consistent {
+		return nil
+	}
+	divergence := &ReplicaDivergence{
+		Database:   database,
+		ShardID:    shardID,
+		FamilyTime: familyTime,
+		Digests:    digests,
+	}
+	for _, node := range nodes {
+		source := digests[node]
+		containsAll := true
+		for _, other := range nodes {
+			if !source.Contains(digests[other]) {
+				containsAll = false
+				break
+			}
+		}
+		if containsAll {
+			divergence.Source = node
+			break
+		}
+	}
+	if divergence.Source == "" {
+		// cannot find the replica which includes the data of all others
+		return divergence
+	}
+	for _, node := range nodes {
+		if !digests[node].Equal(digests[divergence.Source]) {
+			divergence.Lagging = append(divergence.Lagging, node)
+		}
+	}
+	return divergence
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFamilyDigest_Equal(t *testing.T) {
+	d1 := &FamilyDigest{FamilyTime: 10, NumOfFiles: 1, NumOfSeries: 2, SeriesHash: 3, NumOfPoints: 4, DataHash: 5}
+	d2 := &FamilyDigest{FamilyTime: 10, NumOfFiles: 2, NumOfSeries: 2, SeriesHash: 3, NumOfPoints: 4, DataHash: 5}
+	assert.True(t, d1.Equal(d2))
+	assert.False(t, d1.Equal(nil))
+	var d3 *FamilyDigest
+	assert.True(t, d3.Equal(nil))
+	d2.DataHash = 6
+	assert.False(t, d1.Equal(d2))
+
+	assert.True(t, d1.Contains(nil))
+	assert.False(t, d3.Contains(d1))
+}
+
+func TestFamilyDigest_Contains(t *testing.T) {
+	d1 := &FamilyDigest{NumOfSeries: 2, SeriesHash: 3, NumOfPoints: 4, DataHash: 10,
+		Slots: []SlotDigest{{Slot: 1, NumOfPoints: 2, DataHash: 4}, {Slot: 2, NumOfPoints: 2, DataHash: 6}}}
+	// case 1: other misses the points of slot
+	d2 := &FamilyDigest{NumOfSeries: 2, SeriesHash: 3, NumOfPoints: 2, DataHash: 4,
+		Slots: []SlotDigest{{Slot: 1, NumOfPoints: 2, DataHash: 4}, {Slot: 2}}}
+	assert.True(t, d1.Contains(d2))
+	assert.False(t, d2.Contains(d1))
+	// case 2: more points but different data in same slot
+	d3 := &FamilyDigest{NumOfSeries: 2, SeriesHash: 3, NumOfPoints: 5, DataHash: 12,
+		Slots: []SlotDigest{{Slot: 1, NumOfPoints: 3, DataHash: 6}, {Slot: 2, NumOfPoints: 2, DataHash: 6}}}
+	assert.False(t, d1.Contains(d3))
+	assert.False(t, d3.Contains(d1))
+	// case 3: different data in slot which other hasn't
+	d4 := &FamilyDigest{NumOfSeries: 2, SeriesHash: 3, NumOfPoints: 1, DataHash: 7,
+		Slots: []SlotDigest{{Slot: 3, NumOfPoints: 1, DataHash: 7}}}
+	assert.False(t, d1.Contains(d4))
+	// case 4: other has points without slot digests
+	assert.False(t, d1.Contains(&FamilyDigest{NumOfPoints: 1, DataHash: 7}))
+}
+
+func TestNewReplicaDivergence(t *testing.T) {
+	d1 := &FamilyDigest{NumOfSeries: 2, SeriesHash: 3, NumOfPoints: 4, DataHash: 5,
+		Slots: []SlotDigest{{Slot: 1, NumOfPoints: 2, DataHash: 2}, {Slot: 2, NumOfPoints: 2, DataHash: 3}}}
+	d2 := &FamilyDigest{NumOfSeries: 2, SeriesHash: 3, NumOfPoints: 4, DataHash: 5,
+		Slots: []SlotDigest{{Slot: 1, NumOfPoints: 2, DataHash: 2}, {Slot: 2, NumOfPoints: 2, DataHash: 3}}}
+	assert.Nil(t, NewReplicaDivergence("db", 1, 10, map[string]*FamilyDigest{"a": d1}))
+	assert.Nil(t, NewReplicaDivergence("db", 1, 10, map[string]*FamilyDigest{"a": d1, "b": d2}))
+
+	// family missing in replica
+	divergence := NewReplicaDivergence("db", 1, 10, map[string]*FamilyDigest{"a": nil, "b": d2, "c": d1})
+	assert.NotNil(t, divergence)
+	assert.Equal(t, "b", divergence.Source)
+	assert.Equal(t, []string{"a"}, divergence.Lagging)
+	assert.Equal(t, int64(10), divergence.FamilyTime)
+
+	// replica lags
+	d3 := &FamilyDigest{NumOfSeries: 1, SeriesHash: 1, NumOfPoints: 2, DataHash: 2,
+		Slots: []SlotDigest{{Slot: 1, NumOfPoints: 2, DataHash: 2}}}
+	divergence = NewReplicaDivergence("db", 1, 10, map[string]*FamilyDigest{"a": d3, "b": d2, "c": d1})
+	assert.Equal(t, "b", divergence.Source)
+	assert.Equal(t, []string{"a"}, divergence.Lagging)
+
+	// cannot determine the source replica
+	d4 := &FamilyDigest{NumOfSeries: 2, SeriesHash: 3, NumOfPoints: 4, DataHash: 6,
+		Slots: []SlotDigest{{Slot: 1, NumOfPoints: 2, DataHash: 2}, {Slot: 2, NumOfPoints: 2, DataHash: 4}}}
+	divergence = NewReplicaDivergence("db", 1, 10, map[string]*FamilyDigest{"a": d4, "b": d2})
+	assert.NotNil(t, divergence)
+	assert.Empty(t, divergence.Source)
+	assert.Empty(t, divergence.Lagging)
+}
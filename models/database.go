@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/lindb/lindb/pkg/option"
 )
@@ -21,19 +22,85 @@ func (db Database) String() string {
 	result := "create database " + db.Name + " with "
 	result += "shard " + fmt.Sprintf("%d", db.NumOfShard) + ", replica " + fmt.Sprintf("%d", db.ReplicaFactor)
 	result += ", interval " + db.Option.Interval
+	if db.Option.TTL != "" {
+		result += ", ttl " + db.Option.TTL
+	}
+	for idx, rollup := range db.Option.Rollup {
+		result += ", rollup " + rollup
+		if idx < len(db.Option.RollupTTL) && db.Option.RollupTTL[idx] != "" {
+			result += " ttl " + db.Option.RollupTTL[idx]
+		}
+	}
 	return result
 }
 
-// Replica defines replica list for spec shard of database
+// RestoredDatabase represents the database restored from backup in storage node,
+// which is registered by master(database config and shard assignment), so that it's writable and queryable.
+type RestoredDatabase struct {
+	Name     string                `json:"name"`     // database's name
+	ShardIDs []int32               `json:"shardIDs"` // shard ids of restored database
+	Option   option.DatabaseOption `json:"option"`   // time series database option
+	Node     Node                  `json:"node"`     // storage node which restores the database
+}
+
+// Replica defines replica list for spec shard of database,
+// learners are the new replicas which are catching up data when rebalancing,
+// the brokers replicate data to learners, but the data isn't queryable from learners.
 type Replica struct {
 	Replicas []int `json:"replicas"`
+	Learners []int `json:"learners,omitempty"`
 }
 
-// ShardAssignment defines shard assignment for database
+// ShardEpoch represents the num. of shard which is valid since the start time
+type ShardEpoch struct {
+	NumOfShard int   `json:"numOfShard"` // num. of shard
+	StartTime  int64 `json:"startTime"`  // switch timestamp(millisecond), the epoch is valid since start time
+}
+
+// ShardEpochs represents the shard epoch list sorted by start time,
+// the data is routed to shard by the epoch which is valid for the timestamp of data.
+type ShardEpochs []ShardEpoch
+
+// NumOfShardAt returns the num. of shard of the epoch which is valid for the timestamp
+func (epochs ShardEpochs) NumOfShardAt(timestamp int64) int {
+	if len(epochs) == 0 {
+		return 0
+	}
+	numOfShard := epochs[0].NumOfShard
+	for _, epoch := range epochs[1:] {
+		if epoch.StartTime > timestamp {
+			break
+		}
+		numOfShard = epoch.NumOfShard
+	}
+	return numOfShard
+}
+
+// MaxNumOfShard returns the max num. of shard of the epochs which overlap the time range [start, end],
+// the shards whose id < max num. of shard may store the data of the time range.
+func (epochs ShardEpochs) MaxNumOfShard(start, end int64) int {
+	numOfShard := 0
+	for idx, epoch := range epochs {
+		if idx > 0 && epoch.StartTime > end {
+			break
+		}
+		if idx+1 < len(epochs) && epochs[idx+1].StartTime <= start {
+			continue
+		}
+		if epoch.NumOfShard > numOfShard {
+			numOfShard = epoch.NumOfShard
+		}
+	}
+	return numOfShard
+}
+
+// ShardAssignment defines shard assignment for database,
+// epochs record the history of num. of shard when the shards of database is increased.
 type ShardAssignment struct {
 	Name   string           `json:"name"` // database's name
 	Nodes  map[int]*Node    `json:"nodes"`
 	Shards map[int]*Replica `json:"shards"`
+	Epochs ShardEpochs      `json:"epochs,omitempty"`
 }
 
 // NewShardAssignment returns empty shard assignment instance
@@ -54,3 +121,97 @@ func (s *ShardAssignment) AddReplica(shardID int, replicaID int) {
 	}
 	replica.Replicas = append(replica.Replicas, replicaID)
 }
+
+// GetEpochs returns the shard epochs, the num. of shards is valid since beginning if epoch not recorded
+func (s *ShardAssignment) GetEpochs() ShardEpochs {
+	if len(s.Epochs) == 0 {
+		return ShardEpochs{{NumOfShard: len(s.Shards)}}
+	}
+	return s.Epochs
+}
+
+// AddEpoch adds a new epoch which the num. of shard is valid since start time,
+// must be invoked before adding the shards.
+func (s *ShardAssignment) AddEpoch(numOfShard int, startTime int64) {
+	s.Epochs = append(s.GetEpochs(), ShardEpoch{NumOfShard: numOfShard, StartTime: startTime})
+}
+
+// AddLearner adds learner id to learner list of spec shard
+func (s *ShardAssignment) AddLearner(shardID int, learnerID int) {
+	replica, ok := s.Shards[shardID]
+	if !ok {
+		replica = &Replica{}
+		s.Shards[shardID] = replica
+	}
+	replica.Learners = append(replica.Learners, learnerID)
+}
+
+// RemoveLearner removes the learner id from learner list of spec shard
+func (s *ShardAssignment) RemoveLearner(shardID int, learnerID int) {
+	replica, ok := s.Shards[shardID]
+	if !ok {
+		return
+	}
+	var learners []int
+	for _, id := range replica.Learners {
+		if id != learnerID {
+			learners = append(learners, id)
+		}
+	}
+	replica.Learners = learners
+}
+
+// PromoteLearner replaces the replica id with the learner id of spec shard, then removes the learner
+func (s *ShardAssignment) PromoteLearner(shardID int, learnerID int, replicaID int) {
+	replica, ok := s.Shards[shardID]
+	if !ok {
+		return
+	}
+	s.RemoveLearner(shardID, learnerID)
+	for idx, id := range replica.Replicas {
+		if id == replicaID {
+			replica.Replicas[idx] = learnerID
+			return
+		}
+	}
+	replica.Replicas = append(replica.Replicas, learnerID)
+}
+
+// ShardsOfNode returns the sorted shard ids whose replica or learner is the spec node
+func (s *ShardAssignment) ShardsOfNode(nodeID int) []int {
+	var shardIDs []int
+	for shardID, replica := range s.Shards {
+		if containsID(replica.Replicas, nodeID) || containsID(replica.Learners, nodeID) {
+			shardIDs = append(shardIDs, shardID)
+		}
+	}
+	sort.Ints(shardIDs)
+	return shardIDs
+}
+
+// Clone returns a deep copy of shard assignment
+func (s *ShardAssignment) Clone() *ShardAssignment {
+	result := NewShardAssignment(s.Name)
+	result.Epochs = append(ShardEpochs(nil), s.Epochs...)
+	for id, node := range s.Nodes {
+		n := *node
+		result.Nodes[id] = &n
+	}
+	for shardID, replica := range s.Shards {
+		result.Shards[shardID] = &Replica{
+			Replicas: append([]int(nil), replica.Replicas...),
+			Learners: append([]int(nil), replica.Learners...),
+		}
+	}
+	return result
+}
+
+// containsID checks if the id list contains the spec id
+func containsID(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
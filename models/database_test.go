@@ -26,4 +26,78 @@ func TestDatabase_String(t *testing.T) {
 		Option:        option.DatabaseOption{Interval: "10s"},
 	}
 	assert.Equal(t, "create database test with shard 10, replica 1, interval 10s", database.String())
+
+	database.Option = option.DatabaseOption{
+		Interval:  "10s",
+		TTL:       "7d",
+		Rollup:    []string{"5m", "1h"},
+		RollupTTL: []string{"30d"},
+	}
+	assert.Equal(t, "create database test with shard 10, replica 1, interval 10s, ttl 7d, "+
+		"rollup 5m ttl 30d, rollup 1h", database.String())
+}
+
+func TestShardAssignment_Learner(t *testing.T) {
+	shardAssign := NewShardAssignment("test")
+	shardAssign.Nodes[1] = &Node{IP: "1.1.1.1", Port: 2080}
+	shardAssign.AddReplica(1, 1)
+	shardAssign.AddReplica(1, 2)
+	shardAssign.AddReplica(2, 2)
+	shardAssign.AddReplica(2, 3)
+
+	cloned := shardAssign.Clone()
+	assert.Equal(t, shardAssign, cloned)
+	cloned.Nodes[1].Port = 2081
+	cloned.AddLearner(1, 4)
+	cloned.AddLearner(3, 4)
+	assert.Equal(t, uint16(2080), shardAssign.Nodes[1].Port)
+	assert.Empty(t, shardAssign.Shards[1].Learners)
+	assert.Equal(t, []int{1, 3}, cloned.ShardsOfNode(4))
+	assert.Equal(t, []int{1, 2}, cloned.ShardsOfNode(2))
+	assert.Empty(t, cloned.ShardsOfNode(5))
+
+	cloned.PromoteLearner(1, 4, 2)
+	assert.Equal(t, []int{1, 4}, cloned.Shards[1].Replicas)
+	assert.Empty(t, cloned.Shards[1].Learners)
+	// replica not found, append learner
+	cloned.PromoteLearner(3, 4, 2)
+	assert.Equal(t, []int{4}, cloned.Shards[3].Replicas)
+	// shard not found
+	cloned.PromoteLearner(5, 4, 2)
+	assert.Nil(t, cloned.Shards[5])
+
+	cloned.AddLearner(2, 4)
+	cloned.AddLearner(2, 5)
+	cloned.RemoveLearner(2, 4)
+	assert.Equal(t, []int{5}, cloned.Shards[2].Learners)
+	// shard not found
+	cloned.RemoveLearner(5, 4)
+	assert.Nil(t, cloned.Shards[5])
+}
+
+func TestShardAssignment_Epochs(t *testing.T) {
+	shardAssign := NewShardAssignment("test")
+	shardAssign.AddReplica(0, 1)
+	shardAssign.AddReplica(1, 1)
+	assert.Equal(t, ShardEpochs{{NumOfShard: 2}}, shardAssign.GetEpochs())
+
+	shardAssign.AddEpoch(4, 100)
+	shardAssign.AddEpoch(8, 200)
+	epochs := shardAssign.GetEpochs()
+	assert.Equal(t, ShardEpochs{{NumOfShard: 2}, {NumOfShard: 4, StartTime: 100}, {NumOfShard: 8, StartTime: 200}}, epochs)
+	assert.Equal(t, epochs, shardAssign.Clone().Epochs)
+
+	assert.Equal(t, 2, epochs.NumOfShardAt(-1))
+	assert.Equal(t, 2, epochs.NumOfShardAt(99))
+	assert.Equal(t, 4, epochs.NumOfShardAt(100))
+	assert.Equal(t, 4, epochs.NumOfShardAt(199))
+	assert.Equal(t, 8, epochs.NumOfShardAt(300))
+	assert.Equal(t, 0, ShardEpochs{}.NumOfShardAt(300))
+
+	assert.Equal(t, 2, epochs.MaxNumOfShard(0, 50))
+	assert.Equal(t, 4, epochs.MaxNumOfShard(0, 150))
+	assert.Equal(t, 4, epochs.MaxNumOfShard(120, 150))
+	assert.Equal(t, 8, epochs.MaxNumOfShard(50, 250))
+	assert.Equal(t, 8, epochs.MaxNumOfShard(250, 300))
+	assert.Equal(t, 0, ShardEpochs{}.MaxNumOfShard(250, 300))
 }
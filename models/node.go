@@ -50,9 +50,18 @@ type Master struct {
 	ElectTime int64 `json:"electTime"`
 }
 
+// LabelZone is the label key of node's zone(like availability zone/idc)
+const LabelZone = "zone"
+
 // ActiveNode represents active node include online time
 type ActiveNode struct {
-	Version    string `json:"version"`
-	Node       Node   `json:"node"`
-	OnlineTime int64  `json:"onlineTime"` // node online time(millisecond)
+	Version    string            `json:"version"`
+	Node       Node              `json:"node"`
+	OnlineTime int64             `json:"onlineTime"` // node online time(millisecond)
+	Labels     map[string]string `json:"labels"`     // labels of node from config, like zone
+}
+
+// Zone returns the zone of node from labels, returns empty if not set
+func (n *ActiveNode) Zone() string {
+	return n.Labels[LabelZone]
 }
@@ -45,3 +45,10 @@ func TestNode_ParseNode(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestActiveNode_Zone(t *testing.T) {
+	node := &ActiveNode{}
+	assert.Empty(t, node.Zone())
+	node.Labels = map[string]string{LabelZone: "zone-a"}
+	assert.Equal(t, "zone-a", node.Zone())
+}
@@ -48,3 +48,13 @@ type Leaf struct {
 	Receivers []Node
 	ShardIDs  []int32
 }
+
+// QueryJob represents the running query job of broker
+type QueryJob struct {
+	JobID        int64    `json:"jobID"`
+	Database     string   `json:"database"`
+	SQL          string   `json:"sql,omitempty"`
+	StartTime    int64    `json:"startTime"`    // start time of job(ms)
+	StorageNodes []string `json:"storageNodes"` // storage nodes involved
+	Elapsed      int64    `json:"elapsed"`      // elapsed time of job(ms)
+}
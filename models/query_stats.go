@@ -11,6 +11,10 @@ type QueryStats struct {
 	StorageNodes map[string]*StorageStats `json:"storageNodes,omitempty"`
 	Cost         int64                    `json:"cost"` // total query cost
 	ExpressCost  int64                    `json:"expressCost"`
+
+	ReplicaPolicy   string             `json:"replicaPolicy,omitempty"`
+	Replicas        map[string][]int32 `json:"replicas"`        // storage node => shard ids queried
+	RetriedReplicas map[string][]int32 `json:"retriedReplicas"` // failed storage node => shard ids retried
 }
 
 // NewQueryStats creates the query stats
@@ -16,9 +16,17 @@ type ReplicaState struct {
 	Pending      int64  `json:"pending"`      // the num. of pending which it need replica msg
 	ReplicaIndex int64  `json:"replicaIndex"` // replica index for current replicator's channel
 	AckIndex     int64  `json:"ackIndex"`     // commit index
+	Learner      bool   `json:"learner"`      // if target is learner which is catching up data, not queryable
 }
 
 // ShardIndicator returns shard indicator based on database/shard id
 func (r ReplicaState) ShardIndicator() string {
 	return fmt.Sprintf("%s/%d", r.Database, r.ShardID)
 }
+
+// QueryableReplicas represents the queryable replicas of database's shards chosen by replica select policy
+type QueryableReplicas struct {
+	Policy     string             `json:"policy"`
+	Nodes      map[string][]int32 `json:"nodes"`      // chosen replicas, storage node => shard id list
+	Candidates map[int32][]string `json:"candidates"` // shard id => other queryable storage nodes sorted by priority
+}
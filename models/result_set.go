@@ -1,5 +1,7 @@
 package models
 
+import "sort"
+
 // SuggestResult represents the suggest result set
 type SuggestResult struct {
 	Values []string `json:"values"`
@@ -13,6 +15,7 @@ type ResultSet struct {
 	Interval   int64       `json:"interval,omitempty"`
 	Series     []*Series   `json:"series,omitempty"`
 	Stats      *QueryStats `json:"stats,omitempty"`
+	NextCursor string      `json:"nextCursor,omitempty"` // cursor of next page, empty if no more series
 }
 
 // NewResultSet creates a new result set
@@ -25,6 +28,21 @@ func (rs *ResultSet) AddSeries(series *Series) {
 	rs.Series = append(rs.Series, series)
 }
 
+// Paginate keeps the time series in the page by offset/limit, returns true if has more series after the page.
+func (rs *ResultSet) Paginate(offset, limit int) (hasMore bool) {
+	total := len(rs.Series)
+	if offset >= total {
+		rs.Series = nil
+		return false
+	}
+	end := offset + limit
+	if limit <= 0 || end > total {
+		end = total
+	}
+	rs.Series = rs.Series[offset:end]
+	return end < total
+}
+
 // Series represents one time series for metric
 type Series struct {
 	Tags   map[string]string            `json:"tags,omitempty"`
@@ -62,3 +80,31 @@ func NewPoints() *Points {
 func (p *Points) AddPoint(timestamp int64, value float64) {
 	p.Points[timestamp] = value
 }
+
+// Point represents the data point of time series
+type Point struct {
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+// StreamSeries represents one time series of streaming query result, the points are ordered by timestamp
+type StreamSeries struct {
+	Tags   map[string]string  `json:"tags,omitempty"`
+	Fields map[string][]Point `json:"fields,omitempty"`
+}
+
+// NewStreamSeries creates the stream series by time series, sorts the points of fields by timestamp
+func NewStreamSeries(series *Series) *StreamSeries {
+	s := &StreamSeries{Tags: series.Tags, Fields: make(map[string][]Point, len(series.Fields))}
+	for fieldName, points := range series.Fields {
+		sortedPoints := make([]Point, 0, len(points))
+		for timestamp, value := range points {
+			sortedPoints = append(sortedPoints, Point{Timestamp: timestamp, Value: value})
+		}
+		sort.Slice(sortedPoints, func(i, j int) bool {
+			return sortedPoints[i].Timestamp < sortedPoints[j].Timestamp
+		})
+		s.Fields[fieldName] = sortedPoints
+	}
+	return s
+}
@@ -25,3 +25,35 @@ func TestResultSet(t *testing.T) {
 		int64(20): 10.0},
 		s.Fields["f1"])
 }
+
+func TestResultSet_Paginate(t *testing.T) {
+	rs := NewResultSet()
+	for i := 0; i < 5; i++ {
+		rs.AddSeries(NewSeries(nil))
+	}
+	assert.True(t, rs.Paginate(1, 2))
+	assert.Len(t, rs.Series, 2)
+
+	rs.Series = make([]*Series, 5)
+	assert.False(t, rs.Paginate(3, 2))
+	assert.Len(t, rs.Series, 2)
+
+	rs.Series = make([]*Series, 5)
+	assert.False(t, rs.Paginate(3, 10))
+	assert.Len(t, rs.Series, 2)
+
+	rs.Series = make([]*Series, 5)
+	assert.False(t, rs.Paginate(5, 2))
+	assert.Empty(t, rs.Series)
+}
+
+func TestNewStreamSeries(t *testing.T) {
+	series := NewSeries(map[string]string{"key": "value"})
+	points := NewPoints()
+	points.AddPoint(int64(20), 2.0)
+	points.AddPoint(int64(10), 1.0)
+	series.AddField("f1", points)
+	s := NewStreamSeries(series)
+	assert.Equal(t, map[string]string{"key": "value"}, s.Tags)
+	assert.Equal(t, []Point{{Timestamp: 10, Value: 1.0}, {Timestamp: 20, Value: 2.0}}, s.Fields["f1"])
+}
@@ -3,6 +3,7 @@ package models
 import (
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 // CreateShardTask represents the create shard task's param
@@ -26,3 +27,38 @@ type DatabaseFlushTask struct {
 func (t DatabaseFlushTask) Bytes() []byte {
 	return encoding.JSONMarshal(t)
 }
+
+// DatabaseDropTask represents the database drop task's param
+type DatabaseDropTask struct {
+	DatabaseName string `json:"databaseName"` // database's name
+}
+
+// Bytes returns the database drop task's binary data using json
+func (t DatabaseDropTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
+
+// SeriesDeleteTask represents the series delete task's param
+type SeriesDeleteTask struct {
+	DatabaseName string       `json:"databaseName"` // database's name
+	ShardIDs     []int32      `json:"shardIDs"`     // shard ids
+	Statement    *stmt.Delete `json:"statement"`    // delete statement
+}
+
+// Bytes returns the series delete task's binary data using json
+func (t SeriesDeleteTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
+
+// ShardTransferTask represents the shard transfer task's param,
+// the target node pulls the shards of database from source node to build new replica.
+type ShardTransferTask struct {
+	DatabaseName string  `json:"databaseName"` // database's name
+	ShardIDs     []int32 `json:"shardIDs"`     // shard ids
+	Source       Node    `json:"source"`       // source node which the shards are pulled from
+}
+
+// Bytes returns the shard transfer task's binary data using json
+func (t ShardTransferTask) Bytes() []byte {
+	return encoding.JSONMarshal(t)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestCreateShardTask_Bytes(t *testing.T) {
@@ -30,3 +31,31 @@ func TestDatabaseFlushTask_Bytes(t *testing.T) {
 	_ = json.Unmarshal(data, &task1)
 	assert.Equal(t, task, task1)
 }
+
+func TestSeriesDeleteTask_Bytes(t *testing.T) {
+	task := SeriesDeleteTask{
+		DatabaseName: "test",
+		ShardIDs:     []int32{1, 4, 6},
+		Statement: &stmt.Delete{
+			Namespace:  "ns",
+			MetricName: "cpu",
+			Condition:  &stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"},
+		},
+	}
+	data := task.Bytes()
+	task1 := SeriesDeleteTask{}
+	_ = json.Unmarshal(data, &task1)
+	assert.Equal(t, task, task1)
+}
+
+func TestShardTransferTask_Bytes(t *testing.T) {
+	task := ShardTransferTask{
+		DatabaseName: "test",
+		ShardIDs:     []int32{1, 2},
+		Source:       Node{IP: "1.1.1.1", Port: 2080},
+	}
+	data := task.Bytes()
+	task1 := ShardTransferTask{}
+	_ = json.Unmarshal(data, &task1)
+	assert.Equal(t, task, task1)
+}
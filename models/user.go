@@ -0,0 +1,82 @@
+package models
+
+// AllDatabases represents the privilege which applies to all databases
+const AllDatabases = "*"
+
+// Permission represents the permission of database
+type Permission string
+
+// Defines all permissions of database, the admin permission contains write/read permission,
+// the write permission contains read permission.
+const (
+	ReadPermission  Permission = "read"
+	WritePermission Permission = "write"
+	AdminPermission Permission = "admin"
+)
+
+// level returns the level of permission, 0 means unknown permission
+func (p Permission) level() int {
+	switch p {
+	case ReadPermission:
+		return 1
+	case WritePermission:
+		return 2
+	case AdminPermission:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// IsValid checks if the permission is valid
+func (p Permission) IsValid() bool {
+	return p.level() > 0
+}
+
+// Contains checks if the permission contains the other permission
+func (p Permission) Contains(other Permission) bool {
+	return p.IsValid() && p.level() >= other.level()
+}
+
+// Privilege represents the permission of the database
+type Privilege struct {
+	Database   string     `json:"database"` // database name, * means all databases
+	Permission Permission `json:"permission"`
+}
+
+// Role represents a set of privileges which can be granted to user
+type Role struct {
+	Name       string      `json:"name"`
+	Privileges []Privilege `json:"privileges"`
+}
+
+// HasPermission checks if the role has the permission of database,
+// empty database means the permission of all databases is required.
+func (r *Role) HasPermission(database string, permission Permission) bool {
+	for _, privilege := range r.Privileges {
+		if privilege.Database != AllDatabases && (database == "" || privilege.Database != database) {
+			continue
+		}
+		if privilege.Permission.Contains(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// User represents the user of broker
+type User struct {
+	Name         string   `json:"name"`
+	Password     string   `json:"password,omitempty"`     // plaintext password, only used for creating/updating user
+	PasswordHash string   `json:"passwordHash,omitempty"` // hashed password
+	Roles        []string `json:"roles,omitempty"`
+	SuperUser    bool     `json:"superUser,omitempty"` // super user has all permissions
+}
+
+// APIKey represents the api key of user, which is used by write agents instead of login token
+type APIKey struct {
+	ID         string `json:"id"` // hash of key
+	UserName   string `json:"userName"`
+	Key        string `json:"key,omitempty"` // plaintext key, only responses when creating
+	CreateTime int64  `json:"createTime"`
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermission_Contains(t *testing.T) {
+	assert.True(t, AdminPermission.Contains(WritePermission))
+	assert.True(t, AdminPermission.Contains(ReadPermission))
+	assert.True(t, WritePermission.Contains(ReadPermission))
+	assert.True(t, ReadPermission.Contains(ReadPermission))
+	assert.False(t, ReadPermission.Contains(WritePermission))
+	assert.False(t, WritePermission.Contains(AdminPermission))
+	assert.False(t, Permission("unknown").Contains(ReadPermission))
+	assert.False(t, Permission("unknown").IsValid())
+}
+
+func TestRole_HasPermission(t *testing.T) {
+	role := &Role{Name: "dev", Privileges: []Privilege{
+		{Database: "db1", Permission: WritePermission},
+		{Database: "db2", Permission: ReadPermission},
+	}}
+	assert.True(t, role.HasPermission("db1", ReadPermission))
+	assert.True(t, role.HasPermission("db1", WritePermission))
+	assert.False(t, role.HasPermission("db1", AdminPermission))
+	assert.True(t, role.HasPermission("db2", ReadPermission))
+	assert.False(t, role.HasPermission("db2", WritePermission))
+	assert.False(t, role.HasPermission("db3", ReadPermission))
+	assert.False(t, role.HasPermission("", ReadPermission))
+
+	role = &Role{Name: "admin", Privileges: []Privilege{{Database: AllDatabases, Permission: AdminPermission}}}
+	assert.True(t, role.HasPermission("db3", AdminPermission))
+	assert.True(t, role.HasPermission("", AdminPermission))
+}
@@ -3,6 +3,7 @@ package parallel
 import (
 	"context"
 	"errors"
+	"sync"
 
 	"go.uber.org/atomic"
 
@@ -47,6 +48,9 @@ type BrokerExecuteContext interface {
 	ResultCh() chan *series.TimeSeriesEvent
 	// ResultSet returns the final result set
 	ResultSet() (*models.ResultSet, error)
+	// Series converts the time series event into the time series of result for streaming query,
+	// the time series aren't merged into the final result set.
+	Series(event *series.TimeSeriesEvent) ([]*models.Series, error)
 }
 
 type brokerExecuteContext struct {
@@ -55,6 +59,7 @@ type brokerExecuteContext struct {
 	query      *stmt.Query
 	expression aggregation.Expression
 	resultSet  *models.ResultSet
+	processor  *resultSetProcessor
 
 	stats     *models.QueryStats
 	startTime int64
@@ -69,6 +74,7 @@ func NewBrokerExecuteContext(startTime int64, query *stmt.Query) BrokerExecuteCo
 	}
 	if query != nil {
 		ctx.expression = aggregation.NewExpression(query.TimeRange, query.Interval.Int64(), query.SelectItems)
+		ctx.processor = newResultSetProcessor(query)
 	}
 	return ctx
 }
@@ -81,8 +87,32 @@ func (c *brokerExecuteContext) Emit(event *series.TimeSeriesEvent) {
 		return
 	}
 	start := timeutil.NowNano()
+	for _, timeSeries := range c.buildSeries(event) {
+		c.resultSet.AddSeries(timeSeries)
+	}
+	if c.stats != nil {
+		c.stats.ExpressCost = timeutil.NowNano() - start
+	}
+}
+
+// Series converts the time series event into the time series of result for streaming query,
+// having/order by/top/bottom need all time series of result, so streaming query doesn't support them.
+func (c *brokerExecuteContext) Series(event *series.TimeSeriesEvent) ([]*models.Series, error) {
+	if event.Err != nil {
+		return nil, event.Err
+	}
+	if c.query.Having != nil || len(c.query.OrderBy) > 0 || c.processor.hasSelector() {
+		return nil, errStreamNotSupport
+	}
+	return c.buildSeries(event), nil
+}
+
+// buildSeries evaluates the select items for each time series of event, then builds the result time series
+func (c *brokerExecuteContext) buildSeries(event *series.TimeSeriesEvent) []*models.Series {
 	groupByKeys := c.query.GroupBy
 	groupByKeysLength := len(groupByKeys)
+	resultTimeRange := c.resultTimeRange()
+	var seriesList []*models.Series
 	for _, ts := range event.SeriesList {
 		var tags map[string]string
 		if groupByKeysLength > 0 {
@@ -98,26 +128,37 @@ func (c *brokerExecuteContext) Emit(event *series.TimeSeriesEvent) {
 			}
 		}
 		timeSeries := models.NewSeries(tags)
-		c.resultSet.AddSeries(timeSeries)
+		seriesList = append(seriesList, timeSeries)
 		c.expression.Eval(ts)
 		rs := c.expression.ResultSet()
 		for fieldName, values := range rs {
 			if values == nil {
 				continue
 			}
+			aggregation.FillValues(values, c.query.Fill, c.query.FillValue)
 			points := models.NewPoints()
 			it := values.Iterator()
 			for it.HasNext() {
 				slot, val := it.Next()
-				points.AddPoint(int64(slot)*c.query.Interval.Int64()+c.query.TimeRange.Start, val)
+				timestamp := int64(slot)*c.query.Interval.Int64() + c.query.TimeRange.Start
+				// drops the points out of result time range, which are loaded for time shift
+				if resultTimeRange.Contains(timestamp) {
+					points.AddPoint(timestamp, val)
+				}
 			}
 			timeSeries.AddField(fieldName, points)
 		}
 		c.expression.Reset()
 	}
-	if c.stats != nil {
-		c.stats.ExpressCost = timeutil.NowNano() - start
+	return seriesList
+}
+
+// resultTimeRange returns the time range of result, which is the query time range if not set
+func (c *brokerExecuteContext) resultTimeRange() timeutil.TimeRange {
+	if c.query.ResultTimeRange == (timeutil.TimeRange{}) {
+		return c.query.TimeRange
 	}
+	return c.query.ResultTimeRange
 }
 
 func (c *brokerExecuteContext) Complete(err error) {
@@ -134,9 +175,12 @@ func (c *brokerExecuteContext) ResultCh() chan *series.TimeSeriesEvent {
 func (c *brokerExecuteContext) ResultSet() (*models.ResultSet, error) {
 	if c.err == nil {
 		c.resultSet.MetricName = c.query.MetricName
-		c.resultSet.StartTime = c.query.TimeRange.Start
-		c.resultSet.EndTime = c.query.TimeRange.End
+		resultTimeRange := c.resultTimeRange()
+		c.resultSet.StartTime = resultTimeRange.Start
+		c.resultSet.EndTime = resultTimeRange.End
 		c.resultSet.Interval = c.query.Interval.Int64()
+		// filters, sorts and limits the time series by having/order by/limit
+		c.processor.process(c.resultSet)
 	}
 	if c.stats != nil {
 		c.stats.Cost = timeutil.NowNano() - c.startTime
@@ -149,8 +193,16 @@ func (c *brokerExecuteContext) ResultSet() (*models.ResultSet, error) {
 type JobContext interface {
 	Plan() *models.PhysicalPlan
 	Query() *stmt.Query
+	// SQL returns the sql of query, maybe empty if query statement isn't parsed from sql
+	SQL() string
+	// StartTime returns the start time of job(ms)
+	StartTime() int64
+	// Replicas returns the queryable replicas chosen for the job, maybe nil
+	Replicas() *models.QueryableReplicas
 	Emit(event *series.TimeSeriesEvent)
 	Complete()
+	// Kill kills the running job, the receiver of result gets the killed error
+	Kill()
 	ResultSet() chan *series.TimeSeriesEvent
 	Context() context.Context
 	Completed() bool
@@ -160,18 +212,30 @@ type jobContext struct {
 	resultSet chan *series.TimeSeriesEvent
 	plan      *models.PhysicalPlan
 	query     *stmt.Query
+	sql       string
+	startTime int64
+	replicas  *models.QueryableReplicas
 	ctx       context.Context
 	cancel    context.CancelFunc
 
 	completed atomic.Bool
+	killed    atomic.Bool
+
+	mutex  sync.RWMutex // guards sending to and closing the result chan
+	closed bool
 }
 
-func NewJobContext(ctx context.Context, resultSet chan *series.TimeSeriesEvent, plan *models.PhysicalPlan, query *stmt.Query) JobContext {
+func NewJobContext(ctx context.Context, resultSet chan *series.TimeSeriesEvent, plan *models.PhysicalPlan,
+	query *stmt.Query, sql string, replicas *models.QueryableReplicas,
+) JobContext {
 	c, cancel := context.WithCancel(ctx)
 	return &jobContext{
 		resultSet: resultSet,
 		plan:      plan,
 		query:     query,
+		sql:       sql,
+		replicas:  replicas,
+		startTime: timeutil.Now(),
 		ctx:       c,
 		cancel:    cancel,
 	}
@@ -184,22 +248,73 @@ func (c *jobContext) Plan() *models.PhysicalPlan {
 func (c *jobContext) Query() *stmt.Query {
 	return c.query
 }
+
+// SQL returns the sql of query
+func (c *jobContext) SQL() string {
+	return c.sql
+}
+
+// StartTime returns the start time of job(ms)
+func (c *jobContext) StartTime() int64 {
+	return c.startTime
+}
+
+// Replicas returns the queryable replicas chosen for the job, the other replicas are used for retrying
+func (c *jobContext) Replicas() *models.QueryableReplicas {
+	return c.replicas
+}
+
 func (c *jobContext) ResultSet() chan *series.TimeSeriesEvent {
 	return c.resultSet
 }
 
+// Complete completes the job, closes the result chan and releases the context of job
 func (c *jobContext) Complete() {
 	if c.completed.CAS(false, true) {
 		//TODO send result
-		close(c.resultSet)
+		c.closeResultSet()
+		c.cancel()
 	}
 }
+
+// Kill kills the running job, sends the killed error to the receiver of result, then releases the context of job,
+// closes the result chan after the sending in flight(e.g. result merger) gives up.
+func (c *jobContext) Kill() {
+	if c.completed.CAS(false, true) {
+		c.killed.Store(true)
+		c.Emit(&series.TimeSeriesEvent{Err: errJobKilled})
+		c.cancel()
+		c.closeResultSet()
+	}
+}
+
+// closeResultSet closes the result chan, the event emitted after closed is dropped.
+func (c *jobContext) closeResultSet() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.closed = true
+	close(c.resultSet)
+}
+
+// Completed returns if the job is completed, the killed job isn't completed
 func (c *jobContext) Completed() bool {
-	return c.completed.Load()
+	return c.completed.Load() && !c.killed.Load()
 }
 
+// Emit emits the time series event, gives up if the job is canceled(e.g. client disconnect/timeout),
+// because no one receives the result.
 func (c *jobContext) Emit(event *series.TimeSeriesEvent) {
-	c.resultSet <- event
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if c.closed {
+		return
+	}
+	select {
+	case c.resultSet <- event:
+	case <-c.ctx.Done():
+	}
 }
 
 func (c *jobContext) Context() context.Context {
@@ -218,6 +333,8 @@ type TaskContext interface {
 	ParentTaskID() string
 	// ReceiveResult marks receive result, decreases the num. of task tracking
 	ReceiveResult(resp *pb.TaskResponse)
+	// AddExpectResults adds the num. of task tracking, e.g. the failed task is retried by multi-task
+	AddExpectResults(delta int32)
 	// Completed returns if the task is completes
 	Completed() bool
 	// Error returns task's error
@@ -296,6 +413,11 @@ func (c *taskContext) ReceiveResult(resp *pb.TaskResponse) {
 	}
 }
 
+// AddExpectResults adds the num. of task tracking
+func (c *taskContext) AddExpectResults(delta int32) {
+	c.expectResults.Add(delta)
+}
+
 // Error returns task's error
 func (c *taskContext) Error() error {
 	return c.err
@@ -305,3 +427,15 @@ func (c *taskContext) Error() error {
 func (c *taskContext) Completed() bool {
 	return c.expectResults.Load() == 0
 }
+
+// retryTaskContext represents the task context of retried leaf tasks,
+// which has its own task id and shares the result tracking with the root task.
+type retryTaskContext struct {
+	TaskContext
+	taskID string
+}
+
+// TaskID returns the task id of retried leaf tasks
+func (c *retryTaskContext) TaskID() string {
+	return c.taskID
+}
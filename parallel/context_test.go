@@ -1,7 +1,9 @@
 package parallel
 
 import (
+	"context"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -106,6 +108,62 @@ func TestBrokerExecuteContext_Emit_GroupBy(t *testing.T) {
 	assert.Len(t, rs.Series, 0)
 }
 
+func TestBrokerExecuteContext_Fill(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expression := aggregation.NewMockExpression(ctrl)
+
+	q, err := sql.Parse("select f from cpu group by time(10s) fill(0) order by f")
+	query := q.(*stmt.Query)
+	assert.NoError(t, err)
+
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query)
+	brokerCtx := ctx.(*brokerExecuteContext)
+	brokerCtx.expression = expression
+	it := series.NewMockGroupedIterator(ctrl)
+	expression.EXPECT().Eval(gomock.Any())
+	values := collections.NewFloatArray(10)
+	values.SetValue(1, 10.0)
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"f": values})
+	expression.EXPECT().Reset()
+	ctx.Emit(&series.TimeSeriesEvent{
+		SeriesList: []series.GroupedIterator{it},
+	})
+	rs, err := ctx.ResultSet()
+	assert.NoError(t, err)
+	assert.Len(t, rs.Series[0].Fields["f"], 10)
+}
+
+func TestBrokerExecuteContext_ResultTimeRange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expression := aggregation.NewMockExpression(ctrl)
+	q, err := sql.Parse("select time_shift(f, 20) from cpu")
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+	// time range is widened for time shift
+	query.TimeRange = timeutil.TimeRange{Start: 0, End: 100 * timeutil.OneSecond}
+	query.ResultTimeRange = timeutil.TimeRange{Start: 20 * timeutil.OneSecond, End: 100 * timeutil.OneSecond}
+
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query)
+	ctx.(*brokerExecuteContext).expression = expression
+	expression.EXPECT().Eval(gomock.Any())
+	values := collections.NewFloatArray(11)
+	values.SetValue(1, 1.0)
+	values.SetValue(3, 3.0)
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"f": values})
+	expression.EXPECT().Reset()
+	ctx.Emit(&series.TimeSeriesEvent{SeriesList: []series.GroupedIterator{series.NewMockGroupedIterator(ctrl)}})
+	rs, err := ctx.ResultSet()
+	assert.NoError(t, err)
+	assert.Equal(t, 20*timeutil.OneSecond, rs.StartTime)
+	assert.Equal(t, 100*timeutil.OneSecond, rs.EndTime)
+	assert.Equal(t, map[int64]float64{30 * timeutil.OneSecond: 3.0}, rs.Series[0].Fields["f"])
+}
+
 func TestBrokerExecuteContext_ResultSet(t *testing.T) {
 	ctx := NewBrokerExecuteContext(timeutil.NowNano(), nil)
 	ctx.Complete(fmt.Errorf("err"))
@@ -113,3 +171,102 @@ func TestBrokerExecuteContext_ResultSet(t *testing.T) {
 	assert.Error(t, err)
 	assert.NotNil(t, rs)
 }
+
+func TestBrokerExecuteContext_Series(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	expression := aggregation.NewMockExpression(ctrl)
+	q, _ := sql.Parse("select f from cpu")
+	query := q.(*stmt.Query)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+	ctx := NewBrokerExecuteContext(timeutil.NowNano(), query)
+	ctx.(*brokerExecuteContext).expression = expression
+
+	// case 1: event err
+	_, err := ctx.Series(&series.TimeSeriesEvent{Err: fmt.Errorf("err")})
+	assert.Error(t, err)
+	// case 2: build series
+	it := series.NewMockGroupedIterator(ctrl)
+	expression.EXPECT().Eval(gomock.Any())
+	values := collections.NewFloatArray(10)
+	values.SetValue(1, 10.0)
+	expression.EXPECT().ResultSet().Return(map[string]collections.FloatArray{"f": values})
+	expression.EXPECT().Reset()
+	seriesList, err := ctx.Series(&series.TimeSeriesEvent{SeriesList: []series.GroupedIterator{it}})
+	assert.NoError(t, err)
+	assert.Len(t, seriesList, 1)
+	assert.Len(t, seriesList[0].Fields["f"], 1)
+	// not merged into result set
+	rs, err := ctx.ResultSet()
+	assert.NoError(t, err)
+	assert.Empty(t, rs.Series)
+
+	// case 3: not support order by
+	q, _ = sql.Parse("select f from cpu group by host order by f")
+	query = q.(*stmt.Query)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+	ctx = NewBrokerExecuteContext(timeutil.NowNano(), query)
+	_, err = ctx.Series(&series.TimeSeriesEvent{})
+	assert.Equal(t, errStreamNotSupport, err)
+	// case 4: not support top/bottom
+	q, _ = sql.Parse("select top(sum(f), 2) from cpu group by host")
+	query = q.(*stmt.Query)
+	query.Interval = timeutil.Interval(10 * timeutil.OneSecond)
+	ctx = NewBrokerExecuteContext(timeutil.NowNano(), query)
+	_, err = ctx.Series(&series.TimeSeriesEvent{})
+	assert.Equal(t, errStreamNotSupport, err)
+}
+
+func TestJobContext(t *testing.T) {
+	resultCh := make(chan *series.TimeSeriesEvent)
+	ctx, cancel := context.WithCancel(context.TODO())
+	jobCtx := NewJobContext(ctx, resultCh, nil, nil, "", nil)
+	// job canceled, emit doesn't block
+	cancel()
+	jobCtx.Emit(&series.TimeSeriesEvent{})
+	assert.False(t, jobCtx.Completed())
+
+	jobCtx = NewJobContext(context.TODO(), resultCh, nil, nil, "", nil)
+	jobCtx.Complete()
+	assert.True(t, jobCtx.Completed())
+	// context of job is released after completed
+	<-jobCtx.Context().Done()
+	_, ok := <-resultCh
+	assert.False(t, ok)
+}
+
+func TestJobContext_Kill(t *testing.T) {
+	resultCh := make(chan *series.TimeSeriesEvent)
+	jobCtx := NewJobContext(context.TODO(), resultCh, nil, nil, "select f from cpu", nil)
+	assert.Equal(t, "select f from cpu", jobCtx.SQL())
+	assert.True(t, jobCtx.StartTime() > 0)
+	go jobCtx.Kill()
+	event := <-resultCh
+	assert.Equal(t, errJobKilled, event.Err)
+	<-jobCtx.Context().Done()
+	// result chan is closed after killed
+	_, ok := <-resultCh
+	assert.False(t, ok)
+	assert.False(t, jobCtx.Completed())
+	// killed job cannot be completed again
+	jobCtx.Complete()
+	jobCtx.Kill()
+	assert.False(t, jobCtx.Completed())
+	// event emitted after killed is dropped
+	jobCtx.Emit(&series.TimeSeriesEvent{})
+
+	// kill job when emitting result concurrently
+	resultCh = make(chan *series.TimeSeriesEvent)
+	jobCtx = NewJobContext(context.TODO(), resultCh, nil, nil, "", nil)
+	var wait sync.WaitGroup
+	wait.Add(1)
+	go func() {
+		defer wait.Done()
+		jobCtx.Emit(&series.TimeSeriesEvent{})
+	}()
+	go jobCtx.Kill()
+	for range resultCh {
+	}
+	wait.Wait()
+}
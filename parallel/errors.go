@@ -1,6 +1,13 @@
 package parallel
 
-import "errors"
+import (
+	"errors"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/lindb/lindb/constants"
+)
 
 var errUnmarshalPlan = errors.New("unmarshal physical plan error")
 var errUnmarshalQuery = errors.New("unmarshal query statement error")
@@ -9,3 +16,27 @@ var errWrongRequest = errors.New("not found task of current node from physical p
 var errNoSendStream = errors.New("not found send stream")
 var errTaskSend = errors.New("send task request error")
 var errNoDatabase = errors.New("not found database")
+var errStreamNotSupport = errors.New("streaming query not support having/order by")
+var errJobKilled = errors.New("query job is killed")
+var errJobNotFound = errors.New("query job not found")
+
+// unavailableErrors are the errors of leaf task which are caused by the replica being unavailable,
+// such as database/shard not found in storage node(not loaded or moved) and transport failure,
+// the leaf task may succeed on the other replicas.
+var unavailableErrors = []string{
+	errNoDatabase.Error(),
+	errNoSendStream.Error(),
+	constants.ErrDatabaseNotFound.Error(),
+	constants.ErrShardNotFound.Error(),
+	"code = " + codes.Unavailable.String(),
+}
+
+// isUnavailableError returns if the error message of leaf task is caused by the replica being unavailable
+func isUnavailableError(errMsg string) bool {
+	for _, msg := range unavailableErrors {
+		if strings.Contains(errMsg, msg) {
+			return true
+		}
+	}
+	return false
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
 )
@@ -40,6 +41,17 @@ type ExecutorFactory interface {
 		jobManager JobManager,
 	) BrokerExecutor
 
+	// NewQueryBrokerExecutor creates the broker executor based on the query statement which is built by caller
+	NewQueryBrokerExecutor(
+		ctx context.Context,
+		databaseName string,
+		query *stmt.Query,
+		replicaStateMachine replica.StatusStateMachine,
+		nodeStateMachine broker.NodeStateMachine,
+		databaseStateMachine database.DBStateMachine,
+		jobManager JobManager,
+	) BrokerExecutor
+
 	// NewMetadataBrokerExecutor creates the metadata executor in broker side
 	NewMetadataBrokerExecutor(
 		ctx context.Context,
@@ -50,6 +62,12 @@ type ExecutorFactory interface {
 		jobManager JobManager,
 	) MetadataExecutor
 
-	// NewStorageExecuteContext creates the storage execute context in storage side
-	NewStorageExecuteContext(shardIDs []int32, query *stmt.Query) StorageExecuteContext
+	// NewStorageExecuteContext creates the storage execute context in storage side,
+	// storage interval is the interval of data families for querying, rollup is true if it's rollup interval.
+	NewStorageExecuteContext(
+		shardIDs []int32,
+		storageInterval timeutil.Interval,
+		rollup bool,
+		query *stmt.Query,
+	) StorageExecuteContext
 }
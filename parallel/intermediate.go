@@ -2,6 +2,7 @@ package parallel
 
 import (
 	"context"
+	"sync"
 
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/models"
@@ -18,6 +19,8 @@ type intermediateTask struct {
 	curNode     models.Node
 	curNodeID   string
 	taskManager TaskManager
+
+	runningTasks sync.Map // key: parent task id, value: task id of current node
 }
 
 // newIntermediateTask creates the intermediate task
@@ -32,6 +35,14 @@ func newIntermediateTask(curNode models.Node, taskManger TaskManager) *intermedi
 // Process processes the task request, sends task request to leaf nodes based on physical plan,
 // and tracks the task state
 func (p *intermediateTask) Process(ctx context.Context, req *pb.TaskRequest) error {
+	if req.RequestType == pb.RequestType_Cancel {
+		// leaf tasks are canceled by root node directly, only completes the task of current node
+		if taskID, ok := p.runningTasks.Load(req.ParentTaskID); ok {
+			p.runningTasks.Delete(req.ParentTaskID)
+			p.taskManager.Complete(taskID.(string))
+		}
+		return nil
+	}
 	physicalPlan := models.PhysicalPlan{}
 	if err := encoding.JSONUnmarshal(req.PhysicalPlan, &physicalPlan); err != nil {
 		return errUnmarshalPlan
@@ -51,8 +62,9 @@ func (p *intermediateTask) Process(ctx context.Context, req *pb.TaskRequest) err
 			taskID := p.taskManager.AllocTaskID()
 			//TODO set task id
 			taskCtx := newTaskContext(taskID, IntermediateTask, req.ParentTaskID, intermediate.Parent,
-				intermediate.NumOfTask, newResultMerger(ctx, groupAgg, nil))
+				intermediate.NumOfTask, newResultMerger(ctx, groupAgg, nil, nil))
 			p.taskManager.Submit(taskCtx)
+			p.runningTasks.Store(req.ParentTaskID, taskID)
 			taskSubmitted = true
 			break
 		}
@@ -92,6 +104,7 @@ func (p *intermediateTask) Receive(resp *pb.TaskResponse) error {
 
 	if taskCtx.Completed() {
 		p.taskManager.Complete(taskID)
+		p.runningTasks.Delete(taskCtx.ParentTaskID())
 		// if task complete, need send task's result to parent node, if exist parent node
 		if err := p.taskManager.SendResponse(taskCtx.ParentNode(), &pb.TaskResponse{TaskID: taskCtx.ParentTaskID()}); err != nil {
 			return err
@@ -66,6 +66,34 @@ func TestIntermediate_Process(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestIntermediate_Process_Cancel(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("taskID").AnyTimes()
+
+	currentNode := models.Node{IP: "1.1.1.3", Port: 8000}
+	processor := newIntermediateTask(currentNode, taskManager)
+	// cancel not exist task
+	err := processor.Process(context.TODO(), &pb.TaskRequest{RequestType: pb.RequestType_Cancel, ParentTaskID: "parentID"})
+	assert.NoError(t, err)
+
+	query, _ := sql.Parse("select f from cpu group by host")
+	plan, _ := json.Marshal(&models.PhysicalPlan{
+		Intermediates: []models.Intermediate{{BaseNode: models.BaseNode{Indicator: "1.1.1.3:8000"}}},
+	})
+	err = processor.Process(context.TODO(), &pb.TaskRequest{ParentTaskID: "parentID", PhysicalPlan: plan, Payload: encoding.JSONMarshal(query)})
+	assert.NoError(t, err)
+	// cancel running task
+	taskManager.EXPECT().Complete("taskID")
+	err = processor.Process(context.TODO(), &pb.TaskRequest{RequestType: pb.RequestType_Cancel, ParentTaskID: "parentID"})
+	assert.NoError(t, err)
+	_, ok := processor.runningTasks.Load("parentID")
+	assert.False(t, ok)
+}
+
 func TestIntermediate_Receive(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -2,6 +2,7 @@ package parallel
 
 import (
 	"context"
+	"sort"
 	"sync"
 
 	"go.uber.org/atomic"
@@ -9,11 +10,15 @@ import (
 	"github.com/lindb/lindb/aggregation"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/timeutil"
 	pb "github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/sql/stmt"
 )
 
+var jobLogger = logger.GetLogger("parallel", "JobManager")
+
 //go:generate mockgen -source=./job_manager.go -destination=./job_manager_mock.go -package=parallel
 
 // JobManager represents the job manager for the root broker node
@@ -26,6 +31,13 @@ type JobManager interface {
 	) (err error)
 	// GetJob returns job context by job id
 	GetJob(jobID int64) JobContext
+	// ListJobs returns all running query jobs
+	ListJobs() []*models.QueryJob
+	// KillJob kills the running query job by job id, cancels the sub tasks of job
+	KillJob(jobID int64) error
+	// RetryLeafTask retries the shards of failed leaf task on the other replicas,
+	// returns false if the leaf task cannot be retried.
+	RetryLeafTask(jobID int64, taskID string, failedNode string) bool
 	// GetTaskManager return the task manager
 	GetTaskManager() TaskManager
 }
@@ -34,8 +46,9 @@ type JobManager interface {
 type jobManager struct {
 	taskManager TaskManager
 
-	seq  *atomic.Int64
-	jobs sync.Map
+	seq      *atomic.Int64
+	jobs     sync.Map
+	trackers sync.Map // job id => replica tracker
 }
 
 // NewJobManager creates the job manager
@@ -59,6 +72,48 @@ func (j *jobManager) GetJob(jobID int64) JobContext {
 	return jobCtx
 }
 
+// ListJobs returns all running query jobs, sorted by job id
+func (j *jobManager) ListJobs() []*models.QueryJob {
+	now := timeutil.Now()
+	var jobs []*models.QueryJob
+	j.jobs.Range(func(key, value interface{}) bool {
+		jobCtx, ok := value.(JobContext)
+		if !ok {
+			// ignore metadata job
+			return true
+		}
+		plan := jobCtx.Plan()
+		storageNodes := make([]string, 0, len(plan.Leafs))
+		for _, leaf := range plan.Leafs {
+			storageNodes = append(storageNodes, leaf.Indicator)
+		}
+		jobs = append(jobs, &models.QueryJob{
+			JobID:        key.(int64),
+			Database:     plan.Database,
+			SQL:          jobCtx.SQL(),
+			StartTime:    jobCtx.StartTime(),
+			StorageNodes: storageNodes,
+			Elapsed:      now - jobCtx.StartTime(),
+		})
+		return true
+	})
+	sort.Slice(jobs, func(i, k int) bool {
+		return jobs[i].JobID < jobs[k].JobID
+	})
+	return jobs
+}
+
+// KillJob kills the running query job by job id,
+// watching job sends the cancel request to the sub tasks after the job killed.
+func (j *jobManager) KillJob(jobID int64) error {
+	jobCtx := j.GetJob(jobID)
+	if jobCtx == nil {
+		return errJobNotFound
+	}
+	jobCtx.Kill()
+	return nil
+}
+
 // SubmitJob submits the distribution query job based on physical plan,
 // 1. if has intermediate nodes, sends the request to the intermediate nodes
 // 2. else sends the request to the leaf node directly
@@ -67,39 +122,62 @@ func (j *jobManager) SubmitJob(ctx JobContext) (err error) {
 	planPayload := encoding.JSONMarshal(plan)
 	jobID := j.seq.Inc()
 
+	taskID := j.taskManager.AllocTaskID()
+	var targets []string
+
 	defer func() {
-		if err == nil {
-			j.jobs.Store(jobID, ctx)
+		if err != nil {
+			// job isn't running if submit fail, releases it directly
+			j.releaseJob(ctx, jobID, taskID, targets)
+			return
 		}
+		j.jobs.Store(jobID, ctx)
+		go j.watchJob(ctx, jobID, taskID, targets)
 	}()
 
-	taskID := j.taskManager.AllocTaskID()
-
 	// TODO need add param
+	payload := encoding.JSONMarshal(ctx.Query())
 	req := &pb.TaskRequest{
 		JobID:        jobID,
 		ParentTaskID: taskID,
 		PhysicalPlan: planPayload,
-		Payload:      encoding.JSONMarshal(ctx.Query()),
+		Payload:      payload,
 	}
 	query := ctx.Query()
 
+	var tracker *replicaTracker
+	if replicas := ctx.Replicas(); replicas != nil {
+		tracker = newReplicaTracker(taskID, plan, payload, replicas)
+		j.trackers.Store(jobID, tracker)
+	}
 	groupAgg := aggregation.NewGroupingAggregator(query.Interval, query.TimeRange, buildAggregatorSpecs(query.FieldNames))
 	taskCtx := newTaskContext(taskID, RootTask, "", "", plan.Root.NumOfTask,
-		newResultMerger(ctx.Context(), groupAgg, ctx.ResultSet()))
+		newResultMerger(ctx.Context(), groupAgg, ctx.Emit, tracker))
 	j.taskManager.Submit(taskCtx)
 
 	if len(plan.Intermediates) > 0 {
 		for _, intermediate := range plan.Intermediates {
+			// the sent tasks are canceled by watching job if send fail
+			targets = append(targets, intermediate.Indicator)
 			if err = j.taskManager.SendRequest(intermediate.Indicator, req); err != nil {
-				//TODO kill sent leaf task???
 				return err
 			}
+			// leaf tasks are dispatched by intermediate node with same parent task id,
+			// so the leaf nodes also need to be canceled directly.
+			for _, leaf := range plan.Leafs {
+				if leaf.Parent == intermediate.Indicator {
+					targets = append(targets, leaf.Indicator)
+				}
+			}
 		}
 	} else if len(plan.Leafs) > 0 {
 		for _, leaf := range plan.Leafs {
+			targets = append(targets, leaf.Indicator)
 			if err = j.taskManager.SendRequest(leaf.Indicator, req); err != nil {
-				//TODO kill sent leaf task???
+				if tracker != nil && j.retryLeafTask(jobID, tracker, taskCtx, taskID, leaf.Indicator) {
+					err = nil
+					continue
+				}
 				return err
 			}
 		}
@@ -107,6 +185,151 @@ func (j *jobManager) SubmitJob(ctx JobContext) (err error) {
 	return err
 }
 
+// RetryLeafTask retries the shards of failed leaf task on the other replicas,
+// returns false if the job not found or the leaf task cannot be retried.
+func (j *jobManager) RetryLeafTask(jobID int64, taskID string, failedNode string) bool {
+	tracker, ok := j.trackers.Load(jobID)
+	if !ok {
+		return false
+	}
+	taskCtx := j.taskManager.Get(taskID)
+	if taskCtx == nil {
+		return false
+	}
+	return j.retryLeafTask(jobID, tracker.(*replicaTracker), taskCtx, taskID, failedNode)
+}
+
+// retryLeafTask dispatches the shards of failed leaf task to the other replicas with a new task id,
+// the new task shares the result tracking with the root task.
+// if the shards cannot be retried, cancels the retried leaf tasks which are sent already.
+func (j *jobManager) retryLeafTask(jobID int64, tracker *replicaTracker, taskCtx TaskContext,
+	taskID string, failedNode string) bool {
+	sent, ok := j.dispatchRetryTask(jobID, tracker, taskCtx, taskID, failedNode)
+	if !ok {
+		for _, leaf := range sent {
+			j.sendCancelRequest(leaf.node, &pb.TaskRequest{
+				JobID:        jobID,
+				RequestType:  pb.RequestType_Cancel,
+				ParentTaskID: leaf.taskID,
+			})
+		}
+	}
+	return ok
+}
+
+// dispatchRetryTask sends the shards of failed leaf task to the other replicas, retries the shards of
+// the replica which cannot be sent recursively, returns the sent leaf tasks and if all shards are dispatched.
+func (j *jobManager) dispatchRetryTask(jobID int64, tracker *replicaTracker, taskCtx TaskContext,
+	taskID string, failedNode string) ([]leafKey, bool) {
+	retryTaskID := j.taskManager.AllocTaskID()
+	retryNodes := tracker.retry(taskID, failedNode, retryTaskID)
+	if len(retryNodes) == 0 {
+		return nil, false
+	}
+	jobLogger.Warn("leaf task fail, retry the shards on other replicas",
+		logger.Int64("jobID", jobID), logger.String("failedNode", failedNode),
+		logger.Any("retryNodes", retryNodes))
+
+	root := tracker.plan.Root
+	plan := models.NewPhysicalPlan(root)
+	plan.Database = tracker.plan.Database
+	nodes := make([]string, 0, len(retryNodes))
+	for node := range retryNodes {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	for _, node := range nodes {
+		plan.AddLeaf(models.Leaf{
+			BaseNode: models.BaseNode{
+				Parent:    root.Indicator,
+				Indicator: node,
+			},
+			ShardIDs:  retryNodes[node],
+			Receivers: tracker.plan.Leafs[0].Receivers,
+		})
+	}
+	// failed leaf task is replaced by the retried leaf tasks
+	taskCtx.AddExpectResults(int32(len(nodes) - 1))
+	j.taskManager.Submit(&retryTaskContext{TaskContext: taskCtx, taskID: retryTaskID})
+
+	req := &pb.TaskRequest{
+		JobID:        jobID,
+		ParentTaskID: retryTaskID,
+		PhysicalPlan: encoding.JSONMarshal(plan),
+		Payload:      tracker.payload,
+	}
+	var sent []leafKey
+	for _, node := range nodes {
+		if err := j.taskManager.SendRequest(node, req); err != nil {
+			retried, ok := j.dispatchRetryTask(jobID, tracker, taskCtx, retryTaskID, node)
+			sent = append(sent, retried...)
+			if !ok {
+				return sent, false
+			}
+			continue
+		}
+		sent = append(sent, leafKey{taskID: retryTaskID, node: node})
+	}
+	return sent, true
+}
+
+// watchJob waits the job completed or canceled(client disconnect/timeout/killed etc.), then releases the job.
+func (j *jobManager) watchJob(ctx JobContext, jobID int64, taskID string, targets []string) {
+	<-ctx.Context().Done()
+
+	j.releaseJob(ctx, jobID, taskID, targets)
+}
+
+// releaseJob removes the job, if the job isn't completed(canceled or submit fail),
+// sends the cancel request to the sub tasks for stopping the query.
+func (j *jobManager) releaseJob(ctx JobContext, jobID int64, taskID string, targets []string) {
+	j.jobs.Delete(jobID)
+	var retryLeafs []leafKey
+	if tracker, ok := j.trackers.Load(jobID); ok {
+		j.trackers.Delete(jobID)
+		retryTaskIDs := tracker.(*replicaTracker).retryTaskIDs()
+		if len(retryTaskIDs) > 0 {
+			// the last result maybe received by retried task, so root task also need to be removed
+			for _, retryTaskID := range append(retryTaskIDs, taskID) {
+				j.taskManager.Complete(retryTaskID)
+			}
+			for _, leaf := range tracker.(*replicaTracker).runningLeafs() {
+				if leaf.taskID != taskID {
+					retryLeafs = append(retryLeafs, leaf)
+				}
+			}
+		}
+	}
+	if ctx.Completed() {
+		return
+	}
+	j.taskManager.Complete(taskID)
+	req := &pb.TaskRequest{
+		JobID:        jobID,
+		RequestType:  pb.RequestType_Cancel,
+		ParentTaskID: taskID,
+	}
+	for _, target := range targets {
+		j.sendCancelRequest(target, req)
+	}
+	// cancels the retried leaf tasks
+	for _, leaf := range retryLeafs {
+		j.sendCancelRequest(leaf.node, &pb.TaskRequest{
+			JobID:        jobID,
+			RequestType:  pb.RequestType_Cancel,
+			ParentTaskID: leaf.taskID,
+		})
+	}
+}
+
+// sendCancelRequest sends the cancel request to the target node, logs the error if fail
+func (j *jobManager) sendCancelRequest(target string, req *pb.TaskRequest) {
+	if err := j.taskManager.SendRequest(target, req); err != nil {
+		jobLogger.Warn("send cancel task request error",
+			logger.String("target", target), logger.Int64("jobID", req.JobID), logger.Error(err))
+	}
+}
+
 // SubmitMetadataJob submits the distribution metadata query job on physical plan
 func (j *jobManager) SubmitMetadataJob(ctx context.Context, plan *models.PhysicalPlan,
 	suggest *stmt.Metadata, resultSet chan []string,
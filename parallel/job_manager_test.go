@@ -3,12 +3,16 @@ package parallel
 import (
 	"context"
 	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/models"
+	pb "github.com/lindb/lindb/rpc/proto/common"
+	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/sql"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -31,13 +35,21 @@ func TestJobManager_SubmitJob(t *testing.T) {
 		ShardIDs: []int32{1, 2, 4},
 	})
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	// job is released directly if submit fail
+	taskManager.EXPECT().Complete("TaskID")
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).
+		DoAndReturn(func(_ string, req *pb.TaskRequest) error {
+			assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+			return nil
+		})
 	q, _ := sql.Parse("select f from cpu where host='1.1.1.1' and time>'20190729 11:00:00' and time<'20190729 12:00:00'")
 	query := q.(*stmt.Query)
-	err := jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query))
+	err := jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query, "", nil))
 	assert.NotNil(t, err)
+	assert.Nil(t, jobManager.GetJob(1))
 
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil)
-	err = jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query))
+	err = jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query, "", nil))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -63,11 +75,19 @@ func TestJobManager_SubmitJob_2(t *testing.T) {
 	q, _ := sql.Parse("select f from cpu where host='1.1.1.1' and time>'20190729 11:00:00' and time<'20190729 12:00:00'")
 	query := q.(*stmt.Query)
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
-	err := jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query))
+	// job is released directly if submit fail
+	taskManager.EXPECT().Complete("TaskID")
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).
+		DoAndReturn(func(_ string, req *pb.TaskRequest) error {
+			assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+			return nil
+		})
+	err := jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query, "", nil))
 	assert.NotNil(t, err)
+	assert.Nil(t, jobManager.GetJob(1))
 
 	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).Return(nil)
-	err = jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query))
+	err = jobManager.SubmitJob(NewJobContext(context.TODO(), nil, physicalPlan, query, "", nil))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -124,3 +144,244 @@ func TestJobManager_SubmitMetadataJob(t *testing.T) {
 		}, &stmt.Metadata{}, nil)
 	assert.NoError(t, err)
 }
+
+func TestJobManager_CancelJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("TaskID").AnyTimes()
+
+	jobManager := NewJobManager(taskManager)
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
+	physicalPlan.AddLeaf(models.Leaf{
+		BaseNode: models.BaseNode{
+			Parent:    "1.1.1.3:8000",
+			Indicator: "1.1.1.1:9000",
+		},
+		ShardIDs: []int32{1, 2, 4},
+	})
+	q, _ := sql.Parse("select f from cpu")
+	query := q.(*stmt.Query)
+
+	// case 1: job completed, no cancel request
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(nil)
+	jobCtx := NewJobContext(context.TODO(), make(chan *series.TimeSeriesEvent), physicalPlan, query, "", nil)
+	err := jobManager.SubmitJob(jobCtx)
+	assert.NoError(t, err)
+	assert.NotNil(t, jobManager.GetJob(1))
+	jobCtx.Complete()
+	waitJobRemoved(jobManager, 1)
+
+	// case 2: job canceled, send cancel request
+	ctx, cancel := context.WithCancel(context.TODO())
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(nil)
+	err = jobManager.SubmitJob(NewJobContext(ctx, nil, physicalPlan, query, "", nil))
+	assert.NoError(t, err)
+	var wait sync.WaitGroup
+	wait.Add(1)
+	taskManager.EXPECT().Complete("TaskID")
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).
+		DoAndReturn(func(_ string, req *pb.TaskRequest) error {
+			defer wait.Done()
+			assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+			assert.Equal(t, "TaskID", req.ParentTaskID)
+			return fmt.Errorf("err")
+		})
+	cancel()
+	wait.Wait()
+	waitJobRemoved(jobManager, 2)
+}
+
+func TestJobManager_KillJob(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	taskManager.EXPECT().AllocTaskID().Return("TaskID").AnyTimes()
+
+	jobManager := NewJobManager(taskManager)
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
+	physicalPlan.Database = "db"
+	physicalPlan.AddIntermediate(models.Intermediate{
+		BaseNode: models.BaseNode{
+			Parent:    "1.1.1.3:8000",
+			Indicator: "1.1.1.4:8000",
+		},
+		NumOfTask: 1,
+	})
+	physicalPlan.AddLeaf(models.Leaf{
+		BaseNode: models.BaseNode{
+			Parent:    "1.1.1.4:8000",
+			Indicator: "1.1.1.1:9000",
+		},
+		ShardIDs: []int32{1, 2, 4},
+	})
+	q, _ := sql.Parse("select f from cpu group by host")
+	query := q.(*stmt.Query)
+
+	// case 1: job not found
+	assert.Equal(t, errJobNotFound, jobManager.KillJob(1))
+	assert.Empty(t, jobManager.ListJobs())
+
+	// case 2: list jobs, metadata job is ignored
+	taskManager.EXPECT().SendRequest("1.1.1.4:8000", gomock.Any()).Return(nil)
+	resultCh := make(chan *series.TimeSeriesEvent)
+	err := jobManager.SubmitJob(NewJobContext(context.TODO(), resultCh, physicalPlan, query, "select f from cpu group by host", nil))
+	assert.NoError(t, err)
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(nil)
+	err = jobManager.SubmitMetadataJob(context.TODO(), physicalPlan, &stmt.Metadata{}, nil)
+	assert.NoError(t, err)
+	jobs := jobManager.ListJobs()
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, int64(1), jobs[0].JobID)
+	assert.Equal(t, "db", jobs[0].Database)
+	assert.Equal(t, "select f from cpu group by host", jobs[0].SQL)
+	assert.Equal(t, []string{"1.1.1.1:9000"}, jobs[0].StorageNodes)
+
+	// case 3: kill job, send cancel request to intermediate and leaf nodes
+	var wait sync.WaitGroup
+	wait.Add(2)
+	taskManager.EXPECT().Complete("TaskID")
+	taskManager.EXPECT().SendRequest(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ string, req *pb.TaskRequest) error {
+			defer wait.Done()
+			assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+			return nil
+		}).Times(2)
+	go func() {
+		event := <-resultCh
+		assert.Equal(t, errJobKilled, event.Err)
+	}()
+	assert.NoError(t, jobManager.KillJob(1))
+	wait.Wait()
+	waitJobRemoved(jobManager, 1)
+}
+
+func waitJobRemoved(jobManager JobManager, jobID int64) {
+	for jobManager.GetJob(jobID) != nil {
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestJobManager_RetryLeafTask(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	taskManager := NewMockTaskManager(ctrl)
+	taskManager.EXPECT().Submit(gomock.Any()).AnyTimes()
+	seq := 0
+	taskManager.EXPECT().AllocTaskID().DoAndReturn(func() string {
+		seq++
+		return fmt.Sprintf("task-%d", seq)
+	}).AnyTimes()
+
+	jobManager := NewJobManager(taskManager)
+	physicalPlan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 1})
+	physicalPlan.AddLeaf(models.Leaf{
+		BaseNode: models.BaseNode{
+			Parent:    "1.1.1.3:8000",
+			Indicator: "1.1.1.1:9000",
+		},
+		ShardIDs: []int32{1, 2},
+	})
+	replicas := &models.QueryableReplicas{
+		Nodes:      map[string][]int32{"1.1.1.1:9000": {1, 2}},
+		Candidates: map[int32][]string{1: {"1.1.1.2:9000"}, 2: {"1.1.1.2:9000"}},
+	}
+	q, _ := sql.Parse("select f from cpu")
+	query := q.(*stmt.Query)
+
+	// job not found
+	assert.False(t, jobManager.RetryLeafTask(100, "task-1", "1.1.1.1:9000"))
+
+	// case 1: send request fail, retry on other replica when submit job
+	ctx, cancel := context.WithCancel(context.TODO())
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(fmt.Errorf("err"))
+	taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).
+		DoAndReturn(func(_ string, req *pb.TaskRequest) error {
+			assert.Equal(t, "task-2", req.ParentTaskID)
+			return nil
+		})
+	err := jobManager.SubmitJob(NewJobContext(ctx, nil, physicalPlan, query, "", replicas))
+	assert.NoError(t, err)
+	// task not found
+	taskManager.EXPECT().Get("task-2").Return(nil)
+	assert.False(t, jobManager.RetryLeafTask(1, "task-2", "1.1.1.2:9000"))
+	// no other replica
+	taskManager.EXPECT().Get("task-2").Return(NewMockTaskContext(ctrl))
+	assert.False(t, jobManager.RetryLeafTask(1, "task-2", "1.1.1.2:9000"))
+
+	// job canceled, cancels the root task and retried task
+	var wait sync.WaitGroup
+	wait.Add(2)
+	taskManager.EXPECT().Complete("task-1").Times(2)
+	taskManager.EXPECT().Complete("task-2")
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).
+		DoAndReturn(func(_ string, req *pb.TaskRequest) error {
+			defer wait.Done()
+			assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+			assert.Equal(t, "task-1", req.ParentTaskID)
+			return nil
+		})
+	taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).
+		DoAndReturn(func(_ string, req *pb.TaskRequest) error {
+			defer wait.Done()
+			assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+			assert.Equal(t, "task-2", req.ParentTaskID)
+			return nil
+		})
+	cancel()
+	wait.Wait()
+	waitJobRemoved(jobManager, 1)
+
+	// case 2: leaf task responses error, retry on other replica
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(nil)
+	jobCtx := NewJobContext(context.TODO(), make(chan *series.TimeSeriesEvent), physicalPlan, query, "", replicas)
+	err = jobManager.SubmitJob(jobCtx)
+	assert.NoError(t, err)
+	taskCtx := NewMockTaskContext(ctrl)
+	taskCtx.EXPECT().AddExpectResults(int32(0))
+	// task-3 is allocated by the last retry of job 1
+	taskManager.EXPECT().Get("task-4").Return(taskCtx)
+	taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).
+		DoAndReturn(func(_ string, req *pb.TaskRequest) error {
+			assert.Equal(t, "task-5", req.ParentTaskID)
+			return nil
+		})
+	assert.True(t, jobManager.RetryLeafTask(2, "task-4", "1.1.1.1:9000"))
+	taskManager.EXPECT().Complete(gomock.Any()).Times(2)
+	jobCtx.Complete()
+	waitJobRemoved(jobManager, 2)
+
+	// case 3: retried leaf task fails to send and cannot be retried again, cancels the sent retried leaf tasks
+	replicas = &models.QueryableReplicas{
+		Nodes:      map[string][]int32{"1.1.1.1:9000": {1, 2}},
+		Candidates: map[int32][]string{1: {"1.1.1.2:9000"}, 2: {"1.1.1.4:9000"}},
+	}
+	taskManager.EXPECT().SendRequest("1.1.1.1:9000", gomock.Any()).Return(nil)
+	jobCtx = NewJobContext(context.TODO(), make(chan *series.TimeSeriesEvent), physicalPlan, query, "", replicas)
+	err = jobManager.SubmitJob(jobCtx)
+	assert.NoError(t, err)
+	taskCtx.EXPECT().AddExpectResults(int32(1))
+	taskManager.EXPECT().Get("task-6").Return(taskCtx)
+	taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).
+		DoAndReturn(func(_ string, req *pb.TaskRequest) error {
+			assert.Equal(t, pb.RequestType_Data, req.RequestType)
+			assert.Equal(t, "task-7", req.ParentTaskID)
+			return nil
+		})
+	taskManager.EXPECT().SendRequest("1.1.1.4:9000", gomock.Any()).Return(fmt.Errorf("err"))
+	taskManager.EXPECT().SendRequest("1.1.1.2:9000", gomock.Any()).
+		DoAndReturn(func(_ string, req *pb.TaskRequest) error {
+			assert.Equal(t, pb.RequestType_Cancel, req.RequestType)
+			assert.Equal(t, "task-7", req.ParentTaskID)
+			return nil
+		})
+	assert.False(t, jobManager.RetryLeafTask(3, "task-6", "1.1.1.1:9000"))
+	taskManager.EXPECT().Complete(gomock.Any()).Times(2)
+	jobCtx.Complete()
+	waitJobRemoved(jobManager, 3)
+}
@@ -3,6 +3,7 @@ package parallel
 import (
 	"context"
 	"encoding/json"
+	"sync"
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/models"
@@ -23,6 +24,8 @@ type leafTask struct {
 	storageService    service.StorageService
 	executorFactory   ExecutorFactory
 	taskServerFactory rpc.TaskServerFactory
+
+	runningTasks sync.Map // key: parent task id, value: cancel func of the running data search
 }
 
 // newLeafTask creates the leaf task
@@ -42,6 +45,10 @@ func newLeafTask(
 
 // Process processes the task request, searches the metric's data from time series engine
 func (p *leafTask) Process(ctx context.Context, req *pb.TaskRequest) error {
+	if req.RequestType == pb.RequestType_Cancel {
+		p.cancelDataSearch(req.ParentTaskID)
+		return nil
+	}
 	physicalPlan := models.PhysicalPlan{}
 	if err := json.Unmarshal(req.PhysicalPlan, &physicalPlan); err != nil {
 		return errUnmarshalPlan
@@ -118,12 +125,41 @@ func (p *leafTask) processDataSearch(ctx context.Context, db tsdb.Database, shar
 	option := db.GetOption()
 	var interval timeutil.Interval
 	_ = interval.ValueOf(option.Interval)
-	//TODO need get storage interval by query time if has rollup config
-	timeRange, intervalRatio, queryInterval := downSamplingTimeRange(query.Interval, interval, query.TimeRange)
+	// get storage interval by query interval if has rollup config
+	storageInterval := option.GetStorageInterval(query.Interval)
+	timeRange, intervalRatio, queryInterval := downSamplingTimeRange(query.Interval, storageInterval, query.TimeRange)
 	// execute leaf task
-	storageExecuteCtx := p.executorFactory.NewStorageExecuteContext(shardIDs, &query)
-	queryFlow := NewStorageQueryFlow(ctx, storageExecuteCtx, &query, req, stream, db.ExecutorPool(), timeRange, queryInterval, intervalRatio)
+	storageExecuteCtx := p.executorFactory.NewStorageExecuteContext(shardIDs, storageInterval, storageInterval != interval, &query)
+	queryCtx, cancel := newDataSearchContext(ctx)
+	p.runningTasks.Store(req.ParentTaskID, cancel)
+	queryFlow := NewStorageQueryFlow(queryCtx, storageExecuteCtx, &query, req, stream, db.ExecutorPool(), timeRange, queryInterval, intervalRatio)
+	// the context of query flow is done after query flow completed/canceled/timeout
+	go p.releaseDataSearch(req.ParentTaskID, queryFlow.(*storageQueryFlow).ctx, cancel)
 	exec := p.executorFactory.NewStorageExecutor(queryFlow, db, storageExecuteCtx)
 	exec.Execute()
 	return nil
 }
+
+// newDataSearchContext creates the context of data search, which can be canceled by broker.
+// data search is executed asynchronously, but the context of dispatching is released after dispatched,
+// so creates a new context with the same deadline.
+func newDataSearchContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if deadline, ok := ctx.Deadline(); ok {
+		return context.WithDeadline(context.Background(), deadline)
+	}
+	return context.WithCancel(context.Background())
+}
+
+// releaseDataSearch waits the data search done, then releases the context of data search
+func (p *leafTask) releaseDataSearch(taskID string, done context.Context, cancel context.CancelFunc) {
+	<-done.Done()
+	cancel()
+	p.runningTasks.Delete(taskID)
+}
+
+// cancelDataSearch cancels the running data search by parent task id
+func (p *leafTask) cancelDataSearch(taskID string) {
+	if cancel, ok := p.runningTasks.Load(taskID); ok {
+		cancel.(context.CancelFunc)()
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -75,7 +76,7 @@ func TestLeafTask_Process_Fail(t *testing.T) {
 	storageService.EXPECT().GetDatabase(gomock.Any()).Return(mockDatabase, true).AnyTimes()
 	exec := NewMockExecutor(ctrl)
 	exec.EXPECT().Execute()
-	executorFactory.EXPECT().NewStorageExecuteContext(gomock.Any(), gomock.Any()).Return(nil)
+	executorFactory.EXPECT().NewStorageExecuteContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
 	executorFactory.EXPECT().NewStorageExecutor(gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
 	err = processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: plan, Payload: data})
 	assert.NoError(t, err)
@@ -108,9 +109,46 @@ func TestLeafProcessor_Process(t *testing.T) {
 	exec := NewMockExecutor(ctrl)
 	exec.EXPECT().Execute()
 	executorFactory.EXPECT().NewStorageExecutor(gomock.Any(), gomock.Any(), gomock.Any()).Return(exec)
-	executorFactory.EXPECT().NewStorageExecuteContext(gomock.Any(), gomock.Any()).Return(nil)
-	err := processor.Process(context.TODO(), &pb.TaskRequest{PhysicalPlan: plan, Payload: data})
+	executorFactory.EXPECT().NewStorageExecuteContext(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	defer cancel()
+	err := processor.Process(ctx, &pb.TaskRequest{PhysicalPlan: plan, Payload: data, ParentTaskID: "task-1"})
 	assert.NoError(t, err)
+	_, ok := processor.(*leafTask).runningTasks.Load("task-1")
+	assert.True(t, ok)
+
+	// cancel data search by broker
+	err = processor.Process(context.TODO(), &pb.TaskRequest{RequestType: pb.RequestType_Cancel, ParentTaskID: "task-1"})
+	assert.NoError(t, err)
+	// cancel not exist task
+	err = processor.Process(context.TODO(), &pb.TaskRequest{RequestType: pb.RequestType_Cancel, ParentTaskID: "task-2"})
+	assert.NoError(t, err)
+	// running task is released after canceled
+	for {
+		if _, ok := processor.(*leafTask).runningTasks.Load("task-1"); !ok {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestNewDataSearchContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Minute)
+	defer cancel()
+	queryCtx, queryCancel := newDataSearchContext(ctx)
+	deadline, _ := ctx.Deadline()
+	queryDeadline, ok := queryCtx.Deadline()
+	assert.True(t, ok)
+	assert.Equal(t, deadline, queryDeadline)
+	// query context isn't canceled by parent
+	cancel()
+	assert.NoError(t, queryCtx.Err())
+	queryCancel()
+
+	queryCtx, queryCancel = newDataSearchContext(context.TODO())
+	_, ok = queryCtx.Deadline()
+	assert.False(t, ok)
+	queryCancel()
 }
 
 func TestLeafTask_Suggest_Process(t *testing.T) {
@@ -0,0 +1,131 @@
+package parallel
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/lindb/lindb/models"
+)
+
+// leafKey represents the leaf task which is dispatched to storage node with the task id
+type leafKey struct {
+	taskID string
+	node   string
+}
+
+// replicaTracker tracks the replicas which the shards of query job are dispatched to,
+// chooses the other replicas for the shards of failed leaf task.
+type replicaTracker struct {
+	plan       *models.PhysicalPlan
+	payload    []byte // query statement of job
+	policy     string
+	candidates map[int32][]string  // shard id => other replicas sorted by priority
+	leafs      map[leafKey][]int32 // running leaf task => shard ids
+	failed     map[string][]int32  // failed storage node => shard ids
+	retryTasks []string            // task ids of retried leaf tasks
+
+	mutex sync.Mutex
+}
+
+// newReplicaTracker creates the replica tracker based on the physical plan and the queryable replicas
+func newReplicaTracker(rootTaskID string, plan *models.PhysicalPlan, payload []byte,
+	replicas *models.QueryableReplicas) *replicaTracker {
+	t := &replicaTracker{
+		plan:       plan,
+		payload:    payload,
+		policy:     replicas.Policy,
+		candidates: replicas.Candidates,
+		leafs:      make(map[leafKey][]int32),
+		failed:     make(map[string][]int32),
+	}
+	for _, leaf := range plan.Leafs {
+		t.leafs[leafKey{taskID: rootTaskID, node: leaf.Indicator}] = leaf.ShardIDs
+	}
+	return t
+}
+
+// retry chooses the other replicas for the shards of failed leaf task, the new leaf tasks are tracked by retry task id,
+// returns nil if the leaf task cannot be retried:
+// 1) the leaf task is dispatched by intermediate node, root node cannot re-dispatch it
+// 2) any shard of the leaf task has no other queryable replica
+func (t *replicaTracker) retry(taskID, failedNode, retryTaskID string) map[string][]int32 {
+	if len(t.plan.Intermediates) > 0 {
+		return nil
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	key := leafKey{taskID: taskID, node: failedNode}
+	shardIDs, ok := t.leafs[key]
+	if !ok {
+		return nil
+	}
+	retryNodes := make(map[string][]int32)
+	for _, shardID := range shardIDs {
+		node := t.nextReplica(shardID, failedNode)
+		if node == "" {
+			return nil
+		}
+		retryNodes[node] = append(retryNodes[node], shardID)
+	}
+	delete(t.leafs, key)
+	t.failed[failedNode] = append(t.failed[failedNode], shardIDs...)
+	for node, retryShardIDs := range retryNodes {
+		t.leafs[leafKey{taskID: retryTaskID, node: node}] = retryShardIDs
+	}
+	t.retryTasks = append(t.retryTasks, retryTaskID)
+	return retryNodes
+}
+
+// nextReplica returns the first replica of shard which doesn't fail, returns empty if not found
+func (t *replicaTracker) nextReplica(shardID int32, failedNode string) string {
+	for _, node := range t.candidates[shardID] {
+		if node == failedNode {
+			continue
+		}
+		if _, ok := t.failed[node]; ok {
+			continue
+		}
+		return node
+	}
+	return ""
+}
+
+// retryTaskIDs returns the task ids of retried leaf tasks
+func (t *replicaTracker) retryTaskIDs() []string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return append([]string(nil), t.retryTasks...)
+}
+
+// runningLeafs returns the running leaf tasks, for canceling the tasks when job is canceled
+func (t *replicaTracker) runningLeafs() []leafKey {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	result := make([]leafKey, 0, len(t.leafs))
+	for key := range t.leafs {
+		result = append(result, key)
+	}
+	return result
+}
+
+// fillStats fills the chosen replicas and the failed replicas into query stats
+func (t *replicaTracker) fillStats(stats *models.QueryStats) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	stats.ReplicaPolicy = t.policy
+	stats.Replicas = make(map[string][]int32)
+	for key, shardIDs := range t.leafs {
+		stats.Replicas[key.node] = append(stats.Replicas[key.node], shardIDs...)
+	}
+	for _, shardIDs := range stats.Replicas {
+		sort.Slice(shardIDs, func(i, j int) bool { return shardIDs[i] < shardIDs[j] })
+	}
+	if len(t.failed) > 0 {
+		stats.RetriedReplicas = make(map[string][]int32)
+		for node, shardIDs := range t.failed {
+			stats.RetriedReplicas[node] = append([]int32(nil), shardIDs...)
+		}
+	}
+}
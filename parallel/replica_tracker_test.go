@@ -0,0 +1,78 @@
+package parallel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+)
+
+func newTrackerPlan() *models.PhysicalPlan {
+	plan := models.NewPhysicalPlan(models.Root{Indicator: "1.1.1.3:8000", NumOfTask: 2})
+	plan.AddLeaf(models.Leaf{
+		BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.1:9000"},
+		ShardIDs: []int32{1, 2},
+	})
+	plan.AddLeaf(models.Leaf{
+		BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.2:9000"},
+		ShardIDs: []int32{3},
+	})
+	return plan
+}
+
+func TestReplicaTracker_retry(t *testing.T) {
+	tracker := newReplicaTracker("root", newTrackerPlan(), nil, &models.QueryableReplicas{
+		Policy: "least-pending",
+		Candidates: map[int32][]string{
+			1: {"1.1.1.2:9000", "1.1.1.4:9000"},
+			2: {"1.1.1.4:9000"},
+			3: {"1.1.1.1:9000"},
+		},
+	})
+	// leaf task not found
+	assert.Nil(t, tracker.retry("root", "1.1.1.5:9000", "retry-1"))
+	assert.Nil(t, tracker.retry("other", "1.1.1.1:9000", "retry-1"))
+
+	retryNodes := tracker.retry("root", "1.1.1.1:9000", "retry-1")
+	assert.Equal(t, map[string][]int32{"1.1.1.2:9000": {1}, "1.1.1.4:9000": {2}}, retryNodes)
+	assert.Equal(t, []string{"retry-1"}, tracker.retryTaskIDs())
+	assert.Len(t, tracker.runningLeafs(), 3)
+	// failed leaf task cannot be retried twice
+	assert.Nil(t, tracker.retry("root", "1.1.1.1:9000", "retry-2"))
+	// shard 3 has no replica, 1.1.1.1:9000 already failed
+	assert.Nil(t, tracker.retry("root", "1.1.1.2:9000", "retry-2"))
+	// retried leaf task fails again
+	retryNodes = tracker.retry("retry-1", "1.1.1.2:9000", "retry-2")
+	assert.Equal(t, map[string][]int32{"1.1.1.4:9000": {1}}, retryNodes)
+	assert.Equal(t, []string{"retry-1", "retry-2"}, tracker.retryTaskIDs())
+	// shard 2 has no replica left
+	assert.Nil(t, tracker.retry("retry-1", "1.1.1.4:9000", "retry-3"))
+
+	stats := models.NewQueryStats()
+	tracker.fillStats(stats)
+	assert.Equal(t, "least-pending", stats.ReplicaPolicy)
+	assert.Equal(t, map[string][]int32{
+		"1.1.1.2:9000": {3},
+		"1.1.1.4:9000": {1, 2},
+	}, stats.Replicas)
+	assert.Equal(t, map[string][]int32{
+		"1.1.1.1:9000": {1, 2},
+		"1.1.1.2:9000": {1},
+	}, stats.RetriedReplicas)
+}
+
+func TestReplicaTracker_retry_intermediate(t *testing.T) {
+	plan := newTrackerPlan()
+	plan.AddIntermediate(models.Intermediate{
+		BaseNode: models.BaseNode{Parent: "1.1.1.3:8000", Indicator: "1.1.1.4:8000"},
+	})
+	tracker := newReplicaTracker("root", plan, nil, &models.QueryableReplicas{
+		Candidates: map[int32][]string{3: {"1.1.1.1:9000"}},
+	})
+	assert.Nil(t, tracker.retry("root", "1.1.1.2:9000", "retry-1"))
+
+	stats := models.NewQueryStats()
+	tracker.fillStats(stats)
+	assert.Nil(t, stats.RetriedReplicas)
+}
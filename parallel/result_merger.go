@@ -28,7 +28,7 @@ type ResultMerger interface {
 
 // resultMerger implements ResultMerger interface
 type resultMerger struct {
-	resultSet chan *series.TimeSeriesEvent
+	emit func(event *series.TimeSeriesEvent) // emits the result of job, nil for intermediate task
 
 	groupAgg aggregation.GroupingAggregator
 
@@ -37,18 +37,22 @@ type resultMerger struct {
 	closed chan struct{}
 	ctx    context.Context
 
+	tracker *replicaTracker // tracks the replicas of shards, nil for intermediate task
+
 	stats *models.QueryStats
 	err   error
 }
 
 // newResultMerger create a result merger
-func newResultMerger(ctx context.Context, groupAgg aggregation.GroupingAggregator, resultSet chan *series.TimeSeriesEvent) ResultMerger {
+func newResultMerger(ctx context.Context, groupAgg aggregation.GroupingAggregator, emit func(event *series.TimeSeriesEvent),
+	tracker *replicaTracker) ResultMerger {
 	merger := &resultMerger{
-		resultSet: resultSet,
-		groupAgg:  groupAgg,
-		events:    make(chan *pb.TaskResponse),
-		closed:    make(chan struct{}),
-		ctx:       ctx,
+		emit:     emit,
+		groupAgg: groupAgg,
+		tracker:  tracker,
+		events:   make(chan *pb.TaskResponse),
+		closed:   make(chan struct{}),
+		ctx:      ctx,
 	}
 	go func() {
 		defer close(merger.closed)
@@ -67,21 +71,31 @@ func (m *resultMerger) close() {
 	close(m.events)
 	// waiting process completed
 	<-m.closed
+	if m.stats != nil && m.tracker != nil {
+		m.tracker.fillStats(m.stats)
+	}
 	// send result set
 	if m.err != nil {
-		m.resultSet <- &series.TimeSeriesEvent{Err: m.err, Stats: m.stats}
+		m.send(&series.TimeSeriesEvent{Err: m.err, Stats: m.stats})
 	} else {
 		// send all series data
 		resultSet := m.groupAgg.ResultSet()
 		if len(resultSet) > 0 {
-			m.resultSet <- &series.TimeSeriesEvent{
+			m.send(&series.TimeSeriesEvent{
 				SeriesList: resultSet,
 				Stats:      m.stats,
-			}
+			})
 		}
 	}
 }
 
+// send sends the result event by job context, which gives up if the job is canceled
+func (m *resultMerger) send(event *series.TimeSeriesEvent) {
+	if m.emit != nil {
+		m.emit(event)
+	}
+}
+
 // process consumes response event, then handles response
 func (m *resultMerger) process() {
 	for {
@@ -24,7 +24,7 @@ func TestResultMerger_Merge(t *testing.T) {
 	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
 	groupAgg.EXPECT().ResultSet().Return([]series.GroupedIterator{series.NewMockGroupedIterator(ctrl)})
 	ch := make(chan *series.TimeSeriesEvent)
-	merger := newResultMerger(context.TODO(), groupAgg, ch)
+	merger := newResultMerger(context.TODO(), groupAgg, emitTo(context.TODO(), ch), nil)
 	c := atomic.NewInt32(0)
 	var wait sync.WaitGroup
 	wait.Add(1)
@@ -46,7 +46,7 @@ func TestResultMerger_Cancel(t *testing.T) {
 	groupAgg.EXPECT().ResultSet().Return(nil)
 	ch := make(chan *series.TimeSeriesEvent)
 	ctx, cancel := context.WithCancel(context.TODO())
-	merger := newResultMerger(ctx, groupAgg, ch)
+	merger := newResultMerger(ctx, groupAgg, emitTo(ctx, ch), nil)
 	var wait sync.WaitGroup
 	wait.Add(1)
 	go func() {
@@ -63,7 +63,7 @@ func TestResultMerger_Err(t *testing.T) {
 	defer ctrl.Finish()
 	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
 	ch := make(chan *series.TimeSeriesEvent)
-	merger := newResultMerger(context.TODO(), groupAgg, ch)
+	merger := newResultMerger(context.TODO(), groupAgg, emitTo(context.TODO(), ch), nil)
 	c := atomic.NewInt32(0)
 	var wait sync.WaitGroup
 	wait.Add(1)
@@ -81,6 +81,21 @@ func TestResultMerger_Err(t *testing.T) {
 	assert.Equal(t, int32(1), c.Load())
 }
 
+func TestResultMerger_ReplicaStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	groupAgg := aggregation.NewMockGroupingAggregator(ctrl)
+	ch := make(chan *series.TimeSeriesEvent, 1)
+	tracker := newReplicaTracker("taskID", newTrackerPlan(), nil, &models.QueryableReplicas{Policy: "round-robin"})
+	merger := newResultMerger(context.TODO(), groupAgg, emitTo(context.TODO(), ch), tracker)
+	merger.merge(&pb.TaskResponse{TaskID: "taskID", Stats: []byte("{}"), Payload: []byte{1, 2, 3}})
+	merger.close()
+	rs := <-ch
+	assert.Error(t, rs.Err)
+	assert.Equal(t, "round-robin", rs.Stats.ReplicaPolicy)
+	assert.Equal(t, map[string][]int32{"1.1.1.1:9000": {1, 2}, "1.1.1.2:9000": {3}}, rs.Stats.Replicas)
+}
+
 func TestResultMerger_GroupBy(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -88,7 +103,7 @@ func TestResultMerger_GroupBy(t *testing.T) {
 	groupAgg.EXPECT().Aggregate(gomock.Any()).AnyTimes()
 	groupAgg.EXPECT().ResultSet().Return([]series.GroupedIterator{series.NewMockGroupedIterator(ctrl)})
 	ch := make(chan *series.TimeSeriesEvent)
-	merger := newResultMerger(context.TODO(), groupAgg, ch)
+	merger := newResultMerger(context.TODO(), groupAgg, emitTo(context.TODO(), ch), nil)
 	c := atomic.NewInt32(0)
 	var wait sync.WaitGroup
 	wait.Add(1)
@@ -153,3 +168,13 @@ func TestSuggestMerge_merge(t *testing.T) {
 	}
 	wait.Wait()
 }
+
+// emitTo returns the func which emits the event into chan, gives up if canceled
+func emitTo(ctx context.Context, ch chan *series.TimeSeriesEvent) func(event *series.TimeSeriesEvent) {
+	return func(event *series.TimeSeriesEvent) {
+		select {
+		case ch <- event:
+		case <-ctx.Done():
+		}
+	}
+}
@@ -0,0 +1,275 @@
+package parallel
+
+import (
+	"sort"
+
+	"github.com/lindb/lindb/aggregation/function"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/series/tag"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// resultSetProcessor processes the final result set on broker side,
+// filters the time series by having, selects them by top/bottom, sorts them by order by and keeps top-N series by limit.
+type resultSetProcessor struct {
+	query     *stmt.Query
+	selectors []*stmt.SelectItem // select items of top/bottom function
+}
+
+// newResultSetProcessor creates the result set processor for query
+func newResultSetProcessor(query *stmt.Query) *resultSetProcessor {
+	p := &resultSetProcessor{query: query}
+	if query != nil {
+		for _, item := range query.SelectItems {
+			selectItem, ok := item.(*stmt.SelectItem)
+			if !ok {
+				continue
+			}
+			if callExpr, ok := selectItem.Expr.(*stmt.CallExpr); ok && callExpr.FuncType.IsSelector() {
+				p.selectors = append(p.selectors, selectItem)
+			}
+		}
+	}
+	return p
+}
+
+// hasSelector returns if the time series are selected by top/bottom, which needs all time series of result
+func (p *resultSetProcessor) hasSelector() bool {
+	return len(p.selectors) > 0
+}
+
+// process processes the time series of result set
+func (p *resultSetProcessor) process(rs *models.ResultSet) {
+	if p.query == nil || rs == nil {
+		return
+	}
+	if p.query.Having != nil {
+		seriesList := rs.Series[:0]
+		for _, s := range rs.Series {
+			if value, ok := p.eval(s, p.query.Having); ok && value != 0 {
+				seriesList = append(seriesList, s)
+			}
+		}
+		rs.Series = seriesList
+	}
+	for _, selector := range p.selectors {
+		p.selectSeries(rs, selector)
+	}
+	switch {
+	case len(p.query.OrderBy) > 0:
+		sort.SliceStable(rs.Series, func(i, j int) bool {
+			return p.less(rs.Series[i], rs.Series[j])
+		})
+	case p.query.HasGroupBy():
+		// sorts the time series by tags for stable order, which makes the pagination of series consistent
+		p.sortByTags(rs)
+	}
+	if p.query.Limit > 0 && len(rs.Series) > p.query.Limit {
+		rs.Series = rs.Series[:p.query.Limit]
+	}
+}
+
+// selectSeries keeps the k time series with the largest(top) or smallest(bottom) value of the select item,
+// the value of time series is reduced by the function of selector's param, like top(sum(f), 3) => sum,
+// the time series without value are dropped, the time series with same value are ordered by tags.
+func (p *resultSetProcessor) selectSeries(rs *models.ResultSet, selectItem *stmt.SelectItem) {
+	callExpr := selectItem.Expr.(*stmt.CallExpr)
+	k := 0
+	if len(callExpr.Params) == 2 {
+		if arg, ok := callExpr.Params[1].(*stmt.NumberLiteral); ok {
+			k = int(arg.Val)
+		}
+	}
+	funcType := function.Avg
+	if len(callExpr.Params) > 0 {
+		if param, ok := callExpr.Params[0].(*stmt.CallExpr); ok {
+			funcType = param.FuncType
+		}
+	}
+	type candidate struct {
+		series *models.Series
+		tags   string
+		value  float64
+	}
+	var candidates []candidate
+	for _, s := range rs.Series {
+		if value, ok := reduce(s.Fields[fieldNameOf(selectItem)], funcType); ok {
+			candidates = append(candidates, candidate{series: s, tags: tag.Concat(s.Tags), value: value})
+		}
+	}
+	top := callExpr.FuncType == function.Top
+	sort.Slice(candidates, func(i, j int) bool {
+		c1, c2 := candidates[i], candidates[j]
+		switch {
+		case c1.value == c2.value:
+			return c1.tags < c2.tags
+		case top:
+			return c1.value > c2.value
+		default:
+			return c1.value < c2.value
+		}
+	})
+	if k < 0 {
+		k = 0
+	}
+	if len(candidates) > k {
+		candidates = candidates[:k]
+	}
+	seriesList := make([]*models.Series, 0, len(candidates))
+	for _, c := range candidates {
+		seriesList = append(seriesList, c.series)
+	}
+	rs.Series = seriesList
+}
+
+// sortByTags sorts the time series by tags
+func (p *resultSetProcessor) sortByTags(rs *models.ResultSet) {
+	keys := make(map[*models.Series]string, len(rs.Series))
+	for _, s := range rs.Series {
+		keys[s] = tag.Concat(s.Tags)
+	}
+	sort.Slice(rs.Series, func(i, j int) bool {
+		return keys[rs.Series[i]] < keys[rs.Series[j]]
+	})
+}
+
+// less compares two time series based on order by expression list,
+// the series without value are always in the end.
+func (p *resultSetProcessor) less(s1, s2 *models.Series) bool {
+	for _, expr := range p.query.OrderBy {
+		orderBy, ok := expr.(*stmt.OrderByExpr)
+		if !ok {
+			continue
+		}
+		v1, ok1 := p.seriesValue(s1, orderBy.Expr)
+		v2, ok2 := p.seriesValue(s2, orderBy.Expr)
+		switch {
+		case !ok1 && !ok2:
+			continue
+		case !ok1:
+			return false
+		case !ok2:
+			return true
+		case v1 == v2:
+			continue
+		case orderBy.Desc:
+			return v1 > v2
+		default:
+			return v1 < v2
+		}
+	}
+	return false
+}
+
+// eval evaluates the having expression for the time series,
+// the result of boolean expression is 1(true) or 0(false).
+func (p *resultSetProcessor) eval(s *models.Series, expr stmt.Expr) (float64, bool) {
+	switch e := expr.(type) {
+	case *stmt.NumberLiteral:
+		return e.Val, true
+	case *stmt.ParenExpr:
+		return p.eval(s, e.Expr)
+	case *stmt.BinaryExpr:
+		left, leftOK := p.eval(s, e.Left)
+		right, rightOK := p.eval(s, e.Right)
+		switch e.Operator {
+		case stmt.AND:
+			return boolValue(leftOK && rightOK && left != 0 && right != 0), true
+		case stmt.OR:
+			return boolValue((leftOK && left != 0) || (rightOK && right != 0)), true
+		}
+		if !leftOK || !rightOK {
+			return 0, false
+		}
+		switch e.Operator {
+		case stmt.EQUAL:
+			return boolValue(left == right), true
+		case stmt.NOTEQUAL:
+			return boolValue(left != right), true
+		case stmt.LESS:
+			return boolValue(left < right), true
+		case stmt.LESSEQUAL:
+			return boolValue(left <= right), true
+		case stmt.GREATER:
+			return boolValue(left > right), true
+		case stmt.GREATEREQUAL:
+			return boolValue(left >= right), true
+		case stmt.ADD:
+			return left + right, true
+		case stmt.SUB:
+			return left - right, true
+		case stmt.MUL:
+			return left * right, true
+		case stmt.DIV:
+			if right == 0 {
+				return 0, true
+			}
+			return left / right, true
+		default:
+			return 0, false
+		}
+	default:
+		return p.seriesValue(s, expr)
+	}
+}
+
+// seriesValue reduces the points of the field which the expr refers to as a single value,
+// the reduce function depends on the function of select item.
+func (p *resultSetProcessor) seriesValue(s *models.Series, expr stmt.Expr) (float64, bool) {
+	selectItem, ok := p.query.SelectItemOf(expr)
+	if !ok {
+		return 0, false
+	}
+	funcType := function.Avg
+	if callExpr, ok := selectItem.Expr.(*stmt.CallExpr); ok {
+		funcType = callExpr.FuncType
+	}
+	return reduce(s.Fields[fieldNameOf(selectItem)], funcType)
+}
+
+// fieldNameOf returns the field name of select item in result set
+func fieldNameOf(selectItem *stmt.SelectItem) string {
+	if len(selectItem.Alias) > 0 {
+		return selectItem.Alias
+	}
+	return selectItem.Expr.Rewrite()
+}
+
+// reduce reduces the points as a single value by function type:
+// sum/count => sum, min => min, max => max, others => avg.
+func reduce(points map[int64]float64, funcType function.FuncType) (float64, bool) {
+	if len(points) == 0 {
+		return 0, false
+	}
+	var result float64
+	first := true
+	for _, value := range points {
+		switch {
+		case first:
+			result = value
+			first = false
+		case funcType == function.Min:
+			if value < result {
+				result = value
+			}
+		case funcType == function.Max:
+			if value > result {
+				result = value
+			}
+		default:
+			result += value
+		}
+	}
+	if funcType != function.Sum && funcType != function.Count && funcType != function.Min && funcType != function.Max {
+		result /= float64(len(points))
+	}
+	return result, true
+}
+
+// boolValue returns 1 if b is true, else returns 0
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
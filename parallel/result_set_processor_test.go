@@ -0,0 +1,105 @@
+package parallel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/sql"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func newTestSeries(host string, fields map[string][]float64) *models.Series {
+	s := models.NewSeries(map[string]string{"host": host})
+	for fieldName, values := range fields {
+		points := models.NewPoints()
+		for idx, value := range values {
+			points.AddPoint(int64(idx), value)
+		}
+		s.AddField(fieldName, points)
+	}
+	return s
+}
+
+func newTestResultSet() *models.ResultSet {
+	rs := models.NewResultSet()
+	rs.AddSeries(newTestSeries("1", map[string][]float64{"s": {1, 2}, "max(g)": {10, 20}}))
+	rs.AddSeries(newTestSeries("2", map[string][]float64{"s": {5, 5}, "max(g)": {1, 2}}))
+	rs.AddSeries(newTestSeries("3", map[string][]float64{"s": {3, 4}}))
+	rs.AddSeries(newTestSeries("4", map[string][]float64{"s": {3, 4}, "max(g)": {100, 1}}))
+	return rs
+}
+
+func hostsOf(rs *models.ResultSet) []string {
+	var hosts []string
+	for _, s := range rs.Series {
+		hosts = append(hosts, s.Tags["host"])
+	}
+	return hosts
+}
+
+func TestResultSetProcessor_process(t *testing.T) {
+	cases := []struct {
+		sql   string
+		hosts []string
+	}{
+		{sql: "select sum(f) as s,max(g) from cpu group by host", hosts: []string{"1", "2", "3", "4"}},
+		{sql: "select sum(f) as s,max(g) from cpu group by host order by s desc", hosts: []string{"2", "3", "4", "1"}},
+		{sql: "select sum(f) as s,max(g) from cpu group by host order by s desc,max(g) desc",
+			hosts: []string{"2", "4", "3", "1"}},
+		{sql: "select sum(f) as s,max(g) from cpu group by host order by max(g) asc", hosts: []string{"2", "1", "4", "3"}},
+		{sql: "select sum(f) as s,max(g) from cpu group by host order by max(g) desc limit 2", hosts: []string{"4", "1"}},
+		{sql: "select sum(f) as s,max(g) from cpu group by host having s >= 7", hosts: []string{"2", "3", "4"}},
+		{sql: "select sum(f) as s,max(g) from cpu group by host having s > 7 and max(g) < 50", hosts: []string{"2"}},
+		{sql: "select sum(f) as s,max(g) from cpu group by host having (s < 5 or max(g)=2) and s/2 != 1",
+			hosts: []string{"1", "2"}},
+		{sql: "select sum(f) as s,max(g) from cpu group by host having s-1 <= 2 or s*1=10 order by s",
+			hosts: []string{"1", "2"}},
+		{sql: "select sum(f) as s,max(g) from cpu group by host having s/0=0", hosts: []string{"1", "2", "3", "4"}},
+		{sql: "select avg(f) as s,max(g) from cpu group by host having s < 5 order by s", hosts: []string{"1", "3", "4"}},
+		{sql: "select top(sum(f), 2) as s from cpu group by host", hosts: []string{"2", "3"}},
+		{sql: "select bottom(sum(f), 3) as s from cpu group by host", hosts: []string{"1", "3", "4"}},
+		{sql: "select top(sum(f), 3) as s from cpu group by host order by s", hosts: []string{"3", "4", "2"}},
+		{sql: "select top(sum(f), 2) as s from cpu group by host having s > 3", hosts: []string{"2", "3"}},
+		{sql: "select top(sum(f), 0) as s from cpu group by host", hosts: nil},
+		{sql: "select min(f) as s,max(g) from cpu group by host order by s", hosts: []string{"1", "3", "4", "2"}},
+	}
+	for _, c := range cases {
+		q, err := sql.Parse(c.sql)
+		assert.NoError(t, err, c.sql)
+		rs := newTestResultSet()
+		newResultSetProcessor(q.(*stmt.Query)).process(rs)
+		assert.Equal(t, c.hosts, hostsOf(rs), c.sql)
+	}
+
+	// nil query/result set
+	newResultSetProcessor(nil).process(newTestResultSet())
+	newResultSetProcessor(&stmt.Query{}).process(nil)
+	// having with unknown operator
+	rs := newTestResultSet()
+	newResultSetProcessor(&stmt.Query{
+		SelectItems: []stmt.Expr{&stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "s"}}},
+		Having: &stmt.BinaryExpr{
+			Left: &stmt.FieldExpr{Name: "s"}, Operator: stmt.UNKNOWN, Right: &stmt.NumberLiteral{Val: 1},
+		},
+		OrderBy: []stmt.Expr{&stmt.FieldExpr{Name: "s"}},
+	}).process(rs)
+	assert.Empty(t, rs.Series)
+	// sort by tags without order by
+	rs = models.NewResultSet()
+	rs.AddSeries(newTestSeries("3", nil))
+	rs.AddSeries(newTestSeries("1", nil))
+	rs.AddSeries(newTestSeries("2", nil))
+	q, _ := sql.Parse("select f from cpu group by host")
+	newResultSetProcessor(q.(*stmt.Query)).process(rs)
+	assert.Equal(t, []string{"1", "2", "3"}, hostsOf(rs))
+	// limit after sorting by tags
+	rs = models.NewResultSet()
+	rs.AddSeries(newTestSeries("3", nil))
+	rs.AddSeries(newTestSeries("1", nil))
+	rs.AddSeries(newTestSeries("2", nil))
+	q, _ = sql.Parse("select f from cpu group by host limit 2")
+	newResultSetProcessor(q.(*stmt.Query)).process(rs)
+	assert.Equal(t, []string{"1", "2"}, hostsOf(rs))
+}
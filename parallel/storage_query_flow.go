@@ -40,6 +40,7 @@ type storageQueryFlow struct {
 	stream            pb.TaskService_HandleServer
 	req               *pb.TaskRequest
 	ctx               context.Context
+	cancel            context.CancelFunc
 	allocAgg          allocAgg
 
 	queryTimeRange     timeutil.TimeRange
@@ -66,8 +67,10 @@ func NewStorageQueryFlow(ctx context.Context,
 	queryInterval timeutil.Interval,
 	queryIntervalRatio int,
 ) flow.StorageQueryFlow {
+	c, cancel := context.WithCancel(ctx)
 	return &storageQueryFlow{
-		ctx:                ctx,
+		ctx:                c,
+		cancel:             cancel,
 		storageExecuteCtx:  storageExecuteCtx,
 		query:              query,
 		req:                req,
@@ -130,6 +133,7 @@ func (qf *storageQueryFlow) releaseAgg(agg aggregation.ContainerAggregator) {
 // Complete completes the query flow with error
 func (qf *storageQueryFlow) Complete(err error) {
 	if err != nil && qf.completed.CAS(false, true) {
+		defer qf.cancel()
 		// if complete with err, need send err msg directly and mark task completed
 		if err := qf.stream.Send(&pb.TaskResponse{
 			JobID:     qf.req.JobID,
@@ -211,6 +215,7 @@ func (qf *storageQueryFlow) completeTask(taskID int32) {
 	qf.mux.Unlock()
 
 	if completed && qf.completed.CAS(false, true) {
+		defer qf.cancel()
 		// if all tasks of all stages completed
 		var data []byte
 		if qf.reduceAgg != nil {
@@ -279,6 +284,11 @@ func (qf *storageQueryFlow) execute(stage Stage, task concurrent.Task) {
 		// query flow is completed, reject new task execute
 		return
 	}
+	if err := qf.ctx.Err(); err != nil {
+		// query is canceled by broker or timeout, completes query flow with err
+		qf.Complete(err)
+		return
+	}
 	var executePool concurrent.Pool
 	switch stage {
 	case Filtering:
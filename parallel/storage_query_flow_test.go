@@ -230,3 +230,27 @@ func TestStorageQueryFlow_Complete(t *testing.T) {
 	queryFlow.Complete(fmt.Errorf("err")) // send err result
 	queryFlow.Complete(fmt.Errorf("err")) // no send err result
 }
+
+func TestStorageQueryFlow_Canceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageExecuteCtx := NewMockStorageExecuteContext(ctrl)
+	streamHandler := commonmock.NewMockTaskService_HandleServer(ctrl)
+	ctx, cancel := context.WithCancel(context.TODO())
+	queryFlow := NewStorageQueryFlow(ctx, storageExecuteCtx, &stmt.Query{}, &pb.TaskRequest{}, streamHandler, testExecPool,
+		timeutil.TimeRange{}, timeutil.Interval(timeutil.OneSecond), 1)
+	cancel()
+	// query canceled, reject task and send err result
+	streamHandler.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *pb.TaskResponse) error {
+		assert.Equal(t, context.Canceled.Error(), resp.ErrMsg)
+		return nil
+	})
+	executed := false
+	queryFlow.Filtering(func() {
+		executed = true
+	})
+	assert.False(t, executed)
+	// context of query flow is released after completed
+	<-queryFlow.(*storageQueryFlow).ctx.Done()
+}
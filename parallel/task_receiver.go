@@ -16,14 +16,20 @@ func NewTaskReceiver(jobManager JobManager) rpc.TaskReceiver {
 	return &taskReceiver{jobManager: jobManager}
 }
 
-// Receive receives the task result, merges them and finally returns the final result
-func (r *taskReceiver) Receive(resp *pb.TaskResponse) error {
+// Receive receives the task result, merges them and finally returns the final result,
+// if the leaf task of root task fails because the replica is unavailable, retries it on the other replicas first,
+// the other errors(such as bad query) are returned directly, because they fail on all replicas.
+func (r *taskReceiver) Receive(resp *pb.TaskResponse, targetNodeID string) error {
 	taskID := resp.TaskID
 	taskManager := r.jobManager.GetTaskManager()
 	taskCtx := taskManager.Get(taskID)
 	if taskCtx == nil {
 		return nil
 	}
+	if len(resp.ErrMsg) > 0 && isUnavailableError(resp.ErrMsg) && taskCtx.TaskType() == RootTask &&
+		r.jobManager.RetryLeafTask(resp.JobID, taskID, targetNodeID) {
+		return nil
+	}
 
 	taskCtx.ReceiveResult(resp)
 
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"go.uber.org/atomic"
 
+	"github.com/lindb/lindb/constants"
 	pb "github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/series"
 )
@@ -24,7 +25,7 @@ func TestTaskReceiver_Receive(t *testing.T) {
 
 	receiver := NewTaskReceiver(jobManager)
 	taskManager.EXPECT().Get("taskID").Return(nil)
-	err := receiver.Receive(&pb.TaskResponse{TaskID: "taskID"})
+	err := receiver.Receive(&pb.TaskResponse{TaskID: "taskID"}, "leafNode")
 	assert.Nil(t, err)
 
 	merger := NewMockResultMerger(ctrl)
@@ -36,7 +37,7 @@ func TestTaskReceiver_Receive(t *testing.T) {
 	taskManager.EXPECT().Complete("taskID")
 	taskManager.EXPECT().Get("taskID").Return(taskCtx)
 	ch := make(chan *series.TimeSeriesEvent)
-	jobCtx := NewJobContext(context.TODO(), ch, nil, nil)
+	jobCtx := NewJobContext(context.TODO(), ch, nil, nil, "", nil)
 	jobManager.EXPECT().GetJob(gomock.Any()).Return(jobCtx)
 	a := atomic.NewInt32(0)
 
@@ -51,12 +52,47 @@ func TestTaskReceiver_Receive(t *testing.T) {
 		}
 	}()
 
-	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true})
+	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true}, "leafNode")
 	assert.Nil(t, err)
 	wait.Wait()
 	assert.Equal(t, int32(1), a.Load())
 }
 
+func TestTaskReceiver_Receive_NotRetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	jobManager := NewMockJobManager(ctrl)
+	taskManager := NewMockTaskManager(ctrl)
+	jobManager.EXPECT().GetTaskManager().Return(taskManager).AnyTimes()
+	receiver := NewTaskReceiver(jobManager)
+
+	merger := NewMockResultMerger(ctrl)
+	merger.EXPECT().close()
+	taskCtx := newTaskContext("taskID", RootTask, "parentTaskID", "parentNode", 1, merger)
+	taskManager.EXPECT().Get("taskID").Return(taskCtx)
+	taskManager.EXPECT().Complete("taskID")
+	ch := make(chan *series.TimeSeriesEvent, 1)
+	jobCtx := NewJobContext(context.TODO(), ch, nil, nil, "", nil)
+	jobManager.EXPECT().GetJob(gomock.Any()).Return(jobCtx)
+
+	// query error fails on all replicas, doesn't retry leaf task
+	err := receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true, ErrMsg: "field not found"}, "leafNode")
+	assert.Nil(t, err)
+	assert.True(t, taskCtx.Completed())
+	event := <-ch
+	assert.Error(t, event.Err)
+}
+
+func TestIsUnavailableError(t *testing.T) {
+	assert.True(t, isUnavailableError(errNoDatabase.Error()))
+	assert.True(t, isUnavailableError(errNoSendStream.Error()))
+	assert.True(t, isUnavailableError(fmt.Errorf("%w in database storage engine", constants.ErrShardNotFound).Error()))
+	assert.True(t, isUnavailableError("rpc error: code = Unavailable desc = transport is closing"))
+	assert.False(t, isUnavailableError("rpc error: code = InvalidArgument desc = bad request"))
+	assert.False(t, isUnavailableError(errUnmarshalQuery.Error()))
+}
+
 func TestTaskReceiver_Receive_Err(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -70,9 +106,9 @@ func TestTaskReceiver_Receive_Err(t *testing.T) {
 	merger.EXPECT().close()
 	taskCtx := newTaskContext("taskID", RootTask, "parentTaskID", "parentNode", 1, merger)
 	taskManager.EXPECT().Complete("taskID").MaxTimes(2)
-	taskManager.EXPECT().Get("taskID").Return(taskCtx).MaxTimes(2)
+	taskManager.EXPECT().Get("taskID").Return(taskCtx).MaxTimes(3)
 	ch := make(chan *series.TimeSeriesEvent)
-	jobCtx := NewJobContext(context.TODO(), ch, nil, nil)
+	jobCtx := NewJobContext(context.TODO(), ch, nil, nil, "", nil)
 	jobManager.EXPECT().GetJob(gomock.Any()).Return(jobCtx).MaxTimes(2)
 	a := atomic.NewInt32(0)
 	var wait sync.WaitGroup
@@ -86,10 +122,18 @@ func TestTaskReceiver_Receive_Err(t *testing.T) {
 		}
 	}()
 
-	err := receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true, ErrMsg: "error"})
+	// retry leaf task on other replicas
+	jobManager.EXPECT().RetryLeafTask(gomock.Any(), "taskID", "leafNode").Return(true)
+	err := receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true, ErrMsg: errNoDatabase.Error()}, "leafNode")
+	assert.Nil(t, err)
+	assert.False(t, taskCtx.Completed())
+	// cannot retry leaf task
+	jobManager.EXPECT().RetryLeafTask(gomock.Any(), "taskID", "leafNode").Return(false)
+	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true,
+		ErrMsg: "shard not found in database storage engine"}, "leafNode")
 	assert.Nil(t, err)
 	// ignore response
-	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true})
+	err = receiver.Receive(&pb.TaskResponse{TaskID: "taskID", Completed: true}, "leafNode")
 	assert.Nil(t, err)
 	wait.Wait()
 	assert.Equal(t, int32(1), a.Load())
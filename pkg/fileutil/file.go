@@ -1,6 +1,7 @@
 package fileutil
 
 import (
+	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -10,6 +11,7 @@ var (
 	mkdirAllFunc  = os.MkdirAll
 	removeAllFunc = os.RemoveAll
 	removeFunc    = os.Remove
+	linkFunc      = os.Link
 )
 
 // MkDirIfNotExist creates given dir if it not exist
@@ -71,6 +73,38 @@ func Exist(file string) bool {
 	return true
 }
 
+// LinkOrCopy creates hard link of source file, copies the file if cannot create hard link(e.g. cross device)
+func LinkOrCopy(source, target string) error {
+	if err := linkFunc(source, target); err == nil {
+		return nil
+	}
+	return CopyFile(source, target)
+}
+
+// CopyFile copies the source file into target file, then syncs the target file
+func CopyFile(source, target string) (err error) {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = in.Close()
+	}()
+	out, err := os.OpenFile(target, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := out.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}()
+	if _, err = io.Copy(out, in); err != nil {
+		return err
+	}
+	return out.Sync()
+}
+
 // GetExistPath get exist path based on given path
 func GetExistPath(path string) string {
 	if Exist(path) {
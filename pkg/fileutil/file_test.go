@@ -2,6 +2,7 @@ package fileutil
 
 import (
 	"fmt"
+	"io/ioutil"
 	"os"
 	"path/filepath"
 	"testing"
@@ -104,3 +105,30 @@ func TestRemoveFile(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, files, 1)
 }
+
+func TestLinkOrCopy(t *testing.T) {
+	_ = MkDirIfNotExist(testPath)
+	defer func() {
+		_ = RemoveDir(testPath)
+		linkFunc = os.Link
+	}()
+	source := filepath.Join(testPath, "source")
+	assert.NoError(t, ioutil.WriteFile(source, []byte("data"), 0644))
+	// case 1: create hard link
+	assert.NoError(t, LinkOrCopy(source, filepath.Join(testPath, "link")))
+	data, err := ioutil.ReadFile(filepath.Join(testPath, "link"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+	// case 2: copy file if cannot link
+	linkFunc = func(oldname, newname string) error {
+		return fmt.Errorf("err")
+	}
+	assert.NoError(t, LinkOrCopy(source, filepath.Join(testPath, "copy")))
+	data, err = ioutil.ReadFile(filepath.Join(testPath, "copy"))
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+	// case 3: source not exist
+	assert.Error(t, LinkOrCopy(filepath.Join(testPath, "not_exist"), filepath.Join(testPath, "copy2")))
+	// case 4: target dir not exist
+	assert.Error(t, CopyFile(source, filepath.Join(testPath, "not_exist", "copy")))
+}
@@ -0,0 +1,29 @@
+package option
+
+import "fmt"
+
+// ReplicaSelectPolicy represents the policy of choosing one replica of each shard for querying
+type ReplicaSelectPolicy string
+
+const (
+	// LeastPending chooses the replica which has the least num. of pending msg in all brokers
+	LeastPending ReplicaSelectPolicy = "least-pending"
+	// MostCaughtUp chooses the replica whose ack index is closest to the most caught-up replica in all brokers
+	MostCaughtUp ReplicaSelectPolicy = "most-caught-up"
+	// PreferLocalZone chooses the replica in the same zone with broker first, then the least pending one
+	PreferLocalZone ReplicaSelectPolicy = "prefer-local-zone"
+	// RoundRobin chooses the replicas in turn for spreading the query load
+	RoundRobin ReplicaSelectPolicy = "round-robin"
+)
+
+// ParseReplicaSelectPolicy parses the replica select policy, returns least-pending if policy is empty
+func ParseReplicaSelectPolicy(policy string) (ReplicaSelectPolicy, error) {
+	switch p := ReplicaSelectPolicy(policy); p {
+	case "":
+		return LeastPending, nil
+	case LeastPending, MostCaughtUp, PreferLocalZone, RoundRobin:
+		return p, nil
+	default:
+		return "", fmt.Errorf("unknown replica select policy: %s", policy)
+	}
+}
@@ -0,0 +1,20 @@
+package option
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReplicaSelectPolicy(t *testing.T) {
+	policy, err := ParseReplicaSelectPolicy("")
+	assert.NoError(t, err)
+	assert.Equal(t, LeastPending, policy)
+	for _, p := range []ReplicaSelectPolicy{LeastPending, MostCaughtUp, PreferLocalZone, RoundRobin} {
+		policy, err = ParseReplicaSelectPolicy(string(p))
+		assert.NoError(t, err)
+		assert.Equal(t, p, policy)
+	}
+	_, err = ParseReplicaSelectPolicy("random")
+	assert.Error(t, err)
+}
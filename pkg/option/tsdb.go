@@ -2,6 +2,7 @@ package option
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/lindb/lindb/pkg/timeutil"
 )
@@ -18,8 +19,16 @@ type DatabaseOption struct {
 	Behind string `toml:"behind" json:"behind,omitempty"` // allowed timestamp write behind
 	Ahead  string `toml:"ahead" json:"ahead,omitempty"`   // allowed timestamp write ahead
 
+	// data retention of write interval, never expire if not set
+	TTL string `toml:"ttl" json:"ttl,omitempty"`
+	// data retention of each rollup interval(same order with rollup intervals), never expire if not set
+	RollupTTL []string `toml:"rollupTTL" json:"rollupTTL,omitempty"`
+
 	Index FlusherOption `toml:"index" json:"index,omitempty"` // index flusher option
 	Data  FlusherOption `toml:"data" json:"data,omitempty"`   // data flusher data
+
+	// policy of choosing the replica of shard for querying, least-pending if not set
+	ReplicaSelectPolicy string `toml:"replicaSelectPolicy" json:"replicaSelectPolicy,omitempty"`
 }
 
 // FlusherOption represents a flusher configuration for index and memory db
@@ -53,9 +62,103 @@ func (e DatabaseOption) Validate() error {
 			return fmt.Errorf("rollup interval must be large than write interval")
 		}
 	}
+	// data is rolled up from the smaller interval to the larger one,
+	// so each interval must be a multiple of the smaller one
+	intervals := e.GetIntervals()
+	for idx := 1; idx < len(intervals); idx++ {
+		if intervals[idx]%intervals[idx-1] != 0 {
+			return fmt.Errorf("rollup interval must be a multiple of the smaller write/rollup interval")
+		}
+	}
+	if _, err := ParseReplicaSelectPolicy(e.ReplicaSelectPolicy); err != nil {
+		return err
+	}
+	return e.validateTTL()
+}
+
+// GetIntervals returns the write interval and the distinct rollup intervals, sorted by interval asc
+func (e DatabaseOption) GetIntervals() []timeutil.Interval {
+	var result []timeutil.Interval
+	intervals := make(map[timeutil.Interval]struct{})
+	for _, intervalStr := range append([]string{e.Interval}, e.Rollup...) {
+		var interval timeutil.Interval
+		if err := interval.ValueOf(intervalStr); err != nil {
+			continue
+		}
+		if _, ok := intervals[interval]; ok {
+			continue
+		}
+		intervals[interval] = struct{}{}
+		result = append(result, interval)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i] < result[j] })
+	return result
+}
+
+// GetStorageInterval returns the storage interval for querying data by query interval,
+// picks the coarsest write/rollup interval which query interval is a multiple of,
+// returns the write interval if query interval is not set or no rollup interval matches.
+func (e DatabaseOption) GetStorageInterval(queryInterval timeutil.Interval) timeutil.Interval {
+	var result timeutil.Interval
+	_ = result.ValueOf(e.Interval)
+	if queryInterval <= 0 {
+		return result
+	}
+	for _, interval := range e.GetIntervals() {
+		if interval > queryInterval {
+			break
+		}
+		if queryInterval%interval == 0 {
+			result = interval
+		}
+	}
+	return result
+}
+
+// validateTTL checks the data retention of write/rollup intervals if valid
+func (e DatabaseOption) validateTTL() error {
+	if len(e.RollupTTL) > len(e.Rollup) {
+		return fmt.Errorf("num. of rollup ttl cannot be large than num. of rollup interval")
+	}
+	if err := validateInterval(e.TTL, false); err != nil {
+		return err
+	}
+	for _, ttl := range e.RollupTTL {
+		if err := validateInterval(ttl, false); err != nil {
+			return err
+		}
+	}
+	if e.TTL != "" && e.Behind != "" {
+		var ttl, behind timeutil.Interval
+		_ = ttl.ValueOf(e.TTL)
+		_ = behind.ValueOf(e.Behind)
+		if ttl.Int64() <= behind.Int64() {
+			return fmt.Errorf("ttl must be large than behind")
+		}
+	}
 	return nil
 }
 
+// GetTTL returns the data retention of the interval, returns 0 if never expire
+func (e DatabaseOption) GetTTL(interval timeutil.Interval) timeutil.Interval {
+	var ttl timeutil.Interval
+	var writeInterval timeutil.Interval
+	_ = writeInterval.ValueOf(e.Interval)
+	if writeInterval == interval {
+		_ = ttl.ValueOf(e.TTL)
+		return ttl
+	}
+	for idx, intervalStr := range e.Rollup {
+		var rollupInterval timeutil.Interval
+		_ = rollupInterval.ValueOf(intervalStr)
+		if rollupInterval == interval && idx < len(e.RollupTTL) {
+			_ = ttl.ValueOf(e.RollupTTL[idx])
+			return ttl
+		}
+	}
+	return ttl
+}
+
 // validateInterval checks interval string if valid
 func validateInterval(intervalStr string, require bool) error {
 	if !require && intervalStr == "" {
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/timeutil"
 )
 
 func Test_DatabaseOption_Validate(t *testing.T) {
@@ -28,3 +30,79 @@ func Test_DatabaseOption_Validate(t *testing.T) {
 	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"20s", "1m", "1h"}, Behind: "10h", Ahead: "1h"}
 	assert.Nil(t, databaseOption.Validate())
 }
+
+func Test_DatabaseOption_Validate_TTL(t *testing.T) {
+	databaseOption := DatabaseOption{Interval: "10s", TTL: "aa"}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"1m"}, RollupTTL: []string{"30d", "1y"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"1m"}, RollupTTL: []string{"aa"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Behind: "1d", TTL: "1d"}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"1m", "1h"}, Behind: "1h",
+		TTL: "7d", RollupTTL: []string{"30d"}}
+	assert.Nil(t, databaseOption.Validate())
+}
+
+func Test_DatabaseOption_GetTTL(t *testing.T) {
+	databaseOption := DatabaseOption{Interval: "10s", Rollup: []string{"1m", "1h"},
+		TTL: "7d", RollupTTL: []string{"30d"}}
+	var interval timeutil.Interval
+	_ = interval.ValueOf("10s")
+	assert.Equal(t, timeutil.Interval(7*timeutil.OneDay), databaseOption.GetTTL(interval))
+	_ = interval.ValueOf("1m")
+	assert.Equal(t, timeutil.Interval(30*timeutil.OneDay), databaseOption.GetTTL(interval))
+	_ = interval.ValueOf("1h")
+	assert.Equal(t, timeutil.Interval(0), databaseOption.GetTTL(interval))
+	_ = interval.ValueOf("5m")
+	assert.Equal(t, timeutil.Interval(0), databaseOption.GetTTL(interval))
+}
+
+func Test_DatabaseOption_Validate_Rollup(t *testing.T) {
+	databaseOption := DatabaseOption{Interval: "10s", Rollup: []string{"15s"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"1h", "7m"}}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", Rollup: []string{"1h", "5m", "5m"}}
+	assert.Nil(t, databaseOption.Validate())
+}
+
+func Test_DatabaseOption_GetIntervals(t *testing.T) {
+	databaseOption := DatabaseOption{Interval: "10s", Rollup: []string{"1h", "5m", "300s", "aa"}}
+	assert.Equal(t,
+		[]timeutil.Interval{parseInterval("10s"), parseInterval("5m"), parseInterval("1h")},
+		databaseOption.GetIntervals())
+}
+
+func Test_DatabaseOption_GetStorageInterval(t *testing.T) {
+	databaseOption := DatabaseOption{Interval: "10s", Rollup: []string{"5m", "1h"}}
+	assert.Equal(t, parseInterval("10s"), databaseOption.GetStorageInterval(0))
+	cases := []struct {
+		query, storage string
+	}{
+		{"10s", "10s"},
+		{"1m", "10s"},
+		{"5m", "5m"},
+		{"30m", "5m"},
+		{"1h", "1h"},
+		{"1d", "1h"},
+		{"1s", "10s"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, parseInterval(c.storage), databaseOption.GetStorageInterval(parseInterval(c.query)))
+	}
+}
+
+func parseInterval(intervalStr string) timeutil.Interval {
+	var interval timeutil.Interval
+	_ = interval.ValueOf(intervalStr)
+	return interval
+}
+
+func Test_DatabaseOption_Validate_ReplicaSelectPolicy(t *testing.T) {
+	databaseOption := DatabaseOption{Interval: "10s", ReplicaSelectPolicy: "aa"}
+	assert.NotNil(t, databaseOption.Validate())
+	databaseOption = DatabaseOption{Interval: "10s", ReplicaSelectPolicy: string(MostCaughtUp)}
+	assert.Nil(t, databaseOption.Validate())
+}
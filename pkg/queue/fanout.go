@@ -21,6 +21,7 @@ var (
 	newQueueFunc  = NewQueue
 	listDirFunc   = fileutil.ListDir
 	newFanOutFunc = NewFanOut
+	removeDirFunc = fileutil.RemoveDir
 )
 
 // FanOutQueue represents a queue "produce once, consume multiple times".
@@ -31,6 +32,9 @@ type FanOutQueue interface {
 	// GetOrCreateFanOut returns the FanOut if exists,
 	// otherwise creates a new FanOut with consume seq and ack seq == queue tail seq.
 	GetOrCreateFanOut(name string) (FanOut, error)
+	// RemoveFanOut closes the FanOut and removes its persisted seq meta,
+	// the data consumed by removed FanOut only isn't retained any more.
+	RemoveFanOut(name string) error
 	// FanOutNames returns all fanOut names.
 	FanOutNames() []string
 	// Sync checks all the FanOuts tailSeqs, update the tailSeq as the smallest one.
@@ -40,6 +44,12 @@ type FanOutQueue interface {
 	HeadSeq() int64
 	// TailSeq returns the tailSeq which is the smallest seq among all the fanOut tailSeq.
 	TailSeq() int64
+	// Depth returns the num. of messages which aren't acked by all the FanOuts.
+	Depth() int64
+	// DropOldest drops the oldest num. of messages which aren't acked by all the FanOuts,
+	// the consume seq and ack seq of FanOuts are advanced over the dropped messages,
+	// returns the num. of dropped messages.
+	DropOldest(num int64) int64
 	// Close persists Seq meta, FanOut seq meta, release resources.
 	Close()
 	// get gets the message data by spec consume sequence
@@ -118,6 +128,22 @@ func (fq *fanOutQueue) GetOrCreateFanOut(name string) (FanOut, error) {
 	return fo, nil
 }
 
+// RemoveFanOut closes the FanOut and removes its persisted seq meta,
+// the data consumed by removed FanOut only isn't retained any more.
+func (fq *fanOutQueue) RemoveFanOut(name string) error {
+	fq.lock4map.Lock()
+	defer fq.lock4map.Unlock()
+
+	fo, ok := fq.fanOutMap[name]
+	if !ok {
+		return nil
+	}
+	fo.Close()
+	delete(fq.fanOutMap, name)
+
+	return removeDirFunc(path.Join(fq.fanOutDir, name))
+}
+
 // FanOutNames returns all fanOut names
 func (fq *fanOutQueue) FanOutNames() []string {
 	fq.lock4map.RLock()
@@ -168,6 +194,51 @@ func (fq *fanOutQueue) Sync() {
 	}
 }
 
+// Depth returns the num. of messages which aren't acked by all the FanOuts.
+func (fq *fanOutQueue) Depth() int64 {
+	fq.lock4map.RLock()
+	defer fq.lock4map.RUnlock()
+
+	return fq.queue.HeadSeq() - fq.minTailSeq()
+}
+
+// DropOldest drops the oldest num. of messages which aren't acked by all the FanOuts,
+// the consume seq and ack seq of FanOuts are advanced over the dropped messages,
+// returns the num. of dropped messages.
+func (fq *fanOutQueue) DropOldest(num int64) int64 {
+	fq.lock4map.Lock()
+	defer fq.lock4map.Unlock()
+
+	tailSeq := fq.minTailSeq()
+	dropSeq := tailSeq + num
+	if headSeq := fq.queue.HeadSeq(); dropSeq > headSeq {
+		dropSeq = headSeq
+	}
+	if dropSeq <= tailSeq {
+		return 0
+	}
+	for _, fo := range fq.fanOutMap {
+		fo.skip(dropSeq)
+	}
+	// the pages of dropped messages can be removed
+	fq.queue.Ack(dropSeq)
+	return dropSeq - tailSeq
+}
+
+// minTailSeq returns the smallest tailSeq among all the FanOuts, returns queue tailSeq if no FanOut.
+func (fq *fanOutQueue) minTailSeq() int64 {
+	if len(fq.fanOutMap) == 0 {
+		return fq.queue.TailSeq()
+	}
+	tailSeq := fq.queue.HeadSeq()
+	for _, fo := range fq.fanOutMap {
+		if ts := fo.TailSeq(); ts < tailSeq {
+			tailSeq = ts
+		}
+	}
+	return tailSeq
+}
+
 // Close persists Seq meta, FanOut seq meta, release resources.
 func (fq *fanOutQueue) Close() {
 	if fq.closed.CAS(false, true) {
@@ -240,6 +311,8 @@ type FanOut interface {
 	Pending() int64
 	// Close persists  headSeq, tailSeq.
 	Close()
+	// skip advances the consume seq and ack seq to seq, the messages before seq are dropped.
+	skip(seq int64)
 }
 
 // fanOut implements FanOut.
@@ -351,6 +424,10 @@ func (f *fanOut) Ack(ackSeq int64) {
 	f.lock4headSeq.RLock()
 	defer f.lock4headSeq.RUnlock()
 
+	// fanOut maybe closed by removing, but the consumer still acks the data
+	if f.closed.Load() {
+		return
+	}
 	ts := f.TailSeq()
 	hs := f.headSeq.Load()
 	// In the initial condition, ts == 0, if the first ackSeq == 0, it would be ignore.
@@ -397,8 +474,34 @@ func (f *fanOut) Pending() int64 {
 	return qh - fh
 }
 
+// skip advances the consume seq and ack seq to seq, the messages before seq are dropped.
+func (f *fanOut) skip(seq int64) {
+	f.lock4headSeq.Lock()
+	defer f.lock4headSeq.Unlock()
+
+	if f.closed.Load() {
+		return
+	}
+	if f.headSeq.Load() < seq {
+		f.headSeq.Store(seq)
+	}
+	if f.TailSeq() < seq {
+		f.setTailSeq(seq)
+	}
+	f.metaPage.PutUint64(uint64(f.headSeq.Load()), fanOutHeadSeqOffset)
+	f.metaPage.PutUint64(uint64(f.TailSeq()), fanOutTailSeqOffset)
+
+	if err := f.metaPage.Sync(); err != nil {
+		queueLogger.Error("sync fanOut meta page error", logger.String("fanOut", f.name), logger.Error(err))
+	}
+}
+
 // Close persists headSeq, tailSeq.
 func (f *fanOut) Close() {
+	// wait the running ack completed
+	f.lock4headSeq.Lock()
+	defer f.lock4headSeq.Unlock()
+
 	if f.closed.CAS(false, true) {
 		if err := f.metaPageFct.Close(); err != nil {
 			queueLogger.Error("close fanOut meta error", logger.String("fanOut", f.name), logger.Error(err))
@@ -129,6 +129,46 @@ func TestFanOutQueue_GetOrCreateFanOut(t *testing.T) {
 	assert.Equal(t, "group-1", foNames[0])
 }
 
+func TestFanOutQueue_RemoveFanOut(t *testing.T) {
+	dir := path.Join(testPath, "fanOut")
+
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		removeDirFunc = fileutil.RemoveDir
+	}()
+
+	fq, err := NewFanOutQueue(dir, 1024, time.Minute)
+	assert.NoError(t, err)
+	assert.NoError(t, fq.Put([]byte("123")))
+	fo1, err := fq.GetOrCreateFanOut("group-1")
+	assert.NoError(t, err)
+	_, err = fq.GetOrCreateFanOut("group-2")
+	assert.NoError(t, err)
+	// case 1: fanOut not exist
+	assert.NoError(t, fq.RemoveFanOut("group-3"))
+	// case 2: remove fanOut meta err
+	removeDirFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, fq.RemoveFanOut("group-2"))
+	removeDirFunc = fileutil.RemoveDir
+	// case 3: remove fanOut successfully
+	assert.NoError(t, fq.RemoveFanOut("group-1"))
+	assert.Empty(t, fq.FanOutNames())
+	assert.False(t, fileutil.Exist(path.Join(dir, fanOutDirName, "group-1")))
+	// ack after removed is ignored
+	tailSeq := fo1.TailSeq()
+	fo1.Ack(fo1.Consume())
+	assert.Equal(t, tailSeq, fo1.TailSeq())
+	fq.Close()
+
+	// removed fanOut isn't loaded after reopen
+	fq, err = NewFanOutQueue(dir, 1024, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"group-2"}, fq.FanOutNames())
+	fq.Close()
+}
+
 func TestFanOutQueue_Sync(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	dir := path.Join(testPath, "fanOut")
@@ -178,6 +218,65 @@ func TestFanOutQueue_Sync(t *testing.T) {
 	fo2.Ack(s2)
 }
 
+func TestFanOutQueue_DropOldest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dir := path.Join(testPath, "fanOut")
+
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+
+	fq, err := NewFanOutQueue(dir, 1024, time.Minute)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), fq.Depth())
+	assert.Equal(t, int64(0), fq.DropOldest(1))
+
+	for i := 0; i < 10; i++ {
+		err := fq.Put([]byte("12345"))
+		assert.NoError(t, err)
+	}
+	// no fanOut
+	assert.Equal(t, int64(10), fq.Depth())
+
+	fo1, err := fq.GetOrCreateFanOut("group-1")
+	assert.NoError(t, err)
+	fo2, err := fq.GetOrCreateFanOut("group-2")
+	assert.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		fo1.Consume()
+	}
+	fo1.Ack(4)
+	s2 := fo2.Consume() //0
+	fo2.Ack(s2)
+	assert.Equal(t, int64(9), fq.Depth())
+
+	// drops seq 1~3, fo1 isn't changed
+	assert.Equal(t, int64(3), fq.DropOldest(3))
+	assert.Equal(t, int64(6), fq.Depth())
+	assert.Equal(t, int64(3), fq.TailSeq())
+	assert.Equal(t, int64(4), fo2.HeadSeq())
+	assert.Equal(t, int64(3), fo2.TailSeq())
+	assert.Equal(t, int64(5), fo1.HeadSeq())
+	assert.Equal(t, int64(4), fo1.TailSeq())
+	assert.Equal(t, int64(4), fo2.Consume())
+
+	// drops all messages
+	assert.Equal(t, int64(6), fq.DropOldest(100))
+	assert.Equal(t, int64(0), fq.Depth())
+	assert.Equal(t, SeqNoNewMessageAvailable, fo1.Consume())
+	assert.Equal(t, SeqNoNewMessageAvailable, fo2.Consume())
+
+	// sync meta err
+	assert.NoError(t, fq.Put([]byte("12345")))
+	metaPage := page.NewMockMappedPage(ctrl)
+	fo1.(*fanOut).metaPage = metaPage
+	metaPage.EXPECT().PutUint64(gomock.Any(), gomock.Any()).Times(2)
+	metaPage.EXPECT().Sync().Return(fmt.Errorf("err"))
+	assert.Equal(t, int64(1), fq.DropOldest(1))
+	fq.Close()
+}
+
 func TestFanOut_Close(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	dir := path.Join(testPath, "fanOut")
@@ -0,0 +1,93 @@
+package queue
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/queue/page"
+)
+
+// FanOutInfo represents the sequences of fan out, used by offline inspection tool
+type FanOutInfo struct {
+	Name    string // name of fan out
+	HeadSeq int64  // the next seq to consume
+	TailSeq int64  // the seq acked
+}
+
+// Info represents the sequences and pages of fan out queue, used by offline inspection tool
+type Info struct {
+	HeadSeq         int64   // the next seq for appending message
+	TailSeq         int64   // the smallest acked seq of all fan outs
+	ExpireDataPage  int64   // the data page which is expired
+	ExpireIndexPage int64   // the index page which is expired
+	DataPages       []int64 // exist data page ids
+	IndexPages      []int64 // exist index page ids
+	FanOuts         []FanOutInfo
+}
+
+// ReadInfo reads the sequences and pages of fan out queue under dir path without opening the queue
+func ReadInfo(dirPath string) (*Info, error) {
+	if !fileutil.Exist(filepath.Join(dirPath, metaPath, fmt.Sprintf("%d.bat", metaPageIndex))) {
+		return nil, fmt.Errorf("queue meta not exist in path: %s", dirPath)
+	}
+	info := &Info{}
+	if err := readPage(filepath.Join(dirPath, metaPath), metaPageSize, func(fct page.Factory) {
+		if metaPage, ok := fct.GetPage(metaPageIndex); ok {
+			info.HeadSeq = int64(metaPage.ReadUint64(queueHeadSeqOffset)) + 1
+			info.TailSeq = int64(metaPage.ReadUint64(queueTailSeqOffset))
+			info.ExpireDataPage = int64(metaPage.ReadUint64(queueExpireDataOffset))
+			info.ExpireIndexPage = int64(metaPage.ReadUint64(queueExpireIndexOffset))
+		}
+	}); err != nil {
+		return nil, err
+	}
+	if err := readPage(filepath.Join(dirPath, dataPath), dataPageSize, func(fct page.Factory) {
+		info.DataPages = fct.GetPageIDs()
+	}); err != nil {
+		return nil, err
+	}
+	if err := readPage(filepath.Join(dirPath, indexPath), indexPageSize, func(fct page.Factory) {
+		info.IndexPages = fct.GetPageIDs()
+	}); err != nil {
+		return nil, err
+	}
+	fanOutDir := filepath.Join(dirPath, fanOutDirName)
+	if !fileutil.Exist(fanOutDir) {
+		return info, nil
+	}
+	names, err := listDirFunc(fanOutDir)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		fanOut := FanOutInfo{Name: name}
+		if err := readPage(filepath.Join(fanOutDir, name), fanOutMetaSize, func(fct page.Factory) {
+			if metaPage, ok := fct.GetPage(metaPageIndex); ok {
+				fanOut.HeadSeq = int64(metaPage.ReadUint64(fanOutHeadSeqOffset)) + 1
+				fanOut.TailSeq = int64(metaPage.ReadUint64(fanOutTailSeqOffset))
+			}
+		}); err != nil {
+			return nil, err
+		}
+		info.FanOuts = append(info.FanOuts, fanOut)
+	}
+	return info, nil
+}
+
+// readPage loads the exist pages under path, then reads them by page factory
+func readPage(path string, pageSize int, fn func(fct page.Factory)) error {
+	if !fileutil.Exist(path) {
+		return nil
+	}
+	fct, err := newPageFactoryFunc(path, pageSize)
+	if err != nil {
+		return err
+	}
+	fn(fct)
+	if err := fct.Close(); err != nil {
+		queueLogger.Warn("close page factory error", logger.String("path", path), logger.Error(err))
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package queue
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/queue/page"
+)
+
+func TestReadInfo(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dir := filepath.Join(testPath, "inspect")
+	defer func() {
+		newPageFactoryFunc = page.NewFactory
+		listDirFunc = fileutil.ListDir
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	fq, err := NewFanOutQueue(dir, 1024, time.Minute)
+	assert.NoError(t, err)
+	for i := 0; i < 10; i++ {
+		assert.NoError(t, fq.Put([]byte("123")))
+	}
+	fo, err := fq.GetOrCreateFanOut("f1")
+	assert.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		fo.Consume()
+	}
+	fo.Ack(3)
+	fq.Sync()
+	fq.Close()
+
+	// case 1: read info
+	info, err := ReadInfo(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), info.HeadSeq)
+	assert.Equal(t, int64(3), info.TailSeq)
+	assert.Equal(t, []int64{0}, info.DataPages)
+	assert.Equal(t, []int64{0}, info.IndexPages)
+	assert.Equal(t, []FanOutInfo{{Name: "f1", HeadSeq: 5, TailSeq: 3}}, info.FanOuts)
+	// case 2: queue not exist
+	_, err = ReadInfo(filepath.Join(testPath, "not_exist"))
+	assert.Error(t, err)
+	// case 3: list fan out err
+	listDirFunc = func(path string) ([]string, error) {
+		return nil, fmt.Errorf("err")
+	}
+	_, err = ReadInfo(dir)
+	assert.Error(t, err)
+	listDirFunc = fileutil.ListDir
+	// case 4: close page factory err
+	fct := page.NewMockFactory(ctrl)
+	fct.EXPECT().GetPage(gomock.Any()).Return(nil, false).AnyTimes()
+	fct.EXPECT().GetPageIDs().Return(nil).AnyTimes()
+	fct.EXPECT().Close().Return(fmt.Errorf("err")).AnyTimes()
+	newPageFactoryFunc = func(path string, pageSize int) (page.Factory, error) {
+		return fct, nil
+	}
+	info, err = ReadInfo(dir)
+	assert.NoError(t, err)
+	assert.Len(t, info.FanOuts, 1)
+	// case 5: new page factory err
+	for _, failPath := range []string{metaPath, dataPath, indexPath, fanOutDirName} {
+		failPath := failPath
+		newPageFactoryFunc = func(path string, pageSize int) (page.Factory, error) {
+			if filepath.Base(path) == failPath || filepath.Base(filepath.Dir(path)) == failPath {
+				return nil, fmt.Errorf("err")
+			}
+			return fct, nil
+		}
+		_, err = ReadInfo(dir)
+		assert.Error(t, err)
+	}
+}
@@ -176,7 +176,38 @@ func (f *factory) Close() error {
 
 // pageFileName returns the mapped file name
 func (f *factory) pageFileName(index int64) string {
-	return filepath.Join(f.path, fmt.Sprintf("%d.%s", index, pageSuffix))
+	return FileName(f.path, index)
+}
+
+// FileName returns the page file name under path by page index
+func FileName(path string, index int64) string {
+	return filepath.Join(path, fmt.Sprintf("%d.%s", index, pageSuffix))
+}
+
+// ListPageIDs returns the ids of page files under path in order, which doesn't create path or map page files
+func ListPageIDs(path string) (pageIDs []int64, err error) {
+	fileNames, err := listDirFunc(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, fn := range fileNames {
+		seq, err := parsePageID(fn)
+		if err != nil {
+			return nil, err
+		}
+		pageIDs = append(pageIDs, seq)
+	}
+	sort.Slice(pageIDs, func(i, j int) bool { return pageIDs[i] < pageIDs[j] })
+	return pageIDs, nil
+}
+
+// parsePageID parses the page id from page file name
+func parsePageID(fileName string) (int64, error) {
+	idx := strings.Index(fileName, pageSuffix)
+	if idx < 1 {
+		return 0, fmt.Errorf("invalid page file name: %s", fileName)
+	}
+	return strconv.ParseInt(fileName[0:idx-1], 10, 64)
 }
 
 // loadPages loads the exist pages when factory init
@@ -191,8 +222,7 @@ func (f *factory) loadPages() error {
 	}
 
 	for _, fn := range fileNames {
-		seqNumStr := fn[0 : strings.Index(fn, pageSuffix)-1]
-		seq, err := strconv.ParseInt(seqNumStr, 10, 64)
+		seq, err := parsePageID(fn)
 		if err != nil {
 			return err
 		}
@@ -2,6 +2,7 @@ package page
 
 import (
 	"fmt"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -189,3 +190,32 @@ func TestFactory_ReleasePage(t *testing.T) {
 	err = fct.Close()
 	assert.NoError(t, err)
 }
+
+func TestListPageIDs(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		listDirFunc = fileutil.ListDir
+	}()
+	// case 1: path not exist
+	_, err := ListPageIDs(testPath)
+	assert.Error(t, err)
+	assert.False(t, fileutil.Exist(testPath))
+	// case 2: list page ids in order
+	fct, err := NewFactory(testPath, 128)
+	assert.NoError(t, err)
+	for _, pageID := range []int64{10, 2, 1} {
+		_, err = fct.AcquirePage(pageID)
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, fct.Close())
+	pageIDs, err := ListPageIDs(testPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 10}, pageIDs)
+	assert.Equal(t, filepath.Join(testPath, "10.bat"), FileName(testPath, 10))
+	// case 3: invalid page file name
+	listDirFunc = func(path string) ([]string, error) {
+		return []string{"abc"}, nil
+	}
+	_, err = ListPageIDs(testPath)
+	assert.Error(t, err)
+}
@@ -1,6 +1,8 @@
 package page
 
 import (
+	"fmt"
+
 	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/pkg/fileutil"
@@ -11,7 +13,8 @@ import (
 
 // for testing
 var (
-	mapFileFunc = fileutil.RWMap
+	mapFileFunc         = fileutil.RWMap
+	readOnlyMapFileFunc = fileutil.Map
 )
 
 // MappedPage represents a holder for mmap bytes,
@@ -82,6 +85,24 @@ func NewMappedPage(fileName string, size int) (MappedPage, error) {
 	}, nil
 }
 
+// NewReadOnlyMappedPage maps the exist page file for read only, the size of file cannot be less than page size,
+// the page file isn't created or modified, so the page cannot be written.
+func NewReadOnlyMappedPage(fileName string, size int) (MappedPage, error) {
+	bytes, err := readOnlyMapFileFunc(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes) < size {
+		_ = fileutil.Unmap(bytes)
+		return nil, fmt.Errorf("size of page file[%s] is %d, less than page size:%d", fileName, len(bytes), size)
+	}
+	return &mappedPage{
+		fileName:    fileName,
+		mappedBytes: bytes,
+		size:        size,
+	}, nil
+}
+
 // FilePath returns mapped filePath.
 func (mp *mappedPage) FilePath() string {
 	return mp.fileName
@@ -73,3 +73,30 @@ func TestMappedPage_Write_number(t *testing.T) {
 	err = mp.Close()
 	assert.NoError(t, err)
 }
+
+func TestNewReadOnlyMappedPage(t *testing.T) {
+	_ = fileutil.MkDirIfNotExist(testPath)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		readOnlyMapFileFunc = fileutil.Map
+	}()
+	// case 1: file not exist
+	mp, err := NewReadOnlyMappedPage(filepath.Join(testPath, fileName), 128)
+	assert.Error(t, err)
+	assert.Nil(t, mp)
+	assert.False(t, fileutil.Exist(filepath.Join(testPath, fileName)))
+	// case 2: read page
+	mp, err = NewMappedPage(filepath.Join(testPath, fileName), 128)
+	assert.NoError(t, err)
+	mp.PutUint32(10, 0)
+	assert.NoError(t, mp.Close())
+	mp, err = NewReadOnlyMappedPage(filepath.Join(testPath, fileName), 64)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), mp.ReadUint32(0))
+	assert.Equal(t, 64, mp.Size())
+	assert.NoError(t, mp.Close())
+	// case 3: file size less than page size
+	mp, err = NewReadOnlyMappedPage(filepath.Join(testPath, fileName), 256)
+	assert.Error(t, err)
+	assert.Nil(t, mp)
+}
@@ -0,0 +1,220 @@
+package protocol
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/cespare/xxhash"
+
+	"github.com/lindb/lindb/pkg/timeutil"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/tag"
+)
+
+const (
+	graphiteSeparator        = "."
+	graphiteDefaultField     = "value"
+	graphiteMeasurement      = "measurement"
+	graphiteMeasurementMulti = "measurement*"
+	graphiteField            = "field"
+	graphiteFieldMulti       = "field*"
+	graphiteWildcard         = "*"
+)
+
+// graphiteTemplate maps the dotted path of graphite metric to metric name, field name and tags,
+// format: [filter] template [tag1=value1,tag2=value2]
+//   filter: dotted pattern which the path matches, * matches any node, the template without filter matches all paths.
+//   template: dotted items which map the nodes of path,
+//     measurement => node is part of metric name, measurement* => all remaining nodes are part of metric name,
+//     field => node is part of field name, field* => all remaining nodes are part of field name,
+//     empty item => node is skipped, others => tag key of node.
+//   tags: extra tags added into metric.
+// e.g. "servers.* .host.measurement.field region=us" maps "servers.localhost.cpu.idle" to cpu{host=localhost,region=us}.idle
+type graphiteTemplate struct {
+	filter []string
+	items  []string
+	tags   map[string]string
+}
+
+// newGraphiteTemplate parses the graphite template
+func newGraphiteTemplate(template string) (*graphiteTemplate, error) {
+	parts := strings.Fields(template)
+	t := &graphiteTemplate{}
+	switch len(parts) {
+	case 1:
+		t.items = strings.Split(parts[0], graphiteSeparator)
+	case 2:
+		if strings.Contains(parts[1], "=") {
+			t.items = strings.Split(parts[0], graphiteSeparator)
+			t.tags = make(map[string]string)
+			if err := parseTemplateTags(parts[1], t.tags); err != nil {
+				return nil, err
+			}
+		} else {
+			t.filter = strings.Split(parts[0], graphiteSeparator)
+			t.items = strings.Split(parts[1], graphiteSeparator)
+		}
+	case 3:
+		t.filter = strings.Split(parts[0], graphiteSeparator)
+		t.items = strings.Split(parts[1], graphiteSeparator)
+		t.tags = make(map[string]string)
+		if err := parseTemplateTags(parts[2], t.tags); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("invalid graphite template: %s", template)
+	}
+	hasMeasurement := false
+	for _, item := range t.items {
+		if item == graphiteMeasurement || item == graphiteMeasurementMulti {
+			hasMeasurement = true
+		}
+	}
+	if !hasMeasurement {
+		return nil, fmt.Errorf("no measurement in graphite template: %s", template)
+	}
+	return t, nil
+}
+
+// parseTemplateTags parses the extra tags(tag1=value1,tag2=value2) of template
+func parseTemplateTags(s string, tags map[string]string) error {
+	for _, kv := range strings.Split(s, ",") {
+		pair := strings.SplitN(kv, "=", 2)
+		if len(pair) != 2 || pair[0] == "" || pair[1] == "" {
+			return fmt.Errorf("invalid graphite template tag: %s", kv)
+		}
+		tags[pair[0]] = pair[1]
+	}
+	return nil
+}
+
+// match checks if the nodes of path match the filter of template
+func (t *graphiteTemplate) match(nodes []string) bool {
+	if len(t.filter) > len(nodes) {
+		return false
+	}
+	for idx, pattern := range t.filter {
+		if pattern != graphiteWildcard && pattern != nodes[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// apply maps the nodes of path to metric name, field name and tags
+func (t *graphiteTemplate) apply(nodes []string) (name, fieldName string, tags map[string]string) {
+	var measurement, fields []string
+	tags = make(map[string]string)
+	for k, v := range t.tags {
+		tags[k] = v
+	}
+	for idx, item := range t.items {
+		if idx >= len(nodes) {
+			break
+		}
+		switch item {
+		case graphiteMeasurement:
+			measurement = append(measurement, nodes[idx])
+		case graphiteMeasurementMulti:
+			measurement = append(measurement, nodes[idx:]...)
+		case graphiteField:
+			fields = append(fields, nodes[idx])
+		case graphiteFieldMulti:
+			fields = append(fields, nodes[idx:]...)
+		case "":
+		default:
+			tags[item] = nodes[idx]
+		}
+		if item == graphiteMeasurementMulti || item == graphiteFieldMulti {
+			break
+		}
+	}
+	name = strings.Join(measurement, graphiteSeparator)
+	fieldName = strings.Join(fields, graphiteSeparator)
+	if fieldName == "" {
+		fieldName = graphiteDefaultField
+	}
+	return name, fieldName, tags
+}
+
+// GraphiteParser parses graphite plaintext protocol(path value [timestamp]) to LinDB pb protocol,
+// maps the path to metric name, field and tags by the first template which filter matches the path,
+// the whole path is metric name if no template matches.
+type GraphiteParser struct {
+	namespace string
+	templates []*graphiteTemplate
+}
+
+// NewGraphiteParser creates graphite parser with templates
+func NewGraphiteParser(namespace string, templates []string) (*GraphiteParser, error) {
+	p := &GraphiteParser{namespace: namespace}
+	for _, template := range templates {
+		t, err := newGraphiteTemplate(template)
+		if err != nil {
+			return nil, err
+		}
+		p.templates = append(p.templates, t)
+	}
+	return p, nil
+}
+
+// ParseLine parses a line of graphite plaintext protocol, returns nil if the line is empty
+func (p *GraphiteParser) ParseLine(line string) (*pb.Metric, error) {
+	parts := strings.Fields(line)
+	if len(parts) == 0 {
+		return nil, nil
+	}
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, fmt.Errorf("invalid graphite line: %s", line)
+	}
+	value, err := strconv.ParseFloat(parts[1], 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("invalid graphite value: %s", line)
+	}
+	timestamp := timeutil.Now()
+	if len(parts) == 3 {
+		seconds, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid graphite timestamp: %s", line)
+		}
+		// -1 means now
+		if seconds != -1 {
+			timestamp = int64(seconds * 1000)
+		}
+	}
+	nodes := strings.Split(parts[0], graphiteSeparator)
+	name, fieldName, tags := parts[0], graphiteDefaultField, map[string]string(nil)
+	for _, t := range p.templates {
+		if t.match(nodes) {
+			name, fieldName, tags = t.apply(nodes)
+			break
+		}
+	}
+	if name == "" {
+		return nil, fmt.Errorf("no metric name after applying template: %s", line)
+	}
+	metric := &pb.Metric{
+		Namespace: p.namespace,
+		Name:      name,
+		Timestamp: timestamp,
+		Fields: []*pb.Field{{
+			Name:  fieldName,
+			Type:  pb.FieldType_Gauge,
+			Value: value,
+		}},
+	}
+	setMetricTags(metric, tags)
+	return metric, nil
+}
+
+// setMetricTags sets the tags and tags hash of metric, the hash of metric name is used if no tags
+func setMetricTags(metric *pb.Metric, tags map[string]string) {
+	if len(tags) > 0 {
+		metric.Tags = tags
+		metric.TagsHash = xxhash.Sum64String(tag.Concat(tags))
+	} else {
+		metric.TagsHash = xxhash.Sum64String(metric.Name)
+	}
+}
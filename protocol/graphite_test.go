@@ -0,0 +1,80 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+func TestNewGraphiteParser(t *testing.T) {
+	for _, template := range []string{
+		"a.b.c field tag",
+		"host.field",
+		"servers.* .host.measurement.field region",
+		"servers.* .host.measurement.field region=",
+		".host.measurement region=us,zone",
+	} {
+		p, err := NewGraphiteParser("ns", []string{template})
+		assert.Error(t, err, template)
+		assert.Nil(t, p)
+	}
+	p, err := NewGraphiteParser("ns", []string{
+		"measurement.field region=us",
+		"servers.* .host.measurement.field",
+		"servers.* .host.measurement* dc=sh",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, p.templates, 3)
+}
+
+func TestGraphiteParser_ParseLine(t *testing.T) {
+	p, err := NewGraphiteParser("ns", []string{
+		"servers.* .host.measurement.field* region=us",
+		"apps.*.* .app.measurement*",
+		"stats.* ..measurement",
+	})
+	assert.NoError(t, err)
+	// case 1: empty line
+	m, err := p.ParseLine("  ")
+	assert.NoError(t, err)
+	assert.Nil(t, m)
+	// case 2: template with field and extra tags
+	m, err = p.ParseLine("servers.host1.cpu.usage.idle 12.5 1577000000")
+	assert.NoError(t, err)
+	assert.Equal(t, "ns", m.Namespace)
+	assert.Equal(t, "cpu", m.Name)
+	assert.Equal(t, int64(1577000000000), m.Timestamp)
+	assert.Equal(t, map[string]string{"host": "host1", "region": "us"}, m.Tags)
+	assert.Equal(t, []*pb.Field{{Name: "usage.idle", Type: pb.FieldType_Gauge, Value: 12.5}}, m.Fields)
+	assert.NotZero(t, m.TagsHash)
+	// case 3: measurement with remaining nodes, default field
+	m, err = p.ParseLine("apps.web.api.requests.count 10")
+	assert.NoError(t, err)
+	assert.Equal(t, "api.requests.count", m.Name)
+	assert.Equal(t, map[string]string{"app": "web"}, m.Tags)
+	assert.Equal(t, "value", m.Fields[0].Name)
+	assert.NotZero(t, m.Timestamp)
+	// case 4: no template matches, whole path is metric name
+	m, err = p.ParseLine("system.load 1.5 -1")
+	assert.NoError(t, err)
+	assert.Equal(t, "system.load", m.Name)
+	assert.Nil(t, m.Tags)
+	assert.NotZero(t, m.TagsHash)
+	assert.NotZero(t, m.Timestamp)
+	// case 5: no metric name after applying template
+	_, err = p.ParseLine("stats.a 1")
+	assert.Error(t, err)
+	// case 6: invalid lines
+	for _, line := range []string{
+		"system.load",
+		"system.load 1 2 3",
+		"system.load abc 1577000000",
+		"system.load NaN 1577000000",
+		"system.load 1 abc",
+	} {
+		_, err = p.ParseLine(line)
+		assert.Error(t, err, line)
+	}
+}
@@ -0,0 +1,98 @@
+package protocol
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cespare/xxhash"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/series/point"
+	"github.com/lindb/lindb/series/tag"
+)
+
+// InfluxParse parses influxdb line protocol to LinDB pb protocol,
+// metrics of the valid lines are always returned,
+// the error reports each line(or point) which cannot be parsed.
+func InfluxParse(data []byte, namespace, precision string) (*pb.MetricList, error) {
+	points, err := point.ParsePointsWithPrecision(data, precision)
+	if err == point.ErrInvalidPrecision {
+		return nil, err
+	}
+	var failed []string
+	if err != nil {
+		failed = append(failed, err.Error())
+	}
+	metricList := &pb.MetricList{}
+	for _, p := range points {
+		metric, err := influxPointToMetric(p, namespace)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("unable to convert '%s': %v", p.String(), err))
+			continue
+		}
+		metricList.Metrics = append(metricList.Metrics, metric)
+	}
+	if len(failed) > 0 {
+		return metricList, fmt.Errorf("%s", strings.Join(failed, "\n"))
+	}
+	return metricList, nil
+}
+
+// influxPointToMetric converts the point to LinDB pb metric
+func influxPointToMetric(p *point.Point, namespace string) (*pb.Metric, error) {
+	fields, err := p.Fields()
+	if err != nil {
+		return nil, err
+	}
+	metric := &pb.Metric{
+		Namespace: namespace,
+		Name:      string(p.Name()),
+		Timestamp: p.UnixMilli(),
+	}
+	for _, f := range fields {
+		fieldType := getInfluxFieldType(f.Type)
+		if fieldType == pb.FieldType_UNKNOWN {
+			continue
+		}
+		value, ok := f.Value.(float64)
+		if !ok {
+			continue
+		}
+		metric.Fields = append(metric.Fields, &pb.Field{
+			Name:  string(f.Name),
+			Type:  fieldType,
+			Value: value,
+		})
+	}
+	if len(metric.Fields) == 0 {
+		return nil, point.ErrMissingFields
+	}
+	tags := p.Tags()
+	if len(tags) > 0 {
+		metric.Tags = make(map[string]string, len(tags))
+		for _, t := range tags {
+			metric.Tags[string(t.Key)] = string(t.Value)
+		}
+		metric.TagsHash = xxhash.Sum64String(tag.Concat(metric.Tags))
+	} else {
+		metric.TagsHash = xxhash.Sum64String(metric.Name)
+	}
+	return metric, nil
+}
+
+// getInfluxFieldType returns the pb field type by the field type which parsed from field-name's suffix
+func getInfluxFieldType(fieldType field.Type) pb.FieldType {
+	switch fieldType {
+	case field.SumField:
+		return pb.FieldType_Sum
+	case field.MinField:
+		return pb.FieldType_Min
+	case field.MaxField:
+		return pb.FieldType_Max
+	case field.GaugeField:
+		return pb.FieldType_Gauge
+	default:
+		return pb.FieldType_UNKNOWN
+	}
+}
@@ -0,0 +1,72 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/point"
+)
+
+func TestInfluxParse(t *testing.T) {
+	input := `# comment
+cpu.load,host=test,ip=1.1.1.1 load_SUM=7,min_MIN=1,max_MAX=9 1577000000
+memory used_SUM=100 1577000000
+`
+	metrics, err := InfluxParse([]byte(input), "ns", point.PrecisionSecond)
+	assert.NoError(t, err)
+	assert.Len(t, metrics.Metrics, 2)
+
+	m := metrics.Metrics[0]
+	assert.Equal(t, "ns", m.Namespace)
+	assert.Equal(t, "cpu.load", m.Name)
+	assert.Equal(t, int64(1577000000000), m.Timestamp)
+	assert.Equal(t, map[string]string{"host": "test", "ip": "1.1.1.1"}, m.Tags)
+	assert.Len(t, m.Fields, 3)
+	for _, f := range m.Fields {
+		switch f.Name {
+		case "load":
+			assert.Equal(t, pb.FieldType_Sum, f.Type)
+			assert.Equal(t, 7.0, f.Value)
+		case "min":
+			assert.Equal(t, pb.FieldType_Min, f.Type)
+		case "max":
+			assert.Equal(t, pb.FieldType_Max, f.Type)
+		}
+	}
+	assert.Nil(t, metrics.Metrics[1].Tags)
+	assert.NotZero(t, metrics.Metrics[1].TagsHash)
+
+	// invalid precision
+	metrics, err = InfluxParse([]byte(input), "ns", "d")
+	assert.Equal(t, point.ErrInvalidPrecision, err)
+	assert.Nil(t, metrics)
+
+	// partial lines failure
+	input = `cpu.load,host=test load_SUM=7 1577000000
+,host=test load_SUM=7 1577000000
+memory used="100" 1577000000
+disk used_SUM=abc 1577000000
+`
+	metrics, err = InfluxParse([]byte(input), "ns", "")
+	assert.Error(t, err)
+	assert.Len(t, metrics.Metrics, 1)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
+func TestInfluxParse_StandardFields(t *testing.T) {
+	input := `cpu,host=test usage=1.5,count=3i,total=4u,ok=true,msg="str",load_SUM=7 1577000000`
+	metrics, err := InfluxParse([]byte(input), "ns", point.PrecisionSecond)
+	assert.NoError(t, err)
+	assert.Len(t, metrics.Metrics, 1)
+	fields := make(map[string]*pb.Field)
+	for _, f := range metrics.Metrics[0].Fields {
+		fields[f.Name] = f
+	}
+	assert.Len(t, fields, 4)
+	assert.Equal(t, &pb.Field{Name: "usage", Type: pb.FieldType_Gauge, Value: 1.5}, fields["usage"])
+	assert.Equal(t, &pb.Field{Name: "count", Type: pb.FieldType_Gauge, Value: 3}, fields["count"])
+	assert.Equal(t, &pb.Field{Name: "total", Type: pb.FieldType_Gauge, Value: 4}, fields["total"])
+	assert.Equal(t, &pb.Field{Name: "load", Type: pb.FieldType_Sum, Value: 7}, fields["load"])
+}
@@ -0,0 +1,124 @@
+package protocol
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+const (
+	openTSDBPutCommand   = "put"
+	openTSDBDefaultField = "value"
+	// openTSDBMaxSeconds is the max timestamp of seconds precision, the timestamp with 13 digits is milliseconds
+	openTSDBMaxSeconds = 9999999999
+)
+
+// openTSDBDataPoint represents the data point of opentsdb http api(/api/put)
+type openTSDBDataPoint struct {
+	Metric    string            `json:"metric"`
+	Timestamp int64             `json:"timestamp"`
+	Value     json.RawMessage   `json:"value"` // number or string of number
+	Tags      map[string]string `json:"tags"`
+}
+
+// OpenTSDBParseLine parses the put command of opentsdb telnet protocol(put metric timestamp value tagk1=tagv1 ...),
+// returns nil if the line is empty or other command(like version) which isn't supported.
+func OpenTSDBParseLine(line, namespace string) (*pb.Metric, error) {
+	parts := strings.Fields(line)
+	if len(parts) == 0 || parts[0] != openTSDBPutCommand {
+		return nil, nil
+	}
+	if len(parts) < 4 {
+		return nil, fmt.Errorf("invalid opentsdb put command: %s", line)
+	}
+	timestamp, err := strconv.ParseInt(parts[2], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid opentsdb timestamp: %s", line)
+	}
+	value, err := strconv.ParseFloat(parts[3], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid opentsdb value: %s", line)
+	}
+	var tags map[string]string
+	if len(parts) > 4 {
+		tags = make(map[string]string, len(parts)-4)
+		for _, kv := range parts[4:] {
+			pair := strings.SplitN(kv, "=", 2)
+			if len(pair) != 2 || pair[0] == "" || pair[1] == "" {
+				return nil, fmt.Errorf("invalid opentsdb tag: %s", line)
+			}
+			tags[pair[0]] = pair[1]
+		}
+	}
+	return newOpenTSDBMetric(namespace, parts[1], timestamp, value, tags)
+}
+
+// OpenTSDBParseJSON parses the data points(single data point or array) of opentsdb http api(/api/put),
+// metrics of the valid data points are always returned, the error reports each data point which cannot be parsed.
+func OpenTSDBParseJSON(data []byte, namespace string) (*pb.MetricList, error) {
+	data = bytes.TrimSpace(data)
+	var points []*openTSDBDataPoint
+	if len(data) > 0 && data[0] == '[' {
+		if err := json.Unmarshal(data, &points); err != nil {
+			return nil, err
+		}
+	} else {
+		point := &openTSDBDataPoint{}
+		if err := json.Unmarshal(data, point); err != nil {
+			return nil, err
+		}
+		points = append(points, point)
+	}
+	var failed []string
+	metricList := &pb.MetricList{}
+	for _, p := range points {
+		value, err := strconv.ParseFloat(strings.Trim(string(p.Value), `"`), 64)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("invalid value of metric '%s': %s", p.Metric, p.Value))
+			continue
+		}
+		metric, err := newOpenTSDBMetric(namespace, p.Metric, p.Timestamp, value, p.Tags)
+		if err != nil {
+			failed = append(failed, err.Error())
+			continue
+		}
+		metricList.Metrics = append(metricList.Metrics, metric)
+	}
+	if len(failed) > 0 {
+		return metricList, fmt.Errorf("%s", strings.Join(failed, "\n"))
+	}
+	return metricList, nil
+}
+
+// newOpenTSDBMetric creates LinDB pb metric with gauge field, converts timestamp of seconds to milliseconds
+func newOpenTSDBMetric(namespace, name string, timestamp int64, value float64, tags map[string]string) (*pb.Metric, error) {
+	if name == "" {
+		return nil, fmt.Errorf("metric name is empty")
+	}
+	if timestamp <= 0 {
+		return nil, fmt.Errorf("invalid timestamp of metric '%s': %d", name, timestamp)
+	}
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("invalid value of metric '%s': %f", name, value)
+	}
+	if timestamp <= openTSDBMaxSeconds {
+		timestamp *= 1000
+	}
+	metric := &pb.Metric{
+		Namespace: namespace,
+		Name:      name,
+		Timestamp: timestamp,
+		Fields: []*pb.Field{{
+			Name:  openTSDBDefaultField,
+			Type:  pb.FieldType_Gauge,
+			Value: value,
+		}},
+	}
+	setMetricTags(metric, tags)
+	return metric, nil
+}
@@ -0,0 +1,75 @@
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
+)
+
+func TestOpenTSDBParseLine(t *testing.T) {
+	// case 1: empty line and other commands are ignored
+	for _, line := range []string{"", "version", "stats"} {
+		m, err := OpenTSDBParseLine(line, "ns")
+		assert.NoError(t, err)
+		assert.Nil(t, m)
+	}
+	// case 2: put command with seconds
+	m, err := OpenTSDBParseLine("put sys.cpu.user 1577000000 42.5 host=web01 cpu=0", "ns")
+	assert.NoError(t, err)
+	assert.Equal(t, "ns", m.Namespace)
+	assert.Equal(t, "sys.cpu.user", m.Name)
+	assert.Equal(t, int64(1577000000000), m.Timestamp)
+	assert.Equal(t, map[string]string{"host": "web01", "cpu": "0"}, m.Tags)
+	assert.Equal(t, []*pb.Field{{Name: "value", Type: pb.FieldType_Gauge, Value: 42.5}}, m.Fields)
+	assert.NotZero(t, m.TagsHash)
+	// case 3: put command with milliseconds, without tags
+	m, err = OpenTSDBParseLine("put sys.load 1577000000123 1", "ns")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1577000000123), m.Timestamp)
+	assert.Nil(t, m.Tags)
+	assert.NotZero(t, m.TagsHash)
+	// case 4: invalid put commands
+	for _, line := range []string{
+		"put sys.load 1577000000",
+		"put sys.load abc 1",
+		"put sys.load 1577000000 abc",
+		"put sys.load 1577000000 1 host",
+		"put sys.load 1577000000 1 =web01",
+		"put sys.load -1 1",
+		"put sys.load 1577000000 NaN",
+	} {
+		_, err = OpenTSDBParseLine(line, "ns")
+		assert.Error(t, err, line)
+	}
+}
+
+func TestOpenTSDBParseJSON(t *testing.T) {
+	// case 1: single data point
+	metrics, err := OpenTSDBParseJSON([]byte(`{"metric":"sys.cpu","timestamp":1577000000,"value":18,"tags":{"host":"web01"}}`), "ns")
+	assert.NoError(t, err)
+	assert.Len(t, metrics.Metrics, 1)
+	m := metrics.Metrics[0]
+	assert.Equal(t, "sys.cpu", m.Name)
+	assert.Equal(t, int64(1577000000000), m.Timestamp)
+	assert.Equal(t, 18.0, m.Fields[0].Value)
+	assert.Equal(t, map[string]string{"host": "web01"}, m.Tags)
+	// case 2: array of data points, partial failure
+	metrics, err = OpenTSDBParseJSON([]byte(` [
+{"metric":"sys.cpu","timestamp":1577000000123,"value":"1.5","tags":{"host":"web01"}},
+{"metric":"","timestamp":1577000000,"value":1},
+{"metric":"sys.mem","timestamp":1577000000,"value":"abc"}
+]`), "ns")
+	assert.Error(t, err)
+	assert.Len(t, metrics.Metrics, 1)
+	assert.Equal(t, int64(1577000000123), metrics.Metrics[0].Timestamp)
+	assert.Equal(t, 1.5, metrics.Metrics[0].Fields[0].Value)
+	// case 3: invalid json
+	metrics, err = OpenTSDBParseJSON([]byte(`[{"metric":`), "ns")
+	assert.Error(t, err)
+	assert.Nil(t, metrics)
+	metrics, err = OpenTSDBParseJSON([]byte(`{"metric":`), "ns")
+	assert.Error(t, err)
+	assert.Nil(t, metrics)
+}
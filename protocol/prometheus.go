@@ -2,6 +2,10 @@ package protocol
 
 import (
 	"bytes"
+	"math"
+	"sort"
+	"strconv"
+	"sync"
 
 	"github.com/cespare/xxhash"
 	dto "github.com/prometheus/client_model/go"
@@ -12,32 +16,56 @@ import (
 	"github.com/lindb/lindb/series/tag"
 )
 
-// PromParse parses prometheus text protocol to LinDB pb protocol.
-func PromParse(data []byte) (*pb.MetricList, error) {
+// cumulativeTTL is the time to keep the last cumulative values of series which isn't scraped
+const cumulativeTTL = timeutil.OneHour
+
+// PromParser parses prometheus text protocol to LinDB pb protocol.
+// The values of counter/summary/histogram are cumulative, the parser converts them into the increase
+// since the last scrape of same series, so that they are stored as sum fields, which are summed
+// in same time slot and rollup. The first scrape of series only records the cumulative values.
+// NOTICE: the last cumulative values are kept in memory of broker, the increase is lost when the series
+// is scraped after broker restarted or written to other broker.
+type PromParser struct {
+	series    map[uint64]*cumulativeValues // hash of metric name/tags => last cumulative values
+	lastPurge int64
+	mutex     sync.Mutex
+}
+
+// cumulativeValues represents the last cumulative values of series
+type cumulativeValues struct {
+	bounds    []float64 // upper bounds of histogram buckets
+	values    []float64
+	timestamp int64
+}
+
+// NewPromParser creates the prometheus text protocol parser
+func NewPromParser() *PromParser {
+	return &PromParser{
+		series:    make(map[uint64]*cumulativeValues),
+		lastPurge: timeutil.Now(),
+	}
+}
+
+// Parse parses prometheus text protocol to LinDB pb protocol.
+func (p *PromParser) Parse(data []byte) (*pb.MetricList, error) {
 	parser := &expfmt.TextParser{}
 	out, err := parser.TextToMetricFamilies(bytes.NewBuffer(data))
 	if err != nil && len(out) == 0 {
 		return nil, err
 	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	now := timeutil.Now()
 	metricList := &pb.MetricList{}
 	for name, pm := range out {
 		metricType := *pm.Type
-		if metricType == dto.MetricType_UNTYPED {
-			// not support untyped metric type
-			continue
-		}
 		for _, m := range pm.Metric {
-			f := getFieldType(metricType, m)
-			if f == nil {
-				continue
-			}
-
 			metric := &pb.Metric{Name: name}
-			metric.Fields = []*pb.Field{f}
 			if m.TimestampMs != nil {
 				metric.Timestamp = *m.TimestampMs
 			} else {
-				metric.Timestamp = timeutil.Now()
+				metric.Timestamp = now
 			}
 			tagCount := len(m.Label)
 			if tagCount > 0 {
@@ -50,89 +78,206 @@ func PromParse(data []byte) (*pb.MetricList, error) {
 			} else {
 				metric.TagsHash = xxhash.Sum64String(metric.Name)
 			}
-
+			metric.Fields = p.getFields(metricType, m, metric)
+			if len(metric.Fields) == 0 {
+				continue
+			}
 			metricList.Metrics = append(metricList.Metrics, metric)
 		}
 	}
+	p.purge(now)
 	return metricList, nil
 }
 
-func getFieldType(metricType dto.MetricType, metric *dto.Metric) *pb.Field {
+// getFields returns the fields of prometheus metric by metric type,
+// 1. counter => sum field of increase
+// 2. gauge/untyped => gauge field
+// 3. summary => sum/count sum fields of increase, quantile gauge fields
+// 4. histogram => histogram field of buckets' increase with explicit bounds, sum/count sum fields of increase
+func (p *PromParser) getFields(metricType dto.MetricType, m *dto.Metric, metric *pb.Metric) []*pb.Field {
 	switch metricType {
 	case dto.MetricType_COUNTER:
-		if metric.Counter != nil && metric.Counter.Value != nil {
-			return &pb.Field{
-				Name: "counter",
-				//Type:   pb.FieldType_Increase,
-				Value: *metric.Counter.Value,
+		if m.Counter != nil && isValidValue(m.Counter.Value) {
+			increases, ok := p.increase(metric, nil, []float64{*m.Counter.Value})
+			if ok {
+				return []*pb.Field{{
+					Name:  "counter",
+					Type:  pb.FieldType_Sum,
+					Value: increases[0],
+				}}
 			}
 		}
 	case dto.MetricType_GAUGE:
-		if metric.Gauge != nil && metric.Gauge.Value != nil {
-			return &pb.Field{
-				Name: "gauge",
-				//Type:   pb.FieldType_Gauge,
-				Value: *metric.Gauge.Value,
-			}
+		if m.Gauge != nil && isValidValue(m.Gauge.Value) {
+			return []*pb.Field{{
+				Name:  "gauge",
+				Type:  pb.FieldType_Gauge,
+				Value: *m.Gauge.Value,
+			}}
+		}
+	case dto.MetricType_UNTYPED:
+		if m.Untyped != nil && isValidValue(m.Untyped.Value) {
+			return []*pb.Field{{
+				Name:  "untyped",
+				Type:  pb.FieldType_Gauge,
+				Value: *m.Untyped.Value,
+			}}
 		}
 	case dto.MetricType_SUMMARY:
-		if metric.Summary == nil || metric.Summary.SampleCount == nil || metric.Summary.SampleSum == nil {
-			return nil
+		return p.getSummaryFields(m.Summary, metric)
+	case dto.MetricType_HISTOGRAM:
+		return p.getHistogramFields(m.Histogram, metric)
+	}
+	return nil
+}
+
+// getSummaryFields returns the sum/count/quantile fields of summary,
+// quantile field's name is quantile_ + φ, like quantile_0.99.
+func (p *PromParser) getSummaryFields(summary *dto.Summary, metric *pb.Metric) []*pb.Field {
+	if summary == nil || summary.SampleCount == nil || !isValidValue(summary.SampleSum) {
+		return nil
+	}
+	var fields []*pb.Field
+	increases, ok := p.increase(metric, nil, []float64{*summary.SampleSum, float64(*summary.SampleCount)})
+	if ok {
+		fields = append(fields, &pb.Field{
+			Name:  "sum",
+			Type:  pb.FieldType_Sum,
+			Value: increases[0],
+		}, &pb.Field{
+			Name:  "count",
+			Type:  pb.FieldType_Sum,
+			Value: increases[1],
+		})
+	}
+	for _, q := range summary.Quantile {
+		if q.Quantile == nil || !isValidValue(q.Value) {
+			continue
+		}
+		fields = append(fields, &pb.Field{
+			Name:  "quantile_" + strconv.FormatFloat(*q.Quantile, 'f', -1, 64),
+			Type:  pb.FieldType_Gauge,
+			Value: *q.Value,
+		})
+	}
+	return fields
+}
+
+// getHistogramFields returns the sum/count/histogram fields of histogram,
+// converts the increase of cumulative buckets to the count of each bucket,
+// the +Inf bucket's count is calculated by sample count.
+func (p *PromParser) getHistogramFields(histogram *dto.Histogram, metric *pb.Metric) []*pb.Field {
+	if histogram == nil || histogram.SampleCount == nil || !isValidValue(histogram.SampleSum) {
+		return nil
+	}
+	buckets := make([]*dto.Bucket, 0, len(histogram.Bucket))
+	for _, bucket := range histogram.Bucket {
+		if bucket.UpperBound == nil || bucket.CumulativeCount == nil || math.IsInf(*bucket.UpperBound, 1) {
+			continue
+		}
+		buckets = append(buckets, bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return *buckets[i].UpperBound < *buckets[j].UpperBound
+	})
+	// cumulative values: sum, count, cumulative count of buckets
+	bounds := make([]float64, len(buckets))
+	values := make([]float64, len(buckets)+2)
+	values[0] = *histogram.SampleSum
+	values[1] = float64(*histogram.SampleCount)
+	for idx, bucket := range buckets {
+		bounds[idx] = *bucket.UpperBound
+		values[idx+2] = float64(*bucket.CumulativeCount)
+	}
+	increases, ok := p.increase(metric, bounds, values)
+	if !ok {
+		return nil
+	}
+	fields := []*pb.Field{{
+		Name:  "sum",
+		Type:  pb.FieldType_Sum,
+		Value: increases[0],
+	}, {
+		Name:  "count",
+		Type:  pb.FieldType_Sum,
+		Value: increases[1],
+	}}
+	if len(buckets) == 0 {
+		return fields
+	}
+	f := &pb.Field{
+		Name:           "histogram",
+		Type:           pb.FieldType_Histogram,
+		ExplicitBounds: bounds,
+		BucketValues:   make([]float64, len(buckets)+1),
+	}
+	previous := 0.0
+	for idx := range buckets {
+		cumulative := increases[idx+2]
+		f.BucketValues[idx] = math.Max(cumulative-previous, 0)
+		previous = cumulative
+	}
+	// +Inf bucket
+	f.BucketValues[len(buckets)] = math.Max(increases[1]-previous, 0)
+	return append(fields, f)
+}
+
+// increase returns the increase of cumulative values since the last scrape of series,
+// returns false if it's the first scrape or the scrape isn't newer than the last one.
+// if any value decreases, the series is reset(like exporter restarted), the increase is the current values.
+func (p *PromParser) increase(metric *pb.Metric, bounds, values []float64) ([]float64, bool) {
+	key := xxhash.Sum64String(metric.Name + "|" + strconv.FormatUint(metric.TagsHash, 10))
+	last, ok := p.series[key]
+	if !ok || !equalValues(last.bounds, bounds) || len(last.values) != len(values) {
+		p.series[key] = &cumulativeValues{bounds: bounds, values: values, timestamp: metric.Timestamp}
+		return nil, false
+	}
+	if metric.Timestamp <= last.timestamp {
+		return nil, false
+	}
+	increases := make([]float64, len(values))
+	reset := false
+	for idx, value := range values {
+		increases[idx] = value - last.values[idx]
+		if increases[idx] < 0 {
+			reset = true
 		}
-		f := &pb.Field{
-			Name: "summary",
-			//Type: pb.FieldType_Summary,
+	}
+	if reset {
+		copy(increases, values)
+	}
+	last.values = values
+	last.timestamp = metric.Timestamp
+	return increases, true
+}
+
+// purge removes the last cumulative values of series which isn't scraped in ttl
+func (p *PromParser) purge(now int64) {
+	if now-p.lastPurge < cumulativeTTL {
+		return
+	}
+	for key, values := range p.series {
+		if now-values.timestamp > cumulativeTTL {
+			delete(p.series, key)
 		}
-		//FIXME stone1100
-		//count := float64(*metric.Summary.SampleCount)
-		//f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//	PrimitiveID: int32(1),
-		//	Value:       *metric.Summary.SampleSum,
-		//}, &pb.PrimitiveField{
-		//	PrimitiveID: int32(2),
-		//	Value:       count,
-		//})
-		//quantile := metric.Summary.Quantile
-		//for _, q := range quantile {
-		//	switch *q.Quantile {
-		//	case 0.5:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(50),
-		//			Value:       *q.Value * count,
-		//		})
-		//	case 0.75:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(75),
-		//			Value:       *q.Value * count,
-		//		})
-		//	case 0.90:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(90),
-		//			Value:       *q.Value * count,
-		//		})
-		//	case 0.95:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(95),
-		//			Value:       *q.Value * count,
-		//		})
-		//	case 0.99:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(99),
-		//			Value:       *q.Value * count,
-		//		})
-		//	case 0.999:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(39),
-		//			Value:       *q.Value * count,
-		//		})
-		//	case 0.9999:
-		//		f.Fields = append(f.Fields, &pb.PrimitiveField{
-		//			PrimitiveID: int32(49),
-		//			Value:       *q.Value * count,
-		//		})
-		//	}
-		//}
-		return f
 	}
-	return nil
+	p.lastPurge = now
+}
+
+// equalValues checks if two values are same
+func equalValues(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for idx := range a {
+		if a[idx] != b[idx] {
+			return false
+		}
+	}
+	return true
+}
+
+// isValidValue checks if the value of metric is valid(not nil and not NaN).
+func isValidValue(value *float64) bool {
+	return value != nil && !math.IsNaN(*value)
 }
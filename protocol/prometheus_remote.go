@@ -0,0 +1,108 @@
+package protocol
+
+import (
+	"errors"
+	"math"
+
+	"github.com/cespare/xxhash"
+	"github.com/golang/snappy"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/rpc/proto/prompb"
+	"github.com/lindb/lindb/series/tag"
+)
+
+const (
+	// PromMetricNameLabel is the label name of prometheus which represents the metric name
+	PromMetricNameLabel = "__name__"
+	// PromRemoteValueField is the field name which stores the sample value of prometheus remote write
+	PromRemoteValueField = "value"
+)
+
+// errMissingMetricName represents the time series of remote write hasn't metric name label
+var errMissingMetricName = errors.New("metric name label(__name__) not found")
+
+// PromRemoteWriteParse parses the snappy compressed prometheus remote write request to LinDB pb protocol,
+// the label __name__ is metric name, other labels are tags, each sample is a gauge field named value.
+func PromRemoteWriteParse(data []byte, namespace string) (*pb.MetricList, error) {
+	req := &prompb.WriteRequest{}
+	if err := decodeSnappyProto(data, req); err != nil {
+		return nil, err
+	}
+	metricList := &pb.MetricList{}
+	for _, ts := range req.Timeseries {
+		if ts == nil || len(ts.Samples) == 0 {
+			continue
+		}
+		name := ""
+		tags := make(map[string]string, len(ts.Labels))
+		for _, label := range ts.Labels {
+			if label.Name == PromMetricNameLabel {
+				name = label.Value
+				continue
+			}
+			tags[label.Name] = label.Value
+		}
+		if name == "" {
+			return nil, errMissingMetricName
+		}
+		var tagsHash uint64
+		if len(tags) > 0 {
+			tagsHash = xxhash.Sum64String(tag.Concat(tags))
+		} else {
+			tags = nil
+			tagsHash = xxhash.Sum64String(name)
+		}
+		for _, sample := range ts.Samples {
+			// skip stale marker/NaN value
+			if math.IsNaN(sample.Value) {
+				continue
+			}
+			metricList.Metrics = append(metricList.Metrics, &pb.Metric{
+				Namespace: namespace,
+				Name:      name,
+				Timestamp: sample.Timestamp,
+				Tags:      tags,
+				TagsHash:  tagsHash,
+				Fields: []*pb.Field{{
+					Name:  PromRemoteValueField,
+					Type:  pb.FieldType_Gauge,
+					Value: sample.Value,
+				}},
+			})
+		}
+	}
+	return metricList, nil
+}
+
+// PromRemoteReadParse parses the snappy compressed prometheus remote read request.
+func PromRemoteReadParse(data []byte) (*prompb.ReadRequest, error) {
+	req := &prompb.ReadRequest{}
+	if err := decodeSnappyProto(data, req); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// PromRemoteReadEncode encodes the prometheus remote read response with snappy compression.
+func PromRemoteReadEncode(resp *prompb.ReadResponse) ([]byte, error) {
+	data, err := resp.Marshal()
+	if err != nil {
+		return nil, err
+	}
+	return snappy.Encode(nil, data), nil
+}
+
+// snappyProto represents the proto message which is decoded from snappy compressed data
+type snappyProto interface {
+	Unmarshal(data []byte) error
+}
+
+// decodeSnappyProto decompresses the snappy data, then unmarshal it into proto message.
+func decodeSnappyProto(data []byte, msg snappyProto) error {
+	buf, err := snappy.Decode(nil, data)
+	if err != nil {
+		return err
+	}
+	return msg.Unmarshal(buf)
+}
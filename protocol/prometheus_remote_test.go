@@ -0,0 +1,88 @@
+package protocol
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cespare/xxhash"
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/rpc/proto/prompb"
+)
+
+func TestPromRemoteWriteParse(t *testing.T) {
+	// case 1: decode snappy err
+	_, err := PromRemoteWriteParse([]byte("bad data"), "ns")
+	assert.Error(t, err)
+	// case 2: unmarshal err
+	_, err = PromRemoteWriteParse(snappy.Encode(nil, []byte{0xff, 0xff}), "ns")
+	assert.Error(t, err)
+	// case 3: metric name not found
+	_, err = PromRemoteWriteParse(encodeWriteRequest(t, &prompb.WriteRequest{Timeseries: []*prompb.TimeSeries{{
+		Labels:  []*prompb.Label{{Name: "host", Value: "1.1.1.1"}},
+		Samples: []*prompb.Sample{{Value: 1, Timestamp: 10}},
+	}}}), "ns")
+	assert.Equal(t, errMissingMetricName, err)
+	// case 4: parse success
+	metricList, err := PromRemoteWriteParse(encodeWriteRequest(t, &prompb.WriteRequest{Timeseries: []*prompb.TimeSeries{
+		{
+			Labels:  []*prompb.Label{{Name: "__name__", Value: "cpu"}, {Name: "host", Value: "1.1.1.1"}},
+			Samples: []*prompb.Sample{{Value: 1, Timestamp: 10}, {Value: math.NaN(), Timestamp: 20}, {Value: 3, Timestamp: 30}},
+		},
+		{
+			Labels:  []*prompb.Label{{Name: "__name__", Value: "up"}},
+			Samples: []*prompb.Sample{{Value: 1, Timestamp: 10}},
+		},
+		{
+			Labels: []*prompb.Label{{Name: "__name__", Value: "no_samples"}},
+		},
+	}}), "ns")
+	assert.NoError(t, err)
+	assert.Len(t, metricList.Metrics, 3)
+	cpu := metricList.Metrics[0]
+	assert.Equal(t, "ns", cpu.Namespace)
+	assert.Equal(t, "cpu", cpu.Name)
+	assert.Equal(t, int64(10), cpu.Timestamp)
+	assert.Equal(t, map[string]string{"host": "1.1.1.1"}, cpu.Tags)
+	assert.Equal(t, xxhash.Sum64String("host=1.1.1.1"), cpu.TagsHash)
+	assert.Equal(t, []*pb.Field{{Name: "value", Type: pb.FieldType_Gauge, Value: 1}}, cpu.Fields)
+	assert.Equal(t, int64(30), metricList.Metrics[1].Timestamp)
+	up := metricList.Metrics[2]
+	assert.Equal(t, "up", up.Name)
+	assert.Nil(t, up.Tags)
+	assert.Equal(t, xxhash.Sum64String("up"), up.TagsHash)
+}
+
+func TestPromRemoteRead(t *testing.T) {
+	_, err := PromRemoteReadParse([]byte("bad data"))
+	assert.Error(t, err)
+
+	req := &prompb.ReadRequest{Queries: []*prompb.Query{{
+		StartTimestampMs: 10,
+		EndTimestampMs:   20,
+		Matchers:         []*prompb.LabelMatcher{{Type: prompb.LabelMatcher_RE, Name: "host", Value: "1.*"}},
+	}}}
+	data, err := req.Marshal()
+	assert.NoError(t, err)
+	req1, err := PromRemoteReadParse(snappy.Encode(nil, data))
+	assert.NoError(t, err)
+	assert.Equal(t, req, req1)
+
+	resp := &prompb.ReadResponse{Results: []*prompb.QueryResult{{Timeseries: []*prompb.TimeSeries{{
+		Labels:  []*prompb.Label{{Name: "__name__", Value: "cpu"}},
+		Samples: []*prompb.Sample{{Value: 1, Timestamp: 10}},
+	}}}}}
+	data, err = PromRemoteReadEncode(resp)
+	assert.NoError(t, err)
+	resp1 := &prompb.ReadResponse{}
+	assert.NoError(t, decodeSnappyProto(data, resp1))
+	assert.Equal(t, resp, resp1)
+}
+
+func encodeWriteRequest(t *testing.T, req *prompb.WriteRequest) []byte {
+	data, err := req.Marshal()
+	assert.NoError(t, err)
+	return snappy.Encode(nil, data)
+}
@@ -2,8 +2,11 @@ package protocol
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	pb "github.com/lindb/lindb/rpc/proto/field"
 )
 
 func TestPromParse(t *testing.T) {
@@ -57,18 +60,19 @@ testmetric{label="\"bar\""} 1`
 	input += "\n# HELP metric foo\x00bar"
 	input += "\nnull_byte_metric{a=\"abc\x00\"} 1\n"
 
-	metrics, err := PromParse([]byte(input))
+	parser := NewPromParser()
+	metrics, err := parser.Parse([]byte(input))
 	assert.NoError(t, err)
 	assert.NotEmpty(t, metrics)
 
-	metrics, err = PromParse([]byte("empty"))
+	metrics, err = parser.Parse([]byte("empty"))
 	assert.Error(t, err)
 	assert.Empty(t, metrics)
 	input = `# HELP go_gc_duration_seconds A summary of the GC invocation durations.
 # 	TYPE go_gc_duration_seconds summary
 go_gc_duration_seconds{method="post",code="400",quantile="0"} 4.9351e-05
 go_gc_duration_seconds { quantile = "0.9999" } 8.38`
-	metrics, err = PromParse([]byte(input))
+	metrics, err = parser.Parse([]byte(input))
 	assert.NoError(t, err)
 	assert.Empty(t, metrics)
 	input = `# HELP go_gc_duration_seconds A summary of the GC invocation durations.
@@ -77,7 +81,133 @@ go_gc_duration_seconds { quantile = "0.9999" } NaN
 go_gc_duration_seconds_count 9
 go_gc_duration_seconds_sum 90
 `
-	metrics, err = PromParse([]byte(input))
+	// first scrape only records the cumulative values of sum/count
+	parser = NewPromParser()
+	metrics, err = parser.Parse([]byte(input))
+	assert.NoError(t, err)
+	assert.Empty(t, metrics)
+	time.Sleep(2 * time.Millisecond)
+	metrics, err = parser.Parse([]byte(input))
 	assert.NoError(t, err)
 	assert.NotEmpty(t, metrics)
 }
+
+func TestPromParse_Fields(t *testing.T) {
+	input := `# TYPE http_requests_total counter
+http_requests_total{code="200"} 1027 1395066363000
+http_requests_total{code="400"} NaN
+# TYPE go_goroutines gauge
+go_goroutines 33
+# TYPE untyped_metric untyped
+untyped_metric 10
+# TYPE rpc_duration_seconds summary
+rpc_duration_seconds{quantile="0.5"} 4773
+rpc_duration_seconds{quantile="0.99"} NaN
+rpc_duration_seconds_sum 1.7560473e+07
+rpc_duration_seconds_count 2693
+# TYPE request_duration_seconds histogram
+request_duration_seconds_bucket{le="1"} 133988
+request_duration_seconds_bucket{le="0.5"} 129389
+request_duration_seconds_bucket{le="+Inf"} 144320
+request_duration_seconds_sum 53423
+request_duration_seconds_count 144320
+# TYPE empty_histogram histogram
+empty_histogram_sum 0
+empty_histogram_count 0
+`
+	parser := NewPromParser()
+	// first scrape only records the cumulative values
+	metrics := parseMetrics(t, parser, input)
+	assert.Len(t, metrics, 3)
+	assert.Equal(t, []*pb.Field{{Name: "gauge", Type: pb.FieldType_Gauge, Value: 33}}, metrics["go_goroutines"].Fields)
+	assert.Equal(t, []*pb.Field{{Name: "untyped", Type: pb.FieldType_Gauge, Value: 10}}, metrics["untyped_metric"].Fields)
+	assert.Equal(t, []*pb.Field{
+		{Name: "quantile_0.5", Type: pb.FieldType_Gauge, Value: 4773},
+	}, metrics["rpc_duration_seconds"].Fields)
+
+	// second scrape, cumulative values are converted into increase
+	input = `# TYPE http_requests_total counter
+http_requests_total{code="200"} 1030 1395066364000
+# TYPE rpc_duration_seconds summary
+rpc_duration_seconds{quantile="0.5"} 4770
+rpc_duration_seconds_sum 1.7560483e+07
+rpc_duration_seconds_count 2695
+# TYPE request_duration_seconds histogram
+request_duration_seconds_bucket{le="1"} 133998
+request_duration_seconds_bucket{le="0.5"} 129390
+request_duration_seconds_bucket{le="+Inf"} 144340
+request_duration_seconds_sum 53443
+request_duration_seconds_count 144340
+# TYPE empty_histogram histogram
+empty_histogram_sum 0
+empty_histogram_count 0
+`
+	// wait the timestamp of scrape changed
+	time.Sleep(2 * time.Millisecond)
+	metrics = parseMetrics(t, parser, input)
+	assert.Len(t, metrics, 4)
+	counter := metrics["http_requests_total"]
+	assert.Equal(t, int64(1395066364000), counter.Timestamp)
+	assert.Equal(t, map[string]string{"code": "200"}, counter.Tags)
+	assert.Equal(t, []*pb.Field{{Name: "counter", Type: pb.FieldType_Sum, Value: 3}}, counter.Fields)
+	assert.Equal(t, []*pb.Field{
+		{Name: "sum", Type: pb.FieldType_Sum, Value: 10},
+		{Name: "count", Type: pb.FieldType_Sum, Value: 2},
+		{Name: "quantile_0.5", Type: pb.FieldType_Gauge, Value: 4770},
+	}, metrics["rpc_duration_seconds"].Fields)
+	assert.Equal(t, []*pb.Field{
+		{Name: "sum", Type: pb.FieldType_Sum, Value: 20},
+		{Name: "count", Type: pb.FieldType_Sum, Value: 20},
+		{Name: "histogram", Type: pb.FieldType_Histogram,
+			ExplicitBounds: []float64{0.5, 1},
+			BucketValues:   []float64{1, 9, 10}},
+	}, metrics["request_duration_seconds"].Fields)
+	assert.Equal(t, []*pb.Field{
+		{Name: "sum", Type: pb.FieldType_Sum, Value: 0},
+		{Name: "count", Type: pb.FieldType_Sum, Value: 0},
+	}, metrics["empty_histogram"].Fields)
+}
+
+func TestPromParser_increase(t *testing.T) {
+	parser := NewPromParser()
+	metric := &pb.Metric{Name: "cpu", TagsHash: 10, Timestamp: 10}
+	// first scrape
+	_, ok := parser.increase(metric, nil, []float64{10})
+	assert.False(t, ok)
+	// scrape isn't newer than last one
+	_, ok = parser.increase(metric, nil, []float64{12})
+	assert.False(t, ok)
+	metric.Timestamp = 20
+	increases, ok := parser.increase(metric, nil, []float64{12})
+	assert.True(t, ok)
+	assert.Equal(t, []float64{2}, increases)
+	// counter reset
+	metric.Timestamp = 30
+	increases, ok = parser.increase(metric, nil, []float64{5})
+	assert.True(t, ok)
+	assert.Equal(t, []float64{5}, increases)
+	// buckets changed, records new cumulative values
+	metric.Timestamp = 40
+	_, ok = parser.increase(metric, []float64{1}, []float64{5, 6, 7})
+	assert.False(t, ok)
+	metric.Timestamp = 50
+	increases, ok = parser.increase(metric, []float64{1}, []float64{6, 8, 10})
+	assert.True(t, ok)
+	assert.Equal(t, []float64{1, 2, 3}, increases)
+
+	// purge the series which isn't scraped in ttl
+	parser.purge(parser.lastPurge + cumulativeTTL - 1)
+	assert.Len(t, parser.series, 1)
+	parser.purge(parser.lastPurge + cumulativeTTL)
+	assert.Empty(t, parser.series)
+}
+
+func parseMetrics(t *testing.T, parser *PromParser, input string) map[string]*pb.Metric {
+	metricList, err := parser.Parse([]byte(input))
+	assert.NoError(t, err)
+	metrics := make(map[string]*pb.Metric)
+	for _, metric := range metricList.Metrics {
+		metrics[metric.Name] = metric
+	}
+	return metrics
+}
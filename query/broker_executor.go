@@ -6,7 +6,9 @@ import (
 	"github.com/lindb/lindb/coordinator/broker"
 	"github.com/lindb/lindb/coordinator/database"
 	"github.com/lindb/lindb/coordinator/replica"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/sql/stmt"
 )
@@ -45,6 +47,24 @@ func newBrokerExecutor(ctx context.Context, database string, sql string,
 	return exec
 }
 
+// newQueryBrokerExecutor creates the execution which executes the job of parallel query,
+// the query statement is built by caller(e.g. prometheus remote read) instead of parsing sql.
+func newQueryBrokerExecutor(ctx context.Context, database string, query *stmt.Query,
+	replicaStateMachine replica.StatusStateMachine, nodeStateMachine broker.NodeStateMachine,
+	databaseStateMachine database.DBStateMachine,
+	jobManager parallel.JobManager) parallel.BrokerExecutor {
+	exec := &brokerExecutor{
+		query:                query,
+		database:             database,
+		replicaStateMachine:  replicaStateMachine,
+		nodeStateMachine:     nodeStateMachine,
+		databaseStateMachine: databaseStateMachine,
+		jobManager:           jobManager,
+		ctx:                  ctx,
+	}
+	return exec
+}
+
 // Execute executes search logic in broker level,
 // 1) get metadata based on params
 // 2) build execute plan
@@ -59,12 +79,29 @@ func (e *brokerExecutor) Execute() {
 		return
 	}
 
+	policy, err := e.selectPolicy(databaseCfg)
+	if err != nil {
+		e.executeCtx = parallel.NewBrokerExecuteContext(startTime, nil)
+		e.executeCtx.Complete(err)
+		return
+	}
 	//FIXME need using storage's replica state ???
-	storageNodes := e.replicaStateMachine.GetQueryableReplicas(e.database)
+	replicas := e.replicaStateMachine.GetQueryableReplicas(e.database, policy)
+	var storageNodes map[string][]int32
+	if replicas != nil {
+		storageNodes = replicas.Nodes
+	}
 	brokerNodes := e.nodeStateMachine.GetActiveNodes()
 	plan := newBrokerPlan(e.sql, databaseCfg, storageNodes, e.nodeStateMachine.GetCurrentNode(), brokerNodes)
+	if e.query != nil {
+		// query statement is given, no need to parse sql
+		plan.(*brokerPlan).query = e.query
+	}
+	if shardEpochs, ok := e.databaseStateMachine.GetShardEpochs(e.database); ok {
+		// filter the shards which don't store the data of query time range
+		plan.(*brokerPlan).shardEpochs = shardEpochs
+	}
 
-	var err error
 	if len(storageNodes) == 0 {
 		err = errNoAvailableStorageNode
 	} else {
@@ -84,13 +121,21 @@ func (e *brokerExecutor) Execute() {
 	e.query = brokerPlan.query
 
 	if err := e.jobManager.SubmitJob(parallel.NewJobContext(e.ctx,
-		e.executeCtx.ResultCh(), brokerPlan.physicalPlan, e.query),
+		e.executeCtx.ResultCh(), brokerPlan.physicalPlan, e.query, e.sql, replicas),
 	); err != nil {
 		e.executeCtx.Complete(err)
 		return
 	}
 }
 
+// selectPolicy returns the replica select policy of query if set, else returns the policy of database
+func (e *brokerExecutor) selectPolicy(databaseCfg models.Database) (option.ReplicaSelectPolicy, error) {
+	if policy, ok := replica.SelectPolicyFromContext(e.ctx); ok {
+		return policy, nil
+	}
+	return option.ParseReplicaSelectPolicy(databaseCfg.Option.ReplicaSelectPolicy)
+}
+
 func (e *brokerExecutor) ExecuteContext() parallel.BrokerExecuteContext {
 	return e.executeCtx
 }
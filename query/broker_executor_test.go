@@ -14,6 +14,7 @@ import (
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
 	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/sql/stmt"
 )
 
 func TestBrokerExecutor_Execute(t *testing.T) {
@@ -38,9 +39,10 @@ func TestBrokerExecutor_Execute(t *testing.T) {
 	// case 2: storage nodes not exist
 	dbStateMachine.EXPECT().GetDatabaseCfg("test_db").
 		Return(models.Database{Option: option.DatabaseOption{Interval: "10s"}}, true).AnyTimes()
+	dbStateMachine.EXPECT().GetShardEpochs("test_db").Return(models.ShardEpochs{{NumOfShard: 16}}, true).AnyTimes()
 	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
 		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
-	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(nil)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db", option.LeastPending).Return(nil)
 	exec.Execute()
 	assert.NotNil(t, exec.ExecuteContext())
 
@@ -51,6 +53,7 @@ func TestBrokerExecutor_Execute(t *testing.T) {
 		"1.1.1.4:9000": {10, 13, 15},
 		"1.1.1.5:9000": {11, 12, 14},
 	}
+	replicas := &models.QueryableReplicas{Policy: string(option.LeastPending), Nodes: storageNodes}
 	brokerNodes := []models.ActiveNode{
 		generateBrokerActiveNode("1.1.1.1", 8000),
 		generateBrokerActiveNode("1.1.1.2", 8000),
@@ -59,13 +62,13 @@ func TestBrokerExecutor_Execute(t *testing.T) {
 	}
 	exec = newBrokerExecutor(context.TODO(), "test_db", "select f fro",
 		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
-	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db", option.LeastPending).Return(replicas)
 	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
 	exec.Execute()
 
 	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
 		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
-	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db", option.LeastPending).Return(replicas)
 	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
 	jobManager.EXPECT().SubmitJob(gomock.Any())
 	exec.Execute()
@@ -73,8 +76,60 @@ func TestBrokerExecutor_Execute(t *testing.T) {
 	// submit job error
 	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
 		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
-	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db").Return(storageNodes)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db", option.LeastPending).Return(replicas)
 	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
 	jobManager.EXPECT().SubmitJob(gomock.Any()).Return(errors.New("submit job error"))
 	exec.Execute()
+
+	// replica select policy given by caller
+	exec = newBrokerExecutor(replica.WithSelectPolicy(context.TODO(), option.RoundRobin), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db", option.RoundRobin).Return(replicas)
+	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
+	jobManager.EXPECT().SubmitJob(gomock.Any())
+	exec.Execute()
+
+	// query statement given by caller
+	q := &stmt.Query{MetricName: "cpu", SelectItems: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}}
+	exec = newQueryBrokerExecutor(context.TODO(), "test_db", q,
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db", option.LeastPending).Return(replicas)
+	nodeStateMachine.EXPECT().GetActiveNodes().Return(brokerNodes)
+	jobManager.EXPECT().SubmitJob(gomock.Any())
+	exec.Execute()
+	assert.Equal(t, q, exec.(*brokerExecutor).query)
+}
+
+func TestBrokerExecutor_selectPolicy(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	nodeStateMachine := broker.NewMockNodeStateMachine(ctrl)
+	dbStateMachine := database.NewMockDBStateMachine(ctrl)
+	replicaStateMachine := replica.NewMockStatusStateMachine(ctrl)
+	jobManager := parallel.NewMockJobManager(ctrl)
+
+	// database option
+	exec := newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	policy, err := exec.(*brokerExecutor).selectPolicy(models.Database{
+		Option: option.DatabaseOption{ReplicaSelectPolicy: string(option.MostCaughtUp)}})
+	assert.NoError(t, err)
+	assert.Equal(t, option.MostCaughtUp, policy)
+
+	// query policy first
+	exec = newBrokerExecutor(replica.WithSelectPolicy(context.TODO(), option.PreferLocalZone), "test_db",
+		"select f from cpu", replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	policy, err = exec.(*brokerExecutor).selectPolicy(models.Database{
+		Option: option.DatabaseOption{ReplicaSelectPolicy: string(option.MostCaughtUp)}})
+	assert.NoError(t, err)
+	assert.Equal(t, option.PreferLocalZone, policy)
+
+	// unknown policy of database
+	dbStateMachine.EXPECT().GetDatabaseCfg("test_db").
+		Return(models.Database{Option: option.DatabaseOption{Interval: "10s", ReplicaSelectPolicy: "unknown"}}, true)
+	exec = newBrokerExecutor(context.TODO(), "test_db", "select f from cpu",
+		replicaStateMachine, nodeStateMachine, dbStateMachine, jobManager)
+	exec.Execute()
+	assert.NotNil(t, exec.ExecuteContext())
 }
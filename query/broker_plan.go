@@ -1,6 +1,7 @@
 package query
 
 import (
+	"github.com/lindb/lindb/aggregation/function"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/sql"
@@ -16,6 +17,7 @@ type brokerPlan struct {
 	brokerNodes       []models.ActiveNode
 	intermediateNodes []models.Node
 	databaseCfg       models.Database
+	shardEpochs       models.ShardEpochs
 
 	physicalPlan *models.PhysicalPlan
 }
@@ -40,17 +42,18 @@ func newBrokerPlan(sql string, databaseCfg models.Database, storageNodes map[str
 //    c) no other active broker node => node need leafs
 //    d) need intermediate computing nodes
 func (p *brokerPlan) Plan() error {
-	lenOfStorageNodes := len(p.storageNodes)
-	if lenOfStorageNodes == 0 {
+	if len(p.storageNodes) == 0 {
 		return errNoAvailableStorageNode
 	}
 
-	query, err := sql.Parse(p.sql)
-	if err != nil {
-		return err
+	if p.query == nil {
+		query, err := sql.Parse(p.sql)
+		if err != nil {
+			return err
+		}
+		// set query statement
+		p.query = query.(*stmt.Query)
 	}
-	// set query statement
-	p.query = query.(*stmt.Query)
 
 	if p.query.Interval <= 0 {
 		var interval timeutil.Interval
@@ -62,6 +65,13 @@ func (p *brokerPlan) Plan() error {
 	intervalVal := int64(p.query.Interval)
 	p.query.TimeRange.Start = timeutil.Truncate(p.query.TimeRange.Start, intervalVal)
 	p.query.TimeRange.End = timeutil.Truncate(p.query.TimeRange.End, intervalVal)
+	p.widenTimeRange()
+
+	p.filterStorageNodes()
+	lenOfStorageNodes := len(p.storageNodes)
+	if lenOfStorageNodes == 0 {
+		return errNoAvailableStorageNode
+	}
 
 	root := p.currentBrokerNode
 
@@ -88,6 +98,73 @@ func (p *brokerPlan) Plan() error {
 	return nil
 }
 
+// widenTimeRange widens the query time range for the time shift functions, keeps the result time range,
+// time_shift(f, n) moves the points forward by n seconds, so the data of [start-n, end-n] is needed.
+func (p *brokerPlan) widenTimeRange() {
+	p.query.ResultTimeRange = p.query.TimeRange
+	var forward, backward int64
+	for _, item := range p.query.SelectItems {
+		p.visitTimeShift(item, 0, func(shift int64) {
+			if shift > forward {
+				forward = shift
+			}
+			if -shift > backward {
+				backward = -shift
+			}
+		})
+	}
+	p.query.TimeRange.Start -= forward
+	p.query.TimeRange.End += backward
+}
+
+// visitTimeShift visits the fields of expression, passes the total shift(ms) of field to fn,
+// the shift of each time shift function is truncated by interval as same as the function does.
+func (p *brokerPlan) visitTimeShift(expr stmt.Expr, shift int64, fn func(shift int64)) {
+	switch e := expr.(type) {
+	case *stmt.FieldExpr:
+		fn(shift)
+	case *stmt.SelectItem:
+		p.visitTimeShift(e.Expr, shift, fn)
+	case *stmt.ParenExpr:
+		p.visitTimeShift(e.Expr, shift, fn)
+	case *stmt.BinaryExpr:
+		p.visitTimeShift(e.Left, shift, fn)
+		p.visitTimeShift(e.Right, shift, fn)
+	case *stmt.CallExpr:
+		if e.FuncType == function.TimeShift && len(e.Params) == 2 {
+			if arg, ok := e.Params[1].(*stmt.NumberLiteral); ok {
+				interval := int64(p.query.Interval)
+				shift += int64(arg.Val*1000) / interval * interval
+			}
+		}
+		for _, param := range e.Params {
+			p.visitTimeShift(param, shift, fn)
+		}
+	}
+}
+
+// filterStorageNodes filters the shards which don't store the data of query time range,
+// the shards added by shard split only store the data after the switch time of related epoch.
+func (p *brokerPlan) filterStorageNodes() {
+	if len(p.shardEpochs) == 0 {
+		return
+	}
+	numOfShard := int32(p.shardEpochs.MaxNumOfShard(p.query.TimeRange.Start, p.query.TimeRange.End))
+	storageNodes := make(map[string][]int32)
+	for nodeID, shardIDs := range p.storageNodes {
+		var queryShardIDs []int32
+		for _, shardID := range shardIDs {
+			if shardID < numOfShard {
+				queryShardIDs = append(queryShardIDs, shardID)
+			}
+		}
+		if len(queryShardIDs) > 0 {
+			storageNodes[nodeID] = queryShardIDs
+		}
+	}
+	p.storageNodes = storageNodes
+}
+
 // buildIntermediateNodes builds intermediate nodes if need
 func (p *brokerPlan) buildIntermediateNodes() {
 	if len(p.query.GroupBy) == 0 {
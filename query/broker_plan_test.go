@@ -243,3 +243,67 @@ func TestBrokerPlan_GroupBy_One_StorageNode(t *testing.T) {
 func generateBrokerActiveNode(ip string, port int) models.ActiveNode {
 	return models.ActiveNode{Node: models.Node{IP: ip, Port: uint16(port)}}
 }
+
+func TestBrokerPlan_ShardEpochs(t *testing.T) {
+	storageNodes := map[string][]int32{"1.1.1.1:9000": {0, 2}, "1.1.1.2:9000": {1, 3}, "1.1.1.3:9000": {4, 5}}
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+	newPlan := func(sql string) *brokerPlan {
+		plan := newBrokerPlan(sql,
+			models.Database{Option: option.DatabaseOption{Interval: "10s"}},
+			storageNodes, currentNode.Node, nil).(*brokerPlan)
+		// shards increased from 2 to 4 at 2020-01-01 00:00:00, from 4 to 6 at 2020-02-01 00:00:00
+		plan.shardEpochs = models.ShardEpochs{
+			{NumOfShard: 2},
+			{NumOfShard: 4, StartTime: 1577836800000},
+			{NumOfShard: 6, StartTime: 1580515200000},
+		}
+		return plan
+	}
+	// query time range before shard split
+	plan := newPlan("select f from cpu where time>'2019-12-01 00:00:00' and time<'2019-12-02 00:00:00'")
+	err := plan.Plan()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]int32{"1.1.1.1:9000": {0}, "1.1.1.2:9000": {1}}, plan.storageNodes)
+	assert.Len(t, plan.physicalPlan.Leafs, 2)
+
+	// query time range overlaps shard split
+	plan = newPlan("select f from cpu where time>'2019-12-01 00:00:00' and time<'2020-01-02 00:00:00'")
+	err = plan.Plan()
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]int32{"1.1.1.1:9000": {0, 2}, "1.1.1.2:9000": {1, 3}}, plan.storageNodes)
+
+	// query time range after all shard splits
+	plan = newPlan("select f from cpu where time>'2020-03-01 00:00:00' and time<'2020-03-02 00:00:00'")
+	err = plan.Plan()
+	assert.NoError(t, err)
+	assert.Equal(t, storageNodes, plan.storageNodes)
+
+	// no storage node stores the data of query time range
+	plan = newBrokerPlan("select f from cpu where time>'2019-12-01 00:00:00' and time<'2019-12-02 00:00:00'",
+		models.Database{Option: option.DatabaseOption{Interval: "10s"}},
+		map[string][]int32{"1.1.1.3:9000": {4, 5}}, currentNode.Node, nil).(*brokerPlan)
+	plan.shardEpochs = models.ShardEpochs{{NumOfShard: 2}, {NumOfShard: 6, StartTime: 1577836800000}}
+	err = plan.Plan()
+	assert.Equal(t, errNoAvailableStorageNode, err)
+}
+
+func TestBrokerPlan_TimeShift(t *testing.T) {
+	storageNodes := map[string][]int32{"1.1.1.1:9000": {1, 2, 4}}
+	currentNode := generateBrokerActiveNode("1.1.1.3", 8000)
+	newPlan := func(sql string) *brokerPlan {
+		plan := newBrokerPlan(sql,
+			models.Database{Option: option.DatabaseOption{Interval: "10s"}},
+			storageNodes, currentNode.Node, nil).(*brokerPlan)
+		err := plan.Plan()
+		assert.NoError(t, err)
+		return plan
+	}
+	plan := newPlan("select f from cpu")
+	assert.Equal(t, plan.query.ResultTimeRange, plan.query.TimeRange)
+
+	// shift is truncated by interval, nested time shift is accumulated
+	plan = newPlan("select time_shift(f, 25), time_shift(time_shift(g, 20), 10)+time_shift(h, -30) from cpu")
+	result := plan.query.ResultTimeRange
+	assert.Equal(t, result.Start-30*1000, plan.query.TimeRange.Start)
+	assert.Equal(t, result.End+30*1000, plan.query.TimeRange.End)
+}
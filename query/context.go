@@ -2,6 +2,7 @@ package query
 
 import (
 	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/sql/stmt"
 )
 
@@ -10,6 +11,9 @@ type storageExecuteContext struct {
 	query    *stmt.Query
 	shardIDs []int32
 
+	storageInterval timeutil.Interval // interval of data families for querying(write/rollup interval)
+	rollup          bool              // if storage interval is rollup interval, memory data isn't queried
+
 	tagFilterResult map[string]*tagFilterResult
 
 	stats *models.StorageStats // storage query stats track for explain query
@@ -36,6 +40,12 @@ func (ctx *storageExecuteContext) QueryStats() *models.StorageStats {
 	return ctx.stats
 }
 
+// setStorageInterval sets the interval of data families for querying, and if it's rollup interval
+func (ctx *storageExecuteContext) setStorageInterval(storageInterval timeutil.Interval, rollup bool) {
+	ctx.storageInterval = storageInterval
+	ctx.rollup = rollup
+}
+
 // setTagFilterResult sets tag filter result
 func (ctx *storageExecuteContext) setTagFilterResult(tagFilterResult map[string]*tagFilterResult) {
 	ctx.tagFilterResult = tagFilterResult
@@ -0,0 +1,148 @@
+package query
+
+import (
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+// downSamplingResultSet represents the filter result set of smaller interval(write interval/memory database),
+// which down samples the data into the slots of storage interval(rollup interval) when loading data.
+type downSamplingResultSet struct {
+	flow.FilterResultSet
+
+	sourceInterval timeutil.Interval
+	targetInterval timeutil.Interval
+}
+
+// newDownSamplingResultSet creates the down sampling filter result set
+func newDownSamplingResultSet(rs flow.FilterResultSet, sourceInterval, targetInterval timeutil.Interval) flow.FilterResultSet {
+	return &downSamplingResultSet{
+		FilterResultSet: rs,
+		sourceInterval:  sourceInterval,
+		targetInterval:  targetInterval,
+	}
+}
+
+// Load loads the data from storage, the data is down sampled into the aggregator of query flow.
+func (rs *downSamplingResultSet) Load(qf flow.StorageQueryFlow, fieldIDs []field.ID,
+	highKey uint16, seriesID roaring.Container,
+) flow.Scanner {
+	return rs.FilterResultSet.Load(&downSamplingQueryFlow{
+		StorageQueryFlow: qf,
+		sourceInterval:   rs.sourceInterval,
+		targetInterval:   rs.targetInterval,
+	}, fieldIDs, highKey, seriesID)
+}
+
+// downSamplingQueryFlow wraps the aggregator of query flow for down sampling
+type downSamplingQueryFlow struct {
+	flow.StorageQueryFlow
+
+	sourceInterval timeutil.Interval
+	targetInterval timeutil.Interval
+}
+
+// GetAggregator gets the down sampling aggregator which wraps the aggregator of query flow
+func (qf *downSamplingQueryFlow) GetAggregator(highKey uint16) aggregation.ContainerAggregator {
+	agg := qf.StorageQueryFlow.GetAggregator(highKey)
+	if agg == nil {
+		return nil
+	}
+	return &downSamplingAggregator{
+		ContainerAggregator: agg,
+		sourceInterval:      qf.sourceInterval,
+		targetInterval:      qf.targetInterval,
+	}
+}
+
+// downSamplingAggregator wraps the field aggregates for down sampling
+type downSamplingAggregator struct {
+	aggregation.ContainerAggregator
+
+	sourceInterval timeutil.Interval
+	targetInterval timeutil.Interval
+	fieldAggs      aggregation.FieldAggregates
+}
+
+// GetFieldAggregates returns the down sampling aggregates of fields
+func (a *downSamplingAggregator) GetFieldAggregates() aggregation.FieldAggregates {
+	if a.fieldAggs == nil {
+		fieldAggs := a.ContainerAggregator.GetFieldAggregates()
+		a.fieldAggs = make(aggregation.FieldAggregates, len(fieldAggs))
+		for idx, fieldAgg := range fieldAggs {
+			a.fieldAggs[idx] = &downSamplingSeriesAggregator{
+				SeriesAggregator: fieldAgg,
+				sourceInterval:   a.sourceInterval,
+				targetInterval:   a.targetInterval,
+			}
+		}
+	}
+	return a.fieldAggs
+}
+
+// downSamplingSeriesAggregator maps the family of source interval to the family of target interval
+type downSamplingSeriesAggregator struct {
+	aggregation.SeriesAggregator
+
+	sourceInterval timeutil.Interval
+	targetInterval timeutil.Interval
+}
+
+// GetAggregateBlock gets the block of target family which includes the source family,
+// returns the block which down samples the source slots into the target slots.
+func (a *downSamplingSeriesAggregator) GetAggregateBlock(familyTime int64) (series.Block, bool) {
+	calc := a.targetInterval.Calculator()
+	segmentTime := calc.CalcSegmentTime(familyTime)
+	targetFamilyTime := calc.CalcFamilyStartTime(segmentTime, calc.CalcFamily(familyTime, segmentTime))
+	block, ok := a.SeriesAggregator.GetAggregateBlock(targetFamilyTime)
+	if !ok {
+		return nil, false
+	}
+	return &downSamplingBlock{
+		Block:            block,
+		familyTime:       familyTime,
+		targetFamilyTime: targetFamilyTime,
+		sourceInterval:   a.sourceInterval.Int64(),
+		targetInterval:   a.targetInterval.Int64(),
+		aggFunc:          a.GetFieldType().GetAggFunc(),
+		lastSourceSlot:   -1,
+		lastTargetSlot:   -1,
+	}, true
+}
+
+// downSamplingBlock aggregates the values of source slots which are in same target slot,
+// then appends the aggregated value into the target slot.
+type downSamplingBlock struct {
+	series.Block
+
+	familyTime       int64
+	targetFamilyTime int64
+	sourceInterval   int64
+	targetInterval   int64
+	aggFunc          field.AggFunc
+
+	lastSourceSlot int
+	lastTargetSlot int
+	lastValue      float64
+}
+
+// Append appends the value of source slot into the target slot
+func (b *downSamplingBlock) Append(slot int, value float64) bool {
+	if slot <= b.lastSourceSlot {
+		// slots of a series are in order, data of next series is appended
+		b.lastTargetSlot = -1
+	}
+	b.lastSourceSlot = slot
+	targetSlot := int((b.familyTime + int64(slot)*b.sourceInterval - b.targetFamilyTime) / b.targetInterval)
+	if targetSlot == b.lastTargetSlot && b.aggFunc != nil {
+		value = b.aggFunc.Aggregate(b.lastValue, value)
+	}
+	b.lastTargetSlot = targetSlot
+	b.lastValue = value
+	return b.Block.Append(targetSlot, value)
+}
@@ -0,0 +1,70 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestDownSamplingResultSet_Load(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sourceInterval := timeutil.Interval(10 * timeutil.OneSecond)
+	targetInterval := timeutil.Interval(5 * timeutil.OneMinute)
+	rs := flow.NewMockFilterResultSet(ctrl)
+	qf := flow.NewMockStorageQueryFlow(ctrl)
+	downSamplingRS := newDownSamplingResultSet(rs, sourceInterval, targetInterval)
+
+	rs.EXPECT().Load(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(f flow.StorageQueryFlow, _ []field.ID, highKey uint16, _ roaring.Container) flow.Scanner {
+			// case 1: aggregator not exist
+			qf.EXPECT().GetAggregator(highKey).Return(nil)
+			assert.Nil(t, f.GetAggregator(highKey))
+			// case 2: down sampling aggregator
+			containerAgg := aggregation.NewMockContainerAggregator(ctrl)
+			seriesAgg := aggregation.NewMockSeriesAggregator(ctrl)
+			qf.EXPECT().GetAggregator(highKey).Return(containerAgg)
+			containerAgg.EXPECT().GetFieldAggregates().Return(aggregation.FieldAggregates{seriesAgg})
+			agg := f.GetAggregator(highKey)
+			fieldAggs := agg.GetFieldAggregates()
+			assert.Len(t, fieldAggs, 1)
+			// returns cached field aggregates
+			assert.Equal(t, fieldAggs, agg.GetFieldAggregates())
+
+			familyTime, _ := timeutil.ParseTimestamp("20261017 08:00:00", "20060102 15:04:05")
+			targetFamilyTime, _ := timeutil.ParseTimestamp("20261017 00:00:00", "20060102 15:04:05")
+			// block not exist
+			seriesAgg.EXPECT().GetAggregateBlock(targetFamilyTime).Return(nil, false)
+			_, ok := fieldAggs[0].GetAggregateBlock(familyTime)
+			assert.False(t, ok)
+			// down sampling block
+			block := series.NewMockBlock(ctrl)
+			seriesAgg.EXPECT().GetAggregateBlock(targetFamilyTime).Return(block, true)
+			seriesAgg.EXPECT().GetFieldType().Return(field.SumField)
+			b, ok := fieldAggs[0].GetAggregateBlock(familyTime)
+			assert.True(t, ok)
+			// 08:00 => slot 96 of 5 minutes
+			gomock.InOrder(
+				block.EXPECT().Append(96, 1.0).Return(false),
+				block.EXPECT().Append(96, 3.0).Return(false),
+				block.EXPECT().Append(97, 3.0).Return(false),
+				// next series
+				block.EXPECT().Append(96, 5.0).Return(true),
+			)
+			assert.False(t, b.Append(0, 1))
+			assert.False(t, b.Append(29, 2))
+			assert.False(t, b.Append(30, 3))
+			assert.True(t, b.Append(1, 5))
+			return nil
+		})
+	assert.Nil(t, downSamplingRS.Load(qf, []field.ID{1}, 1, roaring.BitmapOf(1).GetContainer(0)))
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
 )
@@ -53,6 +54,21 @@ func (*executorFactory) NewBrokerExecutor(
 		jobManager)
 }
 
+// NewQueryBrokerExecutor creates broker executor based on the query statement
+func (*executorFactory) NewQueryBrokerExecutor(
+	ctx context.Context,
+	databaseName string,
+	query *stmt.Query,
+	replicaStateMachine replica.StatusStateMachine,
+	nodeStateMachine broker.NodeStateMachine,
+	databaseStateMachine database.DBStateMachine,
+	jobManager parallel.JobManager,
+) parallel.BrokerExecutor {
+	return newQueryBrokerExecutor(ctx, databaseName, query,
+		replicaStateMachine, nodeStateMachine, databaseStateMachine,
+		jobManager)
+}
+
 // NewMetadataBrokerExecutor creates the metadata executor in broker side
 func (*executorFactory) NewMetadataBrokerExecutor(
 	ctx context.Context,
@@ -66,6 +82,13 @@ func (*executorFactory) NewMetadataBrokerExecutor(
 }
 
 // NewStorageExecuteContext creates the storage execute context in storage side
-func (*executorFactory) NewStorageExecuteContext(shardIDs []int32, query *stmt.Query) parallel.StorageExecuteContext {
-	return newStorageExecuteContext(shardIDs, query)
+func (*executorFactory) NewStorageExecuteContext(
+	shardIDs []int32,
+	storageInterval timeutil.Interval,
+	rollup bool,
+	query *stmt.Query,
+) parallel.StorageExecuteContext {
+	ctx := newStorageExecuteContext(shardIDs, query)
+	ctx.setStorageInterval(storageInterval, rollup)
+	return ctx
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/sql/stmt"
 	"github.com/lindb/lindb/tsdb"
 )
@@ -20,6 +21,8 @@ func TestExecutorFactory_NewExecutor(t *testing.T) {
 	assert.NotNil(t, factory.NewStorageExecutor(nil, mockDatabase, newStorageExecuteContext(nil, &stmt.Query{})))
 	assert.NotNil(t, factory.NewBrokerExecutor(
 		context.TODO(), "db", "sql", nil, nil, nil, nil))
+	assert.NotNil(t, factory.NewQueryBrokerExecutor(
+		context.TODO(), "db", &stmt.Query{}, nil, nil, nil, nil))
 	assert.NotNil(t, factory.NewMetadataStorageExecutor(nil, nil, nil))
 	assert.NotNil(t, factory.NewMetadataBrokerExecutor(
 		context.TODO(), "db", nil, nil, nil, nil))
@@ -27,5 +30,5 @@ func TestExecutorFactory_NewExecutor(t *testing.T) {
 
 func TestNewExecutorFactory_NewContext(t *testing.T) {
 	factory := NewExecutorFactory()
-	assert.NotNil(t, factory.NewStorageExecuteContext(nil, &stmt.Query{}))
+	assert.NotNil(t, factory.NewStorageExecuteContext(nil, timeutil.Interval(10*timeutil.OneSecond), false, &stmt.Query{}))
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/sql/stmt"
 )
 
@@ -75,11 +76,16 @@ func (e *metadataBrokerExecutor) submitJob(physicalPlan *models.PhysicalPlan, re
 // buildPhysicalPlan builds distribution physical execute plan
 func (e *metadataBrokerExecutor) buildPhysicalPlan() (*models.PhysicalPlan, error) {
 	//FIXME need using storage's replica state ???
-	storageNodes := e.replicaStateMachine.GetQueryableReplicas(e.database)
-	storageNodesLen := len(storageNodes)
-	if storageNodesLen == 0 {
+	policy, ok := replica.SelectPolicyFromContext(e.ctx)
+	if !ok {
+		policy = option.LeastPending
+	}
+	replicas := e.replicaStateMachine.GetQueryableReplicas(e.database, policy)
+	if replicas == nil || len(replicas.Nodes) == 0 {
 		return nil, errNoAvailableStorageNode
 	}
+	storageNodes := replicas.Nodes
+	storageNodesLen := len(storageNodes)
 	curBroker := e.nodeStateMachine.GetCurrentNode()
 	curBrokerIndicator := (&curBroker).Indicator()
 	physicalPlan := &models.PhysicalPlan{
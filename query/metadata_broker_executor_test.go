@@ -12,6 +12,7 @@ import (
 	"github.com/lindb/lindb/coordinator/replica"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/parallel"
+	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/sql/stmt"
 )
 
@@ -27,14 +28,15 @@ func TestMetadataBrokerExecutor_Execute(t *testing.T) {
 		nodeStateMachine, replicaStateMachine, jobManager)
 
 	// no storage node
-	replicaStateMachine.EXPECT().GetQueryableReplicas(gomock.Any()).Return(nil)
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db", option.LeastPending).Return(nil)
 	rs, err := exec.Execute()
 	assert.Error(t, err)
 	assert.Nil(t, rs)
 
 	// submit job err
 	nodeStateMachine.EXPECT().GetCurrentNode().Return(models.Node{IP: "2.2.2.2", Port: 1234})
-	replicaStateMachine.EXPECT().GetQueryableReplicas(gomock.Any()).Return(map[string][]int32{"1.1.1.1:1234": {1, 2, 3}})
+	replicaStateMachine.EXPECT().GetQueryableReplicas("test_db", option.LeastPending).
+		Return(&models.QueryableReplicas{Nodes: map[string][]int32{"1.1.1.1:1234": {1, 2, 3}}})
 	jobManager.EXPECT().SubmitMetadataJob(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
 	rs, err = exec.Execute()
 	assert.Error(t, err)
@@ -0,0 +1,115 @@
+package query
+
+import (
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/coordinator/storage"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
+)
+
+// seriesDeleter implements storage.SeriesDeleter interface,
+// finds the series ids via tag filtering/series searching, then tombstones them in index database of shard,
+// the data of deleted series is purged when compaction.
+type seriesDeleter struct {
+	storageService service.StorageService
+	logger         *logger.Logger
+}
+
+// NewSeriesDeleter creates the series deleter for storage node
+func NewSeriesDeleter(storageService service.StorageService) storage.SeriesDeleter {
+	return &seriesDeleter{
+		storageService: storageService,
+		logger:         logger.GetLogger("query", "SeriesDeleter"),
+	}
+}
+
+// DeleteSeries deletes the series of metric in spec shards based on delete statement,
+// if metric/tag filter not found, returns nil, because nothing need to delete.
+func (d *seriesDeleter) DeleteSeries(databaseName string, shardIDs []int32, deleteStmt *stmt.Delete) error {
+	db, ok := d.storageService.GetDatabase(databaseName)
+	if !ok {
+		return errDatabaseNotExist
+	}
+	metricID, err := db.Metadata().MetadataDatabase().GetMetricID(deleteStmt.Namespace, deleteStmt.MetricName)
+	if err != nil {
+		if err == constants.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+	if deleteStmt.DropMetric {
+		return d.dropMetric(db, shardIDs, metricID, deleteStmt)
+	}
+	var tagFilterResult map[string]*tagFilterResult
+	if deleteStmt.Condition != nil {
+		tagSearch := newTagSearchFunc(deleteStmt.Namespace, deleteStmt.MetricName, deleteStmt.Condition, db.Metadata())
+		tagFilterResult, err = tagSearch.Filter()
+		if err != nil {
+			if err == constants.ErrNotFound {
+				return nil
+			}
+			return err
+		}
+		if len(tagFilterResult) == 0 {
+			// filter not match, nothing need to delete
+			return nil
+		}
+	}
+	for _, shardID := range shardIDs {
+		shard, ok := db.GetShard(shardID)
+		if !ok {
+			continue
+		}
+		var seriesIDs *roaring.Bitmap
+		if deleteStmt.Condition != nil {
+			seriesSearch := newSeriesSearchFunc(shard.IndexDatabase(), tagFilterResult, deleteStmt.Condition)
+			seriesIDs, err = seriesSearch.Search()
+		} else {
+			seriesIDs, err = shard.IndexDatabase().GetSeriesIDsForMetric(deleteStmt.Namespace, deleteStmt.MetricName)
+		}
+		if err != nil {
+			if err == constants.ErrNotFound {
+				continue
+			}
+			return err
+		}
+		if seriesIDs == nil || seriesIDs.IsEmpty() {
+			continue
+		}
+		if err := shard.DeleteSeries(metricID, seriesIDs); err != nil {
+			return err
+		}
+		d.logger.Info("delete series successfully",
+			logger.String("db", databaseName), logger.Any("shardID", shardID),
+			logger.String("metric", deleteStmt.MetricName), logger.Any("series", seriesIDs.GetCardinality()))
+	}
+	return nil
+}
+
+// dropMetric drops the metric in spec shards, then drops the metadata of metric,
+// new metric id will be generated when writing the dropped metric again.
+func (d *seriesDeleter) dropMetric(db tsdb.Database, shardIDs []int32, metricID uint32, deleteStmt *stmt.Delete) error {
+	for _, shardID := range shardIDs {
+		shard, ok := db.GetShard(shardID)
+		if !ok {
+			continue
+		}
+		if err := shard.DropMetric(metricID); err != nil {
+			return err
+		}
+	}
+	if err := db.Metadata().MetadataDatabase().DropMetric(deleteStmt.Namespace, deleteStmt.MetricName); err != nil {
+		if err == constants.ErrNotFound {
+			return nil
+		}
+		return err
+	}
+	d.logger.Info("drop metric successfully",
+		logger.String("db", db.Name()), logger.Any("shardIDs", shardIDs),
+		logger.String("metric", deleteStmt.MetricName), logger.Uint32("metricID", metricID))
+	return nil
+}
@@ -0,0 +1,119 @@
+package query
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/series"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/sql/stmt"
+	"github.com/lindb/lindb/tsdb"
+	"github.com/lindb/lindb/tsdb/indexdb"
+	"github.com/lindb/lindb/tsdb/metadb"
+)
+
+func TestSeriesDeleter_DeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newTagSearchFunc = newTagSearch
+		newSeriesSearchFunc = newSeriesSearch
+		ctrl.Finish()
+	}()
+
+	storageService := service.NewMockStorageService(ctrl)
+	db := tsdb.NewMockDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	shard := tsdb.NewMockShard(ctrl)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	db.EXPECT().Metadata().Return(metadata).AnyTimes()
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	db.EXPECT().GetShard(int32(1)).Return(shard, true).AnyTimes()
+	db.EXPECT().GetShard(int32(2)).Return(nil, false).AnyTimes()
+	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+	tagSearch := NewMockTagSearch(ctrl)
+	newTagSearchFunc = func(namespace, metricName string, condition stmt.Expr, metadata metadb.Metadata) TagSearch {
+		return tagSearch
+	}
+	seriesSearch := NewMockSeriesSearch(ctrl)
+	newSeriesSearchFunc = func(filter series.Filter, filterResult map[string]*tagFilterResult,
+		condition stmt.Expr) SeriesSearch {
+		return seriesSearch
+	}
+
+	deleter := NewSeriesDeleter(storageService)
+	deleteAll := &stmt.Delete{Namespace: "ns", MetricName: "cpu"}
+	deleteByTag := &stmt.Delete{Namespace: "ns", MetricName: "cpu", Condition: &stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"}}
+	shardIDs := []int32{1, 2}
+	// case 1: database not exist
+	storageService.EXPECT().GetDatabase("test").Return(nil, false)
+	err := deleter.DeleteSeries("test", shardIDs, deleteAll)
+	assert.Equal(t, errDatabaseNotExist, err)
+	storageService.EXPECT().GetDatabase("test").Return(db, true).AnyTimes()
+	// case 2: metric not exist
+	metadataDB.EXPECT().GetMetricID("ns", "cpu").Return(uint32(0), constants.ErrNotFound)
+	err = deleter.DeleteSeries("test", shardIDs, deleteAll)
+	assert.NoError(t, err)
+	// case 3: get metric id err
+	metadataDB.EXPECT().GetMetricID("ns", "cpu").Return(uint32(0), fmt.Errorf("err"))
+	err = deleter.DeleteSeries("test", shardIDs, deleteAll)
+	assert.Error(t, err)
+	metadataDB.EXPECT().GetMetricID("ns", "cpu").Return(uint32(10), nil).AnyTimes()
+	// case 4: delete all series of metric
+	indexDB.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(roaring.BitmapOf(1, 2), nil)
+	shard.EXPECT().DeleteSeries(uint32(10), roaring.BitmapOf(1, 2)).Return(nil)
+	err = deleter.DeleteSeries("test", shardIDs, deleteAll)
+	assert.NoError(t, err)
+	// case 5: series not found
+	indexDB.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(nil, constants.ErrNotFound)
+	err = deleter.DeleteSeries("test", shardIDs, deleteAll)
+	assert.NoError(t, err)
+	indexDB.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(roaring.New(), nil)
+	err = deleter.DeleteSeries("test", shardIDs, deleteAll)
+	assert.NoError(t, err)
+	// case 6: get series ids err
+	indexDB.EXPECT().GetSeriesIDsForMetric("ns", "cpu").Return(nil, fmt.Errorf("err"))
+	err = deleter.DeleteSeries("test", shardIDs, deleteAll)
+	assert.Error(t, err)
+	// case 7: tag filter err/not found
+	tagSearch.EXPECT().Filter().Return(nil, fmt.Errorf("err"))
+	err = deleter.DeleteSeries("test", shardIDs, deleteByTag)
+	assert.Error(t, err)
+	tagSearch.EXPECT().Filter().Return(nil, constants.ErrNotFound)
+	err = deleter.DeleteSeries("test", shardIDs, deleteByTag)
+	assert.NoError(t, err)
+	tagSearch.EXPECT().Filter().Return(nil, nil)
+	err = deleter.DeleteSeries("test", shardIDs, deleteByTag)
+	assert.NoError(t, err)
+	// case 8: delete series by tag filter
+	tagSearch.EXPECT().Filter().Return(map[string]*tagFilterResult{"host": {}}, nil).AnyTimes()
+	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(2), nil)
+	shard.EXPECT().DeleteSeries(uint32(10), roaring.BitmapOf(2)).Return(fmt.Errorf("err"))
+	err = deleter.DeleteSeries("test", shardIDs, deleteByTag)
+	assert.Error(t, err)
+	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(2), nil)
+	shard.EXPECT().DeleteSeries(uint32(10), roaring.BitmapOf(2)).Return(nil)
+	err = deleter.DeleteSeries("test", shardIDs, deleteByTag)
+	assert.NoError(t, err)
+	// case 9: drop metric
+	dropMetric := &stmt.Delete{Namespace: "ns", MetricName: "cpu", DropMetric: true}
+	shard.EXPECT().DropMetric(uint32(10)).Return(fmt.Errorf("err"))
+	err = deleter.DeleteSeries("test", shardIDs, dropMetric)
+	assert.Error(t, err)
+	shard.EXPECT().DropMetric(uint32(10)).Return(nil).AnyTimes()
+	metadataDB.EXPECT().DropMetric("ns", "cpu").Return(fmt.Errorf("err"))
+	err = deleter.DeleteSeries("test", shardIDs, dropMetric)
+	assert.Error(t, err)
+	metadataDB.EXPECT().DropMetric("ns", "cpu").Return(constants.ErrNotFound)
+	err = deleter.DeleteSeries("test", shardIDs, dropMetric)
+	assert.NoError(t, err)
+	db.EXPECT().Name().Return("test")
+	metadataDB.EXPECT().DropMetric("ns", "cpu").Return(nil)
+	err = deleter.DeleteSeries("test", shardIDs, dropMetric)
+	assert.NoError(t, err)
+}
@@ -2,6 +2,7 @@ package query
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 
 	"github.com/lindb/roaring"
@@ -26,11 +27,13 @@ var (
 )
 
 var (
-	errNoShardID         = errors.New("there is no shard id in search condition")
-	errNoShardInDatabase = errors.New("there is no shard in database storage engine")
-	errShardNotFound     = errors.New("shard not found in database storage engine")
-	errShardNotMatch     = errors.New("storage's num. of shard not match search condition")
-	errShardNumNotMatch  = errors.New("got shard size not equals input shard size")
+	errNoShardID     = errors.New("there is no shard id in search condition")
+	errShardNotMatch = errors.New("storage's num. of shard not match search condition")
+
+	// the shards are not found in storage engine of replica, broker retries the query on other replicas
+	errNoShardInDatabase = fmt.Errorf("%w: there is no shard in database storage engine", constants.ErrShardNotFound)
+	errShardNotFound     = fmt.Errorf("%w in database storage engine", constants.ErrShardNotFound)
+	errShardNumNotMatch  = fmt.Errorf("%w: got shard size not equals input shard size", constants.ErrShardNotFound)
 )
 
 // filterResultSet represents data filter result set
@@ -197,7 +200,7 @@ func (e *storageExecutor) executeQuery() {
 			}()
 			// 1. get series ids by query condition
 			seriesIDs := roaring.New()
-			t := newSeriesIDsSearchTask(e.ctx, shard, seriesIDs)
+			t := newSeriesIDsSearchTask(e.ctx, shard, e.metricID, seriesIDs)
 			err := t.Run()
 			if err != nil && err != constants.ErrNotFound {
 				// maybe series ids not found in shard, so ignore not found err
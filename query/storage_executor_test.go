@@ -193,6 +193,7 @@ func TestStorageExecute_Execute(t *testing.T) {
 	mockDatabase := tsdb.NewMockDatabase(ctrl)
 
 	index := indexdb.NewMockIndexDatabase(ctrl)
+	index.EXPECT().GetDeletedSeriesIDs(gomock.Any()).Return(nil).AnyTimes()
 	shard := tsdb.NewMockShard(ctrl)
 	shard.EXPECT().IndexDatabase().Return(index).AnyTimes()
 	memDB := memdb.NewMockMemoryDatabase(ctrl)
@@ -140,8 +140,13 @@ func (p *storageExecutePlan) field(parentFunc *stmt.CallExpr, expr stmt.Expr) {
 	case *stmt.SelectItem:
 		p.field(nil, e.Expr)
 	case *stmt.CallExpr:
+		parentFunc := e
+		if e.FuncType.IsTransform() {
+			// field param of transform function uses default down sampling func, like rate(f)
+			parentFunc = nil
+		}
 		for _, param := range e.Params {
-			p.field(e, param)
+			p.field(parentFunc, param)
 		}
 	case *stmt.ParenExpr:
 		p.field(nil, e.Expr)
@@ -149,6 +154,10 @@ func (p *storageExecutePlan) field(parentFunc *stmt.CallExpr, expr stmt.Expr) {
 		p.field(nil, e.Left)
 		p.field(nil, e.Right)
 	case *stmt.FieldExpr:
+		if parentFunc != nil && (parentFunc.FuncType == function.Histogram || parentFunc.FuncType == function.Quantile) {
+			p.histogramField(e.Name)
+			return
+		}
 		fieldMeta, err := p.metadata.MetadataDatabase().GetField(p.namespace, p.query.MetricName, field.Name(e.Name))
 		if err != nil {
 			p.err = err
@@ -181,3 +190,29 @@ func (p *storageExecutePlan) field(parentFunc *stmt.CallExpr, expr stmt.Expr) {
 		downSampling.AddFunctionType(funcType)
 	}
 }
+
+// histogramField plans the bucket fields of histogram field, histogram field is stored as multi bucket fields,
+// down sampling/aggregation of bucket field is sum, quantile is calculated in broker side after merging buckets.
+func (p *storageExecutePlan) histogramField(histogramName string) {
+	allFields, err := p.metadata.MetadataDatabase().GetAllFields(p.namespace, p.query.MetricName)
+	if err != nil {
+		p.err = err
+		return
+	}
+	found := false
+	for _, fieldMeta := range allFields {
+		if fieldMeta.Type != field.HistogramField || !field.IsHistogramBucketOf(fieldMeta.Name, histogramName) {
+			continue
+		}
+		found = true
+		downSampling, exist := p.fields[fieldMeta.ID]
+		if !exist {
+			downSampling = aggregation.NewDownSamplingSpec(fieldMeta.Name, fieldMeta.Type)
+			p.fields[fieldMeta.ID] = downSampling
+		}
+		downSampling.AddFunctionType(function.Sum)
+	}
+	if !found {
+		p.err = fmt.Errorf("histogram field[%s] not found", histogramName)
+	}
+}
@@ -135,6 +135,74 @@ func TestStoragePlan_SelectList(t *testing.T) {
 	assert.Equal(t, []field.ID{11, 13, 14}, storagePlan.getFieldIDs())
 }
 
+func TestStoragePlan_Histogram(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), gomock.Any()).Return(uint32(10), nil).AnyTimes()
+	metadataDB.EXPECT().GetAllFields(gomock.Any(), gomock.Any()).
+		Return([]field.Meta{
+			{ID: 10, Type: field.SumField, Name: "f"},
+			{ID: 11, Type: field.HistogramField, Name: "latency__bucket_0.5"},
+			{ID: 12, Type: field.HistogramField, Name: "latency__bucket_+Inf"},
+			{ID: 13, Type: field.HistogramField, Name: "size__bucket_+Inf"},
+		}, nil).AnyTimes()
+
+	q, _ := sql.Parse("select quantile(latency, 0.99), histogram(latency, 0.5) from cpu")
+	query := q.(*stmt.Query)
+	plan := newStorageExecutePlan("ns", metadata, query)
+	err := plan.Plan()
+	assert.NoError(t, err)
+
+	storagePlan := plan.(*storageExecutePlan)
+	downSampling1 := aggregation.NewDownSamplingSpec("latency__bucket_0.5", field.HistogramField)
+	downSampling1.AddFunctionType(function.Sum)
+	downSampling2 := aggregation.NewDownSamplingSpec("latency__bucket_+Inf", field.HistogramField)
+	downSampling2.AddFunctionType(function.Sum)
+	assert.Equal(t, map[field.ID]aggregation.AggregatorSpec{
+		field.ID(11): downSampling1,
+		field.ID(12): downSampling2,
+	}, storagePlan.fields)
+	assert.Equal(t, []field.ID{11, 12}, storagePlan.getFieldIDs())
+}
+
+func TestStoragePlan_Transform(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+
+	metadataDB.EXPECT().GetMetricID(gomock.Any(), gomock.Any()).Return(uint32(10), nil).AnyTimes()
+	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
+		Return(field.Meta{ID: 10, Type: field.SumField}, nil).AnyTimes()
+	metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("a")).
+		Return(field.Meta{ID: 11, Type: field.MinField}, nil).AnyTimes()
+
+	// field param of transform function uses default down sampling func
+	q, _ := sql.Parse("select rate(f), moving_average(a, 5), top(max(f), 3) from cpu")
+	query := q.(*stmt.Query)
+	plan := newStorageExecutePlan("ns", metadata, query)
+	err := plan.Plan()
+	assert.NoError(t, err)
+
+	storagePlan := plan.(*storageExecutePlan)
+	downSampling1 := aggregation.NewDownSamplingSpec("f", field.SumField)
+	downSampling1.AddFunctionType(function.Sum)
+	downSampling1.AddFunctionType(function.Max)
+	downSampling2 := aggregation.NewDownSamplingSpec("a", field.MinField)
+	downSampling2.AddFunctionType(function.Min)
+	assert.Equal(t, map[field.ID]aggregation.AggregatorSpec{
+		field.ID(10): downSampling1,
+		field.ID(11): downSampling2,
+	}, storagePlan.fields)
+}
+
 func TestStorageExecutePlan_groupBy(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -215,8 +283,8 @@ func TestStorageExecutePlan_field_expr_fail(t *testing.T) {
 
 	gomock.InOrder(
 		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
-		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
-			Return(field.Meta{ID: 10, Type: field.SumField}, nil),
+		metadataDB.EXPECT().GetAllFields(gomock.Any(), gomock.Any()).
+			Return([]field.Meta{{ID: 10, Type: field.SumField, Name: "f"}}, nil),
 	)
 	q, _ = sql.Parse("select histogram(f) from disk")
 	query = q.(*stmt.Query)
@@ -228,8 +296,8 @@ func TestStorageExecutePlan_field_expr_fail(t *testing.T) {
 		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
 		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("d")).
 			Return(field.Meta{ID: 10, Type: field.SumField}, nil),
-		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
-			Return(field.Meta{ID: 10, Type: field.SumField}, nil),
+		metadataDB.EXPECT().GetAllFields(gomock.Any(), gomock.Any()).
+			Return(nil, constants.ErrNotFound),
 	)
 	q, _ = sql.Parse("select (d+histogram(f)+b) from disk")
 	query = q.(*stmt.Query)
@@ -241,8 +309,8 @@ func TestStorageExecutePlan_field_expr_fail(t *testing.T) {
 		metadataDB.EXPECT().GetMetricID(gomock.Any(), "disk").Return(uint32(10), nil),
 		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("d")).
 			Return(field.Meta{ID: 12, Type: field.SumField}, nil),
-		metadataDB.EXPECT().GetField(gomock.Any(), gomock.Any(), field.Name("f")).
-			Return(field.Meta{ID: 11, Type: field.SumField}, nil),
+		metadataDB.EXPECT().GetAllFields(gomock.Any(), gomock.Any()).
+			Return([]field.Meta{{ID: 11, Type: field.SumField, Name: "f"}}, nil),
 	)
 	q, _ = sql.Parse("select (d+histogram(f)+b),e from disk")
 	query = q.(*stmt.Query)
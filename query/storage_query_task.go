@@ -139,18 +139,21 @@ func (t *tagFilterTask) AfterRun() {
 type seriesIDsSearchTask struct {
 	baseQueryTask
 
-	ctx   *storageExecuteContext
-	shard tsdb.Shard
+	ctx      *storageExecuteContext
+	shard    tsdb.Shard
+	metricID uint32
 
 	result *roaring.Bitmap
 }
 
 // newSeriesIDsSearchTask creates series ids search task
-func newSeriesIDsSearchTask(ctx *storageExecuteContext, shard tsdb.Shard, result *roaring.Bitmap) flow.QueryTask {
+func newSeriesIDsSearchTask(ctx *storageExecuteContext, shard tsdb.Shard, metricID uint32,
+	result *roaring.Bitmap) flow.QueryTask {
 	task := &seriesIDsSearchTask{
-		ctx:    ctx,
-		shard:  shard,
-		result: result,
+		ctx:      ctx,
+		shard:    shard,
+		metricID: metricID,
+		result:   result,
 	}
 	if ctx.query.Explain {
 		return &queryStatTask{
@@ -178,6 +181,10 @@ func (t *seriesIDsSearchTask) Run() (err error) {
 	}
 	if err == nil && seriesIDs != nil {
 		t.result.Or(seriesIDs)
+		// exclude the deleted series ids
+		if deletedSeriesIDs := t.shard.IndexDatabase().GetDeletedSeriesIDs(t.metricID); deletedSeriesIDs != nil {
+			t.result.AndNot(deletedSeriesIDs)
+		}
 	}
 	return
 }
@@ -222,11 +229,25 @@ func newMemoryDataFilterTask(ctx *storageExecuteContext, shard tsdb.Shard,
 
 // Run executes memory database data filtering based on series ids and time range
 func (t *memoryDataFilterTask) Run() error {
-	resultSet, err := t.shard.MemoryDatabase().Filter(t.metricID, t.fieldIDs, t.seriesIDs, t.ctx.query.TimeRange)
+	timeRange := t.ctx.query.TimeRange
+	if t.ctx.rollup {
+		// memory database only keeps the data of write interval, which isn't rolled up,
+		// so query the data after rollup boundary, then down samples it into rollup interval
+		timeRange.Start = t.shard.GetRollupBoundary(t.ctx.storageInterval, timeRange)
+		if timeRange.Start > timeRange.End {
+			return nil
+		}
+	}
+	resultSet, err := t.shard.MemoryDatabase().Filter(t.metricID, t.fieldIDs, t.seriesIDs, timeRange)
 	if err != nil {
 		return err
 	}
-	t.result.rs = append(t.result.rs, resultSet...)
+	for _, rs := range resultSet {
+		if t.ctx.rollup {
+			rs = newDownSamplingResultSet(rs, t.shard.Interval(), t.ctx.storageInterval)
+		}
+		t.result.rs = append(t.result.rs, rs)
+	}
 	return nil
 }
 
@@ -271,19 +292,44 @@ func newFileDataFilterTask(ctx *storageExecuteContext, shard tsdb.Shard,
 }
 
 // Run executes file data filtering based on series ids and time range for each data family
+// if storage interval is rollup interval, queries the rolled up data before rollup boundary from rollup interval,
+// and the data after rollup boundary from write interval, then down samples it into rollup interval.
 func (t *fileDataFilterTask) Run() error {
-	families := t.shard.GetDataFamilies(t.ctx.query.Interval.Type(), t.ctx.query.TimeRange)
-	if len(families) == 0 {
-		return nil
+	timeRange := t.ctx.query.TimeRange
+	if !t.ctx.rollup {
+		return t.filter(t.ctx.storageInterval, timeRange, false)
+	}
+	boundary := t.shard.GetRollupBoundary(t.ctx.storageInterval, timeRange)
+	if boundary > timeRange.Start {
+		if err := t.filter(t.ctx.storageInterval,
+			timeutil.TimeRange{Start: timeRange.Start, End: boundary - 1}, false); err != nil {
+			return err
+		}
 	}
+	if boundary <= timeRange.End {
+		if err := t.filter(t.shard.Interval(),
+			timeutil.TimeRange{Start: boundary, End: timeRange.End}, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// filter filters the data families of interval within time range, down samples the result set if need
+func (t *fileDataFilterTask) filter(interval timeutil.Interval, timeRange timeutil.TimeRange, downSampling bool) error {
+	families := t.shard.GetDataFamilies(interval, timeRange)
 	for idx := range families {
 		family := families[idx]
-		// execute data family search in background goroutine
-		resultSet, err := family.Filter(t.metricID, t.fieldIDs, t.seriesIDs, t.ctx.query.TimeRange)
+		resultSet, err := family.Filter(t.metricID, t.fieldIDs, t.seriesIDs, timeRange)
 		if err != nil {
 			return err
 		}
-		t.result.rs = append(t.result.rs, resultSet...)
+		for _, rs := range resultSet {
+			if downSampling {
+				rs = newDownSamplingResultSet(rs, interval, t.ctx.storageInterval)
+			}
+			t.result.rs = append(t.result.rs, rs)
+		}
 	}
 	return nil
 }
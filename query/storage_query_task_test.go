@@ -10,6 +10,7 @@ import (
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/series"
 	"github.com/lindb/lindb/series/field"
 	"github.com/lindb/lindb/series/tag"
@@ -83,8 +84,9 @@ func TestSeriesIDsSearchTask_Run(t *testing.T) {
 	shard := tsdb.NewMockShard(ctrl)
 	indexDB := indexdb.NewMockIndexDatabase(ctrl)
 	shard.EXPECT().IndexDatabase().Return(indexDB).AnyTimes()
+	indexDB.EXPECT().GetDeletedSeriesIDs(uint32(10)).Return(nil).AnyTimes()
 	result := roaring.New()
-	task := newSeriesIDsSearchTask(newStorageExecuteContext(nil, &stmt.Query{}), shard, result)
+	task := newSeriesIDsSearchTask(newStorageExecuteContext(nil, &stmt.Query{}), shard, 10, result)
 	// case 1: search err
 	indexDB.EXPECT().GetSeriesIDsForMetric(gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
 	err := task.Run()
@@ -97,7 +99,7 @@ func TestSeriesIDsSearchTask_Run(t *testing.T) {
 	result.Clear()
 	// case 3: group by tag
 	indexDB.EXPECT().GetSeriesIDsForMetric(gomock.Any(), gomock.Any()).Return(roaring.New(), nil)
-	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, &stmt.Query{GroupBy: []string{"host"}}), shard, result)
+	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, &stmt.Query{GroupBy: []string{"host"}}), shard, 10, result)
 	err = task.Run()
 	assert.NoError(t, err)
 	assert.Equal(t, uint64(0), result.GetCardinality())
@@ -109,7 +111,7 @@ func TestSeriesIDsSearchTask_Run(t *testing.T) {
 		return seriesSearch
 	}
 	seriesSearch.EXPECT().Search().Return(nil, fmt.Errorf("err"))
-	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, query), shard, result)
+	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, query), shard, 10, result)
 	err = task.Run()
 	assert.Error(t, err)
 	// case 5: has condition, return series ids
@@ -123,10 +125,21 @@ func TestSeriesIDsSearchTask_Run(t *testing.T) {
 	query = q.(*stmt.Query)
 	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3), nil)
 	shard.EXPECT().ShardID().Return(int32(10))
-	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, query), shard, result)
+	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, query), shard, 10, result)
 	err = task.Run()
 	assert.NoError(t, err)
 	assert.Equal(t, roaring.BitmapOf(1, 2, 3), result)
+	result.Clear()
+	// case 7: exclude deleted series ids
+	indexDB2 := indexdb.NewMockIndexDatabase(ctrl)
+	shard2 := tsdb.NewMockShard(ctrl)
+	shard2.EXPECT().IndexDatabase().Return(indexDB2).AnyTimes()
+	indexDB2.EXPECT().GetDeletedSeriesIDs(uint32(10)).Return(roaring.BitmapOf(2))
+	seriesSearch.EXPECT().Search().Return(roaring.BitmapOf(1, 2, 3), nil)
+	task = newSeriesIDsSearchTask(newStorageExecuteContext(nil, &stmt.Query{Condition: query.Condition}), shard2, 10, result)
+	err = task.Run()
+	assert.NoError(t, err)
+	assert.Equal(t, roaring.BitmapOf(1, 3), result)
 }
 
 func TestMemoryDataFilterTask_Run(t *testing.T) {
@@ -155,6 +168,24 @@ func TestMemoryDataFilterTask_Run(t *testing.T) {
 	shard.EXPECT().ShardID().Return(int32(10))
 	err = task.Run()
 	assert.NoError(t, err)
+	// case 5: skip memory data when all data rolled up
+	ctx := newStorageExecuteContext(nil, &stmt.Query{TimeRange: timeutil.TimeRange{Start: 0, End: 100}})
+	ctx.setStorageInterval(timeutil.Interval(5*timeutil.OneMinute), true)
+	task = newMemoryDataFilterTask(ctx, shard, 1, []field.ID{10}, seriesIDs, result)
+	shard.EXPECT().GetRollupBoundary(gomock.Any(), gomock.Any()).Return(int64(101))
+	err = task.Run()
+	assert.NoError(t, err)
+	// case 6: query memory data after rollup boundary, down samples it into rollup interval
+	result.rs = nil
+	shard.EXPECT().GetRollupBoundary(gomock.Any(), gomock.Any()).Return(int64(50))
+	shard.EXPECT().Interval().Return(timeutil.Interval(10 * timeutil.OneSecond))
+	memDB.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), timeutil.TimeRange{Start: 50, End: 100}).
+		Return([]flow.FilterResultSet{flow.NewMockFilterResultSet(ctrl)}, nil)
+	err = task.Run()
+	assert.NoError(t, err)
+	assert.Len(t, result.rs, 1)
+	_, ok := result.rs[0].(*downSamplingResultSet)
+	assert.True(t, ok)
 }
 
 func TestFileDataFilterTask_Run(t *testing.T) {
@@ -194,6 +225,60 @@ func TestFileDataFilterTask_Run(t *testing.T) {
 	assert.NotNil(t, result.rs)
 }
 
+func TestFileDataFilterTask_Run_rollup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	writeInterval := timeutil.Interval(10 * timeutil.OneSecond)
+	rollupInterval := timeutil.Interval(5 * timeutil.OneMinute)
+	shard := tsdb.NewMockShard(ctrl)
+	shard.EXPECT().Interval().Return(writeInterval).AnyTimes()
+	seriesIDs := roaring.BitmapOf(1, 2, 3)
+	result := &filterResultSet{}
+	ctx := newStorageExecuteContext(nil, &stmt.Query{TimeRange: timeutil.TimeRange{Start: 0, End: 100}})
+	ctx.setStorageInterval(rollupInterval, true)
+	task := newFileDataFilterTask(ctx, shard, 1, []field.ID{10}, seriesIDs, result)
+	rollupFamily := tsdb.NewMockDataFamily(ctrl)
+	writeFamily := tsdb.NewMockDataFamily(ctrl)
+	// case 1: all data rolled up
+	shard.EXPECT().GetRollupBoundary(rollupInterval, gomock.Any()).Return(int64(101))
+	shard.EXPECT().GetDataFamilies(rollupInterval, timeutil.TimeRange{Start: 0, End: 100}).
+		Return([]tsdb.DataFamily{rollupFamily})
+	rollupFamily.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), timeutil.TimeRange{Start: 0, End: 100}).
+		Return([]flow.FilterResultSet{flow.NewMockFilterResultSet(ctrl)}, nil)
+	err := task.Run()
+	assert.NoError(t, err)
+	assert.Len(t, result.rs, 1)
+	// case 2: query rollup interval before boundary, write interval after boundary
+	result.rs = nil
+	shard.EXPECT().GetRollupBoundary(rollupInterval, gomock.Any()).Return(int64(50))
+	shard.EXPECT().GetDataFamilies(rollupInterval, timeutil.TimeRange{Start: 0, End: 49}).
+		Return([]tsdb.DataFamily{rollupFamily})
+	rollupFamily.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), timeutil.TimeRange{Start: 0, End: 49}).
+		Return([]flow.FilterResultSet{flow.NewMockFilterResultSet(ctrl)}, nil)
+	shard.EXPECT().GetDataFamilies(writeInterval, timeutil.TimeRange{Start: 50, End: 100}).
+		Return([]tsdb.DataFamily{writeFamily})
+	writeFamily.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), timeutil.TimeRange{Start: 50, End: 100}).
+		Return([]flow.FilterResultSet{flow.NewMockFilterResultSet(ctrl)}, nil)
+	err = task.Run()
+	assert.NoError(t, err)
+	assert.Len(t, result.rs, 2)
+	_, ok := result.rs[1].(*downSamplingResultSet)
+	assert.True(t, ok)
+	// case 3: filter rollup interval err
+	shard.EXPECT().GetRollupBoundary(rollupInterval, gomock.Any()).Return(int64(50))
+	shard.EXPECT().GetDataFamilies(rollupInterval, gomock.Any()).Return([]tsdb.DataFamily{rollupFamily})
+	rollupFamily.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	err = task.Run()
+	assert.Error(t, err)
+	// case 4: filter write interval err
+	shard.EXPECT().GetRollupBoundary(rollupInterval, gomock.Any()).Return(int64(0))
+	shard.EXPECT().GetDataFamilies(writeInterval, gomock.Any()).Return([]tsdb.DataFamily{writeFamily})
+	writeFamily.EXPECT().Filter(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, fmt.Errorf("err"))
+	err = task.Run()
+	assert.Error(t, err)
+}
+
 func TestGroupingContextFindTask_Run(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 	"sync"
 	"time"
 
@@ -20,23 +21,34 @@ import (
 // ErrCanceled is the error returned when writing data ctx canceled.
 var ErrCanceled = errors.New("write data ctx done")
 
+// ErrQueueFull is the error returned when the pending data of database exceeds the quota,
+// or the replication queue exceeds the data size limit, the client should retry later.
+var ErrQueueFull = errors.New("replication queue is full, please retry later")
+
 const (
 	defaultReportInterval = 30 * time.Second
 	defaultBufferSize     = 1024
+	defaultBlockTimeout   = 5 * time.Second
+	blockCheckInterval    = 10 * time.Millisecond
 )
 
 var log = logger.GetLogger("replication", "ChannelManager")
 
 // ChannelManager manages the construction, retrieving, closing for all channels.
 type ChannelManager interface {
-	// Write writes a MetricList, the manager handler the database, sharding things.
+	// Write writes a MetricList, the manager handler the database, sharding things,
+	// ErrQueueFull is returned if the write is rejected by the quota of database.
 	Write(database string, list *field.MetricList) error
 	// CreateChannel creates a new channel or returns a existed channel for storage with specific database and shardID,
 	// numOfShard should be greater or equal than the origin setting, otherwise error is returned.
 	// numOfShard is used eot calculate the shardID for a given hash.
 	CreateChannel(database string, numOfShard, shardID int32) (Channel, error)
+	// SyncShardEpochs syncs the shard epochs of database, which are used for routing data when shards increased.
+	SyncShardEpochs(database string, epochs models.ShardEpochs)
 	// SyncReplicatorState syncs replicator state
 	SyncReplicatorState()
+	// DropDatabase stops the replication channels of database, then removes the replication data of it
+	DropDatabase(database string) error
 
 	// Close closes all the channel.
 	Close()
@@ -117,11 +129,37 @@ func (cm *channelManager) CreateChannel(database string, numOfShard, shardID int
 	return ch.CreateChannel(numOfShard, shardID)
 }
 
+// SyncShardEpochs syncs the shard epochs of database, which are used for routing data when shards increased.
+func (cm *channelManager) SyncShardEpochs(database string, epochs models.ShardEpochs) {
+	ch, ok := cm.getDatabaseChannel(database)
+	if !ok {
+		return
+	}
+	ch.SetShardEpochs(epochs)
+}
+
 // SyncReplicatorState syncs replicator state
 func (cm *channelManager) SyncReplicatorState() {
 	cm.syncState <- struct{}{}
 }
 
+// DropDatabase stops the replication channels of database, then removes the replication data of it
+func (cm *channelManager) DropDatabase(database string) error {
+	cm.lock4map.Lock()
+	defer cm.lock4map.Unlock()
+
+	ch, ok := cm.getDatabaseChannel(database)
+	if ok {
+		cm.databaseChannelMap.Delete(database)
+		ch.Stop()
+	}
+	if err := removeDir(path.Join(cm.cfg.Dir, database)); err != nil {
+		return err
+	}
+	cm.logger.Info("drop database replication channel successfully", logger.String("db", database))
+	return nil
+}
+
 // Close closes all the channel.
 func (cm *channelManager) Close() {
 	cm.cancel()
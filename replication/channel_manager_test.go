@@ -21,7 +21,6 @@ var replicationConfig = config.ReplicationChannel{
 	DataSizeLimit:      int64(128),
 	RemoveTaskInterval: ltoml.Duration(time.Minute),
 	ReportInterval:     ltoml.Duration(time.Second),
-	FlushInterval:      ltoml.Duration(0),
 	CheckFlushInterval: ltoml.Duration(100 * time.Millisecond),
 	BufferSize:         2,
 }
@@ -97,6 +96,60 @@ func TestChannelManager_Write(t *testing.T) {
 	cm.Close()
 }
 
+func TestChannelManager_SyncShardEpochs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cm := &channelManager{}
+	// database channel not exist
+	cm.SyncShardEpochs("database", models.ShardEpochs{{NumOfShard: 1}})
+
+	dbChannel := NewMockDatabaseChannel(ctrl)
+	cm.databaseChannelMap.Store("database", dbChannel)
+	dbChannel.EXPECT().SetShardEpochs(models.ShardEpochs{{NumOfShard: 1}, {NumOfShard: 2, StartTime: 10}})
+	cm.SyncShardEpochs("database", models.ShardEpochs{{NumOfShard: 1}, {NumOfShard: 2, StartTime: 10}})
+}
+
+func TestChannelManager_DropDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	dirPath := path.Join(os.TempDir(), "test_channel_manager")
+	defer func() {
+		removeDir = fileutil.RemoveDir
+		if err := os.RemoveAll(dirPath); err != nil {
+			t.Error(err)
+		}
+		ctrl.Finish()
+	}()
+
+	replicatorStateReport := NewMockReplicatorStateReport(ctrl)
+	replicatorStateReport.EXPECT().Report(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
+
+	replicationConfig.Dir = dirPath
+	cm := NewChannelManager(replicationConfig, nil, replicatorStateReport)
+	_, err := cm.CreateChannel("database", 3, 0)
+	assert.NoError(t, err)
+	assert.True(t, fileutil.Exist(path.Join(dirPath, "database")))
+	// drop database successfully
+	err = cm.DropDatabase("database")
+	assert.NoError(t, err)
+	assert.False(t, fileutil.Exist(path.Join(dirPath, "database")))
+	err = cm.Write("database", nil)
+	assert.Error(t, err)
+	// remove dir err
+	dbChannel := NewMockDatabaseChannel(ctrl)
+	cm1 := cm.(*channelManager)
+	cm1.databaseChannelMap.Store("database", dbChannel)
+	dbChannel.EXPECT().Stop()
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	err = cm.DropDatabase("database")
+	assert.Error(t, err)
+	_, ok := cm1.getDatabaseChannel("database")
+	assert.False(t, ok)
+	cm.Close()
+}
+
 func TestChannelManager_ReportState(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	dirPath := path.Join(os.TempDir(), "test_channel_manager")
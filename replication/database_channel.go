@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"path"
+	"sort"
 	"sync"
+	"time"
 
 	"github.com/cespare/xxhash"
 	"go.uber.org/atomic"
@@ -13,6 +15,7 @@ import (
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/rpc/proto/field"
 	"github.com/lindb/lindb/series/tag"
@@ -30,25 +33,34 @@ var (
 // for testing
 var (
 	mkdir         = fileutil.MkDirIfNotExist
+	removeDir     = fileutil.RemoveDir
 	createChannel = newChannel
 )
 
 // DatabaseChannel represents the database level replication channel
 type DatabaseChannel interface {
-	// Write writes the metric data into channel's buffer
+	// Write appends the metric data into shard level channels,
+	// ErrQueueFull is returned if the pending data of database exceeds the quota or any target queue is full,
+	// the data appended into the other channels before the queue becomes full isn't rolled back.
 	Write(metricList *field.MetricList) error
 	// CreateChannel creates the shard level replication channel by given shard id
 	CreateChannel(numOfShard, shardID int32) (Channel, error)
+	// SetShardEpochs sets the shard epochs, the data is routed by the num. of shard which is valid for data's timestamp
+	SetShardEpochs(epochs models.ShardEpochs)
 	// ReplicaState returns the replica state
 	ReplicaState() (replicas []models.ReplicaState)
+	// Stop stops all shard level replication channels of database
+	Stop()
 }
 
 type databaseChannel struct {
 	database      string
 	ctx           context.Context
+	cancel        context.CancelFunc
 	cfg           config.ReplicationChannel
 	fct           rpc.ClientStreamFactory
 	numOfShard    atomic.Int32
+	shardEpochs   atomic.Value // models.ShardEpochs
 	shardChannels sync.Map
 	mutex         sync.Mutex
 }
@@ -62,37 +74,178 @@ func newDatabaseChannel(ctx context.Context,
 	if err := mkdir(dirPath); err != nil {
 		return nil, err
 	}
+	c, cancel := context.WithCancel(ctx)
 	ch := &databaseChannel{
 		database: database,
-		ctx:      ctx,
+		ctx:      c,
+		cancel:   cancel,
 		cfg:      cfg,
 		fct:      fct,
 	}
 	ch.numOfShard.Store(numOfShard)
+	ch.shardEpochs.Store(models.ShardEpochs{{NumOfShard: int(numOfShard)}})
 	return ch, nil
 }
 
-// Write writes the metric data into channel's buffer
-func (dc *databaseChannel) Write(metricList *field.MetricList) (err error) {
-	// sharding metrics to shards
-	numOfShard := uint64(dc.numOfShard.Load())
+// shardMetrics represents the metrics routed to the shard level channel
+type shardMetrics struct {
+	shardID int32
+	channel Channel
+	metrics []*field.Metric
+}
+
+// Write appends the metric data into shard level channels. All metrics are routed and the quota of database and
+// the full state of all target channels are checked before appending, which rejects the write early in most cases.
+// but the check isn't atomic with appending, a target queue may become full by the concurrent writes after checking,
+// then the data appended into the previous channels is kept, the points of remaining shards are counted as rejected.
+// ErrQueueFull is returned if the pending data of database exceeds the quota or any target queue is full.
+func (dc *databaseChannel) Write(metricList *field.MetricList) error {
+	shards, err := dc.routeMetrics(metricList)
+	if err != nil {
+		return err
+	}
+	if err := dc.acquireQuota(); err != nil {
+		dc.rejectPoints(shards)
+		return err
+	}
+	for _, shard := range shards {
+		if shard.channel.IsFull() {
+			dc.rejectPoints(shards)
+			return ErrQueueFull
+		}
+	}
+	for idx, shard := range shards {
+		if err := shard.channel.Write(shard.metrics); err != nil {
+			if err == ErrQueueFull {
+				dc.rejectPoints(shards[idx:])
+			} else {
+				log.Error("channel write data error", logger.String("database", dc.database),
+					logger.Int32("shardID", shard.shardID), logger.Error(err))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// routeMetrics shards metrics to the channels by the epoch which is valid for metric's timestamp
+func (dc *databaseChannel) routeMetrics(metricList *field.MetricList) ([]*shardMetrics, error) {
+	epochs := dc.shardEpochs.Load().(models.ShardEpochs)
+	var shards []*shardMetrics
+	shardIdx := make(map[int32]int)
 	for _, metric := range metricList.Metrics {
 		hash := xxhash.Sum64String(tag.Concat(metric.Tags))
 		// set tags hash code for storage side reuse
 		// !!!IMPORTANT: storage side will use this hash for write
 		metric.TagsHash = hash
+		numOfShard := uint64(epochs.NumOfShardAt(metric.Timestamp))
+		if numOfShard == 0 {
+			return nil, errChannelNotFound
+		}
 		shardID := int32(hash % numOfShard)
-		channel, ok := dc.getChannelByShardID(shardID)
+		idx, ok := shardIdx[shardID]
 		if !ok {
-			err = errChannelNotFound
-			// broker error, do not return to client
-			log.Error("channel not found", logger.String("database", dc.database), logger.Int32("shardID", shardID))
-			continue
+			channel, ok := dc.getChannelByShardID(shardID)
+			if !ok {
+				// broker error, do not return to client
+				log.Error("channel not found", logger.String("database", dc.database), logger.Int32("shardID", shardID))
+				return nil, errChannelNotFound
+			}
+			idx = len(shards)
+			shardIdx[shardID] = idx
+			shards = append(shards, &shardMetrics{shardID: shardID, channel: channel})
 		}
-		if err = channel.Write(metric); err != nil {
-			log.Error("channel write data error", logger.String("database", dc.database), logger.Int32("shardID", shardID))
+		shards[idx].metrics = append(shards[idx].metrics, metric)
+	}
+	return shards, nil
+}
+
+// rejectPoints records the num. of points rejected by full queue
+func (dc *databaseChannel) rejectPoints(shards []*shardMetrics) {
+	for _, shard := range shards {
+		rejectedPoints.WithLabelValues(dc.database, shardLabel(shard.shardID)).Add(float64(len(shard.metrics)))
+	}
+}
+
+// acquireQuota checks if the pending data of database exceeds the quota,
+// handles the overflow based on the overflow policy.
+func (dc *databaseChannel) acquireQuota() error {
+	maxPending := dc.cfg.GetMaxPending(dc.database)
+	if maxPending <= 0 {
+		// no quota
+		return nil
+	}
+	depth := dc.depth()
+	if depth < maxPending {
+		return nil
+	}
+	switch dc.cfg.GetOverflowPolicy() {
+	case config.OverflowReject:
+		return ErrQueueFull
+	case config.OverflowDropOldest:
+		dc.dropOldest(depth - maxPending + 1)
+		return nil
+	default:
+		return dc.waitQuota(maxPending)
+	}
+}
+
+// waitQuota blocks the write until the pending data of database is under the quota,
+// ErrQueueFull is returned if timeout.
+func (dc *databaseChannel) waitQuota(maxPending int64) error {
+	timeout := time.Duration(dc.cfg.BlockTimeout)
+	if timeout <= 0 {
+		timeout = defaultBlockTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(blockCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-dc.ctx.Done():
+			return ErrCanceled
+		case <-timer.C:
+			return ErrQueueFull
+		case <-ticker.C:
+			if dc.depth() < maxPending {
+				return nil
+			}
 		}
 	}
+}
+
+// dropOldest drops the oldest num. of pending msg, drops from the deepest channel first.
+func (dc *databaseChannel) dropOldest(num int64) {
+	var channels []Channel
+	dc.shardChannels.Range(func(key, value interface{}) bool {
+		channel, ok := value.(Channel)
+		if ok {
+			channels = append(channels, channel)
+		}
+		return true
+	})
+	sort.Slice(channels, func(i, j int) bool {
+		return channels[i].Depth() > channels[j].Depth()
+	})
+	for _, channel := range channels {
+		if num <= 0 {
+			return
+		}
+		num -= channel.DropOldest(num)
+	}
+}
+
+// depth returns the num. of pending msg of all shard level channels
+func (dc *databaseChannel) depth() (depth int64) {
+	dc.shardChannels.Range(func(key, value interface{}) bool {
+		channel, ok := value.(Channel)
+		if ok {
+			depth += channel.Depth()
+		}
+		return true
+	})
 	return
 }
 
@@ -126,6 +279,36 @@ func (dc *databaseChannel) CreateChannel(numOfShard, shardID int32) (Channel, er
 	return channel, nil
 }
 
+// SetShardEpochs sets the shard epochs, the data is routed by the num. of shard which is valid for data's timestamp
+func (dc *databaseChannel) SetShardEpochs(epochs models.ShardEpochs) {
+	if len(epochs) == 0 {
+		return
+	}
+	dc.mutex.Lock()
+	defer dc.mutex.Unlock()
+
+	latest := epochs[len(epochs)-1]
+	if numOfShard := int32(latest.NumOfShard); numOfShard > dc.numOfShard.Load() {
+		dc.numOfShard.Store(numOfShard)
+	}
+	current := dc.shardEpochs.Load().(models.ShardEpochs)
+	dc.shardEpochs.Store(epochs)
+
+	if current[len(current)-1] == latest {
+		return
+	}
+	now := timeutil.Now()
+	log.Info("apply shard epoch", logger.String("database", dc.database),
+		logger.Any("numOfShard", latest.NumOfShard), logger.Int64("startTime", latest.StartTime),
+		logger.Int64("appliedTime", now))
+	if latest.StartTime > 0 && now > latest.StartTime {
+		// the data between start time and applied time has been routed by old num. of shard
+		log.Warn("shard epoch is applied after its start time, increase switch delay of sharding",
+			logger.String("database", dc.database), logger.Any("numOfShard", latest.NumOfShard),
+			logger.Int64("startTime", latest.StartTime), logger.Int64("lag", now-latest.StartTime))
+	}
+}
+
 // ReplicaState returns the replica state
 func (dc *databaseChannel) ReplicaState() (replicas []models.ReplicaState) {
 	dc.shardChannels.Range(func(key, value interface{}) bool {
@@ -146,6 +329,7 @@ func (dc *databaseChannel) ReplicaState() (replicas []models.ReplicaState) {
 					Pending:      replicator.Pending(),
 					ReplicaIndex: replicator.ReplicaIndex(),
 					AckIndex:     replicator.AckIndex(),
+					Learner:      channel.IsLearner(target),
 				}
 				replicas = append(replicas, replicatorState)
 			}
@@ -155,6 +339,21 @@ func (dc *databaseChannel) ReplicaState() (replicas []models.ReplicaState) {
 	return
 }
 
+// Stop stops all shard level replication channels of database
+func (dc *databaseChannel) Stop() {
+	dc.mutex.Lock()
+	defer dc.mutex.Unlock()
+
+	dc.cancel()
+	dc.shardChannels.Range(func(key, value interface{}) bool {
+		channel, ok := value.(Channel)
+		if ok {
+			channel.Stop()
+		}
+		return true
+	})
+}
+
 // getChannelByShardID gets the replica channel by shard id
 func (dc *databaseChannel) getChannelByShardID(shardID int32) (Channel, bool) {
 	channel, ok := dc.shardChannels.Load(shardID)
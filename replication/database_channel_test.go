@@ -4,16 +4,20 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
+	"github.com/cespare/xxhash"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/ltoml"
 	"github.com/lindb/lindb/pkg/timeutil"
 	"github.com/lindb/lindb/rpc"
 	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/series/tag"
 )
 
 func TestDatabaseChannel_new(t *testing.T) {
@@ -53,6 +57,7 @@ func TestDatabaseChannel_Write(t *testing.T) {
 	ch1 := ch.(*databaseChannel)
 	ch1.shardChannels.Store(int32(0), shardCh)
 
+	shardCh.EXPECT().IsFull().Return(false)
 	shardCh.EXPECT().Write(gomock.Any()).Return(fmt.Errorf("err"))
 	err = ch.Write(&pb.MetricList{Metrics: []*pb.Metric{
 		{
@@ -69,6 +74,53 @@ func TestDatabaseChannel_Write(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestDatabaseChannel_SetShardEpochs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch, err := newDatabaseChannel(context.TODO(), "test-db", replicationConfig, 1, nil)
+	assert.NoError(t, err)
+	ch1 := ch.(*databaseChannel)
+	shardChannels := []*MockChannel{NewMockChannel(ctrl), NewMockChannel(ctrl)}
+	for idx, shardCh := range shardChannels {
+		ch1.shardChannels.Store(int32(idx), shardCh)
+	}
+	// ignore empty epochs
+	ch.SetShardEpochs(nil)
+	assert.Equal(t, models.ShardEpochs{{NumOfShard: 1}}, ch1.shardEpochs.Load())
+
+	// epoch applied after its start time
+	ch.SetShardEpochs(models.ShardEpochs{{NumOfShard: 1}, {NumOfShard: 2, StartTime: 1000}})
+	assert.Equal(t, int32(2), ch1.numOfShard.Load())
+	// same epoch applied again
+	ch.SetShardEpochs(models.ShardEpochs{{NumOfShard: 1}, {NumOfShard: 2, StartTime: 1000}})
+	assert.Equal(t, int32(2), ch1.numOfShard.Load())
+
+	tags := map[string]string{"host": "1.1.1.1"}
+	newShardID := xxhash.Sum64String(tag.Concat(tags)) % 2
+	for _, shardCh := range shardChannels {
+		shardCh.EXPECT().IsFull().Return(false).AnyTimes()
+	}
+	if newShardID == 0 {
+		shardChannels[0].EXPECT().Write(gomock.Len(2)).Return(nil)
+	} else {
+		// data before switch time routes by old num. of shard
+		shardChannels[0].EXPECT().Write(gomock.Len(1)).Return(nil)
+		// data after switch time routes by new num. of shard
+		shardChannels[newShardID].EXPECT().Write(gomock.Len(1)).Return(nil)
+	}
+	err = ch.Write(&pb.MetricList{Metrics: []*pb.Metric{
+		{Name: "cpu", Timestamp: 999, Tags: tags},
+		{Name: "cpu", Timestamp: 1000, Tags: tags},
+	}})
+	assert.NoError(t, err)
+
+	// num. of shard is 0
+	ch1.shardEpochs.Store(models.ShardEpochs{{NumOfShard: 0}})
+	err = ch.Write(&pb.MetricList{Metrics: []*pb.Metric{{Name: "cpu", Timestamp: 999, Tags: tags}}})
+	assert.Equal(t, errChannelNotFound, err)
+}
+
 func TestDatabaseChannel_CreateChannel(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -136,7 +188,136 @@ func TestDatabaseChannel_ReplicaState(t *testing.T) {
 	replicator.EXPECT().Pending().Return(int64(0))
 	replicator.EXPECT().ReplicaIndex().Return(int64(0))
 	replicator.EXPECT().AckIndex().Return(int64(0))
+	shardCh1.EXPECT().IsLearner(models.Node{IP: "2.2.2.2", Port: 12345}).Return(true)
 
 	replicaState := ch.ReplicaState()
 	assert.Len(t, replicaState, 1)
+	assert.True(t, replicaState[0].Learner)
+}
+
+func TestDatabaseChannel_Stop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch, err := newDatabaseChannel(context.TODO(), "test-db", replicationConfig, 3, nil)
+	assert.NoError(t, err)
+	shardCh0 := NewMockChannel(ctrl)
+	ch1 := ch.(*databaseChannel)
+	ch1.shardChannels.Store(int32(0), shardCh0)
+	ch1.shardChannels.Store(int32(1), "test")
+	shardCh0.EXPECT().Stop()
+	ch.Stop()
+	assert.Error(t, ch1.ctx.Err())
+}
+
+func TestDatabaseChannel_Write_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch, err := newDatabaseChannel(context.TODO(), "test-db", replicationConfig, 2, nil)
+	assert.NoError(t, err)
+	ch1 := ch.(*databaseChannel)
+	shardChannels := []*MockChannel{NewMockChannel(ctrl), NewMockChannel(ctrl)}
+	for idx, shardCh := range shardChannels {
+		ch1.shardChannels.Store(int32(idx), shardCh)
+	}
+	var metrics []*pb.Metric
+	for i := 0; i < 10; i++ {
+		metrics = append(metrics, &pb.Metric{Name: "cpu", Timestamp: timeutil.Now(),
+			Tags: map[string]string{"host": fmt.Sprintf("1.1.1.%d", i)}})
+	}
+	metricList := &pb.MetricList{Metrics: metrics}
+	// metrics are written into both shards
+	shards, err := ch1.routeMetrics(metricList)
+	assert.NoError(t, err)
+	assert.Len(t, shards, 2)
+
+	// any target queue is full, rejects the write without writing any metric
+	shardChannels[0].EXPECT().IsFull().Return(false).AnyTimes()
+	shardChannels[1].EXPECT().IsFull().Return(true)
+	assert.Equal(t, ErrQueueFull, ch.Write(metricList))
+
+	// stops writing after failure
+	shardChannels[1].EXPECT().IsFull().Return(false).AnyTimes()
+	shardChannels[shards[0].shardID].EXPECT().Write(shards[0].metrics).Return(ErrQueueFull)
+	assert.Equal(t, ErrQueueFull, ch.Write(metricList))
+
+	// channel not found, rejects the write without writing any metric
+	ch1.shardChannels.Delete(int32(1))
+	assert.Equal(t, errChannelNotFound, ch.Write(metricList))
+}
+
+func TestDatabaseChannel_Write_quota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	cfg := replicationConfig
+	cfg.MaxPendingPerDatabase = 10
+	cfg.DatabaseMaxPending = map[string]int64{"test-db": 20}
+	cfg.BlockTimeout = ltoml.Duration(50 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	ch, err := newDatabaseChannel(ctx, "test-db", cfg, 2, nil)
+	assert.NoError(t, err)
+	ch1 := ch.(*databaseChannel)
+	shardCh1 := NewMockChannel(ctrl)
+	shardCh2 := NewMockChannel(ctrl)
+	ch1.shardChannels.Store(int32(0), shardCh1)
+	ch1.shardChannels.Store(int32(1), shardCh2)
+	metrics := &pb.MetricList{Metrics: []*pb.Metric{{Name: "cpu", Timestamp: timeutil.Now()}}}
+	writeCh := []*MockChannel{shardCh1, shardCh2}[xxhash.Sum64String(tag.Concat(nil))%2]
+	writeCh.EXPECT().IsFull().Return(false).AnyTimes()
+
+	// under quota
+	shardCh1.EXPECT().Depth().Return(int64(10))
+	shardCh2.EXPECT().Depth().Return(int64(9))
+	writeCh.EXPECT().Write(gomock.Any()).Return(nil)
+	assert.NoError(t, ch.Write(metrics))
+
+	// reject
+	ch1.cfg.OverflowPolicy = config.OverflowReject
+	shardCh1.EXPECT().Depth().Return(int64(10))
+	shardCh2.EXPECT().Depth().Return(int64(10))
+	assert.Equal(t, ErrQueueFull, ch.Write(metrics))
+
+	// drop oldest, drops from the deepest channel first
+	ch1.cfg.OverflowPolicy = config.OverflowDropOldest
+	shardCh1.EXPECT().Depth().Return(int64(8)).AnyTimes()
+	shardCh2.EXPECT().Depth().Return(int64(14)).AnyTimes()
+	gomock.InOrder(
+		shardCh2.EXPECT().DropOldest(int64(3)).Return(int64(1)),
+		shardCh1.EXPECT().DropOldest(int64(2)).Return(int64(2)),
+	)
+	writeCh.EXPECT().Write(gomock.Any()).Return(nil)
+	assert.NoError(t, ch.Write(metrics))
+
+	// block timeout
+	ch1.cfg.OverflowPolicy = config.OverflowBlock
+	assert.Equal(t, ErrQueueFull, ch.Write(metrics))
+	// ctx canceled
+	cancel()
+	assert.Equal(t, ErrCanceled, ch.Write(metrics))
+}
+
+func TestDatabaseChannel_waitQuota(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch, err := newDatabaseChannel(context.TODO(), "test-db", replicationConfig, 1, nil)
+	assert.NoError(t, err)
+	ch1 := ch.(*databaseChannel)
+	shardCh := NewMockChannel(ctrl)
+	ch1.shardChannels.Store(int32(0), shardCh)
+
+	// replicated, pending data under quota
+	gomock.InOrder(
+		shardCh.EXPECT().Depth().Return(int64(10)),
+		shardCh.EXPECT().Depth().Return(int64(9)),
+	)
+	assert.NoError(t, ch1.waitQuota(10))
+	// no quota
+	ch1.cfg.MaxPendingPerDatabase = 0
+	shardCh.EXPECT().IsFull().Return(false)
+	shardCh.EXPECT().Write(gomock.Any()).Return(nil)
+	assert.NoError(t, ch.Write(&pb.MetricList{Metrics: []*pb.Metric{{Name: "cpu", Timestamp: timeutil.Now()}}}))
 }
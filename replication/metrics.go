@@ -0,0 +1,58 @@
+package replication
+
+import (
+	"strconv"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lindb/lindb/monitoring"
+)
+
+var (
+	queueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "replication_queue_depth",
+			Help: "Number of pending msg which isn't replicated to all replicas in replication queue.",
+		},
+		[]string{"db", "shard"},
+	)
+	rejectedPoints = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "replication_rejected_points",
+			Help: "Number of points rejected because the replication queue is full.",
+		},
+		[]string{"db", "shard"},
+	)
+	droppedMessages = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "replication_dropped_messages",
+			Help: "Number of the oldest pending msg dropped by drop-oldest overflow policy.",
+		},
+		[]string{"db", "shard"},
+	)
+	appendFailures = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "replication_append_failures",
+			Help: "Number of msg which cannot be appended into replication queue.",
+		},
+		[]string{"db", "shard"},
+	)
+)
+
+func init() {
+	monitoring.BrokerRegistry.MustRegister(queueDepth, rejectedPoints, droppedMessages, appendFailures)
+}
+
+// shardLabel returns the label value of shard id
+func shardLabel(shardID int32) string {
+	return strconv.Itoa(int(shardID))
+}
+
+// deleteMetrics deletes the metrics of shard level replication channel after the channel stopped
+func deleteMetrics(database string, shardID int32) {
+	shard := shardLabel(shardID)
+	queueDepth.DeleteLabelValues(database, shard)
+	rejectedPoints.DeleteLabelValues(database, shard)
+	droppedMessages.DeleteLabelValues(database, shard)
+	appendFailures.DeleteLabelValues(database, shard)
+}
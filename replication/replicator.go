@@ -2,6 +2,7 @@ package replication
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"time"
 
@@ -22,6 +23,9 @@ const (
 	unaryRPCTimeout = time.Second * 3
 )
 
+// errStopped is returned when the replicator is stopped
+var errStopped = errors.New("replicator is stopped")
+
 // Replicator represents a task to replicate data to target.
 type Replicator interface {
 	// Target returns the target target for replication.
@@ -36,7 +40,7 @@ type Replicator interface {
 	ReplicaIndex() int64
 	// AckIndex returns the index of message replica ack
 	AckIndex() int64
-	// Stop stops the replication task.
+	// Stop stops the replication task, returns after the underlying fanOut isn't used by the task.
 	Stop()
 }
 
@@ -55,6 +59,8 @@ type replicator struct {
 	serviceClient storage.WriteServiceClient
 	// lock to protect clients
 	lock4client sync.RWMutex
+	// lock to protect the underlying fanOut, which isn't used after stopped
+	lock4fo sync.RWMutex
 	// false -> running, true -> stopped
 	stopped atomic.Bool
 	// false -> notReady, true -> ready
@@ -112,8 +118,12 @@ func (r *replicator) AckIndex() int64 {
 	return r.fo.TailSeq()
 }
 
-// Stop stops the replication task.
+// Stop stops the replication task, waits the running access of fanOut completed,
+// so that the underlying queue can be closed after stopped.
 func (r *replicator) Stop() {
+	r.lock4fo.Lock()
+	defer r.lock4fo.Unlock()
+
 	r.stopped.Store(true)
 }
 
@@ -171,7 +181,7 @@ func (r *replicator) recvLoop() {
 		// ackSeq could be nil, means no ack signal
 		ack, ok := resp.Ack.(*storage.WriteResponse_AckSeq)
 		if ok {
-			r.fo.Ack(ack.AckSeq)
+			r.ack(ack.AckSeq)
 		}
 	}
 }
@@ -204,10 +214,13 @@ func (r *replicator) initClient() {
 		// try to reset fanOut headSeq, if success, consume from new headSeq,
 		// if fail, try to reset remote headSeq.
 		r.logger.Info("recvLoop try to set fanOut head seq", logger.Int64("headSeq", nextSeq))
-		if err := r.fo.SetHeadSeq(nextSeq); err != nil {
+		foHeadSeq, err := r.setHeadSeq(nextSeq)
+		if err == errStopped {
+			return
+		}
+		if err != nil {
 			r.logger.Error("recvLoop reset fanOut head seq error", logger.Error(err))
 
-			foHeadSeq := r.fo.HeadSeq()
 			r.logger.Info("recvLoop try to set remote storage head seq", logger.Int64("headSeq", foHeadSeq))
 			if err := r.resetRemoteSeq(foHeadSeq); err != nil {
 				r.logger.Error("recvLoop reset remote head seq error", logger.Error(err))
@@ -230,6 +243,32 @@ func (r *replicator) initClient() {
 	r.setReady(true)
 }
 
+// setHeadSeq sets the head seq of fanOut, returns the head seq of fanOut if fail,
+// returns errStopped if replicator is stopped.
+func (r *replicator) setHeadSeq(seq int64) (int64, error) {
+	r.lock4fo.RLock()
+	defer r.lock4fo.RUnlock()
+
+	if r.isStopped() {
+		return -1, errStopped
+	}
+	if err := r.fo.SetHeadSeq(seq); err != nil {
+		return r.fo.HeadSeq(), err
+	}
+	return seq, nil
+}
+
+// ack acks the replicated seq of fanOut if replicator isn't stopped
+func (r *replicator) ack(seq int64) {
+	r.lock4fo.RLock()
+	defer r.lock4fo.RUnlock()
+
+	if r.isStopped() {
+		return
+	}
+	r.fo.Ack(seq)
+}
+
 func (r *replicator) remoteNextSeq() (int64, error) {
 	nextReq := &storage.NextSeqRequest{
 		Database: r.database,
@@ -290,29 +329,43 @@ func (r *replicator) sendLoop() {
 			continue
 		}
 
-		replicas := r.consumeBatch(&reusedReplicas)
-		// no more replicas
-		if len(replicas) == 0 {
+		if !r.sendBatch(&reusedReplicas) {
+			// no more replicas
 			time.Sleep(10 * time.Millisecond)
-			continue
-		}
-		wr := &storage.WriteRequest{
-			Replicas: replicas,
 		}
+	}
+}
 
-		r.logger.Debug("send replicas",
-			logger.Int64("begin", replicas[0].Seq),
-			logger.Int64("end", replicas[len(replicas)-1].Seq))
+// sendBatch consumes a batch of replicas then sends them to rpc stream, returns false if no more replicas,
+// the fanOut is locked until sent, because the data of replicas is released after the queue closed.
+func (r *replicator) sendBatch(repPointer *[]*storage.Replica) bool {
+	r.lock4fo.RLock()
+	defer r.lock4fo.RUnlock()
 
-		// recvLoop may change streamClient
-		r.lock4client.RLock()
-		cli := r.streamClient
-		r.lock4client.RUnlock()
-		if err := cli.Send(wr); err != nil {
-			r.logger.Error("sendLoop write request error", logger.Error(err))
-			r.setReady(false)
-		}
+	if r.isStopped() {
+		return true
+	}
+	replicas := r.consumeBatch(repPointer)
+	if len(replicas) == 0 {
+		return false
+	}
+	wr := &storage.WriteRequest{
+		Replicas: replicas,
+	}
+
+	r.logger.Debug("send replicas",
+		logger.Int64("begin", replicas[0].Seq),
+		logger.Int64("end", replicas[len(replicas)-1].Seq))
+
+	// recvLoop may change streamClient
+	r.lock4client.RLock()
+	cli := r.streamClient
+	r.lock4client.RUnlock()
+	if err := cli.Send(wr); err != nil {
+		r.logger.Error("sendLoop write request error", logger.Error(err))
+		r.setReady(false)
 	}
+	return true
 }
 
 // consumeBatch consumes a batch of Replicas(limited by batchReplicaSize), the input slice is reused.
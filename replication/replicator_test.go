@@ -472,6 +472,46 @@ func TestReplicator_Ack(t *testing.T) {
 	close(done1)
 }
 
+func TestReplicator_Stop(t *testing.T) {
+	ctl := gomock.NewController(t)
+	defer ctl.Finish()
+
+	mockServiceClient := storagemock.NewMockWriteServiceClient(ctl)
+	mockServiceClient.EXPECT().Next(gomock.Any(), gomock.Any()).Return(&storage.NextSeqResponse{
+		Seq: 5,
+	}, nil).AnyTimes()
+
+	done1 := make(chan struct{})
+	mockClientStream := storagemock.NewMockWriteService_WriteClient(ctl)
+	mockClientStream.EXPECT().Recv().DoAndReturn(func() (*storage.WriteResponse, error) {
+		<-done1
+		return &storage.WriteResponse{
+			Ack: &storage.WriteResponse_AckSeq{AckSeq: int64(1000)},
+		}, nil
+	})
+
+	mockFct := rpc.NewMockClientStreamFactory(ctl)
+	mockFct.EXPECT().CreateWriteServiceClient(node).Return(mockServiceClient, nil).AnyTimes()
+	mockFct.EXPECT().LogicNode().Return(node).AnyTimes()
+	mockFct.EXPECT().CreateWriteClient(database, shardID, node).Return(mockClientStream, nil)
+	mockFanOut := queue.NewMockFanOut(ctl)
+	mockFanOut.EXPECT().SetHeadSeq(int64(5)).Return(nil)
+	var rep Replicator
+	var stopped atomic.Bool
+	mockFanOut.EXPECT().Consume().DoAndReturn(func() int64 {
+		// fanOut isn't used after stopped
+		assert.False(t, stopped.Load())
+		return queue.SeqNoNewMessageAvailable
+	}).AnyTimes()
+	rep = newReplicator(node, database, shardID, mockFanOut, mockFct)
+	time.Sleep(100 * time.Millisecond)
+	rep.Stop()
+	stopped.Store(true)
+	// ack after stopped is ignored
+	close(done1)
+	time.Sleep(100 * time.Millisecond)
+}
+
 func TestReplicator_Loop_panic(t *testing.T) {
 	ctl := gomock.NewController(t)
 	defer ctl.Finish()
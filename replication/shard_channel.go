@@ -7,6 +7,8 @@ import (
 	"sync"
 	"time"
 
+	"go.uber.org/atomic"
+
 	"github.com/lindb/lindb/config"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/logger"
@@ -28,23 +30,41 @@ type Channel interface {
 	Database() string
 	// ShardID returns the shardID attribution.
 	ShardID() int32
-	// Startup starts the channel internal goroutine worker which consumes chan data and writes wal
+	// Startup starts the channel internal goroutine worker which syncs the queue
 	Startup()
-	// Write writes the data into the channel, ErrCanceled is returned when the channel is canceled before
-	// data is wrote successfully.
+	// Write appends the metrics into the queue, returns after the data is appended, so that the failure of appending
+	// is responded to the client. ErrCanceled is returned when the channel is canceled,
+	// ErrQueueFull is returned when the queue exceeds the data size limit.
 	// Concurrent safe.
-	Write(metric *field.Metric) error
+	Write(metrics []*field.Metric) error
+	// IsFull returns if the queue exceeds the data size limit, the write is rejected until the queue is truncated.
+	IsFull() bool
+	// Depth returns the num. of pending msg which isn't replicated to all replicas.
+	Depth() int64
+	// DropOldest drops the oldest num. of pending msg, returns the num. of dropped msg.
+	// The replicas lose the dropped data, the sequence of replica is reset when replicating the next msg.
+	DropOldest(num int64) int64
 	// GetOrCreateReplicator get a existed or creates a new replicator for target.
 	// Concurrent safe.
 	GetOrCreateReplicator(target models.Node) (Replicator, error)
 	// Nodes returns all the target nodes for replication.
 	Targets() []models.Node
+	// SyncTargets stops and removes the replicators whose target isn't in replicas or learners,
+	// also removes the fanOut of removed replicator, so that the queue can be truncated.
+	// learners are the new replicas which are catching up data, the data isn't queryable from learner.
+	// Concurrent safe.
+	SyncTargets(replicas, learners []models.Node)
+	// IsLearner returns if the target is learner which is catching up data.
+	IsLearner(target models.Node) bool
+	// Stop stops the channel, waits the pending data written into queue, then closes the underlying queue.
+	Stop()
 }
 
 // channel implements Channel.
 type channel struct {
 	// context to close channel
 	ctx     context.Context
+	cancel  context.CancelFunc
 	dirPath string
 	// factory to get WriteClient
 	fct      rpc.ClientStreamFactory
@@ -52,26 +72,25 @@ type channel struct {
 	shardID  int32
 	// underlying storage for written data
 	q queue.FanOutQueue
-	// chanel to convert multiple goroutine write to single goroutine write to FanOutQueue
-	ch chan []byte
+	// true if queue exceeds the data size limit, rejects the write until the queue is synced
+	full atomic.Bool
 
 	chunk Chunk // buffer current write metric for compress
 
-	// last flush time
-	lastFlushTime time.Time
-	// interval for check flush
+	// interval for sync queue
 	checkFlushInterval time.Duration
-	// interval for flush
-	flushInterval time.Duration
-	//buffer size limit for batch bytes before append to queue
-	bufferSizeLimit int
 
 	// target -> replicator map
 	replicatorMap sync.Map
+	// learner targets which are catching up data
+	learners map[models.Node]struct{}
 	// lock to protect replicatorMap
 	lock4map   sync.RWMutex
 	lock4write sync.Mutex
 
+	running atomic.Bool
+	stopped chan struct{} // closed after sync routine exited
+
 	logger *logger.Logger
 }
 
@@ -95,19 +114,19 @@ func newChannel(
 		bufferSize = cfg.BufferSize
 	}
 
+	ctx, cancel := context.WithCancel(cxt)
 	c := &channel{
-		ctx:                cxt,
+		ctx:                ctx,
+		cancel:             cancel,
 		dirPath:            dirPath,
 		fct:                fct,
 		database:           database,
 		shardID:            shardID,
 		q:                  q,
-		ch:                 make(chan []byte, 2),
 		chunk:              newChunk(bufferSize),
-		lastFlushTime:      time.Now(),
 		checkFlushInterval: cfg.CheckFlushInterval.Duration(),
-		flushInterval:      cfg.FlushInterval.Duration(),
-		bufferSizeLimit:    cfg.BufferSizeInBytes(),
+		stopped:            make(chan struct{}),
+		learners:           make(map[models.Node]struct{}),
 		logger:             logger.GetLogger("replication", "Channel"),
 	}
 
@@ -124,10 +143,10 @@ func (c *channel) ShardID() int32 {
 	return c.shardID
 }
 
-// Startup starts the channel internal goroutine worker which consumes chan data and writes wal
+// Startup starts the channel internal goroutine worker which syncs the queue
 func (c *channel) Startup() {
-	c.initAppendTask()
-	c.watchClose()
+	c.running.Store(true)
+	c.initSyncTask()
 }
 
 // GetOrCreateReplicator get a existed or creates a new replicator for target.
@@ -165,125 +184,182 @@ func (c *channel) Targets() []models.Node {
 	return nodes
 }
 
-// Write writes the data into the channel, ErrCanceled is returned when the ctx is canceled before
-// data is wrote successfully.
-// Concurrent safe.
-func (c *channel) Write(metric *field.Metric) error {
-	c.lock4write.Lock()
-	defer c.lock4write.Unlock()
-
-	c.chunk.Append(metric)
+// SyncTargets stops and removes the replicators whose target isn't in replicas or learners,
+// also removes the fanOut of removed replicator, so that the queue can be truncated.
+func (c *channel) SyncTargets(replicas, learners []models.Node) {
+	c.lock4map.Lock()
+	defer c.lock4map.Unlock()
 
-	if c.chunk.IsFull() {
-		data, err := c.chunk.MarshalBinary()
-		if err != nil {
-			return err
-		}
-		if len(data) == 0 {
-			return nil
+	targets := make(map[models.Node]struct{})
+	for _, replica := range replicas {
+		targets[replica] = struct{}{}
+	}
+	c.learners = make(map[models.Node]struct{})
+	for _, learner := range learners {
+		targets[learner] = struct{}{}
+		c.learners[learner] = struct{}{}
+	}
+	c.replicatorMap.Range(func(key, value interface{}) bool {
+		target, _ := key.(models.Node)
+		if _, ok := targets[target]; ok {
+			return true
 		}
-		select {
-		case c.ch <- data:
-			return nil
-		case <-c.ctx.Done():
-			return ErrCanceled
+		rep, _ := value.(Replicator)
+		rep.Stop()
+		c.replicatorMap.Delete(target)
+		if err := c.q.RemoveFanOut(target.Indicator()); err != nil {
+			c.logger.Error("remove fanOut of replicator error", logger.String("database", c.database),
+				logger.Int32("shardID", c.shardID), logger.String("target", target.Indicator()), logger.Error(err))
+			return true
 		}
+		c.logger.Info("remove replicator successfully", logger.String("database", c.database),
+			logger.Int32("shardID", c.shardID), logger.String("target", target.Indicator()))
+		return true
+	})
+}
+
+// IsLearner returns if the target is learner which is catching up data.
+func (c *channel) IsLearner(target models.Node) bool {
+	c.lock4map.RLock()
+	defer c.lock4map.RUnlock()
+
+	_, ok := c.learners[target]
+	return ok
+}
+
+// Stop stops the channel, waits the writing data appended into queue, then closes the underlying queue.
+func (c *channel) Stop() {
+	c.cancel()
+	if c.running.Load() {
+		<-c.stopped
 	}
-	return nil
+	// stop replicators synchronously, the fanOuts of queue aren't used by replicators after stopped
+	c.stopReplicators()
+	c.lock4write.Lock()
+	defer c.lock4write.Unlock()
+
+	c.q.Close()
+	deleteMetrics(c.database, c.shardID)
 }
 
-// initAppendTask starts a goroutine to consume data from ch and batch append to q.
-func (c *channel) initAppendTask() {
-	go func() {
-		// handle write wal loop
-		c.writeWAL()
-		c.writePendingBeforeClose()
-		c.logger.Info("close channel append routine", logger.String("database", c.Database()), logger.Int32("shardID", c.ShardID()))
-	}()
+// Depth returns the num. of pending msg which isn't replicated to all replicas.
+func (c *channel) Depth() int64 {
+	return c.q.Depth()
 }
 
-func (c *channel) writePendingBeforeClose() {
-	var wait sync.WaitGroup
-	wait.Add(1)
-	go func() {
-		// try to drain data from chan
-		for data := range c.ch {
-			err := c.q.Put(data)
-			if err != nil {
-				c.logger.Error("append to queue err", logger.Error(err))
-			}
-		}
-		wait.Done()
-	}()
-	close(c.ch)
-	wait.Wait()
-	// flush chunk pending data if chunk not empty
-	if !c.chunk.IsEmpty() {
-		// flush chunk pending data if chunk not empty
-		c.flushChunk()
+// DropOldest drops the oldest num. of pending msg, returns the num. of dropped msg.
+func (c *channel) DropOldest(num int64) int64 {
+	dropped := c.q.DropOldest(num)
+	if dropped > 0 {
+		droppedMessages.WithLabelValues(c.database, shardLabel(c.shardID)).Add(float64(dropped))
+		c.logger.Warn("drop the oldest pending msg", logger.String("database", c.database),
+			logger.Int32("shardID", c.shardID), logger.Int64("dropped", dropped))
 	}
+	return dropped
+}
+
+// IsFull returns if the queue exceeds the data size limit, the write is rejected until the queue is truncated.
+func (c *channel) IsFull() bool {
+	return c.full.Load()
 }
 
-func (c *channel) checkFlush() {
-	now := time.Now()
-	if now.After(c.lastFlushTime.Add(c.flushInterval)) {
-		c.lock4write.Lock()
-		defer c.lock4write.Unlock()
+// Write appends the metrics into the queue, returns after the data is appended, so that the failure of appending
+// is responded to the client. ErrCanceled is returned when the channel is canceled,
+// ErrQueueFull is returned when the queue exceeds the data size limit.
+// Concurrent safe.
+func (c *channel) Write(metrics []*field.Metric) error {
+	if c.full.Load() {
+		return ErrQueueFull
+	}
+	c.lock4write.Lock()
+	defer c.lock4write.Unlock()
 
-		if !c.chunk.IsEmpty() {
-			c.flushChunk()
+	if c.ctx.Err() != nil {
+		return ErrCanceled
+	}
+	for _, metric := range metrics {
+		c.chunk.Append(metric)
+		if c.chunk.IsFull() {
+			if err := c.flushChunk(); err != nil {
+				return err
+			}
 		}
-		c.lastFlushTime = now
 	}
+	return c.flushChunk()
 }
 
-// flush flushes the chunk data and appends data into queue
-func (c *channel) flushChunk() {
+// initSyncTask starts a goroutine to sync the queue periodically.
+func (c *channel) initSyncTask() {
+	go func() {
+		c.syncLoop()
+		c.logger.Info("close channel sync routine", logger.String("database", c.Database()), logger.Int32("shardID", c.ShardID()))
+		close(c.stopped)
+	}()
+}
+
+// flushChunk marshals the chunk data and appends data into queue
+func (c *channel) flushChunk() error {
 	data, err := c.chunk.MarshalBinary()
 	if err != nil {
 		c.logger.Error("chunk marshal err", logger.Error(err))
-		return
+		return err
 	}
 	if len(data) == 0 {
-		return
+		return nil
 	}
-	err = c.q.Put(data)
-	if err != nil {
-		c.logger.Error("append to queue err", logger.Error(err))
+	return c.append(data)
+}
+
+// append appends the data into queue, if queue exceeds the data size limit,
+// rejects the write until the queue is truncated by syncing.
+func (c *channel) append(data []byte) error {
+	err := c.q.Put(data)
+	if err == nil {
+		return nil
+	}
+	if err == queue.ErrExceedingTotalSizeLimit {
+		if c.full.CAS(false, true) {
+			c.logger.Warn("queue exceeds the data size limit, reject the write", logger.String("database", c.database),
+				logger.Int32("shardID", c.shardID))
+		}
+		return ErrQueueFull
 	}
+	// cannot be retried, like message too large
+	appendFailures.WithLabelValues(c.database, shardLabel(c.shardID)).Inc()
+	c.logger.Error("append to queue err", logger.String("database", c.database),
+		logger.Int32("shardID", c.shardID), logger.Error(err))
+	return err
 }
 
-// writeWAL consumes data from chan, then appends the data into queue
-func (c *channel) writeWAL() {
-	// on avg 2 * limit could avoid buffer grow
+// syncQueue acks the msg replicated to all replicas, so that the expired pages of queue can be removed,
+// then updates the queue depth and accepts the write again, the write is rejected by appending if queue is still full.
+func (c *channel) syncQueue() {
+	c.q.Sync()
+	queueDepth.WithLabelValues(c.database, shardLabel(c.shardID)).Set(float64(c.q.Depth()))
+	c.full.Store(false)
+}
+
+// syncLoop syncs the queue periodically until the channel is canceled
+func (c *channel) syncLoop() {
 	ticker := time.NewTicker(c.checkFlushInterval)
 	defer ticker.Stop()
 	for {
 		select {
 		case <-c.ctx.Done():
 			return
-		case data := <-c.ch:
-			err := c.q.Put(data)
-			if err != nil {
-				c.logger.Error("append to queue err", logger.Error(err))
-			}
 		case <-ticker.C:
-			// check
-			c.checkFlush()
+			c.syncQueue()
 		}
 	}
 }
 
-// watchClose waits on the context done then close the ch.
-func (c *channel) watchClose() {
-	go func() {
-		<-c.ctx.Done()
-		c.lock4map.RLock()
-		defer c.lock4map.RUnlock()
-		c.replicatorMap.Range(func(key, value interface{}) bool {
-			rep, _ := value.(Replicator)
-			rep.Stop()
-			return true
-		})
-	}()
+// stopReplicators stops all replicators, returns after the replicators don't use the fanOuts of queue.
+func (c *channel) stopReplicators() {
+	c.lock4map.RLock()
+	defer c.lock4map.RUnlock()
+	c.replicatorMap.Range(func(key, value interface{}) bool {
+		rep, _ := value.(Replicator)
+		rep.Stop()
+		return true
+	})
 }
@@ -63,66 +63,51 @@ func TestChannel_GetOrCreateReplicator(t *testing.T) {
 	time.Sleep(300 * time.Millisecond)
 }
 
-func TestChannel_Write(t *testing.T) {
+func TestChannel_SyncTargets(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ctx, cancel := context.WithCancel(context.TODO())
-	ch, err := newChannel(ctx, replicationConfig, "database", 1, nil)
+	ch, err := newChannel(context.TODO(), replicationConfig, "database", 1, nil)
 	assert.NoError(t, err)
-	ch.Startup()
-
 	ch1 := ch.(*channel)
-	fanout := queue.NewMockFanOutQueue(ctrl)
-	fanout.EXPECT().Put(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
-	ch1.q = fanout
-
-	metric := &pb.Metric{
-		Name:      "cpu",
-		Timestamp: timeutil.Now(),
-		Fields: []*pb.Field{{
-			Name:  "f1",
-			Type:  pb.FieldType_Sum,
-			Value: 1.0,
-		}},
-	}
-	err = ch.Write(metric)
-	assert.NoError(t, err)
-	err = ch.Write(metric)
-	assert.NoError(t, err)
+	q := queue.NewMockFanOutQueue(ctrl)
+	ch1.q = q
+	target1 := models.Node{IP: "1.1.1.1", Port: 12345}
+	target2 := models.Node{IP: "1.1.1.2", Port: 12345}
+	target3 := models.Node{IP: "1.1.1.3", Port: 12345}
+	rep1 := NewMockReplicator(ctrl)
+	rep2 := NewMockReplicator(ctrl)
+	rep3 := NewMockReplicator(ctrl)
+	ch1.replicatorMap.Store(target1, rep1)
+	ch1.replicatorMap.Store(target2, rep2)
+	ch1.replicatorMap.Store(target3, rep3)
 
-	cancel()
-	time.Sleep(time.Millisecond * 600)
+	// target3 is removed, target2 is learner
+	rep3.EXPECT().Stop()
+	q.EXPECT().RemoveFanOut(target3.Indicator()).Return(fmt.Errorf("err"))
+	ch.SyncTargets([]models.Node{target1}, []models.Node{target2})
+	assert.Len(t, ch.Targets(), 2)
+	assert.False(t, ch.IsLearner(target1))
+	assert.True(t, ch.IsLearner(target2))
 
-	ch, err = newChannel(ctx, replicationConfig, "database", 1, nil)
-	assert.NoError(t, err)
-	ch1 = ch.(*channel)
-	// ignore data, after closed
-	chunk := NewMockChunk(ctrl)
-	ch1.chunk = chunk
-	// make sure chan is full
-	ch1.ch <- []byte{1, 2}
-	ch1.ch <- []byte{1, 2}
-	chunk.EXPECT().Append(gomock.Any())
-	chunk.EXPECT().IsFull().Return(true)
-	chunk.EXPECT().MarshalBinary().Return([]byte{1, 2, 3}, nil)
-	err = ch.Write(metric)
-	assert.Error(t, err)
-	time.Sleep(time.Millisecond * 500)
+	// learner promoted, target1 is removed
+	rep1.EXPECT().Stop()
+	q.EXPECT().RemoveFanOut(target1.Indicator()).Return(nil)
+	ch.SyncTargets([]models.Node{target2}, nil)
+	assert.Equal(t, []models.Node{target2}, ch.Targets())
+	assert.False(t, ch.IsLearner(target2))
 }
 
-func TestChannel_checkFlush(t *testing.T) {
+func TestChannel_Write(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	ctx, cancel := context.WithCancel(context.TODO())
 	ch, err := newChannel(ctx, replicationConfig, "database", 1, nil)
 	assert.NoError(t, err)
-	ch.Startup()
 
 	ch1 := ch.(*channel)
 	fanout := queue.NewMockFanOutQueue(ctrl)
-	fanout.EXPECT().Put(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
 	ch1.q = fanout
 
 	metric := &pb.Metric{
@@ -134,38 +119,45 @@ func TestChannel_checkFlush(t *testing.T) {
 			Value: 1.0,
 		}},
 	}
-	err = ch.Write(metric)
+	// appends a msg when chunk is full, then appends the rest
+	fanout.EXPECT().Put(gomock.Any()).Return(nil).Times(2)
+	err = ch.Write([]*pb.Metric{metric, metric, metric})
+	assert.NoError(t, err)
+	// append failure is returned
+	fanout.EXPECT().Put(gomock.Any()).Return(fmt.Errorf("err"))
+	err = ch.Write([]*pb.Metric{metric, metric, metric})
+	assert.Error(t, err)
+	// no data
+	err = ch.Write(nil)
 	assert.NoError(t, err)
 
-	time.Sleep(time.Second)
+	// channel canceled
 	cancel()
-	time.Sleep(300 * time.Millisecond)
+	err = ch.Write([]*pb.Metric{metric})
+	assert.Equal(t, ErrCanceled, err)
 }
 
-func TestChannel_write_pending_before_close(t *testing.T) {
+func TestChannel_sync(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	ch, err := newChannel(context.TODO(), replicationConfig, "database", 1, nil)
-	assert.NoError(t, err)
-	metric := &pb.Metric{
-		Name:      "cpu",
-		Timestamp: timeutil.Now(),
-		Fields: []*pb.Field{{
-			Name:  "f1",
-			Type:  pb.FieldType_Sum,
-			Value: 1.0,
-		}},
-	}
-	err = ch.Write(metric)
+	ctx, cancel := context.WithCancel(context.TODO())
+	ch, err := newChannel(ctx, replicationConfig, "database", 1, nil)
 	assert.NoError(t, err)
 
 	ch1 := ch.(*channel)
-	ch1.ch <- []byte{1, 2, 3}
-	fanOut := queue.NewMockFanOutQueue(ctrl)
-	fanOut.EXPECT().Put(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
-	ch1.q = fanOut
-	ch1.writePendingBeforeClose()
+	fanout := queue.NewMockFanOutQueue(ctrl)
+	fanout.EXPECT().Sync().AnyTimes()
+	fanout.EXPECT().Depth().Return(int64(0)).AnyTimes()
+	fanout.EXPECT().Close()
+	ch1.q = fanout
+	ch1.full.Store(true)
+	ch.Startup()
+
+	time.Sleep(300 * time.Millisecond)
+	assert.False(t, ch.IsFull())
+	cancel()
+	ch.Stop()
 }
 
 func TestChannel_chunk_marshal_err(t *testing.T) {
@@ -190,17 +182,102 @@ func TestChannel_chunk_marshal_err(t *testing.T) {
 	chunk.EXPECT().Append(gomock.Any())
 	chunk.EXPECT().IsFull().Return(true)
 	chunk.EXPECT().MarshalBinary().Return(nil, fmt.Errorf("err"))
-	err = ch.Write(metric)
+	err = ch.Write([]*pb.Metric{metric})
+	assert.Error(t, err)
+
+	chunk.EXPECT().Append(gomock.Any())
+	chunk.EXPECT().IsFull().Return(false)
+	chunk.EXPECT().MarshalBinary().Return(nil, fmt.Errorf("err"))
+	err = ch.Write([]*pb.Metric{metric})
 	assert.Error(t, err)
 
 	chunk.EXPECT().Append(gomock.Any())
 	chunk.EXPECT().IsFull().Return(true)
-	chunk.EXPECT().MarshalBinary().Return(nil, nil)
-	err = ch.Write(metric)
+	chunk.EXPECT().MarshalBinary().Return(nil, nil).Times(2)
+	err = ch.Write([]*pb.Metric{metric})
 	assert.NoError(t, err)
+}
 
-	chunk.EXPECT().MarshalBinary().Return(nil, fmt.Errorf("err"))
-	ch1.flushChunk()
-	chunk.EXPECT().MarshalBinary().Return(nil, nil)
-	ch1.flushChunk()
+func TestChannel_Stop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch, err := newChannel(context.TODO(), replicationConfig, "database", 1, nil)
+	assert.NoError(t, err)
+	fanout := queue.NewMockFanOutQueue(ctrl)
+	ch1 := ch.(*channel)
+	ch1.q = fanout
+	// channel not startup
+	fanout.EXPECT().Close()
+	ch.Stop()
+
+	ch, err = newChannel(context.TODO(), replicationConfig, "database", 1, nil)
+	assert.NoError(t, err)
+	ch1 = ch.(*channel)
+	ch1.q = fanout
+	ch.Startup()
+	// replicators are stopped before closing queue
+	rep := NewMockReplicator(ctrl)
+	ch1.replicatorMap.Store(models.Node{IP: "1.1.1.1", Port: 9000}, rep)
+	gomock.InOrder(
+		rep.EXPECT().Stop(),
+		fanout.EXPECT().Close(),
+	)
+	ch.Stop()
+	select {
+	case <-ch1.stopped:
+	default:
+		t.Fatal("sync routine not exit after channel stopped")
+	}
+}
+
+func TestChannel_queue_full(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch, err := newChannel(context.TODO(), replicationConfig, "database", 1, nil)
+	assert.NoError(t, err)
+	ch1 := ch.(*channel)
+	fanout := queue.NewMockFanOutQueue(ctrl)
+	ch1.q = fanout
+
+	// queue exceeds the data size limit, rejects the write
+	fanout.EXPECT().Put([]byte{1}).Return(queue.ErrExceedingTotalSizeLimit)
+	assert.Equal(t, ErrQueueFull, ch1.append([]byte{1}))
+	assert.True(t, ch.IsFull())
+	assert.Equal(t, ErrQueueFull, ch.Write([]*pb.Metric{{Name: "cpu"}}))
+	// still full
+	fanout.EXPECT().Put([]byte{1}).Return(queue.ErrExceedingTotalSizeLimit)
+	assert.Equal(t, ErrQueueFull, ch1.append([]byte{1}))
+	assert.True(t, ch.IsFull())
+	// accepts the write after synced
+	fanout.EXPECT().Sync()
+	fanout.EXPECT().Depth().Return(int64(7))
+	ch1.syncQueue()
+	assert.False(t, ch.IsFull())
+	fanout.EXPECT().Put([]byte{2}).Return(nil)
+	assert.NoError(t, ch1.append([]byte{2}))
+	assert.False(t, ch.IsFull())
+}
+
+func TestChannel_DropOldest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ch, err := newChannel(context.TODO(), replicationConfig, "database", 1, nil)
+	assert.NoError(t, err)
+	ch1 := ch.(*channel)
+	fanout := queue.NewMockFanOutQueue(ctrl)
+	ch1.q = fanout
+
+	fanout.EXPECT().Depth().Return(int64(10))
+	assert.Equal(t, int64(10), ch.Depth())
+	fanout.EXPECT().DropOldest(int64(5)).Return(int64(3))
+	assert.Equal(t, int64(3), ch.DropOldest(5))
+	fanout.EXPECT().DropOldest(int64(5)).Return(int64(0))
+	assert.Equal(t, int64(0), ch.DropOldest(5))
+
+	fanout.EXPECT().Sync()
+	fanout.EXPECT().Depth().Return(int64(7))
+	ch1.syncQueue()
 }
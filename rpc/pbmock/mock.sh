@@ -1,6 +1,9 @@
 mkdir -p rpc/pbmock/common
 mkdir -p rpc/pbmock/storage
+mkdir -p rpc/pbmock/transfer
 
 mockgen github.com/lindb/lindb/rpc/proto/common TaskServiceClient,TaskService_HandleClient,TaskServiceServer,TaskService_HandleServer > rpc/pbmock/common/common_mock.pb.go
 
 mockgen github.com/lindb/lindb/rpc/proto/storage WriteServiceClient,WriteService_WriteClient,WriteServiceServer,WriteService_WriteServer  > rpc/pbmock/storage/storage_mock.pb.go
+
+mockgen github.com/lindb/lindb/rpc/proto/transfer TransferServiceClient,TransferService_PullClient,TransferService_PullFamilyClient,TransferServiceServer,TransferService_PullServer,TransferService_PullFamilyServer > rpc/pbmock/transfer/transfer_mock.pb.go
@@ -26,11 +26,12 @@ const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 type FieldType int32
 
 const (
-	FieldType_UNKNOWN FieldType = 0
-	FieldType_Sum     FieldType = 1
-	FieldType_Min     FieldType = 2
-	FieldType_Max     FieldType = 3
-	FieldType_Gauge   FieldType = 4
+	FieldType_UNKNOWN   FieldType = 0
+	FieldType_Sum       FieldType = 1
+	FieldType_Min       FieldType = 2
+	FieldType_Max       FieldType = 3
+	FieldType_Gauge     FieldType = 4
+	FieldType_Histogram FieldType = 5
 )
 
 var FieldType_name = map[int32]string{
@@ -39,14 +40,16 @@ var FieldType_name = map[int32]string{
 	2: "Min",
 	3: "Max",
 	4: "Gauge",
+	5: "Histogram",
 }
 
 var FieldType_value = map[string]int32{
-	"UNKNOWN": 0,
-	"Sum":     1,
-	"Min":     2,
-	"Max":     3,
-	"Gauge":   4,
+	"UNKNOWN":   0,
+	"Sum":       1,
+	"Min":       2,
+	"Max":       3,
+	"Gauge":     4,
+	"Histogram": 5,
 }
 
 func (x FieldType) String() string {
@@ -195,6 +198,8 @@ type Field struct {
 	Name                 string    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Type                 FieldType `protobuf:"varint,2,opt,name=type,proto3,enum=field.FieldType" json:"type,omitempty"`
 	Value                float64   `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
+	ExplicitBounds       []float64 `protobuf:"fixed64,4,rep,packed,name=explicitBounds,proto3" json:"explicitBounds,omitempty"`
+	BucketValues         []float64 `protobuf:"fixed64,5,rep,packed,name=bucketValues,proto3" json:"bucketValues,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
 	XXX_unrecognized     []byte    `json:"-"`
 	XXX_sizecache        int32     `json:"-"`
@@ -254,6 +259,20 @@ func (m *Field) GetValue() float64 {
 	return 0
 }
 
+func (m *Field) GetExplicitBounds() []float64 {
+	if m != nil {
+		return m.ExplicitBounds
+	}
+	return nil
+}
+
+func (m *Field) GetBucketValues() []float64 {
+	if m != nil {
+		return m.BucketValues
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterEnum("field.FieldType", FieldType_name, FieldType_value)
 	proto.RegisterType((*MetricList)(nil), "field.MetricList")
@@ -265,29 +284,32 @@ func init() {
 func init() { proto.RegisterFile("field.proto", fileDescriptor_04234ff7fdd53e6e) }
 
 var fileDescriptor_04234ff7fdd53e6e = []byte{
-	// 345 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x54, 0x51, 0xcd, 0x4a, 0xf3, 0x40,
-	0x14, 0xed, 0xe4, 0xaf, 0x5f, 0x6e, 0x3f, 0x65, 0x18, 0x04, 0x87, 0x22, 0x21, 0x94, 0x82, 0x41,
-	0xa1, 0x8b, 0x8a, 0x28, 0x2e, 0x5c, 0x08, 0xfe, 0x80, 0xb6, 0xc2, 0x58, 0xe9, 0x7a, 0xac, 0x63,
-	0x0d, 0x36, 0x6d, 0xe8, 0x4c, 0xc5, 0xec, 0x7c, 0x0c, 0x1f, 0xc9, 0xa5, 0x8f, 0x20, 0xf5, 0x45,
-	0x64, 0x26, 0x69, 0x52, 0x37, 0xc9, 0x39, 0xf7, 0xdc, 0x73, 0x32, 0x27, 0x03, 0x8d, 0xa7, 0x58,
-	0x4c, 0x1e, 0x3b, 0xe9, 0x7c, 0xa6, 0x66, 0xc4, 0x35, 0xa4, 0x75, 0x08, 0xd0, 0x13, 0x6a, 0x1e,
-	0x8f, 0x6e, 0x62, 0xa9, 0xc8, 0x2e, 0xd4, 0x13, 0xc3, 0x24, 0xb5, 0x42, 0x3b, 0x6a, 0x74, 0x37,
-	0x3a, 0xb9, 0x27, 0xdf, 0x61, 0x2b, 0xb5, 0xf5, 0x6e, 0x81, 0x97, 0xcf, 0xc8, 0x0e, 0xf8, 0x53,
-	0x9e, 0x08, 0x99, 0xf2, 0x91, 0xa0, 0x28, 0x44, 0x91, 0xcf, 0xaa, 0x01, 0x21, 0xe0, 0x68, 0x42,
-	0x2d, 0x23, 0x18, 0xac, 0x1d, 0x2a, 0x4e, 0x84, 0x54, 0x3c, 0x49, 0xa9, 0x1d, 0xa2, 0xc8, 0x66,
-	0xd5, 0x80, 0xec, 0x83, 0xa3, 0xf8, 0x58, 0x52, 0xc7, 0x1c, 0x60, 0xfb, 0xcf, 0x01, 0x3a, 0x03,
-	0x3e, 0x96, 0xe7, 0x53, 0x35, 0xcf, 0x98, 0x59, 0x22, 0x4d, 0xf8, 0xa7, 0xdf, 0x57, 0x5c, 0x3e,
-	0x53, 0x37, 0x44, 0x91, 0xc3, 0x4a, 0x4e, 0xda, 0xe0, 0x19, 0xaf, 0xa4, 0x9e, 0x89, 0xfa, 0x5f,
-	0x44, 0x5d, 0xe8, 0x27, 0x2b, 0xb4, 0xe6, 0x11, 0xf8, 0x65, 0x28, 0xc1, 0x60, 0xbf, 0x88, 0xac,
-	0x68, 0xa1, 0x21, 0xd9, 0x02, 0xf7, 0x95, 0x4f, 0x16, 0xab, 0x02, 0x39, 0x39, 0xb1, 0x8e, 0x51,
-	0x6b, 0x08, 0xae, 0x49, 0x2a, 0x2b, 0xa2, 0xb5, 0x8a, 0x6d, 0x70, 0x54, 0x96, 0xe6, 0xae, 0xcd,
-	0x2e, 0x5e, 0xff, 0xf2, 0x20, 0x4b, 0x05, 0x33, 0x6a, 0x15, 0xae, 0x7f, 0x02, 0x2a, 0xc2, 0xf7,
-	0x4e, 0xc1, 0x2f, 0x17, 0x49, 0x03, 0xea, 0xf7, 0xfd, 0xeb, 0xfe, 0xed, 0xb0, 0x8f, 0x6b, 0xa4,
-	0x0e, 0xf6, 0xdd, 0x22, 0xc1, 0x48, 0x83, 0x5e, 0x3c, 0xc5, 0x96, 0x01, 0xfc, 0x0d, 0xdb, 0xc4,
-	0x07, 0xf7, 0x92, 0x2f, 0xc6, 0x02, 0x3b, 0x67, 0xf8, 0x73, 0x19, 0xa0, 0xaf, 0x65, 0x80, 0xbe,
-	0x97, 0x01, 0xfa, 0xf8, 0x09, 0x6a, 0x0f, 0x9e, 0xb9, 0xf2, 0x83, 0xdf, 0x00, 0x00, 0x00, 0xff,
-	0xff, 0xd2, 0xc7, 0x09, 0xaf, 0x01, 0x02, 0x00, 0x00,
+	// 394 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x52, 0x5d, 0x6b, 0xd4, 0x40,
+	0x14, 0xed, 0xe4, 0x63, 0xd7, 0xdc, 0x6d, 0xcb, 0x70, 0x11, 0x1c, 0x8a, 0x2c, 0x21, 0x14, 0x0d,
+	0x0a, 0xfb, 0x50, 0x11, 0xc5, 0xc7, 0x82, 0x5a, 0xd0, 0x5d, 0x61, 0xac, 0xfa, 0x3c, 0x4d, 0xc7,
+	0x38, 0x74, 0xf3, 0x41, 0x66, 0x22, 0xcd, 0x9b, 0x3f, 0xc3, 0x77, 0xff, 0x8c, 0x8f, 0xfe, 0x04,
+	0x59, 0xff, 0x88, 0xcc, 0x24, 0xcd, 0x76, 0xfb, 0x92, 0xdc, 0x73, 0xee, 0x3d, 0x67, 0xe6, 0x5c,
+	0x06, 0x66, 0x5f, 0x95, 0x5c, 0x5f, 0x2e, 0xea, 0xa6, 0x32, 0x15, 0x86, 0x0e, 0x24, 0xcf, 0x01,
+	0x96, 0xd2, 0x34, 0x2a, 0x7b, 0xaf, 0xb4, 0xc1, 0xc7, 0x30, 0x2d, 0x1c, 0xd2, 0xcc, 0x8b, 0xfd,
+	0x74, 0x76, 0x72, 0xb0, 0xe8, 0x35, 0xfd, 0x0c, 0xbf, 0xe9, 0x26, 0x3f, 0x3c, 0x98, 0xf4, 0x1c,
+	0x3e, 0x84, 0xa8, 0x14, 0x85, 0xd4, 0xb5, 0xc8, 0x24, 0x23, 0x31, 0x49, 0x23, 0xbe, 0x25, 0x10,
+	0x21, 0xb0, 0x80, 0x79, 0xae, 0xe1, 0x6a, 0xab, 0x30, 0xaa, 0x90, 0xda, 0x88, 0xa2, 0x66, 0x7e,
+	0x4c, 0x52, 0x9f, 0x6f, 0x09, 0x7c, 0x0a, 0x81, 0x11, 0xb9, 0x66, 0x81, 0xbb, 0xc0, 0x83, 0x9d,
+	0x0b, 0x2c, 0xce, 0x45, 0xae, 0x5f, 0x97, 0xa6, 0xe9, 0xb8, 0x1b, 0xc2, 0x23, 0xb8, 0x67, 0xff,
+	0x67, 0x42, 0x7f, 0x63, 0x61, 0x4c, 0xd2, 0x80, 0x8f, 0x18, 0x8f, 0x61, 0xe2, 0xb4, 0x9a, 0x4d,
+	0x9c, 0xd5, 0xfe, 0x60, 0xf5, 0xc6, 0x7e, 0xf9, 0xd0, 0x3b, 0x7a, 0x01, 0xd1, 0x68, 0x8a, 0x14,
+	0xfc, 0x2b, 0xd9, 0x0d, 0x29, 0x6c, 0x89, 0xf7, 0x21, 0xfc, 0x2e, 0xd6, 0xed, 0x4d, 0x80, 0x1e,
+	0xbc, 0xf2, 0x5e, 0x92, 0xe4, 0x17, 0x81, 0xd0, 0x59, 0x8d, 0x19, 0xc9, 0xad, 0x8c, 0xc7, 0x10,
+	0x98, 0xae, 0xee, 0x65, 0x87, 0x27, 0xf4, 0xf6, 0xd1, 0xe7, 0x5d, 0x2d, 0xb9, 0xeb, 0x6e, 0xdd,
+	0xed, 0x16, 0xc8, 0xe0, 0x8e, 0x8f, 0xe0, 0x50, 0x5e, 0xd7, 0x6b, 0x95, 0x29, 0x73, 0x5a, 0xb5,
+	0xe5, 0x65, 0xbf, 0x0b, 0xc2, 0xef, 0xb0, 0x98, 0xc0, 0xfe, 0x45, 0x9b, 0x5d, 0x49, 0xf3, 0xd9,
+	0xca, 0x34, 0x0b, 0xdd, 0xd4, 0x0e, 0xf7, 0x64, 0x09, 0xd1, 0x78, 0x28, 0xce, 0x60, 0xfa, 0x69,
+	0xf5, 0x6e, 0xf5, 0xe1, 0xcb, 0x8a, 0xee, 0xe1, 0x14, 0xfc, 0x8f, 0x6d, 0x41, 0x89, 0x2d, 0x96,
+	0xaa, 0xa4, 0x9e, 0x2b, 0xc4, 0x35, 0xf5, 0x31, 0x82, 0xf0, 0xad, 0x68, 0x73, 0x49, 0x03, 0x3c,
+	0x80, 0xe8, 0x4c, 0x69, 0x53, 0xe5, 0x8d, 0x28, 0x68, 0x78, 0x4a, 0x7f, 0x6f, 0xe6, 0xe4, 0xcf,
+	0x66, 0x4e, 0xfe, 0x6e, 0xe6, 0xe4, 0xe7, 0xbf, 0xf9, 0xde, 0xc5, 0xc4, 0x3d, 0xa7, 0x67, 0xff,
+	0x07, 0x00, 0xb0, 0x1d, 0x4d, 0x98, 0x5d, 0x02, 0x00, 0x00,
 }
 
 func (m *MetricList) Marshal() (dAtA []byte, err error) {
@@ -439,6 +461,26 @@ func (m *Field) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 		i -= len(m.XXX_unrecognized)
 		copy(dAtA[i:], m.XXX_unrecognized)
 	}
+	if len(m.BucketValues) > 0 {
+		for iNdEx := len(m.BucketValues) - 1; iNdEx >= 0; iNdEx-- {
+			f1 := math.Float64bits(float64(m.BucketValues[iNdEx]))
+			i -= 8
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f1))
+		}
+		i = encodeVarintField(dAtA, i, uint64(len(m.BucketValues)*8))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.ExplicitBounds) > 0 {
+		for iNdEx := len(m.ExplicitBounds) - 1; iNdEx >= 0; iNdEx-- {
+			f2 := math.Float64bits(float64(m.ExplicitBounds[iNdEx]))
+			i -= 8
+			encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(f2))
+		}
+		i = encodeVarintField(dAtA, i, uint64(len(m.ExplicitBounds)*8))
+		i--
+		dAtA[i] = 0x22
+	}
 	if m.Value != 0 {
 		i -= 8
 		encoding_binary.LittleEndian.PutUint64(dAtA[i:], uint64(math.Float64bits(float64(m.Value))))
@@ -545,6 +587,12 @@ func (m *Field) Size() (n int) {
 	if m.Value != 0 {
 		n += 9
 	}
+	if len(m.ExplicitBounds) > 0 {
+		n += 1 + sovField(uint64(len(m.ExplicitBounds)*8)) + len(m.ExplicitBounds)*8
+	}
+	if len(m.BucketValues) > 0 {
+		n += 1 + sovField(uint64(len(m.BucketValues)*8)) + len(m.BucketValues)*8
+	}
 	if m.XXX_unrecognized != nil {
 		n += len(m.XXX_unrecognized)
 	}
@@ -1053,6 +1101,114 @@ func (m *Field) Unmarshal(dAtA []byte) error {
 			v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
 			iNdEx += 8
 			m.Value = float64(math.Float64frombits(v))
+		case 4:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.ExplicitBounds = append(m.ExplicitBounds, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowField
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthField
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthField
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 8
+				if elementCount != 0 && len(m.ExplicitBounds) == 0 {
+					m.ExplicitBounds = make([]float64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.ExplicitBounds = append(m.ExplicitBounds, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExplicitBounds", wireType)
+			}
+		case 5:
+			if wireType == 1 {
+				var v uint64
+				if (iNdEx + 8) > l {
+					return io.ErrUnexpectedEOF
+				}
+				v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+				iNdEx += 8
+				v2 := float64(math.Float64frombits(v))
+				m.BucketValues = append(m.BucketValues, v2)
+			} else if wireType == 2 {
+				var packedLen int
+				for shift := uint(0); ; shift += 7 {
+					if shift >= 64 {
+						return ErrIntOverflowField
+					}
+					if iNdEx >= l {
+						return io.ErrUnexpectedEOF
+					}
+					b := dAtA[iNdEx]
+					iNdEx++
+					packedLen |= int(b&0x7F) << shift
+					if b < 0x80 {
+						break
+					}
+				}
+				if packedLen < 0 {
+					return ErrInvalidLengthField
+				}
+				postIndex := iNdEx + packedLen
+				if postIndex < 0 {
+					return ErrInvalidLengthField
+				}
+				if postIndex > l {
+					return io.ErrUnexpectedEOF
+				}
+				var elementCount int
+				elementCount = packedLen / 8
+				if elementCount != 0 && len(m.BucketValues) == 0 {
+					m.BucketValues = make([]float64, 0, elementCount)
+				}
+				for iNdEx < postIndex {
+					var v uint64
+					if (iNdEx + 8) > l {
+						return io.ErrUnexpectedEOF
+					}
+					v = uint64(encoding_binary.LittleEndian.Uint64(dAtA[iNdEx:]))
+					iNdEx += 8
+					v2 := float64(math.Float64frombits(v))
+					m.BucketValues = append(m.BucketValues, v2)
+				}
+			} else {
+				return fmt.Errorf("proto: wrong wireType = %d for field BucketValues", wireType)
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipField(dAtA[iNdEx:])
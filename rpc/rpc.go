@@ -12,6 +12,7 @@ import (
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/rpc/proto/storage"
+	"github.com/lindb/lindb/rpc/proto/transfer"
 )
 
 //go:generate mockgen -source ./rpc.go -destination=./rpc_mock.go -package=rpc
@@ -83,6 +84,8 @@ type ClientStreamFactory interface {
 	CreateTaskClient(target models.Node) (common.TaskService_HandleClient, error)
 	// CreateWriteServiceClient creates a WriteServiceClient
 	CreateWriteServiceClient(target models.Node) (storage.WriteServiceClient, error)
+	// CreateTransferServiceClient creates a TransferServiceClient
+	CreateTransferServiceClient(target models.Node) (transfer.TransferServiceClient, error)
 }
 
 // clientStreamFactory implements ClientStreamFactory.
@@ -134,6 +137,15 @@ func (w *clientStreamFactory) CreateWriteServiceClient(target models.Node) (stor
 	return storage.NewWriteServiceClient(conn), nil
 }
 
+// CreateTransferServiceClient creates a TransferServiceClient
+func (w *clientStreamFactory) CreateTransferServiceClient(target models.Node) (transfer.TransferServiceClient, error) {
+	conn, err := w.connFct.GetClientConn(target)
+	if err != nil {
+		return nil, err
+	}
+	return transfer.NewTransferServiceClient(conn), nil
+}
+
 // NewClientStreamFactory returns a factory to get clientStream.
 func NewClientStreamFactory(logicNode models.Node) ClientStreamFactory {
 	return &clientStreamFactory{
@@ -89,6 +89,8 @@ func TestClientStreamFactory(t *testing.T) {
 	fct := NewClientStreamFactory(node)
 	_, err := fct.CreateWriteServiceClient(target)
 	assert.Nil(t, err)
+	_, err = fct.CreateTransferServiceClient(target)
+	assert.Nil(t, err)
 
 	assert.Equal(t, fct.LogicNode(), node)
 
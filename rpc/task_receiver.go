@@ -6,6 +6,6 @@ import pb "github.com/lindb/lindb/rpc/proto/common"
 
 // TaskReceiver represents the task result receiver
 type TaskReceiver interface {
-	// Receive receives the task result
-	Receive(req *pb.TaskResponse) error
+	// Receive receives the task result from the target node
+	Receive(req *pb.TaskResponse, targetNodeID string) error
 }
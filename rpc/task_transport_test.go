@@ -58,7 +58,7 @@ func TestTaskClientFactory(t *testing.T) {
 
 	fct := NewTaskClientFactory(models.Node{IP: "127.0.0.1", Port: 123})
 	receiver := NewMockTaskReceiver(ctl)
-	receiver.EXPECT().Receive(gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
+	receiver.EXPECT().Receive(gomock.Any(), gomock.Any()).Return(fmt.Errorf("err")).AnyTimes()
 	fct.SetTaskReceiver(receiver)
 	fct1 := fct.(*taskClientFactory)
 	fct1.connFct = mockClientConnFct
@@ -126,9 +126,9 @@ func TestTaskClientFactory_handler(t *testing.T) {
 		mockClientConnFct.EXPECT().GetClientConn(target).Return(conn, nil),
 		taskService.EXPECT().Handle(gomock.Any(), gomock.Any()).Return(mockTaskClient, nil),
 		mockTaskClient.EXPECT().Recv().Return(nil, nil),
-		receiver.EXPECT().Receive(gomock.Any()).Return(nil),
+		receiver.EXPECT().Receive(gomock.Any(), "test").Return(nil),
 		mockTaskClient.EXPECT().Recv().Return(nil, nil),
-		receiver.EXPECT().Receive(gomock.Any()).DoAndReturn(func(req *common.TaskResponse) error {
+		receiver.EXPECT().Receive(gomock.Any(), "test").DoAndReturn(func(req *common.TaskResponse, targetNodeID string) error {
 			taskClient.running.Store(false)
 			return fmt.Errorf("err")
 		}),
@@ -0,0 +1,45 @@
+package field
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// histogramBucketSeparator separates the histogram field name and the upper bound of bucket,
+// histogram field is stored as multi bucket fields, like latency__bucket_0.5/latency__bucket_+Inf.
+const histogramBucketSeparator = "__bucket_"
+
+// HistogramBucketName returns the bucket field name of histogram field by upper bound of bucket.
+func HistogramBucketName(histogramName string, upperBound float64) Name {
+	return Name(histogramName + histogramBucketSeparator + formatUpperBound(upperBound))
+}
+
+// ParseHistogramBucketName parses the histogram field name and the upper bound of bucket from bucket field name,
+// returns false if the field name isn't a histogram bucket field.
+func ParseHistogramBucketName(fieldName Name) (histogramName string, upperBound float64, ok bool) {
+	name := string(fieldName)
+	idx := strings.LastIndex(name, histogramBucketSeparator)
+	if idx <= 0 {
+		return "", 0, false
+	}
+	upperBound, err := strconv.ParseFloat(name[idx+len(histogramBucketSeparator):], 64)
+	if err != nil {
+		return "", 0, false
+	}
+	return name[:idx], upperBound, true
+}
+
+// IsHistogramBucketOf checks if the field name is the bucket field of given histogram field.
+func IsHistogramBucketOf(fieldName Name, histogramName string) bool {
+	name, _, ok := ParseHistogramBucketName(fieldName)
+	return ok && name == histogramName
+}
+
+// formatUpperBound formats the upper bound of bucket, the last bucket's upper bound is +Inf.
+func formatUpperBound(upperBound float64) string {
+	if math.IsInf(upperBound, 1) {
+		return "+Inf"
+	}
+	return strconv.FormatFloat(upperBound, 'f', -1, 64)
+}
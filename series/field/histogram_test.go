@@ -0,0 +1,36 @@
+package field
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogramBucketName(t *testing.T) {
+	assert.Equal(t, Name("latency__bucket_0.5"), HistogramBucketName("latency", 0.5))
+	assert.Equal(t, Name("latency__bucket_100"), HistogramBucketName("latency", 100))
+	assert.Equal(t, Name("latency__bucket_+Inf"), HistogramBucketName("latency", math.Inf(1)))
+}
+
+func TestParseHistogramBucketName(t *testing.T) {
+	name, upperBound, ok := ParseHistogramBucketName(HistogramBucketName("latency", 0.5))
+	assert.True(t, ok)
+	assert.Equal(t, "latency", name)
+	assert.Equal(t, 0.5, upperBound)
+	name, upperBound, ok = ParseHistogramBucketName(HistogramBucketName("a__bucket_b", math.Inf(1)))
+	assert.True(t, ok)
+	assert.Equal(t, "a__bucket_b", name)
+	assert.True(t, math.IsInf(upperBound, 1))
+
+	_, _, ok = ParseHistogramBucketName("latency")
+	assert.False(t, ok)
+	_, _, ok = ParseHistogramBucketName("__bucket_1")
+	assert.False(t, ok)
+	_, _, ok = ParseHistogramBucketName("latency__bucket_abc")
+	assert.False(t, ok)
+
+	assert.True(t, IsHistogramBucketOf("latency__bucket_1", "latency"))
+	assert.False(t, IsHistogramBucketOf("latency__bucket_1", "cpu"))
+	assert.False(t, IsHistogramBucketOf("latency", "latency"))
+}
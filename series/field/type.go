@@ -69,6 +69,11 @@ func (t Type) GetAggFunc() AggFunc {
 		return minAggregator
 	case MaxField:
 		return maxAggregator
+	case GaugeField:
+		return replaceAggregator
+	case HistogramField:
+		// histogram field is stored as bucket fields, the count of bucket need sum when merging
+		return sumAggregator
 	default:
 		return nil
 	}
@@ -141,6 +146,10 @@ func (t Type) GetFuncFieldParams(funcType function.FuncType) []AggType {
 		return getFieldParamsForSumField(funcType)
 	case MinField:
 		return getFieldParamsForMinField(funcType)
+	case GaugeField:
+		return []AggType{Replace}
+	case HistogramField:
+		return []AggType{Sum}
 	}
 	return nil
 }
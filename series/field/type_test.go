@@ -59,5 +59,16 @@ func TestType_GetAggFunc(t *testing.T) {
 	assert.Equal(t, maxAggregator, MaxField.GetAggFunc())
 	assert.Equal(t, sumAggregator, SumField.GetAggFunc())
 	assert.Equal(t, minAggregator, MinField.GetAggFunc())
+	assert.Equal(t, replaceAggregator, GaugeField.GetAggFunc())
+	assert.Equal(t, sumAggregator, HistogramField.GetAggFunc())
 	assert.Nil(t, Unknown.GetAggFunc())
 }
+
+func TestType_GetFuncFieldParams(t *testing.T) {
+	assert.Equal(t, []AggType{Sum}, SumField.GetFuncFieldParams(function.Sum))
+	assert.Equal(t, []AggType{Max}, SumField.GetFuncFieldParams(function.Max))
+	assert.Equal(t, []AggType{Min}, MinField.GetFuncFieldParams(function.Min))
+	assert.Equal(t, []AggType{Replace}, GaugeField.GetFuncFieldParams(function.Replace))
+	assert.Equal(t, []AggType{Sum}, HistogramField.GetFuncFieldParams(function.Quantile))
+	assert.Nil(t, Unknown.GetFuncFieldParams(function.Sum))
+}
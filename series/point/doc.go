@@ -25,7 +25,10 @@ Gauge -> SumField(total) + SumField(count)
 
 The field type is appended to the field-name,
 It is a 4 byte slice startswith an underline and the upper case abbreviation of field-type,
-such as `_SUM`(sum field), `_MIN`(min field), `_MAX`(max field), `_SMY`(SummaryField)
+such as `_SUM`(sum field), `_MIN`(min field), `_MAX`(max field), `_SMY`(SummaryField).
+Field without type suffix is the standard line protocol field, the numeric value(float,
+integer with `i` suffix, unsigned integer with `u` suffix) is stored as GaugeField(last value),
+string and boolean values are ignored.
 The enhanced example is shown below
 
 weather,location=us-midwest temperature_SUM=82 1465839830100400200
@@ -11,4 +11,5 @@ var (
 	ErrMissingFieldName  = fmt.Errorf("field name is missing")
 	ErrMissingFieldValue = fmt.Errorf("field value is missing")
 	ErrInvalidNumber     = fmt.Errorf("invalid number")
+	ErrInvalidPrecision  = fmt.Errorf("invalid precision")
 )
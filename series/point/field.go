@@ -29,6 +29,7 @@ type FieldIterator struct {
 	key, keybuf []byte
 	valueBuf    []byte
 	fieldType   field.Type
+	suffixed    bool // true if the field-name ends with field-type suffix
 }
 
 func (fi *FieldIterator) Next() bool {
@@ -46,9 +47,12 @@ func (fi *FieldIterator) Next() bool {
 	fi.end, fi.valueBuf = scanFieldValue(fi.data, fi.end+1)
 	fi.end++
 
+	fi.suffixed = true
 	switch {
-	case (len(fi.key) <= fieldSuffixLength) || (len(fi.valueBuf) == 0):
+	case len(fi.valueBuf) == 0:
 		fi.fieldType = field.Unknown
+	case len(fi.key) <= fieldSuffixLength:
+		fi.setDefaultType()
 	case bytes.HasSuffix(fi.key, SumFieldSuffix):
 		fi.fieldType = field.SumField
 	case bytes.HasSuffix(fi.key, MinFieldSuffix):
@@ -59,12 +63,28 @@ func (fi *FieldIterator) Next() bool {
 		fi.fieldType = field.SummaryField
 	case bytes.HasSuffix(fi.key, HistogramFieldSuffix):
 		fi.fieldType = field.HistogramField
+	default:
+		fi.setDefaultType()
 	}
 	return true
 }
 
+// setDefaultType sets the default field type for the standard line protocol field without type suffix,
+// numeric value(float, integer with 'i' suffix, unsigned with 'u' suffix) is stored as GaugeField,
+// string and boolean values are not supported.
+func (fi *FieldIterator) setDefaultType() {
+	fi.suffixed = false
+	fi.fieldType = field.Unknown
+	switch fi.valueBuf[0] {
+	case '"', 't', 'T', 'f', 'F':
+		// string or boolean
+	default:
+		fi.fieldType = field.GaugeField
+	}
+}
+
 func (fi *FieldIterator) Name() []byte {
-	if len(fi.key) <= fieldSuffixLength {
+	if !fi.suffixed || len(fi.key) <= fieldSuffixLength {
 		return fi.key
 	}
 	return fi.key[:len(fi.key)-fieldSuffixLength]
@@ -76,6 +96,7 @@ func (fi *FieldIterator) Type() field.Type {
 
 func (fi *FieldIterator) Reset(data []byte) {
 	fi.fieldType = field.Unknown
+	fi.suffixed = false
 	fi.key = nil
 	fi.valueBuf = nil
 	fi.start = 0
@@ -99,6 +120,29 @@ func (fi *FieldIterator) Float64Value() (float64, error) {
 	return f, nil
 }
 
+// NumberValue returns the numeric value as float64,
+// supports float, integer with 'i' suffix and unsigned integer with 'u' suffix.
+func (fi *FieldIterator) NumberValue() (float64, error) {
+	n := len(fi.valueBuf)
+	if n > 1 {
+		switch fi.valueBuf[n-1] {
+		case 'i':
+			v, err := parseInt64Bytes(fi.valueBuf[:n-1])
+			if err != nil {
+				return 0, fmt.Errorf("parse int64 field value %q with error: %v", fi.valueBuf, err)
+			}
+			return float64(v), nil
+		case 'u':
+			v, err := parseUint64Bytes(fi.valueBuf[:n-1])
+			if err != nil {
+				return 0, fmt.Errorf("parse uint64 field value %q with error: %v", fi.valueBuf, err)
+			}
+			return float64(v), nil
+		}
+	}
+	return fi.Float64Value()
+}
+
 func scanFieldValue(buf []byte, i int) (int, []byte) {
 	start := i
 	for i < len(buf) {
@@ -152,7 +196,8 @@ func MakeFields(dst []byte, fs field.Fields) []byte {
 	if len(fs) == 0 {
 		return dst
 	}
-	for idx, f := range fs {
+	start := len(dst)
+	for _, f := range fs {
 		var suffix []byte
 		switch f.Type {
 		case field.SumField:
@@ -165,6 +210,8 @@ func MakeFields(dst []byte, fs field.Fields) []byte {
 			suffix = SummaryFieldSuffix
 		case field.HistogramField:
 			suffix = HistogramFieldSuffix
+		case field.GaugeField:
+			// gauge field is written without suffix, same as standard line protocol
 		default:
 			continue
 		}
@@ -182,13 +229,13 @@ func MakeFields(dst []byte, fs field.Fields) []byte {
 		default:
 			continue
 		}
+		if len(dst) > start {
+			dst = append(dst, ',')
+		}
 		dst = append(dst, escape.Bytes(f.Name)...)
 		dst = append(dst, suffix...)
 		dst = append(dst, '=')
 		dst = strconv.AppendFloat(dst, thisValue, 'E', -1, 64)
-		if idx != len(fs)-1 {
-			dst = append(dst, ',')
-		}
 	}
 	return dst
 }
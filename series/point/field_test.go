@@ -30,7 +30,7 @@ func Test_FieldIterator(t *testing.T) {
 	except(
 		[]byte("n=0.5,timerCount_SUM=32,timerSum_SUM=120,timerMax_MAX=180,timerMin_MIN=10"),
 		[]testField{
-			{fieldName: "n", fieldValue: float64(0.5), fieldType: field.Unknown},
+			{fieldName: "n", fieldValue: float64(0.5), fieldType: field.GaugeField},
 			{fieldName: "timerCount", fieldValue: float64(32), fieldType: field.SumField},
 			{fieldName: "timerSum", fieldValue: float64(120), fieldType: field.SumField},
 			{fieldName: "timerMax", fieldValue: float64(180), fieldType: field.MaxField},
@@ -55,6 +55,35 @@ func Test_FieldIterator(t *testing.T) {
 	)
 }
 
+func Test_FieldIterator_NumberValue(t *testing.T) {
+	var itr = new(FieldIterator)
+	itr.Reset([]byte(`usage=1.5,count=3i,total=4u,ok=true,msg="str",a_SUM=5i,b=3x,c=9u9u`))
+	expect := []struct {
+		name      string
+		fieldType field.Type
+		value     float64
+		hasErr    bool
+	}{
+		{name: "usage", fieldType: field.GaugeField, value: 1.5},
+		{name: "count", fieldType: field.GaugeField, value: 3},
+		{name: "total", fieldType: field.GaugeField, value: 4},
+		{name: "ok", fieldType: field.Unknown, hasErr: true},
+		{name: "msg", fieldType: field.Unknown, hasErr: true},
+		{name: "a", fieldType: field.SumField, value: 5},
+		{name: "b", fieldType: field.GaugeField, hasErr: true},
+		{name: "c", fieldType: field.GaugeField, hasErr: true},
+	}
+	for _, e := range expect {
+		assert.True(t, itr.Next())
+		assert.Equal(t, e.name, string(itr.Name()))
+		assert.Equal(t, e.fieldType, itr.Type())
+		v, err := itr.NumberValue()
+		assert.Equal(t, e.hasErr, err != nil)
+		assert.Equal(t, e.value, v)
+	}
+	assert.False(t, itr.Next())
+}
+
 func Test_FieldIterator_Int64Value(t *testing.T) {
 	var itr = new(FieldIterator)
 	itr.valueBuf = []byte("1232.32")
@@ -67,3 +96,13 @@ func Test_FieldIterator_Int64Value(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, int64(1232), v)
 }
+
+func Test_MakeFields(t *testing.T) {
+	assert.Nil(t, MakeFields(nil, nil))
+	data := MakeFields(nil, field.Fields{
+		{Name: []byte("a"), Type: field.SumField, Value: 1.0},
+		{Name: []byte("b"), Type: field.GaugeField, Value: int64(2)},
+		{Name: []byte("c"), Type: field.Unknown, Value: 3.0},
+	})
+	assert.Equal(t, "a_SUM=1E+00,b=2E+00", string(data))
+}
@@ -572,7 +572,12 @@ func scanFields(buf []byte, i int) (int, []byte, error) {
 			}
 			// If next byte is not a double-quote, the value must be a boolean
 			if buf[i+1] != '"' {
-				return i, buf[start:i], fmt.Errorf("invalid field value")
+				var err error
+				i, _, err = scanBoolean(buf, i+1)
+				if err != nil {
+					return i, buf[start:i], err
+				}
+				continue
 			}
 		}
 
@@ -771,6 +776,23 @@ func scanNumber(buf []byte, i int) (int, error) {
 	return i, nil
 }
 
+// scanBoolean returns the end position within buf, start at i after
+// scanning over buf for boolean. Valid values for a boolean are
+// t, T, true, TRUE, True, f, F, false, FALSE, False.
+// It returns an error if a invalid boolean is scanned.
+func scanBoolean(buf []byte, i int) (int, []byte, error) {
+	start := i
+	for i < len(buf) && buf[i] != ',' && buf[i] != ' ' {
+		i++
+	}
+	switch string(buf[start:i]) {
+	case "t", "T", "true", "TRUE", "True", "f", "F", "false", "FALSE", "False":
+		return i, buf[start:i], nil
+	default:
+		return i, buf[start:i], fmt.Errorf("invalid field value")
+	}
+}
+
 // walkFields walks each field key and value via fn.  If fn returns false, the iteration
 // is stopped.  The values are the raw byte slices and not the converted types.
 func walkFields(buf []byte, fn func(key, value, data []byte) bool) error {
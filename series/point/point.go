@@ -220,13 +220,14 @@ func (p *Point) parseFields() (field.Fields, error) {
 		switch itr.Type() {
 		case field.Unknown:
 			continue
-		case field.SumField, field.MaxField, field.MinField, field.HistogramField, field.SummaryField:
-			v, err := itr.Float64Value()
+		case field.SumField, field.MaxField, field.MinField, field.HistogramField, field.SummaryField, field.GaugeField:
+			v, err := itr.NumberValue()
 			if err != nil {
 				return nil, fmt.Errorf("parse field: %s with error: %s", string(itr.Name()), err)
 			}
+			// copy the name, because the fields data is rewritten by SetFields
 			fields = append(fields, field.Field{
-				Name: itr.Name(), Type: itr.Type(), Value: v})
+				Name: append([]byte(nil), itr.Name()...), Type: itr.Type(), Value: v})
 		}
 	}
 	if len(fields) == 0 {
@@ -274,9 +275,20 @@ func (p *Point) SetFields(fs field.Fields) *Point {
 // ParsePoints returns a slice of *Point from the text representation of lines separated by new lines,
 // If any poins fails to parse, a error will be returned.
 func ParsePoints(text []byte) ([]*Point, error) {
+	return ParsePointsWithPrecision(text, "")
+}
+
+// ParsePointsWithPrecision is identical to ParsePoints but converts the timestamp with the given precision,
+// if precision is empty, the precision of timestamp will be detected automatically.
+// Points of the valid lines are always returned, the error reports each failed line with its line number.
+func ParsePointsWithPrecision(text []byte, precision string) ([]*Point, error) {
+	if !IsValidPrecision(precision) {
+		return nil, ErrInvalidPrecision
+	}
 	points := make([]*Point, bytes.Count(text, []byte{'\n'})+1)[:0]
 	var (
 		pos    int
+		lineNo int
 		block  []byte
 		failed []string
 		err    error
@@ -284,6 +296,7 @@ func ParsePoints(text []byte) ([]*Point, error) {
 	for pos < len(text) {
 		pos, block = scanLine(text, pos)
 		pos++
+		lineNo++
 
 		if len(block) == 0 {
 			continue
@@ -306,9 +319,9 @@ func ParsePoints(text []byte) ([]*Point, error) {
 			block = block[:len(block)-1]
 		}
 
-		points, err = parsePointsAppend(points, block[start:])
+		points, err = parsePointsAppend(points, block[start:], precision)
 		if err != nil {
-			failed = append(failed, fmt.Sprintf("unable to parse '%s': %v", string(block[start:]), err))
+			failed = append(failed, fmt.Sprintf("line %d: unable to parse '%s': %v", lineNo, string(block[start:]), err))
 		}
 	}
 	if len(failed) > 0 {
@@ -323,11 +336,11 @@ func ParsePointsFromString(text string) ([]*Point, error) {
 	return ParsePoints([]byte(text))
 }
 
-func parsePointsAppend(points []*Point, text []byte) ([]*Point, error) {
+func parsePointsAppend(points []*Point, text []byte, precision string) ([]*Point, error) {
 	// scan the first block which is measurement[,tag1=value1,tag2=value=2...]
 	pos, key, err := scanKey(text, 0)
 	if err != nil {
-		return nil, err
+		return points, err
 	}
 	// metric-name name is required
 	if len(key) == 0 {
@@ -372,7 +385,11 @@ func parsePointsAppend(points []*Point, text []byte) ([]*Point, error) {
 		if err != nil {
 			return points, err
 		}
-		pt.timestamp = MilliSecondOf(ts)
+		if len(precision) == 0 {
+			pt.timestamp = MilliSecondOf(ts)
+		} else {
+			pt.timestamp = MilliSecondWithPrecision(ts, precision)
+		}
 
 		// Determine if there are illegal non-whitespace characters after the
 		// timestamp block.
@@ -188,6 +188,22 @@ func Test_ParsePoints_error(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func Test_ParsePointsWithPrecision(t *testing.T) {
+	_, err := point.ParsePointsWithPrecision([]byte("cpu.load,host=test last15min_SUM=7E+00 1577000000"), "d")
+	assert.Equal(t, point.ErrInvalidPrecision, err)
+
+	points, err := point.ParsePointsWithPrecision([]byte("cpu.load,host=test last15min_SUM=7E+00 1577000000"), point.PrecisionSecond)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1577000000000), points[0].UnixMilli())
+
+	// valid lines are returned, failed lines are reported with line number
+	points, err = point.ParsePointsWithPrecision([]byte(
+		"cpu.load,host=test last15min_SUM=7E+00 1577000000\n,host=test last15min_SUM=7E+00 1577000000"), point.PrecisionSecond)
+	assert.Len(t, points, 1)
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "line 2")
+}
+
 func Test_ParsePoints_TagsError(t *testing.T) {
 	// duplicate tags
 	_, err := point.ParsePointsFromString(
@@ -223,9 +239,9 @@ func Test_ParsePoints_FieldsError(t *testing.T) {
 		"cpu.load,host=test,ip=1.1.1.1,zo\\=ne=sh last15mi\\ n_xxx=7E+00 1577000000000")
 	assert.Nil(t, err)
 
-	// missing fields
+	// missing fields, string field is not supported
 	points, err := point.ParsePointsFromString(
-		"cpu.load,host=test,ip=1.1.1.1,zo\\=ne=sh last15min_xxx=7E+00 1577000000000")
+		"cpu.load,host=test,ip=1.1.1.1,zo\\=ne=sh last15min_xxx=\"str\" 1577000000000")
 	assert.Nil(t, err)
 	_, err = points[0].Fields()
 	assert.NotNil(t, err)
@@ -28,3 +28,45 @@ func MilliSecondOf(timestamp int64) int64 {
 		return timestamp / 1000 / 1000
 	}
 }
+
+// Defines all precisions of timestamp which are compatible with InfluxDB.
+const (
+	PrecisionNanoSecond  = "n"
+	PrecisionMicroSecond = "u"
+	PrecisionMilliSecond = "ms"
+	PrecisionSecond      = "s"
+	PrecisionMinute      = "m"
+	PrecisionHour        = "h"
+)
+
+// IsValidPrecision checks if the precision is supported, empty precision means auto-detection.
+func IsValidPrecision(precision string) bool {
+	switch precision {
+	case "", PrecisionNanoSecond, PrecisionMicroSecond, PrecisionMilliSecond,
+		PrecisionSecond, PrecisionMinute, PrecisionHour:
+		return true
+	default:
+		return false
+	}
+}
+
+// MilliSecondWithPrecision converts the timestamp with given precision to milliseconds,
+// if the precision is unknown, the precision will be detected by MilliSecondOf.
+func MilliSecondWithPrecision(timestamp int64, precision string) int64 {
+	switch precision {
+	case PrecisionNanoSecond:
+		return timestamp / 1000 / 1000
+	case PrecisionMicroSecond:
+		return timestamp / 1000
+	case PrecisionMilliSecond:
+		return timestamp
+	case PrecisionSecond:
+		return timestamp * 1000
+	case PrecisionMinute:
+		return timestamp * 60 * 1000
+	case PrecisionHour:
+		return timestamp * 60 * 60 * 1000
+	default:
+		return MilliSecondOf(timestamp)
+	}
+}
@@ -13,3 +13,17 @@ func Test_MilliSecondOf(t *testing.T) {
 	assert.Equal(t, int64(1576808994000), MilliSecondOf(1576808994000000))
 	assert.Equal(t, int64(1576808994000), MilliSecondOf(1576808994000000000))
 }
+
+func Test_MilliSecondWithPrecision(t *testing.T) {
+	assert.True(t, IsValidPrecision(""))
+	assert.True(t, IsValidPrecision(PrecisionSecond))
+	assert.False(t, IsValidPrecision("d"))
+
+	assert.Equal(t, int64(1576808994000), MilliSecondWithPrecision(1576808994000000000, PrecisionNanoSecond))
+	assert.Equal(t, int64(1576808994000), MilliSecondWithPrecision(1576808994000000, PrecisionMicroSecond))
+	assert.Equal(t, int64(1576808994000), MilliSecondWithPrecision(1576808994000, PrecisionMilliSecond))
+	assert.Equal(t, int64(1576808994000), MilliSecondWithPrecision(1576808994, PrecisionSecond))
+	assert.Equal(t, int64(60000), MilliSecondWithPrecision(1, PrecisionMinute))
+	assert.Equal(t, int64(3600000), MilliSecondWithPrecision(1, PrecisionHour))
+	assert.Equal(t, int64(1576808994000), MilliSecondWithPrecision(1576808994, ""))
+}
@@ -21,6 +21,8 @@ type DatabaseService interface {
 	Get(name string) (*models.Database, error)
 	// List returns all database configs
 	List() ([]*models.Database, error)
+	// Delete deletes database config by name
+	Delete(name string) error
 }
 
 // databaseService implements DatabaseService interface
@@ -96,3 +98,11 @@ func (db *databaseService) List() ([]*models.Database, error) {
 	}
 	return result, nil
 }
+
+// Delete deletes the database config from the state's repo
+func (db *databaseService) Delete(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("database name must not be null")
+	}
+	return db.repo.Delete(context.TODO(), constants.GetDatabaseConfigPath(name))
+}
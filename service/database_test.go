@@ -8,6 +8,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/pkg/option"
 	"github.com/lindb/lindb/pkg/state"
@@ -120,3 +121,22 @@ func TestDatabaseService_List(t *testing.T) {
 	assert.Equal(t, 1, len(list))
 	assert.Equal(t, database, *(list[0]))
 }
+
+func TestDatabaseService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	db := NewDatabaseService(repo)
+
+	err := db.Delete("")
+	assert.Error(t, err)
+
+	repo.EXPECT().Delete(gomock.Any(), constants.GetDatabaseConfigPath("test")).Return(fmt.Errorf("err"))
+	err = db.Delete("test")
+	assert.Error(t, err)
+
+	repo.EXPECT().Delete(gomock.Any(), constants.GetDatabaseConfigPath("test")).Return(nil)
+	err = db.Delete("test")
+	assert.NoError(t, err)
+}
@@ -65,4 +65,8 @@ func TestShardAssignService(t *testing.T) {
 	list, err = srv.List()
 	assert.Nil(t, list)
 	assert.NotNil(t, err)
+
+	repo.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+	err = srv.Delete("db1")
+	assert.NoError(t, err)
 }
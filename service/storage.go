@@ -31,6 +31,16 @@ type StorageService interface {
 	// FLush produces a signal to workers for flushing memory database by name
 	FlushDatabase(ctx context.Context, databaseName string) bool
 
+	// DropDatabase drops the database by given name, removes all data of it
+	DropDatabase(databaseName string) error
+
+	// BackupDatabase backups the database into target path, returns the manifest of backup
+	BackupDatabase(databaseName string, targetPath string) (*tsdb.BackupManifest, error)
+
+	// RestoreDatabase restores the backup as a new database, uses the database name of backup if name is empty,
+	// the shards of backup are restored as new shard ids if shard mapping isn't empty
+	RestoreDatabase(backupPath string, databaseName string, shardMapping map[int32]int32) (tsdb.Database, error)
+
 	// Close closes the time series engine
 	Close()
 }
@@ -95,6 +105,26 @@ func (s *storageService) FlushDatabase(ctx context.Context, databaseName string)
 	return s.engine.FlushDatabase(ctx, databaseName)
 }
 
+func (s *storageService) DropDatabase(databaseName string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.engine.DropDatabase(databaseName)
+}
+
+func (s *storageService) BackupDatabase(databaseName string, targetPath string) (*tsdb.BackupManifest, error) {
+	return s.engine.BackupDatabase(databaseName, targetPath)
+}
+
+func (s *storageService) RestoreDatabase(backupPath string, databaseName string,
+	shardMapping map[int32]int32,
+) (tsdb.Database, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.engine.RestoreDatabase(backupPath, databaseName, shardMapping)
+}
+
 func (s *storageService) Close() {
 	s.engine.Close()
 }
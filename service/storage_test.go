@@ -81,6 +81,38 @@ func TestStorageService_FlushDatabase(t *testing.T) {
 
 }
 
+func TestStorageService_DropDatabase(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEngine := tsdb.NewMockEngine(ctrl)
+
+	service := NewStorageService(mockEngine)
+	mockEngine.EXPECT().DropDatabase("db").Return(fmt.Errorf("err"))
+	err := service.DropDatabase("db")
+	assert.Error(t, err)
+	mockEngine.EXPECT().DropDatabase("db").Return(nil)
+	err = service.DropDatabase("db")
+	assert.NoError(t, err)
+}
+
+func TestStorageService_BackupAndRestore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEngine := tsdb.NewMockEngine(ctrl)
+	service := NewStorageService(mockEngine)
+	mockEngine.EXPECT().BackupDatabase("db", "backup").Return(&tsdb.BackupManifest{Database: "db"}, nil)
+	manifest, err := service.BackupDatabase("db", "backup")
+	assert.NoError(t, err)
+	assert.Equal(t, "db", manifest.Database)
+	mockDatabase := tsdb.NewMockDatabase(ctrl)
+	mockEngine.EXPECT().RestoreDatabase("backup", "db2", map[int32]int32{1: 2}).Return(mockDatabase, nil)
+	db, err := service.RestoreDatabase("backup", "db2", map[int32]int32{1: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, mockDatabase, db)
+}
+
 func TestStorageService_Close(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
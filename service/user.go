@@ -0,0 +1,393 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+//go:generate mockgen -source=./user.go -destination=./user_mock.go -package service
+
+// for testing
+var (
+	randReadFunc           = rand.Read
+	generateFromPasswordFn = bcrypt.GenerateFromPassword
+)
+
+// apiKeyLength represents the length of random bytes of api key
+const apiKeyLength = 24
+
+var (
+	// ErrUserNameOrPasswordInvalid represents the user name or password is invalid when authenticating
+	ErrUserNameOrPasswordInvalid = errors.New("user name or password invalid")
+	// ErrAPIKeyInvalid represents the api key is invalid when authenticating
+	ErrAPIKeyInvalid = errors.New("api key invalid")
+)
+
+// UserService defines the service of user/role/api key for authentication and authorization
+type UserService interface {
+	// SaveUser saves the user, hashes the password if password is set,
+	// keeps the password of exist user if password isn't set.
+	SaveUser(user *models.User) error
+	// GetUser returns the user by name, if not exist return state.ErrNotExist
+	GetUser(name string) (*models.User, error)
+	// ListUsers returns all users
+	ListUsers() ([]*models.User, error)
+	// DeleteUser deletes the user by name
+	DeleteUser(name string) error
+	// SaveRole saves the role
+	SaveRole(role *models.Role) error
+	// GetRole returns the role by name, if not exist return state.ErrNotExist
+	GetRole(name string) (*models.Role, error)
+	// ListRoles returns all roles
+	ListRoles() ([]*models.Role, error)
+	// DeleteRole deletes the role by name
+	DeleteRole(name string) error
+	// CreateAPIKey creates a random api key for the user, the plaintext key only returns once
+	CreateAPIKey(userName string) (*models.APIKey, error)
+	// ListAPIKeys returns all api keys without plaintext key
+	ListAPIKeys() ([]*models.APIKey, error)
+	// DeleteAPIKey deletes the api key by id
+	DeleteAPIKey(id string) error
+	// Authenticate authenticates the user by name and password
+	Authenticate(name, password string) (*models.User, error)
+	// AuthenticateAPIKey authenticates the user by api key
+	AuthenticateAPIKey(key string) (*models.User, error)
+	// Authorize checks if the user has the permission of database,
+	// empty database means the permission of all databases is required.
+	Authorize(user *models.User, database string, permission models.Permission) (bool, error)
+}
+
+// userService implements UserService interface
+type userService struct {
+	repo   state.Repository
+	cache  *userCache
+	logger *logger.Logger
+}
+
+// NewUserService creates the user service which reads user/role/api key from state repo
+func NewUserService(repo state.Repository) UserService {
+	return &userService{
+		repo:   repo,
+		logger: logger.GetLogger("service", "UserService"),
+	}
+}
+
+// NewCachedUserService creates the user service which caches user/role/api key in memory,
+// the cache is kept up to date by watching state repo until ctx done.
+func NewCachedUserService(ctx context.Context, repo state.Repository) UserService {
+	return &userService{
+		repo:   repo,
+		cache:  newUserCache(ctx, repo),
+		logger: logger.GetLogger("service", "UserService"),
+	}
+}
+
+// SaveUser saves the user into state's repo
+func (s *userService) SaveUser(user *models.User) error {
+	if len(user.Name) == 0 {
+		return fmt.Errorf("user name cannot be empty")
+	}
+	if len(user.Password) > 0 {
+		hash, err := generateFromPasswordFn([]byte(user.Password), bcrypt.DefaultCost)
+		if err != nil {
+			return err
+		}
+		user.PasswordHash = string(hash)
+	} else {
+		exist, err := s.loadUser(user.Name)
+		if err != nil {
+			if err == state.ErrNotExist {
+				return fmt.Errorf("password cannot be empty")
+			}
+			return err
+		}
+		user.PasswordHash = exist.PasswordHash
+	}
+	// never stores the plaintext password
+	user.Password = ""
+	for _, roleName := range user.Roles {
+		if _, err := s.loadRole(roleName); err != nil {
+			return fmt.Errorf("get role: %s error: %s", roleName, err)
+		}
+	}
+	data, _ := json.Marshal(user)
+	return s.repo.Put(context.TODO(), constants.GetUserPath(user.Name), data)
+}
+
+// GetUser returns the user in the cache or the state's repo, if not exist return state.ErrNotExist
+func (s *userService) GetUser(name string) (*models.User, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("user name must not be null")
+	}
+	if s.cache != nil {
+		if user, ok, loaded := s.cache.getUser(name); loaded {
+			if !ok {
+				return nil, state.ErrNotExist
+			}
+			return user, nil
+		}
+	}
+	return s.loadUser(name)
+}
+
+// loadUser loads the user from the state's repo, if not exist return state.ErrNotExist
+func (s *userService) loadUser(name string) (*models.User, error) {
+	data, err := s.repo.Get(context.TODO(), constants.GetUserPath(name))
+	if err != nil {
+		return nil, err
+	}
+	user := &models.User{}
+	if err := json.Unmarshal(data, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// ListUsers returns all users
+func (s *userService) ListUsers() ([]*models.User, error) {
+	var result []*models.User
+	err := s.list(constants.UserPath, func(data []byte) error {
+		user := &models.User{}
+		if err := json.Unmarshal(data, user); err != nil {
+			return err
+		}
+		result = append(result, user)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteUser deletes the user from the state's repo
+func (s *userService) DeleteUser(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("user name must not be null")
+	}
+	return s.repo.Delete(context.TODO(), constants.GetUserPath(name))
+}
+
+// SaveRole saves the role into state's repo
+func (s *userService) SaveRole(role *models.Role) error {
+	if len(role.Name) == 0 {
+		return fmt.Errorf("role name cannot be empty")
+	}
+	for _, privilege := range role.Privileges {
+		if len(privilege.Database) == 0 {
+			return fmt.Errorf("database of privilege cannot be empty")
+		}
+		if !privilege.Permission.IsValid() {
+			return fmt.Errorf("permission: %s of privilege is invalid", privilege.Permission)
+		}
+	}
+	data, _ := json.Marshal(role)
+	return s.repo.Put(context.TODO(), constants.GetRolePath(role.Name), data)
+}
+
+// GetRole returns the role in the cache or the state's repo, if not exist return state.ErrNotExist
+func (s *userService) GetRole(name string) (*models.Role, error) {
+	if len(name) == 0 {
+		return nil, fmt.Errorf("role name must not be null")
+	}
+	if s.cache != nil {
+		if role, ok, loaded := s.cache.getRole(name); loaded {
+			if !ok {
+				return nil, state.ErrNotExist
+			}
+			return role, nil
+		}
+	}
+	return s.loadRole(name)
+}
+
+// loadRole loads the role from the state's repo, if not exist return state.ErrNotExist
+func (s *userService) loadRole(name string) (*models.Role, error) {
+	data, err := s.repo.Get(context.TODO(), constants.GetRolePath(name))
+	if err != nil {
+		return nil, err
+	}
+	role := &models.Role{}
+	if err := json.Unmarshal(data, role); err != nil {
+		return nil, err
+	}
+	return role, nil
+}
+
+// ListRoles returns all roles
+func (s *userService) ListRoles() ([]*models.Role, error) {
+	var result []*models.Role
+	err := s.list(constants.RolePath, func(data []byte) error {
+		role := &models.Role{}
+		if err := json.Unmarshal(data, role); err != nil {
+			return err
+		}
+		result = append(result, role)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteRole deletes the role from the state's repo
+func (s *userService) DeleteRole(name string) error {
+	if len(name) == 0 {
+		return fmt.Errorf("role name must not be null")
+	}
+	return s.repo.Delete(context.TODO(), constants.GetRolePath(name))
+}
+
+// CreateAPIKey creates a random api key for the user, only stores the hash of key
+func (s *userService) CreateAPIKey(userName string) (*models.APIKey, error) {
+	if _, err := s.loadUser(userName); err != nil {
+		return nil, err
+	}
+	buf := make([]byte, apiKeyLength)
+	if _, err := randReadFunc(buf); err != nil {
+		return nil, err
+	}
+	key := hex.EncodeToString(buf)
+	apiKey := &models.APIKey{
+		ID:         hashAPIKey(key),
+		UserName:   userName,
+		CreateTime: timeutil.Now(),
+	}
+	data, _ := json.Marshal(apiKey)
+	if err := s.repo.Put(context.TODO(), constants.GetAPIKeyPath(apiKey.ID), data); err != nil {
+		return nil, err
+	}
+	apiKey.Key = key
+	return apiKey, nil
+}
+
+// ListAPIKeys returns all api keys without plaintext key
+func (s *userService) ListAPIKeys() ([]*models.APIKey, error) {
+	var result []*models.APIKey
+	err := s.list(constants.APIKeyPath, func(data []byte) error {
+		apiKey := &models.APIKey{}
+		if err := json.Unmarshal(data, apiKey); err != nil {
+			return err
+		}
+		result = append(result, apiKey)
+		return nil
+	})
+	return result, err
+}
+
+// DeleteAPIKey deletes the api key from the state's repo
+func (s *userService) DeleteAPIKey(id string) error {
+	if len(id) == 0 {
+		return fmt.Errorf("api key id must not be null")
+	}
+	return s.repo.Delete(context.TODO(), constants.GetAPIKeyPath(id))
+}
+
+// Authenticate authenticates the user by name and compares the hashed password
+func (s *userService) Authenticate(name, password string) (*models.User, error) {
+	user, err := s.GetUser(name)
+	if err != nil {
+		if err == state.ErrNotExist {
+			return nil, ErrUserNameOrPasswordInvalid
+		}
+		return nil, err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
+		return nil, ErrUserNameOrPasswordInvalid
+	}
+	return user, nil
+}
+
+// AuthenticateAPIKey authenticates the user by the hash of api key
+func (s *userService) AuthenticateAPIKey(key string) (*models.User, error) {
+	if len(key) == 0 {
+		return nil, ErrAPIKeyInvalid
+	}
+	apiKey, err := s.getAPIKey(hashAPIKey(key))
+	if err != nil {
+		if err == state.ErrNotExist {
+			return nil, ErrAPIKeyInvalid
+		}
+		return nil, err
+	}
+	user, err := s.GetUser(apiKey.UserName)
+	if err != nil {
+		if err == state.ErrNotExist {
+			// user of api key is deleted
+			return nil, ErrAPIKeyInvalid
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+// getAPIKey returns the api key in the cache or the state's repo by id, if not exist return state.ErrNotExist
+func (s *userService) getAPIKey(id string) (*models.APIKey, error) {
+	if s.cache != nil {
+		if apiKey, ok, loaded := s.cache.getAPIKey(id); loaded {
+			if !ok {
+				return nil, state.ErrNotExist
+			}
+			return apiKey, nil
+		}
+	}
+	data, err := s.repo.Get(context.TODO(), constants.GetAPIKeyPath(id))
+	if err != nil {
+		return nil, err
+	}
+	apiKey := &models.APIKey{}
+	if err := json.Unmarshal(data, apiKey); err != nil {
+		return nil, err
+	}
+	return apiKey, nil
+}
+
+// Authorize checks if any role of user has the permission of database
+func (s *userService) Authorize(user *models.User, database string, permission models.Permission) (bool, error) {
+	if user.SuperUser {
+		return true, nil
+	}
+	for _, roleName := range user.Roles {
+		role, err := s.GetRole(roleName)
+		if err != nil {
+			if err == state.ErrNotExist {
+				// role is deleted
+				continue
+			}
+			return false, err
+		}
+		if role.HasPermission(database, permission) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// list lists the values under the prefix, ignores the value which cannot be unmarshalled
+func (s *userService) list(prefix string, unmarshal func(data []byte) error) error {
+	data, err := s.repo.List(context.TODO(), prefix)
+	if err != nil {
+		return err
+	}
+	for _, val := range data {
+		if err := unmarshal(val.Value); err != nil {
+			s.logger.Warn("unmarshal data error",
+				logger.String("data", string(val.Value)))
+		}
+	}
+	return nil
+}
+
+// hashAPIKey returns the sha256 hash of api key, which is used as the id of api key
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
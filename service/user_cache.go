@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/state"
+)
+
+// authPath represents the parent path of user/role/api key
+const authPath = "/auth"
+
+// userCache caches the users/roles/api keys in memory, keeps them up to date by watching the changes of state repo,
+// so authentication and authorization no need to read state repo for each request.
+type userCache struct {
+	users   map[string]*models.User
+	roles   map[string]*models.Role
+	apiKeys map[string]*models.APIKey
+	// loaded represents if all users/roles/api keys are loaded from state repo
+	loaded bool
+	mutex  sync.RWMutex
+
+	logger *logger.Logger
+}
+
+// newUserCache creates the user cache, starts watching the changes of user/role/api key until ctx done
+func newUserCache(ctx context.Context, repo state.Repository) *userCache {
+	cache := &userCache{
+		users:   make(map[string]*models.User),
+		roles:   make(map[string]*models.Role),
+		apiKeys: make(map[string]*models.APIKey),
+		logger:  logger.GetLogger("service", "UserCache"),
+	}
+	eventCh := repo.WatchPrefix(ctx, authPath, true)
+	go func() {
+		for event := range eventCh {
+			cache.handleEvent(event)
+		}
+		cache.logger.Warn("exit user cache watch loop")
+	}()
+	return cache
+}
+
+// handleEvent handles the change event of user/role/api key
+func (c *userCache) handleEvent(event *state.Event) {
+	if event.Err != nil {
+		c.logger.Warn("watch user/role/api key change error", logger.Error(event.Err))
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	switch event.Type {
+	case state.EventTypeAll:
+		// full values, need clean all previous values
+		c.users = make(map[string]*models.User)
+		c.roles = make(map[string]*models.Role)
+		c.apiKeys = make(map[string]*models.APIKey)
+		for _, kv := range event.KeyValues {
+			c.put(kv.Key, kv.Value)
+		}
+		c.loaded = true
+	case state.EventTypeModify:
+		for _, kv := range event.KeyValues {
+			c.put(kv.Key, kv.Value)
+		}
+	case state.EventTypeDelete:
+		for _, kv := range event.KeyValues {
+			c.delete(kv.Key)
+		}
+	}
+}
+
+// put puts the user/role/api key into cache by key's path, must hold the write lock
+func (c *userCache) put(key string, value []byte) {
+	var err error
+	switch {
+	case strings.HasPrefix(key, constants.UserPath+"/"):
+		user := &models.User{}
+		if err = json.Unmarshal(value, user); err == nil {
+			c.users[user.Name] = user
+		}
+	case strings.HasPrefix(key, constants.RolePath+"/"):
+		role := &models.Role{}
+		if err = json.Unmarshal(value, role); err == nil {
+			c.roles[role.Name] = role
+		}
+	case strings.HasPrefix(key, constants.APIKeyPath+"/"):
+		apiKey := &models.APIKey{}
+		if err = json.Unmarshal(value, apiKey); err == nil {
+			c.apiKeys[apiKey.ID] = apiKey
+		}
+	}
+	if err != nil {
+		c.logger.Warn("unmarshal data error",
+			logger.String("key", key), logger.String("data", string(value)))
+	}
+}
+
+// delete deletes the user/role/api key from cache by key's path, must hold the write lock
+func (c *userCache) delete(key string) {
+	name := key[strings.LastIndex(key, "/")+1:]
+	switch {
+	case strings.HasPrefix(key, constants.UserPath+"/"):
+		delete(c.users, name)
+	case strings.HasPrefix(key, constants.RolePath+"/"):
+		delete(c.roles, name)
+	case strings.HasPrefix(key, constants.APIKeyPath+"/"):
+		delete(c.apiKeys, name)
+	}
+}
+
+// getUser returns the copy of user by name, loaded is false if cache not loaded yet
+func (c *userCache) getUser(name string) (user *models.User, ok, loaded bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if !c.loaded {
+		return nil, false, false
+	}
+	exist, ok := c.users[name]
+	if !ok {
+		return nil, false, true
+	}
+	u := *exist
+	return &u, true, true
+}
+
+// getRole returns the role by name, loaded is false if cache not loaded yet
+func (c *userCache) getRole(name string) (role *models.Role, ok, loaded bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if !c.loaded {
+		return nil, false, false
+	}
+	exist, ok := c.roles[name]
+	if !ok {
+		return nil, false, true
+	}
+	r := *exist
+	return &r, true, true
+}
+
+// getAPIKey returns the api key by id, loaded is false if cache not loaded yet
+func (c *userCache) getAPIKey(id string) (apiKey *models.APIKey, ok, loaded bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if !c.loaded {
+		return nil, false, false
+	}
+	exist, ok := c.apiKeys[id]
+	if !ok {
+		return nil, false, true
+	}
+	k := *exist
+	return &k, true, true
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/state"
+)
+
+func TestUserCache_handleEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	eventCh := make(chan *state.Event)
+	repo.EXPECT().WatchPrefix(gomock.Any(), authPath, true).Return(state.WatchEventChan(eventCh))
+	cache := newUserCache(context.TODO(), repo)
+
+	// not loaded
+	_, _, loaded := cache.getUser("test")
+	assert.False(t, loaded)
+	_, _, loaded = cache.getRole("dev")
+	assert.False(t, loaded)
+	_, _, loaded = cache.getAPIKey("id")
+	assert.False(t, loaded)
+
+	// load all
+	eventCh <- &state.Event{Type: state.EventTypeAll, KeyValues: []state.EventKeyValue{
+		{Key: constants.GetUserPath("test"), Value: encodeJSON(&models.User{Name: "test", Roles: []string{"dev"}})},
+		{Key: constants.GetRolePath("dev"), Value: encodeJSON(&models.Role{Name: "dev"})},
+		{Key: constants.GetAPIKeyPath("id"), Value: encodeJSON(&models.APIKey{ID: "id", UserName: "test"})},
+		{Key: constants.GetRolePath("err"), Value: []byte{1, 2, 3}},
+	}}
+	eventCh <- &state.Event{Err: fmt.Errorf("err")}
+	user, ok, loaded := cache.getUser("test")
+	assert.True(t, loaded)
+	assert.True(t, ok)
+	assert.Equal(t, "test", user.Name)
+	role, ok, _ := cache.getRole("dev")
+	assert.True(t, ok)
+	assert.Equal(t, "dev", role.Name)
+	apiKey, ok, _ := cache.getAPIKey("id")
+	assert.True(t, ok)
+	assert.Equal(t, "test", apiKey.UserName)
+	_, ok, _ = cache.getRole("err")
+	assert.False(t, ok)
+
+	// modify/delete
+	eventCh <- &state.Event{Type: state.EventTypeModify, KeyValues: []state.EventKeyValue{
+		{Key: constants.GetUserPath("test2"), Value: encodeJSON(&models.User{Name: "test2"})},
+	}}
+	eventCh <- &state.Event{Type: state.EventTypeDelete, KeyValues: []state.EventKeyValue{
+		{Key: constants.GetUserPath("test")},
+		{Key: constants.GetRolePath("dev")},
+		{Key: constants.GetAPIKeyPath("id")},
+	}}
+	close(eventCh)
+	time.Sleep(10 * time.Millisecond)
+	_, ok, _ = cache.getUser("test2")
+	assert.True(t, ok)
+	_, ok, _ = cache.getUser("test")
+	assert.False(t, ok)
+	_, ok, _ = cache.getRole("dev")
+	assert.False(t, ok)
+	_, ok, _ = cache.getAPIKey("id")
+	assert.False(t, ok)
+}
+
+func TestCachedUserService(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	eventCh := make(chan *state.Event)
+	repo.EXPECT().WatchPrefix(gomock.Any(), authPath, true).Return(state.WatchEventChan(eventCh))
+	srv := NewCachedUserService(context.TODO(), repo)
+
+	// cache not loaded, reads state repo
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("dev")).Return(encodeJSON(&models.Role{Name: "dev"}), nil)
+	role, err := srv.GetRole("dev")
+	assert.NoError(t, err)
+	assert.Equal(t, "dev", role.Name)
+
+	eventCh <- &state.Event{Type: state.EventTypeAll, KeyValues: []state.EventKeyValue{
+		{Key: constants.GetUserPath("test"), Value: encodeJSON(&models.User{Name: "test", Roles: []string{"dev"}})},
+		{Key: constants.GetRolePath("dev"), Value: encodeJSON(&models.Role{Name: "dev",
+			Privileges: []models.Privilege{{Database: "db", Permission: models.ReadPermission}}})},
+		{Key: constants.GetAPIKeyPath(hashAPIKey("key")), Value: encodeJSON(&models.APIKey{ID: hashAPIKey("key"), UserName: "test"})},
+	}}
+	close(eventCh)
+	time.Sleep(10 * time.Millisecond)
+
+	// cache loaded, no need to read state repo
+	user, err := srv.GetUser("test")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", user.Name)
+	_, err = srv.GetUser("test2")
+	assert.Equal(t, state.ErrNotExist, err)
+	_, err = srv.GetRole("test2")
+	assert.Equal(t, state.ErrNotExist, err)
+	user, err = srv.AuthenticateAPIKey("key")
+	assert.NoError(t, err)
+	assert.Equal(t, "test", user.Name)
+	_, err = srv.AuthenticateAPIKey("key2")
+	assert.Equal(t, ErrAPIKeyInvalid, err)
+	ok, err := srv.Authorize(user, "db", models.ReadPermission)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
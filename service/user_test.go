@@ -0,0 +1,280 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/state"
+)
+
+func TestUserService_User(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		generateFromPasswordFn = bcrypt.GenerateFromPassword
+		ctrl.Finish()
+	}()
+
+	repo := state.NewMockRepository(ctrl)
+	srv := NewUserService(repo)
+
+	// case 1: save new user, password is hashed
+	var userData []byte
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("dev")).Return(encodeJSON(&models.Role{Name: "dev"}), nil)
+	repo.EXPECT().Put(gomock.Any(), constants.GetUserPath("test"), gomock.Any()).
+		DoAndReturn(func(_ interface{}, _ string, data []byte) error {
+			userData = data
+			return nil
+		})
+	err := srv.SaveUser(&models.User{Name: "test", Password: "123", Roles: []string{"dev"}})
+	assert.NoError(t, err)
+	user := &models.User{}
+	_ = json.Unmarshal(userData, user)
+	assert.Empty(t, user.Password)
+	assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte("123")))
+
+	// case 2: update user without password, keeps the password
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("test")).Return(userData, nil)
+	repo.EXPECT().Put(gomock.Any(), constants.GetUserPath("test"), gomock.Any()).
+		DoAndReturn(func(_ interface{}, _ string, data []byte) error {
+			user2 := &models.User{}
+			_ = json.Unmarshal(data, user2)
+			assert.Equal(t, user.PasswordHash, user2.PasswordHash)
+			assert.Empty(t, user2.Roles)
+			return nil
+		})
+	err = srv.SaveUser(&models.User{Name: "test"})
+	assert.NoError(t, err)
+
+	// case 3: save user fail
+	err = srv.SaveUser(&models.User{})
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("test")).Return(nil, state.ErrNotExist)
+	err = srv.SaveUser(&models.User{Name: "test"})
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("test")).Return(nil, fmt.Errorf("err"))
+	err = srv.SaveUser(&models.User{Name: "test"})
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("dev")).Return(nil, state.ErrNotExist)
+	err = srv.SaveUser(&models.User{Name: "test", Password: "123", Roles: []string{"dev"}})
+	assert.Error(t, err)
+	generateFromPasswordFn = func(password []byte, cost int) ([]byte, error) {
+		return nil, fmt.Errorf("err")
+	}
+	err = srv.SaveUser(&models.User{Name: "test", Password: "123"})
+	assert.Error(t, err)
+
+	// case 4: get user
+	_, err = srv.GetUser("")
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("test")).Return([]byte{1, 2, 3}, nil)
+	_, err = srv.GetUser("test")
+	assert.Error(t, err)
+
+	// case 5: list users
+	repo.EXPECT().List(gomock.Any(), constants.UserPath).Return([]state.KeyValue{
+		{Key: "test", Value: userData},
+		{Key: "wrong", Value: []byte{1, 2, 3}},
+	}, nil)
+	users, err := srv.ListUsers()
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.User{user}, users)
+	repo.EXPECT().List(gomock.Any(), constants.UserPath).Return(nil, fmt.Errorf("err"))
+	_, err = srv.ListUsers()
+	assert.Error(t, err)
+
+	// case 6: delete user
+	assert.Error(t, srv.DeleteUser(""))
+	repo.EXPECT().Delete(gomock.Any(), constants.GetUserPath("test")).Return(nil)
+	assert.NoError(t, srv.DeleteUser("test"))
+}
+
+func TestUserService_Role(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	srv := NewUserService(repo)
+
+	role := &models.Role{Name: "dev", Privileges: []models.Privilege{{Database: "db", Permission: models.WritePermission}}}
+	repo.EXPECT().Put(gomock.Any(), constants.GetRolePath("dev"), encodeJSON(role)).Return(nil)
+	assert.NoError(t, srv.SaveRole(role))
+	assert.Error(t, srv.SaveRole(&models.Role{}))
+	assert.Error(t, srv.SaveRole(&models.Role{Name: "dev", Privileges: []models.Privilege{{Permission: models.ReadPermission}}}))
+	assert.Error(t, srv.SaveRole(&models.Role{Name: "dev", Privileges: []models.Privilege{{Database: "db", Permission: "all"}}}))
+
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("dev")).Return(encodeJSON(role), nil)
+	role1, err := srv.GetRole("dev")
+	assert.NoError(t, err)
+	assert.Equal(t, role, role1)
+	_, err = srv.GetRole("")
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("dev")).Return([]byte{1, 2, 3}, nil)
+	_, err = srv.GetRole("dev")
+	assert.Error(t, err)
+
+	repo.EXPECT().List(gomock.Any(), constants.RolePath).Return([]state.KeyValue{
+		{Key: "dev", Value: encodeJSON(role)},
+		{Key: "wrong", Value: []byte{1, 2, 3}},
+	}, nil)
+	roles, err := srv.ListRoles()
+	assert.NoError(t, err)
+	assert.Equal(t, []*models.Role{role}, roles)
+
+	assert.Error(t, srv.DeleteRole(""))
+	repo.EXPECT().Delete(gomock.Any(), constants.GetRolePath("dev")).Return(nil)
+	assert.NoError(t, srv.DeleteRole("dev"))
+}
+
+func TestUserService_APIKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		randReadFunc = rand.Read
+		ctrl.Finish()
+	}()
+
+	repo := state.NewMockRepository(ctrl)
+	srv := NewUserService(repo)
+
+	user := &models.User{Name: "agent"}
+	// case 1: create api key
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("agent")).Return(encodeJSON(user), nil)
+	var apiKeyData []byte
+	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ interface{}, _ string, data []byte) error {
+			apiKeyData = data
+			return nil
+		})
+	apiKey, err := srv.CreateAPIKey("agent")
+	assert.NoError(t, err)
+	assert.Len(t, apiKey.Key, apiKeyLength*2)
+	assert.Equal(t, hashAPIKey(apiKey.Key), apiKey.ID)
+	assert.Equal(t, "agent", apiKey.UserName)
+	// plaintext key isn't stored
+	assert.NotContains(t, string(apiKeyData), apiKey.Key)
+
+	// case 2: create api key fail
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("agent")).Return(nil, state.ErrNotExist)
+	_, err = srv.CreateAPIKey("agent")
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("agent")).Return(encodeJSON(user), nil)
+	repo.EXPECT().Put(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("err"))
+	_, err = srv.CreateAPIKey("agent")
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("agent")).Return(encodeJSON(user), nil)
+	randReadFunc = func(b []byte) (n int, err error) {
+		return 0, fmt.Errorf("err")
+	}
+	_, err = srv.CreateAPIKey("agent")
+	assert.Error(t, err)
+
+	// case 3: authenticate api key
+	_, err = srv.AuthenticateAPIKey("")
+	assert.Equal(t, ErrAPIKeyInvalid, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetAPIKeyPath(apiKey.ID)).Return(apiKeyData, nil)
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("agent")).Return(encodeJSON(user), nil)
+	user1, err := srv.AuthenticateAPIKey(apiKey.Key)
+	assert.NoError(t, err)
+	assert.Equal(t, user, user1)
+	repo.EXPECT().Get(gomock.Any(), constants.GetAPIKeyPath(apiKey.ID)).Return(apiKeyData, nil)
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("agent")).Return(nil, state.ErrNotExist)
+	_, err = srv.AuthenticateAPIKey(apiKey.Key)
+	assert.Equal(t, ErrAPIKeyInvalid, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetAPIKeyPath(apiKey.ID)).Return(apiKeyData, nil)
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("agent")).Return(nil, fmt.Errorf("err"))
+	_, err = srv.AuthenticateAPIKey(apiKey.Key)
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetAPIKeyPath(apiKey.ID)).Return(nil, state.ErrNotExist)
+	_, err = srv.AuthenticateAPIKey(apiKey.Key)
+	assert.Equal(t, ErrAPIKeyInvalid, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetAPIKeyPath(apiKey.ID)).Return(nil, fmt.Errorf("err"))
+	_, err = srv.AuthenticateAPIKey(apiKey.Key)
+	assert.Error(t, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetAPIKeyPath(apiKey.ID)).Return([]byte{1, 2, 3}, nil)
+	_, err = srv.AuthenticateAPIKey(apiKey.Key)
+	assert.Error(t, err)
+
+	// case 4: list/delete api key
+	repo.EXPECT().List(gomock.Any(), constants.APIKeyPath).Return([]state.KeyValue{
+		{Key: apiKey.ID, Value: apiKeyData},
+		{Key: "wrong", Value: []byte{1, 2, 3}},
+	}, nil)
+	apiKeys, err := srv.ListAPIKeys()
+	assert.NoError(t, err)
+	assert.Len(t, apiKeys, 1)
+	assert.Empty(t, apiKeys[0].Key)
+	assert.Error(t, srv.DeleteAPIKey(""))
+	repo.EXPECT().Delete(gomock.Any(), constants.GetAPIKeyPath(apiKey.ID)).Return(nil)
+	assert.NoError(t, srv.DeleteAPIKey(apiKey.ID))
+}
+
+func TestUserService_Authenticate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	srv := NewUserService(repo)
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte("123"), bcrypt.MinCost)
+	user := &models.User{Name: "test", PasswordHash: string(hash)}
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("test")).Return(encodeJSON(user), nil).Times(2)
+	user1, err := srv.Authenticate("test", "123")
+	assert.NoError(t, err)
+	assert.Equal(t, user, user1)
+	_, err = srv.Authenticate("test", "1234")
+	assert.Equal(t, ErrUserNameOrPasswordInvalid, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("test")).Return(nil, state.ErrNotExist)
+	_, err = srv.Authenticate("test", "123")
+	assert.Equal(t, ErrUserNameOrPasswordInvalid, err)
+	repo.EXPECT().Get(gomock.Any(), constants.GetUserPath("test")).Return(nil, fmt.Errorf("err"))
+	_, err = srv.Authenticate("test", "123")
+	assert.Error(t, err)
+}
+
+func TestUserService_Authorize(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := state.NewMockRepository(ctrl)
+	srv := NewUserService(repo)
+
+	// super user
+	ok, err := srv.Authorize(&models.User{SuperUser: true}, "", models.AdminPermission)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	user := &models.User{Name: "test", Roles: []string{"deleted", "reader", "writer"}}
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("deleted")).Return(nil, state.ErrNotExist).AnyTimes()
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("reader")).Return(encodeJSON(&models.Role{Name: "reader",
+		Privileges: []models.Privilege{{Database: models.AllDatabases, Permission: models.ReadPermission}}}), nil).AnyTimes()
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("writer")).Return(encodeJSON(&models.Role{Name: "writer",
+		Privileges: []models.Privilege{{Database: "db", Permission: models.WritePermission}}}), nil).AnyTimes()
+	ok, err = srv.Authorize(user, "other", models.ReadPermission)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = srv.Authorize(user, "db", models.WritePermission)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = srv.Authorize(user, "other", models.WritePermission)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+	ok, err = srv.Authorize(user, "db", models.AdminPermission)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	repo.EXPECT().Get(gomock.Any(), constants.GetRolePath("error")).Return(nil, fmt.Errorf("err"))
+	_, err = srv.Authorize(&models.User{Name: "test", Roles: []string{"error"}}, "db", models.ReadPermission)
+	assert.Error(t, err)
+}
+
+func encodeJSON(v interface{}) []byte {
+	data, _ := json.Marshal(v)
+	return data
+}
@@ -148,7 +148,7 @@ func (b *baseStmtParser) completeTagFilterExpr() {
 	b.condition = e
 }
 
-// setExprParam sets expr's param(call,paren,binary)
+// setExprParam sets expr's param(call,paren,binary,order by)
 func (b *baseStmtParser) setExprParam(param stmt.Expr) {
 	if b.exprStack.Empty() {
 		return
@@ -159,6 +159,8 @@ func (b *baseStmtParser) setExprParam(param stmt.Expr) {
 		expr.Params = append(expr.Params, param)
 	case *stmt.ParenExpr:
 		expr.Expr = param
+	case *stmt.OrderByExpr:
+		expr.Expr = param
 	case *stmt.BinaryExpr:
 		if expr.Left == nil {
 			expr.Left = param
@@ -0,0 +1,106 @@
+package sql
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lindb/lindb/sql/grammar"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+const (
+	deleteKeyword = "delete"
+	// deleteSelectPrefix is the select clause for rewriting delete statement to query statement
+	deleteSelectPrefix = "select f "
+	dropKeyword        = "drop"
+	metricKeyword      = "metric"
+	onKeyword          = "on"
+)
+
+// isDeleteStmt checks if the sql is delete statement
+func isDeleteStmt(sql string) bool {
+	sql = strings.TrimSpace(sql)
+	return len(sql) > len(deleteKeyword) &&
+		strings.EqualFold(sql[:len(deleteKeyword)], deleteKeyword) &&
+		strings.TrimSpace(sql[len(deleteKeyword):len(deleteKeyword)+1]) == ""
+}
+
+// rewriteDeleteStmt rewrites delete statement(delete from cpu where ...) to query statement(select f from cpu where ...),
+// because the grammar generated by antlr doesn't define delete statement,
+// so re-uses the from/where clause of query statement for parsing delete statement.
+func rewriteDeleteStmt(sql string) string {
+	sql = strings.TrimSpace(sql)
+	return deleteSelectPrefix + sql[len(deleteKeyword):]
+}
+
+// isDropMetricStmt checks if the sql is drop metric statement
+func isDropMetricStmt(sql string) bool {
+	words := strings.Fields(sql)
+	return len(words) > 2 && strings.EqualFold(words[0], dropKeyword) && strings.EqualFold(words[1], metricKeyword)
+}
+
+// rewriteDropMetricStmt rewrites drop metric statement(drop metric [on 'ns'] cpu) to
+// query statement(select f [on 'ns'] from cpu), only supports the metric name and optional namespace.
+func rewriteDropMetricStmt(sql string) (string, error) {
+	words := strings.Fields(sql)[2:]
+	switch {
+	case len(words) == 1:
+		return deleteSelectPrefix + "from " + words[0], nil
+	case len(words) == 3 && strings.EqualFold(words[0], onKeyword):
+		return deleteSelectPrefix + "on " + words[1] + " from " + words[2], nil
+	default:
+		return "", fmt.Errorf("invalid drop metric statement, only supports: drop metric [on namespace] metric")
+	}
+}
+
+// deleteListener represents the listener for delete/drop metric statement,
+// only supports the from/where(tag filter condition) clause of query statement.
+type deleteListener struct {
+	listener
+	unsupported string
+	dropMetric  bool
+}
+
+// EnterTimeRangeExpr is called when production timeRangeExpr is entered.
+func (l *deleteListener) EnterTimeRangeExpr(ctx *grammar.TimeRangeExprContext) {
+	l.unsupported = "time range"
+}
+
+// EnterGroupByClause is called when production groupByClause is entered.
+func (l *deleteListener) EnterGroupByClause(ctx *grammar.GroupByClauseContext) {
+	l.unsupported = "group by"
+}
+
+// EnterOrderByClause is called when production orderByClause is entered.
+func (l *deleteListener) EnterOrderByClause(ctx *grammar.OrderByClauseContext) {
+	l.unsupported = "order by"
+}
+
+// EnterHavingClause is called when production havingClause is entered.
+func (l *deleteListener) EnterHavingClause(ctx *grammar.HavingClauseContext) {
+	l.unsupported = "having"
+}
+
+// EnterLimitClause is called when production limitClause is entered.
+func (l *deleteListener) EnterLimitClause(ctx *grammar.LimitClauseContext) {
+	l.unsupported = "limit"
+}
+
+// statement returns delete statement, if failure return error
+func (l *deleteListener) statement() (stmt.Statement, error) {
+	if len(l.unsupported) > 0 {
+		return nil, fmt.Errorf("delete statement not support %s clause", l.unsupported)
+	}
+	if l.stmt == nil {
+		return nil, fmt.Errorf("invalid delete statement")
+	}
+	if err := l.stmt.validation(); err != nil {
+		return nil, err
+	}
+	return &stmt.Delete{
+		Namespace:  l.stmt.namespace,
+		MetricName: l.stmt.metricName,
+		Condition:  l.stmt.condition,
+		DropMetric: l.dropMetric,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestDeleteStmt_Parse(t *testing.T) {
+	// case 1: delete all series of metric
+	s, err := Parse("delete from cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.Delete{Namespace: constants.DefaultNamespace, MetricName: "cpu"}, s)
+	// case 2: delete series with tag filter
+	s, err = Parse(" DELETE on 'ns' from 'cpu' where host='1.1.1.1' and zone in ('sh','bj')")
+	assert.NoError(t, err)
+	deleteStmt := s.(*stmt.Delete)
+	assert.Equal(t, "ns", deleteStmt.Namespace)
+	assert.Equal(t, "cpu", deleteStmt.MetricName)
+	assert.Equal(t, &stmt.BinaryExpr{
+		Left:     &stmt.EqualsExpr{Key: "host", Value: "1.1.1.1"},
+		Operator: stmt.AND,
+		Right:    &stmt.InExpr{Key: "zone", Values: []string{"sh", "bj"}},
+	}, deleteStmt.Condition)
+	// case 3: unsupported clause
+	for _, sql := range []string{
+		"delete from cpu where time>now()-1h",
+		"delete from cpu group by host",
+		"delete from cpu order by f",
+		"delete from cpu limit 10",
+	} {
+		s, err = Parse(sql)
+		assert.Error(t, err)
+		assert.Nil(t, s)
+	}
+	// case 4: invalid statement
+	s, err = Parse("delete cpu")
+	assert.Error(t, err)
+	assert.Nil(t, s)
+}
+
+func TestDropMetricStmt_Parse(t *testing.T) {
+	// case 1: drop metric
+	s, err := Parse("drop metric cpu")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.Delete{Namespace: constants.DefaultNamespace, MetricName: "cpu", DropMetric: true}, s)
+	// case 2: drop metric with namespace
+	s, err = Parse(" DROP  Metric on 'ns' 'cpu'")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.Delete{Namespace: "ns", MetricName: "cpu", DropMetric: true}, s)
+	// case 3: invalid statement
+	for _, sql := range []string{
+		"drop metric cpu where host='1.1.1.1'",
+		"drop metric from cpu",
+		"drop metric on ns",
+		"drop metric on ns from cpu",
+	} {
+		s, err = Parse(sql)
+		assert.Error(t, err)
+		assert.Nil(t, s)
+	}
+}
+
+func TestIsDropMetricStmt(t *testing.T) {
+	assert.True(t, isDropMetricStmt("drop metric cpu"))
+	assert.True(t, isDropMetricStmt(" Drop\tMETRIC cpu"))
+	assert.False(t, isDropMetricStmt("drop metric"))
+	assert.False(t, isDropMetricStmt("drop metrics cpu"))
+	assert.False(t, isDropMetricStmt("delete from cpu"))
+}
+
+func TestIsDeleteStmt(t *testing.T) {
+	assert.True(t, isDeleteStmt("delete from cpu"))
+	assert.True(t, isDeleteStmt(" Delete\tfrom cpu"))
+	assert.False(t, isDeleteStmt("delete"))
+	assert.False(t, isDeleteStmt("deleted from cpu"))
+	assert.False(t, isDeleteStmt("select f from cpu"))
+}
+
+func TestDeleteListener_statement(t *testing.T) {
+	l := deleteListener{}
+	s, err := l.statement()
+	assert.Error(t, err)
+	assert.Nil(t, s)
+	l.stmt = newQueryStmtParse(false)
+	s, err = l.statement()
+	assert.Error(t, err)
+	assert.Nil(t, s)
+}
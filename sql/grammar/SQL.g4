@@ -9,6 +9,8 @@ statementList           : showDatabaseStmt
                           | showFieldsStmt
                           | showTagKeysStmt
                           | showTagValuesStmt
+                          | showQueriesStmt
+                          | killQueryStmt
                           | queryStmt;
 //meta data query statement
 showDatabaseStmt     : T_SHOW T_DATASBAES ;
@@ -21,6 +23,10 @@ prefix               : ident ;
 withTagKey           : ident ;
 namespace            : ident ;
 
+//query job statement
+showQueriesStmt      : T_SHOW T_QUERIES ;
+killQueryStmt        : T_KILL T_QUERY L_INT ;
+
 //data query plan
 queryStmt               : T_EXPLAIN? selectExpr (T_ON namespace)? fromClause whereClause? groupByClause? orderByClause? limitClause? T_WITH_VALUE?;
 selectExpr              : T_SELECT fields;
@@ -104,7 +110,11 @@ intervalItem            :
                          | T_YEAR
                          ;
 exprFunc                : funcName T_OPEN_P exprFuncParams? T_CLOSE_P ;
-funcName                : T_SUM | T_MIN | T_MAX | T_AVG | T_COUNT | T_STDDEV | T_HISTOGRAM;
+funcName                :
+                           T_SUM | T_MIN | T_MAX | T_AVG | T_COUNT | T_STDDEV | T_HISTOGRAM
+                         | T_QUANTILE | T_RATE | T_IRATE | T_DERIVATIVE | T_MOVING_AVERAGE
+                         | T_ABS | T_LOG | T_TIME_SHIFT | T_TOP | T_BOTTOM
+                         ;
 exprFuncParams          : funcParam (T_COMMA funcParam)* ;
 funcParam               :
                            fieldExpr
@@ -190,6 +200,16 @@ nonReservedWords      :
                         | T_AVG
                         | T_STDDEV
                         | T_HISTOGRAM
+                        | T_QUANTILE
+                        | T_RATE
+                        | T_IRATE
+                        | T_DERIVATIVE
+                        | T_MOVING_AVERAGE
+                        | T_ABS
+                        | T_LOG
+                        | T_TIME_SHIFT
+                        | T_TOP
+                        | T_BOTTOM
                         ;
 
 // Lexer rules
@@ -264,6 +284,15 @@ T_COUNT              : C O U N T                        ;
 T_AVG                : A V G                            ;
 T_STDDEV             : S T D D E V                      ;
 T_HISTOGRAM          : H I S T O G R A M                ;
+T_QUANTILE           : Q U A N T I L E                  ;
+T_RATE               : R A T E                          ;
+T_IRATE              : I R A T E                        ;
+T_DERIVATIVE         : D E R I V A T I V E              ;
+T_MOVING_AVERAGE     : M O V I N G '_' A V E R A G E    ;
+T_ABS                : A B S                            ;
+T_TIME_SHIFT         : T I M E '_' S H I F T            ;
+T_TOP                : T O P                            ;
+T_BOTTOM             : B O T T O M                      ;
 
 //time unit
 T_SECOND             : S                                ;
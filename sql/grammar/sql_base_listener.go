@@ -86,6 +86,18 @@ func (s *BaseSQLListener) EnterNamespace(ctx *NamespaceContext) {}
 // ExitNamespace is called when production namespace is exited.
 func (s *BaseSQLListener) ExitNamespace(ctx *NamespaceContext) {}
 
+// EnterShowQueriesStmt is called when production showQueriesStmt is entered.
+func (s *BaseSQLListener) EnterShowQueriesStmt(ctx *ShowQueriesStmtContext) {}
+
+// ExitShowQueriesStmt is called when production showQueriesStmt is exited.
+func (s *BaseSQLListener) ExitShowQueriesStmt(ctx *ShowQueriesStmtContext) {}
+
+// EnterKillQueryStmt is called when production killQueryStmt is entered.
+func (s *BaseSQLListener) EnterKillQueryStmt(ctx *KillQueryStmtContext) {}
+
+// ExitKillQueryStmt is called when production killQueryStmt is exited.
+func (s *BaseSQLListener) ExitKillQueryStmt(ctx *KillQueryStmtContext) {}
+
 // EnterQueryStmt is called when production queryStmt is entered.
 func (s *BaseSQLListener) EnterQueryStmt(ctx *QueryStmtContext) {}
 
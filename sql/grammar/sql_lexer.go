@@ -13,7 +13,7 @@ var _ = unicode.IsLetter
 
 
 var serializedLexerAtn = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 105, 902, 
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 2, 114, 992, 
 	8, 1, 4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 
 	9, 7, 4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 
 	4, 13, 9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 
@@ -40,7 +40,9 @@ var serializedLexerAtn = []uint16{
 	4, 120, 9, 120, 4, 121, 9, 121, 4, 122, 9, 122, 4, 123, 9, 123, 4, 124, 
 	9, 124, 4, 125, 9, 125, 4, 126, 9, 126, 4, 127, 9, 127, 4, 128, 9, 128, 
 	4, 129, 9, 129, 4, 130, 9, 130, 4, 131, 9, 131, 4, 132, 9, 132, 4, 133, 
-	9, 133, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 
+	9, 133, 4, 134, 9, 134, 4, 135, 9, 135, 4, 136, 9, 136, 4, 137, 9, 137, 
+	4, 138, 9, 138, 4, 139, 9, 139, 4, 140, 9, 140, 4, 141, 9, 141, 4, 142, 
+	9, 142, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 
 	3, 3, 3, 3, 3, 3, 3, 3, 4, 3, 4, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 
 	5, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 6, 3, 7, 3, 7, 3, 
 	7, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9, 3, 
@@ -80,345 +82,383 @@ var serializedLexerAtn = []uint16{
 	3, 65, 3, 66, 3, 66, 3, 66, 3, 66, 3, 67, 3, 67, 3, 67, 3, 67, 3, 67, 3, 
 	67, 3, 68, 3, 68, 3, 68, 3, 68, 3, 69, 3, 69, 3, 69, 3, 69, 3, 69, 3, 69, 
 	3, 69, 3, 70, 3, 70, 3, 70, 3, 70, 3, 70, 3, 70, 3, 70, 3, 70, 3, 70, 3, 
-	70, 3, 71, 3, 71, 3, 72, 3, 72, 3, 73, 3, 73, 3, 74, 3, 74, 3, 75, 3, 75, 
-	3, 76, 3, 76, 3, 77, 3, 77, 3, 78, 3, 78, 3, 79, 3, 79, 3, 80, 3, 80, 3, 
-	81, 3, 81, 3, 81, 3, 82, 3, 82, 3, 82, 3, 83, 3, 83, 3, 84, 3, 84, 3, 84, 
-	3, 85, 3, 85, 3, 86, 3, 86, 3, 86, 3, 87, 3, 87, 3, 87, 3, 88, 3, 88, 3, 
-	88, 3, 89, 3, 89, 3, 90, 3, 90, 3, 91, 3, 91, 3, 92, 3, 92, 3, 93, 3, 93, 
-	3, 94, 3, 94, 3, 95, 3, 95, 3, 96, 3, 96, 3, 97, 3, 97, 3, 98, 3, 98, 3, 
-	99, 3, 99, 3, 100, 3, 100, 3, 101, 3, 101, 3, 102, 6, 102, 763, 10, 102, 
-	13, 102, 14, 102, 764, 3, 103, 6, 103, 768, 10, 103, 13, 103, 14, 103, 
-	769, 3, 103, 3, 103, 3, 103, 7, 103, 775, 10, 103, 12, 103, 14, 103, 778, 
-	11, 103, 3, 103, 3, 103, 6, 103, 782, 10, 103, 13, 103, 14, 103, 783, 5, 
-	103, 786, 10, 103, 3, 104, 6, 104, 789, 10, 104, 13, 104, 14, 104, 790, 
-	3, 104, 3, 104, 3, 105, 3, 105, 3, 106, 3, 106, 3, 107, 3, 107, 3, 107, 
-	3, 107, 7, 107, 803, 10, 107, 12, 107, 14, 107, 806, 11, 107, 3, 107, 3, 
-	107, 3, 107, 7, 107, 811, 10, 107, 12, 107, 14, 107, 814, 11, 107, 3, 107, 
-	3, 107, 3, 107, 3, 107, 3, 107, 6, 107, 821, 10, 107, 13, 107, 14, 107, 
-	822, 3, 107, 3, 107, 7, 107, 827, 10, 107, 12, 107, 14, 107, 830, 11, 107, 
-	3, 107, 3, 107, 3, 107, 7, 107, 835, 10, 107, 12, 107, 14, 107, 838, 11, 
-	107, 3, 107, 3, 107, 3, 107, 7, 107, 843, 10, 107, 12, 107, 14, 107, 846, 
-	11, 107, 3, 107, 5, 107, 849, 10, 107, 3, 108, 3, 108, 3, 109, 3, 109, 
-	3, 110, 3, 110, 3, 111, 3, 111, 3, 112, 3, 112, 3, 113, 3, 113, 3, 114, 
-	3, 114, 3, 115, 3, 115, 3, 116, 3, 116, 3, 117, 3, 117, 3, 118, 3, 118, 
-	3, 119, 3, 119, 3, 120, 3, 120, 3, 121, 3, 121, 3, 122, 3, 122, 3, 123, 
-	3, 123, 3, 124, 3, 124, 3, 125, 3, 125, 3, 126, 3, 126, 3, 127, 3, 127, 
-	3, 128, 3, 128, 3, 129, 3, 129, 3, 130, 3, 130, 3, 131, 3, 131, 3, 132, 
-	3, 132, 3, 133, 3, 133, 6, 812, 828, 836, 844, 2, 134, 3, 3, 5, 4, 7, 5, 
-	9, 6, 11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12, 23, 13, 25, 14, 27, 
-	15, 29, 16, 31, 17, 33, 18, 35, 19, 37, 20, 39, 21, 41, 22, 43, 23, 45, 
-	24, 47, 25, 49, 26, 51, 27, 53, 28, 55, 29, 57, 30, 59, 31, 61, 32, 63, 
-	33, 65, 34, 67, 35, 69, 36, 71, 37, 73, 38, 75, 39, 77, 40, 79, 41, 81, 
-	42, 83, 43, 85, 44, 87, 45, 89, 46, 91, 47, 93, 48, 95, 49, 97, 50, 99, 
-	51, 101, 52, 103, 53, 105, 54, 107, 55, 109, 56, 111, 57, 113, 58, 115, 
-	59, 117, 60, 119, 61, 121, 62, 123, 63, 125, 64, 127, 65, 129, 66, 131, 
-	67, 133, 68, 135, 69, 137, 70, 139, 71, 141, 72, 143, 73, 145, 74, 147, 
-	75, 149, 76, 151, 77, 153, 78, 155, 79, 157, 80, 159, 81, 161, 82, 163, 
-	83, 165, 84, 167, 85, 169, 86, 171, 87, 173, 88, 175, 89, 177, 90, 179, 
-	91, 181, 92, 183, 93, 185, 94, 187, 95, 189, 96, 191, 97, 193, 98, 195, 
-	99, 197, 100, 199, 101, 201, 102, 203, 103, 205, 104, 207, 105, 209, 2, 
-	211, 2, 213, 2, 215, 2, 217, 2, 219, 2, 221, 2, 223, 2, 225, 2, 227, 2, 
-	229, 2, 231, 2, 233, 2, 235, 2, 237, 2, 239, 2, 241, 2, 243, 2, 245, 2, 
-	247, 2, 249, 2, 251, 2, 253, 2, 255, 2, 257, 2, 259, 2, 261, 2, 263, 2, 
-	265, 2, 3, 2, 34, 3, 2, 48, 48, 5, 2, 11, 12, 15, 15, 34, 34, 3, 2, 50, 
-	59, 4, 2, 67, 92, 99, 124, 4, 2, 48, 48, 97, 97, 6, 2, 37, 38, 60, 60, 
-	66, 66, 97, 97, 4, 2, 67, 67, 99, 99, 4, 2, 68, 68, 100, 100, 4, 2, 69, 
-	69, 101, 101, 4, 2, 70, 70, 102, 102, 4, 2, 71, 71, 103, 103, 4, 2, 72, 
-	72, 104, 104, 4, 2, 73, 73, 105, 105, 4, 2, 74, 74, 106, 106, 4, 2, 75, 
-	75, 107, 107, 4, 2, 76, 76, 108, 108, 4, 2, 77, 77, 109, 109, 4, 2, 78, 
-	78, 110, 110, 4, 2, 79, 79, 111, 111, 4, 2, 80, 80, 112, 112, 4, 2, 81, 
-	81, 113, 113, 4, 2, 82, 82, 114, 114, 4, 2, 83, 83, 115, 115, 4, 2, 84, 
-	84, 116, 116, 4, 2, 85, 85, 117, 117, 4, 2, 86, 86, 118, 118, 4, 2, 87, 
-	87, 119, 119, 4, 2, 88, 88, 120, 120, 4, 2, 89, 89, 121, 121, 4, 2, 90, 
-	90, 122, 122, 4, 2, 91, 91, 123, 123, 4, 2, 92, 92, 124, 124, 2, 893, 2, 
-	3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3, 2, 2, 2, 2, 9, 3, 2, 2, 2, 2, 
-	11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15, 3, 2, 2, 2, 2, 17, 3, 2, 2, 2, 
-	2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2, 2, 23, 3, 2, 2, 2, 2, 25, 3, 2, 2, 
-	2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2, 2, 2, 31, 3, 2, 2, 2, 2, 33, 3, 2, 
-	2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 2, 2, 2, 2, 39, 3, 2, 2, 2, 2, 41, 3, 
-	2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 3, 2, 2, 2, 2, 47, 3, 2, 2, 2, 2, 49, 
-	3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 2, 53, 3, 2, 2, 2, 2, 55, 3, 2, 2, 2, 2, 
-	57, 3, 2, 2, 2, 2, 59, 3, 2, 2, 2, 2, 61, 3, 2, 2, 2, 2, 63, 3, 2, 2, 2, 
-	2, 65, 3, 2, 2, 2, 2, 67, 3, 2, 2, 2, 2, 69, 3, 2, 2, 2, 2, 71, 3, 2, 2, 
-	2, 2, 73, 3, 2, 2, 2, 2, 75, 3, 2, 2, 2, 2, 77, 3, 2, 2, 2, 2, 79, 3, 2, 
-	2, 2, 2, 81, 3, 2, 2, 2, 2, 83, 3, 2, 2, 2, 2, 85, 3, 2, 2, 2, 2, 87, 3, 
-	2, 2, 2, 2, 89, 3, 2, 2, 2, 2, 91, 3, 2, 2, 2, 2, 93, 3, 2, 2, 2, 2, 95, 
-	3, 2, 2, 2, 2, 97, 3, 2, 2, 2, 2, 99, 3, 2, 2, 2, 2, 101, 3, 2, 2, 2, 2, 
-	103, 3, 2, 2, 2, 2, 105, 3, 2, 2, 2, 2, 107, 3, 2, 2, 2, 2, 109, 3, 2, 
-	2, 2, 2, 111, 3, 2, 2, 2, 2, 113, 3, 2, 2, 2, 2, 115, 3, 2, 2, 2, 2, 117, 
-	3, 2, 2, 2, 2, 119, 3, 2, 2, 2, 2, 121, 3, 2, 2, 2, 2, 123, 3, 2, 2, 2, 
-	2, 125, 3, 2, 2, 2, 2, 127, 3, 2, 2, 2, 2, 129, 3, 2, 2, 2, 2, 131, 3, 
-	2, 2, 2, 2, 133, 3, 2, 2, 2, 2, 135, 3, 2, 2, 2, 2, 137, 3, 2, 2, 2, 2, 
-	139, 3, 2, 2, 2, 2, 141, 3, 2, 2, 2, 2, 143, 3, 2, 2, 2, 2, 145, 3, 2, 
-	2, 2, 2, 147, 3, 2, 2, 2, 2, 149, 3, 2, 2, 2, 2, 151, 3, 2, 2, 2, 2, 153, 
-	3, 2, 2, 2, 2, 155, 3, 2, 2, 2, 2, 157, 3, 2, 2, 2, 2, 159, 3, 2, 2, 2, 
-	2, 161, 3, 2, 2, 2, 2, 163, 3, 2, 2, 2, 2, 165, 3, 2, 2, 2, 2, 167, 3, 
-	2, 2, 2, 2, 169, 3, 2, 2, 2, 2, 171, 3, 2, 2, 2, 2, 173, 3, 2, 2, 2, 2, 
-	175, 3, 2, 2, 2, 2, 177, 3, 2, 2, 2, 2, 179, 3, 2, 2, 2, 2, 181, 3, 2, 
-	2, 2, 2, 183, 3, 2, 2, 2, 2, 185, 3, 2, 2, 2, 2, 187, 3, 2, 2, 2, 2, 189, 
-	3, 2, 2, 2, 2, 191, 3, 2, 2, 2, 2, 193, 3, 2, 2, 2, 2, 195, 3, 2, 2, 2, 
-	2, 197, 3, 2, 2, 2, 2, 199, 3, 2, 2, 2, 2, 201, 3, 2, 2, 2, 2, 203, 3, 
-	2, 2, 2, 2, 205, 3, 2, 2, 2, 2, 207, 3, 2, 2, 2, 3, 267, 3, 2, 2, 2, 5, 
-	274, 3, 2, 2, 2, 7, 281, 3, 2, 2, 2, 9, 285, 3, 2, 2, 2, 11, 290, 3, 2, 
-	2, 2, 13, 299, 3, 2, 2, 2, 15, 304, 3, 2, 2, 2, 17, 310, 3, 2, 2, 2, 19, 
-	322, 3, 2, 2, 2, 21, 326, 3, 2, 2, 2, 23, 334, 3, 2, 2, 2, 25, 342, 3, 
-	2, 2, 2, 27, 352, 3, 2, 2, 2, 29, 357, 3, 2, 2, 2, 31, 360, 3, 2, 2, 2, 
-	33, 365, 3, 2, 2, 2, 35, 374, 3, 2, 2, 2, 37, 384, 3, 2, 2, 2, 39, 394, 
-	3, 2, 2, 2, 41, 405, 3, 2, 2, 2, 43, 410, 3, 2, 2, 2, 45, 423, 3, 2, 2, 
-	2, 47, 435, 3, 2, 2, 2, 49, 441, 3, 2, 2, 2, 51, 448, 3, 2, 2, 2, 53, 452, 
-	3, 2, 2, 2, 55, 457, 3, 2, 2, 2, 57, 462, 3, 2, 2, 2, 59, 466, 3, 2, 2, 
-	2, 61, 471, 3, 2, 2, 2, 63, 478, 3, 2, 2, 2, 65, 484, 3, 2, 2, 2, 67, 489, 
-	3, 2, 2, 2, 69, 495, 3, 2, 2, 2, 71, 501, 3, 2, 2, 2, 73, 509, 3, 2, 2, 
-	2, 75, 515, 3, 2, 2, 2, 77, 523, 3, 2, 2, 2, 79, 533, 3, 2, 2, 2, 81, 540, 
-	3, 2, 2, 2, 83, 543, 3, 2, 2, 2, 85, 547, 3, 2, 2, 2, 87, 550, 3, 2, 2, 
-	2, 89, 555, 3, 2, 2, 2, 91, 560, 3, 2, 2, 2, 93, 569, 3, 2, 2, 2, 95, 575, 
-	3, 2, 2, 2, 97, 579, 3, 2, 2, 2, 99, 584, 3, 2, 2, 2, 101, 589, 3, 2, 2, 
-	2, 103, 593, 3, 2, 2, 2, 105, 601, 3, 2, 2, 2, 107, 604, 3, 2, 2, 2, 109, 
-	610, 3, 2, 2, 2, 111, 617, 3, 2, 2, 2, 113, 620, 3, 2, 2, 2, 115, 624, 
-	3, 2, 2, 2, 117, 630, 3, 2, 2, 2, 119, 635, 3, 2, 2, 2, 121, 639, 3, 2, 
-	2, 2, 123, 642, 3, 2, 2, 2, 125, 646, 3, 2, 2, 2, 127, 654, 3, 2, 2, 2, 
-	129, 658, 3, 2, 2, 2, 131, 662, 3, 2, 2, 2, 133, 666, 3, 2, 2, 2, 135, 
-	672, 3, 2, 2, 2, 137, 676, 3, 2, 2, 2, 139, 683, 3, 2, 2, 2, 141, 693, 
-	3, 2, 2, 2, 143, 695, 3, 2, 2, 2, 145, 697, 3, 2, 2, 2, 147, 699, 3, 2, 
-	2, 2, 149, 701, 3, 2, 2, 2, 151, 703, 3, 2, 2, 2, 153, 705, 3, 2, 2, 2, 
-	155, 707, 3, 2, 2, 2, 157, 709, 3, 2, 2, 2, 159, 711, 3, 2, 2, 2, 161, 
-	713, 3, 2, 2, 2, 163, 716, 3, 2, 2, 2, 165, 719, 3, 2, 2, 2, 167, 721, 
-	3, 2, 2, 2, 169, 724, 3, 2, 2, 2, 171, 726, 3, 2, 2, 2, 173, 729, 3, 2, 
-	2, 2, 175, 732, 3, 2, 2, 2, 177, 735, 3, 2, 2, 2, 179, 737, 3, 2, 2, 2, 
-	181, 739, 3, 2, 2, 2, 183, 741, 3, 2, 2, 2, 185, 743, 3, 2, 2, 2, 187, 
-	745, 3, 2, 2, 2, 189, 747, 3, 2, 2, 2, 191, 749, 3, 2, 2, 2, 193, 751, 
-	3, 2, 2, 2, 195, 753, 3, 2, 2, 2, 197, 755, 3, 2, 2, 2, 199, 757, 3, 2, 
-	2, 2, 201, 759, 3, 2, 2, 2, 203, 762, 3, 2, 2, 2, 205, 785, 3, 2, 2, 2, 
-	207, 788, 3, 2, 2, 2, 209, 794, 3, 2, 2, 2, 211, 796, 3, 2, 2, 2, 213, 
-	848, 3, 2, 2, 2, 215, 850, 3, 2, 2, 2, 217, 852, 3, 2, 2, 2, 219, 854, 
-	3, 2, 2, 2, 221, 856, 3, 2, 2, 2, 223, 858, 3, 2, 2, 2, 225, 860, 3, 2, 
-	2, 2, 227, 862, 3, 2, 2, 2, 229, 864, 3, 2, 2, 2, 231, 866, 3, 2, 2, 2, 
-	233, 868, 3, 2, 2, 2, 235, 870, 3, 2, 2, 2, 237, 872, 3, 2, 2, 2, 239, 
-	874, 3, 2, 2, 2, 241, 876, 3, 2, 2, 2, 243, 878, 3, 2, 2, 2, 245, 880, 
-	3, 2, 2, 2, 247, 882, 3, 2, 2, 2, 249, 884, 3, 2, 2, 2, 251, 886, 3, 2, 
-	2, 2, 253, 888, 3, 2, 2, 2, 255, 890, 3, 2, 2, 2, 257, 892, 3, 2, 2, 2, 
-	259, 894, 3, 2, 2, 2, 261, 896, 3, 2, 2, 2, 263, 898, 3, 2, 2, 2, 265, 
-	900, 3, 2, 2, 2, 267, 268, 5, 219, 110, 2, 268, 269, 5, 249, 125, 2, 269, 
-	270, 5, 223, 112, 2, 270, 271, 5, 215, 108, 2, 271, 272, 5, 253, 127, 2, 
-	272, 273, 5, 223, 112, 2, 273, 4, 3, 2, 2, 2, 274, 275, 5, 255, 128, 2, 
-	275, 276, 5, 245, 123, 2, 276, 277, 5, 221, 111, 2, 277, 278, 5, 215, 108, 
-	2, 278, 279, 5, 253, 127, 2, 279, 280, 5, 223, 112, 2, 280, 6, 3, 2, 2, 
-	2, 281, 282, 5, 251, 126, 2, 282, 283, 5, 223, 112, 2, 283, 284, 5, 253, 
-	127, 2, 284, 8, 3, 2, 2, 2, 285, 286, 5, 221, 111, 2, 286, 287, 5, 249, 
-	125, 2, 287, 288, 5, 243, 122, 2, 288, 289, 5, 245, 123, 2, 289, 10, 3, 
-	2, 2, 2, 290, 291, 5, 231, 116, 2, 291, 292, 5, 241, 121, 2, 292, 293, 
-	5, 253, 127, 2, 293, 294, 5, 223, 112, 2, 294, 295, 5, 249, 125, 2, 295, 
-	296, 5, 257, 129, 2, 296, 297, 5, 215, 108, 2, 297, 298, 5, 237, 119, 2, 
-	298, 12, 3, 2, 2, 2, 299, 300, 5, 241, 121, 2, 300, 301, 5, 215, 108, 2, 
-	301, 302, 5, 239, 120, 2, 302, 303, 5, 223, 112, 2, 303, 14, 3, 2, 2, 2, 
-	304, 305, 5, 251, 126, 2, 305, 306, 5, 229, 115, 2, 306, 307, 5, 215, 108, 
-	2, 307, 308, 5, 249, 125, 2, 308, 309, 5, 221, 111, 2, 309, 16, 3, 2, 2, 
-	2, 310, 311, 5, 249, 125, 2, 311, 312, 5, 223, 112, 2, 312, 313, 5, 245, 
-	123, 2, 313, 314, 5, 237, 119, 2, 314, 315, 5, 231, 116, 2, 315, 316, 5, 
-	219, 110, 2, 316, 317, 5, 215, 108, 2, 317, 318, 5, 253, 127, 2, 318, 319, 
-	5, 231, 116, 2, 319, 320, 5, 243, 122, 2, 320, 321, 5, 241, 121, 2, 321, 
-	18, 3, 2, 2, 2, 322, 323, 5, 253, 127, 2, 323, 324, 5, 253, 127, 2, 324, 
-	325, 5, 237, 119, 2, 325, 20, 3, 2, 2, 2, 326, 327, 5, 239, 120, 2, 327, 
-	328, 5, 223, 112, 2, 328, 329, 5, 253, 127, 2, 329, 330, 5, 215, 108, 2, 
-	330, 331, 5, 253, 127, 2, 331, 332, 5, 253, 127, 2, 332, 333, 5, 237, 119, 
-	2, 333, 22, 3, 2, 2, 2, 334, 335, 5, 245, 123, 2, 335, 336, 5, 215, 108, 
-	2, 336, 337, 5, 251, 126, 2, 337, 338, 5, 253, 127, 2, 338, 339, 5, 253, 
-	127, 2, 339, 340, 5, 253, 127, 2, 340, 341, 5, 237, 119, 2, 341, 24, 3, 
-	2, 2, 2, 342, 343, 5, 225, 113, 2, 343, 344, 5, 255, 128, 2, 344, 345, 
-	5, 253, 127, 2, 345, 346, 5, 255, 128, 2, 346, 347, 5, 249, 125, 2, 347, 
-	348, 5, 223, 112, 2, 348, 349, 5, 253, 127, 2, 349, 350, 5, 253, 127, 2, 
-	350, 351, 5, 237, 119, 2, 351, 26, 3, 2, 2, 2, 352, 353, 5, 235, 118, 2, 
-	353, 354, 5, 231, 116, 2, 354, 355, 5, 237, 119, 2, 355, 356, 5, 237, 119, 
-	2, 356, 28, 3, 2, 2, 2, 357, 358, 5, 243, 122, 2, 358, 359, 5, 241, 121, 
-	2, 359, 30, 3, 2, 2, 2, 360, 361, 5, 251, 126, 2, 361, 362, 5, 229, 115, 
-	2, 362, 363, 5, 243, 122, 2, 363, 364, 5, 259, 130, 2, 364, 32, 3, 2, 2, 
-	2, 365, 366, 5, 221, 111, 2, 366, 367, 5, 215, 108, 2, 367, 368, 5, 253, 
-	127, 2, 368, 369, 5, 215, 108, 2, 369, 370, 5, 217, 109, 2, 370, 371, 5, 
-	215, 108, 2, 371, 372, 5, 251, 126, 2, 372, 373, 5, 223, 112, 2, 373, 34, 
-	3, 2, 2, 2, 374, 375, 5, 221, 111, 2, 375, 376, 5, 215, 108, 2, 376, 377, 
-	5, 253, 127, 2, 377, 378, 5, 215, 108, 2, 378, 379, 5, 217, 109, 2, 379, 
-	380, 5, 215, 108, 2, 380, 381, 5, 251, 126, 2, 381, 382, 5, 223, 112, 2, 
-	382, 383, 5, 251, 126, 2, 383, 36, 3, 2, 2, 2, 384, 385, 5, 241, 121, 2, 
-	385, 386, 5, 215, 108, 2, 386, 387, 5, 239, 120, 2, 387, 388, 5, 223, 112, 
-	2, 388, 389, 5, 251, 126, 2, 389, 390, 5, 245, 123, 2, 390, 391, 5, 215, 
-	108, 2, 391, 392, 5, 219, 110, 2, 392, 393, 5, 223, 112, 2, 393, 38, 3, 
-	2, 2, 2, 394, 395, 5, 241, 121, 2, 395, 396, 5, 215, 108, 2, 396, 397, 
-	5, 239, 120, 2, 397, 398, 5, 223, 112, 2, 398, 399, 5, 251, 126, 2, 399, 
-	400, 5, 245, 123, 2, 400, 401, 5, 215, 108, 2, 401, 402, 5, 219, 110, 2, 
-	402, 403, 5, 223, 112, 2, 403, 404, 5, 251, 126, 2, 404, 40, 3, 2, 2, 2, 
-	405, 406, 5, 241, 121, 2, 406, 407, 5, 243, 122, 2, 407, 408, 5, 221, 111, 
-	2, 408, 409, 5, 223, 112, 2, 409, 42, 3, 2, 2, 2, 410, 411, 5, 239, 120, 
-	2, 411, 412, 5, 223, 112, 2, 412, 413, 5, 215, 108, 2, 413, 414, 5, 251, 
-	126, 2, 414, 415, 5, 255, 128, 2, 415, 416, 5, 249, 125, 2, 416, 417, 5, 
-	223, 112, 2, 417, 418, 5, 239, 120, 2, 418, 419, 5, 223, 112, 2, 419, 420, 
-	5, 241, 121, 2, 420, 421, 5, 253, 127, 2, 421, 422, 5, 251, 126, 2, 422, 
-	44, 3, 2, 2, 2, 423, 424, 5, 239, 120, 2, 424, 425, 5, 223, 112, 2, 425, 
-	426, 5, 215, 108, 2, 426, 427, 5, 251, 126, 2, 427, 428, 5, 255, 128, 2, 
-	428, 429, 5, 249, 125, 2, 429, 430, 5, 223, 112, 2, 430, 431, 5, 239, 120, 
-	2, 431, 432, 5, 223, 112, 2, 432, 433, 5, 241, 121, 2, 433, 434, 5, 253, 
-	127, 2, 434, 46, 3, 2, 2, 2, 435, 436, 5, 225, 113, 2, 436, 437, 5, 231, 
-	116, 2, 437, 438, 5, 223, 112, 2, 438, 439, 5, 237, 119, 2, 439, 440, 5, 
-	221, 111, 2, 440, 48, 3, 2, 2, 2, 441, 442, 5, 225, 113, 2, 442, 443, 5, 
-	231, 116, 2, 443, 444, 5, 223, 112, 2, 444, 445, 5, 237, 119, 2, 445, 446, 
-	5, 221, 111, 2, 446, 447, 5, 251, 126, 2, 447, 50, 3, 2, 2, 2, 448, 449, 
-	5, 253, 127, 2, 449, 450, 5, 215, 108, 2, 450, 451, 5, 227, 114, 2, 451, 
-	52, 3, 2, 2, 2, 452, 453, 5, 231, 116, 2, 453, 454, 5, 241, 121, 2, 454, 
-	455, 5, 225, 113, 2, 455, 456, 5, 243, 122, 2, 456, 54, 3, 2, 2, 2, 457, 
-	458, 5, 235, 118, 2, 458, 459, 5, 223, 112, 2, 459, 460, 5, 263, 132, 2, 
-	460, 461, 5, 251, 126, 2, 461, 56, 3, 2, 2, 2, 462, 463, 5, 235, 118, 2, 
-	463, 464, 5, 223, 112, 2, 464, 465, 5, 263, 132, 2, 465, 58, 3, 2, 2, 2, 
-	466, 467, 5, 259, 130, 2, 467, 468, 5, 231, 116, 2, 468, 469, 5, 253, 127, 
-	2, 469, 470, 5, 229, 115, 2, 470, 60, 3, 2, 2, 2, 471, 472, 5, 257, 129, 
-	2, 472, 473, 5, 215, 108, 2, 473, 474, 5, 237, 119, 2, 474, 475, 5, 255, 
-	128, 2, 475, 476, 5, 223, 112, 2, 476, 477, 5, 251, 126, 2, 477, 62, 3, 
-	2, 2, 2, 478, 479, 5, 257, 129, 2, 479, 480, 5, 215, 108, 2, 480, 481, 
-	5, 237, 119, 2, 481, 482, 5, 255, 128, 2, 482, 483, 5, 223, 112, 2, 483, 
-	64, 3, 2, 2, 2, 484, 485, 5, 225, 113, 2, 485, 486, 5, 249, 125, 2, 486, 
-	487, 5, 243, 122, 2, 487, 488, 5, 239, 120, 2, 488, 66, 3, 2, 2, 2, 489, 
-	490, 5, 259, 130, 2, 490, 491, 5, 229, 115, 2, 491, 492, 5, 223, 112, 2, 
-	492, 493, 5, 249, 125, 2, 493, 494, 5, 223, 112, 2, 494, 68, 3, 2, 2, 2, 
-	495, 496, 5, 237, 119, 2, 496, 497, 5, 231, 116, 2, 497, 498, 5, 239, 120, 
-	2, 498, 499, 5, 231, 116, 2, 499, 500, 5, 253, 127, 2, 500, 70, 3, 2, 2, 
-	2, 501, 502, 5, 247, 124, 2, 502, 503, 5, 255, 128, 2, 503, 504, 5, 223, 
-	112, 2, 504, 505, 5, 249, 125, 2, 505, 506, 5, 231, 116, 2, 506, 507, 5, 
-	223, 112, 2, 507, 508, 5, 251, 126, 2, 508, 72, 3, 2, 2, 2, 509, 510, 5, 
-	247, 124, 2, 510, 511, 5, 255, 128, 2, 511, 512, 5, 223, 112, 2, 512, 513, 
-	5, 249, 125, 2, 513, 514, 5, 263, 132, 2, 514, 74, 3, 2, 2, 2, 515, 516, 
-	5, 223, 112, 2, 516, 517, 5, 261, 131, 2, 517, 518, 5, 245, 123, 2, 518, 
-	519, 5, 237, 119, 2, 519, 520, 5, 215, 108, 2, 520, 521, 5, 231, 116, 2, 
-	521, 522, 5, 241, 121, 2, 522, 76, 3, 2, 2, 2, 523, 524, 5, 259, 130, 2, 
-	524, 525, 5, 231, 116, 2, 525, 526, 5, 253, 127, 2, 526, 527, 5, 229, 115, 
-	2, 527, 528, 5, 257, 129, 2, 528, 529, 5, 215, 108, 2, 529, 530, 5, 237, 
-	119, 2, 530, 531, 5, 255, 128, 2, 531, 532, 5, 223, 112, 2, 532, 78, 3, 
-	2, 2, 2, 533, 534, 5, 251, 126, 2, 534, 535, 5, 223, 112, 2, 535, 536, 
-	5, 237, 119, 2, 536, 537, 5, 223, 112, 2, 537, 538, 5, 219, 110, 2, 538, 
-	539, 5, 253, 127, 2, 539, 80, 3, 2, 2, 2, 540, 541, 5, 215, 108, 2, 541, 
-	542, 5, 251, 126, 2, 542, 82, 3, 2, 2, 2, 543, 544, 5, 215, 108, 2, 544, 
-	545, 5, 241, 121, 2, 545, 546, 5, 221, 111, 2, 546, 84, 3, 2, 2, 2, 547, 
-	548, 5, 243, 122, 2, 548, 549, 5, 249, 125, 2, 549, 86, 3, 2, 2, 2, 550, 
-	551, 5, 225, 113, 2, 551, 552, 5, 231, 116, 2, 552, 553, 5, 237, 119, 2, 
-	553, 554, 5, 237, 119, 2, 554, 88, 3, 2, 2, 2, 555, 556, 5, 241, 121, 2, 
-	556, 557, 5, 255, 128, 2, 557, 558, 5, 237, 119, 2, 558, 559, 5, 237, 119, 
-	2, 559, 90, 3, 2, 2, 2, 560, 561, 5, 245, 123, 2, 561, 562, 5, 249, 125, 
-	2, 562, 563, 5, 223, 112, 2, 563, 564, 5, 257, 129, 2, 564, 565, 5, 231, 
-	116, 2, 565, 566, 5, 243, 122, 2, 566, 567, 5, 255, 128, 2, 567, 568, 5, 
-	251, 126, 2, 568, 92, 3, 2, 2, 2, 569, 570, 5, 243, 122, 2, 570, 571, 5, 
-	249, 125, 2, 571, 572, 5, 221, 111, 2, 572, 573, 5, 223, 112, 2, 573, 574, 
-	5, 249, 125, 2, 574, 94, 3, 2, 2, 2, 575, 576, 5, 215, 108, 2, 576, 577, 
-	5, 251, 126, 2, 577, 578, 5, 219, 110, 2, 578, 96, 3, 2, 2, 2, 579, 580, 
-	5, 221, 111, 2, 580, 581, 5, 223, 112, 2, 581, 582, 5, 251, 126, 2, 582, 
-	583, 5, 219, 110, 2, 583, 98, 3, 2, 2, 2, 584, 585, 5, 237, 119, 2, 585, 
-	586, 5, 231, 116, 2, 586, 587, 5, 235, 118, 2, 587, 588, 5, 223, 112, 2, 
-	588, 100, 3, 2, 2, 2, 589, 590, 5, 241, 121, 2, 590, 591, 5, 243, 122, 
-	2, 591, 592, 5, 253, 127, 2, 592, 102, 3, 2, 2, 2, 593, 594, 5, 217, 109, 
-	2, 594, 595, 5, 223, 112, 2, 595, 596, 5, 253, 127, 2, 596, 597, 5, 259, 
-	130, 2, 597, 598, 5, 223, 112, 2, 598, 599, 5, 223, 112, 2, 599, 600, 5, 
-	241, 121, 2, 600, 104, 3, 2, 2, 2, 601, 602, 5, 231, 116, 2, 602, 603, 
-	5, 251, 126, 2, 603, 106, 3, 2, 2, 2, 604, 605, 5, 227, 114, 2, 605, 606, 
-	5, 249, 125, 2, 606, 607, 5, 243, 122, 2, 607, 608, 5, 255, 128, 2, 608, 
-	609, 5, 245, 123, 2, 609, 108, 3, 2, 2, 2, 610, 611, 5, 229, 115, 2, 611, 
-	612, 5, 215, 108, 2, 612, 613, 5, 257, 129, 2, 613, 614, 5, 231, 116, 2, 
-	614, 615, 5, 241, 121, 2, 615, 616, 5, 227, 114, 2, 616, 110, 3, 2, 2, 
-	2, 617, 618, 5, 217, 109, 2, 618, 619, 5, 263, 132, 2, 619, 112, 3, 2, 
-	2, 2, 620, 621, 5, 225, 113, 2, 621, 622, 5, 243, 122, 2, 622, 623, 5, 
-	249, 125, 2, 623, 114, 3, 2, 2, 2, 624, 625, 5, 251, 126, 2, 625, 626, 
-	5, 253, 127, 2, 626, 627, 5, 215, 108, 2, 627, 628, 5, 253, 127, 2, 628, 
-	629, 5, 251, 126, 2, 629, 116, 3, 2, 2, 2, 630, 631, 5, 253, 127, 2, 631, 
-	632, 5, 231, 116, 2, 632, 633, 5, 239, 120, 2, 633, 634, 5, 223, 112, 2, 
-	634, 118, 3, 2, 2, 2, 635, 636, 5, 241, 121, 2, 636, 637, 5, 243, 122, 
-	2, 637, 638, 5, 259, 130, 2, 638, 120, 3, 2, 2, 2, 639, 640, 5, 231, 116, 
-	2, 640, 641, 5, 241, 121, 2, 641, 122, 3, 2, 2, 2, 642, 643, 5, 237, 119, 
-	2, 643, 644, 5, 243, 122, 2, 644, 645, 5, 227, 114, 2, 645, 124, 3, 2, 
-	2, 2, 646, 647, 5, 245, 123, 2, 647, 648, 5, 249, 125, 2, 648, 649, 5, 
-	243, 122, 2, 649, 650, 5, 225, 113, 2, 650, 651, 5, 231, 116, 2, 651, 652, 
-	5, 237, 119, 2, 652, 653, 5, 223, 112, 2, 653, 126, 3, 2, 2, 2, 654, 655, 
-	5, 251, 126, 2, 655, 656, 5, 255, 128, 2, 656, 657, 5, 239, 120, 2, 657, 
-	128, 3, 2, 2, 2, 658, 659, 5, 239, 120, 2, 659, 660, 5, 231, 116, 2, 660, 
-	661, 5, 241, 121, 2, 661, 130, 3, 2, 2, 2, 662, 663, 5, 239, 120, 2, 663, 
-	664, 5, 215, 108, 2, 664, 665, 5, 261, 131, 2, 665, 132, 3, 2, 2, 2, 666, 
-	667, 5, 219, 110, 2, 667, 668, 5, 243, 122, 2, 668, 669, 5, 255, 128, 2, 
-	669, 670, 5, 241, 121, 2, 670, 671, 5, 253, 127, 2, 671, 134, 3, 2, 2, 
-	2, 672, 673, 5, 215, 108, 2, 673, 674, 5, 257, 129, 2, 674, 675, 5, 227, 
-	114, 2, 675, 136, 3, 2, 2, 2, 676, 677, 5, 251, 126, 2, 677, 678, 5, 253, 
-	127, 2, 678, 679, 5, 221, 111, 2, 679, 680, 5, 221, 111, 2, 680, 681, 5, 
-	223, 112, 2, 681, 682, 5, 257, 129, 2, 682, 138, 3, 2, 2, 2, 683, 684, 
-	5, 229, 115, 2, 684, 685, 5, 231, 116, 2, 685, 686, 5, 251, 126, 2, 686, 
-	687, 5, 253, 127, 2, 687, 688, 5, 243, 122, 2, 688, 689, 5, 227, 114, 2, 
-	689, 690, 5, 249, 125, 2, 690, 691, 5, 215, 108, 2, 691, 692, 5, 239, 120, 
-	2, 692, 140, 3, 2, 2, 2, 693, 694, 5, 251, 126, 2, 694, 142, 3, 2, 2, 2, 
-	695, 696, 7, 111, 2, 2, 696, 144, 3, 2, 2, 2, 697, 698, 5, 229, 115, 2, 
-	698, 146, 3, 2, 2, 2, 699, 700, 5, 221, 111, 2, 700, 148, 3, 2, 2, 2, 701, 
-	702, 5, 259, 130, 2, 702, 150, 3, 2, 2, 2, 703, 704, 7, 79, 2, 2, 704, 
-	152, 3, 2, 2, 2, 705, 706, 5, 263, 132, 2, 706, 154, 3, 2, 2, 2, 707, 708, 
-	7, 48, 2, 2, 708, 156, 3, 2, 2, 2, 709, 710, 7, 60, 2, 2, 710, 158, 3, 
-	2, 2, 2, 711, 712, 7, 63, 2, 2, 712, 160, 3, 2, 2, 2, 713, 714, 7, 62, 
-	2, 2, 714, 715, 7, 64, 2, 2, 715, 162, 3, 2, 2, 2, 716, 717, 7, 35, 2, 
-	2, 717, 718, 7, 63, 2, 2, 718, 164, 3, 2, 2, 2, 719, 720, 7, 64, 2, 2, 
-	720, 166, 3, 2, 2, 2, 721, 722, 7, 64, 2, 2, 722, 723, 7, 63, 2, 2, 723, 
-	168, 3, 2, 2, 2, 724, 725, 7, 62, 2, 2, 725, 170, 3, 2, 2, 2, 726, 727, 
-	7, 62, 2, 2, 727, 728, 7, 63, 2, 2, 728, 172, 3, 2, 2, 2, 729, 730, 7, 
-	63, 2, 2, 730, 731, 7, 128, 2, 2, 731, 174, 3, 2, 2, 2, 732, 733, 7, 35, 
-	2, 2, 733, 734, 7, 128, 2, 2, 734, 176, 3, 2, 2, 2, 735, 736, 7, 46, 2, 
-	2, 736, 178, 3, 2, 2, 2, 737, 738, 7, 125, 2, 2, 738, 180, 3, 2, 2, 2, 
-	739, 740, 7, 127, 2, 2, 740, 182, 3, 2, 2, 2, 741, 742, 7, 93, 2, 2, 742, 
-	184, 3, 2, 2, 2, 743, 744, 7, 95, 2, 2, 744, 186, 3, 2, 2, 2, 745, 746, 
-	7, 42, 2, 2, 746, 188, 3, 2, 2, 2, 747, 748, 7, 43, 2, 2, 748, 190, 3, 
-	2, 2, 2, 749, 750, 7, 45, 2, 2, 750, 192, 3, 2, 2, 2, 751, 752, 7, 47, 
-	2, 2, 752, 194, 3, 2, 2, 2, 753, 754, 7, 49, 2, 2, 754, 196, 3, 2, 2, 2, 
-	755, 756, 7, 44, 2, 2, 756, 198, 3, 2, 2, 2, 757, 758, 7, 39, 2, 2, 758, 
-	200, 3, 2, 2, 2, 759, 760, 5, 213, 107, 2, 760, 202, 3, 2, 2, 2, 761, 763, 
-	5, 211, 106, 2, 762, 761, 3, 2, 2, 2, 763, 764, 3, 2, 2, 2, 764, 762, 3, 
-	2, 2, 2, 764, 765, 3, 2, 2, 2, 765, 204, 3, 2, 2, 2, 766, 768, 5, 211, 
-	106, 2, 767, 766, 3, 2, 2, 2, 768, 769, 3, 2, 2, 2, 769, 767, 3, 2, 2, 
-	2, 769, 770, 3, 2, 2, 2, 770, 771, 3, 2, 2, 2, 771, 772, 7, 48, 2, 2, 772, 
-	776, 10, 2, 2, 2, 773, 775, 5, 211, 106, 2, 774, 773, 3, 2, 2, 2, 775, 
-	778, 3, 2, 2, 2, 776, 774, 3, 2, 2, 2, 776, 777, 3, 2, 2, 2, 777, 786, 
-	3, 2, 2, 2, 778, 776, 3, 2, 2, 2, 779, 781, 7, 48, 2, 2, 780, 782, 5, 211, 
-	106, 2, 781, 780, 3, 2, 2, 2, 782, 783, 3, 2, 2, 2, 783, 781, 3, 2, 2, 
-	2, 783, 784, 3, 2, 2, 2, 784, 786, 3, 2, 2, 2, 785, 767, 3, 2, 2, 2, 785, 
-	779, 3, 2, 2, 2, 786, 206, 3, 2, 2, 2, 787, 789, 5, 209, 105, 2, 788, 787, 
-	3, 2, 2, 2, 789, 790, 3, 2, 2, 2, 790, 788, 3, 2, 2, 2, 790, 791, 3, 2, 
-	2, 2, 791, 792, 3, 2, 2, 2, 792, 793, 8, 104, 2, 2, 793, 208, 3, 2, 2, 
-	2, 794, 795, 9, 3, 2, 2, 795, 210, 3, 2, 2, 2, 796, 797, 9, 4, 2, 2, 797, 
-	212, 3, 2, 2, 2, 798, 804, 9, 5, 2, 2, 799, 803, 9, 5, 2, 2, 800, 803, 
-	5, 211, 106, 2, 801, 803, 9, 6, 2, 2, 802, 799, 3, 2, 2, 2, 802, 800, 3, 
-	2, 2, 2, 802, 801, 3, 2, 2, 2, 803, 806, 3, 2, 2, 2, 804, 802, 3, 2, 2, 
-	2, 804, 805, 3, 2, 2, 2, 805, 849, 3, 2, 2, 2, 806, 804, 3, 2, 2, 2, 807, 
-	808, 7, 38, 2, 2, 808, 812, 7, 125, 2, 2, 809, 811, 11, 2, 2, 2, 810, 809, 
-	3, 2, 2, 2, 811, 814, 3, 2, 2, 2, 812, 813, 3, 2, 2, 2, 812, 810, 3, 2, 
-	2, 2, 813, 815, 3, 2, 2, 2, 814, 812, 3, 2, 2, 2, 815, 849, 7, 127, 2, 
-	2, 816, 820, 9, 7, 2, 2, 817, 821, 9, 5, 2, 2, 818, 821, 5, 211, 106, 2, 
-	819, 821, 9, 7, 2, 2, 820, 817, 3, 2, 2, 2, 820, 818, 3, 2, 2, 2, 820, 
-	819, 3, 2, 2, 2, 821, 822, 3, 2, 2, 2, 822, 820, 3, 2, 2, 2, 822, 823, 
-	3, 2, 2, 2, 823, 849, 3, 2, 2, 2, 824, 828, 7, 36, 2, 2, 825, 827, 11, 
-	2, 2, 2, 826, 825, 3, 2, 2, 2, 827, 830, 3, 2, 2, 2, 828, 829, 3, 2, 2, 
-	2, 828, 826, 3, 2, 2, 2, 829, 831, 3, 2, 2, 2, 830, 828, 3, 2, 2, 2, 831, 
-	849, 7, 36, 2, 2, 832, 836, 7, 98, 2, 2, 833, 835, 11, 2, 2, 2, 834, 833, 
-	3, 2, 2, 2, 835, 838, 3, 2, 2, 2, 836, 837, 3, 2, 2, 2, 836, 834, 3, 2, 
-	2, 2, 837, 839, 3, 2, 2, 2, 838, 836, 3, 2, 2, 2, 839, 849, 7, 98, 2, 2, 
-	840, 844, 7, 41, 2, 2, 841, 843, 11, 2, 2, 2, 842, 841, 3, 2, 2, 2, 843, 
-	846, 3, 2, 2, 2, 844, 845, 3, 2, 2, 2, 844, 842, 3, 2, 2, 2, 845, 847, 
-	3, 2, 2, 2, 846, 844, 3, 2, 2, 2, 847, 849, 7, 41, 2, 2, 848, 798, 3, 2, 
-	2, 2, 848, 807, 3, 2, 2, 2, 848, 816, 3, 2, 2, 2, 848, 824, 3, 2, 2, 2, 
-	848, 832, 3, 2, 2, 2, 848, 840, 3, 2, 2, 2, 849, 214, 3, 2, 2, 2, 850, 
-	851, 9, 8, 2, 2, 851, 216, 3, 2, 2, 2, 852, 853, 9, 9, 2, 2, 853, 218, 
-	3, 2, 2, 2, 854, 855, 9, 10, 2, 2, 855, 220, 3, 2, 2, 2, 856, 857, 9, 11, 
-	2, 2, 857, 222, 3, 2, 2, 2, 858, 859, 9, 12, 2, 2, 859, 224, 3, 2, 2, 2, 
-	860, 861, 9, 13, 2, 2, 861, 226, 3, 2, 2, 2, 862, 863, 9, 14, 2, 2, 863, 
-	228, 3, 2, 2, 2, 864, 865, 9, 15, 2, 2, 865, 230, 3, 2, 2, 2, 866, 867, 
-	9, 16, 2, 2, 867, 232, 3, 2, 2, 2, 868, 869, 9, 17, 2, 2, 869, 234, 3, 
-	2, 2, 2, 870, 871, 9, 18, 2, 2, 871, 236, 3, 2, 2, 2, 872, 873, 9, 19, 
-	2, 2, 873, 238, 3, 2, 2, 2, 874, 875, 9, 20, 2, 2, 875, 240, 3, 2, 2, 2, 
-	876, 877, 9, 21, 2, 2, 877, 242, 3, 2, 2, 2, 878, 879, 9, 22, 2, 2, 879, 
-	244, 3, 2, 2, 2, 880, 881, 9, 23, 2, 2, 881, 246, 3, 2, 2, 2, 882, 883, 
-	9, 24, 2, 2, 883, 248, 3, 2, 2, 2, 884, 885, 9, 25, 2, 2, 885, 250, 3, 
-	2, 2, 2, 886, 887, 9, 26, 2, 2, 887, 252, 3, 2, 2, 2, 888, 889, 9, 27, 
-	2, 2, 889, 254, 3, 2, 2, 2, 890, 891, 9, 28, 2, 2, 891, 256, 3, 2, 2, 2, 
-	892, 893, 9, 29, 2, 2, 893, 258, 3, 2, 2, 2, 894, 895, 9, 30, 2, 2, 895, 
-	260, 3, 2, 2, 2, 896, 897, 9, 31, 2, 2, 897, 262, 3, 2, 2, 2, 898, 899, 
-	9, 32, 2, 2, 899, 264, 3, 2, 2, 2, 900, 901, 9, 33, 2, 2, 901, 266, 3, 
-	2, 2, 2, 18, 2, 764, 769, 776, 783, 785, 790, 802, 804, 812, 820, 822, 
-	828, 836, 844, 848, 3, 8, 2, 2,
+	70, 3, 71, 3, 71, 3, 71, 3, 71, 3, 71, 3, 71, 3, 71, 3, 71, 3, 71, 3, 72, 
+	3, 72, 3, 72, 3, 72, 3, 72, 3, 73, 3, 73, 3, 73, 3, 73, 3, 73, 3, 73, 3, 
+	74, 3, 74, 3, 74, 3, 74, 3, 74, 3, 74, 3, 74, 3, 74, 3, 74, 3, 74, 3, 74, 
+	3, 75, 3, 75, 3, 75, 3, 75, 3, 75, 3, 75, 3, 75, 3, 75, 3, 75, 3, 75, 3, 
+	75, 3, 75, 3, 75, 3, 75, 3, 75, 3, 76, 3, 76, 3, 76, 3, 76, 3, 77, 3, 77, 
+	3, 77, 3, 77, 3, 77, 3, 77, 3, 77, 3, 77, 3, 77, 3, 77, 3, 77, 3, 78, 3, 
+	78, 3, 78, 3, 78, 3, 79, 3, 79, 3, 79, 3, 79, 3, 79, 3, 79, 3, 79, 3, 80, 
+	3, 80, 3, 81, 3, 81, 3, 82, 3, 82, 3, 83, 3, 83, 3, 84, 3, 84, 3, 85, 3, 
+	85, 3, 86, 3, 86, 3, 87, 3, 87, 3, 88, 3, 88, 3, 89, 3, 89, 3, 90, 3, 90, 
+	3, 90, 3, 91, 3, 91, 3, 91, 3, 92, 3, 92, 3, 93, 3, 93, 3, 93, 3, 94, 3, 
+	94, 3, 95, 3, 95, 3, 95, 3, 96, 3, 96, 3, 96, 3, 97, 3, 97, 3, 97, 3, 98, 
+	3, 98, 3, 99, 3, 99, 3, 100, 3, 100, 3, 101, 3, 101, 3, 102, 3, 102, 3, 
+	103, 3, 103, 3, 104, 3, 104, 3, 105, 3, 105, 3, 106, 3, 106, 3, 107, 3, 
+	107, 3, 108, 3, 108, 3, 109, 3, 109, 3, 110, 3, 110, 3, 111, 6, 111, 853, 
+	10, 111, 13, 111, 14, 111, 854, 3, 112, 6, 112, 858, 10, 112, 13, 112, 
+	14, 112, 859, 3, 112, 3, 112, 3, 112, 7, 112, 865, 10, 112, 12, 112, 14, 
+	112, 868, 11, 112, 3, 112, 3, 112, 6, 112, 872, 10, 112, 13, 112, 14, 112, 
+	873, 5, 112, 876, 10, 112, 3, 113, 6, 113, 879, 10, 113, 13, 113, 14, 113, 
+	880, 3, 113, 3, 113, 3, 114, 3, 114, 3, 115, 3, 115, 3, 116, 3, 116, 3, 
+	116, 3, 116, 7, 116, 893, 10, 116, 12, 116, 14, 116, 896, 11, 116, 3, 116, 
+	3, 116, 3, 116, 7, 116, 901, 10, 116, 12, 116, 14, 116, 904, 11, 116, 3, 
+	116, 3, 116, 3, 116, 3, 116, 3, 116, 6, 116, 911, 10, 116, 13, 116, 14, 
+	116, 912, 3, 116, 3, 116, 7, 116, 917, 10, 116, 12, 116, 14, 116, 920, 
+	11, 116, 3, 116, 3, 116, 3, 116, 7, 116, 925, 10, 116, 12, 116, 14, 116, 
+	928, 11, 116, 3, 116, 3, 116, 3, 116, 7, 116, 933, 10, 116, 12, 116, 14, 
+	116, 936, 11, 116, 3, 116, 5, 116, 939, 10, 116, 3, 117, 3, 117, 3, 118, 
+	3, 118, 3, 119, 3, 119, 3, 120, 3, 120, 3, 121, 3, 121, 3, 122, 3, 122, 
+	3, 123, 3, 123, 3, 124, 3, 124, 3, 125, 3, 125, 3, 126, 3, 126, 3, 127, 
+	3, 127, 3, 128, 3, 128, 3, 129, 3, 129, 3, 130, 3, 130, 3, 131, 3, 131, 
+	3, 132, 3, 132, 3, 133, 3, 133, 3, 134, 3, 134, 3, 135, 3, 135, 3, 136, 
+	3, 136, 3, 137, 3, 137, 3, 138, 3, 138, 3, 139, 3, 139, 3, 140, 3, 140, 
+	3, 141, 3, 141, 3, 142, 3, 142, 6, 902, 918, 926, 934, 2, 143, 3, 3, 5, 
+	4, 7, 5, 9, 6, 11, 7, 13, 8, 15, 9, 17, 10, 19, 11, 21, 12, 23, 13, 25, 
+	14, 27, 15, 29, 16, 31, 17, 33, 18, 35, 19, 37, 20, 39, 21, 41, 22, 43, 
+	23, 45, 24, 47, 25, 49, 26, 51, 27, 53, 28, 55, 29, 57, 30, 59, 31, 61, 
+	32, 63, 33, 65, 34, 67, 35, 69, 36, 71, 37, 73, 38, 75, 39, 77, 40, 79, 
+	41, 81, 42, 83, 43, 85, 44, 87, 45, 89, 46, 91, 47, 93, 48, 95, 49, 97, 
+	50, 99, 51, 101, 52, 103, 53, 105, 54, 107, 55, 109, 56, 111, 57, 113, 
+	58, 115, 59, 117, 60, 119, 61, 121, 62, 123, 63, 125, 64, 127, 65, 129, 
+	66, 131, 67, 133, 68, 135, 69, 137, 70, 139, 71, 141, 72, 143, 73, 145, 
+	74, 147, 75, 149, 76, 151, 77, 153, 78, 155, 79, 157, 80, 159, 81, 161, 
+	82, 163, 83, 165, 84, 167, 85, 169, 86, 171, 87, 173, 88, 175, 89, 177, 
+	90, 179, 91, 181, 92, 183, 93, 185, 94, 187, 95, 189, 96, 191, 97, 193, 
+	98, 195, 99, 197, 100, 199, 101, 201, 102, 203, 103, 205, 104, 207, 105, 
+	209, 106, 211, 107, 213, 108, 215, 109, 217, 110, 219, 111, 221, 112, 223, 
+	113, 225, 114, 227, 2, 229, 2, 231, 2, 233, 2, 235, 2, 237, 2, 239, 2, 
+	241, 2, 243, 2, 245, 2, 247, 2, 249, 2, 251, 2, 253, 2, 255, 2, 257, 2, 
+	259, 2, 261, 2, 263, 2, 265, 2, 267, 2, 269, 2, 271, 2, 273, 2, 275, 2, 
+	277, 2, 279, 2, 281, 2, 283, 2, 3, 2, 34, 3, 2, 48, 48, 5, 2, 11, 12, 15, 
+	15, 34, 34, 3, 2, 50, 59, 4, 2, 67, 92, 99, 124, 4, 2, 48, 48, 97, 97, 
+	6, 2, 37, 38, 60, 60, 66, 66, 97, 97, 4, 2, 67, 67, 99, 99, 4, 2, 68, 68, 
+	100, 100, 4, 2, 69, 69, 101, 101, 4, 2, 70, 70, 102, 102, 4, 2, 71, 71, 
+	103, 103, 4, 2, 72, 72, 104, 104, 4, 2, 73, 73, 105, 105, 4, 2, 74, 74, 
+	106, 106, 4, 2, 75, 75, 107, 107, 4, 2, 76, 76, 108, 108, 4, 2, 77, 77, 
+	109, 109, 4, 2, 78, 78, 110, 110, 4, 2, 79, 79, 111, 111, 4, 2, 80, 80, 
+	112, 112, 4, 2, 81, 81, 113, 113, 4, 2, 82, 82, 114, 114, 4, 2, 83, 83, 
+	115, 115, 4, 2, 84, 84, 116, 116, 4, 2, 85, 85, 117, 117, 4, 2, 86, 86, 
+	118, 118, 4, 2, 87, 87, 119, 119, 4, 2, 88, 88, 120, 120, 4, 2, 89, 89, 
+	121, 121, 4, 2, 90, 90, 122, 122, 4, 2, 91, 91, 123, 123, 4, 2, 92, 92, 
+	124, 124, 2, 983, 2, 3, 3, 2, 2, 2, 2, 5, 3, 2, 2, 2, 2, 7, 3, 2, 2, 2, 
+	2, 9, 3, 2, 2, 2, 2, 11, 3, 2, 2, 2, 2, 13, 3, 2, 2, 2, 2, 15, 3, 2, 2, 
+	2, 2, 17, 3, 2, 2, 2, 2, 19, 3, 2, 2, 2, 2, 21, 3, 2, 2, 2, 2, 23, 3, 2, 
+	2, 2, 2, 25, 3, 2, 2, 2, 2, 27, 3, 2, 2, 2, 2, 29, 3, 2, 2, 2, 2, 31, 3, 
+	2, 2, 2, 2, 33, 3, 2, 2, 2, 2, 35, 3, 2, 2, 2, 2, 37, 3, 2, 2, 2, 2, 39, 
+	3, 2, 2, 2, 2, 41, 3, 2, 2, 2, 2, 43, 3, 2, 2, 2, 2, 45, 3, 2, 2, 2, 2, 
+	47, 3, 2, 2, 2, 2, 49, 3, 2, 2, 2, 2, 51, 3, 2, 2, 2, 2, 53, 3, 2, 2, 2, 
+	2, 55, 3, 2, 2, 2, 2, 57, 3, 2, 2, 2, 2, 59, 3, 2, 2, 2, 2, 61, 3, 2, 2, 
+	2, 2, 63, 3, 2, 2, 2, 2, 65, 3, 2, 2, 2, 2, 67, 3, 2, 2, 2, 2, 69, 3, 2, 
+	2, 2, 2, 71, 3, 2, 2, 2, 2, 73, 3, 2, 2, 2, 2, 75, 3, 2, 2, 2, 2, 77, 3, 
+	2, 2, 2, 2, 79, 3, 2, 2, 2, 2, 81, 3, 2, 2, 2, 2, 83, 3, 2, 2, 2, 2, 85, 
+	3, 2, 2, 2, 2, 87, 3, 2, 2, 2, 2, 89, 3, 2, 2, 2, 2, 91, 3, 2, 2, 2, 2, 
+	93, 3, 2, 2, 2, 2, 95, 3, 2, 2, 2, 2, 97, 3, 2, 2, 2, 2, 99, 3, 2, 2, 2, 
+	2, 101, 3, 2, 2, 2, 2, 103, 3, 2, 2, 2, 2, 105, 3, 2, 2, 2, 2, 107, 3, 
+	2, 2, 2, 2, 109, 3, 2, 2, 2, 2, 111, 3, 2, 2, 2, 2, 113, 3, 2, 2, 2, 2, 
+	115, 3, 2, 2, 2, 2, 117, 3, 2, 2, 2, 2, 119, 3, 2, 2, 2, 2, 121, 3, 2, 
+	2, 2, 2, 123, 3, 2, 2, 2, 2, 125, 3, 2, 2, 2, 2, 127, 3, 2, 2, 2, 2, 129, 
+	3, 2, 2, 2, 2, 131, 3, 2, 2, 2, 2, 133, 3, 2, 2, 2, 2, 135, 3, 2, 2, 2, 
+	2, 137, 3, 2, 2, 2, 2, 139, 3, 2, 2, 2, 2, 141, 3, 2, 2, 2, 2, 143, 3, 
+	2, 2, 2, 2, 145, 3, 2, 2, 2, 2, 147, 3, 2, 2, 2, 2, 149, 3, 2, 2, 2, 2, 
+	151, 3, 2, 2, 2, 2, 153, 3, 2, 2, 2, 2, 155, 3, 2, 2, 2, 2, 157, 3, 2, 
+	2, 2, 2, 159, 3, 2, 2, 2, 2, 161, 3, 2, 2, 2, 2, 163, 3, 2, 2, 2, 2, 165, 
+	3, 2, 2, 2, 2, 167, 3, 2, 2, 2, 2, 169, 3, 2, 2, 2, 2, 171, 3, 2, 2, 2, 
+	2, 173, 3, 2, 2, 2, 2, 175, 3, 2, 2, 2, 2, 177, 3, 2, 2, 2, 2, 179, 3, 
+	2, 2, 2, 2, 181, 3, 2, 2, 2, 2, 183, 3, 2, 2, 2, 2, 185, 3, 2, 2, 2, 2, 
+	187, 3, 2, 2, 2, 2, 189, 3, 2, 2, 2, 2, 191, 3, 2, 2, 2, 2, 193, 3, 2, 
+	2, 2, 2, 195, 3, 2, 2, 2, 2, 197, 3, 2, 2, 2, 2, 199, 3, 2, 2, 2, 2, 201, 
+	3, 2, 2, 2, 2, 203, 3, 2, 2, 2, 2, 205, 3, 2, 2, 2, 2, 207, 3, 2, 2, 2, 
+	2, 209, 3, 2, 2, 2, 2, 211, 3, 2, 2, 2, 2, 213, 3, 2, 2, 2, 2, 215, 3, 
+	2, 2, 2, 2, 217, 3, 2, 2, 2, 2, 219, 3, 2, 2, 2, 2, 221, 3, 2, 2, 2, 2, 
+	223, 3, 2, 2, 2, 2, 225, 3, 2, 2, 2, 3, 285, 3, 2, 2, 2, 5, 292, 3, 2, 
+	2, 2, 7, 299, 3, 2, 2, 2, 9, 303, 3, 2, 2, 2, 11, 308, 3, 2, 2, 2, 13, 
+	317, 3, 2, 2, 2, 15, 322, 3, 2, 2, 2, 17, 328, 3, 2, 2, 2, 19, 340, 3, 
+	2, 2, 2, 21, 344, 3, 2, 2, 2, 23, 352, 3, 2, 2, 2, 25, 360, 3, 2, 2, 2, 
+	27, 370, 3, 2, 2, 2, 29, 375, 3, 2, 2, 2, 31, 378, 3, 2, 2, 2, 33, 383, 
+	3, 2, 2, 2, 35, 392, 3, 2, 2, 2, 37, 402, 3, 2, 2, 2, 39, 412, 3, 2, 2, 
+	2, 41, 423, 3, 2, 2, 2, 43, 428, 3, 2, 2, 2, 45, 441, 3, 2, 2, 2, 47, 453, 
+	3, 2, 2, 2, 49, 459, 3, 2, 2, 2, 51, 466, 3, 2, 2, 2, 53, 470, 3, 2, 2, 
+	2, 55, 475, 3, 2, 2, 2, 57, 480, 3, 2, 2, 2, 59, 484, 3, 2, 2, 2, 61, 489, 
+	3, 2, 2, 2, 63, 496, 3, 2, 2, 2, 65, 502, 3, 2, 2, 2, 67, 507, 3, 2, 2, 
+	2, 69, 513, 3, 2, 2, 2, 71, 519, 3, 2, 2, 2, 73, 527, 3, 2, 2, 2, 75, 533, 
+	3, 2, 2, 2, 77, 541, 3, 2, 2, 2, 79, 551, 3, 2, 2, 2, 81, 558, 3, 2, 2, 
+	2, 83, 561, 3, 2, 2, 2, 85, 565, 3, 2, 2, 2, 87, 568, 3, 2, 2, 2, 89, 573, 
+	3, 2, 2, 2, 91, 578, 3, 2, 2, 2, 93, 587, 3, 2, 2, 2, 95, 593, 3, 2, 2, 
+	2, 97, 597, 3, 2, 2, 2, 99, 602, 3, 2, 2, 2, 101, 607, 3, 2, 2, 2, 103, 
+	611, 3, 2, 2, 2, 105, 619, 3, 2, 2, 2, 107, 622, 3, 2, 2, 2, 109, 628, 
+	3, 2, 2, 2, 111, 635, 3, 2, 2, 2, 113, 638, 3, 2, 2, 2, 115, 642, 3, 2, 
+	2, 2, 117, 648, 3, 2, 2, 2, 119, 653, 3, 2, 2, 2, 121, 657, 3, 2, 2, 2, 
+	123, 660, 3, 2, 2, 2, 125, 664, 3, 2, 2, 2, 127, 672, 3, 2, 2, 2, 129, 
+	676, 3, 2, 2, 2, 131, 680, 3, 2, 2, 2, 133, 684, 3, 2, 2, 2, 135, 690, 
+	3, 2, 2, 2, 137, 694, 3, 2, 2, 2, 139, 701, 3, 2, 2, 2, 141, 711, 3, 2, 
+	2, 2, 143, 720, 3, 2, 2, 2, 145, 725, 3, 2, 2, 2, 147, 731, 3, 2, 2, 2, 
+	149, 742, 3, 2, 2, 2, 151, 757, 3, 2, 2, 2, 153, 761, 3, 2, 2, 2, 155, 
+	772, 3, 2, 2, 2, 157, 776, 3, 2, 2, 2, 159, 783, 3, 2, 2, 2, 161, 785, 
+	3, 2, 2, 2, 163, 787, 3, 2, 2, 2, 165, 789, 3, 2, 2, 2, 167, 791, 3, 2, 
+	2, 2, 169, 793, 3, 2, 2, 2, 171, 795, 3, 2, 2, 2, 173, 797, 3, 2, 2, 2, 
+	175, 799, 3, 2, 2, 2, 177, 801, 3, 2, 2, 2, 179, 803, 3, 2, 2, 2, 181, 
+	806, 3, 2, 2, 2, 183, 809, 3, 2, 2, 2, 185, 811, 3, 2, 2, 2, 187, 814, 
+	3, 2, 2, 2, 189, 816, 3, 2, 2, 2, 191, 819, 3, 2, 2, 2, 193, 822, 3, 2, 
+	2, 2, 195, 825, 3, 2, 2, 2, 197, 827, 3, 2, 2, 2, 199, 829, 3, 2, 2, 2, 
+	201, 831, 3, 2, 2, 2, 203, 833, 3, 2, 2, 2, 205, 835, 3, 2, 2, 2, 207, 
+	837, 3, 2, 2, 2, 209, 839, 3, 2, 2, 2, 211, 841, 3, 2, 2, 2, 213, 843, 
+	3, 2, 2, 2, 215, 845, 3, 2, 2, 2, 217, 847, 3, 2, 2, 2, 219, 849, 3, 2, 
+	2, 2, 221, 852, 3, 2, 2, 2, 223, 875, 3, 2, 2, 2, 225, 878, 3, 2, 2, 2, 
+	227, 884, 3, 2, 2, 2, 229, 886, 3, 2, 2, 2, 231, 938, 3, 2, 2, 2, 233, 
+	940, 3, 2, 2, 2, 235, 942, 3, 2, 2, 2, 237, 944, 3, 2, 2, 2, 239, 946, 
+	3, 2, 2, 2, 241, 948, 3, 2, 2, 2, 243, 950, 3, 2, 2, 2, 245, 952, 3, 2, 
+	2, 2, 247, 954, 3, 2, 2, 2, 249, 956, 3, 2, 2, 2, 251, 958, 3, 2, 2, 2, 
+	253, 960, 3, 2, 2, 2, 255, 962, 3, 2, 2, 2, 257, 964, 3, 2, 2, 2, 259, 
+	966, 3, 2, 2, 2, 261, 968, 3, 2, 2, 2, 263, 970, 3, 2, 2, 2, 265, 972, 
+	3, 2, 2, 2, 267, 974, 3, 2, 2, 2, 269, 976, 3, 2, 2, 2, 271, 978, 3, 2, 
+	2, 2, 273, 980, 3, 2, 2, 2, 275, 982, 3, 2, 2, 2, 277, 984, 3, 2, 2, 2, 
+	279, 986, 3, 2, 2, 2, 281, 988, 3, 2, 2, 2, 283, 990, 3, 2, 2, 2, 285, 
+	286, 5, 237, 119, 2, 286, 287, 5, 267, 134, 2, 287, 288, 5, 241, 121, 2, 
+	288, 289, 5, 233, 117, 2, 289, 290, 5, 271, 136, 2, 290, 291, 5, 241, 121, 
+	2, 291, 4, 3, 2, 2, 2, 292, 293, 5, 273, 137, 2, 293, 294, 5, 263, 132, 
+	2, 294, 295, 5, 239, 120, 2, 295, 296, 5, 233, 117, 2, 296, 297, 5, 271, 
+	136, 2, 297, 298, 5, 241, 121, 2, 298, 6, 3, 2, 2, 2, 299, 300, 5, 269, 
+	135, 2, 300, 301, 5, 241, 121, 2, 301, 302, 5, 271, 136, 2, 302, 8, 3, 
+	2, 2, 2, 303, 304, 5, 239, 120, 2, 304, 305, 5, 267, 134, 2, 305, 306, 
+	5, 261, 131, 2, 306, 307, 5, 263, 132, 2, 307, 10, 3, 2, 2, 2, 308, 309, 
+	5, 249, 125, 2, 309, 310, 5, 259, 130, 2, 310, 311, 5, 271, 136, 2, 311, 
+	312, 5, 241, 121, 2, 312, 313, 5, 267, 134, 2, 313, 314, 5, 275, 138, 2, 
+	314, 315, 5, 233, 117, 2, 315, 316, 5, 255, 128, 2, 316, 12, 3, 2, 2, 2, 
+	317, 318, 5, 259, 130, 2, 318, 319, 5, 233, 117, 2, 319, 320, 5, 257, 129, 
+	2, 320, 321, 5, 241, 121, 2, 321, 14, 3, 2, 2, 2, 322, 323, 5, 269, 135, 
+	2, 323, 324, 5, 247, 124, 2, 324, 325, 5, 233, 117, 2, 325, 326, 5, 267, 
+	134, 2, 326, 327, 5, 239, 120, 2, 327, 16, 3, 2, 2, 2, 328, 329, 5, 267, 
+	134, 2, 329, 330, 5, 241, 121, 2, 330, 331, 5, 263, 132, 2, 331, 332, 5, 
+	255, 128, 2, 332, 333, 5, 249, 125, 2, 333, 334, 5, 237, 119, 2, 334, 335, 
+	5, 233, 117, 2, 335, 336, 5, 271, 136, 2, 336, 337, 5, 249, 125, 2, 337, 
+	338, 5, 261, 131, 2, 338, 339, 5, 259, 130, 2, 339, 18, 3, 2, 2, 2, 340, 
+	341, 5, 271, 136, 2, 341, 342, 5, 271, 136, 2, 342, 343, 5, 255, 128, 2, 
+	343, 20, 3, 2, 2, 2, 344, 345, 5, 257, 129, 2, 345, 346, 5, 241, 121, 2, 
+	346, 347, 5, 271, 136, 2, 347, 348, 5, 233, 117, 2, 348, 349, 5, 271, 136, 
+	2, 349, 350, 5, 271, 136, 2, 350, 351, 5, 255, 128, 2, 351, 22, 3, 2, 2, 
+	2, 352, 353, 5, 263, 132, 2, 353, 354, 5, 233, 117, 2, 354, 355, 5, 269, 
+	135, 2, 355, 356, 5, 271, 136, 2, 356, 357, 5, 271, 136, 2, 357, 358, 5, 
+	271, 136, 2, 358, 359, 5, 255, 128, 2, 359, 24, 3, 2, 2, 2, 360, 361, 5, 
+	243, 122, 2, 361, 362, 5, 273, 137, 2, 362, 363, 5, 271, 136, 2, 363, 364, 
+	5, 273, 137, 2, 364, 365, 5, 267, 134, 2, 365, 366, 5, 241, 121, 2, 366, 
+	367, 5, 271, 136, 2, 367, 368, 5, 271, 136, 2, 368, 369, 5, 255, 128, 2, 
+	369, 26, 3, 2, 2, 2, 370, 371, 5, 253, 127, 2, 371, 372, 5, 249, 125, 2, 
+	372, 373, 5, 255, 128, 2, 373, 374, 5, 255, 128, 2, 374, 28, 3, 2, 2, 2, 
+	375, 376, 5, 261, 131, 2, 376, 377, 5, 259, 130, 2, 377, 30, 3, 2, 2, 2, 
+	378, 379, 5, 269, 135, 2, 379, 380, 5, 247, 124, 2, 380, 381, 5, 261, 131, 
+	2, 381, 382, 5, 277, 139, 2, 382, 32, 3, 2, 2, 2, 383, 384, 5, 239, 120, 
+	2, 384, 385, 5, 233, 117, 2, 385, 386, 5, 271, 136, 2, 386, 387, 5, 233, 
+	117, 2, 387, 388, 5, 235, 118, 2, 388, 389, 5, 233, 117, 2, 389, 390, 5, 
+	269, 135, 2, 390, 391, 5, 241, 121, 2, 391, 34, 3, 2, 2, 2, 392, 393, 5, 
+	239, 120, 2, 393, 394, 5, 233, 117, 2, 394, 395, 5, 271, 136, 2, 395, 396, 
+	5, 233, 117, 2, 396, 397, 5, 235, 118, 2, 397, 398, 5, 233, 117, 2, 398, 
+	399, 5, 269, 135, 2, 399, 400, 5, 241, 121, 2, 400, 401, 5, 269, 135, 2, 
+	401, 36, 3, 2, 2, 2, 402, 403, 5, 259, 130, 2, 403, 404, 5, 233, 117, 2, 
+	404, 405, 5, 257, 129, 2, 405, 406, 5, 241, 121, 2, 406, 407, 5, 269, 135, 
+	2, 407, 408, 5, 263, 132, 2, 408, 409, 5, 233, 117, 2, 409, 410, 5, 237, 
+	119, 2, 410, 411, 5, 241, 121, 2, 411, 38, 3, 2, 2, 2, 412, 413, 5, 259, 
+	130, 2, 413, 414, 5, 233, 117, 2, 414, 415, 5, 257, 129, 2, 415, 416, 5, 
+	241, 121, 2, 416, 417, 5, 269, 135, 2, 417, 418, 5, 263, 132, 2, 418, 419, 
+	5, 233, 117, 2, 419, 420, 5, 237, 119, 2, 420, 421, 5, 241, 121, 2, 421, 
+	422, 5, 269, 135, 2, 422, 40, 3, 2, 2, 2, 423, 424, 5, 259, 130, 2, 424, 
+	425, 5, 261, 131, 2, 425, 426, 5, 239, 120, 2, 426, 427, 5, 241, 121, 2, 
+	427, 42, 3, 2, 2, 2, 428, 429, 5, 257, 129, 2, 429, 430, 5, 241, 121, 2, 
+	430, 431, 5, 233, 117, 2, 431, 432, 5, 269, 135, 2, 432, 433, 5, 273, 137, 
+	2, 433, 434, 5, 267, 134, 2, 434, 435, 5, 241, 121, 2, 435, 436, 5, 257, 
+	129, 2, 436, 437, 5, 241, 121, 2, 437, 438, 5, 259, 130, 2, 438, 439, 5, 
+	271, 136, 2, 439, 440, 5, 269, 135, 2, 440, 44, 3, 2, 2, 2, 441, 442, 5, 
+	257, 129, 2, 442, 443, 5, 241, 121, 2, 443, 444, 5, 233, 117, 2, 444, 445, 
+	5, 269, 135, 2, 445, 446, 5, 273, 137, 2, 446, 447, 5, 267, 134, 2, 447, 
+	448, 5, 241, 121, 2, 448, 449, 5, 257, 129, 2, 449, 450, 5, 241, 121, 2, 
+	450, 451, 5, 259, 130, 2, 451, 452, 5, 271, 136, 2, 452, 46, 3, 2, 2, 2, 
+	453, 454, 5, 243, 122, 2, 454, 455, 5, 249, 125, 2, 455, 456, 5, 241, 121, 
+	2, 456, 457, 5, 255, 128, 2, 457, 458, 5, 239, 120, 2, 458, 48, 3, 2, 2, 
+	2, 459, 460, 5, 243, 122, 2, 460, 461, 5, 249, 125, 2, 461, 462, 5, 241, 
+	121, 2, 462, 463, 5, 255, 128, 2, 463, 464, 5, 239, 120, 2, 464, 465, 5, 
+	269, 135, 2, 465, 50, 3, 2, 2, 2, 466, 467, 5, 271, 136, 2, 467, 468, 5, 
+	233, 117, 2, 468, 469, 5, 245, 123, 2, 469, 52, 3, 2, 2, 2, 470, 471, 5, 
+	249, 125, 2, 471, 472, 5, 259, 130, 2, 472, 473, 5, 243, 122, 2, 473, 474, 
+	5, 261, 131, 2, 474, 54, 3, 2, 2, 2, 475, 476, 5, 253, 127, 2, 476, 477, 
+	5, 241, 121, 2, 477, 478, 5, 281, 141, 2, 478, 479, 5, 269, 135, 2, 479, 
+	56, 3, 2, 2, 2, 480, 481, 5, 253, 127, 2, 481, 482, 5, 241, 121, 2, 482, 
+	483, 5, 281, 141, 2, 483, 58, 3, 2, 2, 2, 484, 485, 5, 277, 139, 2, 485, 
+	486, 5, 249, 125, 2, 486, 487, 5, 271, 136, 2, 487, 488, 5, 247, 124, 2, 
+	488, 60, 3, 2, 2, 2, 489, 490, 5, 275, 138, 2, 490, 491, 5, 233, 117, 2, 
+	491, 492, 5, 255, 128, 2, 492, 493, 5, 273, 137, 2, 493, 494, 5, 241, 121, 
+	2, 494, 495, 5, 269, 135, 2, 495, 62, 3, 2, 2, 2, 496, 497, 5, 275, 138, 
+	2, 497, 498, 5, 233, 117, 2, 498, 499, 5, 255, 128, 2, 499, 500, 5, 273, 
+	137, 2, 500, 501, 5, 241, 121, 2, 501, 64, 3, 2, 2, 2, 502, 503, 5, 243, 
+	122, 2, 503, 504, 5, 267, 134, 2, 504, 505, 5, 261, 131, 2, 505, 506, 5, 
+	257, 129, 2, 506, 66, 3, 2, 2, 2, 507, 508, 5, 277, 139, 2, 508, 509, 5, 
+	247, 124, 2, 509, 510, 5, 241, 121, 2, 510, 511, 5, 267, 134, 2, 511, 512, 
+	5, 241, 121, 2, 512, 68, 3, 2, 2, 2, 513, 514, 5, 255, 128, 2, 514, 515, 
+	5, 249, 125, 2, 515, 516, 5, 257, 129, 2, 516, 517, 5, 249, 125, 2, 517, 
+	518, 5, 271, 136, 2, 518, 70, 3, 2, 2, 2, 519, 520, 5, 265, 133, 2, 520, 
+	521, 5, 273, 137, 2, 521, 522, 5, 241, 121, 2, 522, 523, 5, 267, 134, 2, 
+	523, 524, 5, 249, 125, 2, 524, 525, 5, 241, 121, 2, 525, 526, 5, 269, 135, 
+	2, 526, 72, 3, 2, 2, 2, 527, 528, 5, 265, 133, 2, 528, 529, 5, 273, 137, 
+	2, 529, 530, 5, 241, 121, 2, 530, 531, 5, 267, 134, 2, 531, 532, 5, 281, 
+	141, 2, 532, 74, 3, 2, 2, 2, 533, 534, 5, 241, 121, 2, 534, 535, 5, 279, 
+	140, 2, 535, 536, 5, 263, 132, 2, 536, 537, 5, 255, 128, 2, 537, 538, 5, 
+	233, 117, 2, 538, 539, 5, 249, 125, 2, 539, 540, 5, 259, 130, 2, 540, 76, 
+	3, 2, 2, 2, 541, 542, 5, 277, 139, 2, 542, 543, 5, 249, 125, 2, 543, 544, 
+	5, 271, 136, 2, 544, 545, 5, 247, 124, 2, 545, 546, 5, 275, 138, 2, 546, 
+	547, 5, 233, 117, 2, 547, 548, 5, 255, 128, 2, 548, 549, 5, 273, 137, 2, 
+	549, 550, 5, 241, 121, 2, 550, 78, 3, 2, 2, 2, 551, 552, 5, 269, 135, 2, 
+	552, 553, 5, 241, 121, 2, 553, 554, 5, 255, 128, 2, 554, 555, 5, 241, 121, 
+	2, 555, 556, 5, 237, 119, 2, 556, 557, 5, 271, 136, 2, 557, 80, 3, 2, 2, 
+	2, 558, 559, 5, 233, 117, 2, 559, 560, 5, 269, 135, 2, 560, 82, 3, 2, 2, 
+	2, 561, 562, 5, 233, 117, 2, 562, 563, 5, 259, 130, 2, 563, 564, 5, 239, 
+	120, 2, 564, 84, 3, 2, 2, 2, 565, 566, 5, 261, 131, 2, 566, 567, 5, 267, 
+	134, 2, 567, 86, 3, 2, 2, 2, 568, 569, 5, 243, 122, 2, 569, 570, 5, 249, 
+	125, 2, 570, 571, 5, 255, 128, 2, 571, 572, 5, 255, 128, 2, 572, 88, 3, 
+	2, 2, 2, 573, 574, 5, 259, 130, 2, 574, 575, 5, 273, 137, 2, 575, 576, 
+	5, 255, 128, 2, 576, 577, 5, 255, 128, 2, 577, 90, 3, 2, 2, 2, 578, 579, 
+	5, 263, 132, 2, 579, 580, 5, 267, 134, 2, 580, 581, 5, 241, 121, 2, 581, 
+	582, 5, 275, 138, 2, 582, 583, 5, 249, 125, 2, 583, 584, 5, 261, 131, 2, 
+	584, 585, 5, 273, 137, 2, 585, 586, 5, 269, 135, 2, 586, 92, 3, 2, 2, 2, 
+	587, 588, 5, 261, 131, 2, 588, 589, 5, 267, 134, 2, 589, 590, 5, 239, 120, 
+	2, 590, 591, 5, 241, 121, 2, 591, 592, 5, 267, 134, 2, 592, 94, 3, 2, 2, 
+	2, 593, 594, 5, 233, 117, 2, 594, 595, 5, 269, 135, 2, 595, 596, 5, 237, 
+	119, 2, 596, 96, 3, 2, 2, 2, 597, 598, 5, 239, 120, 2, 598, 599, 5, 241, 
+	121, 2, 599, 600, 5, 269, 135, 2, 600, 601, 5, 237, 119, 2, 601, 98, 3, 
+	2, 2, 2, 602, 603, 5, 255, 128, 2, 603, 604, 5, 249, 125, 2, 604, 605, 
+	5, 253, 127, 2, 605, 606, 5, 241, 121, 2, 606, 100, 3, 2, 2, 2, 607, 608, 
+	5, 259, 130, 2, 608, 609, 5, 261, 131, 2, 609, 610, 5, 271, 136, 2, 610, 
+	102, 3, 2, 2, 2, 611, 612, 5, 235, 118, 2, 612, 613, 5, 241, 121, 2, 613, 
+	614, 5, 271, 136, 2, 614, 615, 5, 277, 139, 2, 615, 616, 5, 241, 121, 2, 
+	616, 617, 5, 241, 121, 2, 617, 618, 5, 259, 130, 2, 618, 104, 3, 2, 2, 
+	2, 619, 620, 5, 249, 125, 2, 620, 621, 5, 269, 135, 2, 621, 106, 3, 2, 
+	2, 2, 622, 623, 5, 245, 123, 2, 623, 624, 5, 267, 134, 2, 624, 625, 5, 
+	261, 131, 2, 625, 626, 5, 273, 137, 2, 626, 627, 5, 263, 132, 2, 627, 108, 
+	3, 2, 2, 2, 628, 629, 5, 247, 124, 2, 629, 630, 5, 233, 117, 2, 630, 631, 
+	5, 275, 138, 2, 631, 632, 5, 249, 125, 2, 632, 633, 5, 259, 130, 2, 633, 
+	634, 5, 245, 123, 2, 634, 110, 3, 2, 2, 2, 635, 636, 5, 235, 118, 2, 636, 
+	637, 5, 281, 141, 2, 637, 112, 3, 2, 2, 2, 638, 639, 5, 243, 122, 2, 639, 
+	640, 5, 261, 131, 2, 640, 641, 5, 267, 134, 2, 641, 114, 3, 2, 2, 2, 642, 
+	643, 5, 269, 135, 2, 643, 644, 5, 271, 136, 2, 644, 645, 5, 233, 117, 2, 
+	645, 646, 5, 271, 136, 2, 646, 647, 5, 269, 135, 2, 647, 116, 3, 2, 2, 
+	2, 648, 649, 5, 271, 136, 2, 649, 650, 5, 249, 125, 2, 650, 651, 5, 257, 
+	129, 2, 651, 652, 5, 241, 121, 2, 652, 118, 3, 2, 2, 2, 653, 654, 5, 259, 
+	130, 2, 654, 655, 5, 261, 131, 2, 655, 656, 5, 277, 139, 2, 656, 120, 3, 
+	2, 2, 2, 657, 658, 5, 249, 125, 2, 658, 659, 5, 259, 130, 2, 659, 122, 
+	3, 2, 2, 2, 660, 661, 5, 255, 128, 2, 661, 662, 5, 261, 131, 2, 662, 663, 
+	5, 245, 123, 2, 663, 124, 3, 2, 2, 2, 664, 665, 5, 263, 132, 2, 665, 666, 
+	5, 267, 134, 2, 666, 667, 5, 261, 131, 2, 667, 668, 5, 243, 122, 2, 668, 
+	669, 5, 249, 125, 2, 669, 670, 5, 255, 128, 2, 670, 671, 5, 241, 121, 2, 
+	671, 126, 3, 2, 2, 2, 672, 673, 5, 269, 135, 2, 673, 674, 5, 273, 137, 
+	2, 674, 675, 5, 257, 129, 2, 675, 128, 3, 2, 2, 2, 676, 677, 5, 257, 129, 
+	2, 677, 678, 5, 249, 125, 2, 678, 679, 5, 259, 130, 2, 679, 130, 3, 2, 
+	2, 2, 680, 681, 5, 257, 129, 2, 681, 682, 5, 233, 117, 2, 682, 683, 5, 
+	279, 140, 2, 683, 132, 3, 2, 2, 2, 684, 685, 5, 237, 119, 2, 685, 686, 
+	5, 261, 131, 2, 686, 687, 5, 273, 137, 2, 687, 688, 5, 259, 130, 2, 688, 
+	689, 5, 271, 136, 2, 689, 134, 3, 2, 2, 2, 690, 691, 5, 233, 117, 2, 691, 
+	692, 5, 275, 138, 2, 692, 693, 5, 245, 123, 2, 693, 136, 3, 2, 2, 2, 694, 
+	695, 5, 269, 135, 2, 695, 696, 5, 271, 136, 2, 696, 697, 5, 239, 120, 2, 
+	697, 698, 5, 239, 120, 2, 698, 699, 5, 241, 121, 2, 699, 700, 5, 275, 138, 
+	2, 700, 138, 3, 2, 2, 2, 701, 702, 5, 247, 124, 2, 702, 703, 5, 249, 125, 
+	2, 703, 704, 5, 269, 135, 2, 704, 705, 5, 271, 136, 2, 705, 706, 5, 261, 
+	131, 2, 706, 707, 5, 245, 123, 2, 707, 708, 5, 267, 134, 2, 708, 709, 5, 
+	233, 117, 2, 709, 710, 5, 257, 129, 2, 710, 140, 3, 2, 2, 2, 711, 712, 
+	5, 265, 133, 2, 712, 713, 5, 273, 137, 2, 713, 714, 5, 233, 117, 2, 714, 
+	715, 5, 259, 130, 2, 715, 716, 5, 271, 136, 2, 716, 717, 5, 249, 125, 2, 
+	717, 718, 5, 255, 128, 2, 718, 719, 5, 241, 121, 2, 719, 142, 3, 2, 2, 
+	2, 720, 721, 5, 267, 134, 2, 721, 722, 5, 233, 117, 2, 722, 723, 5, 271, 
+	136, 2, 723, 724, 5, 241, 121, 2, 724, 144, 3, 2, 2, 2, 725, 726, 5, 249, 
+	125, 2, 726, 727, 5, 267, 134, 2, 727, 728, 5, 233, 117, 2, 728, 729, 5, 
+	271, 136, 2, 729, 730, 5, 241, 121, 2, 730, 146, 3, 2, 2, 2, 731, 732, 
+	5, 239, 120, 2, 732, 733, 5, 241, 121, 2, 733, 734, 5, 267, 134, 2, 734, 
+	735, 5, 249, 125, 2, 735, 736, 5, 275, 138, 2, 736, 737, 5, 233, 117, 2, 
+	737, 738, 5, 271, 136, 2, 738, 739, 5, 249, 125, 2, 739, 740, 5, 275, 138, 
+	2, 740, 741, 5, 241, 121, 2, 741, 148, 3, 2, 2, 2, 742, 743, 5, 257, 129, 
+	2, 743, 744, 5, 261, 131, 2, 744, 745, 5, 275, 138, 2, 745, 746, 5, 249, 
+	125, 2, 746, 747, 5, 259, 130, 2, 747, 748, 5, 245, 123, 2, 748, 749, 7, 
+	97, 2, 2, 749, 750, 5, 233, 117, 2, 750, 751, 5, 275, 138, 2, 751, 752, 
+	5, 241, 121, 2, 752, 753, 5, 267, 134, 2, 753, 754, 5, 233, 117, 2, 754, 
+	755, 5, 245, 123, 2, 755, 756, 5, 241, 121, 2, 756, 150, 3, 2, 2, 2, 757, 
+	758, 5, 233, 117, 2, 758, 759, 5, 235, 118, 2, 759, 760, 5, 269, 135, 2, 
+	760, 152, 3, 2, 2, 2, 761, 762, 5, 271, 136, 2, 762, 763, 5, 249, 125, 
+	2, 763, 764, 5, 257, 129, 2, 764, 765, 5, 241, 121, 2, 765, 766, 7, 97, 
+	2, 2, 766, 767, 5, 269, 135, 2, 767, 768, 5, 247, 124, 2, 768, 769, 5, 
+	249, 125, 2, 769, 770, 5, 243, 122, 2, 770, 771, 5, 271, 136, 2, 771, 154, 
+	3, 2, 2, 2, 772, 773, 5, 271, 136, 2, 773, 774, 5, 261, 131, 2, 774, 775, 
+	5, 263, 132, 2, 775, 156, 3, 2, 2, 2, 776, 777, 5, 235, 118, 2, 777, 778, 
+	5, 261, 131, 2, 778, 779, 5, 271, 136, 2, 779, 780, 5, 271, 136, 2, 780, 
+	781, 5, 261, 131, 2, 781, 782, 5, 257, 129, 2, 782, 158, 3, 2, 2, 2, 783, 
+	784, 5, 269, 135, 2, 784, 160, 3, 2, 2, 2, 785, 786, 7, 111, 2, 2, 786, 
+	162, 3, 2, 2, 2, 787, 788, 5, 247, 124, 2, 788, 164, 3, 2, 2, 2, 789, 790, 
+	5, 239, 120, 2, 790, 166, 3, 2, 2, 2, 791, 792, 5, 277, 139, 2, 792, 168, 
+	3, 2, 2, 2, 793, 794, 7, 79, 2, 2, 794, 170, 3, 2, 2, 2, 795, 796, 5, 281, 
+	141, 2, 796, 172, 3, 2, 2, 2, 797, 798, 7, 48, 2, 2, 798, 174, 3, 2, 2, 
+	2, 799, 800, 7, 60, 2, 2, 800, 176, 3, 2, 2, 2, 801, 802, 7, 63, 2, 2, 
+	802, 178, 3, 2, 2, 2, 803, 804, 7, 62, 2, 2, 804, 805, 7, 64, 2, 2, 805, 
+	180, 3, 2, 2, 2, 806, 807, 7, 35, 2, 2, 807, 808, 7, 63, 2, 2, 808, 182, 
+	3, 2, 2, 2, 809, 810, 7, 64, 2, 2, 810, 184, 3, 2, 2, 2, 811, 812, 7, 64, 
+	2, 2, 812, 813, 7, 63, 2, 2, 813, 186, 3, 2, 2, 2, 814, 815, 7, 62, 2, 
+	2, 815, 188, 3, 2, 2, 2, 816, 817, 7, 62, 2, 2, 817, 818, 7, 63, 2, 2, 
+	818, 190, 3, 2, 2, 2, 819, 820, 7, 63, 2, 2, 820, 821, 7, 128, 2, 2, 821, 
+	192, 3, 2, 2, 2, 822, 823, 7, 35, 2, 2, 823, 824, 7, 128, 2, 2, 824, 194, 
+	3, 2, 2, 2, 825, 826, 7, 46, 2, 2, 826, 196, 3, 2, 2, 2, 827, 828, 7, 125, 
+	2, 2, 828, 198, 3, 2, 2, 2, 829, 830, 7, 127, 2, 2, 830, 200, 3, 2, 2, 
+	2, 831, 832, 7, 93, 2, 2, 832, 202, 3, 2, 2, 2, 833, 834, 7, 95, 2, 2, 
+	834, 204, 3, 2, 2, 2, 835, 836, 7, 42, 2, 2, 836, 206, 3, 2, 2, 2, 837, 
+	838, 7, 43, 2, 2, 838, 208, 3, 2, 2, 2, 839, 840, 7, 45, 2, 2, 840, 210, 
+	3, 2, 2, 2, 841, 842, 7, 47, 2, 2, 842, 212, 3, 2, 2, 2, 843, 844, 7, 49, 
+	2, 2, 844, 214, 3, 2, 2, 2, 845, 846, 7, 44, 2, 2, 846, 216, 3, 2, 2, 2, 
+	847, 848, 7, 39, 2, 2, 848, 218, 3, 2, 2, 2, 849, 850, 5, 231, 116, 2, 
+	850, 220, 3, 2, 2, 2, 851, 853, 5, 229, 115, 2, 852, 851, 3, 2, 2, 2, 853, 
+	854, 3, 2, 2, 2, 854, 852, 3, 2, 2, 2, 854, 855, 3, 2, 2, 2, 855, 222, 
+	3, 2, 2, 2, 856, 858, 5, 229, 115, 2, 857, 856, 3, 2, 2, 2, 858, 859, 3, 
+	2, 2, 2, 859, 857, 3, 2, 2, 2, 859, 860, 3, 2, 2, 2, 860, 861, 3, 2, 2, 
+	2, 861, 862, 7, 48, 2, 2, 862, 866, 10, 2, 2, 2, 863, 865, 5, 229, 115, 
+	2, 864, 863, 3, 2, 2, 2, 865, 868, 3, 2, 2, 2, 866, 864, 3, 2, 2, 2, 866, 
+	867, 3, 2, 2, 2, 867, 876, 3, 2, 2, 2, 868, 866, 3, 2, 2, 2, 869, 871, 
+	7, 48, 2, 2, 870, 872, 5, 229, 115, 2, 871, 870, 3, 2, 2, 2, 872, 873, 
+	3, 2, 2, 2, 873, 871, 3, 2, 2, 2, 873, 874, 3, 2, 2, 2, 874, 876, 3, 2, 
+	2, 2, 875, 857, 3, 2, 2, 2, 875, 869, 3, 2, 2, 2, 876, 224, 3, 2, 2, 2, 
+	877, 879, 5, 227, 114, 2, 878, 877, 3, 2, 2, 2, 879, 880, 3, 2, 2, 2, 880, 
+	878, 3, 2, 2, 2, 880, 881, 3, 2, 2, 2, 881, 882, 3, 2, 2, 2, 882, 883, 
+	8, 113, 2, 2, 883, 226, 3, 2, 2, 2, 884, 885, 9, 3, 2, 2, 885, 228, 3, 
+	2, 2, 2, 886, 887, 9, 4, 2, 2, 887, 230, 3, 2, 2, 2, 888, 894, 9, 5, 2, 
+	2, 889, 893, 9, 5, 2, 2, 890, 893, 5, 229, 115, 2, 891, 893, 9, 6, 2, 2, 
+	892, 889, 3, 2, 2, 2, 892, 890, 3, 2, 2, 2, 892, 891, 3, 2, 2, 2, 893, 
+	896, 3, 2, 2, 2, 894, 892, 3, 2, 2, 2, 894, 895, 3, 2, 2, 2, 895, 939, 
+	3, 2, 2, 2, 896, 894, 3, 2, 2, 2, 897, 898, 7, 38, 2, 2, 898, 902, 7, 125, 
+	2, 2, 899, 901, 11, 2, 2, 2, 900, 899, 3, 2, 2, 2, 901, 904, 3, 2, 2, 2, 
+	902, 903, 3, 2, 2, 2, 902, 900, 3, 2, 2, 2, 903, 905, 3, 2, 2, 2, 904, 
+	902, 3, 2, 2, 2, 905, 939, 7, 127, 2, 2, 906, 910, 9, 7, 2, 2, 907, 911, 
+	9, 5, 2, 2, 908, 911, 5, 229, 115, 2, 909, 911, 9, 7, 2, 2, 910, 907, 3, 
+	2, 2, 2, 910, 908, 3, 2, 2, 2, 910, 909, 3, 2, 2, 2, 911, 912, 3, 2, 2, 
+	2, 912, 910, 3, 2, 2, 2, 912, 913, 3, 2, 2, 2, 913, 939, 3, 2, 2, 2, 914, 
+	918, 7, 36, 2, 2, 915, 917, 11, 2, 2, 2, 916, 915, 3, 2, 2, 2, 917, 920, 
+	3, 2, 2, 2, 918, 919, 3, 2, 2, 2, 918, 916, 3, 2, 2, 2, 919, 921, 3, 2, 
+	2, 2, 920, 918, 3, 2, 2, 2, 921, 939, 7, 36, 2, 2, 922, 926, 7, 98, 2, 
+	2, 923, 925, 11, 2, 2, 2, 924, 923, 3, 2, 2, 2, 925, 928, 3, 2, 2, 2, 926, 
+	927, 3, 2, 2, 2, 926, 924, 3, 2, 2, 2, 927, 929, 3, 2, 2, 2, 928, 926, 
+	3, 2, 2, 2, 929, 939, 7, 98, 2, 2, 930, 934, 7, 41, 2, 2, 931, 933, 11, 
+	2, 2, 2, 932, 931, 3, 2, 2, 2, 933, 936, 3, 2, 2, 2, 934, 935, 3, 2, 2, 
+	2, 934, 932, 3, 2, 2, 2, 935, 937, 3, 2, 2, 2, 936, 934, 3, 2, 2, 2, 937, 
+	939, 7, 41, 2, 2, 938, 888, 3, 2, 2, 2, 938, 897, 3, 2, 2, 2, 938, 906, 
+	3, 2, 2, 2, 938, 914, 3, 2, 2, 2, 938, 922, 3, 2, 2, 2, 938, 930, 3, 2, 
+	2, 2, 939, 232, 3, 2, 2, 2, 940, 941, 9, 8, 2, 2, 941, 234, 3, 2, 2, 2, 
+	942, 943, 9, 9, 2, 2, 943, 236, 3, 2, 2, 2, 944, 945, 9, 10, 2, 2, 945, 
+	238, 3, 2, 2, 2, 946, 947, 9, 11, 2, 2, 947, 240, 3, 2, 2, 2, 948, 949, 
+	9, 12, 2, 2, 949, 242, 3, 2, 2, 2, 950, 951, 9, 13, 2, 2, 951, 244, 3, 
+	2, 2, 2, 952, 953, 9, 14, 2, 2, 953, 246, 3, 2, 2, 2, 954, 955, 9, 15, 
+	2, 2, 955, 248, 3, 2, 2, 2, 956, 957, 9, 16, 2, 2, 957, 250, 3, 2, 2, 2, 
+	958, 959, 9, 17, 2, 2, 959, 252, 3, 2, 2, 2, 960, 961, 9, 18, 2, 2, 961, 
+	254, 3, 2, 2, 2, 962, 963, 9, 19, 2, 2, 963, 256, 3, 2, 2, 2, 964, 965, 
+	9, 20, 2, 2, 965, 258, 3, 2, 2, 2, 966, 967, 9, 21, 2, 2, 967, 260, 3, 
+	2, 2, 2, 968, 969, 9, 22, 2, 2, 969, 262, 3, 2, 2, 2, 970, 971, 9, 23, 
+	2, 2, 971, 264, 3, 2, 2, 2, 972, 973, 9, 24, 2, 2, 973, 266, 3, 2, 2, 2, 
+	974, 975, 9, 25, 2, 2, 975, 268, 3, 2, 2, 2, 976, 977, 9, 26, 2, 2, 977, 
+	270, 3, 2, 2, 2, 978, 979, 9, 27, 2, 2, 979, 272, 3, 2, 2, 2, 980, 981, 
+	9, 28, 2, 2, 981, 274, 3, 2, 2, 2, 982, 983, 9, 29, 2, 2, 983, 276, 3, 
+	2, 2, 2, 984, 985, 9, 30, 2, 2, 985, 278, 3, 2, 2, 2, 986, 987, 9, 31, 
+	2, 2, 987, 280, 3, 2, 2, 2, 988, 989, 9, 32, 2, 2, 989, 282, 3, 2, 2, 2, 
+	990, 991, 9, 33, 2, 2, 991, 284, 3, 2, 2, 2, 18, 2, 854, 859, 866, 873, 
+	875, 880, 892, 894, 902, 910, 912, 918, 926, 934, 938, 3, 8, 2, 2,
 }
 
 var lexerDeserializer = antlr.NewATNDeserializer(nil)
@@ -436,10 +476,10 @@ var lexerLiteralNames = []string{
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
-	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "'m'", 
-	"", "", "", "'M'", "", "'.'", "':'", "'='", "'<>'", "'!='", "'>'", "'>='", 
-	"'<'", "'<='", "'=~'", "'!~'", "','", "'{'", "'}'", "'['", "']'", "'('", 
-	"')'", "'+'", "'-'", "'/'", "'*'", "'%'",
+	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
+	"", "", "", "", "", "", "", "", "'m'", "", "", "", "'M'", "", "'.'", "':'", 
+	"'='", "'<>'", "'!='", "'>'", "'>='", "'<'", "'<='", "'=~'", "'!~'", "','", 
+	"'{'", "'}'", "'['", "']'", "'('", "')'", "'+'", "'-'", "'/'", "'*'", "'%'",
 }
 
 var lexerSymbolicNames = []string{
@@ -453,12 +493,13 @@ var lexerSymbolicNames = []string{
 	"T_PREVIOUS", "T_ORDER", "T_ASC", "T_DESC", "T_LIKE", "T_NOT", "T_BETWEEN", 
 	"T_IS", "T_GROUP", "T_HAVING", "T_BY", "T_FOR", "T_STATS", "T_TIME", "T_NOW", 
 	"T_IN", "T_LOG", "T_PROFILE", "T_SUM", "T_MIN", "T_MAX", "T_COUNT", "T_AVG", 
-	"T_STDDEV", "T_HISTOGRAM", "T_SECOND", "T_MINUTE", "T_HOUR", "T_DAY", "T_WEEK", 
-	"T_MONTH", "T_YEAR", "T_DOT", "T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", 
-	"T_GREATER", "T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", 
-	"T_COMMA", "T_OPEN_B", "T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", 
-	"T_CLOSE_P", "T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", 
-	"L_DEC", "WS",
+	"T_STDDEV", "T_HISTOGRAM", "T_QUANTILE", "T_RATE", "T_IRATE", "T_DERIVATIVE", 
+	"T_MOVING_AVERAGE", "T_ABS", "T_TIME_SHIFT", "T_TOP", "T_BOTTOM", "T_SECOND", 
+	"T_MINUTE", "T_HOUR", "T_DAY", "T_WEEK", "T_MONTH", "T_YEAR", "T_DOT", 
+	"T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", "T_GREATER", "T_GREATEREQUAL", 
+	"T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", "T_COMMA", "T_OPEN_B", 
+	"T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", "T_CLOSE_P", "T_ADD", 
+	"T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", "L_DEC", "WS",
 }
 
 var lexerRuleNames = []string{
@@ -472,14 +513,16 @@ var lexerRuleNames = []string{
 	"T_PREVIOUS", "T_ORDER", "T_ASC", "T_DESC", "T_LIKE", "T_NOT", "T_BETWEEN", 
 	"T_IS", "T_GROUP", "T_HAVING", "T_BY", "T_FOR", "T_STATS", "T_TIME", "T_NOW", 
 	"T_IN", "T_LOG", "T_PROFILE", "T_SUM", "T_MIN", "T_MAX", "T_COUNT", "T_AVG", 
-	"T_STDDEV", "T_HISTOGRAM", "T_SECOND", "T_MINUTE", "T_HOUR", "T_DAY", "T_WEEK", 
-	"T_MONTH", "T_YEAR", "T_DOT", "T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", 
-	"T_GREATER", "T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", 
-	"T_COMMA", "T_OPEN_B", "T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", 
-	"T_CLOSE_P", "T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", 
-	"L_DEC", "WS", "BLANK", "L_DIGIT", "L_ID_PART", "A", "B", "C", "D", "E", 
-	"F", "G", "H", "I", "J", "K", "L", "M", "N", "O", "P", "Q", "R", "S", "T", 
-	"U", "V", "W", "X", "Y", "Z",
+	"T_STDDEV", "T_HISTOGRAM", "T_QUANTILE", "T_RATE", "T_IRATE", "T_DERIVATIVE", 
+	"T_MOVING_AVERAGE", "T_ABS", "T_TIME_SHIFT", "T_TOP", "T_BOTTOM", "T_SECOND", 
+	"T_MINUTE", "T_HOUR", "T_DAY", "T_WEEK", "T_MONTH", "T_YEAR", "T_DOT", 
+	"T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", "T_GREATER", "T_GREATEREQUAL", 
+	"T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", "T_COMMA", "T_OPEN_B", 
+	"T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", "T_CLOSE_P", "T_ADD", 
+	"T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", "L_DEC", "WS", "BLANK", 
+	"L_DIGIT", "L_ID_PART", "A", "B", "C", "D", "E", "F", "G", "H", "I", "J", 
+	"K", "L", "M", "N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", 
+	"Z",
 }
 
 type SQLLexer struct {
@@ -586,39 +629,48 @@ const (
 	SQLLexerT_AVG = 67
 	SQLLexerT_STDDEV = 68
 	SQLLexerT_HISTOGRAM = 69
-	SQLLexerT_SECOND = 70
-	SQLLexerT_MINUTE = 71
-	SQLLexerT_HOUR = 72
-	SQLLexerT_DAY = 73
-	SQLLexerT_WEEK = 74
-	SQLLexerT_MONTH = 75
-	SQLLexerT_YEAR = 76
-	SQLLexerT_DOT = 77
-	SQLLexerT_COLON = 78
-	SQLLexerT_EQUAL = 79
-	SQLLexerT_NOTEQUAL = 80
-	SQLLexerT_NOTEQUAL2 = 81
-	SQLLexerT_GREATER = 82
-	SQLLexerT_GREATEREQUAL = 83
-	SQLLexerT_LESS = 84
-	SQLLexerT_LESSEQUAL = 85
-	SQLLexerT_REGEXP = 86
-	SQLLexerT_NEQREGEXP = 87
-	SQLLexerT_COMMA = 88
-	SQLLexerT_OPEN_B = 89
-	SQLLexerT_CLOSE_B = 90
-	SQLLexerT_OPEN_SB = 91
-	SQLLexerT_CLOSE_SB = 92
-	SQLLexerT_OPEN_P = 93
-	SQLLexerT_CLOSE_P = 94
-	SQLLexerT_ADD = 95
-	SQLLexerT_SUB = 96
-	SQLLexerT_DIV = 97
-	SQLLexerT_MUL = 98
-	SQLLexerT_MOD = 99
-	SQLLexerL_ID = 100
-	SQLLexerL_INT = 101
-	SQLLexerL_DEC = 102
-	SQLLexerWS = 103
+	SQLLexerT_QUANTILE = 70
+	SQLLexerT_RATE = 71
+	SQLLexerT_IRATE = 72
+	SQLLexerT_DERIVATIVE = 73
+	SQLLexerT_MOVING_AVERAGE = 74
+	SQLLexerT_ABS = 75
+	SQLLexerT_TIME_SHIFT = 76
+	SQLLexerT_TOP = 77
+	SQLLexerT_BOTTOM = 78
+	SQLLexerT_SECOND = 79
+	SQLLexerT_MINUTE = 80
+	SQLLexerT_HOUR = 81
+	SQLLexerT_DAY = 82
+	SQLLexerT_WEEK = 83
+	SQLLexerT_MONTH = 84
+	SQLLexerT_YEAR = 85
+	SQLLexerT_DOT = 86
+	SQLLexerT_COLON = 87
+	SQLLexerT_EQUAL = 88
+	SQLLexerT_NOTEQUAL = 89
+	SQLLexerT_NOTEQUAL2 = 90
+	SQLLexerT_GREATER = 91
+	SQLLexerT_GREATEREQUAL = 92
+	SQLLexerT_LESS = 93
+	SQLLexerT_LESSEQUAL = 94
+	SQLLexerT_REGEXP = 95
+	SQLLexerT_NEQREGEXP = 96
+	SQLLexerT_COMMA = 97
+	SQLLexerT_OPEN_B = 98
+	SQLLexerT_CLOSE_B = 99
+	SQLLexerT_OPEN_SB = 100
+	SQLLexerT_CLOSE_SB = 101
+	SQLLexerT_OPEN_P = 102
+	SQLLexerT_CLOSE_P = 103
+	SQLLexerT_ADD = 104
+	SQLLexerT_SUB = 105
+	SQLLexerT_DIV = 106
+	SQLLexerT_MUL = 107
+	SQLLexerT_MOD = 108
+	SQLLexerL_ID = 109
+	SQLLexerL_INT = 110
+	SQLLexerL_DEC = 111
+	SQLLexerWS = 112
 )
 
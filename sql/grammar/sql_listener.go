@@ -40,6 +40,12 @@ type SQLListener interface {
 	// EnterNamespace is called when entering the namespace production.
 	EnterNamespace(c *NamespaceContext)
 
+	// EnterShowQueriesStmt is called when entering the showQueriesStmt production.
+	EnterShowQueriesStmt(c *ShowQueriesStmtContext)
+
+	// EnterKillQueryStmt is called when entering the killQueryStmt production.
+	EnterKillQueryStmt(c *KillQueryStmtContext)
+
 	// EnterQueryStmt is called when entering the queryStmt production.
 	EnterQueryStmt(c *QueryStmtContext)
 
@@ -205,6 +211,12 @@ type SQLListener interface {
 	// ExitNamespace is called when exiting the namespace production.
 	ExitNamespace(c *NamespaceContext)
 
+	// ExitShowQueriesStmt is called when exiting the showQueriesStmt production.
+	ExitShowQueriesStmt(c *ShowQueriesStmtContext)
+
+	// ExitKillQueryStmt is called when exiting the killQueryStmt production.
+	ExitKillQueryStmt(c *KillQueryStmtContext)
+
 	// ExitQueryStmt is called when exiting the queryStmt production.
 	ExitQueryStmt(c *QueryStmtContext)
 
@@ -16,7 +16,7 @@ var _ = strconv.Itoa
 
 
 var parserATN = []uint16{
-	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 105, 511, 
+	3, 24715, 42794, 33075, 47597, 16764, 15335, 30598, 22884, 3, 114, 524, 
 	4, 2, 9, 2, 4, 3, 9, 3, 4, 4, 9, 4, 4, 5, 9, 5, 4, 6, 9, 6, 4, 7, 9, 7, 
 	4, 8, 9, 8, 4, 9, 9, 9, 4, 10, 9, 10, 4, 11, 9, 11, 4, 12, 9, 12, 4, 13, 
 	9, 13, 4, 14, 9, 14, 4, 15, 9, 15, 4, 16, 9, 16, 4, 17, 9, 17, 4, 18, 9, 
@@ -27,223 +27,229 @@ var parserATN = []uint16{
 	39, 4, 40, 9, 40, 4, 41, 9, 41, 4, 42, 9, 42, 4, 43, 9, 43, 4, 44, 9, 44, 
 	4, 45, 9, 45, 4, 46, 9, 46, 4, 47, 9, 47, 4, 48, 9, 48, 4, 49, 9, 49, 4, 
 	50, 9, 50, 4, 51, 9, 51, 4, 52, 9, 52, 4, 53, 9, 53, 4, 54, 9, 54, 4, 55, 
-	9, 55, 4, 56, 9, 56, 3, 2, 3, 2, 3, 2, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 
-	3, 3, 3, 5, 3, 123, 10, 3, 3, 4, 3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 
-	5, 3, 5, 5, 5, 134, 10, 5, 3, 5, 5, 5, 137, 10, 5, 3, 6, 3, 6, 3, 6, 3, 
-	6, 5, 6, 143, 10, 6, 3, 6, 3, 6, 3, 6, 3, 6, 5, 6, 149, 10, 6, 3, 6, 5, 
-	6, 152, 10, 6, 3, 7, 3, 7, 3, 7, 3, 7, 5, 7, 158, 10, 7, 3, 7, 3, 7, 3, 
-	8, 3, 8, 3, 8, 3, 8, 3, 8, 5, 8, 167, 10, 8, 3, 8, 3, 8, 3, 9, 3, 9, 3, 
-	9, 3, 9, 3, 9, 5, 9, 176, 10, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 
-	9, 184, 10, 9, 3, 9, 5, 9, 187, 10, 9, 3, 10, 3, 10, 3, 11, 3, 11, 3, 12, 
-	3, 12, 3, 13, 5, 13, 196, 10, 13, 3, 13, 3, 13, 3, 13, 5, 13, 201, 10, 
-	13, 3, 13, 3, 13, 5, 13, 205, 10, 13, 3, 13, 5, 13, 208, 10, 13, 3, 13, 
-	5, 13, 211, 10, 13, 3, 13, 5, 13, 214, 10, 13, 3, 13, 5, 13, 217, 10, 13, 
-	3, 14, 3, 14, 3, 14, 3, 15, 3, 15, 3, 15, 7, 15, 225, 10, 15, 12, 15, 14, 
-	15, 228, 11, 15, 3, 16, 3, 16, 5, 16, 232, 10, 16, 3, 17, 3, 17, 3, 17, 
-	3, 18, 3, 18, 3, 18, 3, 19, 3, 19, 3, 19, 3, 20, 3, 20, 3, 20, 3, 20, 3, 
-	20, 3, 20, 3, 20, 3, 20, 5, 20, 251, 10, 20, 5, 20, 253, 10, 20, 3, 21, 
-	3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 
-	21, 3, 21, 3, 21, 5, 21, 269, 10, 21, 3, 21, 3, 21, 3, 21, 3, 21, 3, 21, 
-	3, 21, 5, 21, 277, 10, 21, 3, 21, 3, 21, 3, 21, 3, 21, 5, 21, 283, 10, 
-	21, 3, 21, 3, 21, 3, 21, 7, 21, 288, 10, 21, 12, 21, 14, 21, 291, 11, 21, 
-	3, 22, 3, 22, 3, 22, 7, 22, 296, 10, 22, 12, 22, 14, 22, 299, 11, 22, 3, 
-	23, 3, 23, 3, 23, 5, 23, 304, 10, 23, 3, 24, 3, 24, 3, 24, 3, 24, 5, 24, 
-	310, 10, 24, 3, 25, 3, 25, 5, 25, 314, 10, 25, 3, 26, 3, 26, 3, 26, 5, 
-	26, 319, 10, 26, 3, 26, 3, 26, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 3, 27, 
-	3, 27, 3, 27, 5, 27, 331, 10, 27, 3, 27, 5, 27, 334, 10, 27, 3, 28, 3, 
-	28, 3, 28, 7, 28, 339, 10, 28, 12, 28, 14, 28, 342, 11, 28, 3, 29, 3, 29, 
-	3, 29, 3, 29, 3, 29, 3, 29, 5, 29, 350, 10, 29, 3, 30, 3, 30, 3, 31, 3, 
-	31, 3, 31, 3, 31, 3, 32, 3, 32, 7, 32, 360, 10, 32, 12, 32, 14, 32, 363, 
-	11, 32, 3, 33, 3, 33, 3, 33, 7, 33, 368, 10, 33, 12, 33, 14, 33, 371, 11, 
-	33, 3, 34, 3, 34, 3, 34, 3, 35, 3, 35, 3, 35, 3, 35, 3, 35, 3, 35, 5, 35, 
-	382, 10, 35, 3, 35, 3, 35, 3, 35, 3, 35, 7, 35, 388, 10, 35, 12, 35, 14, 
-	35, 391, 11, 35, 3, 36, 3, 36, 3, 37, 3, 37, 3, 38, 3, 38, 3, 38, 3, 38, 
-	3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 3, 39, 5, 39, 409, 10, 
-	39, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 5, 40, 419, 
-	10, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 3, 40, 
-	3, 40, 3, 40, 3, 40, 7, 40, 433, 10, 40, 12, 40, 14, 40, 436, 11, 40, 3, 
-	41, 3, 41, 3, 41, 3, 42, 3, 42, 3, 43, 3, 43, 3, 43, 5, 43, 446, 10, 43, 
-	3, 43, 3, 43, 3, 44, 3, 44, 3, 45, 3, 45, 3, 45, 7, 45, 455, 10, 45, 12, 
-	45, 14, 45, 458, 11, 45, 3, 46, 3, 46, 5, 46, 462, 10, 46, 3, 47, 3, 47, 
-	5, 47, 466, 10, 47, 3, 47, 3, 47, 5, 47, 470, 10, 47, 3, 48, 3, 48, 3, 
-	48, 3, 48, 3, 49, 5, 49, 477, 10, 49, 3, 49, 3, 49, 3, 50, 5, 50, 482, 
-	10, 50, 3, 50, 3, 50, 3, 51, 3, 51, 3, 51, 3, 52, 3, 52, 3, 53, 3, 53, 
-	3, 54, 3, 54, 3, 55, 3, 55, 5, 55, 497, 10, 55, 3, 55, 3, 55, 3, 55, 5, 
-	55, 502, 10, 55, 7, 55, 504, 10, 55, 12, 55, 14, 55, 507, 11, 55, 3, 56, 
-	3, 56, 3, 56, 2, 5, 40, 68, 78, 57, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 
-	22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 50, 52, 54, 56, 
-	58, 60, 62, 64, 66, 68, 70, 72, 74, 76, 78, 80, 82, 84, 86, 88, 90, 92, 
-	94, 96, 98, 100, 102, 104, 106, 108, 110, 2, 10, 3, 2, 43, 44, 4, 2, 46, 
-	47, 103, 104, 3, 2, 49, 50, 4, 2, 51, 51, 88, 88, 3, 2, 72, 78, 3, 2, 65, 
-	71, 3, 2, 97, 98, 11, 2, 3, 3, 7, 7, 9, 11, 15, 27, 29, 32, 34, 38, 41, 
-	55, 57, 60, 64, 78, 2, 531, 2, 112, 3, 2, 2, 2, 4, 122, 3, 2, 2, 2, 6, 
-	124, 3, 2, 2, 2, 8, 127, 3, 2, 2, 2, 10, 138, 3, 2, 2, 2, 12, 153, 3, 2, 
-	2, 2, 14, 161, 3, 2, 2, 2, 16, 170, 3, 2, 2, 2, 18, 188, 3, 2, 2, 2, 20, 
-	190, 3, 2, 2, 2, 22, 192, 3, 2, 2, 2, 24, 195, 3, 2, 2, 2, 26, 218, 3, 
-	2, 2, 2, 28, 221, 3, 2, 2, 2, 30, 229, 3, 2, 2, 2, 32, 233, 3, 2, 2, 2, 
-	34, 236, 3, 2, 2, 2, 36, 239, 3, 2, 2, 2, 38, 252, 3, 2, 2, 2, 40, 282, 
-	3, 2, 2, 2, 42, 292, 3, 2, 2, 2, 44, 300, 3, 2, 2, 2, 46, 305, 3, 2, 2, 
-	2, 48, 311, 3, 2, 2, 2, 50, 315, 3, 2, 2, 2, 52, 322, 3, 2, 2, 2, 54, 335, 
-	3, 2, 2, 2, 56, 349, 3, 2, 2, 2, 58, 351, 3, 2, 2, 2, 60, 353, 3, 2, 2, 
-	2, 62, 357, 3, 2, 2, 2, 64, 364, 3, 2, 2, 2, 66, 372, 3, 2, 2, 2, 68, 381, 
-	3, 2, 2, 2, 70, 392, 3, 2, 2, 2, 72, 394, 3, 2, 2, 2, 74, 396, 3, 2, 2, 
-	2, 76, 408, 3, 2, 2, 2, 78, 418, 3, 2, 2, 2, 80, 437, 3, 2, 2, 2, 82, 440, 
-	3, 2, 2, 2, 84, 442, 3, 2, 2, 2, 86, 449, 3, 2, 2, 2, 88, 451, 3, 2, 2, 
-	2, 90, 461, 3, 2, 2, 2, 92, 469, 3, 2, 2, 2, 94, 471, 3, 2, 2, 2, 96, 476, 
-	3, 2, 2, 2, 98, 481, 3, 2, 2, 2, 100, 485, 3, 2, 2, 2, 102, 488, 3, 2, 
-	2, 2, 104, 490, 3, 2, 2, 2, 106, 492, 3, 2, 2, 2, 108, 496, 3, 2, 2, 2, 
-	110, 508, 3, 2, 2, 2, 112, 113, 5, 4, 3, 2, 113, 114, 7, 2, 2, 3, 114, 
-	3, 3, 2, 2, 2, 115, 123, 5, 6, 4, 2, 116, 123, 5, 8, 5, 2, 117, 123, 5, 
-	10, 6, 2, 118, 123, 5, 12, 7, 2, 119, 123, 5, 14, 8, 2, 120, 123, 5, 16, 
-	9, 2, 121, 123, 5, 24, 13, 2, 122, 115, 3, 2, 2, 2, 122, 116, 3, 2, 2, 
-	2, 122, 117, 3, 2, 2, 2, 122, 118, 3, 2, 2, 2, 122, 119, 3, 2, 2, 2, 122, 
-	120, 3, 2, 2, 2, 122, 121, 3, 2, 2, 2, 123, 5, 3, 2, 2, 2, 124, 125, 7, 
-	17, 2, 2, 125, 126, 7, 19, 2, 2, 126, 7, 3, 2, 2, 2, 127, 128, 7, 17, 2, 
-	2, 128, 133, 7, 21, 2, 2, 129, 130, 7, 35, 2, 2, 130, 131, 7, 20, 2, 2, 
-	131, 132, 7, 81, 2, 2, 132, 134, 5, 18, 10, 2, 133, 129, 3, 2, 2, 2, 133, 
-	134, 3, 2, 2, 2, 134, 136, 3, 2, 2, 2, 135, 137, 5, 100, 51, 2, 136, 135, 
-	3, 2, 2, 2, 136, 137, 3, 2, 2, 2, 137, 9, 3, 2, 2, 2, 138, 139, 7, 17, 
-	2, 2, 139, 142, 7, 23, 2, 2, 140, 141, 7, 16, 2, 2, 141, 143, 5, 22, 12, 
-	2, 142, 140, 3, 2, 2, 2, 142, 143, 3, 2, 2, 2, 143, 148, 3, 2, 2, 2, 144, 
-	145, 7, 35, 2, 2, 145, 146, 7, 24, 2, 2, 146, 147, 7, 81, 2, 2, 147, 149, 
-	5, 18, 10, 2, 148, 144, 3, 2, 2, 2, 148, 149, 3, 2, 2, 2, 149, 151, 3, 
-	2, 2, 2, 150, 152, 5, 100, 51, 2, 151, 150, 3, 2, 2, 2, 151, 152, 3, 2, 
-	2, 2, 152, 11, 3, 2, 2, 2, 153, 154, 7, 17, 2, 2, 154, 157, 7, 26, 2, 2, 
-	155, 156, 7, 16, 2, 2, 156, 158, 5, 22, 12, 2, 157, 155, 3, 2, 2, 2, 157, 
-	158, 3, 2, 2, 2, 158, 159, 3, 2, 2, 2, 159, 160, 5, 34, 18, 2, 160, 13, 
-	3, 2, 2, 2, 161, 162, 7, 17, 2, 2, 162, 163, 7, 27, 2, 2, 163, 166, 7, 
-	29, 2, 2, 164, 165, 7, 16, 2, 2, 165, 167, 5, 22, 12, 2, 166, 164, 3, 2, 
-	2, 2, 166, 167, 3, 2, 2, 2, 167, 168, 3, 2, 2, 2, 168, 169, 5, 34, 18, 
-	2, 169, 15, 3, 2, 2, 2, 170, 171, 7, 17, 2, 2, 171, 172, 7, 27, 2, 2, 172, 
-	175, 7, 32, 2, 2, 173, 174, 7, 16, 2, 2, 174, 176, 5, 22, 12, 2, 175, 173, 
-	3, 2, 2, 2, 175, 176, 3, 2, 2, 2, 176, 177, 3, 2, 2, 2, 177, 178, 5, 34, 
-	18, 2, 178, 179, 7, 31, 2, 2, 179, 180, 7, 30, 2, 2, 180, 181, 7, 81, 2, 
-	2, 181, 183, 5, 20, 11, 2, 182, 184, 5, 36, 19, 2, 183, 182, 3, 2, 2, 2, 
-	183, 184, 3, 2, 2, 2, 184, 186, 3, 2, 2, 2, 185, 187, 5, 100, 51, 2, 186, 
-	185, 3, 2, 2, 2, 186, 187, 3, 2, 2, 2, 187, 17, 3, 2, 2, 2, 188, 189, 5, 
-	108, 55, 2, 189, 19, 3, 2, 2, 2, 190, 191, 5, 108, 55, 2, 191, 21, 3, 2, 
-	2, 2, 192, 193, 5, 108, 55, 2, 193, 23, 3, 2, 2, 2, 194, 196, 7, 39, 2, 
-	2, 195, 194, 3, 2, 2, 2, 195, 196, 3, 2, 2, 2, 196, 197, 3, 2, 2, 2, 197, 
-	200, 5, 26, 14, 2, 198, 199, 7, 16, 2, 2, 199, 201, 5, 22, 12, 2, 200, 
-	198, 3, 2, 2, 2, 200, 201, 3, 2, 2, 2, 201, 202, 3, 2, 2, 2, 202, 204, 
-	5, 34, 18, 2, 203, 205, 5, 36, 19, 2, 204, 203, 3, 2, 2, 2, 204, 205, 3, 
-	2, 2, 2, 205, 207, 3, 2, 2, 2, 206, 208, 5, 52, 27, 2, 207, 206, 3, 2, 
-	2, 2, 207, 208, 3, 2, 2, 2, 208, 210, 3, 2, 2, 2, 209, 211, 5, 60, 31, 
-	2, 210, 209, 3, 2, 2, 2, 210, 211, 3, 2, 2, 2, 211, 213, 3, 2, 2, 2, 212, 
-	214, 5, 100, 51, 2, 213, 212, 3, 2, 2, 2, 213, 214, 3, 2, 2, 2, 214, 216, 
-	3, 2, 2, 2, 215, 217, 7, 40, 2, 2, 216, 215, 3, 2, 2, 2, 216, 217, 3, 2, 
-	2, 2, 217, 25, 3, 2, 2, 2, 218, 219, 7, 41, 2, 2, 219, 220, 5, 28, 15, 
-	2, 220, 27, 3, 2, 2, 2, 221, 226, 5, 30, 16, 2, 222, 223, 7, 90, 2, 2, 
-	223, 225, 5, 30, 16, 2, 224, 222, 3, 2, 2, 2, 225, 228, 3, 2, 2, 2, 226, 
-	224, 3, 2, 2, 2, 226, 227, 3, 2, 2, 2, 227, 29, 3, 2, 2, 2, 228, 226, 3, 
-	2, 2, 2, 229, 231, 5, 78, 40, 2, 230, 232, 5, 32, 17, 2, 231, 230, 3, 2, 
-	2, 2, 231, 232, 3, 2, 2, 2, 232, 31, 3, 2, 2, 2, 233, 234, 7, 42, 2, 2, 
-	234, 235, 5, 108, 55, 2, 235, 33, 3, 2, 2, 2, 236, 237, 7, 34, 2, 2, 237, 
-	238, 5, 102, 52, 2, 238, 35, 3, 2, 2, 2, 239, 240, 7, 35, 2, 2, 240, 241, 
-	5, 38, 20, 2, 241, 37, 3, 2, 2, 2, 242, 253, 5, 40, 21, 2, 243, 244, 5, 
-	40, 21, 2, 244, 245, 7, 43, 2, 2, 245, 246, 5, 44, 23, 2, 246, 253, 3, 
-	2, 2, 2, 247, 250, 5, 44, 23, 2, 248, 249, 7, 43, 2, 2, 249, 251, 5, 40, 
-	21, 2, 250, 248, 3, 2, 2, 2, 250, 251, 3, 2, 2, 2, 251, 253, 3, 2, 2, 2, 
-	252, 242, 3, 2, 2, 2, 252, 243, 3, 2, 2, 2, 252, 247, 3, 2, 2, 2, 253, 
-	39, 3, 2, 2, 2, 254, 255, 8, 21, 1, 2, 255, 256, 7, 95, 2, 2, 256, 257, 
-	5, 40, 21, 2, 257, 258, 7, 96, 2, 2, 258, 283, 3, 2, 2, 2, 259, 268, 5, 
-	104, 53, 2, 260, 269, 7, 81, 2, 2, 261, 269, 7, 51, 2, 2, 262, 263, 7, 
-	52, 2, 2, 263, 269, 7, 51, 2, 2, 264, 269, 7, 88, 2, 2, 265, 269, 7, 89, 
-	2, 2, 266, 269, 7, 82, 2, 2, 267, 269, 7, 83, 2, 2, 268, 260, 3, 2, 2, 
-	2, 268, 261, 3, 2, 2, 2, 268, 262, 3, 2, 2, 2, 268, 264, 3, 2, 2, 2, 268, 
-	265, 3, 2, 2, 2, 268, 266, 3, 2, 2, 2, 268, 267, 3, 2, 2, 2, 269, 270, 
-	3, 2, 2, 2, 270, 271, 5, 106, 54, 2, 271, 283, 3, 2, 2, 2, 272, 276, 5, 
-	104, 53, 2, 273, 277, 7, 62, 2, 2, 274, 275, 7, 52, 2, 2, 275, 277, 7, 
-	62, 2, 2, 276, 273, 3, 2, 2, 2, 276, 274, 3, 2, 2, 2, 277, 278, 3, 2, 2, 
-	2, 278, 279, 7, 95, 2, 2, 279, 280, 5, 42, 22, 2, 280, 281, 7, 96, 2, 2, 
-	281, 283, 3, 2, 2, 2, 282, 254, 3, 2, 2, 2, 282, 259, 3, 2, 2, 2, 282, 
-	272, 3, 2, 2, 2, 283, 289, 3, 2, 2, 2, 284, 285, 12, 3, 2, 2, 285, 286, 
-	9, 2, 2, 2, 286, 288, 5, 40, 21, 4, 287, 284, 3, 2, 2, 2, 288, 291, 3, 
-	2, 2, 2, 289, 287, 3, 2, 2, 2, 289, 290, 3, 2, 2, 2, 290, 41, 3, 2, 2, 
-	2, 291, 289, 3, 2, 2, 2, 292, 297, 5, 106, 54, 2, 293, 294, 7, 90, 2, 2, 
-	294, 296, 5, 106, 54, 2, 295, 293, 3, 2, 2, 2, 296, 299, 3, 2, 2, 2, 297, 
-	295, 3, 2, 2, 2, 297, 298, 3, 2, 2, 2, 298, 43, 3, 2, 2, 2, 299, 297, 3, 
-	2, 2, 2, 300, 303, 5, 46, 24, 2, 301, 302, 7, 43, 2, 2, 302, 304, 5, 46, 
-	24, 2, 303, 301, 3, 2, 2, 2, 303, 304, 3, 2, 2, 2, 304, 45, 3, 2, 2, 2, 
-	305, 306, 7, 60, 2, 2, 306, 309, 5, 76, 39, 2, 307, 310, 5, 48, 25, 2, 
-	308, 310, 5, 108, 55, 2, 309, 307, 3, 2, 2, 2, 309, 308, 3, 2, 2, 2, 310, 
-	47, 3, 2, 2, 2, 311, 313, 5, 50, 26, 2, 312, 314, 5, 80, 41, 2, 313, 312, 
-	3, 2, 2, 2, 313, 314, 3, 2, 2, 2, 314, 49, 3, 2, 2, 2, 315, 316, 7, 61, 
-	2, 2, 316, 318, 7, 95, 2, 2, 317, 319, 5, 88, 45, 2, 318, 317, 3, 2, 2, 
-	2, 318, 319, 3, 2, 2, 2, 319, 320, 3, 2, 2, 2, 320, 321, 7, 96, 2, 2, 321, 
-	51, 3, 2, 2, 2, 322, 323, 7, 55, 2, 2, 323, 324, 7, 57, 2, 2, 324, 330, 
-	5, 54, 28, 2, 325, 326, 7, 45, 2, 2, 326, 327, 7, 95, 2, 2, 327, 328, 5, 
-	58, 30, 2, 328, 329, 7, 96, 2, 2, 329, 331, 3, 2, 2, 2, 330, 325, 3, 2, 
-	2, 2, 330, 331, 3, 2, 2, 2, 331, 333, 3, 2, 2, 2, 332, 334, 5, 66, 34, 
-	2, 333, 332, 3, 2, 2, 2, 333, 334, 3, 2, 2, 2, 334, 53, 3, 2, 2, 2, 335, 
-	340, 5, 56, 29, 2, 336, 337, 7, 90, 2, 2, 337, 339, 5, 56, 29, 2, 338, 
-	336, 3, 2, 2, 2, 339, 342, 3, 2, 2, 2, 340, 338, 3, 2, 2, 2, 340, 341, 
-	3, 2, 2, 2, 341, 55, 3, 2, 2, 2, 342, 340, 3, 2, 2, 2, 343, 350, 5, 108, 
-	55, 2, 344, 345, 7, 60, 2, 2, 345, 346, 7, 95, 2, 2, 346, 347, 5, 80, 41, 
-	2, 347, 348, 7, 96, 2, 2, 348, 350, 3, 2, 2, 2, 349, 343, 3, 2, 2, 2, 349, 
-	344, 3, 2, 2, 2, 350, 57, 3, 2, 2, 2, 351, 352, 9, 3, 2, 2, 352, 59, 3, 
-	2, 2, 2, 353, 354, 7, 48, 2, 2, 354, 355, 7, 57, 2, 2, 355, 356, 5, 64, 
-	33, 2, 356, 61, 3, 2, 2, 2, 357, 361, 5, 78, 40, 2, 358, 360, 9, 4, 2, 
-	2, 359, 358, 3, 2, 2, 2, 360, 363, 3, 2, 2, 2, 361, 359, 3, 2, 2, 2, 361, 
-	362, 3, 2, 2, 2, 362, 63, 3, 2, 2, 2, 363, 361, 3, 2, 2, 2, 364, 369, 5, 
-	62, 32, 2, 365, 366, 7, 90, 2, 2, 366, 368, 5, 62, 32, 2, 367, 365, 3, 
-	2, 2, 2, 368, 371, 3, 2, 2, 2, 369, 367, 3, 2, 2, 2, 369, 370, 3, 2, 2, 
-	2, 370, 65, 3, 2, 2, 2, 371, 369, 3, 2, 2, 2, 372, 373, 7, 56, 2, 2, 373, 
-	374, 5, 68, 35, 2, 374, 67, 3, 2, 2, 2, 375, 376, 8, 35, 1, 2, 376, 377, 
-	7, 95, 2, 2, 377, 378, 5, 68, 35, 2, 378, 379, 7, 96, 2, 2, 379, 382, 3, 
-	2, 2, 2, 380, 382, 5, 72, 37, 2, 381, 375, 3, 2, 2, 2, 381, 380, 3, 2, 
-	2, 2, 382, 389, 3, 2, 2, 2, 383, 384, 12, 4, 2, 2, 384, 385, 5, 70, 36, 
-	2, 385, 386, 5, 68, 35, 5, 386, 388, 3, 2, 2, 2, 387, 383, 3, 2, 2, 2, 
-	388, 391, 3, 2, 2, 2, 389, 387, 3, 2, 2, 2, 389, 390, 3, 2, 2, 2, 390, 
-	69, 3, 2, 2, 2, 391, 389, 3, 2, 2, 2, 392, 393, 9, 2, 2, 2, 393, 71, 3, 
-	2, 2, 2, 394, 395, 5, 74, 38, 2, 395, 73, 3, 2, 2, 2, 396, 397, 5, 78, 
-	40, 2, 397, 398, 5, 76, 39, 2, 398, 399, 5, 78, 40, 2, 399, 75, 3, 2, 2, 
-	2, 400, 409, 7, 81, 2, 2, 401, 409, 7, 82, 2, 2, 402, 409, 7, 83, 2, 2, 
-	403, 409, 7, 86, 2, 2, 404, 409, 7, 87, 2, 2, 405, 409, 7, 84, 2, 2, 406, 
-	409, 7, 85, 2, 2, 407, 409, 9, 5, 2, 2, 408, 400, 3, 2, 2, 2, 408, 401, 
-	3, 2, 2, 2, 408, 402, 3, 2, 2, 2, 408, 403, 3, 2, 2, 2, 408, 404, 3, 2, 
-	2, 2, 408, 405, 3, 2, 2, 2, 408, 406, 3, 2, 2, 2, 408, 407, 3, 2, 2, 2, 
-	409, 77, 3, 2, 2, 2, 410, 411, 8, 40, 1, 2, 411, 412, 7, 95, 2, 2, 412, 
-	413, 5, 78, 40, 2, 413, 414, 7, 96, 2, 2, 414, 419, 3, 2, 2, 2, 415, 419, 
-	5, 84, 43, 2, 416, 419, 5, 92, 47, 2, 417, 419, 5, 80, 41, 2, 418, 410, 
-	3, 2, 2, 2, 418, 415, 3, 2, 2, 2, 418, 416, 3, 2, 2, 2, 418, 417, 3, 2, 
-	2, 2, 419, 434, 3, 2, 2, 2, 420, 421, 12, 10, 2, 2, 421, 422, 7, 100, 2, 
-	2, 422, 433, 5, 78, 40, 11, 423, 424, 12, 9, 2, 2, 424, 425, 7, 99, 2, 
-	2, 425, 433, 5, 78, 40, 10, 426, 427, 12, 8, 2, 2, 427, 428, 7, 97, 2, 
-	2, 428, 433, 5, 78, 40, 9, 429, 430, 12, 7, 2, 2, 430, 431, 7, 98, 2, 2, 
-	431, 433, 5, 78, 40, 8, 432, 420, 3, 2, 2, 2, 432, 423, 3, 2, 2, 2, 432, 
-	426, 3, 2, 2, 2, 432, 429, 3, 2, 2, 2, 433, 436, 3, 2, 2, 2, 434, 432, 
-	3, 2, 2, 2, 434, 435, 3, 2, 2, 2, 435, 79, 3, 2, 2, 2, 436, 434, 3, 2, 
-	2, 2, 437, 438, 5, 96, 49, 2, 438, 439, 5, 82, 42, 2, 439, 81, 3, 2, 2, 
-	2, 440, 441, 9, 6, 2, 2, 441, 83, 3, 2, 2, 2, 442, 443, 5, 86, 44, 2, 443, 
-	445, 7, 95, 2, 2, 444, 446, 5, 88, 45, 2, 445, 444, 3, 2, 2, 2, 445, 446, 
-	3, 2, 2, 2, 446, 447, 3, 2, 2, 2, 447, 448, 7, 96, 2, 2, 448, 85, 3, 2, 
-	2, 2, 449, 450, 9, 7, 2, 2, 450, 87, 3, 2, 2, 2, 451, 456, 5, 90, 46, 2, 
-	452, 453, 7, 90, 2, 2, 453, 455, 5, 90, 46, 2, 454, 452, 3, 2, 2, 2, 455, 
-	458, 3, 2, 2, 2, 456, 454, 3, 2, 2, 2, 456, 457, 3, 2, 2, 2, 457, 89, 3, 
-	2, 2, 2, 458, 456, 3, 2, 2, 2, 459, 462, 5, 78, 40, 2, 460, 462, 5, 40, 
-	21, 2, 461, 459, 3, 2, 2, 2, 461, 460, 3, 2, 2, 2, 462, 91, 3, 2, 2, 2, 
-	463, 465, 5, 108, 55, 2, 464, 466, 5, 94, 48, 2, 465, 464, 3, 2, 2, 2, 
-	465, 466, 3, 2, 2, 2, 466, 470, 3, 2, 2, 2, 467, 470, 5, 98, 50, 2, 468, 
-	470, 5, 96, 49, 2, 469, 463, 3, 2, 2, 2, 469, 467, 3, 2, 2, 2, 469, 468, 
-	3, 2, 2, 2, 470, 93, 3, 2, 2, 2, 471, 472, 7, 93, 2, 2, 472, 473, 5, 40, 
-	21, 2, 473, 474, 7, 94, 2, 2, 474, 95, 3, 2, 2, 2, 475, 477, 9, 8, 2, 2, 
-	476, 475, 3, 2, 2, 2, 476, 477, 3, 2, 2, 2, 477, 478, 3, 2, 2, 2, 478, 
-	479, 7, 103, 2, 2, 479, 97, 3, 2, 2, 2, 480, 482, 9, 8, 2, 2, 481, 480, 
-	3, 2, 2, 2, 481, 482, 3, 2, 2, 2, 482, 483, 3, 2, 2, 2, 483, 484, 7, 104, 
-	2, 2, 484, 99, 3, 2, 2, 2, 485, 486, 7, 36, 2, 2, 486, 487, 7, 103, 2, 
-	2, 487, 101, 3, 2, 2, 2, 488, 489, 5, 108, 55, 2, 489, 103, 3, 2, 2, 2, 
-	490, 491, 5, 108, 55, 2, 491, 105, 3, 2, 2, 2, 492, 493, 5, 108, 55, 2, 
-	493, 107, 3, 2, 2, 2, 494, 497, 7, 102, 2, 2, 495, 497, 5, 110, 56, 2, 
-	496, 494, 3, 2, 2, 2, 496, 495, 3, 2, 2, 2, 497, 505, 3, 2, 2, 2, 498, 
-	501, 7, 79, 2, 2, 499, 502, 7, 102, 2, 2, 500, 502, 5, 110, 56, 2, 501, 
-	499, 3, 2, 2, 2, 501, 500, 3, 2, 2, 2, 502, 504, 3, 2, 2, 2, 503, 498, 
-	3, 2, 2, 2, 504, 507, 3, 2, 2, 2, 505, 503, 3, 2, 2, 2, 505, 506, 3, 2, 
-	2, 2, 506, 109, 3, 2, 2, 2, 507, 505, 3, 2, 2, 2, 508, 509, 9, 9, 2, 2, 
-	509, 111, 3, 2, 2, 2, 55, 122, 133, 136, 142, 148, 151, 157, 166, 175, 
-	183, 186, 195, 200, 204, 207, 210, 213, 216, 226, 231, 250, 252, 268, 276, 
-	282, 289, 297, 303, 309, 313, 318, 330, 333, 340, 349, 361, 369, 381, 389, 
-	408, 418, 432, 434, 445, 456, 461, 465, 469, 476, 481, 496, 501, 505,
+	9, 55, 4, 56, 9, 56, 4, 57, 9, 57, 4, 58, 9, 58, 3, 2, 3, 2, 3, 2, 3, 3, 
+	3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 5, 3, 129, 10, 3, 3, 4, 
+	3, 4, 3, 4, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 3, 5, 5, 5, 140, 10, 5, 3, 5, 
+	5, 5, 143, 10, 5, 3, 6, 3, 6, 3, 6, 3, 6, 5, 6, 149, 10, 6, 3, 6, 3, 6, 
+	3, 6, 3, 6, 5, 6, 155, 10, 6, 3, 6, 5, 6, 158, 10, 6, 3, 7, 3, 7, 3, 7, 
+	3, 7, 5, 7, 164, 10, 7, 3, 7, 3, 7, 3, 8, 3, 8, 3, 8, 3, 8, 3, 8, 5, 8, 
+	173, 10, 8, 3, 8, 3, 8, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 9, 182, 10, 9, 
+	3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 3, 9, 5, 9, 190, 10, 9, 3, 9, 5, 9, 193, 
+	10, 9, 3, 10, 3, 10, 3, 11, 3, 11, 3, 12, 3, 12, 3, 13, 3, 13, 3, 13, 3, 
+	14, 3, 14, 3, 14, 3, 14, 3, 15, 5, 15, 209, 10, 15, 3, 15, 3, 15, 3, 15, 
+	5, 15, 214, 10, 15, 3, 15, 3, 15, 5, 15, 218, 10, 15, 3, 15, 5, 15, 221, 
+	10, 15, 3, 15, 5, 15, 224, 10, 15, 3, 15, 5, 15, 227, 10, 15, 3, 15, 5, 
+	15, 230, 10, 15, 3, 16, 3, 16, 3, 16, 3, 17, 3, 17, 3, 17, 7, 17, 238, 
+	10, 17, 12, 17, 14, 17, 241, 11, 17, 3, 18, 3, 18, 5, 18, 245, 10, 18, 
+	3, 19, 3, 19, 3, 19, 3, 20, 3, 20, 3, 20, 3, 21, 3, 21, 3, 21, 3, 22, 3, 
+	22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 3, 22, 5, 22, 264, 10, 22, 5, 22, 
+	266, 10, 22, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 
+	23, 3, 23, 3, 23, 3, 23, 3, 23, 3, 23, 5, 23, 282, 10, 23, 3, 23, 3, 23, 
+	3, 23, 3, 23, 3, 23, 3, 23, 5, 23, 290, 10, 23, 3, 23, 3, 23, 3, 23, 3, 
+	23, 5, 23, 296, 10, 23, 3, 23, 3, 23, 3, 23, 7, 23, 301, 10, 23, 12, 23, 
+	14, 23, 304, 11, 23, 3, 24, 3, 24, 3, 24, 7, 24, 309, 10, 24, 12, 24, 14, 
+	24, 312, 11, 24, 3, 25, 3, 25, 3, 25, 5, 25, 317, 10, 25, 3, 26, 3, 26, 
+	3, 26, 3, 26, 5, 26, 323, 10, 26, 3, 27, 3, 27, 5, 27, 327, 10, 27, 3, 
+	28, 3, 28, 3, 28, 5, 28, 332, 10, 28, 3, 28, 3, 28, 3, 29, 3, 29, 3, 29, 
+	3, 29, 3, 29, 3, 29, 3, 29, 3, 29, 5, 29, 344, 10, 29, 3, 29, 5, 29, 347, 
+	10, 29, 3, 30, 3, 30, 3, 30, 7, 30, 352, 10, 30, 12, 30, 14, 30, 355, 11, 
+	30, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 3, 31, 5, 31, 363, 10, 31, 3, 32, 
+	3, 32, 3, 33, 3, 33, 3, 33, 3, 33, 3, 34, 3, 34, 7, 34, 373, 10, 34, 12, 
+	34, 14, 34, 376, 11, 34, 3, 35, 3, 35, 3, 35, 7, 35, 381, 10, 35, 12, 35, 
+	14, 35, 384, 11, 35, 3, 36, 3, 36, 3, 36, 3, 37, 3, 37, 3, 37, 3, 37, 3, 
+	37, 3, 37, 5, 37, 395, 10, 37, 3, 37, 3, 37, 3, 37, 3, 37, 7, 37, 401, 
+	10, 37, 12, 37, 14, 37, 404, 11, 37, 3, 38, 3, 38, 3, 39, 3, 39, 3, 40, 
+	3, 40, 3, 40, 3, 40, 3, 41, 3, 41, 3, 41, 3, 41, 3, 41, 3, 41, 3, 41, 3, 
+	41, 5, 41, 422, 10, 41, 3, 42, 3, 42, 3, 42, 3, 42, 3, 42, 3, 42, 3, 42, 
+	3, 42, 5, 42, 432, 10, 42, 3, 42, 3, 42, 3, 42, 3, 42, 3, 42, 3, 42, 3, 
+	42, 3, 42, 3, 42, 3, 42, 3, 42, 3, 42, 7, 42, 446, 10, 42, 12, 42, 14, 
+	42, 449, 11, 42, 3, 43, 3, 43, 3, 43, 3, 44, 3, 44, 3, 45, 3, 45, 3, 45, 
+	5, 45, 459, 10, 45, 3, 45, 3, 45, 3, 46, 3, 46, 3, 47, 3, 47, 3, 47, 7, 
+	47, 468, 10, 47, 12, 47, 14, 47, 471, 11, 47, 3, 48, 3, 48, 5, 48, 475, 
+	10, 48, 3, 49, 3, 49, 5, 49, 479, 10, 49, 3, 49, 3, 49, 5, 49, 483, 10, 
+	49, 3, 50, 3, 50, 3, 50, 3, 50, 3, 51, 5, 51, 490, 10, 51, 3, 51, 3, 51, 
+	3, 52, 5, 52, 495, 10, 52, 3, 52, 3, 52, 3, 53, 3, 53, 3, 53, 3, 54, 3, 
+	54, 3, 55, 3, 55, 3, 56, 3, 56, 3, 57, 3, 57, 5, 57, 510, 10, 57, 3, 57, 
+	3, 57, 3, 57, 5, 57, 515, 10, 57, 7, 57, 517, 10, 57, 12, 57, 14, 57, 520, 
+	11, 57, 3, 58, 3, 58, 3, 58, 2, 5, 44, 72, 82, 59, 2, 4, 6, 8, 10, 12, 
+	14, 16, 18, 20, 22, 24, 26, 28, 30, 32, 34, 36, 38, 40, 42, 44, 46, 48, 
+	50, 52, 54, 56, 58, 60, 62, 64, 66, 68, 70, 72, 74, 76, 78, 80, 82, 84, 
+	86, 88, 90, 92, 94, 96, 98, 100, 102, 104, 106, 108, 110, 112, 114, 2, 
+	10, 3, 2, 43, 44, 4, 2, 46, 47, 112, 113, 3, 2, 49, 50, 4, 2, 51, 51, 97, 
+	97, 3, 2, 81, 87, 4, 2, 63, 63, 65, 80, 3, 2, 106, 107, 11, 2, 3, 3, 7, 
+	7, 9, 11, 15, 27, 29, 32, 34, 38, 41, 55, 57, 60, 63, 87, 2, 544, 2, 116, 
+	3, 2, 2, 2, 4, 128, 3, 2, 2, 2, 6, 130, 3, 2, 2, 2, 8, 133, 3, 2, 2, 2, 
+	10, 144, 3, 2, 2, 2, 12, 159, 3, 2, 2, 2, 14, 167, 3, 2, 2, 2, 16, 176, 
+	3, 2, 2, 2, 18, 194, 3, 2, 2, 2, 20, 196, 3, 2, 2, 2, 22, 198, 3, 2, 2, 
+	2, 24, 200, 3, 2, 2, 2, 26, 203, 3, 2, 2, 2, 28, 208, 3, 2, 2, 2, 30, 231, 
+	3, 2, 2, 2, 32, 234, 3, 2, 2, 2, 34, 242, 3, 2, 2, 2, 36, 246, 3, 2, 2, 
+	2, 38, 249, 3, 2, 2, 2, 40, 252, 3, 2, 2, 2, 42, 265, 3, 2, 2, 2, 44, 295, 
+	3, 2, 2, 2, 46, 305, 3, 2, 2, 2, 48, 313, 3, 2, 2, 2, 50, 318, 3, 2, 2, 
+	2, 52, 324, 3, 2, 2, 2, 54, 328, 3, 2, 2, 2, 56, 335, 3, 2, 2, 2, 58, 348, 
+	3, 2, 2, 2, 60, 362, 3, 2, 2, 2, 62, 364, 3, 2, 2, 2, 64, 366, 3, 2, 2, 
+	2, 66, 370, 3, 2, 2, 2, 68, 377, 3, 2, 2, 2, 70, 385, 3, 2, 2, 2, 72, 394, 
+	3, 2, 2, 2, 74, 405, 3, 2, 2, 2, 76, 407, 3, 2, 2, 2, 78, 409, 3, 2, 2, 
+	2, 80, 421, 3, 2, 2, 2, 82, 431, 3, 2, 2, 2, 84, 450, 3, 2, 2, 2, 86, 453, 
+	3, 2, 2, 2, 88, 455, 3, 2, 2, 2, 90, 462, 3, 2, 2, 2, 92, 464, 3, 2, 2, 
+	2, 94, 474, 3, 2, 2, 2, 96, 482, 3, 2, 2, 2, 98, 484, 3, 2, 2, 2, 100, 
+	489, 3, 2, 2, 2, 102, 494, 3, 2, 2, 2, 104, 498, 3, 2, 2, 2, 106, 501, 
+	3, 2, 2, 2, 108, 503, 3, 2, 2, 2, 110, 505, 3, 2, 2, 2, 112, 509, 3, 2, 
+	2, 2, 114, 521, 3, 2, 2, 2, 116, 117, 5, 4, 3, 2, 117, 118, 7, 2, 2, 3, 
+	118, 3, 3, 2, 2, 2, 119, 129, 5, 6, 4, 2, 120, 129, 5, 8, 5, 2, 121, 129, 
+	5, 10, 6, 2, 122, 129, 5, 12, 7, 2, 123, 129, 5, 14, 8, 2, 124, 129, 5, 
+	16, 9, 2, 125, 129, 5, 24, 13, 2, 126, 129, 5, 26, 14, 2, 127, 129, 5, 
+	28, 15, 2, 128, 119, 3, 2, 2, 2, 128, 120, 3, 2, 2, 2, 128, 121, 3, 2, 
+	2, 2, 128, 122, 3, 2, 2, 2, 128, 123, 3, 2, 2, 2, 128, 124, 3, 2, 2, 2, 
+	128, 125, 3, 2, 2, 2, 128, 126, 3, 2, 2, 2, 128, 127, 3, 2, 2, 2, 129, 
+	5, 3, 2, 2, 2, 130, 131, 7, 17, 2, 2, 131, 132, 7, 19, 2, 2, 132, 7, 3, 
+	2, 2, 2, 133, 134, 7, 17, 2, 2, 134, 139, 7, 21, 2, 2, 135, 136, 7, 35, 
+	2, 2, 136, 137, 7, 20, 2, 2, 137, 138, 7, 90, 2, 2, 138, 140, 5, 18, 10, 
+	2, 139, 135, 3, 2, 2, 2, 139, 140, 3, 2, 2, 2, 140, 142, 3, 2, 2, 2, 141, 
+	143, 5, 104, 53, 2, 142, 141, 3, 2, 2, 2, 142, 143, 3, 2, 2, 2, 143, 9, 
+	3, 2, 2, 2, 144, 145, 7, 17, 2, 2, 145, 148, 7, 23, 2, 2, 146, 147, 7, 
+	16, 2, 2, 147, 149, 5, 22, 12, 2, 148, 146, 3, 2, 2, 2, 148, 149, 3, 2, 
+	2, 2, 149, 154, 3, 2, 2, 2, 150, 151, 7, 35, 2, 2, 151, 152, 7, 24, 2, 
+	2, 152, 153, 7, 90, 2, 2, 153, 155, 5, 18, 10, 2, 154, 150, 3, 2, 2, 2, 
+	154, 155, 3, 2, 2, 2, 155, 157, 3, 2, 2, 2, 156, 158, 5, 104, 53, 2, 157, 
+	156, 3, 2, 2, 2, 157, 158, 3, 2, 2, 2, 158, 11, 3, 2, 2, 2, 159, 160, 7, 
+	17, 2, 2, 160, 163, 7, 26, 2, 2, 161, 162, 7, 16, 2, 2, 162, 164, 5, 22, 
+	12, 2, 163, 161, 3, 2, 2, 2, 163, 164, 3, 2, 2, 2, 164, 165, 3, 2, 2, 2, 
+	165, 166, 5, 38, 20, 2, 166, 13, 3, 2, 2, 2, 167, 168, 7, 17, 2, 2, 168, 
+	169, 7, 27, 2, 2, 169, 172, 7, 29, 2, 2, 170, 171, 7, 16, 2, 2, 171, 173, 
+	5, 22, 12, 2, 172, 170, 3, 2, 2, 2, 172, 173, 3, 2, 2, 2, 173, 174, 3, 
+	2, 2, 2, 174, 175, 5, 38, 20, 2, 175, 15, 3, 2, 2, 2, 176, 177, 7, 17, 
+	2, 2, 177, 178, 7, 27, 2, 2, 178, 181, 7, 32, 2, 2, 179, 180, 7, 16, 2, 
+	2, 180, 182, 5, 22, 12, 2, 181, 179, 3, 2, 2, 2, 181, 182, 3, 2, 2, 2, 
+	182, 183, 3, 2, 2, 2, 183, 184, 5, 38, 20, 2, 184, 185, 7, 31, 2, 2, 185, 
+	186, 7, 30, 2, 2, 186, 187, 7, 90, 2, 2, 187, 189, 5, 20, 11, 2, 188, 190, 
+	5, 40, 21, 2, 189, 188, 3, 2, 2, 2, 189, 190, 3, 2, 2, 2, 190, 192, 3, 
+	2, 2, 2, 191, 193, 5, 104, 53, 2, 192, 191, 3, 2, 2, 2, 192, 193, 3, 2, 
+	2, 2, 193, 17, 3, 2, 2, 2, 194, 195, 5, 112, 57, 2, 195, 19, 3, 2, 2, 2, 
+	196, 197, 5, 112, 57, 2, 197, 21, 3, 2, 2, 2, 198, 199, 5, 112, 57, 2, 
+	199, 23, 3, 2, 2, 2, 200, 201, 7, 17, 2, 2, 201, 202, 7, 37, 2, 2, 202, 
+	25, 3, 2, 2, 2, 203, 204, 7, 15, 2, 2, 204, 205, 7, 38, 2, 2, 205, 206, 
+	7, 112, 2, 2, 206, 27, 3, 2, 2, 2, 207, 209, 7, 39, 2, 2, 208, 207, 3, 
+	2, 2, 2, 208, 209, 3, 2, 2, 2, 209, 210, 3, 2, 2, 2, 210, 213, 5, 30, 16, 
+	2, 211, 212, 7, 16, 2, 2, 212, 214, 5, 22, 12, 2, 213, 211, 3, 2, 2, 2, 
+	213, 214, 3, 2, 2, 2, 214, 215, 3, 2, 2, 2, 215, 217, 5, 38, 20, 2, 216, 
+	218, 5, 40, 21, 2, 217, 216, 3, 2, 2, 2, 217, 218, 3, 2, 2, 2, 218, 220, 
+	3, 2, 2, 2, 219, 221, 5, 56, 29, 2, 220, 219, 3, 2, 2, 2, 220, 221, 3, 
+	2, 2, 2, 221, 223, 3, 2, 2, 2, 222, 224, 5, 64, 33, 2, 223, 222, 3, 2, 
+	2, 2, 223, 224, 3, 2, 2, 2, 224, 226, 3, 2, 2, 2, 225, 227, 5, 104, 53, 
+	2, 226, 225, 3, 2, 2, 2, 226, 227, 3, 2, 2, 2, 227, 229, 3, 2, 2, 2, 228, 
+	230, 7, 40, 2, 2, 229, 228, 3, 2, 2, 2, 229, 230, 3, 2, 2, 2, 230, 29, 
+	3, 2, 2, 2, 231, 232, 7, 41, 2, 2, 232, 233, 5, 32, 17, 2, 233, 31, 3, 
+	2, 2, 2, 234, 239, 5, 34, 18, 2, 235, 236, 7, 99, 2, 2, 236, 238, 5, 34, 
+	18, 2, 237, 235, 3, 2, 2, 2, 238, 241, 3, 2, 2, 2, 239, 237, 3, 2, 2, 2, 
+	239, 240, 3, 2, 2, 2, 240, 33, 3, 2, 2, 2, 241, 239, 3, 2, 2, 2, 242, 244, 
+	5, 82, 42, 2, 243, 245, 5, 36, 19, 2, 244, 243, 3, 2, 2, 2, 244, 245, 3, 
+	2, 2, 2, 245, 35, 3, 2, 2, 2, 246, 247, 7, 42, 2, 2, 247, 248, 5, 112, 
+	57, 2, 248, 37, 3, 2, 2, 2, 249, 250, 7, 34, 2, 2, 250, 251, 5, 106, 54, 
+	2, 251, 39, 3, 2, 2, 2, 252, 253, 7, 35, 2, 2, 253, 254, 5, 42, 22, 2, 
+	254, 41, 3, 2, 2, 2, 255, 266, 5, 44, 23, 2, 256, 257, 5, 44, 23, 2, 257, 
+	258, 7, 43, 2, 2, 258, 259, 5, 48, 25, 2, 259, 266, 3, 2, 2, 2, 260, 263, 
+	5, 48, 25, 2, 261, 262, 7, 43, 2, 2, 262, 264, 5, 44, 23, 2, 263, 261, 
+	3, 2, 2, 2, 263, 264, 3, 2, 2, 2, 264, 266, 3, 2, 2, 2, 265, 255, 3, 2, 
+	2, 2, 265, 256, 3, 2, 2, 2, 265, 260, 3, 2, 2, 2, 266, 43, 3, 2, 2, 2, 
+	267, 268, 8, 23, 1, 2, 268, 269, 7, 104, 2, 2, 269, 270, 5, 44, 23, 2, 
+	270, 271, 7, 105, 2, 2, 271, 296, 3, 2, 2, 2, 272, 281, 5, 108, 55, 2, 
+	273, 282, 7, 90, 2, 2, 274, 282, 7, 51, 2, 2, 275, 276, 7, 52, 2, 2, 276, 
+	282, 7, 51, 2, 2, 277, 282, 7, 97, 2, 2, 278, 282, 7, 98, 2, 2, 279, 282, 
+	7, 91, 2, 2, 280, 282, 7, 92, 2, 2, 281, 273, 3, 2, 2, 2, 281, 274, 3, 
+	2, 2, 2, 281, 275, 3, 2, 2, 2, 281, 277, 3, 2, 2, 2, 281, 278, 3, 2, 2, 
+	2, 281, 279, 3, 2, 2, 2, 281, 280, 3, 2, 2, 2, 282, 283, 3, 2, 2, 2, 283, 
+	284, 5, 110, 56, 2, 284, 296, 3, 2, 2, 2, 285, 289, 5, 108, 55, 2, 286, 
+	290, 7, 62, 2, 2, 287, 288, 7, 52, 2, 2, 288, 290, 7, 62, 2, 2, 289, 286, 
+	3, 2, 2, 2, 289, 287, 3, 2, 2, 2, 290, 291, 3, 2, 2, 2, 291, 292, 7, 104, 
+	2, 2, 292, 293, 5, 46, 24, 2, 293, 294, 7, 105, 2, 2, 294, 296, 3, 2, 2, 
+	2, 295, 267, 3, 2, 2, 2, 295, 272, 3, 2, 2, 2, 295, 285, 3, 2, 2, 2, 296, 
+	302, 3, 2, 2, 2, 297, 298, 12, 3, 2, 2, 298, 299, 9, 2, 2, 2, 299, 301, 
+	5, 44, 23, 4, 300, 297, 3, 2, 2, 2, 301, 304, 3, 2, 2, 2, 302, 300, 3, 
+	2, 2, 2, 302, 303, 3, 2, 2, 2, 303, 45, 3, 2, 2, 2, 304, 302, 3, 2, 2, 
+	2, 305, 310, 5, 110, 56, 2, 306, 307, 7, 99, 2, 2, 307, 309, 5, 110, 56, 
+	2, 308, 306, 3, 2, 2, 2, 309, 312, 3, 2, 2, 2, 310, 308, 3, 2, 2, 2, 310, 
+	311, 3, 2, 2, 2, 311, 47, 3, 2, 2, 2, 312, 310, 3, 2, 2, 2, 313, 316, 5, 
+	50, 26, 2, 314, 315, 7, 43, 2, 2, 315, 317, 5, 50, 26, 2, 316, 314, 3, 
+	2, 2, 2, 316, 317, 3, 2, 2, 2, 317, 49, 3, 2, 2, 2, 318, 319, 7, 60, 2, 
+	2, 319, 322, 5, 80, 41, 2, 320, 323, 5, 52, 27, 2, 321, 323, 5, 112, 57, 
+	2, 322, 320, 3, 2, 2, 2, 322, 321, 3, 2, 2, 2, 323, 51, 3, 2, 2, 2, 324, 
+	326, 5, 54, 28, 2, 325, 327, 5, 84, 43, 2, 326, 325, 3, 2, 2, 2, 326, 327, 
+	3, 2, 2, 2, 327, 53, 3, 2, 2, 2, 328, 329, 7, 61, 2, 2, 329, 331, 7, 104, 
+	2, 2, 330, 332, 5, 92, 47, 2, 331, 330, 3, 2, 2, 2, 331, 332, 3, 2, 2, 
+	2, 332, 333, 3, 2, 2, 2, 333, 334, 7, 105, 2, 2, 334, 55, 3, 2, 2, 2, 335, 
+	336, 7, 55, 2, 2, 336, 337, 7, 57, 2, 2, 337, 343, 5, 58, 30, 2, 338, 339, 
+	7, 45, 2, 2, 339, 340, 7, 104, 2, 2, 340, 341, 5, 62, 32, 2, 341, 342, 
+	7, 105, 2, 2, 342, 344, 3, 2, 2, 2, 343, 338, 3, 2, 2, 2, 343, 344, 3, 
+	2, 2, 2, 344, 346, 3, 2, 2, 2, 345, 347, 5, 70, 36, 2, 346, 345, 3, 2, 
+	2, 2, 346, 347, 3, 2, 2, 2, 347, 57, 3, 2, 2, 2, 348, 353, 5, 60, 31, 2, 
+	349, 350, 7, 99, 2, 2, 350, 352, 5, 60, 31, 2, 351, 349, 3, 2, 2, 2, 352, 
+	355, 3, 2, 2, 2, 353, 351, 3, 2, 2, 2, 353, 354, 3, 2, 2, 2, 354, 59, 3, 
+	2, 2, 2, 355, 353, 3, 2, 2, 2, 356, 363, 5, 112, 57, 2, 357, 358, 7, 60, 
+	2, 2, 358, 359, 7, 104, 2, 2, 359, 360, 5, 84, 43, 2, 360, 361, 7, 105, 
+	2, 2, 361, 363, 3, 2, 2, 2, 362, 356, 3, 2, 2, 2, 362, 357, 3, 2, 2, 2, 
+	363, 61, 3, 2, 2, 2, 364, 365, 9, 3, 2, 2, 365, 63, 3, 2, 2, 2, 366, 367, 
+	7, 48, 2, 2, 367, 368, 7, 57, 2, 2, 368, 369, 5, 68, 35, 2, 369, 65, 3, 
+	2, 2, 2, 370, 374, 5, 82, 42, 2, 371, 373, 9, 4, 2, 2, 372, 371, 3, 2, 
+	2, 2, 373, 376, 3, 2, 2, 2, 374, 372, 3, 2, 2, 2, 374, 375, 3, 2, 2, 2, 
+	375, 67, 3, 2, 2, 2, 376, 374, 3, 2, 2, 2, 377, 382, 5, 66, 34, 2, 378, 
+	379, 7, 99, 2, 2, 379, 381, 5, 66, 34, 2, 380, 378, 3, 2, 2, 2, 381, 384, 
+	3, 2, 2, 2, 382, 380, 3, 2, 2, 2, 382, 383, 3, 2, 2, 2, 383, 69, 3, 2, 
+	2, 2, 384, 382, 3, 2, 2, 2, 385, 386, 7, 56, 2, 2, 386, 387, 5, 72, 37, 
+	2, 387, 71, 3, 2, 2, 2, 388, 389, 8, 37, 1, 2, 389, 390, 7, 104, 2, 2, 
+	390, 391, 5, 72, 37, 2, 391, 392, 7, 105, 2, 2, 392, 395, 3, 2, 2, 2, 393, 
+	395, 5, 76, 39, 2, 394, 388, 3, 2, 2, 2, 394, 393, 3, 2, 2, 2, 395, 402, 
+	3, 2, 2, 2, 396, 397, 12, 4, 2, 2, 397, 398, 5, 74, 38, 2, 398, 399, 5, 
+	72, 37, 5, 399, 401, 3, 2, 2, 2, 400, 396, 3, 2, 2, 2, 401, 404, 3, 2, 
+	2, 2, 402, 400, 3, 2, 2, 2, 402, 403, 3, 2, 2, 2, 403, 73, 3, 2, 2, 2, 
+	404, 402, 3, 2, 2, 2, 405, 406, 9, 2, 2, 2, 406, 75, 3, 2, 2, 2, 407, 408, 
+	5, 78, 40, 2, 408, 77, 3, 2, 2, 2, 409, 410, 5, 82, 42, 2, 410, 411, 5, 
+	80, 41, 2, 411, 412, 5, 82, 42, 2, 412, 79, 3, 2, 2, 2, 413, 422, 7, 90, 
+	2, 2, 414, 422, 7, 91, 2, 2, 415, 422, 7, 92, 2, 2, 416, 422, 7, 95, 2, 
+	2, 417, 422, 7, 96, 2, 2, 418, 422, 7, 93, 2, 2, 419, 422, 7, 94, 2, 2, 
+	420, 422, 9, 5, 2, 2, 421, 413, 3, 2, 2, 2, 421, 414, 3, 2, 2, 2, 421, 
+	415, 3, 2, 2, 2, 421, 416, 3, 2, 2, 2, 421, 417, 3, 2, 2, 2, 421, 418, 
+	3, 2, 2, 2, 421, 419, 3, 2, 2, 2, 421, 420, 3, 2, 2, 2, 422, 81, 3, 2, 
+	2, 2, 423, 424, 8, 42, 1, 2, 424, 425, 7, 104, 2, 2, 425, 426, 5, 82, 42, 
+	2, 426, 427, 7, 105, 2, 2, 427, 432, 3, 2, 2, 2, 428, 432, 5, 88, 45, 2, 
+	429, 432, 5, 96, 49, 2, 430, 432, 5, 84, 43, 2, 431, 423, 3, 2, 2, 2, 431, 
+	428, 3, 2, 2, 2, 431, 429, 3, 2, 2, 2, 431, 430, 3, 2, 2, 2, 432, 447, 
+	3, 2, 2, 2, 433, 434, 12, 10, 2, 2, 434, 435, 7, 109, 2, 2, 435, 446, 5, 
+	82, 42, 11, 436, 437, 12, 9, 2, 2, 437, 438, 7, 108, 2, 2, 438, 446, 5, 
+	82, 42, 10, 439, 440, 12, 8, 2, 2, 440, 441, 7, 106, 2, 2, 441, 446, 5, 
+	82, 42, 9, 442, 443, 12, 7, 2, 2, 443, 444, 7, 107, 2, 2, 444, 446, 5, 
+	82, 42, 8, 445, 433, 3, 2, 2, 2, 445, 436, 3, 2, 2, 2, 445, 439, 3, 2, 
+	2, 2, 445, 442, 3, 2, 2, 2, 446, 449, 3, 2, 2, 2, 447, 445, 3, 2, 2, 2, 
+	447, 448, 3, 2, 2, 2, 448, 83, 3, 2, 2, 2, 449, 447, 3, 2, 2, 2, 450, 451, 
+	5, 100, 51, 2, 451, 452, 5, 86, 44, 2, 452, 85, 3, 2, 2, 2, 453, 454, 9, 
+	6, 2, 2, 454, 87, 3, 2, 2, 2, 455, 456, 5, 90, 46, 2, 456, 458, 7, 104, 
+	2, 2, 457, 459, 5, 92, 47, 2, 458, 457, 3, 2, 2, 2, 458, 459, 3, 2, 2, 
+	2, 459, 460, 3, 2, 2, 2, 460, 461, 7, 105, 2, 2, 461, 89, 3, 2, 2, 2, 462, 
+	463, 9, 7, 2, 2, 463, 91, 3, 2, 2, 2, 464, 469, 5, 94, 48, 2, 465, 466, 
+	7, 99, 2, 2, 466, 468, 5, 94, 48, 2, 467, 465, 3, 2, 2, 2, 468, 471, 3, 
+	2, 2, 2, 469, 467, 3, 2, 2, 2, 469, 470, 3, 2, 2, 2, 470, 93, 3, 2, 2, 
+	2, 471, 469, 3, 2, 2, 2, 472, 475, 5, 82, 42, 2, 473, 475, 5, 44, 23, 2, 
+	474, 472, 3, 2, 2, 2, 474, 473, 3, 2, 2, 2, 475, 95, 3, 2, 2, 2, 476, 478, 
+	5, 112, 57, 2, 477, 479, 5, 98, 50, 2, 478, 477, 3, 2, 2, 2, 478, 479, 
+	3, 2, 2, 2, 479, 483, 3, 2, 2, 2, 480, 483, 5, 102, 52, 2, 481, 483, 5, 
+	100, 51, 2, 482, 476, 3, 2, 2, 2, 482, 480, 3, 2, 2, 2, 482, 481, 3, 2, 
+	2, 2, 483, 97, 3, 2, 2, 2, 484, 485, 7, 102, 2, 2, 485, 486, 5, 44, 23, 
+	2, 486, 487, 7, 103, 2, 2, 487, 99, 3, 2, 2, 2, 488, 490, 9, 8, 2, 2, 489, 
+	488, 3, 2, 2, 2, 489, 490, 3, 2, 2, 2, 490, 491, 3, 2, 2, 2, 491, 492, 
+	7, 112, 2, 2, 492, 101, 3, 2, 2, 2, 493, 495, 9, 8, 2, 2, 494, 493, 3, 
+	2, 2, 2, 494, 495, 3, 2, 2, 2, 495, 496, 3, 2, 2, 2, 496, 497, 7, 113, 
+	2, 2, 497, 103, 3, 2, 2, 2, 498, 499, 7, 36, 2, 2, 499, 500, 7, 112, 2, 
+	2, 500, 105, 3, 2, 2, 2, 501, 502, 5, 112, 57, 2, 502, 107, 3, 2, 2, 2, 
+	503, 504, 5, 112, 57, 2, 504, 109, 3, 2, 2, 2, 505, 506, 5, 112, 57, 2, 
+	506, 111, 3, 2, 2, 2, 507, 510, 7, 111, 2, 2, 508, 510, 5, 114, 58, 2, 
+	509, 507, 3, 2, 2, 2, 509, 508, 3, 2, 2, 2, 510, 518, 3, 2, 2, 2, 511, 
+	514, 7, 88, 2, 2, 512, 515, 7, 111, 2, 2, 513, 515, 5, 114, 58, 2, 514, 
+	512, 3, 2, 2, 2, 514, 513, 3, 2, 2, 2, 515, 517, 3, 2, 2, 2, 516, 511, 
+	3, 2, 2, 2, 517, 520, 3, 2, 2, 2, 518, 516, 3, 2, 2, 2, 518, 519, 3, 2, 
+	2, 2, 519, 113, 3, 2, 2, 2, 520, 518, 3, 2, 2, 2, 521, 522, 9, 9, 2, 2, 
+	522, 115, 3, 2, 2, 2, 55, 128, 139, 142, 148, 154, 157, 163, 172, 181, 
+	189, 192, 208, 213, 217, 220, 223, 226, 229, 239, 244, 263, 265, 281, 289, 
+	295, 302, 310, 316, 322, 326, 331, 343, 346, 353, 362, 374, 382, 394, 402, 
+	421, 431, 445, 447, 458, 469, 474, 478, 482, 489, 494, 509, 514, 518,
 }
 var deserializer = antlr.NewATNDeserializer(nil)
 var deserializedATN = deserializer.DeserializeFromUInt16(parserATN)
@@ -252,10 +258,10 @@ var literalNames = []string{
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
 	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
-	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "'m'", 
-	"", "", "", "'M'", "", "'.'", "':'", "'='", "'<>'", "'!='", "'>'", "'>='", 
-	"'<'", "'<='", "'=~'", "'!~'", "','", "'{'", "'}'", "'['", "']'", "'('", 
-	"')'", "'+'", "'-'", "'/'", "'*'", "'%'",
+	"", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", 
+	"", "", "", "", "", "", "", "", "'m'", "", "", "", "'M'", "", "'.'", "':'", 
+	"'='", "'<>'", "'!='", "'>'", "'>='", "'<'", "'<='", "'=~'", "'!~'", "','", 
+	"'{'", "'}'", "'['", "']'", "'('", "')'", "'+'", "'-'", "'/'", "'*'", "'%'",
 }
 var symbolicNames = []string{
 	"", "T_CREATE", "T_UPDATE", "T_SET", "T_DROP", "T_INTERVAL", "T_INTERVAL_NAME", 
@@ -268,26 +274,27 @@ var symbolicNames = []string{
 	"T_PREVIOUS", "T_ORDER", "T_ASC", "T_DESC", "T_LIKE", "T_NOT", "T_BETWEEN", 
 	"T_IS", "T_GROUP", "T_HAVING", "T_BY", "T_FOR", "T_STATS", "T_TIME", "T_NOW", 
 	"T_IN", "T_LOG", "T_PROFILE", "T_SUM", "T_MIN", "T_MAX", "T_COUNT", "T_AVG", 
-	"T_STDDEV", "T_HISTOGRAM", "T_SECOND", "T_MINUTE", "T_HOUR", "T_DAY", "T_WEEK", 
-	"T_MONTH", "T_YEAR", "T_DOT", "T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", 
-	"T_GREATER", "T_GREATEREQUAL", "T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", 
-	"T_COMMA", "T_OPEN_B", "T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", 
-	"T_CLOSE_P", "T_ADD", "T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", 
-	"L_DEC", "WS",
+	"T_STDDEV", "T_HISTOGRAM", "T_QUANTILE", "T_RATE", "T_IRATE", "T_DERIVATIVE", 
+	"T_MOVING_AVERAGE", "T_ABS", "T_TIME_SHIFT", "T_TOP", "T_BOTTOM", "T_SECOND", 
+	"T_MINUTE", "T_HOUR", "T_DAY", "T_WEEK", "T_MONTH", "T_YEAR", "T_DOT", 
+	"T_COLON", "T_EQUAL", "T_NOTEQUAL", "T_NOTEQUAL2", "T_GREATER", "T_GREATEREQUAL", 
+	"T_LESS", "T_LESSEQUAL", "T_REGEXP", "T_NEQREGEXP", "T_COMMA", "T_OPEN_B", 
+	"T_CLOSE_B", "T_OPEN_SB", "T_CLOSE_SB", "T_OPEN_P", "T_CLOSE_P", "T_ADD", 
+	"T_SUB", "T_DIV", "T_MUL", "T_MOD", "L_ID", "L_INT", "L_DEC", "WS",
 }
 
 var ruleNames = []string{
 	"statement", "statementList", "showDatabaseStmt", "showNameSpacesStmt", 
 	"showMeasurementsStmt", "showFieldsStmt", "showTagKeysStmt", "showTagValuesStmt", 
-	"prefix", "withTagKey", "namespace", "queryStmt", "selectExpr", "fields", 
-	"field", "alias", "fromClause", "whereClause", "conditionExpr", "tagFilterExpr", 
-	"tagValueList", "timeRangeExpr", "timeExpr", "nowExpr", "nowFunc", "groupByClause", 
-	"groupByKeys", "groupByKey", "fillOption", "orderByClause", "sortField", 
-	"sortFields", "havingClause", "boolExpr", "boolExprLogicalOp", "boolExprAtom", 
-	"binaryExpr", "binaryOperator", "fieldExpr", "durationLit", "intervalItem", 
-	"exprFunc", "funcName", "exprFuncParams", "funcParam", "exprAtom", "identFilter", 
-	"intNumber", "decNumber", "limitClause", "metricName", "tagKey", "tagValue", 
-	"ident", "nonReservedWords",
+	"prefix", "withTagKey", "namespace", "showQueriesStmt", "killQueryStmt", 
+	"queryStmt", "selectExpr", "fields", "field", "alias", "fromClause", "whereClause", 
+	"conditionExpr", "tagFilterExpr", "tagValueList", "timeRangeExpr", "timeExpr", 
+	"nowExpr", "nowFunc", "groupByClause", "groupByKeys", "groupByKey", "fillOption", 
+	"orderByClause", "sortField", "sortFields", "havingClause", "boolExpr", 
+	"boolExprLogicalOp", "boolExprAtom", "binaryExpr", "binaryOperator", "fieldExpr", 
+	"durationLit", "intervalItem", "exprFunc", "funcName", "exprFuncParams", 
+	"funcParam", "exprAtom", "identFilter", "intNumber", "decNumber", "limitClause", 
+	"metricName", "tagKey", "tagValue", "ident", "nonReservedWords",
 }
 var decisionToDFA = make([]*antlr.DFA, len(deserializedATN.DecisionToState))
 
@@ -387,40 +394,49 @@ const (
 	SQLParserT_AVG = 67
 	SQLParserT_STDDEV = 68
 	SQLParserT_HISTOGRAM = 69
-	SQLParserT_SECOND = 70
-	SQLParserT_MINUTE = 71
-	SQLParserT_HOUR = 72
-	SQLParserT_DAY = 73
-	SQLParserT_WEEK = 74
-	SQLParserT_MONTH = 75
-	SQLParserT_YEAR = 76
-	SQLParserT_DOT = 77
-	SQLParserT_COLON = 78
-	SQLParserT_EQUAL = 79
-	SQLParserT_NOTEQUAL = 80
-	SQLParserT_NOTEQUAL2 = 81
-	SQLParserT_GREATER = 82
-	SQLParserT_GREATEREQUAL = 83
-	SQLParserT_LESS = 84
-	SQLParserT_LESSEQUAL = 85
-	SQLParserT_REGEXP = 86
-	SQLParserT_NEQREGEXP = 87
-	SQLParserT_COMMA = 88
-	SQLParserT_OPEN_B = 89
-	SQLParserT_CLOSE_B = 90
-	SQLParserT_OPEN_SB = 91
-	SQLParserT_CLOSE_SB = 92
-	SQLParserT_OPEN_P = 93
-	SQLParserT_CLOSE_P = 94
-	SQLParserT_ADD = 95
-	SQLParserT_SUB = 96
-	SQLParserT_DIV = 97
-	SQLParserT_MUL = 98
-	SQLParserT_MOD = 99
-	SQLParserL_ID = 100
-	SQLParserL_INT = 101
-	SQLParserL_DEC = 102
-	SQLParserWS = 103
+	SQLParserT_QUANTILE = 70
+	SQLParserT_RATE = 71
+	SQLParserT_IRATE = 72
+	SQLParserT_DERIVATIVE = 73
+	SQLParserT_MOVING_AVERAGE = 74
+	SQLParserT_ABS = 75
+	SQLParserT_TIME_SHIFT = 76
+	SQLParserT_TOP = 77
+	SQLParserT_BOTTOM = 78
+	SQLParserT_SECOND = 79
+	SQLParserT_MINUTE = 80
+	SQLParserT_HOUR = 81
+	SQLParserT_DAY = 82
+	SQLParserT_WEEK = 83
+	SQLParserT_MONTH = 84
+	SQLParserT_YEAR = 85
+	SQLParserT_DOT = 86
+	SQLParserT_COLON = 87
+	SQLParserT_EQUAL = 88
+	SQLParserT_NOTEQUAL = 89
+	SQLParserT_NOTEQUAL2 = 90
+	SQLParserT_GREATER = 91
+	SQLParserT_GREATEREQUAL = 92
+	SQLParserT_LESS = 93
+	SQLParserT_LESSEQUAL = 94
+	SQLParserT_REGEXP = 95
+	SQLParserT_NEQREGEXP = 96
+	SQLParserT_COMMA = 97
+	SQLParserT_OPEN_B = 98
+	SQLParserT_CLOSE_B = 99
+	SQLParserT_OPEN_SB = 100
+	SQLParserT_CLOSE_SB = 101
+	SQLParserT_OPEN_P = 102
+	SQLParserT_CLOSE_P = 103
+	SQLParserT_ADD = 104
+	SQLParserT_SUB = 105
+	SQLParserT_DIV = 106
+	SQLParserT_MUL = 107
+	SQLParserT_MOD = 108
+	SQLParserL_ID = 109
+	SQLParserL_INT = 110
+	SQLParserL_DEC = 111
+	SQLParserWS = 112
 )
 
 // SQLParser rules.
@@ -436,50 +452,52 @@ const (
 	SQLParserRULE_prefix = 8
 	SQLParserRULE_withTagKey = 9
 	SQLParserRULE_namespace = 10
-	SQLParserRULE_queryStmt = 11
-	SQLParserRULE_selectExpr = 12
-	SQLParserRULE_fields = 13
-	SQLParserRULE_field = 14
-	SQLParserRULE_alias = 15
-	SQLParserRULE_fromClause = 16
-	SQLParserRULE_whereClause = 17
-	SQLParserRULE_conditionExpr = 18
-	SQLParserRULE_tagFilterExpr = 19
-	SQLParserRULE_tagValueList = 20
-	SQLParserRULE_timeRangeExpr = 21
-	SQLParserRULE_timeExpr = 22
-	SQLParserRULE_nowExpr = 23
-	SQLParserRULE_nowFunc = 24
-	SQLParserRULE_groupByClause = 25
-	SQLParserRULE_groupByKeys = 26
-	SQLParserRULE_groupByKey = 27
-	SQLParserRULE_fillOption = 28
-	SQLParserRULE_orderByClause = 29
-	SQLParserRULE_sortField = 30
-	SQLParserRULE_sortFields = 31
-	SQLParserRULE_havingClause = 32
-	SQLParserRULE_boolExpr = 33
-	SQLParserRULE_boolExprLogicalOp = 34
-	SQLParserRULE_boolExprAtom = 35
-	SQLParserRULE_binaryExpr = 36
-	SQLParserRULE_binaryOperator = 37
-	SQLParserRULE_fieldExpr = 38
-	SQLParserRULE_durationLit = 39
-	SQLParserRULE_intervalItem = 40
-	SQLParserRULE_exprFunc = 41
-	SQLParserRULE_funcName = 42
-	SQLParserRULE_exprFuncParams = 43
-	SQLParserRULE_funcParam = 44
-	SQLParserRULE_exprAtom = 45
-	SQLParserRULE_identFilter = 46
-	SQLParserRULE_intNumber = 47
-	SQLParserRULE_decNumber = 48
-	SQLParserRULE_limitClause = 49
-	SQLParserRULE_metricName = 50
-	SQLParserRULE_tagKey = 51
-	SQLParserRULE_tagValue = 52
-	SQLParserRULE_ident = 53
-	SQLParserRULE_nonReservedWords = 54
+	SQLParserRULE_showQueriesStmt = 11
+	SQLParserRULE_killQueryStmt = 12
+	SQLParserRULE_queryStmt = 13
+	SQLParserRULE_selectExpr = 14
+	SQLParserRULE_fields = 15
+	SQLParserRULE_field = 16
+	SQLParserRULE_alias = 17
+	SQLParserRULE_fromClause = 18
+	SQLParserRULE_whereClause = 19
+	SQLParserRULE_conditionExpr = 20
+	SQLParserRULE_tagFilterExpr = 21
+	SQLParserRULE_tagValueList = 22
+	SQLParserRULE_timeRangeExpr = 23
+	SQLParserRULE_timeExpr = 24
+	SQLParserRULE_nowExpr = 25
+	SQLParserRULE_nowFunc = 26
+	SQLParserRULE_groupByClause = 27
+	SQLParserRULE_groupByKeys = 28
+	SQLParserRULE_groupByKey = 29
+	SQLParserRULE_fillOption = 30
+	SQLParserRULE_orderByClause = 31
+	SQLParserRULE_sortField = 32
+	SQLParserRULE_sortFields = 33
+	SQLParserRULE_havingClause = 34
+	SQLParserRULE_boolExpr = 35
+	SQLParserRULE_boolExprLogicalOp = 36
+	SQLParserRULE_boolExprAtom = 37
+	SQLParserRULE_binaryExpr = 38
+	SQLParserRULE_binaryOperator = 39
+	SQLParserRULE_fieldExpr = 40
+	SQLParserRULE_durationLit = 41
+	SQLParserRULE_intervalItem = 42
+	SQLParserRULE_exprFunc = 43
+	SQLParserRULE_funcName = 44
+	SQLParserRULE_exprFuncParams = 45
+	SQLParserRULE_funcParam = 46
+	SQLParserRULE_exprAtom = 47
+	SQLParserRULE_identFilter = 48
+	SQLParserRULE_intNumber = 49
+	SQLParserRULE_decNumber = 50
+	SQLParserRULE_limitClause = 51
+	SQLParserRULE_metricName = 52
+	SQLParserRULE_tagKey = 53
+	SQLParserRULE_tagValue = 54
+	SQLParserRULE_ident = 55
+	SQLParserRULE_nonReservedWords = 56
 )
 
 // IStatementContext is an interface to support dynamic dispatch.
@@ -580,11 +598,11 @@ func (p *SQLParser) Statement() (localctx IStatementContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(110)
+		p.SetState(114)
 		p.StatementList()
 	}
 	{
-		p.SetState(111)
+		p.SetState(115)
 		p.Match(SQLParserEOF)
 	}
 
@@ -692,6 +710,26 @@ func (s *StatementListContext) ShowTagValuesStmt() IShowTagValuesStmtContext {
 	return t.(IShowTagValuesStmtContext)
 }
 
+func (s *StatementListContext) ShowQueriesStmt() IShowQueriesStmtContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IShowQueriesStmtContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IShowQueriesStmtContext)
+}
+
+func (s *StatementListContext) KillQueryStmt() IKillQueryStmtContext {
+	var t = s.GetTypedRuleContext(reflect.TypeOf((*IKillQueryStmtContext)(nil)).Elem(), 0)
+
+	if t == nil {
+		return nil
+	}
+
+	return t.(IKillQueryStmtContext)
+}
+
 func (s *StatementListContext) QueryStmt() IQueryStmtContext {
 	var t = s.GetTypedRuleContext(reflect.TypeOf((*IQueryStmtContext)(nil)).Elem(), 0)
 
@@ -746,13 +784,13 @@ func (p *SQLParser) StatementList() (localctx IStatementListContext) {
 		}
 	}()
 
-	p.SetState(120)
+	p.SetState(126)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 0, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(113)
+			p.SetState(117)
 			p.ShowDatabaseStmt()
 		}
 
@@ -760,7 +798,7 @@ func (p *SQLParser) StatementList() (localctx IStatementListContext) {
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(114)
+			p.SetState(118)
 			p.ShowNameSpacesStmt()
 		}
 
@@ -768,7 +806,7 @@ func (p *SQLParser) StatementList() (localctx IStatementListContext) {
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(115)
+			p.SetState(119)
 			p.ShowMeasurementsStmt()
 		}
 
@@ -776,7 +814,7 @@ func (p *SQLParser) StatementList() (localctx IStatementListContext) {
 	case 4:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(116)
+			p.SetState(120)
 			p.ShowFieldsStmt()
 		}
 
@@ -784,7 +822,7 @@ func (p *SQLParser) StatementList() (localctx IStatementListContext) {
 	case 5:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(117)
+			p.SetState(121)
 			p.ShowTagKeysStmt()
 		}
 
@@ -792,7 +830,7 @@ func (p *SQLParser) StatementList() (localctx IStatementListContext) {
 	case 6:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(118)
+			p.SetState(122)
 			p.ShowTagValuesStmt()
 		}
 
@@ -800,7 +838,23 @@ func (p *SQLParser) StatementList() (localctx IStatementListContext) {
 	case 7:
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(119)
+			p.SetState(123)
+			p.ShowQueriesStmt()
+		}
+
+
+	case 8:
+		p.EnterOuterAlt(localctx, 8)
+		{
+			p.SetState(124)
+			p.KillQueryStmt()
+		}
+
+
+	case 9:
+		p.EnterOuterAlt(localctx, 9)
+		{
+			p.SetState(125)
 			p.QueryStmt()
 		}
 
@@ -903,11 +957,11 @@ func (p *SQLParser) ShowDatabaseStmt() (localctx IShowDatabaseStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(122)
+		p.SetState(128)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(123)
+		p.SetState(129)
 		p.Match(SQLParserT_DATASBAES)
 	}
 
@@ -1043,45 +1097,45 @@ func (p *SQLParser) ShowNameSpacesStmt() (localctx IShowNameSpacesStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(125)
+		p.SetState(131)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(126)
+		p.SetState(132)
 		p.Match(SQLParserT_NAMESPACES)
 	}
-	p.SetState(131)
+	p.SetState(137)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_WHERE {
 		{
-			p.SetState(127)
+			p.SetState(133)
 			p.Match(SQLParserT_WHERE)
 		}
 		{
-			p.SetState(128)
+			p.SetState(134)
 			p.Match(SQLParserT_NAMESPACE)
 		}
 		{
-			p.SetState(129)
+			p.SetState(135)
 			p.Match(SQLParserT_EQUAL)
 		}
 		{
-			p.SetState(130)
+			p.SetState(136)
 			p.Prefix()
 		}
 
 	}
-	p.SetState(134)
+	p.SetState(140)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_LIMIT {
 		{
-			p.SetState(133)
+			p.SetState(139)
 			p.LimitClause()
 		}
 
@@ -1233,61 +1287,61 @@ func (p *SQLParser) ShowMeasurementsStmt() (localctx IShowMeasurementsStmtContex
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(136)
+		p.SetState(142)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(137)
+		p.SetState(143)
 		p.Match(SQLParserT_MEASUREMENTS)
 	}
-	p.SetState(140)
+	p.SetState(146)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_ON {
 		{
-			p.SetState(138)
+			p.SetState(144)
 			p.Match(SQLParserT_ON)
 		}
 		{
-			p.SetState(139)
+			p.SetState(145)
 			p.Namespace()
 		}
 
 	}
-	p.SetState(146)
+	p.SetState(152)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_WHERE {
 		{
-			p.SetState(142)
+			p.SetState(148)
 			p.Match(SQLParserT_WHERE)
 		}
 		{
-			p.SetState(143)
+			p.SetState(149)
 			p.Match(SQLParserT_MEASUREMENT)
 		}
 		{
-			p.SetState(144)
+			p.SetState(150)
 			p.Match(SQLParserT_EQUAL)
 		}
 		{
-			p.SetState(145)
+			p.SetState(151)
 			p.Prefix()
 		}
 
 	}
-	p.SetState(149)
+	p.SetState(155)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_LIMIT {
 		{
-			p.SetState(148)
+			p.SetState(154)
 			p.LimitClause()
 		}
 
@@ -1417,31 +1471,31 @@ func (p *SQLParser) ShowFieldsStmt() (localctx IShowFieldsStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(151)
+		p.SetState(157)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(152)
+		p.SetState(158)
 		p.Match(SQLParserT_FIELDS)
 	}
-	p.SetState(155)
+	p.SetState(161)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_ON {
 		{
-			p.SetState(153)
+			p.SetState(159)
 			p.Match(SQLParserT_ON)
 		}
 		{
-			p.SetState(154)
+			p.SetState(160)
 			p.Namespace()
 		}
 
 	}
 	{
-		p.SetState(157)
+		p.SetState(163)
 		p.FromClause()
 	}
 
@@ -1573,35 +1627,35 @@ func (p *SQLParser) ShowTagKeysStmt() (localctx IShowTagKeysStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(159)
+		p.SetState(165)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(160)
+		p.SetState(166)
 		p.Match(SQLParserT_TAG)
 	}
 	{
-		p.SetState(161)
+		p.SetState(167)
 		p.Match(SQLParserT_KEYS)
 	}
-	p.SetState(164)
+	p.SetState(170)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_ON {
 		{
-			p.SetState(162)
+			p.SetState(168)
 			p.Match(SQLParserT_ON)
 		}
 		{
-			p.SetState(163)
+			p.SetState(169)
 			p.Namespace()
 		}
 
 	}
 	{
-		p.SetState(166)
+		p.SetState(172)
 		p.FromClause()
 	}
 
@@ -1775,73 +1829,73 @@ func (p *SQLParser) ShowTagValuesStmt() (localctx IShowTagValuesStmtContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(168)
+		p.SetState(174)
 		p.Match(SQLParserT_SHOW)
 	}
 	{
-		p.SetState(169)
+		p.SetState(175)
 		p.Match(SQLParserT_TAG)
 	}
 	{
-		p.SetState(170)
+		p.SetState(176)
 		p.Match(SQLParserT_VALUES)
 	}
-	p.SetState(173)
+	p.SetState(179)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_ON {
 		{
-			p.SetState(171)
+			p.SetState(177)
 			p.Match(SQLParserT_ON)
 		}
 		{
-			p.SetState(172)
+			p.SetState(178)
 			p.Namespace()
 		}
 
 	}
 	{
-		p.SetState(175)
+		p.SetState(181)
 		p.FromClause()
 	}
 	{
-		p.SetState(176)
+		p.SetState(182)
 		p.Match(SQLParserT_WITH)
 	}
 	{
-		p.SetState(177)
+		p.SetState(183)
 		p.Match(SQLParserT_KEY)
 	}
 	{
-		p.SetState(178)
+		p.SetState(184)
 		p.Match(SQLParserT_EQUAL)
 	}
 	{
-		p.SetState(179)
+		p.SetState(185)
 		p.WithTagKey()
 	}
-	p.SetState(181)
+	p.SetState(187)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_WHERE {
 		{
-			p.SetState(180)
+			p.SetState(186)
 			p.WhereClause()
 		}
 
 	}
-	p.SetState(184)
+	p.SetState(190)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_LIMIT {
 		{
-			p.SetState(183)
+			p.SetState(189)
 			p.LimitClause()
 		}
 
@@ -1947,7 +2001,7 @@ func (p *SQLParser) Prefix() (localctx IPrefixContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(186)
+		p.SetState(192)
 		p.Ident()
 	}
 
@@ -2051,7 +2105,7 @@ func (p *SQLParser) WithTagKey() (localctx IWithTagKeyContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(188)
+		p.SetState(194)
 		p.Ident()
 	}
 
@@ -2155,7 +2209,7 @@ func (p *SQLParser) Namespace() (localctx INamespaceContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(190)
+		p.SetState(196)
 		p.Ident()
 	}
 
@@ -2165,6 +2219,226 @@ func (p *SQLParser) Namespace() (localctx INamespaceContext) {
 }
 
 
+// IShowQueriesStmtContext is an interface to support dynamic dispatch.
+type IShowQueriesStmtContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsShowQueriesStmtContext differentiates from other interfaces.
+	IsShowQueriesStmtContext()
+}
+
+type ShowQueriesStmtContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyShowQueriesStmtContext() *ShowQueriesStmtContext {
+	var p = new(ShowQueriesStmtContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_showQueriesStmt
+	return p
+}
+
+func (*ShowQueriesStmtContext) IsShowQueriesStmtContext() {}
+
+func NewShowQueriesStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *ShowQueriesStmtContext {
+	var p = new(ShowQueriesStmtContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_showQueriesStmt
+
+	return p
+}
+
+func (s *ShowQueriesStmtContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *ShowQueriesStmtContext) T_SHOW() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_SHOW, 0)
+}
+
+func (s *ShowQueriesStmtContext) T_QUERIES() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_QUERIES, 0)
+}
+
+func (s *ShowQueriesStmtContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *ShowQueriesStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *ShowQueriesStmtContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterShowQueriesStmt(s)
+	}
+}
+
+func (s *ShowQueriesStmtContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitShowQueriesStmt(s)
+	}
+}
+
+
+
+
+func (p *SQLParser) ShowQueriesStmt() (localctx IShowQueriesStmtContext) {
+	localctx = NewShowQueriesStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 22, SQLParserRULE_showQueriesStmt)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(198)
+		p.Match(SQLParserT_SHOW)
+	}
+	{
+		p.SetState(199)
+		p.Match(SQLParserT_QUERIES)
+	}
+
+
+
+	return localctx
+}
+
+
+// IKillQueryStmtContext is an interface to support dynamic dispatch.
+type IKillQueryStmtContext interface {
+	antlr.ParserRuleContext
+
+	// GetParser returns the parser.
+	GetParser() antlr.Parser
+
+	// IsKillQueryStmtContext differentiates from other interfaces.
+	IsKillQueryStmtContext()
+}
+
+type KillQueryStmtContext struct {
+	*antlr.BaseParserRuleContext
+	parser antlr.Parser
+}
+
+func NewEmptyKillQueryStmtContext() *KillQueryStmtContext {
+	var p = new(KillQueryStmtContext)
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(nil, -1)
+	p.RuleIndex = SQLParserRULE_killQueryStmt
+	return p
+}
+
+func (*KillQueryStmtContext) IsKillQueryStmtContext() {}
+
+func NewKillQueryStmtContext(parser antlr.Parser, parent antlr.ParserRuleContext, invokingState int) *KillQueryStmtContext {
+	var p = new(KillQueryStmtContext)
+
+	p.BaseParserRuleContext = antlr.NewBaseParserRuleContext(parent, invokingState)
+
+	p.parser = parser
+	p.RuleIndex = SQLParserRULE_killQueryStmt
+
+	return p
+}
+
+func (s *KillQueryStmtContext) GetParser() antlr.Parser { return s.parser }
+
+func (s *KillQueryStmtContext) T_KILL() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_KILL, 0)
+}
+
+func (s *KillQueryStmtContext) T_QUERY() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_QUERY, 0)
+}
+
+func (s *KillQueryStmtContext) L_INT() antlr.TerminalNode {
+	return s.GetToken(SQLParserL_INT, 0)
+}
+
+func (s *KillQueryStmtContext) GetRuleContext() antlr.RuleContext {
+	return s
+}
+
+func (s *KillQueryStmtContext) ToStringTree(ruleNames []string, recog antlr.Recognizer) string {
+	return antlr.TreesStringTree(s, ruleNames, recog)
+}
+
+
+func (s *KillQueryStmtContext) EnterRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.EnterKillQueryStmt(s)
+	}
+}
+
+func (s *KillQueryStmtContext) ExitRule(listener antlr.ParseTreeListener) {
+	if listenerT, ok := listener.(SQLListener); ok {
+		listenerT.ExitKillQueryStmt(s)
+	}
+}
+
+
+
+
+func (p *SQLParser) KillQueryStmt() (localctx IKillQueryStmtContext) {
+	localctx = NewKillQueryStmtContext(p, p.GetParserRuleContext(), p.GetState())
+	p.EnterRule(localctx, 24, SQLParserRULE_killQueryStmt)
+
+	defer func() {
+		p.ExitRule()
+	}()
+
+	defer func() {
+		if err := recover(); err != nil {
+			if v, ok := err.(antlr.RecognitionException); ok {
+				localctx.SetException(v)
+				p.GetErrorHandler().ReportError(p, v)
+				p.GetErrorHandler().Recover(p, v)
+			} else {
+				panic(err)
+			}
+		}
+	}()
+
+	p.EnterOuterAlt(localctx, 1)
+	{
+		p.SetState(201)
+		p.Match(SQLParserT_KILL)
+	}
+	{
+		p.SetState(202)
+		p.Match(SQLParserT_QUERY)
+	}
+	{
+		p.SetState(203)
+		p.Match(SQLParserL_INT)
+	}
+
+
+
+	return localctx
+}
+
+
 // IQueryStmtContext is an interface to support dynamic dispatch.
 type IQueryStmtContext interface {
 	antlr.ParserRuleContext
@@ -2311,7 +2585,7 @@ func (s *QueryStmtContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) QueryStmt() (localctx IQueryStmtContext) {
 	localctx = NewQueryStmtContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 22, SQLParserRULE_queryStmt)
+	p.EnterRule(localctx, 26, SQLParserRULE_queryStmt)
 	var _la int
 
 
@@ -2332,98 +2606,98 @@ func (p *SQLParser) QueryStmt() (localctx IQueryStmtContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(193)
+	p.SetState(206)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_EXPLAIN {
 		{
-			p.SetState(192)
+			p.SetState(205)
 			p.Match(SQLParserT_EXPLAIN)
 		}
 
 	}
 	{
-		p.SetState(195)
+		p.SetState(208)
 		p.SelectExpr()
 	}
-	p.SetState(198)
+	p.SetState(211)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_ON {
 		{
-			p.SetState(196)
+			p.SetState(209)
 			p.Match(SQLParserT_ON)
 		}
 		{
-			p.SetState(197)
+			p.SetState(210)
 			p.Namespace()
 		}
 
 	}
 	{
-		p.SetState(200)
+		p.SetState(213)
 		p.FromClause()
 	}
-	p.SetState(202)
+	p.SetState(215)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_WHERE {
 		{
-			p.SetState(201)
+			p.SetState(214)
 			p.WhereClause()
 		}
 
 	}
-	p.SetState(205)
+	p.SetState(218)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_GROUP {
 		{
-			p.SetState(204)
+			p.SetState(217)
 			p.GroupByClause()
 		}
 
 	}
-	p.SetState(208)
+	p.SetState(221)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_ORDER {
 		{
-			p.SetState(207)
+			p.SetState(220)
 			p.OrderByClause()
 		}
 
 	}
-	p.SetState(211)
+	p.SetState(224)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_LIMIT {
 		{
-			p.SetState(210)
+			p.SetState(223)
 			p.LimitClause()
 		}
 
 	}
-	p.SetState(214)
+	p.SetState(227)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_WITH_VALUE {
 		{
-			p.SetState(213)
+			p.SetState(226)
 			p.Match(SQLParserT_WITH_VALUE)
 		}
 
@@ -2513,7 +2787,7 @@ func (s *SelectExprContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) SelectExpr() (localctx ISelectExprContext) {
 	localctx = NewSelectExprContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 24, SQLParserRULE_selectExpr)
+	p.EnterRule(localctx, 28, SQLParserRULE_selectExpr)
 
 	defer func() {
 		p.ExitRule()
@@ -2533,11 +2807,11 @@ func (p *SQLParser) SelectExpr() (localctx ISelectExprContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(216)
+		p.SetState(229)
 		p.Match(SQLParserT_SELECT)
 	}
 	{
-		p.SetState(217)
+		p.SetState(230)
 		p.Fields()
 	}
 
@@ -2642,7 +2916,7 @@ func (s *FieldsContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) Fields() (localctx IFieldsContext) {
 	localctx = NewFieldsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 26, SQLParserRULE_fields)
+	p.EnterRule(localctx, 30, SQLParserRULE_fields)
 	var _la int
 
 
@@ -2664,26 +2938,26 @@ func (p *SQLParser) Fields() (localctx IFieldsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(219)
+		p.SetState(232)
 		p.Field()
 	}
-	p.SetState(224)
+	p.SetState(237)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	for _la == SQLParserT_COMMA {
 		{
-			p.SetState(220)
+			p.SetState(233)
 			p.Match(SQLParserT_COMMA)
 		}
 		{
-			p.SetState(221)
+			p.SetState(234)
 			p.Field()
 		}
 
 
-		p.SetState(226)
+		p.SetState(239)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -2778,7 +3052,7 @@ func (s *FieldContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) Field() (localctx IFieldContext) {
 	localctx = NewFieldContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 28, SQLParserRULE_field)
+	p.EnterRule(localctx, 32, SQLParserRULE_field)
 	var _la int
 
 
@@ -2800,17 +3074,17 @@ func (p *SQLParser) Field() (localctx IFieldContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(227)
+		p.SetState(240)
 		p.fieldExpr(0)
 	}
-	p.SetState(229)
+	p.SetState(242)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_AS {
 		{
-			p.SetState(228)
+			p.SetState(241)
 			p.Alias()
 		}
 
@@ -2900,7 +3174,7 @@ func (s *AliasContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) Alias() (localctx IAliasContext) {
 	localctx = NewAliasContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 30, SQLParserRULE_alias)
+	p.EnterRule(localctx, 34, SQLParserRULE_alias)
 
 	defer func() {
 		p.ExitRule()
@@ -2920,11 +3194,11 @@ func (p *SQLParser) Alias() (localctx IAliasContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(231)
+		p.SetState(244)
 		p.Match(SQLParserT_AS)
 	}
 	{
-		p.SetState(232)
+		p.SetState(245)
 		p.Ident()
 	}
 
@@ -3012,7 +3286,7 @@ func (s *FromClauseContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) FromClause() (localctx IFromClauseContext) {
 	localctx = NewFromClauseContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 32, SQLParserRULE_fromClause)
+	p.EnterRule(localctx, 36, SQLParserRULE_fromClause)
 
 	defer func() {
 		p.ExitRule()
@@ -3032,11 +3306,11 @@ func (p *SQLParser) FromClause() (localctx IFromClauseContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(234)
+		p.SetState(247)
 		p.Match(SQLParserT_FROM)
 	}
 	{
-		p.SetState(235)
+		p.SetState(248)
 		p.MetricName()
 	}
 
@@ -3124,7 +3398,7 @@ func (s *WhereClauseContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) WhereClause() (localctx IWhereClauseContext) {
 	localctx = NewWhereClauseContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 34, SQLParserRULE_whereClause)
+	p.EnterRule(localctx, 38, SQLParserRULE_whereClause)
 
 	defer func() {
 		p.ExitRule()
@@ -3144,11 +3418,11 @@ func (p *SQLParser) WhereClause() (localctx IWhereClauseContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(237)
+		p.SetState(250)
 		p.Match(SQLParserT_WHERE)
 	}
 	{
-		p.SetState(238)
+		p.SetState(251)
 		p.ConditionExpr()
 	}
 
@@ -3246,7 +3520,7 @@ func (s *ConditionExprContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) ConditionExpr() (localctx IConditionExprContext) {
 	localctx = NewConditionExprContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 36, SQLParserRULE_conditionExpr)
+	p.EnterRule(localctx, 40, SQLParserRULE_conditionExpr)
 	var _la int
 
 
@@ -3266,13 +3540,13 @@ func (p *SQLParser) ConditionExpr() (localctx IConditionExprContext) {
 		}
 	}()
 
-	p.SetState(250)
+	p.SetState(263)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 21, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(240)
+			p.SetState(253)
 			p.tagFilterExpr(0)
 		}
 
@@ -3280,15 +3554,15 @@ func (p *SQLParser) ConditionExpr() (localctx IConditionExprContext) {
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(241)
+			p.SetState(254)
 			p.tagFilterExpr(0)
 		}
 		{
-			p.SetState(242)
+			p.SetState(255)
 			p.Match(SQLParserT_AND)
 		}
 		{
-			p.SetState(243)
+			p.SetState(256)
 			p.TimeRangeExpr()
 		}
 
@@ -3296,21 +3570,21 @@ func (p *SQLParser) ConditionExpr() (localctx IConditionExprContext) {
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(245)
+			p.SetState(258)
 			p.TimeRangeExpr()
 		}
-		p.SetState(248)
+		p.SetState(261)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 
 
 		if _la == SQLParserT_AND {
 			{
-				p.SetState(246)
+				p.SetState(259)
 				p.Match(SQLParserT_AND)
 			}
 			{
-				p.SetState(247)
+				p.SetState(260)
 				p.tagFilterExpr(0)
 			}
 
@@ -3497,8 +3771,8 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 	localctx = NewTagFilterExprContext(p, p.GetParserRuleContext(), _parentState)
 	var _prevctx ITagFilterExprContext = localctx
 	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 38
-	p.EnterRecursionRule(localctx, 38, SQLParserRULE_tagFilterExpr, _p)
+	_startState := 42
+	p.EnterRecursionRule(localctx, 42, SQLParserRULE_tagFilterExpr, _p)
 	var _la int
 
 
@@ -3521,82 +3795,82 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(280)
+	p.SetState(293)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 24, p.GetParserRuleContext()) {
 	case 1:
 		{
-			p.SetState(253)
+			p.SetState(266)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(254)
+			p.SetState(267)
 			p.tagFilterExpr(0)
 		}
 		{
-			p.SetState(255)
+			p.SetState(268)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
 
 	case 2:
 		{
-			p.SetState(257)
+			p.SetState(270)
 			p.TagKey()
 		}
-		p.SetState(266)
+		p.SetState(279)
 		p.GetErrorHandler().Sync(p)
 
 		switch p.GetTokenStream().LA(1) {
 		case SQLParserT_EQUAL:
 			{
-				p.SetState(258)
+				p.SetState(271)
 				p.Match(SQLParserT_EQUAL)
 			}
 
 
 		case SQLParserT_LIKE:
 			{
-				p.SetState(259)
+				p.SetState(272)
 				p.Match(SQLParserT_LIKE)
 			}
 
 
 		case SQLParserT_NOT:
 			{
-				p.SetState(260)
+				p.SetState(273)
 				p.Match(SQLParserT_NOT)
 			}
 			{
-				p.SetState(261)
+				p.SetState(274)
 				p.Match(SQLParserT_LIKE)
 			}
 
 
 		case SQLParserT_REGEXP:
 			{
-				p.SetState(262)
+				p.SetState(275)
 				p.Match(SQLParserT_REGEXP)
 			}
 
 
 		case SQLParserT_NEQREGEXP:
 			{
-				p.SetState(263)
+				p.SetState(276)
 				p.Match(SQLParserT_NEQREGEXP)
 			}
 
 
 		case SQLParserT_NOTEQUAL:
 			{
-				p.SetState(264)
+				p.SetState(277)
 				p.Match(SQLParserT_NOTEQUAL)
 			}
 
 
 		case SQLParserT_NOTEQUAL2:
 			{
-				p.SetState(265)
+				p.SetState(278)
 				p.Match(SQLParserT_NOTEQUAL2)
 			}
 
@@ -3606,34 +3880,34 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
 		{
-			p.SetState(268)
+			p.SetState(281)
 			p.TagValue()
 		}
 
 
 	case 3:
 		{
-			p.SetState(270)
+			p.SetState(283)
 			p.TagKey()
 		}
-		p.SetState(274)
+		p.SetState(287)
 		p.GetErrorHandler().Sync(p)
 
 		switch p.GetTokenStream().LA(1) {
 		case SQLParserT_IN:
 			{
-				p.SetState(271)
+				p.SetState(284)
 				p.Match(SQLParserT_IN)
 			}
 
 
 		case SQLParserT_NOT:
 			{
-				p.SetState(272)
+				p.SetState(285)
 				p.Match(SQLParserT_NOT)
 			}
 			{
-				p.SetState(273)
+				p.SetState(286)
 				p.Match(SQLParserT_IN)
 			}
 
@@ -3643,21 +3917,21 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 			panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 		}
 		{
-			p.SetState(276)
+			p.SetState(289)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(277)
+			p.SetState(290)
 			p.TagValueList()
 		}
 		{
-			p.SetState(278)
+			p.SetState(291)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(287)
+	p.SetState(300)
 	p.GetErrorHandler().Sync(p)
 	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 25, p.GetParserRuleContext())
 
@@ -3669,13 +3943,13 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 			_prevctx = localctx
 			localctx = NewTagFilterExprContext(p, _parentctx, _parentState)
 			p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_tagFilterExpr)
-			p.SetState(282)
+			p.SetState(295)
 
 			if !(p.Precpred(p.GetParserRuleContext(), 1)) {
 				panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 1)", ""))
 			}
 			{
-				p.SetState(283)
+				p.SetState(296)
 				_la = p.GetTokenStream().LA(1)
 
 				if !(_la == SQLParserT_AND || _la == SQLParserT_OR) {
@@ -3686,13 +3960,13 @@ func (p *SQLParser) tagFilterExpr(_p int) (localctx ITagFilterExprContext) {
 				}
 			}
 			{
-				p.SetState(284)
+				p.SetState(297)
 				p.tagFilterExpr(2)
 			}
 
 
 		}
-		p.SetState(289)
+		p.SetState(302)
 		p.GetErrorHandler().Sync(p)
 		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 25, p.GetParserRuleContext())
 	}
@@ -3798,7 +4072,7 @@ func (s *TagValueListContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) TagValueList() (localctx ITagValueListContext) {
 	localctx = NewTagValueListContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 40, SQLParserRULE_tagValueList)
+	p.EnterRule(localctx, 44, SQLParserRULE_tagValueList)
 	var _la int
 
 
@@ -3820,26 +4094,26 @@ func (p *SQLParser) TagValueList() (localctx ITagValueListContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(290)
+		p.SetState(303)
 		p.TagValue()
 	}
-	p.SetState(295)
+	p.SetState(308)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	for _la == SQLParserT_COMMA {
 		{
-			p.SetState(291)
+			p.SetState(304)
 			p.Match(SQLParserT_COMMA)
 		}
 		{
-			p.SetState(292)
+			p.SetState(305)
 			p.TagValue()
 		}
 
 
-		p.SetState(297)
+		p.SetState(310)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -3941,7 +4215,7 @@ func (s *TimeRangeExprContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) TimeRangeExpr() (localctx ITimeRangeExprContext) {
 	localctx = NewTimeRangeExprContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 42, SQLParserRULE_timeRangeExpr)
+	p.EnterRule(localctx, 46, SQLParserRULE_timeRangeExpr)
 
 	defer func() {
 		p.ExitRule()
@@ -3961,20 +4235,20 @@ func (p *SQLParser) TimeRangeExpr() (localctx ITimeRangeExprContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(298)
+		p.SetState(311)
 		p.TimeExpr()
 	}
-	p.SetState(301)
+	p.SetState(314)
 	p.GetErrorHandler().Sync(p)
 
 
 	if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 27, p.GetParserRuleContext()) == 1 {
 		{
-			p.SetState(299)
+			p.SetState(312)
 			p.Match(SQLParserT_AND)
 		}
 		{
-			p.SetState(300)
+			p.SetState(313)
 			p.TimeExpr()
 		}
 
@@ -4085,7 +4359,7 @@ func (s *TimeExprContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) TimeExpr() (localctx ITimeExprContext) {
 	localctx = NewTimeExprContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 44, SQLParserRULE_timeExpr)
+	p.EnterRule(localctx, 48, SQLParserRULE_timeExpr)
 
 	defer func() {
 		p.ExitRule()
@@ -4105,27 +4379,27 @@ func (p *SQLParser) TimeExpr() (localctx ITimeExprContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(303)
+		p.SetState(316)
 		p.Match(SQLParserT_TIME)
 	}
 	{
-		p.SetState(304)
+		p.SetState(317)
 		p.BinaryOperator()
 	}
-	p.SetState(307)
+	p.SetState(320)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserT_NOW:
 		{
-			p.SetState(305)
+			p.SetState(318)
 			p.NowExpr()
 		}
 
 
-	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR, SQLParserL_ID:
+	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_LOG, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_QUANTILE, SQLParserT_RATE, SQLParserT_IRATE, SQLParserT_DERIVATIVE, SQLParserT_MOVING_AVERAGE, SQLParserT_ABS, SQLParserT_TIME_SHIFT, SQLParserT_TOP, SQLParserT_BOTTOM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR, SQLParserL_ID:
 		{
-			p.SetState(306)
+			p.SetState(319)
 			p.Ident()
 		}
 
@@ -4225,7 +4499,7 @@ func (s *NowExprContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) NowExpr() (localctx INowExprContext) {
 	localctx = NewNowExprContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 46, SQLParserRULE_nowExpr)
+	p.EnterRule(localctx, 50, SQLParserRULE_nowExpr)
 	var _la int
 
 
@@ -4247,17 +4521,17 @@ func (p *SQLParser) NowExpr() (localctx INowExprContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(309)
+		p.SetState(322)
 		p.NowFunc()
 	}
-	p.SetState(311)
+	p.SetState(324)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
-	if ((((_la - 95)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 95))) & ((1 << (SQLParserT_ADD - 95)) | (1 << (SQLParserT_SUB - 95)) | (1 << (SQLParserL_INT - 95)))) != 0) {
+	if ((((_la - 104)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 104))) & ((1 << (SQLParserT_ADD - 104)) | (1 << (SQLParserT_SUB - 104)) | (1 << (SQLParserL_INT - 104)))) != 0) {
 		{
-			p.SetState(310)
+			p.SetState(323)
 			p.DurationLit()
 		}
 
@@ -4355,7 +4629,7 @@ func (s *NowFuncContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) NowFunc() (localctx INowFuncContext) {
 	localctx = NewNowFuncContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 48, SQLParserRULE_nowFunc)
+	p.EnterRule(localctx, 52, SQLParserRULE_nowFunc)
 	var _la int
 
 
@@ -4377,27 +4651,27 @@ func (p *SQLParser) NowFunc() (localctx INowFuncContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(313)
+		p.SetState(326)
 		p.Match(SQLParserT_NOW)
 	}
 	{
-		p.SetState(314)
+		p.SetState(327)
 		p.Match(SQLParserT_OPEN_P)
 	}
-	p.SetState(316)
+	p.SetState(329)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
-	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)) | (1 << (SQLParserT_OPEN_P - 64)) | (1 << (SQLParserT_ADD - 64)))) != 0) || ((((_la - 96)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 96))) & ((1 << (SQLParserT_SUB - 96)) | (1 << (SQLParserL_ID - 96)) | (1 << (SQLParserL_INT - 96)) | (1 << (SQLParserL_DEC - 96)))) != 0) {
+	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_LOG - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_QUANTILE - 64)) | (1 << (SQLParserT_RATE - 64)) | (1 << (SQLParserT_IRATE - 64)) | (1 << (SQLParserT_DERIVATIVE - 64)) | (1 << (SQLParserT_MOVING_AVERAGE - 64)) | (1 << (SQLParserT_ABS - 64)) | (1 << (SQLParserT_TIME_SHIFT - 64)) | (1 << (SQLParserT_TOP - 64)) | (1 << (SQLParserT_BOTTOM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)))) != 0) || ((((_la - 102)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 102))) & ((1 << (SQLParserT_OPEN_P - 102)) | (1 << (SQLParserT_ADD - 102)) | (1 << (SQLParserT_SUB - 102)) | (1 << (SQLParserL_ID - 102)) | (1 << (SQLParserL_INT - 102)) | (1 << (SQLParserL_DEC - 102)))) != 0) {
 		{
-			p.SetState(315)
+			p.SetState(328)
 			p.ExprFuncParams()
 		}
 
 	}
 	{
-		p.SetState(318)
+		p.SetState(331)
 		p.Match(SQLParserT_CLOSE_P)
 	}
 
@@ -4521,7 +4795,7 @@ func (s *GroupByClauseContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) GroupByClause() (localctx IGroupByClauseContext) {
 	localctx = NewGroupByClauseContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 50, SQLParserRULE_groupByClause)
+	p.EnterRule(localctx, 54, SQLParserRULE_groupByClause)
 	var _la int
 
 
@@ -4543,49 +4817,49 @@ func (p *SQLParser) GroupByClause() (localctx IGroupByClauseContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(320)
+		p.SetState(333)
 		p.Match(SQLParserT_GROUP)
 	}
 	{
-		p.SetState(321)
+		p.SetState(334)
 		p.Match(SQLParserT_BY)
 	}
 	{
-		p.SetState(322)
+		p.SetState(335)
 		p.GroupByKeys()
 	}
-	p.SetState(328)
+	p.SetState(341)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_FILL {
 		{
-			p.SetState(323)
+			p.SetState(336)
 			p.Match(SQLParserT_FILL)
 		}
 		{
-			p.SetState(324)
+			p.SetState(337)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(325)
+			p.SetState(338)
 			p.FillOption()
 		}
 		{
-			p.SetState(326)
+			p.SetState(339)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
 	}
-	p.SetState(331)
+	p.SetState(344)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_HAVING {
 		{
-			p.SetState(330)
+			p.SetState(343)
 			p.HavingClause()
 		}
 
@@ -4692,7 +4966,7 @@ func (s *GroupByKeysContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) GroupByKeys() (localctx IGroupByKeysContext) {
 	localctx = NewGroupByKeysContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 52, SQLParserRULE_groupByKeys)
+	p.EnterRule(localctx, 56, SQLParserRULE_groupByKeys)
 	var _la int
 
 
@@ -4714,26 +4988,26 @@ func (p *SQLParser) GroupByKeys() (localctx IGroupByKeysContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(333)
+		p.SetState(346)
 		p.GroupByKey()
 	}
-	p.SetState(338)
+	p.SetState(351)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	for _la == SQLParserT_COMMA {
 		{
-			p.SetState(334)
+			p.SetState(347)
 			p.Match(SQLParserT_COMMA)
 		}
 		{
-			p.SetState(335)
+			p.SetState(348)
 			p.GroupByKey()
 		}
 
 
-		p.SetState(340)
+		p.SetState(353)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -4840,7 +5114,7 @@ func (s *GroupByKeyContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) GroupByKey() (localctx IGroupByKeyContext) {
 	localctx = NewGroupByKeyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 54, SQLParserRULE_groupByKey)
+	p.EnterRule(localctx, 58, SQLParserRULE_groupByKey)
 
 	defer func() {
 		p.ExitRule()
@@ -4858,13 +5132,13 @@ func (p *SQLParser) GroupByKey() (localctx IGroupByKeyContext) {
 		}
 	}()
 
-	p.SetState(347)
+	p.SetState(360)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 34, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(341)
+			p.SetState(354)
 			p.Ident()
 		}
 
@@ -4872,19 +5146,19 @@ func (p *SQLParser) GroupByKey() (localctx IGroupByKeyContext) {
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(342)
+			p.SetState(355)
 			p.Match(SQLParserT_TIME)
 		}
 		{
-			p.SetState(343)
+			p.SetState(356)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(344)
+			p.SetState(357)
 			p.DurationLit()
 		}
 		{
-			p.SetState(345)
+			p.SetState(358)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
@@ -4975,7 +5249,7 @@ func (s *FillOptionContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) FillOption() (localctx IFillOptionContext) {
 	localctx = NewFillOptionContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 56, SQLParserRULE_fillOption)
+	p.EnterRule(localctx, 60, SQLParserRULE_fillOption)
 	var _la int
 
 
@@ -4997,7 +5271,7 @@ func (p *SQLParser) FillOption() (localctx IFillOptionContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(349)
+		p.SetState(362)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == SQLParserT_NULL || _la == SQLParserT_PREVIOUS || _la == SQLParserL_INT || _la == SQLParserL_DEC) {
@@ -5096,7 +5370,7 @@ func (s *OrderByClauseContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) OrderByClause() (localctx IOrderByClauseContext) {
 	localctx = NewOrderByClauseContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 58, SQLParserRULE_orderByClause)
+	p.EnterRule(localctx, 62, SQLParserRULE_orderByClause)
 
 	defer func() {
 		p.ExitRule()
@@ -5116,15 +5390,15 @@ func (p *SQLParser) OrderByClause() (localctx IOrderByClauseContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(351)
+		p.SetState(364)
 		p.Match(SQLParserT_ORDER)
 	}
 	{
-		p.SetState(352)
+		p.SetState(365)
 		p.Match(SQLParserT_BY)
 	}
 	{
-		p.SetState(353)
+		p.SetState(366)
 		p.SortFields()
 	}
 
@@ -5224,7 +5498,7 @@ func (s *SortFieldContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) SortField() (localctx ISortFieldContext) {
 	localctx = NewSortFieldContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 60, SQLParserRULE_sortField)
+	p.EnterRule(localctx, 64, SQLParserRULE_sortField)
 	var _la int
 
 
@@ -5246,17 +5520,17 @@ func (p *SQLParser) SortField() (localctx ISortFieldContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(355)
+		p.SetState(368)
 		p.fieldExpr(0)
 	}
-	p.SetState(359)
+	p.SetState(372)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	for _la == SQLParserT_ASC || _la == SQLParserT_DESC {
 		{
-			p.SetState(356)
+			p.SetState(369)
 			_la = p.GetTokenStream().LA(1)
 
 			if !(_la == SQLParserT_ASC || _la == SQLParserT_DESC) {
@@ -5268,7 +5542,7 @@ func (p *SQLParser) SortField() (localctx ISortFieldContext) {
 		}
 
 
-		p.SetState(361)
+		p.SetState(374)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -5374,7 +5648,7 @@ func (s *SortFieldsContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) SortFields() (localctx ISortFieldsContext) {
 	localctx = NewSortFieldsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 62, SQLParserRULE_sortFields)
+	p.EnterRule(localctx, 66, SQLParserRULE_sortFields)
 	var _la int
 
 
@@ -5396,26 +5670,26 @@ func (p *SQLParser) SortFields() (localctx ISortFieldsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(362)
+		p.SetState(375)
 		p.SortField()
 	}
-	p.SetState(367)
+	p.SetState(380)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	for _la == SQLParserT_COMMA {
 		{
-			p.SetState(363)
+			p.SetState(376)
 			p.Match(SQLParserT_COMMA)
 		}
 		{
-			p.SetState(364)
+			p.SetState(377)
 			p.SortField()
 		}
 
 
-		p.SetState(369)
+		p.SetState(382)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -5504,7 +5778,7 @@ func (s *HavingClauseContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) HavingClause() (localctx IHavingClauseContext) {
 	localctx = NewHavingClauseContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 64, SQLParserRULE_havingClause)
+	p.EnterRule(localctx, 68, SQLParserRULE_havingClause)
 
 	defer func() {
 		p.ExitRule()
@@ -5524,11 +5798,11 @@ func (p *SQLParser) HavingClause() (localctx IHavingClauseContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(370)
+		p.SetState(383)
 		p.Match(SQLParserT_HAVING)
 	}
 	{
-		p.SetState(371)
+		p.SetState(384)
 		p.boolExpr(0)
 	}
 
@@ -5662,8 +5936,8 @@ func (p *SQLParser) boolExpr(_p int) (localctx IBoolExprContext) {
 	localctx = NewBoolExprContext(p, p.GetParserRuleContext(), _parentState)
 	var _prevctx IBoolExprContext = localctx
 	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 66
-	p.EnterRecursionRule(localctx, 66, SQLParserRULE_boolExpr, _p)
+	_startState := 70
+	p.EnterRecursionRule(localctx, 70, SQLParserRULE_boolExpr, _p)
 
 	defer func() {
 		p.UnrollRecursionContexts(_parentctx)
@@ -5684,33 +5958,33 @@ func (p *SQLParser) boolExpr(_p int) (localctx IBoolExprContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(379)
+	p.SetState(392)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 37, p.GetParserRuleContext()) {
 	case 1:
 		{
-			p.SetState(374)
+			p.SetState(387)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(375)
+			p.SetState(388)
 			p.boolExpr(0)
 		}
 		{
-			p.SetState(376)
+			p.SetState(389)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
 
 	case 2:
 		{
-			p.SetState(378)
+			p.SetState(391)
 			p.BoolExprAtom()
 		}
 
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(387)
+	p.SetState(400)
 	p.GetErrorHandler().Sync(p)
 	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 38, p.GetParserRuleContext())
 
@@ -5722,23 +5996,23 @@ func (p *SQLParser) boolExpr(_p int) (localctx IBoolExprContext) {
 			_prevctx = localctx
 			localctx = NewBoolExprContext(p, _parentctx, _parentState)
 			p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_boolExpr)
-			p.SetState(381)
+			p.SetState(394)
 
 			if !(p.Precpred(p.GetParserRuleContext(), 2)) {
 				panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 2)", ""))
 			}
 			{
-				p.SetState(382)
+				p.SetState(395)
 				p.BoolExprLogicalOp()
 			}
 			{
-				p.SetState(383)
+				p.SetState(396)
 				p.boolExpr(3)
 			}
 
 
 		}
-		p.SetState(389)
+		p.SetState(402)
 		p.GetErrorHandler().Sync(p)
 		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 38, p.GetParserRuleContext())
 	}
@@ -5821,7 +6095,7 @@ func (s *BoolExprLogicalOpContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) BoolExprLogicalOp() (localctx IBoolExprLogicalOpContext) {
 	localctx = NewBoolExprLogicalOpContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 68, SQLParserRULE_boolExprLogicalOp)
+	p.EnterRule(localctx, 72, SQLParserRULE_boolExprLogicalOp)
 	var _la int
 
 
@@ -5843,7 +6117,7 @@ func (p *SQLParser) BoolExprLogicalOp() (localctx IBoolExprLogicalOpContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(390)
+		p.SetState(403)
 		_la = p.GetTokenStream().LA(1)
 
 		if !(_la == SQLParserT_AND || _la == SQLParserT_OR) {
@@ -5934,7 +6208,7 @@ func (s *BoolExprAtomContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) BoolExprAtom() (localctx IBoolExprAtomContext) {
 	localctx = NewBoolExprAtomContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 70, SQLParserRULE_boolExprAtom)
+	p.EnterRule(localctx, 74, SQLParserRULE_boolExprAtom)
 
 	defer func() {
 		p.ExitRule()
@@ -5954,7 +6228,7 @@ func (p *SQLParser) BoolExprAtom() (localctx IBoolExprAtomContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(392)
+		p.SetState(405)
 		p.BinaryExpr()
 	}
 
@@ -6061,7 +6335,7 @@ func (s *BinaryExprContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) BinaryExpr() (localctx IBinaryExprContext) {
 	localctx = NewBinaryExprContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 72, SQLParserRULE_binaryExpr)
+	p.EnterRule(localctx, 76, SQLParserRULE_binaryExpr)
 
 	defer func() {
 		p.ExitRule()
@@ -6081,15 +6355,15 @@ func (p *SQLParser) BinaryExpr() (localctx IBinaryExprContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(394)
+		p.SetState(407)
 		p.fieldExpr(0)
 	}
 	{
-		p.SetState(395)
+		p.SetState(408)
 		p.BinaryOperator()
 	}
 	{
-		p.SetState(396)
+		p.SetState(409)
 		p.fieldExpr(0)
 	}
 
@@ -6199,7 +6473,7 @@ func (s *BinaryOperatorContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) BinaryOperator() (localctx IBinaryOperatorContext) {
 	localctx = NewBinaryOperatorContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 74, SQLParserRULE_binaryOperator)
+	p.EnterRule(localctx, 78, SQLParserRULE_binaryOperator)
 	var _la int
 
 
@@ -6219,14 +6493,14 @@ func (p *SQLParser) BinaryOperator() (localctx IBinaryOperatorContext) {
 		}
 	}()
 
-	p.SetState(406)
+	p.SetState(419)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserT_EQUAL:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(398)
+			p.SetState(411)
 			p.Match(SQLParserT_EQUAL)
 		}
 
@@ -6234,7 +6508,7 @@ func (p *SQLParser) BinaryOperator() (localctx IBinaryOperatorContext) {
 	case SQLParserT_NOTEQUAL:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(399)
+			p.SetState(412)
 			p.Match(SQLParserT_NOTEQUAL)
 		}
 
@@ -6242,7 +6516,7 @@ func (p *SQLParser) BinaryOperator() (localctx IBinaryOperatorContext) {
 	case SQLParserT_NOTEQUAL2:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(400)
+			p.SetState(413)
 			p.Match(SQLParserT_NOTEQUAL2)
 		}
 
@@ -6250,7 +6524,7 @@ func (p *SQLParser) BinaryOperator() (localctx IBinaryOperatorContext) {
 	case SQLParserT_LESS:
 		p.EnterOuterAlt(localctx, 4)
 		{
-			p.SetState(401)
+			p.SetState(414)
 			p.Match(SQLParserT_LESS)
 		}
 
@@ -6258,7 +6532,7 @@ func (p *SQLParser) BinaryOperator() (localctx IBinaryOperatorContext) {
 	case SQLParserT_LESSEQUAL:
 		p.EnterOuterAlt(localctx, 5)
 		{
-			p.SetState(402)
+			p.SetState(415)
 			p.Match(SQLParserT_LESSEQUAL)
 		}
 
@@ -6266,7 +6540,7 @@ func (p *SQLParser) BinaryOperator() (localctx IBinaryOperatorContext) {
 	case SQLParserT_GREATER:
 		p.EnterOuterAlt(localctx, 6)
 		{
-			p.SetState(403)
+			p.SetState(416)
 			p.Match(SQLParserT_GREATER)
 		}
 
@@ -6274,7 +6548,7 @@ func (p *SQLParser) BinaryOperator() (localctx IBinaryOperatorContext) {
 	case SQLParserT_GREATEREQUAL:
 		p.EnterOuterAlt(localctx, 7)
 		{
-			p.SetState(404)
+			p.SetState(417)
 			p.Match(SQLParserT_GREATEREQUAL)
 		}
 
@@ -6282,7 +6556,7 @@ func (p *SQLParser) BinaryOperator() (localctx IBinaryOperatorContext) {
 	case SQLParserT_LIKE, SQLParserT_REGEXP:
 		p.EnterOuterAlt(localctx, 8)
 		{
-			p.SetState(405)
+			p.SetState(418)
 			_la = p.GetTokenStream().LA(1)
 
 			if !(_la == SQLParserT_LIKE || _la == SQLParserT_REGEXP) {
@@ -6454,8 +6728,8 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 	localctx = NewFieldExprContext(p, p.GetParserRuleContext(), _parentState)
 	var _prevctx IFieldExprContext = localctx
 	var _ antlr.ParserRuleContext = _prevctx // TODO: To prevent unused variable warning.
-	_startState := 76
-	p.EnterRecursionRule(localctx, 76, SQLParserRULE_fieldExpr, _p)
+	_startState := 80
+	p.EnterRecursionRule(localctx, 80, SQLParserRULE_fieldExpr, _p)
 
 	defer func() {
 		p.UnrollRecursionContexts(_parentctx)
@@ -6476,47 +6750,47 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(416)
+	p.SetState(429)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 40, p.GetParserRuleContext()) {
 	case 1:
 		{
-			p.SetState(409)
+			p.SetState(422)
 			p.Match(SQLParserT_OPEN_P)
 		}
 		{
-			p.SetState(410)
+			p.SetState(423)
 			p.fieldExpr(0)
 		}
 		{
-			p.SetState(411)
+			p.SetState(424)
 			p.Match(SQLParserT_CLOSE_P)
 		}
 
 
 	case 2:
 		{
-			p.SetState(413)
+			p.SetState(426)
 			p.ExprFunc()
 		}
 
 
 	case 3:
 		{
-			p.SetState(414)
+			p.SetState(427)
 			p.ExprAtom()
 		}
 
 
 	case 4:
 		{
-			p.SetState(415)
+			p.SetState(428)
 			p.DurationLit()
 		}
 
 	}
 	p.GetParserRuleContext().SetStop(p.GetTokenStream().LT(-1))
-	p.SetState(432)
+	p.SetState(445)
 	p.GetErrorHandler().Sync(p)
 	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 42, p.GetParserRuleContext())
 
@@ -6526,23 +6800,23 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 				p.TriggerExitRuleEvent()
 			}
 			_prevctx = localctx
-			p.SetState(430)
+			p.SetState(443)
 			p.GetErrorHandler().Sync(p)
 			switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 41, p.GetParserRuleContext()) {
 			case 1:
 				localctx = NewFieldExprContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
-				p.SetState(418)
+				p.SetState(431)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 8)) {
 					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 8)", ""))
 				}
 				{
-					p.SetState(419)
+					p.SetState(432)
 					p.Match(SQLParserT_MUL)
 				}
 				{
-					p.SetState(420)
+					p.SetState(433)
 					p.fieldExpr(9)
 				}
 
@@ -6550,17 +6824,17 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 			case 2:
 				localctx = NewFieldExprContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
-				p.SetState(421)
+				p.SetState(434)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 7)) {
 					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 7)", ""))
 				}
 				{
-					p.SetState(422)
+					p.SetState(435)
 					p.Match(SQLParserT_DIV)
 				}
 				{
-					p.SetState(423)
+					p.SetState(436)
 					p.fieldExpr(8)
 				}
 
@@ -6568,17 +6842,17 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 			case 3:
 				localctx = NewFieldExprContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
-				p.SetState(424)
+				p.SetState(437)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 6)) {
 					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 6)", ""))
 				}
 				{
-					p.SetState(425)
+					p.SetState(438)
 					p.Match(SQLParserT_ADD)
 				}
 				{
-					p.SetState(426)
+					p.SetState(439)
 					p.fieldExpr(7)
 				}
 
@@ -6586,24 +6860,24 @@ func (p *SQLParser) fieldExpr(_p int) (localctx IFieldExprContext) {
 			case 4:
 				localctx = NewFieldExprContext(p, _parentctx, _parentState)
 				p.PushNewRecursionContext(localctx, _startState, SQLParserRULE_fieldExpr)
-				p.SetState(427)
+				p.SetState(440)
 
 				if !(p.Precpred(p.GetParserRuleContext(), 5)) {
 					panic(antlr.NewFailedPredicateException(p, "p.Precpred(p.GetParserRuleContext(), 5)", ""))
 				}
 				{
-					p.SetState(428)
+					p.SetState(441)
 					p.Match(SQLParserT_SUB)
 				}
 				{
-					p.SetState(429)
+					p.SetState(442)
 					p.fieldExpr(6)
 				}
 
 			}
 
 		}
-		p.SetState(434)
+		p.SetState(447)
 		p.GetErrorHandler().Sync(p)
 		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 42, p.GetParserRuleContext())
 	}
@@ -6698,7 +6972,7 @@ func (s *DurationLitContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) DurationLit() (localctx IDurationLitContext) {
 	localctx = NewDurationLitContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 78, SQLParserRULE_durationLit)
+	p.EnterRule(localctx, 82, SQLParserRULE_durationLit)
 
 	defer func() {
 		p.ExitRule()
@@ -6718,11 +6992,11 @@ func (p *SQLParser) DurationLit() (localctx IDurationLitContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(435)
+		p.SetState(448)
 		p.IntNumber()
 	}
 	{
-		p.SetState(436)
+		p.SetState(449)
 		p.IntervalItem()
 	}
 
@@ -6824,7 +7098,7 @@ func (s *IntervalItemContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) IntervalItem() (localctx IIntervalItemContext) {
 	localctx = NewIntervalItemContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 80, SQLParserRULE_intervalItem)
+	p.EnterRule(localctx, 84, SQLParserRULE_intervalItem)
 	var _la int
 
 
@@ -6846,10 +7120,10 @@ func (p *SQLParser) IntervalItem() (localctx IIntervalItemContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(438)
+		p.SetState(451)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((((_la - 70)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 70))) & ((1 << (SQLParserT_SECOND - 70)) | (1 << (SQLParserT_MINUTE - 70)) | (1 << (SQLParserT_HOUR - 70)) | (1 << (SQLParserT_DAY - 70)) | (1 << (SQLParserT_WEEK - 70)) | (1 << (SQLParserT_MONTH - 70)) | (1 << (SQLParserT_YEAR - 70)))) != 0)) {
+		if !(((((_la - 79)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 79))) & ((1 << (SQLParserT_SECOND - 79)) | (1 << (SQLParserT_MINUTE - 79)) | (1 << (SQLParserT_HOUR - 79)) | (1 << (SQLParserT_DAY - 79)) | (1 << (SQLParserT_WEEK - 79)) | (1 << (SQLParserT_MONTH - 79)) | (1 << (SQLParserT_YEAR - 79)))) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -6955,7 +7229,7 @@ func (s *ExprFuncContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) ExprFunc() (localctx IExprFuncContext) {
 	localctx = NewExprFuncContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 82, SQLParserRULE_exprFunc)
+	p.EnterRule(localctx, 86, SQLParserRULE_exprFunc)
 	var _la int
 
 
@@ -6977,27 +7251,27 @@ func (p *SQLParser) ExprFunc() (localctx IExprFuncContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(440)
+		p.SetState(453)
 		p.FuncName()
 	}
 	{
-		p.SetState(441)
+		p.SetState(454)
 		p.Match(SQLParserT_OPEN_P)
 	}
-	p.SetState(443)
+	p.SetState(456)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
-	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)) | (1 << (SQLParserT_OPEN_P - 64)) | (1 << (SQLParserT_ADD - 64)))) != 0) || ((((_la - 96)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 96))) & ((1 << (SQLParserT_SUB - 96)) | (1 << (SQLParserL_ID - 96)) | (1 << (SQLParserL_INT - 96)) | (1 << (SQLParserL_DEC - 96)))) != 0) {
+	if (((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_LOG - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_QUANTILE - 64)) | (1 << (SQLParserT_RATE - 64)) | (1 << (SQLParserT_IRATE - 64)) | (1 << (SQLParserT_DERIVATIVE - 64)) | (1 << (SQLParserT_MOVING_AVERAGE - 64)) | (1 << (SQLParserT_ABS - 64)) | (1 << (SQLParserT_TIME_SHIFT - 64)) | (1 << (SQLParserT_TOP - 64)) | (1 << (SQLParserT_BOTTOM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)))) != 0) || ((((_la - 102)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 102))) & ((1 << (SQLParserT_OPEN_P - 102)) | (1 << (SQLParserT_ADD - 102)) | (1 << (SQLParserT_SUB - 102)) | (1 << (SQLParserL_ID - 102)) | (1 << (SQLParserL_INT - 102)) | (1 << (SQLParserL_DEC - 102)))) != 0) {
 		{
-			p.SetState(442)
+			p.SetState(455)
 			p.ExprFuncParams()
 		}
 
 	}
 	{
-		p.SetState(445)
+		p.SetState(458)
 		p.Match(SQLParserT_CLOSE_P)
 	}
 
@@ -7073,6 +7347,46 @@ func (s *FuncNameContext) T_HISTOGRAM() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_HISTOGRAM, 0)
 }
 
+func (s *FuncNameContext) T_QUANTILE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_QUANTILE, 0)
+}
+
+func (s *FuncNameContext) T_RATE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_RATE, 0)
+}
+
+func (s *FuncNameContext) T_IRATE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_IRATE, 0)
+}
+
+func (s *FuncNameContext) T_DERIVATIVE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DERIVATIVE, 0)
+}
+
+func (s *FuncNameContext) T_MOVING_AVERAGE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_MOVING_AVERAGE, 0)
+}
+
+func (s *FuncNameContext) T_ABS() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_ABS, 0)
+}
+
+func (s *FuncNameContext) T_LOG() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LOG, 0)
+}
+
+func (s *FuncNameContext) T_TIME_SHIFT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_TIME_SHIFT, 0)
+}
+
+func (s *FuncNameContext) T_TOP() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_TOP, 0)
+}
+
+func (s *FuncNameContext) T_BOTTOM() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_BOTTOM, 0)
+}
+
 func (s *FuncNameContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -7099,7 +7413,7 @@ func (s *FuncNameContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) FuncName() (localctx IFuncNameContext) {
 	localctx = NewFuncNameContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 84, SQLParserRULE_funcName)
+	p.EnterRule(localctx, 88, SQLParserRULE_funcName)
 	var _la int
 
 
@@ -7121,10 +7435,10 @@ func (p *SQLParser) FuncName() (localctx IFuncNameContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(447)
+		p.SetState(460)
 		_la = p.GetTokenStream().LA(1)
 
-		if !(((((_la - 63)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 63))) & ((1 << (SQLParserT_SUM - 63)) | (1 << (SQLParserT_MIN - 63)) | (1 << (SQLParserT_MAX - 63)) | (1 << (SQLParserT_COUNT - 63)) | (1 << (SQLParserT_AVG - 63)) | (1 << (SQLParserT_STDDEV - 63)) | (1 << (SQLParserT_HISTOGRAM - 63)))) != 0)) {
+		if !(((((_la - 61)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 61))) & ((1 << (SQLParserT_LOG - 61)) | (1 << (SQLParserT_SUM - 61)) | (1 << (SQLParserT_MIN - 61)) | (1 << (SQLParserT_MAX - 61)) | (1 << (SQLParserT_COUNT - 61)) | (1 << (SQLParserT_AVG - 61)) | (1 << (SQLParserT_STDDEV - 61)) | (1 << (SQLParserT_HISTOGRAM - 61)) | (1 << (SQLParserT_QUANTILE - 61)) | (1 << (SQLParserT_RATE - 61)) | (1 << (SQLParserT_IRATE - 61)) | (1 << (SQLParserT_DERIVATIVE - 61)) | (1 << (SQLParserT_MOVING_AVERAGE - 61)) | (1 << (SQLParserT_ABS - 61)) | (1 << (SQLParserT_TIME_SHIFT - 61)) | (1 << (SQLParserT_TOP - 61)) | (1 << (SQLParserT_BOTTOM - 61)))) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -7233,7 +7547,7 @@ func (s *ExprFuncParamsContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) ExprFuncParams() (localctx IExprFuncParamsContext) {
 	localctx = NewExprFuncParamsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 86, SQLParserRULE_exprFuncParams)
+	p.EnterRule(localctx, 90, SQLParserRULE_exprFuncParams)
 	var _la int
 
 
@@ -7255,26 +7569,26 @@ func (p *SQLParser) ExprFuncParams() (localctx IExprFuncParamsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(449)
+		p.SetState(462)
 		p.FuncParam()
 	}
-	p.SetState(454)
+	p.SetState(467)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	for _la == SQLParserT_COMMA {
 		{
-			p.SetState(450)
+			p.SetState(463)
 			p.Match(SQLParserT_COMMA)
 		}
 		{
-			p.SetState(451)
+			p.SetState(464)
 			p.FuncParam()
 		}
 
 
-		p.SetState(456)
+		p.SetState(469)
 		p.GetErrorHandler().Sync(p)
 		_la = p.GetTokenStream().LA(1)
 	}
@@ -7369,7 +7683,7 @@ func (s *FuncParamContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) FuncParam() (localctx IFuncParamContext) {
 	localctx = NewFuncParamContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 88, SQLParserRULE_funcParam)
+	p.EnterRule(localctx, 92, SQLParserRULE_funcParam)
 
 	defer func() {
 		p.ExitRule()
@@ -7387,13 +7701,13 @@ func (p *SQLParser) FuncParam() (localctx IFuncParamContext) {
 		}
 	}()
 
-	p.SetState(459)
+	p.SetState(472)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 45, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(457)
+			p.SetState(470)
 			p.fieldExpr(0)
 		}
 
@@ -7401,7 +7715,7 @@ func (p *SQLParser) FuncParam() (localctx IFuncParamContext) {
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(458)
+			p.SetState(471)
 			p.tagFilterExpr(0)
 		}
 
@@ -7516,7 +7830,7 @@ func (s *ExprAtomContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) ExprAtom() (localctx IExprAtomContext) {
 	localctx = NewExprAtomContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 90, SQLParserRULE_exprAtom)
+	p.EnterRule(localctx, 94, SQLParserRULE_exprAtom)
 
 	defer func() {
 		p.ExitRule()
@@ -7534,22 +7848,22 @@ func (p *SQLParser) ExprAtom() (localctx IExprAtomContext) {
 		}
 	}()
 
-	p.SetState(467)
+	p.SetState(480)
 	p.GetErrorHandler().Sync(p)
 	switch p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 47, p.GetParserRuleContext()) {
 	case 1:
 		p.EnterOuterAlt(localctx, 1)
 		{
-			p.SetState(461)
+			p.SetState(474)
 			p.Ident()
 		}
-		p.SetState(463)
+		p.SetState(476)
 		p.GetErrorHandler().Sync(p)
 
 
 		if p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 46, p.GetParserRuleContext()) == 1 {
 			{
-				p.SetState(462)
+				p.SetState(475)
 				p.IdentFilter()
 			}
 
@@ -7560,7 +7874,7 @@ func (p *SQLParser) ExprAtom() (localctx IExprAtomContext) {
 	case 2:
 		p.EnterOuterAlt(localctx, 2)
 		{
-			p.SetState(465)
+			p.SetState(478)
 			p.DecNumber()
 		}
 
@@ -7568,7 +7882,7 @@ func (p *SQLParser) ExprAtom() (localctx IExprAtomContext) {
 	case 3:
 		p.EnterOuterAlt(localctx, 3)
 		{
-			p.SetState(466)
+			p.SetState(479)
 			p.IntNumber()
 		}
 
@@ -7661,7 +7975,7 @@ func (s *IdentFilterContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) IdentFilter() (localctx IIdentFilterContext) {
 	localctx = NewIdentFilterContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 92, SQLParserRULE_identFilter)
+	p.EnterRule(localctx, 96, SQLParserRULE_identFilter)
 
 	defer func() {
 		p.ExitRule()
@@ -7681,15 +7995,15 @@ func (p *SQLParser) IdentFilter() (localctx IIdentFilterContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(469)
+		p.SetState(482)
 		p.Match(SQLParserT_OPEN_SB)
 	}
 	{
-		p.SetState(470)
+		p.SetState(483)
 		p.tagFilterExpr(0)
 	}
 	{
-		p.SetState(471)
+		p.SetState(484)
 		p.Match(SQLParserT_CLOSE_SB)
 	}
 
@@ -7775,7 +8089,7 @@ func (s *IntNumberContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) IntNumber() (localctx IIntNumberContext) {
 	localctx = NewIntNumberContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 94, SQLParserRULE_intNumber)
+	p.EnterRule(localctx, 98, SQLParserRULE_intNumber)
 	var _la int
 
 
@@ -7796,14 +8110,14 @@ func (p *SQLParser) IntNumber() (localctx IIntNumberContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(474)
+	p.SetState(487)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_ADD || _la == SQLParserT_SUB {
 		{
-			p.SetState(473)
+			p.SetState(486)
 			_la = p.GetTokenStream().LA(1)
 
 			if !(_la == SQLParserT_ADD || _la == SQLParserT_SUB) {
@@ -7816,7 +8130,7 @@ func (p *SQLParser) IntNumber() (localctx IIntNumberContext) {
 
 	}
 	{
-		p.SetState(476)
+		p.SetState(489)
 		p.Match(SQLParserL_INT)
 	}
 
@@ -7902,7 +8216,7 @@ func (s *DecNumberContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) DecNumber() (localctx IDecNumberContext) {
 	localctx = NewDecNumberContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 96, SQLParserRULE_decNumber)
+	p.EnterRule(localctx, 100, SQLParserRULE_decNumber)
 	var _la int
 
 
@@ -7923,14 +8237,14 @@ func (p *SQLParser) DecNumber() (localctx IDecNumberContext) {
 	}()
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(479)
+	p.SetState(492)
 	p.GetErrorHandler().Sync(p)
 	_la = p.GetTokenStream().LA(1)
 
 
 	if _la == SQLParserT_ADD || _la == SQLParserT_SUB {
 		{
-			p.SetState(478)
+			p.SetState(491)
 			_la = p.GetTokenStream().LA(1)
 
 			if !(_la == SQLParserT_ADD || _la == SQLParserT_SUB) {
@@ -7943,7 +8257,7 @@ func (p *SQLParser) DecNumber() (localctx IDecNumberContext) {
 
 	}
 	{
-		p.SetState(481)
+		p.SetState(494)
 		p.Match(SQLParserL_DEC)
 	}
 
@@ -8025,7 +8339,7 @@ func (s *LimitClauseContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) LimitClause() (localctx ILimitClauseContext) {
 	localctx = NewLimitClauseContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 98, SQLParserRULE_limitClause)
+	p.EnterRule(localctx, 102, SQLParserRULE_limitClause)
 
 	defer func() {
 		p.ExitRule()
@@ -8045,11 +8359,11 @@ func (p *SQLParser) LimitClause() (localctx ILimitClauseContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(483)
+		p.SetState(496)
 		p.Match(SQLParserT_LIMIT)
 	}
 	{
-		p.SetState(484)
+		p.SetState(497)
 		p.Match(SQLParserL_INT)
 	}
 
@@ -8133,7 +8447,7 @@ func (s *MetricNameContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) MetricName() (localctx IMetricNameContext) {
 	localctx = NewMetricNameContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 100, SQLParserRULE_metricName)
+	p.EnterRule(localctx, 104, SQLParserRULE_metricName)
 
 	defer func() {
 		p.ExitRule()
@@ -8153,7 +8467,7 @@ func (p *SQLParser) MetricName() (localctx IMetricNameContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(486)
+		p.SetState(499)
 		p.Ident()
 	}
 
@@ -8237,7 +8551,7 @@ func (s *TagKeyContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) TagKey() (localctx ITagKeyContext) {
 	localctx = NewTagKeyContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 102, SQLParserRULE_tagKey)
+	p.EnterRule(localctx, 106, SQLParserRULE_tagKey)
 
 	defer func() {
 		p.ExitRule()
@@ -8257,7 +8571,7 @@ func (p *SQLParser) TagKey() (localctx ITagKeyContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(488)
+		p.SetState(501)
 		p.Ident()
 	}
 
@@ -8341,7 +8655,7 @@ func (s *TagValueContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) TagValue() (localctx ITagValueContext) {
 	localctx = NewTagValueContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 104, SQLParserRULE_tagValue)
+	p.EnterRule(localctx, 108, SQLParserRULE_tagValue)
 
 	defer func() {
 		p.ExitRule()
@@ -8361,7 +8675,7 @@ func (p *SQLParser) TagValue() (localctx ITagValueContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(490)
+		p.SetState(503)
 		p.Ident()
 	}
 
@@ -8474,7 +8788,7 @@ func (s *IdentContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) Ident() (localctx IIdentContext) {
 	localctx = NewIdentContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 106, SQLParserRULE_ident)
+	p.EnterRule(localctx, 110, SQLParserRULE_ident)
 
 	defer func() {
 		p.ExitRule()
@@ -8495,20 +8809,20 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 	var _alt int
 
 	p.EnterOuterAlt(localctx, 1)
-	p.SetState(494)
+	p.SetState(507)
 	p.GetErrorHandler().Sync(p)
 
 	switch p.GetTokenStream().LA(1) {
 	case SQLParserL_ID:
 		{
-			p.SetState(492)
+			p.SetState(505)
 			p.Match(SQLParserL_ID)
 		}
 
 
-	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
+	case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_LOG, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_QUANTILE, SQLParserT_RATE, SQLParserT_IRATE, SQLParserT_DERIVATIVE, SQLParserT_MOVING_AVERAGE, SQLParserT_ABS, SQLParserT_TIME_SHIFT, SQLParserT_TOP, SQLParserT_BOTTOM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
 		{
-			p.SetState(493)
+			p.SetState(506)
 			p.NonReservedWords()
 		}
 
@@ -8517,30 +8831,30 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 	default:
 		panic(antlr.NewNoViableAltException(p, nil, nil, nil, nil, nil))
 	}
-	p.SetState(503)
+	p.SetState(516)
 	p.GetErrorHandler().Sync(p)
 	_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 52, p.GetParserRuleContext())
 
 	for _alt != 2 && _alt != antlr.ATNInvalidAltNumber {
 		if _alt == 1 {
 			{
-				p.SetState(496)
+				p.SetState(509)
 				p.Match(SQLParserT_DOT)
 			}
-			p.SetState(499)
+			p.SetState(512)
 			p.GetErrorHandler().Sync(p)
 
 			switch p.GetTokenStream().LA(1) {
 			case SQLParserL_ID:
 				{
-					p.SetState(497)
+					p.SetState(510)
 					p.Match(SQLParserL_ID)
 				}
 
 
-			case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
+			case SQLParserT_CREATE, SQLParserT_INTERVAL, SQLParserT_SHARD, SQLParserT_REPLICATION, SQLParserT_TTL, SQLParserT_KILL, SQLParserT_ON, SQLParserT_SHOW, SQLParserT_DATASBAE, SQLParserT_DATASBAES, SQLParserT_NAMESPACE, SQLParserT_NAMESPACES, SQLParserT_NODE, SQLParserT_MEASUREMENTS, SQLParserT_MEASUREMENT, SQLParserT_FIELD, SQLParserT_FIELDS, SQLParserT_TAG, SQLParserT_KEYS, SQLParserT_KEY, SQLParserT_WITH, SQLParserT_VALUES, SQLParserT_FROM, SQLParserT_WHERE, SQLParserT_LIMIT, SQLParserT_QUERIES, SQLParserT_QUERY, SQLParserT_SELECT, SQLParserT_AS, SQLParserT_AND, SQLParserT_OR, SQLParserT_FILL, SQLParserT_NULL, SQLParserT_PREVIOUS, SQLParserT_ORDER, SQLParserT_ASC, SQLParserT_DESC, SQLParserT_LIKE, SQLParserT_NOT, SQLParserT_BETWEEN, SQLParserT_IS, SQLParserT_GROUP, SQLParserT_BY, SQLParserT_FOR, SQLParserT_STATS, SQLParserT_TIME, SQLParserT_LOG, SQLParserT_PROFILE, SQLParserT_SUM, SQLParserT_MIN, SQLParserT_MAX, SQLParserT_COUNT, SQLParserT_AVG, SQLParserT_STDDEV, SQLParserT_HISTOGRAM, SQLParserT_QUANTILE, SQLParserT_RATE, SQLParserT_IRATE, SQLParserT_DERIVATIVE, SQLParserT_MOVING_AVERAGE, SQLParserT_ABS, SQLParserT_TIME_SHIFT, SQLParserT_TOP, SQLParserT_BOTTOM, SQLParserT_SECOND, SQLParserT_MINUTE, SQLParserT_HOUR, SQLParserT_DAY, SQLParserT_WEEK, SQLParserT_MONTH, SQLParserT_YEAR:
 				{
-					p.SetState(498)
+					p.SetState(511)
 					p.NonReservedWords()
 				}
 
@@ -8552,7 +8866,7 @@ func (p *SQLParser) Ident() (localctx IIdentContext) {
 
 
 		}
-		p.SetState(505)
+		p.SetState(518)
 		p.GetErrorHandler().Sync(p)
 		_alt = p.GetInterpreter().AdaptivePredict(p.GetTokenStream(), 52, p.GetParserRuleContext())
 	}
@@ -8845,6 +9159,46 @@ func (s *NonReservedWordsContext) T_HISTOGRAM() antlr.TerminalNode {
 	return s.GetToken(SQLParserT_HISTOGRAM, 0)
 }
 
+func (s *NonReservedWordsContext) T_QUANTILE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_QUANTILE, 0)
+}
+
+func (s *NonReservedWordsContext) T_RATE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_RATE, 0)
+}
+
+func (s *NonReservedWordsContext) T_IRATE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_IRATE, 0)
+}
+
+func (s *NonReservedWordsContext) T_DERIVATIVE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_DERIVATIVE, 0)
+}
+
+func (s *NonReservedWordsContext) T_MOVING_AVERAGE() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_MOVING_AVERAGE, 0)
+}
+
+func (s *NonReservedWordsContext) T_ABS() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_ABS, 0)
+}
+
+func (s *NonReservedWordsContext) T_LOG() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_LOG, 0)
+}
+
+func (s *NonReservedWordsContext) T_TIME_SHIFT() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_TIME_SHIFT, 0)
+}
+
+func (s *NonReservedWordsContext) T_TOP() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_TOP, 0)
+}
+
+func (s *NonReservedWordsContext) T_BOTTOM() antlr.TerminalNode {
+	return s.GetToken(SQLParserT_BOTTOM, 0)
+}
+
 func (s *NonReservedWordsContext) GetRuleContext() antlr.RuleContext {
 	return s
 }
@@ -8871,7 +9225,7 @@ func (s *NonReservedWordsContext) ExitRule(listener antlr.ParseTreeListener) {
 
 func (p *SQLParser) NonReservedWords() (localctx INonReservedWordsContext) {
 	localctx = NewNonReservedWordsContext(p, p.GetParserRuleContext(), p.GetState())
-	p.EnterRule(localctx, 108, SQLParserRULE_nonReservedWords)
+	p.EnterRule(localctx, 112, SQLParserRULE_nonReservedWords)
 	var _la int
 
 
@@ -8893,10 +9247,10 @@ func (p *SQLParser) NonReservedWords() (localctx INonReservedWordsContext) {
 
 	p.EnterOuterAlt(localctx, 1)
 	{
-		p.SetState(506)
+		p.SetState(519)
 		_la = p.GetTokenStream().LA(1)
 
-		if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)))) != 0)) {
+		if !((((_la) & -(0x1f+1)) == 0 && ((1 << uint(_la)) & ((1 << SQLParserT_CREATE) | (1 << SQLParserT_INTERVAL) | (1 << SQLParserT_SHARD) | (1 << SQLParserT_REPLICATION) | (1 << SQLParserT_TTL) | (1 << SQLParserT_KILL) | (1 << SQLParserT_ON) | (1 << SQLParserT_SHOW) | (1 << SQLParserT_DATASBAE) | (1 << SQLParserT_DATASBAES) | (1 << SQLParserT_NAMESPACE) | (1 << SQLParserT_NAMESPACES) | (1 << SQLParserT_NODE) | (1 << SQLParserT_MEASUREMENTS) | (1 << SQLParserT_MEASUREMENT) | (1 << SQLParserT_FIELD) | (1 << SQLParserT_FIELDS) | (1 << SQLParserT_TAG) | (1 << SQLParserT_KEYS) | (1 << SQLParserT_KEY) | (1 << SQLParserT_WITH) | (1 << SQLParserT_VALUES))) != 0) || ((((_la - 32)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 32))) & ((1 << (SQLParserT_FROM - 32)) | (1 << (SQLParserT_WHERE - 32)) | (1 << (SQLParserT_LIMIT - 32)) | (1 << (SQLParserT_QUERIES - 32)) | (1 << (SQLParserT_QUERY - 32)) | (1 << (SQLParserT_SELECT - 32)) | (1 << (SQLParserT_AS - 32)) | (1 << (SQLParserT_AND - 32)) | (1 << (SQLParserT_OR - 32)) | (1 << (SQLParserT_FILL - 32)) | (1 << (SQLParserT_NULL - 32)) | (1 << (SQLParserT_PREVIOUS - 32)) | (1 << (SQLParserT_ORDER - 32)) | (1 << (SQLParserT_ASC - 32)) | (1 << (SQLParserT_DESC - 32)) | (1 << (SQLParserT_LIKE - 32)) | (1 << (SQLParserT_NOT - 32)) | (1 << (SQLParserT_BETWEEN - 32)) | (1 << (SQLParserT_IS - 32)) | (1 << (SQLParserT_GROUP - 32)) | (1 << (SQLParserT_BY - 32)) | (1 << (SQLParserT_FOR - 32)) | (1 << (SQLParserT_STATS - 32)) | (1 << (SQLParserT_TIME - 32)) | (1 << (SQLParserT_LOG - 32)) | (1 << (SQLParserT_PROFILE - 32)) | (1 << (SQLParserT_SUM - 32)))) != 0) || ((((_la - 64)) & -(0x1f+1)) == 0 && ((1 << uint((_la - 64))) & ((1 << (SQLParserT_MIN - 64)) | (1 << (SQLParserT_MAX - 64)) | (1 << (SQLParserT_COUNT - 64)) | (1 << (SQLParserT_AVG - 64)) | (1 << (SQLParserT_STDDEV - 64)) | (1 << (SQLParserT_HISTOGRAM - 64)) | (1 << (SQLParserT_QUANTILE - 64)) | (1 << (SQLParserT_RATE - 64)) | (1 << (SQLParserT_IRATE - 64)) | (1 << (SQLParserT_DERIVATIVE - 64)) | (1 << (SQLParserT_MOVING_AVERAGE - 64)) | (1 << (SQLParserT_ABS - 64)) | (1 << (SQLParserT_TIME_SHIFT - 64)) | (1 << (SQLParserT_TOP - 64)) | (1 << (SQLParserT_BOTTOM - 64)) | (1 << (SQLParserT_SECOND - 64)) | (1 << (SQLParserT_MINUTE - 64)) | (1 << (SQLParserT_HOUR - 64)) | (1 << (SQLParserT_DAY - 64)) | (1 << (SQLParserT_WEEK - 64)) | (1 << (SQLParserT_MONTH - 64)) | (1 << (SQLParserT_YEAR - 64)))) != 0)) {
 			p.GetErrorHandler().RecoverInline(p)
 		} else {
 			p.GetErrorHandler().ReportMatch(p)
@@ -8912,17 +9266,17 @@ func (p *SQLParser) NonReservedWords() (localctx INonReservedWordsContext) {
 
 func (p *SQLParser) Sempred(localctx antlr.RuleContext, ruleIndex, predIndex int) bool {
 	switch ruleIndex {
-	case 19:
+	case 21:
 			var t *TagFilterExprContext = nil
 			if localctx != nil { t = localctx.(*TagFilterExprContext) }
 			return p.TagFilterExpr_Sempred(t, predIndex)
 
-	case 33:
+	case 35:
 			var t *BoolExprContext = nil
 			if localctx != nil { t = localctx.(*BoolExprContext) }
 			return p.BoolExpr_Sempred(t, predIndex)
 
-	case 38:
+	case 40:
 			var t *FieldExprContext = nil
 			if localctx != nil { t = localctx.(*FieldExprContext) }
 			return p.FieldExpr_Sempred(t, predIndex)
@@ -10,6 +10,8 @@ type listener struct {
 	stmt *queryStmtParse
 
 	metaStmt *metaStmtParser
+
+	queryJobStmt *queryJobStmtParser
 }
 
 // EnterQueryStmt is called when production queryStmt is entered.
@@ -47,6 +49,18 @@ func (l *listener) EnterShowTagValuesStmt(ctx *grammar.ShowTagValuesStmtContext)
 	l.metaStmt = newMetaStmtParser(stmt.TagValue)
 }
 
+// EnterShowQueriesStmt is called when production showQueriesStmt is entered.
+func (l *listener) EnterShowQueriesStmt(ctx *grammar.ShowQueriesStmtContext) {
+	l.queryJobStmt = newQueryJobStmtParser()
+	l.queryJobStmt.visitShowQueries(ctx)
+}
+
+// EnterKillQueryStmt is called when production killQueryStmt is entered.
+func (l *listener) EnterKillQueryStmt(ctx *grammar.KillQueryStmtContext) {
+	l.queryJobStmt = newQueryJobStmtParser()
+	l.queryJobStmt.visitKillQuery(ctx)
+}
+
 // EnterNamespace is called when production namespace is entered.
 func (l *listener) EnterNamespace(ctx *grammar.NamespaceContext) {
 	switch {
@@ -191,12 +205,77 @@ func (l *listener) EnterGroupByKey(ctx *grammar.GroupByKeyContext) {
 	}
 }
 
+// EnterFillOption is called when production fillOption is entered.
+func (l *listener) EnterFillOption(ctx *grammar.FillOptionContext) {
+	if l.stmt != nil {
+		l.stmt.visitFillOption(ctx)
+	}
+}
+
+// EnterHavingClause is called when production havingClause is entered.
+func (l *listener) EnterHavingClause(ctx *grammar.HavingClauseContext) {
+	if l.stmt != nil {
+		l.stmt.visitSelectItemRef()
+	}
+}
+
+// EnterBoolExpr is called when production boolExpr is entered.
+func (l *listener) EnterBoolExpr(ctx *grammar.BoolExprContext) {
+	if l.stmt != nil {
+		l.stmt.visitBoolExpr(ctx)
+	}
+}
+
+// ExitBoolExpr is called when production boolExpr is exited.
+func (l *listener) ExitBoolExpr(ctx *grammar.BoolExprContext) {
+	if l.stmt != nil {
+		l.stmt.completeBoolExpr(ctx)
+	}
+}
+
+// EnterBinaryExpr is called when production binaryExpr is entered.
+func (l *listener) EnterBinaryExpr(ctx *grammar.BinaryExprContext) {
+	if l.stmt != nil {
+		l.stmt.visitBinaryExpr(ctx)
+	}
+}
+
+// ExitBinaryExpr is called when production binaryExpr is exited.
+func (l *listener) ExitBinaryExpr(ctx *grammar.BinaryExprContext) {
+	if l.stmt != nil {
+		l.stmt.completeHavingExpr()
+	}
+}
+
+// EnterOrderByClause is called when production orderByClause is entered.
+func (l *listener) EnterOrderByClause(ctx *grammar.OrderByClauseContext) {
+	if l.stmt != nil {
+		l.stmt.visitSelectItemRef()
+	}
+}
+
+// EnterSortField is called when production sortField is entered.
+func (l *listener) EnterSortField(ctx *grammar.SortFieldContext) {
+	if l.stmt != nil {
+		l.stmt.visitSortField(ctx)
+	}
+}
+
+// ExitSortField is called when production sortField is exited.
+func (l *listener) ExitSortField(ctx *grammar.SortFieldContext) {
+	if l.stmt != nil {
+		l.stmt.completeSortField()
+	}
+}
+
 // statement returns query statement, if failure return error
 func (l *listener) statement() (stmt.Statement, error) {
 	if l.stmt != nil {
 		return l.stmt.build()
 	} else if l.metaStmt != nil {
 		return l.metaStmt.build()
+	} else if l.queryJobStmt != nil {
+		return l.queryJobStmt.build()
 	}
 	return nil, nil
 }
@@ -36,6 +36,16 @@ func Parse(sql string) (stmt stmt.Statement, err error) {
 		}
 	}()
 
+	dropMetricStmt := isDropMetricStmt(sql)
+	deleteStmt := dropMetricStmt || isDeleteStmt(sql)
+	switch {
+	case dropMetricStmt:
+		if sql, err = rewriteDropMetricStmt(sql); err != nil {
+			return nil, err
+		}
+	case deleteStmt:
+		sql = rewriteDeleteStmt(sql)
+	}
 	input := antlr.NewInputStream(sql)
 
 	lexer := grammar.NewSQLLexer(input)
@@ -51,6 +61,13 @@ func Parse(sql string) (stmt stmt.Statement, err error) {
 
 	ctx := parser.Statement()
 
+	if deleteStmt {
+		// create delete statement listener
+		listener := deleteListener{dropMetric: dropMetricStmt}
+		walker.Walk(&listener, ctx)
+		return listener.statement()
+	}
+
 	// create sql listener
 	listener := listener{}
 
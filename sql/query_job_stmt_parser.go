@@ -0,0 +1,42 @@
+package sql
+
+import (
+	"strconv"
+
+	"github.com/lindb/lindb/sql/grammar"
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+// queryJobStmtParser represents show queries/kill query <id> statement parser
+type queryJobStmtParser struct {
+	stmt stmt.Statement
+	err  error
+}
+
+// newQueryJobStmtParser creates a new query job statement parser
+func newQueryJobStmtParser() *queryJobStmtParser {
+	return &queryJobStmtParser{}
+}
+
+// visitShowQueries visits when production show queries statement is entered
+func (s *queryJobStmtParser) visitShowQueries(ctx *grammar.ShowQueriesStmtContext) {
+	s.stmt = &stmt.ShowQueries{}
+}
+
+// visitKillQuery visits when production kill query statement is entered
+func (s *queryJobStmtParser) visitKillQuery(ctx *grammar.KillQueryStmtContext) {
+	jobID, err := strconv.ParseInt(ctx.L_INT().GetText(), 10, 64)
+	if err != nil {
+		s.err = err
+		return
+	}
+	s.stmt = &stmt.KillQuery{JobID: jobID}
+}
+
+// build builds the query job statement
+func (s *queryJobStmtParser) build() (stmt.Statement, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.stmt, nil
+}
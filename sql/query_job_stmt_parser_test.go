@@ -0,0 +1,42 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/sql/stmt"
+)
+
+func TestQueryJobStmt_Parse(t *testing.T) {
+	s, err := Parse("show queries")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.ShowQueries{}, s)
+	s, err = Parse(" SHOW  Queries ")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.ShowQueries{}, s)
+
+	s, err = Parse("kill query 100")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.KillQuery{JobID: 100}, s)
+	s, err = Parse("KILL QUERY 12")
+	assert.NoError(t, err)
+	assert.Equal(t, &stmt.KillQuery{JobID: 12}, s)
+
+	// keywords of query job statement are non-reserved words
+	s, err = Parse("select f from queries")
+	assert.NoError(t, err)
+	assert.Equal(t, "queries", s.(*stmt.Query).MetricName)
+
+	for _, sql := range []string{
+		"kill query",
+		"kill query abc",
+		"kill query 1 2",
+		"kill query 99999999999999999999",
+		"show queries 1",
+	} {
+		s, err = Parse(sql)
+		assert.Error(t, err, sql)
+		assert.Nil(t, s)
+	}
+}
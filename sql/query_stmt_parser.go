@@ -25,11 +25,16 @@ type queryStmtParse struct {
 	startTime int64
 	endTime   int64
 
-	//orderByExpr stmt.Expr
-	//desc        bool
-	groupBy  []string
-	interval int64
-	fieldID  int
+	groupBy   []string
+	interval  int64
+	fill      stmt.FillPolicy
+	fillValue float64
+	having    stmt.Expr
+	orderBy   []stmt.Expr
+	fieldID   int
+
+	// fields in order by/having clause refer to the select items, not the fields of metric
+	refSelectItem bool
 }
 
 // newQueryStmtParse create a query statement parser
@@ -84,10 +89,68 @@ func (q *queryStmtParse) build() (stmt.Statement, error) {
 
 	query.Interval = timeutil.Interval(q.interval)
 	query.GroupBy = q.groupBy
+	query.Fill = q.fill
+	query.FillValue = q.fillValue
+	query.Having = q.having
+	query.OrderBy = q.orderBy
 	query.Limit = q.limit
+	if err := q.validateSelectItemRef(query); err != nil {
+		return nil, err
+	}
 	return query, nil
 }
 
+// validateSelectItemRef validates if the fields of order by/having clause refer to the select items
+func (q *queryStmtParse) validateSelectItemRef(query *stmt.Query) error {
+	for _, orderBy := range query.OrderBy {
+		orderByExpr, ok := orderBy.(*stmt.OrderByExpr)
+		if !ok || orderByExpr.Expr == nil {
+			return fmt.Errorf("order by expression is invalid")
+		}
+		if _, ok := query.SelectItemOf(orderByExpr.Expr); !ok {
+			return fmt.Errorf("order by field: %s not in select list", orderByExpr.Expr.Rewrite())
+		}
+	}
+	if query.Having != nil {
+		return validateHavingExpr(query, query.Having)
+	}
+	return nil
+}
+
+// validateHavingExpr validates if the having expression is a valid boolean expression,
+// and all fields refer to the select items
+func validateHavingExpr(query *stmt.Query, expr stmt.Expr) error {
+	switch e := expr.(type) {
+	case *stmt.ParenExpr:
+		if e.Expr == nil {
+			return fmt.Errorf("having expression is invalid")
+		}
+		return validateHavingExpr(query, e.Expr)
+	case *stmt.BinaryExpr:
+		if e.Left == nil || e.Right == nil {
+			return fmt.Errorf("having expression is invalid")
+		}
+		switch e.Operator {
+		case stmt.AND, stmt.OR,
+			stmt.EQUAL, stmt.NOTEQUAL, stmt.LESS, stmt.LESSEQUAL, stmt.GREATER, stmt.GREATEREQUAL,
+			stmt.ADD, stmt.SUB, stmt.MUL, stmt.DIV:
+		default:
+			return fmt.Errorf("having not support binary operator: %s", stmt.BinaryOPString(e.Operator))
+		}
+		if err := validateHavingExpr(query, e.Left); err != nil {
+			return err
+		}
+		return validateHavingExpr(query, e.Right)
+	case *stmt.NumberLiteral:
+		return nil
+	default:
+		if _, ok := query.SelectItemOf(expr); !ok {
+			return fmt.Errorf("having field: %s not in select list", expr.Rewrite())
+		}
+		return nil
+	}
+}
+
 // validation tests data if invalid
 func (q *queryStmtParse) validation() error {
 	if q.err != nil {
@@ -118,6 +181,106 @@ func (q *queryStmtParse) visitGroupByKey(ctx *grammar.GroupByKeyContext) {
 	}
 }
 
+// visitFillOption visits when production fill option expression is entered
+func (q *queryStmtParse) visitFillOption(ctx *grammar.FillOptionContext) {
+	switch {
+	case ctx.T_NULL() != nil:
+		q.fill = stmt.NullFill
+	case ctx.T_PREVIOUS() != nil:
+		q.fill = stmt.PreviousFill
+	case ctx.L_INT() != nil || ctx.L_DEC() != nil:
+		val, err := strconv.ParseFloat(ctx.GetText(), 64)
+		if err != nil {
+			q.err = err
+			return
+		}
+		q.fill = stmt.NumberFill
+		q.fillValue = val
+	}
+}
+
+// visitSelectItemRef visits when production order by/having expression is entered,
+// after that, the fields refer to the select items
+func (q *queryStmtParse) visitSelectItemRef() {
+	q.refSelectItem = true
+	q.resetExprStack()
+}
+
+// visitSortField visits when production sort field expression is entered
+func (q *queryStmtParse) visitSortField(ctx *grammar.SortFieldContext) {
+	q.exprStack.Push(&stmt.OrderByExpr{Desc: len(ctx.AllT_DESC()) > 0})
+}
+
+// completeSortField completes a sort field expression for order by
+func (q *queryStmtParse) completeSortField() {
+	expr, ok := q.exprStack.Pop().(*stmt.OrderByExpr)
+	if ok {
+		q.orderBy = append(q.orderBy, expr)
+	}
+}
+
+// visitBoolExpr visits when production bool expression of having is entered
+func (q *queryStmtParse) visitBoolExpr(ctx *grammar.BoolExprContext) {
+	switch {
+	case ctx.T_OPEN_P() != nil:
+		q.exprStack.Push(&stmt.ParenExpr{})
+	case ctx.BoolExprLogicalOp() != nil:
+		logicalOp, ok := ctx.BoolExprLogicalOp().(*grammar.BoolExprLogicalOpContext)
+		if !ok {
+			return
+		}
+		if logicalOp.T_OR() != nil {
+			q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.OR})
+		} else {
+			q.exprStack.Push(&stmt.BinaryExpr{Operator: stmt.AND})
+		}
+	}
+}
+
+// completeBoolExpr completes a bool expression of having
+func (q *queryStmtParse) completeBoolExpr(ctx *grammar.BoolExprContext) {
+	if ctx.T_OPEN_P() == nil && ctx.BoolExprLogicalOp() == nil {
+		return
+	}
+	q.completeHavingExpr()
+}
+
+// visitBinaryExpr visits when production compare binary expression of having is entered
+func (q *queryStmtParse) visitBinaryExpr(ctx *grammar.BinaryExprContext) {
+	op := stmt.UNKNOWN
+	if binaryOpCtx, ok := ctx.BinaryOperator().(*grammar.BinaryOperatorContext); ok {
+		switch {
+		case binaryOpCtx.T_EQUAL() != nil:
+			op = stmt.EQUAL
+		case binaryOpCtx.T_NOTEQUAL() != nil || binaryOpCtx.T_NOTEQUAL2() != nil:
+			op = stmt.NOTEQUAL
+		case binaryOpCtx.T_LESS() != nil:
+			op = stmt.LESS
+		case binaryOpCtx.T_LESSEQUAL() != nil:
+			op = stmt.LESSEQUAL
+		case binaryOpCtx.T_GREATER() != nil:
+			op = stmt.GREATER
+		case binaryOpCtx.T_GREATEREQUAL() != nil:
+			op = stmt.GREATEREQUAL
+		}
+	}
+	q.exprStack.Push(&stmt.BinaryExpr{Operator: op})
+}
+
+// completeHavingExpr completes a sub expression of having,
+// sets it as parent's param, if no parent, it's the having expression.
+func (q *queryStmtParse) completeHavingExpr() {
+	expr, ok := q.exprStack.Pop().(stmt.Expr)
+	if !ok {
+		return
+	}
+	if q.exprStack.Empty() {
+		q.having = expr
+		return
+	}
+	q.setExprParam(expr)
+}
+
 // visitTimeRangeExpr visits when production timeRange expression is entered
 func (q *queryStmtParse) visitTimeRangeExpr(ctx *grammar.TimeRangeExprContext) {
 	timeExprCtxList := ctx.AllTimeExpr()
@@ -225,7 +388,7 @@ func (q *queryStmtParse) visitAlias(ctx *grammar.AliasContext) {
 	if len(q.selectItems) == 0 {
 		return
 	}
-	selectItem, ok := (q.selectItems[0]).(*stmt.SelectItem)
+	selectItem, ok := (q.selectItems[len(q.selectItems)-1]).(*stmt.SelectItem)
 	if ok {
 		selectItem.Alias = strutil.GetStringValue(ctx.Ident().GetText())
 	}
@@ -255,6 +418,26 @@ func (q *queryStmtParse) visitFuncName(ctx *grammar.FuncNameContext) {
 		callExpr.FuncType = function.Stddev
 	case ctx.T_HISTOGRAM() != nil:
 		callExpr.FuncType = function.Histogram
+	case ctx.T_QUANTILE() != nil:
+		callExpr.FuncType = function.Quantile
+	case ctx.T_RATE() != nil:
+		callExpr.FuncType = function.Rate
+	case ctx.T_IRATE() != nil:
+		callExpr.FuncType = function.IRate
+	case ctx.T_DERIVATIVE() != nil:
+		callExpr.FuncType = function.Derivative
+	case ctx.T_MOVING_AVERAGE() != nil:
+		callExpr.FuncType = function.MovingAverage
+	case ctx.T_ABS() != nil:
+		callExpr.FuncType = function.Abs
+	case ctx.T_LOG() != nil:
+		callExpr.FuncType = function.Log
+	case ctx.T_TIME_SHIFT() != nil:
+		callExpr.FuncType = function.TimeShift
+	case ctx.T_TOP() != nil:
+		callExpr.FuncType = function.Top
+	case ctx.T_BOTTOM() != nil:
+		callExpr.FuncType = function.Bottom
 	}
 }
 
@@ -282,7 +465,9 @@ func (q *queryStmtParse) visitExprAtom(ctx *grammar.ExprAtomContext) {
 		} else {
 			q.setExprParam(&stmt.FieldExpr{Name: val})
 		}
-		q.fieldNames[val] = struct{}{}
+		if !q.refSelectItem {
+			q.fieldNames[val] = struct{}{}
+		}
 	case ctx.DecNumber() != nil || ctx.IntNumber() != nil:
 		valStr := ""
 		switch {
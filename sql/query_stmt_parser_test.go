@@ -115,6 +115,51 @@ func TestSelectFuncItem(t *testing.T) {
 	assert.Equal(t, stmt.SelectItem{
 		Expr: &stmt.CallExpr{FuncType: function.Histogram, Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}}},
 	}, *selectItem)
+
+	sql = "select quantile(f, 0.99) as p99, QUANTILE(f,0.5), quantile from memory where quantile='a'"
+	q, err = Parse(sql)
+	query = q.(*stmt.Query)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(query.SelectItems))
+	selectItem = (query.SelectItems[0]).(*stmt.SelectItem)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.Quantile,
+			Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}, &stmt.NumberLiteral{Val: 0.99}}},
+		Alias: "p99",
+	}, *selectItem)
+	selectItem = (query.SelectItems[1]).(*stmt.SelectItem)
+	assert.Equal(t, function.Quantile, selectItem.Expr.(*stmt.CallExpr).FuncType)
+	// quantile is field name if not function call
+	selectItem = (query.SelectItems[2]).(*stmt.SelectItem)
+	assert.Equal(t, stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "quantile"}}, *selectItem)
+	assert.Equal(t, []string{"f", "quantile"}, query.FieldNames)
+
+	sql = "select rate(f), irate(f), derivative(f), moving_average(f, 5), abs(f), LOG(f), time_shift(f, 3600), " +
+		"top(sum(f), 3), bottom(f, 3), rate from memory"
+	q, err = Parse(sql)
+	assert.Nil(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, 10, len(query.SelectItems))
+	var funcTypes []function.FuncType
+	for _, item := range query.SelectItems[:9] {
+		funcTypes = append(funcTypes, item.(*stmt.SelectItem).Expr.(*stmt.CallExpr).FuncType)
+	}
+	assert.Equal(t, []function.FuncType{function.Rate, function.IRate, function.Derivative, function.MovingAverage,
+		function.Abs, function.Log, function.TimeShift, function.Top, function.Bottom}, funcTypes)
+	assert.Equal(t, stmt.SelectItem{
+		Expr: &stmt.CallExpr{FuncType: function.MovingAverage,
+			Params: []stmt.Expr{&stmt.FieldExpr{Name: "f"}, &stmt.NumberLiteral{Val: 5}}},
+	}, *(query.SelectItems[3]).(*stmt.SelectItem))
+	assert.Equal(t, "top(sum(f),3.00)", query.SelectItems[7].Rewrite())
+	// rate is field name if not function call
+	assert.Equal(t, stmt.SelectItem{Expr: &stmt.FieldExpr{Name: "rate"}}, *(query.SelectItems[9]).(*stmt.SelectItem))
+
+	// function keywords are non-reserved words
+	q, err = Parse("select log, top, time_shift from memory where moving_average='a'")
+	assert.Nil(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, []string{"log", "time_shift", "top"}, query.FieldNames)
+	assert.Equal(t, &stmt.EqualsExpr{Key: "moving_average", Value: "a"}, query.Condition)
 }
 
 func TestFieldExpression(t *testing.T) {
@@ -362,6 +407,115 @@ func TestGroupBy(t *testing.T) {
 	assert.Equal(t, "/data", query.GroupBy[1])
 }
 
+func TestFill(t *testing.T) {
+	sql := "select f from cpu group by host"
+	q, err := Parse(sql)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, stmt.NoFill, query.Fill)
+
+	sql = "select f from cpu group by host fill(null)"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, stmt.NullFill, query.Fill)
+
+	sql = "select f from cpu group by host fill(previous)"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, stmt.PreviousFill, query.Fill)
+
+	sql = "select f from cpu group by host fill(10)"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, stmt.NumberFill, query.Fill)
+	assert.Equal(t, 10.0, query.FillValue)
+
+	sql = "select f from cpu group by time(1m) fill(1.5)"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, stmt.NumberFill, query.Fill)
+	assert.Equal(t, 1.5, query.FillValue)
+}
+
+func TestOrderBy(t *testing.T) {
+	sql := "select sum(f) as s,max(g) from cpu group by host order by s desc,max(g) limit 10"
+	q, err := Parse(sql)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []string{"f", "g"}, query.FieldNames)
+	assert.Len(t, query.SelectItems, 2)
+	assert.Equal(t, "s", query.SelectItems[0].(*stmt.SelectItem).Alias)
+	assert.Equal(t, []stmt.Expr{
+		&stmt.OrderByExpr{Expr: &stmt.FieldExpr{Name: "s"}, Desc: true},
+		&stmt.OrderByExpr{Expr: &stmt.CallExpr{FuncType: function.Max, Params: []stmt.Expr{&stmt.FieldExpr{Name: "g"}}}},
+	}, query.OrderBy)
+	assert.Equal(t, 10, query.Limit)
+
+	sql = "select f from cpu order by f asc"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, []stmt.Expr{&stmt.OrderByExpr{Expr: &stmt.FieldExpr{Name: "f"}}}, query.OrderBy)
+
+	// order by field not in select list
+	sql = "select f from cpu order by g desc"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+}
+
+func TestHaving(t *testing.T) {
+	sql := "select sum(f) as s,max(g) from cpu group by host having s > 10 and (max(g)<=100 or s=1)"
+	q, err := Parse(sql)
+	assert.NoError(t, err)
+	query := q.(*stmt.Query)
+	assert.Equal(t, []string{"f", "g"}, query.FieldNames)
+	assert.Len(t, query.SelectItems, 2)
+	assert.Equal(t, &stmt.BinaryExpr{
+		Left: &stmt.BinaryExpr{
+			Left:     &stmt.FieldExpr{Name: "s"},
+			Operator: stmt.GREATER,
+			Right:    &stmt.NumberLiteral{Val: 10},
+		},
+		Operator: stmt.AND,
+		Right: &stmt.ParenExpr{
+			Expr: &stmt.BinaryExpr{
+				Left: &stmt.BinaryExpr{
+					Left:     &stmt.CallExpr{FuncType: function.Max, Params: []stmt.Expr{&stmt.FieldExpr{Name: "g"}}},
+					Operator: stmt.LESSEQUAL,
+					Right:    &stmt.NumberLiteral{Val: 100},
+				},
+				Operator: stmt.OR,
+				Right: &stmt.BinaryExpr{
+					Left:     &stmt.FieldExpr{Name: "s"},
+					Operator: stmt.EQUAL,
+					Right:    &stmt.NumberLiteral{Val: 1},
+				},
+			},
+		},
+	}, query.Having)
+
+	sql = "select f from cpu group by host fill(0) having f/2 != 1 order by f desc"
+	q, err = Parse(sql)
+	assert.NoError(t, err)
+	query = q.(*stmt.Query)
+	assert.Equal(t, stmt.NumberFill, query.Fill)
+	assert.Equal(t, "f/2.00!=1.00", query.Having.Rewrite())
+	assert.Len(t, query.OrderBy, 1)
+
+	// having field not in select list
+	sql = "select f from cpu group by host having g > 1"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+	// having not support like
+	sql = "select f from cpu group by host having f like 1"
+	_, err = Parse(sql)
+	assert.Error(t, err)
+}
+
 func TestEmptyCondition(t *testing.T) {
 	sql := "select f from cpu"
 	q, err := Parse(sql)
@@ -12,6 +12,13 @@ const (
 	MUL
 	DIV
 
+	EQUAL
+	NOTEQUAL
+	LESS
+	LESSEQUAL
+	GREATER
+	GREATEREQUAL
+
 	UNKNOWN
 )
 
@@ -30,6 +37,18 @@ func BinaryOPString(op BinaryOP) string {
 		return "*"
 	case DIV:
 		return "/"
+	case EQUAL:
+		return "="
+	case NOTEQUAL:
+		return "!="
+	case LESS:
+		return "<"
+	case LESSEQUAL:
+		return "<="
+	case GREATER:
+		return ">"
+	case GREATEREQUAL:
+		return ">="
 	default:
 		return "unknown"
 	}
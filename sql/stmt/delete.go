@@ -0,0 +1,56 @@
+package stmt
+
+import (
+	"encoding/json"
+
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+// Delete represents delete series statement,
+// the series of metric which match the tag filter condition will be deleted,
+// if condition is nil, deletes all series of metric.
+// if drop metric, drops the metric include all series and metadata.
+type Delete struct {
+	Namespace  string // namespace
+	MetricName string // like table name
+	Condition  Expr   // tag filter condition expression
+	DropMetric bool   // drop metric
+}
+
+// innerDelete represents a wrapper of delete for json encoding
+type innerDelete struct {
+	Namespace  string          `json:"namespace,omitempty"`
+	MetricName string          `json:"metricName,omitempty"`
+	Condition  json.RawMessage `json:"condition,omitempty"`
+	DropMetric bool            `json:"dropMetric,omitempty"`
+}
+
+// MarshalJSON returns json data of delete statement
+func (d *Delete) MarshalJSON() ([]byte, error) {
+	inner := innerDelete{
+		Namespace:  d.Namespace,
+		MetricName: d.MetricName,
+		Condition:  Marshal(d.Condition),
+		DropMetric: d.DropMetric,
+	}
+	return encoding.JSONMarshal(&inner), nil
+}
+
+// UnmarshalJSON parses json data to delete statement
+func (d *Delete) UnmarshalJSON(value []byte) error {
+	inner := innerDelete{}
+	if err := encoding.JSONUnmarshal(value, &inner); err != nil {
+		return err
+	}
+	if inner.Condition != nil {
+		condition, err := Unmarshal(inner.Condition)
+		if err != nil {
+			return err
+		}
+		d.Condition = condition
+	}
+	d.Namespace = inner.Namespace
+	d.MetricName = inner.MetricName
+	d.DropMetric = inner.DropMetric
+	return nil
+}
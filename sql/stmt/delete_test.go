@@ -0,0 +1,50 @@
+package stmt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/encoding"
+)
+
+func TestDelete_MarshalJSON(t *testing.T) {
+	deleteStmt := Delete{
+		Namespace:  "ns",
+		MetricName: "test",
+		Condition: &BinaryExpr{
+			Left:     &InExpr{Key: "ip", Values: []string{"1.1.1.1", "2.2.2.2"}},
+			Operator: AND,
+			Right:    &EqualsExpr{Key: "region", Value: "sh"},
+		},
+	}
+	data := encoding.JSONMarshal(&deleteStmt)
+	deleteStmt1 := Delete{}
+	err := encoding.JSONUnmarshal(data, &deleteStmt1)
+	assert.NoError(t, err)
+	assert.Equal(t, deleteStmt, deleteStmt1)
+
+	// without condition
+	deleteStmt = Delete{Namespace: "ns", MetricName: "test"}
+	data = encoding.JSONMarshal(&deleteStmt)
+	deleteStmt1 = Delete{}
+	err = encoding.JSONUnmarshal(data, &deleteStmt1)
+	assert.NoError(t, err)
+	assert.Equal(t, deleteStmt, deleteStmt1)
+
+	// drop metric
+	deleteStmt = Delete{Namespace: "ns", MetricName: "test", DropMetric: true}
+	data = encoding.JSONMarshal(&deleteStmt)
+	deleteStmt1 = Delete{}
+	err = encoding.JSONUnmarshal(data, &deleteStmt1)
+	assert.NoError(t, err)
+	assert.Equal(t, deleteStmt, deleteStmt1)
+}
+
+func TestDelete_Marshal_Fail(t *testing.T) {
+	deleteStmt := &Delete{}
+	err := deleteStmt.UnmarshalJSON([]byte{1, 2, 3})
+	assert.NotNil(t, err)
+	err = deleteStmt.UnmarshalJSON([]byte("{\"condition\":\"123\"}"))
+	assert.NotNil(t, err)
+}
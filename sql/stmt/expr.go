@@ -84,6 +84,20 @@ type innerBinaryExpr struct {
 	Operator BinaryOP        `json:"operator"`
 }
 
+// OrderByExpr represents an order by expression,
+// the expr refers to a select item by alias or rewrite string
+type OrderByExpr struct {
+	Expr Expr
+	Desc bool
+}
+
+// innerOrderByExpr represents inner wrapper of order by expr for json marshal
+type innerOrderByExpr struct {
+	Type string          `json:"type"`
+	Expr json.RawMessage `json:"expr"`
+	Desc bool            `json:"desc"`
+}
+
 // EqualsExpr represents an equals expression
 type EqualsExpr struct {
 	Key   string `json:"key"`
@@ -150,6 +164,14 @@ func (e *BinaryExpr) Rewrite() string {
 	return fmt.Sprintf("%s%s%s", e.Left.Rewrite(), BinaryOPString(e.Operator), e.Right.Rewrite())
 }
 
+// Rewrite rewrites the order by expr after parse
+func (e *OrderByExpr) Rewrite() string {
+	if e.Desc {
+		return fmt.Sprintf("%s desc", e.Expr.Rewrite())
+	}
+	return fmt.Sprintf("%s asc", e.Expr.Rewrite())
+}
+
 // Rewrite rewrites the not expr after parse
 func (e *NotExpr) Rewrite() string {
 	return fmt.Sprintf("not %s", e.Expr.Rewrite())
@@ -220,6 +242,13 @@ func Marshal(expr Expr) []byte {
 			Operator: e.Operator,
 		}
 		return encoding.JSONMarshal(&inner)
+	case *OrderByExpr:
+		inner := innerOrderByExpr{
+			Type: "orderBy",
+			Expr: Marshal(e.Expr),
+			Desc: e.Desc,
+		}
+		return encoding.JSONMarshal(&inner)
 	default:
 		return nil
 	}
@@ -257,6 +286,8 @@ func Unmarshal(value []byte) (Expr, error) {
 		return unmarshalSelectItem(value)
 	case "call":
 		return unmarshalCall(value)
+	case "orderBy":
+		return unmarshalOrderBy(value)
 	case "not":
 		e, err := Unmarshal(exprData.Expr)
 		if err != nil {
@@ -300,6 +331,20 @@ func unmarshalSelectItem(value []byte) (Expr, error) {
 	return &SelectItem{Alias: innerExpr.Alias, Expr: e}, nil
 }
 
+// unmarshalOrderBy parses value to order by expr
+func unmarshalOrderBy(value []byte) (Expr, error) {
+	innerExpr := innerOrderByExpr{}
+	err := encoding.JSONUnmarshal(value, &innerExpr)
+	if err != nil {
+		return nil, err
+	}
+	e, err := Unmarshal(innerExpr.Expr)
+	if err != nil {
+		return nil, err
+	}
+	return &OrderByExpr{Expr: e, Desc: innerExpr.Desc}, nil
+}
+
 // unmarshalBinary parses value to binary expr
 func unmarshalBinary(value []byte) (Expr, error) {
 	innerExpr := innerBinaryExpr{}
@@ -45,6 +45,10 @@ func TestExpr_Rewrite(t *testing.T) {
 	assert.Equal(t, "tagKey in ()", (&InExpr{Key: "tagKey"}).Rewrite())
 
 	assert.Equal(t, "tagKey=~Regexp", (&RegexExpr{Key: "tagKey", Regexp: "Regexp"}).Rewrite())
+
+	assert.Equal(t, "f desc", (&OrderByExpr{Expr: &FieldExpr{Name: "f"}, Desc: true}).Rewrite())
+	assert.Equal(t, "sum(f) asc",
+		(&OrderByExpr{Expr: &CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{Name: "f"}}}}).Rewrite())
 }
 
 func TestTagFilter(t *testing.T) {
@@ -80,6 +84,10 @@ func TestExpr_Unmarshal_Fail(t *testing.T) {
 	assert.NotNil(t, err)
 	_, err = unmarshalSelectItem([]byte("{\"type\":\"selectItem\",\"expr\":[\"213\"]}"))
 	assert.NotNil(t, err)
+	_, err = unmarshalOrderBy([]byte("123"))
+	assert.NotNil(t, err)
+	_, err = unmarshalOrderBy([]byte("{\"type\":\"orderBy\",\"expr\":[\"213\"]}"))
+	assert.NotNil(t, err)
 	_, err = unmarshalBinary([]byte("123"))
 	assert.NotNil(t, err)
 	_, err = unmarshalBinary([]byte("{\"type\":\"binary\",\"left\":\"123\"}"))
@@ -195,3 +203,14 @@ func TestBinaryExpr_Marshal(t *testing.T) {
 	e := exprData.(*BinaryExpr)
 	assert.Equal(t, *expr, *e)
 }
+
+func TestOrderByExpr_Marshal(t *testing.T) {
+	expr := &OrderByExpr{
+		Expr: &CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{Name: "f"}}},
+		Desc: true,
+	}
+	data := Marshal(expr)
+	exprData, _ := Unmarshal(data)
+	e := exprData.(*OrderByExpr)
+	assert.Equal(t, *expr, *e)
+}
@@ -7,6 +7,21 @@ import (
 	"github.com/lindb/lindb/pkg/timeutil"
 )
 
+// FillPolicy represents the policy for filling the missing points of time series
+type FillPolicy int
+
+// Defines all fill policies of group by clause
+const (
+	// NoFill means no fill option in query, the missing points are absent
+	NoFill FillPolicy = iota
+	// NullFill means fill(null), same as no fill, the missing points are absent
+	NullFill
+	// PreviousFill means fill(previous), the missing points are filled by the previous value
+	PreviousFill
+	// NumberFill means fill(<number>), the missing points are filled by the given number
+	NumberFill
+)
+
 // Query represents search statement
 type Query struct {
 	Explain     bool     //  need explain query execute stat
@@ -16,11 +31,16 @@ type Query struct {
 	FieldNames  []string // select field names
 	Condition   Expr     // tag filter condition expression
 
-	TimeRange timeutil.TimeRange // query time range
-	Interval  timeutil.Interval  // down sampling interval
+	TimeRange       timeutil.TimeRange // query time range
+	Interval        timeutil.Interval  // down sampling interval
+	ResultTimeRange timeutil.TimeRange // result time range, query time range is widened for time shift
 
-	GroupBy []string // group by tag keys
-	Limit   int      // num. of time series list for result
+	GroupBy   []string   // group by tag keys
+	Fill      FillPolicy // fill policy for the missing points of time series
+	FillValue float64    // fill value if fill policy is number
+	Having    Expr       // having condition expression, filters time series of result
+	OrderBy   []Expr     // order by expression list, sorts time series of result
+	Limit     int        // num. of time series list for result
 }
 
 // HasGroupBy returns whether query has group by tag keys
@@ -28,6 +48,22 @@ func (q *Query) HasGroupBy() bool {
 	return len(q.GroupBy) > 0
 }
 
+// SelectItemOf returns the select item which the expr of order by/having refers to,
+// the expr matches the select item by alias or rewrite string.
+func (q *Query) SelectItemOf(expr Expr) (*SelectItem, bool) {
+	name := expr.Rewrite()
+	for _, item := range q.SelectItems {
+		selectItem, ok := item.(*SelectItem)
+		if !ok {
+			continue
+		}
+		if (len(selectItem.Alias) > 0 && selectItem.Alias == name) || selectItem.Expr.Rewrite() == name {
+			return selectItem, true
+		}
+	}
+	return nil, false
+}
+
 // innerQuery represents a wrapper of query for json encoding
 type innerQuery struct {
 	Explain     bool              `json:"Explain,omitempty"`
@@ -37,29 +73,41 @@ type innerQuery struct {
 	FieldNames  []string          `json:"fieldNames,omitempty"`
 	Condition   json.RawMessage   `json:"condition,omitempty"`
 
-	TimeRange timeutil.TimeRange `json:"timeRange,omitempty"`
-	Interval  timeutil.Interval  `json:"interval,omitempty"`
+	TimeRange       timeutil.TimeRange `json:"timeRange,omitempty"`
+	Interval        timeutil.Interval  `json:"interval,omitempty"`
+	ResultTimeRange timeutil.TimeRange `json:"resultTimeRange,omitempty"`
 
-	GroupBy []string `json:"groupBy,omitempty"`
-	Limit   int      `json:"limit,omitempty"`
+	GroupBy   []string          `json:"groupBy,omitempty"`
+	Fill      FillPolicy        `json:"fill,omitempty"`
+	FillValue float64           `json:"fillValue,omitempty"`
+	Having    json.RawMessage   `json:"having,omitempty"`
+	OrderBy   []json.RawMessage `json:"orderBy,omitempty"`
+	Limit     int               `json:"limit,omitempty"`
 }
 
 // MarshalJSON returns json data of query
 func (q *Query) MarshalJSON() ([]byte, error) {
 	inner := innerQuery{
-		Explain:    q.Explain,
-		MetricName: q.MetricName,
-		Namespace:  q.Namespace,
-		Condition:  Marshal(q.Condition),
-		FieldNames: q.FieldNames,
-		TimeRange:  q.TimeRange,
-		Interval:   q.Interval,
-		GroupBy:    q.GroupBy,
-		Limit:      q.Limit,
+		Explain:         q.Explain,
+		MetricName:      q.MetricName,
+		Namespace:       q.Namespace,
+		Condition:       Marshal(q.Condition),
+		FieldNames:      q.FieldNames,
+		TimeRange:       q.TimeRange,
+		Interval:        q.Interval,
+		ResultTimeRange: q.ResultTimeRange,
+		GroupBy:         q.GroupBy,
+		Fill:            q.Fill,
+		FillValue:       q.FillValue,
+		Having:          Marshal(q.Having),
+		Limit:           q.Limit,
 	}
 	for _, item := range q.SelectItems {
 		inner.SelectItems = append(inner.SelectItems, Marshal(item))
 	}
+	for _, item := range q.OrderBy {
+		inner.OrderBy = append(inner.OrderBy, Marshal(item))
+	}
 	return encoding.JSONMarshal(&inner), nil
 }
 
@@ -76,6 +124,13 @@ func (q *Query) UnmarshalJSON(value []byte) error {
 		}
 		q.Condition = condition
 	}
+	if inner.Having != nil {
+		having, err := Unmarshal(inner.Having)
+		if err != nil {
+			return err
+		}
+		q.Having = having
+	}
 	var selectItems []Expr
 	for _, item := range inner.SelectItems {
 		selectItem, err := Unmarshal(item)
@@ -84,6 +139,14 @@ func (q *Query) UnmarshalJSON(value []byte) error {
 		}
 		selectItems = append(selectItems, selectItem)
 	}
+	var orderBy []Expr
+	for _, item := range inner.OrderBy {
+		orderByExpr, err := Unmarshal(item)
+		if err != nil {
+			return err
+		}
+		orderBy = append(orderBy, orderByExpr)
+	}
 	q.Explain = inner.Explain
 	q.MetricName = inner.MetricName
 	q.Namespace = inner.Namespace
@@ -91,7 +154,11 @@ func (q *Query) UnmarshalJSON(value []byte) error {
 	q.FieldNames = inner.FieldNames
 	q.TimeRange = inner.TimeRange
 	q.Interval = inner.Interval
+	q.ResultTimeRange = inner.ResultTimeRange
 	q.GroupBy = inner.GroupBy
+	q.Fill = inner.Fill
+	q.FillValue = inner.FillValue
+	q.OrderBy = orderBy
 	q.Limit = inner.Limit
 	return nil
 }
@@ -0,0 +1,12 @@
+package stmt
+
+// ShowQueries represents show running queries statement,
+// lists the running query jobs of current broker.
+type ShowQueries struct {
+}
+
+// KillQuery represents kill running query statement,
+// cancels the running query job by job id.
+type KillQuery struct {
+	JobID int64 // job id of running query
+}
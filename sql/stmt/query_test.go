@@ -46,10 +46,19 @@ func TestQuery_Marshal(t *testing.T) {
 				Right:    &EqualsExpr{Key: "path", Value: "/home"},
 			}},
 		},
-		TimeRange: timeutil.TimeRange{Start: 10, End: 30},
-		Interval:  1000,
-		GroupBy:   []string{"a", "b", "c"},
-		Limit:     100,
+		TimeRange:       timeutil.TimeRange{Start: 10, End: 30},
+		Interval:        1000,
+		ResultTimeRange: timeutil.TimeRange{Start: 20, End: 30},
+		GroupBy:         []string{"a", "b", "c"},
+		Fill:            NumberFill,
+		FillValue:       1.5,
+		Having: &BinaryExpr{
+			Left:     &FieldExpr{Name: "a"},
+			Operator: GREATER,
+			Right:    &NumberLiteral{Val: 10},
+		},
+		OrderBy: []Expr{&OrderByExpr{Expr: &FieldExpr{Name: "b"}, Desc: true}},
+		Limit:   100,
 	}
 
 	data := encoding.JSONMarshal(&query)
@@ -69,3 +78,32 @@ func TestQuery_Marshal_Fail(t *testing.T) {
 	err = query.UnmarshalJSON([]byte("{\"selectItems\":[\"123\"]}"))
 	assert.NotNil(t, err)
 }
+
+func TestQuery_Unmarshal_Fail(t *testing.T) {
+	query := Query{}
+	err := encoding.JSONUnmarshal([]byte("{\"having\":{\"type\":\"unknown\"}}"), &query)
+	assert.Error(t, err)
+	err = encoding.JSONUnmarshal([]byte("{\"orderBy\":[{\"type\":\"unknown\"}]}"), &query)
+	assert.Error(t, err)
+}
+
+func TestQuery_SelectItemOf(t *testing.T) {
+	sumExpr := &CallExpr{FuncType: function.Sum, Params: []Expr{&FieldExpr{Name: "f"}}}
+	query := Query{
+		SelectItems: []Expr{
+			&SelectItem{Expr: &FieldExpr{Name: "a"}},
+			&SelectItem{Expr: sumExpr, Alias: "s"},
+			&FieldExpr{Name: "c"},
+		},
+	}
+	item, ok := query.SelectItemOf(&FieldExpr{Name: "a"})
+	assert.True(t, ok)
+	assert.Equal(t, "a", item.Rewrite())
+	item, ok = query.SelectItemOf(&FieldExpr{Name: "s"})
+	assert.True(t, ok)
+	assert.Equal(t, sumExpr, item.Expr)
+	_, ok = query.SelectItemOf(sumExpr)
+	assert.True(t, ok)
+	_, ok = query.SelectItemOf(&FieldExpr{Name: "c"})
+	assert.False(t, ok)
+}
@@ -0,0 +1,168 @@
+package admin
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+)
+
+var adminLogger = logger.GetLogger("storage", "adminAPI")
+
+// BackupAPI represents the backup/restore api of databases in storage node,
+// the paths of backup are resolved under the backup root dir of storage node.
+type BackupAPI struct {
+	backupDir      string
+	node           models.Node
+	repo           state.Repository
+	storageService service.StorageService
+}
+
+// NewBackupAPI creates backup api
+func NewBackupAPI(backupDir string, node models.Node, repo state.Repository,
+	storageService service.StorageService,
+) *BackupAPI {
+	return &BackupAPI{
+		backupDir:      backupDir,
+		node:           node,
+		repo:           repo,
+		storageService: storageService,
+	}
+}
+
+// Backup backups the database into target path under backup dir, responses the backup manifest
+func (b *BackupAPI) Backup(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	path, err := api.GetParamsFromRequest("path", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	path, err = ResolveBackupPath(b.backupDir, path)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	manifest, err := b.storageService.BackupDatabase(databaseName, path)
+	if err != nil {
+		adminLogger.Error("backup database error",
+			logger.String("db", databaseName), logger.String("path", path), logger.Error(err))
+		api.Error(w, err)
+		return
+	}
+	api.OK(w, manifest)
+}
+
+// Restore restores the backup under backup dir as a new database, then registers it to master,
+// shard mapping is optional, e.g. 1:3,2:4 means restoring shard 1 as shard 3 and shard 2 as shard 4.
+func (b *BackupAPI) Restore(w http.ResponseWriter, r *http.Request) {
+	path, err := api.GetParamsFromRequest("path", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	path, err = ResolveBackupPath(b.backupDir, path)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	databaseName, err := api.GetParamsFromRequest("db", r, "", false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	mapping, err := api.GetParamsFromRequest("shardMapping", r, "", false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	shardMapping, err := ParseShardMapping(mapping)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	db, err := b.storageService.RestoreDatabase(path, databaseName, shardMapping)
+	if err != nil {
+		adminLogger.Error("restore database error",
+			logger.String("db", databaseName), logger.String("path", path), logger.Error(err))
+		api.Error(w, err)
+		return
+	}
+	if err := b.register(r.Context(), db); err != nil {
+		adminLogger.Error("register restored database error",
+			logger.String("db", db.Name()), logger.String("path", path), logger.Error(err))
+		api.Error(w, fmt.Errorf("database[%s] is restored, but register to master error: %s", db.Name(), err))
+		return
+	}
+	api.OK(w, db.Name())
+}
+
+// register puts the restored database into state repo of storage cluster,
+// master registers the database config and shard assignment when discovering it.
+func (b *BackupAPI) register(ctx context.Context, db tsdb.Database) error {
+	restored := &models.RestoredDatabase{
+		Name:     db.Name(),
+		ShardIDs: db.ShardIDs(),
+		Option:   db.GetOption(),
+		Node:     b.node,
+	}
+	return b.repo.Put(ctx, constants.GetRestoredDatabasePath(db.Name()), encoding.JSONMarshal(restored))
+}
+
+// ResolveBackupPath resolves the path of backup under backup dir, relative path is joined with backup dir,
+// returns err if the path is outside backup dir.
+func ResolveBackupPath(backupDir, path string) (string, error) {
+	root, err := filepath.Abs(backupDir)
+	if err != nil {
+		return "", err
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	path = filepath.Clean(path)
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path[%s] is not under backup dir[%s]", path, root)
+	}
+	return path, nil
+}
+
+// ParseShardMapping parses the shard mapping like 1:3,2:4, returns nil if mapping is empty
+func ParseShardMapping(mapping string) (map[int32]int32, error) {
+	mapping = strings.TrimSpace(mapping)
+	if mapping == "" {
+		return nil, nil
+	}
+	result := make(map[int32]int32)
+	for _, pair := range strings.Split(mapping, ",") {
+		ids := strings.Split(strings.TrimSpace(pair), ":")
+		if len(ids) != 2 {
+			return nil, fmt.Errorf("invalid shard mapping: %s", pair)
+		}
+		source, err := strconv.ParseInt(strings.TrimSpace(ids[0]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source shard of mapping: %s", pair)
+		}
+		target, err := strconv.ParseInt(strings.TrimSpace(ids[1]), 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target shard of mapping: %s", pair)
+		}
+		result[int32(source)] = int32(target)
+	}
+	return result, nil
+}
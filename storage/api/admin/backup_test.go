@@ -0,0 +1,167 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/state"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+)
+
+func TestBackupAPI_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageService := service.NewMockStorageService(ctrl)
+	backupAPI := NewBackupAPI("/tmp/lindb_backup", models.Node{}, nil, storageService)
+	// case 1: no database name
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/backup?path=backup",
+		HandlerFunc:    backupAPI.Backup,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// case 2: no backup path
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/backup?db=test",
+		HandlerFunc:    backupAPI.Backup,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// case 3: backup path outside backup dir
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/backup?db=test&path=../backup",
+		HandlerFunc:    backupAPI.Backup,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// case 4: backup err
+	storageService.EXPECT().BackupDatabase("test", "/tmp/lindb_backup/backup").Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/backup?db=test&path=backup",
+		HandlerFunc:    backupAPI.Backup,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// case 5: backup successfully
+	manifest := &tsdb.BackupManifest{Database: "test", ShardIDs: []int32{1, 2}}
+	storageService.EXPECT().BackupDatabase("test", "/tmp/lindb_backup/backup").Return(manifest, nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/backup?db=test&path=/tmp/lindb_backup/backup",
+		HandlerFunc:    backupAPI.Backup,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: manifest,
+	})
+}
+
+func TestBackupAPI_Restore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageService := service.NewMockStorageService(ctrl)
+	repo := state.NewMockRepository(ctrl)
+	node := models.Node{IP: "1.1.1.1", Port: 2891}
+	backupAPI := NewBackupAPI("/tmp/lindb_backup", node, repo, storageService)
+	// case 1: no backup path
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/restore?db=test",
+		HandlerFunc:    backupAPI.Restore,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// case 2: backup path outside backup dir
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/restore?path=/tmp/backup",
+		HandlerFunc:    backupAPI.Restore,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// case 3: invalid shard mapping
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/restore?path=backup&shardMapping=1-2",
+		HandlerFunc:    backupAPI.Restore,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// case 4: restore err
+	storageService.EXPECT().RestoreDatabase("/tmp/lindb_backup/backup", "", map[int32]int32(nil)).
+		Return(nil, fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/restore?path=backup",
+		HandlerFunc:    backupAPI.Restore,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	db := tsdb.NewMockDatabase(ctrl)
+	db.EXPECT().Name().Return("test2").AnyTimes()
+	db.EXPECT().ShardIDs().Return([]int32{3, 4}).AnyTimes()
+	db.EXPECT().GetOption().Return(option.DatabaseOption{Interval: "10s"}).AnyTimes()
+	storageService.EXPECT().RestoreDatabase("/tmp/lindb_backup/backup", "test2", map[int32]int32{1: 3, 2: 4}).
+		Return(db, nil).Times(2)
+	// case 5: register restored database err
+	repo.EXPECT().Put(gomock.Any(), constants.GetRestoredDatabasePath("test2"), gomock.Any()).Return(fmt.Errorf("err"))
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/restore?path=backup&db=test2&shardMapping=1:3,2:4",
+		HandlerFunc:    backupAPI.Restore,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// case 6: restore successfully
+	repo.EXPECT().Put(gomock.Any(), constants.GetRestoredDatabasePath("test2"),
+		encoding.JSONMarshal(&models.RestoredDatabase{
+			Name:     "test2",
+			ShardIDs: []int32{3, 4},
+			Option:   option.DatabaseOption{Interval: "10s"},
+			Node:     node,
+		})).Return(nil)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/restore?path=backup&db=test2&shardMapping=1:3,2:4",
+		HandlerFunc:    backupAPI.Restore,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: "test2",
+	})
+}
+
+func TestResolveBackupPath(t *testing.T) {
+	path, err := ResolveBackupPath("/tmp/backup", "db/20200101")
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/backup/db/20200101", path)
+	path, err = ResolveBackupPath("/tmp/backup", "/tmp/backup/db/../db2")
+	assert.NoError(t, err)
+	assert.Equal(t, "/tmp/backup/db2", path)
+	_, err = ResolveBackupPath("/tmp/backup", "../db")
+	assert.Error(t, err)
+	_, err = ResolveBackupPath("/tmp/backup", "/tmp/backup")
+	assert.Error(t, err)
+	_, err = ResolveBackupPath("/tmp/backup", "/tmp/backup2/db")
+	assert.Error(t, err)
+	_, err = ResolveBackupPath("/tmp/backup", "/etc")
+	assert.Error(t, err)
+}
+
+func TestParseShardMapping(t *testing.T) {
+	mapping, err := ParseShardMapping("")
+	assert.NoError(t, err)
+	assert.Nil(t, mapping)
+	mapping, err = ParseShardMapping(" 1:3, 2 : 4 ")
+	assert.NoError(t, err)
+	assert.Equal(t, map[int32]int32{1: 3, 2: 4}, mapping)
+	_, err = ParseShardMapping("1:3,2")
+	assert.Error(t, err)
+	_, err = ParseShardMapping("a:3")
+	assert.Error(t, err)
+	_, err = ParseShardMapping("1:b")
+	assert.Error(t, err)
+}
@@ -0,0 +1,86 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/service"
+)
+
+// CompactAPI represents the compaction api of storage node, which triggers full compaction, pauses/resumes compaction
+type CompactAPI struct {
+	storageService service.StorageService
+	scheduler      kv.CompactScheduler
+}
+
+// NewCompactAPI creates compaction api
+func NewCompactAPI(storageService service.StorageService, scheduler kv.CompactScheduler) *CompactAPI {
+	return &CompactAPI{
+		storageService: storageService,
+		scheduler:      scheduler,
+	}
+}
+
+// Compact triggers full compaction of database in background, shard and family are optional,
+// compacts all shards/families of database if not set.
+func (c *CompactAPI) Compact(w http.ResponseWriter, r *http.Request) {
+	databaseName, err := api.GetParamsFromRequest("db", r, "", true)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	shard, err := api.GetParamsFromRequest("shard", r, "", false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	familyName, err := api.GetParamsFromRequest("family", r, "", false)
+	if err != nil {
+		api.Error(w, err)
+		return
+	}
+	if shard == "" {
+		db, ok := c.storageService.GetDatabase(databaseName)
+		if !ok {
+			api.Error(w, fmt.Errorf("database[%s] not found", databaseName))
+			return
+		}
+		db.Compact(familyName)
+	} else {
+		shardID, err := strconv.ParseInt(shard, 10, 32)
+		if err != nil {
+			api.Error(w, fmt.Errorf("invalid shard id: %s", shard))
+			return
+		}
+		s, ok := c.storageService.GetShard(databaseName, int32(shardID))
+		if !ok {
+			api.Error(w, fmt.Errorf("shard[%d] of database[%s] not found", shardID, databaseName))
+			return
+		}
+		s.Compact(familyName)
+	}
+	adminLogger.Info("trigger full compaction",
+		logger.String("db", databaseName), logger.String("shard", shard), logger.String("family", familyName))
+	api.OK(w, c.scheduler.State())
+}
+
+// Pause pauses all compaction jobs of storage node, the running jobs aren't interrupted
+func (c *CompactAPI) Pause(w http.ResponseWriter, r *http.Request) {
+	c.scheduler.Pause()
+	api.OK(w, c.scheduler.State())
+}
+
+// Resume resumes the compaction jobs of storage node
+func (c *CompactAPI) Resume(w http.ResponseWriter, r *http.Request) {
+	c.scheduler.Resume()
+	api.OK(w, c.scheduler.State())
+}
+
+// State responses the state of compaction scheduler
+func (c *CompactAPI) State(w http.ResponseWriter, r *http.Request) {
+	api.OK(w, c.scheduler.State())
+}
@@ -0,0 +1,113 @@
+package admin
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/mock"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+)
+
+func TestCompactAPI_Compact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	storageService := service.NewMockStorageService(ctrl)
+	scheduler := kv.NewMockCompactScheduler(ctrl)
+	state := kv.CompactSchedulerState{Concurrency: 2, Pending: 1}
+	scheduler.EXPECT().State().Return(state).AnyTimes()
+	compactAPI := NewCompactAPI(storageService, scheduler)
+	// case 1: no database name
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/compact",
+		HandlerFunc:    compactAPI.Compact,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// case 2: database not found
+	storageService.EXPECT().GetDatabase("test").Return(nil, false)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/compact?db=test",
+		HandlerFunc:    compactAPI.Compact,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// case 3: compact database
+	db := tsdb.NewMockDatabase(ctrl)
+	storageService.EXPECT().GetDatabase("test").Return(db, true)
+	db.EXPECT().Compact("f")
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/compact?db=test&family=f",
+		HandlerFunc:    compactAPI.Compact,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: state,
+	})
+	// case 4: invalid shard id
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/compact?db=test&shard=a",
+		HandlerFunc:    compactAPI.Compact,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// case 5: shard not found
+	storageService.EXPECT().GetShard("test", int32(1)).Return(nil, false)
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/compact?db=test&shard=1",
+		HandlerFunc:    compactAPI.Compact,
+		ExpectHTTPCode: http.StatusInternalServerError,
+	})
+	// case 6: compact shard
+	shard := tsdb.NewMockShard(ctrl)
+	storageService.EXPECT().GetShard("test", int32(1)).Return(shard, true)
+	shard.EXPECT().Compact("")
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/database/compact?db=test&shard=1",
+		HandlerFunc:    compactAPI.Compact,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: state,
+	})
+}
+
+func TestCompactAPI_PauseAndResume(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	scheduler := kv.NewMockCompactScheduler(ctrl)
+	compactAPI := NewCompactAPI(nil, scheduler)
+	// pause
+	scheduler.EXPECT().Pause()
+	scheduler.EXPECT().State().Return(kv.CompactSchedulerState{Paused: true})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/compaction/pause",
+		HandlerFunc:    compactAPI.Pause,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: kv.CompactSchedulerState{Paused: true},
+	})
+	// resume
+	scheduler.EXPECT().Resume()
+	scheduler.EXPECT().State().Return(kv.CompactSchedulerState{})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodPost,
+		URL:            "/compaction/resume",
+		HandlerFunc:    compactAPI.Resume,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: kv.CompactSchedulerState{},
+	})
+	// state
+	scheduler.EXPECT().State().Return(kv.CompactSchedulerState{Running: 1})
+	mock.DoRequest(t, &mock.HTTPHandler{
+		Method:         http.MethodGet,
+		URL:            "/compaction/state",
+		HandlerFunc:    compactAPI.State,
+		ExpectHTTPCode: http.StatusOK,
+		ExpectResponse: kv.CompactSchedulerState{Running: 1},
+	})
+}
@@ -0,0 +1,29 @@
+package admin
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+
+	"github.com/lindb/lindb/broker/api"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+// LocalOnly is the middleware which only allows the requests from loopback address,
+// because the admin api of storage node operates the local files without authentication.
+func LocalOnly(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		host, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			host = r.RemoteAddr
+		}
+		ip := net.ParseIP(host)
+		if ip == nil || !ip.IsLoopback() {
+			adminLogger.Warn("reject admin request from remote address",
+				logger.String("remote", r.RemoteAddr), logger.String("url", r.URL.Path))
+			api.Forbidden(w, fmt.Errorf("admin api only allows request from localhost"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,31 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalOnly(t *testing.T) {
+	handler := LocalOnly(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	cases := []struct {
+		remoteAddr string
+		code       int
+	}{
+		{remoteAddr: "127.0.0.1:1234", code: http.StatusOK},
+		{remoteAddr: "[::1]:1234", code: http.StatusOK},
+		{remoteAddr: "10.0.0.1:1234", code: http.StatusForbidden},
+		{remoteAddr: "unknown", code: http.StatusForbidden},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodPost, "/database/backup", nil)
+		req.RemoteAddr = c.remoteAddr
+		resp := httptest.NewRecorder()
+		handler.ServeHTTP(resp, req)
+		assert.Equal(t, c.code, resp.Code, c.remoteAddr)
+	}
+}
@@ -0,0 +1,325 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/rpc"
+	"github.com/lindb/lindb/rpc/proto/transfer"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+)
+
+// transferChunkSize is the max size of file chunk sent in one response
+const transferChunkSize = 1024 * 1024
+
+// for testing
+var (
+	jsonMarshalFunc = json.Marshal
+)
+
+// fileSender represents the stream which sends the file chunks
+type fileSender interface {
+	Send(*transfer.PullResponse) error
+}
+
+// fileReceiver represents the stream which receives the file chunks
+type fileReceiver interface {
+	Recv() (*transfer.PullResponse, error)
+}
+
+// Transfer implements the transfer service, which streams the files of shards to the node building new replica,
+// also digests/repairs the data families of shard for replica consistency.
+type Transfer struct {
+	fct            rpc.ClientStreamFactory
+	storageService service.StorageService
+	tempDir        string
+	logger         *logger.Logger
+}
+
+// NewTransfer returns a new Transfer, the shards are backed up into temp dir before sending.
+func NewTransfer(fct rpc.ClientStreamFactory, storageService service.StorageService, tempDir string) *Transfer {
+	return &Transfer{
+		fct:            fct,
+		storageService: storageService,
+		tempDir:        tempDir,
+		logger:         logger.GetLogger("storage", "Transfer"),
+	}
+}
+
+// Pull backups metadata and the shards of database with replica sequences into temp dir,
+// then streams the files of backup, the backup is removed after all files sent.
+func (t *Transfer) Pull(req *transfer.PullRequest, stream transfer.TransferService_PullServer) error {
+	db, ok := t.storageService.GetDatabase(req.Database)
+	if !ok {
+		return status.Errorf(codes.NotFound, "database %s not exists", req.Database)
+	}
+	backupPath := tempPath(t.tempDir, req.Database)
+	defer removeTempPath(backupPath)
+
+	if _, err := db.BackupShards(backupPath, req.ShardIDs); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := sendFiles(backupPath, stream); err != nil {
+		t.logger.Error("send files of shards error", logger.String("db", req.Database),
+			logger.Any("shardIDs", req.ShardIDs), logger.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+	t.logger.Info("send files of shards successfully", logger.String("db", req.Database),
+		logger.Any("shardIDs", req.ShardIDs))
+	return nil
+}
+
+// Digest returns the json encoded digests of data families in shard which overlap the time range.
+func (t *Transfer) Digest(ctx context.Context, req *transfer.DigestRequest) (*transfer.DigestResponse, error) {
+	shard, ok := t.storageService.GetShard(req.Database, req.ShardID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "shard %s/%d not exists", req.Database, req.ShardID)
+	}
+	digests, err := shard.Digest(timeutil.TimeRange{Start: req.StartTime, End: req.EndTime})
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	payload, err := jsonMarshalFunc(digests)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &transfer.DigestResponse{Payload: payload}, nil
+}
+
+// PullFamily backups the files of data family in shard into temp dir,
+// then streams the files of backup, the backup is removed after all files sent.
+func (t *Transfer) PullFamily(req *transfer.PullFamilyRequest, stream transfer.TransferService_PullFamilyServer) error {
+	shard, ok := t.storageService.GetShard(req.Database, req.ShardID)
+	if !ok {
+		return status.Errorf(codes.NotFound, "shard %s/%d not exists", req.Database, req.ShardID)
+	}
+	backupPath := tempPath(t.tempDir, req.Database)
+	defer removeTempPath(backupPath)
+
+	if err := shard.BackupFamily(req.FamilyTime, backupPath); err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	if err := sendFiles(backupPath, stream); err != nil {
+		t.logger.Error("send files of family error", logger.String("db", req.Database),
+			logger.Int32("shardID", req.ShardID), logger.Int64("family", req.FamilyTime), logger.Error(err))
+		return status.Error(codes.Internal, err.Error())
+	}
+	return nil
+}
+
+// Repair pulls the data families of shard from source replica one by one,
+// then replaces the files of local families, stops repairing if any family fails.
+func (t *Transfer) Repair(ctx context.Context, req *transfer.RepairRequest) (*transfer.RepairResponse, error) {
+	shard, ok := t.storageService.GetShard(req.Database, req.ShardID)
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "shard %s/%d not exists", req.Database, req.ShardID)
+	}
+	source := models.Node{IP: req.SourceIP, Port: uint16(req.SourcePort)}
+	cli, err := t.fct.CreateTransferServiceClient(source)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, err.Error())
+	}
+	for _, familyTime := range req.FamilyTimes {
+		if err := t.repairFamily(ctx, cli, shard, req, familyTime); err != nil {
+			t.logger.Error("repair family of shard error", logger.String("db", req.Database),
+				logger.Int32("shardID", req.ShardID), logger.Int64("family", familyTime),
+				logger.String("source", source.Indicator()), logger.Error(err))
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		t.logger.Info("repair family of shard successfully", logger.String("db", req.Database),
+			logger.Int32("shardID", req.ShardID), logger.Int64("family", familyTime),
+			logger.String("source", source.Indicator()))
+	}
+	return &transfer.RepairResponse{}, nil
+}
+
+// repairFamily pulls the files of data family from source replica, then restores the family of shard
+func (t *Transfer) repairFamily(ctx context.Context, cli transfer.TransferServiceClient,
+	shard tsdb.Shard, req *transfer.RepairRequest, familyTime int64) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := cli.PullFamily(rpc.CreateOutgoingContextWithNode(ctx, t.fct.LogicNode()), &transfer.PullFamilyRequest{
+		Database:   req.Database,
+		ShardID:    req.ShardID,
+		FamilyTime: familyTime,
+	})
+	if err != nil {
+		return err
+	}
+	backupPath := tempPath(t.tempDir, req.Database)
+	defer removeTempPath(backupPath)
+
+	if err := receiveFiles(backupPath, stream); err != nil {
+		return err
+	}
+	return shard.RestoreFamily(familyTime, backupPath)
+}
+
+// sendFiles sends all files under the root path in chunks, the empty file is sent as a chunk without data.
+func sendFiles(root string, stream fileSender) error {
+	buf := make([]byte, transferChunkSize)
+	return filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		fileName, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+		}()
+		sent := false
+		for {
+			n, err := f.Read(buf)
+			if n > 0 || (err == io.EOF && !sent) {
+				if err := stream.Send(&transfer.PullResponse{FileName: fileName, Data: buf[:n]}); err != nil {
+					return err
+				}
+				sent = true
+			}
+			if err == io.EOF {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+		}
+	})
+}
+
+// ShardImporter implements the shard importer of task executor,
+// pulls the shards from source node, then restores them as new replica.
+type ShardImporter struct {
+	fct            rpc.ClientStreamFactory
+	storageService service.StorageService
+	tempDir        string
+	logger         *logger.Logger
+}
+
+// NewShardImporter returns a new shard importer, the received files are written into temp dir before restoring.
+func NewShardImporter(fct rpc.ClientStreamFactory, storageService service.StorageService, tempDir string) *ShardImporter {
+	return &ShardImporter{
+		fct:            fct,
+		storageService: storageService,
+		tempDir:        tempDir,
+		logger:         logger.GetLogger("storage", "ShardImporter"),
+	}
+}
+
+// Import pulls the shards of database from source node, then restores them in current node.
+// NOTICE: the id of metric/tag metadata is allocated by each node, so the database cannot exist in current node,
+// all shards of a move are pulled from one source node in one import, the database imported is dropped by
+// coordinator if moving fail, importing is skipped if the database exists with all the shards(restored by previous try).
+func (i *ShardImporter) Import(ctx context.Context, source models.Node, databaseName string, shardIDs []int32) error {
+	if db, ok := i.storageService.GetDatabase(databaseName); ok {
+		for _, shardID := range shardIDs {
+			if _, ok := db.GetShard(shardID); !ok {
+				return fmt.Errorf("database[%s] already exists without shard[%d]", databaseName, shardID)
+			}
+		}
+		return nil
+	}
+	cli, err := i.fct.CreateTransferServiceClient(source)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := cli.Pull(rpc.CreateOutgoingContextWithNode(ctx, i.fct.LogicNode()), &transfer.PullRequest{
+		Database: databaseName,
+		ShardIDs: shardIDs,
+	})
+	if err != nil {
+		return err
+	}
+	backupPath := tempPath(i.tempDir, databaseName)
+	defer removeTempPath(backupPath)
+
+	if err := receiveFiles(backupPath, stream); err != nil {
+		return err
+	}
+	if _, err := i.storageService.RestoreDatabase(backupPath, databaseName, nil); err != nil {
+		return err
+	}
+	i.logger.Info("import shards successfully", logger.String("db", databaseName),
+		logger.Any("shardIDs", shardIDs), logger.String("source", source.Indicator()))
+	return nil
+}
+
+// receiveFiles writes the received file chunks under the root path
+func receiveFiles(root string, stream fileReceiver) error {
+	var (
+		f        *os.File
+		fileName string
+	)
+	closeFile := func() error {
+		if f == nil {
+			return nil
+		}
+		err := f.Close()
+		f = nil
+		return err
+	}
+	defer func() {
+		_ = closeFile()
+	}()
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return closeFile()
+		}
+		if err != nil {
+			return err
+		}
+		if f == nil || resp.FileName != fileName {
+			if err := closeFile(); err != nil {
+				return err
+			}
+			path := filepath.Join(root, resp.FileName)
+			if !strings.HasPrefix(path, filepath.Clean(root)+string(filepath.Separator)) {
+				return fmt.Errorf("invalid file name[%s] of transfer", resp.FileName)
+			}
+			if err := fileutil.MkDirIfNotExist(filepath.Dir(path)); err != nil {
+				return err
+			}
+			if f, err = os.Create(path); err != nil {
+				return err
+			}
+			fileName = resp.FileName
+		}
+		if _, err := f.Write(resp.Data); err != nil {
+			return err
+		}
+	}
+}
+
+// tempPath returns a unique temp path of database under temp dir
+func tempPath(tempDir, databaseName string) string {
+	return filepath.Join(tempDir, fmt.Sprintf("%s-%d", databaseName, timeutil.NowNano()))
+}
+
+// removeTempPath removes the temp path of transfer
+func removeTempPath(path string) {
+	if err := fileutil.RemoveDir(path); err != nil {
+		logger.GetLogger("storage", "Transfer").Warn("remove temp path of transfer error",
+			logger.String("path", path), logger.Error(err))
+	}
+}
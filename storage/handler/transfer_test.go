@@ -0,0 +1,298 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/rpc"
+	transfermock "github.com/lindb/lindb/rpc/pbmock/transfer"
+	"github.com/lindb/lindb/rpc/proto/transfer"
+	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/tsdb"
+)
+
+const transferTestPath = "transfer_test"
+
+// backupFiles writes the files of backup for testing
+func backupFiles(path string) error {
+	if err := fileutil.MkDirIfNotExist(filepath.Join(path, "shard", "1")); err != nil {
+		return err
+	}
+	if err := ioutil.WriteFile(filepath.Join(path, "OPTIONS"), nil, 0644); err != nil {
+		return err
+	}
+	data := make([]byte, transferChunkSize+10)
+	data[transferChunkSize] = 1
+	return ioutil.WriteFile(filepath.Join(path, "shard", "1", "000001.sst"), data, 0644)
+}
+
+func TestTransfer_Pull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(transferTestPath)
+		ctrl.Finish()
+	}()
+	storageService := service.NewMockStorageService(ctrl)
+	db := tsdb.NewMockDatabase(ctrl)
+	stream := transfermock.NewMockTransferService_PullServer(ctrl)
+	transferSrv := NewTransfer(nil, storageService, filepath.Join(transferTestPath, "source"))
+	req := &transfer.PullRequest{Database: "db", ShardIDs: []int32{1}}
+
+	// case 1: database not exist
+	storageService.EXPECT().GetDatabase("db").Return(nil, false)
+	assert.Error(t, transferSrv.Pull(req, stream))
+	storageService.EXPECT().GetDatabase("db").Return(db, true).AnyTimes()
+	// case 2: backup shards err
+	db.EXPECT().BackupShards(gomock.Any(), []int32{1}).Return(nil, fmt.Errorf("err"))
+	assert.Error(t, transferSrv.Pull(req, stream))
+	db.EXPECT().BackupShards(gomock.Any(), []int32{1}).DoAndReturn(
+		func(path string, shardIDs []int32) (*tsdb.BackupManifest, error) {
+			return &tsdb.BackupManifest{}, backupFiles(path)
+		}).AnyTimes()
+	// case 3: send err
+	stream.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, transferSrv.Pull(req, stream))
+	// case 4: send successfully, large file is split into chunks, empty file is sent
+	var responses []*transfer.PullResponse
+	stream.EXPECT().Send(gomock.Any()).DoAndReturn(func(resp *transfer.PullResponse) error {
+		responses = append(responses, &transfer.PullResponse{
+			FileName: resp.FileName,
+			Data:     append([]byte(nil), resp.Data...),
+		})
+		return nil
+	}).Times(3)
+	assert.NoError(t, transferSrv.Pull(req, stream))
+	assert.Len(t, responses, 3)
+	assert.Equal(t, "OPTIONS", responses[0].FileName)
+	assert.Empty(t, responses[0].Data)
+	assert.Equal(t, filepath.Join("shard", "1", "000001.sst"), responses[1].FileName)
+	assert.Len(t, responses[1].Data, transferChunkSize)
+	assert.Len(t, responses[2].Data, 10)
+	// temp backup is removed
+	files, _ := fileutil.ListDir(filepath.Join(transferTestPath, "source"))
+	assert.Empty(t, files)
+
+	// receive files
+	recvPath := filepath.Join(transferTestPath, "target")
+	pullClient := transfermock.NewMockTransferService_PullClient(ctrl)
+	for _, resp := range responses {
+		pullClient.EXPECT().Recv().Return(resp, nil)
+	}
+	pullClient.EXPECT().Recv().Return(nil, io.EOF)
+	assert.NoError(t, receiveFiles(recvPath, pullClient))
+	info, err := os.Stat(filepath.Join(recvPath, "OPTIONS"))
+	assert.NoError(t, err)
+	assert.Zero(t, info.Size())
+	data, err := ioutil.ReadFile(filepath.Join(recvPath, "shard", "1", "000001.sst"))
+	assert.NoError(t, err)
+	assert.Len(t, data, transferChunkSize+10)
+	assert.Equal(t, byte(1), data[transferChunkSize])
+}
+
+func TestTransfer_Digest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		jsonMarshalFunc = json.Marshal
+		ctrl.Finish()
+	}()
+	storageService := service.NewMockStorageService(ctrl)
+	shard := tsdb.NewMockShard(ctrl)
+	transferSrv := NewTransfer(nil, storageService, transferTestPath)
+	req := &transfer.DigestRequest{Database: "db", ShardID: 1, StartTime: 10, EndTime: 20}
+
+	// case 1: shard not exist
+	storageService.EXPECT().GetShard("db", int32(1)).Return(nil, false)
+	_, err := transferSrv.Digest(context.TODO(), req)
+	assert.Error(t, err)
+	storageService.EXPECT().GetShard("db", int32(1)).Return(shard, true).AnyTimes()
+	// case 2: digest err
+	shard.EXPECT().Digest(timeutil.TimeRange{Start: 10, End: 20}).Return(nil, fmt.Errorf("err"))
+	_, err = transferSrv.Digest(context.TODO(), req)
+	assert.Error(t, err)
+	digests := []*models.FamilyDigest{{FamilyTime: 10, NumOfPoints: 1}}
+	shard.EXPECT().Digest(gomock.Any()).Return(digests, nil).AnyTimes()
+	// case 3: marshal err
+	jsonMarshalFunc = func(v interface{}) ([]byte, error) {
+		return nil, fmt.Errorf("err")
+	}
+	_, err = transferSrv.Digest(context.TODO(), req)
+	assert.Error(t, err)
+	jsonMarshalFunc = json.Marshal
+	// case 4: digest successfully
+	resp, err := transferSrv.Digest(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, encoding.JSONMarshal(digests), resp.Payload)
+}
+
+func TestTransfer_PullFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(transferTestPath)
+		ctrl.Finish()
+	}()
+	storageService := service.NewMockStorageService(ctrl)
+	shard := tsdb.NewMockShard(ctrl)
+	stream := transfermock.NewMockTransferService_PullFamilyServer(ctrl)
+	transferSrv := NewTransfer(nil, storageService, transferTestPath)
+	req := &transfer.PullFamilyRequest{Database: "db", ShardID: 1, FamilyTime: 10}
+
+	// case 1: shard not exist
+	storageService.EXPECT().GetShard("db", int32(1)).Return(nil, false)
+	assert.Error(t, transferSrv.PullFamily(req, stream))
+	storageService.EXPECT().GetShard("db", int32(1)).Return(shard, true).AnyTimes()
+	// case 2: backup family err
+	shard.EXPECT().BackupFamily(int64(10), gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, transferSrv.PullFamily(req, stream))
+	shard.EXPECT().BackupFamily(int64(10), gomock.Any()).DoAndReturn(func(familyTime int64, path string) error {
+		if err := fileutil.MkDirIfNotExist(path); err != nil {
+			return err
+		}
+		return ioutil.WriteFile(filepath.Join(path, "000001.sst"), []byte("data"), 0644)
+	}).AnyTimes()
+	// case 3: send err
+	stream.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, transferSrv.PullFamily(req, stream))
+	// case 4: send successfully
+	stream.EXPECT().Send(&transfer.PullResponse{FileName: "000001.sst", Data: []byte("data")}).Return(nil)
+	assert.NoError(t, transferSrv.PullFamily(req, stream))
+	// temp backup is removed
+	files, _ := fileutil.ListDir(transferTestPath)
+	assert.Empty(t, files)
+}
+
+func TestTransfer_Repair(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(transferTestPath)
+		ctrl.Finish()
+	}()
+	fct := rpc.NewMockClientStreamFactory(ctrl)
+	fct.EXPECT().LogicNode().Return(node).AnyTimes()
+	storageService := service.NewMockStorageService(ctrl)
+	shard := tsdb.NewMockShard(ctrl)
+	cli := transfermock.NewMockTransferServiceClient(ctrl)
+	pullClient := transfermock.NewMockTransferService_PullFamilyClient(ctrl)
+	source := models.Node{IP: "1.1.1.1", Port: 2080}
+	transferSrv := NewTransfer(fct, storageService, transferTestPath)
+	req := &transfer.RepairRequest{Database: "db", ShardID: 1, SourceIP: "1.1.1.1", SourcePort: 2080,
+		FamilyTimes: []int64{10, 20}}
+
+	// case 1: shard not exist
+	storageService.EXPECT().GetShard("db", int32(1)).Return(nil, false)
+	_, err := transferSrv.Repair(context.TODO(), req)
+	assert.Error(t, err)
+	storageService.EXPECT().GetShard("db", int32(1)).Return(shard, true).AnyTimes()
+	// case 2: create client err
+	fct.EXPECT().CreateTransferServiceClient(source).Return(nil, fmt.Errorf("err"))
+	_, err = transferSrv.Repair(context.TODO(), req)
+	assert.Error(t, err)
+	fct.EXPECT().CreateTransferServiceClient(source).Return(cli, nil).AnyTimes()
+	// case 3: pull family err
+	cli.EXPECT().PullFamily(gomock.Any(), &transfer.PullFamilyRequest{Database: "db", ShardID: 1, FamilyTime: 10}).
+		Return(nil, fmt.Errorf("err"))
+	_, err = transferSrv.Repair(context.TODO(), req)
+	assert.Error(t, err)
+	cli.EXPECT().PullFamily(gomock.Any(), gomock.Any()).Return(pullClient, nil).AnyTimes()
+	// case 4: receive err
+	pullClient.EXPECT().Recv().Return(nil, fmt.Errorf("err"))
+	_, err = transferSrv.Repair(context.TODO(), req)
+	assert.Error(t, err)
+	// case 5: restore family err
+	pullClient.EXPECT().Recv().Return(nil, io.EOF)
+	shard.EXPECT().RestoreFamily(int64(10), gomock.Any()).Return(fmt.Errorf("err"))
+	_, err = transferSrv.Repair(context.TODO(), req)
+	assert.Error(t, err)
+	// case 6: repair all families successfully
+	pullClient.EXPECT().Recv().Return(&transfer.PullResponse{FileName: "000001.sst", Data: []byte("data")}, nil)
+	pullClient.EXPECT().Recv().Return(nil, io.EOF)
+	shard.EXPECT().RestoreFamily(int64(10), gomock.Any()).DoAndReturn(func(familyTime int64, path string) error {
+		data, err := ioutil.ReadFile(filepath.Join(path, "000001.sst"))
+		assert.NoError(t, err)
+		assert.Equal(t, []byte("data"), data)
+		return nil
+	})
+	pullClient.EXPECT().Recv().Return(nil, io.EOF)
+	shard.EXPECT().RestoreFamily(int64(20), gomock.Any()).Return(nil)
+	_, err = transferSrv.Repair(context.TODO(), req)
+	assert.NoError(t, err)
+	// temp path is removed
+	files, _ := fileutil.ListDir(transferTestPath)
+	assert.Empty(t, files)
+}
+
+func TestReceiveFiles_Fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(transferTestPath)
+		ctrl.Finish()
+	}()
+	pullClient := transfermock.NewMockTransferService_PullClient(ctrl)
+	// case 1: receive err
+	pullClient.EXPECT().Recv().Return(nil, fmt.Errorf("err"))
+	assert.Error(t, receiveFiles(transferTestPath, pullClient))
+	// case 2: file name out of root path
+	pullClient.EXPECT().Recv().Return(&transfer.PullResponse{FileName: "../OPTIONS"}, nil)
+	assert.Error(t, receiveFiles(transferTestPath, pullClient))
+}
+
+func TestShardImporter_Import(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(transferTestPath)
+		ctrl.Finish()
+	}()
+	fct := rpc.NewMockClientStreamFactory(ctrl)
+	fct.EXPECT().LogicNode().Return(node).AnyTimes()
+	storageService := service.NewMockStorageService(ctrl)
+	db := tsdb.NewMockDatabase(ctrl)
+	cli := transfermock.NewMockTransferServiceClient(ctrl)
+	pullClient := transfermock.NewMockTransferService_PullClient(ctrl)
+	source := models.Node{IP: "1.1.1.1", Port: 2080}
+	importer := NewShardImporter(fct, storageService, transferTestPath)
+
+	// case 1: database exists with all shards, imported before
+	storageService.EXPECT().GetDatabase("db").Return(db, true)
+	db.EXPECT().GetShard(int32(1)).Return(nil, true)
+	assert.NoError(t, importer.Import(context.TODO(), source, "db", []int32{1}))
+	// case 2: database exists without shard
+	storageService.EXPECT().GetDatabase("db").Return(db, true)
+	db.EXPECT().GetShard(int32(1)).Return(nil, false)
+	assert.Error(t, importer.Import(context.TODO(), source, "db", []int32{1}))
+	storageService.EXPECT().GetDatabase("db").Return(nil, false).AnyTimes()
+	// case 3: create client err
+	fct.EXPECT().CreateTransferServiceClient(source).Return(nil, fmt.Errorf("err"))
+	assert.Error(t, importer.Import(context.TODO(), source, "db", []int32{1}))
+	fct.EXPECT().CreateTransferServiceClient(source).Return(cli, nil).AnyTimes()
+	// case 4: pull err
+	cli.EXPECT().Pull(gomock.Any(), &transfer.PullRequest{Database: "db", ShardIDs: []int32{1}}).
+		Return(nil, fmt.Errorf("err"))
+	assert.Error(t, importer.Import(context.TODO(), source, "db", []int32{1}))
+	cli.EXPECT().Pull(gomock.Any(), gomock.Any()).Return(pullClient, nil).AnyTimes()
+	// case 5: receive err
+	pullClient.EXPECT().Recv().Return(nil, fmt.Errorf("err"))
+	assert.Error(t, importer.Import(context.TODO(), source, "db", []int32{1}))
+	// case 6: restore err
+	pullClient.EXPECT().Recv().Return(nil, io.EOF)
+	storageService.EXPECT().RestoreDatabase(gomock.Any(), "db", nil).Return(nil, fmt.Errorf("err"))
+	assert.Error(t, importer.Import(context.TODO(), source, "db", []int32{1}))
+	// case 7: import successfully
+	pullClient.EXPECT().Recv().Return(nil, io.EOF)
+	storageService.EXPECT().RestoreDatabase(gomock.Any(), "db", nil).Return(db, nil)
+	assert.NoError(t, importer.Import(context.TODO(), source, "db", []int32{1}))
+	// temp path is removed
+	files, _ := fileutil.ListDir(transferTestPath)
+	assert.Empty(t, files)
+}
@@ -79,7 +79,8 @@ func (w *Writer) Write(stream storage.WriteService_WriteServer) error {
 		return status.Errorf(codes.NotFound, "shard %d for database %s not exists", shardID, database)
 	}
 
-	sequence, err := shard.GetOrCreateSequence(logicNode.Indicator())
+	replicaPeer := logicNode.Indicator()
+	sequence, err := shard.GetOrCreateSequence(replicaPeer)
 	if err != nil {
 		return status.Error(codes.Internal, err.Error())
 	}
@@ -108,16 +109,22 @@ func (w *Writer) Write(stream storage.WriteService_WriteServer) error {
 				return status.Errorf(codes.OutOfRange, "seq num not match replica:%d, storage:%d", seq, hs)
 			}
 
-			w.handleReplica(shard, replica)
+			if err := w.handleReplica(shard, replicaPeer, replica); err != nil {
+				return status.Error(codes.Internal, err.Error())
+			}
 
 			sequence.SetHeadSeq(hs + 1)
 		}
-
+		// replica data is durable after data wal synced, acks the head sequence
+		if err := shard.SyncWAL(); err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		headSeq := sequence.GetHeadSeq()
 		resp := &storage.WriteResponse{
-			CurSeq: sequence.GetHeadSeq(),
+			CurSeq: headSeq,
 		}
 
-		resp.Ack = &storage.WriteResponse_AckSeq{AckSeq: sequence.GetAckSeq()}
+		resp.Ack = &storage.WriteResponse_AckSeq{AckSeq: headSeq}
 
 		if err := stream.Send(resp); err != nil {
 			return status.Error(codes.Internal, err.Error())
@@ -125,28 +132,29 @@ func (w *Writer) Write(stream storage.WriteService_WriteServer) error {
 	}
 }
 
-func (w *Writer) handleReplica(shard tsdb.Shard, replica *storage.Replica) {
+// handleReplica writes the replica data into shard, the corrupted replica data is ignored,
+// returns error if appending data wal fail.
+func (w *Writer) handleReplica(shard tsdb.Shard, replicaPeer string, replica *storage.Replica) error {
 	reader := snappy.NewReader(bytes.NewReader(replica.Data))
 	data, err := ioutil.ReadAll(reader)
 	if err != nil {
 		w.logger.Error("decompress replica data error", logger.Error(err))
-		return
+		return nil
 	}
 	var metricList field.MetricList
 	err = metricList.Unmarshal(data)
 	if err != nil {
 		w.logger.Error("unmarshal metricList", logger.Error(err))
-		return
+		return nil
 	}
 
 	//TODO write metric, need handle panic
-	for _, metric := range metricList.Metrics {
-		err = shard.Write(metric)
-	}
-	if err != nil {
-		w.logger.Error("write metric", logger.Error(err))
-		return
+	if err := shard.WriteReplica(replicaPeer, replica.Seq, &metricList); err != nil {
+		w.logger.Error("write replica", logger.String("replica", replicaPeer), logger.Any("seq", replica.Seq),
+			logger.Error(err))
+		return err
 	}
+	return nil
 }
 
 func getLogicNodeFromCtx(ctx context.Context) (*models.Node, error) {
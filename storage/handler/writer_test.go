@@ -175,11 +175,33 @@ func TestWriter_Write_Fail(t *testing.T) {
 	err = writer.Write(writeServer)
 	assert.Error(t, err)
 
+	// write replica err
 	writeServer.EXPECT().Recv().Return(&storage.WriteRequest{Replicas: []*storage.Replica{{Seq: int64(10)}}}, nil)
-	s.EXPECT().GetHeadSeq().Return(int64(9)).MaxTimes(2)
-	s.EXPECT().SetHeadSeq(gomock.Any())
-	s.EXPECT().GetAckSeq().Return(int64(8))
-	writeServer.EXPECT().Send(gomock.Any()).Return(fmt.Errorf("err"))
+	s.EXPECT().GetHeadSeq().Return(int64(9))
+	shard.EXPECT().WriteReplica(gomock.Any(), int64(10), gomock.Any()).Return(fmt.Errorf("err"))
+	err = writer.Write(writeServer)
+	assert.Error(t, err)
+
+	// sync wal err
+	writeServer.EXPECT().Recv().Return(&storage.WriteRequest{Replicas: []*storage.Replica{{Seq: int64(10)}}}, nil)
+	s.EXPECT().GetHeadSeq().Return(int64(9))
+	s.EXPECT().SetHeadSeq(int64(10))
+	shard.EXPECT().WriteReplica(gomock.Any(), int64(10), gomock.Any()).Return(nil)
+	shard.EXPECT().SyncWAL().Return(fmt.Errorf("err"))
+	err = writer.Write(writeServer)
+	assert.Error(t, err)
+
+	// send err
+	writeServer.EXPECT().Recv().Return(&storage.WriteRequest{Replicas: []*storage.Replica{{Seq: int64(10)}}}, nil)
+	s.EXPECT().GetHeadSeq().Return(int64(9))
+	s.EXPECT().SetHeadSeq(int64(10))
+	s.EXPECT().GetHeadSeq().Return(int64(10))
+	shard.EXPECT().WriteReplica(gomock.Any(), int64(10), gomock.Any()).Return(nil)
+	shard.EXPECT().SyncWAL().Return(nil)
+	writeServer.EXPECT().Send(&storage.WriteResponse{
+		CurSeq: 10,
+		Ack:    &storage.WriteResponse_AckSeq{AckSeq: 10},
+	}).Return(fmt.Errorf("err"))
 	err = writer.Write(writeServer)
 	assert.Error(t, err)
 }
@@ -192,13 +214,15 @@ func TestWriter_handle_replica(t *testing.T) {
 
 	writer := NewWriter(srv)
 	shard := tsdb.NewMockShard(ctrl)
-	writer.handleReplica(shard, &storage.Replica{Seq: int64(10), Data: []byte{1, 2, 3}})
+	err := writer.handleReplica(shard, "peer", &storage.Replica{Seq: int64(10), Data: []byte{1, 2, 3}})
+	assert.NoError(t, err)
 
 	buf := &bytes.Buffer{}
 	compressBuf := snappy.NewBufferedWriter(buf)
 	_, _ = compressBuf.Write([]byte{1, 2, 4})
 	_ = compressBuf.Flush()
-	writer.handleReplica(shard, &storage.Replica{Seq: int64(10), Data: buf.Bytes()})
+	err = writer.handleReplica(shard, "peer", &storage.Replica{Seq: int64(10), Data: buf.Bytes()})
+	assert.NoError(t, err)
 
 	metricList := &field.MetricList{
 		Metrics: []*field.Metric{{Name: "test"}},
@@ -209,8 +233,12 @@ func TestWriter_handle_replica(t *testing.T) {
 	compressBuf = snappy.NewBufferedWriter(buf)
 	_, _ = compressBuf.Write(data)
 	_ = compressBuf.Flush()
-	shard.EXPECT().Write(gomock.Any()).Return(fmt.Errorf("err"))
-	writer.handleReplica(shard, &storage.Replica{Seq: int64(10), Data: buf.Bytes()})
+	shard.EXPECT().WriteReplica("peer", int64(10), metricList).Return(fmt.Errorf("err"))
+	err = writer.handleReplica(shard, "peer", &storage.Replica{Seq: int64(10), Data: buf.Bytes()})
+	assert.Error(t, err)
+	shard.EXPECT().WriteReplica("peer", int64(10), metricList).Return(nil)
+	err = writer.handleReplica(shard, "peer", &storage.Replica{Seq: int64(10), Data: buf.Bytes()})
+	assert.NoError(t, err)
 }
 
 func TestWrite_parse_ctx(t *testing.T) {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"time"
 
 	"github.com/gogo/protobuf/proto"
@@ -17,6 +18,7 @@ import (
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/coordinator/discovery"
 	task "github.com/lindb/lindb/coordinator/storage"
+	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/monitoring"
 	taskHandler "github.com/lindb/lindb/parallel"
@@ -29,7 +31,9 @@ import (
 	"github.com/lindb/lindb/rpc"
 	"github.com/lindb/lindb/rpc/proto/common"
 	"github.com/lindb/lindb/rpc/proto/storage"
+	"github.com/lindb/lindb/rpc/proto/transfer"
 	"github.com/lindb/lindb/service"
+	"github.com/lindb/lindb/storage/api/admin"
 	"github.com/lindb/lindb/storage/handler"
 	"github.com/lindb/lindb/tsdb"
 )
@@ -46,7 +50,8 @@ type factory struct {
 
 // rpcHandler represents all dependency rpc handlers
 type rpcHandler struct {
-	writer *handler.Writer
+	writer   *handler.Writer
+	transfer *handler.Transfer
 	task   *taskHandler.TaskHandler
 }
 
@@ -128,23 +133,26 @@ func (r *runtime) Run() error {
 
 	// start tcp server
 	r.startTCPServer()
-	// start http server
-	r.startHTTPServer()
 
 	// start state repo
 	if err := r.startStateRepo(); err != nil {
 		r.state = server.Failed
 		return err
 	}
+	// start http server, admin api registers restored database into state repo
+	r.startHTTPServer()
 
 	// register storage node info
 	//TODO TTL default value???
-	r.registry = discovery.NewRegistry(r.repo, constants.ActiveNodesPath, r.config.StorageBase.GRPC.TTL.Duration())
+	r.registry = discovery.NewRegistry(r.repo, constants.ActiveNodesPath, r.config.StorageBase.GRPC.TTL.Duration(),
+		r.config.StorageBase.Labels)
 	if err := r.registry.Register(r.node); err != nil {
 		return fmt.Errorf("register storage node error:%s", err)
 	}
 
-	r.taskExecutor = task.NewTaskExecutor(r.ctx, &r.node, r.repo, r.srv.storageService)
+	r.taskExecutor = task.NewTaskExecutor(r.ctx, &r.node, r.repo, r.srv.storageService,
+		query.NewSeriesDeleter(r.srv.storageService),
+		handler.NewShardImporter(rpc.NewClientStreamFactory(r.node), r.srv.storageService, r.transferDir()))
 	r.taskExecutor.Run()
 
 	// start stat monitoring
@@ -244,6 +252,17 @@ func (r *runtime) startHTTPServer() {
 	reporter := promreporter.NewReporter(promreporter.Options{})
 	router := mux.NewRouter().StrictSlash(true)
 	router.Handle("/metrics", reporter.HTTPHandler())
+	// add database backup/restore/compaction admin api, which only allows the requests from localhost
+	adminRouter := router.NewRoute().Subrouter()
+	adminRouter.Use(admin.LocalOnly)
+	backupAPI := admin.NewBackupAPI(r.config.StorageBase.TSDB.BackupDir, r.node, r.repo, r.srv.storageService)
+	adminRouter.HandleFunc("/database/backup", backupAPI.Backup).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/database/restore", backupAPI.Restore).Methods(http.MethodPost)
+	compactAPI := admin.NewCompactAPI(r.srv.storageService, kv.GetCompactScheduler())
+	adminRouter.HandleFunc("/database/compact", compactAPI.Compact).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/compaction/pause", compactAPI.Pause).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/compaction/resume", compactAPI.Resume).Methods(http.MethodPost)
+	adminRouter.HandleFunc("/compaction/state", compactAPI.State).Methods(http.MethodGet)
 
 	r.httpServer = &http.Server{
 		Addr:         fmt.Sprintf(":%d", port),
@@ -281,15 +300,23 @@ func (r *runtime) bindRPCHandlers() {
 		query.NewExecutorFactory(), r.factory.taskServer)
 
 	r.handler = &rpcHandler{
-		writer: handler.NewWriter(r.srv.storageService),
+		writer:   handler.NewWriter(r.srv.storageService),
+		transfer: handler.NewTransfer(rpc.NewClientStreamFactory(r.node), r.srv.storageService, r.transferDir()),
 		task:   taskHandler.NewTaskHandler(r.config.StorageBase.Query, r.factory.taskServer, dispatcher),
 	}
 
 	//TODO add task service ??????
 	storage.RegisterWriteServiceServer(r.server.GetServer(), r.handler.writer)
+	transfer.RegisterTransferServiceServer(r.server.GetServer(), r.handler.transfer)
 	common.RegisterTaskServiceServer(r.server.GetServer(), r.handler.task)
 }
 
+// transferDir returns the temp dir of shard transfer, which is outside of tsdb dir,
+// because each sub dir of tsdb dir is loaded as database.
+func (r *runtime) transferDir() string {
+	return filepath.Join(filepath.Dir(filepath.Clean(r.config.StorageBase.TSDB.Dir)), "transfer")
+}
+
 func (r *runtime) monitoring() {
 	systemStatMonitorEnabled := r.config.Monitor.SystemReportInterval > 0
 	if systemStatMonitorEnabled {
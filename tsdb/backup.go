@@ -0,0 +1,259 @@
+package tsdb
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// for testing
+var (
+	linkOrCopyFunc = fileutil.LinkOrCopy
+	copyFileFunc   = fileutil.CopyFile
+	walkFunc       = filepath.Walk
+)
+
+// backupManifest is the file name of backup manifest, which is written after all data backed up,
+// so the backup is completed only if the manifest file exists.
+const backupManifest = "BACKUP"
+
+// BackupManifest represents the manifest of database backup
+type BackupManifest struct {
+	Database  string                `toml:"database" json:"database"`   // name of backed up database
+	Timestamp int64                 `toml:"timestamp" json:"timestamp"` // backup time(ms)
+	ShardIDs  []int32               `toml:"shardIDs" json:"shardIDs"`   // shard ids of backed up database
+	Option    option.DatabaseOption `toml:"option" json:"option"`       // option of backed up database
+}
+
+// Backup backups metadata and all shards of database into target path, then writes backup manifest.
+// directory tree of backup is same as database, so that it can be restored as a database:
+//    xx/backup/OPTIONS
+//    xx/backup/BACKUP
+//    xx/backup/meta/metric/
+//    xx/backup/meta/tag/
+//    xx/backup/shard/1/
+func (db *database) Backup(targetPath string) (*BackupManifest, error) {
+	return db.backup(targetPath, nil)
+}
+
+// BackupShards backups metadata and the spec shards with replica sequences into target path,
+// which is used to build new replica of shards on other node.
+// directory tree of backup is same as database backup, but includes the replica sequence of shard:
+//    xx/backup/shard/1/replica/
+func (db *database) BackupShards(targetPath string, shardIDs []int32) (*BackupManifest, error) {
+	if len(shardIDs) == 0 {
+		return nil, fmt.Errorf("backup shards of database[%s] without shard", db.name)
+	}
+	return db.backup(targetPath, shardIDs)
+}
+
+// backup backups metadata and shards of database into target path, backups all shards if shard ids is empty,
+// else backups the spec shards with replica sequences.
+func (db *database) backup(targetPath string, shardIDs []int32) (*BackupManifest, error) {
+	if fileutil.Exist(targetPath) {
+		return nil, fmt.Errorf("backup path[%s] already exists", targetPath)
+	}
+	cfg, shards, err := db.listBackupShards(shardIDs)
+	if err != nil {
+		return nil, err
+	}
+	withReplica := len(shardIDs) > 0
+	if !withReplica {
+		shardIDs = cfg.ShardIDs
+	}
+	if err := mkDirIfNotExist(targetPath); err != nil {
+		return nil, err
+	}
+	manifest := &BackupManifest{
+		Database:  db.name,
+		Timestamp: timeutil.Now(),
+		ShardIDs:  shardIDs,
+		Option:    cfg.Option,
+	}
+	// flush tag metadata into kv store
+	if err := db.metadata.Flush(); err != nil {
+		return nil, err
+	}
+	if err := db.metadata.MetadataDatabase().Backup(filepath.Join(targetPath, metaDir, metricMetaDir)); err != nil {
+		return nil, fmt.Errorf("backup metric metadata of database[%s] error: %s", db.name, err)
+	}
+	if err := db.metaStore.Backup(filepath.Join(targetPath, metaDir, tagMetaDir)); err != nil {
+		return nil, fmt.Errorf("backup tag metadata of database[%s] error: %s", db.name, err)
+	}
+	for _, shardID := range shardIDs {
+		shard, ok := shards[shardID]
+		if !ok {
+			continue
+		}
+		shardPath := filepath.Join(targetPath, shardDir, strconv.Itoa(int(shardID)))
+		backup := shard.Backup
+		if withReplica {
+			backup = shard.BackupReplica
+		}
+		if err := backup(shardPath); err != nil {
+			return nil, err
+		}
+	}
+	if err := encodeToml(optionsPath(targetPath), &databaseConfig{ShardIDs: shardIDs, Option: cfg.Option}); err != nil {
+		return nil, err
+	}
+	if err := encodeToml(filepath.Join(targetPath, backupManifest), manifest); err != nil {
+		return nil, err
+	}
+	engineLogger.Info("backup database successfully",
+		logger.String("db", db.name), logger.String("target", targetPath))
+	return manifest, nil
+}
+
+// listBackupShards returns the config and the shards need backup under lock, returns all shards if shard ids is empty,
+// the lock prevents creating shard when listing shards, but isn't held when copying files of shards.
+func (db *database) listBackupShards(shardIDs []int32) (*databaseConfig, map[int32]Shard, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	cfg := db.config
+	withReplica := len(shardIDs) > 0
+	if !withReplica {
+		shardIDs = cfg.ShardIDs
+	}
+	shards := make(map[int32]Shard, len(shardIDs))
+	for _, shardID := range shardIDs {
+		shard, ok := db.GetShard(shardID)
+		if !ok {
+			if withReplica {
+				return nil, nil, fmt.Errorf("shard[%d] of database[%s] not found", shardID, db.name)
+			}
+			continue
+		}
+		shards[shardID] = shard
+	}
+	return cfg, shards, nil
+}
+
+// BackupDatabase backups the database into target path, returns the manifest of backup
+func (e *engine) BackupDatabase(databaseName string, targetPath string) (*BackupManifest, error) {
+	db, ok := e.GetDatabase(databaseName)
+	if !ok {
+		return nil, fmt.Errorf("database[%s] not found", databaseName)
+	}
+	return db.Backup(targetPath)
+}
+
+// RestoreDatabase restores the backup as a new database, uses the database name of backup if name is empty.
+// if shard mapping isn't empty, the shards of backup are restored as new shard ids based on mapping,
+// so that the backup can be restored into other cluster with different shard assignment.
+func (e *engine) RestoreDatabase(backupPath string, databaseName string, shardMapping map[int32]int32) (Database, error) {
+	manifest := &BackupManifest{}
+	if err := decodeToml(filepath.Join(backupPath, backupManifest), manifest); err != nil {
+		return nil, fmt.Errorf("read backup manifest error: %s", err)
+	}
+	if databaseName == "" {
+		databaseName = manifest.Database
+	}
+	shardIDs, err := mappingShardIDs(manifest.ShardIDs, shardMapping)
+	if err != nil {
+		return nil, err
+	}
+	dbPath := filepath.Join(e.cfg.Dir, databaseName)
+	if _, ok := e.GetDatabase(databaseName); ok || fileutil.Exist(dbPath) {
+		return nil, fmt.Errorf("database[%s] already exists", databaseName)
+	}
+	if err := restoreFiles(backupPath, dbPath, shardMapping); err != nil {
+		e.removeRestoreFiles(dbPath)
+		return nil, fmt.Errorf("restore files of database[%s] error: %s", databaseName, err)
+	}
+	cfg := &databaseConfig{ShardIDs: shardIDs, Option: manifest.Option}
+	if err := encodeToml(optionsPath(dbPath), cfg); err != nil {
+		e.removeRestoreFiles(dbPath)
+		return nil, err
+	}
+	db, err := e.CreateDatabase(databaseName)
+	if err != nil {
+		e.removeRestoreFiles(dbPath)
+		return nil, err
+	}
+	engineLogger.Info("restore database successfully",
+		logger.String("db", databaseName), logger.String("backup", backupPath))
+	return db, nil
+}
+
+// removeRestoreFiles removes the database path if restore failure
+func (e *engine) removeRestoreFiles(dbPath string) {
+	if err := removeDir(dbPath); err != nil {
+		engineLogger.Warn("remove database path error when restore failure",
+			logger.String("path", dbPath), logger.Error(err))
+	}
+}
+
+// mappingShardIDs returns the shard ids after mapping, all shards must be in mapping if mapping isn't empty
+func mappingShardIDs(shardIDs []int32, shardMapping map[int32]int32) ([]int32, error) {
+	if len(shardMapping) == 0 {
+		return shardIDs, nil
+	}
+	result := make([]int32, 0, len(shardIDs))
+	targets := make(map[int32]struct{})
+	for _, shardID := range shardIDs {
+		target, ok := shardMapping[shardID]
+		if !ok {
+			return nil, fmt.Errorf("shard[%d] of backup not found in shard mapping", shardID)
+		}
+		if _, ok := targets[target]; ok {
+			return nil, fmt.Errorf("duplicate target shard[%d] in shard mapping", target)
+		}
+		targets[target] = struct{}{}
+		result = append(result, target)
+	}
+	return result, nil
+}
+
+// restoreFiles restores the files of backup into database path, renames shard directory based on shard mapping.
+// sst files are linked if possible because they are immutable, other files(wal/manifest/bbolt etc.) are copied,
+// because they would be modified by restored database.
+func restoreFiles(backupPath, dbPath string, shardMapping map[int32]int32) error {
+	return walkFunc(backupPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(backupPath, path)
+		if err != nil {
+			return err
+		}
+		if relPath == backupManifest || relPath == options {
+			return nil
+		}
+		target := filepath.Join(dbPath, mappingShardPath(relPath, shardMapping))
+		if info.IsDir() {
+			return mkDirIfNotExist(target)
+		}
+		if desc := version.ParseFileName(info.Name()); desc != nil && desc.FileType == version.TypeTable {
+			return linkOrCopyFunc(path, target)
+		}
+		return copyFileFunc(path, target)
+	})
+}
+
+// mappingShardPath replaces the shard id of relative path under shard directory based on shard mapping
+func mappingShardPath(relPath string, shardMapping map[int32]int32) string {
+	parts := strings.SplitN(relPath, string(filepath.Separator), 3)
+	if len(parts) < 2 || parts[0] != shardDir {
+		return relPath
+	}
+	shardID, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return relPath
+	}
+	target, ok := shardMapping[int32(shardID)]
+	if !ok {
+		return relPath
+	}
+	parts[1] = strconv.Itoa(int(target))
+	return filepath.Join(parts...)
+}
@@ -0,0 +1,338 @@
+package tsdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/ltoml"
+	"github.com/lindb/lindb/pkg/option"
+	"github.com/lindb/lindb/pkg/timeutil"
+	pb "github.com/lindb/lindb/rpc/proto/field"
+	"github.com/lindb/lindb/tsdb/metadb"
+)
+
+func TestEngine_BackupAndRestore(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	e, err := NewEngine(config.TSDB{Dir: filepath.Join(testPath, "data")})
+	assert.NoError(t, err)
+	defer e.Close()
+	db, err := e.CreateDatabase("db")
+	assert.NoError(t, err)
+	assert.NoError(t, db.CreateShards(option.DatabaseOption{Interval: "10s"}, []int32{1, 2}))
+	shard, _ := db.GetShard(1)
+	now := timeutil.Now()
+	assert.NoError(t, shard.Write(&pb.Metric{
+		Name:      "cpu",
+		Timestamp: now,
+		Tags:      map[string]string{"host": "1.1.1.1"},
+		TagsHash:  10,
+		Fields:    []*pb.Field{{Name: "f1", Type: pb.FieldType_Sum, Value: 1.0}},
+	}))
+	backupPath := filepath.Join(testPath, "backup")
+
+	// case 1: database not exist
+	manifest, err := e.BackupDatabase("not_exist", backupPath)
+	assert.Error(t, err)
+	assert.Nil(t, manifest)
+	// case 2: backup successfully
+	manifest, err = e.BackupDatabase("db", backupPath)
+	assert.NoError(t, err)
+	assert.Equal(t, "db", manifest.Database)
+	assert.Equal(t, []int32{1, 2}, manifest.ShardIDs)
+	// case 3: backup path exists
+	manifest, err = e.BackupDatabase("db", backupPath)
+	assert.Error(t, err)
+	assert.Nil(t, manifest)
+
+	// case 4: backup manifest not exist
+	restored, err := e.RestoreDatabase(testPath, "db2", nil)
+	assert.Error(t, err)
+	assert.Nil(t, restored)
+	// case 5: database exists
+	restored, err = e.RestoreDatabase(backupPath, "", nil)
+	assert.Error(t, err)
+	assert.Nil(t, restored)
+	// case 6: shard not in mapping
+	restored, err = e.RestoreDatabase(backupPath, "db2", map[int32]int32{1: 3})
+	assert.Error(t, err)
+	assert.Nil(t, restored)
+	// case 7: restore with new shard ids
+	restored, err = e.RestoreDatabase(backupPath, "db2", map[int32]int32{1: 3, 2: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, restored.NumOfShards())
+	_, ok := restored.GetShard(1)
+	assert.False(t, ok)
+	restoredShard, ok := restored.GetShard(3)
+	assert.True(t, ok)
+	_, ok = restored.GetShard(4)
+	assert.True(t, ok)
+	_, ok = e.GetDatabase("db2")
+	assert.True(t, ok)
+	metricID, err := restored.Metadata().MetadataDatabase().GetMetricID(constants.DefaultNamespace, "cpu")
+	assert.NoError(t, err)
+	assert.NotZero(t, metricID)
+	families := restoredShard.GetDataFamilies(timeutil.Interval(10*timeutil.OneSecond),
+		timeutil.TimeRange{Start: now - timeutil.OneHour, End: now + timeutil.OneHour})
+	assert.Len(t, families, 1)
+	// data files of backup aren't modified by restored database
+	assert.True(t, fileutil.Exist(filepath.Join(backupPath, backupManifest)))
+}
+
+func TestDatabase_BackupShards(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	e, err := NewEngine(config.TSDB{Dir: filepath.Join(testPath, "data")})
+	assert.NoError(t, err)
+	defer e.Close()
+	db, err := e.CreateDatabase("db")
+	assert.NoError(t, err)
+	assert.NoError(t, db.CreateShards(option.DatabaseOption{Interval: "10s"}, []int32{1, 2}))
+	shard, _ := db.GetShard(2)
+	seq, err := shard.GetOrCreateSequence("broker-1")
+	assert.NoError(t, err)
+	seq.SetHeadSeq(10)
+	backupPath := filepath.Join(testPath, "backup")
+
+	// case 1: shards are empty
+	manifest, err := db.BackupShards(backupPath, nil)
+	assert.Error(t, err)
+	assert.Nil(t, manifest)
+	// case 2: shard not found
+	manifest, err = db.BackupShards(backupPath, []int32{2, 3})
+	assert.Error(t, err)
+	assert.Nil(t, manifest)
+	// case 3: backup successfully
+	manifest, err = db.BackupShards(backupPath, []int32{2})
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{2}, manifest.ShardIDs)
+	assert.False(t, fileutil.Exist(filepath.Join(backupPath, shardDir, "1")))
+
+	// restore as new replica, continues replication from the sequence
+	e2, err := NewEngine(config.TSDB{Dir: filepath.Join(testPath, "data2")})
+	assert.NoError(t, err)
+	defer e2.Close()
+	restored, err := e2.RestoreDatabase(backupPath, "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, restored.NumOfShards())
+	restoredShard, ok := restored.GetShard(2)
+	assert.True(t, ok)
+	seq, err = restoredShard.GetOrCreateSequence("broker-1")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), seq.GetHeadSeq())
+}
+
+func TestEngine_RestoreDatabase_Fail(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		walkFunc = filepath.Walk
+		encodeToml = ltoml.EncodeToml
+		newDatabaseFunc = newDatabase
+	}()
+	e, err := NewEngine(config.TSDB{Dir: filepath.Join(testPath, "data")})
+	assert.NoError(t, err)
+	defer e.Close()
+	backupPath := filepath.Join(testPath, "backup")
+	assert.NoError(t, fileutil.MkDirIfNotExist(backupPath))
+	assert.NoError(t, ltoml.EncodeToml(filepath.Join(backupPath, backupManifest), &BackupManifest{
+		Database: "db",
+		ShardIDs: []int32{1},
+		Option:   option.DatabaseOption{Interval: "10s"},
+	}))
+	// case 1: restore files err
+	walkFunc = func(root string, walkFn filepath.WalkFunc) error {
+		return fmt.Errorf("err")
+	}
+	db, err := e.RestoreDatabase(backupPath, "", nil)
+	assert.Error(t, err)
+	assert.Nil(t, db)
+	walkFunc = filepath.Walk
+	// case 2: write options err
+	encodeToml = func(fileName string, v interface{}) error {
+		return fmt.Errorf("err")
+	}
+	db, err = e.RestoreDatabase(backupPath, "", nil)
+	assert.Error(t, err)
+	assert.Nil(t, db)
+	encodeToml = ltoml.EncodeToml
+	// case 3: create database err
+	newDatabaseFunc = func(databaseName string, databasePath string, cfg *databaseConfig,
+		flushChecker DataFlushChecker) (Database, error) {
+		return nil, fmt.Errorf("err")
+	}
+	db, err = e.RestoreDatabase(backupPath, "", nil)
+	assert.Error(t, err)
+	assert.Nil(t, db)
+	assert.False(t, fileutil.Exist(filepath.Join(testPath, "data", "db")))
+}
+
+func TestDatabase_Backup_Fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		mkDirIfNotExist = fileutil.MkDirIfNotExist
+		encodeToml = ltoml.EncodeToml
+		ctrl.Finish()
+	}()
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metaStore := kv.NewMockStore(ctrl)
+	shard := NewMockShard(ctrl)
+	db := &database{
+		name:      "db",
+		config:    &databaseConfig{ShardIDs: []int32{1, 2}},
+		metadata:  metadata,
+		metaStore: metaStore,
+	}
+	db.shards.Store(int32(1), shard)
+	backupPath := filepath.Join(testPath, "backup")
+	cases := []struct {
+		name    string
+		prepare func()
+	}{
+		{
+			name: "create backup path err",
+			prepare: func() {
+				mkDirIfNotExist = func(path string) error {
+					return fmt.Errorf("err")
+				}
+			},
+		},
+		{
+			name: "flush metadata err",
+			prepare: func() {
+				metadata.EXPECT().Flush().Return(fmt.Errorf("err"))
+			},
+		},
+		{
+			name: "backup metadata database err",
+			prepare: func() {
+				metadata.EXPECT().Flush().Return(nil)
+				metadataDB.EXPECT().Backup(gomock.Any()).Return(fmt.Errorf("err"))
+			},
+		},
+		{
+			name: "backup meta store err",
+			prepare: func() {
+				metadata.EXPECT().Flush().Return(nil)
+				metadataDB.EXPECT().Backup(gomock.Any()).Return(nil)
+				metaStore.EXPECT().Backup(gomock.Any()).Return(fmt.Errorf("err"))
+			},
+		},
+		{
+			name: "backup shard err",
+			prepare: func() {
+				metadata.EXPECT().Flush().Return(nil)
+				metadataDB.EXPECT().Backup(gomock.Any()).Return(nil)
+				metaStore.EXPECT().Backup(gomock.Any()).Return(nil)
+				shard.EXPECT().Backup(gomock.Any()).Return(fmt.Errorf("err"))
+			},
+		},
+		{
+			name: "write options err",
+			prepare: func() {
+				metadata.EXPECT().Flush().Return(nil)
+				metadataDB.EXPECT().Backup(gomock.Any()).Return(nil)
+				metaStore.EXPECT().Backup(gomock.Any()).Return(nil)
+				shard.EXPECT().Backup(gomock.Any()).Return(nil)
+				encodeToml = func(fileName string, v interface{}) error {
+					return fmt.Errorf("err")
+				}
+			},
+		},
+		{
+			name: "write manifest err",
+			prepare: func() {
+				metadata.EXPECT().Flush().Return(nil)
+				metadataDB.EXPECT().Backup(gomock.Any()).Return(nil)
+				metaStore.EXPECT().Backup(gomock.Any()).Return(nil)
+				shard.EXPECT().Backup(gomock.Any()).Return(nil)
+				encodeToml = func(fileName string, v interface{}) error {
+					if filepath.Base(fileName) == backupManifest {
+						return fmt.Errorf("err")
+					}
+					return nil
+				}
+			},
+		},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				_ = fileutil.RemoveDir(backupPath)
+				mkDirIfNotExist = fileutil.MkDirIfNotExist
+				encodeToml = ltoml.EncodeToml
+			}()
+			tt.prepare()
+			manifest, err := db.Backup(backupPath)
+			assert.Error(t, err)
+			assert.Nil(t, manifest)
+		})
+	}
+}
+
+func TestRestoreFiles(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		linkOrCopyFunc = fileutil.LinkOrCopy
+		copyFileFunc = fileutil.CopyFile
+	}()
+	backupPath := filepath.Join(testPath, "backup")
+	dbPath := filepath.Join(testPath, "db")
+	shardPath := filepath.Join(backupPath, shardDir, "1", indexParentDir, forwardIndexDir)
+	assert.NoError(t, fileutil.MkDirIfNotExist(shardPath))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(shardPath, "000001.sst"), []byte("sst"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(backupPath, shardDir, "1", indexParentDir, "CURRENT"), []byte("current"), 0644))
+	// case 1: restore successfully
+	assert.NoError(t, restoreFiles(backupPath, dbPath, map[int32]int32{1: 5}))
+	assert.True(t, fileutil.Exist(filepath.Join(dbPath, shardDir, "5", indexParentDir, forwardIndexDir, "000001.sst")))
+	assert.True(t, fileutil.Exist(filepath.Join(dbPath, shardDir, "5", indexParentDir, "CURRENT")))
+	// case 2: link sst file err
+	linkOrCopyFunc = func(source, target string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, restoreFiles(backupPath, filepath.Join(testPath, "db2"), nil))
+	// case 3: copy file err
+	copyFileFunc = func(source, target string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, restoreFiles(backupPath, filepath.Join(testPath, "db3"), nil))
+	// case 4: backup path not exist
+	assert.Error(t, restoreFiles(filepath.Join(testPath, "not_exist"), dbPath, nil))
+}
+
+func TestMappingShardPath(t *testing.T) {
+	mapping := map[int32]int32{1: 3}
+	assert.Equal(t, filepath.Join(shardDir, "3", metaDir), mappingShardPath(filepath.Join(shardDir, "1", metaDir), mapping))
+	assert.Equal(t, filepath.Join(shardDir, "3"), mappingShardPath(filepath.Join(shardDir, "1"), mapping))
+	assert.Equal(t, filepath.Join(shardDir, "2"), mappingShardPath(filepath.Join(shardDir, "2"), mapping))
+	assert.Equal(t, filepath.Join(shardDir, "abc"), mappingShardPath(filepath.Join(shardDir, "abc"), mapping))
+	assert.Equal(t, shardDir, mappingShardPath(shardDir, mapping))
+	assert.Equal(t, metaDir, mappingShardPath(metaDir, mapping))
+}
+
+func TestMappingShardIDs(t *testing.T) {
+	shardIDs, err := mappingShardIDs([]int32{1, 2}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{1, 2}, shardIDs)
+	shardIDs, err = mappingShardIDs([]int32{1, 2}, map[int32]int32{1: 3, 2: 4})
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{3, 4}, shardIDs)
+	_, err = mappingShardIDs([]int32{1, 2}, map[int32]int32{1: 3, 2: 3})
+	assert.Error(t, err)
+	_, err = mappingShardIDs([]int32{1, 2}, map[int32]int32{1: 3})
+	assert.Error(t, err)
+}
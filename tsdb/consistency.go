@@ -0,0 +1,496 @@
+package tsdb
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"path/filepath"
+	"sort"
+
+	"github.com/cespare/xxhash"
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/models"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/tsdb/indexdb"
+	"github.com/lindb/lindb/tsdb/metadb"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+)
+
+// for testing
+var (
+	scanSeriesFunc   = metricsdata.ScanSeries
+	inspectTableFunc = table.Inspect
+	listDirFunc      = fileutil.ListDir
+	readFileFunc     = ioutil.ReadFile
+	writeFileFunc    = ioutil.WriteFile
+)
+
+// idMappingFile is the file name of id mapping in the backup of data family
+const idMappingFile = "idmapping.json"
+
+// Digest returns the digests of the data families of write interval which are within the time range,
+// which are compared with other replicas for checking consistency,
+// the family partially out of time range is skipped because it may be still written.
+func (s *shard) Digest(timeRange timeutil.TimeRange) ([]*models.FamilyDigest, error) {
+	var result []*models.FamilyDigest
+	var resolver *digestResolver
+	for _, family := range s.segment.getDataFamilies(timeRange) {
+		familyTimeRange := family.TimeRange()
+		if !timeRange.Contains(familyTimeRange.Start) || !timeRange.Contains(familyTimeRange.End) {
+			continue
+		}
+		if resolver == nil {
+			r, err := newDigestResolver(s.metadata.MetadataDatabase(), s.indexDB)
+			if err != nil {
+				return nil, fmt.Errorf("load metadata of shard[%d] error: %s", s.id, err)
+			}
+			resolver = r
+		}
+		digest, err := digestFamily(family, resolver)
+		if err != nil {
+			return nil, fmt.Errorf("digest family[%d] of shard[%d] error: %s", family.TimeRange().Start, s.id, err)
+		}
+		result = append(result, digest)
+	}
+	return result, nil
+}
+
+// BackupFamily links or copies the files of data family in write interval by family time into target path,
+// the metric/field/series ids used in the files are resolved into id mapping file under target path,
+// because the ids are generated in each replica.
+func (s *shard) BackupFamily(familyTime int64, targetPath string) error {
+	family, ok := s.getDataFamily(familyTime)
+	if !ok {
+		return fmt.Errorf("family[%d] of shard[%d] not found", familyTime, s.id)
+	}
+	if err := family.Family().BackupFiles(targetPath); err != nil {
+		return err
+	}
+	resolver, err := newDigestResolver(s.metadata.MetadataDatabase(), s.indexDB)
+	if err != nil {
+		return fmt.Errorf("load metadata of shard[%d] error: %s", s.id, err)
+	}
+	mapping, err := buildIDMapping(targetPath, resolver)
+	if err != nil {
+		return fmt.Errorf("build id mapping of family[%d] of shard[%d] error: %s", familyTime, s.id, err)
+	}
+	return writeFileFunc(filepath.Join(targetPath, idMappingFile), encoding.JSONMarshal(mapping), 0644)
+}
+
+// RestoreFamily replaces the files of data family in write interval by family time with the files under source path,
+// creates the data family if not exist, which is used to repair the lagging replica.
+// the restore is refused unless all ids in id mapping of source replica are mapped to same metadata in local replica,
+// otherwise the points of source files would be restored into other metrics/fields/series.
+func (s *shard) RestoreFamily(familyTime int64, sourcePath string) error {
+	data, err := readFileFunc(filepath.Join(sourcePath, idMappingFile))
+	if err != nil {
+		return fmt.Errorf("read id mapping of family[%d] error, refuse restoring: %s", familyTime, err)
+	}
+	mapping := &familyIDMapping{}
+	if err := encoding.JSONUnmarshal(data, mapping); err != nil {
+		return fmt.Errorf("unmarshal id mapping of family[%d] error, refuse restoring: %s", familyTime, err)
+	}
+	resolver, err := newDigestResolver(s.metadata.MetadataDatabase(), s.indexDB)
+	if err != nil {
+		return fmt.Errorf("load metadata of shard[%d] error: %s", s.id, err)
+	}
+	if err := verifyIDMapping(mapping, resolver); err != nil {
+		return fmt.Errorf("ids of family[%d] are different with shard[%d], refuse restoring: %s", familyTime, s.id, err)
+	}
+	segment, err := s.segment.GetOrCreateSegment(s.interval.Calculator().GetSegment(familyTime))
+	if err != nil {
+		return err
+	}
+	family, err := segment.GetDataFamily(familyTime)
+	if err != nil {
+		return err
+	}
+	if err := family.Family().ReplaceFiles(sourcePath); err != nil {
+		return err
+	}
+	engineLogger.Info("restore family of shard successfully",
+		logger.String("shard", s.path), logger.Int64("family", familyTime))
+	return nil
+}
+
+// getDataFamily returns the data family of write interval by family time
+func (s *shard) getDataFamily(familyTime int64) (DataFamily, bool) {
+	for _, family := range s.segment.getDataFamilies(timeutil.TimeRange{Start: familyTime, End: familyTime}) {
+		if family.TimeRange().Start == familyTime {
+			return family, true
+		}
+	}
+	return nil, false
+}
+
+// metricDigestMeta represents the metadata of metric which is same in all replicas
+type metricDigestMeta struct {
+	hash       uint64                 // hash of namespace/metric name
+	fields     map[field.ID]fieldMeta // field id => hash of field name/aggregate function
+	tagsHashes map[uint32]uint64      // series id => tags hash
+}
+
+// fieldMeta represents the name, the hash of field name and the aggregate function of field
+type fieldMeta struct {
+	name    field.Name
+	hash    uint64
+	aggFunc field.AggFunc
+}
+
+// digestResolver resolves the metric/field/series ids which are generated in each replica
+// into the metric name/field name/tags hash which are same in all replicas.
+type digestResolver struct {
+	metadata metadb.MetadataDatabase
+	indexDB  indexdb.IndexDatabase
+	metrics  map[uint32]metadb.MetricName
+	metas    map[uint32]*metricDigestMeta
+}
+
+// newDigestResolver creates the digest resolver, syncs the pending metadata then loads all metric names
+func newDigestResolver(metadata metadb.MetadataDatabase, indexDB indexdb.IndexDatabase) (*digestResolver, error) {
+	if err := metadata.Sync(); err != nil {
+		return nil, err
+	}
+	metrics, err := metadata.GetAllMetrics()
+	if err != nil {
+		return nil, err
+	}
+	return &digestResolver{
+		metadata: metadata,
+		indexDB:  indexDB,
+		metrics:  metrics,
+		metas:    make(map[uint32]*metricDigestMeta),
+	}, nil
+}
+
+// getMetric returns the metadata of metric, returns false if metric not exist(dropped)
+func (r *digestResolver) getMetric(metricID uint32) (*metricDigestMeta, bool, error) {
+	if meta, ok := r.metas[metricID]; ok {
+		return meta, meta != nil, nil
+	}
+	metricName, ok := r.metrics[metricID]
+	if !ok {
+		r.metas[metricID] = nil
+		return nil, false, nil
+	}
+	fields, err := r.metadata.GetAllFields(metricName.Namespace, metricName.Name)
+	if err != nil && err != constants.ErrNotFound {
+		return nil, false, err
+	}
+	tagsHashes, err := r.indexDB.GetTagsHashes(metricID)
+	if err != nil {
+		return nil, false, err
+	}
+	meta := &metricDigestMeta{
+		hash:       xxhash.Sum64String(metricName.Namespace + "|" + metricName.Name),
+		fields:     make(map[field.ID]fieldMeta, len(fields)),
+		tagsHashes: tagsHashes,
+	}
+	for _, f := range fields {
+		meta.fields[f.ID] = fieldMeta{
+			name:    f.Name,
+			hash:    xxhash.Sum64String(string(f.Name)),
+			aggFunc: f.Type.GetAggFunc(),
+		}
+	}
+	r.metas[metricID] = meta
+	return meta, true, nil
+}
+
+// getTagsHash returns the tags hash of series, returns false if series not exist(deleted)
+func (m *metricDigestMeta) getTagsHash(seriesID uint32) (uint64, bool) {
+	if seriesID == constants.SeriesIDWithoutTags {
+		return 0, true
+	}
+	tagsHash, ok := m.tagsHashes[seriesID]
+	return tagsHash, ok
+}
+
+// pointKey represents the key of point in metric
+type pointKey struct {
+	seriesID uint32
+	fieldID  field.ID
+	slot     uint16
+}
+
+// digestFamily calculates the digest of all files in data family,
+// the digest is the sum of each series/point's hash, so the families with same data have same digest,
+// even if the data is stored in different files(flushed/compacted at different time).
+// the ids generated in replica are resolved into metric name/tags hash/field name, and the points
+// in same time slot of different files are merged by the aggregate function of field like compaction.
+func digestFamily(family DataFamily, resolver *digestResolver) (*models.FamilyDigest, error) {
+	snapshot := family.Family().GetSnapshot()
+	defer snapshot.Close()
+
+	files := snapshot.GetCurrent().GetAllFiles()
+	// merge the points by file number order, the value of newer file overwrites older one for replace function
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].GetFileNumber() < files[j].GetFileNumber()
+	})
+	digest := &models.FamilyDigest{
+		FamilyTime: family.TimeRange().Start,
+		NumOfFiles: len(files),
+	}
+	slots := make(map[uint16]*models.SlotDigest)
+	readers := make([]table.Reader, len(files))
+	metricIDs := roaring.New()
+	for idx, file := range files {
+		reader, err := snapshot.GetReader(file.GetFileNumber())
+		if err != nil {
+			return nil, err
+		}
+		readers[idx] = reader
+		it := reader.Iterator()
+		for it.HasNext() {
+			metricIDs.Add(it.Key())
+		}
+	}
+	// digest metric one by one, avoid keeping the points of all metrics in memory
+	it := metricIDs.Iterator()
+	for it.HasNext() {
+		metricID := it.Next()
+		meta, ok, err := resolver.getMetric(metricID)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		points, err := mergeMetricPoints(metricID, meta, readers)
+		if err != nil {
+			return nil, err
+		}
+		seriesIDs := roaring.New()
+		for key, value := range points {
+			tagsHash, _ := meta.getTagsHash(key.seriesID)
+			if !seriesIDs.Contains(key.seriesID) {
+				seriesIDs.Add(key.seriesID)
+				digest.NumOfSeries++
+				digest.SeriesHash += pointHash(meta.hash, tagsHash, 0, 0, 0)
+			}
+			hash := pointHash(meta.hash, tagsHash, meta.fields[key.fieldID].hash, key.slot, value)
+			digest.NumOfPoints++
+			digest.DataHash += hash
+			slot, ok := slots[key.slot]
+			if !ok {
+				slot = &models.SlotDigest{Slot: key.slot}
+				slots[key.slot] = slot
+			}
+			slot.NumOfPoints++
+			slot.DataHash += hash
+		}
+	}
+	for _, slot := range slots {
+		digest.Slots = append(digest.Slots, *slot)
+	}
+	sort.Slice(digest.Slots, func(i, j int) bool {
+		return digest.Slots[i].Slot < digest.Slots[j].Slot
+	})
+	return digest, nil
+}
+
+// mergeMetricPoints reads the points of metric from all files, merges the points with same key,
+// the points of deleted series or unknown fields are skipped.
+func mergeMetricPoints(metricID uint32, meta *metricDigestMeta, readers []table.Reader) (map[pointKey]float64, error) {
+	points := make(map[pointKey]float64)
+	for _, reader := range readers {
+		value, err := reader.Get(metricID)
+		if err == constants.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read metric[%d] of file[%s] error:%w", metricID, reader.Path(), err)
+		}
+		r, err := newReaderFunc(reader.Path(), value)
+		if err != nil {
+			return nil, err
+		}
+		if err := scanSeriesFunc(r, func(series *metricsdata.SeriesData) bool {
+			if _, ok := meta.getTagsHash(series.SeriesID); !ok {
+				return true
+			}
+			for _, fieldData := range series.Fields {
+				f, ok := meta.fields[fieldData.Field.ID]
+				if !ok {
+					continue
+				}
+				for idx, slot := range fieldData.Slots {
+					key := pointKey{seriesID: series.SeriesID, fieldID: fieldData.Field.ID, slot: slot}
+					value := fieldData.Values[idx]
+					if old, exist := points[key]; exist && f.aggFunc != nil {
+						value = f.aggFunc.Aggregate(old, value)
+					}
+					points[key] = value
+				}
+			}
+			return true
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return points, nil
+}
+
+// pointHash returns the hash of point, the hash of series if field/slot/value are zero
+func pointHash(metricHash, tagsHash, fieldHash uint64, slot uint16, value float64) uint64 {
+	var buf [34]byte
+	binary.LittleEndian.PutUint64(buf[0:], metricHash)
+	binary.LittleEndian.PutUint64(buf[8:], tagsHash)
+	binary.LittleEndian.PutUint64(buf[16:], fieldHash)
+	binary.LittleEndian.PutUint16(buf[24:], slot)
+	binary.LittleEndian.PutUint64(buf[26:], math.Float64bits(value))
+	return xxhash.Sum64(buf[:])
+}
+
+// familyIDMapping represents the metadata of the ids used in the files of data family
+type familyIDMapping struct {
+	Metrics []metricIDMapping `json:"metrics"`
+}
+
+// metricIDMapping represents the metric name, field names and tags hashes of metric id,
+// the name is empty if metric not exist(dropped).
+type metricIDMapping struct {
+	ID        uint32            `json:"id"`
+	Namespace string            `json:"namespace,omitempty"`
+	Name      string            `json:"name,omitempty"`
+	Fields    []fieldIDMapping  `json:"fields,omitempty"`
+	Series    []seriesIDMapping `json:"series,omitempty"`
+}
+
+// fieldIDMapping represents the field name of field id, the name is empty if field not exist
+type fieldIDMapping struct {
+	ID   field.ID   `json:"id"`
+	Name field.Name `json:"name,omitempty"`
+}
+
+// seriesIDMapping represents the tags hash of series id
+type seriesIDMapping struct {
+	ID       uint32 `json:"id"`
+	TagsHash uint64 `json:"tagsHash"`
+	Deleted  bool   `json:"deleted,omitempty"`
+}
+
+// buildIDMapping resolves the metric/field/series ids used in the sst files under path
+func buildIDMapping(path string, resolver *digestResolver) (*familyIDMapping, error) {
+	fileNames, err := listDirFunc(path)
+	if err != nil {
+		return nil, err
+	}
+	var readers []table.Reader
+	defer func() {
+		for _, reader := range readers {
+			_ = reader.Close()
+		}
+	}()
+	metricIDs := roaring.New()
+	for _, fileName := range fileNames {
+		desc := version.ParseFileName(fileName)
+		if desc == nil || desc.FileType != version.TypeTable {
+			continue
+		}
+		_, reader, err := inspectTableFunc(filepath.Join(path, fileName), table.VerifyOff)
+		if err != nil {
+			return nil, err
+		}
+		readers = append(readers, reader)
+		it := reader.Iterator()
+		for it.HasNext() {
+			metricIDs.Add(it.Key())
+		}
+	}
+	mapping := &familyIDMapping{}
+	it := metricIDs.Iterator()
+	for it.HasNext() {
+		metricID := it.Next()
+		metricMapping, err := buildMetricIDMapping(metricID, readers, resolver)
+		if err != nil {
+			return nil, err
+		}
+		mapping.Metrics = append(mapping.Metrics, metricMapping)
+	}
+	return mapping, nil
+}
+
+// buildMetricIDMapping resolves the field/series ids of metric in all files
+func buildMetricIDMapping(metricID uint32, readers []table.Reader, resolver *digestResolver) (metricIDMapping, error) {
+	result := metricIDMapping{ID: metricID}
+	meta, ok, err := resolver.getMetric(metricID)
+	if err != nil || !ok {
+		return result, err
+	}
+	metricName := resolver.metrics[metricID]
+	result.Namespace = metricName.Namespace
+	result.Name = metricName.Name
+	fieldIDs := make(map[field.ID]struct{})
+	seriesIDs := roaring.New()
+	for _, reader := range readers {
+		value, err := reader.Get(metricID)
+		if err == constants.ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("read metric[%d] of file[%s] error:%w", metricID, reader.Path(), err)
+		}
+		r, err := newReaderFunc(reader.Path(), value)
+		if err != nil {
+			return result, err
+		}
+		for _, f := range r.GetFields() {
+			fieldIDs[f.ID] = struct{}{}
+		}
+		seriesIDs.Or(r.GetSeriesIDs())
+	}
+	for fieldID := range fieldIDs {
+		result.Fields = append(result.Fields, fieldIDMapping{ID: fieldID, Name: meta.fields[fieldID].name})
+	}
+	sort.Slice(result.Fields, func(i, j int) bool {
+		return result.Fields[i].ID < result.Fields[j].ID
+	})
+	it := seriesIDs.Iterator()
+	for it.HasNext() {
+		seriesID := it.Next()
+		tagsHash, ok := meta.getTagsHash(seriesID)
+		result.Series = append(result.Series, seriesIDMapping{ID: seriesID, TagsHash: tagsHash, Deleted: !ok})
+	}
+	return result, nil
+}
+
+// verifyIDMapping checks if all ids in id mapping are mapped to same metadata by resolver of local replica
+func verifyIDMapping(mapping *familyIDMapping, resolver *digestResolver) error {
+	for _, metric := range mapping.Metrics {
+		meta, ok, err := resolver.getMetric(metric.ID)
+		if err != nil {
+			return err
+		}
+		metricName := resolver.metrics[metric.ID]
+		if metricName.Namespace != metric.Namespace || metricName.Name != metric.Name {
+			return fmt.Errorf("metric[%d] is %s/%s in source, but %s/%s in local",
+				metric.ID, metric.Namespace, metric.Name, metricName.Namespace, metricName.Name)
+		}
+		if !ok {
+			// metric is dropped in both replicas
+			continue
+		}
+		for _, f := range metric.Fields {
+			if name := meta.fields[f.ID].name; name != f.Name {
+				return fmt.Errorf("field[%d] of metric[%s] is %s in source, but %s in local", f.ID, metric.Name, f.Name, name)
+			}
+		}
+		for _, series := range metric.Series {
+			tagsHash, ok := meta.getTagsHash(series.ID)
+			if ok == series.Deleted || tagsHash != series.TagsHash {
+				return fmt.Errorf("series[%d] of metric[%s] is different in source and local", series.ID, metric.Name)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,524 @@
+package tsdb
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/kv/table"
+	"github.com/lindb/lindb/kv/version"
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/timeutil"
+	"github.com/lindb/lindb/series/field"
+	"github.com/lindb/lindb/tsdb/indexdb"
+	"github.com/lindb/lindb/tsdb/metadb"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+)
+
+func TestShard_Digest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newReaderFunc = metricsdata.NewReader
+		scanSeriesFunc = metricsdata.ScanSeries
+		ctrl.Finish()
+	}()
+	points := []*metricsdata.SeriesData{
+		newTestSeriesData(1, 1, []uint16{1, 2}, []float64{1, 2}),
+		newTestSeriesData(2, 1, []uint16{1}, []float64{3}),
+	}
+	var blocks [][]*metricsdata.SeriesData
+	mockMetricsDataReader(ctrl, &blocks)
+	segment := NewMockIntervalSegment(ctrl)
+	s := &shard{id: 1, segment: segment}
+	mockDigestMetadata(ctrl, s, 1, map[uint32]uint64{1: 100, 2: 200})
+
+	// case 1: same points in different files
+	segment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{
+		mockDigestFamily(ctrl, &blocks, 10, 1, [][]*metricsdata.SeriesData{points}),
+		mockDigestFamily(ctrl, &blocks, 20, 1, [][]*metricsdata.SeriesData{points[:1], points[1:]}),
+	})
+	digests, err := s.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.NoError(t, err)
+	assert.Len(t, digests, 2)
+	assert.Equal(t, int64(10), digests[0].FamilyTime)
+	assert.Equal(t, 1, digests[0].NumOfFiles)
+	assert.Equal(t, 2, digests[1].NumOfFiles)
+	assert.Equal(t, uint64(2), digests[0].NumOfSeries)
+	assert.Equal(t, uint64(3), digests[0].NumOfPoints)
+	assert.Len(t, digests[0].Slots, 2)
+	assert.Equal(t, uint16(1), digests[0].Slots[0].Slot)
+	assert.Equal(t, uint64(2), digests[0].Slots[0].NumOfPoints)
+	assert.True(t, digests[0].Equal(digests[1]))
+	// case 2: family partially out of time range is skipped
+	segment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{
+		mockDigestFamily(ctrl, &blocks, 10, 1, [][]*metricsdata.SeriesData{points}),
+		mockDigestFamily(ctrl, &blocks, 30, 1, [][]*metricsdata.SeriesData{points}),
+	})
+	digests, err = s.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.NoError(t, err)
+	assert.Len(t, digests, 1)
+	// case 3: value of point is different
+	segment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{
+		mockDigestFamily(ctrl, &blocks, 10, 1, [][]*metricsdata.SeriesData{points}),
+		mockDigestFamily(ctrl, &blocks, 20, 1, [][]*metricsdata.SeriesData{{points[0],
+			newTestSeriesData(2, 1, []uint16{1}, []float64{4})}}),
+	})
+	digests, err = s.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.NoError(t, err)
+	assert.Equal(t, digests[0].SeriesHash, digests[1].SeriesHash)
+	assert.NotEqual(t, digests[0].DataHash, digests[1].DataHash)
+	assert.NotEqual(t, digests[0].Slots[0], digests[1].Slots[0])
+	assert.Equal(t, digests[0].Slots[1], digests[1].Slots[1])
+	assert.False(t, digests[0].Equal(digests[1]))
+	// case 4: points in same slot of different files are merged
+	segment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{
+		mockDigestFamily(ctrl, &blocks, 10, 1, [][]*metricsdata.SeriesData{points}),
+		mockDigestFamily(ctrl, &blocks, 20, 1, [][]*metricsdata.SeriesData{
+			{newTestSeriesData(1, 1, []uint16{1, 2}, []float64{0.5, 2}), points[1]},
+			{newTestSeriesData(1, 1, []uint16{1}, []float64{0.5})},
+		}),
+	})
+	digests, err = s.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.NoError(t, err)
+	assert.True(t, digests[0].Equal(digests[1]))
+	// case 5: points of unknown metric/series/field are skipped
+	segment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{
+		mockDigestFamily(ctrl, &blocks, 10, 1, [][]*metricsdata.SeriesData{points}),
+		mockDigestFamily(ctrl, &blocks, 20, 1, [][]*metricsdata.SeriesData{append(points,
+			newTestSeriesData(3, 1, []uint16{1}, []float64{1}),
+			newTestSeriesData(1, 2, []uint16{1}, []float64{1}))}),
+		mockDigestFamily(ctrl, &blocks, 30, 2, [][]*metricsdata.SeriesData{points}),
+	})
+	digests, err = s.Digest(timeutil.TimeRange{Start: 10, End: 40})
+	assert.NoError(t, err)
+	assert.True(t, digests[0].Equal(digests[1]))
+	assert.Zero(t, digests[2].NumOfPoints)
+	// case 6: scan series err
+	segment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{
+		mockDigestFamily(ctrl, &blocks, 10, 1, [][]*metricsdata.SeriesData{points}),
+	})
+	scanSeriesFunc = func(r metricsdata.Reader, fn func(series *metricsdata.SeriesData) bool) error {
+		return fmt.Errorf("err")
+	}
+	digests, err = s.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.Error(t, err)
+	assert.Nil(t, digests)
+	// case 7: new reader err
+	segment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{
+		mockDigestFamily(ctrl, &blocks, 10, 1, [][]*metricsdata.SeriesData{points}),
+	})
+	newReaderFunc = func(path string, buf []byte) (metricsdata.Reader, error) {
+		return nil, fmt.Errorf("err")
+	}
+	_, err = s.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.Error(t, err)
+}
+
+func TestShard_Digest_ReplicaIDs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newReaderFunc = metricsdata.NewReader
+		scanSeriesFunc = metricsdata.ScanSeries
+		ctrl.Finish()
+	}()
+	var blocks [][]*metricsdata.SeriesData
+	mockMetricsDataReader(ctrl, &blocks)
+	// metric/series ids of same data are different in each replica
+	segment1 := NewMockIntervalSegment(ctrl)
+	s1 := &shard{id: 1, segment: segment1}
+	mockDigestMetadata(ctrl, s1, 1, map[uint32]uint64{1: 100, 2: 200})
+	segment1.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{
+		mockDigestFamily(ctrl, &blocks, 10, 1, [][]*metricsdata.SeriesData{{
+			newTestSeriesData(1, 1, []uint16{1}, []float64{1}),
+			newTestSeriesData(2, 1, []uint16{1}, []float64{2}),
+		}}),
+	})
+	segment2 := NewMockIntervalSegment(ctrl)
+	s2 := &shard{id: 1, segment: segment2}
+	mockDigestMetadata(ctrl, s2, 5, map[uint32]uint64{1: 200, 2: 100})
+	segment2.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{
+		mockDigestFamily(ctrl, &blocks, 10, 5, [][]*metricsdata.SeriesData{{
+			newTestSeriesData(1, 1, []uint16{1}, []float64{2}),
+			newTestSeriesData(2, 1, []uint16{1}, []float64{1}),
+		}}),
+	})
+	digests1, err := s1.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.NoError(t, err)
+	digests2, err := s2.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.NoError(t, err)
+	assert.True(t, digests1[0].Equal(digests2[0]))
+}
+
+func TestShard_Digest_Metadata_Fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	segment := NewMockIntervalSegment(ctrl)
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	s := &shard{id: 1, segment: segment, metadata: metadata, indexDB: indexDB}
+	family := NewMockDataFamily(ctrl)
+	kvFamily := kv.NewMockFamily(ctrl)
+	snapshot := version.NewMockSnapshot(ctrl)
+	v := version.NewMockVersion(ctrl)
+	reader := table.NewMockReader(ctrl)
+	family.EXPECT().Family().Return(kvFamily).AnyTimes()
+	family.EXPECT().TimeRange().Return(timeutil.TimeRange{Start: 10, End: 20}).AnyTimes()
+	kvFamily.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
+	snapshot.EXPECT().Close().AnyTimes()
+	snapshot.EXPECT().GetCurrent().Return(v).AnyTimes()
+	snapshot.EXPECT().GetReader(gomock.Any()).Return(reader, nil).AnyTimes()
+	reader.EXPECT().Iterator().DoAndReturn(func() table.Iterator {
+		it := table.NewMockIterator(ctrl)
+		gomock.InOrder(
+			it.EXPECT().HasNext().Return(true),
+			it.EXPECT().HasNext().Return(false),
+		)
+		it.EXPECT().Key().Return(uint32(1))
+		return it
+	}).AnyTimes()
+	v.EXPECT().GetAllFiles().Return([]*version.FileMeta{version.NewFileMeta(1, 1, 1, 100)}).AnyTimes()
+	segment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{family}).AnyTimes()
+	// case 1: sync metadata err
+	metadataDB.EXPECT().Sync().Return(fmt.Errorf("err"))
+	_, err := s.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.Error(t, err)
+	// case 2: get all metrics err
+	metadataDB.EXPECT().Sync().Return(nil).AnyTimes()
+	metadataDB.EXPECT().GetAllMetrics().Return(nil, fmt.Errorf("err"))
+	_, err = s.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.Error(t, err)
+	// case 3: get all fields err
+	metadataDB.EXPECT().GetAllMetrics().Return(map[uint32]metadb.MetricName{1: {Namespace: "ns", Name: "name"}}, nil).AnyTimes()
+	metadataDB.EXPECT().GetAllFields("ns", "name").Return(nil, fmt.Errorf("err"))
+	_, err = s.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.Error(t, err)
+	// case 4: get tags hashes err
+	metadataDB.EXPECT().GetAllFields("ns", "name").Return(nil, nil).AnyTimes()
+	indexDB.EXPECT().GetTagsHashes(uint32(1)).Return(nil, fmt.Errorf("err"))
+	_, err = s.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.Error(t, err)
+}
+
+func TestShard_Digest_ReadFile_Fail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	segment := NewMockIntervalSegment(ctrl)
+	s := &shard{id: 1, segment: segment}
+	mockDigestMetadata(ctrl, s, 1, nil)
+	family := NewMockDataFamily(ctrl)
+	kvFamily := kv.NewMockFamily(ctrl)
+	snapshot := version.NewMockSnapshot(ctrl)
+	v := version.NewMockVersion(ctrl)
+	family.EXPECT().Family().Return(kvFamily).AnyTimes()
+	family.EXPECT().TimeRange().Return(timeutil.TimeRange{Start: 10, End: 20}).AnyTimes()
+	kvFamily.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
+	snapshot.EXPECT().Close().AnyTimes()
+	snapshot.EXPECT().GetCurrent().Return(v).AnyTimes()
+	v.EXPECT().GetAllFiles().Return([]*version.FileMeta{version.NewFileMeta(1, 1, 1, 100)}).AnyTimes()
+	segment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{family}).AnyTimes()
+	// case 1: get reader err
+	snapshot.EXPECT().GetReader(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	_, err := s.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.Error(t, err)
+	// case 2: read value err
+	reader := table.NewMockReader(ctrl)
+	it := table.NewMockIterator(ctrl)
+	snapshot.EXPECT().GetReader(gomock.Any()).Return(reader, nil)
+	reader.EXPECT().Iterator().Return(it)
+	reader.EXPECT().Path().Return("1.sst").AnyTimes()
+	gomock.InOrder(
+		it.EXPECT().HasNext().Return(true),
+		it.EXPECT().HasNext().Return(false),
+	)
+	it.EXPECT().Key().Return(uint32(1))
+	reader.EXPECT().Get(uint32(1)).Return(nil, fmt.Errorf("err"))
+	_, err = s.Digest(timeutil.TimeRange{Start: 10, End: 30})
+	assert.Error(t, err)
+}
+
+func TestShard_BackupFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newReaderFunc = metricsdata.NewReader
+		inspectTableFunc = table.Inspect
+		listDirFunc = fileutil.ListDir
+		writeFileFunc = ioutil.WriteFile
+		ctrl.Finish()
+	}()
+
+	segment := NewMockIntervalSegment(ctrl)
+	s := &shard{id: 1, segment: segment}
+	mockDigestMetadata(ctrl, s, 1, map[uint32]uint64{1: 100})
+	family := NewMockDataFamily(ctrl)
+	kvFamily := kv.NewMockFamily(ctrl)
+	family.EXPECT().Family().Return(kvFamily).AnyTimes()
+	family.EXPECT().TimeRange().Return(timeutil.TimeRange{Start: 10, End: 20}).AnyTimes()
+	segment.EXPECT().getDataFamilies(gomock.Any()).Return([]DataFamily{family}).AnyTimes()
+
+	// case 1: family not found
+	assert.Error(t, s.BackupFamily(20, "backup"))
+	// case 2: backup family files err
+	kvFamily.EXPECT().BackupFiles("backup").Return(fmt.Errorf("err"))
+	assert.Error(t, s.BackupFamily(10, "backup"))
+	// case 3: list backup files err
+	kvFamily.EXPECT().BackupFiles("backup").Return(nil).AnyTimes()
+	listDirFunc = func(path string) ([]string, error) {
+		return nil, fmt.Errorf("err")
+	}
+	assert.Error(t, s.BackupFamily(10, "backup"))
+	// case 4: open backup file err
+	listDirFunc = func(path string) ([]string, error) {
+		return []string{version.Table(1), "LOCK"}, nil
+	}
+	inspectTableFunc = func(path string, verifyMode table.VerifyMode) (*table.FileInfo, table.Reader, error) {
+		return nil, nil, fmt.Errorf("err")
+	}
+	assert.Error(t, s.BackupFamily(10, "backup"))
+	// case 5: read metric err
+	reader := mockIDMappingReader(ctrl, 1, 2)
+	inspectTableFunc = func(path string, verifyMode table.VerifyMode) (*table.FileInfo, table.Reader, error) {
+		assert.Equal(t, filepath.Join("backup", version.Table(1)), path)
+		return &table.FileInfo{}, reader, nil
+	}
+	reader.EXPECT().Get(uint32(1)).Return(nil, fmt.Errorf("err"))
+	assert.Error(t, s.BackupFamily(10, "backup"))
+	// case 6: write id mapping, metric 2 is dropped, series 2 is deleted and field 2 not exist
+	reader.EXPECT().Get(uint32(1)).Return([]byte{1}, nil).AnyTimes()
+	newReaderFunc = func(path string, buf []byte) (metricsdata.Reader, error) {
+		r := metricsdata.NewMockReader(ctrl)
+		r.EXPECT().GetFields().Return(field.Metas{{ID: 2}, {ID: 1}})
+		r.EXPECT().GetSeriesIDs().Return(roaring.BitmapOf(1, 2))
+		return r, nil
+	}
+	var data []byte
+	writeFileFunc = func(filename string, d []byte, perm os.FileMode) error {
+		assert.Equal(t, filepath.Join("backup", idMappingFile), filename)
+		data = d
+		return nil
+	}
+	assert.NoError(t, s.BackupFamily(10, "backup"))
+	mapping := &familyIDMapping{}
+	assert.NoError(t, encoding.JSONUnmarshal(data, mapping))
+	assert.Equal(t, &familyIDMapping{Metrics: []metricIDMapping{
+		{ID: 1, Namespace: "ns", Name: "name",
+			Fields: []fieldIDMapping{{ID: 1, Name: "f1"}, {ID: 2}},
+			Series: []seriesIDMapping{{ID: 1, TagsHash: 100}, {ID: 2, Deleted: true}},
+		},
+		{ID: 2},
+	}}, mapping)
+	// case 7: new metrics data reader err
+	newReaderFunc = func(path string, buf []byte) (metricsdata.Reader, error) {
+		return nil, fmt.Errorf("err")
+	}
+	assert.Error(t, s.BackupFamily(10, "backup"))
+}
+
+func TestShard_RestoreFamily(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		readFileFunc = ioutil.ReadFile
+		ctrl.Finish()
+	}()
+
+	segment := NewMockIntervalSegment(ctrl)
+	s := &shard{id: 1, segment: segment, interval: timeutil.Interval(10 * timeutil.OneSecond), path: "shard"}
+	mockDigestMetadata(ctrl, s, 1, map[uint32]uint64{1: 100})
+	seg := NewMockSegment(ctrl)
+	family := NewMockDataFamily(ctrl)
+	kvFamily := kv.NewMockFamily(ctrl)
+	family.EXPECT().Family().Return(kvFamily).AnyTimes()
+
+	// case 1: id mapping not found
+	readFileFunc = func(filename string) ([]byte, error) {
+		return nil, fmt.Errorf("err")
+	}
+	assert.Error(t, s.RestoreFamily(10, "source"))
+	// case 2: unmarshal id mapping err
+	readFileFunc = func(filename string) ([]byte, error) {
+		return []byte("abc"), nil
+	}
+	assert.Error(t, s.RestoreFamily(10, "source"))
+	// case 3: ids are different
+	mapping := &familyIDMapping{Metrics: []metricIDMapping{{ID: 1, Namespace: "ns", Name: "name2"}}}
+	readFileFunc = func(filename string) ([]byte, error) {
+		assert.Equal(t, filepath.Join("source", idMappingFile), filename)
+		return encoding.JSONMarshal(mapping), nil
+	}
+	assert.Error(t, s.RestoreFamily(10, "source"))
+	// case 4: get segment err
+	mapping.Metrics[0].Name = "name"
+	segment.EXPECT().GetOrCreateSegment(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	assert.Error(t, s.RestoreFamily(10, "source"))
+	// case 5: get family err
+	segment.EXPECT().GetOrCreateSegment(gomock.Any()).Return(seg, nil).AnyTimes()
+	seg.EXPECT().GetDataFamily(int64(10)).Return(nil, fmt.Errorf("err"))
+	assert.Error(t, s.RestoreFamily(10, "source"))
+	// case 6: replace files err
+	seg.EXPECT().GetDataFamily(int64(10)).Return(family, nil).AnyTimes()
+	kvFamily.EXPECT().ReplaceFiles("source").Return(fmt.Errorf("err"))
+	assert.Error(t, s.RestoreFamily(10, "source"))
+	// case 7: restore successfully
+	kvFamily.EXPECT().ReplaceFiles("source").Return(nil)
+	assert.NoError(t, s.RestoreFamily(10, "source"))
+}
+
+func TestVerifyIDMapping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := &shard{id: 1}
+	mockDigestMetadata(ctrl, s, 1, map[uint32]uint64{1: 100})
+	resolver, err := newDigestResolver(s.metadata.MetadataDatabase(), s.indexDB)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name    string
+		metric  metricIDMapping
+		wantErr bool
+	}{
+		{name: "same ids", metric: metricIDMapping{ID: 1, Namespace: "ns", Name: "name",
+			Fields: []fieldIDMapping{{ID: 1, Name: "f1"}, {ID: 2}},
+			Series: []seriesIDMapping{{ID: 1, TagsHash: 100}, {ID: 2, Deleted: true}}}},
+		{name: "metric dropped in both replicas", metric: metricIDMapping{ID: 2}},
+		{name: "metric dropped in source", metric: metricIDMapping{ID: 1}, wantErr: true},
+		{name: "metric not exist in local", metric: metricIDMapping{ID: 2, Namespace: "ns", Name: "name"}, wantErr: true},
+		{name: "different field", metric: metricIDMapping{ID: 1, Namespace: "ns", Name: "name",
+			Fields: []fieldIDMapping{{ID: 1, Name: "f2"}}}, wantErr: true},
+		{name: "field not exist in local", metric: metricIDMapping{ID: 1, Namespace: "ns", Name: "name",
+			Fields: []fieldIDMapping{{ID: 2, Name: "f2"}}}, wantErr: true},
+		{name: "different series", metric: metricIDMapping{ID: 1, Namespace: "ns", Name: "name",
+			Series: []seriesIDMapping{{ID: 1, TagsHash: 200}}}, wantErr: true},
+		{name: "series deleted in source", metric: metricIDMapping{ID: 1, Namespace: "ns", Name: "name",
+			Series: []seriesIDMapping{{ID: 1, Deleted: true}}}, wantErr: true},
+		{name: "series not exist in local", metric: metricIDMapping{ID: 1, Namespace: "ns", Name: "name",
+			Series: []seriesIDMapping{{ID: 2, TagsHash: 200}}}, wantErr: true},
+	}
+	for _, tt := range cases {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyIDMapping(&familyIDMapping{Metrics: []metricIDMapping{tt.metric}}, resolver)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("verifyIDMapping() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// mockIDMappingReader mocks the reader of backup file which includes the metric ids
+func mockIDMappingReader(ctrl *gomock.Controller, metricIDs ...uint32) *table.MockReader {
+	reader := table.NewMockReader(ctrl)
+	reader.EXPECT().Path().Return("1.sst").AnyTimes()
+	reader.EXPECT().Close().Return(nil).AnyTimes()
+	reader.EXPECT().Get(uint32(2)).Return(nil, constants.ErrNotFound).AnyTimes()
+	reader.EXPECT().Iterator().DoAndReturn(func() table.Iterator {
+		it := table.NewMockIterator(ctrl)
+		var calls []*gomock.Call
+		for _, metricID := range metricIDs {
+			calls = append(calls, it.EXPECT().HasNext().Return(true), it.EXPECT().Key().Return(metricID))
+		}
+		calls = append(calls, it.EXPECT().HasNext().Return(false))
+		gomock.InOrder(calls...)
+		return it
+	}).AnyTimes()
+	return reader
+}
+
+// mockDigestFamily mocks the data family, each file includes the series data of metric
+func mockDigestFamily(ctrl *gomock.Controller, blocks *[][]*metricsdata.SeriesData, familyTime int64,
+	metricID uint32, files [][]*metricsdata.SeriesData) DataFamily {
+	family := NewMockDataFamily(ctrl)
+	kvFamily := kv.NewMockFamily(ctrl)
+	snapshot := version.NewMockSnapshot(ctrl)
+	v := version.NewMockVersion(ctrl)
+	family.EXPECT().Family().Return(kvFamily).AnyTimes()
+	family.EXPECT().TimeRange().Return(timeutil.TimeRange{Start: familyTime, End: familyTime + 10}).AnyTimes()
+	kvFamily.EXPECT().GetSnapshot().Return(snapshot).AnyTimes()
+	snapshot.EXPECT().Close().AnyTimes()
+	snapshot.EXPECT().GetCurrent().Return(v).AnyTimes()
+
+	var fileMetas []*version.FileMeta
+	for idx, series := range files {
+		fileNumber := table.FileNumber(idx)
+		fileMetas = append(fileMetas, version.NewFileMeta(fileNumber, 1, 1, 100))
+		reader := table.NewMockReader(ctrl)
+		it := table.NewMockIterator(ctrl)
+		snapshot.EXPECT().GetReader(fileNumber).Return(reader, nil).AnyTimes()
+		reader.EXPECT().Path().Return(fmt.Sprintf("%d.sst", idx)).AnyTimes()
+		reader.EXPECT().Iterator().Return(it).AnyTimes()
+		gomock.InOrder(
+			it.EXPECT().HasNext().Return(true).MaxTimes(1),
+			it.EXPECT().HasNext().Return(false).MaxTimes(1),
+		)
+		it.EXPECT().Key().Return(metricID).AnyTimes()
+		// value of metric block is the index of series data in test blocks
+		reader.EXPECT().Get(metricID).Return([]byte{byte(len(*blocks))}, nil).AnyTimes()
+		*blocks = append(*blocks, series)
+	}
+	v.EXPECT().GetAllFiles().Return(fileMetas).AnyTimes()
+	return family
+}
+
+// mockDigestMetadata mocks the metadata of shard, the metric(ns/name) includes a sum field(f1),
+// the other metric id is unknown.
+func mockDigestMetadata(ctrl *gomock.Controller, s *shard, metricID uint32, tagsHashes map[uint32]uint64) {
+	metadata := metadb.NewMockMetadata(ctrl)
+	metadataDB := metadb.NewMockMetadataDatabase(ctrl)
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	metadata.EXPECT().MetadataDatabase().Return(metadataDB).AnyTimes()
+	metadataDB.EXPECT().Sync().Return(nil).AnyTimes()
+	metadataDB.EXPECT().GetAllMetrics().Return(map[uint32]metadb.MetricName{
+		metricID: {Namespace: "ns", Name: "name"},
+	}, nil).AnyTimes()
+	metadataDB.EXPECT().GetAllFields("ns", "name").Return([]field.Meta{
+		{ID: 1, Name: "f1", Type: field.SumField},
+	}, nil).AnyTimes()
+	indexDB.EXPECT().GetTagsHashes(metricID).Return(tagsHashes, nil).AnyTimes()
+	s.metadata = metadata
+	s.indexDB = indexDB
+}
+
+// mockMetricsDataReader mocks the metrics data reader/scanner which reads the series data from test blocks
+func mockMetricsDataReader(ctrl *gomock.Controller, blocks *[][]*metricsdata.SeriesData) {
+	readers := make(map[metricsdata.Reader][]*metricsdata.SeriesData)
+	newReaderFunc = func(path string, buf []byte) (metricsdata.Reader, error) {
+		series := (*blocks)[buf[0]]
+		r := metricsdata.NewMockReader(ctrl)
+		seriesIDs := roaring.New()
+		for _, s := range series {
+			seriesIDs.Add(s.SeriesID)
+		}
+		r.EXPECT().GetSeriesIDs().Return(seriesIDs).AnyTimes()
+		readers[r] = series
+		return r, nil
+	}
+	scanSeriesFunc = func(r metricsdata.Reader, fn func(series *metricsdata.SeriesData) bool) error {
+		for _, s := range readers[r] {
+			fn(s)
+		}
+		return nil
+	}
+}
+
+func newTestSeriesData(seriesID uint32, fieldID field.ID, slots []uint16, values []float64) *metricsdata.SeriesData {
+	return &metricsdata.SeriesData{
+		SeriesID: seriesID,
+		Fields: []metricsdata.FieldData{{
+			Field:  field.Meta{ID: fieldID},
+			Slots:  slots,
+			Values: values,
+		}},
+	}
+}
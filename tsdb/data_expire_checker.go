@@ -0,0 +1,90 @@
+package tsdb
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"go.uber.org/atomic"
+
+	"github.com/lindb/lindb/monitoring"
+	"github.com/lindb/lindb/pkg/logger"
+)
+
+//go:generate mockgen -source=./data_expire_checker.go -destination=./data_expire_checker_mock.go -package=tsdb
+
+var (
+	// can be modified in runtime
+	dataExpireCheckInterval = *atomic.NewDuration(10 * time.Minute)
+)
+
+var (
+	dataExpireFailCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "shard_data_expire_failures",
+			Help: "Expire shard data failures.",
+		},
+		[]string{"db"},
+	)
+)
+
+func init() {
+	monitoring.StorageRegistry.MustRegister(dataExpireFailCounter)
+}
+
+// DataExpireChecker represents the data retention checker,
+// which removes the expired segments and data families of each shard periodically.
+type DataExpireChecker interface {
+	// Start starts the checker goroutine in background
+	Start()
+	// Stop stops the background check goroutine
+	Stop()
+}
+
+// dataExpireChecker implements DataExpireChecker interface
+type dataExpireChecker struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// newDataExpireChecker creates the data expire checker
+func newDataExpireChecker(ctx context.Context) DataExpireChecker {
+	c, cancel := context.WithCancel(ctx)
+	return &dataExpireChecker{
+		ctx:    c,
+		cancel: cancel,
+	}
+}
+
+// Start starts the checker goroutine in background
+func (ec *dataExpireChecker) Start() {
+	go ec.startCheckDataExpire()
+}
+
+// Stop stops the background check goroutine
+func (ec *dataExpireChecker) Stop() {
+	ec.cancel()
+}
+
+// startCheckDataExpire checks each shard's data if expired periodically
+func (ec *dataExpireChecker) startCheckDataExpire() {
+	timer := time.NewTimer(dataExpireCheckInterval.Load())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ec.ctx.Done():
+			return
+		case <-timer.C:
+			GetShardManager().WalkEntry(func(shard Shard) {
+				if err := shard.ExpireData(); err != nil {
+					dataExpireFailCounter.WithLabelValues(shard.DatabaseName()).Inc()
+					engineLogger.Error("expire shard data error",
+						logger.String("shard", shard.ShardInfo()), logger.Error(err))
+				}
+			})
+			// reset check interval
+			timer.Reset(dataExpireCheckInterval.Load())
+		}
+	}
+}
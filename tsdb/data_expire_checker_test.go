@@ -0,0 +1,30 @@
+package tsdb
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestDataExpireChecker_Start(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		dataExpireCheckInterval.Store(10 * time.Minute)
+		ctrl.Finish()
+	}()
+	shard := NewMockShard(ctrl)
+	shard.EXPECT().ShardInfo().Return("shardInfo").AnyTimes()
+	shard.EXPECT().DatabaseName().Return("db").AnyTimes()
+	shard.EXPECT().ExpireData().Return(fmt.Errorf("err")).AnyTimes()
+	GetShardManager().AddShard(shard)
+	dataExpireCheckInterval.Store(10 * time.Millisecond)
+	checker := newDataExpireChecker(context.TODO())
+	checker.Start()
+
+	time.Sleep(100 * time.Millisecond)
+	checker.Stop()
+	GetShardManager().RemoveShard(shard)
+}
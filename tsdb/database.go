@@ -6,6 +6,7 @@ import (
 	"io"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -44,6 +45,8 @@ type Database interface {
 	Name() string
 	// NumOfShards returns number of shards in time series database
 	NumOfShards() int
+	// ShardIDs returns the sorted ids of shards in time series database
+	ShardIDs() []int32
 	// GetOption returns the data base options
 	GetOption() option.DatabaseOption
 	// CreateShards creates shards for data partition
@@ -60,6 +63,14 @@ type Database interface {
 	FlushMeta() error
 	// FLush flushes memory data of all shards to disk
 	Flush() error
+	// Backup backups metadata and all shards of database into target path, then writes backup manifest
+	Backup(targetPath string) (*BackupManifest, error)
+	// BackupShards backups metadata and the spec shards with replica sequences into target path,
+	// which is used to build new replica of shards on other node.
+	BackupShards(targetPath string, shardIDs []int32) (*BackupManifest, error)
+	// Compact triggers full compaction of the kv families in meta store and all shards by family name,
+	// triggers all families if family name is empty.
+	Compact(familyName string)
 }
 
 // databaseConfig represents a database configuration about config and shards
@@ -159,6 +170,19 @@ func (db *database) NumOfShards() int {
 	return int(db.numOfShards.Load())
 }
 
+// ShardIDs returns the sorted ids of shards in time series database
+func (db *database) ShardIDs() []int32 {
+	var shardIDs []int32
+	db.shards.Range(func(key, value interface{}) bool {
+		shardIDs = append(shardIDs, key.(int32))
+		return true
+	})
+	sort.Slice(shardIDs, func(i, j int) bool {
+		return shardIDs[i] < shardIDs[j]
+	})
+	return shardIDs
+}
+
 func (db *database) GetOption() option.DatabaseOption {
 	return db.config.Option
 }
@@ -305,6 +329,19 @@ func (db *database) Flush() error {
 	return nil
 }
 
+// Compact triggers full compaction of the kv families in meta store and all shards by family name,
+// triggers all families if family name is empty.
+func (db *database) Compact(familyName string) {
+	db.metaStore.Compact(familyName)
+	db.shards.Range(func(key, value interface{}) bool {
+		shard := value.(Shard)
+		shard.Compact(familyName)
+		return true
+	})
+	engineLogger.Info("trigger full compaction of database",
+		logger.String("db", db.name), logger.String("family", familyName))
+}
+
 // optionsPath returns options file path
 func optionsPath(path string) string {
 	return filepath.Join(path, options)
@@ -90,6 +90,7 @@ func TestDatabase_New(t *testing.T) {
 	assert.NotNil(t, db.ExecutorPool())
 	assert.Equal(t, option.DatabaseOption{Interval: "10s"}, db.GetOption())
 	assert.Equal(t, 3, db.NumOfShards())
+	assert.Equal(t, []int32{1, 2, 3}, db.ShardIDs())
 	kvStore.EXPECT().Close().Return(nil).AnyTimes() // include shard close
 	err = db.Close()
 	assert.NoError(t, err)
@@ -233,3 +234,19 @@ func TestDatabase_Flush(t *testing.T) {
 	err = db.Flush()
 	assert.NoError(t, err)
 }
+
+func TestDatabase_Compact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	metaStore := kv.NewMockStore(ctrl)
+	shard1 := NewMockShard(ctrl)
+	shard2 := NewMockShard(ctrl)
+	db := &database{name: "db", metaStore: metaStore}
+	db.shards.Store(int32(1), shard1)
+	db.shards.Store(int32(2), shard2)
+	metaStore.EXPECT().Compact("")
+	shard1.EXPECT().Compact("")
+	shard2.EXPECT().Compact("")
+	db.Compact("")
+}
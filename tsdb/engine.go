@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/lindb/lindb/config"
+	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/pkg/fileutil"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/ltoml"
@@ -18,6 +19,7 @@ import (
 var (
 	mkDirIfNotExist = fileutil.MkDirIfNotExist
 	listDir         = fileutil.ListDir
+	removeDir       = fileutil.RemoveDir
 	decodeToml      = ltoml.DecodeToml
 	newDatabaseFunc = newDatabase
 )
@@ -33,6 +35,13 @@ type Engine interface {
 	GetDatabase(databaseName string) (Database, bool)
 	// FLushDatabase produces a signal to workers for flushing memory database by name
 	FlushDatabase(ctx context.Context, databaseName string) bool
+	// DropDatabase closes the database by given name, then removes all data of it(shards/segments/metadata)
+	DropDatabase(databaseName string) error
+	// BackupDatabase backups the database into target path, returns the manifest of backup
+	BackupDatabase(databaseName string, targetPath string) (*BackupManifest, error)
+	// RestoreDatabase restores the backup as a new database, uses the database name of backup if name is empty.
+	// if shard mapping isn't empty, the shards of backup are restored as new shard ids based on mapping.
+	RestoreDatabase(backupPath string, databaseName string, shardMapping map[int32]int32) (Database, error)
 	// Close closes the cached time series databases
 	Close()
 
@@ -43,11 +52,12 @@ type Engine interface {
 
 // engine implements Engine
 type engine struct {
-	cfg              config.TSDB        // the common cfg of time series database
-	databases        sync.Map           // databaseName -> Database
-	ctx              context.Context    // context
-	cancel           context.CancelFunc // cancel function of flusher
-	dataFlushChecker DataFlushChecker
+	cfg               config.TSDB        // the common cfg of time series database
+	databases         sync.Map           // databaseName -> Database
+	ctx               context.Context    // context
+	cancel            context.CancelFunc // cancel function of flusher
+	dataFlushChecker  DataFlushChecker
+	dataExpireChecker DataExpireChecker
 }
 
 // NewEngine creates an engine for manipulating the databases
@@ -65,12 +75,29 @@ func newEngine(cfg config.TSDB) (*engine, error) {
 	if err := mkDirIfNotExist(cfg.Dir); err != nil {
 		return nil, fmt.Errorf("create time sereis storage path[%s] erorr: %s", cfg.Dir, err)
 	}
+	// set checksum verification mode of kv stores
+	if err := kv.SetDefaultChecksumVerify(cfg.ChecksumVerify); err != nil {
+		return nil, err
+	}
+	// set compression type of kv families
+	if err := kv.SetDefaultCompression(cfg.Compression); err != nil {
+		return nil, err
+	}
+	// set concurrency and rate limit of compaction jobs in all kv stores
+	kv.GetCompactScheduler().SetOption(kv.CompactSchedulerOption{
+		Concurrency: cfg.CompactConcurrency,
+		RateLimit:   cfg.CompactRateLimit,
+	})
+	// set limit of sst file reader cache shared by all kv stores
+	kv.SetTableCacheOption(cfg.CacheMaxFiles, int64(cfg.CacheMaxSize)*1024*1024)
 	e := &engine{
 		cfg: cfg,
 	}
 	e.ctx, e.cancel = context.WithCancel(context.Background())
 	e.dataFlushChecker = newDataFlushChecker(e.ctx)
 	e.dataFlushChecker.Start()
+	e.dataExpireChecker = newDataExpireChecker(e.ctx)
+	e.dataExpireChecker.Start()
 
 	if err := e.load(); err != nil {
 		engineLogger.Error("load engine data error when create a new engine", logger.Error(err))
@@ -116,6 +143,9 @@ func (e *engine) Close() {
 	if e.dataFlushChecker != nil {
 		e.dataFlushChecker.Stop()
 	}
+	if e.dataExpireChecker != nil {
+		e.dataExpireChecker.Stop()
+	}
 
 	e.databases.Range(func(key, value interface{}) bool {
 		db := value.(Database)
@@ -140,6 +170,26 @@ func (e *engine) FlushDatabase(ctx context.Context, name string) bool {
 	return true
 }
 
+// DropDatabase closes the database by given name, then removes all data of it(shards/segments/metadata)
+func (e *engine) DropDatabase(databaseName string) error {
+	item, ok := e.databases.Load(databaseName)
+	if ok {
+		// remove database from cache first, make sure new write/query cannot get it
+		e.databases.Delete(databaseName)
+		db := item.(Database)
+		if err := db.Close(); err != nil {
+			engineLogger.Warn("close database error when drop database",
+				logger.String("db", databaseName), logger.Error(err))
+		}
+	}
+	dbPath := filepath.Join(e.cfg.Dir, databaseName)
+	if err := removeDir(dbPath); err != nil {
+		return fmt.Errorf("remove database[%s]'s path with error: %s", databaseName, err)
+	}
+	engineLogger.Info("drop database successfully", logger.String("db", databaseName))
+	return nil
+}
+
 // load loads the time series engines if exist
 func (e *engine) load() error {
 	databaseNames, err := listDir(e.cfg.Dir)
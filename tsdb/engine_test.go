@@ -33,6 +33,16 @@ func TestNew(t *testing.T) {
 	assert.Nil(t, e)
 	mkDirIfNotExist = fileutil.MkDirIfNotExist
 
+	// test new error when checksum verify mode is invalid
+	e, err = NewEngine(config.TSDB{Dir: testPath, ChecksumVerify: "unknown"})
+	assert.Error(t, err)
+	assert.Nil(t, e)
+
+	// test new error when compression type is invalid
+	e, err = NewEngine(config.TSDB{Dir: testPath, Compression: "unknown"})
+	assert.Error(t, err)
+	assert.Nil(t, e)
+
 	// test new err when load engine err
 	listDir = func(path string) (strings []string, e error) {
 		return nil, fmt.Errorf("err")
@@ -166,3 +176,39 @@ func Test_Engine_Flush_Database(t *testing.T) {
 	ok = e.FlushDatabase(context.TODO(), "test_db_1")
 	assert.False(t, ok)
 }
+
+func Test_Engine_Drop_Database(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		removeDir = fileutil.RemoveDir
+		ctrl.Finish()
+	}()
+	e, _ := NewEngine(engineCfg)
+	engineImpl := e.(*engine)
+	defer engineImpl.cancel()
+
+	db, err := e.CreateDatabase("test_db")
+	assert.NoError(t, err)
+	assert.NotNil(t, db)
+	// case 1: drop database successfully
+	err = e.DropDatabase("test_db")
+	assert.NoError(t, err)
+	_, ok := e.GetDatabase("test_db")
+	assert.False(t, ok)
+	assert.False(t, fileutil.Exist(filepath.Join(testPath, "test_db")))
+	// case 2: drop not exist database
+	err = e.DropDatabase("test_db")
+	assert.NoError(t, err)
+	// case 3: close database err, remove dir err
+	mockDatabase := NewMockDatabase(ctrl)
+	mockDatabase.EXPECT().Close().Return(fmt.Errorf("err"))
+	engineImpl.databases.Store("test_db_1", mockDatabase)
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	err = e.DropDatabase("test_db_1")
+	assert.Error(t, err)
+	_, ok = e.GetDatabase("test_db_1")
+	assert.False(t, ok)
+}
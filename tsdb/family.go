@@ -3,6 +3,7 @@ package tsdb
 import (
 	"github.com/lindb/roaring"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/pkg/logger"
@@ -86,11 +87,14 @@ func (f *dataFamily) Filter(metricID uint32, fieldIDs []field.ID,
 	}
 	var metricReaders []metricsdata.Reader
 	for _, reader := range readers {
-		value, ok := reader.Get(metricID)
+		value, err := reader.Get(metricID)
 		// metric data not found
-		if !ok {
+		if err == constants.ErrNotFound {
 			continue
 		}
+		if err != nil {
+			return nil, err
+		}
 		r, err := newReaderFunc(reader.Path(), value)
 		if err != nil {
 			return nil, err
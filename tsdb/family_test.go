@@ -7,6 +7,7 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/kv/version"
@@ -63,17 +64,24 @@ func TestDataFamily_Filter(t *testing.T) {
 	reader := table.NewMockReader(ctrl)
 	reader.EXPECT().Path().Return("test_path").AnyTimes()
 	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
-	reader.EXPECT().Get(gomock.Any()).Return(nil, false)
+	reader.EXPECT().Get(gomock.Any()).Return(nil, constants.ErrNotFound)
 	rs, err = dataFamily.Filter(uint32(10), nil, nil, timeutil.TimeRange{})
 	assert.NoError(t, err)
 	assert.Nil(t, rs)
 
+	// case 3: read value err
+	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
+	reader.EXPECT().Get(gomock.Any()).Return(nil, table.ErrChecksumMismatch)
+	rs, err = dataFamily.Filter(uint32(10), nil, nil, timeutil.TimeRange{})
+	assert.Error(t, err)
+	assert.Nil(t, rs)
+
 	// case 3: new metric reader err
 	newReaderFunc = func(file string, buf []byte) (reader metricsdata.Reader, err error) {
 		return nil, fmt.Errorf("err")
 	}
 	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
-	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, true)
+	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, nil)
 	rs, err = dataFamily.Filter(uint32(10), nil, nil, timeutil.TimeRange{})
 	assert.Error(t, err)
 	assert.Nil(t, rs)
@@ -87,7 +95,7 @@ func TestDataFamily_Filter(t *testing.T) {
 		return filter
 	}
 	snapshot.EXPECT().FindReaders(gomock.Any()).Return([]table.Reader{reader}, nil)
-	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, true)
+	reader.EXPECT().Get(gomock.Any()).Return([]byte{1, 2, 3}, nil)
 	filter.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(nil, nil)
 	_, err = dataFamily.Filter(uint32(10), nil, nil, timeutil.TimeRange{})
 	assert.NoError(t, err)
@@ -6,6 +6,7 @@ import (
 	"path"
 	"time"
 
+	"github.com/lindb/roaring"
 	"go.etcd.io/bbolt"
 
 	"github.com/lindb/lindb/constants"
@@ -27,7 +28,10 @@ var (
 )
 
 var (
-	seriesBucketName = []byte("s")
+	seriesBucketName  = []byte("s")
+	deletedBucketName = []byte("d")
+	droppedBucketName = []byte("dm")
+	purgedBucketName  = []byte("p")
 )
 
 // IDMappingBackend represents the id mapping backend storage,
@@ -38,8 +42,25 @@ type IDMappingBackend interface {
 	loadMetricIDMapping(metricID uint32) (idMapping MetricIDMapping, err error)
 	// getSeriesID gets series id by metric id/tags hash, if not exist return constants.ErrNotFount
 	getSeriesID(metricID uint32, tagsHash uint64) (seriesID uint32, err error)
+	// getTagsHashes returns the series id => tags hash mapping of metric, returns empty map if metric not exist
+	getTagsHashes(metricID uint32) (tagsHashes map[uint32]uint64, err error)
 	// saveMapping saves the id mapping event
 	saveMapping(event *mappingEvent) (err error)
+	// deleteSeries removes the tags hash => series id mapping for the deleted series ids,
+	// then saves the deleted series ids as tombstone of metric
+	deleteSeries(metricID uint32, seriesIDs *roaring.Bitmap) (err error)
+	// loadDeletedSeries loads the deleted series ids(tombstone) of all metrics
+	loadDeletedSeries() (deletedSeries map[uint32]*roaring.Bitmap, err error)
+	// dropMetric removes all id mapping and tombstone of metric, then saves the metric id as dropped metric
+	dropMetric(metricID uint32) (err error)
+	// loadDroppedMetrics loads the dropped metric ids
+	loadDroppedMetrics() (metricIDs *roaring.Bitmap, err error)
+	// clearTombstones removes the dropped metric ids, marks the tombstones of metrics with deleted series as purged
+	clearTombstones(metricIDs *roaring.Bitmap) (err error)
+	// loadPurgedMetrics loads the metric ids whose tombstone of deleted series is purged
+	loadPurgedMetrics() (metricIDs *roaring.Bitmap, err error)
+	// backup copies a consistent view of bbolt.DB file into target path
+	backup(targetPath string) error
 }
 
 // idMappingBackend implements IDMappingBackend interface
@@ -63,6 +84,21 @@ func newIDMappingBackend(parent string) (IDMappingBackend, error) {
 		if err != nil {
 			return err
 		}
+		// create deleted series root bucket for save metric's tombstone
+		_, err = tx.CreateBucketIfNotExists(deletedBucketName)
+		if err != nil {
+			return err
+		}
+		// create dropped metric root bucket for save dropped metric ids
+		_, err = tx.CreateBucketIfNotExists(droppedBucketName)
+		if err != nil {
+			return err
+		}
+		// create purged metric root bucket for save metric ids whose tombstone is purged
+		_, err = tx.CreateBucketIfNotExists(purgedBucketName)
+		if err != nil {
+			return err
+		}
 		return nil
 	})
 	if err != nil {
@@ -118,6 +154,29 @@ func (imb *idMappingBackend) getSeriesID(metricID uint32, tagsHash uint64) (seri
 	return
 }
 
+// getTagsHashes returns the series id => tags hash mapping of metric, returns empty map if metric not exist
+func (imb *idMappingBackend) getTagsHashes(metricID uint32) (tagsHashes map[uint32]uint64, err error) {
+	tagsHashes = make(map[uint32]uint64)
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	err = imb.db.View(func(tx *bbolt.Tx) error {
+		metricBucket := tx.Bucket(seriesBucketName).Bucket(scratch[:])
+		if metricBucket == nil {
+			return nil
+		}
+		return metricBucket.ForEach(func(k, v []byte) error {
+			if len(k) == 8 && len(v) == 4 {
+				tagsHashes[binary.LittleEndian.Uint32(v)] = binary.LittleEndian.Uint64(k)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tagsHashes, nil
+}
+
 // saveMapping saves the id mapping event
 func (imb *idMappingBackend) saveMapping(event *mappingEvent) (err error) {
 	err = imb.db.Update(func(tx *bbolt.Tx) error {
@@ -154,6 +213,158 @@ func (imb *idMappingBackend) saveMapping(event *mappingEvent) (err error) {
 	return err
 }
 
+// deleteSeries removes the tags hash => series id mapping for the deleted series ids,
+// then saves the deleted series ids as tombstone of metric
+func (imb *idMappingBackend) deleteSeries(metricID uint32, seriesIDs *roaring.Bitmap) (err error) {
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	err = imb.db.Update(func(tx *bbolt.Tx) error {
+		metricBucket := tx.Bucket(seriesBucketName).Bucket(scratch[:])
+		if metricBucket != nil {
+			// remove tags hash => series id mapping, new series id will be generated when write same tags again
+			var deletedKeys [][]byte
+			c := metricBucket.Cursor()
+			for k, v := c.First(); k != nil; k, v = c.Next() {
+				if len(v) == 4 && seriesIDs.Contains(binary.LittleEndian.Uint32(v)) {
+					deletedKeys = append(deletedKeys, k)
+				}
+			}
+			for _, k := range deletedKeys {
+				if err := metricBucket.Delete(k); err != nil {
+					return err
+				}
+			}
+		}
+		// merge tombstone of metric
+		deletedBucket := tx.Bucket(deletedBucketName)
+		tombstone := seriesIDs.Clone()
+		if value := deletedBucket.Get(scratch[:]); len(value) > 0 {
+			deleted := roaring.New()
+			if err := deleted.UnmarshalBinary(value); err != nil {
+				return err
+			}
+			tombstone.Or(deleted)
+		}
+		data, err := tombstone.ToBytes()
+		if err != nil {
+			return err
+		}
+		// tombstone is changed, need purge again
+		if err := tx.Bucket(purgedBucketName).Delete(scratch[:]); err != nil {
+			return err
+		}
+		return putFunc(deletedBucket, scratch[:], data)
+	})
+	return err
+}
+
+// loadDeletedSeries loads the deleted series ids(tombstone) of all metrics
+func (imb *idMappingBackend) loadDeletedSeries() (deletedSeries map[uint32]*roaring.Bitmap, err error) {
+	deletedSeries = make(map[uint32]*roaring.Bitmap)
+	err = imb.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(deletedBucketName).ForEach(func(k, v []byte) error {
+			// copy value, because the memory of value only valid in transaction
+			deleted := roaring.New()
+			if err := deleted.UnmarshalBinary(append([]byte{}, v...)); err != nil {
+				return err
+			}
+			deletedSeries[binary.LittleEndian.Uint32(k)] = deleted
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deletedSeries, nil
+}
+
+// dropMetric removes all id mapping and tombstone of metric, then saves the metric id as dropped metric
+func (imb *idMappingBackend) dropMetric(metricID uint32) (err error) {
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	err = imb.db.Update(func(tx *bbolt.Tx) error {
+		seriesBucket := tx.Bucket(seriesBucketName)
+		if seriesBucket.Bucket(scratch[:]) != nil {
+			if err := seriesBucket.DeleteBucket(scratch[:]); err != nil {
+				return err
+			}
+		}
+		if err := tx.Bucket(deletedBucketName).Delete(scratch[:]); err != nil {
+			return err
+		}
+		if err := tx.Bucket(purgedBucketName).Delete(scratch[:]); err != nil {
+			return err
+		}
+		return putFunc(tx.Bucket(droppedBucketName), scratch[:], []byte{})
+	})
+	return err
+}
+
+// loadDroppedMetrics loads the dropped metric ids
+func (imb *idMappingBackend) loadDroppedMetrics() (metricIDs *roaring.Bitmap, err error) {
+	metricIDs = roaring.New()
+	err = imb.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(droppedBucketName).ForEach(func(k, v []byte) error {
+			metricIDs.Add(binary.LittleEndian.Uint32(k))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return metricIDs, nil
+}
+
+// clearTombstones removes the dropped metric ids, marks the tombstones of metrics with deleted series as purged
+func (imb *idMappingBackend) clearTombstones(metricIDs *roaring.Bitmap) (err error) {
+	err = imb.db.Update(func(tx *bbolt.Tx) error {
+		droppedBucket := tx.Bucket(droppedBucketName)
+		deletedBucket := tx.Bucket(deletedBucketName)
+		purgedBucket := tx.Bucket(purgedBucketName)
+		it := metricIDs.Iterator()
+		for it.HasNext() {
+			// key must remain valid for the life of the transaction
+			key := make([]byte, 4)
+			binary.LittleEndian.PutUint32(key, it.Next())
+			if droppedBucket.Get(key) != nil {
+				if err := droppedBucket.Delete(key); err != nil {
+					return err
+				}
+			}
+			// deleted series ids are kept for excluding from query
+			if deletedBucket.Get(key) != nil {
+				if err := putFunc(purgedBucket, key, []byte{}); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	return err
+}
+
+// loadPurgedMetrics loads the metric ids whose tombstone of deleted series is purged
+func (imb *idMappingBackend) loadPurgedMetrics() (metricIDs *roaring.Bitmap, err error) {
+	metricIDs = roaring.New()
+	err = imb.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(purgedBucketName).ForEach(func(k, v []byte) error {
+			metricIDs.Add(binary.LittleEndian.Uint32(k))
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return metricIDs, nil
+}
+
+// backup copies a consistent view of bbolt.DB file into target path
+func (imb *idMappingBackend) backup(targetPath string) error {
+	return imb.db.View(func(tx *bbolt.Tx) error {
+		return tx.CopyFile(path.Join(targetPath, MappingDB), 0600)
+	})
+}
+
 // Close closes the bbolt.DB
 func (imb *idMappingBackend) Close() error {
 	return imb.db.Close()
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 	"go.etcd.io/bbolt"
 
@@ -92,6 +93,14 @@ func TestIdMappingBackend_mapping(t *testing.T) {
 	assert.Equal(t, uint32(2), mapping.GetMetricID())
 	mapping1 := mapping.(*metricIDMapping)
 	assert.Equal(t, uint32(300), mapping1.idSequence.Load())
+	// case 6: get tags hashes
+	tagsHashes, err := backend.getTagsHashes(2)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint64{100: 10, 300: 30, 50: 50}, tagsHashes)
+	// case 7: get tags hashes, metric id not exist
+	tagsHashes, err = backend.getTagsHashes(4)
+	assert.NoError(t, err)
+	assert.Empty(t, tagsHashes)
 
 	err = backend.Close()
 	assert.NoError(t, err)
@@ -137,3 +146,165 @@ func TestIdMappingBackend_save_err(t *testing.T) {
 	err = backend.saveMapping(event)
 	assert.Error(t, err)
 }
+
+func TestIdMappingBackend_deleteSeries(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		putFunc = put
+	}()
+	backend, err := newIDMappingBackend(testPath)
+	assert.NoError(t, err)
+	event := newMappingEvent()
+	event.addSeriesID(1, 10, 1)
+	event.addSeriesID(1, 20, 2)
+	event.addSeriesID(1, 30, 3)
+	err = backend.saveMapping(event)
+	assert.NoError(t, err)
+
+	// case 1: delete series
+	err = backend.deleteSeries(1, roaring.BitmapOf(1, 3))
+	assert.NoError(t, err)
+	_, err = backend.getSeriesID(1, 10)
+	assert.Equal(t, constants.ErrNotFound, err)
+	seriesID, err := backend.getSeriesID(1, 20)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), seriesID)
+	// case 2: merge tombstone, metric mapping not exist
+	err = backend.deleteSeries(1, roaring.BitmapOf(5))
+	assert.NoError(t, err)
+	err = backend.deleteSeries(2, roaring.BitmapOf(5))
+	assert.NoError(t, err)
+	deletedSeries, err := backend.loadDeletedSeries()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 3, 5}, deletedSeries[1].ToArray())
+	assert.Equal(t, []uint32{5}, deletedSeries[2].ToArray())
+	// case 3: put tombstone err
+	putFunc = func(bucket *bbolt.Bucket, key, value []byte) error {
+		return fmt.Errorf("err")
+	}
+	err = backend.deleteSeries(2, roaring.BitmapOf(6))
+	assert.Error(t, err)
+	putFunc = put
+	err = backend.Close()
+	assert.NoError(t, err)
+
+	// reopen, tombstone persisted
+	backend, err = newIDMappingBackend(testPath)
+	assert.NoError(t, err)
+	deletedSeries, err = backend.loadDeletedSeries()
+	assert.NoError(t, err)
+	assert.Len(t, deletedSeries, 2)
+	assert.Equal(t, []uint32{5}, deletedSeries[2].ToArray())
+	err = backend.Close()
+	assert.NoError(t, err)
+}
+
+func TestIdMappingBackend_dropMetric(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		putFunc = put
+	}()
+	backend, err := newIDMappingBackend(testPath)
+	assert.NoError(t, err)
+	event := newMappingEvent()
+	event.addSeriesID(1, 10, 1)
+	event.addSeriesID(2, 10, 1)
+	err = backend.saveMapping(event)
+	assert.NoError(t, err)
+	err = backend.deleteSeries(1, roaring.BitmapOf(1))
+	assert.NoError(t, err)
+	// case 1: drop metric
+	err = backend.dropMetric(1)
+	assert.NoError(t, err)
+	err = backend.dropMetric(3)
+	assert.NoError(t, err)
+	_, err = backend.loadMetricIDMapping(1)
+	assert.Equal(t, constants.ErrNotFound, err)
+	seriesID, err := backend.getSeriesID(2, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), seriesID)
+	deletedSeries, err := backend.loadDeletedSeries()
+	assert.NoError(t, err)
+	assert.Empty(t, deletedSeries)
+	// case 2: put dropped metric err
+	putFunc = func(bucket *bbolt.Bucket, key, value []byte) error {
+		return fmt.Errorf("err")
+	}
+	err = backend.dropMetric(2)
+	assert.Error(t, err)
+	putFunc = put
+	err = backend.Close()
+	assert.NoError(t, err)
+
+	// reopen, dropped metric persisted
+	backend, err = newIDMappingBackend(testPath)
+	assert.NoError(t, err)
+	droppedMetrics, err := backend.loadDroppedMetrics()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 3}, droppedMetrics.ToArray())
+	err = backend.Close()
+	assert.NoError(t, err)
+}
+
+func TestIdMappingBackend_clearTombstones(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		putFunc = put
+	}()
+	backend, err := newIDMappingBackend(testPath)
+	assert.NoError(t, err)
+	assert.NoError(t, backend.deleteSeries(1, roaring.BitmapOf(1)))
+	assert.NoError(t, backend.deleteSeries(2, roaring.BitmapOf(1)))
+	assert.NoError(t, backend.dropMetric(3))
+	// case 1: put purged metric err
+	putFunc = func(bucket *bbolt.Bucket, key, value []byte) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, backend.clearTombstones(roaring.BitmapOf(1)))
+	putFunc = put
+	// case 2: clear tombstones
+	assert.NoError(t, backend.clearTombstones(roaring.BitmapOf(1, 2, 3, 4)))
+	// case 3: tombstone changed, purged mark removed
+	assert.NoError(t, backend.deleteSeries(2, roaring.BitmapOf(2)))
+	err = backend.Close()
+	assert.NoError(t, err)
+
+	// reopen, purged metric persisted
+	backend, err = newIDMappingBackend(testPath)
+	assert.NoError(t, err)
+	purgedMetrics, err := backend.loadPurgedMetrics()
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1}, purgedMetrics.ToArray())
+	droppedMetrics, err := backend.loadDroppedMetrics()
+	assert.NoError(t, err)
+	assert.True(t, droppedMetrics.IsEmpty())
+	deletedSeries, err := backend.loadDeletedSeries()
+	assert.NoError(t, err)
+	assert.Len(t, deletedSeries, 2)
+	err = backend.Close()
+	assert.NoError(t, err)
+}
+
+func TestIdMappingBackend_backup(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	backend, err := newIDMappingBackend(testPath)
+	assert.NoError(t, err)
+	event := newMappingEvent()
+	event.addSeriesID(1, 100, 1)
+	assert.NoError(t, backend.saveMapping(event))
+	// case 1: target path not exist
+	assert.Error(t, backend.backup(filepath.Join(testPath, "not_exist", "backup")))
+	// case 2: backup successfully
+	backupPath := filepath.Join(testPath, "backup")
+	assert.NoError(t, fileutil.MkDirIfNotExist(backupPath))
+	assert.NoError(t, backend.backup(backupPath))
+	assert.NoError(t, backend.Close())
+	backup, err := newIDMappingBackend(backupPath)
+	assert.NoError(t, err)
+	seriesID, err := backup.getSeriesID(1, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1), seriesID)
+	assert.NoError(t, backup.Close())
+}
@@ -24,6 +24,7 @@ import (
 var (
 	createBackend   = newIDMappingBackend
 	createSeriesWAL = wal.NewSeriesWAL
+	backupWALFunc   = wal.Backup
 )
 
 var (
@@ -68,6 +69,9 @@ type indexDatabase struct {
 	metricID2Mapping map[uint32]MetricIDMapping // key: metric id, value: metric id mapping
 	metadata         metadb.Metadata            // the metadata for generating ID of metric, field
 	index            InvertedIndex
+	deletedSeries    map[uint32]*roaring.Bitmap // key: metric id, value: tombstoned series ids(read only)
+	droppedMetrics   *roaring.Bitmap            // dropped metric ids(read only)
+	purgedMetrics    *roaring.Bitmap            // metric ids whose tombstoned series are purged from data(read only)
 
 	seriesWAL wal.SeriesWAL
 
@@ -94,6 +98,18 @@ func NewIndexDatabase(ctx context.Context, parent string, metadata metadb.Metada
 			}
 		}
 	}()
+	deletedSeries, err := backend.loadDeletedSeries()
+	if err != nil {
+		return nil, err
+	}
+	droppedMetrics, err := backend.loadDroppedMetrics()
+	if err != nil {
+		return nil, err
+	}
+	purgedMetrics, err := backend.loadPurgedMetrics()
+	if err != nil {
+		return nil, err
+	}
 	seriesWAL, err := createSeriesWAL(filepath.Join(parent, walPath, seriesWALPath))
 	if err != nil {
 		return nil, err
@@ -107,6 +123,9 @@ func NewIndexDatabase(ctx context.Context, parent string, metadata metadb.Metada
 		metadata:         metadata,
 		metricID2Mapping: make(map[uint32]MetricIDMapping),
 		index:            newInvertedIndex(metadata, forwardFamily, invertedFamily),
+		deletedSeries:    deletedSeries,
+		droppedMetrics:   droppedMetrics,
+		purgedMetrics:    purgedMetrics,
 		seriesWAL:        seriesWAL,
 		syncInterval:     syncInterval,
 	}
@@ -190,6 +209,24 @@ func (db *indexDatabase) GetOrCreateSeriesID(metricID uint32, tagsHash uint64,
 	return seriesID, true, nil
 }
 
+// GetTagsHashes returns the tags hash of all series of metric, key is series id,
+// includes the mapping in backend storage and the cached mapping which is not synced.
+func (db *indexDatabase) GetTagsHashes(metricID uint32) (map[uint32]uint64, error) {
+	db.rwMutex.RLock()
+	defer db.rwMutex.RUnlock()
+
+	tagsHashes, err := db.backend.getTagsHashes(metricID)
+	if err != nil {
+		return nil, err
+	}
+	if metricIDMapping, ok := db.metricID2Mapping[metricID]; ok {
+		for seriesID, tagsHash := range metricIDMapping.GetTagsHashes() {
+			tagsHashes[seriesID] = tagsHash
+		}
+	}
+	return tagsHashes, nil
+}
+
 // GetSeriesIDsByTagValueIDs gets series ids by tag value ids for spec metric's tag key
 func (db *indexDatabase) GetSeriesIDsByTagValueIDs(tagKeyID uint32, tagValueIDs *roaring.Bitmap) (*roaring.Bitmap, error) {
 	return db.index.GetSeriesIDsByTagValueIDs(tagKeyID, tagValueIDs)
@@ -228,6 +265,131 @@ func (db *indexDatabase) BuildInvertIndex(namespace, metricName string, tags map
 	buildInvertedIndexCounter.WithLabelValues(db.metadata.DatabaseName()).Inc()
 }
 
+// DeleteSeries tombstones the series ids of metric, the tombstoned series ids are excluded
+// from query, new series id will be generated when writing the deleted series again.
+func (db *indexDatabase) DeleteSeries(metricID uint32, seriesIDs *roaring.Bitmap) error {
+	deleted := seriesIDs.Clone()
+	// series id of the series without tags is fixed, cannot be deleted
+	deleted.Remove(constants.SeriesIDWithoutTags)
+	if deleted.IsEmpty() {
+		return nil
+	}
+
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	if err := db.backend.deleteSeries(metricID, deleted); err != nil {
+		return err
+	}
+	if metricIDMapping, ok := db.metricID2Mapping[metricID]; ok {
+		metricIDMapping.RemoveSeriesIDs(deleted)
+	}
+	// copy on write, the tombstone maybe used by query
+	if tombstone, ok := db.deletedSeries[metricID]; ok {
+		deleted.Or(tombstone)
+	}
+	db.deletedSeries[metricID] = deleted
+	db.unmarkPurged(metricID)
+
+	indexLogger.Info("delete series successfully",
+		logger.String("db", db.path), logger.Uint32("metricID", metricID),
+		logger.Any("tombstones", deleted.GetCardinality()))
+	return nil
+}
+
+// DropMetric tombstones the metric include all series, the dropped metric id is never written again,
+// because new metric id is generated when writing the dropped metric again.
+func (db *indexDatabase) DropMetric(metricID uint32) error {
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	if err := db.backend.dropMetric(metricID); err != nil {
+		return err
+	}
+	delete(db.metricID2Mapping, metricID)
+	delete(db.deletedSeries, metricID)
+	db.unmarkPurged(metricID)
+	// copy on write, the dropped metric ids maybe used by compaction
+	droppedMetrics := db.droppedMetrics.Clone()
+	droppedMetrics.Add(metricID)
+	db.droppedMetrics = droppedMetrics
+
+	indexLogger.Info("drop metric successfully",
+		logger.String("db", db.path), logger.Uint32("metricID", metricID))
+	return nil
+}
+
+// IsMetricDropped returns if the metric is dropped.
+func (db *indexDatabase) IsMetricDropped(metricID uint32) bool {
+	db.rwMutex.RLock()
+	defer db.rwMutex.RUnlock()
+
+	return db.droppedMetrics.Contains(metricID)
+}
+
+// GetDeletedMetricIDs returns the metric ids which are dropped or have tombstoned series ids not purged yet.
+func (db *indexDatabase) GetDeletedMetricIDs() *roaring.Bitmap {
+	db.rwMutex.RLock()
+	defer db.rwMutex.RUnlock()
+
+	metricIDs := db.droppedMetrics.Clone()
+	for metricID := range db.deletedSeries {
+		if !db.purgedMetrics.Contains(metricID) {
+			metricIDs.Add(metricID)
+		}
+	}
+	return metricIDs
+}
+
+// ClearTombstones clears the tombstones of metrics after the deleted data is purged from all data families,
+// the dropped metric ids are removed, the tombstoned series ids are still excluded from query,
+// but aren't purged when compaction any more.
+func (db *indexDatabase) ClearTombstones(metricIDs *roaring.Bitmap) error {
+	if metricIDs == nil || metricIDs.IsEmpty() {
+		return nil
+	}
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	if err := db.backend.clearTombstones(metricIDs); err != nil {
+		return err
+	}
+	// copy on write, the dropped/purged metric ids maybe used by compaction
+	droppedMetrics := db.droppedMetrics.Clone()
+	droppedMetrics.AndNot(metricIDs)
+	db.droppedMetrics = droppedMetrics
+	purgedMetrics := db.purgedMetrics.Clone()
+	for metricID := range db.deletedSeries {
+		if metricIDs.Contains(metricID) {
+			purgedMetrics.Add(metricID)
+		}
+	}
+	db.purgedMetrics = purgedMetrics
+
+	indexLogger.Info("clear tombstones successfully",
+		logger.String("db", db.path), logger.Any("metricIDs", metricIDs.ToArray()))
+	return nil
+}
+
+// unmarkPurged removes the purged mark of metric when tombstone changed, must hold the write lock
+func (db *indexDatabase) unmarkPurged(metricID uint32) {
+	if !db.purgedMetrics.Contains(metricID) {
+		return
+	}
+	// copy on write, the purged metric ids maybe used by compaction
+	purgedMetrics := db.purgedMetrics.Clone()
+	purgedMetrics.Remove(metricID)
+	db.purgedMetrics = purgedMetrics
+}
+
+// GetDeletedSeriesIDs returns the tombstoned series ids of metric, returns nil if not exist.
+func (db *indexDatabase) GetDeletedSeriesIDs(metricID uint32) *roaring.Bitmap {
+	db.rwMutex.RLock()
+	defer db.rwMutex.RUnlock()
+
+	return db.deletedSeries[metricID]
+}
+
 // Flush flushes index data to disk
 func (db *indexDatabase) Flush() error {
 	if err := db.seriesWAL.Sync(); err != nil {
@@ -238,6 +400,25 @@ func (db *indexDatabase) Flush() error {
 	return db.index.Flush()
 }
 
+// Backup flushes index data, then copies series id mapping storage and write ahead log into target path,
+// copies wal firstly, because the data of released wal page has been saved into backend storage.
+func (db *indexDatabase) Backup(targetPath string) error {
+	if err := db.Flush(); err != nil {
+		return err
+	}
+	db.rwMutex.Lock()
+	defer db.rwMutex.Unlock()
+
+	if err := db.seriesWAL.Sync(); err != nil {
+		return err
+	}
+	seriesPath := filepath.Join(walPath, seriesWALPath)
+	if err := backupWALFunc(filepath.Join(db.path, seriesPath), filepath.Join(targetPath, seriesPath)); err != nil {
+		return err
+	}
+	return db.backend.backup(targetPath)
+}
+
 // Close closes the database, releases the resources
 func (db *indexDatabase) Close() error {
 	db.cancel()
@@ -278,6 +459,10 @@ func (db *indexDatabase) seriesRecovery() {
 
 	event := newMappingEvent()
 	db.seriesWAL.Recovery(func(metricID uint32, tagsHash uint64, seriesID uint32) error {
+		// ignore the series which deleted after written into wal
+		if deleted := db.GetDeletedSeriesIDs(metricID); deleted != nil && deleted.Contains(seriesID) {
+			return nil
+		}
 		event.addSeriesID(metricID, tagsHash, seriesID)
 		if event.isFull() {
 			if err := db.backend.saveMapping(event); err != nil {
@@ -3,6 +3,7 @@ package indexdb
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -52,6 +53,9 @@ func TestNewIndexDatabase_err(t *testing.T) {
 	mockMetadata.EXPECT().DatabaseName().Return("test").AnyTimes()
 
 	backend := NewMockIDMappingBackend(ctrl)
+	backend.EXPECT().loadDeletedSeries().Return(make(map[uint32]*roaring.Bitmap), nil).AnyTimes()
+	backend.EXPECT().loadDroppedMetrics().Return(roaring.New(), nil).AnyTimes()
+	backend.EXPECT().loadPurgedMetrics().Return(roaring.New(), nil).AnyTimes()
 	createBackend = func(parent string) (IDMappingBackend, error) {
 		return backend, nil
 	}
@@ -145,6 +149,9 @@ func TestIndexDatabase_series_Recovery_err(t *testing.T) {
 
 	backend := NewMockIDMappingBackend(ctrl)
 	backend.EXPECT().Close().Return(nil).AnyTimes()
+	backend.EXPECT().loadDeletedSeries().Return(make(map[uint32]*roaring.Bitmap), nil).AnyTimes()
+	backend.EXPECT().loadDroppedMetrics().Return(roaring.New(), nil).AnyTimes()
+	backend.EXPECT().loadPurgedMetrics().Return(roaring.New(), nil).AnyTimes()
 	createBackend = func(parent string) (IDMappingBackend, error) {
 		return backend, nil
 	}
@@ -252,6 +259,9 @@ func TestIndexDatabase_GetOrCreateSeriesID_err(t *testing.T) {
 	}()
 
 	backend := NewMockIDMappingBackend(ctrl)
+	backend.EXPECT().loadDeletedSeries().Return(make(map[uint32]*roaring.Bitmap), nil).AnyTimes()
+	backend.EXPECT().loadDroppedMetrics().Return(roaring.New(), nil).AnyTimes()
+	backend.EXPECT().loadPurgedMetrics().Return(roaring.New(), nil).AnyTimes()
 	createBackend = func(parent string) (IDMappingBackend, error) {
 		return backend, nil
 	}
@@ -282,6 +292,257 @@ func TestIndexDatabase_GetOrCreateSeriesID_err(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestIndexDatabase_GetTagsHashes(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		createBackend = newIDMappingBackend
+
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	_, _, _ = db.GetOrCreateSeriesID(1, 10)
+	_, _, _ = db.GetOrCreateSeriesID(1, 20)
+	// case 1: get tags hashes from memory
+	tagsHashes, err := db.GetTagsHashes(1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint64{1: 10, 2: 20}, tagsHashes)
+	tagsHashes, err = db.GetTagsHashes(2)
+	assert.NoError(t, err)
+	assert.Empty(t, tagsHashes)
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// case 2: reopen, get tags hashes from backend
+	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	tagsHashes, err = db.GetTagsHashes(1)
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]uint64{1: 10, 2: 20}, tagsHashes)
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// case 3: get tags hashes err
+	backend := NewMockIDMappingBackend(ctrl)
+	createBackend = func(parent string) (IDMappingBackend, error) {
+		return backend, nil
+	}
+	backend.EXPECT().loadDeletedSeries().Return(make(map[uint32]*roaring.Bitmap), nil)
+	backend.EXPECT().loadDroppedMetrics().Return(roaring.New(), nil)
+	backend.EXPECT().loadPurgedMetrics().Return(roaring.New(), nil)
+	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	backend.EXPECT().getTagsHashes(uint32(1)).Return(nil, fmt.Errorf("err"))
+	tagsHashes, err = db.GetTagsHashes(1)
+	assert.Error(t, err)
+	assert.Nil(t, tagsHashes)
+	backend.EXPECT().Close().Return(nil)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
+func TestIndexDatabase_DeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	_, _, _ = db.GetOrCreateSeriesID(1, 10)
+	_, _, _ = db.GetOrCreateSeriesID(1, 20)
+	// case 1: series without tags cannot be deleted
+	err = db.DeleteSeries(1, roaring.BitmapOf(0))
+	assert.NoError(t, err)
+	assert.Nil(t, db.GetDeletedSeriesIDs(1))
+	// case 2: delete series
+	err = db.DeleteSeries(1, roaring.BitmapOf(0, 1))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1}, db.GetDeletedSeriesIDs(1).ToArray())
+	// case 3: merge tombstone
+	err = db.DeleteSeries(1, roaring.BitmapOf(2))
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, db.GetDeletedSeriesIDs(1).ToArray())
+	// case 4: gen new series id for deleted series
+	seriesID, isCreated, err := db.GetOrCreateSeriesID(1, 10)
+	assert.NoError(t, err)
+	assert.True(t, isCreated)
+	assert.Equal(t, uint32(3), seriesID)
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// reopen, load tombstone
+	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, db.GetDeletedSeriesIDs(1).ToArray())
+	seriesID, isCreated, err = db.GetOrCreateSeriesID(1, 20)
+	assert.NoError(t, err)
+	assert.True(t, isCreated)
+	assert.Equal(t, uint32(4), seriesID)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
+func TestIndexDatabase_DropMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, db.GetDeletedMetricIDs().IsEmpty())
+	_, _, _ = db.GetOrCreateSeriesID(1, 10)
+	err = db.DeleteSeries(1, roaring.BitmapOf(1))
+	assert.NoError(t, err)
+	err = db.DeleteSeries(2, roaring.BitmapOf(1))
+	assert.NoError(t, err)
+	// drop metric
+	err = db.DropMetric(1)
+	assert.NoError(t, err)
+	err = db.DropMetric(3)
+	assert.NoError(t, err)
+	assert.True(t, db.IsMetricDropped(1))
+	assert.False(t, db.IsMetricDropped(2))
+	assert.Nil(t, db.GetDeletedSeriesIDs(1))
+	assert.Equal(t, []uint32{1, 2, 3}, db.GetDeletedMetricIDs().ToArray())
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// reopen, load dropped metrics
+	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	assert.True(t, db.IsMetricDropped(1))
+	assert.True(t, db.IsMetricDropped(3))
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
+func TestIndexDatabase_ClearTombstones(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, db.DeleteSeries(1, roaring.BitmapOf(1, 2)))
+	assert.NoError(t, db.DeleteSeries(2, roaring.BitmapOf(1)))
+	assert.NoError(t, db.DropMetric(3))
+	assert.NoError(t, db.DropMetric(4))
+	// case 1: no tombstones
+	assert.NoError(t, db.ClearTombstones(nil))
+	assert.Equal(t, []uint32{1, 2, 3, 4}, db.GetDeletedMetricIDs().ToArray())
+	// case 2: clear tombstones, deleted series are still excluded from query
+	assert.NoError(t, db.ClearTombstones(roaring.BitmapOf(1, 3, 5)))
+	assert.Equal(t, []uint32{2, 4}, db.GetDeletedMetricIDs().ToArray())
+	assert.False(t, db.IsMetricDropped(3))
+	assert.Equal(t, []uint32{1, 2}, db.GetDeletedSeriesIDs(1).ToArray())
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// reopen, load purged metrics
+	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{2, 4}, db.GetDeletedMetricIDs().ToArray())
+	assert.Equal(t, []uint32{1, 2}, db.GetDeletedSeriesIDs(1).ToArray())
+	// case 3: tombstone changed, need purge again
+	assert.NoError(t, db.DeleteSeries(1, roaring.BitmapOf(3)))
+	assert.Equal(t, []uint32{1, 2, 4}, db.GetDeletedMetricIDs().ToArray())
+	assert.NoError(t, db.ClearTombstones(roaring.BitmapOf(1)))
+	assert.NoError(t, db.DropMetric(1))
+	assert.Equal(t, []uint32{1, 2, 4}, db.GetDeletedMetricIDs().ToArray())
+	err = db.Close()
+	assert.NoError(t, err)
+
+	// reopen, purged mark is removed when tombstone changed
+	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2, 4}, db.GetDeletedMetricIDs().ToArray())
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
+func TestIndexDatabase_DeleteSeries_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		createBackend = newIDMappingBackend
+
+		ctrl.Finish()
+	}()
+
+	backend := NewMockIDMappingBackend(ctrl)
+	createBackend = func(parent string) (IDMappingBackend, error) {
+		return backend, nil
+	}
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	// case 1: load tombstone err
+	backend.EXPECT().loadDeletedSeries().Return(nil, fmt.Errorf("err"))
+	backend.EXPECT().Close().Return(nil)
+	db, err := NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, db)
+	// case 2: load dropped metrics err
+	backend.EXPECT().loadDeletedSeries().Return(make(map[uint32]*roaring.Bitmap), nil)
+	backend.EXPECT().loadDroppedMetrics().Return(nil, fmt.Errorf("err"))
+	backend.EXPECT().Close().Return(nil)
+	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, db)
+	// case 3: load purged metrics err
+	backend.EXPECT().loadDeletedSeries().Return(make(map[uint32]*roaring.Bitmap), nil)
+	backend.EXPECT().loadDroppedMetrics().Return(roaring.New(), nil)
+	backend.EXPECT().loadPurgedMetrics().Return(nil, fmt.Errorf("err"))
+	backend.EXPECT().Close().Return(nil)
+	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, db)
+	// case 4: delete series err
+	backend.EXPECT().loadDeletedSeries().Return(make(map[uint32]*roaring.Bitmap), nil)
+	backend.EXPECT().loadDroppedMetrics().Return(roaring.New(), nil)
+	backend.EXPECT().loadPurgedMetrics().Return(roaring.New(), nil)
+	db, err = NewIndexDatabase(context.TODO(), testPath, meta, nil, nil)
+	assert.NoError(t, err)
+	backend.EXPECT().deleteSeries(uint32(1), gomock.Any()).Return(fmt.Errorf("err"))
+	err = db.DeleteSeries(1, roaring.BitmapOf(1))
+	assert.Error(t, err)
+	assert.Nil(t, db.GetDeletedSeriesIDs(1))
+	// case 5: drop metric err
+	backend.EXPECT().dropMetric(uint32(1)).Return(fmt.Errorf("err"))
+	err = db.DropMetric(1)
+	assert.Error(t, err)
+	assert.False(t, db.IsMetricDropped(1))
+	// case 6: clear tombstones err
+	backend.EXPECT().dropMetric(uint32(1)).Return(nil)
+	assert.NoError(t, db.DropMetric(1))
+	backend.EXPECT().clearTombstones(roaring.BitmapOf(1)).Return(fmt.Errorf("err"))
+	err = db.ClearTombstones(roaring.BitmapOf(1))
+	assert.Error(t, err)
+	assert.True(t, db.IsMetricDropped(1))
+
+	backend.EXPECT().Close().Return(nil)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
 func TestIndexDatabase_GetGroupingContext(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -366,6 +627,9 @@ func TestIndexDatabase_Close(t *testing.T) {
 	}()
 
 	backend := NewMockIDMappingBackend(ctrl)
+	backend.EXPECT().loadDeletedSeries().Return(make(map[uint32]*roaring.Bitmap), nil).AnyTimes()
+	backend.EXPECT().loadDroppedMetrics().Return(roaring.New(), nil).AnyTimes()
+	backend.EXPECT().loadPurgedMetrics().Return(roaring.New(), nil).AnyTimes()
 	createBackend = func(parent string) (IDMappingBackend, error) {
 		return backend, nil
 	}
@@ -445,3 +709,59 @@ func TestIndexDatabase_checkSync(t *testing.T) {
 	err = db.Close()
 	assert.NoError(t, err)
 }
+
+func TestIndexDatabase_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		backupWALFunc = wal.Backup
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db, err := NewIndexDatabase(context.TODO(), filepath.Join(testPath, "db"), meta, nil, nil)
+	assert.NoError(t, err)
+	seriesID, _, err := db.GetOrCreateSeriesID(1, 10)
+	assert.NoError(t, err)
+	backupPath := filepath.Join(testPath, "backup")
+	// case 1: backup successfully
+	assert.NoError(t, db.Backup(backupPath))
+	assert.NoError(t, db.Close())
+	// open index database from backup, series id recovers from wal
+	backup, err := NewIndexDatabase(context.TODO(), backupPath, meta, nil, nil)
+	assert.NoError(t, err)
+	seriesID2, isCreated, err := backup.GetOrCreateSeriesID(1, 10)
+	assert.NoError(t, err)
+	assert.False(t, isCreated)
+	assert.Equal(t, seriesID, seriesID2)
+	assert.NoError(t, backup.Close())
+
+	index := NewMockInvertedIndex(ctrl)
+	seriesWAL := wal.NewMockSeriesWAL(ctrl)
+	backend := NewMockIDMappingBackend(ctrl)
+	db1 := &indexDatabase{path: testPath, index: index, seriesWAL: seriesWAL, backend: backend}
+	// case 2: flush index err
+	seriesWAL.EXPECT().Sync().Return(nil).AnyTimes()
+	index.EXPECT().Flush().Return(fmt.Errorf("err"))
+	assert.Error(t, db1.Backup(backupPath))
+	index.EXPECT().Flush().Return(nil).AnyTimes()
+	// case 3: backup wal err
+	backupWALFunc = func(path, target string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, db1.Backup(backupPath))
+	// case 4: backup backend err
+	backupWALFunc = func(path, target string) error {
+		return nil
+	}
+	backend.EXPECT().backup(backupPath).Return(fmt.Errorf("err"))
+	assert.Error(t, db1.Backup(backupPath))
+	// case 5: sync wal err
+	seriesWAL2 := wal.NewMockSeriesWAL(ctrl)
+	db1.seriesWAL = seriesWAL2
+	seriesWAL2.EXPECT().Sync().Return(nil)
+	seriesWAL2.EXPECT().Sync().Return(fmt.Errorf("err"))
+	assert.Error(t, db1.Backup(backupPath))
+}
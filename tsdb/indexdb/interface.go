@@ -3,6 +3,8 @@ package indexdb
 import (
 	"io"
 
+	"github.com/lindb/roaring"
+
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/series"
 )
@@ -30,9 +32,31 @@ type IndexDatabase interface {
 	// if generate a new series id returns isCreate is true
 	// if generate fail return err
 	GetOrCreateSeriesID(metricID uint32, tagsHash uint64) (seriesID uint32, isCreated bool, err error)
+	// GetTagsHashes returns the tags hash of all series of metric, key is series id
+	GetTagsHashes(metricID uint32) (map[uint32]uint64, error)
 	// BuildInvertIndex builds the inverted index for tag value => series ids,
 	// the tags is considered as a empty key-value pair while tags is nil.
 	BuildInvertIndex(namespace, metricName string, tags map[string]string, seriesID uint32)
+	// DeleteSeries tombstones the series ids of metric, the tombstoned series ids are excluded
+	// from query, new series id will be generated when writing the deleted series again.
+	// NOTICE: the series without tags cannot be deleted.
+	DeleteSeries(metricID uint32, seriesIDs *roaring.Bitmap) error
+	// DropMetric tombstones the metric include all series, the dropped metric id is never written again,
+	// because new metric id is generated when writing the dropped metric again.
+	DropMetric(metricID uint32) error
+	// IsMetricDropped returns if the metric is dropped.
+	IsMetricDropped(metricID uint32) bool
+	// GetDeletedMetricIDs returns the metric ids which are dropped or have tombstoned series ids not purged yet.
+	GetDeletedMetricIDs() *roaring.Bitmap
+	// ClearTombstones clears the tombstones of metrics after the deleted data is purged from all data families,
+	// the dropped metric ids are removed, the tombstoned series ids are still excluded from query,
+	// but aren't purged when compaction any more.
+	ClearTombstones(metricIDs *roaring.Bitmap) error
+	// GetDeletedSeriesIDs returns the tombstoned series ids of metric, returns nil if not exist.
+	// NOTICE: returned bitmap is read only.
+	GetDeletedSeriesIDs(metricID uint32) *roaring.Bitmap
 	// Flush flushes index data to disk
 	Flush() error
+	// Backup flushes index data, then copies series id mapping storage and write ahead log into target path
+	Backup(targetPath string) error
 }
@@ -1,6 +1,7 @@
 package indexdb
 
 import (
+	"github.com/lindb/roaring"
 	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/constants"
@@ -17,8 +18,12 @@ type MetricIDMapping interface {
 	GenSeriesID(tagsHash uint64) (seriesID uint32)
 	// RemoveSeriesID removes series id by tags hash
 	RemoveSeriesID(tagsHash uint64)
+	// RemoveSeriesIDs removes the cached tags hash => series id mapping for the given series ids
+	RemoveSeriesIDs(seriesIDs *roaring.Bitmap)
 	// AddSeriesID adds the series id init cache
 	AddSeriesID(tagsHash uint64, seriesID uint32)
+	// GetTagsHashes returns the cached series id => tags hash mapping
+	GetTagsHashes() map[uint32]uint64
 	// SetMaxSeriesIDsLimit sets the max series ids limit
 	SetMaxSeriesIDsLimit(limit uint32)
 	// GetMaxSeriesIDsLimit returns the max series ids limit
@@ -61,6 +66,15 @@ func (mim *metricIDMapping) AddSeriesID(tagsHash uint64, seriesID uint32) {
 	mim.hash2SeriesID[tagsHash] = seriesID
 }
 
+// GetTagsHashes returns the cached series id => tags hash mapping
+func (mim *metricIDMapping) GetTagsHashes() map[uint32]uint64 {
+	tagsHashes := make(map[uint32]uint64, len(mim.hash2SeriesID))
+	for tagsHash, seriesID := range mim.hash2SeriesID {
+		tagsHashes[seriesID] = tagsHash
+	}
+	return tagsHashes
+}
+
 // GenSeriesID generates series id by tags hash, then cache new series id
 func (mim *metricIDMapping) GenSeriesID(tagsHash uint64) (seriesID uint32) {
 	// generate new series id
@@ -86,6 +100,16 @@ func (mim *metricIDMapping) RemoveSeriesID(tagsHash uint64) {
 	}
 }
 
+// RemoveSeriesIDs removes the cached tags hash => series id mapping for the given series ids,
+// the id sequence is not recycled, because the deleted series ids are kept in tombstone.
+func (mim *metricIDMapping) RemoveSeriesIDs(seriesIDs *roaring.Bitmap) {
+	for tagsHash, seriesID := range mim.hash2SeriesID {
+		if seriesIDs.Contains(seriesID) {
+			delete(mim.hash2SeriesID, tagsHash)
+		}
+	}
+}
+
 // SetMaxSeriesIDsLimit sets the max series ids limit
 func (mim *metricIDMapping) SetMaxSeriesIDsLimit(limit uint32) {
 	mim.maxSeriesIDsLimit.Store(limit)
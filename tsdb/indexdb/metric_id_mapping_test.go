@@ -3,6 +3,7 @@ package indexdb
 import (
 	"testing"
 
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/constants"
@@ -32,6 +33,28 @@ func TestMetricIDMapping_GetOrCreateSeriesID(t *testing.T) {
 	assert.True(t, ok)
 }
 
+func TestMetricIDMapping_RemoveSeriesIDs(t *testing.T) {
+	idMapping := newMetricIDMapping(10, 0)
+	idMapping.GenSeriesID(100)
+	idMapping.GenSeriesID(200)
+	idMapping.RemoveSeriesIDs(roaring.BitmapOf(1))
+	_, ok := idMapping.GetSeriesID(100)
+	assert.False(t, ok)
+	seriesID, ok := idMapping.GetSeriesID(200)
+	assert.True(t, ok)
+	assert.Equal(t, uint32(2), seriesID)
+	// series id sequence not recycled
+	assert.Equal(t, uint32(3), idMapping.GenSeriesID(100))
+}
+
+func TestMetricIDMapping_GetTagsHashes(t *testing.T) {
+	idMapping := newMetricIDMapping(10, 0)
+	assert.Empty(t, idMapping.GetTagsHashes())
+	idMapping.GenSeriesID(100)
+	idMapping.AddSeriesID(200, 5)
+	assert.Equal(t, map[uint32]uint64{1: 100, 5: 200}, idMapping.GetTagsHashes())
+}
+
 func TestMetricIDMapping_SetMaxTagsLimit(t *testing.T) {
 	idMapping := newMetricIDMapping(10, 0)
 	seriesID := idMapping.GenSeriesID(100)
@@ -5,6 +5,10 @@ import (
 	"path/filepath"
 	"sync"
 
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/timeutil"
 )
 
@@ -12,27 +16,45 @@ import (
 
 // IntervalSegment represents a interval segment, there are some segments in a shard.
 type IntervalSegment interface {
+	// Interval returns the interval of interval segment
+	Interval() timeutil.Interval
 	// GetOrCreateSegment creates new segment if not exist, if exist return it
 	GetOrCreateSegment(segmentName string) (Segment, error)
 	// getDataFamilies returns data family list by time range, return nil if not match
 	getDataFamilies(timeRange timeutil.TimeRange) []DataFamily
+	// Expire removes the whole segments and data families which are expired(end time before expire time)
+	Expire(expireTime int64) error
+	// Backup backups all segments into target path, each segment is backed up into the sub directory of segment name
+	Backup(targetPath string) error
+	// Compact triggers full compaction of the data families in all segments by family name,
+	// triggers all families if family name is empty.
+	Compact(familyName string)
+	// Purge purges the deleted data of the keys in all segments when compaction
+	Purge(keys *roaring.Bitmap)
+	// IsPurging returns if the deleted data of the keys is purging in any segment
+	IsPurging(keys *roaring.Bitmap) bool
 	// Close closes interval segment, release resource
 	Close()
 }
 
 // intervalSegment implements IntervalSegment interface
 type intervalSegment struct {
-	path     string
-	interval timeutil.Interval
-	segments sync.Map
+	path         string
+	interval     timeutil.Interval
+	rollupTarget IntervalSegment // target interval segment for rollup, nil if no rollup
+	tombstone    kv.Tombstone    // tombstone for purging deleted data when compaction, nil if not purge
+	segments     sync.Map
 
 	mutex sync.Mutex
 }
 
 // newIntervalSegment create interval segment based on interval/type/path etc.
+// if rollup target isn't nil, data of this interval segment will rollup into the target interval segment.
 func newIntervalSegment(
 	interval timeutil.Interval,
 	path string,
+	rollupTarget IntervalSegment,
+	tombstone kv.Tombstone,
 ) (
 	segment IntervalSegment,
 	err error,
@@ -41,8 +63,10 @@ func newIntervalSegment(
 		return segment, err
 	}
 	intervalSegment := &intervalSegment{
-		path:     path,
-		interval: interval,
+		path:         path,
+		interval:     interval,
+		rollupTarget: rollupTarget,
+		tombstone:    tombstone,
 	}
 
 	defer func() {
@@ -59,7 +83,8 @@ func newIntervalSegment(
 		return segment, err
 	}
 	for _, segmentName := range segmentNames {
-		seg, err := newSegment(segmentName, intervalSegment.interval, filepath.Join(path, segmentName))
+		seg, err := newSegment(segmentName, intervalSegment.interval, filepath.Join(path, segmentName),
+			rollupTarget, tombstone)
 		if err != nil {
 			err = fmt.Errorf("create segmenet error: %s", err)
 			return segment, err
@@ -72,6 +97,11 @@ func newIntervalSegment(
 	return segment, err
 }
 
+// Interval returns the interval of interval segment
+func (s *intervalSegment) Interval() timeutil.Interval {
+	return s.interval
+}
+
 // GetOrCreateSegment creates new segment if not exist, if exist return it
 func (s *intervalSegment) GetOrCreateSegment(segmentName string) (Segment, error) {
 	segment, ok := s.getSegment(segmentName)
@@ -81,7 +111,8 @@ func (s *intervalSegment) GetOrCreateSegment(segmentName string) (Segment, error
 		defer s.mutex.Unlock()
 		segment, ok = s.getSegment(segmentName)
 		if !ok {
-			seg, err := newSegment(segmentName, s.interval, filepath.Join(s.path, segmentName))
+			seg, err := newSegment(segmentName, s.interval, filepath.Join(s.path, segmentName),
+				s.rollupTarget, s.tombstone)
 			if err != nil {
 				return nil, fmt.Errorf("create segmenet error: %s", err)
 			}
@@ -117,6 +148,104 @@ func (s *intervalSegment) getDataFamilies(timeRange timeutil.TimeRange) []DataFa
 	return result
 }
 
+// Expire removes the whole segments and data families which are expired(end time before expire time)
+func (s *intervalSegment) Expire(expireTime int64) error {
+	// lock for preventing create segment when expiring
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	// segment which base time before segment time of expire time is expired wholly
+	expireSegmentTime := s.interval.Calculator().CalcSegmentTime(expireTime)
+	var err error
+	s.segments.Range(func(k, v interface{}) bool {
+		segmentName := k.(string)
+		segment, ok := v.(Segment)
+		if !ok {
+			return true
+		}
+		baseTime := segment.BaseTime()
+		switch {
+		case baseTime < expireSegmentTime:
+			// remove segment first, so that new query cannot get it,
+			// closing waits the readers pinned by the queries in flight released before removing files
+			s.segments.Delete(segmentName)
+			segment.Close()
+			if err = removeDir(filepath.Join(s.path, segmentName)); err != nil {
+				err = fmt.Errorf("remove expired segment[%s] error: %s", segmentName, err)
+				return false
+			}
+			engineLogger.Info("remove expired segment",
+				logger.String("path", s.path), logger.String("segment", segmentName))
+		case baseTime == expireSegmentTime:
+			err = segment.Expire(expireTime)
+			return err == nil
+		}
+		return true
+	})
+	return err
+}
+
+// Backup backups all segments into target path, each segment is backed up into the sub directory of segment name
+func (s *intervalSegment) Backup(targetPath string) error {
+	// lock for preventing remove segment when backing up
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if err := mkDirIfNotExist(targetPath); err != nil {
+		return err
+	}
+	var err error
+	s.segments.Range(func(k, v interface{}) bool {
+		segmentName := k.(string)
+		segment, ok := v.(Segment)
+		if !ok {
+			return true
+		}
+		if err = segment.Backup(filepath.Join(targetPath, segmentName)); err != nil {
+			err = fmt.Errorf("backup segment[%s] error: %s", segmentName, err)
+			return false
+		}
+		return true
+	})
+	return err
+}
+
+// Compact triggers full compaction of the data families in all segments by family name,
+// triggers all families if family name is empty.
+func (s *intervalSegment) Compact(familyName string) {
+	s.segments.Range(func(k, v interface{}) bool {
+		segment, ok := v.(Segment)
+		if ok {
+			segment.Compact(familyName)
+		}
+		return true
+	})
+}
+
+// Purge purges the deleted data of the keys in all segments when compaction
+func (s *intervalSegment) Purge(keys *roaring.Bitmap) {
+	s.segments.Range(func(k, v interface{}) bool {
+		segment, ok := v.(Segment)
+		if ok {
+			segment.Purge(keys)
+		}
+		return true
+	})
+}
+
+// IsPurging returns if the deleted data of the keys is purging in any segment
+func (s *intervalSegment) IsPurging(keys *roaring.Bitmap) bool {
+	purging := false
+	s.segments.Range(func(k, v interface{}) bool {
+		segment, ok := v.(Segment)
+		if ok && segment.IsPurging(keys) {
+			purging = true
+		}
+		return !purging
+	})
+	return purging
+}
+
 // Close closes interval segment, release resource
 func (s *intervalSegment) Close() {
 	s.segments.Range(func(k, v interface{}) bool {
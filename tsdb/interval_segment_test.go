@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/pkg/fileutil"
@@ -21,7 +23,7 @@ func TestIntervalSegment_New(t *testing.T) {
 	mkDirIfNotExist = func(path string) error {
 		return fmt.Errorf("err")
 	}
-	s, err := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, err := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, s)
 	mkDirIfNotExist = fileutil.MkDirIfNotExist
@@ -30,13 +32,13 @@ func TestIntervalSegment_New(t *testing.T) {
 	listDir = func(path string) (strings []string, err error) {
 		return nil, fmt.Errorf("err")
 	}
-	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, s)
 	listDir = fileutil.ListDir
 
 	// case 3: create segment success
-	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, s)
 	assert.True(t, fileutil.Exist(segPath))
@@ -46,11 +48,12 @@ func TestIntervalSegment_New(t *testing.T) {
 	s1, err := newSegment(
 		"20190903",
 		timeutil.Interval(timeutil.OneSecond*10),
-		filepath.Join(segPath, "20190903"))
+		filepath.Join(segPath, "20190903"),
+		nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, s1)
 	// case 5: cannot re-open kv-store
-	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, err = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	assert.Nil(t, s)
 	assert.Error(t, err)
 }
@@ -59,7 +62,7 @@ func TestIntervalSegment_GetOrCreateSegment(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	seg, err := s.GetOrCreateSegment("20190702")
 	assert.Nil(t, err)
 	assert.NotNil(t, seg)
@@ -78,7 +81,7 @@ func TestIntervalSegment_GetOrCreateSegment(t *testing.T) {
 
 	s.Close()
 
-	s, _ = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ = newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 
 	s1, ok := s.(*intervalSegment)
 	if ok {
@@ -95,7 +98,7 @@ func TestIntervalSegment_getDataFamilies(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	segment1, _ := s.GetOrCreateSegment("20190902")
 	now, _ := timeutil.ParseTimestamp("20190902 19:10:48", "20060102 15:04:05")
 	_, _ = segment1.GetDataFamily(now)
@@ -139,3 +142,114 @@ func TestIntervalSegment_getDataFamilies(t *testing.T) {
 	segments = s.getDataFamilies(timeutil.TimeRange{Start: start, End: end})
 	assert.Equal(t, 1, len(segments))
 }
+
+func TestIntervalSegment_Expire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		removeDir = fileutil.RemoveDir
+		ctrl.Finish()
+	}()
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
+	for _, day := range []string{"20190902", "20190903", "20190904"} {
+		segment, _ := s.GetOrCreateSegment(day)
+		now, _ := timeutil.ParseTimestamp(day+" 10:10:48", "20060102 15:04:05")
+		_, _ = segment.GetDataFamily(now)
+		now, _ = timeutil.ParseTimestamp(day+" 20:10:48", "20060102 15:04:05")
+		_, _ = segment.GetDataFamily(now)
+	}
+	// case 1: remove whole segment and expire families
+	expireTime, _ := timeutil.ParseTimestamp("20190903 12:00:00", "20060102 15:04:05")
+	err := s.Expire(expireTime)
+	assert.NoError(t, err)
+	assert.False(t, fileutil.Exist(filepath.Join(segPath, "20190902")))
+	start, _ := timeutil.ParseTimestamp("20190902 00:00:00", "20060102 15:04:05")
+	end, _ := timeutil.ParseTimestamp("20190904 23:00:00", "20060102 15:04:05")
+	assert.Len(t, s.getDataFamilies(timeutil.TimeRange{Start: start, End: end}), 3)
+	// case 2: expire segment families err
+	segment := NewMockSegment(ctrl)
+	segmentTime, _ := timeutil.ParseTimestamp("20190903 00:00:00", "20060102 15:04:05")
+	segment.EXPECT().BaseTime().Return(segmentTime).AnyTimes()
+	segment.EXPECT().Expire(gomock.Any()).Return(fmt.Errorf("err"))
+	s1 := s.(*intervalSegment)
+	s1.segments.Store("test", segment)
+	err = s.Expire(expireTime)
+	assert.Error(t, err)
+	s1.segments.Delete("test")
+	// case 3: remove segment err
+	removeDir = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	expireTime, _ = timeutil.ParseTimestamp("20190905 12:00:00", "20060102 15:04:05")
+	err = s.Expire(expireTime)
+	assert.Error(t, err)
+	s.Close()
+}
+
+func TestIntervalSegment_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		mkDirIfNotExist = fileutil.MkDirIfNotExist
+		ctrl.Finish()
+	}()
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
+	segment, _ := s.GetOrCreateSegment("20190902")
+	now, _ := timeutil.ParseTimestamp("20190902 10:10:48", "20060102 15:04:05")
+	_, _ = segment.GetDataFamily(now)
+	backupPath := filepath.Join(testPath, "backup")
+	// case 1: backup successfully
+	assert.NoError(t, s.Backup(backupPath))
+	assert.True(t, fileutil.Exist(filepath.Join(backupPath, "20190902")))
+	s.Close()
+	// case 2: create backup path err
+	mkDirIfNotExist = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, s.Backup(backupPath))
+	mkDirIfNotExist = fileutil.MkDirIfNotExist
+	// case 3: backup segment err
+	mockSegment := NewMockSegment(ctrl)
+	mockSegment.EXPECT().Backup(gomock.Any()).Return(fmt.Errorf("err"))
+	s1 := s.(*intervalSegment)
+	s1.segments.Delete("20190902")
+	s1.segments.Store("20190903", mockSegment)
+	assert.Error(t, s.Backup(backupPath))
+}
+
+func TestIntervalSegment_Compact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := &intervalSegment{}
+	segment1 := NewMockSegment(ctrl)
+	segment2 := NewMockSegment(ctrl)
+	s.segments.Store("20190902", segment1)
+	s.segments.Store("20190903", segment2)
+	s.segments.Store("20190904", "not segment")
+	segment1.EXPECT().Compact("")
+	segment2.EXPECT().Compact("")
+	s.Compact("")
+}
+
+func TestIntervalSegment_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	s := &intervalSegment{}
+	segment1 := NewMockSegment(ctrl)
+	segment2 := NewMockSegment(ctrl)
+	s.segments.Store("20190902", segment1)
+	s.segments.Store("20190903", segment2)
+	s.segments.Store("20190904", "not segment")
+	keys := roaring.BitmapOf(1, 2)
+	segment1.EXPECT().Purge(keys)
+	segment2.EXPECT().Purge(keys)
+	s.Purge(keys)
+	// case: purging in any segment
+	segment1.EXPECT().IsPurging(keys).Return(false).AnyTimes()
+	segment2.EXPECT().IsPurging(keys).Return(true)
+	assert.True(t, s.IsPurging(keys))
+	segment2.EXPECT().IsPurging(keys).Return(false)
+	assert.False(t, s.IsPurging(keys))
+}
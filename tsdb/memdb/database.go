@@ -2,6 +2,7 @@ package memdb
 
 import (
 	"io"
+	"math"
 	"sort"
 	"sync"
 
@@ -212,17 +213,7 @@ func (md *memoryDatabase) Write(
 	tStore, size := mStore.GetOrCreateTStore(seriesID)
 	written := false
 
-	for _, f := range fields {
-		fieldType := getFieldType(f)
-		if fieldType == field.Unknown {
-			md.getUnknownFieldTypeCounter.Inc()
-			continue
-		}
-		fieldID, err := md.metadata.MetadataDatabase().GenFieldID(namespace, metricName, field.Name(f.Name), fieldType)
-		if err != nil {
-			md.generateFieldIDFailCounter.Inc()
-			continue
-		}
+	writeField := func(fieldID field.ID, fieldType field.Type, value float64) error {
 		md.writeDataPointCounter.Inc()
 		pStore, ok := tStore.GetFStore(fID, fieldID)
 		if !ok {
@@ -233,11 +224,43 @@ func (md *memoryDatabase) Write(
 			pStore = newFieldStore(buf, fID, fieldID)
 			size += tStore.InsertFStore(pStore)
 		}
-		size += pStore.Write(fieldType, slotIndex, f.Value)
+		size += pStore.Write(fieldType, slotIndex, value)
 
 		// if write data success, add field into metric level for cache
 		mStore.AddField(fieldID, fieldType)
 		written = true
+		return nil
+	}
+
+	for _, f := range fields {
+		fieldType := getFieldType(f)
+		if fieldType == field.Unknown || (fieldType == field.HistogramField && !isValidHistogram(f)) {
+			md.getUnknownFieldTypeCounter.Inc()
+			continue
+		}
+		if fieldType == field.HistogramField {
+			// histogram field stores as bucket fields, the last bucket's upper bound is +Inf,
+			// generates all bucket field ids before writing, avoid writing partial buckets.
+			fieldIDs, err := md.genHistogramFieldIDs(namespace, metricName, f)
+			if err != nil {
+				md.generateFieldIDFailCounter.Inc()
+				continue
+			}
+			for idx, value := range f.BucketValues {
+				if err := writeField(fieldIDs[idx], fieldType, value); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		fieldID, err := md.metadata.MetadataDatabase().GenFieldID(namespace, metricName, field.Name(f.Name), fieldType)
+		if err != nil {
+			md.generateFieldIDFailCounter.Inc()
+			continue
+		}
+		if err := writeField(fieldID, fieldType, f.Value); err != nil {
+			return err
+		}
 	}
 	if written {
 		mStore.SetTimestamp(fi, slotIndex)
@@ -246,6 +269,38 @@ func (md *memoryDatabase) Write(
 	return nil
 }
 
+// genHistogramFieldIDs generates the field ids of all buckets for histogram field.
+func (md *memoryDatabase) genHistogramFieldIDs(namespace, metricName string, f *pb.Field) ([]field.ID, error) {
+	fieldIDs := make([]field.ID, len(f.BucketValues))
+	for idx := range f.BucketValues {
+		upperBound := math.Inf(1)
+		if idx < len(f.ExplicitBounds) {
+			upperBound = f.ExplicitBounds[idx]
+		}
+		fieldID, err := md.metadata.MetadataDatabase().GenFieldID(namespace, metricName,
+			field.HistogramBucketName(f.Name, upperBound), field.HistogramField)
+		if err != nil {
+			return nil, err
+		}
+		fieldIDs[idx] = fieldID
+	}
+	return fieldIDs, nil
+}
+
+// isValidHistogram checks if the explicit bounds of histogram are in ascending order,
+// and the count of buckets is len(bounds)+1(include +Inf bucket).
+func isValidHistogram(f *pb.Field) bool {
+	if len(f.BucketValues) != len(f.ExplicitBounds)+1 {
+		return false
+	}
+	for idx := 1; idx < len(f.ExplicitBounds); idx++ {
+		if f.ExplicitBounds[idx] <= f.ExplicitBounds[idx-1] {
+			return false
+		}
+	}
+	return true
+}
+
 // Families returns the families in memory which has not been flushed yet.
 func (md *memoryDatabase) Families() []int64 {
 	var families []int64
@@ -150,6 +150,51 @@ func TestMemoryDatabase_Write(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, md.Families(), 2)
 	assert.True(t, md.MemSize() > 0)
+	// case 7: write histogram field as bucket fields
+	gomock.InOrder(
+		mockMetadataDatabase.EXPECT().GenFieldID("ns", "test1", field.Name("h__bucket_0.5"), field.HistogramField).Return(field.ID(2), nil),
+		mockMetadataDatabase.EXPECT().GenFieldID("ns", "test1", field.Name("h__bucket_+Inf"), field.HistogramField).Return(field.ID(3), nil),
+		tStore.EXPECT().GetFStore(gomock.Any(), field.ID(2)).Return(fStore, true),
+		fStore.EXPECT().Write(field.HistogramField, gomock.Any(), 1.0).Return(10),
+		mockMStore.EXPECT().AddField(field.ID(2), field.HistogramField),
+		tStore.EXPECT().GetFStore(gomock.Any(), field.ID(3)).Return(fStore, true),
+		fStore.EXPECT().Write(field.HistogramField, gomock.Any(), 2.0).Return(10),
+		mockMStore.EXPECT().AddField(field.ID(3), field.HistogramField),
+		mockMStore.EXPECT().SetTimestamp(gomock.Any(), gomock.Any()),
+	)
+	err = md.Write("ns", "test1", uint32(1), uint32(10), 1564300800000, []*pb.Field{{
+		Name:           "h",
+		Type:           pb.FieldType_Histogram,
+		ExplicitBounds: []float64{0.5},
+		BucketValues:   []float64{1, 2},
+	}})
+	assert.NoError(t, err)
+	// case 8: gen histogram bucket field id fail, skip whole histogram field
+	gomock.InOrder(
+		mockMetadataDatabase.EXPECT().GenFieldID("ns", "test1", field.Name("h__bucket_0.5"), field.HistogramField).Return(field.ID(2), nil),
+		mockMetadataDatabase.EXPECT().GenFieldID("ns", "test1", field.Name("h__bucket_+Inf"), field.HistogramField).
+			Return(field.ID(0), fmt.Errorf("err")),
+	)
+	err = md.Write("ns", "test1", uint32(1), uint32(10), 1564300800000, []*pb.Field{{
+		Name:           "h",
+		Type:           pb.FieldType_Histogram,
+		ExplicitBounds: []float64{0.5},
+		BucketValues:   []float64{1, 2},
+	}})
+	assert.NoError(t, err)
+	// case 9: invalid histogram field
+	err = md.Write("ns", "test1", uint32(1), uint32(10), 1564300800000, []*pb.Field{{
+		Name:           "h",
+		Type:           pb.FieldType_Histogram,
+		ExplicitBounds: []float64{0.5},
+		BucketValues:   []float64{1},
+	}, {
+		Name:           "h",
+		Type:           pb.FieldType_Histogram,
+		ExplicitBounds: []float64{0.5, 0.1},
+		BucketValues:   []float64{1, 2, 3},
+	}})
+	assert.NoError(t, err)
 
 	err = md.Close()
 	assert.NoError(t, err)
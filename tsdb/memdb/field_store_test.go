@@ -147,6 +147,18 @@ func TestFieldStore_Write2(t *testing.T) {
 	assert.Equal(t, uint16(0), s.getEnd())
 }
 
+func TestFieldStore_Write_Histogram(t *testing.T) {
+	buf := make([]byte, pageSize)
+	store := newFieldStore(buf, familyID(12), field.ID(1))
+	s := store.(*fieldStore)
+	// the count of histogram bucket in same time slot is summed
+	store.Write(field.HistogramField, 10, 3)
+	store.Write(field.HistogramField, 10, 5)
+	value, ok := s.getCurrentValue(10, 10)
+	assert.True(t, ok)
+	assert.InDelta(t, 8.0, value, 0)
+}
+
 func TestFieldStore_Write_Compact_err(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -16,6 +16,8 @@ func getFieldType(f *pb.Field) field.Type {
 		return field.MinField
 	case pb.FieldType_Gauge:
 		return field.GaugeField
+	case pb.FieldType_Histogram:
+		return field.HistogramField
 	default:
 		return field.Unknown
 	}
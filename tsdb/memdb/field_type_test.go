@@ -15,6 +15,7 @@ func TestFieldType_getFieldType(t *testing.T) {
 	assert.Equal(t, field.MinField, getFieldType(&pb.Field{Type: pb.FieldType_Min}))
 	assert.Equal(t, field.MaxField, getFieldType(&pb.Field{Type: pb.FieldType_Max}))
 	assert.Equal(t, field.GaugeField, getFieldType(&pb.Field{Type: pb.FieldType_Gauge}))
+	assert.Equal(t, field.HistogramField, getFieldType(&pb.Field{Type: pb.FieldType_Histogram}))
 }
 
 func TestBuildFieldKey(t *testing.T) {
@@ -51,6 +51,12 @@ type Metadata interface {
 	Flush() error
 }
 
+// MetricName represents the namespace and name of metric
+type MetricName struct {
+	Namespace string
+	Name      string
+}
+
 // MetadataDatabase represents the metadata storage includes namespace/metric metadata
 type MetadataDatabase interface {
 	io.Closer
@@ -60,6 +66,13 @@ type MetadataDatabase interface {
 
 	// SuggestNamespace suggests the namespace by namespace's prefix
 	SuggestNamespace(prefix string, limit int) (namespaces []string, err error)
+	// DropMetric drops the metadata of metric, the metric id of dropped metric is never reused,
+	// new metric id will be generated when writing the same metric again.
+	DropMetric(namespace, metricName string) error
 	// Sync syncs the pending metadata update event
 	Sync() error
+	// GetAllMetrics returns the namespace/name of all synced metrics, key is metric id
+	GetAllMetrics() (map[uint32]MetricName, error)
+	// Backup copies metadata storage and write ahead log into target path
+	Backup(targetPath string) error
 }
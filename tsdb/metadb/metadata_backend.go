@@ -30,10 +30,11 @@ var (
 )
 
 var (
-	nsBucketName     = []byte("ns")
-	metricBucketName = []byte("m")
-	tagBucketName    = []byte("t")
-	fieldBucketName  = []byte("f")
+	nsBucketName      = []byte("ns")
+	metricBucketName  = []byte("m")
+	tagBucketName     = []byte("t")
+	fieldBucketName   = []byte("f")
+	droppedBucketName = []byte("d")
 )
 
 // MetadataBackend represents the metadata backend storage
@@ -60,6 +61,8 @@ type MetadataBackend interface {
 	// getMetricMetadata gets the metric metadata include all fields/tags by metric id, if not exist return series.ErrNotFound
 	getMetricMetadata(metricID uint32) (metadata MetricMetadata, err error)
 
+	// getAllMetrics returns the namespace/name of all metrics, key is metric id
+	getAllMetrics() (metrics map[uint32]MetricName, err error)
 	// getMetricID gets the metric id by namespace and metric name, if not exist return series.ErrNotFound
 	getMetricID(namespace string, metricName string) (metricID uint32, err error)
 	// getTagKeyID gets the tag key id by metric id and tag key key, if not exist return series.ErrNotFound
@@ -71,11 +74,17 @@ type MetadataBackend interface {
 	// getAllFields returns the  all fields by metric id, if not exist return series.ErrNotFound
 	getAllFields(metricID uint32) (fields []field.Meta, err error)
 
-	// saveMetadata saves the pending metadata include namespace/metric metadata
+	// saveMetadata saves the pending metadata include namespace/metric metadata,
+	// the metadata of dropped metric is ignored.
 	saveMetadata(event *metadataUpdateEvent) error
+	// dropMetric removes the namespace/metric name => metric id mapping and metric metadata,
+	// then marks the metric id as dropped, the dropped metric id is never reused.
+	dropMetric(namespace, metricName string, metricID uint32) error
 
 	// sync syncs bbolt.DB file data
 	sync() error
+	// backup copies a consistent view of bbolt.DB file into target path
+	backup(targetPath string) error
 }
 
 // metadataBackend implements the MetadataBackend interface
@@ -112,6 +121,10 @@ func newMetadataBackend(parent string) (MetadataBackend, error) {
 		}
 		// load tag key id sequence
 		tagKeyIDSequence.Store(uint32(metricBucket.Sequence()))
+		// create dropped bucket for save dropped metric ids
+		if _, err := tx.CreateBucketIfNotExists(droppedBucketName); err != nil {
+			return err
+		}
 		return nil
 	})
 	if err != nil {
@@ -230,6 +243,29 @@ func (mb *metadataBackend) getMetricMetadata(metricID uint32) (metadata MetricMe
 	return
 }
 
+// getAllMetrics returns the namespace/name of all metrics, key is metric id
+func (mb *metadataBackend) getAllMetrics() (metrics map[uint32]MetricName, err error) {
+	metrics = make(map[uint32]MetricName)
+	err = mb.db.View(func(tx *bbolt.Tx) error {
+		return tx.Bucket(nsBucketName).ForEach(func(namespace, v []byte) error {
+			nsBucket := tx.Bucket(nsBucketName).Bucket(namespace)
+			if nsBucket == nil {
+				return nil
+			}
+			return nsBucket.ForEach(func(metricName, value []byte) error {
+				if len(value) == 4 {
+					metrics[binary.LittleEndian.Uint32(value)] = MetricName{
+						Namespace: string(namespace),
+						Name:      string(metricName),
+					}
+				}
+				return nil
+			})
+		})
+	})
+	return
+}
+
 // getMetricID gets the metric id by namespace and metric name, if not exist return series.ErrNotFound
 func (mb *metadataBackend) getMetricID(namespace string, metricName string) (metricID uint32, err error) {
 	err = mb.db.View(func(tx *bbolt.Tx) error {
@@ -320,10 +356,11 @@ func (mb *metadataBackend) getAllFields(metricID uint32) (fields []field.Meta, e
 // saveMetadata saves the pending metadata include namespace/metric metadata
 func (mb *metadataBackend) saveMetadata(event *metadataUpdateEvent) (err error) {
 	err = mb.db.Update(func(tx *bbolt.Tx) error {
-		if err := mb.saveNamespaceAndMetric(tx.Bucket(nsBucketName), event); err != nil {
+		droppedBucket := tx.Bucket(droppedBucketName)
+		if err := mb.saveNamespaceAndMetric(tx.Bucket(nsBucketName), droppedBucket, event); err != nil {
 			return err
 		}
-		if err := mb.saveMetricMetadata(tx.Bucket(metricBucketName), event); err != nil {
+		if err := mb.saveMetricMetadata(tx.Bucket(metricBucketName), droppedBucket, event); err != nil {
 			return err
 		}
 		return nil
@@ -331,11 +368,44 @@ func (mb *metadataBackend) saveMetadata(event *metadataUpdateEvent) (err error)
 	return
 }
 
+// dropMetric removes the namespace/metric name => metric id mapping and metric metadata,
+// then marks the metric id as dropped, the dropped metric id is never reused.
+func (mb *metadataBackend) dropMetric(namespace, metricName string, metricID uint32) error {
+	var scratch [4]byte
+	binary.LittleEndian.PutUint32(scratch[:], metricID)
+	return mb.db.Update(func(tx *bbolt.Tx) error {
+		if err := tx.Bucket(droppedBucketName).Put(scratch[:], []byte{}); err != nil {
+			return err
+		}
+		nsBucket := tx.Bucket(nsBucketName).Bucket([]byte(namespace))
+		if nsBucket != nil {
+			value := nsBucket.Get([]byte(metricName))
+			if len(value) == 4 && binary.LittleEndian.Uint32(value) == metricID {
+				if err := nsBucket.Delete([]byte(metricName)); err != nil {
+					return err
+				}
+			}
+		}
+		metricRootBucket := tx.Bucket(metricBucketName)
+		if metricRootBucket.Bucket(scratch[:]) != nil {
+			return metricRootBucket.DeleteBucket(scratch[:])
+		}
+		return nil
+	})
+}
+
 // sync syncs the bbolt.DB file data
 func (mb *metadataBackend) sync() error {
 	return mb.db.Sync()
 }
 
+// backup copies a consistent view of bbolt.DB file into target path
+func (mb *metadataBackend) backup(targetPath string) error {
+	return mb.db.View(func(tx *bbolt.Tx) error {
+		return tx.CopyFile(path.Join(targetPath, MetaDB), 0600)
+	})
+}
+
 // Close closes the bbolt.DB
 func (mb *metadataBackend) Close() error {
 	return mb.db.Close()
@@ -367,7 +437,9 @@ func loadTagKeys(tagKeyBucket *bbolt.Bucket) (tags []tag.Meta) {
 }
 
 // saveNamespaceAndMetric saves namespaces and metric entry set
-func (mb *metadataBackend) saveNamespaceAndMetric(nsRootBucket *bbolt.Bucket, event *metadataUpdateEvent) (err error) {
+func (mb *metadataBackend) saveNamespaceAndMetric(nsRootBucket, droppedBucket *bbolt.Bucket,
+	event *metadataUpdateEvent,
+) (err error) {
 	for ns, nsEvent := range event.namespaces {
 		// save namespace name
 		bucket, err := nsRootBucket.CreateBucketIfNotExists([]byte(ns))
@@ -378,6 +450,10 @@ func (mb *metadataBackend) saveNamespaceAndMetric(nsRootBucket *bbolt.Bucket, ev
 		for _, metric := range nsEvent.metrics {
 			var scratch [4]byte
 			binary.LittleEndian.PutUint32(scratch[:], metric.id)
+			if droppedBucket.Get(scratch[:]) != nil {
+				// ignore dropped metric
+				continue
+			}
 			if err := bucket.Put([]byte(metric.name), scratch[:]); err != nil {
 				return err
 			}
@@ -396,11 +472,17 @@ func (mb *metadataBackend) saveNamespaceAndMetric(nsRootBucket *bbolt.Bucket, ev
 }
 
 // saveMetricMetadata saves metric metadata include fields/tag keys if exist with metric root bucket
-func (mb *metadataBackend) saveMetricMetadata(metricRootBucket *bbolt.Bucket, event *metadataUpdateEvent) (err error) {
+func (mb *metadataBackend) saveMetricMetadata(metricRootBucket, droppedBucket *bbolt.Bucket,
+	event *metadataUpdateEvent,
+) (err error) {
 	for metricID, meta := range event.metrics {
 		var scratch [4]byte
 		binary.LittleEndian.PutUint32(scratch[:], metricID)
 		mID := scratch[:]
+		if droppedBucket.Get(mID) != nil {
+			// ignore dropped metric
+			continue
+		}
 		metricBucket := metricRootBucket.Bucket(mID)
 		var fBucket *bbolt.Bucket
 		var tBucket *bbolt.Bucket
@@ -88,6 +88,22 @@ func TestMetadataBackend_suggestNamespace(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestMetadataBackend_getAllMetrics(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	db := mockMetadataBackend(t)
+
+	metrics, err := db.getAllMetrics()
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]MetricName{
+		1: {Namespace: "ns-1", Name: "name1"},
+		2: {Namespace: "ns-1", Name: "name2"},
+		3: {Namespace: "ns-2", Name: "name3"},
+		4: {Namespace: "ns-2", Name: "name2"},
+	}, metrics)
+}
+
 func TestMetadataBackend_suggestMetricName(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
@@ -256,6 +272,42 @@ func TestMetadataBackend_saveMetadata(t *testing.T) {
 	assert.Equal(t, constants.ErrNotFound, err)
 }
 
+func TestMetadataBackend_dropMetric(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	db := mockMetadataBackend(t)
+	// case 1: drop metric
+	err := db.dropMetric("ns-1", "name1", 1)
+	assert.NoError(t, err)
+	_, err = db.getMetricID("ns-1", "name1")
+	assert.Equal(t, constants.ErrNotFound, err)
+	_, err = db.getMetricMetadata(1)
+	assert.Equal(t, constants.ErrNotFound, err)
+	// case 2: metadata of dropped metric is ignored
+	err = db.saveMetadata(mockMetadataEvent())
+	assert.NoError(t, err)
+	_, err = db.getMetricID("ns-1", "name1")
+	assert.Equal(t, constants.ErrNotFound, err)
+	_, err = db.getAllFields(1)
+	assert.Equal(t, constants.ErrNotFound, err)
+	// case 3: metric name is mapping to new metric id
+	event := newMetadataUpdateEvent()
+	event.addMetric("ns-1", "name1", 10)
+	err = db.saveMetadata(event)
+	assert.NoError(t, err)
+	err = db.dropMetric("ns-1", "name1", 1)
+	assert.NoError(t, err)
+	metricID, err := db.getMetricID("ns-1", "name1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), metricID)
+	// case 4: drop not exist namespace
+	err = db.dropMetric("ns-3", "name1", 100)
+	assert.NoError(t, err)
+	err = db.Close()
+	assert.NoError(t, err)
+}
+
 func TestMetadataBackend_save_err(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
@@ -355,6 +407,27 @@ func TestMetadataBackend_sync(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestMetadataBackend_backup(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	db := mockMetadataBackend(t)
+	// case 1: target path not exist
+	err := db.backup(filepath.Join(testPath, "not_exist", "backup"))
+	assert.Error(t, err)
+	// case 2: backup successfully
+	backupPath := filepath.Join(testPath, "backup")
+	assert.NoError(t, fileutil.MkDirIfNotExist(backupPath))
+	assert.NoError(t, db.backup(backupPath))
+	assert.NoError(t, db.Close())
+	backup, err := newMetadataBackend(backupPath)
+	assert.NoError(t, err)
+	metricID, err := backup.getMetricID("ns-1", "name2")
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(2), metricID)
+	assert.NoError(t, backup.Close())
+}
+
 func newMockMetadataBackend(t *testing.T) MetadataBackend {
 	db, err := newMetadataBackend(testPath)
 	assert.NoError(t, err)
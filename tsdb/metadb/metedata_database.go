@@ -24,6 +24,7 @@ import (
 var (
 	createMetadataBackend = newMetadataBackend
 	createMetaWAL         = wal.NewMetricMetaWAL
+	backupWALFunc         = wal.Backup
 )
 
 var (
@@ -369,6 +370,36 @@ func (mdb *metadataDatabase) GenTagKeyID(namespace, metricName, tagKey string) (
 	return
 }
 
+// DropMetric drops the metadata of metric, the metric id of dropped metric is never reused,
+// new metric id will be generated when writing the same metric again.
+func (mdb *metadataDatabase) DropMetric(namespace, metricName string) error {
+	key := namespace + metricName
+
+	mdb.rwMux.Lock()
+	defer mdb.rwMux.Unlock()
+
+	var metricID uint32
+	metricMetadata, ok := mdb.metrics[key]
+	if ok {
+		metricID = metricMetadata.getMetricID()
+	} else {
+		var err error
+		metricID, err = mdb.backend.getMetricID(namespace, metricName)
+		if err != nil {
+			return err
+		}
+	}
+	if err := mdb.backend.dropMetric(namespace, metricName, metricID); err != nil {
+		return err
+	}
+	delete(mdb.metrics, key)
+
+	metaLogger.Info("drop metric successfully",
+		logger.String("db", mdb.path), logger.String("namespace", namespace),
+		logger.String("metric", metricName), logger.Uint32("metricID", metricID))
+	return nil
+}
+
 // Sync syncs the bbolt.DB's data file and metadata write ahead log
 func (mdb *metadataDatabase) Sync() error {
 	if err := mdb.metaWAL.Sync(); err != nil {
@@ -378,6 +409,27 @@ func (mdb *metadataDatabase) Sync() error {
 	return nil
 }
 
+// GetAllMetrics returns the namespace/name of all synced metrics, key is metric id,
+// the metric which is generated but not synced isn't included.
+func (mdb *metadataDatabase) GetAllMetrics() (map[uint32]MetricName, error) {
+	return mdb.backend.getAllMetrics()
+}
+
+// Backup copies metadata storage and write ahead log into target path,
+// copies wal firstly, because the data of released wal page has been saved into backend storage.
+func (mdb *metadataDatabase) Backup(targetPath string) error {
+	mdb.rwMux.Lock()
+	defer mdb.rwMux.Unlock()
+
+	if err := mdb.metaWAL.Sync(); err != nil {
+		return err
+	}
+	if err := backupWALFunc(filepath.Join(mdb.path, walPath), filepath.Join(targetPath, walPath)); err != nil {
+		return err
+	}
+	return mdb.backend.backup(targetPath)
+}
+
 // Close closes the resources
 func (mdb *metadataDatabase) Close() error {
 	mdb.cancel()
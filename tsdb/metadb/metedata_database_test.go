@@ -3,6 +3,7 @@ package metadb
 import (
 	"context"
 	"fmt"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -91,6 +92,29 @@ func TestMetadataDatabase_SuggestNamespace(t *testing.T) {
 	_ = db.Close()
 }
 
+func TestMetadataDatabase_GetAllMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		createMetadataBackend = newMetadataBackend
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+	mockBackend := NewMockMetadataBackend(ctrl)
+	createMetadataBackend = func(parent string) (backend MetadataBackend, err error) {
+		return mockBackend, nil
+	}
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	mockBackend.EXPECT().getAllMetrics().Return(map[uint32]MetricName{1: {Namespace: "ns", Name: "name"}}, nil)
+	metrics, err := db.GetAllMetrics()
+	assert.NoError(t, err)
+	assert.Equal(t, map[uint32]MetricName{1: {Namespace: "ns", Name: "name"}}, metrics)
+
+	mockBackend.EXPECT().Close().Return(nil)
+	_ = db.Close()
+}
+
 func TestMetadataDatabase_SuggestMetricName(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -369,6 +393,50 @@ func TestMetadataDatabase_GenMetricID(t *testing.T) {
 	_ = db.Close()
 }
 
+func TestMetadataDatabase_DropMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		createMetadataBackend = newMetadataBackend
+		_ = fileutil.RemoveDir(testPath)
+
+		ctrl.Finish()
+	}()
+	mockBackend := NewMockMetadataBackend(ctrl)
+	createMetadataBackend = func(parent string) (backend MetadataBackend, err error) {
+		return mockBackend, nil
+	}
+	db, err := NewMetadataDatabase(context.TODO(), "test", testPath)
+	assert.NoError(t, err)
+	gomock.InOrder(
+		mockBackend.EXPECT().loadMetricMetadata("ns-1", "name1").Return(nil, constants.ErrNotFound),
+		mockBackend.EXPECT().genMetricID().Return(uint32(1)),
+	)
+	_, err = db.GenMetricID("ns-1", "name1")
+	assert.NoError(t, err)
+	// case 1: drop metric err
+	mockBackend.EXPECT().dropMetric("ns-1", "name1", uint32(1)).Return(fmt.Errorf("err"))
+	err = db.DropMetric("ns-1", "name1")
+	assert.Error(t, err)
+	// case 2: drop metric in memory
+	mockBackend.EXPECT().dropMetric("ns-1", "name1", uint32(1)).Return(nil)
+	err = db.DropMetric("ns-1", "name1")
+	assert.NoError(t, err)
+	// case 3: drop metric in backend
+	mockBackend.EXPECT().getMetricID("ns-1", "name1").Return(uint32(10), nil)
+	mockBackend.EXPECT().dropMetric("ns-1", "name1", uint32(10)).Return(nil)
+	err = db.DropMetric("ns-1", "name1")
+	assert.NoError(t, err)
+	// case 4: metric not exist
+	mockBackend.EXPECT().getMetricID("ns-1", "name2").Return(uint32(0), constants.ErrNotFound)
+	err = db.DropMetric("ns-1", "name2")
+	assert.Equal(t, constants.ErrNotFound, err)
+
+	mockBackend.EXPECT().saveMetadata(gomock.Any()).AnyTimes()
+	mockBackend.EXPECT().sync().Return(nil).AnyTimes()
+	mockBackend.EXPECT().Close().Return(nil)
+	_ = db.Close()
+}
+
 func TestMetadataDatabase_GetMetricID_wal(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -818,3 +886,46 @@ func newMockMetadataDatabase(t *testing.T) MetadataDatabase {
 
 	return db
 }
+
+func TestMetadataDatabase_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		backupWALFunc = wal.Backup
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	db, err := NewMetadataDatabase(context.TODO(), "test", filepath.Join(testPath, "db"))
+	assert.NoError(t, err)
+	metricID, err := db.GenMetricID("ns", "name")
+	assert.NoError(t, err)
+	backupPath := filepath.Join(testPath, "backup")
+	// case 1: backup successfully
+	assert.NoError(t, db.Backup(backupPath))
+	assert.NoError(t, db.Close())
+	// open metadata database from backup, metadata recovers from wal
+	backup, err := NewMetadataDatabase(context.TODO(), "test", backupPath)
+	assert.NoError(t, err)
+	metricID2, err := backup.GetMetricID("ns", "name")
+	assert.NoError(t, err)
+	assert.Equal(t, metricID, metricID2)
+	assert.NoError(t, backup.Close())
+
+	mockWAL := wal.NewMockMetricMetaWAL(ctrl)
+	mockBackend := NewMockMetadataBackend(ctrl)
+	db1 := &metadataDatabase{path: testPath, metaWAL: mockWAL, backend: mockBackend}
+	// case 2: sync wal err
+	mockWAL.EXPECT().Sync().Return(fmt.Errorf("err"))
+	assert.Error(t, db1.Backup(backupPath))
+	// case 3: backup wal err
+	mockWAL.EXPECT().Sync().Return(nil).AnyTimes()
+	backupWALFunc = func(path, target string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, db1.Backup(backupPath))
+	// case 4: backup backend err
+	backupWALFunc = func(path, target string) error {
+		return nil
+	}
+	mockBackend.EXPECT().backup(backupPath).Return(fmt.Errorf("err"))
+	assert.Error(t, db1.Backup(backupPath))
+}
@@ -0,0 +1,50 @@
+package tsdb
+
+import (
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+// rollup implements kv.Rollup interface,
+// represents the rollup relation of source data family => target data family(larger interval).
+type rollup struct {
+	sourceInterval  timeutil.Interval
+	sourceBaseTime  int64 // start time of source family
+	targetInterval  timeutil.Interval
+	targetBaseTime  int64 // start time of target family
+	targetFamily    kv.Family
+	targetIntervalC timeutil.IntervalCalculator
+}
+
+// newRollup creates the rollup relation of source data family => target data family
+func newRollup(sourceInterval timeutil.Interval, sourceBaseTime int64, target DataFamily) kv.Rollup {
+	targetInterval := timeutil.Interval(target.Interval())
+	return &rollup{
+		sourceInterval:  sourceInterval,
+		sourceBaseTime:  sourceBaseTime,
+		targetInterval:  targetInterval,
+		targetBaseTime:  target.TimeRange().Start,
+		targetFamily:    target.Family(),
+		targetIntervalC: targetInterval.Calculator(),
+	}
+}
+
+// GetTimestamp returns the timestamp based on source family and source slot
+func (r *rollup) GetTimestamp(slot uint16) int64 {
+	return r.sourceBaseTime + int64(slot)*r.sourceInterval.Int64()
+}
+
+// IntervalRatio return interval ratio = target interval/source interval
+func (r *rollup) IntervalRatio() uint16 {
+	return uint16(r.targetInterval.Int64() / r.sourceInterval.Int64())
+}
+
+// CalcSlot calculates the target slot based on source timestamp
+func (r *rollup) CalcSlot(timestamp int64) uint16 {
+	return uint16(r.targetIntervalC.CalcSlot(timestamp, r.targetBaseTime, r.targetInterval.Int64()))
+}
+
+// TargetFamily returns the target family of source family
+func (r *rollup) TargetFamily() kv.Family {
+	return r.targetFamily
+}
@@ -0,0 +1,33 @@
+package tsdb
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/pkg/timeutil"
+)
+
+func TestRollup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	sourceBaseTime, _ := timeutil.ParseTimestamp("20190904 10:00:00", "20060102 15:04:05")
+	targetBaseTime, _ := timeutil.ParseTimestamp("20190904 00:00:00", "20060102 15:04:05")
+	family := kv.NewMockFamily(ctrl)
+	target := NewMockDataFamily(ctrl)
+	target.EXPECT().Interval().Return(5 * timeutil.OneMinute)
+	target.EXPECT().TimeRange().Return(timeutil.TimeRange{Start: targetBaseTime})
+	target.EXPECT().Family().Return(family)
+
+	r := newRollup(timeutil.Interval(10*timeutil.OneSecond), sourceBaseTime, target)
+	assert.Equal(t, family, r.TargetFamily())
+	assert.Equal(t, uint16(30), r.IntervalRatio())
+	assert.Equal(t, sourceBaseTime+10*timeutil.OneSecond, r.GetTimestamp(1))
+	// 10:00:10 => slot 120 of target family(5 minutes interval)
+	assert.Equal(t, uint16(120), r.CalcSlot(r.GetTimestamp(1)))
+	// 10:05:00 => slot 121 of target family
+	assert.Equal(t, uint16(121), r.CalcSlot(r.GetTimestamp(30)))
+}
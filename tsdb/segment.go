@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"sync"
 
+	"github.com/lindb/roaring"
+
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/pkg/logger"
@@ -26,8 +28,19 @@ type Segment interface {
 	BaseTime() int64
 	// GetDataFamily returns the data family based on timestamp
 	GetDataFamily(timestamp int64) (DataFamily, error)
+	// Expire drops the data families which end time before expire time
+	Expire(expireTime int64) error
 	// Close closes segment, include kv store
 	Close()
+	// Backup links or copies the current files of kv store into target path
+	Backup(targetPath string) error
+	// Compact triggers full compaction of the data families in kv store by family name,
+	// triggers all families if family name is empty.
+	Compact(familyName string)
+	// Purge purges the deleted data of the keys in kv store when compaction
+	Purge(keys *roaring.Bitmap)
+	// IsPurging returns if the deleted data of the keys is purging in kv store
+	IsPurging(keys *roaring.Bitmap) bool
 	// getDataFamilies returns data family list by time range, return nil if not match
 	getDataFamilies(timeRange timeutil.TimeRange) []DataFamily
 }
@@ -39,16 +52,22 @@ type segment struct {
 	interval timeutil.Interval
 	families sync.Map
 
+	rollupTarget IntervalSegment // target interval segment for rollup, nil if no rollup
+
 	mutex sync.Mutex
 
 	logger *logger.Logger
 }
 
-// newSegment returns segment, segment is wrapper of kv store
+// newSegment returns segment, segment is wrapper of kv store,
+// if rollup target isn't nil, registers the rollup relation into kv store,
+// if tombstone isn't nil, registers the tombstone into kv store for purging deleted data.
 func newSegment(
 	segmentName string,
 	interval timeutil.Interval,
 	path string,
+	rollupTarget IntervalSegment,
+	tombstone kv.Tombstone,
 ) (
 	Segment,
 	error,
@@ -69,6 +88,14 @@ func newSegment(
 		kvStore:  kvStore,
 		interval: interval,
 		logger:   logger.GetLogger("tsdb", "Segment"),
+
+		rollupTarget: rollupTarget,
+	}
+	if rollupTarget != nil {
+		kvStore.RegisterRollup(rollupTarget.Interval(), s.newRollup)
+	}
+	if tombstone != nil {
+		kvStore.RegisterTombstone(tombstone)
 	}
 	for _, familyName := range familyNames {
 		familyTime, err := strconv.Atoi(familyName)
@@ -144,6 +171,56 @@ func (s *segment) GetDataFamily(timestamp int64) (DataFamily, error) {
 	return f, nil
 }
 
+// Expire drops the data families which end time before expire time
+func (s *segment) Expire(expireTime int64) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var err error
+	s.families.Range(func(k, v interface{}) bool {
+		family, ok := v.(DataFamily)
+		if !ok {
+			return true
+		}
+		timeRange := family.TimeRange()
+		if timeRange.End >= expireTime {
+			return true
+		}
+		if err = s.kvStore.DropFamily(family.Family().Name()); err != nil {
+			return false
+		}
+		s.families.Delete(k)
+		s.logger.Info("drop expired data family",
+			logger.String("family", family.Family().Name()), logger.Int64("end", timeRange.End))
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("drop expired data family error: %s", err)
+	}
+	return nil
+}
+
+// Backup links or copies the current files of kv store into target path
+func (s *segment) Backup(targetPath string) error {
+	return s.kvStore.Backup(targetPath)
+}
+
+// Compact triggers full compaction of the data families in kv store by family name,
+// triggers all families if family name is empty.
+func (s *segment) Compact(familyName string) {
+	s.kvStore.Compact(familyName)
+}
+
+// Purge purges the deleted data of the keys in kv store when compaction
+func (s *segment) Purge(keys *roaring.Bitmap) {
+	s.kvStore.Purge(keys)
+}
+
+// IsPurging returns if the deleted data of the keys is purging in kv store
+func (s *segment) IsPurging(keys *roaring.Bitmap) bool {
+	return s.kvStore.IsPurging(keys)
+}
+
 // Close closes segment, include kv store
 func (s *segment) Close() {
 	if err := s.kvStore.Close(); err != nil {
@@ -151,6 +228,26 @@ func (s *segment) Close() {
 	}
 }
 
+// newRollup creates the rollup relation of source data family in this segment => target data family,
+// the target data family is created if not exist.
+func (s *segment) newRollup(sourceFamilyName string) (kv.Rollup, error) {
+	familyTime, err := strconv.Atoi(sourceFamilyName)
+	if err != nil {
+		return nil, fmt.Errorf("parse source family[%s] time error:%s", sourceFamilyName, err)
+	}
+	familyStartTime := s.interval.Calculator().CalcFamilyStartTime(s.baseTime, familyTime)
+	targetSegment, err := s.rollupTarget.GetOrCreateSegment(
+		s.rollupTarget.Interval().Calculator().GetSegment(familyStartTime))
+	if err != nil {
+		return nil, err
+	}
+	targetFamily, err := targetSegment.GetDataFamily(familyStartTime)
+	if err != nil {
+		return nil, err
+	}
+	return newRollup(s.interval, familyStartTime, targetFamily), nil
+}
+
 func (s *segment) initDataFamily(familyTime int, family kv.Family) DataFamily {
 	calc := s.interval.Calculator()
 	// create data family
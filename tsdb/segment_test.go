@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/constants"
@@ -20,7 +21,7 @@ func TestSegment_Close(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	seg, _ := s.GetOrCreateSegment("20190702")
 	seg1 := seg.(*segment)
 
@@ -37,7 +38,7 @@ func TestSegment_GetDataFamily(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath)
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
 	seg, _ := s.GetOrCreateSegment("20190904")
 	now, _ := timeutil.ParseTimestamp("20190904 19:10:48", "20060102 15:04:05")
 	familyBaseTime, _ := timeutil.ParseTimestamp("20190904 19:00:00", "20060102 15:04:05")
@@ -86,7 +87,7 @@ func TestSegment_New(t *testing.T) {
 	defer func() {
 		_ = fileutil.RemoveDir(testPath)
 	}()
-	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath)
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, s)
 	now, _ := timeutil.ParseTimestamp("20190904 19:10:40", "20060102 15:04:05")
@@ -96,7 +97,7 @@ func TestSegment_New(t *testing.T) {
 	s.Close()
 
 	// reopen
-	s, err = newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath)
+	s, err = newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, s)
 	f, err = s.GetDataFamily(now)
@@ -104,7 +105,7 @@ func TestSegment_New(t *testing.T) {
 	assert.NotNil(t, f)
 
 	// cannot reopen
-	s2, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath)
+	s2, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, s2)
 
@@ -125,7 +126,122 @@ func TestSegment_loadFamily_err(t *testing.T) {
 		return kvStore, nil
 	}
 	kvStore.EXPECT().ListFamilyNames().Return([]string{"abc"})
-	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath)
+	s, err := newSegment("20190904", timeutil.Interval(timeutil.OneSecond*10), testPath, nil, nil)
 	assert.Error(t, err)
 	assert.Nil(t, s)
 }
+
+func TestSegment_Expire(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	s, _ := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, nil, nil)
+	seg, _ := s.GetOrCreateSegment("20190904")
+	for _, hour := range []string{"10", "11", "19"} {
+		now, _ := timeutil.ParseTimestamp("20190904 "+hour+":10:48", "20060102 15:04:05")
+		_, _ = seg.GetDataFamily(now)
+	}
+	expireTime, _ := timeutil.ParseTimestamp("20190904 12:00:00", "20060102 15:04:05")
+	// case 1: expire families
+	err := seg.Expire(expireTime)
+	assert.NoError(t, err)
+	seg1 := seg.(*segment)
+	assert.Equal(t, []string{"19"}, seg1.kvStore.ListFamilyNames())
+	start, _ := timeutil.ParseTimestamp("20190904 00:00:00", "20060102 15:04:05")
+	end, _ := timeutil.ParseTimestamp("20190904 23:00:00", "20060102 15:04:05")
+	assert.Len(t, seg.getDataFamilies(timeutil.TimeRange{Start: start, End: end}), 1)
+	// case 2: drop family err
+	store := kv.NewMockStore(ctrl)
+	store.EXPECT().Close().Return(nil).AnyTimes()
+	realStore := seg1.kvStore
+	seg1.kvStore = store
+	store.EXPECT().DropFamily(gomock.Any()).Return(fmt.Errorf("err"))
+	expireTime, _ = timeutil.ParseTimestamp("20190904 23:00:00", "20060102 15:04:05")
+	err = seg.Expire(expireTime)
+	assert.Error(t, err)
+	seg1.kvStore = realStore
+	s.Close()
+}
+
+func TestSegment_newRollup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	target, err := newIntervalSegment(timeutil.Interval(timeutil.OneMinute*5),
+		filepath.Join(testPath, shardDir, "2", segmentDir, rollupDir, "300000"), nil, nil)
+	assert.NoError(t, err)
+	s, err := newIntervalSegment(timeutil.Interval(timeutil.OneSecond*10), segPath, target, nil)
+	assert.NoError(t, err)
+	seg, err := s.GetOrCreateSegment("20190904")
+	assert.NoError(t, err)
+	seg1 := seg.(*segment)
+	// case 1: parse family time err
+	r, err := seg1.newRollup("abc")
+	assert.Error(t, err)
+	assert.Nil(t, r)
+	// case 2: create rollup relation successfully
+	r, err = seg1.newRollup("10")
+	assert.NoError(t, err)
+	sourceBaseTime, _ := timeutil.ParseTimestamp("20190904 10:00:00", "20060102 15:04:05")
+	assert.Equal(t, sourceBaseTime, r.GetTimestamp(0))
+	assert.Equal(t, uint16(30), r.IntervalRatio())
+	assert.Equal(t, "4", r.TargetFamily().Name())
+	// case 3: get target segment err
+	targetSegment := NewMockIntervalSegment(ctrl)
+	targetSegment.EXPECT().Interval().Return(timeutil.Interval(timeutil.OneMinute * 5)).AnyTimes()
+	seg1.rollupTarget = targetSegment
+	targetSegment.EXPECT().GetOrCreateSegment(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	r, err = seg1.newRollup("10")
+	assert.Error(t, err)
+	assert.Nil(t, r)
+	// case 4: get target family err
+	mockSegment := NewMockSegment(ctrl)
+	targetSegment.EXPECT().GetOrCreateSegment(gomock.Any()).Return(mockSegment, nil)
+	mockSegment.EXPECT().GetDataFamily(gomock.Any()).Return(nil, fmt.Errorf("err"))
+	r, err = seg1.newRollup("10")
+	assert.Error(t, err)
+	assert.Nil(t, r)
+	s.Close()
+	target.Close()
+}
+
+func TestSegment_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	store := kv.NewMockStore(ctrl)
+	seg := &segment{kvStore: store}
+	store.EXPECT().Backup("backup").Return(fmt.Errorf("err"))
+	assert.Error(t, seg.Backup("backup"))
+	store.EXPECT().Backup("backup").Return(nil)
+	assert.NoError(t, seg.Backup("backup"))
+}
+
+func TestSegment_Compact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := kv.NewMockStore(ctrl)
+	seg := &segment{kvStore: store}
+	store.EXPECT().Compact("20")
+	seg.Compact("20")
+}
+
+func TestSegment_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := kv.NewMockStore(ctrl)
+	seg := &segment{kvStore: store}
+	keys := roaring.BitmapOf(1, 2)
+	store.EXPECT().Purge(keys)
+	seg.Purge(keys)
+	store.EXPECT().IsPurging(keys).Return(true)
+	assert.True(t, seg.IsPurging(keys))
+}
@@ -68,6 +68,30 @@ func newReplicaSequence(dirPath string) (ReplicaSequence, error) {
 	return &replicaSequence{dirPath: dirPath}, nil
 }
 
+// backupReplicaSequence writes the replica sequences of heads into target path, the heads are acked,
+// so that the shard restored from backup continues replication from the heads.
+func backupReplicaSequence(targetPath string, heads map[string]int64) error {
+	if err := mkDirIfNotExist(targetPath); err != nil {
+		return err
+	}
+	for remotePeer, head := range heads {
+		seq, err := newSequenceFunc(path.Join(targetPath, remotePeer))
+		if err != nil {
+			return err
+		}
+		seq.SetHeadSeq(head)
+		seq.SetAckSeq(head)
+		if err := seq.Sync(); err != nil {
+			_ = seq.Close()
+			return err
+		}
+		if err := seq.Close(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // getOrCreateSequence gets the replica sequence by remote replica peer if exist, else creates a new sequence
 func (ss *replicaSequence) getOrCreateSequence(remotePeer string) (replication.Sequence, error) {
 	val, ok := ss.sequenceMap.Load(remotePeer)
@@ -161,3 +161,46 @@ func TestReplicaSequence_Close(t *testing.T) {
 	err = seq.Close()
 	assert.Error(t, err)
 }
+
+func TestBackupReplicaSequence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		mkDirIfNotExist = fileutil.MkDirIfNotExist
+		newSequenceFunc = replication.NewSequence
+		ctrl.Finish()
+	}()
+	heads := map[string]int64{"remote-test": 10}
+	// case 1: create dir err
+	mkDirIfNotExist = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, backupReplicaSequence(_testSequencePath, heads))
+	mkDirIfNotExist = fileutil.MkDirIfNotExist
+	// case 2: create sequence err
+	newSequenceFunc = func(dirPath string) (replication.Sequence, error) {
+		return nil, fmt.Errorf("err")
+	}
+	assert.Error(t, backupReplicaSequence(_testSequencePath, heads))
+	// case 3: sync sequence err
+	mockSeq := replication.NewMockSequence(ctrl)
+	newSequenceFunc = func(dirPath string) (replication.Sequence, error) {
+		return mockSeq, nil
+	}
+	mockSeq.EXPECT().SetHeadSeq(int64(10)).AnyTimes()
+	mockSeq.EXPECT().SetAckSeq(int64(10)).AnyTimes()
+	mockSeq.EXPECT().Sync().Return(fmt.Errorf("err"))
+	mockSeq.EXPECT().Close().Return(nil)
+	assert.Error(t, backupReplicaSequence(_testSequencePath, heads))
+	// case 4: close sequence err
+	mockSeq.EXPECT().Sync().Return(nil)
+	mockSeq.EXPECT().Close().Return(fmt.Errorf("err"))
+	assert.Error(t, backupReplicaSequence(_testSequencePath, heads))
+	newSequenceFunc = replication.NewSequence
+	// case 5: backup successfully, the head is restored
+	assert.NoError(t, backupReplicaSequence(_testSequencePath, heads))
+	seq, err := newReplicaSequence(_testSequencePath)
+	assert.NoError(t, err)
+	assert.Equal(t, heads, seq.getAllHeads())
+	assert.NoError(t, seq.Close())
+}
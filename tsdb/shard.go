@@ -5,14 +5,17 @@ import (
 	"fmt"
 	"io"
 	"path/filepath"
+	"runtime"
 	"strconv"
 	"sync"
 
+	"github.com/lindb/roaring"
 	"github.com/prometheus/client_golang/prometheus"
 	"go.uber.org/atomic"
 
 	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/models"
 	"github.com/lindb/lindb/monitoring"
 	"github.com/lindb/lindb/pkg/logger"
 	"github.com/lindb/lindb/pkg/option"
@@ -24,6 +27,7 @@ import (
 	"github.com/lindb/lindb/tsdb/metadb"
 	"github.com/lindb/lindb/tsdb/tblstore/invertedindex"
 	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+	"github.com/lindb/lindb/tsdb/wal"
 )
 
 //go:generate mockgen -source=./shard.go -destination=./shard_mock.go -package=tsdb
@@ -35,6 +39,7 @@ var (
 	newKVStoreFunc         = kv.NewStore
 	newIndexDBFunc         = indexdb.NewIndexDatabase
 	newMemoryDBFunc        = memdb.NewMemoryDatabase
+	newDataWALFunc         = wal.NewDataWAL
 )
 
 var (
@@ -73,11 +78,13 @@ func init() {
 const (
 	replicaDir       = "replica"
 	segmentDir       = "segment"
+	rollupDir        = "rollup"
 	indexParentDir   = "index"
 	forwardIndexDir  = "forward"
 	invertedIndexDir = "inverted"
 	metaDir          = "meta"
 	tempDir          = "temp"
+	walDir           = "wal"
 )
 
 // Shard is a horizontal partition of metrics for LinDB.
@@ -88,14 +95,24 @@ type Shard interface {
 	ShardID() int32
 	// ShardInfo returns the unique shard info
 	ShardInfo() string
-	// GetDataFamilies returns data family list by interval type and time range, return nil if not match
-	GetDataFamilies(intervalType timeutil.IntervalType, timeRange timeutil.TimeRange) []DataFamily
+	// GetDataFamilies returns data family list by interval and time range, return nil if not match
+	GetDataFamilies(interval timeutil.Interval, timeRange timeutil.TimeRange) []DataFamily
+	// Interval returns the write interval of shard
+	Interval() timeutil.Interval
+	// GetRollupBoundary returns the start time of the data which isn't rolled up into rollup interval within time range,
+	// the data before boundary is queried from rollup interval, others from write interval and memory database.
+	GetRollupBoundary(interval timeutil.Interval, timeRange timeutil.TimeRange) int64
 	// MemoryDatabase returns memory database
 	MemoryDatabase() memdb.MemoryDatabase
 	// IndexDatabase returns the index-database
 	IndexDatabase() indexdb.IndexDatabase
 	// Write writes the metric-point into memory-database.
 	Write(metric *pb.Metric) error
+	// WriteReplica appends the metric batch of replica sequence into data write ahead log,
+	// then writes the metric-points into memory-database, returns error if appending write ahead log fail.
+	WriteReplica(replicaPeer string, seq int64, metricList *pb.MetricList) error
+	// SyncWAL flushes data write ahead log into disk, the replica data appended before is durable after synced.
+	SyncWAL() error
 	// GetOrCreateSequence gets the replica sequence by given remote peer if exist, else creates a new sequence
 	GetOrCreateSequence(replicaPeer string) (replication.Sequence, error)
 	// Close releases shard's resource, such as flush data, spawned goroutines etc.
@@ -106,6 +123,31 @@ type Shard interface {
 	NeedFlush() bool
 	// IsFlushing checks if this shard is in flushing
 	IsFlushing() bool
+	// ExpireData removes the expired segments and data families based on ttl of each interval,
+	// then clears the tombstones of metrics whose deleted data is purged from all interval segments.
+	ExpireData() error
+	// Backup flushes memory data, then backups index and data of all interval segments into target path,
+	// the replica sequence isn't included, because it's related to the replication of current node.
+	Backup(targetPath string) error
+	// BackupReplica backups shard with the replica sequences, which is used to build new replica of shard,
+	// the new replica continues replication from the replica sequences of current node.
+	BackupReplica(targetPath string) error
+	// Compact triggers full compaction of the kv families in index store and all interval segments by family name,
+	// triggers all families if family name is empty.
+	Compact(familyName string)
+	// DeleteSeries tombstones the series of metric in index database,
+	// then purges the data of deleted series when compaction.
+	DeleteSeries(metricID uint32, seriesIDs *roaring.Bitmap) error
+	// DropMetric tombstones the metric in index database, then purges the data of metric when compaction.
+	DropMetric(metricID uint32) error
+	// Digest returns the digests of the data families of write interval which are within the time range
+	Digest(timeRange timeutil.TimeRange) ([]*models.FamilyDigest, error)
+	// BackupFamily links or copies the files of data family in write interval by family time into target path,
+	// with the id mapping of the files
+	BackupFamily(familyTime int64, targetPath string) error
+	// RestoreFamily replaces the files of data family in write interval by family time with the files under source path,
+	// refuses restoring if the id mapping of the files is different with local metadata
+	RestoreFamily(familyTime int64, sourcePath string) error
 	// initIndexDatabase initializes index database
 	initIndexDatabase() error
 }
@@ -114,6 +156,7 @@ type Shard interface {
 // directory tree:
 //    xx/shard/1/ (path)
 //    xx/shard/1/replica
+//    xx/shard/1/wal
 //    xx/shard/1/temp/123213123131 // time of ns
 //    xx/shard/1/meta/
 //    xx/shard/1/index/inverted/
@@ -129,15 +172,21 @@ type shard struct {
 	mutable   memdb.MemoryDatabase // current accept user write data points
 	immutable memdb.MemoryDatabase // need flush data to disk persist
 
+	dataWAL          wal.DataWAL // write ahead log of replica data which is in memory database
+	immutableWALPage int64       // the last page of data wal which data is in immutable memory database
+	walLock          sync.Mutex  // keeps data wal and memory database consistent when writing/swapping
+
 	indexDB  indexdb.IndexDatabase
 	metadata metadb.Metadata
 	// write accept time range
 	interval timeutil.Interval
 	ahead    timeutil.Interval
 	behind   timeutil.Interval
+	// data retention of each interval
+	ttl map[timeutil.Interval]timeutil.Interval
 	// segments keeps all interval segments,
 	// includes one smallest interval segment for writing data, and rollup interval segments
-	segments       map[timeutil.IntervalType]IntervalSegment
+	segments       map[timeutil.Interval]IntervalSegment
 	segment        IntervalSegment // smallest interval for writing data
 	isFlushing     atomic.Bool     // restrict flusher concurrency
 	flushCondition sync.WaitGroup  // flush condition
@@ -146,6 +195,10 @@ type shard struct {
 	forwardFamily  kv.Family // forward store
 	invertedFamily kv.Family // inverted store
 
+	purgeLock      sync.Mutex
+	pendingPurges  *roaring.Bitmap // tombstoned metric ids, purged again after the memory data before tombstoned is flushed
+	purgingMetrics *roaring.Bitmap // tombstoned metric ids whose deleted data is purging in interval segments
+
 	rwMutex sync.RWMutex
 
 	buildIndexTimer  prometheus.Observer
@@ -184,25 +237,14 @@ func newShard(
 		sequence:         replicaSequence,
 		metadata:         db.Metadata(),
 		interval:         interval,
-		segments:         make(map[timeutil.IntervalType]IntervalSegment),
+		segments:         make(map[timeutil.Interval]IntervalSegment),
+		ttl:              make(map[timeutil.Interval]timeutil.Interval),
 		isFlushing:       *atomic.NewBool(false),
+		purgingMetrics:   roaring.New(),
 		buildIndexTimer:  buildIndexTimer.WithLabelValues(db.Name(), shardIDStr),
 		writeMetricTimer: writeMetricTimer.WithLabelValues(db.Name(), shardIDStr),
 		memFlushTimer:    memFlushTimer.WithLabelValues(db.Name(), shardIDStr),
 	}
-	// new segment for writing
-	createdShard.segment, err = newIntervalSegmentFunc(
-		interval,
-		filepath.Join(shardPath, segmentDir, interval.Type().String()))
-
-	if err != nil {
-		return nil, err
-	}
-	_ = createdShard.ahead.ValueOf(option.Ahead)
-	_ = createdShard.behind.ValueOf(option.Behind)
-	// add writing segment into segment list
-	createdShard.segments[interval.Type()] = createdShard.segment
-
 	defer func() {
 		if err != nil {
 			if err := createdShard.Close(); err != nil {
@@ -211,14 +253,27 @@ func newShard(
 			}
 		}
 	}()
+	// index database need be initialized before segments, because the tombstone of segment is based on it
 	if err = createdShard.initIndexDatabase(); err != nil {
 		return nil, fmt.Errorf("create index database for shard[%d] error: %s", shardID, err)
 	}
+	// the tombstones not cleared need purge again, the deleted data maybe replayed from data wal
+	createdShard.pendingPurges = createdShard.indexDB.GetDeletedMetricIDs()
+	// new segments for writing and rollup
+	if err = createdShard.initIntervalSegments(); err != nil {
+		return nil, err
+	}
+	_ = createdShard.ahead.ValueOf(option.Ahead)
+	_ = createdShard.behind.ValueOf(option.Behind)
+	createdShard.initTTL()
 	memDB, err := createdShard.createMemoryDatabase()
 	if err != nil {
 		return nil, err
 	}
 	createdShard.mutable = memDB
+	if err = createdShard.initDataWAL(); err != nil {
+		return nil, fmt.Errorf("replay data wal for shard[%d] error: %s", shardID, err)
+	}
 	// add shard into global shard manager
 	GetShardManager().AddShard(createdShard)
 	return createdShard, nil
@@ -247,14 +302,63 @@ func (s *shard) IndexDatabase() indexdb.IndexDatabase {
 	return s.indexDB
 }
 
-func (s *shard) GetDataFamilies(intervalType timeutil.IntervalType, timeRange timeutil.TimeRange) []DataFamily {
-	segment, ok := s.segments[intervalType]
+func (s *shard) GetDataFamilies(interval timeutil.Interval, timeRange timeutil.TimeRange) []DataFamily {
+	segment, ok := s.segments[interval]
 	if ok {
 		return segment.getDataFamilies(timeRange)
 	}
 	return nil
 }
 
+// Interval returns the write interval of shard
+func (s *shard) Interval() timeutil.Interval {
+	return s.interval
+}
+
+// GetRollupBoundary returns the start time of the data which isn't rolled up into rollup interval within time range,
+// the data in memory database and the families of smaller intervals which have pending rollup files isn't rolled up,
+// boundary is truncated by rollup interval, so the rollup slot of boundary only contains the rolled up data.
+func (s *shard) GetRollupBoundary(interval timeutil.Interval, timeRange timeutil.TimeRange) int64 {
+	boundary := timeRange.End + 1
+	for segmentInterval, segment := range s.segments {
+		if segmentInterval >= interval {
+			continue
+		}
+		for _, family := range segment.getDataFamilies(timeRange) {
+			familyStart := family.TimeRange().Start
+			if familyStart < boundary && family.Family().HasPendingRollup() {
+				boundary = familyStart
+			}
+		}
+	}
+	calc := s.interval.Calculator()
+	s.rwMutex.RLock()
+	memDBs := []memdb.MemoryDatabase{s.mutable, s.immutable}
+	s.rwMutex.RUnlock()
+	for _, memDB := range memDBs {
+		if memDB == nil {
+			continue
+		}
+		for _, familyTime := range memDB.Families() {
+			if familyTime < boundary && calc.CalcFamilyEndTime(familyTime) >= timeRange.Start {
+				boundary = familyTime
+			}
+		}
+	}
+	if boundary > timeRange.End {
+		return boundary
+	}
+	// truncate boundary by the slot of rollup family
+	rollupCalc := interval.Calculator()
+	segmentTime := rollupCalc.CalcSegmentTime(boundary)
+	familyTime := rollupCalc.CalcFamilyStartTime(segmentTime, rollupCalc.CalcFamily(boundary, segmentTime))
+	boundary = familyTime + int64(rollupCalc.CalcSlot(boundary, familyTime, interval.Int64()))*interval.Int64()
+	if boundary < timeRange.Start {
+		return timeRange.Start
+	}
+	return boundary
+}
+
 // MemoryDatabase returns memory database
 func (s *shard) MemoryDatabase() memdb.MemoryDatabase {
 	var memDB memdb.MemoryDatabase
@@ -283,6 +387,10 @@ func (s *shard) Write(metric *pb.Metric) (err error) {
 		(s.ahead.Int64() > 0 && timestamp > now+s.ahead.Int64()) {
 		return nil
 	}
+	// drop expired metric point
+	if ttl := s.ttl[s.interval]; ttl.Int64() > 0 && timestamp < now-ttl.Int64() {
+		return nil
+	}
 	ns := metric.Namespace
 	if len(ns) == 0 {
 		ns = constants.DefaultNamespace
@@ -324,6 +432,49 @@ func (s *shard) Write(metric *pb.Metric) (err error) {
 	return db.Write(ns, metric.Name, metricID, seriesID, metric.Timestamp, metric.Fields)
 }
 
+// WriteReplica appends the metric batch of replica sequence into data write ahead log,
+// then writes the metric-points into memory-database, returns error if appending write ahead log fail.
+func (s *shard) WriteReplica(replicaPeer string, seq int64, metricList *pb.MetricList) error {
+	data, err := metricList.Marshal()
+	if err != nil {
+		return err
+	}
+	s.walLock.Lock()
+	defer s.walLock.Unlock()
+
+	if err := s.dataWAL.Append(replicaPeer, seq, data); err != nil {
+		return err
+	}
+	s.writeMetrics(metricList.Metrics)
+	// advances the head sequence with the data under wal lock, keeps it consistent with memory database
+	sequence, err := s.sequence.getOrCreateSequence(replicaPeer)
+	if err != nil {
+		return err
+	}
+	if seq > sequence.GetHeadSeq() {
+		sequence.SetHeadSeq(seq)
+	}
+	return nil
+}
+
+// SyncWAL flushes data write ahead log into disk, the replica data appended before is durable after synced.
+func (s *shard) SyncWAL() error {
+	s.walLock.Lock()
+	defer s.walLock.Unlock()
+
+	return s.dataWAL.Sync()
+}
+
+// writeMetrics writes the metric-points into memory-database, the invalid metric is ignored
+func (s *shard) writeMetrics(metrics []*pb.Metric) {
+	for _, metric := range metrics {
+		if err := s.Write(metric); err != nil {
+			engineLogger.Error("write metric error",
+				logger.String("shard", s.path), logger.String("metric", metric.GetName()), logger.Error(err))
+		}
+	}
+}
+
 func (s *shard) Close() error {
 	// wait previous flush job completed
 	s.flushCondition.Wait()
@@ -350,6 +501,20 @@ func (s *shard) Close() error {
 		}
 	}
 	s.ackReplicaSeq()
+	if s.dataWAL != nil {
+		// all data is flushed, removes all pages of data wal
+		s.walLock.Lock()
+		pageIndex, err := s.dataWAL.Roll()
+		s.walLock.Unlock()
+		if err != nil {
+			engineLogger.Error("roll data wal error when close shard", logger.String("shard", s.path), logger.Error(err))
+		} else {
+			s.truncateDataWAL(pageIndex)
+		}
+		if err := s.dataWAL.Close(); err != nil {
+			return err
+		}
+	}
 	return s.sequence.Close()
 }
 
@@ -364,6 +529,10 @@ func (s *shard) NeedFlush() bool {
 	if s.hasImmutable() {
 		return false
 	}
+	if s.hasPendingPurges() {
+		// flush the memory data before tombstoned, then purges the deleted data
+		return true
+	}
 
 	memDB := s.MemoryDatabase()
 	//TODO add time threshold???
@@ -376,8 +545,12 @@ func (s *shard) Flush() (err error) {
 	if !s.isFlushing.CAS(false, true) {
 		return nil
 	}
+	// the deleted data of the metrics tombstoned before swapping is in data families after flushing
+	pendingPurges := s.takePendingPurges()
+	memDB := s.MemoryDatabase()
 	// 1. swap memory database
 	s.swapMemoryDatabase()
+	swapped := s.isImmutable(memDB)
 	// 2. mark flush job doing
 	s.flushCondition.Add(1)
 
@@ -388,16 +561,29 @@ func (s *shard) Flush() (err error) {
 		s.isFlushing.Store(false)
 	}()
 
+	if err = s.flushImmutable(); err != nil || !swapped {
+		// purge after next flushing
+		s.addPendingPurges(pendingPurges)
+		return err
+	}
+	s.purgeFlushed(pendingPurges)
+	return nil
+}
+
+// flushImmutable flushes index and immutable memory database to disk, then commits replica sequence,
+// must be invoked in flush job.
+func (s *shard) flushImmutable() error {
 	//FIXME stone1100
 	// index flush
 	if s.indexDB != nil {
-		if err = s.indexDB.Flush(); err != nil {
+		if err := s.indexDB.Flush(); err != nil {
 			return err
 		}
 	}
 
 	// flush immutable, if exist
 	// maybe not exist, when swap fail
+	flushedWALPage := int64(-1)
 	if s.immutable != nil {
 		if err := s.flushMemoryDatabase(s.immutable); err != nil {
 			return err
@@ -405,13 +591,259 @@ func (s *shard) Flush() (err error) {
 		// after flush success, mark immutable as nil
 		s.rwMutex.Lock()
 		s.immutable = nil
+		flushedWALPage = s.immutableWALPage
 		s.rwMutex.Unlock()
 	}
-	// finally, commit replica sequence
+	// finally, commit replica sequence, then removes the data wal pages of flushed memory database
 	s.ackReplicaSeq()
+	if flushedWALPage >= 0 {
+		s.truncateDataWAL(flushedWALPage)
+	}
 	return nil
 }
 
+// Backup flushes memory data, then backups index and data of all interval segments into target path,
+// the replica sequence isn't included, because it's related to the replication of current node.
+// NOTICE: the data written after flushing isn't included in backup.
+func (s *shard) Backup(targetPath string) error {
+	if err := s.Flush(); err != nil {
+		return err
+	}
+	// wait flush job completed, maybe the flush job is running by other goroutine
+	s.flushCondition.Wait()
+	return s.backupFiles(targetPath)
+}
+
+// backupFiles backups index and data of all interval segments into target path
+func (s *shard) backupFiles(targetPath string) error {
+	if err := s.indexDB.Backup(filepath.Join(targetPath, metaDir)); err != nil {
+		return fmt.Errorf("backup index database of shard[%d] error: %s", s.id, err)
+	}
+	if err := s.indexStore.Backup(filepath.Join(targetPath, indexParentDir)); err != nil {
+		return fmt.Errorf("backup index store of shard[%d] error: %s", s.id, err)
+	}
+	for interval, segment := range s.segments {
+		if err := segment.Backup(filepath.Join(targetPath, s.intervalSegmentDir(interval))); err != nil {
+			return fmt.Errorf("backup interval segment[%d] of shard[%d] error: %s", interval.Int64(), s.id, err)
+		}
+	}
+	engineLogger.Info("backup shard successfully",
+		logger.String("shard", s.path), logger.String("target", targetPath))
+	return nil
+}
+
+// BackupReplica backups shard with the replica sequences, which is used to build new replica of shard,
+// the new replica continues replication from the replica sequences of current node.
+// the sequences are snapshot when swapping memory database under wal lock, other flush jobs are blocked until
+// backup completed, so that the backup includes exactly the data before sequences, which isn't replicated again.
+func (s *shard) BackupReplica(targetPath string) error {
+	// wait flush job in progress completed, then marks flush job doing
+	for !s.isFlushing.CAS(false, true) {
+		s.flushCondition.Wait()
+		runtime.Gosched()
+	}
+	s.flushCondition.Add(1)
+	defer func() {
+		s.flushCondition.Done()
+		s.isFlushing.Store(false)
+	}()
+
+	// flushes the immutable memory database left by the flush job fail
+	if err := s.flushImmutable(); err != nil {
+		return err
+	}
+	s.walLock.Lock()
+	heads := s.sequence.getAllHeads()
+	err := s.swapMemoryDatabaseWithLock()
+	s.walLock.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := s.flushImmutable(); err != nil {
+		return err
+	}
+	if err := s.backupFiles(targetPath); err != nil {
+		return err
+	}
+	if err := backupReplicaSequence(filepath.Join(targetPath, replicaDir), heads); err != nil {
+		return fmt.Errorf("backup replica sequence of shard[%d] error: %s", s.id, err)
+	}
+	return nil
+}
+
+// Compact triggers full compaction of the kv families in index store and all interval segments by family name,
+// triggers all families if family name is empty.
+func (s *shard) Compact(familyName string) {
+	s.indexStore.Compact(familyName)
+	for _, segment := range s.segments {
+		segment.Compact(familyName)
+	}
+}
+
+// DeleteSeries tombstones the series of metric in index database,
+// then purges the data of deleted series when compaction.
+func (s *shard) DeleteSeries(metricID uint32, seriesIDs *roaring.Bitmap) error {
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
+
+	if err := s.indexDB.DeleteSeries(metricID, seriesIDs); err != nil {
+		return err
+	}
+	s.addPendingPurgeWithLock(metricID)
+	return nil
+}
+
+// DropMetric tombstones the metric in index database, then purges the data of metric when compaction.
+func (s *shard) DropMetric(metricID uint32) error {
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
+
+	if err := s.indexDB.DropMetric(metricID); err != nil {
+		return err
+	}
+	s.addPendingPurgeWithLock(metricID)
+	return nil
+}
+
+// addPendingPurgeWithLock purges the deleted data of tombstoned metric in data families,
+// then marks it pending until the memory data before tombstoned is flushed, must hold the purge lock
+func (s *shard) addPendingPurgeWithLock(metricID uint32) {
+	s.purge(roaring.BitmapOf(metricID))
+	s.pendingPurges.Add(metricID)
+	s.purgingMetrics.Remove(metricID)
+}
+
+// hasPendingPurges returns if has tombstoned metrics which wait for the memory data flushed
+func (s *shard) hasPendingPurges() bool {
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
+	return !s.pendingPurges.IsEmpty()
+}
+
+// takePendingPurges takes the tombstoned metrics which wait for the memory data flushed
+func (s *shard) takePendingPurges() *roaring.Bitmap {
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
+	pendingPurges := s.pendingPurges
+	s.pendingPurges = roaring.New()
+	return pendingPurges
+}
+
+// addPendingPurges adds back the tombstoned metrics if the memory data isn't flushed
+func (s *shard) addPendingPurges(metricIDs *roaring.Bitmap) {
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
+	s.pendingPurges.Or(metricIDs)
+}
+
+// purgeFlushed purges the deleted data of tombstoned metrics again after the memory data is flushed,
+// the tombstones are cleared after purge jobs of all data families completed.
+func (s *shard) purgeFlushed(metricIDs *roaring.Bitmap) {
+	if metricIDs.IsEmpty() {
+		return
+	}
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
+	s.purge(metricIDs)
+	s.purgingMetrics.Or(metricIDs)
+}
+
+// purge purges the deleted data of metrics in all interval segments when compaction
+func (s *shard) purge(metricIDs *roaring.Bitmap) {
+	for _, segment := range s.segments {
+		segment.Purge(metricIDs)
+	}
+}
+
+// clearTombstones clears the tombstones of metrics whose deleted data is purged from all interval segments
+func (s *shard) clearTombstones() error {
+	s.purgeLock.Lock()
+	defer s.purgeLock.Unlock()
+
+	purged := roaring.New()
+	it := s.purgingMetrics.Iterator()
+	for it.HasNext() {
+		metricID := it.Next()
+		if s.pendingPurges.Contains(metricID) || s.isPurging(roaring.BitmapOf(metricID)) {
+			continue
+		}
+		purged.Add(metricID)
+	}
+	if purged.IsEmpty() {
+		return nil
+	}
+	if err := s.indexDB.ClearTombstones(purged); err != nil {
+		return err
+	}
+	s.purgingMetrics.AndNot(purged)
+	return nil
+}
+
+// isPurging returns if the deleted data of metrics is purging in any interval segment
+func (s *shard) isPurging(metricIDs *roaring.Bitmap) bool {
+	for _, segment := range s.segments {
+		if segment.IsPurging(metricIDs) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpireData removes the expired segments and data families based on ttl of each interval,
+// then clears the tombstones of metrics whose deleted data is purged from all interval segments.
+func (s *shard) ExpireData() error {
+	now := timeutil.Now()
+	for interval, segment := range s.segments {
+		ttl := s.ttl[interval]
+		if ttl.Int64() <= 0 {
+			// never expire
+			continue
+		}
+		if err := segment.Expire(now - ttl.Int64()); err != nil {
+			return err
+		}
+	}
+	return s.clearTombstones()
+}
+
+// initIntervalSegments initializes the interval segments of write/rollup intervals,
+// creates segments from the largest interval, because each interval segment rollups into next larger one.
+// directory tree:
+//    xx/shard/1/segment/day/ (write interval)
+//    xx/shard/1/segment/rollup/300000/ (rollup interval of ms)
+func (s *shard) initIntervalSegments() error {
+	intervals := s.option.GetIntervals()
+	var rollupTarget IntervalSegment
+	tombstone := newSeriesTombstone(s.indexDB)
+	for idx := len(intervals) - 1; idx >= 0; idx-- {
+		interval := intervals[idx]
+		path := filepath.Join(s.path, s.intervalSegmentDir(interval))
+		segment, err := newIntervalSegmentFunc(interval, path, rollupTarget, tombstone)
+		if err != nil {
+			return err
+		}
+		s.segments[interval] = segment
+		rollupTarget = segment
+	}
+	s.segment = s.segments[s.interval]
+	return nil
+}
+
+// intervalSegmentDir returns the relative directory of interval segment under shard path
+func (s *shard) intervalSegmentDir(interval timeutil.Interval) string {
+	if interval == s.interval {
+		return filepath.Join(segmentDir, interval.Type().String())
+	}
+	return filepath.Join(segmentDir, rollupDir, strconv.FormatInt(interval.Int64(), 10))
+}
+
+// initTTL initializes the data retention of write/rollup intervals
+func (s *shard) initTTL() {
+	for interval := range s.segments {
+		s.ttl[interval] = s.option.GetTTL(interval)
+	}
+}
+
 // initIndexDatabase initializes the index database
 func (s *shard) initIndexDatabase() error {
 	var err error
@@ -447,6 +879,59 @@ func (s *shard) initIndexDatabase() error {
 	return nil
 }
 
+// initDataWAL opens the data write ahead log, then replays the replica data into memory database,
+// the head sequence of replica peer is restored because the replayed data has been acked.
+func (s *shard) initDataWAL() error {
+	dataWAL, err := newDataWALFunc(filepath.Join(s.path, walDir))
+	if err != nil {
+		return err
+	}
+	heads := make(map[string]int64)
+	if err := dataWAL.Replay(func(replicaPeer string, seq int64, data []byte) error {
+		var metricList pb.MetricList
+		if err := metricList.Unmarshal(data); err != nil {
+			return err
+		}
+		s.writeMetrics(metricList.Metrics)
+		if seq > heads[replicaPeer] {
+			heads[replicaPeer] = seq
+		}
+		return nil
+	}); err != nil {
+		// keeps data wal for next replay, drops the replayed data of memory database
+		if err := dataWAL.Close(); err != nil {
+			engineLogger.Error("close data wal error when replay fail", logger.String("shard", s.path), logger.Error(err))
+		}
+		if err := s.mutable.Close(); err != nil {
+			engineLogger.Error("close memory database error when replay fail",
+				logger.String("shard", s.path), logger.Error(err))
+		}
+		s.mutable = nil
+		return err
+	}
+	s.dataWAL = dataWAL
+	for replicaPeer, head := range heads {
+		sequence, err := s.sequence.getOrCreateSequence(replicaPeer)
+		if err != nil {
+			return err
+		}
+		if head > sequence.GetHeadSeq() {
+			sequence.SetHeadSeq(head)
+		}
+	}
+	return nil
+}
+
+// truncateDataWAL removes the data wal pages which index <= page index, the data of those pages is flushed
+func (s *shard) truncateDataWAL(pageIndex int64) {
+	s.walLock.Lock()
+	defer s.walLock.Unlock()
+
+	if err := s.dataWAL.Truncate(pageIndex); err != nil {
+		engineLogger.Error("truncate data wal error", logger.String("shard", s.path), logger.Error(err))
+	}
+}
+
 // hasImmutable checks if has immutable memory database
 func (s *shard) hasImmutable() bool {
 	s.rwMutex.RLock()
@@ -454,23 +939,46 @@ func (s *shard) hasImmutable() bool {
 	return s.immutable != nil
 }
 
+// isImmutable checks if the memory database is immutable
+func (s *shard) isImmutable(memDB memdb.MemoryDatabase) bool {
+	s.rwMutex.RLock()
+	defer s.rwMutex.RUnlock()
+	return s.immutable == memDB
+}
+
 // swapMemoryDatabase swaps mutable/immutable memory database
 func (s *shard) swapMemoryDatabase() {
+	s.walLock.Lock()
+	defer s.walLock.Unlock()
+
+	if err := s.swapMemoryDatabaseWithLock(); err != nil {
+		engineLogger.Error("swap memory database error",
+			logger.String("shard", s.path), logger.Error(err))
+	}
+}
+
+// swapMemoryDatabaseWithLock swaps mutable/immutable memory database, must hold the wal lock
+func (s *shard) swapMemoryDatabaseWithLock() error {
 	s.rwMutex.Lock()
 	defer s.rwMutex.Unlock()
 	// if immutable is not nil, cannot do swap
 	if s.immutable != nil {
-		return
+		return nil
+	}
+	// seal current page of data wal, the data of old memory database is all in sealed pages
+	walPage, err := s.dataWAL.Roll()
+	if err != nil {
+		return fmt.Errorf("roll data wal error: %s", err)
 	}
 
 	memDB, err := s.createMemoryDatabase()
 	if err != nil {
-		engineLogger.Error("create new memory database error when swap",
-			logger.String("shard", s.path), logger.Error(err))
-		return
+		return fmt.Errorf("create new memory database error: %s", err)
 	}
 	s.immutable = s.mutable // mark old memory database is immutable
+	s.immutableWALPage = walPage
 	s.mutable = memDB       // create new  memory database as mutable
+	return nil
 }
 
 // createMemoryDatabase creates a new memory database for writing data points
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/constants"
@@ -18,6 +19,7 @@ import (
 	"github.com/lindb/lindb/tsdb/indexdb"
 	"github.com/lindb/lindb/tsdb/memdb"
 	"github.com/lindb/lindb/tsdb/metadb"
+	"github.com/lindb/lindb/tsdb/wal"
 )
 
 var _testShard1Path = filepath.Join(testPath, shardDir, "1")
@@ -32,6 +34,7 @@ func TestShard_New(t *testing.T) {
 		newKVStoreFunc = kv.NewStore
 		newIndexDBFunc = indexdb.NewIndexDatabase
 		newMemoryDBFunc = memdb.NewMemoryDatabase
+		newDataWALFunc = wal.NewDataWAL
 
 		ctrl.Finish()
 	}()
@@ -66,7 +69,8 @@ func TestShard_New(t *testing.T) {
 	assert.Nil(t, thisShard)
 	// case 5: new interval segment err
 	newReplicaSequenceFunc = newReplicaSequence
-	newIntervalSegmentFunc = func(interval timeutil.Interval, path string) (segment IntervalSegment, err error) {
+	newIntervalSegmentFunc = func(interval timeutil.Interval, path string,
+		rollupTarget IntervalSegment, tombstone kv.Tombstone) (segment IntervalSegment, err error) {
 		return nil, fmt.Errorf("err")
 	}
 	thisShard, err = newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
@@ -118,7 +122,16 @@ func TestShard_New(t *testing.T) {
 	assert.Nil(t, thisShard)
 	newMemoryDBFunc = memdb.NewMemoryDatabase
 
-	// case 11: create shard success
+	// case 11: create data wal err
+	newDataWALFunc = func(path string) (wal.DataWAL, error) {
+		return nil, fmt.Errorf("err")
+	}
+	thisShard, err = newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
+	assert.Error(t, err)
+	assert.Nil(t, thisShard)
+	newDataWALFunc = wal.NewDataWAL
+
+	// case 12: create shard success
 	thisShard, err = newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
 	assert.NoError(t, err)
 	assert.NotNil(t, thisShard)
@@ -146,9 +159,9 @@ func TestShard_GetDataFamilies(t *testing.T) {
 	db.EXPECT().Name().Return("test-db").AnyTimes()
 	db.EXPECT().Metadata().Return(meta).AnyTimes()
 	s, _ := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
-	assert.Nil(t, s.GetDataFamilies(timeutil.Month, timeutil.TimeRange{}))
-	assert.Nil(t, s.GetDataFamilies(timeutil.Day, timeutil.TimeRange{}))
-	assert.Equal(t, 0, len(s.GetDataFamilies(timeutil.Day, timeutil.TimeRange{})))
+	assert.Nil(t, s.GetDataFamilies(timeutil.Interval(5*timeutil.OneMinute), timeutil.TimeRange{}))
+	assert.Nil(t, s.GetDataFamilies(timeutil.Interval(10*timeutil.OneSecond), timeutil.TimeRange{}))
+	assert.Equal(t, 0, len(s.GetDataFamilies(timeutil.Interval(10*timeutil.OneSecond), timeutil.TimeRange{})))
 }
 
 func TestShard_Write(t *testing.T) {
@@ -270,6 +283,103 @@ func TestShard_Write(t *testing.T) {
 	assert.NotNil(t, shardINTF.MemoryDatabase())
 }
 
+func TestShard_WriteReplica(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		newKVStoreFunc = kv.NewStore
+		newIndexDBFunc = indexdb.NewIndexDatabase
+		newMemoryDBFunc = memdb.NewMemoryDatabase
+		ctrl.Finish()
+	}()
+	kvStore := kv.NewMockStore(ctrl)
+	kvStore.EXPECT().CreateFamily(gomock.Any(), gomock.Any()).Return(kv.NewMockFamily(ctrl), nil).AnyTimes()
+	kvStore.EXPECT().Close().Return(nil).AnyTimes()
+	newKVStoreFunc = func(name string, option kv.StoreOption) (kv.Store, error) {
+		return kvStore, nil
+	}
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	indexDB.EXPECT().Flush().Return(nil).AnyTimes()
+	indexDB.EXPECT().Close().Return(nil).AnyTimes()
+	indexDB.EXPECT().GetDeletedMetricIDs().Return(roaring.New()).AnyTimes()
+	newIndexDBFunc = func(ctx context.Context, parent string,
+		metadata metadb.Metadata, forward kv.Family, inverted kv.Family,
+	) (indexdb.IndexDatabase, error) {
+		return indexDB, nil
+	}
+	memDB := memdb.NewMockMemoryDatabase(ctrl)
+	memDB.EXPECT().Families().Return(nil).AnyTimes()
+	memDB.EXPECT().Close().Return(nil).AnyTimes()
+	newMemoryDBFunc = func(cfg memdb.MemoryDatabaseCfg) (memdb.MemoryDatabase, error) {
+		return memDB, nil
+	}
+	db := NewMockDatabase(ctrl)
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(metadb.NewMockMetadata(ctrl)).AnyTimes()
+	// crash simulates the storage node crashed, the shard isn't closed
+	crash := func(s Shard) {
+		s1 := s.(*shard)
+		GetShardManager().RemoveShard(s1)
+		assert.NoError(t, s1.dataWAL.Close())
+		assert.NoError(t, s1.sequence.Close())
+	}
+	// metric without field is ignored when writing memory database
+	metricList := &pb.MetricList{Metrics: []*pb.Metric{{Name: "test", Timestamp: timeutil.Now()}}}
+
+	s, err := newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
+	assert.NoError(t, err)
+	// case 1: write and flush data
+	assert.NoError(t, s.WriteReplica("peer", 1, metricList))
+	assert.NoError(t, s.WriteReplica("peer", 2, metricList))
+	// head sequence is advanced with the data
+	headSeq, err := s.GetOrCreateSequence("peer")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), headSeq.GetHeadSeq())
+	assert.NoError(t, s.SyncWAL())
+	assert.NoError(t, s.Flush())
+	assert.NoError(t, s.WriteReplica("peer", 3, metricList))
+	assert.NoError(t, s.SyncWAL())
+	crash(s)
+	// flushed data is truncated
+	var seqs []int64
+	err = wal.ReadDataWAL(filepath.Join(_testShard1Path, walDir), func(replicaPeer string, seq int64, data []byte) error {
+		seqs = append(seqs, seq)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3}, seqs)
+	// case 2: replay data wal, restores head sequence
+	s, err = newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
+	assert.NoError(t, err)
+	seq, err := s.GetOrCreateSequence("peer")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), seq.GetHeadSeq())
+	// case 3: append data wal err
+	s1 := s.(*shard)
+	dataWAL := s1.dataWAL
+	mockWAL := wal.NewMockDataWAL(ctrl)
+	s1.dataWAL = mockWAL
+	mockWAL.EXPECT().Append("peer", int64(4), gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, s.WriteReplica("peer", 4, metricList))
+	// case 4: sync data wal err
+	mockWAL.EXPECT().Sync().Return(fmt.Errorf("err"))
+	assert.Error(t, s.SyncWAL())
+	// case 5: roll data wal err, not swap memory database
+	mockWAL.EXPECT().Roll().Return(int64(0), fmt.Errorf("err"))
+	assert.NoError(t, s.Flush())
+	assert.False(t, s1.hasImmutable())
+	s1.dataWAL = dataWAL
+	crash(s)
+	// case 6: replay bad data err
+	dataWAL, err = wal.NewDataWAL(filepath.Join(_testShard1Path, walDir))
+	assert.NoError(t, err)
+	assert.NoError(t, dataWAL.Append("peer", 4, []byte{1, 2, 3}))
+	assert.NoError(t, dataWAL.Close())
+	s, err = newShard(db, 1, _testShard1Path, option.DatabaseOption{Interval: "10s"})
+	assert.Error(t, err)
+	assert.Nil(t, s)
+}
+
 func TestShard_Close(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer func() {
@@ -425,3 +535,342 @@ func mockShard(ctrl *gomock.Controller) *shard {
 	s1 := s.(*shard)
 	return s1
 }
+
+func TestShard_ExpireData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	db := NewMockDatabase(ctrl)
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	s, err := newShard(db, 1, _testShard1Path, option.DatabaseOption{
+		Interval:  "10s",
+		Rollup:    []string{"5m", "1h"},
+		TTL:       "1d",
+		RollupTTL: []string{"30d"},
+	})
+	assert.NoError(t, err)
+	shardIns := s.(*shard)
+	tenSeconds := timeutil.Interval(10 * timeutil.OneSecond)
+	fiveMinutes := timeutil.Interval(5 * timeutil.OneMinute)
+	oneHour := timeutil.Interval(timeutil.OneHour)
+	assert.Equal(t, timeutil.Interval(timeutil.OneDay), shardIns.ttl[tenSeconds])
+	assert.Equal(t, timeutil.Interval(30*timeutil.OneDay), shardIns.ttl[fiveMinutes])
+	assert.Equal(t, timeutil.Interval(0), shardIns.ttl[oneHour])
+
+	// case 1: reject expired metric
+	assert.NoError(t, s.Write(&pb.Metric{
+		Name:      "test",
+		Timestamp: timeutil.Now() - 2*timeutil.OneDay,
+		Fields: []*pb.Field{{
+			Name:  "f1",
+			Value: 1.0,
+		}},
+	}))
+	// case 2: expire data err
+	daySegment := NewMockIntervalSegment(ctrl)
+	yearSegment := NewMockIntervalSegment(ctrl)
+	shardIns.segments = map[timeutil.Interval]IntervalSegment{
+		tenSeconds: daySegment,
+		oneHour:    yearSegment,
+	}
+	daySegment.EXPECT().Expire(gomock.Any()).Return(fmt.Errorf("err"))
+	assert.Error(t, s.ExpireData())
+	// case 3: expire data, year segment never expire
+	daySegment.EXPECT().Expire(gomock.Any()).Return(nil)
+	assert.NoError(t, s.ExpireData())
+	GetShardManager().RemoveShard(s)
+}
+
+func TestShard_initIntervalSegments(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+	}()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	db := NewMockDatabase(ctrl)
+	meta := metadb.NewMockMetadata(ctrl)
+	meta.EXPECT().DatabaseName().Return("test").AnyTimes()
+	db.EXPECT().Name().Return("test-db").AnyTimes()
+	db.EXPECT().Metadata().Return(meta).AnyTimes()
+	s, err := newShard(db, 1, _testShard1Path, option.DatabaseOption{
+		Interval: "10s",
+		Rollup:   []string{"5m", "1h"},
+	})
+	assert.NoError(t, err)
+	shardIns := s.(*shard)
+	assert.Len(t, shardIns.segments, 3)
+	assert.Equal(t, shardIns.segments[timeutil.Interval(10*timeutil.OneSecond)], shardIns.segment)
+	assert.Equal(t, timeutil.Interval(10*timeutil.OneSecond), shardIns.segment.Interval())
+	assert.True(t, fileutil.Exist(filepath.Join(_testShard1Path, segmentDir, timeutil.Day.String())))
+	assert.True(t, fileutil.Exist(filepath.Join(_testShard1Path, segmentDir, rollupDir, "300000")))
+	assert.True(t, fileutil.Exist(filepath.Join(_testShard1Path, segmentDir, rollupDir, "3600000")))
+	// write interval segment => 5 minutes segment => 1 hour segment
+	writeSegment := shardIns.segment.(*intervalSegment)
+	assert.Equal(t, timeutil.Interval(5*timeutil.OneMinute), writeSegment.rollupTarget.Interval())
+	fiveMinSegment := writeSegment.rollupTarget.(*intervalSegment)
+	assert.Equal(t, timeutil.Interval(timeutil.OneHour), fiveMinSegment.rollupTarget.Interval())
+	assert.Nil(t, fiveMinSegment.rollupTarget.(*intervalSegment).rollupTarget)
+	GetShardManager().RemoveShard(s)
+}
+
+func TestShard_Backup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	s1 := mockShard(ctrl)
+	defer GetShardManager().RemoveShard(s1)
+	mutable := memdb.NewMockMemoryDatabase(ctrl)
+	mutable.EXPECT().Families().Return(nil).AnyTimes()
+	mutable.EXPECT().Close().Return(nil).AnyTimes()
+	s1.mutable = mutable
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	indexStore := kv.NewMockStore(ctrl)
+	segment := NewMockIntervalSegment(ctrl)
+	s1.indexDB = indexDB
+	s1.indexStore = indexStore
+	s1.segments = map[timeutil.Interval]IntervalSegment{s1.interval: segment}
+	backupPath := filepath.Join(testPath, "backup")
+	// case 1: flush err
+	indexDB.EXPECT().Flush().Return(fmt.Errorf("err"))
+	assert.Error(t, s1.Backup(backupPath))
+	indexDB.EXPECT().Flush().Return(nil).AnyTimes()
+	// case 2: backup index database err
+	indexDB.EXPECT().Backup(filepath.Join(backupPath, metaDir)).Return(fmt.Errorf("err"))
+	assert.Error(t, s1.Backup(backupPath))
+	indexDB.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	// case 3: backup index store err
+	indexStore.EXPECT().Backup(filepath.Join(backupPath, indexParentDir)).Return(fmt.Errorf("err"))
+	assert.Error(t, s1.Backup(backupPath))
+	indexStore.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	// case 4: backup interval segment err
+	segmentPath := filepath.Join(backupPath, segmentDir, s1.interval.Type().String())
+	segment.EXPECT().Backup(segmentPath).Return(fmt.Errorf("err"))
+	assert.Error(t, s1.Backup(backupPath))
+	// case 5: backup successfully
+	segment.EXPECT().Backup(segmentPath).Return(nil)
+	assert.NoError(t, s1.Backup(backupPath))
+}
+
+func TestShard_BackupReplica(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		mkDirIfNotExist = fileutil.MkDirIfNotExist
+		ctrl.Finish()
+	}()
+	s1 := mockShard(ctrl)
+	defer GetShardManager().RemoveShard(s1)
+	mutable := memdb.NewMockMemoryDatabase(ctrl)
+	mutable.EXPECT().Families().Return(nil).AnyTimes()
+	mutable.EXPECT().Close().Return(nil).AnyTimes()
+	s1.mutable = mutable
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	indexStore := kv.NewMockStore(ctrl)
+	s1.indexDB = indexDB
+	s1.indexStore = indexStore
+	s1.segments = nil
+	seq, err := s1.GetOrCreateSequence("broker-1")
+	assert.NoError(t, err)
+	seq.SetHeadSeq(10)
+	backupPath := filepath.Join(testPath, "backup")
+	// case 1: backup shard err
+	indexDB.EXPECT().Flush().Return(fmt.Errorf("err"))
+	assert.Error(t, s1.BackupReplica(backupPath))
+	indexDB.EXPECT().Flush().Return(nil).AnyTimes()
+	indexDB.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	indexStore.EXPECT().Backup(gomock.Any()).Return(nil).AnyTimes()
+	// case 2: backup replica sequence err
+	mkDirIfNotExist = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, s1.BackupReplica(backupPath))
+	mkDirIfNotExist = fileutil.MkDirIfNotExist
+	// case 3: backup successfully
+	assert.NoError(t, s1.BackupReplica(backupPath))
+	sequence, err := newReplicaSequence(filepath.Join(backupPath, replicaDir))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"broker-1": 10}, sequence.getAllHeads())
+	assert.NoError(t, sequence.Close())
+}
+
+func TestShard_Compact(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	indexStore := kv.NewMockStore(ctrl)
+	segment := NewMockIntervalSegment(ctrl)
+	s := &shard{
+		indexStore: indexStore,
+		segments:   map[timeutil.Interval]IntervalSegment{timeutil.Interval(timeutil.OneSecond * 10): segment},
+	}
+	indexStore.EXPECT().Compact("f")
+	segment.EXPECT().Compact("f")
+	s.Compact("f")
+}
+
+func TestShard_DeleteSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	segment := NewMockIntervalSegment(ctrl)
+	s := &shard{
+		indexDB:        indexDB,
+		segments:       map[timeutil.Interval]IntervalSegment{timeutil.Interval(timeutil.OneSecond * 10): segment},
+		pendingPurges:  roaring.New(),
+		purgingMetrics: roaring.BitmapOf(10),
+	}
+	// case 1: delete series err
+	indexDB.EXPECT().DeleteSeries(uint32(10), roaring.BitmapOf(1, 2)).Return(fmt.Errorf("err"))
+	err := s.DeleteSeries(10, roaring.BitmapOf(1, 2))
+	assert.Error(t, err)
+	// case 2: delete series and purge data
+	indexDB.EXPECT().DeleteSeries(uint32(10), roaring.BitmapOf(1, 2)).Return(nil)
+	segment.EXPECT().Purge(roaring.BitmapOf(10))
+	err = s.DeleteSeries(10, roaring.BitmapOf(1, 2))
+	assert.NoError(t, err)
+	// purge again after memory data flushed
+	assert.True(t, s.pendingPurges.Contains(10))
+	assert.False(t, s.purgingMetrics.Contains(10))
+}
+
+func TestShard_DropMetric(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	segment := NewMockIntervalSegment(ctrl)
+	s := &shard{
+		indexDB:        indexDB,
+		segments:       map[timeutil.Interval]IntervalSegment{timeutil.Interval(timeutil.OneSecond * 10): segment},
+		pendingPurges:  roaring.New(),
+		purgingMetrics: roaring.BitmapOf(10),
+	}
+	// case 1: drop metric err
+	indexDB.EXPECT().DropMetric(uint32(10)).Return(fmt.Errorf("err"))
+	err := s.DropMetric(10)
+	assert.Error(t, err)
+	// case 2: drop metric and purge data
+	indexDB.EXPECT().DropMetric(uint32(10)).Return(nil)
+	segment.EXPECT().Purge(roaring.BitmapOf(10))
+	err = s.DropMetric(10)
+	assert.NoError(t, err)
+	// purge again after memory data flushed
+	assert.True(t, s.pendingPurges.Contains(10))
+	assert.False(t, s.purgingMetrics.Contains(10))
+}
+
+func TestShard_ClearTombstones(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		_ = fileutil.RemoveDir(testPath)
+		ctrl.Finish()
+	}()
+	s1 := mockShard(ctrl)
+	defer GetShardManager().RemoveShard(s1)
+	mutable := memdb.NewMockMemoryDatabase(ctrl)
+	mutable.EXPECT().Families().Return(nil).AnyTimes()
+	mutable.EXPECT().Close().Return(nil).AnyTimes()
+	s1.mutable = mutable
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	segment := NewMockIntervalSegment(ctrl)
+	s1.indexDB = indexDB
+	s1.segments = map[timeutil.Interval]IntervalSegment{s1.interval: segment}
+
+	// case 1: tombstone metric, need flush memory data
+	indexDB.EXPECT().DropMetric(uint32(10)).Return(nil)
+	segment.EXPECT().Purge(roaring.BitmapOf(10))
+	assert.NoError(t, s1.DropMetric(10))
+	assert.True(t, s1.NeedFlush())
+	// case 2: flush fail, purge after next flushing
+	indexDB.EXPECT().Flush().Return(fmt.Errorf("err"))
+	assert.Error(t, s1.Flush())
+	assert.True(t, s1.pendingPurges.Contains(10))
+	assert.True(t, s1.purgingMetrics.IsEmpty())
+	// case 3: flush immutable memory data which failed to flush, mutable memory data isn't flushed
+	indexDB.EXPECT().Flush().Return(nil).AnyTimes()
+	assert.NoError(t, s1.Flush())
+	assert.True(t, s1.pendingPurges.Contains(10))
+	assert.True(t, s1.purgingMetrics.IsEmpty())
+	// case 4: purge again after flushing
+	segment.EXPECT().Purge(roaring.BitmapOf(10))
+	assert.NoError(t, s1.Flush())
+	assert.True(t, s1.pendingPurges.IsEmpty())
+	assert.True(t, s1.purgingMetrics.Contains(10))
+	// case 5: purge job not completed
+	segment.EXPECT().IsPurging(roaring.BitmapOf(10)).Return(true)
+	assert.NoError(t, s1.ExpireData())
+	assert.True(t, s1.purgingMetrics.Contains(10))
+	// case 6: clear tombstone err
+	segment.EXPECT().IsPurging(roaring.BitmapOf(10)).Return(false)
+	indexDB.EXPECT().ClearTombstones(roaring.BitmapOf(10)).Return(fmt.Errorf("err"))
+	assert.Error(t, s1.ExpireData())
+	assert.True(t, s1.purgingMetrics.Contains(10))
+	// case 7: clear tombstone
+	segment.EXPECT().IsPurging(roaring.BitmapOf(10)).Return(false)
+	indexDB.EXPECT().ClearTombstones(roaring.BitmapOf(10)).Return(nil)
+	assert.NoError(t, s1.ExpireData())
+	assert.True(t, s1.purgingMetrics.IsEmpty())
+	// case 8: no tombstone need clear
+	assert.NoError(t, s1.ExpireData())
+	assert.False(t, s1.NeedFlush())
+}
+
+func TestShard_GetRollupBoundary(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	writeInterval := timeutil.Interval(10 * timeutil.OneSecond)
+	rollupInterval := timeutil.Interval(5 * timeutil.OneMinute)
+	segment := NewMockIntervalSegment(ctrl)
+	rollupSegment := NewMockIntervalSegment(ctrl)
+	mutable := memdb.NewMockMemoryDatabase(ctrl)
+	s := &shard{
+		interval: writeInterval,
+		mutable:  mutable,
+		segments: map[timeutil.Interval]IntervalSegment{writeInterval: segment, rollupInterval: rollupSegment},
+	}
+	assert.Equal(t, writeInterval, s.Interval())
+	start, _ := timeutil.ParseTimestamp("20261017 08:00:00", "20060102 15:04:05")
+	timeRange := timeutil.TimeRange{Start: start, End: start + 3*timeutil.OneHour - 1}
+
+	family1 := NewMockDataFamily(ctrl)
+	family2 := NewMockDataFamily(ctrl)
+	kvFamily1 := kv.NewMockFamily(ctrl)
+	kvFamily2 := kv.NewMockFamily(ctrl)
+	family1.EXPECT().TimeRange().Return(timeutil.TimeRange{Start: start, End: start + timeutil.OneHour - 1}).AnyTimes()
+	family2.EXPECT().TimeRange().Return(timeutil.TimeRange{Start: start + timeutil.OneHour, End: start + 2*timeutil.OneHour - 1}).AnyTimes()
+	family1.EXPECT().Family().Return(kvFamily1).AnyTimes()
+	family2.EXPECT().Family().Return(kvFamily2).AnyTimes()
+	segment.EXPECT().getDataFamilies(timeRange).Return([]DataFamily{family1, family2}).AnyTimes()
+
+	// case 1: all data rolled up
+	kvFamily1.EXPECT().HasPendingRollup().Return(false)
+	kvFamily2.EXPECT().HasPendingRollup().Return(false)
+	mutable.EXPECT().Families().Return(nil)
+	assert.Equal(t, timeRange.End+1, s.GetRollupBoundary(rollupInterval, timeRange))
+	// case 2: family has pending rollup files
+	kvFamily1.EXPECT().HasPendingRollup().Return(false)
+	kvFamily2.EXPECT().HasPendingRollup().Return(true)
+	mutable.EXPECT().Families().Return(nil)
+	assert.Equal(t, start+timeutil.OneHour, s.GetRollupBoundary(rollupInterval, timeRange))
+	// case 3: memory database has data
+	kvFamily1.EXPECT().HasPendingRollup().Return(false)
+	kvFamily2.EXPECT().HasPendingRollup().Return(false)
+	mutable.EXPECT().Families().Return([]int64{start + 2*timeutil.OneHour})
+	assert.Equal(t, start+2*timeutil.OneHour, s.GetRollupBoundary(rollupInterval, timeRange))
+	// case 4: boundary before query time range
+	timeRange2 := timeutil.TimeRange{Start: start + 30*timeutil.OneMinute, End: timeRange.End}
+	segment.EXPECT().getDataFamilies(timeRange2).Return([]DataFamily{family1, family2})
+	kvFamily1.EXPECT().HasPendingRollup().Return(true)
+	mutable.EXPECT().Families().Return(nil)
+	assert.Equal(t, timeRange2.Start, s.GetRollupBoundary(rollupInterval, timeRange2))
+}
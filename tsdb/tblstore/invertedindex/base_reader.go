@@ -2,9 +2,12 @@ package invertedindex
 
 import (
 	"fmt"
+	"hash/crc32"
 
 	"github.com/lindb/roaring"
 
+	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/stream"
 )
@@ -33,6 +36,11 @@ func (r *baseReader) initReader() error {
 	keysStartPos := int(stream.ReadUint32(r.buf, footerPos))
 	offsetsPos := int(stream.ReadUint32(r.buf, footerPos+4))
 	r.crc32CheckSum = stream.ReadUint32(r.buf, footerPos+8)
+	// verify checksum of block
+	if crc32.ChecksumIEEE(r.buf[:footerPos+8]) != r.crc32CheckSum {
+		table.BlockChecksumFailures.Inc()
+		return constants.ErrDataFileCorruption
+	}
 	// validate offsets
 	if keysStartPos > footerPos || offsetsPos > keysStartPos {
 		return fmt.Errorf("bad offsets")
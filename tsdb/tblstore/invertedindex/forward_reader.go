@@ -3,6 +3,7 @@ package invertedindex
 import (
 	"github.com/lindb/roaring"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/series"
@@ -61,10 +62,13 @@ func (r *forwardReader) GetGroupingScanner(tagKeyID uint32, seriesIDs *roaring.B
 // findReader finds the tag forward reader by tag key id, if reader exist, will invoke callback function
 func (r *forwardReader) findReader(tagKeyID uint32, callback func(reader TagForwardReader)) error {
 	for _, reader := range r.readers {
-		value, ok := reader.Get(tagKeyID)
-		if !ok {
+		value, err := reader.Get(tagKeyID)
+		if err == constants.ErrNotFound {
 			continue
 		}
+		if err != nil {
+			return err
+		}
 		indexReader, err := NewTagForwardReader(value)
 		if err != nil {
 			return err
@@ -8,6 +8,7 @@ import (
 	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/encoding"
@@ -129,9 +130,9 @@ func buildForwardReader(ctrl *gomock.Controller) ForwardReader {
 	block := buildForwardBlock()
 	// mock readers
 	mockReader := table.NewMockReader(ctrl)
-	mockReader.EXPECT().Get(uint32(10)).Return(nil, true).AnyTimes()
-	mockReader.EXPECT().Get(uint32(19)).Return(nil, false).AnyTimes()
-	mockReader.EXPECT().Get(uint32(20)).Return(block, true).AnyTimes()
+	mockReader.EXPECT().Get(uint32(10)).Return(nil, nil).AnyTimes()
+	mockReader.EXPECT().Get(uint32(19)).Return(nil, constants.ErrNotFound).AnyTimes()
+	mockReader.EXPECT().Get(uint32(20)).Return(block, nil).AnyTimes()
 	// build series index inverterReader
 	return NewForwardReader([]table.Reader{mockReader})
 }
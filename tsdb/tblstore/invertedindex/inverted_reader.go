@@ -3,6 +3,7 @@ package invertedindex
 import (
 	"github.com/lindb/roaring"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/encoding"
 )
@@ -42,10 +43,13 @@ func (r *inverterReader) GetSeriesIDsByTagValueIDs(tagKeyID uint32, tagValueIDs
 func (r *inverterReader) loadSeriesIDs(tagKeyID uint32, fn func(indexReader *tagInvertedReader) (*roaring.Bitmap, error)) (*roaring.Bitmap, error) {
 	seriesIDs := roaring.New()
 	for _, reader := range r.readers {
-		value, ok := reader.Get(tagKeyID)
-		if !ok {
+		value, err := reader.Get(tagKeyID)
+		if err == constants.ErrNotFound {
 			continue
 		}
+		if err != nil {
+			return nil, err
+		}
 		indexReader, err := newTagInvertedReader(value)
 		if err != nil {
 			return nil, err
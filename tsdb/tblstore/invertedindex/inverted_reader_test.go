@@ -9,6 +9,7 @@ import (
 	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/encoding"
@@ -83,11 +84,11 @@ func buildInvertedIndexReader(ctrl *gomock.Controller) InvertedReader {
 	zoneBlock, ipBlock, hostBlock := buildInvertedIndexBlock()
 	// mock readers
 	mockReader := table.NewMockReader(ctrl)
-	mockReader.EXPECT().Get(uint32(10)).Return(nil, true).AnyTimes()
-	mockReader.EXPECT().Get(uint32(19)).Return(nil, false).AnyTimes()
-	mockReader.EXPECT().Get(uint32(20)).Return(zoneBlock, true).AnyTimes()
-	mockReader.EXPECT().Get(uint32(21)).Return(ipBlock, true).AnyTimes()
-	mockReader.EXPECT().Get(uint32(22)).Return(hostBlock, true).AnyTimes()
+	mockReader.EXPECT().Get(uint32(10)).Return(nil, nil).AnyTimes()
+	mockReader.EXPECT().Get(uint32(19)).Return(nil, constants.ErrNotFound).AnyTimes()
+	mockReader.EXPECT().Get(uint32(20)).Return(zoneBlock, nil).AnyTimes()
+	mockReader.EXPECT().Get(uint32(21)).Return(ipBlock, nil).AnyTimes()
+	mockReader.EXPECT().Get(uint32(22)).Return(hostBlock, nil).AnyTimes()
 	// build series index inverterReader
 	return NewInvertedReader([]table.Reader{mockReader})
 }
@@ -167,6 +168,13 @@ func TestReader_InvertedIndex_reader_err(t *testing.T) {
 		5})
 	assert.Error(t, err)
 	assert.Nil(t, reader)
+	// case 4: checksum err
+	encoding.BitmapUnmarshal = bitmapUnmarshal
+	corruptedBlock := append([]byte{}, zoneBlock...)
+	corruptedBlock[0]++
+	reader, err = newTagInvertedReader(corruptedBlock)
+	assert.Equal(t, constants.ErrDataFileCorruption, err)
+	assert.Nil(t, reader)
 }
 
 func TestTagInvertedReader_scan(t *testing.T) {
@@ -0,0 +1,88 @@
+package metricsdata
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lindb/lindb/pkg/encoding"
+	"github.com/lindb/lindb/series/field"
+)
+
+// SeriesData represents the decoded data of series in metric block, used by offline inspection tool
+type SeriesData struct {
+	SeriesID uint32
+	Fields   []FieldData
+}
+
+// FieldData represents the decoded points of field
+type FieldData struct {
+	Field  field.Meta
+	Slots  []uint16
+	Values []float64
+}
+
+// ScanSeries decodes all series data of metric block in series id order, stops scanning if fn returns false.
+func ScanSeries(r Reader, fn func(series *SeriesData) bool) error {
+	reader, ok := r.(*reader)
+	if !ok {
+		return fmt.Errorf("unsupported metric block reader of file:%s", r.Path())
+	}
+	tsd := encoding.GetTSDDecoder()
+	defer encoding.ReleaseTSDDecoder(tsd)
+
+	for idx, highKey := range reader.seriesIDs.GetHighKeys() {
+		container := reader.seriesIDs.GetContainerAtIndex(idx)
+		offset, _ := reader.highOffsets.Get(idx)
+		seriesOffsets := encoding.NewFixedOffsetDecoder(reader.buf[offset:])
+		it := container.PeekableIterator()
+		seriesIdx := 0
+		for it.HasNext() {
+			lowSeriesID := it.Next()
+			position, ok := seriesOffsets.Get(seriesIdx)
+			seriesIdx++
+			if !ok {
+				return fmt.Errorf("series offset not found, series id:%d", uint32(highKey)<<16|uint32(lowSeriesID))
+			}
+			series := &SeriesData{SeriesID: uint32(highKey)<<16 | uint32(lowSeriesID)}
+			reader.decodeSeriesData(position, tsd, series)
+			if !fn(series) {
+				return nil
+			}
+		}
+	}
+	return nil
+}
+
+// decodeSeriesData decodes the points of all fields of series by given position
+func (r *reader) decodeSeriesData(position int, tsd *encoding.TSDDecoder, series *SeriesData) {
+	fieldCount := r.fields.Len()
+	if fieldCount == 1 {
+		// metric has one field, just read the data
+		tsd.ResetWithTimeRange(r.buf[position:], r.start, r.end)
+		series.Fields = append(series.Fields, decodeField(r.fields[0], tsd))
+		return
+	}
+	seriesData := r.buf[position:]
+	fieldOffsets := encoding.NewFixedOffsetDecoder(seriesData)
+	fieldsData := seriesData[fieldOffsets.Header()+fieldCount*fieldOffsets.ValueWidth():]
+	for idx, fieldMeta := range r.fields {
+		offset, ok := fieldOffsets.Get(idx)
+		if !ok || offset >= len(fieldsData) {
+			continue
+		}
+		tsd.ResetWithTimeRange(fieldsData[offset:], r.start, r.end)
+		series.Fields = append(series.Fields, decodeField(fieldMeta, tsd))
+	}
+}
+
+// decodeField decodes all points of field
+func decodeField(fieldMeta field.Meta, tsd *encoding.TSDDecoder) FieldData {
+	data := FieldData{Field: fieldMeta}
+	for tsd.Next() {
+		if tsd.HasValue() {
+			data.Slots = append(data.Slots, tsd.Slot())
+			data.Values = append(data.Values, math.Float64frombits(tsd.Value()))
+		}
+	}
+	return data
+}
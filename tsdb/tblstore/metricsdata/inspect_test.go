@@ -0,0 +1,60 @@
+package metricsdata
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestScanSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// case 1: scan all series of multi-fields
+	r, err := NewReader("1.sst", mockMetricBlock())
+	assert.NoError(t, err)
+	var result []*SeriesData
+	err = ScanSeries(r, func(series *SeriesData) bool {
+		result = append(result, series)
+		return true
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 11)
+	assert.Equal(t, uint32(0), result[0].SeriesID)
+	assert.Equal(t, uint32(4096), result[1].SeriesID)
+	assert.Len(t, result[0].Fields, 4)
+	assert.Equal(t, field.Meta{ID: 2, Type: field.SumField}, result[0].Fields[0].Field)
+	assert.Equal(t, []uint16{5}, result[0].Fields[0].Slots)
+	assert.Equal(t, []float64{0}, result[0].Fields[0].Values)
+	assert.Equal(t, uint32(65536+10), result[10].SeriesID)
+	// case 2: stop scanning
+	count := 0
+	err = ScanSeries(r, func(series *SeriesData) bool {
+		count++
+		return false
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	// case 3: scan series of one field
+	r, err = NewReader("1.sst", mockMetricBlockForOneField())
+	assert.NoError(t, err)
+	result = result[:0]
+	err = ScanSeries(r, func(series *SeriesData) bool {
+		result = append(result, series)
+		return true
+	})
+	assert.NoError(t, err)
+	assert.Len(t, result, 11)
+	assert.Len(t, result[10].Fields, 1)
+	assert.Equal(t, []float64{10}, result[10].Fields[0].Values)
+	// case 4: unsupported reader
+	mockReader := NewMockReader(ctrl)
+	mockReader.EXPECT().Path().Return("1.sst")
+	err = ScanSeries(mockReader, func(series *SeriesData) bool {
+		return true
+	})
+	assert.Error(t, err)
+}
@@ -17,6 +17,14 @@ func init() {
 	kv.RegisterMerger(MetricDataMerger, NewMerger)
 }
 
+// SeriesTombstone represents the dropped metrics and deleted series of metric, which are purged by merger
+type SeriesTombstone interface {
+	// IsMetricDropped returns if the metric is dropped
+	IsMetricDropped(metricID uint32) bool
+	// GetDeletedSeriesIDs returns the deleted series ids of metric, returns nil if not exist
+	GetDeletedSeriesIDs(metricID uint32) *roaring.Bitmap
+}
+
 type mergerContext struct {
 	scanners               []*dataScanner
 	seriesIDs              *roaring.Bitmap // target series ids
@@ -32,6 +40,7 @@ type merger struct {
 	flusher      *kv.NopFlusher
 	seriesMerger SeriesMerger
 	rollup       kv.Rollup
+	tombstone    SeriesTombstone
 }
 
 // NewMerger creates a metric data merger
@@ -45,22 +54,41 @@ func NewMerger() kv.Merger {
 	}
 }
 
-// Init initializes metric data merger, if rollup context exist do rollup job, else do compact job
+// Init initializes metric data merger, if rollup context exist do rollup job, else do compact job,
+// if tombstone context exist purge the deleted series.
 func (m *merger) Init(params map[string]interface{}) {
 	rollupCtx, ok := params[kv.RollupContext]
 	if ok {
 		m.rollup = rollupCtx.(kv.Rollup)
 	}
+	tombstoneCtx, ok := params[kv.TombstoneContext]
+	if ok {
+		m.tombstone, _ = tombstoneCtx.(SeriesTombstone)
+	}
 }
 
 // Merge merges the multi metric data into one target metric data for same metric id
 func (m *merger) Merge(key uint32, values [][]byte) ([]byte, error) {
+	if m.tombstone != nil && m.tombstone.IsMetricDropped(key) {
+		// metric dropped, drop the metric data
+		return nil, nil
+	}
 	blockCount := len(values)
 	// 1. prepare readers and metric level data(field/time slot/series ids)
 	mergeCtx, err := m.prepare(values)
 	if err != nil {
 		return nil, err
 	}
+	// purge the deleted series
+	if m.tombstone != nil {
+		if deletedSeriesIDs := m.tombstone.GetDeletedSeriesIDs(key); deletedSeriesIDs != nil {
+			mergeCtx.seriesIDs.AndNot(deletedSeriesIDs)
+			if mergeCtx.seriesIDs.IsEmpty() {
+				// all series deleted, drop the metric data
+				return nil, nil
+			}
+		}
+	}
 	// 2. flush fields
 	m.dataFlusher.FlushFieldMetas(mergeCtx.targetFields)
 	// 3. merge series data by roaring container
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/kv"
@@ -138,3 +139,63 @@ func mockMetricMergeBlockOneField(seriesIDs []uint32, start, end uint16) []byte
 	_ = flusher.FlushMetric(uint32(10), start, end)
 	return nopKVFlusher.Bytes()
 }
+
+type mockSeriesTombstone struct {
+	dropped *roaring.Bitmap
+	deleted map[uint32]*roaring.Bitmap
+}
+
+func (t *mockSeriesTombstone) IsMetricDropped(metricID uint32) bool {
+	return t.dropped.Contains(metricID)
+}
+
+func (t *mockSeriesTombstone) GetDeletedSeriesIDs(metricID uint32) *roaring.Bitmap {
+	return t.deleted[metricID]
+}
+
+func TestMerger_Purge_Merge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	flusher := NewMockFlusher(ctrl)
+	seriesMerger := NewMockSeriesMerger(ctrl)
+	merge := NewMerger()
+	merge.Init(map[string]interface{}{kv.TombstoneContext: &mockSeriesTombstone{
+		dropped: roaring.BitmapOf(3),
+		deleted: map[uint32]*roaring.Bitmap{1: roaring.BitmapOf(2, 20), 2: roaring.BitmapOf(1, 2, 4)},
+	}})
+	m := merge.(*merger)
+	m.dataFlusher = flusher
+	m.seriesMerger = seriesMerger
+	flusher.EXPECT().FlushFieldMetas(gomock.Any()).AnyTimes()
+	// case 1: purge deleted series
+	seriesMerger.EXPECT().merge(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(nil).Times(2)
+	gomock.InOrder(
+		flusher.EXPECT().FlushSeries(uint32(1)),
+		flusher.EXPECT().FlushSeries(uint32(4)),
+		flusher.EXPECT().FlushMetric(uint32(1), uint16(10), uint16(15)).Return(nil),
+	)
+	_, err := merge.Merge(
+		1,
+		[][]byte{
+			mockMetricMergeBlock([]uint32{1, 2, 4}, 10, 10),
+			mockMetricMergeBlock([]uint32{2, 20}, 15, 15),
+		})
+	assert.NoError(t, err)
+	// case 2: all series deleted
+	data, err := merge.Merge(
+		2,
+		[][]byte{
+			mockMetricMergeBlock([]uint32{1, 2, 4}, 10, 10),
+		})
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+	// case 3: metric dropped
+	data, err = merge.Merge(
+		3,
+		[][]byte{
+			mockMetricMergeBlock([]uint32{1, 2, 4}, 10, 10),
+		})
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+}
@@ -2,12 +2,15 @@ package metricsdata
 
 import (
 	"fmt"
+	"hash/crc32"
 	"math"
 
 	"github.com/lindb/roaring"
 
 	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
+	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/stream"
 	"github.com/lindb/lindb/series"
@@ -207,6 +210,11 @@ func (r *reader) initReader() error {
 	seriesIDsStartPos := int(stream.ReadUint32(r.buf, footerPos+8))
 	highOffsetsPos := int(stream.ReadUint32(r.buf, footerPos+12))
 	r.crc32CheckSum = stream.ReadUint32(r.buf, footerPos+16)
+	// verify checksum of block
+	if crc32.ChecksumIEEE(r.buf[:footerPos+16]) != r.crc32CheckSum {
+		table.BlockChecksumFailures.Inc()
+		return constants.ErrDataFileCorruption
+	}
 	// validate offsets
 	if fieldMetaStartPos > footerPos || seriesIDsStartPos > highOffsetsPos {
 		return fmt.Errorf("bad offsets")
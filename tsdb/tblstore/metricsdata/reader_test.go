@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/lindb/lindb/aggregation"
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/flow"
 	"github.com/lindb/lindb/kv"
 	"github.com/lindb/lindb/pkg/bit"
@@ -59,6 +60,12 @@ func TestNewReader(t *testing.T) {
 	r, err = NewReader("1.sst", mockMetricBlock())
 	assert.Error(t, err)
 	assert.Nil(t, r)
+	// case 5: checksum err
+	block := mockMetricBlock()
+	block[0]++
+	r, err = NewReader("1.sst", block)
+	assert.Equal(t, constants.ErrDataFileCorruption, err)
+	assert.Nil(t, r)
 }
 
 func TestReader_Load(t *testing.T) {
@@ -177,6 +177,28 @@ func TestSeriesMerger_rollup_merge(t *testing.T) {
 		}
 	}
 	assert.Equal(t, 2, c)
+	// case 3: the count of histogram bucket is summed when rollup
+	reader1.EXPECT().getFieldData(gomock.Any()).Return(mockField(10))
+	reader1.EXPECT().slotRange().Return(uint16(10), uint16(10))
+	reader2.EXPECT().getFieldData(gomock.Any()).Return(mockField(12))
+	reader2.EXPECT().slotRange().Return(uint16(12), uint16(12))
+	flusher.EXPECT().FlushField(gomock.Any()).DoAndReturn(func(data []byte) {
+		result = data
+	})
+	err = merger.merge(
+		&mergerContext{
+			targetFields: field.Metas{{ID: 1, Type: field.HistogramField}},
+			sourceStart:  5,
+			sourceEnd:    15,
+			targetStart:  0,
+			targetEnd:    0,
+			ratio:        30,
+		}, decodeStreams, encodeStream, readers)
+	assert.NoError(t, err)
+	tsd = encoding.GetTSDDecoder()
+	tsd.ResetWithTimeRange(result, 0, 0)
+	assert.True(t, tsd.HasValueWithSlot(0))
+	assert.Equal(t, 20.0, math.Float64frombits(tsd.Value()))
 }
 
 func mockField(start uint16) []byte {
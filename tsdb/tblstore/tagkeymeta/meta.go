@@ -3,11 +3,13 @@ package tagkeymeta
 import (
 	"bytes"
 	"fmt"
+	"hash/crc32"
 	"regexp"
 	"sort"
 	"strings"
 
 	"github.com/lindb/lindb/constants"
+	"github.com/lindb/lindb/kv/table"
 	"github.com/lindb/lindb/pkg/encoding"
 	"github.com/lindb/lindb/pkg/stream"
 	"github.com/lindb/lindb/pkg/strutil"
@@ -94,6 +96,11 @@ func newTagKeyMeta(tagKeyMetaBlock []byte) (TagKeyMeta, error) {
 	meta.offsetsPos = int(meta.sr.ReadUint32())
 	meta.tagValueIDSeq = meta.sr.ReadUint32()
 	meta.crc32CheckSum = meta.sr.ReadUint32()
+	// verify checksum of block
+	if crc32.ChecksumIEEE(tagKeyMetaBlock[:len(tagKeyMetaBlock)-4]) != meta.crc32CheckSum {
+		table.BlockChecksumFailures.Inc()
+		return nil, constants.ErrDataFileCorruption
+	}
 
 	expectedOrders := []int{0,
 		meta.bitmapPos, meta.bitmapPos + 1,
@@ -5,6 +5,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/lindb/lindb/constants"
 	"github.com/lindb/lindb/kv"
 
 	"github.com/lindb/roaring"
@@ -24,6 +25,12 @@ func Test_newTagKeyMeta_error_cases(t *testing.T) {
 		4, 4, 4, 4,
 		5})
 	assert.Error(t, err)
+
+	// case3: checksum mismatch
+	block := append([]byte{}, buildTestTrieData()...)
+	block[0]++
+	_, err = newTagKeyMeta(block)
+	assert.Equal(t, constants.ErrDataFileCorruption, err)
 }
 
 var testOnce sync.Once
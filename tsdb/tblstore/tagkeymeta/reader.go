@@ -57,10 +57,13 @@ func NewReader(readers []table.Reader) Reader {
 // so the max sequence will be stored in the first table.reader that is tag key store.
 func (r *tagReader) GetTagValueSeq(tagKeyID uint32) (tagValueSeq uint32, err error) {
 	for _, reader := range r.readers {
-		tagKeyMetaBlock, ok := reader.Get(tagKeyID)
-		if !ok {
+		tagKeyMetaBlock, err := reader.Get(tagKeyID)
+		if err == constants.ErrNotFound {
 			continue
 		}
+		if err != nil {
+			return 0, err
+		}
 		//FIXME stone1100 opt need cache entry set
 		meta, err := newTagKeyMeta(tagKeyMetaBlock)
 		if err != nil {
@@ -74,10 +77,13 @@ func (r *tagReader) GetTagValueSeq(tagKeyID uint32) (tagValueSeq uint32, err err
 // GetTagValueID returns the tag value id for spec metric's tag key id, if not exist return constants.ErrNotFound
 func (r *tagReader) GetTagValueID(tagID uint32, tagValue string) (tagValueID uint32, err error) {
 	for _, reader := range r.readers {
-		tagKeyMetaBlock, ok := reader.Get(tagID)
-		if !ok {
+		tagKeyMetaBlock, err := reader.Get(tagID)
+		if err == constants.ErrNotFound {
 			continue
 		}
+		if err != nil {
+			return 0, err
+		}
 		meta, err := newTagKeyMeta(tagKeyMetaBlock)
 		if err != nil {
 			return 0, err
@@ -130,8 +136,8 @@ func (r *tagReader) GetTagValueIDsForTagKeyID(tagID uint32) (*roaring.Bitmap, er
 // filterTagKeyMetas filters the tag-key-metas by tag key id
 func (r *tagReader) filterTagKeyMetas(tagID uint32) (metas TagKeyMetas) {
 	for _, reader := range r.readers {
-		tagKeyMetaBlock, ok := reader.Get(tagID)
-		if !ok {
+		tagKeyMetaBlock, err := reader.Get(tagID)
+		if err != nil {
 			continue
 		}
 		tagKeyMeta, err := newTagKeyMeta(tagKeyMetaBlock)
@@ -155,8 +161,8 @@ func (r *tagReader) SuggestTagValues(
 		limit = constants.MaxSuggestions
 	}
 	for _, reader := range r.readers {
-		tagKeyMetaBlock, ok := reader.Get(tagKeyID)
-		if !ok {
+		tagKeyMetaBlock, err := reader.Get(tagKeyID)
+		if err != nil {
 			continue
 		}
 		tagKeyMeta, err := newTagKeyMeta(tagKeyMetaBlock)
@@ -187,10 +193,13 @@ func (r *tagReader) WalkTagValues(
 	fn func(tagValue []byte, tagValueID uint32) bool,
 ) error {
 	for _, reader := range r.readers {
-		tagKeyMetaBlock, ok := reader.Get(tagKeyID)
-		if !ok {
+		tagKeyMetaBlock, err := reader.Get(tagKeyID)
+		if err == constants.ErrNotFound {
 			continue
 		}
+		if err != nil {
+			return err
+		}
 		tagKeyMeta, err := newTagKeyMeta(tagKeyMetaBlock)
 		if err != nil {
 			continue
@@ -220,10 +229,13 @@ func (r *tagReader) CollectTagValues(
 		if tagValueIDs.IsEmpty() {
 			return nil
 		}
-		tagKeyMetaBlock, ok := reader.Get(tagKeyID)
-		if !ok {
+		tagKeyMetaBlock, err := reader.Get(tagKeyID)
+		if err == constants.ErrNotFound {
 			continue
 		}
+		if err != nil {
+			return err
+		}
 		tagKeyMeta, err := newTagKeyMeta(tagKeyMetaBlock)
 		if err != nil {
 			continue
@@ -0,0 +1,38 @@
+package tsdb
+
+import (
+	"github.com/lindb/roaring"
+
+	"github.com/lindb/lindb/kv"
+	"github.com/lindb/lindb/tsdb/indexdb"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+)
+
+// seriesTombstone implements kv.Tombstone and metricsdata.SeriesTombstone interface,
+// the dropped metrics and deleted series of index database are purged when compaction.
+type seriesTombstone struct {
+	indexDB indexdb.IndexDatabase
+}
+
+// newSeriesTombstone creates the tombstone based on index database
+func newSeriesTombstone(indexDB indexdb.IndexDatabase) kv.Tombstone {
+	return &seriesTombstone{indexDB: indexDB}
+}
+
+// Keys returns the metric ids which are dropped or have deleted series
+func (t *seriesTombstone) Keys() *roaring.Bitmap {
+	return t.indexDB.GetDeletedMetricIDs()
+}
+
+// IsMetricDropped returns if the metric is dropped
+func (t *seriesTombstone) IsMetricDropped(metricID uint32) bool {
+	return t.indexDB.IsMetricDropped(metricID)
+}
+
+// GetDeletedSeriesIDs returns the deleted series ids of metric, returns nil if not exist
+func (t *seriesTombstone) GetDeletedSeriesIDs(metricID uint32) *roaring.Bitmap {
+	return t.indexDB.GetDeletedSeriesIDs(metricID)
+}
+
+// make sure tombstone can be used by metric data merger
+var _ metricsdata.SeriesTombstone = (*seriesTombstone)(nil)
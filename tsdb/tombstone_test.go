@@ -0,0 +1,28 @@
+package tsdb
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/lindb/roaring"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/tsdb/indexdb"
+	"github.com/lindb/lindb/tsdb/tblstore/metricsdata"
+)
+
+func TestSeriesTombstone(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	indexDB := indexdb.NewMockIndexDatabase(ctrl)
+	tombstone := newSeriesTombstone(indexDB)
+	indexDB.EXPECT().GetDeletedMetricIDs().Return(roaring.BitmapOf(1, 2))
+	assert.Equal(t, []uint32{1, 2}, tombstone.Keys().ToArray())
+
+	seriesTombstone := tombstone.(metricsdata.SeriesTombstone)
+	indexDB.EXPECT().IsMetricDropped(uint32(1)).Return(true)
+	assert.True(t, seriesTombstone.IsMetricDropped(1))
+	indexDB.EXPECT().GetDeletedSeriesIDs(uint32(2)).Return(roaring.BitmapOf(10))
+	assert.Equal(t, []uint32{10}, seriesTombstone.GetDeletedSeriesIDs(2).ToArray())
+}
@@ -0,0 +1,36 @@
+package wal
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+// for testing
+var (
+	listDirFunc  = fileutil.ListDir
+	copyFileFunc = fileutil.CopyFile
+)
+
+// Backup copies the page files of write ahead log into target path.
+// NOTICE: the wal must be synced before backup, page files cannot be linked because they are mapped for writing,
+// the page released during copying is skipped, because the data of released page has been committed.
+func Backup(path, target string) error {
+	if err := mkDirFunc(target); err != nil {
+		return err
+	}
+	fileNames, err := listDirFunc(path)
+	if err != nil {
+		return err
+	}
+	for _, fileName := range fileNames {
+		if err := copyFileFunc(filepath.Join(path, fileName), filepath.Join(target, fileName)); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package wal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+)
+
+func TestBackup(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testMetaWALPath)
+	}()
+	wal, err := NewMetricMetaWAL(filepath.Join(testMetaWALPath, "source"))
+	assert.NoError(t, err)
+	assert.NoError(t, wal.AppendMetric(ns, "name", 10))
+	assert.NoError(t, wal.Sync())
+	assert.NoError(t, Backup(filepath.Join(testMetaWALPath, "source"), filepath.Join(testMetaWALPath, "target")))
+	assert.NoError(t, wal.Close())
+
+	// recover from backup
+	wal, err = NewMetricMetaWAL(filepath.Join(testMetaWALPath, "target"))
+	assert.NoError(t, err)
+	assert.True(t, wal.NeedRecovery())
+	var metricIDs []uint32
+	wal.Recovery(func(namespace, metricName string, metricID uint32) error {
+		metricIDs = append(metricIDs, metricID)
+		return nil
+	}, nil, nil, func() error {
+		return nil
+	})
+	assert.Equal(t, []uint32{10}, metricIDs)
+	assert.NoError(t, wal.Close())
+}
+
+func TestBackup_Fail(t *testing.T) {
+	defer func() {
+		mkDirFunc = fileutil.MkDirIfNotExist
+		listDirFunc = fileutil.ListDir
+		copyFileFunc = fileutil.CopyFile
+		_ = fileutil.RemoveDir(testMetaWALPath)
+	}()
+	// case 1: make target path err
+	mkDirFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, Backup(testMetaWALPath, filepath.Join(testMetaWALPath, "target")))
+	mkDirFunc = fileutil.MkDirIfNotExist
+	// case 2: list wal path err
+	listDirFunc = func(path string) ([]string, error) {
+		return nil, fmt.Errorf("err")
+	}
+	assert.Error(t, Backup(testMetaWALPath, filepath.Join(testMetaWALPath, "target")))
+	listDirFunc = func(path string) ([]string, error) {
+		return []string{"1.bat"}, nil
+	}
+	// case 3: copy file err
+	copyFileFunc = func(source, target string) error {
+		return fmt.Errorf("err")
+	}
+	assert.Error(t, Backup(testMetaWALPath, filepath.Join(testMetaWALPath, "target")))
+	// case 4: skip released page
+	copyFileFunc = func(source, target string) error {
+		return os.ErrNotExist
+	}
+	assert.NoError(t, Backup(testMetaWALPath, filepath.Join(testMetaWALPath, "target")))
+}
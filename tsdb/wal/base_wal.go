@@ -44,6 +44,9 @@ type FieldRecoveryFunc = func(metricID uint32, fID field.ID, fieldName field.Nam
 // TagKeyRecoveryFunc represents the tag key recovery function
 type TagKeyRecoveryFunc = func(metricID uint32, tagKeyID uint32, tagKey string) error
 
+// DataRecoveryFunc represents the replica data recovery function
+type DataRecoveryFunc = func(replicaPeer string, seq int64, metricList []byte) error
+
 // CommitFunc represents the commit function after recovery
 type CommitFunc = func() error
 
@@ -143,6 +146,40 @@ func (wal *baseWAL) putString(value string) {
 	wal.offset += length
 }
 
+func (wal *baseWAL) putBytes(value []byte) {
+	wal.currentPage.WriteBytes(value, wal.offset)
+	wal.offset += len(value)
+}
+
+// roll seals current page, then acquires new page for appending data, returns the index of sealed page
+func (wal *baseWAL) roll() (int64, error) {
+	if err := wal.currentPage.Sync(); err != nil {
+		return 0, err
+	}
+	sealedPageIndex := wal.pageIndex.Load()
+	walPage, err := wal.walFactory.AcquirePage(sealedPageIndex + 1)
+	if err != nil {
+		return 0, err
+	}
+	wal.currentPage = walPage
+	wal.pageIndex.Inc()
+	wal.offset = 0
+	return sealedPageIndex, nil
+}
+
+// release releases the sealed pages which index <= page index, then commits the page index
+func (wal *baseWAL) release(pageIndex int64) error {
+	current := wal.pageIndex.Load()
+	for i := wal.commitPageIndex.Load() + 1; i <= pageIndex && i < current; i++ {
+		if err := wal.walFactory.ReleasePage(i); err != nil {
+			releaseWALPageFailCounter.Inc()
+			return err
+		}
+		wal.commitPageIndex.Store(i)
+	}
+	return nil
+}
+
 // sync flushes data into disk
 func (wal *baseWAL) sync() error {
 	return wal.currentPage.Sync()
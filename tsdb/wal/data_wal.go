@@ -0,0 +1,168 @@
+package wal
+
+import (
+	"fmt"
+	"hash/crc32"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/lindb/lindb/monitoring"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/queue/page"
+)
+
+//go:generate mockgen -source=./data_wal.go -destination=./data_wal_mock.go -package=wal
+
+var dataWALLogger = logger.GetLogger("wal", "data")
+
+var (
+	replayDataFailCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "wal_replay_data_fail",
+			Help: "Replay replica data fail when data wal replay.",
+		},
+	)
+)
+
+func init() {
+	monitoring.StorageRegistry.MustRegister(replayDataFailCounter)
+}
+
+const (
+	dataPageSize          = 32 * 1024 * 1024 // data wal page size
+	dataEntryHeaderLength = 4 + 4            // payload length + payload checksum
+)
+
+// DataWAL represents write ahead log which stores the replica data(replica peer + sequence + metric batch)
+// for memory database of shard, the pages are truncated after memory database flushed.
+// entry format: payload length(4) + payload crc32(4) + replica peer + sequence(8) + marshaled metric list
+type DataWAL interface {
+	// Append appends the marshaled metric list of replica sequence into wal log
+	Append(replicaPeer string, seq int64, metricList []byte) error
+	// Replay reads all entries of the pages which are not truncated in page order,
+	// then writes data via recovery function, the pages are kept until truncated.
+	Replay(recovery DataRecoveryFunc) error
+	// Roll seals current page, the following entries are appended into new page, returns the index of sealed page
+	Roll() (int64, error)
+	// Truncate removes the sealed pages which index <= page index, invoked after the data of pages flushed
+	Truncate(pageIndex int64) error
+	// Sync flushes data into disk
+	Sync() error
+	// Close closes the wal log
+	Close() error
+}
+
+// dataWAL implements DataWAL interface
+type dataWAL struct {
+	base *baseWAL
+}
+
+// NewDataWAL creates a new data write ahead log
+func NewDataWAL(path string) (DataWAL, error) {
+	base, err := newBaseWAL(path, dataPageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &dataWAL{base: base}, nil
+}
+
+// Append appends the marshaled metric list of replica sequence into wal log
+func (wal *dataWAL) Append(replicaPeer string, seq int64, metricList []byte) error {
+	if len(replicaPeer) > 255 {
+		return fmt.Errorf("replica peer[%s] too long", replicaPeer)
+	}
+	payloadLength := 1 + len(replicaPeer) + 8 + len(metricList)
+	if dataEntryHeaderLength+payloadLength > dataPageSize {
+		return fmt.Errorf("data entry too large, length: %d", payloadLength)
+	}
+	if err := wal.base.checkPage(dataEntryHeaderLength + payloadLength); err != nil {
+		return err
+	}
+	// writes payload first, then writes length and checksum of payload
+	headerOffset := wal.base.offset
+	wal.base.offset += dataEntryHeaderLength
+	wal.base.putString(replicaPeer)
+	wal.base.putUint64(uint64(seq))
+	wal.base.putBytes(metricList)
+
+	payload := wal.base.currentPage.ReadBytes(headerOffset+dataEntryHeaderLength, payloadLength)
+	wal.base.currentPage.PutUint32(uint32(payloadLength), headerOffset)
+	wal.base.currentPage.PutUint32(crc32.ChecksumIEEE(payload), headerOffset+4)
+	return nil
+}
+
+// Replay reads all entries of the pages which are not truncated in page order,
+// then writes data via recovery function, the pages are kept until truncated.
+func (wal *dataWAL) Replay(recovery DataRecoveryFunc) error {
+	current := wal.base.pageIndex.Load()
+	committed := wal.base.commitPageIndex.Load()
+	for i := committed + 1; i < current; i++ {
+		walPage, ok := wal.base.walFactory.GetPage(i)
+		if !ok {
+			continue
+		}
+		if err := readDataPage(walPage, recovery); err != nil {
+			replayDataFailCounter.Inc()
+
+			dataWALLogger.Error("replay data wal page error",
+				logger.String("wal", wal.base.path), logger.Any("page", i), logger.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// readDataPage reads all entries of data wal page, then writes data via recovery function,
+// stops reading if the entry is incomplete(the tail of page isn't synced before crash).
+func readDataPage(walPage page.MappedPage, recovery DataRecoveryFunc) error {
+	offset := 0
+	for offset+dataEntryHeaderLength < dataPageSize {
+		payloadLength := int(walPage.ReadUint32(offset))
+		if payloadLength == 0 {
+			break
+		}
+		payloadOffset := offset + dataEntryHeaderLength
+		if payloadOffset+payloadLength > dataPageSize {
+			dataWALLogger.Warn("ignore incomplete data wal entry",
+				logger.String("page", walPage.FilePath()), logger.Any("offset", offset))
+			break
+		}
+		payload := walPage.ReadBytes(payloadOffset, payloadLength)
+		if crc32.ChecksumIEEE(payload) != walPage.ReadUint32(offset+4) {
+			dataWALLogger.Warn("ignore data wal entry with bad checksum",
+				logger.String("page", walPage.FilePath()), logger.Any("offset", offset))
+			break
+		}
+		replicaPeer, n := readString(walPage, payloadOffset)
+		seq := int64(walPage.ReadUint64(payloadOffset + n))
+		if err := recovery(replicaPeer, seq, payload[n+8:]); err != nil {
+			return err
+		}
+		offset = payloadOffset + payloadLength
+	}
+	return nil
+}
+
+// Roll seals current page, the following entries are appended into new page, returns the index of sealed page
+func (wal *dataWAL) Roll() (int64, error) {
+	return wal.base.roll()
+}
+
+// Truncate removes the sealed pages which index <= page index, invoked after the data of pages flushed
+func (wal *dataWAL) Truncate(pageIndex int64) error {
+	return wal.base.release(pageIndex)
+}
+
+// Sync flushes data into disk
+func (wal *dataWAL) Sync() error {
+	return wal.base.sync()
+}
+
+// Close closes the wal log, includes all pages which are not truncated
+func (wal *dataWAL) Close() error {
+	if err := wal.base.sync(); err != nil {
+		dataWALLogger.Error("sync data wal error when close",
+			logger.String("wal", wal.base.path), logger.Error(err))
+	}
+	return wal.base.walFactory.Close()
+}
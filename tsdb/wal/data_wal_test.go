@@ -0,0 +1,188 @@
+package wal
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/queue/page"
+)
+
+var testDataWALPath = "dataWAL"
+
+type dataEntry struct {
+	replicaPeer string
+	seq         int64
+	metricList  string
+}
+
+func TestNewDataWAL(t *testing.T) {
+	defer func() {
+		mkDirFunc = fileutil.MkDirIfNotExist
+		_ = fileutil.RemoveDir(testDataWALPath)
+	}()
+	// case 1: make path err
+	mkDirFunc = func(path string) error {
+		return fmt.Errorf("err")
+	}
+	wal, err := NewDataWAL(testDataWALPath)
+	assert.Error(t, err)
+	assert.Nil(t, wal)
+	mkDirFunc = fileutil.MkDirIfNotExist
+	// case 2: new wal
+	wal, err = NewDataWAL(testDataWALPath)
+	assert.NoError(t, err)
+	assert.NotNil(t, wal)
+	assert.NoError(t, wal.Close())
+}
+
+func TestDataWAL_Replay(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testDataWALPath)
+	}()
+	wal, err := NewDataWAL(testDataWALPath)
+	assert.NoError(t, err)
+	assert.NoError(t, wal.Append("peer-1", 1, []byte("m1")))
+	assert.NoError(t, wal.Append("peer-2", 1, []byte("m2")))
+	sealedPage, err := wal.Roll()
+	assert.NoError(t, err)
+	assert.NoError(t, wal.Append("peer-1", 2, []byte("m3")))
+	assert.NoError(t, wal.Sync())
+	assert.NoError(t, wal.Close())
+
+	readEntries := func(wal DataWAL) (entries []dataEntry) {
+		err := wal.Replay(func(replicaPeer string, seq int64, metricList []byte) error {
+			entries = append(entries, dataEntry{replicaPeer: replicaPeer, seq: seq, metricList: string(metricList)})
+			return nil
+		})
+		assert.NoError(t, err)
+		return entries
+	}
+	// case 1: re-open, replay all pages
+	wal, err = NewDataWAL(testDataWALPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []dataEntry{
+		{replicaPeer: "peer-1", seq: 1, metricList: "m1"},
+		{replicaPeer: "peer-2", seq: 1, metricList: "m2"},
+		{replicaPeer: "peer-1", seq: 2, metricList: "m3"},
+	}, readEntries(wal))
+	// case 2: replay again, pages are kept until truncated
+	assert.Len(t, readEntries(wal), 3)
+	// case 3: recovery func err
+	err = wal.Replay(func(replicaPeer string, seq int64, metricList []byte) error {
+		return fmt.Errorf("err")
+	})
+	assert.Error(t, err)
+	// case 4: truncate sealed page
+	assert.NoError(t, wal.Truncate(sealedPage))
+	assert.Equal(t, []dataEntry{{replicaPeer: "peer-1", seq: 2, metricList: "m3"}}, readEntries(wal))
+	// case 5: truncate all pages
+	sealedPage, err = wal.Roll()
+	assert.NoError(t, err)
+	assert.NoError(t, wal.Truncate(sealedPage))
+	assert.Empty(t, readEntries(wal))
+	assert.NoError(t, wal.Close())
+	// case 6: re-open, no data
+	wal, err = NewDataWAL(testDataWALPath)
+	assert.NoError(t, err)
+	assert.Empty(t, readEntries(wal))
+	assert.NoError(t, wal.Close())
+}
+
+func TestDataWAL_Append(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testDataWALPath)
+	}()
+	wal, err := NewDataWAL(testDataWALPath)
+	assert.NoError(t, err)
+	// case 1: replica peer too long
+	assert.Error(t, wal.Append(strings.Repeat("a", 256), 1, []byte("m1")))
+	// case 2: entry too large
+	assert.Error(t, wal.Append("peer", 1, make([]byte, dataPageSize)))
+	// case 3: alloc new page if current page is full
+	metricList := make([]byte, dataPageSize/2)
+	assert.NoError(t, wal.Append("peer", 1, metricList))
+	assert.NoError(t, wal.Append("peer", 2, metricList))
+	assert.NoError(t, wal.Append("peer", 3, metricList))
+	assert.NoError(t, wal.Close())
+
+	wal, err = NewDataWAL(testDataWALPath)
+	assert.NoError(t, err)
+	var seqs []int64
+	err = wal.Replay(func(replicaPeer string, seq int64, metricList []byte) error {
+		seqs = append(seqs, seq)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 3}, seqs)
+	assert.NoError(t, wal.Close())
+}
+
+func TestDataWAL_Replay_bad_entry(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testDataWALPath)
+	}()
+	wal, err := NewDataWAL(testDataWALPath)
+	assert.NoError(t, err)
+	assert.NoError(t, wal.Append("peer", 1, []byte("m1")))
+	assert.NoError(t, wal.Append("peer", 2, []byte("m2")))
+	// corrupt the payload of second entry
+	w := wal.(*dataWAL)
+	w.base.currentPage.PutUint8(0xff, w.base.offset-1)
+	assert.NoError(t, wal.Close())
+
+	wal, err = NewDataWAL(testDataWALPath)
+	assert.NoError(t, err)
+	var seqs []int64
+	err = wal.Replay(func(replicaPeer string, seq int64, metricList []byte) error {
+		seqs = append(seqs, seq)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, seqs)
+	assert.NoError(t, wal.Close())
+}
+
+func TestDataWAL_Roll_Truncate_err(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		newPageFactoryFunc = page.NewFactory
+		_ = fileutil.RemoveDir(testDataWALPath)
+		ctrl.Finish()
+	}()
+	fct := page.NewMockFactory(ctrl)
+	newPageFactoryFunc = func(path string, pageSize int) (page.Factory, error) {
+		return fct, nil
+	}
+	mockPage := page.NewMockMappedPage(ctrl)
+	fct.EXPECT().GetPageIDs().Return(nil)
+	fct.EXPECT().AcquirePage(int64(1)).Return(mockPage, nil)
+	wal, err := NewDataWAL(testDataWALPath)
+	assert.NoError(t, err)
+	// case 1: sync page err
+	mockPage.EXPECT().Sync().Return(fmt.Errorf("err"))
+	_, err = wal.Roll()
+	assert.Error(t, err)
+	// case 2: acquire page err
+	mockPage.EXPECT().Sync().Return(nil)
+	fct.EXPECT().AcquirePage(int64(2)).Return(nil, fmt.Errorf("err"))
+	_, err = wal.Roll()
+	assert.Error(t, err)
+	// case 3: roll success
+	mockPage.EXPECT().Sync().Return(nil)
+	fct.EXPECT().AcquirePage(int64(2)).Return(mockPage, nil)
+	pageIndex, err := wal.Roll()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), pageIndex)
+	// case 4: release page err
+	fct.EXPECT().ReleasePage(int64(1)).Return(fmt.Errorf("err"))
+	assert.Error(t, wal.Truncate(pageIndex))
+	// case 5: close with sync err
+	mockPage.EXPECT().Sync().Return(fmt.Errorf("err"))
+	fct.EXPECT().Close().Return(nil)
+	assert.NoError(t, wal.Close())
+}
@@ -0,0 +1,69 @@
+package wal
+
+import (
+	"fmt"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/queue/page"
+)
+
+// for testing
+var (
+	listPageIDsFunc     = page.ListPageIDs
+	newReadOnlyPageFunc = page.NewReadOnlyMappedPage
+)
+
+// ReadMetricMetaWAL reads all entries of metric meta wal pages under path in page order without opening the wal,
+// includes the entries of current page which are not committed, used by offline inspection tool.
+func ReadMetricMetaWAL(path string,
+	metricRecovery MetricRecoveryFunc,
+	fieldRecovery FieldRecoveryFunc,
+	tagKeyRecovery TagKeyRecoveryFunc,
+) error {
+	return readPages(path, metricMetaPageSize, func(walPage page.MappedPage) error {
+		return readMetaPage(walPage, metricRecovery, fieldRecovery, tagKeyRecovery)
+	})
+}
+
+// ReadSeriesWAL reads all entries of series wal pages under path in page order without opening the wal,
+// includes the entries of current page which are not committed, used by offline inspection tool.
+func ReadSeriesWAL(path string, recovery SeriesRecoveryFunc) error {
+	return readPages(path, seriesPageSize, func(walPage page.MappedPage) error {
+		return readSeriesPage(walPage, recovery)
+	})
+}
+
+// ReadDataWAL reads all entries of data wal pages under path in page order without opening the wal,
+// includes the entries of current page which are not synced, used by offline inspection tool.
+func ReadDataWAL(path string, recovery DataRecoveryFunc) error {
+	return readPages(path, dataPageSize, func(walPage page.MappedPage) error {
+		return readDataPage(walPage, recovery)
+	})
+}
+
+// readPages reads all exist wal pages under path in page order,
+// the pages are mapped for read only, so that the wal files are never created or modified.
+func readPages(path string, pageSize int, fn func(walPage page.MappedPage) error) error {
+	if !fileutil.Exist(path) {
+		return fmt.Errorf("wal path[%s] not exist", path)
+	}
+	pageIDs, err := listPageIDsFunc(path)
+	if err != nil {
+		return err
+	}
+	for _, pageID := range pageIDs {
+		walPage, err := newReadOnlyPageFunc(page.FileName(path, pageID), pageSize)
+		if err != nil {
+			return fmt.Errorf("open wal page[%d] error:%s", pageID, err)
+		}
+		err = fn(walPage)
+		if e := walPage.Close(); e != nil {
+			baseWALLogger.Error("close wal page error", logger.String("wal", path), logger.Error(e))
+		}
+		if err != nil {
+			return fmt.Errorf("read wal page[%d] error:%s", pageID, err)
+		}
+	}
+	return nil
+}
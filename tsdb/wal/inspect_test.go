@@ -0,0 +1,130 @@
+package wal
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/lindb/lindb/pkg/fileutil"
+	"github.com/lindb/lindb/pkg/queue/page"
+	"github.com/lindb/lindb/series/field"
+)
+
+func TestReadMetricMetaWAL(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testMetaWALPath)
+	}()
+	wal, err := NewMetricMetaWAL(testMetaWALPath)
+	assert.NoError(t, err)
+	assert.NoError(t, wal.AppendMetric(ns, "name", 10))
+	assert.NoError(t, wal.AppendField(10, 1, "f", field.SumField))
+	assert.NoError(t, wal.AppendTagKey(10, 2, "host"))
+	assert.NoError(t, wal.Sync())
+	assert.NoError(t, wal.Close())
+
+	var entries []string
+	metricRecovery := func(namespace, metricName string, metricID uint32) error {
+		entries = append(entries, fmt.Sprintf("metric:%s/%s/%d", namespace, metricName, metricID))
+		return nil
+	}
+	fieldRecovery := func(metricID uint32, fID field.ID, fieldName field.Name, fType field.Type) error {
+		entries = append(entries, fmt.Sprintf("field:%d/%d/%s/%s", metricID, fID, fieldName, fType))
+		return nil
+	}
+	tagKeyRecovery := func(metricID uint32, tagKeyID uint32, tagKey string) error {
+		entries = append(entries, fmt.Sprintf("tagKey:%d/%d/%s", metricID, tagKeyID, tagKey))
+		return nil
+	}
+	// case 1: read uncommitted entries of current page
+	err = ReadMetricMetaWAL(testMetaWALPath, metricRecovery, fieldRecovery, tagKeyRecovery)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"metric:ns/name/10", "field:10/1/f/sum", "tagKey:10/2/host"}, entries)
+	// case 2: recovery func err
+	err = ReadMetricMetaWAL(testMetaWALPath, func(namespace, metricName string, metricID uint32) error {
+		return fmt.Errorf("err")
+	}, fieldRecovery, tagKeyRecovery)
+	assert.Error(t, err)
+	// case 3: path not exist
+	err = ReadMetricMetaWAL(filepath.Join(testMetaWALPath, "not_exist"), metricRecovery, fieldRecovery, tagKeyRecovery)
+	assert.Error(t, err)
+}
+
+func TestReadSeriesWAL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer func() {
+		listPageIDsFunc = page.ListPageIDs
+		newReadOnlyPageFunc = page.NewReadOnlyMappedPage
+		_ = fileutil.RemoveDir(testSeriesWALPath)
+		ctrl.Finish()
+	}()
+	wal, err := NewSeriesWAL(testSeriesWALPath)
+	assert.NoError(t, err)
+	assert.NoError(t, wal.Append(10, 100, 1))
+	assert.NoError(t, wal.Append(10, 200, 2))
+	assert.NoError(t, wal.Sync())
+	assert.NoError(t, wal.Close())
+
+	// case 1: read entries
+	var seriesIDs []uint32
+	err = ReadSeriesWAL(testSeriesWALPath, func(metricID uint32, tagsHash uint64, seriesID uint32) error {
+		seriesIDs = append(seriesIDs, seriesID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []uint32{1, 2}, seriesIDs)
+	// case 2: recovery func err
+	err = ReadSeriesWAL(testSeriesWALPath, func(metricID uint32, tagsHash uint64, seriesID uint32) error {
+		return fmt.Errorf("err")
+	})
+	assert.Error(t, err)
+	// case 3: list page ids err
+	listPageIDsFunc = func(path string) ([]int64, error) {
+		return nil, fmt.Errorf("err")
+	}
+	err = ReadSeriesWAL(testSeriesWALPath, nil)
+	assert.Error(t, err)
+	// case 4: open page err
+	listPageIDsFunc = page.ListPageIDs
+	newReadOnlyPageFunc = func(fileName string, size int) (page.MappedPage, error) {
+		return nil, fmt.Errorf("err")
+	}
+	err = ReadSeriesWAL(testSeriesWALPath, nil)
+	assert.Error(t, err)
+	// case 5: close page err
+	walPage := page.NewMockMappedPage(ctrl)
+	newReadOnlyPageFunc = func(fileName string, size int) (page.MappedPage, error) {
+		return walPage, nil
+	}
+	walPage.EXPECT().ReadUint32(gomock.Any()).Return(uint32(0))
+	walPage.EXPECT().Close().Return(fmt.Errorf("err"))
+	err = ReadSeriesWAL(testSeriesWALPath, nil)
+	assert.NoError(t, err)
+}
+
+func TestReadDataWAL(t *testing.T) {
+	defer func() {
+		_ = fileutil.RemoveDir(testDataWALPath)
+	}()
+	wal, err := NewDataWAL(testDataWALPath)
+	assert.NoError(t, err)
+	assert.NoError(t, wal.Append("peer", 1, []byte("m1")))
+	assert.NoError(t, wal.Append("peer", 2, []byte("m2")))
+	assert.NoError(t, wal.Close())
+
+	// case 1: read entries of current page
+	var seqs []int64
+	err = ReadDataWAL(testDataWALPath, func(replicaPeer string, seq int64, metricList []byte) error {
+		seqs = append(seqs, seq)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2}, seqs)
+	// case 2: recovery func err
+	err = ReadDataWAL(testDataWALPath, func(replicaPeer string, seq int64, metricList []byte) error {
+		return fmt.Errorf("err")
+	})
+	assert.Error(t, err)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/lindb/lindb/monitoring"
 	"github.com/lindb/lindb/pkg/logger"
+	"github.com/lindb/lindb/pkg/queue/page"
 	"github.com/lindb/lindb/series/field"
 )
 
@@ -148,59 +149,10 @@ func (m *metricMetaWAL) Recovery(metricRecovery MetricRecoveryFunc,
 		if !ok {
 			continue
 		}
-		offset := 0
-		completed := false
-		for !completed {
-			mType := metaType(walPage.ReadUint8(offset))
-			offset++
-			switch mType {
-			case metricType: // recovery metric
-				ns, n := readString(walPage, offset)
-				offset += n
-				metricName, n := readString(walPage, offset)
-				offset += n
-				metricID := walPage.ReadUint32(offset)
-				offset += 4
-				if err := metricRecovery(ns, metricName, metricID); err != nil {
-					recoverMetricFailCounter.Inc()
-
-					metaWAlLogger.Error("invoke metric recovery func error",
-						logger.String("wal", m.base.path), logger.Error(err))
-					return
-				}
-			case fieldType: // recovery field
-				metricID := walPage.ReadUint32(offset)
-				offset += 4
-				fID := walPage.ReadUint8(offset)
-				offset++
-				fieldName, n := readString(walPage, offset)
-				offset += n
-				fType := walPage.ReadUint8(offset)
-				offset++
-				if err := fieldRecovery(metricID, field.ID(fID), field.Name(fieldName), field.Type(fType)); err != nil {
-					recoverFieldFailCounter.Inc()
-
-					metaWAlLogger.Error("invoke field recovery func error",
-						logger.String("wal", m.base.path), logger.Error(err))
-					return
-				}
-			case tagKeyType: // recovery tag key
-				metricID := walPage.ReadUint32(offset)
-				offset += 4
-				tagKeyID := walPage.ReadUint32(offset)
-				offset += 4
-				tagKey, n := readString(walPage, offset)
-				offset += n
-				if err := tagKeyRecovery(metricID, tagKeyID, tagKey); err != nil {
-					recoverTagKeyFailCounter.Inc()
-
-					metaWAlLogger.Error("invoke tag key recovery func error",
-						logger.String("wal", m.base.path), logger.Error(err))
-					return
-				}
-			default:
-				completed = true // no data
-			}
+		if err := readMetaPage(walPage, metricRecovery, fieldRecovery, tagKeyRecovery); err != nil {
+			metaWAlLogger.Error("invoke recovery func error",
+				logger.String("wal", m.base.path), logger.Error(err))
+			return
 		}
 
 		if err := commit(); err != nil {
@@ -222,6 +174,58 @@ func (m *metricMetaWAL) Recovery(metricRecovery MetricRecoveryFunc,
 	}
 }
 
+// readMetaPage reads all entries of meta wal page, then writes data via recovery function
+func readMetaPage(walPage page.MappedPage,
+	metricRecovery MetricRecoveryFunc,
+	fieldRecovery FieldRecoveryFunc,
+	tagKeyRecovery TagKeyRecoveryFunc,
+) error {
+	offset := 0
+	for {
+		mType := metaType(walPage.ReadUint8(offset))
+		offset++
+		switch mType {
+		case metricType: // recovery metric
+			ns, n := readString(walPage, offset)
+			offset += n
+			metricName, n := readString(walPage, offset)
+			offset += n
+			metricID := walPage.ReadUint32(offset)
+			offset += 4
+			if err := metricRecovery(ns, metricName, metricID); err != nil {
+				recoverMetricFailCounter.Inc()
+				return err
+			}
+		case fieldType: // recovery field
+			metricID := walPage.ReadUint32(offset)
+			offset += 4
+			fID := walPage.ReadUint8(offset)
+			offset++
+			fieldName, n := readString(walPage, offset)
+			offset += n
+			fType := walPage.ReadUint8(offset)
+			offset++
+			if err := fieldRecovery(metricID, field.ID(fID), field.Name(fieldName), field.Type(fType)); err != nil {
+				recoverFieldFailCounter.Inc()
+				return err
+			}
+		case tagKeyType: // recovery tag key
+			metricID := walPage.ReadUint32(offset)
+			offset += 4
+			tagKeyID := walPage.ReadUint32(offset)
+			offset += 4
+			tagKey, n := readString(walPage, offset)
+			offset += n
+			if err := tagKeyRecovery(metricID, tagKeyID, tagKey); err != nil {
+				recoverTagKeyFailCounter.Inc()
+				return err
+			}
+		default:
+			return nil // no data
+		}
+	}
+}
+
 // Sync flushes metric meta into disk
 func (m *metricMetaWAL) Sync() error {
 	return m.base.sync()
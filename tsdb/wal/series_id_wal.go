@@ -61,7 +61,7 @@ type seriesWAL struct {
 
 // NewSeriesWAL creates a new series write ahead log
 func NewSeriesWAL(path string) (SeriesWAL, error) {
-	base, err := newBaseWAL(path, metricMetaPageSize)
+	base, err := newBaseWAL(path, seriesPageSize)
 	if err != nil {
 		return nil, err
 	}
@@ -94,23 +94,12 @@ func (wal *seriesWAL) Recovery(recovery SeriesRecoveryFunc, commit CommitFunc) {
 		if !ok {
 			continue
 		}
-		offset := 0
-		for offset < seriesPageSize {
-			metricID := walPage.ReadUint32(offset + metricIDOffset)
-			if metricID == 0 {
-				break
-			}
-
-			if err := recovery(metricID,
-				walPage.ReadUint64(offset+tagsHashOffset),
-				walPage.ReadUint32(offset+seriesIDOffset)); err != nil {
-				recoverSeriesFailCounter.Inc()
-
-				seriesWALLogger.Error("invoke recovery func error",
-					logger.String("wal", wal.base.path), logger.Error(err))
-				return
-			}
-			offset += seriesEntryLength
+		if err := readSeriesPage(walPage, recovery); err != nil {
+			recoverSeriesFailCounter.Inc()
+
+			seriesWALLogger.Error("invoke recovery func error",
+				logger.String("wal", wal.base.path), logger.Error(err))
+			return
 		}
 
 		if err := commit(); err != nil {
@@ -132,6 +121,24 @@ func (wal *seriesWAL) Recovery(recovery SeriesRecoveryFunc, commit CommitFunc) {
 	}
 }
 
+// readSeriesPage reads all entries of series wal page, then writes data via recovery function
+func readSeriesPage(walPage page.MappedPage, recovery SeriesRecoveryFunc) error {
+	offset := 0
+	for offset < seriesPageSize {
+		metricID := walPage.ReadUint32(offset + metricIDOffset)
+		if metricID == 0 {
+			break
+		}
+		if err := recovery(metricID,
+			walPage.ReadUint64(offset+tagsHashOffset),
+			walPage.ReadUint32(offset+seriesIDOffset)); err != nil {
+			return err
+		}
+		offset += seriesEntryLength
+	}
+	return nil
+}
+
 // Sync flushes data into disk
 func (wal *seriesWAL) Sync() error {
 	return wal.base.sync()